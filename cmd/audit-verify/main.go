@@ -0,0 +1,120 @@
+// Package main verifies the admin audit log hash chains stored in Postgres,
+// the super-admin (M20) chain and the control-plane (M86) chain, from
+// genesis through every signed anchor.
+//
+// Usage:
+//
+//	POSTGRES_DSN=... ADMIN_AUDIT_SIGNING_KEY=... go run ./cmd/audit-verify
+//	go run ./cmd/audit-verify -json
+//
+// The exit status is 1 when either chain is broken, with the first broken
+// entry or anchor printed.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	admindashboardpostgres "solomon/contexts/internal-ops/admin-dashboard-service/adapters/postgres"
+	admindashboardapplication "solomon/contexts/internal-ops/admin-dashboard-service/application"
+	superadminpostgres "solomon/contexts/internal-ops/super-admin-dashboard/adapters/postgres"
+	superadminapplication "solomon/contexts/internal-ops/super-admin-dashboard/application"
+	"solomon/internal/platform/auditchain"
+	"solomon/internal/platform/db"
+)
+
+func main() {
+	dsn := flag.String("dsn", os.Getenv("POSTGRES_DSN"), "Postgres DSN")
+	key := flag.String("key", os.Getenv("ADMIN_AUDIT_SIGNING_KEY"), "audit signing key")
+	timeout := flag.Duration("timeout", 10*time.Minute, "verification timeout")
+	asJSON := flag.Bool("json", false, "print the report as JSON")
+	flag.Parse()
+
+	if strings.TrimSpace(*dsn) == "" || strings.TrimSpace(*key) == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	pg, err := db.Connect(*dsn)
+	if err != nil {
+		log.Fatalf("connect postgres: %v", err)
+	}
+	defer func() { _ = pg.Close() }()
+
+	signer := auditchain.HMACSigner{Key: []byte(strings.TrimSpace(*key))}
+	chains := []struct {
+		name   string
+		verify func(context.Context) (auditchain.Report, error)
+	}{
+		{
+			name: "super_admin",
+			verify: superadminapplication.Service{
+				AuditChain:  superadminpostgres.NewAuditChainRepository(pg.DB),
+				AuditSigner: signer,
+			}.VerifyAuditChain,
+		},
+		{
+			name: "control_plane",
+			verify: admindashboardapplication.Service{
+				Repo:        admindashboardpostgres.NewAuditChainRepository(pg.DB),
+				AuditSigner: signer,
+			}.VerifyAuditChain,
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	reports := make(map[string]auditchain.Report, len(chains))
+	valid := true
+	for _, chain := range chains {
+		report, err := chain.verify(ctx)
+		if err != nil {
+			cancel()
+			log.Fatalf("verify %s audit chain: %v", chain.name, err)
+		}
+		reports[chain.name] = report
+		valid = valid && report.Valid
+	}
+	cancel()
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(reports); err != nil {
+			log.Fatalf("encode report: %v", err)
+		}
+	} else {
+		for _, chain := range chains {
+			fmt.Printf("[%s]\n", chain.name)
+			printReport(reports[chain.name])
+		}
+	}
+	if !valid {
+		_ = pg.Close()
+		os.Exit(1)
+	}
+}
+
+func printReport(report auditchain.Report) {
+	fmt.Printf("entries checked: %d\n", report.EntriesChecked)
+	fmt.Printf("anchors checked: %d\n", report.AnchorsChecked)
+	fmt.Printf("head sequence:   %d\n", report.HeadSequence)
+	fmt.Printf("head hash:       %s\n", report.HeadHash)
+	if report.Valid {
+		fmt.Println("chain: valid")
+		return
+	}
+	broken := report.FirstBreak
+	fmt.Printf("chain: BROKEN at sequence %d (%s)", broken.Sequence, broken.Reason)
+	if broken.AuditID != "" {
+		fmt.Printf(" audit_id=%s", broken.AuditID)
+	}
+	if broken.AnchorID != "" {
+		fmt.Printf(" anchor_id=%s", broken.AnchorID)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,101 @@
+// Package main scores a community-health message classifier against a
+// labelled JSONL corpus.
+//
+// Usage:
+//
+//	go run ./cmd/classifier-eval -corpus contexts/community-experience/community-health-service/testdata/classifier_corpus.jsonl
+//	go run ./cmd/classifier-eval -corpus corpus.jsonl -classifier weighted -config lexicon.json
+//	go run ./cmd/classifier-eval -corpus corpus.jsonl -classifier http -endpoint http://localhost:9000/classify
+//
+// Each corpus line is {"content": "...", "category": "harassment", "severity": 2, "sentiment": "negative"};
+// category is a toxicity category or "none" and sentiment is optional.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"solomon/contexts/community-experience/community-health-service/adapters/classifier"
+	"solomon/contexts/community-experience/community-health-service/application"
+)
+
+func main() {
+	corpusPath := flag.String("corpus", "", "labelled JSONL corpus (required)")
+	kind := flag.String("classifier", classifier.KindLexicon, "lexicon, weighted or http")
+	configPath := flag.String("config", "", "weighted classifier config file")
+	endpoint := flag.String("endpoint", "", "http classifier endpoint")
+	apiKey := flag.String("api-key", os.Getenv("COMMUNITY_HEALTH_CLASSIFIER_API_KEY"), "http classifier bearer token")
+	timeout := flag.Duration("timeout", 5*time.Second, "http classifier request timeout")
+	asJSON := flag.Bool("json", false, "print the report as JSON")
+	minAccuracy := flag.Float64("min-category-accuracy", 0, "exit non-zero when category accuracy is below this value")
+	flag.Parse()
+
+	if *corpusPath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	messageClassifier, err := classifier.New(classifier.Options{
+		Kind:       *kind,
+		ConfigPath: *configPath,
+		Endpoint:   *endpoint,
+		APIKey:     *apiKey,
+		Timeout:    *timeout,
+	})
+	if err != nil {
+		log.Fatalf("build classifier: %v", err)
+	}
+
+	file, err := os.Open(*corpusPath)
+	if err != nil {
+		log.Fatalf("open corpus: %v", err)
+	}
+	corpus, err := application.ReadLabelledCorpus(file)
+	_ = file.Close()
+	if err != nil {
+		log.Fatalf("read corpus: %v", err)
+	}
+
+	report, err := application.EvaluateClassifier(context.Background(), messageClassifier, corpus)
+	if err != nil {
+		log.Fatalf("evaluate classifier: %v", err)
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			log.Fatalf("encode report: %v", err)
+		}
+	} else {
+		printReport(*kind, report)
+	}
+	if report.CategoryAccuracy < *minAccuracy {
+		os.Exit(1)
+	}
+}
+
+func printReport(kind string, report application.EvaluationReport) {
+	fmt.Printf("classifier:          %s\n", kind)
+	fmt.Printf("samples:             %d\n", report.Samples)
+	fmt.Printf("category accuracy:   %.4f\n", report.CategoryAccuracy)
+	fmt.Printf("severity accuracy:   %.4f\n", report.SeverityAccuracy)
+	fmt.Printf("severity MAE:        %.4f\n", report.SeverityMAE)
+	if report.SentimentSamples > 0 {
+		fmt.Printf("sentiment accuracy:  %.4f (%d labelled)\n", report.SentimentAccuracy, report.SentimentSamples)
+	}
+	fmt.Println()
+
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "CATEGORY\tSUPPORT\tPRECISION\tRECALL\tF1")
+	for _, metrics := range report.Categories {
+		fmt.Fprintf(table, "%s\t%d\t%.4f\t%.4f\t%.4f\n",
+			metrics.Category, metrics.Support, metrics.Precision, metrics.Recall, metrics.F1)
+	}
+	_ = table.Flush()
+}
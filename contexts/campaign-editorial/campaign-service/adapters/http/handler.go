@@ -7,7 +7,6 @@ import (
 	"strings"
 	"time"
 
-	application "solomon/contexts/campaign-editorial/campaign-service/application"
 	"solomon/contexts/campaign-editorial/campaign-service/application/commands"
 	"solomon/contexts/campaign-editorial/campaign-service/application/queries"
 	"solomon/contexts/campaign-editorial/campaign-service/domain/entities"
@@ -248,21 +247,6 @@ func (h Handler) GetAnalyticsHandler(ctx context.Context, campaignID string) (ht
 	}, nil
 }
 
-func (h Handler) ExportAnalyticsHandler(ctx context.Context, campaignID string) (httptransport.ExportAnalyticsResponse, error) {
-	logger := application.ResolveLogger(h.Logger)
-	url, expiresAt := h.ExportAnalytics.Execute(ctx, campaignID)
-	logger.Info("campaign analytics export generated",
-		"event", "campaign_analytics_export_generated",
-		"module", "campaign-editorial/campaign-service",
-		"layer", "transport",
-		"campaign_id", campaignID,
-	)
-	return httptransport.ExportAnalyticsResponse{
-		DownloadURL: url,
-		ExpiresAt:   expiresAt.Format(time.RFC3339),
-	}, nil
-}
-
 func mapCampaign(item entities.Campaign) httptransport.CampaignDTO {
 	result := httptransport.CampaignDTO{
 		CampaignID:              item.CampaignID,
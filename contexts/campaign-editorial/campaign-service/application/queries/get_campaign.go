@@ -3,11 +3,12 @@ package queries
 import (
 	"context"
 	"log/slog"
+	"strconv"
 	"strings"
-	"time"
 
 	application "solomon/contexts/campaign-editorial/campaign-service/application"
 	"solomon/contexts/campaign-editorial/campaign-service/domain/entities"
+	domainerrors "solomon/contexts/campaign-editorial/campaign-service/domain/errors"
 	"solomon/contexts/campaign-editorial/campaign-service/ports"
 )
 
@@ -52,28 +53,89 @@ func (uc GetAnalyticsUseCase) Execute(ctx context.Context, campaignID string) (G
 	if err != nil {
 		return GetAnalyticsResult{}, err
 	}
-	viewsApprox := int64((campaign.BudgetSpent / campaign.RatePer1KViews) * 1000)
 	logger.Debug("campaign analytics fetched",
 		"event", "campaign_analytics_fetched",
 		"module", "campaign-editorial/campaign-service",
 		"layer", "application",
 		"campaign_id", campaignID,
 	)
+	return analyticsOf(campaign), nil
+}
+
+func analyticsOf(campaign entities.Campaign) GetAnalyticsResult {
+	var viewsApprox int64
+	if campaign.RatePer1KViews > 0 {
+		viewsApprox = int64((campaign.BudgetSpent / campaign.RatePer1KViews) * 1000)
+	}
 	return GetAnalyticsResult{
 		SubmissionCount: 0,
 		TotalViews:      viewsApprox,
 		BudgetSpent:     campaign.BudgetSpent,
 		BudgetRemaining: campaign.BudgetRemaining,
-	}, nil
+	}
+}
+
+// AnalyticsExportColumns are the columns of a campaign analytics export, in
+// the order ExportAnalyticsUseCase.Stream emits them.
+var AnalyticsExportColumns = []string{
+	"campaign_id",
+	"title",
+	"status",
+	"submission_count",
+	"total_views",
+	"budget_spent",
+	"budget_remaining",
+	"rate_per_1k_views",
 }
 
+// ExportAnalyticsUseCase feeds the shared export subsystem with the
+// analytics of a campaign; only the owning brand may export them.
 type ExportAnalyticsUseCase struct {
-	Clock  ports.Clock
-	Logger *slog.Logger
+	Campaigns ports.CampaignRepository
+	Logger    *slog.Logger
+}
+
+func (uc ExportAnalyticsUseCase) Authorize(ctx context.Context, actorID string, campaignID string) error {
+	_, err := uc.ownedCampaign(ctx, actorID, campaignID)
+	return err
+}
+
+func (uc ExportAnalyticsUseCase) Stream(ctx context.Context, actorID string, campaignID string, emit func(row []string) error) error {
+	campaign, err := uc.ownedCampaign(ctx, actorID, campaignID)
+	if err != nil {
+		return err
+	}
+	analytics := analyticsOf(campaign)
+	application.ResolveLogger(uc.Logger).Info("campaign analytics exported",
+		"event", "campaign_analytics_exported",
+		"module", "campaign-editorial/campaign-service",
+		"layer", "application",
+		"campaign_id", campaign.CampaignID,
+		"actor_id", actorID,
+	)
+	return emit([]string{
+		campaign.CampaignID,
+		campaign.Title,
+		string(campaign.Status),
+		strconv.Itoa(analytics.SubmissionCount),
+		strconv.FormatInt(analytics.TotalViews, 10),
+		strconv.FormatFloat(analytics.BudgetSpent, 'f', 2, 64),
+		strconv.FormatFloat(analytics.BudgetRemaining, 'f', 2, 64),
+		strconv.FormatFloat(campaign.RatePer1KViews, 'f', -1, 64),
+	})
 }
 
-func (uc ExportAnalyticsUseCase) Execute(_ context.Context, campaignID string) (string, time.Time) {
-	now := uc.Clock.Now().UTC()
-	expiresAt := now.Add(30 * time.Minute)
-	return "https://exports.viralforge.local/campaigns/" + strings.TrimSpace(campaignID) + "/analytics.csv", expiresAt
+func (uc ExportAnalyticsUseCase) ownedCampaign(ctx context.Context, actorID string, campaignID string) (entities.Campaign, error) {
+	campaignID = strings.TrimSpace(campaignID)
+	if campaignID == "" {
+		return entities.Campaign{}, domainerrors.ErrInvalidCampaignInput
+	}
+	campaign, err := uc.Campaigns.GetCampaign(ctx, campaignID)
+	if err != nil {
+		return entities.Campaign{}, err
+	}
+	if strings.TrimSpace(actorID) == "" || campaign.BrandID != strings.TrimSpace(actorID) {
+		return entities.Campaign{}, domainerrors.ErrCampaignAccessDenied
+	}
+	return campaign, nil
 }
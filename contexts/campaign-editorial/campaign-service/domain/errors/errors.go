@@ -4,6 +4,7 @@ import "errors"
 
 var (
 	ErrCampaignNotFound       = errors.New("campaign not found")
+	ErrCampaignAccessDenied   = errors.New("campaign belongs to another brand")
 	ErrInvalidCampaignInput   = errors.New("invalid campaign input")
 	ErrCampaignNotEditable    = errors.New("campaign cannot be edited in current state")
 	ErrCampaignEditRestricted = errors.New("campaign edit restricted in current state")
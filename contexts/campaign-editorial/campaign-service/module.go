@@ -92,8 +92,8 @@ func NewModule(deps Dependencies) Module {
 		Logger:    deps.Logger,
 	}
 	exportAnalytics := queries.ExportAnalyticsUseCase{
-		Clock:  deps.Clock,
-		Logger: deps.Logger,
+		Campaigns: deps.Campaigns,
+		Logger:    deps.Logger,
 	}
 
 	return Module{
@@ -139,6 +139,12 @@ type AnalyticsResponse struct {
 }
 
 type ExportAnalyticsResponse struct {
-	DownloadURL string `json:"download_url"`
-	ExpiresAt   string `json:"expires_at"`
+	ExportJobID string   `json:"export_job_id"`
+	CampaignID  string   `json:"campaign_id"`
+	Status      string   `json:"status"`
+	Format      string   `json:"format"`
+	Columns     []string `json:"columns"`
+	RowLimit    int      `json:"row_limit"`
+	CreatedAt   string   `json:"created_at"`
+	StatusURL   string   `json:"status_url"`
 }
@@ -91,6 +91,21 @@ func (s Service) RemoveSavedCampaign(ctx context.Context, idempotencyKey string,
 	return s.saveOrUnsave(ctx, idempotencyKey, userID, campaignID, false)
 }
 
+// SubmissionExportColumns is the column order of ExportSubmissionRow.
+var SubmissionExportColumns = []string{"submission_id", "campaign_id", "campaign_title", "status", "views", "earnings", "submitted_at"}
+
+func ExportSubmissionRow(item ports.SubmissionRecord) []string {
+	return []string{
+		item.SubmissionID,
+		item.CampaignID,
+		item.CampaignTitle,
+		item.Status,
+		strconv.Itoa(item.Views),
+		strconv.FormatFloat(item.Earnings, 'f', 2, 64),
+		item.SubmittedAt.UTC().Format(time.RFC3339),
+	}
+}
+
 func (s Service) ExportSubmissionsCSV(ctx context.Context, userID string, query ports.SubmissionQuery) (string, error) {
 	items, err := s.ListSubmissions(ctx, userID, query)
 	if err != nil {
@@ -98,17 +113,9 @@ func (s Service) ExportSubmissionsCSV(ctx context.Context, userID string, query
 	}
 	builder := &strings.Builder{}
 	writer := csv.NewWriter(builder)
-	_ = writer.Write([]string{"submission_id", "campaign_id", "campaign_title", "status", "views", "earnings", "submitted_at"})
+	_ = writer.Write(SubmissionExportColumns)
 	for _, item := range items {
-		_ = writer.Write([]string{
-			item.SubmissionID,
-			item.CampaignID,
-			item.CampaignTitle,
-			item.Status,
-			strconv.Itoa(item.Views),
-			strconv.FormatFloat(item.Earnings, 'f', 2, 64),
-			item.SubmittedAt.UTC().Format(time.RFC3339),
-		})
+		_ = writer.Write(ExportSubmissionRow(item))
 	}
 	writer.Flush()
 	if err := writer.Error(); err != nil {
@@ -117,6 +124,35 @@ func (s Service) ExportSubmissionsCSV(ctx context.Context, userID string, query
 	return builder.String(), nil
 }
 
+// StreamSubmissions pages through the user's submissions for asynchronous
+// exports, calling fn for up to limit records.
+func (s Service) StreamSubmissions(ctx context.Context, userID string, status string, limit int, fn func(ports.SubmissionRecord) error) error {
+	const pageSize = 50
+	if limit <= 0 {
+		return domainerrors.ErrInvalidRequest
+	}
+	sent := 0
+	for offset := 0; sent < limit; offset += pageSize {
+		items, err := s.ListSubmissions(ctx, userID, ports.SubmissionQuery{Status: status, Limit: pageSize, Offset: offset})
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			if sent == limit {
+				return nil
+			}
+			if err := fn(item); err != nil {
+				return err
+			}
+			sent++
+		}
+		if len(items) < pageSize {
+			return nil
+		}
+	}
+	return nil
+}
+
 func (s Service) ApplySubmissionLifecycleEvent(ctx context.Context, event ports.SubmissionLifecycleEvent) error {
 	event.EventID = strings.TrimSpace(event.EventID)
 	event.SubmissionID = strings.TrimSpace(event.SubmissionID)
@@ -3,6 +3,7 @@ package application
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -68,3 +69,32 @@ func TestApplySubmissionEventDeduplicates(t *testing.T) {
 		t.Fatalf("second apply should be noop: %v", err)
 	}
 }
+
+func TestStreamSubmissionsPagesUpToLimit(t *testing.T) {
+	store := memory.NewStore()
+	svc := Service{Repo: store, Idempotency: store, EventDedup: store, Clock: store}
+	now := time.Now().UTC()
+	for i := 0; i < 60; i++ {
+		_ = store.ApplySubmissionLifecycleEvent(context.Background(), ports.SubmissionLifecycleEvent{
+			SubmissionID: fmt.Sprintf("sub-%02d", i),
+			UserID:       "editor-stream",
+			Status:       "approved",
+			OccurredAt:   now,
+		})
+	}
+
+	var streamed []string
+	err := svc.StreamSubmissions(context.Background(), "editor-stream", "approved", 55, func(item ports.SubmissionRecord) error {
+		streamed = append(streamed, item.SubmissionID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("stream failed: %v", err)
+	}
+	if len(streamed) != 55 || streamed[0] != "sub-00" || streamed[54] != "sub-54" {
+		t.Fatalf("expected the first 55 submissions across pages, got %d: %v", len(streamed), streamed)
+	}
+	if err := svc.StreamSubmissions(context.Background(), "editor-stream", "bogus", 10, func(ports.SubmissionRecord) error { return nil }); !errors.Is(err, domainerrors.ErrInvalidRequest) {
+		t.Fatalf("expected invalid status to fail, got %v", err)
+	}
+}
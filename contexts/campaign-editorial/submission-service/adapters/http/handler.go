@@ -18,6 +18,7 @@ type Handler struct {
 	ReviewSubmission commands.ReviewSubmissionUseCase
 	ReportSubmission commands.ReportSubmissionUseCase
 	BulkOperation    commands.BulkOperationUseCase
+	ScreeningPolicy  commands.ScreeningPolicyUseCase
 	Queries          queries.QueryUseCase
 	Logger           *slog.Logger
 }
@@ -58,6 +59,7 @@ func (h Handler) CreateSubmissionHandler(
 		Platform:       req.Platform,
 		PostURL:        req.PostURL,
 		CpvRate:        req.CpvRate,
+		Caption:        req.Caption,
 	})
 	if err != nil {
 		logger.Error("submission create request failed",
@@ -457,6 +459,81 @@ func (h Handler) AnalyticsHandler(ctx context.Context, actorID string, submissio
 	}, nil
 }
 
+// PutScreeningPolicyHandler godoc
+// @Summary Replace campaign pre-moderation rules
+// @Description Replaces the pre-moderation rules evaluated for every new submission to the campaign.
+// @Tags submission-service
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-User-Id header string true "Brand user id"
+// @Param campaign_id path string true "Campaign id"
+// @Param request body httptransport.PutScreeningPolicyRequest true "Screening policy payload"
+// @Success 200 {object} httptransport.ScreeningPolicyResponse
+// @Failure 400 {object} httptransport.ErrorResponse
+// @Failure 403 {object} httptransport.ErrorResponse
+// @Failure 404 {object} httptransport.ErrorResponse
+// @Failure 500 {object} httptransport.ErrorResponse
+// @Router /submissions/campaigns/{campaign_id}/screening-policy [put]
+func (h Handler) PutScreeningPolicyHandler(
+	ctx context.Context,
+	actorID string,
+	campaignID string,
+	req httptransport.PutScreeningPolicyRequest,
+) (httptransport.ScreeningPolicyResponse, error) {
+	rules := make([]entities.ScreeningRule, 0, len(req.Rules))
+	for _, rule := range req.Rules {
+		rules = append(rules, entities.ScreeningRule{
+			RuleType:          rule.RuleType,
+			OnFail:            rule.OnFail,
+			RiskWeight:        rule.RiskWeight,
+			MinTier:           rule.MinTier,
+			MinAccountAgeDays: rule.MinAccountAgeDays,
+		})
+	}
+	policy, err := h.ScreeningPolicy.Put(ctx, commands.PutScreeningPolicyCommand{
+		ActorID:     actorID,
+		CampaignID:  campaignID,
+		Rules:       rules,
+		AutoApprove: req.AutoApprove,
+	})
+	if err != nil {
+		application.ResolveLogger(h.Logger).Error("submission screening policy update failed",
+			"event", "submission_screening_policy_update_failed",
+			"module", "campaign-editorial/submission-service",
+			"layer", "adapter",
+			"campaign_id", campaignID,
+			"error", err.Error(),
+		)
+		return httptransport.ScreeningPolicyResponse{}, err
+	}
+	return mapScreeningPolicy(policy), nil
+}
+
+// GetScreeningPolicyHandler godoc
+// @Summary Get campaign pre-moderation rules
+// @Tags submission-service
+// @Produce json
+// @Security BearerAuth
+// @Param X-User-Id header string true "Brand user id"
+// @Param campaign_id path string true "Campaign id"
+// @Success 200 {object} httptransport.ScreeningPolicyResponse
+// @Failure 403 {object} httptransport.ErrorResponse
+// @Failure 404 {object} httptransport.ErrorResponse
+// @Failure 500 {object} httptransport.ErrorResponse
+// @Router /submissions/campaigns/{campaign_id}/screening-policy [get]
+func (h Handler) GetScreeningPolicyHandler(
+	ctx context.Context,
+	actorID string,
+	campaignID string,
+) (httptransport.ScreeningPolicyResponse, error) {
+	policy, err := h.ScreeningPolicy.Get(ctx, actorID, campaignID)
+	if err != nil {
+		return httptransport.ScreeningPolicyResponse{}, err
+	}
+	return mapScreeningPolicy(policy), nil
+}
+
 func mapSubmission(item entities.Submission) httptransport.SubmissionDTO {
 	dto := httptransport.SubmissionDTO{
 		SubmissionID:  item.SubmissionID,
@@ -490,9 +567,35 @@ func mapSubmission(item entities.Submission) httptransport.SubmissionDTO {
 	if item.LockedViews != nil {
 		dto.LockedViews = *item.LockedViews
 	}
+	if screening, ok := item.Metadata["pre_moderation"].(map[string]any); ok {
+		dto.PreModeration = &httptransport.PreModerationDTO{}
+		dto.PreModeration.Outcome, _ = screening["outcome"].(string)
+		dto.PreModeration.ReasonCode, _ = screening["reason_code"].(string)
+		dto.PreModeration.RiskScore, _ = screening["risk_score"].(float64)
+	}
 	return dto
 }
 
+func mapScreeningPolicy(policy entities.ScreeningPolicy) httptransport.ScreeningPolicyResponse {
+	resp := httptransport.ScreeningPolicyResponse{
+		CampaignID:      policy.CampaignID,
+		Rules:           make([]httptransport.ScreeningRuleDTO, 0, len(policy.Rules)),
+		AutoApprove:     policy.AutoApprove,
+		UpdatedByUserID: policy.UpdatedByUserID,
+		UpdatedAt:       policy.UpdatedAt.Format(time.RFC3339),
+	}
+	for _, rule := range policy.Rules {
+		resp.Rules = append(resp.Rules, httptransport.ScreeningRuleDTO{
+			RuleType:          rule.RuleType,
+			OnFail:            rule.OnFail,
+			RiskWeight:        rule.RiskWeight,
+			MinTier:           rule.MinTier,
+			MinAccountAgeDays: rule.MinAccountAgeDays,
+		})
+	}
+	return resp
+}
+
 func mapDashboard(summary queries.DashboardSummary) httptransport.DashboardResponse {
 	return httptransport.DashboardResponse{
 		Total:    summary.Total,
@@ -28,9 +28,11 @@ type dedupRecord struct {
 
 type campaignProjection struct {
 	id               string
+	brandID          string
 	status           string
 	allowedPlatforms []string
 	ratePer1KViews   float64
+	requiredHashtags []string
 }
 
 type Store struct {
@@ -44,6 +46,8 @@ type Store struct {
 	snapshots   map[string]entities.ViewSnapshot
 
 	campaigns   map[string]campaignProjection
+	policies    map[string]entities.ScreeningPolicy
+	creators    map[string]ports.CreatorProfile
 	idempotency map[string]ports.IdempotencyRecord
 	outbox      map[string]outboxRecord
 	eventDedup  map[string]dedupRecord
@@ -62,6 +66,8 @@ func NewStore(seed []entities.Submission) *Store {
 		operations:  make(map[string]entities.BulkSubmissionOperation),
 		snapshots:   make(map[string]entities.ViewSnapshot),
 		campaigns:   make(map[string]campaignProjection),
+		policies:    make(map[string]entities.ScreeningPolicy),
+		creators:    make(map[string]ports.CreatorProfile),
 		idempotency: make(map[string]ports.IdempotencyRecord),
 		outbox:      make(map[string]outboxRecord),
 		eventDedup:  make(map[string]dedupRecord),
@@ -73,12 +79,86 @@ func (s *Store) SetCampaign(campaignID string, status string, allowedPlatforms [
 	defer s.mu.Unlock()
 
 	id := strings.TrimSpace(campaignID)
-	s.campaigns[id] = campaignProjection{
-		id:               id,
-		status:           strings.TrimSpace(status),
-		allowedPlatforms: append([]string(nil), allowedPlatforms...),
-		ratePer1KViews:   ratePer1KViews,
+	row := s.campaigns[id]
+	row.id = id
+	row.status = strings.TrimSpace(status)
+	row.allowedPlatforms = append([]string(nil), allowedPlatforms...)
+	row.ratePer1KViews = ratePer1KViews
+	s.campaigns[id] = row
+}
+
+// SetCampaignRequirements records the owning brand and required hashtags of
+// a campaign projection.
+func (s *Store) SetCampaignRequirements(campaignID string, brandID string, requiredHashtags []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := strings.TrimSpace(campaignID)
+	row := s.campaigns[id]
+	row.id = id
+	row.brandID = strings.TrimSpace(brandID)
+	row.requiredHashtags = append([]string(nil), requiredHashtags...)
+	s.campaigns[id] = row
+}
+
+func (s *Store) SetCreatorProfile(profile ports.CreatorProfile) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	profile.CreatorID = strings.TrimSpace(profile.CreatorID)
+	s.creators[profile.CreatorID] = profile
+}
+
+func (s *Store) GetCreatorProfile(_ context.Context, creatorID string) (ports.CreatorProfile, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	id := strings.TrimSpace(creatorID)
+	if profile, ok := s.creators[id]; ok {
+		return profile, nil
 	}
+	return ports.CreatorProfile{CreatorID: id}, nil
+}
+
+func (s *Store) GetScreeningPolicy(_ context.Context, campaignID string) (entities.ScreeningPolicy, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	policy, ok := s.policies[strings.TrimSpace(campaignID)]
+	if !ok {
+		return entities.ScreeningPolicy{}, domainerrors.ErrScreeningPolicyNotFound
+	}
+	policy.Rules = append([]entities.ScreeningRule(nil), policy.Rules...)
+	return policy, nil
+}
+
+func (s *Store) PutScreeningPolicy(_ context.Context, policy entities.ScreeningPolicy) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	policy.Rules = append([]entities.ScreeningRule(nil), policy.Rules...)
+	s.policies[strings.TrimSpace(policy.CampaignID)] = policy
+	return nil
+}
+
+// Flags returns the flags recorded for a submission, oldest first.
+func (s *Store) Flags(submissionID string) []entities.SubmissionFlag {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	items := []entities.SubmissionFlag{}
+	for _, flag := range s.flags {
+		if flag.SubmissionID == strings.TrimSpace(submissionID) {
+			items = append(items, flag)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].CreatedAt.Equal(items[j].CreatedAt) {
+			return items[i].FlagType < items[j].FlagType
+		}
+		return items[i].CreatedAt.Before(items[j].CreatedAt)
+	})
+	return items
 }
 
 func (s *Store) GetCampaignForSubmission(_ context.Context, campaignID string) (ports.CampaignForSubmission, error) {
@@ -91,9 +171,11 @@ func (s *Store) GetCampaignForSubmission(_ context.Context, campaignID string) (
 	}
 	return ports.CampaignForSubmission{
 		CampaignID:       row.id,
+		BrandID:          row.brandID,
 		Status:           row.status,
 		AllowedPlatforms: append([]string(nil), row.allowedPlatforms...),
 		RatePer1KViews:   row.ratePer1KViews,
+		RequiredHashtags: append([]string(nil), row.requiredHashtags...),
 	}, nil
 }
 
@@ -156,6 +238,12 @@ func (s *Store) ListSubmissions(_ context.Context, filter ports.SubmissionFilter
 		if filter.Status != "" && item.Status != filter.Status {
 			continue
 		}
+		if strings.TrimSpace(filter.Platform) != "" && item.Platform != strings.TrimSpace(filter.Platform) {
+			continue
+		}
+		if strings.TrimSpace(filter.PostID) != "" && item.PostID != strings.TrimSpace(filter.PostID) {
+			continue
+		}
 		items = append(items, item)
 	}
 	sort.Slice(items, func(i, j int) bool {
@@ -410,6 +498,36 @@ func (s *Store) ListDueViewLock(
 	return items, nil
 }
 
+func (s *Store) ListViewLockedBefore(
+	_ context.Context,
+	lockedBefore time.Time,
+	limit int,
+) ([]entities.Submission, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if limit <= 0 {
+		limit = 100
+	}
+	items := make([]entities.Submission, 0)
+	for _, submission := range s.submissions {
+		if submission.Status != entities.SubmissionStatusViewLocked {
+			continue
+		}
+		if submission.LockedAt == nil || submission.LockedAt.After(lockedBefore.UTC()) {
+			continue
+		}
+		items = append(items, submission)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].LockedAt.Before(*items[j].LockedAt)
+	})
+	if len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
 func (s *Store) Now() time.Time {
 	return time.Now().UTC()
 }
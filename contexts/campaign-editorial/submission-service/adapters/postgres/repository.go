@@ -111,6 +111,12 @@ func (r *Repository) ListSubmissions(ctx context.Context, filter ports.Submissio
 	if filter.Status != "" {
 		tx = tx.Where("status = ?", string(filter.Status))
 	}
+	if strings.TrimSpace(filter.Platform) != "" {
+		tx = tx.Where("platform = ?", strings.TrimSpace(filter.Platform))
+	}
+	if strings.TrimSpace(filter.PostID) != "" {
+		tx = tx.Where("post_id = ?", strings.TrimSpace(filter.PostID))
+	}
 
 	var rows []submissionModel
 	if err := tx.Order("created_at DESC").Find(&rows).Error; err != nil {
@@ -488,6 +494,32 @@ func (r *Repository) ListDueViewLock(
 	return items, nil
 }
 
+func (r *Repository) ListViewLockedBefore(
+	ctx context.Context,
+	lockedBefore time.Time,
+	limit int,
+) ([]entities.Submission, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	var rows []submissionModel
+	if err := r.db.WithContext(ctx).
+		Where("status = ?", string(entities.SubmissionStatusViewLocked)).
+		Where("locked_at IS NOT NULL").
+		Where("locked_at <= ?", lockedBefore.UTC()).
+		Order("locked_at ASC").
+		Limit(limit).
+		Find(&rows).
+		Error; err != nil {
+		return nil, err
+	}
+	items := make([]entities.Submission, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, row.toEntity())
+	}
+	return items, nil
+}
+
 func (r *Repository) GetCampaignForSubmission(ctx context.Context, campaignID string) (ports.CampaignForSubmission, error) {
 	var row campaignProjectionModel
 	if err := r.db.WithContext(ctx).
@@ -501,12 +533,63 @@ func (r *Repository) GetCampaignForSubmission(ctx context.Context, campaignID st
 	}
 	return ports.CampaignForSubmission{
 		CampaignID:       row.CampaignID,
+		BrandID:          row.BrandID,
 		Status:           row.Status,
 		AllowedPlatforms: append([]string(nil), row.AllowedPlatforms...),
 		RatePer1KViews:   row.RatePer1KViews,
+		RequiredHashtags: append([]string(nil), row.RequiredHashtags...),
 	}, nil
 }
 
+// GetCreatorProfile reads the creator's reputation tier from the M48
+// projection. Account registration dates are owned by M01, which exposes no
+// read model here yet, so AccountCreatedAt stays zero and account-age rules
+// route the submission to review.
+func (r *Repository) GetCreatorProfile(ctx context.Context, creatorID string) (ports.CreatorProfile, error) {
+	profile := ports.CreatorProfile{CreatorID: strings.TrimSpace(creatorID)}
+	var row reputationTierModel
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", profile.CreatorID).
+		First(&row).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return profile, nil
+		}
+		return ports.CreatorProfile{}, err
+	}
+	profile.ReputationTier = row.CurrentTier
+	return profile, nil
+}
+
+func (r *Repository) GetScreeningPolicy(ctx context.Context, campaignID string) (entities.ScreeningPolicy, error) {
+	var row screeningPolicyModel
+	if err := r.db.WithContext(ctx).
+		Where("campaign_id = ?", strings.TrimSpace(campaignID)).
+		First(&row).
+		Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.ScreeningPolicy{}, domainerrors.ErrScreeningPolicyNotFound
+		}
+		return entities.ScreeningPolicy{}, err
+	}
+	return row.toEntity()
+}
+
+func (r *Repository) PutScreeningPolicy(ctx context.Context, policy entities.ScreeningPolicy) error {
+	row, err := screeningPolicyModelFromEntity(policy)
+	if err != nil {
+		return err
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "campaign_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"rules", "auto_approve", "updated_by_user_id", "updated_at"}),
+		}).
+		Create(&row).
+		Error
+}
+
 type submissionModel struct {
 	SubmissionID          string     `gorm:"column:submission_id;primaryKey"`
 	CampaignID            string     `gorm:"column:campaign_id"`
@@ -767,15 +850,96 @@ func (eventDedupModel) TableName() string {
 
 type campaignProjectionModel struct {
 	CampaignID       string   `gorm:"column:campaign_id;primaryKey"`
+	BrandID          string   `gorm:"column:brand_id"`
 	Status           string   `gorm:"column:status"`
 	AllowedPlatforms []string `gorm:"column:allowed_platforms;type:text[]"`
 	RatePer1KViews   float64  `gorm:"column:rate_per_1k_views"`
+	RequiredHashtags []string `gorm:"column:required_hashtags;type:text[]"`
 }
 
 func (campaignProjectionModel) TableName() string {
 	return "campaigns"
 }
 
+type reputationTierModel struct {
+	UserID      string `gorm:"column:user_id;primaryKey"`
+	CurrentTier string `gorm:"column:current_tier"`
+}
+
+func (reputationTierModel) TableName() string {
+	return "user_reputation_tiers"
+}
+
+type screeningRuleRow struct {
+	RuleType          string  `json:"rule_type"`
+	OnFail            string  `json:"on_fail"`
+	RiskWeight        float64 `json:"risk_weight,omitempty"`
+	MinTier           string  `json:"min_tier,omitempty"`
+	MinAccountAgeDays int     `json:"min_account_age_days,omitempty"`
+}
+
+type screeningPolicyModel struct {
+	CampaignID      string    `gorm:"column:campaign_id;primaryKey"`
+	Rules           []byte    `gorm:"column:rules"`
+	AutoApprove     bool      `gorm:"column:auto_approve"`
+	UpdatedByUserID string    `gorm:"column:updated_by_user_id"`
+	UpdatedAt       time.Time `gorm:"column:updated_at"`
+}
+
+func (screeningPolicyModel) TableName() string {
+	return "submission_screening_policies"
+}
+
+func screeningPolicyModelFromEntity(policy entities.ScreeningPolicy) (screeningPolicyModel, error) {
+	rules := make([]screeningRuleRow, 0, len(policy.Rules))
+	for _, rule := range policy.Rules {
+		rules = append(rules, screeningRuleRow{
+			RuleType:          rule.RuleType,
+			OnFail:            rule.OnFail,
+			RiskWeight:        rule.RiskWeight,
+			MinTier:           rule.MinTier,
+			MinAccountAgeDays: rule.MinAccountAgeDays,
+		})
+	}
+	raw, err := json.Marshal(rules)
+	if err != nil {
+		return screeningPolicyModel{}, err
+	}
+	return screeningPolicyModel{
+		CampaignID:      strings.TrimSpace(policy.CampaignID),
+		Rules:           raw,
+		AutoApprove:     policy.AutoApprove,
+		UpdatedByUserID: strings.TrimSpace(policy.UpdatedByUserID),
+		UpdatedAt:       policy.UpdatedAt.UTC(),
+	}, nil
+}
+
+func (m screeningPolicyModel) toEntity() (entities.ScreeningPolicy, error) {
+	var rows []screeningRuleRow
+	if len(m.Rules) > 0 {
+		if err := json.Unmarshal(m.Rules, &rows); err != nil {
+			return entities.ScreeningPolicy{}, err
+		}
+	}
+	policy := entities.ScreeningPolicy{
+		CampaignID:      m.CampaignID,
+		Rules:           make([]entities.ScreeningRule, 0, len(rows)),
+		AutoApprove:     m.AutoApprove,
+		UpdatedByUserID: m.UpdatedByUserID,
+		UpdatedAt:       m.UpdatedAt.UTC(),
+	}
+	for _, row := range rows {
+		policy.Rules = append(policy.Rules, entities.ScreeningRule{
+			RuleType:          row.RuleType,
+			OnFail:            row.OnFail,
+			RiskWeight:        row.RiskWeight,
+			MinTier:           row.MinTier,
+			MinAccountAgeDays: row.MinAccountAgeDays,
+		})
+	}
+	return policy, nil
+}
+
 func normalizeOptionalTime(value *time.Time) *time.Time {
 	if value == nil {
 		return nil
@@ -22,11 +22,16 @@ type CreateSubmissionCommand struct {
 	Platform       string
 	PostURL        string
 	CpvRate        float64
+	// Caption is the post caption as submitted; pre-moderation reads the
+	// campaign's required hashtags from it.
+	Caption string
 }
 
 type CreateSubmissionUseCase struct {
 	Repository     ports.Repository
 	Campaigns      ports.CampaignReadRepository
+	Policies       ports.ScreeningPolicyRepository
+	Creators       ports.CreatorProfileReader
 	Idempotency    ports.IdempotencyStore
 	Outbox         ports.OutboxWriter
 	Clock          ports.Clock
@@ -115,6 +120,7 @@ func (uc CreateSubmissionUseCase) Execute(ctx context.Context, cmd CreateSubmiss
 		return CreateSubmissionResult{}, domainerrors.ErrUnsupportedPlatform
 	}
 	lockedCPVRate := cmd.CpvRate
+	var requiredHashtags []string
 	if uc.Campaigns != nil {
 		campaign, err := uc.Campaigns.GetCampaignForSubmission(ctx, strings.TrimSpace(cmd.CampaignID))
 		if err != nil {
@@ -150,6 +156,7 @@ func (uc CreateSubmissionUseCase) Execute(ctx context.Context, cmd CreateSubmiss
 		if campaign.RatePer1KViews > 0 {
 			lockedCPVRate = campaign.RatePer1KViews
 		}
+		requiredHashtags = campaign.RequiredHashtags
 	}
 
 	postID, handle, err := extractPostReference(normalizedPlatform, strings.TrimSpace(cmd.PostURL))
@@ -187,6 +194,9 @@ func (uc CreateSubmissionUseCase) Execute(ctx context.Context, cmd CreateSubmiss
 		UpdatedAt:             now,
 		CpvRate:               lockedCPVRate,
 	}
+	if caption := strings.TrimSpace(cmd.Caption); caption != "" {
+		submission.Metadata = map[string]any{"caption": caption}
+	}
 	if !submission.ValidateCreate() {
 		logger.Error("submission create failed: invalid input",
 			"event", "submission_create_invalid_input",
@@ -287,6 +297,18 @@ func (uc CreateSubmissionUseCase) Execute(ctx context.Context, cmd CreateSubmiss
 		}
 	}
 
+	submission, err = uc.preModerate(ctx, submission, requiredHashtags, now)
+	if err != nil {
+		logger.Error("submission pre-moderation failed",
+			"event", "submission_pre_moderation_failed",
+			"module", "campaign-editorial/submission-service",
+			"layer", "application",
+			"submission_id", submission.SubmissionID,
+			"error", err.Error(),
+		)
+		return CreateSubmissionResult{}, err
+	}
+
 	if uc.Idempotency != nil {
 		payload, err := json.Marshal(createSubmissionReplayPayload{
 			SubmissionID: submission.SubmissionID,
@@ -344,6 +366,9 @@ func hashCreateSubmissionCommand(cmd CreateSubmissionCommand) string {
 		"post_url":    strings.TrimSpace(cmd.PostURL),
 		"cpv_rate":    cmd.CpvRate,
 	}
+	if caption := strings.TrimSpace(cmd.Caption); caption != "" {
+		payload["caption"] = caption
+	}
 	raw, _ := json.Marshal(payload)
 	sum := sha256.Sum256(raw)
 	return hex.EncodeToString(sum[:])
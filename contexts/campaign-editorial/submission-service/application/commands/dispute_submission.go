@@ -0,0 +1,249 @@
+package commands
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	application "solomon/contexts/campaign-editorial/submission-service/application"
+	"solomon/contexts/campaign-editorial/submission-service/domain/entities"
+	domainerrors "solomon/contexts/campaign-editorial/submission-service/domain/errors"
+	"solomon/contexts/campaign-editorial/submission-service/ports"
+)
+
+// DisputeSubmissionCommand moves a rejected submission into dispute while a
+// moderation appeal filed by its creator is open.
+type DisputeSubmissionCommand struct {
+	IdempotencyKey string
+	SubmissionID   string
+	ActorID        string
+	AppealID       string
+	Reason         string
+}
+
+// ResolveDisputeCommand closes a dispute. Reversed approves the submission;
+// otherwise the original rejection is restored.
+type ResolveDisputeCommand struct {
+	IdempotencyKey string
+	SubmissionID   string
+	ActorID        string
+	AppealID       string
+	Reversed       bool
+	Notes          string
+}
+
+func (uc ReviewSubmissionUseCase) Dispute(ctx context.Context, cmd DisputeSubmissionCommand) error {
+	if strings.TrimSpace(cmd.IdempotencyKey) == "" {
+		return domainerrors.ErrIdempotencyKeyRequired
+	}
+	now := uc.resolveNow()
+	requestHash := hashDisputeCommand(cmd)
+	if done, err := uc.checkReplay(ctx, cmd.IdempotencyKey, requestHash, now); err != nil || done {
+		return err
+	}
+
+	submission, err := uc.Repository.GetSubmission(ctx, strings.TrimSpace(cmd.SubmissionID))
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(cmd.ActorID) == "" || strings.TrimSpace(cmd.ActorID) != submission.CreatorID {
+		return domainerrors.ErrUnauthorizedActor
+	}
+	if submission.Status != entities.SubmissionStatusRejected {
+		return domainerrors.ErrInvalidStatusTransition
+	}
+
+	submission.Status = entities.SubmissionStatusDisputed
+	submission.UpdatedAt = now
+	if err := uc.Repository.UpdateSubmission(ctx, submission); err != nil {
+		return err
+	}
+	if err := uc.appendAudit(ctx, entities.SubmissionAudit{
+		SubmissionID: submission.SubmissionID,
+		Action:       "disputed",
+		OldStatus:    entities.SubmissionStatusRejected,
+		NewStatus:    entities.SubmissionStatusDisputed,
+		ActorID:      strings.TrimSpace(cmd.ActorID),
+		ActorRole:    "creator",
+		ReasonCode:   strings.TrimSpace(cmd.AppealID),
+		ReasonNotes:  strings.TrimSpace(cmd.Reason),
+		CreatedAt:    now,
+	}); err != nil {
+		return err
+	}
+	if err := uc.storeReplay(ctx, cmd.IdempotencyKey, requestHash, submission, now); err != nil {
+		return err
+	}
+	application.ResolveLogger(uc.Logger).Info("submission disputed",
+		"event", "submission_disputed",
+		"module", "campaign-editorial/submission-service",
+		"layer", "application",
+		"submission_id", submission.SubmissionID,
+		"appeal_id", strings.TrimSpace(cmd.AppealID),
+	)
+	return nil
+}
+
+func (uc ReviewSubmissionUseCase) ResolveDispute(ctx context.Context, cmd ResolveDisputeCommand) error {
+	if strings.TrimSpace(cmd.IdempotencyKey) == "" {
+		return domainerrors.ErrIdempotencyKeyRequired
+	}
+	now := uc.resolveNow()
+	requestHash := hashResolveDisputeCommand(cmd)
+	if done, err := uc.checkReplay(ctx, cmd.IdempotencyKey, requestHash, now); err != nil || done {
+		return err
+	}
+
+	submission, err := uc.Repository.GetSubmission(ctx, strings.TrimSpace(cmd.SubmissionID))
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(cmd.ActorID) == "" {
+		return domainerrors.ErrUnauthorizedActor
+	}
+	if submission.Status != entities.SubmissionStatusDisputed {
+		return domainerrors.ErrInvalidStatusTransition
+	}
+
+	action := "dispute_upheld"
+	newStatus := entities.SubmissionStatusRejected
+	if cmd.Reversed {
+		// A reversal is an approval in every downstream sense: it opens the
+		// verification window and emits submission.approved.
+		action = "dispute_reversed"
+		newStatus = entities.SubmissionStatusApproved
+		windowEnd := now.Add(30 * 24 * time.Hour)
+		submission.ApprovedAt = &now
+		submission.ApprovedByUserID = strings.TrimSpace(cmd.ActorID)
+		submission.ApprovalReason = "appeal_reversed"
+		submission.VerificationStart = &now
+		submission.VerificationWindowEnd = &windowEnd
+		submission.RejectedAt = nil
+	}
+	submission.Status = newStatus
+	submission.UpdatedAt = now
+	if err := uc.Repository.UpdateSubmission(ctx, submission); err != nil {
+		return err
+	}
+	if err := uc.appendAudit(ctx, entities.SubmissionAudit{
+		SubmissionID: submission.SubmissionID,
+		Action:       action,
+		OldStatus:    entities.SubmissionStatusDisputed,
+		NewStatus:    newStatus,
+		ActorID:      strings.TrimSpace(cmd.ActorID),
+		ActorRole:    "moderator",
+		ReasonCode:   strings.TrimSpace(cmd.AppealID),
+		ReasonNotes:  strings.TrimSpace(cmd.Notes),
+		CreatedAt:    now,
+	}); err != nil {
+		return err
+	}
+	if cmd.Reversed && uc.Outbox != nil {
+		eventID, err := uc.IDGen.NewID(ctx)
+		if err != nil {
+			return err
+		}
+		envelope, err := newSubmissionEnvelope(
+			eventID,
+			"submission.approved",
+			submission.SubmissionID,
+			now,
+			map[string]any{
+				"submission_id": submission.SubmissionID,
+				"creator_id":    submission.CreatorID,
+				"user_id":       submission.CreatorID,
+				"campaign_id":   submission.CampaignID,
+				"approved_at":   now.Format(time.RFC3339),
+			},
+		)
+		if err != nil {
+			return err
+		}
+		if err := uc.Outbox.AppendOutbox(ctx, envelope); err != nil {
+			return err
+		}
+	}
+	if err := uc.storeReplay(ctx, cmd.IdempotencyKey, requestHash, submission, now); err != nil {
+		return err
+	}
+	application.ResolveLogger(uc.Logger).Info("submission dispute resolved",
+		"event", "submission_"+action,
+		"module", "campaign-editorial/submission-service",
+		"layer", "application",
+		"submission_id", submission.SubmissionID,
+		"appeal_id", strings.TrimSpace(cmd.AppealID),
+		"status", string(newStatus),
+	)
+	return nil
+}
+
+func (uc ReviewSubmissionUseCase) checkReplay(ctx context.Context, key string, requestHash string, now time.Time) (bool, error) {
+	if uc.Idempotency == nil {
+		return false, nil
+	}
+	record, found, err := uc.Idempotency.GetRecord(ctx, key, now)
+	if err != nil || !found {
+		return false, err
+	}
+	if record.RequestHash != requestHash {
+		return false, domainerrors.ErrIdempotencyKeyConflict
+	}
+	return true, nil
+}
+
+func (uc ReviewSubmissionUseCase) storeReplay(ctx context.Context, key string, requestHash string, submission entities.Submission, now time.Time) error {
+	if uc.Idempotency == nil {
+		return nil
+	}
+	payload, _ := json.Marshal(map[string]string{
+		"submission_id": submission.SubmissionID,
+		"status":        string(submission.Status),
+	})
+	return uc.Idempotency.PutRecord(ctx, ports.IdempotencyRecord{
+		Key:             key,
+		RequestHash:     requestHash,
+		ResponsePayload: payload,
+		ExpiresAt:       now.Add(uc.resolveIdempotencyTTL()),
+	})
+}
+
+func (uc ReviewSubmissionUseCase) appendAudit(ctx context.Context, audit entities.SubmissionAudit) error {
+	auditID, err := uc.IDGen.NewID(ctx)
+	if err != nil {
+		return err
+	}
+	audit.AuditID = auditID
+	return uc.Repository.AddAudit(ctx, audit)
+}
+
+func hashDisputeCommand(cmd DisputeSubmissionCommand) string {
+	raw, _ := json.Marshal(map[string]string{
+		"submission_id": strings.TrimSpace(cmd.SubmissionID),
+		"actor_id":      strings.TrimSpace(cmd.ActorID),
+		"appeal_id":     strings.TrimSpace(cmd.AppealID),
+		"reason":        strings.TrimSpace(cmd.Reason),
+		"op":            "dispute",
+	})
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
+
+func hashResolveDisputeCommand(cmd ResolveDisputeCommand) string {
+	outcome := "upheld"
+	if cmd.Reversed {
+		outcome = "reversed"
+	}
+	raw, _ := json.Marshal(map[string]string{
+		"submission_id": strings.TrimSpace(cmd.SubmissionID),
+		"actor_id":      strings.TrimSpace(cmd.ActorID),
+		"appeal_id":     strings.TrimSpace(cmd.AppealID),
+		"outcome":       outcome,
+		"notes":         strings.TrimSpace(cmd.Notes),
+		"op":            "resolve_dispute",
+	})
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	application "solomon/contexts/campaign-editorial/submission-service/application"
+	"solomon/contexts/campaign-editorial/submission-service/domain/entities"
+	domainerrors "solomon/contexts/campaign-editorial/submission-service/domain/errors"
+	"solomon/contexts/campaign-editorial/submission-service/ports"
+)
+
+// PayoutSettlementUseCase moves view-locked submissions through the payout
+// statuses on behalf of the payout service: reward_eligible once their hold
+// period has passed, paid once the payout carrying them settles. Both
+// transitions are idempotent, so the payout service can retry them.
+type PayoutSettlementUseCase struct {
+	Repository ports.Repository
+	Payouts    ports.PayoutRepository
+	Clock      ports.Clock
+	IDGen      ports.IDGenerator
+	Logger     *slog.Logger
+}
+
+func (uc PayoutSettlementUseCase) ListMaturedEarnings(
+	ctx context.Context,
+	lockedBefore time.Time,
+	limit int,
+) ([]entities.Submission, error) {
+	return uc.Payouts.ListViewLockedBefore(ctx, lockedBefore, limit)
+}
+
+func (uc PayoutSettlementUseCase) MarkRewardEligible(ctx context.Context, submissionID string) error {
+	return uc.transition(ctx, submissionID, entities.SubmissionStatusViewLocked, entities.SubmissionStatusRewardEligible, "reward_eligible", "payout_hold_cleared", "")
+}
+
+func (uc PayoutSettlementUseCase) MarkPaid(ctx context.Context, submissionID string, payoutID string) error {
+	return uc.transition(ctx, submissionID, entities.SubmissionStatusRewardEligible, entities.SubmissionStatusPaid, "paid", "payout_settled", payoutID)
+}
+
+func (uc PayoutSettlementUseCase) transition(
+	ctx context.Context,
+	submissionID string,
+	from entities.SubmissionStatus,
+	to entities.SubmissionStatus,
+	action string,
+	reasonCode string,
+	notes string,
+) error {
+	submission, err := uc.Repository.GetSubmission(ctx, strings.TrimSpace(submissionID))
+	if err != nil {
+		return err
+	}
+	if submission.Status == to {
+		return nil
+	}
+	if submission.Status != from {
+		return domainerrors.ErrInvalidStatusTransition
+	}
+
+	now := time.Now().UTC()
+	if uc.Clock != nil {
+		now = uc.Clock.Now().UTC()
+	}
+	submission.Status = to
+	submission.UpdatedAt = now
+	if err := uc.Repository.UpdateSubmission(ctx, submission); err != nil {
+		return err
+	}
+	auditID, err := uc.IDGen.NewID(ctx)
+	if err != nil {
+		return err
+	}
+	if err := uc.Repository.AddAudit(ctx, entities.SubmissionAudit{
+		AuditID:      auditID,
+		SubmissionID: submission.SubmissionID,
+		Action:       action,
+		OldStatus:    from,
+		NewStatus:    to,
+		ActorID:      "system",
+		ActorRole:    "system",
+		ReasonCode:   reasonCode,
+		ReasonNotes:  strings.TrimSpace(notes),
+		CreatedAt:    now,
+	}); err != nil {
+		return err
+	}
+	application.ResolveLogger(uc.Logger).Info("submission payout status changed",
+		"event", "submission_"+action,
+		"module", "campaign-editorial/submission-service",
+		"layer", "application",
+		"submission_id", submission.SubmissionID,
+		"status", string(to),
+	)
+	return nil
+}
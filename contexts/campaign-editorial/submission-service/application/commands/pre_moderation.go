@@ -0,0 +1,238 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	application "solomon/contexts/campaign-editorial/submission-service/application"
+	"solomon/contexts/campaign-editorial/submission-service/domain/entities"
+	domainerrors "solomon/contexts/campaign-editorial/submission-service/domain/errors"
+	"solomon/contexts/campaign-editorial/submission-service/domain/services"
+	"solomon/contexts/campaign-editorial/submission-service/ports"
+)
+
+const preModerationReviewer = "system:pre_moderation"
+
+// preModerate evaluates the campaign's screening policy against a newly
+// created submission, records every rule outcome as a submission flag and
+// applies the resulting route: auto-approve, the moderation queue (flagged,
+// carrying the computed risk score) or auto-reject. Campaigns without a
+// policy leave the submission pending.
+func (uc CreateSubmissionUseCase) preModerate(
+	ctx context.Context,
+	submission entities.Submission,
+	requiredHashtags []string,
+	now time.Time,
+) (entities.Submission, error) {
+	if uc.Policies == nil {
+		return submission, nil
+	}
+	policy, err := uc.Policies.GetScreeningPolicy(ctx, submission.CampaignID)
+	if errors.Is(err, domainerrors.ErrScreeningPolicyNotFound) {
+		return submission, nil
+	}
+	if err != nil {
+		return submission, err
+	}
+	if len(policy.Rules) == 0 && !policy.AutoApprove {
+		return submission, nil
+	}
+
+	input := services.ScreeningInput{
+		Caption:          captionOf(submission),
+		RequiredHashtags: requiredHashtags,
+		Now:              now,
+	}
+	if uc.Creators != nil {
+		profile, err := uc.Creators.GetCreatorProfile(ctx, submission.CreatorID)
+		if err != nil {
+			return submission, err
+		}
+		input.CreatorTier = profile.ReputationTier
+		input.AccountCreatedAt = profile.AccountCreatedAt
+	}
+	duplicate, err := uc.hasDuplicatePostID(ctx, submission)
+	if err != nil {
+		return submission, err
+	}
+	input.DuplicatePostID = duplicate
+
+	decision := services.EvaluateScreening(policy, input)
+	for _, result := range decision.Results {
+		if err := uc.recordScreeningFlag(ctx, submission.SubmissionID, result, now); err != nil {
+			return submission, err
+		}
+	}
+	if decision.Outcome == entities.ScreeningOutcomeNone {
+		return submission, nil
+	}
+
+	previousStatus := submission.Status
+	if submission.Metadata == nil {
+		submission.Metadata = map[string]any{}
+	}
+	submission.Metadata["pre_moderation"] = map[string]any{
+		"outcome":     string(decision.Outcome),
+		"reason_code": decision.ReasonCode,
+		"risk_score":  decision.RiskScore,
+	}
+	submission.UpdatedAt = now
+
+	var (
+		action    string
+		eventType string
+		eventData map[string]any
+	)
+	switch decision.Outcome {
+	case entities.ScreeningOutcomeAutoApprove:
+		windowEnd := now.Add(30 * 24 * time.Hour)
+		submission.Status = entities.SubmissionStatusApproved
+		submission.ApprovedAt = &now
+		submission.ApprovedByUserID = ""
+		submission.ApprovalReason = decision.ReasonCode
+		submission.VerificationStart = &now
+		submission.VerificationWindowEnd = &windowEnd
+		action = "auto_approved"
+		eventType = "submission.auto_approved"
+		eventData = map[string]any{"auto_approved_at": now.Format(time.RFC3339)}
+	case entities.ScreeningOutcomeReview:
+		submission.Status = entities.SubmissionStatusFlagged
+		action = "flagged"
+		eventType = "submission.flagged"
+		eventData = map[string]any{
+			"reason":     decision.ReasonCode,
+			"risk_score": decision.RiskScore,
+			"flagged_at": now.Format(time.RFC3339),
+		}
+	case entities.ScreeningOutcomeAutoReject:
+		submission.Status = entities.SubmissionStatusRejected
+		submission.RejectedAt = &now
+		submission.RejectionReason = decision.ReasonCode
+		submission.RejectionNotes = "rejected by campaign pre-moderation rules"
+		action = "rejected"
+		eventType = "submission.rejected"
+		eventData = map[string]any{
+			"reason":      decision.ReasonCode,
+			"rejected_at": now.Format(time.RFC3339),
+		}
+	}
+
+	if err := uc.Repository.UpdateSubmission(ctx, submission); err != nil {
+		return submission, err
+	}
+	auditID, err := uc.IDGen.NewID(ctx)
+	if err != nil {
+		return submission, err
+	}
+	if err := uc.Repository.AddAudit(ctx, entities.SubmissionAudit{
+		AuditID:      auditID,
+		SubmissionID: submission.SubmissionID,
+		Action:       action,
+		OldStatus:    previousStatus,
+		NewStatus:    submission.Status,
+		ActorID:      preModerationReviewer,
+		ActorRole:    "system",
+		ReasonCode:   decision.ReasonCode,
+		CreatedAt:    now,
+	}); err != nil {
+		return submission, err
+	}
+	if uc.Outbox != nil {
+		eventID, err := uc.IDGen.NewID(ctx)
+		if err != nil {
+			return submission, err
+		}
+		eventData["submission_id"] = submission.SubmissionID
+		eventData["creator_id"] = submission.CreatorID
+		eventData["user_id"] = submission.CreatorID
+		eventData["campaign_id"] = submission.CampaignID
+		envelope, err := newSubmissionEnvelope(eventID, eventType, submission.SubmissionID, now, eventData)
+		if err != nil {
+			return submission, err
+		}
+		if err := uc.Outbox.AppendOutbox(ctx, envelope); err != nil {
+			return submission, err
+		}
+	}
+
+	application.ResolveLogger(uc.Logger).Info("submission pre-moderated",
+		"event", "submission_pre_moderated",
+		"module", "campaign-editorial/submission-service",
+		"layer", "application",
+		"submission_id", submission.SubmissionID,
+		"outcome", string(decision.Outcome),
+		"reason_code", decision.ReasonCode,
+		"risk_score", decision.RiskScore,
+	)
+	return submission, nil
+}
+
+// hasDuplicatePostID reports whether the same post is already live in any
+// campaign. Rejected and cancelled submissions do not count.
+func (uc CreateSubmissionUseCase) hasDuplicatePostID(ctx context.Context, submission entities.Submission) (bool, error) {
+	if strings.TrimSpace(submission.PostID) == "" {
+		return false, nil
+	}
+	items, err := uc.Repository.ListSubmissions(ctx, ports.SubmissionFilter{
+		Platform: submission.Platform,
+		PostID:   submission.PostID,
+	})
+	if err != nil {
+		return false, err
+	}
+	for _, item := range items {
+		if item.SubmissionID == submission.SubmissionID ||
+			item.Status == entities.SubmissionStatusRejected ||
+			item.Status == entities.SubmissionStatusCancelled {
+			continue
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+func (uc CreateSubmissionUseCase) recordScreeningFlag(
+	ctx context.Context,
+	submissionID string,
+	result entities.ScreeningResult,
+	now time.Time,
+) error {
+	flagID, err := uc.IDGen.NewID(ctx)
+	if err != nil {
+		return err
+	}
+	details := map[string]any{
+		"passed":      result.Passed,
+		"action":      result.Action,
+		"reason_code": result.ReasonCode,
+		"risk_weight": result.RiskWeight,
+	}
+	for key, value := range result.Details {
+		details[key] = value
+	}
+	flag := entities.SubmissionFlag{
+		FlagID:       flagID,
+		SubmissionID: submissionID,
+		FlagType:     "pre_moderation_" + result.RuleType,
+		Severity:     "low",
+		Details:      details,
+		CreatedAt:    now,
+	}
+	switch {
+	case result.Passed:
+		flag.IsResolved = true
+		flag.ResolvedAt = &now
+	case result.Action == entities.ScreeningActionReject:
+		flag.Severity = "high"
+	default:
+		flag.Severity = "medium"
+	}
+	return uc.Repository.AddFlag(ctx, flag)
+}
+
+func captionOf(submission entities.Submission) string {
+	caption, _ := submission.Metadata["caption"].(string)
+	return caption
+}
@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	application "solomon/contexts/campaign-editorial/submission-service/application"
+	"solomon/contexts/campaign-editorial/submission-service/domain/entities"
+	domainerrors "solomon/contexts/campaign-editorial/submission-service/domain/errors"
+	"solomon/contexts/campaign-editorial/submission-service/ports"
+)
+
+type PutScreeningPolicyCommand struct {
+	ActorID     string
+	CampaignID  string
+	Rules       []entities.ScreeningRule
+	AutoApprove bool
+}
+
+// ScreeningPolicyUseCase manages the pre-moderation rules of a campaign.
+// When campaign data is available only the owning brand may read or change
+// them.
+type ScreeningPolicyUseCase struct {
+	Policies  ports.ScreeningPolicyRepository
+	Campaigns ports.CampaignReadRepository
+	Clock     ports.Clock
+	Logger    *slog.Logger
+}
+
+func (uc ScreeningPolicyUseCase) Put(ctx context.Context, cmd PutScreeningPolicyCommand) (entities.ScreeningPolicy, error) {
+	actorID := strings.TrimSpace(cmd.ActorID)
+	campaignID := strings.TrimSpace(cmd.CampaignID)
+	if err := uc.authorize(ctx, actorID, campaignID); err != nil {
+		return entities.ScreeningPolicy{}, err
+	}
+	now := time.Now().UTC()
+	if uc.Clock != nil {
+		now = uc.Clock.Now().UTC()
+	}
+	policy := entities.ScreeningPolicy{
+		CampaignID:      campaignID,
+		Rules:           make([]entities.ScreeningRule, 0, len(cmd.Rules)),
+		AutoApprove:     cmd.AutoApprove,
+		UpdatedByUserID: actorID,
+		UpdatedAt:       now,
+	}
+	for _, rule := range cmd.Rules {
+		rule.RuleType = strings.ToLower(strings.TrimSpace(rule.RuleType))
+		rule.OnFail = strings.ToLower(strings.TrimSpace(rule.OnFail))
+		rule.MinTier = strings.ToLower(strings.TrimSpace(rule.MinTier))
+		policy.Rules = append(policy.Rules, rule)
+	}
+	if !policy.Validate() {
+		return entities.ScreeningPolicy{}, domainerrors.ErrInvalidScreeningPolicy
+	}
+	if err := uc.Policies.PutScreeningPolicy(ctx, policy); err != nil {
+		return entities.ScreeningPolicy{}, err
+	}
+	application.ResolveLogger(uc.Logger).Info("submission screening policy updated",
+		"event", "submission_screening_policy_updated",
+		"module", "campaign-editorial/submission-service",
+		"layer", "application",
+		"campaign_id", policy.CampaignID,
+		"rule_count", len(policy.Rules),
+		"auto_approve", policy.AutoApprove,
+	)
+	return policy, nil
+}
+
+func (uc ScreeningPolicyUseCase) Get(ctx context.Context, actorID string, campaignID string) (entities.ScreeningPolicy, error) {
+	actorID = strings.TrimSpace(actorID)
+	campaignID = strings.TrimSpace(campaignID)
+	if err := uc.authorize(ctx, actorID, campaignID); err != nil {
+		return entities.ScreeningPolicy{}, err
+	}
+	return uc.Policies.GetScreeningPolicy(ctx, campaignID)
+}
+
+func (uc ScreeningPolicyUseCase) authorize(ctx context.Context, actorID string, campaignID string) error {
+	if actorID == "" {
+		return domainerrors.ErrUnauthorizedActor
+	}
+	if campaignID == "" {
+		return domainerrors.ErrInvalidScreeningPolicy
+	}
+	if uc.Campaigns == nil {
+		return nil
+	}
+	campaign, err := uc.Campaigns.GetCampaignForSubmission(ctx, campaignID)
+	if err != nil {
+		return err
+	}
+	if campaign.BrandID != "" && campaign.BrandID != actorID {
+		return domainerrors.ErrUnauthorizedActor
+	}
+	return nil
+}
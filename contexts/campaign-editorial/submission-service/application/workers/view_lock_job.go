@@ -17,6 +17,7 @@ type ViewLockJob struct {
 	Clock           ports.Clock
 	IDGen           ports.IDGenerator
 	Outbox          ports.OutboxWriter
+	Ledger          ports.EarningsLedger
 	BatchSize       int
 	PlatformFeeRate float64
 	Disabled        bool
@@ -72,6 +73,21 @@ func (j ViewLockJob) RunOnce(ctx context.Context) error {
 		submission.Status = entities.SubmissionStatusViewLocked
 		submission.UpdatedAt = now
 
+		// Post before locking so a failed post leaves the submission due and
+		// the next cycle retries it; a repeated post replays.
+		if j.Ledger != nil && gross > 0 {
+			if err := j.Ledger.PostViewLockEarning(ctx, submission); err != nil {
+				logger.Error("submission view-lock ledger posting failed",
+					"event", "submission_view_lock_ledger_post_failed",
+					"module", "campaign-editorial/submission-service",
+					"layer", "worker",
+					"submission_id", submission.SubmissionID,
+					"error", err.Error(),
+				)
+				return err
+			}
+		}
+
 		if err := j.Repository.UpdateSubmission(ctx, submission); err != nil {
 			logger.Error("submission view-lock update failed",
 				"event", "submission_view_lock_update_failed",
@@ -0,0 +1,121 @@
+package entities
+
+import (
+	"strings"
+	"time"
+)
+
+// Pre-moderation rule types a brand can enable for a campaign.
+const (
+	ScreeningRuleRequiredHashtags  = "required_hashtags"
+	ScreeningRuleMinReputationTier = "min_reputation_tier"
+	ScreeningRuleUniquePostID      = "unique_post_id"
+	ScreeningRuleMinAccountAge     = "min_account_age"
+)
+
+// What happens to a submission when a rule fails: review sends it to the
+// moderation queue, reject closes it immediately with the rule's reason code.
+const (
+	ScreeningActionReview = "review"
+	ScreeningActionReject = "reject"
+)
+
+type ScreeningOutcome string
+
+const (
+	// ScreeningOutcomeNone leaves the submission pending for manual review
+	// or the 48h auto-approve job.
+	ScreeningOutcomeNone        ScreeningOutcome = "none"
+	ScreeningOutcomeAutoApprove ScreeningOutcome = "auto_approve"
+	ScreeningOutcomeReview      ScreeningOutcome = "review"
+	ScreeningOutcomeAutoReject  ScreeningOutcome = "auto_reject"
+)
+
+type ScreeningRule struct {
+	RuleType string
+	OnFail   string
+	// RiskWeight is added to the submission's risk score when the rule
+	// fails; zero uses the rule type's default weight.
+	RiskWeight        float64
+	MinTier           string
+	MinAccountAgeDays int
+}
+
+// ScreeningPolicy is the rule set evaluated for every new submission to a
+// campaign. With AutoApprove set, a submission that passes every rule is
+// approved without waiting for a reviewer.
+type ScreeningPolicy struct {
+	CampaignID      string
+	Rules           []ScreeningRule
+	AutoApprove     bool
+	UpdatedByUserID string
+	UpdatedAt       time.Time
+}
+
+func (p ScreeningPolicy) Validate() bool {
+	if strings.TrimSpace(p.CampaignID) == "" {
+		return false
+	}
+	seen := make(map[string]struct{}, len(p.Rules))
+	for _, rule := range p.Rules {
+		if _, ok := seen[rule.RuleType]; ok {
+			return false
+		}
+		seen[rule.RuleType] = struct{}{}
+		if rule.OnFail != ScreeningActionReview && rule.OnFail != ScreeningActionReject {
+			return false
+		}
+		if rule.RiskWeight < 0 || rule.RiskWeight > 1 {
+			return false
+		}
+		switch rule.RuleType {
+		case ScreeningRuleRequiredHashtags, ScreeningRuleUniquePostID:
+		case ScreeningRuleMinReputationTier:
+			if ReputationTierRank(rule.MinTier) == 0 {
+				return false
+			}
+		case ScreeningRuleMinAccountAge:
+			if rule.MinAccountAgeDays <= 0 {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// ScreeningResult is the outcome of one rule for one submission; each is
+// recorded as a submission flag.
+type ScreeningResult struct {
+	RuleType   string
+	Passed     bool
+	Action     string
+	ReasonCode string
+	RiskWeight float64
+	Details    map[string]any
+}
+
+type ScreeningDecision struct {
+	Outcome    ScreeningOutcome
+	ReasonCode string
+	RiskScore  float64
+	Results    []ScreeningResult
+}
+
+// ReputationTierRank orders reputation tiers from 1 (bronze) to 4
+// (platinum); unknown tiers rank 0.
+func ReputationTierRank(tier string) int {
+	switch strings.ToLower(strings.TrimSpace(tier)) {
+	case "bronze":
+		return 1
+	case "silver":
+		return 2
+	case "gold":
+		return 3
+	case "platinum":
+		return 4
+	default:
+		return 0
+	}
+}
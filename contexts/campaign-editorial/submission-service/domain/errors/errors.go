@@ -16,4 +16,6 @@ var (
 	ErrIdempotencyKeyRequired  = errors.New("idempotency key is required")
 	ErrIdempotencyKeyConflict  = errors.New("idempotency key conflict")
 	ErrAlreadyReported         = errors.New("submission already reported by this user")
+	ErrInvalidScreeningPolicy  = errors.New("invalid screening policy")
+	ErrScreeningPolicyNotFound = errors.New("screening policy not found")
 )
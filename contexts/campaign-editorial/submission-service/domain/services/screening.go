@@ -0,0 +1,187 @@
+package services
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"solomon/contexts/campaign-editorial/submission-service/domain/entities"
+)
+
+// ScreeningInput is everything the pre-moderation rules look at. Unknown
+// creator data is left empty: an empty tier ranks below bronze and a zero
+// account creation time never satisfies an account-age rule.
+type ScreeningInput struct {
+	Caption          string
+	RequiredHashtags []string
+	CreatorTier      string
+	AccountCreatedAt time.Time
+	DuplicatePostID  bool
+	Now              time.Time
+}
+
+var defaultRiskWeights = map[string]float64{
+	entities.ScreeningRuleRequiredHashtags:  0.3,
+	entities.ScreeningRuleMinReputationTier: 0.4,
+	entities.ScreeningRuleUniquePostID:      0.8,
+	entities.ScreeningRuleMinAccountAge:     0.5,
+}
+
+// EvaluateScreening runs every rule in the policy. Any failed reject rule
+// rejects the submission with that rule's reason code; otherwise any failed
+// rule sends it to review with the summed risk weights as its risk score.
+// When everything passes the submission is auto-approved if the policy
+// allows it and left pending otherwise.
+func EvaluateScreening(policy entities.ScreeningPolicy, input ScreeningInput) entities.ScreeningDecision {
+	decision := entities.ScreeningDecision{Outcome: entities.ScreeningOutcomeNone}
+	var rejectReason, reviewReason string
+	var reviewWeight float64
+	for _, rule := range policy.Rules {
+		result := evaluateRule(rule, input)
+		decision.Results = append(decision.Results, result)
+		if result.Passed {
+			continue
+		}
+		decision.RiskScore += result.RiskWeight
+		if result.Action == entities.ScreeningActionReject {
+			if rejectReason == "" {
+				rejectReason = result.ReasonCode
+			}
+			continue
+		}
+		if result.RiskWeight > reviewWeight || reviewReason == "" {
+			reviewReason = result.ReasonCode
+			reviewWeight = result.RiskWeight
+		}
+	}
+	decision.RiskScore = math.Min(1, math.Round(decision.RiskScore*100)/100)
+
+	switch {
+	case rejectReason != "":
+		decision.Outcome = entities.ScreeningOutcomeAutoReject
+		decision.ReasonCode = rejectReason
+	case reviewReason != "":
+		decision.Outcome = entities.ScreeningOutcomeReview
+		decision.ReasonCode = reviewReason
+	case policy.AutoApprove:
+		decision.Outcome = entities.ScreeningOutcomeAutoApprove
+		decision.ReasonCode = "pre_moderation_passed"
+	}
+	return decision
+}
+
+func evaluateRule(rule entities.ScreeningRule, input ScreeningInput) entities.ScreeningResult {
+	weight := rule.RiskWeight
+	if weight == 0 {
+		weight = defaultRiskWeights[rule.RuleType]
+	}
+	result := entities.ScreeningResult{
+		RuleType:   rule.RuleType,
+		Passed:     true,
+		Action:     rule.OnFail,
+		RiskWeight: weight,
+		Details:    map[string]any{},
+	}
+
+	switch rule.RuleType {
+	case entities.ScreeningRuleRequiredHashtags:
+		missing := MissingHashtags(input.Caption, input.RequiredHashtags)
+		result.Details["required"] = normalizeHashtags(input.RequiredHashtags)
+		if len(missing) > 0 {
+			result.Passed = false
+			result.ReasonCode = "missing_required_hashtags"
+			result.Details["missing"] = missing
+		}
+	case entities.ScreeningRuleMinReputationTier:
+		result.Details["min_tier"] = strings.ToLower(strings.TrimSpace(rule.MinTier))
+		result.Details["tier"] = strings.ToLower(strings.TrimSpace(input.CreatorTier))
+		if entities.ReputationTierRank(input.CreatorTier) < entities.ReputationTierRank(rule.MinTier) {
+			result.Passed = false
+			result.ReasonCode = "reputation_tier_below_minimum"
+		}
+	case entities.ScreeningRuleUniquePostID:
+		if input.DuplicatePostID {
+			result.Passed = false
+			result.ReasonCode = "duplicate_post_id"
+		}
+	case entities.ScreeningRuleMinAccountAge:
+		result.Details["min_account_age_days"] = rule.MinAccountAgeDays
+		if input.AccountCreatedAt.IsZero() {
+			// Without a registration date a reviewer decides; an unknown
+			// age is never grounds for an automatic rejection.
+			result.Passed = false
+			result.Action = entities.ScreeningActionReview
+			result.ReasonCode = "account_age_unknown"
+			break
+		}
+		ageDays := int(input.Now.Sub(input.AccountCreatedAt).Hours() / 24)
+		result.Details["account_age_days"] = ageDays
+		if ageDays < rule.MinAccountAgeDays {
+			result.Passed = false
+			result.ReasonCode = "account_too_new"
+		}
+	}
+	return result
+}
+
+// MissingHashtags returns the required hashtags, lower-cased and without
+// the leading '#', that do not appear in the caption.
+func MissingHashtags(caption string, required []string) []string {
+	present := map[string]struct{}{}
+	for _, tag := range ExtractHashtags(caption) {
+		present[tag] = struct{}{}
+	}
+	missing := []string{}
+	for _, tag := range normalizeHashtags(required) {
+		if _, ok := present[tag]; !ok {
+			missing = append(missing, tag)
+		}
+	}
+	return missing
+}
+
+// ExtractHashtags returns the distinct hashtags in text, lower-cased and
+// without the leading '#'.
+func ExtractHashtags(text string) []string {
+	seen := map[string]struct{}{}
+	tags := []string{}
+	runes := []rune(text)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '#' {
+			continue
+		}
+		j := i + 1
+		for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_') {
+			j++
+		}
+		if j > i+1 {
+			tag := strings.ToLower(string(runes[i+1 : j]))
+			if _, ok := seen[tag]; !ok {
+				seen[tag] = struct{}{}
+				tags = append(tags, tag)
+			}
+		}
+		i = j - 1
+	}
+	return tags
+}
+
+func normalizeHashtags(tags []string) []string {
+	seen := map[string]struct{}{}
+	out := []string{}
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+		if tag == "" {
+			continue
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		out = append(out, tag)
+	}
+	sort.Strings(out)
+	return out
+}
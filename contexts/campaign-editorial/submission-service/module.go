@@ -20,6 +20,8 @@ type Module struct {
 type Dependencies struct {
 	Repository     ports.Repository
 	Campaigns      ports.CampaignReadRepository
+	Policies       ports.ScreeningPolicyRepository
+	Creators       ports.CreatorProfileReader
 	Idempotency    ports.IdempotencyStore
 	Outbox         ports.OutboxWriter
 	Clock          ports.Clock
@@ -32,6 +34,8 @@ func NewModule(deps Dependencies) Module {
 	createSubmission := commands.CreateSubmissionUseCase{
 		Repository:     deps.Repository,
 		Campaigns:      deps.Campaigns,
+		Policies:       deps.Policies,
+		Creators:       deps.Creators,
 		Idempotency:    deps.Idempotency,
 		Outbox:         deps.Outbox,
 		Clock:          deps.Clock,
@@ -39,6 +43,12 @@ func NewModule(deps Dependencies) Module {
 		IdempotencyTTL: deps.IdempotencyTTL,
 		Logger:         deps.Logger,
 	}
+	screeningPolicy := commands.ScreeningPolicyUseCase{
+		Policies:  deps.Policies,
+		Campaigns: deps.Campaigns,
+		Clock:     deps.Clock,
+		Logger:    deps.Logger,
+	}
 	reviewSubmission := commands.ReviewSubmissionUseCase{
 		Repository:     deps.Repository,
 		Clock:          deps.Clock,
@@ -77,6 +87,7 @@ func NewModule(deps Dependencies) Module {
 			ReviewSubmission: reviewSubmission,
 			ReportSubmission: reportSubmission,
 			BulkOperation:    bulkOperation,
+			ScreeningPolicy:  screeningPolicy,
 			Queries:          queryUseCase,
 			Logger:           deps.Logger,
 		},
@@ -88,6 +99,8 @@ func NewInMemoryModule(seed []entities.Submission, logger *slog.Logger) Module {
 	module := NewModule(Dependencies{
 		Repository:     store,
 		Campaigns:      nil,
+		Policies:       store,
+		Creators:       store,
 		Idempotency:    store,
 		Outbox:         store,
 		Clock:          store,
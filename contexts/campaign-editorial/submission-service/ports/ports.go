@@ -12,13 +12,25 @@ type SubmissionFilter struct {
 	CreatorID  string
 	CampaignID string
 	Status     entities.SubmissionStatus
+	Platform   string
+	PostID     string
 }
 
 type CampaignForSubmission struct {
 	CampaignID       string
+	BrandID          string
 	Status           string
 	AllowedPlatforms []string
 	RatePer1KViews   float64
+	RequiredHashtags []string
+}
+
+// CreatorProfile is what pre-moderation knows about a creator. Fields the
+// platform has no record of are left empty.
+type CreatorProfile struct {
+	CreatorID        string
+	ReputationTier   string
+	AccountCreatedAt time.Time
 }
 
 type Repository interface {
@@ -37,6 +49,18 @@ type CampaignReadRepository interface {
 	GetCampaignForSubmission(ctx context.Context, campaignID string) (CampaignForSubmission, error)
 }
 
+type CreatorProfileReader interface {
+	GetCreatorProfile(ctx context.Context, creatorID string) (CreatorProfile, error)
+}
+
+// ScreeningPolicyRepository stores the pre-moderation rule set of each
+// campaign. GetScreeningPolicy returns ErrScreeningPolicyNotFound when the
+// campaign has none.
+type ScreeningPolicyRepository interface {
+	GetScreeningPolicy(ctx context.Context, campaignID string) (entities.ScreeningPolicy, error)
+	PutScreeningPolicy(ctx context.Context, policy entities.ScreeningPolicy) error
+}
+
 type Clock interface {
 	Now() time.Time
 }
@@ -100,3 +124,15 @@ type AutoApproveRepository interface {
 type ViewLockRepository interface {
 	ListDueViewLock(ctx context.Context, threshold time.Time, limit int) ([]entities.Submission, error)
 }
+
+// PayoutRepository lists view-locked submissions whose earnings have cleared
+// the payout hold period.
+type PayoutRepository interface {
+	ListViewLockedBefore(ctx context.Context, lockedBefore time.Time, limit int) ([]entities.Submission, error)
+}
+
+// EarningsLedger posts the gross earnings of a view-locked submission to the
+// creator's wallet. Postings are keyed by submission, so retrying is safe.
+type EarningsLedger interface {
+	PostViewLockEarning(ctx context.Context, submission entities.Submission) error
+}
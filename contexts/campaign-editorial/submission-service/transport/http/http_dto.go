@@ -11,6 +11,7 @@ type CreateSubmissionRequest struct {
 	Platform       string  `json:"platform"`
 	PostURL        string  `json:"post_url"`
 	CpvRate        float64 `json:"cpv_rate"`
+	Caption        string  `json:"caption,omitempty"`
 }
 
 type ApproveSubmissionRequest struct {
@@ -65,6 +66,35 @@ type SubmissionDTO struct {
 	VerificationEnd   string `json:"verification_end,omitempty"`
 	ViewsCount        int    `json:"views_count"`
 	LockedViews       int    `json:"locked_views,omitempty"`
+
+	PreModeration *PreModerationDTO `json:"pre_moderation,omitempty"`
+}
+
+type PreModerationDTO struct {
+	Outcome    string  `json:"outcome"`
+	ReasonCode string  `json:"reason_code,omitempty"`
+	RiskScore  float64 `json:"risk_score"`
+}
+
+type ScreeningRuleDTO struct {
+	RuleType          string  `json:"rule_type"`
+	OnFail            string  `json:"on_fail"`
+	RiskWeight        float64 `json:"risk_weight,omitempty"`
+	MinTier           string  `json:"min_tier,omitempty"`
+	MinAccountAgeDays int     `json:"min_account_age_days,omitempty"`
+}
+
+type PutScreeningPolicyRequest struct {
+	Rules       []ScreeningRuleDTO `json:"rules"`
+	AutoApprove bool               `json:"auto_approve"`
+}
+
+type ScreeningPolicyResponse struct {
+	CampaignID      string             `json:"campaign_id"`
+	Rules           []ScreeningRuleDTO `json:"rules"`
+	AutoApprove     bool               `json:"auto_approve"`
+	UpdatedByUserID string             `json:"updated_by_user_id"`
+	UpdatedAt       string             `json:"updated_at"`
 }
 
 type CreateSubmissionResponse struct {
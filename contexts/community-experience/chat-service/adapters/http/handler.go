@@ -85,12 +85,14 @@ func (h Handler) ListMessagesHandler(
 	ctx context.Context,
 	channelID string,
 	beforeMessageID string,
+	afterMessageID string,
 	afterSequence int64,
 	limit int,
 ) (httptransport.ListMessagesResponse, error) {
-	items, err := h.Service.ListMessages(ctx, ports.ListMessagesInput{
+	page, err := h.Service.ListMessages(ctx, ports.ListMessagesInput{
 		ChannelID:       channelID,
 		BeforeMessageID: beforeMessageID,
+		AfterMessageID:  afterMessageID,
 		AfterSequence:   afterSequence,
 		Limit:           limit,
 	})
@@ -98,14 +100,19 @@ func (h Handler) ListMessagesHandler(
 		return httptransport.ListMessagesResponse{}, err
 	}
 	resp := httptransport.ListMessagesResponse{Status: "success"}
-	resp.Data.Messages = make([]httptransport.MessageDTO, 0, len(items))
-	for _, item := range items {
+	resp.Data.Messages = make([]httptransport.MessageDTO, 0, len(page.Messages))
+	for _, item := range page.Messages {
 		resp.Data.Messages = append(resp.Data.Messages, toMessageDTO(item))
 	}
 	resp.Data.Limit = limit
 	if resp.Data.Limit <= 0 {
 		resp.Data.Limit = 50
 	}
+	resp.Data.HasMore = page.HasMore
+	if len(page.Messages) > 0 {
+		resp.Data.NextBefore = page.Messages[len(page.Messages)-1].MessageID
+		resp.Data.NextAfter = page.Messages[0].MessageID
+	}
 	return resp, nil
 }
 
@@ -325,7 +332,14 @@ func (h Handler) AddAttachmentHandler(
 	idempotencyKey string,
 	req httptransport.AddAttachmentRequest,
 ) (httptransport.AddAttachmentResponse, error) {
-	item, err := h.Service.AddAttachment(ctx, idempotencyKey, messageID, userID, req.Filename, req.FileSize, req.MimeType)
+	item, err := h.Service.AddAttachment(ctx, idempotencyKey, ports.AddAttachmentInput{
+		MessageID: messageID,
+		UserID:    userID,
+		Filename:  req.Filename,
+		FileSize:  req.FileSize,
+		MimeType:  req.MimeType,
+		Content:   req.Content,
+	})
 	if err != nil {
 		return httptransport.AddAttachmentResponse{}, err
 	}
@@ -337,7 +351,7 @@ func (h Handler) AddAttachmentHandler(
 	resp.Data.MimeType = item.MimeType
 	resp.Data.URL = item.URL
 	resp.Data.ScanResult = item.ScanResult
-	resp.Data.ScannedAt = item.ScannedAt.UTC().Format(time.RFC3339)
+	resp.Data.CreatedAt = item.CreatedAt.UTC().Format(time.RFC3339)
 	return resp, nil
 }
 
@@ -357,6 +371,10 @@ func (h Handler) GetAttachmentHandler(
 	resp.Data.FileSize = item.FileSize
 	resp.Data.MimeType = item.MimeType
 	resp.Data.URL = item.URL
+	resp.Data.ScanResult = item.ScanResult
+	if item.ScannedAt != nil {
+		resp.Data.ScannedAt = item.ScannedAt.UTC().Format(time.RFC3339)
+	}
 	return resp, nil
 }
 
@@ -364,18 +382,25 @@ func (h Handler) ExportMessagesHandler(
 	ctx context.Context,
 	serverID string,
 	channelID string,
+	cursor string,
 	limit int,
 ) (httptransport.ExportMessagesResponse, error) {
-	items, err := h.Service.ExportMessages(ctx, serverID, channelID, limit)
+	page, err := h.Service.ExportMessages(ctx, ports.ExportMessagesInput{
+		ServerID:       serverID,
+		ChannelID:      channelID,
+		AfterMessageID: cursor,
+		Limit:          limit,
+	})
 	if err != nil {
 		return httptransport.ExportMessagesResponse{}, err
 	}
 	resp := httptransport.ExportMessagesResponse{Status: "success"}
-	resp.Data.Messages = make([]httptransport.MessageDTO, 0, len(items))
-	for _, item := range items {
+	resp.Data.Messages = make([]httptransport.MessageDTO, 0, len(page.Messages))
+	for _, item := range page.Messages {
 		resp.Data.Messages = append(resp.Data.Messages, toMessageDTO(item))
 	}
 	resp.Data.Count = len(resp.Data.Messages)
+	resp.Data.NextCursor = page.NextCursor
 	return resp, nil
 }
 
@@ -393,6 +418,7 @@ func toMessageDTO(item ports.Message) httptransport.MessageDTO {
 		UpdatedAt:      item.UpdatedAt.UTC().Format(time.RFC3339),
 		Edited:         item.Edited,
 		Reactions:      item.ReactionCounters,
+		EmbedsPending:  item.EmbedsPending,
 	}
 	if item.DeletedAt != nil {
 		dto.DeletedAt = item.DeletedAt.UTC().Format(time.RFC3339)
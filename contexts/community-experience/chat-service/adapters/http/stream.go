@@ -0,0 +1,121 @@
+package httpadapter
+
+import (
+	"context"
+	"time"
+
+	"solomon/contexts/community-experience/chat-service/ports"
+	httptransport "solomon/contexts/community-experience/chat-service/transport/http"
+)
+
+const StreamReady = "ready"
+
+// OpenChannelStream subscribes to a channel, replays messages after
+// afterSequence, emits a ready frame carrying the resume point, and then
+// forwards live events. Messages already delivered by the replay are not sent
+// twice. The returned channel closes when ctx ends or the subscriber is
+// dropped for falling behind; the caller must always invoke cancel.
+func (h Handler) OpenChannelStream(
+	ctx context.Context,
+	channelID string,
+	afterSequence int64,
+) (<-chan httptransport.ChatStreamEvent, func(), error) {
+	live, unsubscribe, err := h.Service.SubscribeChannel(channelID)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx, stop := context.WithCancel(ctx)
+	cancel := func() {
+		stop()
+		unsubscribe()
+	}
+
+	out := make(chan httptransport.ChatStreamEvent)
+	go func() {
+		defer close(out)
+		send := func(frame httptransport.ChatStreamEvent) error {
+			select {
+			case out <- frame:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		last, err := h.Service.ReplayMessages(ctx, channelID, afterSequence, func(message ports.Message) error {
+			dto := toMessageDTO(message)
+			return send(httptransport.ChatStreamEvent{
+				Type:           ports.RealtimeMessageCreated,
+				ChannelID:      message.ChannelID,
+				SequenceNumber: message.SequenceNumber,
+				MessageID:      message.MessageID,
+				UserID:         message.UserID,
+				Message:        &dto,
+				OccurredAt:     message.CreatedAt.UTC().Format(time.RFC3339),
+			})
+		})
+		if err != nil {
+			return
+		}
+		if err := send(httptransport.ChatStreamEvent{
+			Type:           StreamReady,
+			ChannelID:      channelID,
+			SequenceNumber: last,
+		}); err != nil {
+			return
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-live:
+				if !ok {
+					return
+				}
+				if event.Type == ports.RealtimeMessageCreated && event.Message != nil &&
+					event.Message.SequenceNumber <= last {
+					continue
+				}
+				if err := send(toStreamEvent(event)); err != nil {
+					return
+				}
+			}
+		}
+	}()
+	return out, cancel, nil
+}
+
+func (h Handler) TypingHandler(
+	ctx context.Context,
+	userID string,
+	channelID string,
+	req httptransport.TypingRequest,
+) (httptransport.TypingResponse, error) {
+	if err := h.Service.SignalTyping(ctx, userID, req.ServerID, channelID); err != nil {
+		return httptransport.TypingResponse{}, err
+	}
+	resp := httptransport.TypingResponse{Status: "accepted"}
+	resp.Data.ChannelID = channelID
+	resp.Data.UserID = userID
+	return resp, nil
+}
+
+func toStreamEvent(event ports.RealtimeEvent) httptransport.ChatStreamEvent {
+	frame := httptransport.ChatStreamEvent{
+		Type:       event.Type,
+		EventID:    event.EventID,
+		ChannelID:  event.ChannelID,
+		MessageID:  event.MessageID,
+		UserID:     event.UserID,
+		Emoji:      event.Emoji,
+		Reactions:  event.Reactions,
+		OccurredAt: event.OccurredAt.UTC().Format(time.RFC3339),
+	}
+	if event.Message != nil {
+		dto := toMessageDTO(*event.Message)
+		frame.Message = &dto
+		frame.SequenceNumber = event.Message.SequenceNumber
+	}
+	return frame
+}
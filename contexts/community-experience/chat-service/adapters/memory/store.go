@@ -1,8 +1,11 @@
 package memory
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"sort"
 	"strings"
 	"sync"
@@ -25,15 +28,24 @@ type Store struct {
 	reactionCounters  map[string]map[string]int
 	attachments       map[string]ports.Attachment
 	attachmentsByMsg  map[string][]string
+	attachmentContent map[string][]byte
+	linkPreviews      map[string]ports.LinkPreview
 	pins              map[string]string
 	threadLocks       map[string]bool
 	serverModerators  map[string]ports.ModeratorSet
 	userMutes         map[string]ports.MuteRecord
 	reportedMessageID map[string]time.Time
+	outbox            map[string]outboxRecord
+	outboxOrder       []string
 
 	sequence uint64
 }
 
+type outboxRecord struct {
+	ports.OutboxMessage
+	PublishedAt *time.Time
+}
+
 func NewStore() *Store {
 	now := time.Now().UTC().Add(-2 * time.Hour)
 	message := ports.Message{
@@ -66,11 +78,14 @@ func NewStore() *Store {
 		reactionCounters:  map[string]map[string]int{"msg_001": {}},
 		attachments:       make(map[string]ports.Attachment),
 		attachmentsByMsg:  make(map[string][]string),
+		attachmentContent: make(map[string][]byte),
+		linkPreviews:      make(map[string]ports.LinkPreview),
 		pins:              make(map[string]string),
 		threadLocks:       make(map[string]bool),
 		serverModerators:  make(map[string]ports.ModeratorSet),
 		userMutes:         make(map[string]ports.MuteRecord),
 		reportedMessageID: make(map[string]time.Time),
+		outbox:            make(map[string]outboxRecord),
 		sequence:          1,
 	}
 }
@@ -106,7 +121,8 @@ func (s *Store) CreateMessage(ctx context.Context, input ports.CreateMessageInpu
 		CreatedAt:        now.UTC(),
 		UpdatedAt:        now.UTC(),
 		Mentions:         parseMentions(parsed),
-		Embeds:           parseEmbeds(parsed),
+		Embeds:           []ports.Embed{},
+		EmbedsPending:    containsLink(parsed),
 		ReactionCounters: map[string]int{},
 	}
 	s.messages[item.MessageID] = item
@@ -136,7 +152,8 @@ func (s *Store) UpdateMessage(ctx context.Context, input ports.UpdateMessageInpu
 	item.UpdatedAt = now.UTC()
 	item.Edited = true
 	item.Mentions = parseMentions(item.Content)
-	item.Embeds = parseEmbeds(item.Content)
+	item.Embeds = []ports.Embed{}
+	item.EmbedsPending = containsLink(item.Content)
 	s.messages[item.MessageID] = item
 	return cloneMessage(item), nil
 }
@@ -160,56 +177,84 @@ func (s *Store) DeleteMessage(ctx context.Context, input ports.DeleteMessageInpu
 	item.DeletedByUserID = input.UserID
 	item.DeletionReason = strings.TrimSpace(input.Reason)
 	item.Content = "[Deleted]"
+	item.Embeds = []ports.Embed{}
+	item.EmbedsPending = false
 	item.UpdatedAt = ts
 	s.messages[item.MessageID] = item
 	return cloneMessage(item), nil
 }
 
-func (s *Store) ListMessages(ctx context.Context, input ports.ListMessagesInput) ([]ports.Message, error) {
+func (s *Store) GetMessage(ctx context.Context, messageID string) (ports.Message, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	ids := append([]string(nil), s.channelMessages[input.ChannelID]...)
-	if len(ids) == 0 {
-		return []ports.Message{}, nil
+	item, ok := s.messages[messageID]
+	if !ok {
+		return ports.Message{}, domainerrors.ErrMessageNotFound
 	}
+	return cloneMessage(item), nil
+}
+
+func (s *Store) ListMessages(ctx context.Context, input ports.ListMessagesInput) (ports.MessagePage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	limit := input.Limit
 	if limit <= 0 {
 		limit = 50
 	}
+	// channelMessages is append-only in sequence order, so it doubles as the
+	// (channel_id, sequence_number) keyset index.
+	ids := s.channelMessages[input.ChannelID]
 
-	startSeq := int64(0)
-	if input.AfterSequence > 0 {
-		startSeq = input.AfterSequence
-	}
 	if input.BeforeMessageID != "" {
-		if before, ok := s.messages[input.BeforeMessageID]; ok {
-			startSeq = before.SequenceNumber - int64(limit) - 1
-			if startSeq < 0 {
-				startSeq = 0
-			}
+		cursor, err := s.cursorMessage(input.ChannelID, input.BeforeMessageID)
+		if err != nil {
+			return ports.MessagePage{}, err
+		}
+		end := sort.Search(len(ids), func(i int) bool {
+			return s.messages[ids[i]].SequenceNumber >= cursor.SequenceNumber
+		})
+		start := end - limit
+		if start < 0 {
+			start = 0
 		}
+		return ports.MessagePage{
+			Messages: s.newestFirst(ids[start:end]),
+			HasMore:  start > 0,
+		}, nil
 	}
 
-	items := make([]ports.Message, 0, len(ids))
-	for _, id := range ids {
-		item, ok := s.messages[id]
-		if !ok {
-			continue
+	afterSeq := input.AfterSequence
+	if input.AfterMessageID != "" {
+		cursor, err := s.cursorMessage(input.ChannelID, input.AfterMessageID)
+		if err != nil {
+			return ports.MessagePage{}, err
 		}
-		if item.SequenceNumber <= startSeq {
-			continue
+		afterSeq = cursor.SequenceNumber
+	}
+	if input.AfterMessageID != "" || afterSeq > 0 {
+		start := sort.Search(len(ids), func(i int) bool {
+			return s.messages[ids[i]].SequenceNumber > afterSeq
+		})
+		end := start + limit
+		if end > len(ids) {
+			end = len(ids)
 		}
-		items = append(items, cloneMessage(item))
+		return ports.MessagePage{
+			Messages: s.newestFirst(ids[start:end]),
+			HasMore:  end < len(ids),
+		}, nil
 	}
 
-	sort.Slice(items, func(i, j int) bool {
-		return items[i].SequenceNumber > items[j].SequenceNumber
-	})
-	if len(items) > limit {
-		items = items[:limit]
+	start := len(ids) - limit
+	if start < 0 {
+		start = 0
 	}
-	return items, nil
+	return ports.MessagePage{
+		Messages: s.newestFirst(ids[start:]),
+		HasMore:  start > 0,
+	}, nil
 }
 
 func (s *Store) SearchMessages(ctx context.Context, input ports.SearchInput) ([]ports.SearchResult, int, error) {
@@ -480,37 +525,31 @@ func (s *Store) MuteUser(
 	return record, nil
 }
 
-func (s *Store) AddAttachment(
-	ctx context.Context,
-	messageID string,
-	userID string,
-	filename string,
-	fileSize int64,
-	mimeType string,
-	now time.Time,
-) (ports.Attachment, error) {
+func (s *Store) AddAttachment(ctx context.Context, input ports.AddAttachmentInput, now time.Time) (ports.Attachment, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if _, ok := s.messages[messageID]; !ok {
+	if _, ok := s.messages[input.MessageID]; !ok {
 		return ports.Attachment{}, domainerrors.ErrMessageNotFound
 	}
-	if fileSize > 50*1024*1024 {
+	if len(input.Content) > 50*1024*1024 {
 		return ports.Attachment{}, domainerrors.ErrInvalidRequest
 	}
+	filename := strings.TrimSpace(input.Filename)
 	attachment := ports.Attachment{
 		AttachmentID: "att_" + s.nextID("m46"),
-		MessageID:    messageID,
-		UserID:       userID,
-		Filename:     strings.TrimSpace(filename),
-		FileSize:     fileSize,
-		MimeType:     strings.TrimSpace(mimeType),
-		URL:          "https://cdn.viralforge.local/chat/" + messageID + "/" + strings.TrimSpace(filename),
-		ScanResult:   "CLEAN",
-		ScannedAt:    now.UTC(),
+		MessageID:    input.MessageID,
+		UserID:       input.UserID,
+		Filename:     filename,
+		FileSize:     int64(len(input.Content)),
+		MimeType:     strings.TrimSpace(input.MimeType),
+		URL:          "https://cdn.viralforge.local/chat/" + input.MessageID + "/" + filename,
+		ScanResult:   ports.ScanResultPending,
+		CreatedAt:    now.UTC(),
 	}
 	s.attachments[attachment.AttachmentID] = attachment
-	s.attachmentsByMsg[messageID] = append(s.attachmentsByMsg[messageID], attachment.AttachmentID)
+	s.attachmentsByMsg[input.MessageID] = append(s.attachmentsByMsg[input.MessageID], attachment.AttachmentID)
+	s.attachmentContent[attachment.AttachmentID] = append([]byte(nil), input.Content...)
 	return attachment, nil
 }
 
@@ -525,30 +564,115 @@ func (s *Store) GetAttachment(ctx context.Context, messageID string, attachmentI
 	return item, nil
 }
 
-func (s *Store) ExportMessages(ctx context.Context, serverID string, channelID string, limit int) ([]ports.Message, error) {
+// ListPendingAttachmentScans returns attachments awaiting a scan, oldest first.
+func (s *Store) ListPendingAttachmentScans(ctx context.Context, limit int) ([]ports.Attachment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	items := make([]ports.Attachment, 0)
+	for _, item := range s.attachments {
+		if item.ScanResult == ports.ScanResultPending {
+			items = append(items, item)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].CreatedAt.Equal(items[j].CreatedAt) {
+			return items[i].CreatedAt.Before(items[j].CreatedAt)
+		}
+		return items[i].AttachmentID < items[j].AttachmentID
+	})
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+func (s *Store) OpenAttachmentContent(ctx context.Context, attachmentID string) (io.ReadCloser, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	content, ok := s.attachmentContent[attachmentID]
+	if !ok {
+		return nil, domainerrors.ErrAttachmentNotFound
+	}
+	return io.NopCloser(bytes.NewReader(content)), nil
+}
+
+// UpdateAttachmentScan applies a scan outcome to a pending attachment. Once an
+// attachment leaves pending_scan its result is final.
+func (s *Store) UpdateAttachmentScan(
+	ctx context.Context,
+	update ports.AttachmentScanUpdate,
+	now time.Time,
+) (ports.Attachment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, ok := s.attachments[update.AttachmentID]
+	if !ok {
+		return ports.Attachment{}, domainerrors.ErrAttachmentNotFound
+	}
+	if item.ScanResult != ports.ScanResultPending {
+		return ports.Attachment{}, domainerrors.ErrConflict
+	}
+	item.ScanAttempts = update.Attempts
+	if update.ScanResult != ports.ScanResultPending {
+		ts := now.UTC()
+		item.ScanResult = update.ScanResult
+		item.ScanSignature = update.Signature
+		item.ScannedAt = &ts
+		if update.ScanResult == ports.ScanResultInfected {
+			item.QuarantinedAt = &ts
+		}
+	}
+	s.attachments[item.AttachmentID] = item
+	return item, nil
+}
+
+func (s *Store) ExportMessages(ctx context.Context, input ports.ExportMessagesInput) (ports.ExportPage, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
+	limit := input.Limit
 	if limit <= 0 {
 		limit = 1000
 	}
 	items := make([]ports.Message, 0)
 	for _, item := range s.messages {
-		if serverID != "" && item.ServerID != serverID {
+		if input.ServerID != "" && item.ServerID != input.ServerID {
 			continue
 		}
-		if channelID != "" && item.ChannelID != channelID {
+		if input.ChannelID != "" && item.ChannelID != input.ChannelID {
 			continue
 		}
-		items = append(items, cloneMessage(item))
+		items = append(items, item)
 	}
 	sort.Slice(items, func(i, j int) bool {
-		return items[i].CreatedAt.Before(items[j].CreatedAt)
+		return exportOrderLess(items[i], items[j])
 	})
-	if len(items) > limit {
-		items = items[:limit]
+
+	start := 0
+	if input.AfterMessageID != "" {
+		cursor, ok := s.messages[input.AfterMessageID]
+		if !ok {
+			return ports.ExportPage{}, domainerrors.ErrInvalidRequest
+		}
+		start = sort.Search(len(items), func(i int) bool {
+			return exportOrderLess(cursor, items[i])
+		})
 	}
-	return items, nil
+	end := start + limit
+	if end > len(items) {
+		end = len(items)
+	}
+	page := ports.ExportPage{Messages: make([]ports.Message, 0, end-start)}
+	for _, item := range items[start:end] {
+		page.Messages = append(page.Messages, cloneMessage(item))
+	}
+	if end < len(items) && len(page.Messages) > 0 {
+		page.NextCursor = page.Messages[len(page.Messages)-1].MessageID
+	}
+	return page, nil
 }
 
 func (s *Store) Get(ctx context.Context, key string, now time.Time) (ports.IdempotencyRecord, bool, error) {
@@ -580,6 +704,79 @@ func (s *Store) Put(ctx context.Context, record ports.IdempotencyRecord) error {
 	return nil
 }
 
+// AppendOutbox records an event once; re-appending the same event ID is a no-op.
+func (s *Store) AppendOutbox(ctx context.Context, envelope ports.EventEnvelope) error {
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	outboxID := strings.TrimSpace(envelope.EventID)
+	if outboxID == "" {
+		outboxID = s.nextID("chat_outbox")
+	}
+	if _, ok := s.outbox[outboxID]; ok {
+		return nil
+	}
+	createdAt := envelope.OccurredAt.UTC()
+	if createdAt.IsZero() {
+		createdAt = time.Now().UTC()
+	}
+	s.outbox[outboxID] = outboxRecord{
+		OutboxMessage: ports.OutboxMessage{
+			OutboxID:     outboxID,
+			EventType:    strings.TrimSpace(envelope.EventType),
+			PartitionKey: strings.TrimSpace(envelope.PartitionKey),
+			Payload:      payload,
+			CreatedAt:    createdAt,
+		},
+	}
+	s.outboxOrder = append(s.outboxOrder, outboxID)
+	return nil
+}
+
+// ListPendingOutbox returns unpublished events in append order.
+func (s *Store) ListPendingOutbox(ctx context.Context, limit int) ([]ports.OutboxMessage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if limit <= 0 {
+		limit = 100
+	}
+	items := make([]ports.OutboxMessage, 0, limit)
+	for _, outboxID := range s.outboxOrder {
+		row := s.outbox[outboxID]
+		if row.PublishedAt != nil {
+			continue
+		}
+		item := row.OutboxMessage
+		item.Payload = append([]byte(nil), row.Payload...)
+		items = append(items, item)
+		if len(items) == limit {
+			break
+		}
+	}
+	return items, nil
+}
+
+func (s *Store) MarkOutboxPublished(ctx context.Context, outboxID string, publishedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	outboxID = strings.TrimSpace(outboxID)
+	row, ok := s.outbox[outboxID]
+	if !ok {
+		return domainerrors.ErrNotFound
+	}
+	timestamp := publishedAt.UTC()
+	row.PublishedAt = &timestamp
+	s.outbox[outboxID] = row
+	return nil
+}
+
 func (s *Store) NewID(ctx context.Context) (string, error) {
 	return s.nextID("m46"), nil
 }
@@ -607,6 +804,31 @@ func (s *Store) activeMute(userID string, serverID string, now time.Time) (bool,
 	return false, true
 }
 
+func (s *Store) cursorMessage(channelID string, messageID string) (ports.Message, error) {
+	item, ok := s.messages[messageID]
+	if !ok || item.ChannelID != channelID {
+		return ports.Message{}, domainerrors.ErrInvalidRequest
+	}
+	return item, nil
+}
+
+func (s *Store) newestFirst(ids []string) []ports.Message {
+	items := make([]ports.Message, 0, len(ids))
+	for i := len(ids) - 1; i >= 0; i-- {
+		items = append(items, cloneMessage(s.messages[ids[i]]))
+	}
+	return items
+}
+
+// exportOrderLess orders exports by (created_at, message_id), matching the
+// keyset used by the postgres adapter.
+func exportOrderLess(a ports.Message, b ports.Message) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.Before(b.CreatedAt)
+	}
+	return a.MessageID < b.MessageID
+}
+
 func canModerate(userID string) bool {
 	normalized := strings.ToLower(strings.TrimSpace(userID))
 	return strings.HasPrefix(normalized, "mod_") ||
@@ -652,19 +874,16 @@ func parseMentions(content string) []ports.Mention {
 	return out
 }
 
-func parseEmbeds(content string) []ports.Embed {
-	words := strings.Fields(content)
-	out := make([]ports.Embed, 0)
-	for _, word := range words {
-		candidate := strings.Trim(word, ".,!?;:")
+// containsLink reports whether content has a link for the unfurler; embeds
+// themselves are only filled in once the links have been fetched.
+func containsLink(content string) bool {
+	for _, word := range strings.Fields(content) {
+		candidate := strings.Trim(word, ".,!?;:()<>\"'")
 		if strings.HasPrefix(candidate, "http://") || strings.HasPrefix(candidate, "https://") {
-			out = append(out, ports.Embed{
-				URL:   candidate,
-				Title: "Link Preview",
-			})
+			return true
 		}
 	}
-	return out
+	return false
 }
 
 func dedupeList(values []string) []string {
@@ -0,0 +1,70 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	domainerrors "solomon/contexts/community-experience/chat-service/domain/errors"
+	"solomon/contexts/community-experience/chat-service/ports"
+)
+
+// ListPendingUnfurls returns live messages whose links await unfurling,
+// oldest first.
+func (s *Store) ListPendingUnfurls(ctx context.Context, limit int) ([]ports.Message, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	items := make([]ports.Message, 0)
+	for _, item := range s.messages {
+		if item.EmbedsPending && item.DeletedAt == nil {
+			items = append(items, cloneMessage(item))
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].CreatedAt.Equal(items[j].CreatedAt) {
+			return items[i].CreatedAt.Before(items[j].CreatedAt)
+		}
+		return items[i].MessageID < items[j].MessageID
+	})
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+func (s *Store) SetMessageEmbeds(ctx context.Context, update ports.MessageEmbedsUpdate, now time.Time) (ports.Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, ok := s.messages[update.MessageID]
+	if !ok {
+		return ports.Message{}, domainerrors.ErrMessageNotFound
+	}
+	if item.DeletedAt != nil || !item.UpdatedAt.Equal(update.ContentVersion) {
+		return ports.Message{}, domainerrors.ErrConflict
+	}
+	item.Embeds = append([]ports.Embed{}, update.Embeds...)
+	item.EmbedsPending = false
+	s.messages[item.MessageID] = item
+	return cloneMessage(item), nil
+}
+
+func (s *Store) GetLinkPreview(ctx context.Context, url string, now time.Time) (ports.LinkPreview, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	preview, ok := s.linkPreviews[url]
+	if !ok || !now.Before(preview.ExpiresAt) {
+		return ports.LinkPreview{}, false, nil
+	}
+	return preview, true, nil
+}
+
+func (s *Store) PutLinkPreview(ctx context.Context, preview ports.LinkPreview) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.linkPreviews[preview.URL] = preview
+	return nil
+}
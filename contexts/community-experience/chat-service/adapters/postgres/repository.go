@@ -0,0 +1,1327 @@
+package postgresadapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	domainerrors "solomon/contexts/community-experience/chat-service/domain/errors"
+	"solomon/contexts/community-experience/chat-service/ports"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	defaultServerID   = "srv_001"
+	editWindow        = 5 * time.Minute
+	maxAttachmentSize = 50 * 1024 * 1024
+
+	outboxStatusPending   = "pending"
+	outboxStatusPublished = "published"
+)
+
+type Repository struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+// NewRepository builds the GORM-backed chat repository adapter.
+func NewRepository(db *gorm.DB, logger *slog.Logger) *Repository {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Repository{db: db, logger: logger}
+}
+
+// CreateMessage allocates the next channel sequence and stores the message in one transaction.
+func (r *Repository) CreateMessage(ctx context.Context, input ports.CreateMessageInput, now time.Time) (ports.Message, error) {
+	channelID := strings.TrimSpace(input.ChannelID)
+	serverID := strings.TrimSpace(input.ServerID)
+	if serverID == "" {
+		serverID = defaultServerID
+	}
+	content := strings.TrimSpace(input.Content)
+
+	var row messageModel
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var activeMutes int64
+		if err := tx.Model(&muteModel{}).
+			Where("user_id = ? AND server_id = ? AND (muted_until IS NULL OR muted_until > ?)", input.UserID, serverID, now.UTC()).
+			Count(&activeMutes).Error; err != nil {
+			return err
+		}
+		if activeMutes > 0 {
+			return domainerrors.ErrForbidden
+		}
+
+		var sequence int64
+		if err := tx.Raw(
+			`INSERT INTO chat_channel_sequences (channel_id, last_sequence) VALUES (?, 1)
+			 ON CONFLICT (channel_id) DO UPDATE SET last_sequence = chat_channel_sequences.last_sequence + 1
+			 RETURNING last_sequence`,
+			channelID,
+		).Scan(&sequence).Error; err != nil {
+			return err
+		}
+
+		mentions, err := json.Marshal(parseMentions(content))
+		if err != nil {
+			return err
+		}
+		row = messageModel{
+			MessageID:      "msg_" + uuid.NewString(),
+			ServerID:       serverID,
+			ChannelID:      channelID,
+			ThreadID:       strings.TrimSpace(input.ThreadID),
+			UserID:         input.UserID,
+			Username:       defaultString(strings.TrimSpace(input.Username), input.UserID),
+			Content:        content,
+			SequenceNumber: sequence,
+			Mentions:       mentions,
+			Embeds:         []byte("[]"),
+			EmbedsPending:  containsLink(content),
+			CreatedAt:      now.UTC(),
+			UpdatedAt:      now.UTC(),
+		}
+		return mapWriteError(tx.Create(&row).Error)
+	})
+	if err != nil {
+		return ports.Message{}, err
+	}
+	return row.toPort(nil), nil
+}
+
+// UpdateMessage rewrites message content inside the edit window and records the previous revision.
+func (r *Repository) UpdateMessage(ctx context.Context, input ports.UpdateMessageInput, now time.Time) (ports.Message, error) {
+	var row messageModel
+	var counters map[string]int
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockMessage(tx, input.MessageID, &row); err != nil {
+			return err
+		}
+		if row.DeletedAt != nil {
+			return domainerrors.ErrConflict
+		}
+		if row.UserID != input.UserID {
+			return domainerrors.ErrForbidden
+		}
+		if now.UTC().After(row.CreatedAt.UTC().Add(editWindow)) {
+			return domainerrors.ErrConflict
+		}
+
+		if err := tx.Create(&messageEditModel{
+			EditID:          "edit_" + uuid.NewString(),
+			MessageID:       row.MessageID,
+			EditorID:        input.UserID,
+			PreviousContent: row.Content,
+			EditedAt:        now.UTC(),
+		}).Error; err != nil {
+			return mapWriteError(err)
+		}
+
+		content := strings.TrimSpace(input.Content)
+		mentions, err := json.Marshal(parseMentions(content))
+		if err != nil {
+			return err
+		}
+		row.Content = content
+		row.Mentions = mentions
+		row.Embeds = []byte("[]")
+		row.EmbedsPending = containsLink(content)
+		row.Edited = true
+		row.UpdatedAt = now.UTC()
+		if err := tx.Model(&messageModel{}).
+			Where("message_id = ?", row.MessageID).
+			Updates(map[string]any{
+				"content":        row.Content,
+				"mentions":       row.Mentions,
+				"embeds":         row.Embeds,
+				"embeds_pending": row.EmbedsPending,
+				"edited":         true,
+				"updated_at":     row.UpdatedAt,
+			}).Error; err != nil {
+			return err
+		}
+
+		byMessage, err := loadReactionCounters(tx, []string{row.MessageID})
+		if err != nil {
+			return err
+		}
+		counters = byMessage[row.MessageID]
+		return nil
+	})
+	if err != nil {
+		return ports.Message{}, err
+	}
+	return row.toPort(counters), nil
+}
+
+// DeleteMessage soft-deletes a message so channel sequences and cursors stay stable.
+func (r *Repository) DeleteMessage(ctx context.Context, input ports.DeleteMessageInput, now time.Time) (ports.Message, error) {
+	var row messageModel
+	var counters map[string]int
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockMessage(tx, input.MessageID, &row); err != nil {
+			return err
+		}
+		if row.DeletedAt == nil {
+			if row.UserID != input.UserID && !canModerate(input.UserID) {
+				return domainerrors.ErrForbidden
+			}
+			ts := now.UTC()
+			row.DeletedAt = &ts
+			row.DeletedByUserID = input.UserID
+			row.DeletionReason = strings.TrimSpace(input.Reason)
+			row.Content = "[Deleted]"
+			row.Embeds = []byte("[]")
+			row.EmbedsPending = false
+			row.UpdatedAt = ts
+			if err := tx.Model(&messageModel{}).
+				Where("message_id = ?", row.MessageID).
+				Updates(map[string]any{
+					"deleted_at":         row.DeletedAt,
+					"deleted_by_user_id": row.DeletedByUserID,
+					"deletion_reason":    row.DeletionReason,
+					"content":            row.Content,
+					"embeds":             row.Embeds,
+					"embeds_pending":     false,
+					"updated_at":         row.UpdatedAt,
+				}).Error; err != nil {
+				return err
+			}
+		}
+
+		byMessage, err := loadReactionCounters(tx, []string{row.MessageID})
+		if err != nil {
+			return err
+		}
+		counters = byMessage[row.MessageID]
+		return nil
+	})
+	if err != nil {
+		return ports.Message{}, err
+	}
+	return row.toPort(counters), nil
+}
+
+// GetMessage loads one message with its reaction counters.
+func (r *Repository) GetMessage(ctx context.Context, messageID string) (ports.Message, error) {
+	db := r.db.WithContext(ctx)
+	var row messageModel
+	if err := db.Where("message_id = ?", messageID).First(&row).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ports.Message{}, domainerrors.ErrMessageNotFound
+		}
+		return ports.Message{}, err
+	}
+	messages, err := toMessages(db, []messageModel{row})
+	if err != nil {
+		return ports.Message{}, err
+	}
+	return messages[0], nil
+}
+
+// ListMessages pages channel history with a (channel_id, sequence_number) keyset.
+// Fetching limit+1 rows tells whether another page exists without a COUNT.
+func (r *Repository) ListMessages(ctx context.Context, input ports.ListMessagesInput) (ports.MessagePage, error) {
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	db := r.db.WithContext(ctx)
+	query := db.Where("channel_id = ?", input.ChannelID)
+	ascending := false
+
+	switch {
+	case input.BeforeMessageID != "":
+		sequence, err := cursorSequence(db, input.ChannelID, input.BeforeMessageID)
+		if err != nil {
+			return ports.MessagePage{}, err
+		}
+		query = query.Where("sequence_number < ?", sequence).Order("sequence_number DESC")
+	case input.AfterMessageID != "" || input.AfterSequence > 0:
+		afterSeq := input.AfterSequence
+		if input.AfterMessageID != "" {
+			sequence, err := cursorSequence(db, input.ChannelID, input.AfterMessageID)
+			if err != nil {
+				return ports.MessagePage{}, err
+			}
+			afterSeq = sequence
+		}
+		query = query.Where("sequence_number > ?", afterSeq).Order("sequence_number ASC")
+		ascending = true
+	default:
+		query = query.Order("sequence_number DESC")
+	}
+
+	var rows []messageModel
+	if err := query.Limit(limit + 1).Find(&rows).Error; err != nil {
+		return ports.MessagePage{}, err
+	}
+	hasMore := len(rows) > limit
+	if hasMore {
+		rows = rows[:limit]
+	}
+	if ascending {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+
+	messages, err := toMessages(db, rows)
+	if err != nil {
+		return ports.MessagePage{}, err
+	}
+	return ports.MessagePage{Messages: messages, HasMore: hasMore}, nil
+}
+
+// SearchMessages runs a case-insensitive substring search over live messages.
+func (r *Repository) SearchMessages(ctx context.Context, input ports.SearchInput) ([]ports.SearchResult, int, error) {
+	query := strings.TrimSpace(input.Query)
+	if query == "" {
+		return nil, 0, domainerrors.ErrInvalidRequest
+	}
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+
+	base := r.db.WithContext(ctx).Model(&messageModel{}).
+		Where("deleted_at IS NULL").
+		Where("content ILIKE ?", "%"+escapeLike(query)+"%")
+	if input.ChannelID != "" {
+		base = base.Where("channel_id = ?", input.ChannelID)
+	}
+
+	var total int64
+	if err := base.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var rows []messageModel
+	if err := base.Session(&gorm.Session{}).
+		Order("created_at DESC, message_id DESC").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+
+	results := make([]ports.SearchResult, 0, len(rows))
+	for _, row := range rows {
+		snippet := row.Content
+		if len(snippet) > 120 {
+			snippet = snippet[:120]
+		}
+		results = append(results, ports.SearchResult{
+			MessageID: row.MessageID,
+			ChannelID: row.ChannelID,
+			Username:  row.Username,
+			Content:   row.Content,
+			Snippet:   snippet,
+			CreatedAt: row.CreatedAt.UTC(),
+		})
+	}
+	return results, int(total), nil
+}
+
+// UpsertReadState moves a user's read marker for a channel.
+func (r *Repository) UpsertReadState(
+	ctx context.Context,
+	userID string,
+	channelID string,
+	lastReadMessageID string,
+	now time.Time,
+) (ports.ReadState, error) {
+	row := readStateModel{
+		ReadStateID:       "read_" + uuid.NewString(),
+		UserID:            userID,
+		ChannelID:         channelID,
+		LastReadMessageID: strings.TrimSpace(lastReadMessageID),
+		LastReadAt:        now.UTC(),
+	}
+	db := r.db.WithContext(ctx)
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "channel_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_read_message_id", "last_read_at"}),
+	}).Create(&row).Error; err != nil {
+		return ports.ReadState{}, err
+	}
+	var stored readStateModel
+	if err := db.Where("user_id = ? AND channel_id = ?", userID, channelID).First(&stored).Error; err != nil {
+		return ports.ReadState{}, err
+	}
+	return stored.toPort(), nil
+}
+
+// UnreadCount counts live messages after the user's read marker.
+func (r *Repository) UnreadCount(ctx context.Context, userID string, channelID string, lastReadMessageID string) (int, error) {
+	db := r.db.WithContext(ctx)
+	if lastReadMessageID == "" {
+		var state readStateModel
+		err := db.Where("user_id = ? AND channel_id = ?", userID, channelID).First(&state).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, err
+		}
+		lastReadMessageID = state.LastReadMessageID
+	}
+
+	lastSeq := int64(0)
+	if lastReadMessageID != "" {
+		var marker messageModel
+		err := db.Select("sequence_number").Where("message_id = ?", lastReadMessageID).First(&marker).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, err
+		}
+		lastSeq = marker.SequenceNumber
+	}
+
+	var count int64
+	if err := db.Model(&messageModel{}).
+		Where("channel_id = ? AND sequence_number > ? AND deleted_at IS NULL", channelID, lastSeq).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// AddReaction stores one reaction per (message, user, emoji) and returns fresh counters.
+func (r *Repository) AddReaction(
+	ctx context.Context,
+	messageID string,
+	userID string,
+	emoji string,
+	now time.Time,
+) (ports.Reaction, map[string]int, error) {
+	emoji = strings.TrimSpace(emoji)
+	db := r.db.WithContext(ctx)
+	if err := requireMessage(db, messageID); err != nil {
+		return ports.Reaction{}, nil, err
+	}
+	row := reactionModel{
+		ReactionID: "rxn_" + uuid.NewString(),
+		MessageID:  messageID,
+		UserID:     userID,
+		Emoji:      emoji,
+		CreatedAt:  now.UTC(),
+	}
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "message_id"}, {Name: "user_id"}, {Name: "emoji"}},
+		DoNothing: true,
+	}).Create(&row).Error; err != nil {
+		return ports.Reaction{}, nil, mapWriteError(err)
+	}
+	var stored reactionModel
+	if err := db.Where("message_id = ? AND user_id = ? AND emoji = ?", messageID, userID, emoji).First(&stored).Error; err != nil {
+		return ports.Reaction{}, nil, err
+	}
+	byMessage, err := loadReactionCounters(db, []string{messageID})
+	if err != nil {
+		return ports.Reaction{}, nil, err
+	}
+	return stored.toPort(), byMessage[messageID], nil
+}
+
+// RemoveReaction deletes a user's reaction and returns fresh counters.
+func (r *Repository) RemoveReaction(ctx context.Context, messageID string, userID string, emoji string) (map[string]int, error) {
+	db := r.db.WithContext(ctx)
+	if err := requireMessage(db, messageID); err != nil {
+		return nil, err
+	}
+	if err := db.Where("message_id = ? AND user_id = ? AND emoji = ?", messageID, userID, strings.TrimSpace(emoji)).
+		Delete(&reactionModel{}).Error; err != nil {
+		return nil, err
+	}
+	byMessage, err := loadReactionCounters(db, []string{messageID})
+	if err != nil {
+		return nil, err
+	}
+	return byMessage[messageID], nil
+}
+
+func (r *Repository) PinMessage(ctx context.Context, messageID string, actorID string, reason string, now time.Time) error {
+	db := r.db.WithContext(ctx)
+	if err := requireMessage(db, messageID); err != nil {
+		return err
+	}
+	if !canModerate(actorID) {
+		return domainerrors.ErrForbidden
+	}
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "message_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"pinned_by", "reason", "pinned_at"}),
+	}).Create(&pinModel{
+		MessageID: messageID,
+		PinnedBy:  actorID,
+		Reason:    strings.TrimSpace(reason),
+		PinnedAt:  now.UTC(),
+	}).Error
+}
+
+func (r *Repository) ReportMessage(
+	ctx context.Context,
+	messageID string,
+	actorID string,
+	reason string,
+	description string,
+	now time.Time,
+) error {
+	db := r.db.WithContext(ctx)
+	if err := requireMessage(db, messageID); err != nil {
+		return err
+	}
+	return mapWriteError(db.Create(&reportModel{
+		ReportID:    "rpt_" + uuid.NewString(),
+		MessageID:   messageID,
+		ReporterID:  actorID,
+		Reason:      reason,
+		Description: description,
+		ReportedAt:  now.UTC(),
+	}).Error)
+}
+
+func (r *Repository) LockThread(ctx context.Context, threadID string, actorID string, now time.Time) error {
+	if strings.TrimSpace(threadID) == "" {
+		return domainerrors.ErrInvalidRequest
+	}
+	if !canModerate(actorID) {
+		return domainerrors.ErrForbidden
+	}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "thread_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"locked_by", "locked_at"}),
+	}).Create(&threadLockModel{
+		ThreadID: threadID,
+		LockedBy: actorID,
+		LockedAt: now.UTC(),
+	}).Error
+}
+
+// UpdateModerators replaces a server's moderator set atomically.
+func (r *Repository) UpdateModerators(
+	ctx context.Context,
+	serverID string,
+	actorID string,
+	moderatorIDs []string,
+	now time.Time,
+) (ports.ModeratorSet, error) {
+	if !canModerate(actorID) {
+		return ports.ModeratorSet{}, domainerrors.ErrForbidden
+	}
+	set := ports.ModeratorSet{
+		ServerID:     serverID,
+		ModeratorIDs: dedupeList(moderatorIDs),
+		UpdatedBy:    actorID,
+		UpdatedAt:    now.UTC(),
+	}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("server_id = ?", serverID).Delete(&moderatorModel{}).Error; err != nil {
+			return err
+		}
+		if len(set.ModeratorIDs) == 0 {
+			return nil
+		}
+		rows := make([]moderatorModel, 0, len(set.ModeratorIDs))
+		for _, moderatorID := range set.ModeratorIDs {
+			rows = append(rows, moderatorModel{
+				ServerID:  serverID,
+				UserID:    moderatorID,
+				UpdatedBy: actorID,
+				UpdatedAt: set.UpdatedAt,
+			})
+		}
+		return mapWriteError(tx.Create(&rows).Error)
+	})
+	if err != nil {
+		return ports.ModeratorSet{}, err
+	}
+	return set, nil
+}
+
+// MuteUser keeps a single current mute per (user, server); a new mute replaces the old one.
+func (r *Repository) MuteUser(
+	ctx context.Context,
+	targetUserID string,
+	serverID string,
+	actorID string,
+	duration time.Duration,
+	reason string,
+	now time.Time,
+) (ports.MuteRecord, error) {
+	if !canModerate(actorID) {
+		return ports.MuteRecord{}, domainerrors.ErrForbidden
+	}
+	var mutedUntil *time.Time
+	if duration > 0 {
+		ts := now.UTC().Add(duration)
+		mutedUntil = &ts
+	}
+	row := muteModel{
+		MuteID:        "mute_" + uuid.NewString(),
+		UserID:        targetUserID,
+		ServerID:      serverID,
+		MutedByUserID: actorID,
+		MutedUntil:    mutedUntil,
+		Reason:        strings.TrimSpace(reason),
+		CreatedAt:     now.UTC(),
+	}
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "server_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"mute_id", "muted_by_user_id", "muted_until", "reason", "created_at"}),
+	}).Create(&row).Error; err != nil {
+		return ports.MuteRecord{}, err
+	}
+	return row.toPort(), nil
+}
+
+// AddAttachment stores the attachment row and its content together; the scan
+// worker reads the content back from chat_attachment_contents.
+func (r *Repository) AddAttachment(ctx context.Context, input ports.AddAttachmentInput, now time.Time) (ports.Attachment, error) {
+	if len(input.Content) > maxAttachmentSize {
+		return ports.Attachment{}, domainerrors.ErrInvalidRequest
+	}
+	filename := strings.TrimSpace(input.Filename)
+	row := attachmentModel{
+		AttachmentID: "att_" + uuid.NewString(),
+		MessageID:    input.MessageID,
+		UserID:       input.UserID,
+		Filename:     filename,
+		FileSize:     int64(len(input.Content)),
+		MimeType:     strings.TrimSpace(input.MimeType),
+		URL:          "https://cdn.viralforge.local/chat/" + input.MessageID + "/" + filename,
+		ScanResult:   ports.ScanResultPending,
+		CreatedAt:    now.UTC(),
+	}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := requireMessage(tx, input.MessageID); err != nil {
+			return err
+		}
+		if err := tx.Create(&row).Error; err != nil {
+			return mapWriteError(err)
+		}
+		return tx.Create(&attachmentContentModel{
+			AttachmentID: row.AttachmentID,
+			Content:      input.Content,
+		}).Error
+	})
+	if err != nil {
+		return ports.Attachment{}, err
+	}
+	return row.toPort(), nil
+}
+
+func (r *Repository) GetAttachment(ctx context.Context, messageID string, attachmentID string) (ports.Attachment, error) {
+	var row attachmentModel
+	err := r.db.WithContext(ctx).
+		Where("attachment_id = ? AND message_id = ?", attachmentID, messageID).
+		First(&row).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ports.Attachment{}, domainerrors.ErrAttachmentNotFound
+		}
+		return ports.Attachment{}, err
+	}
+	return row.toPort(), nil
+}
+
+// ListPendingAttachmentScans loads attachments awaiting a scan, oldest first.
+func (r *Repository) ListPendingAttachmentScans(ctx context.Context, limit int) ([]ports.Attachment, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	var rows []attachmentModel
+	if err := r.db.WithContext(ctx).
+		Where("scan_result = ?", ports.ScanResultPending).
+		Order("created_at ASC, attachment_id ASC").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	items := make([]ports.Attachment, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, row.toPort())
+	}
+	return items, nil
+}
+
+func (r *Repository) OpenAttachmentContent(ctx context.Context, attachmentID string) (io.ReadCloser, error) {
+	var row attachmentContentModel
+	err := r.db.WithContext(ctx).
+		Where("attachment_id = ?", attachmentID).
+		First(&row).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(row.Content)), nil
+}
+
+// UpdateAttachmentScan applies a scan outcome to a pending attachment. The row
+// is locked so two workers cannot both settle the same attachment.
+func (r *Repository) UpdateAttachmentScan(
+	ctx context.Context,
+	update ports.AttachmentScanUpdate,
+	now time.Time,
+) (ports.Attachment, error) {
+	var row attachmentModel
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("attachment_id = ?", update.AttachmentID).
+			First(&row).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domainerrors.ErrAttachmentNotFound
+			}
+			return err
+		}
+		if row.ScanResult != ports.ScanResultPending {
+			return domainerrors.ErrConflict
+		}
+		updates := map[string]any{"scan_attempts": update.Attempts}
+		row.ScanAttempts = update.Attempts
+		if update.ScanResult != ports.ScanResultPending {
+			ts := now.UTC()
+			updates["scan_result"] = update.ScanResult
+			updates["scan_signature"] = update.Signature
+			updates["scanned_at"] = ts
+			row.ScanResult = update.ScanResult
+			row.ScanSignature = update.Signature
+			row.ScannedAt = &ts
+			if update.ScanResult == ports.ScanResultInfected {
+				updates["quarantined_at"] = ts
+				row.QuarantinedAt = &ts
+			}
+		}
+		return tx.Model(&attachmentModel{}).
+			Where("attachment_id = ?", update.AttachmentID).
+			Updates(updates).Error
+	})
+	if err != nil {
+		return ports.Attachment{}, err
+	}
+	return row.toPort(), nil
+}
+
+// ExportMessages pages a server or channel oldest first with a (created_at, message_id) keyset.
+func (r *Repository) ExportMessages(ctx context.Context, input ports.ExportMessagesInput) (ports.ExportPage, error) {
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 1000
+	}
+	db := r.db.WithContext(ctx)
+	query := db.Model(&messageModel{})
+	if input.ServerID != "" {
+		query = query.Where("server_id = ?", input.ServerID)
+	}
+	if input.ChannelID != "" {
+		query = query.Where("channel_id = ?", input.ChannelID)
+	}
+	if input.AfterMessageID != "" {
+		var cursor messageModel
+		if err := db.Select("message_id", "created_at").
+			Where("message_id = ?", input.AfterMessageID).
+			First(&cursor).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ports.ExportPage{}, domainerrors.ErrInvalidRequest
+			}
+			return ports.ExportPage{}, err
+		}
+		query = query.Where("(created_at, message_id) > (?, ?)", cursor.CreatedAt, cursor.MessageID)
+	}
+
+	var rows []messageModel
+	if err := query.Order("created_at ASC, message_id ASC").Limit(limit + 1).Find(&rows).Error; err != nil {
+		return ports.ExportPage{}, err
+	}
+	hasMore := len(rows) > limit
+	if hasMore {
+		rows = rows[:limit]
+	}
+	messages, err := toMessages(db, rows)
+	if err != nil {
+		return ports.ExportPage{}, err
+	}
+	page := ports.ExportPage{Messages: messages}
+	if hasMore && len(messages) > 0 {
+		page.NextCursor = messages[len(messages)-1].MessageID
+	}
+	return page, nil
+}
+
+// Get loads an idempotency record and evicts expired entries.
+func (r *Repository) Get(ctx context.Context, key string, now time.Time) (ports.IdempotencyRecord, bool, error) {
+	var row idempotencyModel
+	err := r.db.WithContext(ctx).Where("key = ?", key).First(&row).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ports.IdempotencyRecord{}, false, nil
+		}
+		return ports.IdempotencyRecord{}, false, err
+	}
+	if !row.ExpiresAt.After(now.UTC()) {
+		if err := r.db.WithContext(ctx).Where("key = ?", key).Delete(&idempotencyModel{}).Error; err != nil {
+			return ports.IdempotencyRecord{}, false, err
+		}
+		return ports.IdempotencyRecord{}, false, nil
+	}
+	return row.toPort(), true, nil
+}
+
+// Put inserts a new idempotency record and checks request-hash collisions.
+func (r *Repository) Put(ctx context.Context, record ports.IdempotencyRecord) error {
+	row := idempotencyModel{
+		Key:         record.Key,
+		RequestHash: record.RequestHash,
+		Payload:     append([]byte(nil), record.Payload...),
+		ExpiresAt:   record.ExpiresAt.UTC(),
+		CreatedAt:   time.Now().UTC(),
+	}
+	createResult := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "key"}},
+			DoNothing: true,
+		}).
+		Create(&row)
+	if createResult.Error != nil {
+		return createResult.Error
+	}
+	if createResult.RowsAffected > 0 {
+		return nil
+	}
+
+	var existing idempotencyModel
+	if err := r.db.WithContext(ctx).Where("key = ?", row.Key).First(&existing).Error; err != nil {
+		return err
+	}
+	if existing.RequestHash != row.RequestHash {
+		return domainerrors.ErrIdempotencyConflict
+	}
+	return nil
+}
+
+// AppendOutbox stores an event for relay; re-appending the same event ID is a no-op.
+func (r *Repository) AppendOutbox(ctx context.Context, envelope ports.EventEnvelope) error {
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+	row := outboxModel{
+		OutboxID:     strings.TrimSpace(envelope.EventID),
+		EventType:    strings.TrimSpace(envelope.EventType),
+		PartitionKey: strings.TrimSpace(envelope.PartitionKey),
+		Payload:      payload,
+		Status:       outboxStatusPending,
+		CreatedAt:    envelope.OccurredAt.UTC(),
+	}
+	if row.OutboxID == "" {
+		row.OutboxID = uuid.NewString()
+	}
+	if row.CreatedAt.IsZero() {
+		row.CreatedAt = time.Now().UTC()
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "outbox_id"}},
+			DoNothing: true,
+		}).
+		Create(&row).Error
+}
+
+// ListPendingOutbox loads unsent outbox rows oldest-first.
+func (r *Repository) ListPendingOutbox(ctx context.Context, limit int) ([]ports.OutboxMessage, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	var rows []outboxModel
+	if err := r.db.WithContext(ctx).
+		Where("status = ?", outboxStatusPending).
+		Order("created_at ASC, seq ASC").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	items := make([]ports.OutboxMessage, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, ports.OutboxMessage{
+			OutboxID:     row.OutboxID,
+			EventType:    row.EventType,
+			PartitionKey: row.PartitionKey,
+			Payload:      append([]byte(nil), row.Payload...),
+			CreatedAt:    row.CreatedAt.UTC(),
+		})
+	}
+	return items, nil
+}
+
+// MarkOutboxPublished marks one outbox row as published.
+func (r *Repository) MarkOutboxPublished(ctx context.Context, outboxID string, publishedAt time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&outboxModel{}).
+		Where("outbox_id = ?", strings.TrimSpace(outboxID)).
+		Updates(map[string]any{
+			"status":       outboxStatusPublished,
+			"published_at": publishedAt.UTC(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.ErrNotFound
+	}
+	return nil
+}
+
+func lockMessage(tx *gorm.DB, messageID string, row *messageModel) error {
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("message_id = ?", messageID).
+		First(row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domainerrors.ErrMessageNotFound
+	}
+	return err
+}
+
+func requireMessage(db *gorm.DB, messageID string) error {
+	var count int64
+	if err := db.Model(&messageModel{}).Where("message_id = ?", messageID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return domainerrors.ErrMessageNotFound
+	}
+	return nil
+}
+
+// cursorSequence resolves a message-ID cursor to its channel sequence. Cursors
+// from another channel are rejected rather than silently re-anchored.
+func cursorSequence(db *gorm.DB, channelID string, messageID string) (int64, error) {
+	var row messageModel
+	err := db.Select("sequence_number").
+		Where("message_id = ? AND channel_id = ?", messageID, channelID).
+		First(&row).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, domainerrors.ErrInvalidRequest
+		}
+		return 0, err
+	}
+	return row.SequenceNumber, nil
+}
+
+func toMessages(db *gorm.DB, rows []messageModel) ([]ports.Message, error) {
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.MessageID)
+	}
+	counters, err := loadReactionCounters(db, ids)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]ports.Message, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, row.toPort(counters[row.MessageID]))
+	}
+	return items, nil
+}
+
+func loadReactionCounters(db *gorm.DB, messageIDs []string) (map[string]map[string]int, error) {
+	out := make(map[string]map[string]int, len(messageIDs))
+	if len(messageIDs) == 0 {
+		return out, nil
+	}
+	type counterRow struct {
+		MessageID string `gorm:"column:message_id"`
+		Emoji     string `gorm:"column:emoji"`
+		Total     int    `gorm:"column:total"`
+	}
+	var rows []counterRow
+	if err := db.Model(&reactionModel{}).
+		Select("message_id, emoji, COUNT(*) AS total").
+		Where("message_id IN ?", messageIDs).
+		Group("message_id, emoji").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if _, ok := out[row.MessageID]; !ok {
+			out[row.MessageID] = make(map[string]int)
+		}
+		out[row.MessageID][row.Emoji] = row.Total
+	}
+	for _, id := range messageIDs {
+		if _, ok := out[id]; !ok {
+			out[id] = map[string]int{}
+		}
+	}
+	return out, nil
+}
+
+type messageModel struct {
+	MessageID       string     `gorm:"column:message_id;primaryKey"`
+	ServerID        string     `gorm:"column:server_id"`
+	ChannelID       string     `gorm:"column:channel_id"`
+	ThreadID        string     `gorm:"column:thread_id"`
+	UserID          string     `gorm:"column:user_id"`
+	Username        string     `gorm:"column:username"`
+	Content         string     `gorm:"column:content"`
+	SequenceNumber  int64      `gorm:"column:sequence_number"`
+	Mentions        []byte     `gorm:"column:mentions"`
+	Embeds          []byte     `gorm:"column:embeds"`
+	EmbedsPending   bool       `gorm:"column:embeds_pending"`
+	Edited          bool       `gorm:"column:edited"`
+	CreatedAt       time.Time  `gorm:"column:created_at"`
+	UpdatedAt       time.Time  `gorm:"column:updated_at"`
+	DeletedAt       *time.Time `gorm:"column:deleted_at"`
+	DeletedByUserID string     `gorm:"column:deleted_by_user_id"`
+	DeletionReason  string     `gorm:"column:deletion_reason"`
+}
+
+func (messageModel) TableName() string {
+	return "chat_messages"
+}
+
+func (m messageModel) toPort(counters map[string]int) ports.Message {
+	mentions := []ports.Mention{}
+	if len(m.Mentions) > 0 {
+		_ = json.Unmarshal(m.Mentions, &mentions)
+	}
+	embeds := []ports.Embed{}
+	if len(m.Embeds) > 0 {
+		_ = json.Unmarshal(m.Embeds, &embeds)
+	}
+	if counters == nil {
+		counters = map[string]int{}
+	}
+	var deletedAt *time.Time
+	if m.DeletedAt != nil {
+		ts := m.DeletedAt.UTC()
+		deletedAt = &ts
+	}
+	return ports.Message{
+		MessageID:        m.MessageID,
+		ServerID:         m.ServerID,
+		ChannelID:        m.ChannelID,
+		ThreadID:         m.ThreadID,
+		UserID:           m.UserID,
+		Username:         m.Username,
+		Content:          m.Content,
+		SequenceNumber:   m.SequenceNumber,
+		CreatedAt:        m.CreatedAt.UTC(),
+		UpdatedAt:        m.UpdatedAt.UTC(),
+		Edited:           m.Edited,
+		DeletedAt:        deletedAt,
+		DeletedByUserID:  m.DeletedByUserID,
+		DeletionReason:   m.DeletionReason,
+		Mentions:         mentions,
+		Embeds:           embeds,
+		EmbedsPending:    m.EmbedsPending,
+		ReactionCounters: counters,
+	}
+}
+
+type messageEditModel struct {
+	EditID          string    `gorm:"column:edit_id;primaryKey"`
+	MessageID       string    `gorm:"column:message_id"`
+	EditorID        string    `gorm:"column:editor_id"`
+	PreviousContent string    `gorm:"column:previous_content"`
+	EditedAt        time.Time `gorm:"column:edited_at"`
+}
+
+func (messageEditModel) TableName() string {
+	return "chat_message_edits"
+}
+
+type reactionModel struct {
+	ReactionID string    `gorm:"column:reaction_id;primaryKey"`
+	MessageID  string    `gorm:"column:message_id"`
+	UserID     string    `gorm:"column:user_id"`
+	Emoji      string    `gorm:"column:emoji"`
+	CreatedAt  time.Time `gorm:"column:created_at"`
+}
+
+func (reactionModel) TableName() string {
+	return "chat_reactions"
+}
+
+func (m reactionModel) toPort() ports.Reaction {
+	return ports.Reaction{
+		ReactionID: m.ReactionID,
+		MessageID:  m.MessageID,
+		UserID:     m.UserID,
+		Emoji:      m.Emoji,
+		CreatedAt:  m.CreatedAt.UTC(),
+	}
+}
+
+type readStateModel struct {
+	ReadStateID       string    `gorm:"column:read_state_id;primaryKey"`
+	UserID            string    `gorm:"column:user_id"`
+	ChannelID         string    `gorm:"column:channel_id"`
+	LastReadMessageID string    `gorm:"column:last_read_message_id"`
+	LastReadAt        time.Time `gorm:"column:last_read_at"`
+}
+
+func (readStateModel) TableName() string {
+	return "chat_read_states"
+}
+
+func (m readStateModel) toPort() ports.ReadState {
+	return ports.ReadState{
+		ReadStateID:       m.ReadStateID,
+		UserID:            m.UserID,
+		ChannelID:         m.ChannelID,
+		LastReadMessageID: m.LastReadMessageID,
+		LastReadAt:        m.LastReadAt.UTC(),
+	}
+}
+
+type muteModel struct {
+	MuteID        string     `gorm:"column:mute_id;primaryKey"`
+	UserID        string     `gorm:"column:user_id"`
+	ServerID      string     `gorm:"column:server_id"`
+	MutedByUserID string     `gorm:"column:muted_by_user_id"`
+	MutedUntil    *time.Time `gorm:"column:muted_until"`
+	Reason        string     `gorm:"column:reason"`
+	CreatedAt     time.Time  `gorm:"column:created_at"`
+}
+
+func (muteModel) TableName() string {
+	return "chat_mutes"
+}
+
+func (m muteModel) toPort() ports.MuteRecord {
+	return ports.MuteRecord{
+		MuteID:        m.MuteID,
+		UserID:        m.UserID,
+		ServerID:      m.ServerID,
+		MutedByUserID: m.MutedByUserID,
+		MutedUntil:    m.MutedUntil,
+		Reason:        m.Reason,
+		CreatedAt:     m.CreatedAt.UTC(),
+	}
+}
+
+type moderatorModel struct {
+	ServerID  string    `gorm:"column:server_id;primaryKey"`
+	UserID    string    `gorm:"column:user_id;primaryKey"`
+	UpdatedBy string    `gorm:"column:updated_by"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+}
+
+func (moderatorModel) TableName() string {
+	return "chat_server_moderators"
+}
+
+type pinModel struct {
+	MessageID string    `gorm:"column:message_id;primaryKey"`
+	PinnedBy  string    `gorm:"column:pinned_by"`
+	Reason    string    `gorm:"column:reason"`
+	PinnedAt  time.Time `gorm:"column:pinned_at"`
+}
+
+func (pinModel) TableName() string {
+	return "chat_pins"
+}
+
+type reportModel struct {
+	ReportID    string    `gorm:"column:report_id;primaryKey"`
+	MessageID   string    `gorm:"column:message_id"`
+	ReporterID  string    `gorm:"column:reporter_id"`
+	Reason      string    `gorm:"column:reason"`
+	Description string    `gorm:"column:description"`
+	ReportedAt  time.Time `gorm:"column:reported_at"`
+}
+
+func (reportModel) TableName() string {
+	return "chat_reports"
+}
+
+type threadLockModel struct {
+	ThreadID string    `gorm:"column:thread_id;primaryKey"`
+	LockedBy string    `gorm:"column:locked_by"`
+	LockedAt time.Time `gorm:"column:locked_at"`
+}
+
+func (threadLockModel) TableName() string {
+	return "chat_thread_locks"
+}
+
+type attachmentModel struct {
+	AttachmentID  string     `gorm:"column:attachment_id;primaryKey"`
+	MessageID     string     `gorm:"column:message_id"`
+	UserID        string     `gorm:"column:user_id"`
+	Filename      string     `gorm:"column:filename"`
+	FileSize      int64      `gorm:"column:file_size"`
+	MimeType      string     `gorm:"column:mime_type"`
+	URL           string     `gorm:"column:url"`
+	ScanResult    string     `gorm:"column:scan_result"`
+	ScanSignature string     `gorm:"column:scan_signature"`
+	ScanAttempts  int        `gorm:"column:scan_attempts"`
+	ScannedAt     *time.Time `gorm:"column:scanned_at"`
+	QuarantinedAt *time.Time `gorm:"column:quarantined_at"`
+	CreatedAt     time.Time  `gorm:"column:created_at"`
+}
+
+func (attachmentModel) TableName() string {
+	return "chat_attachments"
+}
+
+func (m attachmentModel) toPort() ports.Attachment {
+	return ports.Attachment{
+		AttachmentID:  m.AttachmentID,
+		MessageID:     m.MessageID,
+		UserID:        m.UserID,
+		Filename:      m.Filename,
+		FileSize:      m.FileSize,
+		MimeType:      m.MimeType,
+		URL:           m.URL,
+		ScanResult:    m.ScanResult,
+		ScanSignature: m.ScanSignature,
+		ScanAttempts:  m.ScanAttempts,
+		ScannedAt:     utcPtr(m.ScannedAt),
+		QuarantinedAt: utcPtr(m.QuarantinedAt),
+		CreatedAt:     m.CreatedAt.UTC(),
+	}
+}
+
+type attachmentContentModel struct {
+	AttachmentID string `gorm:"column:attachment_id;primaryKey"`
+	Content      []byte `gorm:"column:content"`
+}
+
+func (attachmentContentModel) TableName() string {
+	return "chat_attachment_contents"
+}
+
+type idempotencyModel struct {
+	Key         string    `gorm:"column:key;primaryKey"`
+	RequestHash string    `gorm:"column:request_hash"`
+	Payload     []byte    `gorm:"column:payload"`
+	ExpiresAt   time.Time `gorm:"column:expires_at"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+}
+
+func (idempotencyModel) TableName() string {
+	return "chat_idempotency"
+}
+
+func (m idempotencyModel) toPort() ports.IdempotencyRecord {
+	return ports.IdempotencyRecord{
+		Key:         m.Key,
+		RequestHash: m.RequestHash,
+		Payload:     append([]byte(nil), m.Payload...),
+		ExpiresAt:   m.ExpiresAt.UTC(),
+	}
+}
+
+type outboxModel struct {
+	OutboxID     string     `gorm:"column:outbox_id;primaryKey"`
+	Seq          int64      `gorm:"column:seq;->"`
+	EventType    string     `gorm:"column:event_type"`
+	PartitionKey string     `gorm:"column:partition_key"`
+	Payload      []byte     `gorm:"column:payload"`
+	Status       string     `gorm:"column:status"`
+	CreatedAt    time.Time  `gorm:"column:created_at"`
+	PublishedAt  *time.Time `gorm:"column:published_at"`
+}
+
+func (outboxModel) TableName() string {
+	return "chat_outbox"
+}
+
+func mapWriteError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23503":
+			return domainerrors.ErrMessageNotFound
+		case "23505":
+			return domainerrors.ErrConflict
+		}
+	}
+	return err
+}
+
+func canModerate(userID string) bool {
+	normalized := strings.ToLower(strings.TrimSpace(userID))
+	return strings.HasPrefix(normalized, "mod_") ||
+		strings.HasPrefix(normalized, "creator_") ||
+		strings.HasPrefix(normalized, "admin_")
+}
+
+func parseMentions(content string) []ports.Mention {
+	out := make([]ports.Mention, 0)
+	for _, word := range strings.Fields(content) {
+		if len(word) < 2 || word[0] != '@' {
+			continue
+		}
+		username := strings.Trim(word[1:], ".,!?;:")
+		if username == "" {
+			continue
+		}
+		out = append(out, ports.Mention{UserID: username, Username: username})
+	}
+	return out
+}
+
+// containsLink reports whether content has a link for the unfurler.
+func containsLink(content string) bool {
+	for _, word := range strings.Fields(content) {
+		candidate := strings.Trim(word, ".,!?;:()<>\"'")
+		if strings.HasPrefix(candidate, "http://") || strings.HasPrefix(candidate, "https://") {
+			return true
+		}
+	}
+	return false
+}
+
+func dedupeList(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	out := make([]string, 0, len(values))
+	for _, value := range values {
+		normalized := strings.TrimSpace(value)
+		if normalized == "" {
+			continue
+		}
+		if _, ok := seen[normalized]; ok {
+			continue
+		}
+		seen[normalized] = struct{}{}
+		out = append(out, normalized)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+func defaultString(value string, fallback string) string {
+	if strings.TrimSpace(value) == "" {
+		return fallback
+	}
+	return value
+}
+
+var _ ports.Repository = (*Repository)(nil)
+var _ ports.IdempotencyStore = (*Repository)(nil)
+
+func utcPtr(ts *time.Time) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	value := ts.UTC()
+	return &value
+}
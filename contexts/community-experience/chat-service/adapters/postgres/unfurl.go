@@ -0,0 +1,130 @@
+package postgresadapter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	domainerrors "solomon/contexts/community-experience/chat-service/domain/errors"
+	"solomon/contexts/community-experience/chat-service/ports"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ListPendingUnfurls loads live messages whose links await unfurling, oldest
+// first.
+func (r *Repository) ListPendingUnfurls(ctx context.Context, limit int) ([]ports.Message, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	db := r.db.WithContext(ctx)
+	var rows []messageModel
+	if err := db.
+		Where("embeds_pending = TRUE AND deleted_at IS NULL").
+		Order("created_at ASC, message_id ASC").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return toMessages(db, rows)
+}
+
+// SetMessageEmbeds stores unfurled embeds unless the message changed after
+// the unfurler read it; an edit re-queues the message with its new links.
+func (r *Repository) SetMessageEmbeds(ctx context.Context, update ports.MessageEmbedsUpdate, now time.Time) (ports.Message, error) {
+	embeds, err := json.Marshal(update.Embeds)
+	if err != nil {
+		return ports.Message{}, err
+	}
+	var row messageModel
+	var counters map[string]int
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockMessage(tx, update.MessageID, &row); err != nil {
+			return err
+		}
+		if row.DeletedAt != nil || !row.UpdatedAt.Equal(update.ContentVersion) {
+			return domainerrors.ErrConflict
+		}
+		row.Embeds = embeds
+		row.EmbedsPending = false
+		if err := tx.Model(&messageModel{}).
+			Where("message_id = ?", row.MessageID).
+			Updates(map[string]any{
+				"embeds":         row.Embeds,
+				"embeds_pending": false,
+			}).Error; err != nil {
+			return err
+		}
+		byMessage, err := loadReactionCounters(tx, []string{row.MessageID})
+		if err != nil {
+			return err
+		}
+		counters = byMessage[row.MessageID]
+		return nil
+	})
+	if err != nil {
+		return ports.Message{}, err
+	}
+	return row.toPort(counters), nil
+}
+
+func (r *Repository) GetLinkPreview(ctx context.Context, url string, now time.Time) (ports.LinkPreview, bool, error) {
+	var row linkPreviewModel
+	err := r.db.WithContext(ctx).
+		Where("url = ? AND expires_at > ?", url, now.UTC()).
+		First(&row).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ports.LinkPreview{}, false, nil
+		}
+		return ports.LinkPreview{}, false, err
+	}
+	return row.toPort(), true, nil
+}
+
+func (r *Repository) PutLinkPreview(ctx context.Context, preview ports.LinkPreview) error {
+	row := linkPreviewModel{
+		URL:         preview.URL,
+		Title:       preview.Embed.Title,
+		Description: preview.Embed.Description,
+		ImageURL:    preview.Embed.ImageURL,
+		Failed:      preview.Failed,
+		FetchedAt:   preview.FetchedAt.UTC(),
+		ExpiresAt:   preview.ExpiresAt.UTC(),
+	}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "url"}},
+		DoUpdates: clause.AssignmentColumns([]string{"title", "description", "image_url", "failed", "fetched_at", "expires_at"}),
+	}).Create(&row).Error
+}
+
+type linkPreviewModel struct {
+	URL         string    `gorm:"column:url;primaryKey"`
+	Title       string    `gorm:"column:title"`
+	Description string    `gorm:"column:description"`
+	ImageURL    string    `gorm:"column:image_url"`
+	Failed      bool      `gorm:"column:failed"`
+	FetchedAt   time.Time `gorm:"column:fetched_at"`
+	ExpiresAt   time.Time `gorm:"column:expires_at"`
+}
+
+func (linkPreviewModel) TableName() string {
+	return "chat_link_previews"
+}
+
+func (m linkPreviewModel) toPort() ports.LinkPreview {
+	return ports.LinkPreview{
+		URL: m.URL,
+		Embed: ports.Embed{
+			URL:         m.URL,
+			Title:       m.Title,
+			Description: m.Description,
+			ImageURL:    m.ImageURL,
+		},
+		Failed:    m.Failed,
+		FetchedAt: m.FetchedAt.UTC(),
+		ExpiresAt: m.ExpiresAt.UTC(),
+	}
+}
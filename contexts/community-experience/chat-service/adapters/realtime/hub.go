@@ -0,0 +1,111 @@
+package realtime
+
+import (
+	"context"
+	"sync"
+
+	"solomon/contexts/community-experience/chat-service/ports"
+)
+
+const defaultSubscriberBuffer = 64
+
+// Hub fans realtime events out to the channel subscribers connected to this
+// instance. A subscriber whose buffer fills is disconnected instead of
+// blocking publishers; it resumes from its last sequence number on reconnect.
+type Hub struct {
+	mu          sync.Mutex
+	subscribers map[string]map[*subscriber]struct{}
+	buffer      int
+}
+
+type subscriber struct {
+	events chan ports.RealtimeEvent
+	closed bool
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		subscribers: make(map[string]map[*subscriber]struct{}),
+		buffer:      defaultSubscriberBuffer,
+	}
+}
+
+func (h *Hub) SubscribeChannel(channelID string) (<-chan ports.RealtimeEvent, func()) {
+	sub := &subscriber{events: make(chan ports.RealtimeEvent, h.buffer)}
+
+	h.mu.Lock()
+	if _, ok := h.subscribers[channelID]; !ok {
+		h.subscribers[channelID] = make(map[*subscriber]struct{})
+	}
+	h.subscribers[channelID][sub] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			h.removeLocked(channelID, sub)
+		})
+	}
+	return sub.events, cancel
+}
+
+func (h *Hub) PublishRealtime(ctx context.Context, event ports.RealtimeEvent) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subscribers[event.ChannelID] {
+		select {
+		case sub.events <- cloneEvent(event):
+		default:
+			h.removeLocked(event.ChannelID, sub)
+		}
+	}
+	return nil
+}
+
+// Subscribers reports how many local listeners a channel has.
+func (h *Hub) Subscribers(channelID string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subscribers[channelID])
+}
+
+func (h *Hub) removeLocked(channelID string, sub *subscriber) {
+	if sub.closed {
+		return
+	}
+	sub.closed = true
+	close(sub.events)
+	delete(h.subscribers[channelID], sub)
+	if len(h.subscribers[channelID]) == 0 {
+		delete(h.subscribers, channelID)
+	}
+}
+
+func cloneEvent(in ports.RealtimeEvent) ports.RealtimeEvent {
+	out := in
+	if in.Message != nil {
+		message := *in.Message
+		message.Mentions = append([]ports.Mention(nil), in.Message.Mentions...)
+		message.Embeds = append([]ports.Embed(nil), in.Message.Embeds...)
+		message.ReactionCounters = cloneCounters(in.Message.ReactionCounters)
+		out.Message = &message
+	}
+	if in.Reactions != nil {
+		out.Reactions = cloneCounters(in.Reactions)
+	}
+	return out
+}
+
+func cloneCounters(in map[string]int) map[string]int {
+	out := make(map[string]int, len(in))
+	for key, value := range in {
+		out[key] = value
+	}
+	return out
+}
+
+var _ ports.RealtimePublisher = (*Hub)(nil)
+var _ ports.RealtimeSubscriber = (*Hub)(nil)
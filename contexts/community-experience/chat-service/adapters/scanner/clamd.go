@@ -0,0 +1,117 @@
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"solomon/contexts/community-experience/chat-service/ports"
+)
+
+const (
+	defaultClamdTimeout   = 30 * time.Second
+	defaultClamdChunkSize = 64 * 1024
+)
+
+// ClamdScanner scans attachments with a clamd daemon using the INSTREAM
+// command: content is streamed as length-prefixed chunks and clamd replies
+// "stream: OK" or "stream: <signature> FOUND".
+type ClamdScanner struct {
+	// Network is "tcp" (default) or "unix".
+	Network   string
+	Address   string
+	Timeout   time.Duration
+	ChunkSize int
+}
+
+func (c ClamdScanner) ScanAttachment(
+	ctx context.Context,
+	attachment ports.Attachment,
+	content io.Reader,
+) (ports.ScanVerdict, error) {
+	if strings.TrimSpace(c.Address) == "" {
+		return ports.ScanVerdict{}, errors.New("clamd address is not configured")
+	}
+	network := c.Network
+	if network == "" {
+		network = "tcp"
+	}
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = defaultClamdTimeout
+	}
+	chunkSize := c.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = defaultClamdChunkSize
+	}
+
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, network, c.Address)
+	if err != nil {
+		return ports.ScanVerdict{}, fmt.Errorf("clamd dial: %w", err)
+	}
+	defer conn.Close()
+	deadline := time.Now().Add(timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return ports.ScanVerdict{}, err
+	}
+
+	if _, err := io.WriteString(conn, "zINSTREAM\x00"); err != nil {
+		return ports.ScanVerdict{}, fmt.Errorf("clamd write: %w", err)
+	}
+	buf := make([]byte, chunkSize)
+	var size [4]byte
+	for {
+		n, readErr := content.Read(buf)
+		if n > 0 {
+			binary.BigEndian.PutUint32(size[:], uint32(n))
+			if _, err := conn.Write(size[:]); err != nil {
+				return ports.ScanVerdict{}, fmt.Errorf("clamd write: %w", err)
+			}
+			if _, err := conn.Write(buf[:n]); err != nil {
+				return ports.ScanVerdict{}, fmt.Errorf("clamd write: %w", err)
+			}
+		}
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			return ports.ScanVerdict{}, readErr
+		}
+	}
+	binary.BigEndian.PutUint32(size[:], 0)
+	if _, err := conn.Write(size[:]); err != nil {
+		return ports.ScanVerdict{}, fmt.Errorf("clamd write: %w", err)
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return ports.ScanVerdict{}, fmt.Errorf("clamd read: %w", err)
+	}
+	return parseClamdReply(reply)
+}
+
+func parseClamdReply(reply string) (ports.ScanVerdict, error) {
+	reply = strings.TrimSpace(strings.TrimRight(reply, "\x00"))
+	result := strings.TrimSpace(strings.TrimPrefix(reply, "stream:"))
+	switch {
+	case result == "OK":
+		return ports.ScanVerdict{}, nil
+	case strings.HasSuffix(result, " FOUND"):
+		return ports.ScanVerdict{
+			Infected:  true,
+			Signature: strings.TrimSpace(strings.TrimSuffix(result, " FOUND")),
+		}, nil
+	default:
+		return ports.ScanVerdict{}, fmt.Errorf("clamd: unexpected reply %q", reply)
+	}
+}
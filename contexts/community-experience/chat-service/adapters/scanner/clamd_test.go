@@ -0,0 +1,121 @@
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"solomon/contexts/community-experience/chat-service/ports"
+)
+
+// fakeClamd accepts INSTREAM sessions, reassembles the streamed chunks and
+// answers with reply(content).
+func fakeClamd(t *testing.T, reply func(content []byte) string) (string, <-chan []byte) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	received := make(chan []byte, 4)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				command, err := reader.ReadString(0)
+				if err != nil || command != "zINSTREAM\x00" {
+					io.WriteString(conn, "UNKNOWN COMMAND\x00")
+					return
+				}
+				var content bytes.Buffer
+				for {
+					var size [4]byte
+					if _, err := io.ReadFull(reader, size[:]); err != nil {
+						return
+					}
+					n := binary.BigEndian.Uint32(size[:])
+					if n == 0 {
+						break
+					}
+					if _, err := io.CopyN(&content, reader, int64(n)); err != nil {
+						return
+					}
+				}
+				received <- content.Bytes()
+				io.WriteString(conn, reply(content.Bytes())+"\x00")
+			}(conn)
+		}
+	}()
+	return listener.Addr().String(), received
+}
+
+func clamdReply(content []byte) string {
+	if bytes.Contains(content, []byte("EICAR")) {
+		return "stream: Eicar-Test-Signature FOUND"
+	}
+	return "stream: OK"
+}
+
+func TestClamdScannerStreamsContentInChunks(t *testing.T) {
+	addr, received := fakeClamd(t, clamdReply)
+	scanner := ClamdScanner{Address: addr, Timeout: 2 * time.Second, ChunkSize: 3}
+	content := "hello clamd, this spans several chunks"
+
+	verdict, err := scanner.ScanAttachment(context.Background(), ports.Attachment{}, strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("scan failed: %v", err)
+	}
+	if verdict.Infected {
+		t.Fatalf("expected clean verdict, got %+v", verdict)
+	}
+	if got := string(<-received); got != content {
+		t.Fatalf("clamd received %q, want %q", got, content)
+	}
+}
+
+func TestClamdScannerReportsSignature(t *testing.T) {
+	addr, _ := fakeClamd(t, clamdReply)
+	scanner := ClamdScanner{Address: addr, Timeout: 2 * time.Second}
+
+	verdict, err := scanner.ScanAttachment(context.Background(), ports.Attachment{}, strings.NewReader("X5O!P%@AP EICAR test file"))
+	if err != nil {
+		t.Fatalf("scan failed: %v", err)
+	}
+	if !verdict.Infected || verdict.Signature != "Eicar-Test-Signature" {
+		t.Fatalf("expected infected verdict with signature, got %+v", verdict)
+	}
+}
+
+func TestClamdScannerFailsOnErrorReply(t *testing.T) {
+	addr, _ := fakeClamd(t, func([]byte) string { return "INSTREAM size limit exceeded. ERROR" })
+	scanner := ClamdScanner{Address: addr, Timeout: 2 * time.Second}
+
+	if _, err := scanner.ScanAttachment(context.Background(), ports.Attachment{}, strings.NewReader("data")); err == nil {
+		t.Fatal("expected error for clamd ERROR reply")
+	}
+}
+
+func TestClamdScannerFailsWhenDaemonIsDown(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	scanner := ClamdScanner{Address: addr, Timeout: time.Second}
+	if _, err := scanner.ScanAttachment(context.Background(), ports.Attachment{}, strings.NewReader("data")); err == nil {
+		t.Fatal("expected dial error")
+	}
+}
@@ -0,0 +1,40 @@
+package scanner
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"solomon/contexts/community-experience/chat-service/ports"
+)
+
+const (
+	KindNoop  = "noop"
+	KindClamd = "clamd"
+)
+
+// Options selects and configures an attachment scanner by name.
+type Options struct {
+	Kind string
+	// Network, Address and Timeout configure KindClamd. Address is host:port
+	// for tcp or a socket path for unix.
+	Network string
+	Address string
+	Timeout time.Duration
+}
+
+// New builds the scanner named by opts.Kind. The no-op scanner must be named
+// explicitly; an empty kind is the clamd scanner.
+func New(opts Options) (ports.AttachmentScanner, error) {
+	switch strings.ToLower(strings.TrimSpace(opts.Kind)) {
+	case KindNoop:
+		return NoopScanner{}, nil
+	case "", KindClamd:
+		if strings.TrimSpace(opts.Address) == "" {
+			return nil, fmt.Errorf("clamd scanner requires an address")
+		}
+		return ClamdScanner{Network: opts.Network, Address: opts.Address, Timeout: opts.Timeout}, nil
+	default:
+		return nil, fmt.Errorf("unknown attachment scanner kind %q", opts.Kind)
+	}
+}
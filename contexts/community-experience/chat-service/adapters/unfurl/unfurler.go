@@ -0,0 +1,316 @@
+package unfurl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"solomon/contexts/community-experience/chat-service/ports"
+
+	"golang.org/x/net/html"
+)
+
+const (
+	defaultTimeout      = 5 * time.Second
+	defaultMaxBodyBytes = 512 * 1024
+	defaultMaxRedirects = 3
+	defaultUserAgent    = "SolomonLinkPreview/1.0"
+	maxTitleRunes       = 256
+	maxDescriptionRunes = 1024
+)
+
+// ErrNoPreview is returned when a page has no usable preview metadata.
+var ErrNoPreview = errors.New("unfurl: no preview metadata")
+
+// HTTPUnfurler builds link previews from OpenGraph and Twitter card meta
+// tags, falling back to a page's oEmbed endpoint. Every connection is checked
+// against the SSRF guard, bodies are capped at MaxBodyBytes and the whole
+// fetch, redirects and oEmbed included, is bounded by Timeout.
+type HTTPUnfurler struct {
+	Timeout      time.Duration
+	MaxBodyBytes int64
+	MaxRedirects int
+	UserAgent    string
+	// AllowPrivateNetworks disables the SSRF guard so tests can unfurl a
+	// loopback fixture. Never enable it in production.
+	AllowPrivateNetworks bool
+}
+
+type pageMeta struct {
+	title       string
+	description string
+	image       string
+	oembedURL   string
+}
+
+type oembedResponse struct {
+	Title        string `json:"title"`
+	AuthorName   string `json:"author_name"`
+	ProviderName string `json:"provider_name"`
+	ThumbnailURL string `json:"thumbnail_url"`
+}
+
+func (u HTTPUnfurler) Unfurl(ctx context.Context, rawURL string) (ports.Embed, error) {
+	target, err := parseLink(rawURL)
+	if err != nil {
+		return ports.Embed{}, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, u.timeout())
+	defer cancel()
+	client := u.client()
+
+	body, pageURL, err := u.fetch(ctx, client, target, "text/html,application/xhtml+xml", "text/html", "application/xhtml+xml")
+	if err != nil {
+		return ports.Embed{}, err
+	}
+	meta := parsePageMeta(body, pageURL)
+	if meta.oembedURL != "" && (meta.title == "" || meta.image == "") {
+		// oEmbed only fills gaps, so a failed lookup keeps the page metadata.
+		if oembed, oembedURL, err := u.fetchOEmbed(ctx, client, meta.oembedURL); err == nil {
+			meta.title = firstNonEmpty(meta.title, oembed.Title)
+			meta.description = firstNonEmpty(meta.description, oembed.AuthorName, oembed.ProviderName)
+			meta.image = firstNonEmpty(meta.image, resolveLink(oembedURL, oembed.ThumbnailURL))
+		}
+	}
+	if meta.title == "" && meta.description == "" {
+		return ports.Embed{}, ErrNoPreview
+	}
+	return ports.Embed{
+		URL:         rawURL,
+		Title:       truncateRunes(meta.title, maxTitleRunes),
+		Description: truncateRunes(meta.description, maxDescriptionRunes),
+		ImageURL:    meta.image,
+	}, nil
+}
+
+func (u HTTPUnfurler) fetchOEmbed(ctx context.Context, client *http.Client, rawURL string) (oembedResponse, *url.URL, error) {
+	target, err := parseLink(rawURL)
+	if err != nil {
+		return oembedResponse{}, nil, err
+	}
+	body, finalURL, err := u.fetch(ctx, client, target, "application/json", "application/json", "text/javascript")
+	if err != nil {
+		return oembedResponse{}, nil, err
+	}
+	var out oembedResponse
+	if err := json.Unmarshal(body, &out); err != nil {
+		return oembedResponse{}, nil, err
+	}
+	return out, finalURL, nil
+}
+
+// fetch GETs target and returns at most MaxBodyBytes of a 2xx body whose
+// media type is one of accepted, along with the final URL after redirects.
+func (u HTTPUnfurler) fetch(
+	ctx context.Context,
+	client *http.Client,
+	target *url.URL,
+	accept string,
+	accepted ...string,
+) ([]byte, *url.URL, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", accept)
+	req.Header.Set("User-Agent", firstNonEmpty(u.UserAgent, defaultUserAgent))
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, nil, fmt.Errorf("unfurl: unexpected status %d", resp.StatusCode)
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if !containsString(accepted, mediaType) {
+		return nil, nil, fmt.Errorf("unfurl: unsupported content type %q", mediaType)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, u.maxBodyBytes()))
+	if err != nil {
+		return nil, nil, err
+	}
+	return body, resp.Request.URL, nil
+}
+
+func (u HTTPUnfurler) client() *http.Client {
+	timeout := u.timeout()
+	dialer := &net.Dialer{Timeout: timeout}
+	if !u.AllowPrivateNetworks {
+		dialer.Control = guardDial
+	}
+	maxRedirects := u.MaxRedirects
+	if maxRedirects <= 0 {
+		maxRedirects = defaultMaxRedirects
+	}
+	return &http.Client{
+		Timeout: timeout,
+		// Proxy stays nil: an environment proxy would dial on our behalf and
+		// bypass the address guard.
+		Transport: &http.Transport{
+			DialContext:            dialer.DialContext,
+			TLSHandshakeTimeout:    timeout,
+			ResponseHeaderTimeout:  timeout,
+			MaxResponseHeaderBytes: 64 * 1024,
+			DisableKeepAlives:      true,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > maxRedirects {
+				return fmt.Errorf("unfurl: stopped after %d redirects", maxRedirects)
+			}
+			_, err := parseLink(req.URL.String())
+			return err
+		},
+	}
+}
+
+func (u HTTPUnfurler) timeout() time.Duration {
+	if u.Timeout <= 0 {
+		return defaultTimeout
+	}
+	return u.Timeout
+}
+
+func (u HTTPUnfurler) maxBodyBytes() int64 {
+	if u.MaxBodyBytes <= 0 {
+		return defaultMaxBodyBytes
+	}
+	return u.MaxBodyBytes
+}
+
+// parseLink accepts absolute http(s) URLs without embedded credentials.
+func parseLink(raw string) (*url.URL, error) {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return nil, fmt.Errorf("unfurl: unsupported scheme %q", parsed.Scheme)
+	}
+	if parsed.Hostname() == "" || parsed.User != nil {
+		return nil, fmt.Errorf("unfurl: invalid link")
+	}
+	return parsed, nil
+}
+
+// parsePageMeta reads preview metadata from the document head. OpenGraph
+// tags win over Twitter cards, which win over <title> and the description
+// meta tag.
+func parsePageMeta(body []byte, pageURL *url.URL) pageMeta {
+	values := make(map[string]string)
+	var title strings.Builder
+	inTitle := false
+	oembedHref := ""
+
+	tokenizer := html.NewTokenizer(bytes.NewReader(body))
+head:
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			break head
+		case html.TextToken:
+			if inTitle {
+				title.Write(tokenizer.Text())
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			switch string(name) {
+			case "title":
+				inTitle = false
+			case "head":
+				break head
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tokenizer.TagName()
+			attrs := make(map[string]string)
+			for hasAttr {
+				var key, value []byte
+				key, value, hasAttr = tokenizer.TagAttr()
+				attrs[strings.ToLower(string(key))] = string(value)
+			}
+			switch string(name) {
+			case "body":
+				break head
+			case "title":
+				inTitle = true
+			case "meta":
+				key := strings.ToLower(firstNonEmpty(attrs["property"], attrs["name"]))
+				if _, seen := values[key]; key != "" && !seen {
+					values[key] = attrs["content"]
+				}
+			case "link":
+				if strings.EqualFold(attrs["type"], "application/json+oembed") && oembedHref == "" {
+					oembedHref = attrs["href"]
+				}
+			}
+		}
+	}
+	meta := pageMeta{
+		title:       firstNonEmpty(values["og:title"], values["twitter:title"], title.String()),
+		description: firstNonEmpty(values["og:description"], values["twitter:description"], values["description"]),
+		image: resolveLink(pageURL, firstNonEmpty(
+			values["og:image:secure_url"],
+			values["og:image"],
+			values["og:image:url"],
+			values["twitter:image"],
+		)),
+	}
+	if oembedHref != "" {
+		meta.oembedURL = resolveLink(pageURL, oembedHref)
+	}
+	return meta
+}
+
+// resolveLink resolves ref against base and keeps it only if the result is
+// an http(s) URL.
+func resolveLink(base *url.URL, ref string) string {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return ""
+	}
+	parsed, err := url.Parse(ref)
+	if err != nil {
+		return ""
+	}
+	resolved := base.ResolveReference(parsed)
+	if resolved.Scheme != "http" && resolved.Scheme != "https" {
+		return ""
+	}
+	return resolved.String()
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value = strings.Join(strings.Fields(value), " "); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func truncateRunes(value string, limit int) string {
+	if utf8.RuneCountInString(value) <= limit {
+		return value
+	}
+	runes := []rune(value)
+	return strings.TrimSpace(string(runes[:limit-1])) + "…"
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,268 @@
+package application
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	domainerrors "solomon/contexts/community-experience/chat-service/domain/errors"
+	"solomon/contexts/community-experience/chat-service/ports"
+)
+
+const (
+	// AttachmentQuarantinedTopic notifies a message author that an attachment
+	// on their message failed malware scanning.
+	AttachmentQuarantinedTopic = "chat.attachment.quarantined"
+
+	// maxAttachmentScanAttempts bounds retries before an attachment is marked
+	// scan_error and stays unservable.
+	maxAttachmentScanAttempts = 5
+	defaultScanBatchSize      = 20
+)
+
+// AttachmentScanRun summarizes one pass of the attachment scan worker.
+type AttachmentScanRun struct {
+	Scanned   int
+	Clean     int
+	Unscanned int
+	Infected  int
+	Failed    int
+}
+
+// AddAttachment stores an upload as pending_scan; it is not served until the
+// scan worker marks it clean.
+func (s Service) AddAttachment(
+	ctx context.Context,
+	idempotencyKey string,
+	input ports.AddAttachmentInput,
+) (ports.Attachment, error) {
+	var out ports.Attachment
+	input.MessageID = strings.TrimSpace(input.MessageID)
+	input.UserID = strings.TrimSpace(input.UserID)
+	input.Filename = strings.TrimSpace(input.Filename)
+	input.MimeType = strings.TrimSpace(input.MimeType)
+	if input.MessageID == "" || input.UserID == "" || input.Filename == "" || len(input.Content) == 0 ||
+		(input.FileSize != 0 && input.FileSize != int64(len(input.Content))) {
+		return out, domainerrors.ErrInvalidRequest
+	}
+	if err := s.requireIdempotency(idempotencyKey); err != nil {
+		return out, err
+	}
+	contentSum := sha256.Sum256(input.Content)
+	requestHash := hashStrings(
+		"add_attachment",
+		input.MessageID,
+		input.UserID,
+		input.Filename,
+		input.MimeType,
+		hex.EncodeToString(contentSum[:]),
+	)
+	err := s.runIdempotent(
+		ctx,
+		idempotencyKey,
+		requestHash,
+		func(raw []byte) error { return json.Unmarshal(raw, &out) },
+		func() ([]byte, error) {
+			result, err := s.Repo.AddAttachment(ctx, input, s.now())
+			if err != nil {
+				return nil, err
+			}
+			return json.Marshal(result)
+		},
+	)
+	return out, err
+}
+
+// GetAttachment returns attachment metadata only once it has scanned clean
+// or was passed unscanned by the no-op scanner.
+func (s Service) GetAttachment(ctx context.Context, messageID string, attachmentID string) (ports.Attachment, error) {
+	if strings.TrimSpace(messageID) == "" || strings.TrimSpace(attachmentID) == "" {
+		return ports.Attachment{}, domainerrors.ErrInvalidRequest
+	}
+	item, err := s.Repo.GetAttachment(ctx, messageID, attachmentID)
+	if err != nil {
+		return ports.Attachment{}, err
+	}
+	switch item.ScanResult {
+	case ports.ScanResultClean, ports.ScanResultUnscanned:
+		return item, nil
+	case ports.ScanResultInfected:
+		return ports.Attachment{}, domainerrors.ErrAttachmentBlocked
+	default:
+		return ports.Attachment{}, domainerrors.ErrAttachmentNotReady
+	}
+}
+
+// ScanPendingAttachments scans up to limit pending attachments. Scanner
+// failures leave an attachment pending for the next pass until it has failed
+// maxAttachmentScanAttempts times. Infected attachments are quarantined and
+// the author of their message is notified.
+func (s Service) ScanPendingAttachments(ctx context.Context, limit int) (AttachmentScanRun, error) {
+	var run AttachmentScanRun
+	if s.Scanner == nil {
+		return run, nil
+	}
+	if limit <= 0 {
+		limit = defaultScanBatchSize
+	}
+	pending, err := s.Repo.ListPendingAttachmentScans(ctx, limit)
+	if err != nil {
+		return run, err
+	}
+	for _, attachment := range pending {
+		if err := ctx.Err(); err != nil {
+			return run, err
+		}
+		run.Scanned++
+		verdict, err := s.scanAttachment(ctx, attachment)
+		update := ports.AttachmentScanUpdate{
+			AttachmentID: attachment.AttachmentID,
+			Attempts:     attachment.ScanAttempts + 1,
+		}
+		switch {
+		case err != nil:
+			run.Failed++
+			update.ScanResult = ports.ScanResultPending
+			if update.Attempts >= maxAttachmentScanAttempts {
+				update.ScanResult = ports.ScanResultScanError
+			}
+			ResolveLogger(s.Logger).Warn("chat attachment scan failed",
+				"event", "chat_attachment_scan_failed",
+				"module", "community-experience/chat-service",
+				"layer", "application",
+				"attachment_id", attachment.AttachmentID,
+				"attempts", update.Attempts,
+				"scan_result", update.ScanResult,
+				"error", err.Error(),
+			)
+		case verdict.Infected:
+			run.Infected++
+			update.ScanResult = ports.ScanResultInfected
+			update.Signature = verdict.Signature
+		case verdict.Unscanned:
+			run.Unscanned++
+			update.ScanResult = ports.ScanResultUnscanned
+		default:
+			run.Clean++
+			update.ScanResult = ports.ScanResultClean
+		}
+
+		updated, err := s.Repo.UpdateAttachmentScan(ctx, update, s.now())
+		if errors.Is(err, domainerrors.ErrConflict) {
+			// Another worker settled this attachment first.
+			continue
+		}
+		if err != nil {
+			return run, err
+		}
+		if updated.ScanResult == ports.ScanResultInfected {
+			s.quarantineAttachment(ctx, updated)
+		}
+	}
+	return run, nil
+}
+
+func (s Service) scanAttachment(ctx context.Context, attachment ports.Attachment) (ports.ScanVerdict, error) {
+	content, err := s.Repo.OpenAttachmentContent(ctx, attachment.AttachmentID)
+	if err != nil {
+		return ports.ScanVerdict{}, err
+	}
+	defer content.Close()
+	return s.Scanner.ScanAttachment(ctx, attachment, content)
+}
+
+// quarantineAttachment notifies the message author. Like the message outbox it
+// is best effort: the quarantine itself has committed.
+func (s Service) quarantineAttachment(ctx context.Context, attachment ports.Attachment) {
+	logger := ResolveLogger(s.Logger)
+	logger.Warn("chat attachment quarantined",
+		"event", "chat_attachment_quarantined",
+		"module", "community-experience/chat-service",
+		"layer", "application",
+		"attachment_id", attachment.AttachmentID,
+		"message_id", attachment.MessageID,
+		"signature", attachment.ScanSignature,
+	)
+	if s.Notifier == nil {
+		return
+	}
+	message, err := s.Repo.GetMessage(ctx, attachment.MessageID)
+	if err == nil {
+		err = s.Notifier.NotifyAttachmentQuarantined(ctx, ports.AttachmentQuarantineNotice{
+			Attachment:  attachment,
+			RecipientID: message.UserID,
+			ServerID:    message.ServerID,
+			ChannelID:   message.ChannelID,
+		})
+	}
+	if err != nil {
+		logger.Warn("chat attachment quarantine notification failed",
+			"event", "chat_attachment_quarantine_notify_failed",
+			"module", "community-experience/chat-service",
+			"layer", "application",
+			"attachment_id", attachment.AttachmentID,
+			"message_id", attachment.MessageID,
+			"error", err.Error(),
+		)
+	}
+}
+
+type attachmentQuarantinedPayload struct {
+	AttachmentID  string `json:"attachment_id"`
+	MessageID     string `json:"message_id"`
+	ServerID      string `json:"server_id"`
+	ChannelID     string `json:"channel_id"`
+	RecipientID   string `json:"recipient_user_id"`
+	UploaderID    string `json:"uploader_user_id"`
+	Filename      string `json:"filename"`
+	Signature     string `json:"signature,omitempty"`
+	QuarantinedAt string `json:"quarantined_at"`
+}
+
+// OutboxAttachmentNotifier delivers quarantine notices as
+// chat.attachment.quarantined outbox events for the notification channels.
+type OutboxAttachmentNotifier struct {
+	Outbox ports.OutboxWriter
+}
+
+func (n OutboxAttachmentNotifier) NotifyAttachmentQuarantined(
+	ctx context.Context,
+	notice ports.AttachmentQuarantineNotice,
+) error {
+	attachment := notice.Attachment
+	quarantinedAt := attachment.CreatedAt.UTC()
+	if attachment.QuarantinedAt != nil {
+		quarantinedAt = attachment.QuarantinedAt.UTC()
+	}
+	data, err := json.Marshal(attachmentQuarantinedPayload{
+		AttachmentID:  attachment.AttachmentID,
+		MessageID:     attachment.MessageID,
+		ServerID:      notice.ServerID,
+		ChannelID:     notice.ChannelID,
+		RecipientID:   notice.RecipientID,
+		UploaderID:    attachment.UserID,
+		Filename:      attachment.Filename,
+		Signature:     attachment.ScanSignature,
+		QuarantinedAt: quarantinedAt.Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		return err
+	}
+	// An attachment is quarantined at most once, so its ID alone keys the event.
+	eventID := "evt_chat_" + hashStrings(AttachmentQuarantinedTopic, attachment.AttachmentID)[:32]
+	return n.Outbox.AppendOutbox(ctx, ports.EventEnvelope{
+		EventID:          eventID,
+		EventType:        AttachmentQuarantinedTopic,
+		OccurredAt:       quarantinedAt,
+		SourceService:    "chat-service",
+		TraceID:          eventID,
+		SchemaVersion:    1,
+		PartitionKeyPath: "server_id",
+		PartitionKey:     notice.ServerID,
+		Data:             data,
+	})
+}
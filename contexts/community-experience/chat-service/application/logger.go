@@ -2,7 +2,7 @@ package application
 
 import "log/slog"
 
-func resolveLogger(logger *slog.Logger) *slog.Logger {
+func ResolveLogger(logger *slog.Logger) *slog.Logger {
 	if logger == nil {
 		return slog.Default()
 	}
@@ -0,0 +1,99 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"solomon/contexts/community-experience/chat-service/ports"
+)
+
+// Message lifecycle topics consumed by other modules (community-health).
+// Unlike chat.realtime these go through the outbox, so a consumer that was
+// down still receives every event once it starts.
+const (
+	MessageCreatedTopic = "chat.message.created"
+	MessageEditedTopic  = "chat.message.edited"
+	MessageDeletedTopic = "chat.message.deleted"
+)
+
+type messageEventPayload struct {
+	MessageID       string `json:"message_id"`
+	ServerID        string `json:"server_id"`
+	ChannelID       string `json:"channel_id"`
+	ThreadID        string `json:"thread_id,omitempty"`
+	UserID          string `json:"user_id"`
+	Content         string `json:"content,omitempty"`
+	OldContent      string `json:"old_content,omitempty"`
+	CreatedAt       string `json:"created_at"`
+	EditedAt        string `json:"edited_at,omitempty"`
+	DeletedAt       string `json:"deleted_at,omitempty"`
+	DeletedByUserID string `json:"deleted_by_user_id,omitempty"`
+}
+
+// appendMessageEvent records a chat.message.* event in the outbox. Like the
+// realtime push it is best effort: the message write has committed and
+// retrying the request would replay the stored response, not the write.
+func (s Service) appendMessageEvent(ctx context.Context, topic string, message ports.Message, oldContent string) {
+	if s.Outbox == nil {
+		return
+	}
+	payload := messageEventPayload{
+		MessageID: message.MessageID,
+		ServerID:  message.ServerID,
+		ChannelID: message.ChannelID,
+		ThreadID:  message.ThreadID,
+		UserID:    message.UserID,
+		CreatedAt: message.CreatedAt.UTC().Format(time.RFC3339Nano),
+	}
+	occurredAt := message.CreatedAt.UTC()
+	switch topic {
+	case MessageCreatedTopic:
+		payload.Content = message.Content
+	case MessageEditedTopic:
+		payload.Content = message.Content
+		payload.OldContent = oldContent
+		payload.EditedAt = message.UpdatedAt.UTC().Format(time.RFC3339Nano)
+		occurredAt = message.UpdatedAt.UTC()
+	case MessageDeletedTopic:
+		deletedAt := message.UpdatedAt.UTC()
+		if message.DeletedAt != nil {
+			deletedAt = message.DeletedAt.UTC()
+		}
+		payload.DeletedAt = deletedAt.Format(time.RFC3339Nano)
+		payload.DeletedByUserID = message.DeletedByUserID
+		occurredAt = deletedAt
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		s.logOutboxFailure(topic, message.MessageID, err)
+		return
+	}
+	// The ID is derived from the message and its version so an event appended
+	// twice collapses to one outbox row and consumers can dedupe on it.
+	eventID := "evt_chat_" + hashStrings(topic, message.MessageID, occurredAt.Format(time.RFC3339Nano))[:32]
+	if err := s.Outbox.AppendOutbox(ctx, ports.EventEnvelope{
+		EventID:          eventID,
+		EventType:        topic,
+		OccurredAt:       occurredAt,
+		SourceService:    "chat-service",
+		TraceID:          eventID,
+		SchemaVersion:    1,
+		PartitionKeyPath: "server_id",
+		PartitionKey:     message.ServerID,
+		Data:             data,
+	}); err != nil {
+		s.logOutboxFailure(topic, message.MessageID, err)
+	}
+}
+
+func (s Service) logOutboxFailure(topic string, messageID string, err error) {
+	ResolveLogger(s.Logger).Warn("chat message event append failed",
+		"event", "chat_message_outbox_append_failed",
+		"module", "community-experience/chat-service",
+		"layer", "application",
+		"event_type", topic,
+		"message_id", messageID,
+		"error", err.Error(),
+	)
+}
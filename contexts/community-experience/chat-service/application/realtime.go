@@ -0,0 +1,263 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	domainerrors "solomon/contexts/community-experience/chat-service/domain/errors"
+	"solomon/contexts/community-experience/chat-service/ports"
+)
+
+// RealtimeTopic carries chat.realtime events between API instances so each one
+// can push changes to the subscribers connected to it.
+const RealtimeTopic = "chat.realtime"
+
+const replayPageSize = 200
+
+// BusRealtimePublisher publishes realtime events to the event bus instead of
+// a local hub. Every API instance consumes the topic with its own consumer
+// group (see workers.RealtimeFanout) and delivers to its local subscribers.
+type BusRealtimePublisher struct {
+	Bus   ports.EventPublisher
+	Topic string
+}
+
+func (p BusRealtimePublisher) PublishRealtime(ctx context.Context, event ports.RealtimeEvent) error {
+	envelope, err := EncodeRealtimeEvent(event)
+	if err != nil {
+		return err
+	}
+	topic := strings.TrimSpace(p.Topic)
+	if topic == "" {
+		topic = RealtimeTopic
+	}
+	return p.Bus.Publish(ctx, topic, envelope)
+}
+
+type realtimePayload struct {
+	Type           string           `json:"type"`
+	ServerID       string           `json:"server_id"`
+	ChannelID      string           `json:"channel_id"`
+	MessageID      string           `json:"message_id,omitempty"`
+	UserID         string           `json:"user_id,omitempty"`
+	Emoji          string           `json:"emoji,omitempty"`
+	Message        *realtimeMessage `json:"message,omitempty"`
+	Reactions      map[string]int   `json:"reactions,omitempty"`
+	SequenceNumber int64            `json:"sequence_number,omitempty"`
+}
+
+type realtimeMessage struct {
+	MessageID        string          `json:"message_id"`
+	ServerID         string          `json:"server_id"`
+	ChannelID        string          `json:"channel_id"`
+	ThreadID         string          `json:"thread_id,omitempty"`
+	UserID           string          `json:"user_id"`
+	Username         string          `json:"username"`
+	Content          string          `json:"content"`
+	SequenceNumber   int64           `json:"sequence_number"`
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+	Edited           bool            `json:"edited"`
+	DeletedAt        *time.Time      `json:"deleted_at,omitempty"`
+	DeletedByUserID  string          `json:"deleted_by_user_id,omitempty"`
+	DeletionReason   string          `json:"deletion_reason,omitempty"`
+	Mentions         []ports.Mention `json:"mentions"`
+	Embeds           []ports.Embed   `json:"embeds"`
+	EmbedsPending    bool            `json:"embeds_pending,omitempty"`
+	ReactionCounters map[string]int  `json:"reaction_counters"`
+}
+
+// EncodeRealtimeEvent wraps a realtime event in the canonical envelope,
+// partitioned by channel so per-channel ordering survives the bus.
+func EncodeRealtimeEvent(event ports.RealtimeEvent) (ports.EventEnvelope, error) {
+	payload := realtimePayload{
+		Type:      event.Type,
+		ServerID:  event.ServerID,
+		ChannelID: event.ChannelID,
+		MessageID: event.MessageID,
+		UserID:    event.UserID,
+		Emoji:     event.Emoji,
+		Reactions: event.Reactions,
+	}
+	if event.Message != nil {
+		message := realtimeMessage(*event.Message)
+		payload.Message = &message
+		payload.SequenceNumber = event.Message.SequenceNumber
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return ports.EventEnvelope{}, err
+	}
+	return ports.EventEnvelope{
+		EventID:          event.EventID,
+		EventType:        RealtimeTopic,
+		OccurredAt:       event.OccurredAt.UTC(),
+		SourceService:    "chat-service",
+		TraceID:          event.EventID,
+		SchemaVersion:    1,
+		PartitionKeyPath: "channel_id",
+		PartitionKey:     event.ChannelID,
+		Data:             data,
+	}, nil
+}
+
+// DecodeRealtimeEvent reverses EncodeRealtimeEvent.
+func DecodeRealtimeEvent(envelope ports.EventEnvelope) (ports.RealtimeEvent, error) {
+	var payload realtimePayload
+	if err := json.Unmarshal(envelope.Data, &payload); err != nil {
+		return ports.RealtimeEvent{}, fmt.Errorf("decode chat.realtime payload: %w", err)
+	}
+	if strings.TrimSpace(payload.ChannelID) == "" || strings.TrimSpace(payload.Type) == "" {
+		return ports.RealtimeEvent{}, fmt.Errorf("chat.realtime payload missing channel_id or type")
+	}
+	event := ports.RealtimeEvent{
+		EventID:    envelope.EventID,
+		Type:       payload.Type,
+		ServerID:   payload.ServerID,
+		ChannelID:  payload.ChannelID,
+		MessageID:  payload.MessageID,
+		UserID:     payload.UserID,
+		Emoji:      payload.Emoji,
+		Reactions:  payload.Reactions,
+		OccurredAt: envelope.OccurredAt.UTC(),
+	}
+	if payload.Message != nil {
+		message := ports.Message(*payload.Message)
+		event.Message = &message
+	}
+	return event, nil
+}
+
+// SubscribeChannel registers a live listener for a channel. Callers subscribe
+// before ReplayMessages so nothing posted in between is missed.
+func (s Service) SubscribeChannel(channelID string) (<-chan ports.RealtimeEvent, func(), error) {
+	channelID = strings.TrimSpace(channelID)
+	if channelID == "" || s.Streams == nil {
+		return nil, nil, domainerrors.ErrInvalidRequest
+	}
+	events, cancel := s.Streams.SubscribeChannel(channelID)
+	return events, cancel, nil
+}
+
+// ReplayMessages walks channel history after afterSequence oldest first, so a
+// reconnecting client catches up before live events resume. It returns the
+// last sequence number delivered; zero means nothing to replay.
+func (s Service) ReplayMessages(
+	ctx context.Context,
+	channelID string,
+	afterSequence int64,
+	emit func(ports.Message) error,
+) (int64, error) {
+	channelID = strings.TrimSpace(channelID)
+	if channelID == "" || afterSequence < 0 {
+		return afterSequence, domainerrors.ErrInvalidRequest
+	}
+	last := afterSequence
+	if afterSequence == 0 {
+		// A fresh subscriber only wants what happens from now on.
+		return last, nil
+	}
+	for {
+		page, err := s.Repo.ListMessages(ctx, ports.ListMessagesInput{
+			ChannelID:     channelID,
+			AfterSequence: last,
+			Limit:         replayPageSize,
+		})
+		if err != nil {
+			return last, err
+		}
+		for i := len(page.Messages) - 1; i >= 0; i-- {
+			if err := emit(page.Messages[i]); err != nil {
+				return last, err
+			}
+			last = page.Messages[i].SequenceNumber
+		}
+		if !page.HasMore || len(page.Messages) == 0 {
+			return last, nil
+		}
+	}
+}
+
+// SignalTyping broadcasts an ephemeral typing indicator; nothing is stored.
+func (s Service) SignalTyping(ctx context.Context, userID string, serverID string, channelID string) error {
+	if strings.TrimSpace(userID) == "" || strings.TrimSpace(channelID) == "" {
+		return domainerrors.ErrInvalidRequest
+	}
+	return s.publishRealtime(ctx, ports.RealtimeEvent{
+		Type:      ports.RealtimeTyping,
+		ServerID:  strings.TrimSpace(serverID),
+		ChannelID: strings.TrimSpace(channelID),
+		UserID:    userID,
+	})
+}
+
+func (s Service) publishMessageEvent(ctx context.Context, eventType string, actorID string, message ports.Message) {
+	item := message
+	_ = s.publishRealtime(ctx, ports.RealtimeEvent{
+		Type:      eventType,
+		ServerID:  message.ServerID,
+		ChannelID: message.ChannelID,
+		MessageID: message.MessageID,
+		UserID:    actorID,
+		Message:   &item,
+	})
+}
+
+func (s Service) publishReactionEvent(ctx context.Context, eventType string, messageID string, userID string, emoji string, counters map[string]int) {
+	message, err := s.Repo.GetMessage(ctx, messageID)
+	if err != nil {
+		s.logRealtimeFailure(eventType, messageID, err)
+		return
+	}
+	_ = s.publishRealtime(ctx, ports.RealtimeEvent{
+		Type:      eventType,
+		ServerID:  message.ServerID,
+		ChannelID: message.ChannelID,
+		MessageID: messageID,
+		UserID:    userID,
+		Emoji:     strings.TrimSpace(emoji),
+		Reactions: counters,
+	})
+}
+
+// publishRealtime is best effort: the write already committed, and clients
+// that miss a push recover through sequence replay or history reads.
+func (s Service) publishRealtime(ctx context.Context, event ports.RealtimeEvent) error {
+	if s.Realtime == nil {
+		return nil
+	}
+	if event.EventID == "" {
+		event.EventID = s.newEventID(ctx, event)
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = s.now()
+	}
+	if err := s.Realtime.PublishRealtime(ctx, event); err != nil {
+		s.logRealtimeFailure(event.Type, event.MessageID, err)
+		return err
+	}
+	return nil
+}
+
+func (s Service) newEventID(ctx context.Context, event ports.RealtimeEvent) string {
+	if s.IDGen != nil {
+		if id, err := s.IDGen.NewID(ctx); err == nil && strings.TrimSpace(id) != "" {
+			return id
+		}
+	}
+	return hashStrings("chat_realtime", event.Type, event.ChannelID, event.MessageID, event.UserID, time.Now().UTC().Format(time.RFC3339Nano))
+}
+
+func (s Service) logRealtimeFailure(eventType string, messageID string, err error) {
+	ResolveLogger(s.Logger).Warn("chat realtime publish failed",
+		"event", "chat_realtime_publish_failed",
+		"module", "community-experience/chat-service",
+		"layer", "application",
+		"realtime_type", eventType,
+		"message_id", messageID,
+		"error", err.Error(),
+	)
+}
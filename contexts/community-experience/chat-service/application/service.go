@@ -17,6 +17,14 @@ type Service struct {
 	Repo           ports.Repository
 	Idempotency    ports.IdempotencyStore
 	Clock          ports.Clock
+	IDGen          ports.IDGenerator
+	Realtime       ports.RealtimePublisher
+	Streams        ports.RealtimeSubscriber
+	Outbox         ports.OutboxWriter
+	Scanner        ports.AttachmentScanner
+	Notifier       ports.AttachmentNotifier
+	Unfurler       ports.LinkUnfurler
+	LinkPreviews   ports.LinkPreviewCache
 	Logger         *slog.Logger
 	IdempotencyTTL time.Duration
 }
@@ -45,6 +53,8 @@ func (s Service) PostMessage(
 			if err != nil {
 				return nil, err
 			}
+			s.publishMessageEvent(ctx, ports.RealtimeMessageCreated, input.UserID, result)
+			s.appendMessageEvent(ctx, MessageCreatedTopic, result, "")
 			return json.Marshal(result)
 		},
 	)
@@ -71,10 +81,16 @@ func (s Service) EditMessage(
 		requestHash,
 		func(raw []byte) error { return json.Unmarshal(raw, &out) },
 		func() ([]byte, error) {
+			previous, err := s.Repo.GetMessage(ctx, input.MessageID)
+			if err != nil {
+				return nil, err
+			}
 			result, err := s.Repo.UpdateMessage(ctx, input, s.now())
 			if err != nil {
 				return nil, err
 			}
+			s.publishMessageEvent(ctx, ports.RealtimeMessageEdited, input.UserID, result)
+			s.appendMessageEvent(ctx, MessageEditedTopic, result, previous.Content)
 			return json.Marshal(result)
 		},
 	)
@@ -105,15 +121,23 @@ func (s Service) DeleteMessage(
 			if err != nil {
 				return nil, err
 			}
+			s.publishMessageEvent(ctx, ports.RealtimeMessageDeleted, input.UserID, result)
+			s.appendMessageEvent(ctx, MessageDeletedTopic, result, "")
 			return json.Marshal(result)
 		},
 	)
 	return out, err
 }
 
-func (s Service) ListMessages(ctx context.Context, input ports.ListMessagesInput) ([]ports.Message, error) {
-	if strings.TrimSpace(input.ChannelID) == "" {
-		return nil, domainerrors.ErrInvalidRequest
+func (s Service) ListMessages(ctx context.Context, input ports.ListMessagesInput) (ports.MessagePage, error) {
+	input.ChannelID = strings.TrimSpace(input.ChannelID)
+	input.BeforeMessageID = strings.TrimSpace(input.BeforeMessageID)
+	input.AfterMessageID = strings.TrimSpace(input.AfterMessageID)
+	if input.ChannelID == "" || input.AfterSequence < 0 {
+		return ports.MessagePage{}, domainerrors.ErrInvalidRequest
+	}
+	if input.BeforeMessageID != "" && (input.AfterMessageID != "" || input.AfterSequence > 0) {
+		return ports.MessagePage{}, domainerrors.ErrInvalidRequest
 	}
 	if input.Limit <= 0 {
 		input.Limit = 50
@@ -188,6 +212,7 @@ func (s Service) AddReaction(
 			if err != nil {
 				return nil, err
 			}
+			s.publishReactionEvent(ctx, ports.RealtimeReactionAdded, messageID, userID, emoji, c)
 			return json.Marshal(struct {
 				Reaction ports.Reaction
 				Counts   map[string]int
@@ -224,6 +249,7 @@ func (s Service) RemoveReaction(
 			if err != nil {
 				return nil, err
 			}
+			s.publishReactionEvent(ctx, ports.RealtimeReactionRemoved, messageID, userID, emoji, result)
 			return json.Marshal(result)
 		},
 	)
@@ -247,6 +273,11 @@ func (s Service) PinMessage(ctx context.Context, idempotencyKey string, messageI
 			if err := s.Repo.PinMessage(ctx, messageID, actorID, reason, s.now()); err != nil {
 				return nil, err
 			}
+			if message, err := s.Repo.GetMessage(ctx, messageID); err == nil {
+				s.publishMessageEvent(ctx, ports.RealtimeMessagePinned, actorID, message)
+			} else {
+				s.logRealtimeFailure(ports.RealtimeMessagePinned, messageID, err)
+			}
 			return []byte(`{}`), nil
 		},
 	)
@@ -361,57 +392,20 @@ func (s Service) MuteUser(
 	return out, err
 }
 
-func (s Service) AddAttachment(
-	ctx context.Context,
-	idempotencyKey string,
-	messageID string,
-	userID string,
-	filename string,
-	fileSize int64,
-	mimeType string,
-) (ports.Attachment, error) {
-	var out ports.Attachment
-	if strings.TrimSpace(messageID) == "" || strings.TrimSpace(userID) == "" || strings.TrimSpace(filename) == "" || fileSize <= 0 {
-		return out, domainerrors.ErrInvalidRequest
-	}
-	if err := s.requireIdempotency(idempotencyKey); err != nil {
-		return out, err
+func (s Service) ExportMessages(ctx context.Context, input ports.ExportMessagesInput) (ports.ExportPage, error) {
+	input.ServerID = strings.TrimSpace(input.ServerID)
+	input.ChannelID = strings.TrimSpace(input.ChannelID)
+	input.AfterMessageID = strings.TrimSpace(input.AfterMessageID)
+	if input.ServerID == "" && input.ChannelID == "" {
+		return ports.ExportPage{}, domainerrors.ErrInvalidRequest
 	}
-	requestHash := hashStrings("add_attachment", messageID, userID, filename, mimeType)
-	err := s.runIdempotent(
-		ctx,
-		idempotencyKey,
-		requestHash,
-		func(raw []byte) error { return json.Unmarshal(raw, &out) },
-		func() ([]byte, error) {
-			result, err := s.Repo.AddAttachment(ctx, messageID, userID, filename, fileSize, mimeType, s.now())
-			if err != nil {
-				return nil, err
-			}
-			return json.Marshal(result)
-		},
-	)
-	return out, err
-}
-
-func (s Service) GetAttachment(ctx context.Context, messageID string, attachmentID string) (ports.Attachment, error) {
-	if strings.TrimSpace(messageID) == "" || strings.TrimSpace(attachmentID) == "" {
-		return ports.Attachment{}, domainerrors.ErrInvalidRequest
-	}
-	return s.Repo.GetAttachment(ctx, messageID, attachmentID)
-}
-
-func (s Service) ExportMessages(ctx context.Context, serverID string, channelID string, limit int) ([]ports.Message, error) {
-	if strings.TrimSpace(serverID) == "" && strings.TrimSpace(channelID) == "" {
-		return nil, domainerrors.ErrInvalidRequest
-	}
-	if limit <= 0 {
-		limit = 1000
+	if input.Limit <= 0 {
+		input.Limit = 1000
 	}
-	if limit > 5000 {
-		limit = 5000
+	if input.Limit > 5000 {
+		input.Limit = 5000
 	}
-	return s.Repo.ExportMessages(ctx, serverID, channelID, limit)
+	return s.Repo.ExportMessages(ctx, input)
 }
 
 func (s Service) now() time.Time {
@@ -467,7 +461,7 @@ func (s Service) runIdempotent(
 		return err
 	}
 
-	resolveLogger(s.Logger).Debug("chat service idempotent operation committed",
+	ResolveLogger(s.Logger).Debug("chat service idempotent operation committed",
 		"event", "chat_service_idempotent_operation_committed",
 		"module", "community-experience/chat-service",
 		"layer", "application",
@@ -0,0 +1,165 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	domainerrors "solomon/contexts/community-experience/chat-service/domain/errors"
+	"solomon/contexts/community-experience/chat-service/ports"
+)
+
+const (
+	// MaxEmbedsPerMessage caps how many links in one message are unfurled.
+	MaxEmbedsPerMessage = 5
+
+	linkPreviewTTL       = 24 * time.Hour
+	failedLinkPreviewTTL = time.Hour
+	defaultUnfurlBatch   = 20
+)
+
+// LinkUnfurlRun summarizes one pass of the link unfurl worker.
+type LinkUnfurlRun struct {
+	Messages  int
+	Updated   int
+	Fetched   int
+	CacheHits int
+	Failed    int
+}
+
+// UnfurlPendingLinks fetches previews for up to limit messages with
+// unresolved links, stores the verified embeds and pushes a realtime
+// message.edited event so connected clients render the previews. Content is
+// unchanged, so no chat.message.edited outbox event is written. Fetch
+// failures only drop that link's embed; the message is still settled.
+func (s Service) UnfurlPendingLinks(ctx context.Context, limit int) (LinkUnfurlRun, error) {
+	var run LinkUnfurlRun
+	if s.Unfurler == nil {
+		return run, nil
+	}
+	if limit <= 0 {
+		limit = defaultUnfurlBatch
+	}
+	pending, err := s.Repo.ListPendingUnfurls(ctx, limit)
+	if err != nil {
+		return run, err
+	}
+	for _, message := range pending {
+		if err := ctx.Err(); err != nil {
+			return run, err
+		}
+		run.Messages++
+		embeds := make([]ports.Embed, 0, MaxEmbedsPerMessage)
+		for _, link := range ExtractLinks(message.Content) {
+			embed, ok := s.linkPreview(ctx, link, &run)
+			if ok {
+				embeds = append(embeds, embed)
+			}
+		}
+		updated, err := s.Repo.SetMessageEmbeds(ctx, ports.MessageEmbedsUpdate{
+			MessageID:      message.MessageID,
+			ContentVersion: message.UpdatedAt,
+			Embeds:         embeds,
+		}, s.now())
+		if errors.Is(err, domainerrors.ErrConflict) || errors.Is(err, domainerrors.ErrMessageNotFound) {
+			// Edited or deleted while unfurling; an edit is picked up again.
+			continue
+		}
+		if err != nil {
+			return run, err
+		}
+		run.Updated++
+		if len(updated.Embeds) > 0 {
+			s.publishMessageEvent(ctx, ports.RealtimeMessageEdited, updated.UserID, updated)
+		}
+	}
+	return run, nil
+}
+
+// linkPreview resolves one link through the cache, fetching and caching it on
+// a miss. Failures are cached too so a dead link is not refetched by every
+// message that repeats it.
+func (s Service) linkPreview(ctx context.Context, link string, run *LinkUnfurlRun) (ports.Embed, bool) {
+	logger := ResolveLogger(s.Logger)
+	now := s.now()
+	if s.LinkPreviews != nil {
+		cached, found, err := s.LinkPreviews.GetLinkPreview(ctx, link, now)
+		if err != nil {
+			logger.Warn("chat link preview cache read failed",
+				"event", "chat_link_preview_cache_read_failed",
+				"module", "community-experience/chat-service",
+				"layer", "application",
+				"error", err.Error(),
+			)
+		} else if found {
+			run.CacheHits++
+			return cached.Embed, !cached.Failed
+		}
+	}
+
+	run.Fetched++
+	preview := ports.LinkPreview{URL: link, FetchedAt: now, ExpiresAt: now.Add(linkPreviewTTL)}
+	embed, err := s.Unfurler.Unfurl(ctx, link)
+	if err != nil {
+		run.Failed++
+		preview.Failed = true
+		preview.ExpiresAt = now.Add(failedLinkPreviewTTL)
+		logger.Debug("chat link unfurl failed",
+			"event", "chat_link_unfurl_failed",
+			"module", "community-experience/chat-service",
+			"layer", "application",
+			"host", linkHost(link),
+			"error", err.Error(),
+		)
+	} else {
+		embed.URL = link
+		preview.Embed = embed
+	}
+	if s.LinkPreviews != nil {
+		if err := s.LinkPreviews.PutLinkPreview(ctx, preview); err != nil {
+			logger.Warn("chat link preview cache write failed",
+				"event", "chat_link_preview_cache_write_failed",
+				"module", "community-experience/chat-service",
+				"layer", "application",
+				"error", err.Error(),
+			)
+		}
+	}
+	return preview.Embed, !preview.Failed
+}
+
+// ExtractLinks returns the distinct http(s) links in content in order of
+// appearance, at most MaxEmbedsPerMessage of them.
+func ExtractLinks(content string) []string {
+	seen := make(map[string]struct{})
+	links := make([]string, 0)
+	for _, word := range strings.Fields(content) {
+		candidate := strings.Trim(word, ".,!?;:()<>\"'")
+		if !strings.HasPrefix(candidate, "http://") && !strings.HasPrefix(candidate, "https://") {
+			continue
+		}
+		parsed, err := url.Parse(candidate)
+		if err != nil || parsed.Host == "" {
+			continue
+		}
+		if _, ok := seen[candidate]; ok {
+			continue
+		}
+		seen[candidate] = struct{}{}
+		links = append(links, candidate)
+		if len(links) == MaxEmbedsPerMessage {
+			break
+		}
+	}
+	return links
+}
+
+func linkHost(link string) string {
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	return parsed.Hostname()
+}
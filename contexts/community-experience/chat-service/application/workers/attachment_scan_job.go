@@ -0,0 +1,45 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+
+	application "solomon/contexts/community-experience/chat-service/application"
+)
+
+// AttachmentScanJob hands pending attachments to the malware scanner in
+// bounded batches.
+type AttachmentScanJob struct {
+	Service   application.Service
+	BatchSize int
+	Logger    *slog.Logger
+}
+
+func (j AttachmentScanJob) RunOnce(ctx context.Context) error {
+	logger := application.ResolveLogger(j.Logger)
+	run, err := j.Service.ScanPendingAttachments(ctx, j.BatchSize)
+	if err != nil {
+		logger.Error("chat attachment scan cycle failed",
+			"event", "chat_attachment_scan_cycle_failed",
+			"module", "community-experience/chat-service",
+			"layer", "worker",
+			"scanned", run.Scanned,
+			"error", err.Error(),
+		)
+		return err
+	}
+	if run.Scanned == 0 {
+		return nil
+	}
+	logger.Info("chat attachment scan cycle completed",
+		"event", "chat_attachment_scan_cycle_completed",
+		"module", "community-experience/chat-service",
+		"layer", "worker",
+		"scanned", run.Scanned,
+		"clean", run.Clean,
+		"unscanned", run.Unscanned,
+		"infected", run.Infected,
+		"failed", run.Failed,
+	)
+	return nil
+}
@@ -0,0 +1,45 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+
+	application "solomon/contexts/community-experience/chat-service/application"
+)
+
+// LinkUnfurlJob resolves link previews for recently posted or edited messages
+// in bounded batches.
+type LinkUnfurlJob struct {
+	Service   application.Service
+	BatchSize int
+	Logger    *slog.Logger
+}
+
+func (j LinkUnfurlJob) RunOnce(ctx context.Context) error {
+	logger := application.ResolveLogger(j.Logger)
+	run, err := j.Service.UnfurlPendingLinks(ctx, j.BatchSize)
+	if err != nil {
+		logger.Error("chat link unfurl cycle failed",
+			"event", "chat_link_unfurl_cycle_failed",
+			"module", "community-experience/chat-service",
+			"layer", "worker",
+			"messages", run.Messages,
+			"error", err.Error(),
+		)
+		return err
+	}
+	if run.Messages == 0 {
+		return nil
+	}
+	logger.Debug("chat link unfurl cycle completed",
+		"event", "chat_link_unfurl_cycle_completed",
+		"module", "community-experience/chat-service",
+		"layer", "worker",
+		"messages", run.Messages,
+		"updated", run.Updated,
+		"fetched", run.Fetched,
+		"cache_hits", run.CacheHits,
+		"failed", run.Failed,
+	)
+	return nil
+}
@@ -0,0 +1,118 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	application "solomon/contexts/community-experience/chat-service/application"
+	"solomon/contexts/community-experience/chat-service/ports"
+)
+
+// OutboxRelay publishes pending chat.message.* outbox rows to the event bus.
+type OutboxRelay struct {
+	Outbox    ports.OutboxRepository
+	Publisher ports.EventPublisher
+	Clock     ports.Clock
+	BatchSize int
+	Logger    *slog.Logger
+}
+
+// RunOnce publishes one bounded batch and marks each row published only after
+// the broker accepted it. It stops on the first failure so the next cycle
+// retries the remaining rows in order.
+func (r OutboxRelay) RunOnce(ctx context.Context) error {
+	_, err := r.publishBatch(ctx)
+	return err
+}
+
+// Drain publishes batches until the outbox is empty. It runs at startup so
+// events written while consumers were down are delivered before new traffic.
+func (r OutboxRelay) Drain(ctx context.Context) error {
+	for {
+		published, err := r.publishBatch(ctx)
+		if err != nil {
+			return err
+		}
+		if published < r.batchSize() {
+			return nil
+		}
+	}
+}
+
+func (r OutboxRelay) publishBatch(ctx context.Context) (int, error) {
+	logger := application.ResolveLogger(r.Logger)
+	limit := r.batchSize()
+	pending, err := r.Outbox.ListPendingOutbox(ctx, limit)
+	if err != nil {
+		logger.Error("chat outbox list failed",
+			"event", "chat_outbox_list_failed",
+			"module", "community-experience/chat-service",
+			"layer", "worker",
+			"error", err.Error(),
+		)
+		return 0, err
+	}
+	if len(pending) == 0 {
+		return 0, nil
+	}
+
+	now := time.Now().UTC()
+	if r.Clock != nil {
+		now = r.Clock.Now().UTC()
+	}
+	for i, row := range pending {
+		var event ports.EventEnvelope
+		if err := json.Unmarshal(row.Payload, &event); err != nil {
+			logger.Error("chat outbox decode failed",
+				"event", "chat_outbox_decode_failed",
+				"module", "community-experience/chat-service",
+				"layer", "worker",
+				"outbox_id", row.OutboxID,
+				"error", err.Error(),
+			)
+			return i, err
+		}
+		topic := event.EventType
+		if topic == "" {
+			topic = row.EventType
+		}
+		if err := r.Publisher.Publish(ctx, topic, event); err != nil {
+			logger.Error("chat outbox publish failed",
+				"event", "chat_outbox_publish_failed",
+				"module", "community-experience/chat-service",
+				"layer", "worker",
+				"outbox_id", row.OutboxID,
+				"event_type", topic,
+				"error", err.Error(),
+			)
+			return i, err
+		}
+		if err := r.Outbox.MarkOutboxPublished(ctx, row.OutboxID, now); err != nil {
+			logger.Error("chat outbox mark published failed",
+				"event", "chat_outbox_mark_published_failed",
+				"module", "community-experience/chat-service",
+				"layer", "worker",
+				"outbox_id", row.OutboxID,
+				"error", err.Error(),
+			)
+			return i, err
+		}
+	}
+
+	logger.Info("chat outbox relay cycle completed",
+		"event", "chat_outbox_relay_completed",
+		"module", "community-experience/chat-service",
+		"layer", "worker",
+		"published_count", len(pending),
+	)
+	return len(pending), nil
+}
+
+func (r OutboxRelay) batchSize() int {
+	if r.BatchSize <= 0 {
+		return 100
+	}
+	return r.BatchSize
+}
@@ -0,0 +1,44 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	application "solomon/contexts/community-experience/chat-service/application"
+	"solomon/contexts/community-experience/chat-service/ports"
+)
+
+// RealtimeFanout consumes chat.realtime events from the bus and delivers them
+// to the subscribers connected to this API instance. ConsumerGroup must be
+// unique per instance so that every instance receives every event.
+type RealtimeFanout struct {
+	Subscriber    ports.EventSubscriber
+	Hub           ports.RealtimePublisher
+	Topic         string
+	ConsumerGroup string
+	Logger        *slog.Logger
+}
+
+func (f RealtimeFanout) Start(ctx context.Context) error {
+	topic := strings.TrimSpace(f.Topic)
+	if topic == "" {
+		topic = application.RealtimeTopic
+	}
+	return f.Subscriber.Subscribe(ctx, topic, f.ConsumerGroup, f.handle)
+}
+
+func (f RealtimeFanout) handle(ctx context.Context, envelope ports.EventEnvelope) error {
+	event, err := application.DecodeRealtimeEvent(envelope)
+	if err != nil {
+		application.ResolveLogger(f.Logger).Warn("chat realtime event rejected",
+			"event", "chat_realtime_fanout_rejected",
+			"module", "community-experience/chat-service",
+			"layer", "worker",
+			"event_id", envelope.EventID,
+			"error", err.Error(),
+		)
+		return err
+	}
+	return f.Hub.PublishRealtime(ctx, event)
+}
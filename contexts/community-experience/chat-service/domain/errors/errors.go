@@ -13,4 +13,6 @@ var (
 
 	ErrMessageNotFound    = errors.New("message not found")
 	ErrAttachmentNotFound = errors.New("attachment not found")
+	ErrAttachmentNotReady = errors.New("attachment has not passed malware scanning")
+	ErrAttachmentBlocked  = errors.New("attachment is quarantined")
 )
@@ -1,18 +1,32 @@
 package chatservice
 
 import (
+	"context"
 	"log/slog"
+	"strings"
 	"time"
 
 	httpadapter "solomon/contexts/community-experience/chat-service/adapters/http"
 	"solomon/contexts/community-experience/chat-service/adapters/memory"
+	"solomon/contexts/community-experience/chat-service/adapters/realtime"
+	"solomon/contexts/community-experience/chat-service/adapters/scanner"
 	"solomon/contexts/community-experience/chat-service/application"
+	"solomon/contexts/community-experience/chat-service/application/workers"
 	"solomon/contexts/community-experience/chat-service/ports"
 )
 
 type Module struct {
 	Handler httpadapter.Handler
 	Store   *memory.Store
+	Hub     *realtime.Hub
+	// Fanout relays bus events to Hub; nil when realtime stays in-process.
+	Fanout *workers.RealtimeFanout
+	// Relay publishes chat.message.* outbox rows; nil without an event bus.
+	Relay *workers.OutboxRelay
+	// AttachmentScans hands pending attachments to the malware scanner.
+	AttachmentScans workers.AttachmentScanJob
+	// LinkUnfurls fills in link previews; a no-op without an Unfurler.
+	LinkUnfurls workers.LinkUnfurlJob
 }
 
 type Dependencies struct {
@@ -22,13 +36,68 @@ type Dependencies struct {
 	IDGenerator    ports.IDGenerator
 	IdempotencyTTL time.Duration
 	Logger         *slog.Logger
+	// EventPublisher and EventSubscriber enable cross-instance realtime
+	// fan-out. InstanceID names this instance's consumer group.
+	EventPublisher  ports.EventPublisher
+	EventSubscriber ports.EventSubscriber
+	InstanceID      string
+	// Outbox records chat.message.* events for other modules; they are
+	// relayed only when EventPublisher is set.
+	Outbox ports.OutboxStore
+	// Scanner inspects uploaded attachments; it defaults to a no-op scanner
+	// that passes everything. Notifier tells message authors about
+	// quarantined attachments and defaults to chat.attachment.quarantined
+	// outbox events.
+	Scanner  ports.AttachmentScanner
+	Notifier ports.AttachmentNotifier
+	// Unfurler fetches link previews for message embeds; nil leaves links
+	// unfurled. LinkPreviews caches results per URL.
+	Unfurler     ports.LinkUnfurler
+	LinkPreviews ports.LinkPreviewCache
 }
 
 func NewModule(deps Dependencies) Module {
+	hub := realtime.NewHub()
+	var publisher ports.RealtimePublisher = hub
+	var fanout *workers.RealtimeFanout
+	if deps.EventPublisher != nil && deps.EventSubscriber != nil {
+		publisher = application.BusRealtimePublisher{Bus: deps.EventPublisher}
+		fanout = &workers.RealtimeFanout{
+			Subscriber:    deps.EventSubscriber,
+			Hub:           hub,
+			ConsumerGroup: "chat-service-realtime-" + instanceID(deps),
+			Logger:        deps.Logger,
+		}
+	}
+	var relay *workers.OutboxRelay
+	if deps.Outbox != nil && deps.EventPublisher != nil {
+		relay = &workers.OutboxRelay{
+			Outbox:    deps.Outbox,
+			Publisher: deps.EventPublisher,
+			Clock:     deps.Clock,
+			Logger:    deps.Logger,
+		}
+	}
+	attachmentScanner := deps.Scanner
+	if attachmentScanner == nil {
+		attachmentScanner = scanner.NoopScanner{}
+	}
+	notifier := deps.Notifier
+	if notifier == nil && deps.Outbox != nil {
+		notifier = application.OutboxAttachmentNotifier{Outbox: deps.Outbox}
+	}
 	service := application.Service{
 		Repo:           deps.Repository,
 		Idempotency:    deps.Idempotency,
 		Clock:          deps.Clock,
+		IDGen:          deps.IDGenerator,
+		Realtime:       publisher,
+		Streams:        hub,
+		Outbox:         deps.Outbox,
+		Scanner:        attachmentScanner,
+		Notifier:       notifier,
+		Unfurler:       deps.Unfurler,
+		LinkPreviews:   deps.LinkPreviews,
 		Logger:         deps.Logger,
 		IdempotencyTTL: deps.IdempotencyTTL,
 	}
@@ -37,18 +106,90 @@ func NewModule(deps Dependencies) Module {
 			Service: service,
 			Logger:  deps.Logger,
 		},
+		Hub:    hub,
+		Fanout: fanout,
+		Relay:  relay,
+		AttachmentScans: workers.AttachmentScanJob{
+			Service: service,
+			Logger:  deps.Logger,
+		},
+		LinkUnfurls: workers.LinkUnfurlJob{
+			Service: service,
+			Logger:  deps.Logger,
+		},
 	}
 }
 
+// StartRealtime subscribes this instance to the realtime topic until ctx ends.
+func (m Module) StartRealtime(ctx context.Context) error {
+	if m.Fanout == nil {
+		return nil
+	}
+	return m.Fanout.Start(ctx)
+}
+
+// DrainOutbox publishes every pending chat.message.* event; run it once at
+// startup after consumers have subscribed.
+func (m Module) DrainOutbox(ctx context.Context) error {
+	if m.Relay == nil {
+		return nil
+	}
+	return m.Relay.Drain(ctx)
+}
+
+// RelayOutbox publishes one batch of pending chat.message.* events.
+func (m Module) RelayOutbox(ctx context.Context) error {
+	if m.Relay == nil {
+		return nil
+	}
+	return m.Relay.RunOnce(ctx)
+}
+
+// ScanAttachments scans one batch of attachments awaiting a malware scan.
+func (m Module) ScanAttachments(ctx context.Context) error {
+	return m.AttachmentScans.RunOnce(ctx)
+}
+
+// UnfurlLinks resolves link previews for one batch of messages.
+func (m Module) UnfurlLinks(ctx context.Context) error {
+	return m.LinkUnfurls.RunOnce(ctx)
+}
+
+func instanceID(deps Dependencies) string {
+	if id := strings.TrimSpace(deps.InstanceID); id != "" {
+		return id
+	}
+	if deps.IDGenerator != nil {
+		if id, err := deps.IDGenerator.NewID(context.Background()); err == nil && id != "" {
+			return id
+		}
+	}
+	return "local"
+}
+
 func NewInMemoryModule(logger *slog.Logger) Module {
+	return NewInMemoryModuleWithEvents(logger, nil, nil)
+}
+
+// NewInMemoryModuleWithEvents builds the in-memory module on a shared event
+// bus, enabling cross-instance realtime fan-out and the outbox relay.
+func NewInMemoryModuleWithEvents(
+	logger *slog.Logger,
+	publisher ports.EventPublisher,
+	subscriber ports.EventSubscriber,
+) Module {
 	store := memory.NewStore()
 	module := NewModule(Dependencies{
-		Repository:     store,
-		Idempotency:    store,
-		Clock:          store,
-		IDGenerator:    store,
-		IdempotencyTTL: 7 * 24 * time.Hour,
-		Logger:         logger,
+		Repository:      store,
+		Idempotency:     store,
+		Clock:           store,
+		IDGenerator:     store,
+		IdempotencyTTL:  7 * 24 * time.Hour,
+		Logger:          logger,
+		EventPublisher:  publisher,
+		EventSubscriber: subscriber,
+		Outbox:          store,
+		LinkPreviews:    store,
 	})
 	module.Store = store
 	return module
@@ -2,7 +2,10 @@ package ports
 
 import (
 	"context"
+	"io"
 	"time"
+
+	contractsv1 "solomon/contracts/gen/events/v1"
 )
 
 type Clock interface {
@@ -37,23 +40,54 @@ type Embed struct {
 	ImageURL    string
 }
 
+// LinkUnfurler fetches preview metadata for a link posted in chat.
+type LinkUnfurler interface {
+	Unfurl(ctx context.Context, rawURL string) (Embed, error)
+}
+
+// LinkPreview is a cached unfurl result. Failed entries remember links that
+// could not be unfurled so they are not refetched until ExpiresAt.
+type LinkPreview struct {
+	URL       string
+	Embed     Embed
+	Failed    bool
+	FetchedAt time.Time
+	ExpiresAt time.Time
+}
+
+type LinkPreviewCache interface {
+	GetLinkPreview(ctx context.Context, url string, now time.Time) (LinkPreview, bool, error)
+	PutLinkPreview(ctx context.Context, preview LinkPreview) error
+}
+
+// MessageEmbedsUpdate replaces a message's embeds with unfurled ones.
+// ContentVersion is the UpdatedAt the embeds were built from; the update is
+// rejected if the message has been edited or deleted since.
+type MessageEmbedsUpdate struct {
+	MessageID      string
+	ContentVersion time.Time
+	Embeds         []Embed
+}
+
 type Message struct {
-	MessageID        string
-	ServerID         string
-	ChannelID        string
-	ThreadID         string
-	UserID           string
-	Username         string
-	Content          string
-	SequenceNumber   int64
-	CreatedAt        time.Time
-	UpdatedAt        time.Time
-	Edited           bool
-	DeletedAt        *time.Time
-	DeletedByUserID  string
-	DeletionReason   string
-	Mentions         []Mention
-	Embeds           []Embed
+	MessageID       string
+	ServerID        string
+	ChannelID       string
+	ThreadID        string
+	UserID          string
+	Username        string
+	Content         string
+	SequenceNumber  int64
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	Edited          bool
+	DeletedAt       *time.Time
+	DeletedByUserID string
+	DeletionReason  string
+	Mentions        []Mention
+	Embeds          []Embed
+	// EmbedsPending is set while links in Content await unfurling.
+	EmbedsPending    bool
 	ReactionCounters map[string]int
 }
 
@@ -82,16 +116,79 @@ type Reaction struct {
 	CreatedAt  time.Time
 }
 
+// Attachment scan results. Attachments start pending_scan and are served
+// only once clean, or unscanned when the development no-op scanner passed
+// them without inspection; infected attachments are quarantined and
+// scan_error marks attachments the scanner gave up on.
+const (
+	ScanResultPending   = "pending_scan"
+	ScanResultClean     = "clean"
+	ScanResultUnscanned = "unscanned"
+	ScanResultInfected  = "infected"
+	ScanResultScanError = "scan_error"
+)
+
 type Attachment struct {
+	AttachmentID  string
+	MessageID     string
+	UserID        string
+	Filename      string
+	FileSize      int64
+	MimeType      string
+	URL           string
+	ScanResult    string
+	ScanSignature string
+	ScanAttempts  int
+	ScannedAt     *time.Time
+	QuarantinedAt *time.Time
+	CreatedAt     time.Time
+}
+
+// AddAttachmentInput is one upload. FileSize is the client's declared size;
+// when set it must match len(Content).
+type AddAttachmentInput struct {
+	MessageID string
+	UserID    string
+	Filename  string
+	FileSize  int64
+	MimeType  string
+	Content   []byte
+}
+
+// AttachmentScanUpdate records one scan attempt. ScanResult stays
+// pending_scan while a failed attempt will be retried.
+type AttachmentScanUpdate struct {
 	AttachmentID string
-	MessageID    string
-	UserID       string
-	Filename     string
-	FileSize     int64
-	MimeType     string
-	URL          string
 	ScanResult   string
-	ScannedAt    time.Time
+	Signature    string
+	Attempts     int
+}
+
+// ScanVerdict is a scanner's finding for one attachment. Signature names the
+// matched malware when Infected is set. Unscanned reports that the content
+// was not inspected at all.
+type ScanVerdict struct {
+	Infected  bool
+	Signature string
+	Unscanned bool
+}
+
+// AttachmentScanner inspects attachment content for malware.
+type AttachmentScanner interface {
+	ScanAttachment(ctx context.Context, attachment Attachment, content io.Reader) (ScanVerdict, error)
+}
+
+// AttachmentQuarantineNotice tells a message author that an attachment on
+// their message was quarantined.
+type AttachmentQuarantineNotice struct {
+	Attachment  Attachment
+	RecipientID string
+	ServerID    string
+	ChannelID   string
+}
+
+type AttachmentNotifier interface {
+	NotifyAttachmentQuarantined(ctx context.Context, notice AttachmentQuarantineNotice) error
 }
 
 type MuteRecord struct {
@@ -132,13 +229,40 @@ type DeleteMessageInput struct {
 	Reason    string
 }
 
+// ListMessagesInput selects one keyset page of channel history. At most one
+// of BeforeMessageID (older messages) and AfterMessageID/AfterSequence (newer
+// messages) may be set; with neither, the newest messages are returned.
 type ListMessagesInput struct {
 	ChannelID       string
 	BeforeMessageID string
+	AfterMessageID  string
 	AfterSequence   int64
 	Limit           int
 }
 
+// MessagePage is a page of channel messages ordered newest first. HasMore
+// reports whether further messages exist in the paging direction.
+type MessagePage struct {
+	Messages []Message
+	HasMore  bool
+}
+
+// ExportMessagesInput pages a server or channel export oldest first, resuming
+// strictly after AfterMessageID.
+type ExportMessagesInput struct {
+	ServerID       string
+	ChannelID      string
+	AfterMessageID string
+	Limit          int
+}
+
+// ExportPage is one export batch. NextCursor is empty once the export is
+// exhausted.
+type ExportPage struct {
+	Messages   []Message
+	NextCursor string
+}
+
 type SearchInput struct {
 	Query     string
 	ChannelID string
@@ -149,7 +273,8 @@ type Repository interface {
 	CreateMessage(ctx context.Context, input CreateMessageInput, now time.Time) (Message, error)
 	UpdateMessage(ctx context.Context, input UpdateMessageInput, now time.Time) (Message, error)
 	DeleteMessage(ctx context.Context, input DeleteMessageInput, now time.Time) (Message, error)
-	ListMessages(ctx context.Context, input ListMessagesInput) ([]Message, error)
+	GetMessage(ctx context.Context, messageID string) (Message, error)
+	ListMessages(ctx context.Context, input ListMessagesInput) (MessagePage, error)
 	SearchMessages(ctx context.Context, input SearchInput) ([]SearchResult, int, error)
 	UpsertReadState(ctx context.Context, userID string, channelID string, lastReadMessageID string, now time.Time) (ReadState, error)
 	UnreadCount(ctx context.Context, userID string, channelID string, lastReadMessageID string) (int, error)
@@ -160,7 +285,90 @@ type Repository interface {
 	LockThread(ctx context.Context, threadID string, actorID string, now time.Time) error
 	UpdateModerators(ctx context.Context, serverID string, actorID string, moderatorIDs []string, now time.Time) (ModeratorSet, error)
 	MuteUser(ctx context.Context, targetUserID string, serverID string, actorID string, duration time.Duration, reason string, now time.Time) (MuteRecord, error)
-	AddAttachment(ctx context.Context, messageID string, userID string, filename string, fileSize int64, mimeType string, now time.Time) (Attachment, error)
+	AddAttachment(ctx context.Context, input AddAttachmentInput, now time.Time) (Attachment, error)
 	GetAttachment(ctx context.Context, messageID string, attachmentID string) (Attachment, error)
-	ExportMessages(ctx context.Context, serverID string, channelID string, limit int) ([]Message, error)
+	ListPendingAttachmentScans(ctx context.Context, limit int) ([]Attachment, error)
+	OpenAttachmentContent(ctx context.Context, attachmentID string) (io.ReadCloser, error)
+	UpdateAttachmentScan(ctx context.Context, update AttachmentScanUpdate, now time.Time) (Attachment, error)
+	ExportMessages(ctx context.Context, input ExportMessagesInput) (ExportPage, error)
+	ListPendingUnfurls(ctx context.Context, limit int) ([]Message, error)
+	SetMessageEmbeds(ctx context.Context, update MessageEmbedsUpdate, now time.Time) (Message, error)
+}
+
+const (
+	RealtimeMessageCreated  = "message.created"
+	RealtimeMessageEdited   = "message.edited"
+	RealtimeMessageDeleted  = "message.deleted"
+	RealtimeReactionAdded   = "reaction.added"
+	RealtimeReactionRemoved = "reaction.removed"
+	RealtimeMessagePinned   = "message.pinned"
+	RealtimeTyping          = "typing"
+)
+
+// RealtimeEvent is one channel change pushed to live subscribers. Message is
+// set for message.* events; Reactions carries the fresh counters for
+// reaction.* events.
+type RealtimeEvent struct {
+	EventID    string
+	Type       string
+	ServerID   string
+	ChannelID  string
+	MessageID  string
+	UserID     string
+	Emoji      string
+	Message    *Message
+	Reactions  map[string]int
+	OccurredAt time.Time
+}
+
+// RealtimePublisher fans a realtime event out to every subscriber of its
+// channel, on this instance or through the event bus to all instances.
+type RealtimePublisher interface {
+	PublishRealtime(ctx context.Context, event RealtimeEvent) error
+}
+
+// RealtimeSubscriber registers a local listener for one channel. The returned
+// channel is closed when cancel is called or when the listener falls too far
+// behind; clients then reconnect and resume by sequence number.
+type RealtimeSubscriber interface {
+	SubscribeChannel(channelID string) (<-chan RealtimeEvent, func())
+}
+
+type EventEnvelope = contractsv1.Envelope
+
+type EventPublisher interface {
+	Publish(ctx context.Context, topic string, event EventEnvelope) error
+}
+
+type EventSubscriber interface {
+	Subscribe(
+		ctx context.Context,
+		topic string,
+		consumerGroup string,
+		handler func(context.Context, EventEnvelope) error,
+	) error
+}
+
+type OutboxWriter interface {
+	AppendOutbox(ctx context.Context, envelope EventEnvelope) error
+}
+
+type OutboxMessage struct {
+	OutboxID     string
+	EventType    string
+	PartitionKey string
+	Payload      []byte
+	CreatedAt    time.Time
+}
+
+type OutboxRepository interface {
+	ListPendingOutbox(ctx context.Context, limit int) ([]OutboxMessage, error)
+	MarkOutboxPublished(ctx context.Context, outboxID string, publishedAt time.Time) error
+}
+
+// OutboxStore is implemented by adapters that both record and relay
+// chat.message.* events.
+type OutboxStore interface {
+	OutboxWriter
+	OutboxRepository
 }
@@ -28,6 +28,7 @@ type MessageDTO struct {
 	SequenceNumber int64          `json:"sequence_number,omitempty"`
 	Mentions       []MentionDTO   `json:"mentions,omitempty"`
 	Embeds         []EmbedDTO     `json:"embeds,omitempty"`
+	EmbedsPending  bool           `json:"embeds_pending,omitempty"`
 	Reactions      map[string]int `json:"reactions,omitempty"`
 	CreatedAt      string         `json:"created_at"`
 	UpdatedAt      string         `json:"updated_at,omitempty"`
@@ -75,8 +76,11 @@ type DeleteMessageResponse struct {
 type ListMessagesResponse struct {
 	Status string `json:"status"`
 	Data   struct {
-		Messages []MessageDTO `json:"messages"`
-		Limit    int          `json:"limit"`
+		Messages   []MessageDTO `json:"messages"`
+		Limit      int          `json:"limit"`
+		HasMore    bool         `json:"has_more"`
+		NextBefore string       `json:"next_before,omitempty"`
+		NextAfter  string       `json:"next_after,omitempty"`
 	} `json:"data"`
 }
 
@@ -155,10 +159,14 @@ type UpdateModeratorsRequest struct {
 	ModeratorIDs []string `json:"moderator_ids"`
 }
 
+// AddAttachmentRequest carries the file inline; encoding/json decodes the
+// base64 content_base64 field. FileSize is optional and, when set, must match
+// the decoded content length.
 type AddAttachmentRequest struct {
 	Filename string `json:"filename"`
 	FileSize int64  `json:"file_size"`
 	MimeType string `json:"mime_type"`
+	Content  []byte `json:"content_base64"`
 }
 
 type AddAttachmentResponse struct {
@@ -171,7 +179,7 @@ type AddAttachmentResponse struct {
 		MimeType     string `json:"mime_type"`
 		URL          string `json:"url"`
 		ScanResult   string `json:"scan_result"`
-		ScannedAt    string `json:"scanned_at"`
+		CreatedAt    string `json:"created_at"`
 	} `json:"data"`
 }
 
@@ -184,13 +192,48 @@ type GetAttachmentResponse struct {
 		FileSize     int64  `json:"file_size"`
 		MimeType     string `json:"mime_type"`
 		URL          string `json:"url"`
+		ScanResult   string `json:"scan_result"`
+		ScannedAt    string `json:"scanned_at"`
 	} `json:"data"`
 }
 
 type ExportMessagesResponse struct {
 	Status string `json:"status"`
 	Data   struct {
-		Count    int          `json:"count"`
-		Messages []MessageDTO `json:"messages"`
+		Count      int          `json:"count"`
+		Messages   []MessageDTO `json:"messages"`
+		NextCursor string       `json:"next_cursor,omitempty"`
+	} `json:"data"`
+}
+
+// ChatStreamEvent is one frame pushed over the SSE or WebSocket channel stream.
+type ChatStreamEvent struct {
+	Type           string         `json:"type"`
+	EventID        string         `json:"event_id,omitempty"`
+	ChannelID      string         `json:"channel_id"`
+	SequenceNumber int64          `json:"sequence_number,omitempty"`
+	MessageID      string         `json:"message_id,omitempty"`
+	UserID         string         `json:"user_id,omitempty"`
+	Emoji          string         `json:"emoji,omitempty"`
+	Reactions      map[string]int `json:"reactions,omitempty"`
+	Message        *MessageDTO    `json:"message,omitempty"`
+	OccurredAt     string         `json:"occurred_at,omitempty"`
+}
+
+// ChatStreamClientFrame is a frame sent by a WebSocket client.
+type ChatStreamClientFrame struct {
+	Type     string `json:"type"`
+	ServerID string `json:"server_id,omitempty"`
+}
+
+type TypingRequest struct {
+	ServerID string `json:"server_id,omitempty"`
+}
+
+type TypingResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		ChannelID string `json:"channel_id"`
+		UserID    string `json:"user_id"`
 	} `json:"data"`
 }
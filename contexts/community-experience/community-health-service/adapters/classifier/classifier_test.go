@@ -0,0 +1,132 @@
+package classifier
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"solomon/contexts/community-experience/community-health-service/ports"
+)
+
+func approx(a float64, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestLexiconClassifierKeepsKeywordScores(t *testing.T) {
+	output, err := LexiconClassifier{}.Classify(context.Background(), ports.ClassificationInput{
+		Content: "You IDIOT loser, watch out. Thanks though",
+	})
+	if err != nil {
+		t.Fatalf("classify failed: %v", err)
+	}
+	if !approx(output.Toxicity.Harassment, 0.85) || !approx(output.Toxicity.Threats, 0.65) || output.Toxicity.Spam != 0 {
+		t.Fatalf("unexpected toxicity signal: %+v", output.Toxicity)
+	}
+	if !approx(output.Sentiment.Score, 1) || !approx(output.Sentiment.Confidence, 1) {
+		t.Fatalf("unexpected sentiment signal: %+v", output.Sentiment)
+	}
+}
+
+func TestWeightedClassifierLoadsRulesFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "classifier.json")
+	config := `{
+		"sentiment": {
+			"positive": [{"term": "gg", "weight": 2}],
+			"negative": [{"pattern": "\\bugh+\\b", "weight": 1}]
+		},
+		"toxicity": {
+			"spam": [{"pattern": "discord\\.gg/\\w+", "weight": 0.75}],
+			"harassment": [{"term": "noob", "weight": 0.4}, {"term": "uninstall", "weight": 0.4}]
+		}
+	}`
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	classifier, err := LoadWeightedClassifier(path)
+	if err != nil {
+		t.Fatalf("load classifier: %v", err)
+	}
+
+	output, err := classifier.Classify(context.Background(), ports.ClassificationInput{
+		Content: "GG but ughhh noob, uninstall and join discord.gg/free",
+	})
+	if err != nil {
+		t.Fatalf("classify failed: %v", err)
+	}
+	if !approx(output.Sentiment.Score, 1.0/3.0) {
+		t.Fatalf("expected weighted sentiment 1/3, got %v", output.Sentiment.Score)
+	}
+	if !approx(output.Toxicity.Spam, 0.75) || !approx(output.Toxicity.Harassment, 0.8) {
+		t.Fatalf("unexpected toxicity signal: %+v", output.Toxicity)
+	}
+}
+
+func TestWeightedClassifierRejectsInvalidConfig(t *testing.T) {
+	cases := map[string]WeightedConfig{
+		"unknown category": {Toxicity: map[string][]WeightedRule{"rudeness": {{Term: "x", Weight: 1}}}},
+		"zero weight":      {Toxicity: map[string][]WeightedRule{"spam": {{Term: "x"}}}},
+		"term and pattern": {Toxicity: map[string][]WeightedRule{"spam": {{Term: "x", Pattern: "y", Weight: 1}}}},
+		"bad pattern":      {Toxicity: map[string][]WeightedRule{"spam": {{Pattern: "(", Weight: 1}}}},
+	}
+	for name, cfg := range cases {
+		if _, err := NewWeightedClassifier(cfg); err == nil {
+			t.Fatalf("%s: expected config error", name)
+		}
+	}
+}
+
+func TestHTTPClassifierMapsModelResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var req map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req["content"] != "hello" || req["message_id"] != "msg-1" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"sentiment":{"score":0.4,"confidence":0.9,"language":"de","sarcasm":true},"toxicity":{"threats":0.95}}`))
+	}))
+	defer server.Close()
+
+	output, err := HTTPClassifier{Endpoint: server.URL, APIKey: "secret"}.Classify(context.Background(), ports.ClassificationInput{
+		MessageID: "msg-1",
+		Content:   "hello",
+	})
+	if err != nil {
+		t.Fatalf("classify failed: %v", err)
+	}
+	if output.Sentiment.Score != 0.4 || output.Sentiment.Language != "de" || !output.Sentiment.SarcasmFlag || output.Toxicity.Threats != 0.95 {
+		t.Fatalf("unexpected output: %+v", output)
+	}
+}
+
+func TestHTTPClassifierFailureFallsBackToLexicon(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	input := ports.ClassificationInput{Content: "free money, click this"}
+	if _, err := (HTTPClassifier{Endpoint: server.URL}).Classify(context.Background(), input); err == nil {
+		t.Fatalf("expected an error for a 503 response")
+	}
+
+	classifier, err := New(Options{Kind: KindHTTP, Endpoint: server.URL, FallbackToLexicon: true})
+	if err != nil {
+		t.Fatalf("build classifier: %v", err)
+	}
+	output, err := classifier.Classify(context.Background(), input)
+	if err != nil {
+		t.Fatalf("expected lexicon fallback, got %v", err)
+	}
+	if !approx(output.Toxicity.Spam, 0.85) {
+		t.Fatalf("expected lexicon spam score, got %+v", output.Toxicity)
+	}
+}
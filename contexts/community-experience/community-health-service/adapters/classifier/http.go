@@ -0,0 +1,160 @@
+package classifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"solomon/contexts/community-experience/community-health-service/ports"
+)
+
+const defaultHTTPClassifierTimeout = 2 * time.Second
+
+// HTTPClassifier calls an external model server. It POSTs
+//
+//	{"message_id", "server_id", "channel_id", "user_id", "content"}
+//
+// to Endpoint and expects
+//
+//	{"sentiment": {"score", "confidence", "language", "emoji_adjusted", "sarcasm"},
+//	 "toxicity": {"hate_speech", "harassment", "threats", "sexual", "spam", "misinformation"}}
+//
+// with scores in the same ranges as ports.ClassifierOutput.
+type HTTPClassifier struct {
+	Endpoint string
+	// APIKey, when set, is sent as a bearer token.
+	APIKey  string
+	Client  *http.Client
+	Timeout time.Duration
+}
+
+type httpClassifierRequest struct {
+	MessageID string `json:"message_id"`
+	ServerID  string `json:"server_id"`
+	ChannelID string `json:"channel_id"`
+	UserID    string `json:"user_id"`
+	Content   string `json:"content"`
+}
+
+type httpClassifierResponse struct {
+	Sentiment *struct {
+		Score         float64 `json:"score"`
+		Confidence    float64 `json:"confidence"`
+		Language      string  `json:"language"`
+		EmojiAdjusted bool    `json:"emoji_adjusted"`
+		Sarcasm       bool    `json:"sarcasm"`
+	} `json:"sentiment"`
+	Toxicity *struct {
+		HateSpeech     float64 `json:"hate_speech"`
+		Harassment     float64 `json:"harassment"`
+		Threats        float64 `json:"threats"`
+		Sexual         float64 `json:"sexual"`
+		Spam           float64 `json:"spam"`
+		Misinformation float64 `json:"misinformation"`
+	} `json:"toxicity"`
+}
+
+func (c HTTPClassifier) Classify(ctx context.Context, input ports.ClassificationInput) (ports.ClassifierOutput, error) {
+	endpoint := strings.TrimSpace(c.Endpoint)
+	if endpoint == "" {
+		return ports.ClassifierOutput{}, fmt.Errorf("http classifier: endpoint is not configured")
+	}
+	body, err := json.Marshal(httpClassifierRequest{
+		MessageID: input.MessageID,
+		ServerID:  input.ServerID,
+		ChannelID: input.ChannelID,
+		UserID:    input.UserID,
+		Content:   input.Content,
+	})
+	if err != nil {
+		return ports.ClassifierOutput{}, err
+	}
+
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = defaultHTTPClassifierTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return ports.ClassifierOutput{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if key := strings.TrimSpace(c.APIKey); key != "" {
+		req.Header.Set("Authorization", "Bearer "+key)
+	}
+
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return ports.ClassifierOutput{}, fmt.Errorf("http classifier: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+		return ports.ClassifierOutput{}, fmt.Errorf("http classifier: unexpected status %d", resp.StatusCode)
+	}
+
+	var decoded httpClassifierResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&decoded); err != nil {
+		return ports.ClassifierOutput{}, fmt.Errorf("http classifier: decode response: %w", err)
+	}
+	if decoded.Sentiment == nil || decoded.Toxicity == nil {
+		return ports.ClassifierOutput{}, fmt.Errorf("http classifier: response is missing sentiment or toxicity")
+	}
+	return ports.ClassifierOutput{
+		Sentiment: ports.SentimentSignal{
+			Score:         decoded.Sentiment.Score,
+			Confidence:    decoded.Sentiment.Confidence,
+			Language:      decoded.Sentiment.Language,
+			EmojiAdjusted: decoded.Sentiment.EmojiAdjusted,
+			SarcasmFlag:   decoded.Sentiment.Sarcasm,
+		},
+		Toxicity: ports.ToxicitySignal{
+			HateSpeech:     decoded.Toxicity.HateSpeech,
+			Harassment:     decoded.Toxicity.Harassment,
+			Threats:        decoded.Toxicity.Threats,
+			Sexual:         decoded.Toxicity.Sexual,
+			Spam:           decoded.Toxicity.Spam,
+			Misinformation: decoded.Toxicity.Misinformation,
+		},
+	}, nil
+}
+
+// Fallback tries Primary and uses Secondary when it fails, so an external
+// model outage degrades to the lexicon instead of stopping ingestion.
+type Fallback struct {
+	Primary   ports.MessageClassifier
+	Secondary ports.MessageClassifier
+	Logger    *slog.Logger
+}
+
+func (f Fallback) Classify(ctx context.Context, input ports.ClassificationInput) (ports.ClassifierOutput, error) {
+	output, err := f.Primary.Classify(ctx, input)
+	if err == nil || f.Secondary == nil {
+		return output, err
+	}
+	logger := f.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	logger.Warn("primary message classifier failed, using fallback",
+		"event", "community_health_classifier_fallback",
+		"module", "community-experience/community-health-service",
+		"layer", "adapter",
+		"message_id", input.MessageID,
+		"error", err.Error(),
+	)
+	return f.Secondary.Classify(ctx, input)
+}
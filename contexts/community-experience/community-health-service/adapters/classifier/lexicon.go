@@ -0,0 +1,80 @@
+package classifier
+
+import (
+	"context"
+	"math"
+	"strings"
+
+	"solomon/contexts/community-experience/community-health-service/ports"
+)
+
+var (
+	lexiconPositiveWords = []string{"thanks", "great", "awesome", "love", "helpful", "nice", "good", "amazing", "happy"}
+	lexiconNegativeWords = []string{"hate", "stupid", "terrible", "awful", "bad", "angry", "worst", "toxic"}
+
+	lexiconToxicityKeywords = struct {
+		hate, harassment, threats, sexual, spam, misinformation []string
+	}{
+		hate:           []string{"slur", "racist"},
+		harassment:     []string{"idiot", "loser", "trash", "stupid"},
+		threats:        []string{"kill", "hurt", "fight you", "watch out"},
+		sexual:         []string{"sexual", "explicit", "nude"},
+		spam:           []string{"buy now", "free money", "click this"},
+		misinformation: []string{"fake cure", "hoax"},
+	}
+)
+
+// LexiconClassifier is the built-in keyword heuristic: substring hits against
+// fixed word lists. It needs no configuration and never fails.
+type LexiconClassifier struct{}
+
+func (LexiconClassifier) Classify(_ context.Context, input ports.ClassificationInput) (ports.ClassifierOutput, error) {
+	lower := strings.ToLower(input.Content)
+
+	posHits := countHits(lower, lexiconPositiveWords)
+	negHits := countHits(lower, lexiconNegativeWords)
+	score := 0.0
+	if totalHits := posHits + negHits; totalHits > 0 {
+		score = float64(posHits-negHits) / float64(totalHits)
+	}
+	score = math.Max(-1, math.Min(1, score))
+
+	keywords := lexiconToxicityKeywords
+	return ports.ClassifierOutput{
+		Sentiment: ports.SentimentSignal{
+			Score:         score,
+			Confidence:    0.7 + math.Min(0.3, math.Abs(score)*0.3),
+			Language:      "en",
+			EmojiAdjusted: strings.Contains(input.Content, ":") || strings.Contains(input.Content, "😊"),
+			SarcasmFlag:   strings.Contains(lower, "/s"),
+		},
+		Toxicity: ports.ToxicitySignal{
+			HateSpeech:     keywordScore(lower, keywords.hate),
+			Harassment:     keywordScore(lower, keywords.harassment),
+			Threats:        keywordScore(lower, keywords.threats),
+			Sexual:         keywordScore(lower, keywords.sexual),
+			Spam:           keywordScore(lower, keywords.spam),
+			Misinformation: keywordScore(lower, keywords.misinformation),
+		},
+	}, nil
+}
+
+func countHits(content string, words []string) int {
+	hits := 0
+	for _, word := range words {
+		if strings.Contains(content, word) {
+			hits++
+		}
+	}
+	return hits
+}
+
+// keywordScore starts at 0.65 for one hit and adds 0.2 per extra hit, so a
+// single keyword is severity 1 and two are severity 2.
+func keywordScore(content string, keywords []string) float64 {
+	hits := countHits(content, keywords)
+	if hits == 0 {
+		return 0
+	}
+	return math.Min(1, 0.45+float64(hits)*0.2)
+}
@@ -0,0 +1,55 @@
+package classifier
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"solomon/contexts/community-experience/community-health-service/ports"
+)
+
+const (
+	KindLexicon  = "lexicon"
+	KindWeighted = "weighted"
+	KindHTTP     = "http"
+)
+
+// Options selects and configures a classifier by name.
+type Options struct {
+	Kind string
+	// ConfigPath is the WeightedConfig file for KindWeighted.
+	ConfigPath string
+	// Endpoint, APIKey and Timeout configure KindHTTP.
+	Endpoint string
+	APIKey   string
+	Timeout  time.Duration
+	// FallbackToLexicon wraps KindHTTP so model-server failures fall back to
+	// the lexicon instead of failing ingestion.
+	FallbackToLexicon bool
+	Logger            *slog.Logger
+}
+
+// New builds the classifier named by opts.Kind; an empty kind is the lexicon.
+func New(opts Options) (ports.MessageClassifier, error) {
+	switch strings.ToLower(strings.TrimSpace(opts.Kind)) {
+	case "", KindLexicon:
+		return LexiconClassifier{}, nil
+	case KindWeighted:
+		if strings.TrimSpace(opts.ConfigPath) == "" {
+			return nil, fmt.Errorf("weighted classifier requires a config path")
+		}
+		return LoadWeightedClassifier(opts.ConfigPath)
+	case KindHTTP:
+		if strings.TrimSpace(opts.Endpoint) == "" {
+			return nil, fmt.Errorf("http classifier requires an endpoint")
+		}
+		primary := HTTPClassifier{Endpoint: opts.Endpoint, APIKey: opts.APIKey, Timeout: opts.Timeout}
+		if !opts.FallbackToLexicon {
+			return primary, nil
+		}
+		return Fallback{Primary: primary, Secondary: LexiconClassifier{}, Logger: opts.Logger}, nil
+	default:
+		return nil, fmt.Errorf("unknown classifier kind %q", opts.Kind)
+	}
+}
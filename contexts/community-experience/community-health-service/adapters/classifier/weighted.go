@@ -0,0 +1,176 @@
+package classifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"strings"
+
+	"solomon/contexts/community-experience/community-health-service/ports"
+)
+
+// WeightedRule matches either a case-insensitive substring (Term) or a
+// regular expression (Pattern) and contributes Weight when it matches.
+type WeightedRule struct {
+	Term    string  `json:"term,omitempty"`
+	Pattern string  `json:"pattern,omitempty"`
+	Weight  float64 `json:"weight"`
+}
+
+// WeightedConfig is the file format read by LoadWeightedClassifier:
+//
+//	{
+//	  "sentiment": {"positive": [{"term": "thanks", "weight": 1}],
+//	                "negative": [{"pattern": "\\bugh+\\b", "weight": 0.5}]},
+//	  "toxicity": {"harassment": [{"term": "idiot", "weight": 0.65}]}
+//	}
+//
+// Toxicity keys are hate_speech, harassment, threats, sexual, spam and
+// misinformation; a category scores the sum of its matched weights, capped
+// at 1. Sentiment is (positive - negative) / (positive + negative).
+type WeightedConfig struct {
+	Sentiment struct {
+		Positive []WeightedRule `json:"positive"`
+		Negative []WeightedRule `json:"negative"`
+	} `json:"sentiment"`
+	Toxicity map[string][]WeightedRule `json:"toxicity"`
+}
+
+type compiledRule struct {
+	term    string
+	pattern *regexp.Regexp
+	weight  float64
+}
+
+func (r compiledRule) matches(lower string) bool {
+	if r.pattern != nil {
+		return r.pattern.MatchString(lower)
+	}
+	return strings.Contains(lower, r.term)
+}
+
+// WeightedClassifier scores content with configurable weighted terms and
+// regular expressions, so lexicons can be tuned without a code change.
+type WeightedClassifier struct {
+	positive []compiledRule
+	negative []compiledRule
+	toxicity map[string][]compiledRule
+}
+
+// LoadWeightedClassifier reads a WeightedConfig JSON file.
+func LoadWeightedClassifier(path string) (*WeightedClassifier, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read classifier config: %w", err)
+	}
+	var cfg WeightedConfig
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("decode classifier config %s: %w", path, err)
+	}
+	return NewWeightedClassifier(cfg)
+}
+
+// NewWeightedClassifier validates and compiles cfg.
+func NewWeightedClassifier(cfg WeightedConfig) (*WeightedClassifier, error) {
+	positive, err := compileRules("sentiment.positive", cfg.Sentiment.Positive)
+	if err != nil {
+		return nil, err
+	}
+	negative, err := compileRules("sentiment.negative", cfg.Sentiment.Negative)
+	if err != nil {
+		return nil, err
+	}
+	toxicity := make(map[string][]compiledRule, len(cfg.Toxicity))
+	for category, rules := range cfg.Toxicity {
+		if !knownToxicityCategory(category) {
+			return nil, fmt.Errorf("classifier config: unknown toxicity category %q", category)
+		}
+		compiled, err := compileRules("toxicity."+category, rules)
+		if err != nil {
+			return nil, err
+		}
+		toxicity[category] = compiled
+	}
+	return &WeightedClassifier{positive: positive, negative: negative, toxicity: toxicity}, nil
+}
+
+func (c *WeightedClassifier) Classify(_ context.Context, input ports.ClassificationInput) (ports.ClassifierOutput, error) {
+	lower := strings.ToLower(input.Content)
+
+	positive := matchedWeight(lower, c.positive)
+	negative := matchedWeight(lower, c.negative)
+	score := 0.0
+	if total := positive + negative; total > 0 {
+		score = (positive - negative) / total
+	}
+	return ports.ClassifierOutput{
+		Sentiment: ports.SentimentSignal{
+			Score:         score,
+			Confidence:    0.7 + math.Min(0.3, math.Abs(score)*0.3),
+			Language:      "en",
+			EmojiAdjusted: strings.Contains(input.Content, ":") || strings.Contains(input.Content, "😊"),
+			SarcasmFlag:   strings.Contains(lower, "/s"),
+		},
+		Toxicity: ports.ToxicitySignal{
+			HateSpeech:     c.categoryScore(lower, "hate_speech"),
+			Harassment:     c.categoryScore(lower, "harassment"),
+			Threats:        c.categoryScore(lower, "threats"),
+			Sexual:         c.categoryScore(lower, "sexual"),
+			Spam:           c.categoryScore(lower, "spam"),
+			Misinformation: c.categoryScore(lower, "misinformation"),
+		},
+	}, nil
+}
+
+func (c *WeightedClassifier) categoryScore(lower string, category string) float64 {
+	return math.Min(1, matchedWeight(lower, c.toxicity[category]))
+}
+
+func matchedWeight(lower string, rules []compiledRule) float64 {
+	total := 0.0
+	for _, rule := range rules {
+		if rule.matches(lower) {
+			total += rule.weight
+		}
+	}
+	return total
+}
+
+func compileRules(section string, rules []WeightedRule) ([]compiledRule, error) {
+	out := make([]compiledRule, 0, len(rules))
+	for index, rule := range rules {
+		term := strings.ToLower(strings.TrimSpace(rule.Term))
+		pattern := strings.TrimSpace(rule.Pattern)
+		if (term == "") == (pattern == "") {
+			return nil, fmt.Errorf("classifier config: %s[%d] needs exactly one of term or pattern", section, index)
+		}
+		if rule.Weight <= 0 || math.IsNaN(rule.Weight) || math.IsInf(rule.Weight, 0) {
+			return nil, fmt.Errorf("classifier config: %s[%d] weight must be positive", section, index)
+		}
+		compiled := compiledRule{term: term, weight: rule.Weight}
+		if pattern != "" {
+			expr, err := regexp.Compile("(?i)" + pattern)
+			if err != nil {
+				return nil, fmt.Errorf("classifier config: %s[%d] pattern: %w", section, index, err)
+			}
+			compiled.pattern = expr
+		}
+		out = append(out, compiled)
+	}
+	return out, nil
+}
+
+func knownToxicityCategory(category string) bool {
+	switch category {
+	case "hate_speech", "harassment", "threats", "sexual", "spam", "misinformation":
+		return true
+	default:
+		return false
+	}
+}
@@ -93,6 +93,8 @@ func (h Handler) GetCommunityHealthScoreHandler(
 	resp.Data.Metrics.EngagementGini = item.EngagementGini
 	resp.Data.Metrics.AvgModerationLatencyHours = item.AvgModerationLatencyHr
 	resp.Data.Alerts = item.Alerts
+	resp.Data.OpenAlerts = item.OpenAlerts
+	resp.Data.CategoryFeedback = toCategoryFeedbackDTOs(item.CategoryFeedback)
 	resp.Data.CalculatedAt = item.CalculatedAt.UTC().Format(time.RFC3339)
 	return resp, nil
 }
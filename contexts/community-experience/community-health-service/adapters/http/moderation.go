@@ -0,0 +1,144 @@
+package httpadapter
+
+import (
+	"context"
+	"time"
+
+	"solomon/contexts/community-experience/community-health-service/ports"
+	httptransport "solomon/contexts/community-experience/community-health-service/transport/http"
+)
+
+func (h Handler) ListAlertsHandler(
+	ctx context.Context,
+	serverID string,
+	status string,
+) (httptransport.ListAlertsResponse, error) {
+	items, err := h.Service.ListAlerts(ctx, serverID, status)
+	if err != nil {
+		return httptransport.ListAlertsResponse{}, err
+	}
+	resp := httptransport.ListAlertsResponse{Status: "success"}
+	resp.Data.Alerts = make([]httptransport.AlertDTO, 0, len(items))
+	for _, item := range items {
+		resp.Data.Alerts = append(resp.Data.Alerts, toAlertDTO(item))
+	}
+	return resp, nil
+}
+
+func (h Handler) TransitionAlertHandler(
+	ctx context.Context,
+	idempotencyKey string,
+	serverID string,
+	alertID string,
+	moderatorID string,
+	status string,
+	req httptransport.AlertTransitionRequest,
+) (httptransport.AlertResponse, error) {
+	item, err := h.Service.TransitionAlert(ctx, idempotencyKey, ports.AlertTransitionInput{
+		ServerID:    serverID,
+		AlertID:     alertID,
+		ModeratorID: moderatorID,
+		Status:      status,
+		Note:        req.Note,
+	})
+	if err != nil {
+		return httptransport.AlertResponse{}, err
+	}
+	return httptransport.AlertResponse{Status: "success", Data: toAlertDTO(item)}, nil
+}
+
+func (h Handler) RecordModerationFeedbackHandler(
+	ctx context.Context,
+	idempotencyKey string,
+	serverID string,
+	messageID string,
+	moderatorID string,
+	req httptransport.ModerationFeedbackRequest,
+) (httptransport.ModerationFeedbackResponse, error) {
+	result, err := h.Service.RecordModerationFeedback(ctx, idempotencyKey, ports.ModerationFeedbackInput{
+		ServerID:     serverID,
+		MessageID:    messageID,
+		ModeratorID:  moderatorID,
+		FeedbackType: req.FeedbackType,
+		Category:     req.Category,
+		Note:         req.Note,
+	})
+	if err != nil {
+		return httptransport.ModerationFeedbackResponse{}, err
+	}
+	resp := httptransport.ModerationFeedbackResponse{Status: "success"}
+	resp.Data.FeedbackID = result.Feedback.FeedbackID
+	resp.Data.MessageID = result.Feedback.MessageID
+	resp.Data.ModeratorID = result.Feedback.ModeratorID
+	resp.Data.FeedbackType = result.Feedback.FeedbackType
+	resp.Data.Category = result.Feedback.Category
+	resp.Data.Note = result.Feedback.Note
+	resp.Data.CreatedAt = result.Feedback.CreatedAt.UTC().Format(time.RFC3339)
+	resp.Data.Tuning = toModerationTuningDTO(result.Tuning)
+	return resp, nil
+}
+
+func (h Handler) GetModerationTuningHandler(
+	ctx context.Context,
+	serverID string,
+) (httptransport.ModerationTuningResponse, error) {
+	item, err := h.Service.GetModerationTuning(ctx, serverID)
+	if err != nil {
+		return httptransport.ModerationTuningResponse{}, err
+	}
+	return httptransport.ModerationTuningResponse{Status: "success", Data: toModerationTuningDTO(item)}, nil
+}
+
+func toAlertDTO(item ports.RealTimeAlert) httptransport.AlertDTO {
+	dto := httptransport.AlertDTO{
+		AlertID:        item.AlertID,
+		AlertType:      item.AlertType,
+		ServerID:       item.ServerID,
+		ChannelID:      item.ChannelID,
+		MessageID:      item.MessageID,
+		Severity:       item.Severity,
+		Status:         item.Status,
+		ResponseHint:   item.ResponseHint,
+		TriggeredAt:    item.TriggeredAt.UTC().Format(time.RFC3339),
+		AcknowledgedBy: item.AcknowledgedBy,
+		ClosedBy:       item.ClosedBy,
+		Note:           item.Note,
+	}
+	if item.AcknowledgedAt != nil {
+		dto.AcknowledgedAt = item.AcknowledgedAt.UTC().Format(time.RFC3339)
+	}
+	if item.ClosedAt != nil {
+		dto.ClosedAt = item.ClosedAt.UTC().Format(time.RFC3339)
+	}
+	return dto
+}
+
+func toModerationTuningDTO(item ports.ModerationTuning) httptransport.ModerationTuningDTO {
+	dto := httptransport.ModerationTuningDTO{
+		ServerID:   item.ServerID,
+		Thresholds: make(map[string]float64, len(item.Thresholds)),
+		Categories: toCategoryFeedbackDTOs(item.Categories),
+	}
+	for category, threshold := range item.Thresholds {
+		dto.Thresholds[category] = threshold
+	}
+	if !item.UpdatedAt.IsZero() {
+		dto.UpdatedAt = item.UpdatedAt.UTC().Format(time.RFC3339)
+	}
+	return dto
+}
+
+func toCategoryFeedbackDTOs(items []ports.CategoryFeedbackMetrics) []httptransport.CategoryFeedbackMetrics {
+	out := make([]httptransport.CategoryFeedbackMetrics, 0, len(items))
+	for _, item := range items {
+		out = append(out, httptransport.CategoryFeedbackMetrics{
+			Category:       item.Category,
+			Threshold:      item.Threshold,
+			Confirmed:      item.Confirmed,
+			FalsePositives: item.FalsePositives,
+			FalseNegatives: item.FalseNegatives,
+			Precision:      item.Precision,
+		})
+	}
+	return out
+}
@@ -0,0 +1,192 @@
+package httpadapter
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"solomon/contexts/community-experience/community-health-service/ports"
+	httptransport "solomon/contexts/community-experience/community-health-service/transport/http"
+)
+
+// weeklyReportCSVHeader lists one row per report; trends and risk users stay
+// in the JSON form.
+var weeklyReportCSVHeader = []string{
+	"report_id",
+	"week_start_date",
+	"week_end_date",
+	"health_score",
+	"health_category",
+	"total_messages",
+	"active_users",
+	"positive_pct",
+	"negative_pct",
+	"toxicity_pct",
+	"avg_sentiment",
+	"alerts_total",
+	"alerts_open",
+	"alerts_resolved",
+	"alerts_dismissed",
+	"avg_ack_latency_hours",
+	"health_score_change",
+	"top_risk_user_id",
+	"generated_at",
+}
+
+func (h Handler) ListWeeklyReportsHandler(
+	ctx context.Context,
+	serverID string,
+	cursor string,
+	limit int,
+) (httptransport.ListWeeklyReportsResponse, error) {
+	page, err := h.Service.ListWeeklyReports(ctx, ports.ListWeeklyReportsInput{
+		ServerID:   serverID,
+		BeforeWeek: cursor,
+		Limit:      limit,
+	})
+	if err != nil {
+		return httptransport.ListWeeklyReportsResponse{}, err
+	}
+	resp := httptransport.ListWeeklyReportsResponse{Status: "success"}
+	resp.Data.Reports = make([]httptransport.WeeklyReportDTO, 0, len(page.Reports))
+	for _, item := range page.Reports {
+		resp.Data.Reports = append(resp.Data.Reports, toWeeklyReportDTO(item))
+	}
+	resp.Data.NextCursor = page.NextCursor
+	return resp, nil
+}
+
+func (h Handler) GetWeeklyReportHandler(
+	ctx context.Context,
+	serverID string,
+	reportID string,
+) (httptransport.WeeklyReportResponse, error) {
+	item, err := h.Service.GetWeeklyReport(ctx, serverID, reportID)
+	if err != nil {
+		return httptransport.WeeklyReportResponse{}, err
+	}
+	return httptransport.WeeklyReportResponse{Status: "success", Data: toWeeklyReportDTO(item)}, nil
+}
+
+// ExportWeeklyReportsCSV writes the same page ListWeeklyReportsHandler would
+// return as CSV and returns the cursor for the next page.
+func (h Handler) ExportWeeklyReportsCSV(
+	ctx context.Context,
+	w io.Writer,
+	serverID string,
+	cursor string,
+	limit int,
+) (string, error) {
+	page, err := h.Service.ListWeeklyReports(ctx, ports.ListWeeklyReportsInput{
+		ServerID:   serverID,
+		BeforeWeek: cursor,
+		Limit:      limit,
+	})
+	if err != nil {
+		return "", err
+	}
+	writer := csv.NewWriter(w)
+	if err := writer.Write(weeklyReportCSVHeader); err != nil {
+		return "", err
+	}
+	for _, item := range page.Reports {
+		if err := writer.Write(weeklyReportCSVRow(item)); err != nil {
+			return "", err
+		}
+	}
+	writer.Flush()
+	return page.NextCursor, writer.Error()
+}
+
+func weeklyReportCSVRow(item ports.WeeklyHealthReport) []string {
+	scoreChange := ""
+	for _, trend := range item.Trends {
+		if trend.Metric == "health_score" {
+			scoreChange = formatFloat(trend.Change)
+		}
+	}
+	topRiskUser := ""
+	if len(item.TopRiskUsers) > 0 {
+		topRiskUser = item.TopRiskUsers[0].UserID
+	}
+	return []string{
+		item.ReportID,
+		item.WeekStartDate,
+		item.WeekEndDate,
+		strconv.Itoa(item.Metrics.HealthScore),
+		item.Metrics.HealthCategory,
+		strconv.Itoa(item.Metrics.TotalMessages),
+		strconv.Itoa(item.Metrics.ActiveUsers),
+		formatFloat(item.Metrics.PositivePct),
+		formatFloat(item.Metrics.NegativePct),
+		formatFloat(item.Metrics.ToxicityPct),
+		formatFloat(item.Metrics.AvgSentiment),
+		strconv.Itoa(item.Alerts.Total),
+		strconv.Itoa(item.Alerts.Open),
+		strconv.Itoa(item.Alerts.Resolved),
+		strconv.Itoa(item.Alerts.Dismissed),
+		formatFloat(item.Metrics.AvgAckLatencyHours),
+		scoreChange,
+		topRiskUser,
+		item.GeneratedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func toWeeklyReportDTO(item ports.WeeklyHealthReport) httptransport.WeeklyReportDTO {
+	dto := httptransport.WeeklyReportDTO{
+		ReportID:      item.ReportID,
+		ServerID:      item.ServerID,
+		WeekStartDate: item.WeekStartDate,
+		WeekEndDate:   item.WeekEndDate,
+		Metrics: httptransport.WeeklyReportMetrics{
+			TotalMessages:      item.Metrics.TotalMessages,
+			ActiveUsers:        item.Metrics.ActiveUsers,
+			PositivePct:        item.Metrics.PositivePct,
+			NegativePct:        item.Metrics.NegativePct,
+			ToxicityPct:        item.Metrics.ToxicityPct,
+			AvgSentiment:       item.Metrics.AvgSentiment,
+			HealthScore:        item.Metrics.HealthScore,
+			HealthCategory:     item.Metrics.HealthCategory,
+			AvgAckLatencyHours: item.Metrics.AvgAckLatencyHours,
+		},
+		PreviousWeekStartDate: item.PreviousWeekStartDate,
+		Trends:                make([]httptransport.MetricTrend, 0, len(item.Trends)),
+		TopRiskUsers:          make([]httptransport.RiskUserSummary, 0, len(item.TopRiskUsers)),
+		Alerts: httptransport.AlertSummary{
+			Total:              item.Alerts.Total,
+			Open:               item.Alerts.Open,
+			Acknowledged:       item.Alerts.Acknowledged,
+			Resolved:           item.Alerts.Resolved,
+			Dismissed:          item.Alerts.Dismissed,
+			ByType:             item.Alerts.ByType,
+			BySeverity:         item.Alerts.BySeverity,
+			AvgAckLatencyHours: item.Alerts.AvgAckLatencyHours,
+		},
+		GeneratedAt: item.GeneratedAt.UTC().Format(time.RFC3339),
+	}
+	for _, trend := range item.Trends {
+		dto.Trends = append(dto.Trends, httptransport.MetricTrend{
+			Metric:    trend.Metric,
+			Current:   trend.Current,
+			Previous:  trend.Previous,
+			Change:    trend.Change,
+			Direction: trend.Direction,
+		})
+	}
+	for _, user := range item.TopRiskUsers {
+		dto.TopRiskUsers = append(dto.TopRiskUsers, httptransport.RiskUserSummary{
+			UserID:            user.UserID,
+			RiskScore:         user.RiskScore,
+			RiskLevel:         user.RiskLevel,
+			ToxicMessageCount: user.ToxicMessageCount,
+			WarningCount:      user.WarningCount,
+		})
+	}
+	return dto
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,258 @@
+package memory
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	domainerrors "solomon/contexts/community-experience/community-health-service/domain/errors"
+	"solomon/contexts/community-experience/community-health-service/ports"
+)
+
+const (
+	defaultFlagThreshold = 0.5
+	// Each false positive raises a category's flag threshold by one step and
+	// each false negative lowers it, within the bounds below.
+	thresholdStep       = 0.02
+	minFlagThreshold    = 0.3
+	maxFlagThreshold    = 0.7
+	latencyWindow       = 7 * 24 * time.Hour
+	latencyTargetHours  = 1.0
+	latencyCeilingHours = 24.0
+	// neutralLatencyHealth applies while a server has no recent alerts.
+	neutralLatencyHealth = 12
+)
+
+func (s *Store) ListAlerts(ctx context.Context, serverID string, status string) ([]ports.RealTimeAlert, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	items := make([]ports.RealTimeAlert, 0, len(s.alertsByServer[serverID]))
+	for _, alertID := range s.alertsByServer[serverID] {
+		alert, ok := s.alertsByID[alertID]
+		if !ok || (status != "" && alert.Status != status) {
+			continue
+		}
+		items = append(items, alert)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].TriggeredAt.After(items[j].TriggeredAt)
+	})
+	return items, nil
+}
+
+func (s *Store) TransitionAlert(
+	ctx context.Context,
+	input ports.AlertTransitionInput,
+	now time.Time,
+) (ports.RealTimeAlert, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	alert, ok := s.alertsByID[input.AlertID]
+	if !ok || alert.ServerID != input.ServerID {
+		return ports.RealTimeAlert{}, domainerrors.ErrNotFound
+	}
+	if !canTransitionAlert(alert.Status, input.Status) {
+		return ports.RealTimeAlert{}, domainerrors.ErrConflict
+	}
+
+	ts := now.UTC()
+	if alert.AcknowledgedAt == nil {
+		alert.AcknowledgedAt = &ts
+		alert.AcknowledgedBy = input.ModeratorID
+	}
+	if input.Status == ports.AlertStatusResolved || input.Status == ports.AlertStatusDismissed {
+		alert.ClosedAt = &ts
+		alert.ClosedBy = input.ModeratorID
+	}
+	alert.Status = input.Status
+	if input.Note != "" {
+		alert.Note = input.Note
+	}
+	s.alertsByID[alert.AlertID] = alert
+	s.recomputeHealthScore(alert.ServerID, now)
+	return alert, nil
+}
+
+func (s *Store) RecordModerationFeedback(
+	ctx context.Context,
+	input ports.ModerationFeedbackInput,
+	now time.Time,
+) (ports.ModerationFeedbackResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	toxicity, ok := s.toxicityByMessage[input.MessageID]
+	if !ok || toxicity.ServerID != input.ServerID {
+		return ports.ModerationFeedbackResult{}, domainerrors.ErrNotFound
+	}
+	flagged := toxicity.MaxSeverity > 0
+	category := input.Category
+	switch input.FeedbackType {
+	case ports.FeedbackConfirmed, ports.FeedbackFalsePositive:
+		if !flagged || (category != "" && category != toxicity.PrimaryCategory) {
+			return ports.ModerationFeedbackResult{}, domainerrors.ErrConflict
+		}
+		category = toxicity.PrimaryCategory
+	case ports.FeedbackFalseNegative:
+		if flagged && category == toxicity.PrimaryCategory {
+			return ports.ModerationFeedbackResult{}, domainerrors.ErrConflict
+		}
+	default:
+		return ports.ModerationFeedbackResult{}, domainerrors.ErrInvalidRequest
+	}
+
+	key := strings.Join([]string{input.ServerID, input.MessageID, input.ModeratorID}, "|")
+	if _, exists := s.feedbackByKey[key]; exists {
+		return ports.ModerationFeedbackResult{}, domainerrors.ErrConflict
+	}
+	feedback := ports.ModerationFeedback{
+		FeedbackID:   "feedback_" + s.nextID("m49"),
+		ServerID:     input.ServerID,
+		MessageID:    input.MessageID,
+		ModeratorID:  input.ModeratorID,
+		FeedbackType: input.FeedbackType,
+		Category:     category,
+		Note:         input.Note,
+		CreatedAt:    now.UTC(),
+	}
+	s.feedbackByID[feedback.FeedbackID] = feedback
+	s.feedbackByKey[key] = feedback.FeedbackID
+	s.feedbackByServer[input.ServerID] = append(s.feedbackByServer[input.ServerID], feedback.FeedbackID)
+
+	thresholds, ok := s.thresholdsByServer[input.ServerID]
+	if !ok {
+		thresholds = make(map[string]float64)
+		s.thresholdsByServer[input.ServerID] = thresholds
+	}
+	current := defaultFlagThreshold
+	if value, ok := thresholds[category]; ok {
+		current = value
+	}
+	switch input.FeedbackType {
+	case ports.FeedbackFalsePositive:
+		thresholds[category] = round2(math.Min(maxFlagThreshold, current+thresholdStep))
+	case ports.FeedbackFalseNegative:
+		thresholds[category] = round2(math.Max(minFlagThreshold, current-thresholdStep))
+	default:
+		thresholds[category] = current
+	}
+	s.tuningUpdatedAt[input.ServerID] = now.UTC()
+	s.recomputeHealthScore(input.ServerID, now)
+
+	return ports.ModerationFeedbackResult{
+		Feedback: feedback,
+		Tuning:   s.moderationTuning(input.ServerID),
+	}, nil
+}
+
+func (s *Store) GetModerationTuning(ctx context.Context, serverID string) (ports.ModerationTuning, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.moderationTuning(serverID), nil
+}
+
+func (s *Store) moderationTuning(serverID string) ports.ModerationTuning {
+	thresholds := make(map[string]float64, len(s.thresholdsByServer[serverID]))
+	for category, threshold := range s.thresholdsByServer[serverID] {
+		thresholds[category] = threshold
+	}
+	return ports.ModerationTuning{
+		ServerID:   serverID,
+		Thresholds: thresholds,
+		Categories: s.categoryFeedback(serverID),
+		UpdatedAt:  s.tuningUpdatedAt[serverID],
+	}
+}
+
+// categoryFeedback aggregates a server's feedback per category, ordered by
+// category name.
+func (s *Store) categoryFeedback(serverID string) []ports.CategoryFeedbackMetrics {
+	byCategory := make(map[string]*ports.CategoryFeedbackMetrics)
+	for _, feedbackID := range s.feedbackByServer[serverID] {
+		feedback := s.feedbackByID[feedbackID]
+		metrics, ok := byCategory[feedback.Category]
+		if !ok {
+			metrics = &ports.CategoryFeedbackMetrics{Category: feedback.Category}
+			byCategory[feedback.Category] = metrics
+		}
+		switch feedback.FeedbackType {
+		case ports.FeedbackConfirmed:
+			metrics.Confirmed++
+		case ports.FeedbackFalsePositive:
+			metrics.FalsePositives++
+		case ports.FeedbackFalseNegative:
+			metrics.FalseNegatives++
+		}
+	}
+
+	items := make([]ports.CategoryFeedbackMetrics, 0, len(byCategory))
+	for category, metrics := range byCategory {
+		metrics.Threshold = defaultFlagThreshold
+		if threshold, ok := s.thresholdsByServer[serverID][category]; ok {
+			metrics.Threshold = threshold
+		}
+		if reviewed := metrics.Confirmed + metrics.FalsePositives; reviewed > 0 {
+			precision := round2(float64(metrics.Confirmed) / float64(reviewed))
+			metrics.Precision = &precision
+		}
+		items = append(items, *metrics)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Category < items[j].Category })
+	return items
+}
+
+// alertLatencyHealth scores how quickly moderators acknowledge alerts raised
+// in the last week. Full marks at or under an hour on average, nothing at a
+// day or more; alerts still waiting count their age so far.
+func (s *Store) alertLatencyHealth(serverID string, now time.Time) (float64, int) {
+	now = now.UTC()
+	total := 0.0
+	count := 0
+	for _, alertID := range s.alertsByServer[serverID] {
+		alert, ok := s.alertsByID[alertID]
+		if !ok || now.Sub(alert.TriggeredAt) > latencyWindow {
+			continue
+		}
+		acknowledgedAt := now
+		if alert.AcknowledgedAt != nil {
+			acknowledgedAt = alert.AcknowledgedAt.UTC()
+		}
+		total += math.Max(0, acknowledgedAt.Sub(alert.TriggeredAt).Hours())
+		count++
+	}
+	if count == 0 {
+		return 0, neutralLatencyHealth
+	}
+	avg := total / float64(count)
+	ratio := (latencyCeilingHours - avg) / (latencyCeilingHours - latencyTargetHours)
+	return round2(avg), clampInt(int(ratio*25.0), 0, 25)
+}
+
+func (s *Store) openAlertCount(serverID string) int {
+	open := 0
+	for _, alertID := range s.alertsByServer[serverID] {
+		switch s.alertsByID[alertID].Status {
+		case ports.AlertStatusSent, ports.AlertStatusAcknowledged:
+			open++
+		}
+	}
+	return open
+}
+
+func canTransitionAlert(from string, to string) bool {
+	switch from {
+	case ports.AlertStatusSent:
+		return to == ports.AlertStatusAcknowledged ||
+			to == ports.AlertStatusResolved ||
+			to == ports.AlertStatusDismissed
+	case ports.AlertStatusAcknowledged:
+		return to == ports.AlertStatusResolved || to == ports.AlertStatusDismissed
+	default:
+		return false
+	}
+}
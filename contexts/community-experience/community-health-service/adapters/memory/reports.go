@@ -0,0 +1,198 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	domainerrors "solomon/contexts/community-experience/community-health-service/domain/errors"
+	"solomon/contexts/community-experience/community-health-service/ports"
+)
+
+const (
+	topRiskUserLimit       = 5
+	defaultReportPageLimit = 12
+)
+
+func (s *Store) ListActiveServers(ctx context.Context) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	seen := make(map[string]struct{})
+	for serverID, ids := range s.messageIDsByServer {
+		if len(ids) > 0 {
+			seen[serverID] = struct{}{}
+		}
+	}
+	for serverID := range s.alertsByServer {
+		seen[serverID] = struct{}{}
+	}
+	for serverID := range s.healthByServer {
+		seen[serverID] = struct{}{}
+	}
+	items := make([]string, 0, len(seen))
+	for serverID := range seen {
+		items = append(items, serverID)
+	}
+	sort.Strings(items)
+	return items, nil
+}
+
+func (s *Store) GetWeeklyActivity(
+	ctx context.Context,
+	serverID string,
+	weekStart time.Time,
+	weekEnd time.Time,
+) (ports.WeeklyActivity, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	activity := ports.WeeklyActivity{
+		ServerID:  serverID,
+		WeekStart: weekStart.UTC(),
+		WeekEnd:   weekEnd.UTC(),
+	}
+	inWeek := func(ts time.Time) bool {
+		return !ts.Before(activity.WeekStart) && ts.Before(activity.WeekEnd)
+	}
+
+	users := make(map[string]struct{})
+	positive, negative, toxic := 0, 0, 0
+	sentimentTotal := 0.0
+	for _, messageID := range s.messageIDsByServer[serverID] {
+		sentiment, ok := s.sentimentByMessage[messageID]
+		if !ok || !inWeek(sentiment.AnalyzedAt) {
+			continue
+		}
+		activity.TotalMessages++
+		users[sentiment.UserID] = struct{}{}
+		sentimentTotal += sentiment.SentimentScore
+		switch {
+		case sentiment.SentimentScore > 0.1:
+			positive++
+		case sentiment.SentimentScore < -0.1:
+			negative++
+		}
+		if toxicity, ok := s.toxicityByMessage[messageID]; ok && toxicity.MaxSeverity > 0 {
+			toxic++
+		}
+	}
+	activity.ActiveUsers = len(users)
+	if activity.TotalMessages > 0 {
+		total := float64(activity.TotalMessages)
+		activity.PositivePct = round2(float64(positive) / total)
+		activity.NegativePct = round2(float64(negative) / total)
+		activity.ToxicityPct = round2(float64(toxic) / total)
+		activity.AvgSentiment = round2(sentimentTotal / total)
+	}
+	if score, ok := s.healthByServer[serverID]; ok {
+		activity.HealthScore = score.HealthScore
+		activity.HealthCategory = score.Category
+	}
+
+	for _, alertID := range s.alertsByServer[serverID] {
+		if alert, ok := s.alertsByID[alertID]; ok && inWeek(alert.TriggeredAt) {
+			activity.Alerts = append(activity.Alerts, alert)
+		}
+	}
+
+	prefix := serverID + "|"
+	for key, risk := range s.riskByServerUser {
+		if strings.HasPrefix(key, prefix) && risk.RiskScore > 0 {
+			activity.TopRiskUsers = append(activity.TopRiskUsers, risk)
+		}
+	}
+	sort.Slice(activity.TopRiskUsers, func(i, j int) bool {
+		left, right := activity.TopRiskUsers[i], activity.TopRiskUsers[j]
+		if left.RiskScore != right.RiskScore {
+			return left.RiskScore > right.RiskScore
+		}
+		if left.ToxicMessageCount != right.ToxicMessageCount {
+			return left.ToxicMessageCount > right.ToxicMessageCount
+		}
+		return left.UserID < right.UserID
+	})
+	if len(activity.TopRiskUsers) > topRiskUserLimit {
+		activity.TopRiskUsers = activity.TopRiskUsers[:topRiskUserLimit]
+	}
+	return activity, nil
+}
+
+func (s *Store) SaveWeeklyReport(
+	ctx context.Context,
+	report ports.WeeklyHealthReport,
+) (ports.WeeklyHealthReport, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := reportWeekKey(report.ServerID, report.WeekStartDate)
+	if reportID, ok := s.reportsByWeek[key]; ok {
+		return s.reportsByID[reportID], false, nil
+	}
+	s.reportsByID[report.ReportID] = report
+	s.reportsByWeek[key] = report.ReportID
+	return report, true, nil
+}
+
+func (s *Store) GetWeeklyReport(ctx context.Context, serverID string, reportID string) (ports.WeeklyHealthReport, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	report, ok := s.reportsByID[reportID]
+	if !ok || report.ServerID != serverID {
+		return ports.WeeklyHealthReport{}, domainerrors.ErrNotFound
+	}
+	return report, nil
+}
+
+func (s *Store) GetWeeklyReportForWeek(
+	ctx context.Context,
+	serverID string,
+	weekStartDate string,
+) (ports.WeeklyHealthReport, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	reportID, ok := s.reportsByWeek[reportWeekKey(serverID, weekStartDate)]
+	if !ok {
+		return ports.WeeklyHealthReport{}, domainerrors.ErrNotFound
+	}
+	return s.reportsByID[reportID], nil
+}
+
+func (s *Store) ListWeeklyReports(
+	ctx context.Context,
+	input ports.ListWeeklyReportsInput,
+) (ports.WeeklyReportPage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultReportPageLimit
+	}
+	items := make([]ports.WeeklyHealthReport, 0)
+	for _, report := range s.reportsByID {
+		if report.ServerID != input.ServerID {
+			continue
+		}
+		if input.BeforeWeek != "" && report.WeekStartDate >= input.BeforeWeek {
+			continue
+		}
+		items = append(items, report)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].WeekStartDate > items[j].WeekStartDate })
+
+	page := ports.WeeklyReportPage{}
+	if len(items) > limit {
+		items = items[:limit]
+		page.NextCursor = items[len(items)-1].WeekStartDate
+	}
+	page.Reports = items
+	return page, nil
+}
+
+func reportWeekKey(serverID string, weekStartDate string) string {
+	return serverID + "|" + weekStartDate
+}
@@ -23,7 +23,12 @@ type Store struct {
 	healthByServer     map[string]ports.CommunityHealthScore
 	alertsByID         map[string]ports.RealTimeAlert
 	reportsByID        map[string]ports.WeeklyHealthReport
+	reportsByWeek      map[string]string
 	feedbackByID       map[string]ports.ModerationFeedback
+	feedbackByKey      map[string]string
+	feedbackByServer   map[string][]string
+	thresholdsByServer map[string]map[string]float64
+	tuningUpdatedAt    map[string]time.Time
 
 	messageIDsByServer map[string][]string
 	messageOwner       map[string]string
@@ -31,6 +36,7 @@ type Store struct {
 	messageChannel     map[string]string
 	userMessageCounts  map[string]map[string]int
 	alertsByServer     map[string][]string
+	deletedMessages    map[string]time.Time
 
 	idempotency map[string]ports.IdempotencyRecord
 	eventDedup  map[string]time.Time
@@ -68,20 +74,31 @@ func NewStore() *Store {
 		},
 		alertsByID:         make(map[string]ports.RealTimeAlert),
 		reportsByID:        make(map[string]ports.WeeklyHealthReport),
+		reportsByWeek:      make(map[string]string),
 		feedbackByID:       make(map[string]ports.ModerationFeedback),
+		feedbackByKey:      make(map[string]string),
+		feedbackByServer:   make(map[string][]string),
+		thresholdsByServer: make(map[string]map[string]float64),
+		tuningUpdatedAt:    make(map[string]time.Time),
 		messageIDsByServer: make(map[string][]string),
 		messageOwner:       make(map[string]string),
 		messageServer:      make(map[string]string),
 		messageChannel:     make(map[string]string),
 		userMessageCounts:  make(map[string]map[string]int),
 		alertsByServer:     make(map[string][]string),
+		deletedMessages:    make(map[string]time.Time),
 		idempotency:        make(map[string]ports.IdempotencyRecord),
 		eventDedup:         make(map[string]time.Time),
 		sequence:           1,
 	}
 }
 
-func (s *Store) IngestWebhook(ctx context.Context, input ports.WebhookIngestInput, now time.Time) (ports.IngestionResult, error) {
+func (s *Store) IngestWebhook(
+	ctx context.Context,
+	input ports.WebhookIngestInput,
+	classification *ports.MessageClassification,
+	now time.Time,
+) (ports.IngestionResult, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -103,6 +120,7 @@ func (s *Store) IngestWebhook(ctx context.Context, input ports.WebhookIngestInpu
 	switch eventType {
 	case "chat.message.deleted":
 		s.deleteMessageArtifacts(input.MessageID, input.ServerID, input.UserID)
+		s.deletedMessages[input.MessageID] = now.UTC()
 		s.recomputeHealthScore(input.ServerID, now)
 		return ports.IngestionResult{
 			MessageID:   input.MessageID,
@@ -110,21 +128,22 @@ func (s *Store) IngestWebhook(ctx context.Context, input ports.WebhookIngestInpu
 			ProcessedAt: now.UTC(),
 		}, nil
 	case "chat.message.created", "chat.message.edited":
-		// continue
+		// Events on separate topics may arrive out of order; a message that
+		// was already deleted must not be scored again.
+		if _, deleted := s.deletedMessages[input.MessageID]; deleted {
+			return ports.IngestionResult{
+				MessageID:   input.MessageID,
+				EventType:   eventType,
+				ProcessedAt: now.UTC(),
+			}, nil
+		}
 	default:
 		return ports.IngestionResult{}, domainerrors.ErrInvalidRequest
 	}
 
-	content := strings.TrimSpace(input.Content)
-	if eventType == "chat.message.edited" && strings.TrimSpace(input.NewContent) != "" {
-		content = strings.TrimSpace(input.NewContent)
-	}
-	if content == "" {
+	if classification == nil {
 		return ports.IngestionResult{}, domainerrors.ErrInvalidRequest
 	}
-	if len(content) > 10000 {
-		content = content[:10000]
-	}
 
 	channelID := strings.TrimSpace(input.ChannelID)
 	if channelID == "" {
@@ -150,15 +169,40 @@ func (s *Store) IngestWebhook(ctx context.Context, input ports.WebhookIngestInpu
 		s.userMessageCounts[input.ServerID][userID]++
 	}
 
-	sentiment := analyzeSentiment(input.MessageID, input.ServerID, channelID, userID, content, now)
-	toxicity := analyzeToxicity(input.MessageID, input.ServerID, channelID, userID, content, now)
+	sentiment := ports.MessageSentiment{
+		MessageID:      input.MessageID,
+		ServerID:       input.ServerID,
+		ChannelID:      channelID,
+		UserID:         userID,
+		SentimentScore: classification.SentimentScore,
+		Confidence:     classification.SentimentConfidence,
+		Category:       classification.SentimentCategory,
+		Language:       classification.Language,
+		EmojiAdjusted:  classification.EmojiAdjusted,
+		SarcasmFlag:    classification.SarcasmFlag,
+		AnalyzedAt:     now.UTC(),
+	}
+	toxicity := ports.MessageToxicity{
+		MessageID:           input.MessageID,
+		ServerID:            input.ServerID,
+		ChannelID:           channelID,
+		UserID:              userID,
+		HateSpeechScore:     classification.HateSpeechScore,
+		HarassmentScore:     classification.HarassmentScore,
+		ThreatsScore:        classification.ThreatsScore,
+		SexualScore:         classification.SexualScore,
+		SpamScore:           classification.SpamScore,
+		MisinformationScore: classification.MisinformationScore,
+		MaxSeverity:         classification.MaxSeverity,
+		PrimaryCategory:     classification.PrimaryCategory,
+		AnalyzedAt:          now.UTC(),
+	}
 	s.sentimentByMessage[input.MessageID] = sentiment
 	s.toxicityByMessage[input.MessageID] = toxicity
 
 	risk := s.recomputeRisk(input.ServerID, userID, toxicity.MaxSeverity, now)
 	alerts := s.buildAlerts(input.ServerID, channelID, userID, sentiment, toxicity, now)
-	score := s.recomputeHealthScore(input.ServerID, now)
-	s.generateWeeklyReport(input.ServerID, score, now)
+	s.recomputeHealthScore(input.ServerID, now)
 
 	return ports.IngestionResult{
 		MessageID:        input.MessageID,
@@ -249,6 +293,7 @@ func (s *Store) nextID(prefix string) string {
 }
 
 func (s *Store) deleteMessageArtifacts(messageID string, serverID string, userID string) {
+	_, known := s.messageOwner[messageID]
 	if strings.TrimSpace(serverID) == "" {
 		serverID = s.messageServer[messageID]
 	}
@@ -270,7 +315,7 @@ func (s *Store) deleteMessageArtifacts(messageID string, serverID string, userID
 		}
 	}
 	s.messageIDsByServer[serverID] = out
-	if userCounts, ok := s.userMessageCounts[serverID]; ok && userID != "" && userCounts[userID] > 0 {
+	if userCounts, ok := s.userMessageCounts[serverID]; ok && known && userID != "" && userCounts[userID] > 0 {
 		userCounts[userID]--
 		if userCounts[userID] == 0 {
 			delete(userCounts, userID)
@@ -335,9 +380,10 @@ func (s *Store) buildAlerts(
 			AlertType:    "threat",
 			ServerID:     serverID,
 			ChannelID:    channelID,
+			MessageID:    toxicity.MessageID,
 			Severity:     severityLabel(toxicity.MaxSeverity),
 			TriggeredAt:  now.UTC(),
-			Status:       "sent",
+			Status:       ports.AlertStatusSent,
 			ResponseHint: "Review and moderate high-severity toxic message",
 		}
 		s.alertsByID[alert.AlertID] = alert
@@ -353,7 +399,7 @@ func (s *Store) buildAlerts(
 			ChannelID:    channelID,
 			Severity:     "high",
 			TriggeredAt:  now.UTC(),
-			Status:       "sent",
+			Status:       ports.AlertStatusSent,
 			ResponseHint: "Negativity spike detected in channel",
 		}
 		s.alertsByID[alert.AlertID] = alert
@@ -369,7 +415,7 @@ func (s *Store) buildAlerts(
 			ChannelID:    channelID,
 			Severity:     "critical",
 			TriggeredAt:  now.UTC(),
-			Status:       "sent",
+			Status:       ports.AlertStatusSent,
 			ResponseHint: "High-risk user activity detected",
 		}
 		s.alertsByID[alert.AlertID] = alert
@@ -412,7 +458,7 @@ func (s *Store) recomputeHealthScore(serverID string, now time.Time) ports.Commu
 	sentimentHealth := clampInt(int((positivePct/0.60)*25.0), 0, 25)
 	toxicityHealth := clampInt(int((1.0-(toxicityPct/0.02))*25.0), 0, 25)
 	engagementHealth := clampInt(int((1.0-math.Abs(gini-0.4)/0.8)*25.0), 0, 25)
-	latencyHealth := 12
+	avgLatencyHr, latencyHealth := s.alertLatencyHealth(serverID, now)
 
 	previous := s.healthByServer[serverID]
 	trend := "stable"
@@ -447,8 +493,10 @@ func (s *Store) recomputeHealthScore(serverID string, now time.Time) ports.Commu
 		PositivePct:            round2(positivePct),
 		ToxicityPct:            round2(toxicityPct),
 		EngagementGini:         round2(gini),
-		AvgModerationLatencyHr: 0.5,
+		AvgModerationLatencyHr: avgLatencyHr,
 		Alerts:                 len(s.alertsByServer[serverID]),
+		OpenAlerts:             s.openAlertCount(serverID),
+		CategoryFeedback:       s.categoryFeedback(serverID),
 		CalculatedAt:           now.UTC(),
 	}
 	s.healthByServer[serverID] = item
@@ -477,164 +525,6 @@ func (s *Store) averageSentimentLastN(serverID string, n int) float64 {
 	return sum / float64(count)
 }
 
-func (s *Store) generateWeeklyReport(serverID string, score ports.CommunityHealthScore, now time.Time) {
-	report := ports.WeeklyHealthReport{
-		ReportID:      "report_" + s.nextID("m49"),
-		ServerID:      serverID,
-		WeekStartDate: score.WeekStartDate,
-		MetricsJSON: map[string]any{
-			"health_score":    score.HealthScore,
-			"positive_pct":    score.PositivePct,
-			"toxicity_pct":    score.ToxicityPct,
-			"engagement_gini": score.EngagementGini,
-			"trend":           score.Trend,
-			"alerts":          score.Alerts,
-		},
-		GeneratedAt: now.UTC(),
-	}
-	s.reportsByID[report.ReportID] = report
-}
-
-func analyzeSentiment(
-	messageID string,
-	serverID string,
-	channelID string,
-	userID string,
-	content string,
-	now time.Time,
-) ports.MessageSentiment {
-	positiveWords := []string{"thanks", "great", "awesome", "love", "helpful", "nice", "good", "amazing", "happy"}
-	negativeWords := []string{"hate", "stupid", "terrible", "awful", "bad", "angry", "worst", "toxic"}
-
-	lower := strings.ToLower(content)
-	posHits := 0
-	negHits := 0
-	for _, word := range positiveWords {
-		if strings.Contains(lower, word) {
-			posHits++
-		}
-	}
-	for _, word := range negativeWords {
-		if strings.Contains(lower, word) {
-			negHits++
-		}
-	}
-
-	score := 0.0
-	totalHits := posHits + negHits
-	if totalHits > 0 {
-		score = float64(posHits-negHits) / float64(totalHits)
-	}
-	score = math.Max(-1, math.Min(1, score))
-	confidence := 0.7 + math.Min(0.3, math.Abs(score)*0.3)
-
-	category := "neutral"
-	switch {
-	case score <= -0.5:
-		category = "very_negative"
-	case score < -0.1:
-		category = "negative"
-	case score <= 0.1:
-		category = "neutral"
-	case score < 0.5:
-		category = "positive"
-	default:
-		category = "very_positive"
-	}
-
-	return ports.MessageSentiment{
-		MessageID:      messageID,
-		ServerID:       serverID,
-		ChannelID:      channelID,
-		UserID:         userID,
-		SentimentScore: round2(score),
-		Confidence:     round2(confidence),
-		Category:       category,
-		Language:       "en",
-		EmojiAdjusted:  strings.Contains(content, ":") || strings.Contains(content, "😊"),
-		SarcasmFlag:    strings.Contains(lower, "/s"),
-		AnalyzedAt:     now.UTC(),
-	}
-}
-
-func analyzeToxicity(
-	messageID string,
-	serverID string,
-	channelID string,
-	userID string,
-	content string,
-	now time.Time,
-) ports.MessageToxicity {
-	lower := strings.ToLower(content)
-	hate := scoreKeyword(lower, []string{"slur", "racist"})
-	harassment := scoreKeyword(lower, []string{"idiot", "loser", "trash", "stupid"})
-	threats := scoreKeyword(lower, []string{"kill", "hurt", "fight you", "watch out"})
-	sexual := scoreKeyword(lower, []string{"sexual", "explicit", "nude"})
-	spam := scoreKeyword(lower, []string{"buy now", "free money", "click this"})
-	misinformation := scoreKeyword(lower, []string{"fake cure", "hoax"})
-
-	maxSeverity := 0
-	primaryCategory := ""
-	maxScore := 0.0
-	scores := map[string]float64{
-		"hate_speech":    hate,
-		"harassment":     harassment,
-		"threats":        threats,
-		"sexual":         sexual,
-		"spam":           spam,
-		"misinformation": misinformation,
-	}
-	for category, score := range scores {
-		if score > maxScore {
-			maxScore = score
-			primaryCategory = category
-		}
-	}
-	switch {
-	case maxScore >= 0.9:
-		maxSeverity = 3
-	case maxScore >= 0.7:
-		maxSeverity = 2
-	case maxScore >= 0.5:
-		maxSeverity = 1
-	default:
-		maxSeverity = 0
-	}
-
-	return ports.MessageToxicity{
-		MessageID:           messageID,
-		ServerID:            serverID,
-		ChannelID:           channelID,
-		UserID:              userID,
-		HateSpeechScore:     round2(hate),
-		HarassmentScore:     round2(harassment),
-		ThreatsScore:        round2(threats),
-		SexualScore:         round2(sexual),
-		SpamScore:           round2(spam),
-		MisinformationScore: round2(misinformation),
-		MaxSeverity:         maxSeverity,
-		PrimaryCategory:     defaultString(primaryCategory, "none"),
-		AnalyzedAt:          now.UTC(),
-	}
-}
-
-func scoreKeyword(content string, keywords []string) float64 {
-	hits := 0
-	for _, keyword := range keywords {
-		if strings.Contains(content, keyword) {
-			hits++
-		}
-	}
-	if hits == 0 {
-		return 0
-	}
-	score := 0.45 + float64(hits)*0.2
-	if score > 1 {
-		score = 1
-	}
-	return score
-}
-
 func computeGini(counts map[string]int) float64 {
 	if len(counts) == 0 {
 		return 0
@@ -815,6 +705,7 @@ func defaultString(value string, fallback string) string {
 }
 
 var _ ports.Repository = (*Store)(nil)
+var _ ports.ReportRepository = (*Store)(nil)
 var _ ports.IdempotencyStore = (*Store)(nil)
 var _ ports.Clock = (*Store)(nil)
 var _ ports.IDGenerator = (*Store)(nil)
@@ -8,6 +8,16 @@ import (
 	"solomon/contexts/community-experience/community-health-service/ports"
 )
 
+func testClassification() *ports.MessageClassification {
+	return &ports.MessageClassification{
+		SentimentScore:      0.5,
+		SentimentConfidence: 0.85,
+		SentimentCategory:   "very_positive",
+		Language:            "en",
+		PrimaryCategory:     "none",
+	}
+}
+
 func TestIngestWebhookDeduplicatesByEventID(t *testing.T) {
 	store := NewStore()
 	now := time.Date(2026, time.February, 5, 12, 0, 0, 0, time.UTC)
@@ -22,10 +32,10 @@ func TestIngestWebhookDeduplicatesByEventID(t *testing.T) {
 		Content:   "great work team",
 	}
 
-	if _, err := store.IngestWebhook(context.Background(), input, now); err != nil {
+	if _, err := store.IngestWebhook(context.Background(), input, testClassification(), now); err != nil {
 		t.Fatalf("first ingest failed: %v", err)
 	}
-	if _, err := store.IngestWebhook(context.Background(), input, now.Add(time.Second)); err != nil {
+	if _, err := store.IngestWebhook(context.Background(), input, testClassification(), now.Add(time.Second)); err != nil {
 		t.Fatalf("duplicate ingest failed: %v", err)
 	}
 
@@ -56,7 +66,7 @@ func TestIngestWebhookEditDoesNotIncrementAndDeleteUsesStoredOwner(t *testing.T)
 		Content:   "hello",
 		CreatedAt: &createdAt,
 	}
-	if _, err := store.IngestWebhook(context.Background(), created, now); err != nil {
+	if _, err := store.IngestWebhook(context.Background(), created, testClassification(), now); err != nil {
 		t.Fatalf("create ingest failed: %v", err)
 	}
 
@@ -68,7 +78,7 @@ func TestIngestWebhookEditDoesNotIncrementAndDeleteUsesStoredOwner(t *testing.T)
 		NewContent: "hello updated",
 		EditedAt:   &editedAt,
 	}
-	if _, err := store.IngestWebhook(context.Background(), edited, now.Add(time.Minute)); err != nil {
+	if _, err := store.IngestWebhook(context.Background(), edited, testClassification(), now.Add(time.Minute)); err != nil {
 		t.Fatalf("edit ingest failed: %v", err)
 	}
 
@@ -87,7 +97,7 @@ func TestIngestWebhookEditDoesNotIncrementAndDeleteUsesStoredOwner(t *testing.T)
 		ServerID:  "server-b",
 		DeletedAt: &deletedAt,
 	}
-	if _, err := store.IngestWebhook(context.Background(), deleted, now.Add(2*time.Minute)); err != nil {
+	if _, err := store.IngestWebhook(context.Background(), deleted, nil, now.Add(2*time.Minute)); err != nil {
 		t.Fatalf("delete ingest failed: %v", err)
 	}
 
@@ -99,3 +109,205 @@ func TestIngestWebhookEditDoesNotIncrementAndDeleteUsesStoredOwner(t *testing.T)
 		t.Fatalf("expected total_messages=0 after delete, got %d", scoreAfterDelete.TotalMessages)
 	}
 }
+
+func TestIngestWebhookDeleteBeforeCreateKeepsMessageOut(t *testing.T) {
+	store := NewStore()
+	now := time.Date(2026, time.February, 5, 14, 0, 0, 0, time.UTC)
+
+	deleted := ports.WebhookIngestInput{
+		EventID:   "evt-del-first",
+		EventType: "chat.message.deleted",
+		MessageID: "msg-late",
+		ServerID:  "server-c",
+		UserID:    "user-c",
+	}
+	if _, err := store.IngestWebhook(context.Background(), deleted, nil, now); err != nil {
+		t.Fatalf("delete ingest failed: %v", err)
+	}
+	created := ports.WebhookIngestInput{
+		EventID:   "evt-create-late",
+		EventType: "chat.message.created",
+		MessageID: "msg-late",
+		ServerID:  "server-c",
+		ChannelID: "channel-c",
+		UserID:    "user-c",
+		Content:   "hello",
+	}
+	if _, err := store.IngestWebhook(context.Background(), created, testClassification(), now.Add(time.Second)); err != nil {
+		t.Fatalf("late create ingest failed: %v", err)
+	}
+
+	score, err := store.GetCommunityHealthScore(context.Background(), "server-c")
+	if err != nil {
+		t.Fatalf("score lookup failed: %v", err)
+	}
+	if score.TotalMessages != 0 {
+		t.Fatalf("expected deleted message to stay out of the score, got %d", score.TotalMessages)
+	}
+	if _, ok := store.sentimentByMessage["msg-late"]; ok {
+		t.Fatalf("expected no sentiment for a message deleted before it was created")
+	}
+}
+
+func ingestToxicMessage(t *testing.T, store *Store, messageID string, category string, severity int, now time.Time) {
+	t.Helper()
+	classification := testClassification()
+	classification.PrimaryCategory = category
+	classification.MaxSeverity = severity
+	_, err := store.IngestWebhook(context.Background(), ports.WebhookIngestInput{
+		EventID:   "evt-" + messageID,
+		EventType: "chat.message.created",
+		MessageID: messageID,
+		ServerID:  "server-mod",
+		ChannelID: "channel-mod",
+		UserID:    "user-mod",
+		Content:   "content",
+	}, classification, now)
+	if err != nil {
+		t.Fatalf("ingest %s failed: %v", messageID, err)
+	}
+}
+
+func TestAlertLifecycleFeedsLatencyHealth(t *testing.T) {
+	store := NewStore()
+	now := time.Date(2026, time.February, 5, 12, 0, 0, 0, time.UTC)
+	ingestToxicMessage(t, store, "msg-threat", "threats", 2, now)
+
+	alerts, err := store.ListAlerts(context.Background(), "server-mod", ports.AlertStatusSent)
+	if err != nil || len(alerts) != 1 {
+		t.Fatalf("expected one sent alert, got %v err=%v", alerts, err)
+	}
+	alert := alerts[0]
+	if alert.MessageID != "msg-threat" {
+		t.Fatalf("expected alert to reference the message, got %+v", alert)
+	}
+
+	acked, err := store.TransitionAlert(context.Background(), ports.AlertTransitionInput{
+		ServerID:    "server-mod",
+		AlertID:     alert.AlertID,
+		ModeratorID: "mod-1",
+		Status:      ports.AlertStatusAcknowledged,
+	}, now.Add(30*time.Minute))
+	if err != nil {
+		t.Fatalf("acknowledge failed: %v", err)
+	}
+	if acked.AcknowledgedAt == nil || acked.AcknowledgedBy != "mod-1" {
+		t.Fatalf("expected acknowledgement to be recorded, got %+v", acked)
+	}
+	score, _ := store.GetCommunityHealthScore(context.Background(), "server-mod")
+	if score.AvgModerationLatencyHr != 0.5 || score.LatencyHealth != 25 || score.OpenAlerts != 1 {
+		t.Fatalf("unexpected latency after fast acknowledgement: %+v", score)
+	}
+
+	resolved, err := store.TransitionAlert(context.Background(), ports.AlertTransitionInput{
+		ServerID:    "server-mod",
+		AlertID:     alert.AlertID,
+		ModeratorID: "mod-2",
+		Status:      ports.AlertStatusResolved,
+	}, now.Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("resolve failed: %v", err)
+	}
+	if resolved.AcknowledgedBy != "mod-1" || resolved.ClosedBy != "mod-2" || resolved.ClosedAt == nil {
+		t.Fatalf("expected resolve to keep the acknowledgement, got %+v", resolved)
+	}
+	if _, err := store.TransitionAlert(context.Background(), ports.AlertTransitionInput{
+		ServerID:    "server-mod",
+		AlertID:     alert.AlertID,
+		ModeratorID: "mod-2",
+		Status:      ports.AlertStatusDismissed,
+	}, now.Add(3*time.Hour)); err == nil {
+		t.Fatalf("expected a resolved alert to reject further transitions")
+	}
+
+	ingestToxicMessage(t, store, "msg-threat-2", "threats", 2, now.Add(time.Hour))
+	score = store.recomputeHealthScore("server-mod", now.Add(13*time.Hour))
+	if score.AvgModerationLatencyHr != 6.25 || score.LatencyHealth != 19 {
+		t.Fatalf("expected an ignored alert to drag latency health down, got %+v", score)
+	}
+}
+
+func TestModerationFeedbackTunesThresholdsAndPrecision(t *testing.T) {
+	store := NewStore()
+	now := time.Date(2026, time.February, 5, 12, 0, 0, 0, time.UTC)
+	ingestToxicMessage(t, store, "msg-spam-1", "spam", 1, now)
+	ingestToxicMessage(t, store, "msg-spam-2", "spam", 1, now)
+	ingestToxicMessage(t, store, "msg-clean", "none", 0, now)
+
+	record := func(messageID string, moderatorID string, feedbackType string, category string) (ports.ModerationFeedbackResult, error) {
+		return store.RecordModerationFeedback(context.Background(), ports.ModerationFeedbackInput{
+			ServerID:     "server-mod",
+			MessageID:    messageID,
+			ModeratorID:  moderatorID,
+			FeedbackType: feedbackType,
+			Category:     category,
+		}, now.Add(time.Minute))
+	}
+
+	if _, err := record("msg-spam-1", "mod-1", ports.FeedbackFalsePositive, ""); err != nil {
+		t.Fatalf("false positive failed: %v", err)
+	}
+	if _, err := record("msg-spam-1", "mod-1", ports.FeedbackConfirmed, ""); err == nil {
+		t.Fatalf("expected duplicate feedback from the same moderator to conflict")
+	}
+	if _, err := record("msg-clean", "mod-1", ports.FeedbackFalsePositive, ""); err == nil {
+		t.Fatalf("expected false positive on an unflagged message to conflict")
+	}
+	if _, err := record("msg-spam-2", "mod-1", ports.FeedbackConfirmed, ""); err != nil {
+		t.Fatalf("confirm failed: %v", err)
+	}
+	result, err := record("msg-clean", "mod-1", ports.FeedbackFalseNegative, "harassment")
+	if err != nil {
+		t.Fatalf("false negative failed: %v", err)
+	}
+
+	tuning := result.Tuning
+	if tuning.Thresholds["spam"] != 0.52 || tuning.Thresholds["harassment"] != 0.48 {
+		t.Fatalf("unexpected tuned thresholds: %+v", tuning.Thresholds)
+	}
+	if len(tuning.Categories) != 2 || tuning.Categories[1].Category != "spam" {
+		t.Fatalf("unexpected category metrics: %+v", tuning.Categories)
+	}
+	spam := tuning.Categories[1]
+	if spam.Confirmed != 1 || spam.FalsePositives != 1 || spam.Precision == nil || *spam.Precision != 0.5 {
+		t.Fatalf("unexpected spam precision: %+v", spam)
+	}
+	if tuning.Categories[0].Precision != nil || tuning.Categories[0].FalseNegatives != 1 {
+		t.Fatalf("expected harassment to have no precision yet: %+v", tuning.Categories[0])
+	}
+	score, _ := store.GetCommunityHealthScore(context.Background(), "server-mod")
+	if len(score.CategoryFeedback) != 2 {
+		t.Fatalf("expected dashboard to carry category feedback, got %+v", score.CategoryFeedback)
+	}
+}
+
+func TestWeeklyActivityCountsOnlyTheRequestedWeek(t *testing.T) {
+	store := NewStore()
+	weekStart := time.Date(2026, time.February, 2, 0, 0, 0, 0, time.UTC)
+	ingestToxicMessage(t, store, "msg-before", "none", 0, weekStart.Add(-time.Hour))
+	ingestToxicMessage(t, store, "msg-in-1", "threats", 2, weekStart.Add(time.Hour))
+	ingestToxicMessage(t, store, "msg-in-2", "none", 0, weekStart.Add(48*time.Hour))
+
+	activity, err := store.GetWeeklyActivity(context.Background(), "server-mod", weekStart, weekStart.AddDate(0, 0, 7))
+	if err != nil {
+		t.Fatalf("weekly activity failed: %v", err)
+	}
+	if activity.TotalMessages != 2 || activity.ActiveUsers != 1 || activity.ToxicityPct != 0.5 {
+		t.Fatalf("unexpected weekly activity: %+v", activity)
+	}
+	if len(activity.Alerts) != 1 || activity.Alerts[0].MessageID != "msg-in-1" {
+		t.Fatalf("expected only the in-week alert, got %+v", activity.Alerts)
+	}
+	if len(activity.TopRiskUsers) != 1 || activity.TopRiskUsers[0].UserID != "user-mod" {
+		t.Fatalf("unexpected top risk users: %+v", activity.TopRiskUsers)
+	}
+
+	servers, _ := store.ListActiveServers(context.Background())
+	found := false
+	for _, serverID := range servers {
+		found = found || serverID == "server-mod"
+	}
+	if !found {
+		t.Fatalf("expected server-mod among active servers, got %v", servers)
+	}
+}
@@ -0,0 +1,186 @@
+package postgresadapter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	domainerrors "solomon/contexts/community-experience/community-health-service/domain/errors"
+	"solomon/contexts/community-experience/community-health-service/ports"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const defaultReportPageLimit = 12
+
+// ReportRepository stores weekly community health reports. Message scoring
+// stays in the module's in-memory store; only the reports outlive restarts.
+type ReportRepository struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+// NewReportRepository builds the GORM-backed weekly report repository.
+func NewReportRepository(db *gorm.DB, logger *slog.Logger) *ReportRepository {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &ReportRepository{db: db, logger: logger}
+}
+
+// SaveWeeklyReport inserts the report unless the server already has one for
+// the week, in which case the stored report wins.
+func (r *ReportRepository) SaveWeeklyReport(
+	ctx context.Context,
+	report ports.WeeklyHealthReport,
+) (ports.WeeklyHealthReport, bool, error) {
+	row, err := toReportModel(report)
+	if err != nil {
+		return ports.WeeklyHealthReport{}, false, err
+	}
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "server_id"}, {Name: "week_start_date"}},
+			DoNothing: true,
+		}).
+		Create(&row)
+	if result.Error != nil {
+		return ports.WeeklyHealthReport{}, false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		existing, err := r.GetWeeklyReportForWeek(ctx, report.ServerID, report.WeekStartDate)
+		return existing, false, err
+	}
+	return report, true, nil
+}
+
+func (r *ReportRepository) GetWeeklyReport(
+	ctx context.Context,
+	serverID string,
+	reportID string,
+) (ports.WeeklyHealthReport, error) {
+	return r.first(ctx, "server_id = ? AND report_id = ?", strings.TrimSpace(serverID), strings.TrimSpace(reportID))
+}
+
+func (r *ReportRepository) GetWeeklyReportForWeek(
+	ctx context.Context,
+	serverID string,
+	weekStartDate string,
+) (ports.WeeklyHealthReport, error) {
+	return r.first(ctx, "server_id = ? AND week_start_date = ?", strings.TrimSpace(serverID), strings.TrimSpace(weekStartDate))
+}
+
+// ListWeeklyReports pages newest week first, keyed on week_start_date.
+func (r *ReportRepository) ListWeeklyReports(
+	ctx context.Context,
+	input ports.ListWeeklyReportsInput,
+) (ports.WeeklyReportPage, error) {
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultReportPageLimit
+	}
+	query := r.db.WithContext(ctx).Where("server_id = ?", strings.TrimSpace(input.ServerID))
+	if before := strings.TrimSpace(input.BeforeWeek); before != "" {
+		query = query.Where("week_start_date < ?", before)
+	}
+	var rows []reportModel
+	if err := query.Order("week_start_date DESC").Limit(limit + 1).Find(&rows).Error; err != nil {
+		return ports.WeeklyReportPage{}, err
+	}
+
+	page := ports.WeeklyReportPage{}
+	if len(rows) > limit {
+		rows = rows[:limit]
+		page.NextCursor = rows[len(rows)-1].WeekStartDate
+	}
+	page.Reports = make([]ports.WeeklyHealthReport, 0, len(rows))
+	for _, row := range rows {
+		report, err := row.toDomain()
+		if err != nil {
+			return ports.WeeklyReportPage{}, err
+		}
+		page.Reports = append(page.Reports, report)
+	}
+	return page, nil
+}
+
+func (r *ReportRepository) first(ctx context.Context, query string, args ...any) (ports.WeeklyHealthReport, error) {
+	var row reportModel
+	err := r.db.WithContext(ctx).Where(query, args...).First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ports.WeeklyHealthReport{}, domainerrors.ErrNotFound
+	}
+	if err != nil {
+		return ports.WeeklyHealthReport{}, err
+	}
+	return row.toDomain()
+}
+
+type reportModel struct {
+	ReportID      string    `gorm:"column:report_id;primaryKey"`
+	ServerID      string    `gorm:"column:server_id"`
+	WeekStartDate string    `gorm:"column:week_start_date"`
+	Report        []byte    `gorm:"column:report;type:jsonb"`
+	GeneratedAt   time.Time `gorm:"column:generated_at"`
+}
+
+func (reportModel) TableName() string {
+	return "community_health_weekly_reports"
+}
+
+// reportPayload is the JSON body of a stored report; identity columns live
+// on the row itself.
+type reportPayload struct {
+	WeekEndDate           string                    `json:"week_end_date"`
+	Metrics               ports.WeeklyReportMetrics `json:"metrics"`
+	PreviousWeekStartDate string                    `json:"previous_week_start_date,omitempty"`
+	Trends                []ports.MetricTrend       `json:"trends"`
+	TopRiskUsers          []ports.RiskUserSummary   `json:"top_risk_users"`
+	Alerts                ports.AlertSummary        `json:"alerts"`
+}
+
+func toReportModel(report ports.WeeklyHealthReport) (reportModel, error) {
+	payload, err := json.Marshal(reportPayload{
+		WeekEndDate:           report.WeekEndDate,
+		Metrics:               report.Metrics,
+		PreviousWeekStartDate: report.PreviousWeekStartDate,
+		Trends:                report.Trends,
+		TopRiskUsers:          report.TopRiskUsers,
+		Alerts:                report.Alerts,
+	})
+	if err != nil {
+		return reportModel{}, err
+	}
+	return reportModel{
+		ReportID:      report.ReportID,
+		ServerID:      report.ServerID,
+		WeekStartDate: report.WeekStartDate,
+		Report:        payload,
+		GeneratedAt:   report.GeneratedAt.UTC(),
+	}, nil
+}
+
+func (row reportModel) toDomain() (ports.WeeklyHealthReport, error) {
+	var payload reportPayload
+	if err := json.Unmarshal(row.Report, &payload); err != nil {
+		return ports.WeeklyHealthReport{}, err
+	}
+	return ports.WeeklyHealthReport{
+		ReportID:              row.ReportID,
+		ServerID:              row.ServerID,
+		WeekStartDate:         row.WeekStartDate,
+		WeekEndDate:           payload.WeekEndDate,
+		Metrics:               payload.Metrics,
+		PreviousWeekStartDate: payload.PreviousWeekStartDate,
+		Trends:                payload.Trends,
+		TopRiskUsers:          payload.TopRiskUsers,
+		Alerts:                payload.Alerts,
+		GeneratedAt:           row.GeneratedAt.UTC(),
+	}, nil
+}
+
+var _ ports.ReportRepository = (*ReportRepository)(nil)
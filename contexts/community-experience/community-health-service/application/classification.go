@@ -0,0 +1,195 @@
+package application
+
+import (
+	"context"
+	"math"
+	"strings"
+
+	domainerrors "solomon/contexts/community-experience/community-health-service/domain/errors"
+	"solomon/contexts/community-experience/community-health-service/ports"
+)
+
+const maxClassifiedContentLength = 10000
+
+// toxicityCategories fixes the order used to pick the primary category, so
+// ties resolve the same way for every classifier.
+var toxicityCategories = []string{
+	"hate_speech",
+	"harassment",
+	"threats",
+	"sexual",
+	"spam",
+	"misinformation",
+}
+
+// DefaultToxicityThreshold is the score at which a category is flagged
+// (severity 1) until moderator feedback tunes it for a server. Severity 2 and
+// 3 start 0.2 and 0.4 above the flag threshold.
+const DefaultToxicityThreshold = 0.5
+
+// DeriveClassification maps raw classifier signals to the stored sentiment
+// category, primary toxicity category and severity using default thresholds.
+func DeriveClassification(output ports.ClassifierOutput) ports.MessageClassification {
+	return DeriveClassificationWithThresholds(output, nil)
+}
+
+// DeriveClassificationWithThresholds is DeriveClassification with per-category
+// flag thresholds; categories missing from thresholds use the default. The
+// primary category is the most severe one, ties going to the higher score.
+func DeriveClassificationWithThresholds(
+	output ports.ClassifierOutput,
+	thresholds map[string]float64,
+) ports.MessageClassification {
+	sentiment := math.Max(-1, math.Min(1, output.Sentiment.Score))
+	confidence := math.Max(0, math.Min(1, output.Sentiment.Confidence))
+	scores := []float64{
+		clampUnit(output.Toxicity.HateSpeech),
+		clampUnit(output.Toxicity.Harassment),
+		clampUnit(output.Toxicity.Threats),
+		clampUnit(output.Toxicity.Sexual),
+		clampUnit(output.Toxicity.Spam),
+		clampUnit(output.Toxicity.Misinformation),
+	}
+	primary := "none"
+	maxScore := 0.0
+	maxSeverity := 0
+	for index, score := range scores {
+		category := toxicityCategories[index]
+		severity := ToxicitySeverityAt(score, toxicityThreshold(thresholds, category))
+		if severity > maxSeverity || (severity == maxSeverity && score > maxScore) {
+			maxScore = score
+			maxSeverity = severity
+			primary = category
+		}
+	}
+	language := strings.TrimSpace(output.Sentiment.Language)
+	if language == "" {
+		language = "en"
+	}
+	return ports.MessageClassification{
+		SentimentScore:      round2(sentiment),
+		SentimentConfidence: round2(confidence),
+		SentimentCategory:   SentimentCategory(sentiment),
+		Language:            language,
+		EmojiAdjusted:       output.Sentiment.EmojiAdjusted,
+		SarcasmFlag:         output.Sentiment.SarcasmFlag,
+		HateSpeechScore:     round2(scores[0]),
+		HarassmentScore:     round2(scores[1]),
+		ThreatsScore:        round2(scores[2]),
+		SexualScore:         round2(scores[3]),
+		SpamScore:           round2(scores[4]),
+		MisinformationScore: round2(scores[5]),
+		MaxSeverity:         maxSeverity,
+		PrimaryCategory:     primary,
+	}
+}
+
+// SentimentCategory buckets a sentiment score in [-1, 1].
+func SentimentCategory(score float64) string {
+	switch {
+	case score <= -0.5:
+		return "very_negative"
+	case score < -0.1:
+		return "negative"
+	case score <= 0.1:
+		return "neutral"
+	case score < 0.5:
+		return "positive"
+	default:
+		return "very_positive"
+	}
+}
+
+// ToxicitySeverity maps the highest category score to severity 0-3 at the
+// default threshold.
+func ToxicitySeverity(maxScore float64) int {
+	return ToxicitySeverityAt(maxScore, DefaultToxicityThreshold)
+}
+
+// ToxicitySeverityAt maps a category score to severity 0-3 given that
+// category's flag threshold.
+func ToxicitySeverityAt(score float64, threshold float64) int {
+	// Compare in hundredths so a tuned threshold such as 0.52+0.2 does not
+	// miss a score of exactly 0.72 to floating point error.
+	points := math.Round(score * 100)
+	base := math.Round(threshold * 100)
+	switch {
+	case points >= base+40:
+		return 3
+	case points >= base+20:
+		return 2
+	case points >= base:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// IsToxicityCategory reports whether category is one a classifier scores.
+func IsToxicityCategory(category string) bool {
+	for _, known := range toxicityCategories {
+		if known == category {
+			return true
+		}
+	}
+	return false
+}
+
+func toxicityThreshold(thresholds map[string]float64, category string) float64 {
+	if threshold, ok := thresholds[category]; ok && threshold > 0 {
+		return threshold
+	}
+	return DefaultToxicityThreshold
+}
+
+// classify scores the content a created or edited event carries. Deletions
+// need no analysis and return nil.
+func (s Service) classify(ctx context.Context, input ports.WebhookIngestInput) (*ports.MessageClassification, error) {
+	if input.EventType == "chat.message.deleted" {
+		return nil, nil
+	}
+	content := strings.TrimSpace(input.Content)
+	if input.EventType == "chat.message.edited" && strings.TrimSpace(input.NewContent) != "" {
+		content = strings.TrimSpace(input.NewContent)
+	}
+	if content == "" {
+		return nil, domainerrors.ErrInvalidRequest
+	}
+	if len(content) > maxClassifiedContentLength {
+		content = content[:maxClassifiedContentLength]
+	}
+	if s.Classifier == nil {
+		return nil, domainerrors.ErrClassifierUnavailable
+	}
+	output, err := s.Classifier.Classify(ctx, ports.ClassificationInput{
+		MessageID: input.MessageID,
+		ServerID:  input.ServerID,
+		ChannelID: input.ChannelID,
+		UserID:    input.UserID,
+		Content:   content,
+	})
+	if err != nil {
+		ResolveLogger(s.Logger).Warn("community health classification failed",
+			"event", "community_health_classification_failed",
+			"module", "community-experience/community-health-service",
+			"layer", "application",
+			"message_id", input.MessageID,
+			"error", err.Error(),
+		)
+		return nil, err
+	}
+	tuning, err := s.Repo.GetModerationTuning(ctx, input.ServerID)
+	if err != nil {
+		return nil, err
+	}
+	classification := DeriveClassificationWithThresholds(output, tuning.Thresholds)
+	return &classification, nil
+}
+
+func clampUnit(value float64) float64 {
+	return math.Max(0, math.Min(1, value))
+}
+
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,172 @@
+package application
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"solomon/contexts/community-experience/community-health-service/ports"
+)
+
+// LabelledMessage is one line of an evaluation corpus (JSONL):
+//
+//	{"content": "you absolute idiot", "category": "harassment", "severity": 1, "sentiment": "neutral"}
+//
+// category is a toxicity category or "none"; sentiment is optional.
+type LabelledMessage struct {
+	Content   string `json:"content"`
+	Category  string `json:"category"`
+	Severity  int    `json:"severity"`
+	Sentiment string `json:"sentiment,omitempty"`
+}
+
+type CategoryMetrics struct {
+	Category  string  `json:"category"`
+	Support   int     `json:"support"`
+	Precision float64 `json:"precision"`
+	Recall    float64 `json:"recall"`
+	F1        float64 `json:"f1"`
+}
+
+type EvaluationReport struct {
+	Samples           int               `json:"samples"`
+	CategoryAccuracy  float64           `json:"category_accuracy"`
+	SeverityAccuracy  float64           `json:"severity_accuracy"`
+	SeverityMAE       float64           `json:"severity_mae"`
+	SentimentSamples  int               `json:"sentiment_samples"`
+	SentimentAccuracy float64           `json:"sentiment_accuracy"`
+	Categories        []CategoryMetrics `json:"categories"`
+}
+
+// ReadLabelledCorpus parses a JSONL corpus, skipping blank lines.
+func ReadLabelledCorpus(r io.Reader) ([]LabelledMessage, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	var out []LabelledMessage
+	line := 0
+	for scanner.Scan() {
+		line++
+		raw := strings.TrimSpace(scanner.Text())
+		if raw == "" {
+			continue
+		}
+		var item LabelledMessage
+		if err := json.Unmarshal([]byte(raw), &item); err != nil {
+			return nil, fmt.Errorf("corpus line %d: %w", line, err)
+		}
+		item.Category = strings.ToLower(strings.TrimSpace(item.Category))
+		if item.Category == "" {
+			item.Category = "none"
+		}
+		item.Sentiment = strings.ToLower(strings.TrimSpace(item.Sentiment))
+		if strings.TrimSpace(item.Content) == "" || item.Severity < 0 || item.Severity > 3 {
+			return nil, fmt.Errorf("corpus line %d: content is required and severity must be 0-3", line)
+		}
+		out = append(out, item)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EvaluateClassifier scores classifier against labelled messages using the
+// same derivation as ingestion, so results reflect production categories and
+// severities.
+func EvaluateClassifier(
+	ctx context.Context,
+	classifier ports.MessageClassifier,
+	corpus []LabelledMessage,
+) (EvaluationReport, error) {
+	report := EvaluationReport{Samples: len(corpus)}
+	if len(corpus) == 0 {
+		return report, nil
+	}
+
+	type counts struct{ truePositive, falsePositive, falseNegative, support int }
+	perCategory := map[string]*counts{}
+	bucket := func(category string) *counts {
+		if perCategory[category] == nil {
+			perCategory[category] = &counts{}
+		}
+		return perCategory[category]
+	}
+
+	categoryHits, severityHits, sentimentHits := 0, 0, 0
+	severityError := 0
+	for index, item := range corpus {
+		content := strings.TrimSpace(item.Content)
+		if len(content) > maxClassifiedContentLength {
+			content = content[:maxClassifiedContentLength]
+		}
+		output, err := classifier.Classify(ctx, ports.ClassificationInput{
+			MessageID: fmt.Sprintf("eval_%d", index+1),
+			Content:   content,
+		})
+		if err != nil {
+			return EvaluationReport{}, fmt.Errorf("classify sample %d: %w", index+1, err)
+		}
+		got := DeriveClassification(output)
+
+		bucket(item.Category).support++
+		if got.PrimaryCategory == item.Category {
+			categoryHits++
+			bucket(item.Category).truePositive++
+		} else {
+			bucket(item.Category).falseNegative++
+			bucket(got.PrimaryCategory).falsePositive++
+		}
+		if got.MaxSeverity == item.Severity {
+			severityHits++
+		}
+		diff := got.MaxSeverity - item.Severity
+		if diff < 0 {
+			diff = -diff
+		}
+		severityError += diff
+		if item.Sentiment != "" {
+			report.SentimentSamples++
+			if got.SentimentCategory == item.Sentiment {
+				sentimentHits++
+			}
+		}
+	}
+
+	total := float64(len(corpus))
+	report.CategoryAccuracy = round4(float64(categoryHits) / total)
+	report.SeverityAccuracy = round4(float64(severityHits) / total)
+	report.SeverityMAE = round4(float64(severityError) / total)
+	if report.SentimentSamples > 0 {
+		report.SentimentAccuracy = round4(float64(sentimentHits) / float64(report.SentimentSamples))
+	}
+
+	names := make([]string, 0, len(perCategory))
+	for name := range perCategory {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		c := perCategory[name]
+		metrics := CategoryMetrics{Category: name, Support: c.support}
+		if predicted := c.truePositive + c.falsePositive; predicted > 0 {
+			metrics.Precision = round4(float64(c.truePositive) / float64(predicted))
+		}
+		if c.support > 0 {
+			metrics.Recall = round4(float64(c.truePositive) / float64(c.support))
+		}
+		if metrics.Precision+metrics.Recall > 0 {
+			metrics.F1 = round4(2 * metrics.Precision * metrics.Recall / (metrics.Precision + metrics.Recall))
+		}
+		report.Categories = append(report.Categories, metrics)
+	}
+	return report, nil
+}
+
+func round4(value float64) float64 {
+	return math.Round(value*10000) / 10000
+}
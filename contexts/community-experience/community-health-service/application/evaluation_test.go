@@ -0,0 +1,58 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"solomon/contexts/community-experience/community-health-service/ports"
+)
+
+type keywordClassifier map[string]ports.ToxicitySignal
+
+func (c keywordClassifier) Classify(ctx context.Context, input ports.ClassificationInput) (ports.ClassifierOutput, error) {
+	return ports.ClassifierOutput{Toxicity: c[input.Content]}, nil
+}
+
+func TestEvaluateClassifierScoresCategoriesAndSeverity(t *testing.T) {
+	corpus, err := ReadLabelledCorpus(strings.NewReader(`
+{"content": "clean", "category": "none", "severity": 0, "sentiment": "neutral"}
+{"content": "mean", "category": "harassment", "severity": 2}
+{"content": "spammy", "category": "spam", "severity": 1}
+`))
+	if err != nil {
+		t.Fatalf("read corpus: %v", err)
+	}
+	classifier := keywordClassifier{
+		"mean":   {Harassment: 0.75},
+		"spammy": {Harassment: 0.55},
+	}
+
+	report, err := EvaluateClassifier(context.Background(), classifier, corpus)
+	if err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+	if report.Samples != 3 || report.CategoryAccuracy != 0.6667 || report.SeverityAccuracy != 1 {
+		t.Fatalf("unexpected summary: %+v", report)
+	}
+	if report.SentimentSamples != 1 || report.SentimentAccuracy != 1 {
+		t.Fatalf("unexpected sentiment summary: %+v", report)
+	}
+	for _, metrics := range report.Categories {
+		if metrics.Category == "harassment" && (metrics.Precision != 0.5 || metrics.Recall != 1) {
+			t.Fatalf("unexpected harassment metrics: %+v", metrics)
+		}
+		if metrics.Category == "spam" && metrics.Recall != 0 {
+			t.Fatalf("unexpected spam metrics: %+v", metrics)
+		}
+	}
+}
+
+func TestReadLabelledCorpusRejectsInvalidLines(t *testing.T) {
+	if _, err := ReadLabelledCorpus(strings.NewReader(`{"content": "x", "severity": 5}`)); err == nil {
+		t.Fatalf("expected out-of-range severity to be rejected")
+	}
+	if _, err := ReadLabelledCorpus(strings.NewReader(`not json`)); err == nil {
+		t.Fatalf("expected malformed line to be rejected")
+	}
+}
@@ -0,0 +1,163 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	domainerrors "solomon/contexts/community-experience/community-health-service/domain/errors"
+	"solomon/contexts/community-experience/community-health-service/ports"
+)
+
+const maxModerationNoteLength = 1000
+
+func (s Service) ListAlerts(ctx context.Context, serverID string, status string) ([]ports.RealTimeAlert, error) {
+	serverID = strings.TrimSpace(serverID)
+	status = strings.ToLower(strings.TrimSpace(status))
+	if serverID == "" || (status != "" && !isAlertStatus(status)) {
+		return nil, domainerrors.ErrInvalidRequest
+	}
+	return s.Repo.ListAlerts(ctx, serverID, status)
+}
+
+// TransitionAlert moves an alert along its lifecycle on behalf of a
+// moderator. Terminal alerts cannot change again.
+func (s Service) TransitionAlert(
+	ctx context.Context,
+	idempotencyKey string,
+	input ports.AlertTransitionInput,
+) (ports.RealTimeAlert, error) {
+	var out ports.RealTimeAlert
+	input.ServerID = strings.TrimSpace(input.ServerID)
+	input.AlertID = strings.TrimSpace(input.AlertID)
+	input.ModeratorID = strings.TrimSpace(input.ModeratorID)
+	input.Status = strings.ToLower(strings.TrimSpace(input.Status))
+	input.Note = strings.TrimSpace(input.Note)
+	if input.ServerID == "" || input.AlertID == "" || input.ModeratorID == "" ||
+		input.Status == ports.AlertStatusSent || !isAlertStatus(input.Status) ||
+		len(input.Note) > maxModerationNoteLength {
+		return out, domainerrors.ErrInvalidRequest
+	}
+	if err := s.requireIdempotency(idempotencyKey); err != nil {
+		return out, err
+	}
+	requestHash := hashStrings(
+		"community_health_transition_alert",
+		input.ServerID,
+		input.AlertID,
+		input.ModeratorID,
+		input.Status,
+		input.Note,
+	)
+	err := s.runIdempotent(
+		ctx,
+		idempotencyKey,
+		requestHash,
+		func(raw []byte) error { return json.Unmarshal(raw, &out) },
+		func() ([]byte, error) {
+			alert, err := s.Repo.TransitionAlert(ctx, input, s.now())
+			if err != nil {
+				return nil, err
+			}
+			ResolveLogger(s.Logger).Info("community health alert transitioned",
+				"event", "community_health_alert_transitioned",
+				"module", "community-experience/community-health-service",
+				"layer", "application",
+				"server_id", alert.ServerID,
+				"alert_id", alert.AlertID,
+				"status", alert.Status,
+			)
+			return json.Marshal(alert)
+		},
+	)
+	return out, err
+}
+
+// RecordModerationFeedback stores a moderator's verdict on a message's
+// toxicity classification and returns the server's retuned thresholds.
+// False negatives must name the category the classifier missed; the other
+// verdicts apply to the category the message was flagged for.
+func (s Service) RecordModerationFeedback(
+	ctx context.Context,
+	idempotencyKey string,
+	input ports.ModerationFeedbackInput,
+) (ports.ModerationFeedbackResult, error) {
+	var out ports.ModerationFeedbackResult
+	input.ServerID = strings.TrimSpace(input.ServerID)
+	input.MessageID = strings.TrimSpace(input.MessageID)
+	input.ModeratorID = strings.TrimSpace(input.ModeratorID)
+	input.FeedbackType = strings.ToLower(strings.TrimSpace(input.FeedbackType))
+	input.Category = strings.ToLower(strings.TrimSpace(input.Category))
+	input.Note = strings.TrimSpace(input.Note)
+	if input.ServerID == "" || input.MessageID == "" || input.ModeratorID == "" ||
+		len(input.Note) > maxModerationNoteLength {
+		return out, domainerrors.ErrInvalidRequest
+	}
+	switch input.FeedbackType {
+	case ports.FeedbackConfirmed, ports.FeedbackFalsePositive:
+		if input.Category != "" && !IsToxicityCategory(input.Category) {
+			return out, domainerrors.ErrInvalidRequest
+		}
+	case ports.FeedbackFalseNegative:
+		if !IsToxicityCategory(input.Category) {
+			return out, domainerrors.ErrInvalidRequest
+		}
+	default:
+		return out, domainerrors.ErrInvalidRequest
+	}
+	if err := s.requireIdempotency(idempotencyKey); err != nil {
+		return out, err
+	}
+	requestHash := hashStrings(
+		"community_health_record_feedback",
+		input.ServerID,
+		input.MessageID,
+		input.ModeratorID,
+		input.FeedbackType,
+		input.Category,
+		input.Note,
+	)
+	err := s.runIdempotent(
+		ctx,
+		idempotencyKey,
+		requestHash,
+		func(raw []byte) error { return json.Unmarshal(raw, &out) },
+		func() ([]byte, error) {
+			result, err := s.Repo.RecordModerationFeedback(ctx, input, s.now())
+			if err != nil {
+				return nil, err
+			}
+			ResolveLogger(s.Logger).Info("community health moderation feedback recorded",
+				"event", "community_health_moderation_feedback_recorded",
+				"module", "community-experience/community-health-service",
+				"layer", "application",
+				"server_id", result.Feedback.ServerID,
+				"message_id", result.Feedback.MessageID,
+				"feedback_type", result.Feedback.FeedbackType,
+				"category", result.Feedback.Category,
+				"threshold", result.Tuning.Thresholds[result.Feedback.Category],
+			)
+			return json.Marshal(result)
+		},
+	)
+	return out, err
+}
+
+func (s Service) GetModerationTuning(ctx context.Context, serverID string) (ports.ModerationTuning, error) {
+	if strings.TrimSpace(serverID) == "" {
+		return ports.ModerationTuning{}, domainerrors.ErrInvalidRequest
+	}
+	return s.Repo.GetModerationTuning(ctx, strings.TrimSpace(serverID))
+}
+
+func isAlertStatus(status string) bool {
+	switch status {
+	case ports.AlertStatusSent,
+		ports.AlertStatusAcknowledged,
+		ports.AlertStatusResolved,
+		ports.AlertStatusDismissed:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,259 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	domainerrors "solomon/contexts/community-experience/community-health-service/domain/errors"
+	"solomon/contexts/community-experience/community-health-service/ports"
+)
+
+const (
+	weekDateLayout     = "2006-01-02"
+	maxReportPageLimit = 52
+	trendFlatTolerance = 0.005
+	trendDirectionUp   = "up"
+	trendDirectionDown = "down"
+	trendDirectionFlat = "flat"
+)
+
+// WeeklyReportRun summarizes one pass of the weekly report job.
+type WeeklyReportRun struct {
+	WeekStartDate string
+	Servers       int
+	Generated     int
+	Existing      int
+	Failed        int
+}
+
+// GenerateWeeklyReports builds the report for the last completed week (weeks
+// start Monday 00:00 UTC) for every active server that lacks one. It is safe
+// to run repeatedly: reports already stored for the week are left alone.
+func (s Service) GenerateWeeklyReports(ctx context.Context) (WeeklyReportRun, error) {
+	if s.Reports == nil {
+		return WeeklyReportRun{}, nil
+	}
+	weekStart := WeekStart(s.now()).AddDate(0, 0, -7)
+	run := WeeklyReportRun{WeekStartDate: weekStart.Format(weekDateLayout)}
+	servers, err := s.Repo.ListActiveServers(ctx)
+	if err != nil {
+		return run, err
+	}
+	run.Servers = len(servers)
+
+	var firstErr error
+	for _, serverID := range servers {
+		if _, err := s.Reports.GetWeeklyReportForWeek(ctx, serverID, run.WeekStartDate); err == nil {
+			run.Existing++
+			continue
+		} else if !errors.Is(err, domainerrors.ErrNotFound) {
+			run.Failed++
+			firstErr = errors.Join(firstErr, err)
+			continue
+		}
+		_, created, err := s.BuildWeeklyReport(ctx, serverID, weekStart)
+		if err != nil {
+			run.Failed++
+			firstErr = errors.Join(firstErr, err)
+			ResolveLogger(s.Logger).Warn("community health weekly report failed",
+				"event", "community_health_weekly_report_failed",
+				"module", "community-experience/community-health-service",
+				"layer", "application",
+				"server_id", serverID,
+				"week_start_date", run.WeekStartDate,
+				"error", err.Error(),
+			)
+			continue
+		}
+		if created {
+			run.Generated++
+		} else {
+			run.Existing++
+		}
+	}
+	return run, firstErr
+}
+
+// BuildWeeklyReport builds and stores a server's report for the week starting
+// at weekStart, comparing it with the previous week's stored report.
+func (s Service) BuildWeeklyReport(
+	ctx context.Context,
+	serverID string,
+	weekStart time.Time,
+) (ports.WeeklyHealthReport, bool, error) {
+	if s.Reports == nil {
+		return ports.WeeklyHealthReport{}, false, domainerrors.ErrNotFound
+	}
+	weekStart = WeekStart(weekStart)
+	weekEnd := weekStart.AddDate(0, 0, 7)
+	activity, err := s.Repo.GetWeeklyActivity(ctx, serverID, weekStart, weekEnd)
+	if err != nil {
+		return ports.WeeklyHealthReport{}, false, err
+	}
+
+	alerts := summarizeAlerts(activity.Alerts)
+	report := ports.WeeklyHealthReport{
+		ReportID:      "report_" + hashStrings("community_health_weekly_report", serverID, weekStart.Format(weekDateLayout))[:24],
+		ServerID:      serverID,
+		WeekStartDate: weekStart.Format(weekDateLayout),
+		WeekEndDate:   weekEnd.AddDate(0, 0, -1).Format(weekDateLayout),
+		Metrics: ports.WeeklyReportMetrics{
+			TotalMessages:      activity.TotalMessages,
+			ActiveUsers:        activity.ActiveUsers,
+			PositivePct:        activity.PositivePct,
+			NegativePct:        activity.NegativePct,
+			ToxicityPct:        activity.ToxicityPct,
+			AvgSentiment:       activity.AvgSentiment,
+			HealthScore:        activity.HealthScore,
+			HealthCategory:     activity.HealthCategory,
+			AvgAckLatencyHours: alerts.AvgAckLatencyHours,
+		},
+		Alerts:       alerts,
+		TopRiskUsers: make([]ports.RiskUserSummary, 0, len(activity.TopRiskUsers)),
+		Trends:       []ports.MetricTrend{},
+		GeneratedAt:  s.now(),
+	}
+	for _, risk := range activity.TopRiskUsers {
+		report.TopRiskUsers = append(report.TopRiskUsers, ports.RiskUserSummary{
+			UserID:            risk.UserID,
+			RiskScore:         risk.RiskScore,
+			RiskLevel:         risk.RiskLevel,
+			ToxicMessageCount: risk.ToxicMessageCount,
+			WarningCount:      risk.WarningCount,
+		})
+	}
+
+	previousWeek := weekStart.AddDate(0, 0, -7).Format(weekDateLayout)
+	previous, err := s.Reports.GetWeeklyReportForWeek(ctx, serverID, previousWeek)
+	switch {
+	case err == nil:
+		report.PreviousWeekStartDate = previous.WeekStartDate
+		report.Trends = weeklyTrends(report, previous)
+	case !errors.Is(err, domainerrors.ErrNotFound):
+		return ports.WeeklyHealthReport{}, false, err
+	}
+
+	stored, created, err := s.Reports.SaveWeeklyReport(ctx, report)
+	if err != nil {
+		return ports.WeeklyHealthReport{}, false, err
+	}
+	if created {
+		ResolveLogger(s.Logger).Info("community health weekly report generated",
+			"event", "community_health_weekly_report_generated",
+			"module", "community-experience/community-health-service",
+			"layer", "application",
+			"server_id", serverID,
+			"report_id", stored.ReportID,
+			"week_start_date", stored.WeekStartDate,
+		)
+	}
+	return stored, created, nil
+}
+
+func (s Service) ListWeeklyReports(ctx context.Context, input ports.ListWeeklyReportsInput) (ports.WeeklyReportPage, error) {
+	input.ServerID = strings.TrimSpace(input.ServerID)
+	input.BeforeWeek = strings.TrimSpace(input.BeforeWeek)
+	if input.ServerID == "" || input.Limit < 0 || input.Limit > maxReportPageLimit {
+		return ports.WeeklyReportPage{}, domainerrors.ErrInvalidRequest
+	}
+	if input.BeforeWeek != "" {
+		if _, err := time.Parse(weekDateLayout, input.BeforeWeek); err != nil {
+			return ports.WeeklyReportPage{}, domainerrors.ErrInvalidRequest
+		}
+	}
+	if s.Reports == nil {
+		return ports.WeeklyReportPage{Reports: []ports.WeeklyHealthReport{}}, nil
+	}
+	return s.Reports.ListWeeklyReports(ctx, input)
+}
+
+func (s Service) GetWeeklyReport(ctx context.Context, serverID string, reportID string) (ports.WeeklyHealthReport, error) {
+	serverID = strings.TrimSpace(serverID)
+	reportID = strings.TrimSpace(reportID)
+	if serverID == "" || reportID == "" {
+		return ports.WeeklyHealthReport{}, domainerrors.ErrInvalidRequest
+	}
+	if s.Reports == nil {
+		return ports.WeeklyHealthReport{}, domainerrors.ErrNotFound
+	}
+	return s.Reports.GetWeeklyReport(ctx, serverID, reportID)
+}
+
+// WeekStart returns midnight UTC on the Monday of ts's week.
+func WeekStart(ts time.Time) time.Time {
+	day := ts.UTC()
+	offset := (int(day.Weekday()) + 6) % 7
+	return time.Date(day.Year(), day.Month(), day.Day()-offset, 0, 0, 0, 0, time.UTC)
+}
+
+func summarizeAlerts(alerts []ports.RealTimeAlert) ports.AlertSummary {
+	summary := ports.AlertSummary{
+		Total:      len(alerts),
+		ByType:     make(map[string]int),
+		BySeverity: make(map[string]int),
+	}
+	latencyTotal := 0.0
+	acknowledged := 0
+	for _, alert := range alerts {
+		summary.ByType[alert.AlertType]++
+		summary.BySeverity[alert.Severity]++
+		switch alert.Status {
+		case ports.AlertStatusSent:
+			summary.Open++
+		case ports.AlertStatusAcknowledged:
+			summary.Open++
+			summary.Acknowledged++
+		case ports.AlertStatusResolved:
+			summary.Resolved++
+		case ports.AlertStatusDismissed:
+			summary.Dismissed++
+		}
+		if alert.AcknowledgedAt != nil {
+			latencyTotal += math.Max(0, alert.AcknowledgedAt.Sub(alert.TriggeredAt).Hours())
+			acknowledged++
+		}
+	}
+	if acknowledged > 0 {
+		summary.AvgAckLatencyHours = round2(latencyTotal / float64(acknowledged))
+	}
+	return summary
+}
+
+func weeklyTrends(current ports.WeeklyHealthReport, previous ports.WeeklyHealthReport) []ports.MetricTrend {
+	pairs := []struct {
+		metric   string
+		current  float64
+		previous float64
+	}{
+		{"health_score", float64(current.Metrics.HealthScore), float64(previous.Metrics.HealthScore)},
+		{"total_messages", float64(current.Metrics.TotalMessages), float64(previous.Metrics.TotalMessages)},
+		{"active_users", float64(current.Metrics.ActiveUsers), float64(previous.Metrics.ActiveUsers)},
+		{"positive_pct", current.Metrics.PositivePct, previous.Metrics.PositivePct},
+		{"toxicity_pct", current.Metrics.ToxicityPct, previous.Metrics.ToxicityPct},
+		{"avg_sentiment", current.Metrics.AvgSentiment, previous.Metrics.AvgSentiment},
+		{"alerts", float64(current.Alerts.Total), float64(previous.Alerts.Total)},
+		{"avg_ack_latency_hours", current.Metrics.AvgAckLatencyHours, previous.Metrics.AvgAckLatencyHours},
+	}
+	trends := make([]ports.MetricTrend, 0, len(pairs))
+	for _, pair := range pairs {
+		change := round2(pair.current - pair.previous)
+		direction := trendDirectionFlat
+		switch {
+		case change > trendFlatTolerance:
+			direction = trendDirectionUp
+		case change < -trendFlatTolerance:
+			direction = trendDirectionDown
+		}
+		trends = append(trends, ports.MetricTrend{
+			Metric:    pair.metric,
+			Current:   pair.current,
+			Previous:  pair.previous,
+			Change:    change,
+			Direction: direction,
+		})
+	}
+	return trends
+}
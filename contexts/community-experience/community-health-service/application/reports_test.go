@@ -0,0 +1,122 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domainerrors "solomon/contexts/community-experience/community-health-service/domain/errors"
+	"solomon/contexts/community-experience/community-health-service/ports"
+)
+
+type testReports struct {
+	byWeek map[string]ports.WeeklyHealthReport
+	saves  int
+}
+
+func (r *testReports) SaveWeeklyReport(
+	ctx context.Context,
+	report ports.WeeklyHealthReport,
+) (ports.WeeklyHealthReport, bool, error) {
+	key := report.ServerID + "|" + report.WeekStartDate
+	if existing, ok := r.byWeek[key]; ok {
+		return existing, false, nil
+	}
+	r.saves++
+	r.byWeek[key] = report
+	return report, true, nil
+}
+
+func (r *testReports) GetWeeklyReport(ctx context.Context, serverID string, reportID string) (ports.WeeklyHealthReport, error) {
+	for _, report := range r.byWeek {
+		if report.ServerID == serverID && report.ReportID == reportID {
+			return report, nil
+		}
+	}
+	return ports.WeeklyHealthReport{}, domainerrors.ErrNotFound
+}
+
+func (r *testReports) GetWeeklyReportForWeek(
+	ctx context.Context,
+	serverID string,
+	weekStartDate string,
+) (ports.WeeklyHealthReport, error) {
+	report, ok := r.byWeek[serverID+"|"+weekStartDate]
+	if !ok {
+		return ports.WeeklyHealthReport{}, domainerrors.ErrNotFound
+	}
+	return report, nil
+}
+
+func (r *testReports) ListWeeklyReports(
+	ctx context.Context,
+	input ports.ListWeeklyReportsInput,
+) (ports.WeeklyReportPage, error) {
+	return ports.WeeklyReportPage{}, nil
+}
+
+func TestGenerateWeeklyReportsBuildsLastWeekWithTrends(t *testing.T) {
+	triggeredAt := time.Date(2026, time.February, 3, 9, 0, 0, 0, time.UTC)
+	acknowledgedAt := triggeredAt.Add(90 * time.Minute)
+	repo := &testRepo{activity: map[string]ports.WeeklyActivity{
+		"server-1": {
+			TotalMessages:  40,
+			ActiveUsers:    6,
+			PositivePct:    0.55,
+			ToxicityPct:    0.05,
+			HealthScore:    68,
+			HealthCategory: "good",
+			Alerts: []ports.RealTimeAlert{
+				{AlertType: "threat", Severity: "high", Status: ports.AlertStatusResolved, TriggeredAt: triggeredAt, AcknowledgedAt: &acknowledgedAt},
+				{AlertType: "sentiment_spike", Severity: "high", Status: ports.AlertStatusSent, TriggeredAt: triggeredAt},
+			},
+			TopRiskUsers: []ports.UserRiskScore{{UserID: "user-risky", RiskScore: 0.62, RiskLevel: "orange", ToxicMessageCount: 3}},
+		},
+	}}
+	reports := &testReports{byWeek: map[string]ports.WeeklyHealthReport{
+		"server-1|2026-01-26": {
+			ServerID:      "server-1",
+			WeekStartDate: "2026-01-26",
+			Metrics:       ports.WeeklyReportMetrics{TotalMessages: 50, ActiveUsers: 6, HealthScore: 72, PositivePct: 0.55},
+			Alerts:        ports.AlertSummary{Total: 1},
+		},
+	}}
+	service := Service{
+		Repo:    repo,
+		Reports: reports,
+		// Wednesday of the following week: the last completed week starts 2026-02-02.
+		Clock: fixedClock{now: time.Date(2026, time.February, 11, 8, 0, 0, 0, time.UTC)},
+	}
+
+	run, err := service.GenerateWeeklyReports(context.Background())
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	if run.WeekStartDate != "2026-02-02" || run.Generated != 1 {
+		t.Fatalf("unexpected run: %+v", run)
+	}
+	report := reports.byWeek["server-1|2026-02-02"]
+	if report.WeekEndDate != "2026-02-08" || report.PreviousWeekStartDate != "2026-01-26" {
+		t.Fatalf("unexpected report window: %+v", report)
+	}
+	if report.Alerts.Total != 2 || report.Alerts.Open != 1 || report.Alerts.Resolved != 1 ||
+		report.Alerts.ByType["threat"] != 1 || report.Metrics.AvgAckLatencyHours != 1.5 {
+		t.Fatalf("unexpected alert summary: %+v", report.Alerts)
+	}
+	if len(report.TopRiskUsers) != 1 || report.TopRiskUsers[0].UserID != "user-risky" {
+		t.Fatalf("unexpected top risk users: %+v", report.TopRiskUsers)
+	}
+	trends := make(map[string]ports.MetricTrend, len(report.Trends))
+	for _, trend := range report.Trends {
+		trends[trend.Metric] = trend
+	}
+	if trends["health_score"].Change != -4 || trends["health_score"].Direction != "down" ||
+		trends["positive_pct"].Direction != "flat" || trends["alerts"].Direction != "up" {
+		t.Fatalf("unexpected trends: %+v", report.Trends)
+	}
+
+	again, err := service.GenerateWeeklyReports(context.Background())
+	if err != nil || again.Generated != 0 || again.Existing != 1 || reports.saves != 1 {
+		t.Fatalf("expected rerun to keep the stored report, run=%+v saves=%d err=%v", again, reports.saves, err)
+	}
+}
@@ -15,8 +15,10 @@ import (
 
 type Service struct {
 	Repo           ports.Repository
+	Reports        ports.ReportRepository
 	Idempotency    ports.IdempotencyStore
 	Clock          ports.Clock
+	Classifier     ports.MessageClassifier
 	Logger         *slog.Logger
 	IdempotencyTTL time.Duration
 }
@@ -47,7 +49,11 @@ func (s Service) IngestWebhook(
 		requestHash,
 		func(raw []byte) error { return json.Unmarshal(raw, &out) },
 		func() ([]byte, error) {
-			result, err := s.Repo.IngestWebhook(ctx, input, s.now())
+			classification, err := s.classify(ctx, input)
+			if err != nil {
+				return nil, err
+			}
+			result, err := s.Repo.IngestWebhook(ctx, input, classification, s.now())
 			if err != nil {
 				return nil, err
 			}
@@ -124,7 +130,7 @@ func (s Service) runIdempotent(
 		return err
 	}
 
-	resolveLogger(s.Logger).Debug("community health idempotent operation committed",
+	ResolveLogger(s.Logger).Debug("community health idempotent operation committed",
 		"event", "community_health_idempotent_operation_committed",
 		"module", "community-experience/community-health-service",
 		"layer", "application",
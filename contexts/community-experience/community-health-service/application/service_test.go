@@ -9,11 +9,21 @@ import (
 )
 
 type testRepo struct {
-	lastInput ports.WebhookIngestInput
+	lastInput          ports.WebhookIngestInput
+	lastClassification *ports.MessageClassification
+	thresholds         map[string]float64
+	feedbackCalls      int
+	activity           map[string]ports.WeeklyActivity
 }
 
-func (r *testRepo) IngestWebhook(ctx context.Context, input ports.WebhookIngestInput, now time.Time) (ports.IngestionResult, error) {
+func (r *testRepo) IngestWebhook(
+	ctx context.Context,
+	input ports.WebhookIngestInput,
+	classification *ports.MessageClassification,
+	now time.Time,
+) (ports.IngestionResult, error) {
 	r.lastInput = input
+	r.lastClassification = classification
 	return ports.IngestionResult{
 		MessageID:   input.MessageID,
 		EventType:   input.EventType,
@@ -29,6 +39,57 @@ func (r *testRepo) GetUserRiskScore(ctx context.Context, serverID string, userID
 	return ports.UserRiskScore{}, nil
 }
 
+func (r *testRepo) ListAlerts(ctx context.Context, serverID string, status string) ([]ports.RealTimeAlert, error) {
+	return nil, nil
+}
+
+func (r *testRepo) TransitionAlert(
+	ctx context.Context,
+	input ports.AlertTransitionInput,
+	now time.Time,
+) (ports.RealTimeAlert, error) {
+	return ports.RealTimeAlert{AlertID: input.AlertID, ServerID: input.ServerID, Status: input.Status}, nil
+}
+
+func (r *testRepo) RecordModerationFeedback(
+	ctx context.Context,
+	input ports.ModerationFeedbackInput,
+	now time.Time,
+) (ports.ModerationFeedbackResult, error) {
+	r.feedbackCalls++
+	return ports.ModerationFeedbackResult{Feedback: ports.ModerationFeedback{
+		ServerID:     input.ServerID,
+		MessageID:    input.MessageID,
+		FeedbackType: input.FeedbackType,
+		Category:     input.Category,
+	}}, nil
+}
+
+func (r *testRepo) ListActiveServers(ctx context.Context) ([]string, error) {
+	servers := make([]string, 0, len(r.activity))
+	for serverID := range r.activity {
+		servers = append(servers, serverID)
+	}
+	return servers, nil
+}
+
+func (r *testRepo) GetWeeklyActivity(
+	ctx context.Context,
+	serverID string,
+	weekStart time.Time,
+	weekEnd time.Time,
+) (ports.WeeklyActivity, error) {
+	activity := r.activity[serverID]
+	activity.ServerID = serverID
+	activity.WeekStart = weekStart
+	activity.WeekEnd = weekEnd
+	return activity, nil
+}
+
+func (r *testRepo) GetModerationTuning(ctx context.Context, serverID string) (ports.ModerationTuning, error) {
+	return ports.ModerationTuning{ServerID: serverID, Thresholds: r.thresholds}, nil
+}
+
 type testIdempotency struct {
 	store map[string]ports.IdempotencyRecord
 }
@@ -52,6 +113,16 @@ type fixedClock struct {
 
 func (c fixedClock) Now() time.Time { return c.now }
 
+type stubClassifier struct {
+	output ports.ClassifierOutput
+	inputs []ports.ClassificationInput
+}
+
+func (c *stubClassifier) Classify(ctx context.Context, input ports.ClassificationInput) (ports.ClassifierOutput, error) {
+	c.inputs = append(c.inputs, input)
+	return c.output, nil
+}
+
 func TestIngestWebhookAllowsEditedEventWithoutUserID(t *testing.T) {
 	repo := &testRepo{}
 	clock := fixedClock{now: time.Date(2026, time.February, 5, 12, 0, 0, 0, time.UTC)}
@@ -59,6 +130,7 @@ func TestIngestWebhookAllowsEditedEventWithoutUserID(t *testing.T) {
 		Repo:        repo,
 		Idempotency: &testIdempotency{store: make(map[string]ports.IdempotencyRecord)},
 		Clock:       clock,
+		Classifier:  &stubClassifier{},
 	}
 
 	editedAt := clock.now
@@ -76,3 +148,115 @@ func TestIngestWebhookAllowsEditedEventWithoutUserID(t *testing.T) {
 		t.Fatalf("expected normalized event type, got %q", repo.lastInput.EventType)
 	}
 }
+
+func TestIngestWebhookClassifiesEditedContentAndDerivesSeverity(t *testing.T) {
+	repo := &testRepo{}
+	classifier := &stubClassifier{output: ports.ClassifierOutput{
+		Sentiment: ports.SentimentSignal{Score: -0.6, Confidence: 0.88},
+		Toxicity:  ports.ToxicitySignal{Harassment: 0.85, Threats: 0.65},
+	}}
+	service := Service{
+		Repo:        repo,
+		Idempotency: &testIdempotency{store: make(map[string]ports.IdempotencyRecord)},
+		Clock:       fixedClock{now: time.Date(2026, time.February, 5, 12, 0, 0, 0, time.UTC)},
+		Classifier:  classifier,
+	}
+
+	_, err := service.IngestWebhook(context.Background(), "idem-2", ports.WebhookIngestInput{
+		EventType:  "chat.message.edited",
+		MessageID:  "msg-2",
+		ServerID:   "server-1",
+		Content:    "original",
+		NewContent: "  edited text  ",
+	})
+	if err != nil {
+		t.Fatalf("ingest failed: %v", err)
+	}
+	if len(classifier.inputs) != 1 || classifier.inputs[0].Content != "edited text" {
+		t.Fatalf("expected edited content to be classified, got %+v", classifier.inputs)
+	}
+	got := repo.lastClassification
+	if got == nil {
+		t.Fatalf("expected classification to reach the repository")
+	}
+	if got.PrimaryCategory != "harassment" || got.MaxSeverity != 2 || got.SentimentCategory != "very_negative" {
+		t.Fatalf("unexpected derived classification: %+v", got)
+	}
+
+	_, err = service.IngestWebhook(context.Background(), "idem-3", ports.WebhookIngestInput{
+		EventType: "chat.message.deleted",
+		MessageID: "msg-2",
+		ServerID:  "server-1",
+	})
+	if err != nil {
+		t.Fatalf("delete ingest failed: %v", err)
+	}
+	if len(classifier.inputs) != 1 || repo.lastClassification != nil {
+		t.Fatalf("expected deletions to skip classification")
+	}
+}
+
+func TestIngestWebhookAppliesServerTunedThresholds(t *testing.T) {
+	repo := &testRepo{thresholds: map[string]float64{"spam": 0.56, "harassment": 0.44}}
+	service := Service{
+		Repo:        repo,
+		Idempotency: &testIdempotency{store: make(map[string]ports.IdempotencyRecord)},
+		Clock:       fixedClock{now: time.Date(2026, time.February, 5, 12, 0, 0, 0, time.UTC)},
+		Classifier: &stubClassifier{output: ports.ClassifierOutput{
+			Toxicity: ports.ToxicitySignal{Spam: 0.55, Harassment: 0.45},
+		}},
+	}
+
+	_, err := service.IngestWebhook(context.Background(), "idem-tuned", ports.WebhookIngestInput{
+		EventType: "chat.message.created",
+		MessageID: "msg-tuned",
+		ServerID:  "server-1",
+		UserID:    "user-1",
+		Content:   "buy now",
+	})
+	if err != nil {
+		t.Fatalf("ingest failed: %v", err)
+	}
+	got := repo.lastClassification
+	if got.PrimaryCategory != "harassment" || got.MaxSeverity != 1 {
+		t.Fatalf("expected tuned thresholds to flag harassment over spam, got %+v", got)
+	}
+}
+
+func TestRecordModerationFeedbackValidatesVerdict(t *testing.T) {
+	repo := &testRepo{}
+	service := Service{
+		Repo:        repo,
+		Idempotency: &testIdempotency{store: make(map[string]ports.IdempotencyRecord)},
+		Clock:       fixedClock{now: time.Date(2026, time.February, 5, 12, 0, 0, 0, time.UTC)},
+	}
+	base := ports.ModerationFeedbackInput{ServerID: "server-1", MessageID: "msg-1", ModeratorID: "mod-1"}
+
+	missingCategory := base
+	missingCategory.FeedbackType = ports.FeedbackFalseNegative
+	if _, err := service.RecordModerationFeedback(context.Background(), "idem-fn", missingCategory); err == nil {
+		t.Fatalf("expected false negative without category to be rejected")
+	}
+	unknownType := base
+	unknownType.FeedbackType = "maybe"
+	if _, err := service.RecordModerationFeedback(context.Background(), "idem-unknown", unknownType); err == nil {
+		t.Fatalf("expected unknown feedback type to be rejected")
+	}
+
+	valid := base
+	valid.FeedbackType = " False_Negative "
+	valid.Category = "Threats"
+	result, err := service.RecordModerationFeedback(context.Background(), "idem-valid", valid)
+	if err != nil {
+		t.Fatalf("feedback failed: %v", err)
+	}
+	if result.Feedback.FeedbackType != ports.FeedbackFalseNegative || result.Feedback.Category != "threats" {
+		t.Fatalf("expected normalized feedback, got %+v", result.Feedback)
+	}
+	if _, err := service.RecordModerationFeedback(context.Background(), "idem-valid", valid); err != nil {
+		t.Fatalf("replay failed: %v", err)
+	}
+	if repo.feedbackCalls != 1 {
+		t.Fatalf("expected idempotent replay to skip the repository, got %d calls", repo.feedbackCalls)
+	}
+}
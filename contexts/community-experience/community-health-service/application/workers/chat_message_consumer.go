@@ -0,0 +1,164 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	application "solomon/contexts/community-experience/community-health-service/application"
+	domainerrors "solomon/contexts/community-experience/community-health-service/domain/errors"
+	"solomon/contexts/community-experience/community-health-service/ports"
+)
+
+const defaultChatMessageConsumerGroup = "community-health-service-chat-messages-cg"
+
+// ChatMessageTopics are the chat-service lifecycle events that feed message
+// scoring, user risk and health scores.
+var ChatMessageTopics = []string{
+	"chat.message.created",
+	"chat.message.edited",
+	"chat.message.deleted",
+}
+
+type chatMessagePayload struct {
+	MessageID       string `json:"message_id"`
+	ServerID        string `json:"server_id"`
+	ChannelID       string `json:"channel_id"`
+	ThreadID        string `json:"thread_id"`
+	UserID          string `json:"user_id"`
+	Content         string `json:"content"`
+	OldContent      string `json:"old_content"`
+	CreatedAt       string `json:"created_at"`
+	EditedAt        string `json:"edited_at"`
+	DeletedAt       string `json:"deleted_at"`
+	DeletedByUserID string `json:"deleted_by_user_id"`
+}
+
+// ChatMessageConsumer ingests chat.message.* events through the same use case
+// as the chat webhook. The event ID doubles as the idempotency key, so
+// redelivered events are acknowledged without being scored twice.
+type ChatMessageConsumer struct {
+	Subscriber    ports.EventSubscriber
+	Service       application.Service
+	ConsumerGroup string
+	Logger        *slog.Logger
+}
+
+func (c ChatMessageConsumer) Start(ctx context.Context) error {
+	logger := application.ResolveLogger(c.Logger)
+	group := c.ConsumerGroup
+	if group == "" {
+		group = defaultChatMessageConsumerGroup
+	}
+	for _, topic := range ChatMessageTopics {
+		if err := c.Subscriber.Subscribe(ctx, topic, group, c.Handle); err != nil {
+			logger.Error("community health chat consumer subscribe failed",
+				"event", "community_health_chat_consumer_subscribe_failed",
+				"module", "community-experience/community-health-service",
+				"layer", "worker",
+				"topic", topic,
+				"consumer_group", group,
+				"error", err.Error(),
+			)
+			return err
+		}
+	}
+	logger.Info("community health chat consumer subscribed",
+		"event", "community_health_chat_consumer_subscribed",
+		"module", "community-experience/community-health-service",
+		"layer", "worker",
+		"consumer_group", group,
+	)
+	return nil
+}
+
+// Handle ingests one chat.message.* envelope.
+func (c ChatMessageConsumer) Handle(ctx context.Context, event ports.EventEnvelope) error {
+	logger := application.ResolveLogger(c.Logger)
+	var payload chatMessagePayload
+	if err := json.Unmarshal(event.Data, &payload); err != nil {
+		logger.Error("community health chat event decode failed",
+			"event", "community_health_chat_event_decode_failed",
+			"module", "community-experience/community-health-service",
+			"layer", "worker",
+			"event_id", event.EventID,
+			"error", err.Error(),
+		)
+		return err
+	}
+	if strings.TrimSpace(event.EventID) == "" {
+		return domainerrors.ErrInvalidRequest
+	}
+
+	input := ports.WebhookIngestInput{
+		EventID:         event.EventID,
+		EventType:       event.EventType,
+		MessageID:       payload.MessageID,
+		ServerID:        payload.ServerID,
+		ChannelID:       payload.ChannelID,
+		UserID:          payload.UserID,
+		Content:         payload.Content,
+		ThreadID:        payload.ThreadID,
+		OldContent:      payload.OldContent,
+		DeletedByUserID: payload.DeletedByUserID,
+		CreatedAt:       parseEventTime(payload.CreatedAt),
+		EditedAt:        parseEventTime(payload.EditedAt),
+		DeletedAt:       parseEventTime(payload.DeletedAt),
+	}
+	if event.EventType == "chat.message.edited" {
+		input.NewContent = payload.Content
+	}
+
+	result, err := c.Service.IngestWebhook(ctx, "chat-event:"+event.EventID, input)
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrInvalidRequest) {
+			// Malformed events cannot succeed on redelivery; log and drop.
+			logger.Warn("community health chat event rejected",
+				"event", "community_health_chat_event_rejected",
+				"module", "community-experience/community-health-service",
+				"layer", "worker",
+				"event_id", event.EventID,
+				"event_type", event.EventType,
+				"message_id", payload.MessageID,
+			)
+			return nil
+		}
+		logger.Error("community health chat event ingestion failed",
+			"event", "community_health_chat_event_ingestion_failed",
+			"module", "community-experience/community-health-service",
+			"layer", "worker",
+			"event_id", event.EventID,
+			"event_type", event.EventType,
+			"message_id", payload.MessageID,
+			"error", err.Error(),
+		)
+		return err
+	}
+
+	logger.Debug("community health chat event ingested",
+		"event", "community_health_chat_event_ingested",
+		"module", "community-experience/community-health-service",
+		"layer", "worker",
+		"event_id", event.EventID,
+		"event_type", result.EventType,
+		"message_id", result.MessageID,
+		"alerts_generated", result.AlertsGenerated,
+	)
+	return nil
+}
+
+func parseEventTime(raw string) *time.Time {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil
+	}
+	ts, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		return nil
+	}
+	ts = ts.UTC()
+	return &ts
+}
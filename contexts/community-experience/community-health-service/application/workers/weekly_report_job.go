@@ -0,0 +1,46 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+
+	application "solomon/contexts/community-experience/community-health-service/application"
+)
+
+// WeeklyReportJob builds last week's community health report for every
+// active server. Runs are idempotent, so it can be scheduled well inside a
+// week and still produces one report per server shortly after the boundary.
+type WeeklyReportJob struct {
+	Service  application.Service
+	Disabled bool
+	Logger   *slog.Logger
+}
+
+func (j WeeklyReportJob) RunOnce(ctx context.Context) error {
+	if j.Disabled {
+		return nil
+	}
+	logger := application.ResolveLogger(j.Logger)
+	run, err := j.Service.GenerateWeeklyReports(ctx)
+	if err != nil {
+		logger.Error("community health weekly report cycle failed",
+			"event", "community_health_weekly_report_cycle_failed",
+			"module", "community-experience/community-health-service",
+			"layer", "worker",
+			"week_start_date", run.WeekStartDate,
+			"failed", run.Failed,
+			"error", err.Error(),
+		)
+		return err
+	}
+	logger.Debug("community health weekly report cycle succeeded",
+		"event", "community_health_weekly_report_cycle_succeeded",
+		"module", "community-experience/community-health-service",
+		"layer", "worker",
+		"week_start_date", run.WeekStartDate,
+		"servers", run.Servers,
+		"generated", run.Generated,
+		"existing", run.Existing,
+	)
+	return nil
+}
@@ -10,4 +10,5 @@ var (
 	ErrForbidden              = errors.New("forbidden")
 	ErrConflict               = errors.New("conflict")
 	ErrUnauthorizedWebhook    = errors.New("webhook signature is invalid")
+	ErrClassifierUnavailable  = errors.New("message classifier is unavailable")
 )
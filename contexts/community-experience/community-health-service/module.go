@@ -1,54 +1,126 @@
 package communityhealthservice
 
 import (
+	"context"
 	"log/slog"
 	"time"
 
+	"solomon/contexts/community-experience/community-health-service/adapters/classifier"
 	httpadapter "solomon/contexts/community-experience/community-health-service/adapters/http"
 	"solomon/contexts/community-experience/community-health-service/adapters/memory"
 	"solomon/contexts/community-experience/community-health-service/application"
+	"solomon/contexts/community-experience/community-health-service/application/workers"
 	"solomon/contexts/community-experience/community-health-service/ports"
 )
 
 type Module struct {
 	Handler httpadapter.Handler
 	Store   *memory.Store
+	// ChatConsumer ingests chat.message.* events; nil without an event bus.
+	ChatConsumer  *workers.ChatMessageConsumer
+	WeeklyReports workers.WeeklyReportJob
 }
 
 type Dependencies struct {
-	Repository     ports.Repository
+	Repository ports.Repository
+	// Reports persists weekly health reports.
+	Reports        ports.ReportRepository
 	Idempotency    ports.IdempotencyStore
 	Clock          ports.Clock
 	IDGenerator    ports.IDGenerator
 	IdempotencyTTL time.Duration
 	Logger         *slog.Logger
+	// EventSubscriber feeds chat-service message events into ingestion.
+	EventSubscriber ports.EventSubscriber
+	// Classifier scores message content; nil uses the built-in lexicon.
+	Classifier ports.MessageClassifier
 }
 
 func NewModule(deps Dependencies) Module {
+	messageClassifier := deps.Classifier
+	if messageClassifier == nil {
+		messageClassifier = classifier.LexiconClassifier{}
+	}
 	service := application.Service{
 		Repo:           deps.Repository,
+		Reports:        deps.Reports,
 		Idempotency:    deps.Idempotency,
 		Clock:          deps.Clock,
+		Classifier:     messageClassifier,
 		Logger:         deps.Logger,
 		IdempotencyTTL: deps.IdempotencyTTL,
 	}
+	var consumer *workers.ChatMessageConsumer
+	if deps.EventSubscriber != nil {
+		consumer = &workers.ChatMessageConsumer{
+			Subscriber: deps.EventSubscriber,
+			Service:    service,
+			Logger:     deps.Logger,
+		}
+	}
 	return Module{
 		Handler: httpadapter.Handler{
 			Service: service,
 			Logger:  deps.Logger,
 		},
+		ChatConsumer: consumer,
+		WeeklyReports: workers.WeeklyReportJob{
+			Service: service,
+			Logger:  deps.Logger,
+		},
+	}
+}
+
+// StartConsumers subscribes to chat-service message events until ctx ends.
+func (m Module) StartConsumers(ctx context.Context) error {
+	if m.ChatConsumer == nil {
+		return nil
 	}
+	return m.ChatConsumer.Start(ctx)
+}
+
+// GenerateWeeklyReports builds any missing reports for the last completed week.
+func (m Module) GenerateWeeklyReports(ctx context.Context) error {
+	return m.WeeklyReports.RunOnce(ctx)
 }
 
 func NewInMemoryModule(logger *slog.Logger) Module {
+	return NewInMemoryModuleWithEvents(logger, nil, nil)
+}
+
+// NewInMemoryModuleWithEvents builds the in-memory module consuming chat
+// message events from subscriber and scoring them with messageClassifier
+// (the lexicon when nil).
+func NewInMemoryModuleWithEvents(
+	logger *slog.Logger,
+	subscriber ports.EventSubscriber,
+	messageClassifier ports.MessageClassifier,
+) Module {
+	return NewInMemoryModuleWithReports(logger, subscriber, messageClassifier, nil)
+}
+
+// NewInMemoryModuleWithReports is NewInMemoryModuleWithEvents with weekly
+// reports persisted to reports instead of the in-memory store.
+func NewInMemoryModuleWithReports(
+	logger *slog.Logger,
+	subscriber ports.EventSubscriber,
+	messageClassifier ports.MessageClassifier,
+	reports ports.ReportRepository,
+) Module {
 	store := memory.NewStore()
+	if reports == nil {
+		reports = store
+	}
 	module := NewModule(Dependencies{
-		Repository:     store,
-		Idempotency:    store,
-		Clock:          store,
-		IDGenerator:    store,
-		IdempotencyTTL: 7 * 24 * time.Hour,
-		Logger:         logger,
+		Repository:      store,
+		Reports:         reports,
+		Idempotency:     store,
+		Clock:           store,
+		IDGenerator:     store,
+		IdempotencyTTL:  7 * 24 * time.Hour,
+		Logger:          logger,
+		EventSubscriber: subscriber,
+		Classifier:      messageClassifier,
 	})
 	module.Store = store
 	return module
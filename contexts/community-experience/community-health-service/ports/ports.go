@@ -3,6 +3,8 @@ package ports
 import (
 	"context"
 	"time"
+
+	contractsv1 "solomon/contracts/gen/events/v1"
 )
 
 type Clock interface {
@@ -86,51 +88,215 @@ type UserRiskScore struct {
 }
 
 type CommunityHealthScore struct {
-	ScoreID                string
-	ServerID               string
-	WeekStartDate          string
-	HealthScore            int
-	Category               string
-	Trend                  string
-	SentimentHealth        int
-	ToxicityHealth         int
-	EngagementHealth       int
-	LatencyHealth          int
-	TrendBonus             int
-	TotalMessages          int
-	PositivePct            float64
-	ToxicityPct            float64
-	EngagementGini         float64
+	ScoreID          string
+	ServerID         string
+	WeekStartDate    string
+	HealthScore      int
+	Category         string
+	Trend            string
+	SentimentHealth  int
+	ToxicityHealth   int
+	EngagementHealth int
+	LatencyHealth    int
+	TrendBonus       int
+	TotalMessages    int
+	PositivePct      float64
+	ToxicityPct      float64
+	EngagementGini   float64
+	// AvgModerationLatencyHr is the mean hours from an alert firing to its
+	// acknowledgement over the last week; unacknowledged alerts count their
+	// age so far.
 	AvgModerationLatencyHr float64
 	Alerts                 int
+	OpenAlerts             int
+	CategoryFeedback       []CategoryFeedbackMetrics
 	CalculatedAt           time.Time
 }
 
+// Alert lifecycle: alerts start as sent, may be acknowledged, and end as
+// resolved or dismissed. Leaving sent for any status records acknowledgement.
+const (
+	AlertStatusSent         = "sent"
+	AlertStatusAcknowledged = "acknowledged"
+	AlertStatusResolved     = "resolved"
+	AlertStatusDismissed    = "dismissed"
+)
+
 type RealTimeAlert struct {
-	AlertID      string
-	AlertType    string
-	ServerID     string
-	ChannelID    string
-	Severity     string
-	TriggeredAt  time.Time
-	Status       string
-	ResponseHint string
+	AlertID        string
+	AlertType      string
+	ServerID       string
+	ChannelID      string
+	MessageID      string
+	Severity       string
+	TriggeredAt    time.Time
+	Status         string
+	ResponseHint   string
+	AcknowledgedAt *time.Time
+	AcknowledgedBy string
+	ClosedAt       *time.Time
+	ClosedBy       string
+	Note           string
+}
+
+type AlertTransitionInput struct {
+	ServerID    string
+	AlertID     string
+	ModeratorID string
+	Status      string
+	Note        string
+}
+
+// WeeklyActivity is a server's raw activity for one week, read from the
+// scoring store when a weekly report is built.
+type WeeklyActivity struct {
+	ServerID       string
+	WeekStart      time.Time
+	WeekEnd        time.Time
+	TotalMessages  int
+	ActiveUsers    int
+	PositivePct    float64
+	NegativePct    float64
+	ToxicityPct    float64
+	AvgSentiment   float64
+	HealthScore    int
+	HealthCategory string
+	// Alerts holds the alerts triggered during the week in their current state.
+	Alerts []RealTimeAlert
+	// TopRiskUsers are the server's highest-risk users at build time.
+	TopRiskUsers []UserRiskScore
+}
+
+type WeeklyReportMetrics struct {
+	TotalMessages      int
+	ActiveUsers        int
+	PositivePct        float64
+	NegativePct        float64
+	ToxicityPct        float64
+	AvgSentiment       float64
+	HealthScore        int
+	HealthCategory     string
+	AvgAckLatencyHours float64
+}
+
+// MetricTrend compares one metric with the previous week's report.
+type MetricTrend struct {
+	Metric    string
+	Current   float64
+	Previous  float64
+	Change    float64
+	Direction string
+}
+
+type AlertSummary struct {
+	Total              int
+	Open               int
+	Acknowledged       int
+	Resolved           int
+	Dismissed          int
+	ByType             map[string]int
+	BySeverity         map[string]int
+	AvgAckLatencyHours float64
+}
+
+type RiskUserSummary struct {
+	UserID            string
+	RiskScore         float64
+	RiskLevel         string
+	ToxicMessageCount int
+	WarningCount      int
 }
 
 type WeeklyHealthReport struct {
 	ReportID      string
 	ServerID      string
 	WeekStartDate string
-	MetricsJSON   map[string]any
-	GeneratedAt   time.Time
+	WeekEndDate   string
+	Metrics       WeeklyReportMetrics
+	// PreviousWeekStartDate is empty when no report exists for the week
+	// before, in which case Trends is empty too.
+	PreviousWeekStartDate string
+	Trends                []MetricTrend
+	TopRiskUsers          []RiskUserSummary
+	Alerts                AlertSummary
+	GeneratedAt           time.Time
+}
+
+type ListWeeklyReportsInput struct {
+	ServerID string
+	// BeforeWeek pages backwards: only reports for weeks starting before this
+	// date (YYYY-MM-DD) are returned.
+	BeforeWeek string
+	Limit      int
 }
 
+type WeeklyReportPage struct {
+	Reports    []WeeklyHealthReport
+	NextCursor string
+}
+
+// ReportRepository persists weekly reports, at most one per server and week.
+type ReportRepository interface {
+	// SaveWeeklyReport stores report unless one already exists for its server
+	// and week, in which case the stored report is returned with created false.
+	SaveWeeklyReport(ctx context.Context, report WeeklyHealthReport) (WeeklyHealthReport, bool, error)
+	GetWeeklyReport(ctx context.Context, serverID string, reportID string) (WeeklyHealthReport, error)
+	GetWeeklyReportForWeek(ctx context.Context, serverID string, weekStartDate string) (WeeklyHealthReport, error)
+	ListWeeklyReports(ctx context.Context, input ListWeeklyReportsInput) (WeeklyReportPage, error)
+}
+
+// Moderator verdicts on a message's toxicity classification.
+const (
+	FeedbackConfirmed     = "confirmed"
+	FeedbackFalsePositive = "false_positive"
+	FeedbackFalseNegative = "false_negative"
+)
+
 type ModerationFeedback struct {
 	FeedbackID   string
+	ServerID     string
 	MessageID    string
 	ModeratorID  string
 	FeedbackType string
-	CreatedAt    time.Time
+	// Category is the verdict the feedback is about: the flagged category for
+	// confirmed and false_positive, the missed category for false_negative.
+	Category  string
+	Note      string
+	CreatedAt time.Time
+}
+
+type ModerationFeedbackInput struct {
+	ServerID     string
+	MessageID    string
+	ModeratorID  string
+	FeedbackType string
+	Category     string
+	Note         string
+}
+
+// CategoryFeedbackMetrics summarizes moderator feedback for one toxicity
+// category. Precision is nil until a flagged verdict has been reviewed.
+type CategoryFeedbackMetrics struct {
+	Category       string
+	Threshold      float64
+	Confirmed      int
+	FalsePositives int
+	FalseNegatives int
+	Precision      *float64
+}
+
+// ModerationTuning is a server's feedback-tuned flag threshold per toxicity
+// category together with the feedback that produced it.
+type ModerationTuning struct {
+	ServerID   string
+	Thresholds map[string]float64
+	Categories []CategoryFeedbackMetrics
+	UpdatedAt  time.Time
+}
+
+type ModerationFeedbackResult struct {
+	Feedback ModerationFeedback
+	Tuning   ModerationTuning
 }
 
 type IngestionResult struct {
@@ -144,8 +310,99 @@ type IngestionResult struct {
 	ProcessedAt      time.Time
 }
 
+// ClassificationInput is one message to score. Content is already trimmed
+// and truncated to the ingestion limit.
+type ClassificationInput struct {
+	MessageID string
+	ServerID  string
+	ChannelID string
+	UserID    string
+	Content   string
+}
+
+// SentimentSignal is a classifier's raw sentiment output; Score is in [-1, 1].
+type SentimentSignal struct {
+	Score         float64
+	Confidence    float64
+	Language      string
+	EmojiAdjusted bool
+	SarcasmFlag   bool
+}
+
+// ToxicitySignal holds per-category toxicity scores in [0, 1].
+type ToxicitySignal struct {
+	HateSpeech     float64
+	Harassment     float64
+	Threats        float64
+	Sexual         float64
+	Spam           float64
+	Misinformation float64
+}
+
+type ClassifierOutput struct {
+	Sentiment SentimentSignal
+	Toxicity  ToxicitySignal
+}
+
+// MessageClassifier scores message content. Implementations only report
+// signals; sentiment category, primary toxicity category and severity are
+// derived by the application so every classifier maps scores the same way.
+type MessageClassifier interface {
+	Classify(ctx context.Context, input ClassificationInput) (ClassifierOutput, error)
+}
+
+// MessageClassification is the derived analysis stored for a message.
+type MessageClassification struct {
+	SentimentScore      float64
+	SentimentConfidence float64
+	SentimentCategory   string
+	Language            string
+	EmojiAdjusted       bool
+	SarcasmFlag         bool
+
+	HateSpeechScore     float64
+	HarassmentScore     float64
+	ThreatsScore        float64
+	SexualScore         float64
+	SpamScore           float64
+	MisinformationScore float64
+	MaxSeverity         int
+	PrimaryCategory     string
+}
+
 type Repository interface {
-	IngestWebhook(ctx context.Context, input WebhookIngestInput, now time.Time) (IngestionResult, error)
+	// IngestWebhook applies a message event. classification is required for
+	// created and edited events and ignored for deletions.
+	IngestWebhook(
+		ctx context.Context,
+		input WebhookIngestInput,
+		classification *MessageClassification,
+		now time.Time,
+	) (IngestionResult, error)
 	GetCommunityHealthScore(ctx context.Context, serverID string) (CommunityHealthScore, error)
 	GetUserRiskScore(ctx context.Context, serverID string, userID string) (UserRiskScore, error)
+	// ListAlerts returns a server's alerts newest first, optionally filtered
+	// by status.
+	ListAlerts(ctx context.Context, serverID string, status string) ([]RealTimeAlert, error)
+	TransitionAlert(ctx context.Context, input AlertTransitionInput, now time.Time) (RealTimeAlert, error)
+	RecordModerationFeedback(
+		ctx context.Context,
+		input ModerationFeedbackInput,
+		now time.Time,
+	) (ModerationFeedbackResult, error)
+	GetModerationTuning(ctx context.Context, serverID string) (ModerationTuning, error)
+	// ListActiveServers returns the servers with any recorded activity.
+	ListActiveServers(ctx context.Context) ([]string, error)
+	GetWeeklyActivity(ctx context.Context, serverID string, weekStart time.Time, weekEnd time.Time) (WeeklyActivity, error)
+}
+
+type EventEnvelope = contractsv1.Envelope
+
+type EventSubscriber interface {
+	Subscribe(
+		ctx context.Context,
+		topic string,
+		consumerGroup string,
+		handler func(context.Context, EventEnvelope) error,
+	) error
 }
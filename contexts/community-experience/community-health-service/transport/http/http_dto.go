@@ -58,11 +58,87 @@ type CommunityHealthScoreResponse struct {
 			EngagementGini            float64 `json:"engagement_gini"`
 			AvgModerationLatencyHours float64 `json:"avg_moderation_latency_hours"`
 		} `json:"metrics"`
-		Alerts       int    `json:"alerts"`
-		CalculatedAt string `json:"calculated_at"`
+		Alerts           int                       `json:"alerts"`
+		OpenAlerts       int                       `json:"open_alerts"`
+		CategoryFeedback []CategoryFeedbackMetrics `json:"category_feedback"`
+		CalculatedAt     string                    `json:"calculated_at"`
 	} `json:"data"`
 }
 
+type CategoryFeedbackMetrics struct {
+	Category       string   `json:"category"`
+	Threshold      float64  `json:"threshold"`
+	Confirmed      int      `json:"confirmed"`
+	FalsePositives int      `json:"false_positives"`
+	FalseNegatives int      `json:"false_negatives"`
+	Precision      *float64 `json:"precision"`
+}
+
+type AlertDTO struct {
+	AlertID        string `json:"alert_id"`
+	AlertType      string `json:"alert_type"`
+	ServerID       string `json:"server_id"`
+	ChannelID      string `json:"channel_id"`
+	MessageID      string `json:"message_id,omitempty"`
+	Severity       string `json:"severity"`
+	Status         string `json:"status"`
+	ResponseHint   string `json:"response_hint"`
+	TriggeredAt    string `json:"triggered_at"`
+	AcknowledgedAt string `json:"acknowledged_at,omitempty"`
+	AcknowledgedBy string `json:"acknowledged_by,omitempty"`
+	ClosedAt       string `json:"closed_at,omitempty"`
+	ClosedBy       string `json:"closed_by,omitempty"`
+	Note           string `json:"note,omitempty"`
+}
+
+type ListAlertsResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		Alerts []AlertDTO `json:"alerts"`
+	} `json:"data"`
+}
+
+type AlertTransitionRequest struct {
+	Note string `json:"note,omitempty"`
+}
+
+type AlertResponse struct {
+	Status string   `json:"status"`
+	Data   AlertDTO `json:"data"`
+}
+
+type ModerationFeedbackRequest struct {
+	FeedbackType string `json:"feedback_type"`
+	Category     string `json:"category,omitempty"`
+	Note         string `json:"note,omitempty"`
+}
+
+type ModerationTuningDTO struct {
+	ServerID   string                    `json:"server_id"`
+	Thresholds map[string]float64        `json:"thresholds"`
+	Categories []CategoryFeedbackMetrics `json:"categories"`
+	UpdatedAt  string                    `json:"updated_at,omitempty"`
+}
+
+type ModerationFeedbackResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		FeedbackID   string              `json:"feedback_id"`
+		MessageID    string              `json:"message_id"`
+		ModeratorID  string              `json:"moderator_id"`
+		FeedbackType string              `json:"feedback_type"`
+		Category     string              `json:"category"`
+		Note         string              `json:"note,omitempty"`
+		CreatedAt    string              `json:"created_at"`
+		Tuning       ModerationTuningDTO `json:"tuning"`
+	} `json:"data"`
+}
+
+type ModerationTuningResponse struct {
+	Status string              `json:"status"`
+	Data   ModerationTuningDTO `json:"data"`
+}
+
 type UserRiskScoreResponse struct {
 	Status string `json:"status"`
 	Data   struct {
@@ -77,3 +153,68 @@ type UserRiskScoreResponse struct {
 		Recommendations    []string `json:"recommendations"`
 	} `json:"data"`
 }
+
+type WeeklyReportDTO struct {
+	ReportID              string              `json:"report_id"`
+	ServerID              string              `json:"server_id"`
+	WeekStartDate         string              `json:"week_start_date"`
+	WeekEndDate           string              `json:"week_end_date"`
+	Metrics               WeeklyReportMetrics `json:"metrics"`
+	PreviousWeekStartDate string              `json:"previous_week_start_date,omitempty"`
+	Trends                []MetricTrend       `json:"trends"`
+	TopRiskUsers          []RiskUserSummary   `json:"top_risk_users"`
+	Alerts                AlertSummary        `json:"alerts"`
+	GeneratedAt           string              `json:"generated_at"`
+}
+
+type WeeklyReportMetrics struct {
+	TotalMessages      int     `json:"total_messages"`
+	ActiveUsers        int     `json:"active_users"`
+	PositivePct        float64 `json:"positive_pct"`
+	NegativePct        float64 `json:"negative_pct"`
+	ToxicityPct        float64 `json:"toxicity_pct"`
+	AvgSentiment       float64 `json:"avg_sentiment"`
+	HealthScore        int     `json:"health_score"`
+	HealthCategory     string  `json:"health_category"`
+	AvgAckLatencyHours float64 `json:"avg_ack_latency_hours"`
+}
+
+type MetricTrend struct {
+	Metric    string  `json:"metric"`
+	Current   float64 `json:"current"`
+	Previous  float64 `json:"previous"`
+	Change    float64 `json:"change"`
+	Direction string  `json:"direction"`
+}
+
+type RiskUserSummary struct {
+	UserID            string  `json:"user_id"`
+	RiskScore         float64 `json:"risk_score"`
+	RiskLevel         string  `json:"risk_level"`
+	ToxicMessageCount int     `json:"toxic_message_count"`
+	WarningCount      int     `json:"warning_count"`
+}
+
+type AlertSummary struct {
+	Total              int            `json:"total"`
+	Open               int            `json:"open"`
+	Acknowledged       int            `json:"acknowledged"`
+	Resolved           int            `json:"resolved"`
+	Dismissed          int            `json:"dismissed"`
+	ByType             map[string]int `json:"by_type"`
+	BySeverity         map[string]int `json:"by_severity"`
+	AvgAckLatencyHours float64        `json:"avg_ack_latency_hours"`
+}
+
+type ListWeeklyReportsResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		Reports    []WeeklyReportDTO `json:"reports"`
+		NextCursor string            `json:"next_cursor,omitempty"`
+	} `json:"data"`
+}
+
+type WeeklyReportResponse struct {
+	Status string          `json:"status"`
+	Data   WeeklyReportDTO `json:"data"`
+}
@@ -115,6 +115,7 @@ func (h Handler) PurchaseProductHandler(
 		ProductID:         result.ProductID,
 		Status:            result.Status,
 		FulfillmentStatus: result.FulfillmentStatus,
+		InvoiceID:         result.InvoiceID,
 		CreatedAt:         result.CreatedAt.UTC().Format(time.RFC3339),
 	}, nil
 }
@@ -20,6 +20,15 @@ type Store struct {
 	purchases   map[string]ports.Purchase
 	idempotency map[string]ports.IdempotencyRecord
 	sequence    uint64
+
+	// pendingInvoices is the invoice outbox, keyed by source type and ID.
+	pendingInvoices map[string]pendingInvoiceRecord
+}
+
+type pendingInvoiceRecord struct {
+	ports.PendingInvoice
+	InvoiceID string
+	IssuedAt  *time.Time
 }
 
 func NewStore() *Store {
@@ -93,6 +102,8 @@ func NewStore() *Store {
 		access:      access,
 		purchases:   make(map[string]ports.Purchase),
 		idempotency: make(map[string]ports.IdempotencyRecord),
+
+		pendingInvoices: make(map[string]pendingInvoiceRecord),
 	}
 }
 
@@ -443,6 +454,72 @@ func (s *Store) Put(ctx context.Context, record ports.IdempotencyRecord) error {
 	return nil
 }
 
+func (s *Store) EnqueueInvoice(ctx context.Context, req ports.InvoiceRequest, lastError string, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now = now.UTC()
+	key := invoiceSourceKey(req.SourceType, req.SourceID)
+	record, ok := s.pendingInvoices[key]
+	if !ok {
+		record = pendingInvoiceRecord{PendingInvoice: ports.PendingInvoice{
+			Request:    cloneInvoiceRequest(req),
+			EnqueuedAt: now,
+		}}
+	}
+	if record.IssuedAt != nil {
+		return nil
+	}
+	record.Attempts++
+	record.LastError = lastError
+	record.UpdatedAt = now
+	s.pendingInvoices[key] = record
+	return nil
+}
+
+func (s *Store) ListPendingInvoices(ctx context.Context, limit int) ([]ports.PendingInvoice, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	items := make([]ports.PendingInvoice, 0)
+	for _, record := range s.pendingInvoices {
+		if record.IssuedAt != nil {
+			continue
+		}
+		item := record.PendingInvoice
+		item.Request = cloneInvoiceRequest(item.Request)
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i int, j int) bool {
+		if !items[i].UpdatedAt.Equal(items[j].UpdatedAt) {
+			return items[i].UpdatedAt.Before(items[j].UpdatedAt)
+		}
+		return invoiceSourceKey(items[i].Request.SourceType, items[i].Request.SourceID) <
+			invoiceSourceKey(items[j].Request.SourceType, items[j].Request.SourceID)
+	})
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+func (s *Store) MarkInvoiceIssued(ctx context.Context, sourceType string, sourceID string, invoiceID string, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := invoiceSourceKey(sourceType, sourceID)
+	record, ok := s.pendingInvoices[key]
+	if !ok {
+		return domainerrors.ErrNotFound
+	}
+	issuedAt := now.UTC()
+	record.InvoiceID = invoiceID
+	record.IssuedAt = &issuedAt
+	record.UpdatedAt = issuedAt
+	s.pendingInvoices[key] = record
+	return nil
+}
+
 func (s *Store) NewID(ctx context.Context) (string, error) {
 	return s.nextID("m60"), nil
 }
@@ -462,7 +539,18 @@ func cloneProduct(in ports.Product) ports.Product {
 	return out
 }
 
+func invoiceSourceKey(sourceType string, sourceID string) string {
+	return strings.TrimSpace(sourceType) + "|" + strings.TrimSpace(sourceID)
+}
+
+func cloneInvoiceRequest(in ports.InvoiceRequest) ports.InvoiceRequest {
+	out := in
+	out.Lines = append([]ports.InvoiceLine(nil), in.Lines...)
+	return out
+}
+
 var _ ports.Repository = (*Store)(nil)
 var _ ports.IdempotencyStore = (*Store)(nil)
 var _ ports.Clock = (*Store)(nil)
 var _ ports.IDGenerator = (*Store)(nil)
+var _ ports.InvoiceOutbox = (*Store)(nil)
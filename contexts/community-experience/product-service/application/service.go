@@ -18,6 +18,8 @@ type Service struct {
 	Repo           ports.Repository
 	Idempotency    ports.IdempotencyStore
 	Clock          ports.Clock
+	Invoices       ports.InvoiceIssuer
+	InvoiceOutbox  ports.InvoiceOutbox
 	Logger         *slog.Logger
 	IdempotencyTTL time.Duration
 }
@@ -103,6 +105,11 @@ func (s Service) PurchaseProduct(
 			if err != nil {
 				return nil, err
 			}
+			invoiceID, err := s.issuePurchaseInvoice(ctx, result)
+			if err != nil {
+				return nil, err
+			}
+			result.InvoiceID = invoiceID
 			return json.Marshal(result)
 		},
 	)
@@ -267,6 +274,89 @@ func (s Service) DeleteUserData(
 	return out, err
 }
 
+// issuePurchaseInvoice issues the invoice for a committed purchase. When
+// billing fails the request goes to the invoice outbox for
+// RetryPendingInvoices, so the purchase is never left without an invoice;
+// only a failed enqueue fails the call.
+func (s Service) issuePurchaseInvoice(ctx context.Context, purchase ports.Purchase) (string, error) {
+	if s.Invoices == nil || purchase.AmountCents <= 0 {
+		return "", nil
+	}
+	description := purchase.ProductID
+	if product, err := s.Repo.GetProduct(ctx, purchase.ProductID); err == nil && strings.TrimSpace(product.Name) != "" {
+		description = product.Name
+	}
+	req := ports.InvoiceRequest{
+		UserID:     purchase.UserID,
+		SourceType: "product_purchase",
+		SourceID:   purchase.PurchaseID,
+		Currency:   purchase.Currency,
+		Lines: []ports.InvoiceLine{{
+			Description:     description,
+			Quantity:        1,
+			UnitAmountCents: purchase.AmountCents,
+			ReferenceType:   "product",
+			ReferenceID:     purchase.ProductID,
+		}},
+	}
+	invoiceID, err := s.Invoices.IssueInvoice(ctx, req)
+	if err == nil {
+		return invoiceID, nil
+	}
+	ResolveLogger(s.Logger).Warn("product purchase invoice issuance failed",
+		"event", "product_service_purchase_invoice_failed",
+		"module", "community-experience/product-service",
+		"layer", "application",
+		"purchase_id", purchase.PurchaseID,
+		"error", err.Error(),
+	)
+	if s.InvoiceOutbox == nil {
+		return "", err
+	}
+	if enqueueErr := s.InvoiceOutbox.EnqueueInvoice(ctx, req, err.Error(), s.now()); enqueueErr != nil {
+		return "", enqueueErr
+	}
+	return "", nil
+}
+
+// InvoiceRetryResult summarises one pass over the invoice outbox.
+type InvoiceRetryResult struct {
+	Pending int
+	Issued  int
+	Failed  int
+}
+
+// RetryPendingInvoices re-issues purchase invoices that failed inline.
+// Billing keys invoices by source, so a retry after a lost response returns
+// the invoice that was already issued.
+func (s Service) RetryPendingInvoices(ctx context.Context, limit int) (InvoiceRetryResult, error) {
+	result := InvoiceRetryResult{}
+	if s.Invoices == nil || s.InvoiceOutbox == nil {
+		return result, nil
+	}
+	pending, err := s.InvoiceOutbox.ListPendingInvoices(ctx, limit)
+	if err != nil {
+		return result, err
+	}
+	result.Pending = len(pending)
+	for _, item := range pending {
+		req := item.Request
+		invoiceID, err := s.Invoices.IssueInvoice(ctx, req)
+		if err != nil {
+			result.Failed++
+			if err := s.InvoiceOutbox.EnqueueInvoice(ctx, req, err.Error(), s.now()); err != nil {
+				return result, err
+			}
+			continue
+		}
+		if err := s.InvoiceOutbox.MarkInvoiceIssued(ctx, req.SourceType, req.SourceID, invoiceID, s.now()); err != nil {
+			return result, err
+		}
+		result.Issued++
+	}
+	return result, nil
+}
+
 func (s Service) now() time.Time {
 	if s.Clock == nil {
 		return time.Now().UTC()
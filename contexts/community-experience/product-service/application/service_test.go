@@ -0,0 +1,65 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"solomon/contexts/community-experience/product-service/adapters/memory"
+	"solomon/contexts/community-experience/product-service/ports"
+)
+
+func TestFailedPurchaseInvoiceIsQueuedAndRetried(t *testing.T) {
+	store := memory.NewStore()
+	issuer := &recordingIssuer{err: errors.New("billing unavailable")}
+	service := Service{
+		Repo:          store,
+		Idempotency:   store,
+		Clock:         fixedClock{now: time.Date(2026, time.February, 6, 12, 0, 0, 0, time.UTC)},
+		Invoices:      issuer,
+		InvoiceOutbox: store,
+	}
+	ctx := context.Background()
+
+	purchase, err := service.PurchaseProduct(ctx, "idem-1", "user_1", "prod_001")
+	if err != nil {
+		t.Fatalf("purchase should succeed with the invoice queued: %v", err)
+	}
+	if purchase.InvoiceID != "" {
+		t.Fatalf("expected no invoice yet, got %s", purchase.InvoiceID)
+	}
+	pending, err := store.ListPendingInvoices(ctx, 10)
+	if err != nil || len(pending) != 1 || pending[0].Request.SourceID != purchase.PurchaseID {
+		t.Fatalf("expected the purchase in the invoice outbox, got %+v err=%v", pending, err)
+	}
+
+	issuer.err = nil
+	result, err := service.RetryPendingInvoices(ctx, 10)
+	if err != nil || result.Issued != 1 {
+		t.Fatalf("expected the retry to issue the invoice, got %+v err=%v", result, err)
+	}
+	if pending, _ := store.ListPendingInvoices(ctx, 10); len(pending) != 0 {
+		t.Fatalf("expected an empty outbox, got %+v", pending)
+	}
+}
+
+type recordingIssuer struct {
+	err      error
+	requests []ports.InvoiceRequest
+}
+
+func (i *recordingIssuer) IssueInvoice(_ context.Context, req ports.InvoiceRequest) (string, error) {
+	i.requests = append(i.requests, req)
+	if i.err != nil {
+		return "", i.err
+	}
+	return fmt.Sprintf("inv_%d", len(i.requests)), nil
+}
+
+type fixedClock struct {
+	now time.Time
+}
+
+func (f fixedClock) Now() time.Time { return f.now }
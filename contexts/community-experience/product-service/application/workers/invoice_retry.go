@@ -0,0 +1,52 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+
+	"solomon/contexts/community-experience/product-service/application"
+)
+
+// InvoiceRetryJob issues the purchase invoices that failed inline for M60.
+type InvoiceRetryJob struct {
+	Service   application.Service
+	BatchSize int
+	Logger    *slog.Logger
+}
+
+func (j InvoiceRetryJob) RunOnce(ctx context.Context) error {
+	logger := application.ResolveLogger(j.Logger)
+	limit := j.BatchSize
+	if limit <= 0 {
+		limit = 100
+	}
+	result, err := j.Service.RetryPendingInvoices(ctx, limit)
+	if err != nil {
+		logger.Error("product invoice retry cycle failed",
+			"event", "product_service_invoice_retry_cycle_failed",
+			"module", "community-experience/product-service",
+			"layer", "worker",
+			"error", err.Error(),
+		)
+		return err
+	}
+	if result.Failed > 0 {
+		logger.Warn("product purchase invoices still pending",
+			"event", "product_service_invoice_retry_pending",
+			"module", "community-experience/product-service",
+			"layer", "worker",
+			"pending", result.Pending,
+			"issued", result.Issued,
+			"failed", result.Failed,
+		)
+		return nil
+	}
+	logger.Debug("product invoice retry cycle succeeded",
+		"event", "product_service_invoice_retry_cycle_succeeded",
+		"module", "community-experience/product-service",
+		"layer", "worker",
+		"pending", result.Pending,
+		"issued", result.Issued,
+	)
+	return nil
+}
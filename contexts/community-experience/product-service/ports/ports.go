@@ -88,6 +88,7 @@ type Purchase struct {
 	FulfillmentStatus string
 	CreatedAt         time.Time
 	FulfilledAt       *time.Time
+	InvoiceID         string
 }
 
 type FulfillmentResult struct {
@@ -121,6 +122,47 @@ type UserDeleteResult struct {
 	ProcessedAt        time.Time
 }
 
+type InvoiceLine struct {
+	Description     string
+	Quantity        int
+	UnitAmountCents int64
+	ReferenceType   string
+	ReferenceID     string
+}
+
+type InvoiceRequest struct {
+	UserID     string
+	SourceType string
+	SourceID   string
+	Currency   string
+	Lines      []InvoiceLine
+}
+
+// InvoiceIssuer issues the billing invoice for a completed charge. Issuance
+// is keyed by source, so retries return the original invoice ID.
+type InvoiceIssuer interface {
+	IssueInvoice(ctx context.Context, req InvoiceRequest) (string, error)
+}
+
+// PendingInvoice is a purchase whose invoice could not be issued inline.
+type PendingInvoice struct {
+	Request    InvoiceRequest
+	Attempts   int
+	LastError  string
+	EnqueuedAt time.Time
+	UpdatedAt  time.Time
+}
+
+// InvoiceOutbox holds invoice requests that failed to issue until a retry
+// issues them. Requests are keyed by source, so enqueueing one twice only
+// records another failed attempt.
+type InvoiceOutbox interface {
+	EnqueueInvoice(ctx context.Context, req InvoiceRequest, lastError string, now time.Time) error
+	// ListPendingInvoices returns unissued requests, least recently tried first.
+	ListPendingInvoices(ctx context.Context, limit int) ([]PendingInvoice, error)
+	MarkInvoiceIssued(ctx context.Context, sourceType string, sourceID string, invoiceID string, now time.Time) error
+}
+
 type Repository interface {
 	ListProducts(ctx context.Context, filter ProductFilter) ([]Product, int, error)
 	CreateProduct(ctx context.Context, input CreateProductInput, now time.Time) (Product, error)
@@ -85,6 +85,7 @@ type PurchaseProductResponse struct {
 	ProductID         string `json:"product_id"`
 	Status            string `json:"status"`
 	FulfillmentStatus string `json:"fulfillment_status"`
+	InvoiceID         string `json:"invoice_id,omitempty"`
 	CreatedAt         string `json:"created_at"`
 	Replayed          bool   `json:"replayed,omitempty"`
 }
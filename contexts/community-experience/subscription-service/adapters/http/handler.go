@@ -33,6 +33,7 @@ func (h Handler) CreateSubscriptionHandler(
 	resp.Data.Status = item.Status
 	resp.Data.AmountCents = item.AmountCents
 	resp.Data.Currency = item.Currency
+	resp.Data.InvoiceID = item.InvoiceID
 	if item.TrialEnd != nil {
 		resp.Data.TrialEnd = item.TrialEnd.UTC().Format(time.RFC3339)
 	}
@@ -66,6 +67,7 @@ func (h Handler) ChangePlanHandler(
 	resp.Data.NewPlan = item.NewPlanName
 	resp.Data.ProrationAmountCents = item.ProrationAmountCents
 	resp.Data.ProrationDescription = item.ProrationDescription
+	resp.Data.InvoiceID = item.InvoiceID
 	resp.Data.ChangedAt = item.ChangedAt.UTC().Format(time.RFC3339)
 	if item.NextBillingDate != nil {
 		resp.Data.NextBillingDate = item.NextBillingDate.UTC().Format(time.RFC3339)
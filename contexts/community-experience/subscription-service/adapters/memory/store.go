@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -24,10 +25,19 @@ type Store struct {
 	productIDsByPlanID map[string]string
 	validProductIDs    map[string]struct{}
 
+	// pendingInvoices is the invoice outbox, keyed by source type and ID.
+	pendingInvoices map[string]pendingInvoiceRecord
+
 	idempotency map[string]ports.IdempotencyRecord
 	sequence    uint64
 }
 
+type pendingInvoiceRecord struct {
+	ports.PendingInvoice
+	InvoiceID string
+	IssuedAt  *time.Time
+}
+
 func NewStore() *Store {
 	now := time.Now().UTC()
 	plans := map[string]ports.SubscriptionPlan{
@@ -104,8 +114,9 @@ func NewStore() *Store {
 			"prod_001": {},
 			"prod_002": {},
 		},
-		idempotency: make(map[string]ports.IdempotencyRecord),
-		sequence:    1,
+		pendingInvoices: make(map[string]pendingInvoiceRecord),
+		idempotency:     make(map[string]ports.IdempotencyRecord),
+		sequence:        1,
 	}
 	for planID, plan := range plans {
 		store.plansByID[planID] = plan
@@ -226,6 +237,7 @@ func (s *Store) ChangePlan(
 	s.subscriptionsByID[subscriptionID] = item
 
 	result := ports.PlanChangeResult{
+		ChangeID:             "chg_" + s.nextID("m61"),
 		SubscriptionID:       item.SubscriptionID,
 		OldPlanID:            oldPlan.PlanID,
 		OldPlanName:          oldPlan.PlanName,
@@ -233,6 +245,7 @@ func (s *Store) ChangePlan(
 		NewPlanName:          newPlan.PlanName,
 		ProrationAmountCents: proration,
 		ProrationDescription: prorationDescription(proration),
+		Currency:             item.Currency,
 		NextBillingDate:      item.NextBillingDate,
 		ChangedAt:            now,
 	}
@@ -298,6 +311,72 @@ func (s *Store) CancelSubscription(
 	}, nil
 }
 
+func (s *Store) EnqueueInvoice(ctx context.Context, req ports.InvoiceRequest, lastError string, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now = now.UTC()
+	key := invoiceSourceKey(req.SourceType, req.SourceID)
+	record, ok := s.pendingInvoices[key]
+	if !ok {
+		record = pendingInvoiceRecord{PendingInvoice: ports.PendingInvoice{
+			Request:    cloneInvoiceRequest(req),
+			EnqueuedAt: now,
+		}}
+	}
+	if record.IssuedAt != nil {
+		return nil
+	}
+	record.Attempts++
+	record.LastError = lastError
+	record.UpdatedAt = now
+	s.pendingInvoices[key] = record
+	return nil
+}
+
+func (s *Store) ListPendingInvoices(ctx context.Context, limit int) ([]ports.PendingInvoice, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	items := make([]ports.PendingInvoice, 0)
+	for _, record := range s.pendingInvoices {
+		if record.IssuedAt != nil {
+			continue
+		}
+		item := record.PendingInvoice
+		item.Request = cloneInvoiceRequest(item.Request)
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i int, j int) bool {
+		if !items[i].UpdatedAt.Equal(items[j].UpdatedAt) {
+			return items[i].UpdatedAt.Before(items[j].UpdatedAt)
+		}
+		return invoiceSourceKey(items[i].Request.SourceType, items[i].Request.SourceID) <
+			invoiceSourceKey(items[j].Request.SourceType, items[j].Request.SourceID)
+	})
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+func (s *Store) MarkInvoiceIssued(ctx context.Context, sourceType string, sourceID string, invoiceID string, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := invoiceSourceKey(sourceType, sourceID)
+	record, ok := s.pendingInvoices[key]
+	if !ok {
+		return domainerrors.ErrNotFound
+	}
+	issuedAt := now.UTC()
+	record.InvoiceID = invoiceID
+	record.IssuedAt = &issuedAt
+	record.UpdatedAt = issuedAt
+	s.pendingInvoices[key] = record
+	return nil
+}
+
 func (s *Store) Get(ctx context.Context, key string, now time.Time) (ports.IdempotencyRecord, bool, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -385,6 +464,16 @@ func prorationDescription(amount int64) string {
 	}
 }
 
+func invoiceSourceKey(sourceType string, sourceID string) string {
+	return strings.TrimSpace(sourceType) + "|" + strings.TrimSpace(sourceID)
+}
+
+func cloneInvoiceRequest(in ports.InvoiceRequest) ports.InvoiceRequest {
+	out := in
+	out.Lines = append([]ports.InvoiceLine(nil), in.Lines...)
+	return out
+}
+
 func trialKey(userID string, planID string) string {
 	return strings.TrimSpace(userID) + "|" + strings.TrimSpace(planID)
 }
@@ -436,3 +525,4 @@ var _ ports.Repository = (*Store)(nil)
 var _ ports.IdempotencyStore = (*Store)(nil)
 var _ ports.Clock = (*Store)(nil)
 var _ ports.IDGenerator = (*Store)(nil)
+var _ ports.InvoiceOutbox = (*Store)(nil)
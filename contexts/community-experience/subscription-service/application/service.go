@@ -17,6 +17,8 @@ type Service struct {
 	Repo           ports.Repository
 	Idempotency    ports.IdempotencyStore
 	Clock          ports.Clock
+	Invoices       ports.InvoiceIssuer
+	InvoiceOutbox  ports.InvoiceOutbox
 	Logger         *slog.Logger
 	IdempotencyTTL time.Duration
 }
@@ -46,6 +48,24 @@ func (s Service) CreateSubscription(
 			if err != nil {
 				return nil, err
 			}
+			if result.Status == "active" && result.AmountCents > 0 {
+				result.InvoiceID, err = s.issueInvoice(ctx, ports.InvoiceRequest{
+					UserID:     userID,
+					SourceType: "subscription_charge",
+					SourceID:   result.SubscriptionID,
+					Currency:   result.Currency,
+					Lines: []ports.InvoiceLine{{
+						Description:     result.PlanName + " subscription",
+						Quantity:        1,
+						UnitAmountCents: result.AmountCents,
+						ReferenceType:   "subscription_plan",
+						ReferenceID:     result.PlanID,
+					}},
+				})
+				if err != nil {
+					return nil, err
+				}
+			}
 			return json.Marshal(result)
 		},
 	)
@@ -79,6 +99,24 @@ func (s Service) ChangePlan(
 			if err != nil {
 				return nil, err
 			}
+			if result.ProrationAmountCents > 0 {
+				result.InvoiceID, err = s.issueInvoice(ctx, ports.InvoiceRequest{
+					UserID:     userID,
+					SourceType: "subscription_proration",
+					SourceID:   result.ChangeID,
+					Currency:   result.Currency,
+					Lines: []ports.InvoiceLine{{
+						Description:     "Proration: " + result.OldPlanName + " to " + result.NewPlanName,
+						Quantity:        1,
+						UnitAmountCents: result.ProrationAmountCents,
+						ReferenceType:   "subscription_plan",
+						ReferenceID:     result.NewPlanID,
+					}},
+				})
+				if err != nil {
+					return nil, err
+				}
+			}
 			return json.Marshal(result)
 		},
 	)
@@ -126,6 +164,73 @@ func (s Service) CancelSubscription(
 	return out, err
 }
 
+// issueInvoice issues the invoice for a committed charge. When billing
+// fails the request goes to the invoice outbox for RetryPendingInvoices, so
+// the charge is never left without an invoice; only a failed enqueue fails
+// the call.
+func (s Service) issueInvoice(ctx context.Context, req ports.InvoiceRequest) (string, error) {
+	if s.Invoices == nil {
+		return "", nil
+	}
+	invoiceID, err := s.Invoices.IssueInvoice(ctx, req)
+	if err == nil {
+		return invoiceID, nil
+	}
+	ResolveLogger(s.Logger).Warn("subscription invoice issuance failed",
+		"event", "subscription_invoice_issuance_failed",
+		"module", "community-experience/subscription-service",
+		"layer", "application",
+		"source_type", req.SourceType,
+		"source_id", req.SourceID,
+		"error", err.Error(),
+	)
+	if s.InvoiceOutbox == nil {
+		return "", err
+	}
+	if enqueueErr := s.InvoiceOutbox.EnqueueInvoice(ctx, req, err.Error(), s.now()); enqueueErr != nil {
+		return "", enqueueErr
+	}
+	return "", nil
+}
+
+// InvoiceRetryResult summarises one pass over the invoice outbox.
+type InvoiceRetryResult struct {
+	Pending int
+	Issued  int
+	Failed  int
+}
+
+// RetryPendingInvoices re-issues invoices that failed inline. Billing keys
+// invoices by source, so a retry after a lost response returns the invoice
+// that was already issued.
+func (s Service) RetryPendingInvoices(ctx context.Context, limit int) (InvoiceRetryResult, error) {
+	result := InvoiceRetryResult{}
+	if s.Invoices == nil || s.InvoiceOutbox == nil {
+		return result, nil
+	}
+	pending, err := s.InvoiceOutbox.ListPendingInvoices(ctx, limit)
+	if err != nil {
+		return result, err
+	}
+	result.Pending = len(pending)
+	for _, item := range pending {
+		req := item.Request
+		invoiceID, err := s.Invoices.IssueInvoice(ctx, req)
+		if err != nil {
+			result.Failed++
+			if err := s.InvoiceOutbox.EnqueueInvoice(ctx, req, err.Error(), s.now()); err != nil {
+				return result, err
+			}
+			continue
+		}
+		if err := s.InvoiceOutbox.MarkInvoiceIssued(ctx, req.SourceType, req.SourceID, invoiceID, s.now()); err != nil {
+			return result, err
+		}
+		result.Issued++
+	}
+	return result, nil
+}
+
 func (s Service) now() time.Time {
 	if s.Clock == nil {
 		return time.Now().UTC()
@@ -179,7 +284,7 @@ func (s Service) runIdempotent(
 		return err
 	}
 
-	resolveLogger(s.Logger).Debug("subscription idempotent operation committed",
+	ResolveLogger(s.Logger).Debug("subscription idempotent operation committed",
 		"event", "subscription_idempotent_operation_committed",
 		"module", "community-experience/subscription-service",
 		"layer", "application",
@@ -2,6 +2,8 @@ package application
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -32,6 +34,106 @@ func TestCreateSubscriptionIdempotencyReplay(t *testing.T) {
 	}
 }
 
+func TestChangePlanKeysProrationInvoicePerChange(t *testing.T) {
+	store := memory.NewStore()
+	issuer := &recordingIssuer{}
+	now := time.Date(2026, time.February, 6, 12, 0, 0, 0, time.UTC)
+	service := Service{
+		Repo:        store,
+		Idempotency: store,
+		Clock:       fixedClock{now: now},
+		Invoices:    issuer,
+	}
+	ctx := context.Background()
+
+	created, err := service.CreateSubscription(ctx, "idem-create", "user_1", createInput("plan_pro_monthly", false))
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	// Two upgrades within the same second must not share an invoice.
+	if _, err := service.ChangePlan(ctx, "idem-up-1", "user_1", created.SubscriptionID, "plan_enterprise_monthly"); err != nil {
+		t.Fatalf("first change failed: %v", err)
+	}
+	if _, err := service.ChangePlan(ctx, "idem-down", "user_1", created.SubscriptionID, "plan_pro_monthly"); err != nil {
+		t.Fatalf("downgrade failed: %v", err)
+	}
+	if _, err := service.ChangePlan(ctx, "idem-up-2", "user_1", created.SubscriptionID, "plan_enterprise_monthly"); err != nil {
+		t.Fatalf("second change failed: %v", err)
+	}
+
+	var prorations []string
+	for _, req := range issuer.requests {
+		if req.SourceType == "subscription_proration" {
+			prorations = append(prorations, req.SourceID)
+		}
+	}
+	if len(prorations) != 2 || prorations[0] == prorations[1] {
+		t.Fatalf("expected two distinct proration sources, got %v", prorations)
+	}
+}
+
+func TestFailedInvoiceIsQueuedAndRetried(t *testing.T) {
+	store := memory.NewStore()
+	issuer := &recordingIssuer{err: errors.New("billing unavailable")}
+	now := time.Date(2026, time.February, 6, 12, 0, 0, 0, time.UTC)
+	service := Service{
+		Repo:          store,
+		Idempotency:   store,
+		Clock:         fixedClock{now: now},
+		Invoices:      issuer,
+		InvoiceOutbox: store,
+	}
+	ctx := context.Background()
+
+	created, err := service.CreateSubscription(ctx, "idem-1", "user_1", createInput("plan_pro_monthly", false))
+	if err != nil {
+		t.Fatalf("create should succeed with the invoice queued: %v", err)
+	}
+	if created.InvoiceID != "" {
+		t.Fatalf("expected no invoice yet, got %s", created.InvoiceID)
+	}
+	pending, err := store.ListPendingInvoices(ctx, 10)
+	if err != nil || len(pending) != 1 || pending[0].Request.SourceID != created.SubscriptionID {
+		t.Fatalf("expected the charge in the invoice outbox, got %+v err=%v", pending, err)
+	}
+
+	issuer.err = nil
+	result, err := service.RetryPendingInvoices(ctx, 10)
+	if err != nil || result.Issued != 1 {
+		t.Fatalf("expected the retry to issue the invoice, got %+v err=%v", result, err)
+	}
+	if pending, _ := store.ListPendingInvoices(ctx, 10); len(pending) != 0 {
+		t.Fatalf("expected an empty outbox, got %+v", pending)
+	}
+}
+
+func TestFailedInvoiceWithoutOutboxFailsTheCall(t *testing.T) {
+	store := memory.NewStore()
+	service := Service{
+		Repo:        store,
+		Idempotency: store,
+		Clock:       fixedClock{now: time.Date(2026, time.February, 6, 12, 0, 0, 0, time.UTC)},
+		Invoices:    &recordingIssuer{err: errors.New("billing unavailable")},
+	}
+
+	if _, err := service.CreateSubscription(context.Background(), "idem-1", "user_1", createInput("plan_pro_monthly", false)); err == nil {
+		t.Fatal("expected the invoice failure to surface")
+	}
+}
+
+type recordingIssuer struct {
+	err      error
+	requests []ports.InvoiceRequest
+}
+
+func (i *recordingIssuer) IssueInvoice(_ context.Context, req ports.InvoiceRequest) (string, error) {
+	i.requests = append(i.requests, req)
+	if i.err != nil {
+		return "", i.err
+	}
+	return fmt.Sprintf("inv_%d", len(i.requests)), nil
+}
+
 type fixedClock struct {
 	now time.Time
 }
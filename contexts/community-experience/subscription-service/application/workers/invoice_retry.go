@@ -0,0 +1,52 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+
+	"solomon/contexts/community-experience/subscription-service/application"
+)
+
+// InvoiceRetryJob issues the subscription invoices that failed inline for M61.
+type InvoiceRetryJob struct {
+	Service   application.Service
+	BatchSize int
+	Logger    *slog.Logger
+}
+
+func (j InvoiceRetryJob) RunOnce(ctx context.Context) error {
+	logger := application.ResolveLogger(j.Logger)
+	limit := j.BatchSize
+	if limit <= 0 {
+		limit = 100
+	}
+	result, err := j.Service.RetryPendingInvoices(ctx, limit)
+	if err != nil {
+		logger.Error("subscription invoice retry cycle failed",
+			"event", "subscription_invoice_retry_cycle_failed",
+			"module", "community-experience/subscription-service",
+			"layer", "worker",
+			"error", err.Error(),
+		)
+		return err
+	}
+	if result.Failed > 0 {
+		logger.Warn("subscription invoices still pending",
+			"event", "subscription_invoice_retry_pending",
+			"module", "community-experience/subscription-service",
+			"layer", "worker",
+			"pending", result.Pending,
+			"issued", result.Issued,
+			"failed", result.Failed,
+		)
+		return nil
+	}
+	logger.Debug("subscription invoice retry cycle succeeded",
+		"event", "subscription_invoice_retry_cycle_succeeded",
+		"module", "community-experience/subscription-service",
+		"layer", "worker",
+		"pending", result.Pending,
+		"issued", result.Issued,
+	)
+	return nil
+}
@@ -7,12 +7,14 @@ import (
 	httpadapter "solomon/contexts/community-experience/subscription-service/adapters/http"
 	"solomon/contexts/community-experience/subscription-service/adapters/memory"
 	"solomon/contexts/community-experience/subscription-service/application"
+	"solomon/contexts/community-experience/subscription-service/application/workers"
 	"solomon/contexts/community-experience/subscription-service/ports"
 )
 
 type Module struct {
-	Handler httpadapter.Handler
-	Store   *memory.Store
+	Handler      httpadapter.Handler
+	InvoiceRetry workers.InvoiceRetryJob
+	Store        *memory.Store
 }
 
 type Dependencies struct {
@@ -20,6 +22,8 @@ type Dependencies struct {
 	Idempotency    ports.IdempotencyStore
 	Clock          ports.Clock
 	IDGenerator    ports.IDGenerator
+	Invoices       ports.InvoiceIssuer
+	InvoiceOutbox  ports.InvoiceOutbox
 	IdempotencyTTL time.Duration
 	Logger         *slog.Logger
 }
@@ -29,6 +33,8 @@ func NewModule(deps Dependencies) Module {
 		Repo:           deps.Repository,
 		Idempotency:    deps.Idempotency,
 		Clock:          deps.Clock,
+		Invoices:       deps.Invoices,
+		InvoiceOutbox:  deps.InvoiceOutbox,
 		Logger:         deps.Logger,
 		IdempotencyTTL: deps.IdempotencyTTL,
 	}
@@ -37,16 +43,31 @@ func NewModule(deps Dependencies) Module {
 			Service: service,
 			Logger:  deps.Logger,
 		},
+		InvoiceRetry: workers.InvoiceRetryJob{
+			Service:   service,
+			BatchSize: 100,
+			Logger:    deps.Logger,
+		},
 	}
 }
 
 func NewInMemoryModule(logger *slog.Logger) Module {
+	return NewInMemoryModuleWithInvoices(logger, nil)
+}
+
+// NewInMemoryModuleWithInvoices wires the in-memory store with an invoice
+// issuer so paid subscription charges produce billing invoices. Charges
+// whose invoice fails to issue wait in the store's invoice outbox for
+// InvoiceRetry.
+func NewInMemoryModuleWithInvoices(logger *slog.Logger, invoices ports.InvoiceIssuer) Module {
 	store := memory.NewStore()
 	module := NewModule(Dependencies{
 		Repository:     store,
 		Idempotency:    store,
 		Clock:          store,
 		IDGenerator:    store,
+		Invoices:       invoices,
+		InvoiceOutbox:  store,
 		IdempotencyTTL: 7 * 24 * time.Hour,
 		Logger:         logger,
 	})
@@ -60,6 +60,7 @@ type Subscription struct {
 	CanceledAt           *time.Time
 	AccessEndsAt         *time.Time
 	CancellationFeedback string
+	InvoiceID            string
 	UpdatedAt            time.Time
 	CreatedAt            time.Time
 }
@@ -69,7 +70,10 @@ type CreateSubscriptionInput struct {
 	Trial  bool
 }
 
+// PlanChangeResult describes one plan change. ChangeID is unique per change
+// and keys the proration invoice.
 type PlanChangeResult struct {
+	ChangeID             string
 	SubscriptionID       string
 	OldPlanID            string
 	OldPlanName          string
@@ -77,7 +81,9 @@ type PlanChangeResult struct {
 	NewPlanName          string
 	ProrationAmountCents int64
 	ProrationDescription string
+	Currency             string
 	NextBillingDate      *time.Time
+	InvoiceID            string
 	ChangedAt            time.Time
 }
 
@@ -90,6 +96,47 @@ type CancelSubscriptionResult struct {
 	CanceledAt           *time.Time
 }
 
+type InvoiceLine struct {
+	Description     string
+	Quantity        int
+	UnitAmountCents int64
+	ReferenceType   string
+	ReferenceID     string
+}
+
+type InvoiceRequest struct {
+	UserID     string
+	SourceType string
+	SourceID   string
+	Currency   string
+	Lines      []InvoiceLine
+}
+
+// InvoiceIssuer issues the billing invoice for a subscription charge.
+// Issuance is keyed by source, so retries return the original invoice ID.
+type InvoiceIssuer interface {
+	IssueInvoice(ctx context.Context, req InvoiceRequest) (string, error)
+}
+
+// PendingInvoice is a charge whose invoice could not be issued inline.
+type PendingInvoice struct {
+	Request    InvoiceRequest
+	Attempts   int
+	LastError  string
+	EnqueuedAt time.Time
+	UpdatedAt  time.Time
+}
+
+// InvoiceOutbox holds invoice requests that failed to issue until a retry
+// issues them. Requests are keyed by source, so enqueueing one twice only
+// records another failed attempt.
+type InvoiceOutbox interface {
+	EnqueueInvoice(ctx context.Context, req InvoiceRequest, lastError string, now time.Time) error
+	// ListPendingInvoices returns unissued requests, least recently tried first.
+	ListPendingInvoices(ctx context.Context, limit int) ([]PendingInvoice, error)
+	MarkInvoiceIssued(ctx context.Context, sourceType string, sourceID string, invoiceID string, now time.Time) error
+}
+
 type Repository interface {
 	CreateSubscription(ctx context.Context, userID string, input CreateSubscriptionInput, now time.Time) (Subscription, error)
 	ChangePlan(ctx context.Context, userID string, subscriptionID string, newPlanID string, now time.Time) (PlanChangeResult, error)
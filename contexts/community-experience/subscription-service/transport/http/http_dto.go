@@ -20,6 +20,7 @@ type CreateSubscriptionResponse struct {
 		NextBillingDate string `json:"next_billing_date,omitempty"`
 		AmountCents     int64  `json:"amount_cents"`
 		Currency        string `json:"currency"`
+		InvoiceID       string `json:"invoice_id,omitempty"`
 	} `json:"data"`
 }
 
@@ -36,6 +37,7 @@ type ChangePlanResponse struct {
 		ProrationAmountCents int64  `json:"proration_amount_cents"`
 		ProrationDescription string `json:"proration_description"`
 		NextBillingDate      string `json:"next_billing_date,omitempty"`
+		InvoiceID            string `json:"invoice_id,omitempty"`
 		ChangedAt            string `json:"changed_at"`
 	} `json:"data"`
 }
@@ -0,0 +1,190 @@
+package httpadapter
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"solomon/contexts/finance-core/billing-service/application"
+	"solomon/contexts/finance-core/billing-service/ports"
+	httptransport "solomon/contexts/finance-core/billing-service/transport/http"
+)
+
+type Handler struct {
+	Service application.Service
+	Logger  *slog.Logger
+}
+
+func (h Handler) IssueInvoiceHandler(
+	ctx context.Context,
+	req httptransport.IssueInvoiceRequest,
+) (httptransport.GetInvoiceResponse, error) {
+	input := ports.IssueInvoiceInput{
+		UserID:     strings.TrimSpace(req.UserID),
+		SourceType: strings.TrimSpace(req.SourceType),
+		SourceID:   strings.TrimSpace(req.SourceID),
+		Currency:   strings.TrimSpace(req.Currency),
+		Lines:      make([]ports.InvoiceLineInput, 0, len(req.Lines)),
+	}
+	for _, line := range req.Lines {
+		input.Lines = append(input.Lines, ports.InvoiceLineInput{
+			Description:     strings.TrimSpace(line.Description),
+			Quantity:        line.Quantity,
+			UnitAmountCents: line.UnitAmountCents,
+			ReferenceType:   strings.TrimSpace(line.ReferenceType),
+			ReferenceID:     strings.TrimSpace(line.ReferenceID),
+		})
+	}
+	invoice, err := h.Service.IssueInvoice(ctx, input)
+	if err != nil {
+		return httptransport.GetInvoiceResponse{}, err
+	}
+	return httptransport.GetInvoiceResponse{
+		Status: "success",
+		Data:   toInvoiceDTO(application.InvoiceDocument{Invoice: invoice}),
+	}, nil
+}
+
+func (h Handler) ListInvoicesHandler(
+	ctx context.Context,
+	userID string,
+	req httptransport.ListInvoicesRequest,
+) (httptransport.ListInvoicesResponse, error) {
+	items, total, err := h.Service.ListUserInvoices(ctx, userID, req.Limit, req.Offset)
+	if err != nil {
+		return httptransport.ListInvoicesResponse{}, err
+	}
+	resp := httptransport.ListInvoicesResponse{Status: "success"}
+	resp.Data.Invoices = make([]httptransport.InvoiceSummaryDTO, 0, len(items))
+	for _, item := range items {
+		resp.Data.Invoices = append(resp.Data.Invoices, httptransport.InvoiceSummaryDTO{
+			InvoiceID:     item.InvoiceID,
+			InvoiceNumber: item.InvoiceNumber,
+			SourceType:    item.SourceType,
+			SourceID:      item.SourceID,
+			Currency:      item.Currency,
+			TotalCents:    item.TotalCents,
+			IssuedAt:      item.IssuedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	resp.Data.Total = total
+	resp.Data.Limit = req.Limit
+	resp.Data.Offset = req.Offset
+	return resp, nil
+}
+
+func (h Handler) GetInvoiceHandler(
+	ctx context.Context,
+	userID string,
+	invoiceID string,
+) (httptransport.GetInvoiceResponse, error) {
+	document, err := h.Service.GetUserInvoice(ctx, userID, strings.TrimSpace(invoiceID))
+	if err != nil {
+		return httptransport.GetInvoiceResponse{}, err
+	}
+	return httptransport.GetInvoiceResponse{
+		Status: "success",
+		Data:   toInvoiceDTO(document),
+	}, nil
+}
+
+func (h Handler) GetInvoicePDFHandler(
+	ctx context.Context,
+	userID string,
+	invoiceID string,
+) (httptransport.InvoicePDF, error) {
+	invoice, body, err := h.Service.RenderUserInvoicePDF(ctx, userID, strings.TrimSpace(invoiceID))
+	if err != nil {
+		return httptransport.InvoicePDF{}, err
+	}
+	return httptransport.InvoicePDF{
+		FileName: invoice.InvoiceNumber + ".pdf",
+		Body:     body,
+	}, nil
+}
+
+func (h Handler) CreateCreditNoteHandler(
+	ctx context.Context,
+	actorID string,
+	idempotencyKey string,
+	invoiceID string,
+	req httptransport.CreateCreditNoteRequest,
+) (httptransport.CreateCreditNoteResponse, error) {
+	note, err := h.Service.IssueCreditNote(ctx, idempotencyKey, ports.IssueCreditNoteInput{
+		ActorID:     actorID,
+		InvoiceID:   strings.TrimSpace(invoiceID),
+		LineItemID:  strings.TrimSpace(req.LineItemID),
+		AmountCents: req.AmountCents,
+		Reason:      strings.TrimSpace(req.Reason),
+	})
+	if err != nil {
+		return httptransport.CreateCreditNoteResponse{}, err
+	}
+	return httptransport.CreateCreditNoteResponse{
+		Status: "success",
+		Data:   toCreditNoteDTO(note),
+	}, nil
+}
+
+func toInvoiceDTO(document application.InvoiceDocument) httptransport.InvoiceDTO {
+	invoice := document.Invoice
+	out := httptransport.InvoiceDTO{
+		InvoiceID:     invoice.InvoiceID,
+		InvoiceNumber: invoice.InvoiceNumber,
+		UserID:        invoice.UserID,
+		SourceType:    invoice.SourceType,
+		SourceID:      invoice.SourceID,
+		Currency:      invoice.Currency,
+		LineItems:     make([]httptransport.InvoiceLineItemDTO, 0, len(invoice.LineItems)),
+		TaxLines:      make([]httptransport.InvoiceTaxLineDTO, 0, len(invoice.TaxLines)),
+		SubtotalCents: invoice.SubtotalCents,
+		TaxCents:      invoice.TaxCents,
+		TotalCents:    invoice.TotalCents,
+		CreditedCents: document.CreditedCents(),
+		CreditNotes:   make([]httptransport.CreditNoteDTO, 0, len(document.CreditNotes)),
+		IssuedAt:      invoice.IssuedAt.UTC().Format(time.RFC3339),
+	}
+	for _, item := range invoice.LineItems {
+		out.LineItems = append(out.LineItems, httptransport.InvoiceLineItemDTO{
+			LineItemID:      item.LineItemID,
+			Description:     item.Description,
+			Quantity:        item.Quantity,
+			UnitAmountCents: item.UnitAmountCents,
+			AmountCents:     item.AmountCents,
+			TaxCents:        item.TaxCents,
+			ReferenceType:   item.ReferenceType,
+			ReferenceID:     item.ReferenceID,
+		})
+	}
+	for _, tax := range invoice.TaxLines {
+		out.TaxLines = append(out.TaxLines, httptransport.InvoiceTaxLineDTO{
+			TaxCode:         tax.TaxCode,
+			Name:            tax.Name,
+			RateBasisPoints: tax.RateBasisPoints,
+			Inclusive:       tax.Inclusive,
+			TaxableCents:    tax.TaxableCents,
+			TaxCents:        tax.TaxCents,
+		})
+	}
+	for _, note := range document.CreditNotes {
+		out.CreditNotes = append(out.CreditNotes, toCreditNoteDTO(note))
+	}
+	return out
+}
+
+func toCreditNoteDTO(note ports.CreditNote) httptransport.CreditNoteDTO {
+	return httptransport.CreditNoteDTO{
+		CreditNoteID:     note.CreditNoteID,
+		CreditNoteNumber: note.CreditNoteNumber,
+		InvoiceID:        note.InvoiceID,
+		InvoiceNumber:    note.InvoiceNumber,
+		LineItemID:       note.LineItemID,
+		Currency:         note.Currency,
+		AmountCents:      note.AmountCents,
+		TaxCents:         note.TaxCents,
+		Reason:           note.Reason,
+		IssuedBy:         note.IssuedBy,
+		IssuedAt:         note.IssuedAt.UTC().Format(time.RFC3339),
+	}
+}
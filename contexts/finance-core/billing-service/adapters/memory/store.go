@@ -0,0 +1,265 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	domainerrors "solomon/contexts/finance-core/billing-service/domain/errors"
+	"solomon/contexts/finance-core/billing-service/domain/services"
+	"solomon/contexts/finance-core/billing-service/ports"
+)
+
+type Store struct {
+	mu sync.RWMutex
+
+	invoices        map[string]ports.Invoice
+	invoiceBySource map[string]string
+	creditNotes     map[string][]ports.CreditNote
+	documentSeq     map[string]int64
+	taxRates        map[string][]ports.TaxRate
+
+	idempotency map[string]ports.IdempotencyRecord
+	sequence    uint64
+}
+
+func NewStore() *Store {
+	now := time.Now().UTC()
+	store := &Store{
+		invoices:        make(map[string]ports.Invoice),
+		invoiceBySource: make(map[string]string),
+		creditNotes:     make(map[string][]ports.CreditNote),
+		documentSeq:     make(map[string]int64),
+		taxRates: map[string][]ports.TaxRate{
+			"USD": {{TaxCode: "us_sales_tax", Name: "Sales tax", RateBasisPoints: 0}},
+			"EUR": {{TaxCode: "eu_vat_standard", Name: "VAT", RateBasisPoints: 2000}},
+			"GBP": {{TaxCode: "uk_vat_standard", Name: "VAT", RateBasisPoints: 2000}},
+		},
+		idempotency: make(map[string]ports.IdempotencyRecord),
+		sequence:    1,
+	}
+
+	// Seed invoice used by local admin billing-refund flows.
+	seedIssuedAt := now.Add(-7 * 24 * time.Hour)
+	store.documentSeq[documentSeqKey(ports.DocumentKindInvoice, seedIssuedAt)] = 1
+	store.putInvoice(ports.Invoice{
+		InvoiceID:     "invoice-1",
+		InvoiceNumber: fmt.Sprintf("INV-%04d-%06d", seedIssuedAt.Year(), 1),
+		UserID:        "user_001",
+		SourceType:    ports.SourceTypeSubscriptionCharge,
+		SourceID:      "sub_seed_1",
+		Currency:      "USD",
+		LineItems: []ports.InvoiceLineItem{
+			{
+				LineItemID:      "line-1",
+				Description:     "Pro Monthly subscription",
+				Quantity:        1,
+				UnitAmountCents: 4900,
+				AmountCents:     4900,
+				ReferenceType:   "subscription_plan",
+				ReferenceID:     "plan_pro_monthly",
+			},
+		},
+		TaxLines: []ports.InvoiceTaxLine{
+			{TaxCode: "us_sales_tax", Name: "Sales tax", Inclusive: true, TaxableCents: 4900},
+		},
+		SubtotalCents: 4900,
+		TotalCents:    4900,
+		IssuedAt:      seedIssuedAt,
+	})
+	return store
+}
+
+func (s *Store) CreateInvoice(_ context.Context, invoice ports.Invoice) (ports.Invoice, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existingID, ok := s.invoiceBySource[sourceKey(invoice.SourceType, invoice.SourceID)]; ok {
+		return cloneInvoice(s.invoices[existingID]), nil
+	}
+	if _, ok := s.invoices[invoice.InvoiceID]; ok {
+		return ports.Invoice{}, domainerrors.ErrConflict
+	}
+	invoice.InvoiceNumber = services.InvoiceNumber(invoice.IssuedAt, s.nextDocumentNumber(ports.DocumentKindInvoice, invoice.IssuedAt))
+	s.putInvoice(invoice)
+	return cloneInvoice(invoice), nil
+}
+
+func (s *Store) GetInvoice(_ context.Context, invoiceID string) (ports.Invoice, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	invoice, ok := s.invoices[strings.TrimSpace(invoiceID)]
+	if !ok {
+		return ports.Invoice{}, domainerrors.ErrInvoiceNotFound
+	}
+	return cloneInvoice(invoice), nil
+}
+
+func (s *Store) GetInvoiceBySource(_ context.Context, sourceType string, sourceID string) (ports.Invoice, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	invoiceID, ok := s.invoiceBySource[sourceKey(sourceType, sourceID)]
+	if !ok {
+		return ports.Invoice{}, false, nil
+	}
+	return cloneInvoice(s.invoices[invoiceID]), true, nil
+}
+
+func (s *Store) ListInvoicesByUser(_ context.Context, userID string, limit int, offset int) ([]ports.Invoice, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	items := make([]ports.Invoice, 0)
+	for _, invoice := range s.invoices {
+		if invoice.UserID == userID {
+			items = append(items, invoice)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].IssuedAt.Equal(items[j].IssuedAt) {
+			return items[i].InvoiceNumber > items[j].InvoiceNumber
+		}
+		return items[i].IssuedAt.After(items[j].IssuedAt)
+	})
+	total := len(items)
+	if offset >= total {
+		return []ports.Invoice{}, total, nil
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+	out := make([]ports.Invoice, 0, end-offset)
+	for _, invoice := range items[offset:end] {
+		out = append(out, cloneInvoice(invoice))
+	}
+	return out, total, nil
+}
+
+func (s *Store) CreateCreditNote(_ context.Context, note ports.CreditNote) (ports.CreditNote, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	invoice, ok := s.invoices[note.InvoiceID]
+	if !ok {
+		return ports.CreditNote{}, domainerrors.ErrInvoiceNotFound
+	}
+	var lineAmount int64 = -1
+	for _, item := range invoice.LineItems {
+		if item.LineItemID == note.LineItemID {
+			lineAmount = item.AmountCents
+			break
+		}
+	}
+	if lineAmount < 0 {
+		return ports.CreditNote{}, domainerrors.ErrLineItemNotFound
+	}
+	credited := note.AmountCents
+	for _, existing := range s.creditNotes[note.InvoiceID] {
+		if existing.LineItemID == note.LineItemID {
+			credited += existing.AmountCents
+		}
+	}
+	if credited > lineAmount {
+		return ports.CreditNote{}, domainerrors.ErrCreditExceedsRefundable
+	}
+	note.CreditNoteNumber = services.CreditNoteNumber(note.IssuedAt, s.nextDocumentNumber(ports.DocumentKindCreditNote, note.IssuedAt))
+	s.creditNotes[note.InvoiceID] = append(s.creditNotes[note.InvoiceID], note)
+	return note, nil
+}
+
+func (s *Store) ListCreditNotesByInvoice(_ context.Context, invoiceID string) ([]ports.CreditNote, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]ports.CreditNote(nil), s.creditNotes[invoiceID]...), nil
+}
+
+func (s *Store) TaxRatesFor(_ context.Context, _ string, currency string) ([]ports.TaxRate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]ports.TaxRate(nil), s.taxRates[strings.ToUpper(strings.TrimSpace(currency))]...), nil
+}
+
+func (s *Store) Get(_ context.Context, key string, now time.Time) (ports.IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.idempotency[key]
+	if !ok {
+		return ports.IdempotencyRecord{}, false, nil
+	}
+	if !record.ExpiresAt.IsZero() && now.UTC().After(record.ExpiresAt.UTC()) {
+		delete(s.idempotency, key)
+		return ports.IdempotencyRecord{}, false, nil
+	}
+	return record, true, nil
+}
+
+func (s *Store) Put(_ context.Context, record ports.IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.idempotency[record.Key]; ok {
+		if existing.RequestHash != record.RequestHash {
+			return domainerrors.ErrIdempotencyConflict
+		}
+		return nil
+	}
+	s.idempotency[record.Key] = record
+	return nil
+}
+
+func (s *Store) NewID(_ context.Context) (string, error) {
+	return s.nextID("m05"), nil
+}
+
+func (s *Store) Now() time.Time {
+	return time.Now().UTC()
+}
+
+func (s *Store) putInvoice(invoice ports.Invoice) {
+	s.invoices[invoice.InvoiceID] = cloneInvoice(invoice)
+	s.invoiceBySource[sourceKey(invoice.SourceType, invoice.SourceID)] = invoice.InvoiceID
+}
+
+func (s *Store) nextID(prefix string) string {
+	n := atomic.AddUint64(&s.sequence, 1)
+	return fmt.Sprintf("%s_%d", prefix, n)
+}
+
+// nextDocumentNumber advances the sequence of a kind and year. Callers hold
+// s.mu for the write that uses the number.
+func (s *Store) nextDocumentNumber(kind string, issuedAt time.Time) int64 {
+	key := documentSeqKey(kind, issuedAt)
+	s.documentSeq[key]++
+	return s.documentSeq[key]
+}
+
+func documentSeqKey(kind string, issuedAt time.Time) string {
+	return fmt.Sprintf("%s:%04d", kind, issuedAt.UTC().Year())
+}
+
+func sourceKey(sourceType string, sourceID string) string {
+	return strings.TrimSpace(sourceType) + ":" + strings.TrimSpace(sourceID)
+}
+
+func cloneInvoice(in ports.Invoice) ports.Invoice {
+	out := in
+	out.LineItems = append([]ports.InvoiceLineItem(nil), in.LineItems...)
+	out.TaxLines = append([]ports.InvoiceTaxLine(nil), in.TaxLines...)
+	return out
+}
+
+var _ ports.Repository = (*Store)(nil)
+var _ ports.IdempotencyStore = (*Store)(nil)
+var _ ports.TaxRateProvider = (*Store)(nil)
+var _ ports.Clock = (*Store)(nil)
+var _ ports.IDGenerator = (*Store)(nil)
@@ -0,0 +1,166 @@
+package pdfadapter
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"solomon/contexts/finance-core/billing-service/ports"
+)
+
+const (
+	pageWidth  = 595 // A4 in points
+	pageHeight = 842
+	marginLeft = 50
+	marginTop  = 60
+	lineHeight = 14
+	maxLines   = (pageHeight - 2*marginTop) / lineHeight
+)
+
+// Renderer writes single-font, text-only PDF 1.4 documents. It keeps the
+// module free of third-party PDF dependencies while producing files any
+// viewer can open.
+type Renderer struct {
+	IssuerName string
+}
+
+func (r Renderer) RenderPDF(invoice ports.Invoice, creditNotes []ports.CreditNote) ([]byte, error) {
+	lines := r.invoiceLines(invoice, creditNotes)
+
+	pages := make([][]string, 0, len(lines)/maxLines+1)
+	for len(lines) > maxLines {
+		pages = append(pages, lines[:maxLines])
+		lines = lines[maxLines:]
+	}
+	pages = append(pages, lines)
+
+	// Object layout: 1 catalog, 2 pages, 3 font, then (page, content) pairs.
+	var objects []string
+	objects = append(objects, "<< /Type /Catalog /Pages 2 0 R >>")
+	kids := make([]string, 0, len(pages))
+	for i := range pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", 4+i*2))
+	}
+	objects = append(objects, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	objects = append(objects, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	for i, page := range pages {
+		stream := pageStream(page)
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+				pageWidth, pageHeight, 5+i*2),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(stream), stream),
+		)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, 0, len(objects))
+	for i, object := range objects {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xrefOffset := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info << /Title (%s) >> >>\nstartxref\n%d\n%%%%EOF\n",
+		len(objects)+1, escapeText("Invoice "+invoice.InvoiceNumber), xrefOffset)
+	return buf.Bytes(), nil
+}
+
+func (r Renderer) invoiceLines(invoice ports.Invoice, creditNotes []ports.CreditNote) []string {
+	issuer := strings.TrimSpace(r.IssuerName)
+	if issuer == "" {
+		issuer = "ViralForge"
+	}
+	lines := []string{
+		issuer,
+		"INVOICE " + invoice.InvoiceNumber,
+		"",
+		"Invoice ID: " + invoice.InvoiceID,
+		"Issued: " + invoice.IssuedAt.UTC().Format(time.RFC3339),
+		"Billed to: " + invoice.UserID,
+		"Currency: " + invoice.Currency,
+		"",
+		"Line items",
+	}
+	for _, item := range invoice.LineItems {
+		lines = append(lines, fmt.Sprintf("  %s  x%d  @ %s  = %s",
+			item.Description,
+			item.Quantity,
+			formatMoney(item.UnitAmountCents, invoice.Currency),
+			formatMoney(item.AmountCents, invoice.Currency),
+		))
+	}
+	lines = append(lines, "", "Subtotal (excl. tax): "+formatMoney(invoice.SubtotalCents, invoice.Currency))
+	for _, tax := range invoice.TaxLines {
+		lines = append(lines, fmt.Sprintf("%s %s (included): %s",
+			tax.Name,
+			formatRate(tax.RateBasisPoints),
+			formatMoney(tax.TaxCents, invoice.Currency),
+		))
+	}
+	lines = append(lines, "Total: "+formatMoney(invoice.TotalCents, invoice.Currency))
+
+	if len(creditNotes) > 0 {
+		lines = append(lines, "", "Credit notes")
+		var credited int64
+		for _, note := range creditNotes {
+			credited += note.AmountCents
+			lines = append(lines, fmt.Sprintf("  %s  %s  -%s  %s",
+				note.CreditNoteNumber,
+				note.IssuedAt.UTC().Format("2006-01-02"),
+				formatMoney(note.AmountCents, note.Currency),
+				note.Reason,
+			))
+		}
+		lines = append(lines, "Net after credits: "+formatMoney(invoice.TotalCents-credited, invoice.Currency))
+	}
+	return lines
+}
+
+func pageStream(lines []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "BT\n/F1 10 Tf\n%d TL\n%d %d Td\n", lineHeight, marginLeft, pageHeight-marginTop)
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteString("T*\n")
+		}
+		fmt.Fprintf(&b, "(%s) Tj\n", escapeText(line))
+	}
+	b.WriteString("ET")
+	return b.String()
+}
+
+func escapeText(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case r < 32 || r > 126:
+			b.WriteRune('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func formatMoney(cents int64, currency string) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d %s", sign, cents/100, cents%100, currency)
+}
+
+func formatRate(basisPoints int) string {
+	return fmt.Sprintf("%d.%02d%%", basisPoints/100, basisPoints%100)
+}
+
+var _ ports.InvoiceRenderer = Renderer{}
@@ -0,0 +1,163 @@
+package postgresadapter
+
+import (
+	"time"
+
+	"solomon/contexts/finance-core/billing-service/ports"
+)
+
+type invoiceModel struct {
+	InvoiceID     string    `gorm:"column:invoice_id;primaryKey"`
+	InvoiceNumber string    `gorm:"column:invoice_number"`
+	UserID        string    `gorm:"column:user_id"`
+	SourceType    string    `gorm:"column:source_type"`
+	SourceID      string    `gorm:"column:source_id"`
+	Currency      string    `gorm:"column:currency"`
+	SubtotalCents int64     `gorm:"column:subtotal_cents"`
+	TaxCents      int64     `gorm:"column:tax_cents"`
+	TotalCents    int64     `gorm:"column:total_cents"`
+	IssuedAt      time.Time `gorm:"column:issued_at"`
+}
+
+func (invoiceModel) TableName() string {
+	return "billing_invoices"
+}
+
+func (m invoiceModel) toPort() ports.Invoice {
+	return ports.Invoice{
+		InvoiceID:     m.InvoiceID,
+		InvoiceNumber: m.InvoiceNumber,
+		UserID:        m.UserID,
+		SourceType:    m.SourceType,
+		SourceID:      m.SourceID,
+		Currency:      m.Currency,
+		SubtotalCents: m.SubtotalCents,
+		TaxCents:      m.TaxCents,
+		TotalCents:    m.TotalCents,
+		IssuedAt:      m.IssuedAt.UTC(),
+	}
+}
+
+type lineItemModel struct {
+	LineItemID      string `gorm:"column:line_item_id;primaryKey"`
+	InvoiceID       string `gorm:"column:invoice_id"`
+	Position        int    `gorm:"column:position"`
+	Description     string `gorm:"column:description"`
+	Quantity        int    `gorm:"column:quantity"`
+	UnitAmountCents int64  `gorm:"column:unit_amount_cents"`
+	AmountCents     int64  `gorm:"column:amount_cents"`
+	TaxCents        int64  `gorm:"column:tax_cents"`
+	ReferenceType   string `gorm:"column:reference_type"`
+	ReferenceID     string `gorm:"column:reference_id"`
+}
+
+func (lineItemModel) TableName() string {
+	return "billing_invoice_line_items"
+}
+
+func (m lineItemModel) toPort() ports.InvoiceLineItem {
+	return ports.InvoiceLineItem{
+		LineItemID:      m.LineItemID,
+		Description:     m.Description,
+		Quantity:        m.Quantity,
+		UnitAmountCents: m.UnitAmountCents,
+		AmountCents:     m.AmountCents,
+		TaxCents:        m.TaxCents,
+		ReferenceType:   m.ReferenceType,
+		ReferenceID:     m.ReferenceID,
+	}
+}
+
+type taxLineModel struct {
+	InvoiceID       string `gorm:"column:invoice_id;primaryKey"`
+	Position        int    `gorm:"column:position;primaryKey"`
+	TaxCode         string `gorm:"column:tax_code"`
+	Name            string `gorm:"column:name"`
+	RateBasisPoints int    `gorm:"column:rate_basis_points"`
+	Inclusive       bool   `gorm:"column:inclusive"`
+	TaxableCents    int64  `gorm:"column:taxable_cents"`
+	TaxCents        int64  `gorm:"column:tax_cents"`
+}
+
+func (taxLineModel) TableName() string {
+	return "billing_invoice_tax_lines"
+}
+
+func (m taxLineModel) toPort() ports.InvoiceTaxLine {
+	return ports.InvoiceTaxLine{
+		TaxCode:         m.TaxCode,
+		Name:            m.Name,
+		RateBasisPoints: m.RateBasisPoints,
+		Inclusive:       m.Inclusive,
+		TaxableCents:    m.TaxableCents,
+		TaxCents:        m.TaxCents,
+	}
+}
+
+type creditNoteModel struct {
+	CreditNoteID     string    `gorm:"column:credit_note_id;primaryKey"`
+	CreditNoteNumber string    `gorm:"column:credit_note_number"`
+	InvoiceID        string    `gorm:"column:invoice_id"`
+	InvoiceNumber    string    `gorm:"column:invoice_number"`
+	UserID           string    `gorm:"column:user_id"`
+	LineItemID       string    `gorm:"column:line_item_id"`
+	Currency         string    `gorm:"column:currency"`
+	AmountCents      int64     `gorm:"column:amount_cents"`
+	TaxCents         int64     `gorm:"column:tax_cents"`
+	Reason           string    `gorm:"column:reason"`
+	IssuedBy         string    `gorm:"column:issued_by"`
+	IssuedAt         time.Time `gorm:"column:issued_at"`
+}
+
+func (creditNoteModel) TableName() string {
+	return "billing_credit_notes"
+}
+
+func (m creditNoteModel) toPort() ports.CreditNote {
+	return ports.CreditNote{
+		CreditNoteID:     m.CreditNoteID,
+		CreditNoteNumber: m.CreditNoteNumber,
+		InvoiceID:        m.InvoiceID,
+		InvoiceNumber:    m.InvoiceNumber,
+		UserID:           m.UserID,
+		LineItemID:       m.LineItemID,
+		Currency:         m.Currency,
+		AmountCents:      m.AmountCents,
+		TaxCents:         m.TaxCents,
+		Reason:           m.Reason,
+		IssuedBy:         m.IssuedBy,
+		IssuedAt:         m.IssuedAt.UTC(),
+	}
+}
+
+type taxRateModel struct {
+	Currency        string `gorm:"column:currency;primaryKey"`
+	TaxCode         string `gorm:"column:tax_code;primaryKey"`
+	Name            string `gorm:"column:name"`
+	RateBasisPoints int    `gorm:"column:rate_basis_points"`
+}
+
+func (taxRateModel) TableName() string {
+	return "billing_tax_rates"
+}
+
+type idempotencyModel struct {
+	Key         string    `gorm:"column:key;primaryKey"`
+	RequestHash string    `gorm:"column:request_hash"`
+	Payload     []byte    `gorm:"column:payload"`
+	ExpiresAt   time.Time `gorm:"column:expires_at"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+}
+
+func (idempotencyModel) TableName() string {
+	return "billing_idempotency"
+}
+
+func (m idempotencyModel) toPort() ports.IdempotencyRecord {
+	return ports.IdempotencyRecord{
+		Key:         m.Key,
+		RequestHash: m.RequestHash,
+		Payload:     append([]byte(nil), m.Payload...),
+		ExpiresAt:   m.ExpiresAt.UTC(),
+	}
+}
@@ -0,0 +1,378 @@
+package postgresadapter
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	domainerrors "solomon/contexts/finance-core/billing-service/domain/errors"
+	"solomon/contexts/finance-core/billing-service/domain/services"
+	"solomon/contexts/finance-core/billing-service/ports"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository persists invoices, their line and tax items, credit notes and
+// the document number sequences. A document reserves its number in the
+// transaction that stores it, so a rolled-back write leaves no gap. An
+// invoice and its items commit in one transaction; credit notes lock their
+// invoice row so concurrent refunds of one line cannot exceed its amount.
+type Repository struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+// NewRepository builds the GORM-backed billing adapter.
+func NewRepository(db *gorm.DB, logger *slog.Logger) *Repository {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Repository{db: db, logger: logger}
+}
+
+// errSourceInvoiced rolls back an invoice insert that lost the race for
+// its source, releasing the number it reserved.
+var errSourceInvoiced = errors.New("source already invoiced")
+
+func (r *Repository) CreateInvoice(ctx context.Context, invoice ports.Invoice) (ports.Invoice, error) {
+	var out ports.Invoice
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		sequence, err := nextDocumentNumber(tx, ports.DocumentKindInvoice, invoice.IssuedAt)
+		if err != nil {
+			return err
+		}
+		row := invoiceModel{
+			InvoiceID:     invoice.InvoiceID,
+			InvoiceNumber: services.InvoiceNumber(invoice.IssuedAt, sequence),
+			UserID:        invoice.UserID,
+			SourceType:    invoice.SourceType,
+			SourceID:      invoice.SourceID,
+			Currency:      invoice.Currency,
+			SubtotalCents: invoice.SubtotalCents,
+			TaxCents:      invoice.TaxCents,
+			TotalCents:    invoice.TotalCents,
+			IssuedAt:      invoice.IssuedAt.UTC(),
+		}
+		created := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "source_type"}, {Name: "source_id"}},
+			DoNothing: true,
+		}).Create(&row)
+		if created.Error != nil {
+			return mapWriteError(created.Error)
+		}
+		if created.RowsAffected == 0 {
+			return errSourceInvoiced
+		}
+		for i, item := range invoice.LineItems {
+			if err := tx.Create(&lineItemModel{
+				LineItemID:      item.LineItemID,
+				InvoiceID:       invoice.InvoiceID,
+				Position:        i + 1,
+				Description:     item.Description,
+				Quantity:        item.Quantity,
+				UnitAmountCents: item.UnitAmountCents,
+				AmountCents:     item.AmountCents,
+				TaxCents:        item.TaxCents,
+				ReferenceType:   item.ReferenceType,
+				ReferenceID:     item.ReferenceID,
+			}).Error; err != nil {
+				return mapWriteError(err)
+			}
+		}
+		for i, line := range invoice.TaxLines {
+			if err := tx.Create(&taxLineModel{
+				InvoiceID:       invoice.InvoiceID,
+				Position:        i + 1,
+				TaxCode:         line.TaxCode,
+				Name:            line.Name,
+				RateBasisPoints: line.RateBasisPoints,
+				Inclusive:       line.Inclusive,
+				TaxableCents:    line.TaxableCents,
+				TaxCents:        line.TaxCents,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		loaded, err := withItems(tx, []invoiceModel{row})
+		if err != nil {
+			return err
+		}
+		out = loaded[0]
+		return nil
+	})
+	if errors.Is(err, errSourceInvoiced) {
+		// The source was invoiced concurrently; return that invoice.
+		existing, found, err := r.GetInvoiceBySource(ctx, invoice.SourceType, invoice.SourceID)
+		if err != nil {
+			return ports.Invoice{}, err
+		}
+		if !found {
+			return ports.Invoice{}, domainerrors.ErrConflict
+		}
+		return existing, nil
+	}
+	if err != nil {
+		return ports.Invoice{}, err
+	}
+	return out, nil
+}
+
+func (r *Repository) GetInvoice(ctx context.Context, invoiceID string) (ports.Invoice, error) {
+	db := r.db.WithContext(ctx)
+	var row invoiceModel
+	if err := db.Where("invoice_id = ?", strings.TrimSpace(invoiceID)).First(&row).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ports.Invoice{}, domainerrors.ErrInvoiceNotFound
+		}
+		return ports.Invoice{}, err
+	}
+	invoices, err := withItems(db, []invoiceModel{row})
+	if err != nil {
+		return ports.Invoice{}, err
+	}
+	return invoices[0], nil
+}
+
+func (r *Repository) GetInvoiceBySource(ctx context.Context, sourceType string, sourceID string) (ports.Invoice, bool, error) {
+	return getInvoiceBySource(r.db.WithContext(ctx), sourceType, sourceID)
+}
+
+func (r *Repository) ListInvoicesByUser(ctx context.Context, userID string, limit int, offset int) ([]ports.Invoice, int, error) {
+	db := r.db.WithContext(ctx)
+	var total int64
+	if err := db.Model(&invoiceModel{}).Where("user_id = ?", userID).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var rows []invoiceModel
+	if err := db.
+		Where("user_id = ?", userID).
+		Order("issued_at DESC, invoice_number DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	invoices, err := withItems(db, rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return invoices, int(total), nil
+}
+
+func (r *Repository) CreateCreditNote(ctx context.Context, note ports.CreditNote) (ports.CreditNote, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var invoice invoiceModel
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("invoice_id = ?", note.InvoiceID).
+			First(&invoice).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domainerrors.ErrInvoiceNotFound
+			}
+			return err
+		}
+		var line lineItemModel
+		if err := tx.Where("invoice_id = ? AND line_item_id = ?", invoice.InvoiceID, note.LineItemID).First(&line).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domainerrors.ErrLineItemNotFound
+			}
+			return err
+		}
+		var credited int64
+		if err := tx.Model(&creditNoteModel{}).
+			Select("COALESCE(SUM(amount_cents), 0)").
+			Where("invoice_id = ? AND line_item_id = ?", invoice.InvoiceID, line.LineItemID).
+			Scan(&credited).Error; err != nil {
+			return err
+		}
+		if credited+note.AmountCents > line.AmountCents {
+			return domainerrors.ErrCreditExceedsRefundable
+		}
+		sequence, err := nextDocumentNumber(tx, ports.DocumentKindCreditNote, note.IssuedAt)
+		if err != nil {
+			return err
+		}
+		note.CreditNoteNumber = services.CreditNoteNumber(note.IssuedAt, sequence)
+		return mapWriteError(tx.Create(&creditNoteModel{
+			CreditNoteID:     note.CreditNoteID,
+			CreditNoteNumber: note.CreditNoteNumber,
+			InvoiceID:        note.InvoiceID,
+			InvoiceNumber:    note.InvoiceNumber,
+			UserID:           note.UserID,
+			LineItemID:       note.LineItemID,
+			Currency:         note.Currency,
+			AmountCents:      note.AmountCents,
+			TaxCents:         note.TaxCents,
+			Reason:           note.Reason,
+			IssuedBy:         note.IssuedBy,
+			IssuedAt:         note.IssuedAt.UTC(),
+		}).Error)
+	})
+	if err != nil {
+		return ports.CreditNote{}, err
+	}
+	return note, nil
+}
+
+func (r *Repository) ListCreditNotesByInvoice(ctx context.Context, invoiceID string) ([]ports.CreditNote, error) {
+	var rows []creditNoteModel
+	if err := r.db.WithContext(ctx).
+		Where("invoice_id = ?", invoiceID).
+		Order("issued_at ASC, credit_note_number ASC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	items := make([]ports.CreditNote, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, row.toPort())
+	}
+	return items, nil
+}
+
+func (r *Repository) TaxRatesFor(ctx context.Context, _ string, currency string) ([]ports.TaxRate, error) {
+	var rows []taxRateModel
+	if err := r.db.WithContext(ctx).
+		Where("currency = ?", strings.ToUpper(strings.TrimSpace(currency))).
+		Order("tax_code ASC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	rates := make([]ports.TaxRate, 0, len(rows))
+	for _, row := range rows {
+		rates = append(rates, ports.TaxRate{
+			TaxCode:         row.TaxCode,
+			Name:            row.Name,
+			RateBasisPoints: row.RateBasisPoints,
+		})
+	}
+	return rates, nil
+}
+
+func (r *Repository) Get(ctx context.Context, key string, now time.Time) (ports.IdempotencyRecord, bool, error) {
+	var row idempotencyModel
+	err := r.db.WithContext(ctx).Where("key = ?", key).First(&row).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ports.IdempotencyRecord{}, false, nil
+		}
+		return ports.IdempotencyRecord{}, false, err
+	}
+	if !row.ExpiresAt.After(now.UTC()) {
+		if err := r.db.WithContext(ctx).Where("key = ?", key).Delete(&idempotencyModel{}).Error; err != nil {
+			return ports.IdempotencyRecord{}, false, err
+		}
+		return ports.IdempotencyRecord{}, false, nil
+	}
+	return row.toPort(), true, nil
+}
+
+// Put inserts a new idempotency record and checks request-hash collisions.
+func (r *Repository) Put(ctx context.Context, record ports.IdempotencyRecord) error {
+	row := idempotencyModel{
+		Key:         record.Key,
+		RequestHash: record.RequestHash,
+		Payload:     append([]byte(nil), record.Payload...),
+		ExpiresAt:   record.ExpiresAt.UTC(),
+		CreatedAt:   time.Now().UTC(),
+	}
+	created := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "key"}},
+			DoNothing: true,
+		}).
+		Create(&row)
+	if created.Error != nil {
+		return created.Error
+	}
+	if created.RowsAffected > 0 {
+		return nil
+	}
+	var existing idempotencyModel
+	if err := r.db.WithContext(ctx).Where("key = ?", row.Key).First(&existing).Error; err != nil {
+		return err
+	}
+	if existing.RequestHash != row.RequestHash {
+		return domainerrors.ErrIdempotencyConflict
+	}
+	return nil
+}
+
+// nextDocumentNumber reserves the next number of a kind and year. The
+// sequence row stays locked until tx ends, so numbers are handed out in
+// commit order.
+func nextDocumentNumber(tx *gorm.DB, kind string, issuedAt time.Time) (int64, error) {
+	var next int64
+	err := tx.Raw(`
+INSERT INTO billing_document_sequences (kind, year, last_value)
+VALUES (?, ?, 1)
+ON CONFLICT (kind, year) DO UPDATE SET last_value = billing_document_sequences.last_value + 1
+RETURNING last_value`, kind, issuedAt.UTC().Year()).Scan(&next).Error
+	return next, err
+}
+
+func getInvoiceBySource(db *gorm.DB, sourceType string, sourceID string) (ports.Invoice, bool, error) {
+	var row invoiceModel
+	err := db.Where("source_type = ? AND source_id = ?", strings.TrimSpace(sourceType), strings.TrimSpace(sourceID)).First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ports.Invoice{}, false, nil
+	}
+	if err != nil {
+		return ports.Invoice{}, false, err
+	}
+	invoices, err := withItems(db, []invoiceModel{row})
+	if err != nil {
+		return ports.Invoice{}, false, err
+	}
+	return invoices[0], true, nil
+}
+
+// withItems loads the line and tax items of the given invoices in two
+// queries and returns the invoices in their original order.
+func withItems(db *gorm.DB, rows []invoiceModel) ([]ports.Invoice, error) {
+	if len(rows) == 0 {
+		return []ports.Invoice{}, nil
+	}
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.InvoiceID)
+	}
+	var lines []lineItemModel
+	if err := db.Where("invoice_id IN ?", ids).Order("invoice_id ASC, position ASC").Find(&lines).Error; err != nil {
+		return nil, err
+	}
+	var taxes []taxLineModel
+	if err := db.Where("invoice_id IN ?", ids).Order("invoice_id ASC, position ASC").Find(&taxes).Error; err != nil {
+		return nil, err
+	}
+	linesByInvoice := make(map[string][]ports.InvoiceLineItem, len(rows))
+	for _, line := range lines {
+		linesByInvoice[line.InvoiceID] = append(linesByInvoice[line.InvoiceID], line.toPort())
+	}
+	taxesByInvoice := make(map[string][]ports.InvoiceTaxLine, len(rows))
+	for _, tax := range taxes {
+		taxesByInvoice[tax.InvoiceID] = append(taxesByInvoice[tax.InvoiceID], tax.toPort())
+	}
+	invoices := make([]ports.Invoice, 0, len(rows))
+	for _, row := range rows {
+		invoice := row.toPort()
+		invoice.LineItems = linesByInvoice[row.InvoiceID]
+		invoice.TaxLines = taxesByInvoice[row.InvoiceID]
+		invoices = append(invoices, invoice)
+	}
+	return invoices, nil
+}
+
+func mapWriteError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return domainerrors.ErrConflict
+	}
+	return err
+}
+
+var _ ports.Repository = (*Repository)(nil)
+var _ ports.IdempotencyStore = (*Repository)(nil)
+var _ ports.TaxRateProvider = (*Repository)(nil)
@@ -0,0 +1,10 @@
+package application
+
+import "log/slog"
+
+func resolveLogger(logger *slog.Logger) *slog.Logger {
+	if logger == nil {
+		return slog.Default()
+	}
+	return logger
+}
@@ -0,0 +1,367 @@
+package application
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	domainerrors "solomon/contexts/finance-core/billing-service/domain/errors"
+	"solomon/contexts/finance-core/billing-service/domain/services"
+	"solomon/contexts/finance-core/billing-service/ports"
+)
+
+type Service struct {
+	Repo           ports.Repository
+	Idempotency    ports.IdempotencyStore
+	TaxRates       ports.TaxRateProvider
+	Renderer       ports.InvoiceRenderer
+	Clock          ports.Clock
+	IDGen          ports.IDGenerator
+	Logger         *slog.Logger
+	IdempotencyTTL time.Duration
+}
+
+type InvoiceDocument struct {
+	Invoice     ports.Invoice
+	CreditNotes []ports.CreditNote
+}
+
+// CreditedCents returns the total amount credited against the invoice.
+func (d InvoiceDocument) CreditedCents() int64 {
+	var total int64
+	for _, note := range d.CreditNotes {
+		total += note.AmountCents
+	}
+	return total
+}
+
+// IssueInvoice creates one invoice per charge source. Re-issuing for the same
+// source returns the original invoice, so callers can retry safely.
+func (s Service) IssueInvoice(ctx context.Context, input ports.IssueInvoiceInput) (ports.Invoice, error) {
+	input.UserID = strings.TrimSpace(input.UserID)
+	input.SourceType = strings.TrimSpace(input.SourceType)
+	input.SourceID = strings.TrimSpace(input.SourceID)
+	currency, ok := services.NormalizeCurrency(input.Currency)
+	if input.UserID == "" || input.SourceType == "" || input.SourceID == "" || !ok || len(input.Lines) == 0 {
+		return ports.Invoice{}, domainerrors.ErrInvalidRequest
+	}
+	for _, line := range input.Lines {
+		if strings.TrimSpace(line.Description) == "" || line.Quantity <= 0 || line.UnitAmountCents < 0 {
+			return ports.Invoice{}, domainerrors.ErrInvalidRequest
+		}
+	}
+
+	if existing, found, err := s.Repo.GetInvoiceBySource(ctx, input.SourceType, input.SourceID); err != nil {
+		return ports.Invoice{}, err
+	} else if found {
+		return existing, nil
+	}
+
+	var rates []ports.TaxRate
+	if s.TaxRates != nil {
+		resolved, err := s.TaxRates.TaxRatesFor(ctx, input.UserID, currency)
+		if err != nil {
+			return ports.Invoice{}, err
+		}
+		rates = resolved
+	}
+
+	now := s.now()
+	invoiceID, err := s.newID(ctx)
+	if err != nil {
+		return ports.Invoice{}, err
+	}
+
+	invoice := ports.Invoice{
+		InvoiceID:  invoiceID,
+		UserID:     input.UserID,
+		SourceType: input.SourceType,
+		SourceID:   input.SourceID,
+		Currency:   currency,
+		LineItems:  make([]ports.InvoiceLineItem, 0, len(input.Lines)),
+		IssuedAt:   now,
+	}
+	rateBasisPoints := make([]int, len(rates))
+	for i, rate := range rates {
+		rateBasisPoints[i] = rate.RateBasisPoints
+	}
+	taxByRate := make([]int64, len(rates))
+	for i, line := range input.Lines {
+		amount := services.LineAmount(line.Quantity, line.UnitAmountCents)
+		item := ports.InvoiceLineItem{
+			LineItemID:      fmt.Sprintf("%s_line_%d", invoiceID, i+1),
+			Description:     strings.TrimSpace(line.Description),
+			Quantity:        line.Quantity,
+			UnitAmountCents: line.UnitAmountCents,
+			AmountCents:     amount,
+			ReferenceType:   strings.TrimSpace(line.ReferenceType),
+			ReferenceID:     strings.TrimSpace(line.ReferenceID),
+		}
+		for j, tax := range services.InclusiveTaxes(amount, rateBasisPoints) {
+			item.TaxCents += tax
+			taxByRate[j] += tax
+		}
+		invoice.LineItems = append(invoice.LineItems, item)
+		invoice.TotalCents += amount
+	}
+	for _, tax := range taxByRate {
+		invoice.TaxCents += tax
+	}
+	invoice.SubtotalCents = invoice.TotalCents - invoice.TaxCents
+	// Every inclusive rate applies to the same net amount, so each tax line
+	// is taxable on the subtotal rather than the tax-inclusive total.
+	for j, rate := range rates {
+		invoice.TaxLines = append(invoice.TaxLines, ports.InvoiceTaxLine{
+			TaxCode:         rate.TaxCode,
+			Name:            rate.Name,
+			RateBasisPoints: rate.RateBasisPoints,
+			Inclusive:       true,
+			TaxableCents:    invoice.SubtotalCents,
+			TaxCents:        taxByRate[j],
+		})
+	}
+
+	created, err := s.Repo.CreateInvoice(ctx, invoice)
+	if err != nil {
+		return ports.Invoice{}, err
+	}
+	resolveLogger(s.Logger).Info("invoice issued",
+		"event", "billing_invoice_issued",
+		"module", "finance-core/billing-service",
+		"layer", "application",
+		"invoice_id", created.InvoiceID,
+		"invoice_number", created.InvoiceNumber,
+		"source_type", created.SourceType,
+		"source_id", created.SourceID,
+	)
+	return created, nil
+}
+
+func (s Service) ListUserInvoices(ctx context.Context, userID string, limit int, offset int) ([]ports.Invoice, int, error) {
+	if strings.TrimSpace(userID) == "" || offset < 0 {
+		return nil, 0, domainerrors.ErrInvalidRequest
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	return s.Repo.ListInvoicesByUser(ctx, strings.TrimSpace(userID), limit, offset)
+}
+
+// GetUserInvoice loads an invoice with its credit notes, scoped to its owner.
+func (s Service) GetUserInvoice(ctx context.Context, userID string, invoiceID string) (InvoiceDocument, error) {
+	if strings.TrimSpace(userID) == "" || strings.TrimSpace(invoiceID) == "" {
+		return InvoiceDocument{}, domainerrors.ErrInvalidRequest
+	}
+	document, err := s.loadDocument(ctx, strings.TrimSpace(invoiceID))
+	if err != nil {
+		return InvoiceDocument{}, err
+	}
+	if document.Invoice.UserID != strings.TrimSpace(userID) {
+		// Hide other users' invoices rather than leaking their existence.
+		return InvoiceDocument{}, domainerrors.ErrInvoiceNotFound
+	}
+	return document, nil
+}
+
+func (s Service) RenderUserInvoicePDF(ctx context.Context, userID string, invoiceID string) (ports.Invoice, []byte, error) {
+	if s.Renderer == nil {
+		return ports.Invoice{}, nil, domainerrors.ErrUnsupportedFormat
+	}
+	document, err := s.GetUserInvoice(ctx, userID, invoiceID)
+	if err != nil {
+		return ports.Invoice{}, nil, err
+	}
+	body, err := s.Renderer.RenderPDF(document.Invoice, document.CreditNotes)
+	if err != nil {
+		return ports.Invoice{}, nil, err
+	}
+	return document.Invoice, body, nil
+}
+
+// IssueCreditNote records a refund against one invoice line item. Tax is
+// reversed in proportion to the credited share of the line.
+func (s Service) IssueCreditNote(
+	ctx context.Context,
+	idempotencyKey string,
+	input ports.IssueCreditNoteInput,
+) (ports.CreditNote, error) {
+	var out ports.CreditNote
+	input.ActorID = strings.TrimSpace(input.ActorID)
+	input.InvoiceID = strings.TrimSpace(input.InvoiceID)
+	input.LineItemID = strings.TrimSpace(input.LineItemID)
+	input.Reason = strings.TrimSpace(input.Reason)
+	if input.ActorID == "" || input.InvoiceID == "" || input.LineItemID == "" ||
+		input.Reason == "" || input.AmountCents <= 0 {
+		return out, domainerrors.ErrInvalidRequest
+	}
+	if err := s.requireIdempotency(idempotencyKey); err != nil {
+		return out, err
+	}
+
+	requestHash := hashStrings(
+		"m05_issue_credit_note",
+		input.ActorID,
+		input.InvoiceID,
+		input.LineItemID,
+		fmt.Sprintf("%d", input.AmountCents),
+		input.Reason,
+	)
+	err := s.runIdempotent(
+		ctx,
+		strings.TrimSpace(idempotencyKey),
+		requestHash,
+		func(raw []byte) error { return json.Unmarshal(raw, &out) },
+		func() ([]byte, error) {
+			note, err := s.issueCreditNote(ctx, input)
+			if err != nil {
+				return nil, err
+			}
+			return json.Marshal(note)
+		},
+	)
+	return out, err
+}
+
+func (s Service) issueCreditNote(ctx context.Context, input ports.IssueCreditNoteInput) (ports.CreditNote, error) {
+	document, err := s.loadDocument(ctx, input.InvoiceID)
+	if err != nil {
+		return ports.CreditNote{}, err
+	}
+	var line *ports.InvoiceLineItem
+	for i := range document.Invoice.LineItems {
+		if document.Invoice.LineItems[i].LineItemID == input.LineItemID {
+			line = &document.Invoice.LineItems[i]
+			break
+		}
+	}
+	if line == nil {
+		return ports.CreditNote{}, domainerrors.ErrLineItemNotFound
+	}
+	var credited int64
+	for _, note := range document.CreditNotes {
+		if note.LineItemID == line.LineItemID {
+			credited += note.AmountCents
+		}
+	}
+	if credited+input.AmountCents > line.AmountCents {
+		return ports.CreditNote{}, domainerrors.ErrCreditExceedsRefundable
+	}
+
+	now := s.now()
+	noteID, err := s.newID(ctx)
+	if err != nil {
+		return ports.CreditNote{}, err
+	}
+	note, err := s.Repo.CreateCreditNote(ctx, ports.CreditNote{
+		CreditNoteID:  noteID,
+		InvoiceID:     document.Invoice.InvoiceID,
+		InvoiceNumber: document.Invoice.InvoiceNumber,
+		UserID:        document.Invoice.UserID,
+		LineItemID:    line.LineItemID,
+		Currency:      document.Invoice.Currency,
+		AmountCents:   input.AmountCents,
+		TaxCents:      services.ProportionalCredit(line.TaxCents, input.AmountCents, line.AmountCents),
+		Reason:        input.Reason,
+		IssuedBy:      input.ActorID,
+		IssuedAt:      now,
+	})
+	if err != nil {
+		return ports.CreditNote{}, err
+	}
+	resolveLogger(s.Logger).Info("credit note issued",
+		"event", "billing_credit_note_issued",
+		"module", "finance-core/billing-service",
+		"layer", "application",
+		"credit_note_id", note.CreditNoteID,
+		"invoice_id", note.InvoiceID,
+		"amount_cents", note.AmountCents,
+	)
+	return note, nil
+}
+
+func (s Service) loadDocument(ctx context.Context, invoiceID string) (InvoiceDocument, error) {
+	invoice, err := s.Repo.GetInvoice(ctx, invoiceID)
+	if err != nil {
+		return InvoiceDocument{}, err
+	}
+	notes, err := s.Repo.ListCreditNotesByInvoice(ctx, invoice.InvoiceID)
+	if err != nil {
+		return InvoiceDocument{}, err
+	}
+	return InvoiceDocument{Invoice: invoice, CreditNotes: notes}, nil
+}
+
+func (s Service) newID(ctx context.Context) (string, error) {
+	if s.IDGen == nil {
+		return "", domainerrors.ErrInvalidRequest
+	}
+	return s.IDGen.NewID(ctx)
+}
+
+func (s Service) now() time.Time {
+	if s.Clock == nil {
+		return time.Now().UTC()
+	}
+	return s.Clock.Now().UTC()
+}
+
+func (s Service) idempotencyTTL() time.Duration {
+	if s.IdempotencyTTL <= 0 {
+		return 7 * 24 * time.Hour
+	}
+	return s.IdempotencyTTL
+}
+
+func (s Service) requireIdempotency(key string) error {
+	if strings.TrimSpace(key) == "" {
+		return domainerrors.ErrIdempotencyKeyRequired
+	}
+	return nil
+}
+
+func (s Service) runIdempotent(
+	ctx context.Context,
+	key string,
+	requestHash string,
+	decode func([]byte) error,
+	exec func() ([]byte, error),
+) error {
+	now := s.now()
+	record, found, err := s.Idempotency.Get(ctx, key, now)
+	if err != nil {
+		return err
+	}
+	if found {
+		if record.RequestHash != requestHash {
+			return domainerrors.ErrIdempotencyConflict
+		}
+		return decode(record.Payload)
+	}
+
+	payload, err := exec()
+	if err != nil {
+		return err
+	}
+	if err := s.Idempotency.Put(ctx, ports.IdempotencyRecord{
+		Key:         key,
+		RequestHash: requestHash,
+		Payload:     payload,
+		ExpiresAt:   now.Add(s.idempotencyTTL()),
+	}); err != nil {
+		return err
+	}
+	return decode(payload)
+}
+
+func hashStrings(values ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(values, "|")))
+	return hex.EncodeToString(sum[:])
+}
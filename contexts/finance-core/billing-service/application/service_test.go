@@ -0,0 +1,185 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"solomon/contexts/finance-core/billing-service/adapters/memory"
+	pdfadapter "solomon/contexts/finance-core/billing-service/adapters/pdf"
+	domainerrors "solomon/contexts/finance-core/billing-service/domain/errors"
+	"solomon/contexts/finance-core/billing-service/ports"
+)
+
+func TestIssueInvoiceIsIdempotentPerSourceAndNumbered(t *testing.T) {
+	service := newTestService()
+
+	first, err := service.IssueInvoice(context.Background(), purchaseInput("purchase-1", "EUR", 1200))
+	if err != nil {
+		t.Fatalf("issue invoice failed: %v", err)
+	}
+	again, err := service.IssueInvoice(context.Background(), purchaseInput("purchase-1", "EUR", 1200))
+	if err != nil {
+		t.Fatalf("re-issue invoice failed: %v", err)
+	}
+	if first.InvoiceID != again.InvoiceID || first.InvoiceNumber != again.InvoiceNumber {
+		t.Fatalf("expected same invoice for same source, got %s and %s", first.InvoiceID, again.InvoiceID)
+	}
+	if !strings.HasPrefix(first.InvoiceNumber, "INV-2026-") {
+		t.Fatalf("unexpected invoice number %s", first.InvoiceNumber)
+	}
+	// 12.00 EUR including 20% VAT: 2.00 tax, 10.00 net.
+	if first.TotalCents != 1200 || first.TaxCents != 200 || first.SubtotalCents != 1000 {
+		t.Fatalf("unexpected totals: total=%d tax=%d subtotal=%d", first.TotalCents, first.TaxCents, first.SubtotalCents)
+	}
+	if len(first.TaxLines) != 1 || first.TaxLines[0].TaxableCents != 1000 {
+		t.Fatalf("expected VAT taxable on the 10.00 net, got %+v", first.TaxLines)
+	}
+
+	second, err := service.IssueInvoice(context.Background(), purchaseInput("purchase-2", "USD", 500))
+	if err != nil {
+		t.Fatalf("issue second invoice failed: %v", err)
+	}
+	if second.InvoiceNumber <= first.InvoiceNumber {
+		t.Fatalf("expected increasing numbers, got %s after %s", second.InvoiceNumber, first.InvoiceNumber)
+	}
+}
+
+func TestIssueInvoiceExtractsStackedInclusiveRatesOnce(t *testing.T) {
+	service := newTestService()
+	service.TaxRates = stubTaxRates{
+		{TaxCode: "state", Name: "State tax", RateBasisPoints: 1000},
+		{TaxCode: "city", Name: "City tax", RateBasisPoints: 1000},
+	}
+
+	invoice, err := service.IssueInvoice(context.Background(), purchaseInput("purchase-1", "USD", 1200))
+	if err != nil {
+		t.Fatalf("issue invoice failed: %v", err)
+	}
+	// 12.00 including a combined 20%: 10.00 net and 2.00 tax, split evenly.
+	if invoice.TaxCents != 200 || invoice.SubtotalCents != 1000 {
+		t.Fatalf("unexpected totals: tax=%d subtotal=%d", invoice.TaxCents, invoice.SubtotalCents)
+	}
+	if len(invoice.TaxLines) != 2 || invoice.TaxLines[0].TaxCents != 100 || invoice.TaxLines[1].TaxCents != 100 {
+		t.Fatalf("unexpected tax lines %+v", invoice.TaxLines)
+	}
+	if invoice.LineItems[0].TaxCents != 200 {
+		t.Fatalf("expected line tax 200, got %d", invoice.LineItems[0].TaxCents)
+	}
+}
+
+func TestGetUserInvoiceIsOwnerScoped(t *testing.T) {
+	service := newTestService()
+	invoice, err := service.IssueInvoice(context.Background(), purchaseInput("purchase-1", "USD", 500))
+	if err != nil {
+		t.Fatalf("issue invoice failed: %v", err)
+	}
+
+	if _, err := service.GetUserInvoice(context.Background(), "user_2", invoice.InvoiceID); !errors.Is(err, domainerrors.ErrInvoiceNotFound) {
+		t.Fatalf("expected invoice not found for other user, got %v", err)
+	}
+	_, body, err := service.RenderUserInvoicePDF(context.Background(), "user_1", invoice.InvoiceID)
+	if err != nil {
+		t.Fatalf("render pdf failed: %v", err)
+	}
+	if !bytes.HasPrefix(body, []byte("%PDF-1.4")) {
+		t.Fatalf("expected pdf document, got %q", body[:16])
+	}
+}
+
+func TestIssueCreditNoteLeavesInvoiceUnchanged(t *testing.T) {
+	service := newTestService()
+	invoice, err := service.IssueInvoice(context.Background(), purchaseInput("purchase-1", "EUR", 1200))
+	if err != nil {
+		t.Fatalf("issue invoice failed: %v", err)
+	}
+	lineID := invoice.LineItems[0].LineItemID
+
+	note, err := service.IssueCreditNote(context.Background(), "idem-credit-1", ports.IssueCreditNoteInput{
+		ActorID:     "admin_1",
+		InvoiceID:   invoice.InvoiceID,
+		LineItemID:  lineID,
+		AmountCents: 600,
+		Reason:      "partial refund",
+	})
+	if err != nil {
+		t.Fatalf("issue credit note failed: %v", err)
+	}
+	if note.TaxCents != 100 || note.CreditNoteNumber != "CN-2026-000001" {
+		t.Fatalf("unexpected credit note: %+v", note)
+	}
+
+	replay, err := service.IssueCreditNote(context.Background(), "idem-credit-1", ports.IssueCreditNoteInput{
+		ActorID:     "admin_1",
+		InvoiceID:   invoice.InvoiceID,
+		LineItemID:  lineID,
+		AmountCents: 600,
+		Reason:      "partial refund",
+	})
+	if err != nil || replay.CreditNoteID != note.CreditNoteID {
+		t.Fatalf("expected idempotent replay, got %+v err=%v", replay, err)
+	}
+
+	_, err = service.IssueCreditNote(context.Background(), "idem-credit-2", ports.IssueCreditNoteInput{
+		ActorID:     "admin_1",
+		InvoiceID:   invoice.InvoiceID,
+		LineItemID:  lineID,
+		AmountCents: 601,
+		Reason:      "over refund",
+	})
+	if !errors.Is(err, domainerrors.ErrCreditExceedsRefundable) {
+		t.Fatalf("expected over-credit rejection, got %v", err)
+	}
+
+	document, err := service.GetUserInvoice(context.Background(), "user_1", invoice.InvoiceID)
+	if err != nil {
+		t.Fatalf("get invoice failed: %v", err)
+	}
+	if document.Invoice.TotalCents != 1200 || document.CreditedCents() != 600 {
+		t.Fatalf("expected immutable invoice with credit, got total=%d credited=%d",
+			document.Invoice.TotalCents, document.CreditedCents())
+	}
+}
+
+func newTestService() Service {
+	store := memory.NewStore()
+	return Service{
+		Repo:        store,
+		Idempotency: store,
+		TaxRates:    store,
+		Renderer:    pdfadapter.Renderer{},
+		Clock:       fixedClock{now: time.Date(2026, time.March, 4, 10, 0, 0, 0, time.UTC)},
+		IDGen:       store,
+	}
+}
+
+func purchaseInput(sourceID string, currency string, amountCents int64) ports.IssueInvoiceInput {
+	return ports.IssueInvoiceInput{
+		UserID:     "user_1",
+		SourceType: ports.SourceTypeProductPurchase,
+		SourceID:   sourceID,
+		Currency:   currency,
+		Lines: []ports.InvoiceLineInput{{
+			Description:     "Creator preset pack",
+			Quantity:        1,
+			UnitAmountCents: amountCents,
+			ReferenceType:   "product",
+			ReferenceID:     "prod_001",
+		}},
+	}
+}
+
+type stubTaxRates []ports.TaxRate
+
+func (r stubTaxRates) TaxRatesFor(context.Context, string, string) ([]ports.TaxRate, error) {
+	return r, nil
+}
+
+type fixedClock struct {
+	now time.Time
+}
+
+func (f fixedClock) Now() time.Time { return f.now }
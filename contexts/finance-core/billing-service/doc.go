@@ -0,0 +1,2 @@
+// Package billingservice implements the M05 Billing invoicing surface in Solomon.
+package billingservice
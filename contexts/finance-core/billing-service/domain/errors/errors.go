@@ -0,0 +1,17 @@
+package errors
+
+import "errors"
+
+var (
+	ErrInvalidRequest         = errors.New("invalid request")
+	ErrIdempotencyKeyRequired = errors.New("idempotency key is required")
+	ErrIdempotencyConflict    = errors.New("idempotency key reused with different request")
+	ErrNotFound               = errors.New("resource not found")
+	ErrConflict               = errors.New("conflict")
+	ErrForbidden              = errors.New("forbidden")
+
+	ErrInvoiceNotFound         = errors.New("invoice not found")
+	ErrLineItemNotFound        = errors.New("invoice line item not found")
+	ErrCreditExceedsRefundable = errors.New("credit amount exceeds refundable balance")
+	ErrUnsupportedFormat       = errors.New("unsupported invoice format")
+)
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LineAmount returns quantity * unit amount in minor units.
+func LineAmount(quantity int, unitAmountCents int64) int64 {
+	if quantity <= 0 {
+		return 0
+	}
+	return int64(quantity) * unitAmountCents
+}
+
+// InclusiveTax extracts the tax portion of a tax-inclusive gross amount.
+// Rates are expressed in basis points (2000 = 20%) and rounded half-up.
+func InclusiveTax(grossCents int64, rateBasisPoints int) int64 {
+	if grossCents <= 0 || rateBasisPoints <= 0 {
+		return 0
+	}
+	divisor := int64(10000 + rateBasisPoints)
+	net := (grossCents*10000 + divisor/2) / divisor
+	return grossCents - net
+}
+
+// InclusiveTaxes splits the tax inside a tax-inclusive gross across rates
+// that all apply to the same net amount, such as a state and a city sales
+// tax. The combined rate is extracted once and shared in proportion to each
+// rate, with the rounding remainder on the last rate, so the parts always
+// sum to the combined tax.
+func InclusiveTaxes(grossCents int64, ratesBasisPoints []int) []int64 {
+	taxes := make([]int64, len(ratesBasisPoints))
+	combined, last := 0, -1
+	for i, rate := range ratesBasisPoints {
+		if rate > 0 {
+			combined += rate
+			last = i
+		}
+	}
+	total := InclusiveTax(grossCents, combined)
+	if total == 0 {
+		return taxes
+	}
+	var allocated int64
+	for i, rate := range ratesBasisPoints {
+		if rate <= 0 {
+			continue
+		}
+		if i == last {
+			taxes[i] = total - allocated
+			break
+		}
+		taxes[i] = (total*int64(rate) + int64(combined)/2) / int64(combined)
+		allocated += taxes[i]
+	}
+	return taxes
+}
+
+// ProportionalCredit returns the share of a tax amount attributable to a
+// partial credit of a line, so credit notes reverse tax in proportion.
+func ProportionalCredit(taxCents int64, creditCents int64, lineCents int64) int64 {
+	if taxCents <= 0 || creditCents <= 0 || lineCents <= 0 {
+		return 0
+	}
+	if creditCents >= lineCents {
+		return taxCents
+	}
+	return (taxCents*creditCents + lineCents/2) / lineCents
+}
+
+// DocumentNumber formats a sequential, year-scoped document number such as
+// INV-2026-000042 or CN-2026-000003.
+func DocumentNumber(prefix string, issuedAt time.Time, sequence int64) string {
+	return fmt.Sprintf("%s-%04d-%06d", strings.ToUpper(strings.TrimSpace(prefix)), issuedAt.UTC().Year(), sequence)
+}
+
+// InvoiceNumber formats the number of the invoice with the given sequence.
+func InvoiceNumber(issuedAt time.Time, sequence int64) string {
+	return DocumentNumber("INV", issuedAt, sequence)
+}
+
+// CreditNoteNumber formats the number of the credit note with the given
+// sequence.
+func CreditNoteNumber(issuedAt time.Time, sequence int64) string {
+	return DocumentNumber("CN", issuedAt, sequence)
+}
+
+// NormalizeCurrency upper-cases ISO 4217 codes and rejects anything else.
+func NormalizeCurrency(currency string) (string, bool) {
+	value := strings.ToUpper(strings.TrimSpace(currency))
+	if len(value) != 3 {
+		return "", false
+	}
+	for _, r := range value {
+		if r < 'A' || r > 'Z' {
+			return "", false
+		}
+	}
+	return value, true
+}
@@ -0,0 +1,63 @@
+package billingservice
+
+import (
+	"log/slog"
+	"time"
+
+	httpadapter "solomon/contexts/finance-core/billing-service/adapters/http"
+	"solomon/contexts/finance-core/billing-service/adapters/memory"
+	pdfadapter "solomon/contexts/finance-core/billing-service/adapters/pdf"
+	"solomon/contexts/finance-core/billing-service/application"
+	"solomon/contexts/finance-core/billing-service/ports"
+)
+
+type Module struct {
+	Handler httpadapter.Handler
+	Store   *memory.Store
+}
+
+type Dependencies struct {
+	Repository     ports.Repository
+	Idempotency    ports.IdempotencyStore
+	TaxRates       ports.TaxRateProvider
+	Renderer       ports.InvoiceRenderer
+	Clock          ports.Clock
+	IDGenerator    ports.IDGenerator
+	IdempotencyTTL time.Duration
+	Logger         *slog.Logger
+}
+
+func NewModule(deps Dependencies) Module {
+	service := application.Service{
+		Repo:           deps.Repository,
+		Idempotency:    deps.Idempotency,
+		TaxRates:       deps.TaxRates,
+		Renderer:       deps.Renderer,
+		Clock:          deps.Clock,
+		IDGen:          deps.IDGenerator,
+		Logger:         deps.Logger,
+		IdempotencyTTL: deps.IdempotencyTTL,
+	}
+	return Module{
+		Handler: httpadapter.Handler{
+			Service: service,
+			Logger:  deps.Logger,
+		},
+	}
+}
+
+func NewInMemoryModule(logger *slog.Logger) Module {
+	store := memory.NewStore()
+	module := NewModule(Dependencies{
+		Repository:     store,
+		Idempotency:    store,
+		TaxRates:       store,
+		Renderer:       pdfadapter.Renderer{},
+		Clock:          store,
+		IDGenerator:    store,
+		IdempotencyTTL: 7 * 24 * time.Hour,
+		Logger:         logger,
+	})
+	module.Store = store
+	return module
+}
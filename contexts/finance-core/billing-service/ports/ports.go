@@ -0,0 +1,143 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+type Clock interface {
+	Now() time.Time
+}
+
+type IDGenerator interface {
+	NewID(ctx context.Context) (string, error)
+}
+
+type IdempotencyRecord struct {
+	Key         string
+	RequestHash string
+	Payload     []byte
+	ExpiresAt   time.Time
+}
+
+type IdempotencyStore interface {
+	Get(ctx context.Context, key string, now time.Time) (IdempotencyRecord, bool, error)
+	Put(ctx context.Context, record IdempotencyRecord) error
+}
+
+const (
+	SourceTypeProductPurchase     = "product_purchase"
+	SourceTypeSubscriptionCharge  = "subscription_charge"
+	SourceTypeSubscriptionProrate = "subscription_proration"
+
+	DocumentKindInvoice    = "invoice"
+	DocumentKindCreditNote = "credit_note"
+)
+
+type InvoiceLineItem struct {
+	LineItemID      string
+	Description     string
+	Quantity        int
+	UnitAmountCents int64
+	AmountCents     int64
+	TaxCents        int64
+	ReferenceType   string
+	ReferenceID     string
+}
+
+type InvoiceTaxLine struct {
+	TaxCode         string
+	Name            string
+	RateBasisPoints int
+	Inclusive       bool
+	TaxableCents    int64
+	TaxCents        int64
+}
+
+// Invoice is immutable once issued. Refunds never mutate it; they are
+// recorded as CreditNote documents referencing the invoice.
+type Invoice struct {
+	InvoiceID     string
+	InvoiceNumber string
+	UserID        string
+	SourceType    string
+	SourceID      string
+	Currency      string
+	LineItems     []InvoiceLineItem
+	TaxLines      []InvoiceTaxLine
+	SubtotalCents int64
+	TaxCents      int64
+	TotalCents    int64
+	IssuedAt      time.Time
+}
+
+type CreditNote struct {
+	CreditNoteID     string
+	CreditNoteNumber string
+	InvoiceID        string
+	InvoiceNumber    string
+	UserID           string
+	LineItemID       string
+	Currency         string
+	AmountCents      int64
+	TaxCents         int64
+	Reason           string
+	IssuedBy         string
+	IssuedAt         time.Time
+}
+
+type InvoiceLineInput struct {
+	Description     string
+	Quantity        int
+	UnitAmountCents int64
+	ReferenceType   string
+	ReferenceID     string
+}
+
+type IssueInvoiceInput struct {
+	UserID     string
+	SourceType string
+	SourceID   string
+	Currency   string
+	Lines      []InvoiceLineInput
+}
+
+type IssueCreditNoteInput struct {
+	ActorID     string
+	InvoiceID   string
+	LineItemID  string
+	AmountCents int64
+	Reason      string
+}
+
+type TaxRate struct {
+	TaxCode         string
+	Name            string
+	RateBasisPoints int
+}
+
+// TaxRateProvider resolves the tax-inclusive rates applied to a charge.
+type TaxRateProvider interface {
+	TaxRatesFor(ctx context.Context, userID string, currency string) ([]TaxRate, error)
+}
+
+// InvoiceRenderer produces downloadable invoice documents.
+type InvoiceRenderer interface {
+	RenderPDF(invoice Invoice, creditNotes []CreditNote) ([]byte, error)
+}
+
+type Repository interface {
+	// CreateInvoice numbers the invoice from its year's invoice sequence in
+	// the transaction that stores it, so a failed write never consumes a
+	// number. It returns the existing invoice when the source was already
+	// invoiced.
+	CreateInvoice(ctx context.Context, invoice Invoice) (Invoice, error)
+	GetInvoice(ctx context.Context, invoiceID string) (Invoice, error)
+	GetInvoiceBySource(ctx context.Context, sourceType string, sourceID string) (Invoice, bool, error)
+	ListInvoicesByUser(ctx context.Context, userID string, limit int, offset int) ([]Invoice, int, error)
+	// CreateCreditNote numbers the note from its year's credit note sequence
+	// in the transaction that stores it, and must reject notes that would
+	// credit a line beyond its amount.
+	CreateCreditNote(ctx context.Context, note CreditNote) (CreditNote, error)
+	ListCreditNotesByInvoice(ctx context.Context, invoiceID string) ([]CreditNote, error)
+}
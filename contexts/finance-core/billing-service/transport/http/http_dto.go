@@ -0,0 +1,119 @@
+package http
+
+type ErrorResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type InvoiceLineItemDTO struct {
+	LineItemID      string `json:"line_item_id"`
+	Description     string `json:"description"`
+	Quantity        int    `json:"quantity"`
+	UnitAmountCents int64  `json:"unit_amount_cents"`
+	AmountCents     int64  `json:"amount_cents"`
+	TaxCents        int64  `json:"tax_cents"`
+	ReferenceType   string `json:"reference_type,omitempty"`
+	ReferenceID     string `json:"reference_id,omitempty"`
+}
+
+type InvoiceTaxLineDTO struct {
+	TaxCode         string `json:"tax_code"`
+	Name            string `json:"name"`
+	RateBasisPoints int    `json:"rate_basis_points"`
+	Inclusive       bool   `json:"inclusive"`
+	TaxableCents    int64  `json:"taxable_cents"`
+	TaxCents        int64  `json:"tax_cents"`
+}
+
+type CreditNoteDTO struct {
+	CreditNoteID     string `json:"credit_note_id"`
+	CreditNoteNumber string `json:"credit_note_number"`
+	InvoiceID        string `json:"invoice_id"`
+	InvoiceNumber    string `json:"invoice_number"`
+	LineItemID       string `json:"line_item_id"`
+	Currency         string `json:"currency"`
+	AmountCents      int64  `json:"amount_cents"`
+	TaxCents         int64  `json:"tax_cents"`
+	Reason           string `json:"reason"`
+	IssuedBy         string `json:"issued_by"`
+	IssuedAt         string `json:"issued_at"`
+}
+
+type InvoiceSummaryDTO struct {
+	InvoiceID     string `json:"invoice_id"`
+	InvoiceNumber string `json:"invoice_number"`
+	SourceType    string `json:"source_type"`
+	SourceID      string `json:"source_id"`
+	Currency      string `json:"currency"`
+	TotalCents    int64  `json:"total_cents"`
+	IssuedAt      string `json:"issued_at"`
+}
+
+type InvoiceDTO struct {
+	InvoiceID     string               `json:"invoice_id"`
+	InvoiceNumber string               `json:"invoice_number"`
+	UserID        string               `json:"user_id"`
+	SourceType    string               `json:"source_type"`
+	SourceID      string               `json:"source_id"`
+	Currency      string               `json:"currency"`
+	LineItems     []InvoiceLineItemDTO `json:"line_items"`
+	TaxLines      []InvoiceTaxLineDTO  `json:"tax_lines"`
+	SubtotalCents int64                `json:"subtotal_cents"`
+	TaxCents      int64                `json:"tax_cents"`
+	TotalCents    int64                `json:"total_cents"`
+	CreditedCents int64                `json:"credited_cents"`
+	CreditNotes   []CreditNoteDTO      `json:"credit_notes"`
+	IssuedAt      string               `json:"issued_at"`
+}
+
+type IssueInvoiceLineRequest struct {
+	Description     string `json:"description"`
+	Quantity        int    `json:"quantity"`
+	UnitAmountCents int64  `json:"unit_amount_cents"`
+	ReferenceType   string `json:"reference_type,omitempty"`
+	ReferenceID     string `json:"reference_id,omitempty"`
+}
+
+type IssueInvoiceRequest struct {
+	UserID     string                    `json:"user_id"`
+	SourceType string                    `json:"source_type"`
+	SourceID   string                    `json:"source_id"`
+	Currency   string                    `json:"currency"`
+	Lines      []IssueInvoiceLineRequest `json:"lines"`
+}
+
+type ListInvoicesRequest struct {
+	Limit  int
+	Offset int
+}
+
+type ListInvoicesResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		Invoices []InvoiceSummaryDTO `json:"invoices"`
+		Total    int                 `json:"total"`
+		Limit    int                 `json:"limit"`
+		Offset   int                 `json:"offset"`
+	} `json:"data"`
+}
+
+type GetInvoiceResponse struct {
+	Status string     `json:"status"`
+	Data   InvoiceDTO `json:"data"`
+}
+
+type InvoicePDF struct {
+	FileName string
+	Body     []byte
+}
+
+type CreateCreditNoteRequest struct {
+	LineItemID  string `json:"line_item_id"`
+	AmountCents int64  `json:"amount_cents"`
+	Reason      string `json:"reason"`
+}
+
+type CreateCreditNoteResponse struct {
+	Status string        `json:"status"`
+	Data   CreditNoteDTO `json:"data"`
+}
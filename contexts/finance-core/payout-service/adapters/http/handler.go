@@ -0,0 +1,60 @@
+package httpadapter
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"solomon/contexts/finance-core/payout-service/application"
+	"solomon/contexts/finance-core/payout-service/ports"
+	httptransport "solomon/contexts/finance-core/payout-service/transport/http"
+)
+
+type Handler struct {
+	Service application.Service
+	Logger  *slog.Logger
+}
+
+func (h Handler) GetPayoutHandler(ctx context.Context, payoutID string) (httptransport.PayoutResponse, error) {
+	payout, err := h.Service.GetPayout(ctx, payoutID)
+	if err != nil {
+		return httptransport.PayoutResponse{}, err
+	}
+	return toPayoutResponse(payout), nil
+}
+
+func (h Handler) RetryPayoutHandler(
+	ctx context.Context,
+	adminID string,
+	payoutID string,
+	req httptransport.RetryPayoutRequest,
+) (httptransport.PayoutResponse, error) {
+	payout, err := h.Service.RetryPayout(ctx, adminID, payoutID, req.Reason)
+	if err != nil {
+		return httptransport.PayoutResponse{}, err
+	}
+	return toPayoutResponse(payout), nil
+}
+
+func toPayoutResponse(payout ports.Payout) httptransport.PayoutResponse {
+	resp := httptransport.PayoutResponse{
+		PayoutID:          payout.PayoutID,
+		CreatorID:         payout.CreatorID,
+		AmountCents:       payout.AmountCents,
+		Currency:          payout.Currency,
+		Status:            payout.Status,
+		SubmissionIDs:     append([]string{}, payout.SubmissionIDs...),
+		Attempts:          payout.Attempts,
+		LastError:         payout.LastError,
+		ProviderReference: payout.ProviderReference,
+		CreatedAt:         payout.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:         payout.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+	if payout.NextAttemptAt != nil {
+		resp.NextAttemptAt = payout.NextAttemptAt.UTC().Format(time.RFC3339)
+	}
+	if payout.PaidAt != nil {
+		resp.PaidAt = payout.PaidAt.UTC().Format(time.RFC3339)
+	}
+	return resp
+}
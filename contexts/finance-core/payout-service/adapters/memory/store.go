@@ -0,0 +1,190 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	domainerrors "solomon/contexts/finance-core/payout-service/domain/errors"
+	"solomon/contexts/finance-core/payout-service/ports"
+)
+
+type Store struct {
+	mu       sync.RWMutex
+	earnings map[string]ports.Earning
+	payouts  map[string]ports.Payout
+	sequence uint64
+}
+
+func NewStore() *Store {
+	store := &Store{
+		earnings: make(map[string]ports.Earning),
+		payouts:  make(map[string]ports.Payout),
+	}
+
+	// A payout whose retries ran out, so local admin retry flows have
+	// something to act on.
+	failedAt := time.Now().UTC().Add(-24 * time.Hour)
+	store.earnings["submission-seed-1"] = ports.Earning{
+		SubmissionID: "submission-seed-1",
+		CreatorID:    "user-1",
+		CampaignID:   "campaign-seed-1",
+		AmountCents:  4200,
+		LockedAt:     failedAt.Add(-8 * 24 * time.Hour),
+		PayoutID:     "pay-1",
+		RecordedAt:   failedAt.Add(-time.Hour),
+	}
+	store.payouts["pay-1"] = ports.Payout{
+		PayoutID:      "pay-1",
+		CreatorID:     "user-1",
+		AmountCents:   4200,
+		Currency:      "USD",
+		Status:        ports.PayoutStatusFailed,
+		SubmissionIDs: []string{"submission-seed-1"},
+		Attempts:      6,
+		LastError:     "provider unavailable",
+		CreatedAt:     failedAt.Add(-time.Hour),
+		UpdatedAt:     failedAt,
+	}
+	store.sequence = 1
+	return store
+}
+
+func (s *Store) RecordEarning(_ context.Context, earning ports.Earning) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.earnings[earning.SubmissionID]; exists {
+		return nil
+	}
+	earning.PayoutID = ""
+	s.earnings[earning.SubmissionID] = earning
+	return nil
+}
+
+func (s *Store) ListBatchableCreators(
+	_ context.Context,
+	minimumCents int64,
+	afterCreatorID string,
+	limit int,
+) ([]ports.CreatorEarnings, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	totals := make(map[string]int64)
+	for _, earning := range s.earnings {
+		if earning.PayoutID == "" && earning.CreatorID > afterCreatorID {
+			totals[earning.CreatorID] += earning.AmountCents
+		}
+	}
+	items := make([]ports.CreatorEarnings, 0, len(totals))
+	for creatorID, total := range totals {
+		if total >= minimumCents {
+			items = append(items, ports.CreatorEarnings{CreatorID: creatorID, TotalCents: total})
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].CreatorID < items[j].CreatorID
+	})
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+func (s *Store) ListUnbatchedEarnings(_ context.Context, creatorID string) ([]ports.Earning, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	items := make([]ports.Earning, 0)
+	for _, earning := range s.earnings {
+		if earning.PayoutID == "" && earning.CreatorID == creatorID {
+			items = append(items, earning)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].LockedAt.Before(items[j].LockedAt)
+	})
+	return items, nil
+}
+
+func (s *Store) CreatePayout(_ context.Context, payout ports.Payout) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, submissionID := range payout.SubmissionIDs {
+		earning, ok := s.earnings[submissionID]
+		if !ok || earning.PayoutID != "" {
+			return domainerrors.ErrEarningAlreadyBatched
+		}
+	}
+	for _, submissionID := range payout.SubmissionIDs {
+		earning := s.earnings[submissionID]
+		earning.PayoutID = payout.PayoutID
+		s.earnings[submissionID] = earning
+	}
+	s.payouts[payout.PayoutID] = clonePayout(payout)
+	return nil
+}
+
+func (s *Store) GetPayout(_ context.Context, payoutID string) (ports.Payout, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	payout, ok := s.payouts[payoutID]
+	if !ok {
+		return ports.Payout{}, domainerrors.ErrPayoutNotFound
+	}
+	return clonePayout(payout), nil
+}
+
+func (s *Store) ListDuePayouts(_ context.Context, now time.Time, limit int) ([]ports.Payout, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	items := make([]ports.Payout, 0)
+	for _, payout := range s.payouts {
+		if payout.Status == ports.PayoutStatusPaid || payout.NextAttemptAt == nil || payout.NextAttemptAt.After(now) {
+			continue
+		}
+		items = append(items, clonePayout(payout))
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].NextAttemptAt.Before(*items[j].NextAttemptAt)
+	})
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+func (s *Store) UpdatePayout(_ context.Context, payout ports.Payout) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.payouts[payout.PayoutID]; !ok {
+		return domainerrors.ErrPayoutNotFound
+	}
+	s.payouts[payout.PayoutID] = clonePayout(payout)
+	return nil
+}
+
+func (s *Store) NewID(_ context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sequence++
+	return fmt.Sprintf("pay-%d", s.sequence), nil
+}
+
+func (s *Store) Now() time.Time {
+	return time.Now().UTC()
+}
+
+func clonePayout(in ports.Payout) ports.Payout {
+	out := in
+	out.SubmissionIDs = append([]string(nil), in.SubmissionIDs...)
+	if in.NextAttemptAt != nil {
+		next := *in.NextAttemptAt
+		out.NextAttemptAt = &next
+	}
+	if in.PaidAt != nil {
+		paidAt := *in.PaidAt
+		out.PaidAt = &paidAt
+	}
+	return out
+}
@@ -0,0 +1,278 @@
+package postgresadapter
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	domainerrors "solomon/contexts/finance-core/payout-service/domain/errors"
+	"solomon/contexts/finance-core/payout-service/ports"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository persists recorded earnings and payout batches. An earning joins
+// a payout through a conditional update, so two workers batching the same
+// earnings cannot both succeed.
+type Repository struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+// NewRepository builds the GORM-backed payout adapter.
+func NewRepository(db *gorm.DB, logger *slog.Logger) *Repository {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Repository{db: db, logger: logger}
+}
+
+func (r *Repository) RecordEarning(ctx context.Context, earning ports.Earning) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&earningModel{
+		SubmissionID: earning.SubmissionID,
+		CreatorID:    earning.CreatorID,
+		CampaignID:   earning.CampaignID,
+		AmountCents:  earning.AmountCents,
+		LockedAt:     earning.LockedAt.UTC(),
+		RecordedAt:   earning.RecordedAt.UTC(),
+	}).Error
+}
+
+// ListBatchableCreators totals unbatched earnings per creator in SQL, so a
+// page holds whole creators and never splits one creator's earnings.
+func (r *Repository) ListBatchableCreators(
+	ctx context.Context,
+	minimumCents int64,
+	afterCreatorID string,
+	limit int,
+) ([]ports.CreatorEarnings, error) {
+	var rows []struct {
+		CreatorID  string
+		TotalCents int64
+	}
+	if err := r.db.WithContext(ctx).
+		Model(&earningModel{}).
+		Select("creator_id, SUM(amount_cents) AS total_cents").
+		Where("payout_id IS NULL").
+		Where("creator_id > ?", afterCreatorID).
+		Group("creator_id").
+		Having("SUM(amount_cents) >= ?", minimumCents).
+		Order("creator_id ASC").
+		Limit(limit).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	items := make([]ports.CreatorEarnings, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, ports.CreatorEarnings{CreatorID: row.CreatorID, TotalCents: row.TotalCents})
+	}
+	return items, nil
+}
+
+func (r *Repository) ListUnbatchedEarnings(ctx context.Context, creatorID string) ([]ports.Earning, error) {
+	var rows []earningModel
+	if err := r.db.WithContext(ctx).
+		Where("payout_id IS NULL").
+		Where("creator_id = ?", creatorID).
+		Order("locked_at ASC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	items := make([]ports.Earning, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, row.toPort())
+	}
+	return items, nil
+}
+
+func (r *Repository) CreatePayout(ctx context.Context, payout ports.Payout) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&payoutModel{
+			PayoutID:       payout.PayoutID,
+			CreatorID:      payout.CreatorID,
+			AmountCents:    payout.AmountCents,
+			Currency:       payout.Currency,
+			Status:         payout.Status,
+			Attempts:       payout.Attempts,
+			NextAttemptAt:  utcPtr(payout.NextAttemptAt),
+			LedgerDebited:  payout.LedgerDebited,
+			LedgerReleases: payout.LedgerReleases,
+			CreatedAt:      payout.CreatedAt.UTC(),
+			UpdatedAt:      payout.UpdatedAt.UTC(),
+		}).Error; err != nil {
+			return err
+		}
+		result := tx.Model(&earningModel{}).
+			Where("submission_id IN ?", payout.SubmissionIDs).
+			Where("payout_id IS NULL").
+			Update("payout_id", payout.PayoutID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != int64(len(payout.SubmissionIDs)) {
+			return domainerrors.ErrEarningAlreadyBatched
+		}
+		return nil
+	})
+}
+
+func (r *Repository) GetPayout(ctx context.Context, payoutID string) (ports.Payout, error) {
+	db := r.db.WithContext(ctx)
+	var row payoutModel
+	err := db.Where("payout_id = ?", payoutID).First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ports.Payout{}, domainerrors.ErrPayoutNotFound
+	}
+	if err != nil {
+		return ports.Payout{}, err
+	}
+	items, err := withSubmissions(db, []payoutModel{row})
+	if err != nil {
+		return ports.Payout{}, err
+	}
+	return items[0], nil
+}
+
+func (r *Repository) ListDuePayouts(ctx context.Context, now time.Time, limit int) ([]ports.Payout, error) {
+	db := r.db.WithContext(ctx)
+	var rows []payoutModel
+	if err := db.
+		Where("status <> ?", ports.PayoutStatusPaid).
+		Where("next_attempt_at IS NOT NULL AND next_attempt_at <= ?", now.UTC()).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return withSubmissions(db, rows)
+}
+
+func (r *Repository) UpdatePayout(ctx context.Context, payout ports.Payout) error {
+	result := r.db.WithContext(ctx).
+		Model(&payoutModel{}).
+		Where("payout_id = ?", payout.PayoutID).
+		Updates(map[string]any{
+			"status":             payout.Status,
+			"attempts":           payout.Attempts,
+			"next_attempt_at":    utcPtr(payout.NextAttemptAt),
+			"last_error":         payout.LastError,
+			"provider_reference": payout.ProviderReference,
+			"ledger_debited":     payout.LedgerDebited,
+			"ledger_releases":    payout.LedgerReleases,
+			"updated_at":         payout.UpdatedAt.UTC(),
+			"paid_at":            utcPtr(payout.PaidAt),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.ErrPayoutNotFound
+	}
+	return nil
+}
+
+func withSubmissions(db *gorm.DB, rows []payoutModel) ([]ports.Payout, error) {
+	if len(rows) == 0 {
+		return []ports.Payout{}, nil
+	}
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.PayoutID)
+	}
+	var earnings []earningModel
+	if err := db.Where("payout_id IN ?", ids).Order("locked_at ASC").Find(&earnings).Error; err != nil {
+		return nil, err
+	}
+	byPayout := make(map[string][]string, len(rows))
+	for _, earning := range earnings {
+		if earning.PayoutID != nil {
+			byPayout[*earning.PayoutID] = append(byPayout[*earning.PayoutID], earning.SubmissionID)
+		}
+	}
+	items := make([]ports.Payout, 0, len(rows))
+	for _, row := range rows {
+		payout := row.toPort()
+		payout.SubmissionIDs = byPayout[row.PayoutID]
+		items = append(items, payout)
+	}
+	return items, nil
+}
+
+func utcPtr(value *time.Time) *time.Time {
+	if value == nil {
+		return nil
+	}
+	utc := value.UTC()
+	return &utc
+}
+
+type earningModel struct {
+	SubmissionID string    `gorm:"column:submission_id;primaryKey"`
+	CreatorID    string    `gorm:"column:creator_id"`
+	CampaignID   string    `gorm:"column:campaign_id"`
+	AmountCents  int64     `gorm:"column:amount_cents"`
+	LockedAt     time.Time `gorm:"column:locked_at"`
+	PayoutID     *string   `gorm:"column:payout_id"`
+	RecordedAt   time.Time `gorm:"column:recorded_at"`
+}
+
+func (earningModel) TableName() string {
+	return "payout_earnings"
+}
+
+func (m earningModel) toPort() ports.Earning {
+	earning := ports.Earning{
+		SubmissionID: m.SubmissionID,
+		CreatorID:    m.CreatorID,
+		CampaignID:   m.CampaignID,
+		AmountCents:  m.AmountCents,
+		LockedAt:     m.LockedAt.UTC(),
+		RecordedAt:   m.RecordedAt.UTC(),
+	}
+	if m.PayoutID != nil {
+		earning.PayoutID = *m.PayoutID
+	}
+	return earning
+}
+
+type payoutModel struct {
+	PayoutID          string     `gorm:"column:payout_id;primaryKey"`
+	CreatorID         string     `gorm:"column:creator_id"`
+	AmountCents       int64      `gorm:"column:amount_cents"`
+	Currency          string     `gorm:"column:currency"`
+	Status            string     `gorm:"column:status"`
+	Attempts          int        `gorm:"column:attempts"`
+	NextAttemptAt     *time.Time `gorm:"column:next_attempt_at"`
+	LastError         string     `gorm:"column:last_error"`
+	ProviderReference string     `gorm:"column:provider_reference"`
+	LedgerDebited     bool       `gorm:"column:ledger_debited"`
+	LedgerReleases    int        `gorm:"column:ledger_releases"`
+	CreatedAt         time.Time  `gorm:"column:created_at"`
+	UpdatedAt         time.Time  `gorm:"column:updated_at"`
+	PaidAt            *time.Time `gorm:"column:paid_at"`
+}
+
+func (payoutModel) TableName() string {
+	return "payouts"
+}
+
+func (m payoutModel) toPort() ports.Payout {
+	return ports.Payout{
+		PayoutID:          m.PayoutID,
+		CreatorID:         m.CreatorID,
+		AmountCents:       m.AmountCents,
+		Currency:          m.Currency,
+		Status:            m.Status,
+		Attempts:          m.Attempts,
+		NextAttemptAt:     utcPtr(m.NextAttemptAt),
+		LastError:         m.LastError,
+		ProviderReference: m.ProviderReference,
+		LedgerDebited:     m.LedgerDebited,
+		LedgerReleases:    m.LedgerReleases,
+		CreatedAt:         m.CreatedAt.UTC(),
+		UpdatedAt:         m.UpdatedAt.UTC(),
+		PaidAt:            utcPtr(m.PaidAt),
+	}
+}
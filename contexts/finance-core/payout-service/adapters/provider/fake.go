@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"solomon/contexts/finance-core/payout-service/ports"
+)
+
+// Fake is an in-process payout provider. It accepts every transfer unless
+// Fail returns an error for it, and replays accepted transfers by
+// idempotency key the way a real provider would.
+type Fake struct {
+	Fail func(req ports.TransferRequest) error
+
+	mu        sync.Mutex
+	transfers map[string]ports.TransferRequest
+}
+
+func NewFake() *Fake {
+	return &Fake{transfers: make(map[string]ports.TransferRequest)}
+}
+
+func (f *Fake) Transfer(_ context.Context, req ports.TransferRequest) (ports.TransferResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.transfers == nil {
+		f.transfers = make(map[string]ports.TransferRequest)
+	}
+	key := strings.TrimSpace(req.IdempotencyKey)
+	if _, ok := f.transfers[key]; ok {
+		return ports.TransferResult{ProviderReference: "fake_" + key}, nil
+	}
+	if f.Fail != nil {
+		if err := f.Fail(req); err != nil {
+			return ports.TransferResult{}, err
+		}
+	}
+	f.transfers[key] = req
+	return ports.TransferResult{ProviderReference: "fake_" + key}, nil
+}
+
+// Transfers returns the accepted transfers.
+func (f *Fake) Transfers() []ports.TransferRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	items := make([]ports.TransferRequest, 0, len(f.transfers))
+	for _, req := range f.transfers {
+		items = append(items, req)
+	}
+	return items
+}
@@ -0,0 +1,473 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	domainerrors "solomon/contexts/finance-core/payout-service/domain/errors"
+	"solomon/contexts/finance-core/payout-service/domain/services"
+	"solomon/contexts/finance-core/payout-service/ports"
+)
+
+const (
+	defaultMinimumPayoutCents  = 2000
+	defaultHoldPeriod          = 7 * 24 * time.Hour
+	defaultMaxAttempts         = 6
+	defaultRetryBaseDelay      = 5 * time.Minute
+	defaultRetryMaxDelay       = 6 * time.Hour
+	defaultHoldRecheckInterval = time.Hour
+	defaultCurrency            = "USD"
+	defaultBatchSize           = 500
+)
+
+// Service batches creators' matured earnings into payouts and settles them
+// through the payout provider. Zero-valued settings fall back to the
+// defaults above.
+type Service struct {
+	Repo       ports.Repository
+	Earnings   ports.EarningsSource
+	Provider   ports.PayoutProvider
+	Ledger     ports.SettlementLedger
+	LegalHolds ports.LegalHoldChecker
+	Clock      ports.Clock
+	IDGen      ports.IDGenerator
+
+	// MinimumPayoutCents is the smallest total a creator's batch may have;
+	// smaller totals keep accumulating.
+	MinimumPayoutCents int64
+	// HoldPeriod is how long earnings stay view-locked before they become
+	// reward_eligible and can be batched.
+	HoldPeriod          time.Duration
+	MaxAttempts         int
+	RetryBaseDelay      time.Duration
+	RetryMaxDelay       time.Duration
+	HoldRecheckInterval time.Duration
+	Currency            string
+	BatchSize           int
+	Logger              *slog.Logger
+}
+
+type SettlementReport struct {
+	Paid   int
+	Failed int
+	Held   int
+}
+
+// RunCycle collects matured earnings, batches them and settles due payouts.
+func (s Service) RunCycle(ctx context.Context) error {
+	if _, err := s.CollectEarnings(ctx); err != nil {
+		return err
+	}
+	if _, err := s.BuildBatches(ctx); err != nil {
+		return err
+	}
+	_, err := s.SettleDuePayouts(ctx)
+	return err
+}
+
+// CollectEarnings records the earnings of submissions view-locked for at
+// least the hold period and marks those submissions reward_eligible.
+func (s Service) CollectEarnings(ctx context.Context) (int, error) {
+	now := s.now()
+	items, err := s.Earnings.ListMaturedEarnings(ctx, now.Add(-s.holdPeriod()), s.batchSize())
+	if err != nil {
+		return 0, err
+	}
+	for _, earning := range items {
+		earning.RecordedAt = now
+		// Record before marking so a crash in between leaves the earning
+		// recorded; the submission is listed again and re-recording is a
+		// no-op.
+		if err := s.Repo.RecordEarning(ctx, earning); err != nil {
+			return 0, err
+		}
+		if err := s.Earnings.MarkRewardEligible(ctx, earning.SubmissionID); err != nil {
+			return 0, err
+		}
+	}
+	return len(items), nil
+}
+
+// BuildBatches groups each creator's unbatched earnings into a pending
+// payout. Creators below the minimum keep accumulating, and creators under a
+// legal hold are skipped until the hold is released. Creators are paged by
+// ID, so skipped creators never crowd out the ones after them.
+func (s Service) BuildBatches(ctx context.Context) ([]ports.Payout, error) {
+	created := make([]ports.Payout, 0)
+	after := ""
+	for {
+		creators, err := s.Repo.ListBatchableCreators(ctx, s.minimumPayoutCents(), after, s.batchSize())
+		if err != nil {
+			return created, err
+		}
+		for _, creator := range creators {
+			if s.isHeld(ctx, creator.CreatorID) {
+				continue
+			}
+			payout, ok, err := s.batchCreator(ctx, creator.CreatorID)
+			if err != nil {
+				return created, err
+			}
+			if ok {
+				created = append(created, payout)
+			}
+		}
+		if len(creators) < s.batchSize() {
+			return created, nil
+		}
+		after = creators[len(creators)-1].CreatorID
+	}
+}
+
+// batchCreator turns all of a creator's unbatched earnings into one payout.
+func (s Service) batchCreator(ctx context.Context, creatorID string) (ports.Payout, bool, error) {
+	earnings, err := s.Repo.ListUnbatchedEarnings(ctx, creatorID)
+	if err != nil {
+		return ports.Payout{}, false, err
+	}
+	var total int64
+	submissionIDs := make([]string, 0, len(earnings))
+	for _, earning := range earnings {
+		total += earning.AmountCents
+		submissionIDs = append(submissionIDs, earning.SubmissionID)
+	}
+	// Another worker may have batched some of them since the page was read.
+	if total < s.minimumPayoutCents() {
+		return ports.Payout{}, false, nil
+	}
+
+	payoutID, err := s.IDGen.NewID(ctx)
+	if err != nil {
+		return ports.Payout{}, false, err
+	}
+	now := s.now()
+	payout := ports.Payout{
+		PayoutID:      payoutID,
+		CreatorID:     creatorID,
+		AmountCents:   total,
+		Currency:      s.currency(),
+		Status:        ports.PayoutStatusPending,
+		SubmissionIDs: submissionIDs,
+		NextAttemptAt: &now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := s.Repo.CreatePayout(ctx, payout); err != nil {
+		if errors.Is(err, domainerrors.ErrEarningAlreadyBatched) {
+			// Another worker batched these earnings first.
+			return ports.Payout{}, false, nil
+		}
+		return ports.Payout{}, false, err
+	}
+	resolveLogger(s.Logger).Info("payout batch created",
+		"event", "payout_batch_created",
+		"module", "finance-core/payout-service",
+		"layer", "application",
+		"payout_id", payout.PayoutID,
+		"creator_id", creatorID,
+		"amount_cents", total,
+		"submission_count", len(submissionIDs),
+	)
+	return payout, true, nil
+}
+
+// SettleDuePayouts attempts the transfer of every due payout. Each payout
+// is debited from the creator's wallet in the ledger before its transfer is
+// sent, and its submissions are marked paid once the transfer succeeds.
+// Failures back off exponentially until MaxAttempts, after which the ledger
+// debit is reversed and the payout waits for an admin retry. A payout that
+// cannot be settled is logged and skipped so it never blocks the others.
+func (s Service) SettleDuePayouts(ctx context.Context) (SettlementReport, error) {
+	report := SettlementReport{}
+	if s.Provider == nil {
+		return report, domainerrors.ErrProviderUnavailable
+	}
+	if s.Ledger == nil {
+		return report, domainerrors.ErrLedgerUnavailable
+	}
+	due, err := s.Repo.ListDuePayouts(ctx, s.now(), s.batchSize())
+	if err != nil {
+		return report, err
+	}
+	for _, payout := range due {
+		status, err := s.settle(ctx, payout)
+		if err != nil {
+			resolveLogger(s.Logger).Error("payout settlement failed",
+				"event", "payout_settlement_failed",
+				"module", "finance-core/payout-service",
+				"layer", "application",
+				"payout_id", payout.PayoutID,
+				"error", err.Error(),
+			)
+			report.Failed++
+			continue
+		}
+		switch status {
+		case ports.PayoutStatusPaid:
+			report.Paid++
+		case ports.PayoutStatusHeld:
+			report.Held++
+		case ports.PayoutStatusFailed:
+			report.Failed++
+		}
+	}
+	return report, nil
+}
+
+func (s Service) settle(ctx context.Context, payout ports.Payout) (string, error) {
+	logger := resolveLogger(s.Logger)
+	now := s.now()
+	if s.isHeld(ctx, payout.CreatorID) {
+		next := now.Add(s.holdRecheckInterval())
+		payout.Status = ports.PayoutStatusHeld
+		payout.NextAttemptAt = &next
+		payout.UpdatedAt = now
+		return payout.Status, s.Repo.UpdatePayout(ctx, payout)
+	}
+
+	// A reservation left behind by an exhausted payout whose reversal
+	// failed is released before anything is sent again.
+	if payout.LedgerDebited && payout.Attempts >= s.maxAttempts() {
+		return s.release(ctx, payout, now)
+	}
+
+	// Reserve the payout in the ledger and record that before the transfer,
+	// so money only leaves once the wallet is debited. Reposting the same
+	// reservation after a crash is a no-op.
+	if !payout.LedgerDebited {
+		if err := s.Ledger.PostPayoutSettlement(ctx, payout); err != nil {
+			payout = s.recordFailure(payout, now, "payout_ledger_debit_failed", err)
+			return payout.Status, s.Repo.UpdatePayout(ctx, payout)
+		}
+		payout.LedgerDebited = true
+		payout.UpdatedAt = now
+		if err := s.Repo.UpdatePayout(ctx, payout); err != nil {
+			return "", err
+		}
+	}
+
+	// The idempotency key stays the same across retries, so a transfer the
+	// provider already accepted is never sent twice.
+	result, err := s.Provider.Transfer(ctx, ports.TransferRequest{
+		PayoutID:       payout.PayoutID,
+		CreatorID:      payout.CreatorID,
+		AmountCents:    payout.AmountCents,
+		Currency:       payout.Currency,
+		IdempotencyKey: "payout:" + payout.PayoutID,
+	})
+	if err != nil {
+		payout = s.recordFailure(payout, now, "payout_transfer_failed", err)
+		if payout.NextAttemptAt == nil {
+			return s.release(ctx, payout, now)
+		}
+		return payout.Status, s.Repo.UpdatePayout(ctx, payout)
+	}
+
+	// Mark submissions before the payout: if a step fails the payout stays
+	// due, and the retry replays the transfer under the same key.
+	for _, submissionID := range payout.SubmissionIDs {
+		if err := s.Earnings.MarkPaid(ctx, submissionID, payout.PayoutID); err != nil {
+			return "", err
+		}
+	}
+	payout.Attempts++
+	payout.Status = ports.PayoutStatusPaid
+	payout.ProviderReference = strings.TrimSpace(result.ProviderReference)
+	payout.LastError = ""
+	payout.NextAttemptAt = nil
+	payout.PaidAt = &now
+	payout.UpdatedAt = now
+	if err := s.Repo.UpdatePayout(ctx, payout); err != nil {
+		return "", err
+	}
+	logger.Info("payout settled",
+		"event", "payout_settled",
+		"module", "finance-core/payout-service",
+		"layer", "application",
+		"payout_id", payout.PayoutID,
+		"creator_id", payout.CreatorID,
+		"amount_cents", payout.AmountCents,
+		"provider_reference", payout.ProviderReference,
+	)
+	return payout.Status, nil
+}
+
+// recordFailure counts a failed attempt and schedules the next one with
+// backoff, or none once MaxAttempts is reached.
+func (s Service) recordFailure(payout ports.Payout, now time.Time, event string, cause error) ports.Payout {
+	payout.Attempts++
+	payout.Status = ports.PayoutStatusFailed
+	payout.LastError = cause.Error()
+	payout.UpdatedAt = now
+	payout.NextAttemptAt = nil
+	if payout.Attempts < s.maxAttempts() {
+		next := now.Add(services.RetryDelay(payout.Attempts, s.retryBaseDelay(), s.retryMaxDelay()))
+		payout.NextAttemptAt = &next
+	}
+	resolveLogger(s.Logger).Warn("payout attempt failed",
+		"event", event,
+		"module", "finance-core/payout-service",
+		"layer", "application",
+		"payout_id", payout.PayoutID,
+		"attempts", payout.Attempts,
+		"retry_scheduled", payout.NextAttemptAt != nil,
+		"error", cause.Error(),
+	)
+	return payout
+}
+
+// release reverses the ledger reservation of a payout whose transfer failed
+// for good, returning the money to the creator's wallet. If the reversal
+// fails the payout is kept due so the next cycle releases it again.
+func (s Service) release(ctx context.Context, payout ports.Payout, now time.Time) (string, error) {
+	payout.UpdatedAt = now
+	if err := s.Ledger.ReversePayoutSettlement(ctx, payout); err != nil {
+		next := now.Add(s.retryBaseDelay())
+		payout.NextAttemptAt = &next
+		if updateErr := s.Repo.UpdatePayout(ctx, payout); updateErr != nil {
+			return "", updateErr
+		}
+		return "", err
+	}
+	payout.LedgerDebited = false
+	payout.LedgerReleases++
+	payout.NextAttemptAt = nil
+	if err := s.Repo.UpdatePayout(ctx, payout); err != nil {
+		return "", err
+	}
+	resolveLogger(s.Logger).Warn("payout reservation released",
+		"event", "payout_reservation_released",
+		"module", "finance-core/payout-service",
+		"layer", "application",
+		"payout_id", payout.PayoutID,
+		"creator_id", payout.CreatorID,
+		"amount_cents", payout.AmountCents,
+		"ledger_releases", payout.LedgerReleases,
+	)
+	return payout.Status, nil
+}
+
+// RetryPayout reschedules a failed payout for an immediate attempt with a
+// fresh retry budget.
+func (s Service) RetryPayout(ctx context.Context, adminID string, payoutID string, reason string) (ports.Payout, error) {
+	if strings.TrimSpace(adminID) == "" || strings.TrimSpace(payoutID) == "" || strings.TrimSpace(reason) == "" {
+		return ports.Payout{}, domainerrors.ErrInvalidRequest
+	}
+	payout, err := s.Repo.GetPayout(ctx, strings.TrimSpace(payoutID))
+	if err != nil {
+		return ports.Payout{}, err
+	}
+	if payout.Status != ports.PayoutStatusFailed {
+		return ports.Payout{}, domainerrors.ErrPayoutNotRetryable
+	}
+	now := s.now()
+	payout.Status = ports.PayoutStatusPending
+	payout.Attempts = 0
+	payout.NextAttemptAt = &now
+	payout.UpdatedAt = now
+	if err := s.Repo.UpdatePayout(ctx, payout); err != nil {
+		return ports.Payout{}, err
+	}
+	resolveLogger(s.Logger).Info("payout retry scheduled",
+		"event", "payout_retry_scheduled",
+		"module", "finance-core/payout-service",
+		"layer", "application",
+		"payout_id", payout.PayoutID,
+		"admin_id", strings.TrimSpace(adminID),
+		"reason", strings.TrimSpace(reason),
+	)
+	return payout, nil
+}
+
+func (s Service) GetPayout(ctx context.Context, payoutID string) (ports.Payout, error) {
+	if strings.TrimSpace(payoutID) == "" {
+		return ports.Payout{}, domainerrors.ErrInvalidRequest
+	}
+	return s.Repo.GetPayout(ctx, strings.TrimSpace(payoutID))
+}
+
+// isHeld fails closed: without a hold checker, or when a creator's hold
+// status cannot be read, the creator is treated as held for this cycle.
+func (s Service) isHeld(ctx context.Context, creatorID string) bool {
+	if s.LegalHolds == nil {
+		return true
+	}
+	held, err := s.LegalHolds.IsHeld(ctx, creatorID)
+	if err != nil {
+		resolveLogger(s.Logger).Warn("payout legal hold check failed",
+			"event", "payout_legal_hold_check_failed",
+			"module", "finance-core/payout-service",
+			"layer", "application",
+			"creator_id", creatorID,
+			"error", err.Error(),
+		)
+		return true
+	}
+	return held
+}
+
+func (s Service) now() time.Time {
+	if s.Clock == nil {
+		return time.Now().UTC()
+	}
+	return s.Clock.Now().UTC()
+}
+
+func (s Service) minimumPayoutCents() int64 {
+	if s.MinimumPayoutCents <= 0 {
+		return defaultMinimumPayoutCents
+	}
+	return s.MinimumPayoutCents
+}
+
+func (s Service) holdPeriod() time.Duration {
+	if s.HoldPeriod <= 0 {
+		return defaultHoldPeriod
+	}
+	return s.HoldPeriod
+}
+
+func (s Service) maxAttempts() int {
+	if s.MaxAttempts <= 0 {
+		return defaultMaxAttempts
+	}
+	return s.MaxAttempts
+}
+
+func (s Service) retryBaseDelay() time.Duration {
+	if s.RetryBaseDelay <= 0 {
+		return defaultRetryBaseDelay
+	}
+	return s.RetryBaseDelay
+}
+
+func (s Service) retryMaxDelay() time.Duration {
+	if s.RetryMaxDelay <= 0 {
+		return defaultRetryMaxDelay
+	}
+	return s.RetryMaxDelay
+}
+
+func (s Service) holdRecheckInterval() time.Duration {
+	if s.HoldRecheckInterval <= 0 {
+		return defaultHoldRecheckInterval
+	}
+	return s.HoldRecheckInterval
+}
+
+func (s Service) currency() string {
+	if strings.TrimSpace(s.Currency) == "" {
+		return defaultCurrency
+	}
+	return strings.ToUpper(strings.TrimSpace(s.Currency))
+}
+
+func (s Service) batchSize() int {
+	if s.BatchSize <= 0 {
+		return defaultBatchSize
+	}
+	return s.BatchSize
+}
@@ -0,0 +1,351 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"solomon/contexts/finance-core/payout-service/adapters/memory"
+	"solomon/contexts/finance-core/payout-service/adapters/provider"
+	domainerrors "solomon/contexts/finance-core/payout-service/domain/errors"
+	"solomon/contexts/finance-core/payout-service/ports"
+)
+
+func TestRunCycleBatchesMaturedEarningsAboveMinimum(t *testing.T) {
+	now := time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)
+	earnings := newFakeEarnings(
+		earning("sub-1", "creator-1", 1500, now.Add(-8*24*time.Hour)),
+		earning("sub-2", "creator-1", 700, now.Add(-9*24*time.Hour)),
+		earning("sub-3", "creator-2", 900, now.Add(-10*24*time.Hour)),
+		earning("sub-4", "creator-3", 5000, now.Add(-2*24*time.Hour)),
+	)
+	fake := provider.NewFake()
+	service := newTestService(earnings, fake, &fixedClock{now: now})
+
+	if err := service.RunCycle(context.Background()); err != nil {
+		t.Fatalf("run cycle failed: %v", err)
+	}
+
+	transfers := fake.Transfers()
+	if len(transfers) != 1 || transfers[0].CreatorID != "creator-1" || transfers[0].AmountCents != 2200 {
+		t.Fatalf("expected one 2200 cent transfer to creator-1, got %+v", transfers)
+	}
+	for _, id := range []string{"sub-1", "sub-2"} {
+		if earnings.status[id] != "paid" {
+			t.Fatalf("expected %s paid, got %q", id, earnings.status[id])
+		}
+	}
+	// Below the minimum: eligible, but still waiting for more earnings.
+	if earnings.status["sub-3"] != "reward_eligible" {
+		t.Fatalf("expected sub-3 reward_eligible, got %q", earnings.status["sub-3"])
+	}
+	// Still inside the hold period.
+	if earnings.status["sub-4"] != "view_locked" {
+		t.Fatalf("expected sub-4 still view_locked, got %q", earnings.status["sub-4"])
+	}
+}
+
+func TestLedgerDebitFailureHoldsBackTransfer(t *testing.T) {
+	now := time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)
+	earnings := newFakeEarnings(earning("sub-1", "creator-1", 5000, now.Add(-8*24*time.Hour)))
+	fake := provider.NewFake()
+	clock := &fixedClock{now: now}
+	service := newTestService(earnings, fake, clock)
+	service.RetryBaseDelay = time.Minute
+	ledger := service.Ledger.(*fakeLedger)
+	ledger.Fail = errors.New("insufficient funds")
+	ctx := context.Background()
+
+	if err := service.RunCycle(ctx); err != nil {
+		t.Fatalf("run cycle failed: %v", err)
+	}
+	if len(fake.Transfers()) != 0 || earnings.status["sub-1"] == "paid" {
+		t.Fatalf("expected no transfer before the ledger debit, got %+v status=%q", fake.Transfers(), earnings.status["sub-1"])
+	}
+	due, _ := service.Repo.ListDuePayouts(ctx, now.Add(time.Minute), 10)
+	if len(due) != 1 || due[0].Attempts != 1 || due[0].LedgerDebited {
+		t.Fatalf("expected the payout rescheduled without a debit, got %+v", due)
+	}
+
+	ledger.Fail = nil
+	clock.now = now.Add(time.Minute)
+	report, err := service.SettleDuePayouts(ctx)
+	if err != nil || report.Paid != 1 {
+		t.Fatalf("expected the retried payout paid, got %+v err=%v", report, err)
+	}
+	if len(fake.Transfers()) != 1 || fake.Transfers()[0].IdempotencyKey != "payout:"+due[0].PayoutID {
+		t.Fatalf("expected one transfer under the payout key, got %+v", fake.Transfers())
+	}
+	if ledger.reserved[due[0].PayoutID] != 5000 || len(ledger.entries) != 1 {
+		t.Fatalf("expected one 5000 cent ledger debit, got reserved=%v entries=%v", ledger.reserved, ledger.entries)
+	}
+}
+
+func TestFailingPayoutIsSkippedWithoutStoppingOthers(t *testing.T) {
+	now := time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)
+	earnings := newFakeEarnings(
+		earning("sub-1", "creator-1", 5000, now.Add(-8*24*time.Hour)),
+		earning("sub-2", "creator-2", 5000, now.Add(-8*24*time.Hour)),
+	)
+	earnings.failPaid = map[string]bool{"sub-1": true}
+	fake := provider.NewFake()
+	service := newTestService(earnings, fake, &fixedClock{now: now})
+	ctx := context.Background()
+
+	if err := service.RunCycle(ctx); err != nil {
+		t.Fatalf("expected a failing payout not to fail the cycle, got %v", err)
+	}
+	if earnings.status["sub-2"] != "paid" || earnings.status["sub-1"] == "paid" {
+		t.Fatalf("expected only sub-2 paid, got %v", earnings.status)
+	}
+
+	// The skipped payout stays due and reuses its debit and transfer key.
+	delete(earnings.failPaid, "sub-1")
+	report, err := service.SettleDuePayouts(ctx)
+	if err != nil || report.Paid != 1 || earnings.status["sub-1"] != "paid" {
+		t.Fatalf("expected the skipped payout paid on the next cycle, got %+v err=%v", report, err)
+	}
+	if len(fake.Transfers()) != 2 || len(service.Ledger.(*fakeLedger).entries) != 2 {
+		t.Fatalf("expected one transfer and one debit per payout, got %d transfers", len(fake.Transfers()))
+	}
+}
+
+func TestBuildBatchesPagesPastHeldCreatorsAndBatchesWholeTotals(t *testing.T) {
+	now := time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)
+	locked := now.Add(-8 * 24 * time.Hour)
+	earnings := newFakeEarnings(
+		earning("sub-a1", "creator-a", 5000, locked),
+		earning("sub-b1", "creator-b", 100, locked),
+		earning("sub-c1", "creator-c", 1500, locked),
+		earning("sub-c2", "creator-c", 1500, locked.Add(time.Minute)),
+		earning("sub-c3", "creator-c", 1500, locked.Add(2*time.Minute)),
+		earning("sub-d1", "creator-d", 2500, locked),
+	)
+	service := newTestService(earnings, provider.NewFake(), &fixedClock{now: now})
+	service.LegalHolds.(*fakeHolds).held["creator-a"] = true
+	service.BatchSize = 1
+	ctx := context.Background()
+
+	for i := 0; i < 6; i++ {
+		if _, err := service.CollectEarnings(ctx); err != nil {
+			t.Fatalf("collect earnings failed: %v", err)
+		}
+	}
+	payouts, err := service.BuildBatches(ctx)
+	if err != nil {
+		t.Fatalf("build batches failed: %v", err)
+	}
+	totals := map[string]int64{}
+	for _, payout := range payouts {
+		totals[payout.CreatorID] = payout.AmountCents
+	}
+	if len(totals) != 2 || totals["creator-c"] != 4500 || totals["creator-d"] != 2500 {
+		t.Fatalf("expected whole batches for creator-c and creator-d only, got %v", totals)
+	}
+}
+
+func TestLegalHoldBlocksBatchingAndSettlement(t *testing.T) {
+	now := time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)
+	earnings := newFakeEarnings(earning("sub-1", "creator-1", 5000, now.Add(-8*24*time.Hour)))
+	fake := provider.NewFake()
+	service := newTestService(earnings, fake, &fixedClock{now: now})
+	holds := service.LegalHolds.(*fakeHolds)
+	holds.held["creator-1"] = true
+
+	if err := service.RunCycle(context.Background()); err != nil {
+		t.Fatalf("run cycle failed: %v", err)
+	}
+	if len(fake.Transfers()) != 0 {
+		t.Fatalf("expected no transfer for held creator")
+	}
+
+	// Hold placed after batching: the payout is parked, not transferred.
+	holds.held["creator-1"] = false
+	payouts, err := service.BuildBatches(context.Background())
+	if err != nil || len(payouts) != 1 {
+		t.Fatalf("expected one batch after release, got %d err=%v", len(payouts), err)
+	}
+	holds.held["creator-1"] = true
+	report, err := service.SettleDuePayouts(context.Background())
+	if err != nil || report.Held != 1 {
+		t.Fatalf("expected held payout, got %+v err=%v", report, err)
+	}
+	payout, _ := service.GetPayout(context.Background(), payouts[0].PayoutID)
+	if payout.Status != ports.PayoutStatusHeld || payout.Attempts != 0 {
+		t.Fatalf("unexpected held payout %+v", payout)
+	}
+}
+
+func TestFailedTransfersBackOffUntilExhaustedThenAdminRetry(t *testing.T) {
+	clock := &fixedClock{now: time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)}
+	earnings := newFakeEarnings(earning("sub-1", "creator-1", 5000, clock.now.Add(-8*24*time.Hour)))
+	fake := provider.NewFake()
+	fake.Fail = func(ports.TransferRequest) error { return errors.New("provider timeout") }
+	service := newTestService(earnings, fake, clock)
+	service.MaxAttempts = 3
+	service.RetryBaseDelay = time.Minute
+	ctx := context.Background()
+
+	if err := service.RunCycle(ctx); err != nil {
+		t.Fatalf("run cycle failed: %v", err)
+	}
+	due, _ := service.Repo.ListDuePayouts(ctx, clock.now.Add(time.Hour), 10)
+	if len(due) != 1 {
+		t.Fatalf("expected a scheduled retry, got %d", len(due))
+	}
+	payoutID := due[0].PayoutID
+	if want := clock.now.Add(time.Minute); !due[0].NextAttemptAt.Equal(want) {
+		t.Fatalf("expected first retry at %s, got %s", want, due[0].NextAttemptAt)
+	}
+
+	clock.now = clock.now.Add(time.Minute)
+	if _, err := service.SettleDuePayouts(ctx); err != nil {
+		t.Fatalf("settle failed: %v", err)
+	}
+	payout, _ := service.GetPayout(ctx, payoutID)
+	if want := clock.now.Add(2 * time.Minute); payout.Attempts != 2 || !payout.NextAttemptAt.Equal(want) {
+		t.Fatalf("expected second retry doubled to %s, got attempts=%d next=%v", want, payout.Attempts, payout.NextAttemptAt)
+	}
+
+	clock.now = clock.now.Add(2 * time.Minute)
+	if _, err := service.SettleDuePayouts(ctx); err != nil {
+		t.Fatalf("settle failed: %v", err)
+	}
+	payout, _ = service.GetPayout(ctx, payoutID)
+	if payout.Status != ports.PayoutStatusFailed || payout.NextAttemptAt != nil || payout.LastError != "provider timeout" {
+		t.Fatalf("expected exhausted failed payout, got %+v", payout)
+	}
+	ledger := service.Ledger.(*fakeLedger)
+	if payout.LedgerDebited || payout.LedgerReleases != 1 || ledger.reserved[payoutID] != 0 {
+		t.Fatalf("expected the exhausted payout's debit reversed, got %+v reserved=%d", payout, ledger.reserved[payoutID])
+	}
+
+	if _, err := service.RetryPayout(ctx, "admin-1", "missing", "manual"); !errors.Is(err, domainerrors.ErrPayoutNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+	fake.Fail = nil
+	if _, err := service.RetryPayout(ctx, "admin-1", payoutID, "provider recovered"); err != nil {
+		t.Fatalf("admin retry failed: %v", err)
+	}
+	report, err := service.SettleDuePayouts(ctx)
+	if err != nil || report.Paid != 1 {
+		t.Fatalf("expected retried payout paid, got %+v err=%v", report, err)
+	}
+	if earnings.status["sub-1"] != "paid" {
+		t.Fatalf("expected sub-1 paid, got %q", earnings.status["sub-1"])
+	}
+	if ledger.reserved[payoutID] != 5000 || len(ledger.entries) != 3 {
+		t.Fatalf("expected the retry debited under a new reservation, got reserved=%d entries=%v", ledger.reserved[payoutID], ledger.entries)
+	}
+	if _, err := service.RetryPayout(ctx, "admin-1", payoutID, "again"); !errors.Is(err, domainerrors.ErrPayoutNotRetryable) {
+		t.Fatalf("expected paid payout not retryable, got %v", err)
+	}
+}
+
+type fixedClock struct {
+	now time.Time
+}
+
+func (c *fixedClock) Now() time.Time {
+	return c.now
+}
+
+type fakeHolds struct {
+	held map[string]bool
+}
+
+func (h *fakeHolds) IsHeld(_ context.Context, creatorID string) (bool, error) {
+	return h.held[creatorID], nil
+}
+
+// fakeLedger keeps one entry per payout reservation, like the wallet
+// ledger's idempotency keys, and fails while Fail is set. reserved is each
+// payout's net debit.
+type fakeLedger struct {
+	Fail     error
+	entries  map[string]bool
+	reserved map[string]int64
+}
+
+func (l *fakeLedger) PostPayoutSettlement(_ context.Context, payout ports.Payout) error {
+	return l.post(fmt.Sprintf("payout:%s:%d", payout.PayoutID, payout.LedgerReleases), payout.PayoutID, payout.AmountCents)
+}
+
+func (l *fakeLedger) ReversePayoutSettlement(_ context.Context, payout ports.Payout) error {
+	return l.post(fmt.Sprintf("payout_reversal:%s:%d", payout.PayoutID, payout.LedgerReleases), payout.PayoutID, -payout.AmountCents)
+}
+
+func (l *fakeLedger) post(key string, payoutID string, amountCents int64) error {
+	if l.Fail != nil {
+		return l.Fail
+	}
+	if !l.entries[key] {
+		l.entries[key] = true
+		l.reserved[payoutID] += amountCents
+	}
+	return nil
+}
+
+// fakeEarnings stands in for the submission service: submissions start
+// view_locked and move through reward_eligible to paid.
+type fakeEarnings struct {
+	items    []ports.Earning
+	status   map[string]string
+	failPaid map[string]bool
+}
+
+func newFakeEarnings(items ...ports.Earning) *fakeEarnings {
+	status := make(map[string]string, len(items))
+	for _, item := range items {
+		status[item.SubmissionID] = "view_locked"
+	}
+	return &fakeEarnings{items: items, status: status}
+}
+
+func (f *fakeEarnings) ListMaturedEarnings(_ context.Context, lockedBefore time.Time, _ int) ([]ports.Earning, error) {
+	out := make([]ports.Earning, 0)
+	for _, item := range f.items {
+		if f.status[item.SubmissionID] == "view_locked" && !item.LockedAt.After(lockedBefore) {
+			out = append(out, item)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeEarnings) MarkRewardEligible(_ context.Context, submissionID string) error {
+	f.status[submissionID] = "reward_eligible"
+	return nil
+}
+
+func (f *fakeEarnings) MarkPaid(_ context.Context, submissionID string, _ string) error {
+	if f.failPaid[submissionID] {
+		return errors.New("submission service unavailable")
+	}
+	f.status[submissionID] = "paid"
+	return nil
+}
+
+func earning(submissionID string, creatorID string, cents int64, lockedAt time.Time) ports.Earning {
+	return ports.Earning{
+		SubmissionID: submissionID,
+		CreatorID:    creatorID,
+		CampaignID:   "campaign-1",
+		AmountCents:  cents,
+		LockedAt:     lockedAt,
+	}
+}
+
+func newTestService(earnings ports.EarningsSource, payouts ports.PayoutProvider, clock ports.Clock) Service {
+	store := memory.NewStore()
+	return Service{
+		Repo:       store,
+		Earnings:   earnings,
+		Provider:   payouts,
+		Ledger:     &fakeLedger{entries: map[string]bool{}, reserved: map[string]int64{}},
+		LegalHolds: &fakeHolds{held: map[string]bool{}},
+		Clock:      clock,
+		IDGen:      store,
+	}
+}
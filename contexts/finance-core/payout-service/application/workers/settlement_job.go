@@ -0,0 +1,49 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"solomon/contexts/finance-core/payout-service/application"
+)
+
+// SettlementJob runs a payout cycle at most once per Interval: it collects
+// matured earnings, batches them per creator and settles due payouts.
+type SettlementJob struct {
+	Service  application.Service
+	Interval time.Duration
+	Disabled bool
+	Logger   *slog.Logger
+
+	lastRun time.Time
+}
+
+func (j *SettlementJob) RunOnce(ctx context.Context) error {
+	logger := j.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if j.Disabled {
+		return nil
+	}
+	now := time.Now().UTC()
+	if j.Service.Clock != nil {
+		now = j.Service.Clock.Now().UTC()
+	}
+	if !j.lastRun.IsZero() && now.Sub(j.lastRun) < j.Interval {
+		return nil
+	}
+	j.lastRun = now
+
+	if err := j.Service.RunCycle(ctx); err != nil {
+		logger.Error("payout settlement cycle failed",
+			"event", "payout_settlement_cycle_failed",
+			"module", "finance-core/payout-service",
+			"layer", "worker",
+			"error", err.Error(),
+		)
+		return err
+	}
+	return nil
+}
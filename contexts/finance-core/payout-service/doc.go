@@ -0,0 +1,2 @@
+// Package payoutservice implements creator payout batching and settlement in Solomon.
+package payoutservice
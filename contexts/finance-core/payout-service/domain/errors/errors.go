@@ -0,0 +1,14 @@
+package errors
+
+import "errors"
+
+var (
+	ErrInvalidRequest = errors.New("invalid request")
+	ErrNotFound       = errors.New("resource not found")
+
+	ErrPayoutNotFound        = errors.New("payout not found")
+	ErrPayoutNotRetryable    = errors.New("payout is not in a retryable state")
+	ErrEarningAlreadyBatched = errors.New("earning already belongs to a payout")
+	ErrProviderUnavailable   = errors.New("payout provider unavailable")
+	ErrLedgerUnavailable     = errors.New("wallet ledger unavailable")
+)
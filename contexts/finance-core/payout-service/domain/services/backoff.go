@@ -0,0 +1,22 @@
+package services
+
+import "time"
+
+// RetryDelay is the wait before retrying a payout after its attempt-th
+// failure: base doubled per failure, capped at max.
+func RetryDelay(attempt int, base time.Duration, max time.Duration) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	delay := base
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	if delay > max {
+		return max
+	}
+	return delay
+}
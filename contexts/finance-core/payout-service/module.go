@@ -0,0 +1,86 @@
+package payoutservice
+
+import (
+	"log/slog"
+	"time"
+
+	httpadapter "solomon/contexts/finance-core/payout-service/adapters/http"
+	"solomon/contexts/finance-core/payout-service/adapters/memory"
+	"solomon/contexts/finance-core/payout-service/adapters/provider"
+	"solomon/contexts/finance-core/payout-service/application"
+	"solomon/contexts/finance-core/payout-service/application/workers"
+	"solomon/contexts/finance-core/payout-service/ports"
+)
+
+type Module struct {
+	Handler    httpadapter.Handler
+	Settlement *workers.SettlementJob
+	Store      *memory.Store
+	Provider   *provider.Fake
+}
+
+type Dependencies struct {
+	Repository         ports.Repository
+	Earnings           ports.EarningsSource
+	Provider           ports.PayoutProvider
+	Ledger             ports.SettlementLedger
+	LegalHolds         ports.LegalHoldChecker
+	Clock              ports.Clock
+	IDGenerator        ports.IDGenerator
+	MinimumPayoutCents int64
+	HoldPeriod         time.Duration
+	MaxAttempts        int
+	RetryBaseDelay     time.Duration
+	RetryMaxDelay      time.Duration
+	SettlementInterval time.Duration
+	DisableSettlement  bool
+	Logger             *slog.Logger
+}
+
+func NewModule(deps Dependencies) Module {
+	service := application.Service{
+		Repo:               deps.Repository,
+		Earnings:           deps.Earnings,
+		Provider:           deps.Provider,
+		Ledger:             deps.Ledger,
+		LegalHolds:         deps.LegalHolds,
+		Clock:              deps.Clock,
+		IDGen:              deps.IDGenerator,
+		MinimumPayoutCents: deps.MinimumPayoutCents,
+		HoldPeriod:         deps.HoldPeriod,
+		MaxAttempts:        deps.MaxAttempts,
+		RetryBaseDelay:     deps.RetryBaseDelay,
+		RetryMaxDelay:      deps.RetryMaxDelay,
+		Logger:             deps.Logger,
+	}
+	return Module{
+		Handler: httpadapter.Handler{
+			Service: service,
+			Logger:  deps.Logger,
+		},
+		Settlement: &workers.SettlementJob{
+			Service:  service,
+			Interval: deps.SettlementInterval,
+			Disabled: deps.DisableSettlement,
+			Logger:   deps.Logger,
+		},
+	}
+}
+
+// NewInMemoryModule serves payout lookups and admin retries. It has no
+// earnings source, so its settlement job stays disabled.
+func NewInMemoryModule(logger *slog.Logger) Module {
+	store := memory.NewStore()
+	fake := provider.NewFake()
+	module := NewModule(Dependencies{
+		Repository:        store,
+		Provider:          fake,
+		Clock:             store,
+		IDGenerator:       store,
+		DisableSettlement: true,
+		Logger:            logger,
+	})
+	module.Store = store
+	module.Provider = fake
+	return module
+}
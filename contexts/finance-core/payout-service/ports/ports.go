@@ -0,0 +1,130 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+type Clock interface {
+	Now() time.Time
+}
+
+type IDGenerator interface {
+	NewID(ctx context.Context) (string, error)
+}
+
+const (
+	// PayoutStatusPending payouts are due for their first transfer attempt.
+	PayoutStatusPending = "pending"
+	// PayoutStatusHeld payouts are blocked by a legal hold on the creator
+	// and are re-checked at NextAttemptAt.
+	PayoutStatusHeld = "held"
+	// PayoutStatusFailed payouts are retried at NextAttemptAt; once retries
+	// are exhausted NextAttemptAt is cleared and only an admin retry
+	// reschedules them.
+	PayoutStatusFailed = "failed"
+	PayoutStatusPaid   = "paid"
+)
+
+// Earning is a view-locked submission's net earnings, recorded once its hold
+// period has passed. PayoutID is empty until the earning is batched.
+type Earning struct {
+	SubmissionID string
+	CreatorID    string
+	CampaignID   string
+	AmountCents  int64
+	LockedAt     time.Time
+	PayoutID     string
+	RecordedAt   time.Time
+}
+
+// Payout is one creator's batch of earnings and its settlement state.
+type Payout struct {
+	PayoutID          string
+	CreatorID         string
+	AmountCents       int64
+	Currency          string
+	Status            string
+	SubmissionIDs     []string
+	Attempts          int
+	NextAttemptAt     *time.Time
+	LastError         string
+	ProviderReference string
+	// LedgerDebited is set once the payout is reserved out of the creator's
+	// wallet, before its transfer is sent.
+	LedgerDebited bool
+	// LedgerReleases counts reservations reversed after a transfer failed
+	// for good; the next reservation is keyed by it.
+	LedgerReleases int
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	PaidAt         *time.Time
+}
+
+// CreatorEarnings is a creator's total of unbatched earnings.
+type CreatorEarnings struct {
+	CreatorID  string
+	TotalCents int64
+}
+
+type Repository interface {
+	// RecordEarning stores an earning once per submission; recording it
+	// again is a no-op.
+	RecordEarning(ctx context.Context, earning Earning) error
+	// ListBatchableCreators pages, in creator ID order after afterCreatorID,
+	// the creators whose unbatched earnings total at least minimumCents.
+	ListBatchableCreators(ctx context.Context, minimumCents int64, afterCreatorID string, limit int) ([]CreatorEarnings, error)
+	// ListUnbatchedEarnings returns all of a creator's unbatched earnings.
+	ListUnbatchedEarnings(ctx context.Context, creatorID string) ([]Earning, error)
+	// CreatePayout stores the payout and assigns its submissions' earnings
+	// to it, failing with ErrEarningAlreadyBatched if any already belongs to
+	// another payout.
+	CreatePayout(ctx context.Context, payout Payout) error
+	GetPayout(ctx context.Context, payoutID string) (Payout, error)
+	// ListDuePayouts returns unpaid payouts whose NextAttemptAt has passed.
+	ListDuePayouts(ctx context.Context, now time.Time, limit int) ([]Payout, error)
+	UpdatePayout(ctx context.Context, payout Payout) error
+}
+
+// EarningsSource is the submission service's side of payouts: it lists
+// view-locked earnings and records the reward_eligible and paid transitions.
+type EarningsSource interface {
+	ListMaturedEarnings(ctx context.Context, lockedBefore time.Time, limit int) ([]Earning, error)
+	MarkRewardEligible(ctx context.Context, submissionID string) error
+	MarkPaid(ctx context.Context, submissionID string, payoutID string) error
+}
+
+type TransferRequest struct {
+	PayoutID    string
+	CreatorID   string
+	AmountCents int64
+	Currency    string
+	// IdempotencyKey is stable across retries of one payout, so a transfer
+	// the provider already accepted is never sent twice.
+	IdempotencyKey string
+}
+
+type TransferResult struct {
+	ProviderReference string
+}
+
+type PayoutProvider interface {
+	Transfer(ctx context.Context, req TransferRequest) (TransferResult, error)
+}
+
+// SettlementLedger reserves payouts in the wallet ledger before they are
+// transferred. Both calls are keyed by the payout and its LedgerReleases, so
+// repeating either for the same reservation is a no-op.
+type SettlementLedger interface {
+	// PostPayoutSettlement debits the payout from the creator's wallet.
+	PostPayoutSettlement(ctx context.Context, payout Payout) error
+	// ReversePayoutSettlement credits a reserved payout back to the
+	// creator's wallet.
+	ReversePayoutSettlement(ctx context.Context, payout Payout) error
+}
+
+// LegalHoldChecker reports whether a creator's funds are frozen by a legal
+// hold.
+type LegalHoldChecker interface {
+	IsHeld(ctx context.Context, creatorID string) (bool, error)
+}
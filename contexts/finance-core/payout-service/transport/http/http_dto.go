@@ -0,0 +1,26 @@
+package http
+
+type ErrorResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type RetryPayoutRequest struct {
+	Reason string `json:"reason"`
+}
+
+type PayoutResponse struct {
+	PayoutID          string   `json:"payout_id"`
+	CreatorID         string   `json:"creator_id"`
+	AmountCents       int64    `json:"amount_cents"`
+	Currency          string   `json:"currency"`
+	Status            string   `json:"status"`
+	SubmissionIDs     []string `json:"submission_ids"`
+	Attempts          int      `json:"attempts"`
+	NextAttemptAt     string   `json:"next_attempt_at,omitempty"`
+	LastError         string   `json:"last_error,omitempty"`
+	ProviderReference string   `json:"provider_reference,omitempty"`
+	CreatedAt         string   `json:"created_at"`
+	UpdatedAt         string   `json:"updated_at"`
+	PaidAt            string   `json:"paid_at,omitempty"`
+}
@@ -0,0 +1,161 @@
+package httpadapter
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"solomon/contexts/finance-core/wallet-ledger-service/application"
+	"solomon/contexts/finance-core/wallet-ledger-service/domain/services"
+	"solomon/contexts/finance-core/wallet-ledger-service/ports"
+	httptransport "solomon/contexts/finance-core/wallet-ledger-service/transport/http"
+)
+
+type Handler struct {
+	Service application.Service
+	Logger  *slog.Logger
+}
+
+func (h Handler) PostAdminAdjustmentHandler(
+	ctx context.Context,
+	req httptransport.AdminAdjustmentRequest,
+) (httptransport.WalletPostingResponse, error) {
+	entry, replayed, err := h.Service.PostAdminAdjustment(ctx, application.AdminAdjustmentInput{
+		IdempotencyKey: req.IdempotencyKey,
+		AdminID:        req.AdminID,
+		UserID:         req.UserID,
+		AmountCents:    req.AmountCents,
+		Direction:      req.Direction,
+		Reason:         req.Reason,
+	})
+	if err != nil {
+		return httptransport.WalletPostingResponse{}, err
+	}
+	return toWalletPosting(entry, services.UserWalletAccount(req.UserID), replayed), nil
+}
+
+func (h Handler) PostViewLockEarningHandler(
+	ctx context.Context,
+	req httptransport.ViewLockEarningRequest,
+) (httptransport.WalletPostingResponse, error) {
+	entry, replayed, err := h.Service.PostViewLockEarning(ctx, application.ViewLockEarningInput{
+		SubmissionID: req.SubmissionID,
+		CreatorID:    req.CreatorID,
+		CampaignID:   req.CampaignID,
+		GrossCents:   req.GrossCents,
+		FeeCents:     req.FeeCents,
+	})
+	if err != nil {
+		return httptransport.WalletPostingResponse{}, err
+	}
+	return toWalletPosting(entry, services.UserWalletAccount(req.CreatorID), replayed), nil
+}
+
+func (h Handler) PostPayoutSettlementHandler(
+	ctx context.Context,
+	req httptransport.PayoutSettlementRequest,
+) (httptransport.WalletPostingResponse, error) {
+	entry, replayed, err := h.Service.PostPayoutSettlement(ctx, application.PayoutSettlementInput{
+		PayoutID:    req.PayoutID,
+		CreatorID:   req.CreatorID,
+		AmountCents: req.AmountCents,
+		Reservation: req.Reservation,
+	})
+	if err != nil {
+		return httptransport.WalletPostingResponse{}, err
+	}
+	return toWalletPosting(entry, services.UserWalletAccount(req.CreatorID), replayed), nil
+}
+
+func (h Handler) ReversePayoutSettlementHandler(
+	ctx context.Context,
+	req httptransport.PayoutSettlementRequest,
+) (httptransport.WalletPostingResponse, error) {
+	entry, replayed, err := h.Service.ReversePayoutSettlement(ctx, application.PayoutSettlementInput{
+		PayoutID:    req.PayoutID,
+		CreatorID:   req.CreatorID,
+		AmountCents: req.AmountCents,
+		Reservation: req.Reservation,
+	})
+	if err != nil {
+		return httptransport.WalletPostingResponse{}, err
+	}
+	return toWalletPosting(entry, services.UserWalletAccount(req.CreatorID), replayed), nil
+}
+
+func (h Handler) GetAccountStatementHandler(
+	ctx context.Context,
+	accountID string,
+	limit int,
+	offset int,
+) (httptransport.AccountStatementResponse, error) {
+	statement, err := h.Service.GetAccountStatement(ctx, accountID, limit, offset)
+	if err != nil {
+		return httptransport.AccountStatementResponse{}, err
+	}
+	resp := httptransport.AccountStatementResponse{
+		AccountID:          statement.Account.AccountID,
+		AccountType:        statement.Account.AccountType,
+		OwnerID:            statement.Account.OwnerID,
+		BalanceCents:       statement.JournalBalanceCents,
+		CachedBalanceCents: statement.Account.CachedBalanceCents,
+		Entries:            make([]httptransport.JournalEntryDTO, 0, len(statement.Entries)),
+	}
+	for _, entry := range statement.Entries {
+		item := httptransport.JournalEntryDTO{
+			EntryID:       entry.EntryID,
+			EntryType:     entry.EntryType,
+			ReferenceType: entry.ReferenceType,
+			ReferenceID:   entry.ReferenceID,
+			Description:   entry.Description,
+			Postings:      make([]httptransport.PostingDTO, 0, len(entry.Postings)),
+			PostedAt:      entry.PostedAt.UTC().Format(time.RFC3339),
+		}
+		for _, posting := range entry.Postings {
+			item.Postings = append(item.Postings, httptransport.PostingDTO{
+				AccountID:         posting.AccountID,
+				Direction:         posting.Direction,
+				AmountCents:       posting.AmountCents,
+				BalanceAfterCents: posting.BalanceAfterCents,
+			})
+		}
+		resp.Entries = append(resp.Entries, item)
+	}
+	return resp, nil
+}
+
+func (h Handler) ListDriftsHandler(ctx context.Context, limit int) (httptransport.BalanceDriftsResponse, error) {
+	items, err := h.Service.ListDrifts(ctx, limit)
+	if err != nil {
+		return httptransport.BalanceDriftsResponse{}, err
+	}
+	resp := httptransport.BalanceDriftsResponse{
+		Drifts: make([]httptransport.BalanceDriftDTO, 0, len(items)),
+	}
+	for _, item := range items {
+		resp.Drifts = append(resp.Drifts, httptransport.BalanceDriftDTO{
+			DriftID:             item.DriftID,
+			AccountID:           item.AccountID,
+			CachedBalanceCents:  item.CachedBalanceCents,
+			JournalBalanceCents: item.JournalBalanceCents,
+			DriftCents:          item.DriftCents,
+			DetectedAt:          item.DetectedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	return resp, nil
+}
+
+func toWalletPosting(entry ports.JournalEntry, accountID string, replayed bool) httptransport.WalletPostingResponse {
+	resp := httptransport.WalletPostingResponse{
+		EntryID:   entry.EntryID,
+		AccountID: strings.TrimSpace(accountID),
+		PostedAt:  entry.PostedAt.UTC().Format(time.RFC3339),
+		Replayed:  replayed,
+	}
+	if posting, ok := application.PostingFor(entry, resp.AccountID); ok {
+		resp.BalanceBeforeCents = application.BalanceBefore(posting)
+		resp.BalanceAfterCents = posting.BalanceAfterCents
+	}
+	return resp
+}
@@ -0,0 +1,237 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	domainerrors "solomon/contexts/finance-core/wallet-ledger-service/domain/errors"
+	"solomon/contexts/finance-core/wallet-ledger-service/domain/services"
+	"solomon/contexts/finance-core/wallet-ledger-service/ports"
+)
+
+type Store struct {
+	mu sync.RWMutex
+
+	accounts map[string]ports.Account
+	entries  []ports.JournalEntry
+	byKey    map[string]int
+	drifts   []ports.BalanceDrift
+	sequence uint64
+}
+
+func NewStore() *Store {
+	store := &Store{
+		accounts: make(map[string]ports.Account),
+		entries:  make([]ports.JournalEntry, 0),
+		byKey:    make(map[string]int),
+		drifts:   make([]ports.BalanceDrift, 0),
+	}
+
+	// Opening balances for the wallets seeded by the local super-admin store.
+	openedAt := time.Now().UTC().Add(-30 * 24 * time.Hour)
+	for _, seed := range []struct {
+		userID string
+		cents  int64
+	}{
+		{userID: "user-1", cents: 50000},
+		{userID: "user-2", cents: 15000},
+	} {
+		_, _, _ = store.post(ports.JournalEntry{
+			EntryID:        store.nextID("je"),
+			IdempotencyKey: "opening_balance:" + seed.userID,
+			EntryType:      ports.EntryTypeOpeningBalance,
+			ReferenceType:  "user",
+			ReferenceID:    seed.userID,
+			Description:    "opening balance",
+			Postings: []ports.Posting{
+				{AccountID: services.OpeningBalancesAccount, Direction: services.Debit, AmountCents: seed.cents},
+				{AccountID: services.UserWalletAccount(seed.userID), Direction: services.Credit, AmountCents: seed.cents},
+			},
+			PostedAt: openedAt,
+		})
+	}
+	return store
+}
+
+func (s *Store) PostEntry(_ context.Context, entry ports.JournalEntry) (ports.JournalEntry, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.post(entry)
+}
+
+func (s *Store) post(entry ports.JournalEntry) (ports.JournalEntry, bool, error) {
+	if index, ok := s.byKey[entry.IdempotencyKey]; ok {
+		return cloneEntry(s.entries[index]), true, nil
+	}
+
+	balances := make(map[string]int64, len(entry.Postings))
+	for _, posting := range entry.Postings {
+		if _, ok := balances[posting.AccountID]; !ok {
+			balances[posting.AccountID] = s.journalBalance(posting.AccountID)
+		}
+	}
+	posted := cloneEntry(entry)
+	for i, posting := range posted.Postings {
+		accountType, _, ok := services.ParseAccount(posting.AccountID)
+		if !ok {
+			return ports.JournalEntry{}, false, domainerrors.ErrInvalidAccount
+		}
+		balances[posting.AccountID] += services.SignedAmount(posting.Direction, posting.AmountCents)
+		if balances[posting.AccountID] < 0 && !services.AllowsNegativeBalance(accountType) {
+			return ports.JournalEntry{}, false, domainerrors.ErrInsufficientFunds
+		}
+		posted.Postings[i].BalanceAfterCents = balances[posting.AccountID]
+	}
+
+	for _, posting := range posted.Postings {
+		account, ok := s.accounts[posting.AccountID]
+		if !ok {
+			accountType, ownerID, _ := services.ParseAccount(posting.AccountID)
+			account = ports.Account{
+				AccountID:   posting.AccountID,
+				AccountType: accountType,
+				OwnerID:     ownerID,
+				CreatedAt:   posted.PostedAt,
+			}
+		}
+		account.CachedBalanceCents += services.SignedAmount(posting.Direction, posting.AmountCents)
+		account.UpdatedAt = posted.PostedAt
+		s.accounts[posting.AccountID] = account
+	}
+	s.byKey[posted.IdempotencyKey] = len(s.entries)
+	s.entries = append(s.entries, posted)
+	return cloneEntry(posted), false, nil
+}
+
+func (s *Store) GetAccount(_ context.Context, accountID string) (ports.Account, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	account, ok := s.accounts[accountID]
+	if !ok {
+		return ports.Account{}, domainerrors.ErrAccountNotFound
+	}
+	return account, nil
+}
+
+func (s *Store) JournalBalance(_ context.Context, accountID string) (int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.journalBalance(accountID), nil
+}
+
+func (s *Store) ListEntriesByAccount(_ context.Context, accountID string, limit int, offset int) ([]ports.JournalEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	items := make([]ports.JournalEntry, 0)
+	for i := len(s.entries) - 1; i >= 0; i-- {
+		if _, ok := postingFor(s.entries[i], accountID); ok {
+			items = append(items, s.entries[i])
+		}
+	}
+	if offset >= len(items) {
+		return []ports.JournalEntry{}, nil
+	}
+	end := offset + limit
+	if end > len(items) {
+		end = len(items)
+	}
+	out := make([]ports.JournalEntry, 0, end-offset)
+	for _, entry := range items[offset:end] {
+		out = append(out, cloneEntry(entry))
+	}
+	return out, nil
+}
+
+func (s *Store) ListAccountBalances(_ context.Context, afterAccountID string, limit int) ([]ports.AccountBalance, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ids := make([]string, 0, len(s.accounts))
+	for id := range s.accounts {
+		if id > afterAccountID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	items := make([]ports.AccountBalance, 0, len(ids))
+	for _, id := range ids {
+		items = append(items, ports.AccountBalance{
+			AccountID:           id,
+			CachedBalanceCents:  s.accounts[id].CachedBalanceCents,
+			JournalBalanceCents: s.journalBalance(id),
+		})
+	}
+	return items, nil
+}
+
+func (s *Store) RecordDrift(_ context.Context, drift ports.BalanceDrift) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.drifts = append(s.drifts, drift)
+	return nil
+}
+
+func (s *Store) ListDrifts(_ context.Context, limit int) ([]ports.BalanceDrift, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	items := make([]ports.BalanceDrift, 0, limit)
+	for i := len(s.drifts) - 1; i >= 0 && len(items) < limit; i-- {
+		items = append(items, s.drifts[i])
+	}
+	return items, nil
+}
+
+func (s *Store) NewID(_ context.Context) (string, error) {
+	return s.nextID("led"), nil
+}
+
+func (s *Store) Now() time.Time {
+	return time.Now().UTC()
+}
+
+func (s *Store) journalBalance(accountID string) int64 {
+	var balance int64
+	for _, entry := range s.entries {
+		for _, posting := range entry.Postings {
+			if posting.AccountID == accountID {
+				balance += services.SignedAmount(posting.Direction, posting.AmountCents)
+			}
+		}
+	}
+	return balance
+}
+
+func (s *Store) nextID(prefix string) string {
+	n := atomic.AddUint64(&s.sequence, 1)
+	return fmt.Sprintf("%s_%d", prefix, n)
+}
+
+func postingFor(entry ports.JournalEntry, accountID string) (ports.Posting, bool) {
+	for _, posting := range entry.Postings {
+		if posting.AccountID == accountID {
+			return posting, true
+		}
+	}
+	return ports.Posting{}, false
+}
+
+func cloneEntry(in ports.JournalEntry) ports.JournalEntry {
+	out := in
+	out.Postings = append([]ports.Posting(nil), in.Postings...)
+	return out
+}
+
+var _ ports.Repository = (*Store)(nil)
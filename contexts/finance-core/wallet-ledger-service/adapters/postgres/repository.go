@@ -0,0 +1,391 @@
+package postgresadapter
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+	"time"
+
+	domainerrors "solomon/contexts/finance-core/wallet-ledger-service/domain/errors"
+	"solomon/contexts/finance-core/wallet-ledger-service/domain/services"
+	"solomon/contexts/finance-core/wallet-ledger-service/ports"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const signedAmountSQL = "CASE WHEN direction = 'credit' THEN amount_cents ELSE -amount_cents END"
+
+// Repository persists the journal, its postings and the running account
+// balances. Posting locks every touched account row and works from its
+// cached balance, so concurrent entries against one account serialise and
+// a post costs the same however long the account's history is. Reconcile
+// re-sums the journal to catch any drift in the cached balances.
+type Repository struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+// NewRepository builds the GORM-backed wallet ledger adapter.
+func NewRepository(db *gorm.DB, logger *slog.Logger) *Repository {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Repository{db: db, logger: logger}
+}
+
+func (r *Repository) PostEntry(ctx context.Context, entry ports.JournalEntry) (ports.JournalEntry, bool, error) {
+	if existing, found, err := r.getEntryByKey(r.db.WithContext(ctx), entry.IdempotencyKey); err != nil || found {
+		return existing, found, err
+	}
+
+	posted := entry
+	posted.Postings = append([]ports.Posting(nil), entry.Postings...)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&entryModel{
+			EntryID:        entry.EntryID,
+			IdempotencyKey: entry.IdempotencyKey,
+			RequestHash:    entry.RequestHash,
+			EntryType:      entry.EntryType,
+			ReferenceType:  entry.ReferenceType,
+			ReferenceID:    entry.ReferenceID,
+			Description:    entry.Description,
+			PostedAt:       entry.PostedAt.UTC(),
+		}).Error; err != nil {
+			return err
+		}
+
+		accountIDs, balances, err := lockAccounts(tx, posted.Postings, entry.PostedAt.UTC())
+		if err != nil {
+			return err
+		}
+		rows := make([]postingModel, 0, len(posted.Postings))
+		for i, posting := range posted.Postings {
+			accountType, _, _ := services.ParseAccount(posting.AccountID)
+			delta := services.SignedAmount(posting.Direction, posting.AmountCents)
+			balances[posting.AccountID] += delta
+			if balances[posting.AccountID] < 0 && !services.AllowsNegativeBalance(accountType) {
+				return domainerrors.ErrInsufficientFunds
+			}
+			posted.Postings[i].BalanceAfterCents = balances[posting.AccountID]
+			rows = append(rows, postingModel{
+				EntryID:           entry.EntryID,
+				LineNo:            i + 1,
+				AccountID:         posting.AccountID,
+				Direction:         posting.Direction,
+				AmountCents:       posting.AmountCents,
+				BalanceAfterCents: balances[posting.AccountID],
+				PostedAt:          entry.PostedAt.UTC(),
+			})
+		}
+		if err := tx.Create(&rows).Error; err != nil {
+			return err
+		}
+		for _, accountID := range accountIDs {
+			if err := tx.Model(&accountModel{}).
+				Where("account_id = ?", accountID).
+				Updates(map[string]any{
+					"cached_balance_cents": balances[accountID],
+					"updated_at":           entry.PostedAt.UTC(),
+				}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			// A concurrent post of the same key won the insert.
+			return r.getEntryByKey(r.db.WithContext(ctx), entry.IdempotencyKey)
+		}
+		return ports.JournalEntry{}, false, err
+	}
+	return posted, false, nil
+}
+
+func (r *Repository) GetAccount(ctx context.Context, accountID string) (ports.Account, error) {
+	var row accountModel
+	err := r.db.WithContext(ctx).Where("account_id = ?", accountID).First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ports.Account{}, domainerrors.ErrAccountNotFound
+	}
+	if err != nil {
+		return ports.Account{}, err
+	}
+	return row.toPort(), nil
+}
+
+func (r *Repository) JournalBalance(ctx context.Context, accountID string) (int64, error) {
+	balances, err := journalBalances(r.db.WithContext(ctx), []string{accountID})
+	if err != nil {
+		return 0, err
+	}
+	return balances[accountID], nil
+}
+
+func (r *Repository) ListEntriesByAccount(ctx context.Context, accountID string, limit int, offset int) ([]ports.JournalEntry, error) {
+	db := r.db.WithContext(ctx)
+	var rows []entryModel
+	if err := db.
+		Where("entry_id IN (?)", db.Model(&postingModel{}).Select("entry_id").Where("account_id = ?", accountID)).
+		Order("posted_at DESC, entry_id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return r.withPostings(db, rows)
+}
+
+// ListAccountBalances reads each cached balance and its journal sum in one
+// statement, so both come from one snapshot and a post committing mid-read
+// cannot show up as drift.
+func (r *Repository) ListAccountBalances(ctx context.Context, afterAccountID string, limit int) ([]ports.AccountBalance, error) {
+	var rows []struct {
+		AccountID           string
+		CachedBalanceCents  int64
+		JournalBalanceCents int64
+	}
+	journal := r.db.Model(&postingModel{}).
+		Select("COALESCE(SUM(" + signedAmountSQL + "), 0)").
+		Where("ledger_postings.account_id = ledger_accounts.account_id")
+	if err := r.db.WithContext(ctx).
+		Model(&accountModel{}).
+		Select("ledger_accounts.account_id, ledger_accounts.cached_balance_cents, (?) AS journal_balance_cents", journal).
+		Where("ledger_accounts.account_id > ?", afterAccountID).
+		Order("ledger_accounts.account_id ASC").
+		Limit(limit).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	items := make([]ports.AccountBalance, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, ports.AccountBalance{
+			AccountID:           row.AccountID,
+			CachedBalanceCents:  row.CachedBalanceCents,
+			JournalBalanceCents: row.JournalBalanceCents,
+		})
+	}
+	return items, nil
+}
+
+func (r *Repository) RecordDrift(ctx context.Context, drift ports.BalanceDrift) error {
+	return r.db.WithContext(ctx).Create(&driftModel{
+		DriftID:             drift.DriftID,
+		AccountID:           drift.AccountID,
+		CachedBalanceCents:  drift.CachedBalanceCents,
+		JournalBalanceCents: drift.JournalBalanceCents,
+		DriftCents:          drift.DriftCents,
+		DetectedAt:          drift.DetectedAt.UTC(),
+	}).Error
+}
+
+func (r *Repository) ListDrifts(ctx context.Context, limit int) ([]ports.BalanceDrift, error) {
+	var rows []driftModel
+	if err := r.db.WithContext(ctx).
+		Order("detected_at DESC, drift_id DESC").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	items := make([]ports.BalanceDrift, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, ports.BalanceDrift{
+			DriftID:             row.DriftID,
+			AccountID:           row.AccountID,
+			CachedBalanceCents:  row.CachedBalanceCents,
+			JournalBalanceCents: row.JournalBalanceCents,
+			DriftCents:          row.DriftCents,
+			DetectedAt:          row.DetectedAt.UTC(),
+		})
+	}
+	return items, nil
+}
+
+func (r *Repository) getEntryByKey(db *gorm.DB, idempotencyKey string) (ports.JournalEntry, bool, error) {
+	var rows []entryModel
+	if err := db.Where("idempotency_key = ?", idempotencyKey).Limit(1).Find(&rows).Error; err != nil {
+		return ports.JournalEntry{}, false, err
+	}
+	if len(rows) == 0 {
+		return ports.JournalEntry{}, false, nil
+	}
+	entries, err := r.withPostings(db, rows)
+	if err != nil {
+		return ports.JournalEntry{}, false, err
+	}
+	return entries[0], true, nil
+}
+
+func (r *Repository) withPostings(db *gorm.DB, rows []entryModel) ([]ports.JournalEntry, error) {
+	if len(rows) == 0 {
+		return []ports.JournalEntry{}, nil
+	}
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.EntryID)
+	}
+	var postings []postingModel
+	if err := db.Where("entry_id IN ?", ids).Order("entry_id, line_no").Find(&postings).Error; err != nil {
+		return nil, err
+	}
+	byEntry := make(map[string][]ports.Posting, len(rows))
+	for _, posting := range postings {
+		byEntry[posting.EntryID] = append(byEntry[posting.EntryID], ports.Posting{
+			AccountID:         posting.AccountID,
+			Direction:         posting.Direction,
+			AmountCents:       posting.AmountCents,
+			BalanceAfterCents: posting.BalanceAfterCents,
+		})
+	}
+	items := make([]ports.JournalEntry, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, ports.JournalEntry{
+			EntryID:        row.EntryID,
+			IdempotencyKey: row.IdempotencyKey,
+			RequestHash:    row.RequestHash,
+			EntryType:      row.EntryType,
+			ReferenceType:  row.ReferenceType,
+			ReferenceID:    row.ReferenceID,
+			Description:    row.Description,
+			Postings:       byEntry[row.EntryID],
+			PostedAt:       row.PostedAt.UTC(),
+		})
+	}
+	return items, nil
+}
+
+// lockAccounts creates any missing accounts and locks every touched account
+// row in ID order, so entries sharing accounts cannot deadlock. It returns
+// the locked rows' running balances.
+func lockAccounts(tx *gorm.DB, postings []ports.Posting, now time.Time) ([]string, map[string]int64, error) {
+	seen := make(map[string]bool, len(postings))
+	ids := make([]string, 0, len(postings))
+	for _, posting := range postings {
+		if !seen[posting.AccountID] {
+			seen[posting.AccountID] = true
+			ids = append(ids, posting.AccountID)
+		}
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		accountType, ownerID, ok := services.ParseAccount(id)
+		if !ok {
+			return nil, nil, domainerrors.ErrInvalidAccount
+		}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&accountModel{
+			AccountID:   id,
+			AccountType: accountType,
+			OwnerID:     ownerID,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}).Error; err != nil {
+			return nil, nil, err
+		}
+	}
+	var locked []accountModel
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("account_id IN ?", ids).
+		Order("account_id ASC").
+		Find(&locked).Error; err != nil {
+		return nil, nil, err
+	}
+	balances := make(map[string]int64, len(locked))
+	for _, account := range locked {
+		balances[account.AccountID] = account.CachedBalanceCents
+	}
+	return ids, balances, nil
+}
+
+func journalBalances(db *gorm.DB, accountIDs []string) (map[string]int64, error) {
+	balances := make(map[string]int64, len(accountIDs))
+	if len(accountIDs) == 0 {
+		return balances, nil
+	}
+	var rows []struct {
+		AccountID string
+		Balance   int64
+	}
+	if err := db.Model(&postingModel{}).
+		Select("account_id, COALESCE(SUM("+signedAmountSQL+"), 0) AS balance").
+		Where("account_id IN ?", accountIDs).
+		Group("account_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		balances[row.AccountID] = row.Balance
+	}
+	return balances, nil
+}
+
+type accountModel struct {
+	AccountID          string    `gorm:"column:account_id;primaryKey"`
+	AccountType        string    `gorm:"column:account_type"`
+	OwnerID            string    `gorm:"column:owner_id"`
+	CachedBalanceCents int64     `gorm:"column:cached_balance_cents"`
+	CreatedAt          time.Time `gorm:"column:created_at"`
+	UpdatedAt          time.Time `gorm:"column:updated_at"`
+}
+
+func (accountModel) TableName() string {
+	return "ledger_accounts"
+}
+
+func (m accountModel) toPort() ports.Account {
+	return ports.Account{
+		AccountID:          m.AccountID,
+		AccountType:        m.AccountType,
+		OwnerID:            m.OwnerID,
+		CachedBalanceCents: m.CachedBalanceCents,
+		CreatedAt:          m.CreatedAt.UTC(),
+		UpdatedAt:          m.UpdatedAt.UTC(),
+	}
+}
+
+type entryModel struct {
+	EntryID        string    `gorm:"column:entry_id;primaryKey"`
+	IdempotencyKey string    `gorm:"column:idempotency_key"`
+	RequestHash    string    `gorm:"column:request_hash"`
+	EntryType      string    `gorm:"column:entry_type"`
+	ReferenceType  string    `gorm:"column:reference_type"`
+	ReferenceID    string    `gorm:"column:reference_id"`
+	Description    string    `gorm:"column:description"`
+	PostedAt       time.Time `gorm:"column:posted_at"`
+}
+
+func (entryModel) TableName() string {
+	return "ledger_journal_entries"
+}
+
+type postingModel struct {
+	EntryID           string    `gorm:"column:entry_id;primaryKey"`
+	LineNo            int       `gorm:"column:line_no;primaryKey;autoIncrement:false"`
+	AccountID         string    `gorm:"column:account_id"`
+	Direction         string    `gorm:"column:direction"`
+	AmountCents       int64     `gorm:"column:amount_cents"`
+	BalanceAfterCents int64     `gorm:"column:balance_after_cents"`
+	PostedAt          time.Time `gorm:"column:posted_at"`
+}
+
+func (postingModel) TableName() string {
+	return "ledger_postings"
+}
+
+type driftModel struct {
+	DriftID             string    `gorm:"column:drift_id;primaryKey"`
+	AccountID           string    `gorm:"column:account_id"`
+	CachedBalanceCents  int64     `gorm:"column:cached_balance_cents"`
+	JournalBalanceCents int64     `gorm:"column:journal_balance_cents"`
+	DriftCents          int64     `gorm:"column:drift_cents"`
+	DetectedAt          time.Time `gorm:"column:detected_at"`
+}
+
+func (driftModel) TableName() string {
+	return "ledger_balance_drifts"
+}
+
+var _ ports.Repository = (*Repository)(nil)
@@ -0,0 +1,399 @@
+package application
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	domainerrors "solomon/contexts/finance-core/wallet-ledger-service/domain/errors"
+	"solomon/contexts/finance-core/wallet-ledger-service/domain/services"
+	"solomon/contexts/finance-core/wallet-ledger-service/ports"
+)
+
+const reconcilePageSize = 200
+
+type Service struct {
+	Repo   ports.Repository
+	Clock  ports.Clock
+	IDGen  ports.IDGenerator
+	Logger *slog.Logger
+}
+
+type PostEntryInput struct {
+	IdempotencyKey string
+	EntryType      string
+	ReferenceType  string
+	ReferenceID    string
+	Description    string
+	Postings       []ports.Posting
+}
+
+type AdminAdjustmentInput struct {
+	IdempotencyKey string
+	AdminID        string
+	UserID         string
+	AmountCents    int64
+	// Direction is credit to add to the wallet or debit to take from it.
+	Direction string
+	Reason    string
+}
+
+// ViewLockEarningInput is a view-locked submission's gross earnings and the
+// platform fee withheld from them; the creator is credited the rest.
+type ViewLockEarningInput struct {
+	SubmissionID string
+	CreatorID    string
+	CampaignID   string
+	GrossCents   int64
+	FeeCents     int64
+}
+
+// PayoutSettlementInput is a creator payout about to be transferred.
+// Reservation numbers the payout's debits: a payout whose transfer failed
+// for good is reversed, and an admin retry debits it again under the next
+// reservation.
+type PayoutSettlementInput struct {
+	PayoutID    string
+	CreatorID   string
+	AmountCents int64
+	Reservation int
+}
+
+// AccountStatement is an account with its journal-derived balance and a page
+// of the entries behind it.
+type AccountStatement struct {
+	Account             ports.Account
+	JournalBalanceCents int64
+	Entries             []ports.JournalEntry
+}
+
+type ReconciliationReport struct {
+	AccountsChecked int
+	Drifts          []ports.BalanceDrift
+}
+
+// PostEntry validates and posts a balanced journal entry. Re-posting an
+// idempotency key returns the original entry as replayed, or
+// ErrIdempotencyConflict when the postings differ.
+func (s Service) PostEntry(ctx context.Context, input PostEntryInput) (ports.JournalEntry, bool, error) {
+	input.IdempotencyKey = strings.TrimSpace(input.IdempotencyKey)
+	input.EntryType = strings.TrimSpace(input.EntryType)
+	if input.IdempotencyKey == "" {
+		return ports.JournalEntry{}, false, domainerrors.ErrIdempotencyKeyRequired
+	}
+	if input.EntryType == "" {
+		return ports.JournalEntry{}, false, domainerrors.ErrInvalidRequest
+	}
+	lines := make([]services.Line, 0, len(input.Postings))
+	postings := make([]ports.Posting, 0, len(input.Postings))
+	for _, posting := range input.Postings {
+		posting.AccountID = strings.TrimSpace(posting.AccountID)
+		posting.Direction = strings.ToLower(strings.TrimSpace(posting.Direction))
+		posting.BalanceAfterCents = 0
+		lines = append(lines, services.Line{
+			AccountID:   posting.AccountID,
+			Direction:   posting.Direction,
+			AmountCents: posting.AmountCents,
+		})
+		postings = append(postings, posting)
+	}
+	if err := services.ValidateEntry(lines); err != nil {
+		return ports.JournalEntry{}, false, err
+	}
+
+	entryID, err := s.IDGen.NewID(ctx)
+	if err != nil {
+		return ports.JournalEntry{}, false, err
+	}
+	entry := ports.JournalEntry{
+		EntryID:        entryID,
+		IdempotencyKey: input.IdempotencyKey,
+		RequestHash:    hashEntry(input.EntryType, input.ReferenceType, input.ReferenceID, postings),
+		EntryType:      input.EntryType,
+		ReferenceType:  strings.TrimSpace(input.ReferenceType),
+		ReferenceID:    strings.TrimSpace(input.ReferenceID),
+		Description:    strings.TrimSpace(input.Description),
+		Postings:       postings,
+		PostedAt:       s.now(),
+	}
+	posted, replayed, err := s.Repo.PostEntry(ctx, entry)
+	if err != nil {
+		return ports.JournalEntry{}, false, err
+	}
+	if replayed {
+		if posted.RequestHash != entry.RequestHash {
+			return ports.JournalEntry{}, false, domainerrors.ErrIdempotencyConflict
+		}
+		return posted, true, nil
+	}
+	resolveLogger(s.Logger).Info("journal entry posted",
+		"event", "wallet_ledger_entry_posted",
+		"module", "finance-core/wallet-ledger-service",
+		"layer", "application",
+		"entry_id", posted.EntryID,
+		"entry_type", posted.EntryType,
+		"reference_type", posted.ReferenceType,
+		"reference_id", posted.ReferenceID,
+	)
+	return posted, false, nil
+}
+
+// PostAdminAdjustment moves money between a user's wallet and the admin
+// adjustments account. Debits cannot overdraw the wallet.
+func (s Service) PostAdminAdjustment(ctx context.Context, input AdminAdjustmentInput) (ports.JournalEntry, bool, error) {
+	wallet := services.UserWalletAccount(input.UserID)
+	var postings []ports.Posting
+	switch strings.ToLower(strings.TrimSpace(input.Direction)) {
+	case services.Credit:
+		postings = transfer(services.AdminAdjustmentsAccount, wallet, input.AmountCents)
+	case services.Debit:
+		postings = transfer(wallet, services.AdminAdjustmentsAccount, input.AmountCents)
+	default:
+		return ports.JournalEntry{}, false, domainerrors.ErrInvalidRequest
+	}
+	if strings.TrimSpace(input.UserID) == "" || strings.TrimSpace(input.AdminID) == "" {
+		return ports.JournalEntry{}, false, domainerrors.ErrInvalidRequest
+	}
+	key := strings.TrimSpace(input.IdempotencyKey)
+	if key == "" {
+		return ports.JournalEntry{}, false, domainerrors.ErrIdempotencyKeyRequired
+	}
+	adminID := strings.TrimSpace(input.AdminID)
+	return s.PostEntry(ctx, PostEntryInput{
+		// Keys are scoped by admin, so two admins reusing a key do not
+		// replay each other's adjustments.
+		IdempotencyKey: "admin_adjustment:" + adminID + ":" + key,
+		EntryType:      ports.EntryTypeAdminAdjustment,
+		ReferenceType:  "admin",
+		ReferenceID:    adminID,
+		Description:    input.Reason,
+		Postings:       postings,
+	})
+}
+
+// PostViewLockEarning debits the campaign for the gross earnings of a
+// view-locked submission and splits them in one entry: the net to the
+// creator's wallet and the fee to the platform fees account. One entry per
+// submission.
+func (s Service) PostViewLockEarning(ctx context.Context, input ViewLockEarningInput) (ports.JournalEntry, bool, error) {
+	submissionID := strings.TrimSpace(input.SubmissionID)
+	if submissionID == "" || strings.TrimSpace(input.CreatorID) == "" || strings.TrimSpace(input.CampaignID) == "" {
+		return ports.JournalEntry{}, false, domainerrors.ErrInvalidRequest
+	}
+	if input.FeeCents < 0 || input.FeeCents > input.GrossCents {
+		return ports.JournalEntry{}, false, domainerrors.ErrInvalidRequest
+	}
+	postings := []ports.Posting{
+		{AccountID: services.CampaignPayoutsAccount(input.CampaignID), Direction: services.Debit, AmountCents: input.GrossCents},
+	}
+	if net := input.GrossCents - input.FeeCents; net > 0 {
+		postings = append(postings, ports.Posting{AccountID: services.UserWalletAccount(input.CreatorID), Direction: services.Credit, AmountCents: net})
+	}
+	if input.FeeCents > 0 {
+		postings = append(postings, ports.Posting{AccountID: services.PlatformFeesAccount, Direction: services.Credit, AmountCents: input.FeeCents})
+	}
+	return s.PostEntry(ctx, PostEntryInput{
+		IdempotencyKey: "view_lock:" + submissionID,
+		EntryType:      ports.EntryTypeViewLockEarning,
+		ReferenceType:  "submission",
+		ReferenceID:    submissionID,
+		Description:    "view-locked submission earnings",
+		Postings:       postings,
+	})
+}
+
+// PostPayoutSettlement moves a payout out of the creator's wallet into the
+// payout clearing account before it is transferred. One entry per payout
+// reservation, so a settlement retried after a partial failure posts nothing
+// new. Like any wallet debit it cannot overdraw the wallet.
+func (s Service) PostPayoutSettlement(ctx context.Context, input PayoutSettlementInput) (ports.JournalEntry, bool, error) {
+	payoutID := strings.TrimSpace(input.PayoutID)
+	if payoutID == "" || strings.TrimSpace(input.CreatorID) == "" {
+		return ports.JournalEntry{}, false, domainerrors.ErrInvalidRequest
+	}
+	return s.PostEntry(ctx, PostEntryInput{
+		IdempotencyKey: payoutEntryKey("payout:", payoutID, input.Reservation),
+		EntryType:      ports.EntryTypePayoutSettlement,
+		ReferenceType:  "payout",
+		ReferenceID:    payoutID,
+		Description:    "creator payout reserved for transfer",
+		Postings: transfer(
+			services.UserWalletAccount(input.CreatorID),
+			services.PayoutClearingAccount,
+			input.AmountCents,
+		),
+	})
+}
+
+// ReversePayoutSettlement returns a reserved payout from the clearing
+// account to the creator's wallet once its transfer has failed for good.
+// One entry per payout reservation.
+func (s Service) ReversePayoutSettlement(ctx context.Context, input PayoutSettlementInput) (ports.JournalEntry, bool, error) {
+	payoutID := strings.TrimSpace(input.PayoutID)
+	if payoutID == "" || strings.TrimSpace(input.CreatorID) == "" {
+		return ports.JournalEntry{}, false, domainerrors.ErrInvalidRequest
+	}
+	return s.PostEntry(ctx, PostEntryInput{
+		IdempotencyKey: payoutEntryKey("payout_reversal:", payoutID, input.Reservation),
+		EntryType:      ports.EntryTypePayoutReversal,
+		ReferenceType:  "payout",
+		ReferenceID:    payoutID,
+		Description:    "creator payout transfer failed",
+		Postings: transfer(
+			services.PayoutClearingAccount,
+			services.UserWalletAccount(input.CreatorID),
+			input.AmountCents,
+		),
+	})
+}
+
+// GetAccountStatement loads an account with its journal balance and a page
+// of its entries, newest first.
+func (s Service) GetAccountStatement(ctx context.Context, accountID string, limit int, offset int) (AccountStatement, error) {
+	accountID = strings.TrimSpace(accountID)
+	if _, _, ok := services.ParseAccount(accountID); !ok {
+		return AccountStatement{}, domainerrors.ErrInvalidAccount
+	}
+	if offset < 0 {
+		return AccountStatement{}, domainerrors.ErrInvalidRequest
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	account, err := s.Repo.GetAccount(ctx, accountID)
+	if err != nil {
+		return AccountStatement{}, err
+	}
+	balance, err := s.Repo.JournalBalance(ctx, accountID)
+	if err != nil {
+		return AccountStatement{}, err
+	}
+	entries, err := s.Repo.ListEntriesByAccount(ctx, accountID, limit, offset)
+	if err != nil {
+		return AccountStatement{}, err
+	}
+	return AccountStatement{Account: account, JournalBalanceCents: balance, Entries: entries}, nil
+}
+
+// Reconcile compares every account's cached balance with its journal and
+// flags the accounts that drifted. It never corrects the cache, so a drift
+// stays visible until someone investigates it.
+func (s Service) Reconcile(ctx context.Context) (ReconciliationReport, error) {
+	logger := resolveLogger(s.Logger)
+	report := ReconciliationReport{}
+	after := ""
+	for {
+		page, err := s.Repo.ListAccountBalances(ctx, after, reconcilePageSize)
+		if err != nil {
+			return report, err
+		}
+		for _, balance := range page {
+			report.AccountsChecked++
+			if balance.CachedBalanceCents == balance.JournalBalanceCents {
+				continue
+			}
+			driftID, err := s.IDGen.NewID(ctx)
+			if err != nil {
+				return report, err
+			}
+			drift := ports.BalanceDrift{
+				DriftID:             driftID,
+				AccountID:           balance.AccountID,
+				CachedBalanceCents:  balance.CachedBalanceCents,
+				JournalBalanceCents: balance.JournalBalanceCents,
+				DriftCents:          balance.CachedBalanceCents - balance.JournalBalanceCents,
+				DetectedAt:          s.now(),
+			}
+			if err := s.Repo.RecordDrift(ctx, drift); err != nil {
+				return report, err
+			}
+			report.Drifts = append(report.Drifts, drift)
+			logger.Warn("ledger account balance drifted from journal",
+				"event", "wallet_ledger_balance_drift",
+				"module", "finance-core/wallet-ledger-service",
+				"layer", "application",
+				"account_id", drift.AccountID,
+				"cached_balance_cents", drift.CachedBalanceCents,
+				"journal_balance_cents", drift.JournalBalanceCents,
+			)
+		}
+		if len(page) < reconcilePageSize {
+			break
+		}
+		after = page[len(page)-1].AccountID
+	}
+	logger.Info("ledger reconciliation completed",
+		"event", "wallet_ledger_reconciliation_completed",
+		"module", "finance-core/wallet-ledger-service",
+		"layer", "application",
+		"accounts_checked", report.AccountsChecked,
+		"drift_count", len(report.Drifts),
+	)
+	return report, nil
+}
+
+func (s Service) ListDrifts(ctx context.Context, limit int) ([]ports.BalanceDrift, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 200 {
+		limit = 200
+	}
+	return s.Repo.ListDrifts(ctx, limit)
+}
+
+// PostingFor returns the entry's posting against accountID.
+func PostingFor(entry ports.JournalEntry, accountID string) (ports.Posting, bool) {
+	for _, posting := range entry.Postings {
+		if posting.AccountID == accountID {
+			return posting, true
+		}
+	}
+	return ports.Posting{}, false
+}
+
+// BalanceBefore is the account balance just before posting was applied.
+func BalanceBefore(posting ports.Posting) int64 {
+	return posting.BalanceAfterCents - services.SignedAmount(posting.Direction, posting.AmountCents)
+}
+
+func (s Service) now() time.Time {
+	if s.Clock == nil {
+		return time.Now().UTC()
+	}
+	return s.Clock.Now().UTC()
+}
+
+// transfer debits from and credits to with the same amount.
+// payoutEntryKey keys a payout's entries by reservation. The first
+// reservation keeps the bare payout key settlements were posted under
+// before payouts could be reversed.
+func payoutEntryKey(prefix string, payoutID string, reservation int) string {
+	if reservation <= 1 {
+		return prefix + payoutID
+	}
+	return fmt.Sprintf("%s%s:%d", prefix, payoutID, reservation)
+}
+
+func transfer(from string, to string, amountCents int64) []ports.Posting {
+	return []ports.Posting{
+		{AccountID: from, Direction: services.Debit, AmountCents: amountCents},
+		{AccountID: to, Direction: services.Credit, AmountCents: amountCents},
+	}
+}
+
+func hashEntry(entryType string, referenceType string, referenceID string, postings []ports.Posting) string {
+	values := []string{entryType, strings.TrimSpace(referenceType), strings.TrimSpace(referenceID)}
+	for _, posting := range postings {
+		values = append(values, fmt.Sprintf("%s:%s:%d", posting.AccountID, posting.Direction, posting.AmountCents))
+	}
+	sum := sha256.Sum256([]byte(strings.Join(values, "|")))
+	return hex.EncodeToString(sum[:])
+}
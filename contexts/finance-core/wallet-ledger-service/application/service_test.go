@@ -0,0 +1,332 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"solomon/contexts/finance-core/wallet-ledger-service/adapters/memory"
+	domainerrors "solomon/contexts/finance-core/wallet-ledger-service/domain/errors"
+	"solomon/contexts/finance-core/wallet-ledger-service/domain/services"
+	"solomon/contexts/finance-core/wallet-ledger-service/ports"
+)
+
+func TestPostEntryRejectsUnbalancedPostings(t *testing.T) {
+	service := newTestService(memory.NewStore())
+
+	_, _, err := service.PostEntry(context.Background(), PostEntryInput{
+		IdempotencyKey: "manual-1",
+		EntryType:      ports.EntryTypeAdminAdjustment,
+		Postings: []ports.Posting{
+			{AccountID: services.AdminAdjustmentsAccount, Direction: services.Debit, AmountCents: 500},
+			{AccountID: services.UserWalletAccount("user-1"), Direction: services.Credit, AmountCents: 400},
+		},
+	})
+	if !errors.Is(err, domainerrors.ErrUnbalancedEntry) {
+		t.Fatalf("expected unbalanced entry, got %v", err)
+	}
+}
+
+func TestViewLockEarningCreditsNetAndWithholdsFeeInOneEntry(t *testing.T) {
+	service := newTestService(memory.NewStore())
+	ctx := context.Background()
+
+	entry, _, err := service.PostViewLockEarning(ctx, ViewLockEarningInput{
+		SubmissionID: "sub-fee-1",
+		CreatorID:    "creator-2",
+		CampaignID:   "campaign-2",
+		GrossCents:   1000,
+		FeeCents:     150,
+	})
+	if err != nil {
+		t.Fatalf("post view-lock earning failed: %v", err)
+	}
+	if len(entry.Postings) != 3 {
+		t.Fatalf("expected gross debit, net credit and fee credit, got %+v", entry.Postings)
+	}
+	for accountID, want := range map[string]int64{
+		services.UserWalletAccount("creator-2"):       850,
+		services.CampaignPayoutsAccount("campaign-2"): -1000,
+		services.PlatformFeesAccount:                  150,
+	} {
+		statement, err := service.GetAccountStatement(ctx, accountID, 20, 0)
+		if err != nil {
+			t.Fatalf("statement for %s failed: %v", accountID, err)
+		}
+		if statement.JournalBalanceCents != want || statement.Account.CachedBalanceCents != want {
+			t.Fatalf("%s: expected %d, got journal=%d cached=%d",
+				accountID, want, statement.JournalBalanceCents, statement.Account.CachedBalanceCents)
+		}
+	}
+
+	if _, _, err := service.PostViewLockEarning(ctx, ViewLockEarningInput{
+		SubmissionID: "sub-fee-2",
+		CreatorID:    "creator-2",
+		CampaignID:   "campaign-2",
+		GrossCents:   100,
+		FeeCents:     101,
+	}); !errors.Is(err, domainerrors.ErrInvalidRequest) {
+		t.Fatalf("expected a fee above gross to be rejected, got %v", err)
+	}
+}
+
+func TestViewLockEarningWithoutFeeCreditsTheGross(t *testing.T) {
+	service := newTestService(memory.NewStore())
+	ctx := context.Background()
+
+	entry, _, err := service.PostViewLockEarning(ctx, ViewLockEarningInput{
+		SubmissionID: "sub-1",
+		CreatorID:    "creator-1",
+		CampaignID:   "campaign-1",
+		GrossCents:   1000,
+	})
+	if err != nil {
+		t.Fatalf("post view-lock earning failed: %v", err)
+	}
+	if len(entry.Postings) != 2 {
+		t.Fatalf("expected gross debit and net credit only, got %+v", entry.Postings)
+	}
+
+	for accountID, want := range map[string]int64{
+		services.UserWalletAccount("creator-1"):       1000,
+		services.CampaignPayoutsAccount("campaign-1"): -1000,
+	} {
+		statement, err := service.GetAccountStatement(ctx, accountID, 20, 0)
+		if err != nil {
+			t.Fatalf("statement for %s failed: %v", accountID, err)
+		}
+		if statement.JournalBalanceCents != want || statement.Account.CachedBalanceCents != want {
+			t.Fatalf("%s: expected %d, got journal=%d cached=%d",
+				accountID, want, statement.JournalBalanceCents, statement.Account.CachedBalanceCents)
+		}
+	}
+}
+
+func TestPayoutSettlementDebitsWalletOncePerPayout(t *testing.T) {
+	service := newTestService(memory.NewStore())
+	ctx := context.Background()
+
+	if _, _, err := service.PostViewLockEarning(ctx, ViewLockEarningInput{
+		SubmissionID: "sub-pay-1",
+		CreatorID:    "creator-3",
+		CampaignID:   "campaign-3",
+		GrossCents:   3000,
+		FeeCents:     450,
+	}); err != nil {
+		t.Fatalf("post view-lock earning failed: %v", err)
+	}
+	settlement := PayoutSettlementInput{PayoutID: "pay-1", CreatorID: "creator-3", AmountCents: 2550}
+	if _, replayed, err := service.PostPayoutSettlement(ctx, settlement); err != nil || replayed {
+		t.Fatalf("post payout settlement: replayed=%v err=%v", replayed, err)
+	}
+	if _, replayed, err := service.PostPayoutSettlement(ctx, settlement); err != nil || !replayed {
+		t.Fatalf("expected settlement retry to replay, got replayed=%v err=%v", replayed, err)
+	}
+	for accountID, want := range map[string]int64{
+		services.UserWalletAccount("creator-3"): 0,
+		services.PayoutClearingAccount:          2550,
+	} {
+		statement, err := service.GetAccountStatement(ctx, accountID, 20, 0)
+		if err != nil {
+			t.Fatalf("statement for %s failed: %v", accountID, err)
+		}
+		if statement.JournalBalanceCents != want || statement.Account.CachedBalanceCents != want {
+			t.Fatalf("%s: expected %d, got journal=%d cached=%d",
+				accountID, want, statement.JournalBalanceCents, statement.Account.CachedBalanceCents)
+		}
+	}
+
+	if _, _, err := service.PostPayoutSettlement(ctx, PayoutSettlementInput{
+		PayoutID:    "pay-2",
+		CreatorID:   "creator-3",
+		AmountCents: 1,
+	}); !errors.Is(err, domainerrors.ErrInsufficientFunds) {
+		t.Fatalf("expected a settlement above the wallet balance to be rejected, got %v", err)
+	}
+}
+
+func TestPayoutReversalReturnsReservationToWallet(t *testing.T) {
+	service := newTestService(memory.NewStore())
+	ctx := context.Background()
+
+	if _, _, err := service.PostViewLockEarning(ctx, ViewLockEarningInput{
+		SubmissionID: "sub-rev-1",
+		CreatorID:    "creator-4",
+		CampaignID:   "campaign-4",
+		GrossCents:   2000,
+		FeeCents:     0,
+	}); err != nil {
+		t.Fatalf("post view-lock earning failed: %v", err)
+	}
+	first := PayoutSettlementInput{PayoutID: "pay-rev", CreatorID: "creator-4", AmountCents: 2000, Reservation: 1}
+	if _, _, err := service.PostPayoutSettlement(ctx, first); err != nil {
+		t.Fatalf("post payout settlement: %v", err)
+	}
+	if _, replayed, err := service.ReversePayoutSettlement(ctx, first); err != nil || replayed {
+		t.Fatalf("reverse payout settlement: replayed=%v err=%v", replayed, err)
+	}
+	if _, replayed, err := service.ReversePayoutSettlement(ctx, first); err != nil || !replayed {
+		t.Fatalf("expected reversal retry to replay, got replayed=%v err=%v", replayed, err)
+	}
+
+	second := first
+	second.Reservation = 2
+	if _, replayed, err := service.PostPayoutSettlement(ctx, second); err != nil || replayed {
+		t.Fatalf("expected the next reservation to debit again, got replayed=%v err=%v", replayed, err)
+	}
+	for accountID, want := range map[string]int64{
+		services.UserWalletAccount("creator-4"): 0,
+		services.PayoutClearingAccount:          2000,
+	} {
+		statement, err := service.GetAccountStatement(ctx, accountID, 20, 0)
+		if err != nil {
+			t.Fatalf("statement for %s failed: %v", accountID, err)
+		}
+		if statement.JournalBalanceCents != want || statement.Account.CachedBalanceCents != want {
+			t.Fatalf("%s: expected %d, got journal=%d cached=%d",
+				accountID, want, statement.JournalBalanceCents, statement.Account.CachedBalanceCents)
+		}
+	}
+}
+
+func TestAdminAdjustmentReplaysAndDetectsConflicts(t *testing.T) {
+	service := newTestService(memory.NewStore())
+	ctx := context.Background()
+	input := AdminAdjustmentInput{
+		IdempotencyKey: "adj-1",
+		AdminID:        "admin-1",
+		UserID:         "user-1",
+		AmountCents:    2500,
+		Direction:      services.Credit,
+		Reason:         "goodwill",
+	}
+
+	first, replayed, err := service.PostAdminAdjustment(ctx, input)
+	if err != nil || replayed {
+		t.Fatalf("first adjustment: replayed=%v err=%v", replayed, err)
+	}
+	again, replayed, err := service.PostAdminAdjustment(ctx, input)
+	if err != nil || !replayed || again.EntryID != first.EntryID {
+		t.Fatalf("expected replay of %s, got %s replayed=%v err=%v", first.EntryID, again.EntryID, replayed, err)
+	}
+
+	input.AmountCents = 3000
+	if _, _, err := service.PostAdminAdjustment(ctx, input); !errors.Is(err, domainerrors.ErrIdempotencyConflict) {
+		t.Fatalf("expected idempotency conflict, got %v", err)
+	}
+
+	input.AdminID = "admin-2"
+	other, replayed, err := service.PostAdminAdjustment(ctx, input)
+	if err != nil || replayed || other.EntryID == first.EntryID {
+		t.Fatalf("expected another admin's key to post a new entry, got %s replayed=%v err=%v", other.EntryID, replayed, err)
+	}
+
+	statement, err := service.GetAccountStatement(ctx, services.UserWalletAccount("user-1"), 20, 0)
+	if err != nil {
+		t.Fatalf("statement failed: %v", err)
+	}
+	if statement.JournalBalanceCents != 55500 {
+		t.Fatalf("expected one credit per admin on top of the opening balance, got %d", statement.JournalBalanceCents)
+	}
+}
+
+func TestAdminDebitCannotOverdrawWallet(t *testing.T) {
+	service := newTestService(memory.NewStore())
+
+	_, _, err := service.PostAdminAdjustment(context.Background(), AdminAdjustmentInput{
+		IdempotencyKey: "adj-overdraw",
+		AdminID:        "admin-1",
+		UserID:         "user-2",
+		AmountCents:    15001,
+		Direction:      services.Debit,
+		Reason:         "chargeback",
+	})
+	if !errors.Is(err, domainerrors.ErrInsufficientFunds) {
+		t.Fatalf("expected insufficient funds, got %v", err)
+	}
+}
+
+func TestReconcileFlagsDriftWithoutCorrectingIt(t *testing.T) {
+	store := &driftingStore{Store: memory.NewStore(), accountID: services.UserWalletAccount("user-1"), skewCents: 700}
+	service := newTestService(store)
+	ctx := context.Background()
+
+	report, err := service.Reconcile(ctx)
+	if err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	if report.AccountsChecked == 0 || len(report.Drifts) != 1 {
+		t.Fatalf("expected one drift, got checked=%d drifts=%d", report.AccountsChecked, len(report.Drifts))
+	}
+	if drift := report.Drifts[0]; drift.AccountID != store.accountID || drift.DriftCents != 700 {
+		t.Fatalf("unexpected drift %+v", drift)
+	}
+
+	recorded, err := service.ListDrifts(ctx, 10)
+	if err != nil || len(recorded) != 1 {
+		t.Fatalf("expected recorded drift, got %d err=%v", len(recorded), err)
+	}
+}
+
+func TestReconcileDuringConcurrentPostsFlagsNoDrift(t *testing.T) {
+	service := newTestService(memory.NewStore())
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			if _, _, err := service.PostAdminAdjustment(ctx, AdminAdjustmentInput{
+				IdempotencyKey: fmt.Sprintf("concurrent-%d", i),
+				AdminID:        "admin-1",
+				UserID:         "user-1",
+				AmountCents:    100,
+				Direction:      services.Credit,
+				Reason:         "goodwill",
+			}); err != nil {
+				t.Errorf("post %d failed: %v", i, err)
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < 50; i++ {
+		report, err := service.Reconcile(ctx)
+		if err != nil {
+			t.Fatalf("reconcile failed: %v", err)
+		}
+		if len(report.Drifts) != 0 {
+			t.Fatalf("expected no drift while posting, got %+v", report.Drifts)
+		}
+	}
+	wg.Wait()
+}
+
+// driftingStore reports a skewed cached balance for one account, as a cache
+// update lost outside the posting transaction would.
+type driftingStore struct {
+	*memory.Store
+	accountID string
+	skewCents int64
+}
+
+func (s *driftingStore) ListAccountBalances(ctx context.Context, afterAccountID string, limit int) ([]ports.AccountBalance, error) {
+	items, err := s.Store.ListAccountBalances(ctx, afterAccountID, limit)
+	for i := range items {
+		if items[i].AccountID == s.accountID {
+			items[i].CachedBalanceCents += s.skewCents
+		}
+	}
+	return items, err
+}
+
+func newTestService(repo interface {
+	ports.Repository
+	ports.Clock
+	ports.IDGenerator
+}) Service {
+	return Service{Repo: repo, Clock: repo, IDGen: repo}
+}
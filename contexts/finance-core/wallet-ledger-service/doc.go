@@ -0,0 +1,2 @@
+// Package walletledgerservice implements the double-entry wallet ledger in Solomon.
+package walletledgerservice
@@ -0,0 +1,15 @@
+package errors
+
+import "errors"
+
+var (
+	ErrInvalidRequest         = errors.New("invalid request")
+	ErrIdempotencyKeyRequired = errors.New("idempotency key is required")
+	ErrIdempotencyConflict    = errors.New("idempotency key reused with different postings")
+	ErrNotFound               = errors.New("resource not found")
+
+	ErrAccountNotFound   = errors.New("ledger account not found")
+	ErrInvalidAccount    = errors.New("invalid ledger account")
+	ErrUnbalancedEntry   = errors.New("journal entry debits and credits do not balance")
+	ErrInsufficientFunds = errors.New("insufficient wallet balance")
+)
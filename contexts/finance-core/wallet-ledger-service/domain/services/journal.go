@@ -0,0 +1,124 @@
+package services
+
+import (
+	"strings"
+
+	domainerrors "solomon/contexts/finance-core/wallet-ledger-service/domain/errors"
+)
+
+// Posting directions.
+const (
+	Debit  = "debit"
+	Credit = "credit"
+)
+
+// Account types. An account ID encodes its type: user wallets are
+// user:<user_id>, campaign payout accounts campaign:<campaign_id> and the
+// platform accounts are fixed IDs under platform:.
+const (
+	AccountTypeUserWallet       = "user_wallet"
+	AccountTypeCampaignPayouts  = "campaign_payouts"
+	AccountTypePlatformFees     = "platform_fees"
+	AccountTypeAdminAdjustments = "admin_adjustments"
+	AccountTypeOpeningBalances  = "opening_balances"
+	AccountTypePayoutClearing   = "payout_clearing"
+)
+
+const (
+	PlatformFeesAccount     = "platform:fees"
+	AdminAdjustmentsAccount = "platform:admin_adjustments"
+	OpeningBalancesAccount  = "platform:opening_balances"
+	// PayoutClearingAccount receives wallet money once a payout has been
+	// transferred to the creator outside the platform.
+	PayoutClearingAccount = "platform:payout_clearing"
+)
+
+var platformAccounts = map[string]string{
+	PlatformFeesAccount:     AccountTypePlatformFees,
+	AdminAdjustmentsAccount: AccountTypeAdminAdjustments,
+	OpeningBalancesAccount:  AccountTypeOpeningBalances,
+	PayoutClearingAccount:   AccountTypePayoutClearing,
+}
+
+// UserWalletAccount is the wallet account of a user.
+func UserWalletAccount(userID string) string {
+	return "user:" + strings.TrimSpace(userID)
+}
+
+// CampaignPayoutsAccount funds the earnings a campaign pays out to creators.
+func CampaignPayoutsAccount(campaignID string) string {
+	return "campaign:" + strings.TrimSpace(campaignID)
+}
+
+// ParseAccount returns the type and owner encoded in an account ID.
+func ParseAccount(accountID string) (string, string, bool) {
+	accountID = strings.TrimSpace(accountID)
+	if accountType, ok := platformAccounts[accountID]; ok {
+		return accountType, "", true
+	}
+	kind, owner, found := strings.Cut(accountID, ":")
+	if !found || strings.TrimSpace(owner) == "" || strings.ContainsAny(owner, " \t\n") {
+		return "", "", false
+	}
+	switch kind {
+	case "user":
+		return AccountTypeUserWallet, owner, true
+	case "campaign":
+		return AccountTypeCampaignPayouts, owner, true
+	default:
+		return "", "", false
+	}
+}
+
+// AllowsNegativeBalance reports whether an account may be overdrawn. User
+// wallets may not; campaign and platform accounts are the contra side that
+// funds wallets and run negative by design.
+func AllowsNegativeBalance(accountType string) bool {
+	return accountType != AccountTypeUserWallet
+}
+
+// SignedAmount is a posting's effect on a balance. Balances are
+// credit-normal: credits add to them and debits subtract.
+func SignedAmount(direction string, amountCents int64) int64 {
+	if direction == Debit {
+		return -amountCents
+	}
+	return amountCents
+}
+
+// Line is one side of a journal entry.
+type Line struct {
+	AccountID   string
+	Direction   string
+	AmountCents int64
+}
+
+// ValidateEntry checks that an entry has at least one debit and one credit,
+// that every line is a positive amount against a known account and that
+// total debits equal total credits.
+func ValidateEntry(lines []Line) error {
+	if len(lines) < 2 {
+		return domainerrors.ErrUnbalancedEntry
+	}
+	var debits, credits int64
+	for _, line := range lines {
+		if _, _, ok := ParseAccount(line.AccountID); !ok {
+			return domainerrors.ErrInvalidAccount
+		}
+		if line.AmountCents <= 0 {
+			return domainerrors.ErrInvalidRequest
+		}
+		switch line.Direction {
+		case Debit:
+			debits += line.AmountCents
+		case Credit:
+			credits += line.AmountCents
+		default:
+			return domainerrors.ErrInvalidRequest
+		}
+	}
+	if debits == 0 || debits != credits {
+		return domainerrors.ErrUnbalancedEntry
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package walletledgerservice
+
+import (
+	"context"
+	"log/slog"
+
+	httpadapter "solomon/contexts/finance-core/wallet-ledger-service/adapters/http"
+	"solomon/contexts/finance-core/wallet-ledger-service/adapters/memory"
+	"solomon/contexts/finance-core/wallet-ledger-service/application"
+	"solomon/contexts/finance-core/wallet-ledger-service/ports"
+)
+
+type Module struct {
+	Handler httpadapter.Handler
+	Store   *memory.Store
+}
+
+type Dependencies struct {
+	Repository  ports.Repository
+	Clock       ports.Clock
+	IDGenerator ports.IDGenerator
+	Logger      *slog.Logger
+}
+
+func NewModule(deps Dependencies) Module {
+	service := application.Service{
+		Repo:   deps.Repository,
+		Clock:  deps.Clock,
+		IDGen:  deps.IDGenerator,
+		Logger: deps.Logger,
+	}
+	return Module{
+		Handler: httpadapter.Handler{
+			Service: service,
+			Logger:  deps.Logger,
+		},
+	}
+}
+
+// Reconcile checks every cached account balance against the journal and
+// flags the accounts that drifted.
+func (m Module) Reconcile(ctx context.Context) error {
+	_, err := m.Handler.Service.Reconcile(ctx)
+	return err
+}
+
+func NewInMemoryModule(logger *slog.Logger) Module {
+	store := memory.NewStore()
+	module := NewModule(Dependencies{
+		Repository:  store,
+		Clock:       store,
+		IDGenerator: store,
+		Logger:      logger,
+	})
+	module.Store = store
+	return module
+}
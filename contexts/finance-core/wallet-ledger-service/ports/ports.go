@@ -0,0 +1,95 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+type Clock interface {
+	Now() time.Time
+}
+
+type IDGenerator interface {
+	NewID(ctx context.Context) (string, error)
+}
+
+const (
+	EntryTypeOpeningBalance   = "opening_balance"
+	EntryTypeAdminAdjustment  = "admin_adjustment"
+	EntryTypeViewLockEarning  = "view_lock_earning"
+	EntryTypePayoutSettlement = "payout_settlement"
+	EntryTypePayoutReversal   = "payout_reversal"
+)
+
+// Posting is one line of a journal entry. BalanceAfterCents is the account's
+// journal balance once the posting was applied, stamped when it is posted.
+type Posting struct {
+	AccountID         string
+	Direction         string
+	AmountCents       int64
+	BalanceAfterCents int64
+}
+
+// JournalEntry is immutable once posted. Its idempotency key is unique, so
+// posting the same key again returns the original entry.
+type JournalEntry struct {
+	EntryID        string
+	IdempotencyKey string
+	RequestHash    string
+	EntryType      string
+	ReferenceType  string
+	ReferenceID    string
+	Description    string
+	Postings       []Posting
+	PostedAt       time.Time
+}
+
+// Account carries a cached balance kept up to date on every posting. The
+// journal remains the source of truth; the cache only serves fast reads and
+// is checked against the journal by reconciliation.
+type Account struct {
+	AccountID          string
+	AccountType        string
+	OwnerID            string
+	CachedBalanceCents int64
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// AccountBalance pairs an account's cached balance with the balance derived
+// from its journal postings.
+type AccountBalance struct {
+	AccountID           string
+	CachedBalanceCents  int64
+	JournalBalanceCents int64
+}
+
+// BalanceDrift flags an account whose cached balance disagreed with its
+// journal when reconciled.
+type BalanceDrift struct {
+	DriftID             string
+	AccountID           string
+	CachedBalanceCents  int64
+	JournalBalanceCents int64
+	DriftCents          int64
+	DetectedAt          time.Time
+}
+
+type Repository interface {
+	// PostEntry atomically stores the entry, creates missing accounts, stamps
+	// each posting with the account's journal balance after it and applies it
+	// to the cached balances. An already posted idempotency key returns the
+	// stored entry and true. A posting that would overdraw an account which
+	// may not go negative fails with ErrInsufficientFunds.
+	PostEntry(ctx context.Context, entry JournalEntry) (JournalEntry, bool, error)
+	GetAccount(ctx context.Context, accountID string) (Account, error)
+	// JournalBalance sums the account's postings.
+	JournalBalance(ctx context.Context, accountID string) (int64, error)
+	// ListEntriesByAccount pages the entries touching an account, newest first.
+	ListEntriesByAccount(ctx context.Context, accountID string, limit int, offset int) ([]JournalEntry, error)
+	// ListAccountBalances pages accounts in ID order after afterAccountID.
+	ListAccountBalances(ctx context.Context, afterAccountID string, limit int) ([]AccountBalance, error)
+	RecordDrift(ctx context.Context, drift BalanceDrift) error
+	// ListDrifts returns flagged drifts, newest first.
+	ListDrifts(ctx context.Context, limit int) ([]BalanceDrift, error)
+}
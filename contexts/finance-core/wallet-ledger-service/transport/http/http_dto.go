@@ -0,0 +1,80 @@
+package http
+
+type ErrorResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type AdminAdjustmentRequest struct {
+	IdempotencyKey string `json:"idempotency_key"`
+	AdminID        string `json:"admin_id"`
+	UserID         string `json:"user_id"`
+	AmountCents    int64  `json:"amount_cents"`
+	Direction      string `json:"direction"`
+	Reason         string `json:"reason"`
+}
+
+type ViewLockEarningRequest struct {
+	SubmissionID string `json:"submission_id"`
+	CreatorID    string `json:"creator_id"`
+	CampaignID   string `json:"campaign_id"`
+	GrossCents   int64  `json:"gross_cents"`
+	FeeCents     int64  `json:"fee_cents"`
+}
+
+type PayoutSettlementRequest struct {
+	PayoutID    string `json:"payout_id"`
+	CreatorID   string `json:"creator_id"`
+	AmountCents int64  `json:"amount_cents"`
+	Reservation int    `json:"reservation"`
+}
+
+// WalletPostingResponse reports an entry and its effect on the user wallet
+// it touched.
+type WalletPostingResponse struct {
+	EntryID            string `json:"entry_id"`
+	AccountID          string `json:"account_id"`
+	BalanceBeforeCents int64  `json:"balance_before_cents"`
+	BalanceAfterCents  int64  `json:"balance_after_cents"`
+	PostedAt           string `json:"posted_at"`
+	Replayed           bool   `json:"replayed,omitempty"`
+}
+
+type PostingDTO struct {
+	AccountID         string `json:"account_id"`
+	Direction         string `json:"direction"`
+	AmountCents       int64  `json:"amount_cents"`
+	BalanceAfterCents int64  `json:"balance_after_cents"`
+}
+
+type JournalEntryDTO struct {
+	EntryID       string       `json:"entry_id"`
+	EntryType     string       `json:"entry_type"`
+	ReferenceType string       `json:"reference_type,omitempty"`
+	ReferenceID   string       `json:"reference_id,omitempty"`
+	Description   string       `json:"description,omitempty"`
+	Postings      []PostingDTO `json:"postings"`
+	PostedAt      string       `json:"posted_at"`
+}
+
+type AccountStatementResponse struct {
+	AccountID          string            `json:"account_id"`
+	AccountType        string            `json:"account_type"`
+	OwnerID            string            `json:"owner_id,omitempty"`
+	BalanceCents       int64             `json:"balance_cents"`
+	CachedBalanceCents int64             `json:"cached_balance_cents"`
+	Entries            []JournalEntryDTO `json:"entries"`
+}
+
+type BalanceDriftDTO struct {
+	DriftID             string `json:"drift_id"`
+	AccountID           string `json:"account_id"`
+	CachedBalanceCents  int64  `json:"cached_balance_cents"`
+	JournalBalanceCents int64  `json:"journal_balance_cents"`
+	DriftCents          int64  `json:"drift_cents"`
+	DetectedAt          string `json:"detected_at"`
+}
+
+type BalanceDriftsResponse struct {
+	Drifts []BalanceDriftDTO `json:"drifts"`
+}
@@ -3,6 +3,7 @@ package httpadapter
 import (
 	"context"
 	"log/slog"
+	"strconv"
 	"strings"
 	"time"
 
@@ -26,19 +27,23 @@ func (h Handler) GetFlowHandler(ctx context.Context, userID string) (httptranspo
 	resp.Data.UserID = item.UserID
 	resp.Data.Role = item.Role
 	resp.Data.FlowID = item.FlowID
+	resp.Data.FlowVersion = item.FlowVersion
+	resp.Data.ExperimentKey = item.ExperimentKey
 	resp.Data.VariantKey = item.VariantKey
 	resp.Data.Status = item.Status
 	resp.Data.CompletedSteps = item.CompletedSteps
 	resp.Data.TotalSteps = item.TotalSteps
 	for _, step := range item.Steps {
 		resp.Data.Steps = append(resp.Data.Steps, struct {
-			StepKey string `json:"step_key"`
-			Title   string `json:"title"`
-			Status  string `json:"status"`
+			StepKey         string `json:"step_key"`
+			Title           string `json:"title"`
+			Status          string `json:"status"`
+			CompletionEvent string `json:"completion_event,omitempty"`
 		}{
-			StepKey: step.StepKey,
-			Title:   step.Title,
-			Status:  step.Status,
+			StepKey:         step.StepKey,
+			Title:           step.Title,
+			Status:          step.Status,
+			CompletionEvent: step.CompletionEvent,
 		})
 	}
 	return resp, nil
@@ -117,20 +122,141 @@ func (h Handler) ListAdminFlowsHandler(ctx context.Context) (httptransport.Admin
 	resp := httptransport.AdminFlowsResponse{Status: "success"}
 	for _, item := range items {
 		resp.Data.Flows = append(resp.Data.Flows, struct {
-			FlowID     string `json:"flow_id"`
-			Role       string `json:"role"`
-			IsActive   bool   `json:"is_active"`
-			StepsCount int    `json:"steps_count"`
+			FlowID           string `json:"flow_id"`
+			Role             string `json:"role"`
+			Name             string `json:"name"`
+			IsActive         bool   `json:"is_active"`
+			StepsCount       int    `json:"steps_count"`
+			PublishedVersion int    `json:"published_version"`
+			LatestVersion    int    `json:"latest_version"`
 		}{
-			FlowID:     item.FlowID,
-			Role:       item.Role,
-			IsActive:   item.IsActive,
-			StepsCount: item.StepsCount,
+			FlowID:           item.FlowID,
+			Role:             item.Role,
+			Name:             item.Name,
+			IsActive:         item.IsActive,
+			StepsCount:       item.StepsCount,
+			PublishedVersion: item.PublishedVersion,
+			LatestVersion:    item.LatestVersion,
 		})
 	}
 	return resp, nil
 }
 
+func (h Handler) GetFlowDefinitionHandler(ctx context.Context, flowID string) (httptransport.FlowDefinitionResponse, error) {
+	item, err := h.Service.GetFlowDefinition(ctx, strings.TrimSpace(flowID))
+	if err != nil {
+		return httptransport.FlowDefinitionResponse{}, err
+	}
+	return httptransport.FlowDefinitionResponse{Status: "success", Data: toFlowDefinitionDTO(item)}, nil
+}
+
+func (h Handler) CreateFlowHandler(
+	ctx context.Context,
+	idempotencyKey string,
+	actorID string,
+	req httptransport.CreateFlowRequest,
+) (httptransport.FlowDefinitionResponse, error) {
+	item, err := h.Service.CreateFlow(ctx, idempotencyKey, strings.TrimSpace(actorID), ports.CreateFlowInput{
+		Role:  req.Role,
+		Name:  req.Name,
+		Steps: fromFlowStepDTOs(req.Steps),
+	})
+	if err != nil {
+		return httptransport.FlowDefinitionResponse{}, err
+	}
+	return httptransport.FlowDefinitionResponse{Status: "success", Data: toFlowDefinitionDTO(item)}, nil
+}
+
+func (h Handler) SaveFlowDraftHandler(
+	ctx context.Context,
+	idempotencyKey string,
+	actorID string,
+	flowID string,
+	req httptransport.SaveFlowDraftRequest,
+) (httptransport.FlowVersionResponse, error) {
+	item, err := h.Service.SaveFlowDraft(
+		ctx,
+		idempotencyKey,
+		strings.TrimSpace(actorID),
+		strings.TrimSpace(flowID),
+		fromFlowStepDTOs(req.Steps),
+	)
+	if err != nil {
+		return httptransport.FlowVersionResponse{}, err
+	}
+	resp := httptransport.FlowVersionResponse{Status: "success"}
+	resp.Data.FlowID = item.FlowID
+	resp.Data.FlowVersionDTO = toFlowVersionDTO(item)
+	return resp, nil
+}
+
+func (h Handler) PublishFlowVersionHandler(
+	ctx context.Context,
+	idempotencyKey string,
+	actorID string,
+	flowID string,
+	version string,
+) (httptransport.FlowDefinitionResponse, error) {
+	versionNumber, err := strconv.Atoi(strings.TrimSpace(version))
+	if err != nil {
+		return httptransport.FlowDefinitionResponse{}, domainerrors.ErrInvalidRequest
+	}
+	item, err := h.Service.PublishFlowVersion(
+		ctx,
+		idempotencyKey,
+		strings.TrimSpace(actorID),
+		strings.TrimSpace(flowID),
+		versionNumber,
+	)
+	if err != nil {
+		return httptransport.FlowDefinitionResponse{}, err
+	}
+	return httptransport.FlowDefinitionResponse{Status: "success", Data: toFlowDefinitionDTO(item)}, nil
+}
+
+func (h Handler) ArchiveFlowHandler(
+	ctx context.Context,
+	idempotencyKey string,
+	actorID string,
+	flowID string,
+) (httptransport.FlowDefinitionResponse, error) {
+	item, err := h.Service.ArchiveFlow(ctx, idempotencyKey, strings.TrimSpace(actorID), strings.TrimSpace(flowID))
+	if err != nil {
+		return httptransport.FlowDefinitionResponse{}, err
+	}
+	return httptransport.FlowDefinitionResponse{Status: "success", Data: toFlowDefinitionDTO(item)}, nil
+}
+
+func (h Handler) ConsumeActivityEventHandler(
+	ctx context.Context,
+	req httptransport.ActivityEventRequest,
+) (httptransport.ActivityEventResponse, error) {
+	occurredAt := time.Time{}
+	if strings.TrimSpace(req.OccurredAt) != "" {
+		parsed, err := time.Parse(time.RFC3339, strings.TrimSpace(req.OccurredAt))
+		if err != nil {
+			return httptransport.ActivityEventResponse{}, domainerrors.ErrInvalidRequest
+		}
+		occurredAt = parsed.UTC()
+	}
+	item, err := h.Service.ApplyActivityEvent(ctx, ports.ActivityEvent{
+		EventID:    strings.TrimSpace(req.EventID),
+		UserID:     strings.TrimSpace(req.UserID),
+		EventType:  strings.TrimSpace(req.EventType),
+		OccurredAt: occurredAt,
+	})
+	if err != nil {
+		return httptransport.ActivityEventResponse{}, err
+	}
+	resp := httptransport.ActivityEventResponse{Status: "success"}
+	resp.Data.UserID = item.UserID
+	resp.Data.Status = item.Status
+	resp.Data.CompletedStepKeys = item.CompletedStepKeys
+	resp.Data.CompletedSteps = item.CompletedSteps
+	resp.Data.TotalSteps = item.TotalSteps
+	return resp, nil
+}
+
 func (h Handler) ConsumeUserRegisteredEventHandler(
 	ctx context.Context,
 	req httptransport.UserRegisteredEventRequest,
@@ -147,6 +273,7 @@ func (h Handler) ConsumeUserRegisteredEventHandler(
 		EventID:    strings.TrimSpace(req.EventID),
 		UserID:     strings.TrimSpace(req.UserID),
 		Role:       strings.ToLower(strings.TrimSpace(req.Role)),
+		Email:      strings.TrimSpace(req.Email),
 		OccurredAt: occurredAt,
 	})
 	if err != nil {
@@ -159,3 +286,210 @@ func (h Handler) ConsumeUserRegisteredEventHandler(
 	resp.Data.Status = item.Status
 	return resp, nil
 }
+
+func (h Handler) ListExperimentsHandler(ctx context.Context) (httptransport.ExperimentsResponse, error) {
+	items, err := h.Service.ListExperiments(ctx)
+	if err != nil {
+		return httptransport.ExperimentsResponse{}, err
+	}
+	resp := httptransport.ExperimentsResponse{Status: "success"}
+	resp.Data.Experiments = make([]httptransport.ExperimentDTO, 0, len(items))
+	for _, item := range items {
+		resp.Data.Experiments = append(resp.Data.Experiments, toExperimentDTO(item))
+	}
+	return resp, nil
+}
+
+func (h Handler) CreateExperimentHandler(
+	ctx context.Context,
+	idempotencyKey string,
+	actorID string,
+	req httptransport.CreateExperimentRequest,
+) (httptransport.ExperimentResponse, error) {
+	variants := make([]ports.ExperimentVariant, 0, len(req.Variants))
+	for _, variant := range req.Variants {
+		variants = append(variants, ports.ExperimentVariant{
+			VariantKey: variant.VariantKey,
+			Weight:     variant.Weight,
+			FlowID:     variant.FlowID,
+		})
+	}
+	item, err := h.Service.CreateExperiment(ctx, idempotencyKey, strings.TrimSpace(actorID), ports.CreateExperimentInput{
+		ExperimentKey: req.ExperimentKey,
+		Role:          req.Role,
+		Variants:      variants,
+	})
+	if err != nil {
+		return httptransport.ExperimentResponse{}, err
+	}
+	return httptransport.ExperimentResponse{Status: "success", Data: toExperimentDTO(item)}, nil
+}
+
+func (h Handler) StopExperimentHandler(
+	ctx context.Context,
+	idempotencyKey string,
+	actorID string,
+	experimentKey string,
+) (httptransport.ExperimentResponse, error) {
+	item, err := h.Service.StopExperiment(ctx, idempotencyKey, strings.TrimSpace(actorID), strings.TrimSpace(experimentKey))
+	if err != nil {
+		return httptransport.ExperimentResponse{}, err
+	}
+	return httptransport.ExperimentResponse{Status: "success", Data: toExperimentDTO(item)}, nil
+}
+
+func (h Handler) ExperimentResultsHandler(ctx context.Context, experimentKey string) (httptransport.ExperimentResultsResponse, error) {
+	report, err := h.Service.GetExperimentReport(ctx, strings.TrimSpace(experimentKey))
+	if err != nil {
+		return httptransport.ExperimentResultsResponse{}, err
+	}
+	resp := httptransport.ExperimentResultsResponse{Status: "success"}
+	resp.Data.Experiment = toExperimentDTO(report.Experiment)
+	for _, item := range report.Variants {
+		resp.Data.Variants = append(resp.Data.Variants, struct {
+			VariantKey                  string  `json:"variant_key"`
+			Weight                      int     `json:"weight"`
+			Assigned                    int     `json:"assigned"`
+			Exposed                     int     `json:"exposed"`
+			Completed                   int     `json:"completed"`
+			CompletionRate              float64 `json:"completion_rate"`
+			AvgTimeToCompleteSeconds    int64   `json:"avg_time_to_complete_seconds"`
+			MedianTimeToCompleteSeconds int64   `json:"median_time_to_complete_seconds"`
+		}{
+			VariantKey:                  item.VariantKey,
+			Weight:                      item.Weight,
+			Assigned:                    item.Assigned,
+			Exposed:                     item.Exposed,
+			Completed:                   item.Completed,
+			CompletionRate:              item.CompletionRate,
+			AvgTimeToCompleteSeconds:    item.AvgTimeToCompleteSeconds,
+			MedianTimeToCompleteSeconds: item.MedianTimeToCompleteSeconds,
+		})
+	}
+	return resp, nil
+}
+
+func (h Handler) GetReminderPreferencesHandler(ctx context.Context, userID string) (httptransport.ReminderPreferencesResponse, error) {
+	item, err := h.Service.GetReminderPreferences(ctx, strings.TrimSpace(userID))
+	if err != nil {
+		return httptransport.ReminderPreferencesResponse{}, err
+	}
+	return toReminderPreferencesResponse(item), nil
+}
+
+func (h Handler) UpdateReminderPreferencesHandler(
+	ctx context.Context,
+	userID string,
+	req httptransport.ReminderPreferencesRequest,
+) (httptransport.ReminderPreferencesResponse, error) {
+	item, err := h.Service.UpdateReminderPreferences(ctx, ports.ReminderPreferences{
+		UserID:          strings.TrimSpace(userID),
+		EmailEnabled:    req.EmailEnabled,
+		InAppEnabled:    req.InAppEnabled,
+		Unsubscribed:    req.Unsubscribed,
+		QuietHoursStart: req.QuietHoursStart,
+		QuietHoursEnd:   req.QuietHoursEnd,
+		Timezone:        req.Timezone,
+	})
+	if err != nil {
+		return httptransport.ReminderPreferencesResponse{}, err
+	}
+	return toReminderPreferencesResponse(item), nil
+}
+
+func (h Handler) UnsubscribeRemindersHandler(ctx context.Context, userID string) (httptransport.ReminderPreferencesResponse, error) {
+	item, err := h.Service.UnsubscribeReminders(ctx, strings.TrimSpace(userID))
+	if err != nil {
+		return httptransport.ReminderPreferencesResponse{}, err
+	}
+	return toReminderPreferencesResponse(item), nil
+}
+
+func toReminderPreferencesResponse(item ports.ReminderPreferences) httptransport.ReminderPreferencesResponse {
+	resp := httptransport.ReminderPreferencesResponse{Status: "success"}
+	resp.Data.UserID = item.UserID
+	resp.Data.EmailEnabled = item.EmailEnabled
+	resp.Data.InAppEnabled = item.InAppEnabled
+	resp.Data.Unsubscribed = item.Unsubscribed
+	resp.Data.QuietHoursStart = item.QuietHoursStart
+	resp.Data.QuietHoursEnd = item.QuietHoursEnd
+	resp.Data.Timezone = item.Timezone
+	if !item.UpdatedAt.IsZero() {
+		resp.Data.UpdatedAt = item.UpdatedAt.UTC().Format(time.RFC3339)
+	}
+	return resp
+}
+
+func toExperimentDTO(item ports.Experiment) httptransport.ExperimentDTO {
+	out := httptransport.ExperimentDTO{
+		ExperimentKey: item.ExperimentKey,
+		Role:          item.Role,
+		Status:        item.Status,
+		Variants:      make([]httptransport.ExperimentVariantDTO, 0, len(item.Variants)),
+		CreatedBy:     item.CreatedBy,
+		CreatedAt:     item.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	for _, variant := range item.Variants {
+		out.Variants = append(out.Variants, httptransport.ExperimentVariantDTO{
+			VariantKey: variant.VariantKey,
+			Weight:     variant.Weight,
+			FlowID:     variant.FlowID,
+		})
+	}
+	if item.StoppedAt != nil {
+		out.StoppedAt = item.StoppedAt.UTC().Format(time.RFC3339)
+	}
+	return out
+}
+
+func fromFlowStepDTOs(items []httptransport.FlowStepDTO) []ports.FlowStep {
+	out := make([]ports.FlowStep, 0, len(items))
+	for _, item := range items {
+		out = append(out, ports.FlowStep{
+			StepKey:         item.StepKey,
+			Title:           item.Title,
+			CompletionEvent: item.CompletionEvent,
+		})
+	}
+	return out
+}
+
+func toFlowDefinitionDTO(item ports.FlowDefinition) httptransport.FlowDefinitionDTO {
+	out := httptransport.FlowDefinitionDTO{
+		FlowID:           item.FlowID,
+		Role:             item.Role,
+		Name:             item.Name,
+		IsActive:         item.IsActive,
+		Archived:         item.Archived,
+		PublishedVersion: item.PublishedVersion,
+		Versions:         make([]httptransport.FlowVersionDTO, 0, len(item.Versions)),
+		CreatedAt:        item.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:        item.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+	for _, version := range item.Versions {
+		out.Versions = append(out.Versions, toFlowVersionDTO(version))
+	}
+	return out
+}
+
+func toFlowVersionDTO(item ports.FlowVersion) httptransport.FlowVersionDTO {
+	out := httptransport.FlowVersionDTO{
+		Version:   item.Version,
+		Status:    item.Status,
+		Steps:     make([]httptransport.FlowStepDTO, 0, len(item.Steps)),
+		CreatedBy: item.CreatedBy,
+		CreatedAt: item.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	for _, step := range item.Steps {
+		out.Steps = append(out.Steps, httptransport.FlowStepDTO{
+			StepKey:         step.StepKey,
+			Title:           step.Title,
+			CompletionEvent: step.CompletionEvent,
+		})
+	}
+	if item.PublishedAt != nil {
+		out.PublishedBy = item.PublishedBy
+		out.PublishedAt = item.PublishedAt.UTC().Format(time.RFC3339)
+	}
+	return out
+}
@@ -16,22 +16,33 @@ import (
 	"solomon/contexts/identity-access/onboarding-service/ports"
 )
 
-type flowDefinition struct {
-	FlowID string
-	Role   string
-	Steps  []ports.FlowStep
+type flowRecord struct {
+	FlowID    string
+	Role      string
+	Name      string
+	Archived  bool
+	Versions  []ports.FlowVersion
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
 
 type progressRecord struct {
 	UserID              string
+	Email               string
 	Role                string
 	FlowID              string
+	FlowVersion         int
+	ExperimentKey       string
 	VariantKey          string
 	Status              string
 	StepStatusByStepKey map[string]string
 	CreatedAt           time.Time
 	UpdatedAt           time.Time
+	CompletedAt         *time.Time
 	ReminderScheduledAt *time.Time
+	RemindersSent       int
+	LastReminderAt      *time.Time
+	RemindersStopped    bool
 }
 
 type dedupRecord struct {
@@ -42,52 +53,79 @@ type dedupRecord struct {
 type Store struct {
 	mu sync.RWMutex
 
-	flowsByRole      map[string]flowDefinition
-	progressByUserID map[string]progressRecord
-	eventDedupByID   map[string]dedupRecord
-	idempotency      map[string]ports.IdempotencyRecord
-	sequence         uint64
+	flowsByID          map[string]flowRecord
+	activeFlowIDByRole map[string]string
+	progressByUserID   map[string]progressRecord
+	eventDedupByID     map[string]dedupRecord
+	activityDedupByID  map[string]time.Time
+	experimentsByKey   map[string]ports.Experiment
+	assignmentsByUser  map[string]ports.VariantAssignment
+	outbox             []ports.EventEnvelope
+	preferencesByUser  map[string]ports.ReminderPreferences
+	idempotency        map[string]ports.IdempotencyRecord
+	sequence           uint64
 }
 
 func NewStore() *Store {
-	return &Store{
-		flowsByRole: map[string]flowDefinition{
-			ports.RoleBrand: {
-				FlowID: "flow_brand_default",
-				Role:   ports.RoleBrand,
-				Steps: []ports.FlowStep{
-					{StepKey: "welcome", Title: "Welcome to ViralForge"},
-					{StepKey: "connect_storefront", Title: "Connect your storefront"},
-					{StepKey: "create_first_product", Title: "Create your first product"},
-				},
-			},
-			ports.RoleEditor: {
-				FlowID: "flow_editor_default",
-				Role:   ports.RoleEditor,
-				Steps: []ports.FlowStep{
-					{StepKey: "welcome", Title: "Welcome to ViralForge"},
-					{StepKey: "complete_profile", Title: "Complete your profile"},
-					{StepKey: "submit_first_clip", Title: "Submit your first clip"},
-				},
-			},
-			ports.RoleInfluencer: {
-				FlowID: "flow_influencer_default",
-				Role:   ports.RoleInfluencer,
-				Steps: []ports.FlowStep{
-					{StepKey: "welcome", Title: "Welcome to ViralForge"},
-					{StepKey: "connect_social", Title: "Connect social account"},
-					{StepKey: "join_first_campaign", Title: "Join your first campaign"},
-				},
-			},
-		},
-		progressByUserID: make(map[string]progressRecord),
-		eventDedupByID:   make(map[string]dedupRecord),
-		idempotency:      make(map[string]ports.IdempotencyRecord),
-		sequence:         1,
-	}
-}
-
-func (s *Store) ConsumeUserRegisteredEvent(ctx context.Context, event ports.UserRegisteredEvent, now time.Time) (ports.FlowState, error) {
+	store := &Store{
+		flowsByID:          make(map[string]flowRecord),
+		activeFlowIDByRole: make(map[string]string),
+		progressByUserID:   make(map[string]progressRecord),
+		eventDedupByID:     make(map[string]dedupRecord),
+		activityDedupByID:  make(map[string]time.Time),
+		experimentsByKey:   make(map[string]ports.Experiment),
+		assignmentsByUser:  make(map[string]ports.VariantAssignment),
+		preferencesByUser:  make(map[string]ports.ReminderPreferences),
+		idempotency:        make(map[string]ports.IdempotencyRecord),
+		sequence:           1,
+	}
+	seededAt := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	store.seedFlow("flow_brand_default", ports.RoleBrand, "Brand onboarding", seededAt, []ports.FlowStep{
+		{StepKey: "welcome", Title: "Welcome to ViralForge"},
+		{StepKey: "connect_storefront", Title: "Connect your storefront", CompletionEvent: "storefront.connected"},
+		{StepKey: "create_first_product", Title: "Create your first product", CompletionEvent: "product.created"},
+	})
+	store.seedFlow("flow_editor_default", ports.RoleEditor, "Editor onboarding", seededAt, []ports.FlowStep{
+		{StepKey: "welcome", Title: "Welcome to ViralForge"},
+		{StepKey: "complete_profile", Title: "Complete your profile", CompletionEvent: "profile.completed"},
+		{StepKey: "submit_first_clip", Title: "Submit your first clip", CompletionEvent: "submission.created"},
+	})
+	store.seedFlow("flow_influencer_default", ports.RoleInfluencer, "Influencer onboarding", seededAt, []ports.FlowStep{
+		{StepKey: "welcome", Title: "Welcome to ViralForge"},
+		{StepKey: "connect_social", Title: "Connect social account", CompletionEvent: "social_account.connected"},
+		{StepKey: "join_first_campaign", Title: "Join your first campaign", CompletionEvent: "campaign.joined"},
+	})
+	return store
+}
+
+func (s *Store) seedFlow(flowID string, role string, name string, at time.Time, steps []ports.FlowStep) {
+	publishedAt := at
+	s.flowsByID[flowID] = flowRecord{
+		FlowID: flowID,
+		Role:   role,
+		Name:   name,
+		Versions: []ports.FlowVersion{{
+			FlowID:      flowID,
+			Version:     1,
+			Status:      ports.FlowVersionPublished,
+			Steps:       steps,
+			CreatedBy:   "system",
+			CreatedAt:   at,
+			PublishedBy: "system",
+			PublishedAt: &publishedAt,
+		}},
+		CreatedAt: at,
+		UpdatedAt: at,
+	}
+	s.activeFlowIDByRole[role] = flowID
+}
+
+func (s *Store) ConsumeUserRegisteredEvent(
+	ctx context.Context,
+	event ports.UserRegisteredEvent,
+	assignment ports.VariantAssignment,
+	now time.Time,
+) (ports.FlowState, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -112,21 +150,40 @@ func (s *Store) ConsumeUserRegisteredEvent(ctx context.Context, event ports.User
 		}
 	}
 
-	flow, ok := s.flowsByRole[role]
+	flow, version, ok := s.activePublishedLocked(role)
+	if assignment.FlowID != "" {
+		flow, ok = s.flowsByID[assignment.FlowID]
+		if ok && flow.Role == role && !flow.Archived {
+			version, ok = publishedVersion(flow)
+		} else {
+			ok = false
+		}
+	}
 	if !ok {
 		return ports.FlowState{}, domainerrors.ErrDependencyUnavailable
 	}
 
 	if _, exists := s.progressByUserID[event.UserID]; !exists {
-		stepStatus := make(map[string]string, len(flow.Steps))
-		for _, step := range flow.Steps {
+		variantKey := ports.DefaultVariantKey
+		if assignment.ExperimentKey != "" {
+			variantKey = assignment.VariantKey
+			assignment.UserID = event.UserID
+			assignment.AssignedAt = now
+			assignment.ExposedAt = nil
+			s.assignmentsByUser[event.UserID] = assignment
+		}
+		stepStatus := make(map[string]string, len(version.Steps))
+		for _, step := range version.Steps {
 			stepStatus[step.StepKey] = "pending"
 		}
 		s.progressByUserID[event.UserID] = progressRecord{
 			UserID:              event.UserID,
+			Email:               strings.TrimSpace(event.Email),
 			Role:                role,
 			FlowID:              flow.FlowID,
-			VariantKey:          chooseVariant(event.UserID),
+			FlowVersion:         version.Version,
+			ExperimentKey:       assignment.ExperimentKey,
+			VariantKey:          variantKey,
 			Status:              "in_progress",
 			StepStatusByStepKey: stepStatus,
 			CreatedAt:           now,
@@ -154,12 +211,12 @@ func (s *Store) CompleteStep(ctx context.Context, userID string, stepKey string,
 	if !ok {
 		return ports.StepCompletion{}, domainerrors.ErrProgressNotFound
 	}
-	flow, ok := s.flowsByRole[progress.Role]
+	steps, ok := s.pinnedStepsLocked(progress)
 	if !ok {
 		return ports.StepCompletion{}, domainerrors.ErrFlowNotFound
 	}
 	stepKey = strings.TrimSpace(stepKey)
-	if !hasStep(flow, stepKey) {
+	if !hasStep(steps, stepKey) {
 		return ports.StepCompletion{}, domainerrors.ErrStepNotFound
 	}
 
@@ -177,9 +234,11 @@ func (s *Store) CompleteStep(ctx context.Context, userID string, stepKey string,
 	progress.UpdatedAt = now.UTC()
 
 	completed := countCompleted(progress.StepStatusByStepKey)
-	total := len(flow.Steps)
+	total := len(steps)
 	if completed >= total {
+		completedAt := now.UTC()
 		progress.Status = "completed"
+		progress.CompletedAt = &completedAt
 	} else {
 		progress.Status = "in_progress"
 	}
@@ -217,6 +276,9 @@ func (s *Store) SkipFlow(ctx context.Context, userID string, reason string, now
 	progress.Status = "skipped"
 	progress.UpdatedAt = now.UTC()
 	progress.ReminderScheduledAt = &reminder
+	progress.RemindersSent = 0
+	progress.LastReminderAt = nil
+	progress.RemindersStopped = false
 	_ = reason
 	s.progressByUserID[userID] = progress
 	return ports.SkipResult{
@@ -237,7 +299,8 @@ func (s *Store) ResumeFlow(ctx context.Context, userID string, now time.Time) (p
 		return ports.ResumeResult{}, domainerrors.ErrResumeNotAllowed
 	}
 
-	nextStep := nextPendingStep(progress, s.flowsByRole[progress.Role])
+	steps, _ := s.pinnedStepsLocked(progress)
+	nextStep := nextPendingStep(progress, steps)
 	if nextStep == "" {
 		return ports.ResumeResult{}, domainerrors.ErrResumeNotAllowed
 	}
@@ -245,6 +308,9 @@ func (s *Store) ResumeFlow(ctx context.Context, userID string, now time.Time) (p
 	progress.Status = "in_progress"
 	progress.UpdatedAt = now.UTC()
 	progress.ReminderScheduledAt = nil
+	progress.RemindersSent = 0
+	progress.LastReminderAt = nil
+	progress.RemindersStopped = false
 	s.progressByUserID[userID] = progress
 	return ports.ResumeResult{
 		Status:   "in_progress",
@@ -256,21 +322,471 @@ func (s *Store) ListAdminFlows(ctx context.Context) ([]ports.AdminFlow, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	items := make([]ports.AdminFlow, 0, len(s.flowsByRole))
-	for _, flow := range s.flowsByRole {
-		items = append(items, ports.AdminFlow{
-			FlowID:     flow.FlowID,
-			Role:       flow.Role,
-			IsActive:   true,
-			StepsCount: len(flow.Steps),
+	items := make([]ports.AdminFlow, 0, len(s.flowsByID))
+	for _, flow := range s.flowsByID {
+		if flow.Archived {
+			continue
+		}
+		item := ports.AdminFlow{
+			FlowID:   flow.FlowID,
+			Role:     flow.Role,
+			Name:     flow.Name,
+			IsActive: s.activeFlowIDByRole[flow.Role] == flow.FlowID,
+		}
+		if len(flow.Versions) > 0 {
+			item.LatestVersion = flow.Versions[len(flow.Versions)-1].Version
+		}
+		if published, ok := publishedVersion(flow); ok {
+			item.PublishedVersion = published.Version
+			item.StepsCount = len(published.Steps)
+		}
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i int, j int) bool {
+		if items[i].Role != items[j].Role {
+			return items[i].Role < items[j].Role
+		}
+		return items[i].FlowID < items[j].FlowID
+	})
+	return items, nil
+}
+
+func (s *Store) CreateFlowDefinition(ctx context.Context, actorID string, input ports.CreateFlowInput, now time.Time) (ports.FlowDefinition, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now = now.UTC()
+	flowID := s.nextID("flow")
+	flow := flowRecord{
+		FlowID: flowID,
+		Role:   input.Role,
+		Name:   input.Name,
+		Versions: []ports.FlowVersion{{
+			FlowID:    flowID,
+			Version:   1,
+			Status:    ports.FlowVersionDraft,
+			Steps:     cloneSteps(input.Steps),
+			CreatedBy: actorID,
+			CreatedAt: now,
+		}},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	s.flowsByID[flowID] = flow
+	return s.toDefinitionLocked(flow), nil
+}
+
+func (s *Store) GetFlowDefinition(ctx context.Context, flowID string) (ports.FlowDefinition, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	flow, ok := s.flowsByID[strings.TrimSpace(flowID)]
+	if !ok {
+		return ports.FlowDefinition{}, domainerrors.ErrFlowNotFound
+	}
+	return s.toDefinitionLocked(flow), nil
+}
+
+func (s *Store) SaveFlowDraft(ctx context.Context, actorID string, flowID string, steps []ports.FlowStep, now time.Time) (ports.FlowVersion, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	flow, ok := s.flowsByID[strings.TrimSpace(flowID)]
+	if !ok {
+		return ports.FlowVersion{}, domainerrors.ErrFlowNotFound
+	}
+	if flow.Archived {
+		return ports.FlowVersion{}, domainerrors.ErrFlowArchived
+	}
+	now = now.UTC()
+	last := len(flow.Versions) - 1
+	if last >= 0 && flow.Versions[last].Status == ports.FlowVersionDraft {
+		flow.Versions[last].Steps = cloneSteps(steps)
+		flow.Versions[last].CreatedBy = actorID
+		flow.Versions[last].CreatedAt = now
+	} else {
+		flow.Versions = append(flow.Versions, ports.FlowVersion{
+			FlowID:    flow.FlowID,
+			Version:   last + 2,
+			Status:    ports.FlowVersionDraft,
+			Steps:     cloneSteps(steps),
+			CreatedBy: actorID,
+			CreatedAt: now,
 		})
 	}
+	flow.UpdatedAt = now
+	s.flowsByID[flow.FlowID] = flow
+	return cloneVersion(flow.Versions[len(flow.Versions)-1]), nil
+}
+
+func (s *Store) PublishFlowVersion(ctx context.Context, actorID string, flowID string, version int, now time.Time) (ports.FlowDefinition, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	flow, ok := s.flowsByID[strings.TrimSpace(flowID)]
+	if !ok {
+		return ports.FlowDefinition{}, domainerrors.ErrFlowNotFound
+	}
+	if flow.Archived {
+		return ports.FlowDefinition{}, domainerrors.ErrFlowArchived
+	}
+	index := -1
+	for i := range flow.Versions {
+		if flow.Versions[i].Version == version {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return ports.FlowDefinition{}, domainerrors.ErrFlowVersionNotFound
+	}
+	if flow.Versions[index].Status != ports.FlowVersionDraft {
+		return ports.FlowDefinition{}, domainerrors.ErrConflict
+	}
+
+	now = now.UTC()
+	for i := range flow.Versions {
+		if flow.Versions[i].Status == ports.FlowVersionPublished {
+			flow.Versions[i].Status = ports.FlowVersionSuperseded
+		}
+	}
+	flow.Versions[index].Status = ports.FlowVersionPublished
+	flow.Versions[index].PublishedBy = actorID
+	flow.Versions[index].PublishedAt = &now
+	flow.UpdatedAt = now
+	s.flowsByID[flow.FlowID] = flow
+	s.activeFlowIDByRole[flow.Role] = flow.FlowID
+	return s.toDefinitionLocked(flow), nil
+}
+
+func (s *Store) ArchiveFlowDefinition(ctx context.Context, actorID string, flowID string, now time.Time) (ports.FlowDefinition, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	flow, ok := s.flowsByID[strings.TrimSpace(flowID)]
+	if !ok {
+		return ports.FlowDefinition{}, domainerrors.ErrFlowNotFound
+	}
+	if s.activeFlowIDByRole[flow.Role] == flow.FlowID {
+		return ports.FlowDefinition{}, domainerrors.ErrFlowActive
+	}
+	for _, experiment := range s.experimentsByKey {
+		if experiment.Status != ports.ExperimentRunning {
+			continue
+		}
+		for _, variant := range experiment.Variants {
+			if variant.FlowID == flow.FlowID {
+				return ports.FlowDefinition{}, domainerrors.ErrFlowActive
+			}
+		}
+	}
+	if !flow.Archived {
+		flow.Archived = true
+		flow.UpdatedAt = now.UTC()
+		s.flowsByID[flow.FlowID] = flow
+	}
+	return s.toDefinitionLocked(flow), nil
+}
+
+func (s *Store) ApplyActivityEvent(ctx context.Context, event ports.ActivityEvent, now time.Time) (ports.ActivityResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now = now.UTC()
+	userID := strings.TrimSpace(event.UserID)
+	progress, ok := s.progressByUserID[userID]
+	if !ok {
+		return ports.ActivityResult{}, domainerrors.ErrProgressNotFound
+	}
+	steps, ok := s.pinnedStepsLocked(progress)
+	if !ok {
+		return ports.ActivityResult{}, domainerrors.ErrFlowNotFound
+	}
+	result := ports.ActivityResult{
+		UserID:            userID,
+		Status:            progress.Status,
+		CompletedStepKeys: []string{},
+		TotalSteps:        len(steps),
+	}
+	if expiresAt, seen := s.activityDedupByID[event.EventID]; seen && now.Before(expiresAt) {
+		result.CompletedSteps = countCompleted(progress.StepStatusByStepKey)
+		return result, nil
+	}
+	s.activityDedupByID[event.EventID] = now.Add(7 * 24 * time.Hour)
+
+	// Skipped flows still record progress so resuming picks up where the
+	// user actually is; completed flows have nothing left to satisfy.
+	if progress.Status != "completed" {
+		for _, step := range steps {
+			if step.CompletionEvent == "" || step.CompletionEvent != event.EventType {
+				continue
+			}
+			if progress.StepStatusByStepKey[step.StepKey] == "completed" {
+				continue
+			}
+			progress.StepStatusByStepKey[step.StepKey] = "completed"
+			result.CompletedStepKeys = append(result.CompletedStepKeys, step.StepKey)
+		}
+		if len(result.CompletedStepKeys) > 0 {
+			progress.UpdatedAt = now
+			if countCompleted(progress.StepStatusByStepKey) >= len(steps) {
+				completedAt := now
+				progress.Status = "completed"
+				progress.CompletedAt = &completedAt
+				progress.ReminderScheduledAt = nil
+			}
+			s.progressByUserID[userID] = progress
+		}
+	}
+	result.Status = progress.Status
+	result.CompletedSteps = countCompleted(progress.StepStatusByStepKey)
+	return result, nil
+}
+
+func (s *Store) CreateExperiment(ctx context.Context, actorID string, input ports.CreateExperimentInput, now time.Time) (ports.Experiment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.experimentsByKey[input.ExperimentKey]; exists {
+		return ports.Experiment{}, domainerrors.ErrConflict
+	}
+	for _, experiment := range s.experimentsByKey {
+		if experiment.Role == input.Role && experiment.Status == ports.ExperimentRunning {
+			return ports.Experiment{}, domainerrors.ErrExperimentRunning
+		}
+	}
+	for _, variant := range input.Variants {
+		if variant.FlowID == "" {
+			continue
+		}
+		flow, ok := s.flowsByID[variant.FlowID]
+		if !ok {
+			return ports.Experiment{}, domainerrors.ErrFlowNotFound
+		}
+		if flow.Archived {
+			return ports.Experiment{}, domainerrors.ErrFlowArchived
+		}
+		if _, published := publishedVersion(flow); !published || flow.Role != input.Role {
+			return ports.Experiment{}, domainerrors.ErrInvalidRequest
+		}
+	}
+
+	experiment := ports.Experiment{
+		ExperimentKey: input.ExperimentKey,
+		Role:          input.Role,
+		Status:        ports.ExperimentRunning,
+		Variants:      append([]ports.ExperimentVariant(nil), input.Variants...),
+		CreatedBy:     actorID,
+		CreatedAt:     now.UTC(),
+	}
+	s.experimentsByKey[experiment.ExperimentKey] = experiment
+	return cloneExperiment(experiment), nil
+}
+
+func (s *Store) ListExperiments(ctx context.Context) ([]ports.Experiment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	items := make([]ports.Experiment, 0, len(s.experimentsByKey))
+	for _, experiment := range s.experimentsByKey {
+		items = append(items, cloneExperiment(experiment))
+	}
 	sort.Slice(items, func(i int, j int) bool {
-		return items[i].Role < items[j].Role
+		if !items[i].CreatedAt.Equal(items[j].CreatedAt) {
+			return items[i].CreatedAt.After(items[j].CreatedAt)
+		}
+		return items[i].ExperimentKey < items[j].ExperimentKey
 	})
 	return items, nil
 }
 
+func (s *Store) GetExperiment(ctx context.Context, experimentKey string) (ports.Experiment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	experiment, ok := s.experimentsByKey[strings.TrimSpace(experimentKey)]
+	if !ok {
+		return ports.Experiment{}, domainerrors.ErrExperimentNotFound
+	}
+	return cloneExperiment(experiment), nil
+}
+
+func (s *Store) GetRunningExperiment(ctx context.Context, role string) (ports.Experiment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, experiment := range s.experimentsByKey {
+		if experiment.Role == role && experiment.Status == ports.ExperimentRunning {
+			return cloneExperiment(experiment), nil
+		}
+	}
+	return ports.Experiment{}, domainerrors.ErrExperimentNotFound
+}
+
+func (s *Store) StopExperiment(ctx context.Context, actorID string, experimentKey string, now time.Time) (ports.Experiment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	experiment, ok := s.experimentsByKey[strings.TrimSpace(experimentKey)]
+	if !ok {
+		return ports.Experiment{}, domainerrors.ErrExperimentNotFound
+	}
+	if experiment.Status != ports.ExperimentStopped {
+		stoppedAt := now.UTC()
+		experiment.Status = ports.ExperimentStopped
+		experiment.StoppedAt = &stoppedAt
+		s.experimentsByKey[experiment.ExperimentKey] = experiment
+	}
+	return cloneExperiment(experiment), nil
+}
+
+func (s *Store) MarkVariantExposed(ctx context.Context, userID string, now time.Time) (ports.VariantAssignment, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	assignment, ok := s.assignmentsByUser[strings.TrimSpace(userID)]
+	if !ok {
+		return ports.VariantAssignment{}, false, domainerrors.ErrNotFound
+	}
+	if assignment.ExposedAt != nil {
+		return cloneAssignment(assignment), false, nil
+	}
+	exposedAt := now.UTC()
+	assignment.ExposedAt = &exposedAt
+	s.assignmentsByUser[assignment.UserID] = assignment
+	return cloneAssignment(assignment), true, nil
+}
+
+func (s *Store) ListExperimentOutcomes(ctx context.Context, experimentKey string) ([]ports.ExperimentOutcome, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	experimentKey = strings.TrimSpace(experimentKey)
+	if _, ok := s.experimentsByKey[experimentKey]; !ok {
+		return nil, domainerrors.ErrExperimentNotFound
+	}
+	items := make([]ports.ExperimentOutcome, 0)
+	for userID, assignment := range s.assignmentsByUser {
+		if assignment.ExperimentKey != experimentKey {
+			continue
+		}
+		item := ports.ExperimentOutcome{
+			UserID:     userID,
+			VariantKey: assignment.VariantKey,
+			AssignedAt: assignment.AssignedAt,
+			ExposedAt:  assignment.ExposedAt,
+		}
+		if progress, ok := s.progressByUserID[userID]; ok && progress.CompletedAt != nil {
+			completedAt := *progress.CompletedAt
+			item.CompletedAt = &completedAt
+		}
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i int, j int) bool { return items[i].UserID < items[j].UserID })
+	return items, nil
+}
+
+func (s *Store) ListDueReminders(ctx context.Context, now time.Time, stalledBefore time.Time, limit int) ([]ports.ReminderCandidate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	items := make([]ports.ReminderCandidate, 0)
+	for _, progress := range s.progressByUserID {
+		reason, dueAt, ok := reminderSlot(progress)
+		if !ok {
+			continue
+		}
+		if reason == ports.ReminderReasonSkipped && dueAt.After(now) {
+			continue
+		}
+		if reason == ports.ReminderReasonStalled && dueAt.After(stalledBefore) {
+			continue
+		}
+		items = append(items, s.reminderCandidateLocked(progress, reason, dueAt))
+	}
+	sort.Slice(items, func(i int, j int) bool {
+		if !items[i].DueAt.Equal(items[j].DueAt) {
+			return items[i].DueAt.Before(items[j].DueAt)
+		}
+		return items[i].UserID < items[j].UserID
+	})
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+func (s *Store) ClaimReminder(ctx context.Context, candidate ports.ReminderCandidate, now time.Time, nextAt *time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	progress, ok := s.progressByUserID[candidate.UserID]
+	if !ok {
+		return domainerrors.ErrProgressNotFound
+	}
+	reason, dueAt, ok := reminderSlot(progress)
+	if !ok || reason != candidate.Reason || !dueAt.Equal(candidate.DueAt) {
+		return domainerrors.ErrConflict
+	}
+	sentAt := now.UTC()
+	progress.RemindersSent++
+	progress.LastReminderAt = &sentAt
+	if nextAt == nil {
+		progress.RemindersStopped = true
+		progress.ReminderScheduledAt = nil
+	} else if reason == ports.ReminderReasonSkipped {
+		next := nextAt.UTC()
+		progress.ReminderScheduledAt = &next
+	}
+	s.progressByUserID[progress.UserID] = progress
+	return nil
+}
+
+func (s *Store) GetReminderPreferences(ctx context.Context, userID string) (ports.ReminderPreferences, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	userID = strings.TrimSpace(userID)
+	if preferences, ok := s.preferencesByUser[userID]; ok {
+		return preferences, nil
+	}
+	return ports.ReminderPreferences{
+		UserID:       userID,
+		EmailEnabled: true,
+		InAppEnabled: true,
+		Timezone:     "UTC",
+	}, nil
+}
+
+func (s *Store) SaveReminderPreferences(ctx context.Context, preferences ports.ReminderPreferences) (ports.ReminderPreferences, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.preferencesByUser[preferences.UserID] = preferences
+	return preferences, nil
+}
+
+func (s *Store) AppendOutbox(ctx context.Context, envelope ports.EventEnvelope) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.outbox {
+		if existing.EventID == envelope.EventID {
+			return nil
+		}
+	}
+	s.outbox = append(s.outbox, envelope)
+	return nil
+}
+
+// Outbox returns a copy of the events appended so far, oldest first.
+func (s *Store) Outbox() []ports.EventEnvelope {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]ports.EventEnvelope(nil), s.outbox...)
+}
+
 func (s *Store) Get(ctx context.Context, key string, now time.Time) (ports.IdempotencyRecord, bool, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -318,36 +834,109 @@ func (s *Store) getFlowLocked(userID string) (ports.FlowState, error) {
 	if !ok {
 		return ports.FlowState{}, domainerrors.ErrProgressNotFound
 	}
-	flow, ok := s.flowsByRole[progress.Role]
+	pinned, ok := s.pinnedStepsLocked(progress)
 	if !ok {
 		return ports.FlowState{}, domainerrors.ErrFlowNotFound
 	}
-	steps := make([]ports.FlowStep, 0, len(flow.Steps))
-	for _, step := range flow.Steps {
+	steps := make([]ports.FlowStep, 0, len(pinned))
+	for _, step := range pinned {
 		status := progress.StepStatusByStepKey[step.StepKey]
 		if status == "" {
 			status = "pending"
 		}
 		steps = append(steps, ports.FlowStep{
-			StepKey: step.StepKey,
-			Title:   step.Title,
-			Status:  status,
+			StepKey:         step.StepKey,
+			Title:           step.Title,
+			Status:          status,
+			CompletionEvent: step.CompletionEvent,
 		})
 	}
 	return ports.FlowState{
 		UserID:         progress.UserID,
 		Role:           progress.Role,
 		FlowID:         progress.FlowID,
+		FlowVersion:    progress.FlowVersion,
+		ExperimentKey:  progress.ExperimentKey,
 		VariantKey:     progress.VariantKey,
 		Status:         progress.Status,
 		CompletedSteps: countCompleted(progress.StepStatusByStepKey),
-		TotalSteps:     len(flow.Steps),
+		TotalSteps:     len(pinned),
 		Steps:          steps,
 	}, nil
 }
 
-func hasStep(flow flowDefinition, stepKey string) bool {
-	for _, step := range flow.Steps {
+// activePublishedLocked returns the role's active flow and its published
+// version, which is what newly registered users are pinned to.
+func (s *Store) activePublishedLocked(role string) (flowRecord, ports.FlowVersion, bool) {
+	flow, ok := s.flowsByID[s.activeFlowIDByRole[role]]
+	if !ok {
+		return flowRecord{}, ports.FlowVersion{}, false
+	}
+	version, ok := publishedVersion(flow)
+	return flow, version, ok
+}
+
+// pinnedStepsLocked resolves the steps of the version a user started on.
+func (s *Store) pinnedStepsLocked(progress progressRecord) ([]ports.FlowStep, bool) {
+	flow, ok := s.flowsByID[progress.FlowID]
+	if !ok {
+		return nil, false
+	}
+	for _, version := range flow.Versions {
+		if version.Version == progress.FlowVersion {
+			return version.Steps, true
+		}
+	}
+	return nil, false
+}
+
+func (s *Store) toDefinitionLocked(flow flowRecord) ports.FlowDefinition {
+	out := ports.FlowDefinition{
+		FlowID:    flow.FlowID,
+		Role:      flow.Role,
+		Name:      flow.Name,
+		IsActive:  s.activeFlowIDByRole[flow.Role] == flow.FlowID,
+		Archived:  flow.Archived,
+		Versions:  make([]ports.FlowVersion, 0, len(flow.Versions)),
+		CreatedAt: flow.CreatedAt,
+		UpdatedAt: flow.UpdatedAt,
+	}
+	if published, ok := publishedVersion(flow); ok {
+		out.PublishedVersion = published.Version
+	}
+	for _, version := range flow.Versions {
+		out.Versions = append(out.Versions, cloneVersion(version))
+	}
+	return out
+}
+
+func publishedVersion(flow flowRecord) (ports.FlowVersion, bool) {
+	for _, version := range flow.Versions {
+		if version.Status == ports.FlowVersionPublished {
+			return version, true
+		}
+	}
+	return ports.FlowVersion{}, false
+}
+
+func cloneVersion(in ports.FlowVersion) ports.FlowVersion {
+	out := in
+	out.Steps = cloneSteps(in.Steps)
+	if in.PublishedAt != nil {
+		publishedAt := *in.PublishedAt
+		out.PublishedAt = &publishedAt
+	}
+	return out
+}
+
+func cloneSteps(in []ports.FlowStep) []ports.FlowStep {
+	out := make([]ports.FlowStep, len(in))
+	copy(out, in)
+	return out
+}
+
+func hasStep(steps []ports.FlowStep, stepKey string) bool {
+	for _, step := range steps {
 		if step.StepKey == stepKey {
 			return true
 		}
@@ -365,8 +954,8 @@ func countCompleted(stepStatus map[string]string) int {
 	return count
 }
 
-func nextPendingStep(progress progressRecord, flow flowDefinition) string {
-	for _, step := range flow.Steps {
+func nextPendingStep(progress progressRecord, steps []ports.FlowStep) string {
+	for _, step := range steps {
 		if progress.StepStatusByStepKey[step.StepKey] != "completed" {
 			return step.StepKey
 		}
@@ -374,12 +963,70 @@ func nextPendingStep(progress progressRecord, flow flowDefinition) string {
 	return ""
 }
 
-func chooseVariant(userID string) string {
-	sum := sha256.Sum256([]byte(userID))
-	if sum[0]%2 == 0 {
-		return "A"
+// reminderSlot reports which reminder a flow is waiting on. Skipped flows
+// are due at their scheduled time; in-progress flows are anchored at their
+// last activity or last reminder, whichever is later.
+func reminderSlot(progress progressRecord) (string, time.Time, bool) {
+	if progress.RemindersStopped {
+		return "", time.Time{}, false
+	}
+	switch progress.Status {
+	case "skipped":
+		if progress.ReminderScheduledAt == nil {
+			return "", time.Time{}, false
+		}
+		return ports.ReminderReasonSkipped, progress.ReminderScheduledAt.UTC(), true
+	case "in_progress":
+		anchor := progress.UpdatedAt.UTC()
+		if progress.LastReminderAt != nil && progress.LastReminderAt.After(anchor) {
+			anchor = progress.LastReminderAt.UTC()
+		}
+		return ports.ReminderReasonStalled, anchor, true
+	default:
+		return "", time.Time{}, false
+	}
+}
+
+func (s *Store) reminderCandidateLocked(progress progressRecord, reason string, dueAt time.Time) ports.ReminderCandidate {
+	steps, _ := s.pinnedStepsLocked(progress)
+	item := ports.ReminderCandidate{
+		UserID:         progress.UserID,
+		Email:          progress.Email,
+		Role:           progress.Role,
+		FlowID:         progress.FlowID,
+		Status:         progress.Status,
+		Reason:         reason,
+		NextStep:       nextPendingStep(progress, steps),
+		CompletedSteps: countCompleted(progress.StepStatusByStepKey),
+		TotalSteps:     len(steps),
+		RemindersSent:  progress.RemindersSent,
+		DueAt:          dueAt,
+	}
+	for _, step := range steps {
+		if step.StepKey == item.NextStep {
+			item.NextStepTitle = step.Title
+		}
+	}
+	return item
+}
+
+func cloneExperiment(in ports.Experiment) ports.Experiment {
+	out := in
+	out.Variants = append([]ports.ExperimentVariant(nil), in.Variants...)
+	if in.StoppedAt != nil {
+		stoppedAt := *in.StoppedAt
+		out.StoppedAt = &stoppedAt
+	}
+	return out
+}
+
+func cloneAssignment(in ports.VariantAssignment) ports.VariantAssignment {
+	out := in
+	if in.ExposedAt != nil {
+		exposedAt := *in.ExposedAt
+		out.ExposedAt = &exposedAt
 	}
-	return "B"
+	return out
 }
 
 func hashEvent(event ports.UserRegisteredEvent) string {
@@ -390,5 +1037,6 @@ func hashEvent(event ports.UserRegisteredEvent) string {
 
 var _ ports.Repository = (*Store)(nil)
 var _ ports.IdempotencyStore = (*Store)(nil)
+var _ ports.OutboxWriter = (*Store)(nil)
 var _ ports.Clock = (*Store)(nil)
 var _ ports.IDGenerator = (*Store)(nil)
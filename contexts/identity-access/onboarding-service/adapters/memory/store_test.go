@@ -16,7 +16,7 @@ func TestUserRegisteredCreatesFlowProgress(t *testing.T) {
 		UserID:     "user_onb_1",
 		Role:       "editor",
 		OccurredAt: time.Now().UTC(),
-	}, time.Now().UTC())
+	}, ports.VariantAssignment{}, time.Now().UTC())
 	if err != nil {
 		t.Fatalf("consume event failed: %v", err)
 	}
@@ -31,7 +31,7 @@ func TestUserRegisteredUnknownRoleRejected(t *testing.T) {
 		EventID: "evt_reg_2",
 		UserID:  "user_onb_2",
 		Role:    "admin",
-	}, time.Now().UTC())
+	}, ports.VariantAssignment{}, time.Now().UTC())
 	if err == nil {
 		t.Fatal("expected unknown role error")
 	}
@@ -48,7 +48,7 @@ func TestCompleteSkipResumeLifecycle(t *testing.T) {
 		EventID: "evt_reg_3",
 		UserID:  "user_onb_3",
 		Role:    "brand",
-	}, now)
+	}, ports.VariantAssignment{}, now)
 	if err != nil {
 		t.Fatalf("consume event failed: %v", err)
 	}
@@ -72,3 +72,61 @@ func TestCompleteSkipResumeLifecycle(t *testing.T) {
 		t.Fatalf("unexpected resume status %s", resumed.Status)
 	}
 }
+
+func TestPublishedFlowVersionKeepsExistingUsersPinned(t *testing.T) {
+	store := NewStore()
+	now := time.Now().UTC()
+
+	if _, err := store.ConsumeUserRegisteredEvent(context.Background(), ports.UserRegisteredEvent{
+		EventID: "evt_reg_pin_1",
+		UserID:  "user_onb_pin_1",
+		Role:    "editor",
+	}, ports.VariantAssignment{}, now); err != nil {
+		t.Fatalf("consume event failed: %v", err)
+	}
+
+	draft, err := store.SaveFlowDraft(context.Background(), "admin_1", "flow_editor_default", []ports.FlowStep{
+		{StepKey: "submit_first_clip", Title: "Submit your first clip", CompletionEvent: "submission.created"},
+		{StepKey: "welcome", Title: "Welcome to ViralForge"},
+	}, now)
+	if err != nil {
+		t.Fatalf("save draft failed: %v", err)
+	}
+	if draft.Version != 2 || draft.Status != ports.FlowVersionDraft {
+		t.Fatalf("expected draft version 2, got %+v", draft)
+	}
+	flow, err := store.PublishFlowVersion(context.Background(), "admin_1", "flow_editor_default", 2, now)
+	if err != nil {
+		t.Fatalf("publish failed: %v", err)
+	}
+	if flow.PublishedVersion != 2 || flow.Versions[0].Status != ports.FlowVersionSuperseded {
+		t.Fatalf("expected version 2 published and version 1 superseded, got %+v", flow)
+	}
+
+	existing, err := store.GetFlow(context.Background(), "user_onb_pin_1")
+	if err != nil {
+		t.Fatalf("get existing flow failed: %v", err)
+	}
+	if existing.FlowVersion != 1 || existing.TotalSteps != 3 {
+		t.Fatalf("expected existing user pinned to version 1, got version=%d steps=%d", existing.FlowVersion, existing.TotalSteps)
+	}
+
+	fresh, err := store.ConsumeUserRegisteredEvent(context.Background(), ports.UserRegisteredEvent{
+		EventID: "evt_reg_pin_2",
+		UserID:  "user_onb_pin_2",
+		Role:    "editor",
+	}, ports.VariantAssignment{}, now)
+	if err != nil {
+		t.Fatalf("consume second event failed: %v", err)
+	}
+	if fresh.FlowVersion != 2 || fresh.TotalSteps != 2 {
+		t.Fatalf("expected new user on version 2, got version=%d steps=%d", fresh.FlowVersion, fresh.TotalSteps)
+	}
+
+	if _, err := store.PublishFlowVersion(context.Background(), "admin_1", "flow_editor_default", 2, now); err != domainerrors.ErrConflict {
+		t.Fatalf("expected conflict republishing, got %v", err)
+	}
+	if _, err := store.ArchiveFlowDefinition(context.Background(), "admin_1", "flow_editor_default", now); err != domainerrors.ErrFlowActive {
+		t.Fatalf("expected active flow archive rejection, got %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+package notification
+
+import (
+	"context"
+	"sync"
+
+	"solomon/contexts/identity-access/onboarding-service/ports"
+)
+
+// Recorder is the in-process notifier used by the in-memory module. It keeps
+// every delivered notification so in-app messages can be listed per user.
+type Recorder struct {
+	mu    sync.RWMutex
+	items []ports.Notification
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+func (r *Recorder) Notify(ctx context.Context, notification ports.Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.items = append(r.items, notification)
+	return nil
+}
+
+// Sent returns delivered notifications, oldest first.
+func (r *Recorder) Sent() []ports.Notification {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]ports.Notification(nil), r.items...)
+}
+
+// Inbox returns the in-app notifications delivered to a user.
+func (r *Recorder) Inbox(userID string) []ports.Notification {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	items := make([]ports.Notification, 0)
+	for _, item := range r.items {
+		if item.Channel == ports.ChannelInApp && item.UserID == userID {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+var _ ports.Notifier = (*Recorder)(nil)
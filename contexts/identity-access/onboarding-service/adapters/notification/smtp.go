@@ -0,0 +1,69 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"strings"
+	"time"
+
+	domainerrors "solomon/contexts/identity-access/onboarding-service/domain/errors"
+	"solomon/contexts/identity-access/onboarding-service/ports"
+)
+
+// SMTPNotifier delivers the email channel over SMTP and hands in-app
+// notifications to InApp. Point Addr at a local sink (see SMTPSink) in
+// development and tests.
+type SMTPNotifier struct {
+	Addr  string
+	From  string
+	Auth  smtp.Auth
+	InApp ports.Notifier
+}
+
+func (n SMTPNotifier) Notify(ctx context.Context, notification ports.Notification) error {
+	switch notification.Channel {
+	case ports.ChannelEmail:
+		return n.sendEmail(notification)
+	case ports.ChannelInApp:
+		if n.InApp == nil {
+			return domainerrors.ErrDependencyUnavailable
+		}
+		return n.InApp.Notify(ctx, notification)
+	default:
+		return domainerrors.ErrInvalidRequest
+	}
+}
+
+func (n SMTPNotifier) sendEmail(notification ports.Notification) error {
+	recipient := strings.TrimSpace(notification.Recipient)
+	if recipient == "" || strings.ContainsAny(recipient, "\r\n") || strings.ContainsAny(notification.Subject, "\r\n") {
+		return domainerrors.ErrInvalidRequest
+	}
+	if strings.TrimSpace(n.Addr) == "" {
+		return domainerrors.ErrDependencyUnavailable
+	}
+	createdAt := notification.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now().UTC()
+	}
+
+	var message strings.Builder
+	fmt.Fprintf(&message, "From: %s\r\n", n.From)
+	fmt.Fprintf(&message, "To: %s\r\n", recipient)
+	fmt.Fprintf(&message, "Subject: %s\r\n", notification.Subject)
+	fmt.Fprintf(&message, "Date: %s\r\n", createdAt.UTC().Format(time.RFC1123Z))
+	fmt.Fprintf(&message, "Message-ID: <%s@onboarding.solomon>\r\n", notification.NotificationID)
+	message.WriteString("MIME-Version: 1.0\r\n")
+	message.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	message.WriteString("\r\n")
+	message.WriteString(strings.ReplaceAll(notification.Body, "\n", "\r\n"))
+	message.WriteString("\r\n")
+
+	if err := smtp.SendMail(n.Addr, n.Auth, n.From, []string{recipient}, []byte(message.String())); err != nil {
+		return fmt.Errorf("%w: %v", domainerrors.ErrDependencyUnavailable, err)
+	}
+	return nil
+}
+
+var _ ports.Notifier = SMTPNotifier{}
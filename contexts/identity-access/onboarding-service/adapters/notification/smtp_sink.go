@@ -0,0 +1,155 @@
+package notification
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"sync"
+)
+
+// SinkMessage is one message accepted by an SMTPSink.
+type SinkMessage struct {
+	From string
+	To   []string
+	Data string
+}
+
+// SMTPSink is a minimal local SMTP server that accepts every message and
+// keeps it in memory. It speaks just enough of RFC 5321 for net/smtp and
+// common clients, and is meant for tests and local development only.
+type SMTPSink struct {
+	listener net.Listener
+	wg       sync.WaitGroup
+
+	mu       sync.RWMutex
+	messages []SinkMessage
+}
+
+// StartSMTPSink listens on addr (for example "127.0.0.1:0") and serves
+// connections until Close is called.
+func StartSMTPSink(addr string) (*SMTPSink, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	sink := &SMTPSink{listener: listener}
+	sink.wg.Add(1)
+	go sink.serve()
+	return sink, nil
+}
+
+func (s *SMTPSink) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Messages returns accepted messages, oldest first.
+func (s *SMTPSink) Messages() []SinkMessage {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]SinkMessage(nil), s.messages...)
+}
+
+func (s *SMTPSink) Close() error {
+	err := s.listener.Close()
+	s.wg.Wait()
+	return err
+}
+
+func (s *SMTPSink) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handle(conn)
+		}()
+	}
+}
+
+func (s *SMTPSink) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	reply := func(line string) bool {
+		_, err := conn.Write([]byte(line + "\r\n"))
+		return err == nil
+	}
+	if !reply("220 localhost ESMTP sink") {
+		return
+	}
+
+	var current SinkMessage
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		verb := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(verb, "EHLO"), strings.HasPrefix(verb, "HELO"):
+			current = SinkMessage{}
+			reply("250 localhost")
+		case strings.HasPrefix(verb, "MAIL FROM:"):
+			current = SinkMessage{From: trimAddress(line[len("MAIL FROM:"):])}
+			reply("250 OK")
+		case strings.HasPrefix(verb, "RCPT TO:"):
+			current.To = append(current.To, trimAddress(line[len("RCPT TO:"):]))
+			reply("250 OK")
+		case verb == "DATA":
+			if !reply("354 End data with <CR><LF>.<CR><LF>") {
+				return
+			}
+			data, ok := readData(reader)
+			if !ok {
+				return
+			}
+			current.Data = data
+			s.mu.Lock()
+			s.messages = append(s.messages, current)
+			s.mu.Unlock()
+			current = SinkMessage{}
+			reply("250 OK queued")
+		case verb == "RSET":
+			current = SinkMessage{}
+			reply("250 OK")
+		case verb == "NOOP":
+			reply("250 OK")
+		case verb == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func readData(reader *bufio.Reader) (string, bool) {
+	var data strings.Builder
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return "", false
+		}
+		trimmed := strings.TrimRight(line, "\r\n")
+		if trimmed == "." {
+			return data.String(), true
+		}
+		// Undo dot-stuffing from the client.
+		trimmed = strings.TrimPrefix(trimmed, ".")
+		data.WriteString(trimmed)
+		data.WriteString("\n")
+	}
+}
+
+func trimAddress(value string) string {
+	value = strings.TrimSpace(value)
+	if index := strings.Index(value, " "); index >= 0 {
+		value = value[:index]
+	}
+	return strings.Trim(value, "<>")
+}
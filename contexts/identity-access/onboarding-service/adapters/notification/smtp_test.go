@@ -0,0 +1,49 @@
+package notification
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"solomon/contexts/identity-access/onboarding-service/ports"
+)
+
+func TestSMTPNotifierDeliversToSink(t *testing.T) {
+	sink, err := StartSMTPSink("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("start sink failed: %v", err)
+	}
+	defer sink.Close()
+
+	inbox := NewRecorder()
+	notifier := SMTPNotifier{Addr: sink.Addr(), From: "onboarding@viralforge.local", InApp: inbox}
+	err = notifier.Notify(context.Background(), ports.Notification{
+		NotificationID: "ntf_1",
+		UserID:         "user_1",
+		Channel:        ports.ChannelEmail,
+		Recipient:      "creator@example.com",
+		Subject:        "Pick up where you left off",
+		Body:           "Next up: connect your storefront.\n.leading dot survives\n",
+		CreatedAt:      time.Date(2026, time.March, 2, 10, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("send email failed: %v", err)
+	}
+	messages := sink.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("expected one message in sink, got %d", len(messages))
+	}
+	if messages[0].To[0] != "creator@example.com" ||
+		!strings.Contains(messages[0].Data, "Subject: Pick up where you left off") ||
+		!strings.Contains(messages[0].Data, "\n.leading dot survives\n") {
+		t.Fatalf("unexpected message: %+v", messages[0])
+	}
+
+	if err := notifier.Notify(context.Background(), ports.Notification{UserID: "user_1", Channel: ports.ChannelInApp, Body: "hi"}); err != nil {
+		t.Fatalf("in-app delivery failed: %v", err)
+	}
+	if len(inbox.Inbox("user_1")) != 1 {
+		t.Fatalf("expected in-app notification to reach inbox")
+	}
+}
@@ -0,0 +1,311 @@
+package postgresadapter
+
+import (
+	"encoding/json"
+	"time"
+
+	"solomon/contexts/identity-access/onboarding-service/ports"
+)
+
+// stepJSON is the persisted shape of a flow step inside
+// onboarding_flow_versions.steps.
+type stepJSON struct {
+	StepKey         string `json:"step_key"`
+	Title           string `json:"title"`
+	CompletionEvent string `json:"completion_event,omitempty"`
+}
+
+type variantJSON struct {
+	VariantKey string `json:"variant_key"`
+	Weight     int    `json:"weight"`
+	FlowID     string `json:"flow_id,omitempty"`
+}
+
+type flowModel struct {
+	FlowID    string    `gorm:"column:flow_id;primaryKey"`
+	Role      string    `gorm:"column:role"`
+	Name      string    `gorm:"column:name"`
+	Archived  bool      `gorm:"column:archived"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+}
+
+func (flowModel) TableName() string {
+	return "onboarding_flows"
+}
+
+type flowVersionModel struct {
+	FlowID      string     `gorm:"column:flow_id;primaryKey"`
+	Version     int        `gorm:"column:version;primaryKey"`
+	Status      string     `gorm:"column:status"`
+	Steps       []byte     `gorm:"column:steps"`
+	CreatedBy   string     `gorm:"column:created_by"`
+	CreatedAt   time.Time  `gorm:"column:created_at"`
+	PublishedBy string     `gorm:"column:published_by"`
+	PublishedAt *time.Time `gorm:"column:published_at"`
+}
+
+func (flowVersionModel) TableName() string {
+	return "onboarding_flow_versions"
+}
+
+func (m flowVersionModel) toPort() (ports.FlowVersion, error) {
+	steps, err := decodeSteps(m.Steps)
+	if err != nil {
+		return ports.FlowVersion{}, err
+	}
+	out := ports.FlowVersion{
+		FlowID:      m.FlowID,
+		Version:     m.Version,
+		Status:      m.Status,
+		Steps:       steps,
+		CreatedBy:   m.CreatedBy,
+		CreatedAt:   m.CreatedAt.UTC(),
+		PublishedBy: m.PublishedBy,
+	}
+	if m.PublishedAt != nil {
+		publishedAt := m.PublishedAt.UTC()
+		out.PublishedAt = &publishedAt
+	}
+	return out, nil
+}
+
+type activeFlowModel struct {
+	Role      string    `gorm:"column:role;primaryKey"`
+	FlowID    string    `gorm:"column:flow_id"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+}
+
+func (activeFlowModel) TableName() string {
+	return "onboarding_active_flows"
+}
+
+type progressModel struct {
+	UserID              string     `gorm:"column:user_id;primaryKey"`
+	Email               string     `gorm:"column:email"`
+	Role                string     `gorm:"column:role"`
+	FlowID              string     `gorm:"column:flow_id"`
+	FlowVersion         int        `gorm:"column:flow_version"`
+	ExperimentKey       string     `gorm:"column:experiment_key"`
+	VariantKey          string     `gorm:"column:variant_key"`
+	Status              string     `gorm:"column:status"`
+	StepStatus          []byte     `gorm:"column:step_status"`
+	CreatedAt           time.Time  `gorm:"column:created_at"`
+	UpdatedAt           time.Time  `gorm:"column:updated_at"`
+	CompletedAt         *time.Time `gorm:"column:completed_at"`
+	ReminderScheduledAt *time.Time `gorm:"column:reminder_scheduled_at"`
+	RemindersSent       int        `gorm:"column:reminders_sent"`
+	LastReminderAt      *time.Time `gorm:"column:last_reminder_at"`
+	RemindersStopped    bool       `gorm:"column:reminders_stopped"`
+}
+
+func (progressModel) TableName() string {
+	return "onboarding_progress"
+}
+
+func (m progressModel) stepStatus() (map[string]string, error) {
+	out := make(map[string]string)
+	if len(m.StepStatus) == 0 {
+		return out, nil
+	}
+	if err := json.Unmarshal(m.StepStatus, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type registrationDedupModel struct {
+	EventID     string    `gorm:"column:event_id;primaryKey"`
+	RequestHash string    `gorm:"column:request_hash"`
+	ExpiresAt   time.Time `gorm:"column:expires_at"`
+}
+
+func (registrationDedupModel) TableName() string {
+	return "onboarding_registration_dedup"
+}
+
+type activityDedupModel struct {
+	EventID   string    `gorm:"column:event_id;primaryKey"`
+	ExpiresAt time.Time `gorm:"column:expires_at"`
+}
+
+func (activityDedupModel) TableName() string {
+	return "onboarding_activity_dedup"
+}
+
+type experimentModel struct {
+	ExperimentKey string     `gorm:"column:experiment_key;primaryKey"`
+	Role          string     `gorm:"column:role"`
+	Status        string     `gorm:"column:status"`
+	Variants      []byte     `gorm:"column:variants"`
+	CreatedBy     string     `gorm:"column:created_by"`
+	CreatedAt     time.Time  `gorm:"column:created_at"`
+	StoppedAt     *time.Time `gorm:"column:stopped_at"`
+}
+
+func (experimentModel) TableName() string {
+	return "onboarding_experiments"
+}
+
+func (m experimentModel) toPort() (ports.Experiment, error) {
+	var variants []variantJSON
+	if len(m.Variants) > 0 {
+		if err := json.Unmarshal(m.Variants, &variants); err != nil {
+			return ports.Experiment{}, err
+		}
+	}
+	out := ports.Experiment{
+		ExperimentKey: m.ExperimentKey,
+		Role:          m.Role,
+		Status:        m.Status,
+		Variants:      make([]ports.ExperimentVariant, 0, len(variants)),
+		CreatedBy:     m.CreatedBy,
+		CreatedAt:     m.CreatedAt.UTC(),
+	}
+	for _, variant := range variants {
+		out.Variants = append(out.Variants, ports.ExperimentVariant{
+			VariantKey: variant.VariantKey,
+			Weight:     variant.Weight,
+			FlowID:     variant.FlowID,
+		})
+	}
+	if m.StoppedAt != nil {
+		stoppedAt := m.StoppedAt.UTC()
+		out.StoppedAt = &stoppedAt
+	}
+	return out, nil
+}
+
+type assignmentModel struct {
+	UserID        string     `gorm:"column:user_id;primaryKey"`
+	ExperimentKey string     `gorm:"column:experiment_key"`
+	VariantKey    string     `gorm:"column:variant_key"`
+	FlowID        string     `gorm:"column:flow_id"`
+	AssignedAt    time.Time  `gorm:"column:assigned_at"`
+	ExposedAt     *time.Time `gorm:"column:exposed_at"`
+}
+
+func (assignmentModel) TableName() string {
+	return "onboarding_variant_assignments"
+}
+
+func (m assignmentModel) toPort() ports.VariantAssignment {
+	out := ports.VariantAssignment{
+		UserID:        m.UserID,
+		ExperimentKey: m.ExperimentKey,
+		VariantKey:    m.VariantKey,
+		FlowID:        m.FlowID,
+		AssignedAt:    m.AssignedAt.UTC(),
+	}
+	if m.ExposedAt != nil {
+		exposedAt := m.ExposedAt.UTC()
+		out.ExposedAt = &exposedAt
+	}
+	return out
+}
+
+type preferencesModel struct {
+	UserID          string    `gorm:"column:user_id;primaryKey"`
+	EmailEnabled    bool      `gorm:"column:email_enabled"`
+	InAppEnabled    bool      `gorm:"column:in_app_enabled"`
+	Unsubscribed    bool      `gorm:"column:unsubscribed"`
+	QuietHoursStart string    `gorm:"column:quiet_hours_start"`
+	QuietHoursEnd   string    `gorm:"column:quiet_hours_end"`
+	Timezone        string    `gorm:"column:timezone"`
+	UpdatedAt       time.Time `gorm:"column:updated_at"`
+}
+
+func (preferencesModel) TableName() string {
+	return "onboarding_reminder_preferences"
+}
+
+func (m preferencesModel) toPort() ports.ReminderPreferences {
+	return ports.ReminderPreferences{
+		UserID:          m.UserID,
+		EmailEnabled:    m.EmailEnabled,
+		InAppEnabled:    m.InAppEnabled,
+		Unsubscribed:    m.Unsubscribed,
+		QuietHoursStart: m.QuietHoursStart,
+		QuietHoursEnd:   m.QuietHoursEnd,
+		Timezone:        m.Timezone,
+		UpdatedAt:       m.UpdatedAt.UTC(),
+	}
+}
+
+type outboxModel struct {
+	OutboxID     string     `gorm:"column:outbox_id;primaryKey"`
+	Seq          int64      `gorm:"column:seq;->"`
+	EventType    string     `gorm:"column:event_type"`
+	PartitionKey string     `gorm:"column:partition_key"`
+	Payload      []byte     `gorm:"column:payload"`
+	Status       string     `gorm:"column:status"`
+	CreatedAt    time.Time  `gorm:"column:created_at"`
+	PublishedAt  *time.Time `gorm:"column:published_at"`
+}
+
+func (outboxModel) TableName() string {
+	return "onboarding_outbox"
+}
+
+type idempotencyModel struct {
+	Key         string    `gorm:"column:key;primaryKey"`
+	RequestHash string    `gorm:"column:request_hash"`
+	Payload     []byte    `gorm:"column:payload"`
+	ExpiresAt   time.Time `gorm:"column:expires_at"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+}
+
+func (idempotencyModel) TableName() string {
+	return "onboarding_idempotency"
+}
+
+func (m idempotencyModel) toPort() ports.IdempotencyRecord {
+	return ports.IdempotencyRecord{
+		Key:         m.Key,
+		RequestHash: m.RequestHash,
+		Payload:     append([]byte(nil), m.Payload...),
+		ExpiresAt:   m.ExpiresAt.UTC(),
+	}
+}
+
+func encodeSteps(steps []ports.FlowStep) ([]byte, error) {
+	items := make([]stepJSON, 0, len(steps))
+	for _, step := range steps {
+		items = append(items, stepJSON{
+			StepKey:         step.StepKey,
+			Title:           step.Title,
+			CompletionEvent: step.CompletionEvent,
+		})
+	}
+	return json.Marshal(items)
+}
+
+func decodeSteps(raw []byte) ([]ports.FlowStep, error) {
+	var items []stepJSON
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return nil, err
+		}
+	}
+	steps := make([]ports.FlowStep, 0, len(items))
+	for _, item := range items {
+		steps = append(steps, ports.FlowStep{
+			StepKey:         item.StepKey,
+			Title:           item.Title,
+			CompletionEvent: item.CompletionEvent,
+		})
+	}
+	return steps, nil
+}
+
+func encodeVariants(variants []ports.ExperimentVariant) ([]byte, error) {
+	items := make([]variantJSON, 0, len(variants))
+	for _, variant := range variants {
+		items = append(items, variantJSON{
+			VariantKey: variant.VariantKey,
+			Weight:     variant.Weight,
+			FlowID:     variant.FlowID,
+		})
+	}
+	return json.Marshal(items)
+}
@@ -0,0 +1,1253 @@
+package postgresadapter
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	domainerrors "solomon/contexts/identity-access/onboarding-service/domain/errors"
+	"solomon/contexts/identity-access/onboarding-service/ports"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	outboxStatusPending = "pending"
+	eventDedupTTL       = 7 * 24 * time.Hour
+	skipReminderDelay   = 7 * 24 * time.Hour
+)
+
+// Repository persists onboarding flows, user progress, experiments and
+// reminder state. Every write that reads progress first locks the user's
+// progress row, so activity events, reminder claims and user actions on the
+// same flow serialise; the partial unique indexes settle races between
+// admins publishing flows or starting experiments.
+type Repository struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+// NewRepository builds the GORM-backed onboarding adapter.
+func NewRepository(db *gorm.DB, logger *slog.Logger) *Repository {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Repository{db: db, logger: logger}
+}
+
+func (r *Repository) ConsumeUserRegisteredEvent(
+	ctx context.Context,
+	event ports.UserRegisteredEvent,
+	assignment ports.VariantAssignment,
+	now time.Time,
+) (ports.FlowState, error) {
+	now = now.UTC()
+	eventID := strings.TrimSpace(event.EventID)
+	userID := strings.TrimSpace(event.UserID)
+	if eventID == "" || userID == "" {
+		return ports.FlowState{}, domainerrors.ErrSchemaInvalid
+	}
+	role := strings.ToLower(strings.TrimSpace(event.Role))
+	if !ports.IsValidRole(role) {
+		return ports.FlowState{}, domainerrors.ErrUnknownRole
+	}
+
+	hash := hashEvent(event)
+	var out ports.FlowState
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var dedup registrationDedupModel
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("event_id = ?", eventID).
+			First(&dedup).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+		case err != nil:
+			return err
+		case now.After(dedup.ExpiresAt.UTC()):
+		case dedup.RequestHash != hash:
+			return domainerrors.ErrIdempotencyConflict
+		default:
+			state, err := loadFlowState(tx, userID)
+			out = state
+			return err
+		}
+
+		flow, version, err := enrollmentFlow(tx, role, assignment.FlowID)
+		if err != nil {
+			return err
+		}
+		variantKey := ports.DefaultVariantKey
+		if assignment.ExperimentKey != "" {
+			variantKey = assignment.VariantKey
+		}
+		stepStatus := make(map[string]string, len(version.Steps))
+		for _, step := range version.Steps {
+			stepStatus[step.StepKey] = "pending"
+		}
+		rawStatus, err := json.Marshal(stepStatus)
+		if err != nil {
+			return err
+		}
+		created := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoNothing: true,
+		}).Create(&progressModel{
+			UserID:        userID,
+			Email:         strings.TrimSpace(event.Email),
+			Role:          role,
+			FlowID:        flow.FlowID,
+			FlowVersion:   version.Version,
+			ExperimentKey: assignment.ExperimentKey,
+			VariantKey:    variantKey,
+			Status:        "in_progress",
+			StepStatus:    rawStatus,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		})
+		if created.Error != nil {
+			return created.Error
+		}
+		if created.RowsAffected > 0 && assignment.ExperimentKey != "" {
+			if err := tx.Create(&assignmentModel{
+				UserID:        userID,
+				ExperimentKey: assignment.ExperimentKey,
+				VariantKey:    assignment.VariantKey,
+				FlowID:        assignment.FlowID,
+				AssignedAt:    now,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "event_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"request_hash", "expires_at"}),
+		}).Create(&registrationDedupModel{
+			EventID:     eventID,
+			RequestHash: hash,
+			ExpiresAt:   now.Add(eventDedupTTL),
+		}).Error; err != nil {
+			return err
+		}
+		state, err := loadFlowState(tx, userID)
+		out = state
+		return err
+	})
+	if err != nil {
+		return ports.FlowState{}, err
+	}
+	return out, nil
+}
+
+func (r *Repository) GetFlow(ctx context.Context, userID string) (ports.FlowState, error) {
+	return loadFlowState(r.db.WithContext(ctx), strings.TrimSpace(userID))
+}
+
+func (r *Repository) CompleteStep(ctx context.Context, userID string, stepKey string, metadata map[string]any, now time.Time) (ports.StepCompletion, error) {
+	now = now.UTC()
+	stepKey = strings.TrimSpace(stepKey)
+	var out ports.StepCompletion
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		progress, err := lockProgress(tx, userID)
+		if err != nil {
+			return err
+		}
+		steps, err := pinnedSteps(tx, progress)
+		if err != nil {
+			return err
+		}
+		if !hasStep(steps, stepKey) {
+			return domainerrors.ErrStepNotFound
+		}
+		switch progress.Status {
+		case "completed":
+			return domainerrors.ErrFlowAlreadyCompleted
+		case "skipped":
+			return domainerrors.ErrConflict
+		}
+		stepStatus, err := progress.stepStatus()
+		if err != nil {
+			return err
+		}
+		if stepStatus[stepKey] == "completed" {
+			return domainerrors.ErrStepAlreadyCompleted
+		}
+		stepStatus[stepKey] = "completed"
+
+		updates := map[string]any{"updated_at": now, "status": "in_progress"}
+		completed := countCompleted(stepStatus)
+		if completed >= len(steps) {
+			updates["status"] = "completed"
+			updates["completed_at"] = now
+		}
+		if err := saveProgress(tx, progress.UserID, stepStatus, updates); err != nil {
+			return err
+		}
+		out = ports.StepCompletion{
+			StepKey:        stepKey,
+			Status:         "completed",
+			CompletedSteps: completed,
+			TotalSteps:     len(steps),
+		}
+		return nil
+	})
+	if err != nil {
+		return ports.StepCompletion{}, err
+	}
+	return out, nil
+}
+
+func (r *Repository) SkipFlow(ctx context.Context, userID string, reason string, now time.Time) (ports.SkipResult, error) {
+	now = now.UTC()
+	var out ports.SkipResult
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		progress, err := lockProgress(tx, userID)
+		if err != nil {
+			return err
+		}
+		switch progress.Status {
+		case "completed":
+			return domainerrors.ErrFlowAlreadyCompleted
+		case "skipped":
+			out = ports.SkipResult{Status: "skipped", ReminderScheduledAt: now.Add(skipReminderDelay)}
+			if progress.ReminderScheduledAt != nil {
+				out.ReminderScheduledAt = progress.ReminderScheduledAt.UTC()
+			}
+			return nil
+		}
+
+		reminder := now.Add(skipReminderDelay)
+		if err := tx.Model(&progressModel{}).
+			Where("user_id = ?", progress.UserID).
+			Updates(map[string]any{
+				"status":                "skipped",
+				"updated_at":            now,
+				"reminder_scheduled_at": reminder,
+				"reminders_sent":        0,
+				"last_reminder_at":      nil,
+				"reminders_stopped":     false,
+			}).Error; err != nil {
+			return err
+		}
+		out = ports.SkipResult{Status: "skipped", ReminderScheduledAt: reminder}
+		return nil
+	})
+	if err != nil {
+		return ports.SkipResult{}, err
+	}
+	return out, nil
+}
+
+func (r *Repository) ResumeFlow(ctx context.Context, userID string, now time.Time) (ports.ResumeResult, error) {
+	now = now.UTC()
+	var out ports.ResumeResult
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		progress, err := lockProgress(tx, userID)
+		if err != nil {
+			return err
+		}
+		if progress.Status != "skipped" {
+			return domainerrors.ErrResumeNotAllowed
+		}
+		steps, err := pinnedSteps(tx, progress)
+		if err != nil && !errors.Is(err, domainerrors.ErrFlowNotFound) {
+			return err
+		}
+		stepStatus, err := progress.stepStatus()
+		if err != nil {
+			return err
+		}
+		nextStep := nextPendingStep(stepStatus, steps)
+		if nextStep == "" {
+			return domainerrors.ErrResumeNotAllowed
+		}
+		if err := tx.Model(&progressModel{}).
+			Where("user_id = ?", progress.UserID).
+			Updates(map[string]any{
+				"status":                "in_progress",
+				"updated_at":            now,
+				"reminder_scheduled_at": nil,
+				"reminders_sent":        0,
+				"last_reminder_at":      nil,
+				"reminders_stopped":     false,
+			}).Error; err != nil {
+			return err
+		}
+		out = ports.ResumeResult{Status: "in_progress", NextStep: nextStep}
+		return nil
+	})
+	if err != nil {
+		return ports.ResumeResult{}, err
+	}
+	return out, nil
+}
+
+func (r *Repository) ListAdminFlows(ctx context.Context) ([]ports.AdminFlow, error) {
+	db := r.db.WithContext(ctx)
+	var flows []flowModel
+	if err := db.Where("archived = ?", false).Find(&flows).Error; err != nil {
+		return nil, err
+	}
+	active, err := activeFlowIDs(db)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]ports.AdminFlow, 0, len(flows))
+	for _, flow := range flows {
+		versions, err := flowVersions(db, flow.FlowID)
+		if err != nil {
+			return nil, err
+		}
+		item := ports.AdminFlow{
+			FlowID:   flow.FlowID,
+			Role:     flow.Role,
+			Name:     flow.Name,
+			IsActive: active[flow.Role] == flow.FlowID,
+		}
+		if len(versions) > 0 {
+			item.LatestVersion = versions[len(versions)-1].Version
+		}
+		if published, ok := publishedVersion(versions); ok {
+			item.PublishedVersion = published.Version
+			item.StepsCount = len(published.Steps)
+		}
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i int, j int) bool {
+		if items[i].Role != items[j].Role {
+			return items[i].Role < items[j].Role
+		}
+		return items[i].FlowID < items[j].FlowID
+	})
+	return items, nil
+}
+
+func (r *Repository) CreateFlowDefinition(ctx context.Context, actorID string, input ports.CreateFlowInput, now time.Time) (ports.FlowDefinition, error) {
+	now = now.UTC()
+	steps, err := encodeSteps(input.Steps)
+	if err != nil {
+		return ports.FlowDefinition{}, err
+	}
+	flow := flowModel{
+		FlowID:    "flow_" + uuid.NewString(),
+		Role:      input.Role,
+		Name:      input.Name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	var out ports.FlowDefinition
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&flow).Error; err != nil {
+			return mapWriteError(err)
+		}
+		if err := tx.Create(&flowVersionModel{
+			FlowID:    flow.FlowID,
+			Version:   1,
+			Status:    ports.FlowVersionDraft,
+			Steps:     steps,
+			CreatedBy: actorID,
+			CreatedAt: now,
+		}).Error; err != nil {
+			return err
+		}
+		definition, err := toDefinition(tx, flow)
+		out = definition
+		return err
+	})
+	if err != nil {
+		return ports.FlowDefinition{}, err
+	}
+	return out, nil
+}
+
+func (r *Repository) GetFlowDefinition(ctx context.Context, flowID string) (ports.FlowDefinition, error) {
+	db := r.db.WithContext(ctx)
+	var flow flowModel
+	if err := db.Where("flow_id = ?", strings.TrimSpace(flowID)).First(&flow).Error; err != nil {
+		return ports.FlowDefinition{}, mapFlowLookupError(err)
+	}
+	return toDefinition(db, flow)
+}
+
+func (r *Repository) SaveFlowDraft(ctx context.Context, actorID string, flowID string, steps []ports.FlowStep, now time.Time) (ports.FlowVersion, error) {
+	now = now.UTC()
+	rawSteps, err := encodeSteps(steps)
+	if err != nil {
+		return ports.FlowVersion{}, err
+	}
+	var out ports.FlowVersion
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		flow, err := lockFlow(tx, flowID)
+		if err != nil {
+			return err
+		}
+		if flow.Archived {
+			return domainerrors.ErrFlowArchived
+		}
+		var latest flowVersionModel
+		err = tx.Where("flow_id = ?", flow.FlowID).Order("version DESC").First(&latest).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		draft := flowVersionModel{
+			FlowID:    flow.FlowID,
+			Version:   latest.Version + 1,
+			Status:    ports.FlowVersionDraft,
+			Steps:     rawSteps,
+			CreatedBy: actorID,
+			CreatedAt: now,
+		}
+		if err == nil && latest.Status == ports.FlowVersionDraft {
+			draft.Version = latest.Version
+			if err := tx.Model(&flowVersionModel{}).
+				Where("flow_id = ? AND version = ?", flow.FlowID, latest.Version).
+				Updates(map[string]any{
+					"steps":      rawSteps,
+					"created_by": actorID,
+					"created_at": now,
+				}).Error; err != nil {
+				return err
+			}
+		} else if err := tx.Create(&draft).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&flowModel{}).Where("flow_id = ?", flow.FlowID).Update("updated_at", now).Error; err != nil {
+			return err
+		}
+		version, err := draft.toPort()
+		out = version
+		return err
+	})
+	if err != nil {
+		return ports.FlowVersion{}, err
+	}
+	return out, nil
+}
+
+func (r *Repository) PublishFlowVersion(ctx context.Context, actorID string, flowID string, version int, now time.Time) (ports.FlowDefinition, error) {
+	now = now.UTC()
+	var out ports.FlowDefinition
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		flow, err := lockFlow(tx, flowID)
+		if err != nil {
+			return err
+		}
+		if flow.Archived {
+			return domainerrors.ErrFlowArchived
+		}
+		var target flowVersionModel
+		if err := tx.Where("flow_id = ? AND version = ?", flow.FlowID, version).First(&target).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domainerrors.ErrFlowVersionNotFound
+			}
+			return err
+		}
+		if target.Status != ports.FlowVersionDraft {
+			return domainerrors.ErrConflict
+		}
+		if err := tx.Model(&flowVersionModel{}).
+			Where("flow_id = ? AND status = ?", flow.FlowID, ports.FlowVersionPublished).
+			Update("status", ports.FlowVersionSuperseded).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&flowVersionModel{}).
+			Where("flow_id = ? AND version = ?", flow.FlowID, version).
+			Updates(map[string]any{
+				"status":       ports.FlowVersionPublished,
+				"published_by": actorID,
+				"published_at": now,
+			}).Error; err != nil {
+			return mapWriteError(err)
+		}
+		flow.UpdatedAt = now
+		if err := tx.Model(&flowModel{}).Where("flow_id = ?", flow.FlowID).Update("updated_at", now).Error; err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "role"}},
+			DoUpdates: clause.AssignmentColumns([]string{"flow_id", "updated_at"}),
+		}).Create(&activeFlowModel{Role: flow.Role, FlowID: flow.FlowID, UpdatedAt: now}).Error; err != nil {
+			return err
+		}
+		definition, err := toDefinition(tx, flow)
+		out = definition
+		return err
+	})
+	if err != nil {
+		return ports.FlowDefinition{}, err
+	}
+	return out, nil
+}
+
+func (r *Repository) ArchiveFlowDefinition(ctx context.Context, actorID string, flowID string, now time.Time) (ports.FlowDefinition, error) {
+	now = now.UTC()
+	var out ports.FlowDefinition
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		flow, err := lockFlow(tx, flowID)
+		if err != nil {
+			return err
+		}
+		active, err := activeFlowIDs(tx)
+		if err != nil {
+			return err
+		}
+		if active[flow.Role] == flow.FlowID {
+			return domainerrors.ErrFlowActive
+		}
+		running, err := listExperiments(tx.Where("status = ?", ports.ExperimentRunning))
+		if err != nil {
+			return err
+		}
+		for _, experiment := range running {
+			for _, variant := range experiment.Variants {
+				if variant.FlowID == flow.FlowID {
+					return domainerrors.ErrFlowActive
+				}
+			}
+		}
+		if !flow.Archived {
+			flow.Archived = true
+			flow.UpdatedAt = now
+			if err := tx.Model(&flowModel{}).
+				Where("flow_id = ?", flow.FlowID).
+				Updates(map[string]any{"archived": true, "updated_at": now}).Error; err != nil {
+				return err
+			}
+		}
+		definition, err := toDefinition(tx, flow)
+		out = definition
+		return err
+	})
+	if err != nil {
+		return ports.FlowDefinition{}, err
+	}
+	return out, nil
+}
+
+func (r *Repository) ApplyActivityEvent(ctx context.Context, event ports.ActivityEvent, now time.Time) (ports.ActivityResult, error) {
+	now = now.UTC()
+	userID := strings.TrimSpace(event.UserID)
+	var out ports.ActivityResult
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		progress, err := lockProgress(tx, userID)
+		if err != nil {
+			return err
+		}
+		steps, err := pinnedSteps(tx, progress)
+		if err != nil {
+			return err
+		}
+		stepStatus, err := progress.stepStatus()
+		if err != nil {
+			return err
+		}
+		out = ports.ActivityResult{
+			UserID:            userID,
+			Status:            progress.Status,
+			CompletedStepKeys: []string{},
+			CompletedSteps:    countCompleted(stepStatus),
+			TotalSteps:        len(steps),
+		}
+
+		var dedup activityDedupModel
+		err = tx.Where("event_id = ?", event.EventID).First(&dedup).Error
+		if err == nil && now.Before(dedup.ExpiresAt.UTC()) {
+			return nil
+		}
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "event_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"expires_at"}),
+		}).Create(&activityDedupModel{EventID: event.EventID, ExpiresAt: now.Add(eventDedupTTL)}).Error; err != nil {
+			return err
+		}
+
+		// Skipped flows still record progress so resuming picks up where the
+		// user actually is; completed flows have nothing left to satisfy.
+		if progress.Status == "completed" {
+			return nil
+		}
+		for _, step := range steps {
+			if step.CompletionEvent == "" || step.CompletionEvent != event.EventType {
+				continue
+			}
+			if stepStatus[step.StepKey] == "completed" {
+				continue
+			}
+			stepStatus[step.StepKey] = "completed"
+			out.CompletedStepKeys = append(out.CompletedStepKeys, step.StepKey)
+		}
+		if len(out.CompletedStepKeys) == 0 {
+			return nil
+		}
+		updates := map[string]any{"updated_at": now}
+		out.CompletedSteps = countCompleted(stepStatus)
+		if out.CompletedSteps >= len(steps) {
+			updates["status"] = "completed"
+			updates["completed_at"] = now
+			updates["reminder_scheduled_at"] = nil
+			out.Status = "completed"
+		}
+		return saveProgress(tx, progress.UserID, stepStatus, updates)
+	})
+	if err != nil {
+		return ports.ActivityResult{}, err
+	}
+	return out, nil
+}
+
+func (r *Repository) CreateExperiment(ctx context.Context, actorID string, input ports.CreateExperimentInput, now time.Time) (ports.Experiment, error) {
+	variants, err := encodeVariants(input.Variants)
+	if err != nil {
+		return ports.Experiment{}, err
+	}
+	row := experimentModel{
+		ExperimentKey: input.ExperimentKey,
+		Role:          input.Role,
+		Status:        ports.ExperimentRunning,
+		Variants:      variants,
+		CreatedBy:     actorID,
+		CreatedAt:     now.UTC(),
+	}
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing int64
+		if err := tx.Model(&experimentModel{}).Where("experiment_key = ?", input.ExperimentKey).Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return domainerrors.ErrConflict
+		}
+		var running int64
+		if err := tx.Model(&experimentModel{}).
+			Where("role = ? AND status = ?", input.Role, ports.ExperimentRunning).
+			Count(&running).Error; err != nil {
+			return err
+		}
+		if running > 0 {
+			return domainerrors.ErrExperimentRunning
+		}
+		for _, variant := range input.Variants {
+			if variant.FlowID == "" {
+				continue
+			}
+			var flow flowModel
+			if err := tx.Where("flow_id = ?", variant.FlowID).First(&flow).Error; err != nil {
+				return mapFlowLookupError(err)
+			}
+			if flow.Archived {
+				return domainerrors.ErrFlowArchived
+			}
+			versions, err := flowVersions(tx, flow.FlowID)
+			if err != nil {
+				return err
+			}
+			if _, published := publishedVersion(versions); !published || flow.Role != input.Role {
+				return domainerrors.ErrInvalidRequest
+			}
+		}
+		return mapWriteError(tx.Create(&row).Error)
+	})
+	if err != nil {
+		return ports.Experiment{}, err
+	}
+	return row.toPort()
+}
+
+func (r *Repository) ListExperiments(ctx context.Context) ([]ports.Experiment, error) {
+	return listExperiments(r.db.WithContext(ctx).Order("created_at DESC, experiment_key ASC"))
+}
+
+func (r *Repository) GetExperiment(ctx context.Context, experimentKey string) (ports.Experiment, error) {
+	var row experimentModel
+	if err := r.db.WithContext(ctx).Where("experiment_key = ?", strings.TrimSpace(experimentKey)).First(&row).Error; err != nil {
+		return ports.Experiment{}, mapExperimentLookupError(err)
+	}
+	return row.toPort()
+}
+
+func (r *Repository) GetRunningExperiment(ctx context.Context, role string) (ports.Experiment, error) {
+	var row experimentModel
+	if err := r.db.WithContext(ctx).
+		Where("role = ? AND status = ?", role, ports.ExperimentRunning).
+		First(&row).Error; err != nil {
+		return ports.Experiment{}, mapExperimentLookupError(err)
+	}
+	return row.toPort()
+}
+
+func (r *Repository) StopExperiment(ctx context.Context, actorID string, experimentKey string, now time.Time) (ports.Experiment, error) {
+	db := r.db.WithContext(ctx)
+	experimentKey = strings.TrimSpace(experimentKey)
+	if err := db.Model(&experimentModel{}).
+		Where("experiment_key = ? AND status <> ?", experimentKey, ports.ExperimentStopped).
+		Updates(map[string]any{"status": ports.ExperimentStopped, "stopped_at": now.UTC()}).Error; err != nil {
+		return ports.Experiment{}, err
+	}
+	return r.GetExperiment(ctx, experimentKey)
+}
+
+func (r *Repository) MarkVariantExposed(ctx context.Context, userID string, now time.Time) (ports.VariantAssignment, bool, error) {
+	db := r.db.WithContext(ctx)
+	userID = strings.TrimSpace(userID)
+	result := db.Model(&assignmentModel{}).
+		Where("user_id = ? AND exposed_at IS NULL", userID).
+		Update("exposed_at", now.UTC())
+	if result.Error != nil {
+		return ports.VariantAssignment{}, false, result.Error
+	}
+	var row assignmentModel
+	if err := db.Where("user_id = ?", userID).First(&row).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ports.VariantAssignment{}, false, domainerrors.ErrNotFound
+		}
+		return ports.VariantAssignment{}, false, err
+	}
+	return row.toPort(), result.RowsAffected > 0, nil
+}
+
+func (r *Repository) ListExperimentOutcomes(ctx context.Context, experimentKey string) ([]ports.ExperimentOutcome, error) {
+	db := r.db.WithContext(ctx)
+	experimentKey = strings.TrimSpace(experimentKey)
+	if _, err := r.GetExperiment(ctx, experimentKey); err != nil {
+		return nil, err
+	}
+	var rows []struct {
+		UserID      string
+		VariantKey  string
+		AssignedAt  time.Time
+		ExposedAt   *time.Time
+		CompletedAt *time.Time
+	}
+	if err := db.Table("onboarding_variant_assignments AS a").
+		Select("a.user_id, a.variant_key, a.assigned_at, a.exposed_at, p.completed_at").
+		Joins("LEFT JOIN onboarding_progress AS p ON p.user_id = a.user_id").
+		Where("a.experiment_key = ?", experimentKey).
+		Order("a.user_id ASC").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	items := make([]ports.ExperimentOutcome, 0, len(rows))
+	for _, row := range rows {
+		item := ports.ExperimentOutcome{
+			UserID:     row.UserID,
+			VariantKey: row.VariantKey,
+			AssignedAt: row.AssignedAt.UTC(),
+		}
+		if row.ExposedAt != nil {
+			exposedAt := row.ExposedAt.UTC()
+			item.ExposedAt = &exposedAt
+		}
+		if row.CompletedAt != nil {
+			completedAt := row.CompletedAt.UTC()
+			item.CompletedAt = &completedAt
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (r *Repository) ListDueReminders(ctx context.Context, now time.Time, stalledBefore time.Time, limit int) ([]ports.ReminderCandidate, error) {
+	db := r.db.WithContext(ctx)
+	query := db.
+		Where("NOT reminders_stopped").
+		Where(
+			db.Where("status = ? AND reminder_scheduled_at IS NOT NULL AND reminder_scheduled_at <= ?", "skipped", now.UTC()).
+				Or("status = ? AND GREATEST(updated_at, COALESCE(last_reminder_at, updated_at)) <= ?", "in_progress", stalledBefore.UTC()),
+		).
+		Order("COALESCE(reminder_scheduled_at, GREATEST(updated_at, COALESCE(last_reminder_at, updated_at))) ASC, user_id ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	var rows []progressModel
+	if err := query.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	stepsByVersion := make(map[string][]ports.FlowStep)
+	items := make([]ports.ReminderCandidate, 0, len(rows))
+	for _, progress := range rows {
+		reason, dueAt, ok := reminderSlot(progress)
+		if !ok {
+			continue
+		}
+		versionKey := fmt.Sprintf("%s#%d", progress.FlowID, progress.FlowVersion)
+		steps, cached := stepsByVersion[versionKey]
+		if !cached {
+			loaded, err := pinnedSteps(db, progress)
+			if err != nil && !errors.Is(err, domainerrors.ErrFlowNotFound) {
+				return nil, err
+			}
+			steps = loaded
+			stepsByVersion[versionKey] = steps
+		}
+		item, err := reminderCandidate(progress, steps, reason, dueAt)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (r *Repository) ClaimReminder(ctx context.Context, candidate ports.ReminderCandidate, now time.Time, nextAt *time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		progress, err := lockProgress(tx, candidate.UserID)
+		if err != nil {
+			return err
+		}
+		reason, dueAt, ok := reminderSlot(progress)
+		if !ok || reason != candidate.Reason || !dueAt.Equal(candidate.DueAt) {
+			return domainerrors.ErrConflict
+		}
+		updates := map[string]any{
+			"reminders_sent":   gorm.Expr("reminders_sent + 1"),
+			"last_reminder_at": now.UTC(),
+		}
+		if nextAt == nil {
+			updates["reminders_stopped"] = true
+			updates["reminder_scheduled_at"] = nil
+		} else if reason == ports.ReminderReasonSkipped {
+			updates["reminder_scheduled_at"] = nextAt.UTC()
+		}
+		return tx.Model(&progressModel{}).Where("user_id = ?", progress.UserID).Updates(updates).Error
+	})
+}
+
+func (r *Repository) GetReminderPreferences(ctx context.Context, userID string) (ports.ReminderPreferences, error) {
+	userID = strings.TrimSpace(userID)
+	var row preferencesModel
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ports.ReminderPreferences{
+			UserID:       userID,
+			EmailEnabled: true,
+			InAppEnabled: true,
+			Timezone:     "UTC",
+		}, nil
+	}
+	if err != nil {
+		return ports.ReminderPreferences{}, err
+	}
+	return row.toPort(), nil
+}
+
+func (r *Repository) SaveReminderPreferences(ctx context.Context, preferences ports.ReminderPreferences) (ports.ReminderPreferences, error) {
+	row := preferencesModel{
+		UserID:          preferences.UserID,
+		EmailEnabled:    preferences.EmailEnabled,
+		InAppEnabled:    preferences.InAppEnabled,
+		Unsubscribed:    preferences.Unsubscribed,
+		QuietHoursStart: preferences.QuietHoursStart,
+		QuietHoursEnd:   preferences.QuietHoursEnd,
+		Timezone:        preferences.Timezone,
+		UpdatedAt:       preferences.UpdatedAt.UTC(),
+	}
+	if row.UpdatedAt.IsZero() {
+		row.UpdatedAt = time.Now().UTC()
+	}
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		UpdateAll: true,
+	}).Create(&row).Error; err != nil {
+		return ports.ReminderPreferences{}, err
+	}
+	return preferences, nil
+}
+
+// AppendOutbox stores an event for relay; re-appending the same event ID is a no-op.
+func (r *Repository) AppendOutbox(ctx context.Context, envelope ports.EventEnvelope) error {
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+	row := outboxModel{
+		OutboxID:     strings.TrimSpace(envelope.EventID),
+		EventType:    strings.TrimSpace(envelope.EventType),
+		PartitionKey: strings.TrimSpace(envelope.PartitionKey),
+		Payload:      payload,
+		Status:       outboxStatusPending,
+		CreatedAt:    envelope.OccurredAt.UTC(),
+	}
+	if row.OutboxID == "" {
+		row.OutboxID = uuid.NewString()
+	}
+	if row.CreatedAt.IsZero() {
+		row.CreatedAt = time.Now().UTC()
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "outbox_id"}},
+			DoNothing: true,
+		}).
+		Create(&row).Error
+}
+
+func (r *Repository) Get(ctx context.Context, key string, now time.Time) (ports.IdempotencyRecord, bool, error) {
+	var row idempotencyModel
+	err := r.db.WithContext(ctx).Where("key = ?", key).First(&row).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ports.IdempotencyRecord{}, false, nil
+		}
+		return ports.IdempotencyRecord{}, false, err
+	}
+	if !row.ExpiresAt.After(now.UTC()) {
+		if err := r.db.WithContext(ctx).Where("key = ?", key).Delete(&idempotencyModel{}).Error; err != nil {
+			return ports.IdempotencyRecord{}, false, err
+		}
+		return ports.IdempotencyRecord{}, false, nil
+	}
+	return row.toPort(), true, nil
+}
+
+// Put inserts a new idempotency record and checks request-hash collisions.
+func (r *Repository) Put(ctx context.Context, record ports.IdempotencyRecord) error {
+	row := idempotencyModel{
+		Key:         record.Key,
+		RequestHash: record.RequestHash,
+		Payload:     append([]byte(nil), record.Payload...),
+		ExpiresAt:   record.ExpiresAt.UTC(),
+		CreatedAt:   time.Now().UTC(),
+	}
+	created := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "key"}},
+			DoNothing: true,
+		}).
+		Create(&row)
+	if created.Error != nil {
+		return created.Error
+	}
+	if created.RowsAffected > 0 {
+		return nil
+	}
+	var existing idempotencyModel
+	if err := r.db.WithContext(ctx).Where("key = ?", row.Key).First(&existing).Error; err != nil {
+		return err
+	}
+	if existing.RequestHash != row.RequestHash {
+		return domainerrors.ErrIdempotencyConflict
+	}
+	return nil
+}
+
+// enrollmentFlow resolves the flow a new user is pinned to: the assigned
+// variant's flow when it is a published flow of the role, otherwise the
+// role's active flow.
+func enrollmentFlow(tx *gorm.DB, role string, assignedFlowID string) (flowModel, ports.FlowVersion, error) {
+	flowID := assignedFlowID
+	if flowID == "" {
+		var active activeFlowModel
+		if err := tx.Where("role = ?", role).First(&active).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return flowModel{}, ports.FlowVersion{}, domainerrors.ErrDependencyUnavailable
+			}
+			return flowModel{}, ports.FlowVersion{}, err
+		}
+		flowID = active.FlowID
+	}
+	var flow flowModel
+	if err := tx.Where("flow_id = ?", flowID).First(&flow).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return flowModel{}, ports.FlowVersion{}, domainerrors.ErrDependencyUnavailable
+		}
+		return flowModel{}, ports.FlowVersion{}, err
+	}
+	if flow.Role != role || (assignedFlowID != "" && flow.Archived) {
+		return flowModel{}, ports.FlowVersion{}, domainerrors.ErrDependencyUnavailable
+	}
+	versions, err := flowVersions(tx, flow.FlowID)
+	if err != nil {
+		return flowModel{}, ports.FlowVersion{}, err
+	}
+	version, ok := publishedVersion(versions)
+	if !ok {
+		return flowModel{}, ports.FlowVersion{}, domainerrors.ErrDependencyUnavailable
+	}
+	return flow, version, nil
+}
+
+func loadFlowState(db *gorm.DB, userID string) (ports.FlowState, error) {
+	var progress progressModel
+	if err := db.Where("user_id = ?", userID).First(&progress).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ports.FlowState{}, domainerrors.ErrProgressNotFound
+		}
+		return ports.FlowState{}, err
+	}
+	pinned, err := pinnedSteps(db, progress)
+	if err != nil {
+		return ports.FlowState{}, err
+	}
+	stepStatus, err := progress.stepStatus()
+	if err != nil {
+		return ports.FlowState{}, err
+	}
+	steps := make([]ports.FlowStep, 0, len(pinned))
+	for _, step := range pinned {
+		status := stepStatus[step.StepKey]
+		if status == "" {
+			status = "pending"
+		}
+		steps = append(steps, ports.FlowStep{
+			StepKey:         step.StepKey,
+			Title:           step.Title,
+			Status:          status,
+			CompletionEvent: step.CompletionEvent,
+		})
+	}
+	return ports.FlowState{
+		UserID:         progress.UserID,
+		Role:           progress.Role,
+		FlowID:         progress.FlowID,
+		FlowVersion:    progress.FlowVersion,
+		ExperimentKey:  progress.ExperimentKey,
+		VariantKey:     progress.VariantKey,
+		Status:         progress.Status,
+		CompletedSteps: countCompleted(stepStatus),
+		TotalSteps:     len(pinned),
+		Steps:          steps,
+	}, nil
+}
+
+func lockProgress(tx *gorm.DB, userID string) (progressModel, error) {
+	var progress progressModel
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ?", strings.TrimSpace(userID)).
+		First(&progress).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return progressModel{}, domainerrors.ErrProgressNotFound
+		}
+		return progressModel{}, err
+	}
+	return progress, nil
+}
+
+func saveProgress(tx *gorm.DB, userID string, stepStatus map[string]string, updates map[string]any) error {
+	raw, err := json.Marshal(stepStatus)
+	if err != nil {
+		return err
+	}
+	updates["step_status"] = raw
+	return tx.Model(&progressModel{}).Where("user_id = ?", userID).Updates(updates).Error
+}
+
+// pinnedSteps resolves the steps of the version a user started on.
+func pinnedSteps(db *gorm.DB, progress progressModel) ([]ports.FlowStep, error) {
+	var row flowVersionModel
+	if err := db.Where("flow_id = ? AND version = ?", progress.FlowID, progress.FlowVersion).First(&row).Error; err != nil {
+		return nil, mapFlowLookupError(err)
+	}
+	return decodeSteps(row.Steps)
+}
+
+func lockFlow(tx *gorm.DB, flowID string) (flowModel, error) {
+	var flow flowModel
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("flow_id = ?", strings.TrimSpace(flowID)).
+		First(&flow).Error; err != nil {
+		return flowModel{}, mapFlowLookupError(err)
+	}
+	return flow, nil
+}
+
+func flowVersions(db *gorm.DB, flowID string) ([]ports.FlowVersion, error) {
+	var rows []flowVersionModel
+	if err := db.Where("flow_id = ?", flowID).Order("version ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	versions := make([]ports.FlowVersion, 0, len(rows))
+	for _, row := range rows {
+		version, err := row.toPort()
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, version)
+	}
+	return versions, nil
+}
+
+func activeFlowIDs(db *gorm.DB) (map[string]string, error) {
+	var rows []activeFlowModel
+	if err := db.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	active := make(map[string]string, len(rows))
+	for _, row := range rows {
+		active[row.Role] = row.FlowID
+	}
+	return active, nil
+}
+
+func toDefinition(db *gorm.DB, flow flowModel) (ports.FlowDefinition, error) {
+	versions, err := flowVersions(db, flow.FlowID)
+	if err != nil {
+		return ports.FlowDefinition{}, err
+	}
+	active, err := activeFlowIDs(db)
+	if err != nil {
+		return ports.FlowDefinition{}, err
+	}
+	out := ports.FlowDefinition{
+		FlowID:    flow.FlowID,
+		Role:      flow.Role,
+		Name:      flow.Name,
+		IsActive:  active[flow.Role] == flow.FlowID,
+		Archived:  flow.Archived,
+		Versions:  versions,
+		CreatedAt: flow.CreatedAt.UTC(),
+		UpdatedAt: flow.UpdatedAt.UTC(),
+	}
+	if published, ok := publishedVersion(versions); ok {
+		out.PublishedVersion = published.Version
+	}
+	return out, nil
+}
+
+func listExperiments(query *gorm.DB) ([]ports.Experiment, error) {
+	var rows []experimentModel
+	if err := query.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	items := make([]ports.Experiment, 0, len(rows))
+	for _, row := range rows {
+		experiment, err := row.toPort()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, experiment)
+	}
+	return items, nil
+}
+
+func publishedVersion(versions []ports.FlowVersion) (ports.FlowVersion, bool) {
+	for _, version := range versions {
+		if version.Status == ports.FlowVersionPublished {
+			return version, true
+		}
+	}
+	return ports.FlowVersion{}, false
+}
+
+func hasStep(steps []ports.FlowStep, stepKey string) bool {
+	for _, step := range steps {
+		if step.StepKey == stepKey {
+			return true
+		}
+	}
+	return false
+}
+
+func countCompleted(stepStatus map[string]string) int {
+	count := 0
+	for _, status := range stepStatus {
+		if status == "completed" {
+			count++
+		}
+	}
+	return count
+}
+
+func nextPendingStep(stepStatus map[string]string, steps []ports.FlowStep) string {
+	for _, step := range steps {
+		if stepStatus[step.StepKey] != "completed" {
+			return step.StepKey
+		}
+	}
+	return ""
+}
+
+// reminderSlot reports which reminder a flow is waiting on. Skipped flows
+// are due at their scheduled time; in-progress flows are anchored at their
+// last activity or last reminder, whichever is later.
+func reminderSlot(progress progressModel) (string, time.Time, bool) {
+	if progress.RemindersStopped {
+		return "", time.Time{}, false
+	}
+	switch progress.Status {
+	case "skipped":
+		if progress.ReminderScheduledAt == nil {
+			return "", time.Time{}, false
+		}
+		return ports.ReminderReasonSkipped, progress.ReminderScheduledAt.UTC(), true
+	case "in_progress":
+		anchor := progress.UpdatedAt.UTC()
+		if progress.LastReminderAt != nil && progress.LastReminderAt.After(anchor) {
+			anchor = progress.LastReminderAt.UTC()
+		}
+		return ports.ReminderReasonStalled, anchor, true
+	default:
+		return "", time.Time{}, false
+	}
+}
+
+func reminderCandidate(progress progressModel, steps []ports.FlowStep, reason string, dueAt time.Time) (ports.ReminderCandidate, error) {
+	stepStatus, err := progress.stepStatus()
+	if err != nil {
+		return ports.ReminderCandidate{}, err
+	}
+	item := ports.ReminderCandidate{
+		UserID:         progress.UserID,
+		Email:          progress.Email,
+		Role:           progress.Role,
+		FlowID:         progress.FlowID,
+		Status:         progress.Status,
+		Reason:         reason,
+		NextStep:       nextPendingStep(stepStatus, steps),
+		CompletedSteps: countCompleted(stepStatus),
+		TotalSteps:     len(steps),
+		RemindersSent:  progress.RemindersSent,
+		DueAt:          dueAt,
+	}
+	for _, step := range steps {
+		if step.StepKey == item.NextStep {
+			item.NextStepTitle = step.Title
+		}
+	}
+	return item, nil
+}
+
+func hashEvent(event ports.UserRegisteredEvent) string {
+	raw, _ := json.Marshal(event)
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
+
+func mapFlowLookupError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domainerrors.ErrFlowNotFound
+	}
+	return err
+}
+
+func mapExperimentLookupError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domainerrors.ErrExperimentNotFound
+	}
+	return err
+}
+
+func mapWriteError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		if pgErr.ConstraintName == "idx_onboarding_experiments_one_running" {
+			return domainerrors.ErrExperimentRunning
+		}
+		return domainerrors.ErrConflict
+	}
+	return err
+}
+
+var _ ports.Repository = (*Repository)(nil)
+var _ ports.IdempotencyStore = (*Repository)(nil)
+var _ ports.OutboxWriter = (*Repository)(nil)
@@ -0,0 +1,75 @@
+package application
+
+import (
+	"strings"
+	"text/template"
+	"time"
+
+	"solomon/contexts/identity-access/onboarding-service/ports"
+)
+
+type reminderTemplate struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+// reminderTemplates are keyed by reminder reason. The same copy is used for
+// both channels; in-app messages simply ignore the subject line.
+var reminderTemplates = map[string]reminderTemplate{
+	ports.ReminderReasonSkipped: {
+		subject: template.Must(template.New("skipped_subject").Parse(
+			"Pick up where you left off on ViralForge")),
+		body: template.Must(template.New("skipped_body").Parse(
+			"You paused your {{.Role}} onboarding with {{.Remaining}} of {{.Total}} steps to go.\n" +
+				"{{if .NextStepTitle}}Next up: {{.NextStepTitle}}.\n{{end}}" +
+				"Resume any time from your dashboard.\n")),
+	},
+	ports.ReminderReasonStalled: {
+		subject: template.Must(template.New("stalled_subject").Parse(
+			"You're {{.Completed}} of {{.Total}} steps into onboarding")),
+		body: template.Must(template.New("stalled_body").Parse(
+			"Your {{.Role}} setup is nearly there.\n" +
+				"{{if .NextStepTitle}}Next up: {{.NextStepTitle}}.\n{{end}}" +
+				"It only takes a few minutes to finish.\n")),
+	},
+}
+
+type reminderView struct {
+	Role          string
+	NextStepTitle string
+	Completed     int
+	Total         int
+	Remaining     int
+}
+
+func renderReminder(candidate ports.ReminderCandidate, channel string, now time.Time) (ports.Notification, error) {
+	tmpl, ok := reminderTemplates[candidate.Reason]
+	if !ok {
+		tmpl = reminderTemplates[ports.ReminderReasonStalled]
+	}
+	view := reminderView{
+		Role:          candidate.Role,
+		NextStepTitle: candidate.NextStepTitle,
+		Completed:     candidate.CompletedSteps,
+		Total:         candidate.TotalSteps,
+		Remaining:     candidate.TotalSteps - candidate.CompletedSteps,
+	}
+	var subject strings.Builder
+	if err := tmpl.subject.Execute(&subject, view); err != nil {
+		return ports.Notification{}, err
+	}
+	var body strings.Builder
+	if err := tmpl.body.Execute(&body, view); err != nil {
+		return ports.Notification{}, err
+	}
+	return ports.Notification{
+		NotificationID: "ntf_" + hashStrings(candidate.UserID, candidate.Reason, candidate.DueAt.UTC().Format(time.RFC3339Nano), channel)[:24],
+		UserID:         candidate.UserID,
+		Channel:        channel,
+		Recipient:      candidate.Email,
+		TemplateKey:    "onboarding." + candidate.Reason + "_nudge",
+		Subject:        subject.String(),
+		Body:           body.String(),
+		CreatedAt:      now,
+	}, nil
+}
@@ -0,0 +1,219 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	domainerrors "solomon/contexts/identity-access/onboarding-service/domain/errors"
+	"solomon/contexts/identity-access/onboarding-service/domain/services"
+	"solomon/contexts/identity-access/onboarding-service/ports"
+)
+
+// ReminderPolicy tunes onboarding nudges. Zero values fall back to a nudge
+// after three idle days, a weekly follow-up and at most three reminders.
+type ReminderPolicy struct {
+	StallAfter   time.Duration
+	Interval     time.Duration
+	MaxReminders int
+}
+
+func (p ReminderPolicy) stallAfter() time.Duration {
+	if p.StallAfter <= 0 {
+		return 72 * time.Hour
+	}
+	return p.StallAfter
+}
+
+func (p ReminderPolicy) interval() time.Duration {
+	if p.Interval <= 0 {
+		return 7 * 24 * time.Hour
+	}
+	return p.Interval
+}
+
+func (p ReminderPolicy) maxReminders() int {
+	if p.MaxReminders <= 0 {
+		return 3
+	}
+	return p.MaxReminders
+}
+
+func (s Service) GetReminderPreferences(ctx context.Context, userID string) (ports.ReminderPreferences, error) {
+	userID = strings.TrimSpace(userID)
+	if userID == "" {
+		return ports.ReminderPreferences{}, domainerrors.ErrInvalidRequest
+	}
+	return s.Repo.GetReminderPreferences(ctx, userID)
+}
+
+func (s Service) UpdateReminderPreferences(ctx context.Context, preferences ports.ReminderPreferences) (ports.ReminderPreferences, error) {
+	preferences.UserID = strings.TrimSpace(preferences.UserID)
+	preferences.QuietHoursStart = strings.TrimSpace(preferences.QuietHoursStart)
+	preferences.QuietHoursEnd = strings.TrimSpace(preferences.QuietHoursEnd)
+	preferences.Timezone = strings.TrimSpace(preferences.Timezone)
+	if preferences.UserID == "" {
+		return ports.ReminderPreferences{}, domainerrors.ErrInvalidRequest
+	}
+	if (preferences.QuietHoursStart == "") != (preferences.QuietHoursEnd == "") {
+		return ports.ReminderPreferences{}, domainerrors.ErrInvalidRequest
+	}
+	if preferences.QuietHoursStart != "" {
+		if _, err := services.ParseClock(preferences.QuietHoursStart); err != nil {
+			return ports.ReminderPreferences{}, domainerrors.ErrInvalidRequest
+		}
+		if _, err := services.ParseClock(preferences.QuietHoursEnd); err != nil {
+			return ports.ReminderPreferences{}, domainerrors.ErrInvalidRequest
+		}
+	}
+	if preferences.Timezone == "" {
+		preferences.Timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(preferences.Timezone); err != nil {
+		return ports.ReminderPreferences{}, domainerrors.ErrInvalidRequest
+	}
+	preferences.UpdatedAt = s.now()
+	return s.Repo.SaveReminderPreferences(ctx, preferences)
+}
+
+// UnsubscribeReminders opts a user out of all onboarding nudges while
+// keeping their channel and quiet-hour settings.
+func (s Service) UnsubscribeReminders(ctx context.Context, userID string) (ports.ReminderPreferences, error) {
+	preferences, err := s.GetReminderPreferences(ctx, userID)
+	if err != nil {
+		return ports.ReminderPreferences{}, err
+	}
+	if preferences.Unsubscribed {
+		return preferences, nil
+	}
+	preferences.Unsubscribed = true
+	preferences.UpdatedAt = s.now()
+	out, err := s.Repo.SaveReminderPreferences(ctx, preferences)
+	if err != nil {
+		return ports.ReminderPreferences{}, err
+	}
+	ResolveLogger(s.Logger).Info("onboarding reminders unsubscribed",
+		"event", "onboarding_reminders_unsubscribed",
+		"module", "identity-access/onboarding-service",
+		"layer", "application",
+		"user_id", out.UserID,
+	)
+	return out, nil
+}
+
+// ProcessDueReminders sends nudges for skipped and stalled flows. Each slot
+// is claimed before delivery so a reminder goes out at most once; users in
+// quiet hours are left due and picked up on a later pass.
+func (s Service) ProcessDueReminders(ctx context.Context, limit int) (ports.ReminderRunResult, error) {
+	var result ports.ReminderRunResult
+	if s.Notifier == nil {
+		return result, domainerrors.ErrDependencyUnavailable
+	}
+	if limit <= 0 {
+		limit = 100
+	}
+	now := s.now()
+	candidates, err := s.Repo.ListDueReminders(ctx, now, now.Add(-s.Reminders.stallAfter()), limit)
+	if err != nil {
+		return result, err
+	}
+	result.Due = len(candidates)
+
+	logger := ResolveLogger(s.Logger)
+	for _, candidate := range candidates {
+		preferences, err := s.Repo.GetReminderPreferences(ctx, candidate.UserID)
+		if err != nil {
+			result.Failed++
+			continue
+		}
+		channels := reminderChannels(candidate, preferences)
+		if preferences.Unsubscribed || len(channels) == 0 {
+			if err := s.Repo.ClaimReminder(ctx, candidate, now, nil); err == nil {
+				result.Suppressed++
+			}
+			continue
+		}
+		if inQuietHours(now, preferences) {
+			result.Deferred++
+			continue
+		}
+
+		var nextAt *time.Time
+		if candidate.RemindersSent+1 < s.Reminders.maxReminders() {
+			next := now.Add(s.Reminders.interval())
+			nextAt = &next
+		}
+		if err := s.Repo.ClaimReminder(ctx, candidate, now, nextAt); err != nil {
+			if !errors.Is(err, domainerrors.ErrConflict) {
+				result.Failed++
+			}
+			continue
+		}
+
+		sent := false
+		for _, channel := range channels {
+			notification, err := renderReminder(candidate, channel, now)
+			if err == nil {
+				err = s.Notifier.Notify(ctx, notification)
+			}
+			if err != nil {
+				logger.Warn("onboarding reminder delivery failed",
+					"event", "onboarding_reminder_delivery_failed",
+					"module", "identity-access/onboarding-service",
+					"layer", "application",
+					"user_id", candidate.UserID,
+					"channel", channel,
+					"reason", candidate.Reason,
+					"error", err.Error(),
+				)
+				continue
+			}
+			sent = true
+		}
+		if !sent {
+			result.Failed++
+			continue
+		}
+		result.Sent++
+		logger.Info("onboarding reminder sent",
+			"event", "onboarding_reminder_sent",
+			"module", "identity-access/onboarding-service",
+			"layer", "application",
+			"user_id", candidate.UserID,
+			"reason", candidate.Reason,
+			"reminder_number", candidate.RemindersSent+1,
+		)
+	}
+	return result, nil
+}
+
+func reminderChannels(candidate ports.ReminderCandidate, preferences ports.ReminderPreferences) []string {
+	channels := make([]string, 0, 2)
+	if preferences.EmailEnabled && strings.TrimSpace(candidate.Email) != "" {
+		channels = append(channels, ports.ChannelEmail)
+	}
+	if preferences.InAppEnabled {
+		channels = append(channels, ports.ChannelInApp)
+	}
+	return channels
+}
+
+func inQuietHours(now time.Time, preferences ports.ReminderPreferences) bool {
+	if preferences.QuietHoursStart == "" || preferences.QuietHoursEnd == "" {
+		return false
+	}
+	start, err := services.ParseClock(preferences.QuietHoursStart)
+	if err != nil {
+		return false
+	}
+	end, err := services.ParseClock(preferences.QuietHoursEnd)
+	if err != nil {
+		return false
+	}
+	location, err := time.LoadLocation(preferences.Timezone)
+	if err != nil {
+		location = time.UTC
+	}
+	return services.InQuietHours(now.In(location), start, end)
+}
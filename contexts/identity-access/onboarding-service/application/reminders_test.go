@@ -0,0 +1,95 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"solomon/contexts/identity-access/onboarding-service/adapters/memory"
+	"solomon/contexts/identity-access/onboarding-service/adapters/notification"
+	"solomon/contexts/identity-access/onboarding-service/ports"
+)
+
+type stepClock struct {
+	now time.Time
+}
+
+func (c *stepClock) Now() time.Time { return c.now }
+
+func newReminderService(t *testing.T) (Service, *stepClock, *notification.Recorder) {
+	t.Helper()
+	store := memory.NewStore()
+	clock := &stepClock{now: time.Date(2026, time.March, 2, 12, 0, 0, 0, time.UTC)}
+	recorder := notification.NewRecorder()
+	service := Service{Repo: store, Idempotency: store, Notifier: recorder, Clock: clock}
+	if _, err := service.ConsumeUserRegisteredEvent(context.Background(), ports.UserRegisteredEvent{
+		EventID: "evt_rem_1",
+		UserID:  "user_rem_1",
+		Role:    "brand",
+		Email:   "brand@example.com",
+	}); err != nil {
+		t.Fatalf("consume event failed: %v", err)
+	}
+	return service, clock, recorder
+}
+
+func TestSkippedFlowReminderSentOnceAndStopsOnResume(t *testing.T) {
+	service, clock, recorder := newReminderService(t)
+	if _, err := service.SkipFlow(context.Background(), "idem-rem-skip", "user_rem_1", "later"); err != nil {
+		t.Fatalf("skip failed: %v", err)
+	}
+
+	clock.now = clock.now.Add(7*24*time.Hour + time.Minute)
+	result, err := service.ProcessDueReminders(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("process reminders failed: %v", err)
+	}
+	if result.Sent != 1 || len(recorder.Sent()) != 2 {
+		t.Fatalf("expected one reminder on two channels, got %+v sent=%d", result, len(recorder.Sent()))
+	}
+	again, _ := service.ProcessDueReminders(context.Background(), 10)
+	if again.Due != 0 {
+		t.Fatalf("expected reminder slot consumed, got %+v", again)
+	}
+
+	if _, err := service.ResumeFlow(context.Background(), "idem-rem-resume", "user_rem_1"); err != nil {
+		t.Fatalf("resume failed: %v", err)
+	}
+	clock.now = clock.now.Add(7 * 24 * time.Hour)
+	afterResume, _ := service.ProcessDueReminders(context.Background(), 10)
+	if afterResume.Sent != 1 || recorder.Sent()[2].TemplateKey != "onboarding.stalled_nudge" {
+		t.Fatalf("expected only a stalled nudge after resume, got %+v", afterResume)
+	}
+}
+
+func TestRemindersRespectQuietHoursAndUnsubscribe(t *testing.T) {
+	service, clock, recorder := newReminderService(t)
+	clock.now = time.Date(2026, time.March, 6, 2, 30, 0, 0, time.UTC)
+
+	if _, err := service.UpdateReminderPreferences(context.Background(), ports.ReminderPreferences{
+		UserID:          "user_rem_1",
+		EmailEnabled:    true,
+		InAppEnabled:    true,
+		QuietHoursStart: "22:00",
+		QuietHoursEnd:   "07:00",
+		Timezone:        "Europe/Berlin",
+	}); err != nil {
+		t.Fatalf("update preferences failed: %v", err)
+	}
+	deferred, err := service.ProcessDueReminders(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("process reminders failed: %v", err)
+	}
+	if deferred.Deferred != 1 || len(recorder.Sent()) != 0 {
+		t.Fatalf("expected reminder deferred during quiet hours, got %+v", deferred)
+	}
+
+	if _, err := service.UnsubscribeReminders(context.Background(), "user_rem_1"); err != nil {
+		t.Fatalf("unsubscribe failed: %v", err)
+	}
+	clock.now = time.Date(2026, time.March, 6, 12, 0, 0, 0, time.UTC)
+	suppressed, _ := service.ProcessDueReminders(context.Background(), 10)
+	if suppressed.Suppressed != 1 || len(recorder.Sent()) != 0 {
+		t.Fatalf("expected unsubscribed reminder suppressed, got %+v", suppressed)
+	}
+}
@@ -5,20 +5,26 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"log/slog"
+	"strconv"
 	"strings"
 	"time"
 
 	domainerrors "solomon/contexts/identity-access/onboarding-service/domain/errors"
+	"solomon/contexts/identity-access/onboarding-service/domain/services"
 	"solomon/contexts/identity-access/onboarding-service/ports"
 )
 
 type Service struct {
 	Repo           ports.Repository
 	Idempotency    ports.IdempotencyStore
+	Outbox         ports.OutboxWriter
+	Notifier       ports.Notifier
 	Clock          ports.Clock
 	Logger         *slog.Logger
 	IdempotencyTTL time.Duration
+	Reminders      ReminderPolicy
 }
 
 func (s Service) ConsumeUserRegisteredEvent(
@@ -37,14 +43,27 @@ func (s Service) ConsumeUserRegisteredEvent(
 		event.OccurredAt = s.now()
 	}
 	event.Role = strings.ToLower(strings.TrimSpace(event.Role))
-	return s.Repo.ConsumeUserRegisteredEvent(ctx, event, s.now())
+	assignment, err := s.assignVariant(ctx, event.UserID, event.Role)
+	if err != nil {
+		return ports.FlowState{}, err
+	}
+	return s.Repo.ConsumeUserRegisteredEvent(ctx, event, assignment, s.now())
 }
 
+// GetFlow returns the user's flow. The first read of a flow enrolled in an
+// experiment counts as the exposure to its variant.
 func (s Service) GetFlow(ctx context.Context, userID string) (ports.FlowState, error) {
 	if strings.TrimSpace(userID) == "" {
 		return ports.FlowState{}, domainerrors.ErrInvalidRequest
 	}
-	return s.Repo.GetFlow(ctx, strings.TrimSpace(userID))
+	state, err := s.Repo.GetFlow(ctx, strings.TrimSpace(userID))
+	if err != nil {
+		return ports.FlowState{}, err
+	}
+	if state.ExperimentKey != "" {
+		s.recordExposure(ctx, state)
+	}
+	return state, nil
 }
 
 func (s Service) CompleteStep(
@@ -142,6 +161,408 @@ func (s Service) ListAdminFlows(ctx context.Context) ([]ports.AdminFlow, error)
 	return s.Repo.ListAdminFlows(ctx)
 }
 
+func (s Service) GetFlowDefinition(ctx context.Context, flowID string) (ports.FlowDefinition, error) {
+	if strings.TrimSpace(flowID) == "" {
+		return ports.FlowDefinition{}, domainerrors.ErrInvalidRequest
+	}
+	return s.Repo.GetFlowDefinition(ctx, strings.TrimSpace(flowID))
+}
+
+func (s Service) CreateFlow(
+	ctx context.Context,
+	idempotencyKey string,
+	actorID string,
+	input ports.CreateFlowInput,
+) (ports.FlowDefinition, error) {
+	var out ports.FlowDefinition
+	input.Role = strings.ToLower(strings.TrimSpace(input.Role))
+	input.Name = strings.TrimSpace(input.Name)
+	if strings.TrimSpace(actorID) == "" || input.Name == "" {
+		return out, domainerrors.ErrInvalidRequest
+	}
+	if !ports.IsValidRole(input.Role) {
+		return out, domainerrors.ErrUnknownRole
+	}
+	steps, err := normalizeSteps(input.Steps)
+	if err != nil {
+		return out, err
+	}
+	input.Steps = steps
+	if err := s.requireIdempotency(idempotencyKey); err != nil {
+		return out, err
+	}
+	requestHash := hashStrings("m22_create_flow", actorID, input.Role, input.Name, stepsFingerprint(steps))
+	err = s.runIdempotent(
+		ctx,
+		strings.TrimSpace(idempotencyKey),
+		requestHash,
+		func(raw []byte) error { return json.Unmarshal(raw, &out) },
+		func() ([]byte, error) {
+			item, err := s.Repo.CreateFlowDefinition(ctx, actorID, input, s.now())
+			if err != nil {
+				return nil, err
+			}
+			s.logFlowAuthoring("onboarding_flow_created", actorID, item.FlowID, 1)
+			return json.Marshal(item)
+		},
+	)
+	return out, err
+}
+
+func (s Service) SaveFlowDraft(
+	ctx context.Context,
+	idempotencyKey string,
+	actorID string,
+	flowID string,
+	steps []ports.FlowStep,
+) (ports.FlowVersion, error) {
+	var out ports.FlowVersion
+	flowID = strings.TrimSpace(flowID)
+	if strings.TrimSpace(actorID) == "" || flowID == "" {
+		return out, domainerrors.ErrInvalidRequest
+	}
+	steps, err := normalizeSteps(steps)
+	if err != nil {
+		return out, err
+	}
+	if err := s.requireIdempotency(idempotencyKey); err != nil {
+		return out, err
+	}
+	requestHash := hashStrings("m22_save_flow_draft", actorID, flowID, stepsFingerprint(steps))
+	err = s.runIdempotent(
+		ctx,
+		strings.TrimSpace(idempotencyKey),
+		requestHash,
+		func(raw []byte) error { return json.Unmarshal(raw, &out) },
+		func() ([]byte, error) {
+			item, err := s.Repo.SaveFlowDraft(ctx, actorID, flowID, steps, s.now())
+			if err != nil {
+				return nil, err
+			}
+			s.logFlowAuthoring("onboarding_flow_draft_saved", actorID, flowID, item.Version)
+			return json.Marshal(item)
+		},
+	)
+	return out, err
+}
+
+func (s Service) PublishFlowVersion(
+	ctx context.Context,
+	idempotencyKey string,
+	actorID string,
+	flowID string,
+	version int,
+) (ports.FlowDefinition, error) {
+	var out ports.FlowDefinition
+	flowID = strings.TrimSpace(flowID)
+	if strings.TrimSpace(actorID) == "" || flowID == "" || version <= 0 {
+		return out, domainerrors.ErrInvalidRequest
+	}
+	if err := s.requireIdempotency(idempotencyKey); err != nil {
+		return out, err
+	}
+	requestHash := hashStrings("m22_publish_flow", actorID, flowID, strconv.Itoa(version))
+	err := s.runIdempotent(
+		ctx,
+		strings.TrimSpace(idempotencyKey),
+		requestHash,
+		func(raw []byte) error { return json.Unmarshal(raw, &out) },
+		func() ([]byte, error) {
+			item, err := s.Repo.PublishFlowVersion(ctx, actorID, flowID, version, s.now())
+			if err != nil {
+				return nil, err
+			}
+			s.logFlowAuthoring("onboarding_flow_published", actorID, flowID, version)
+			return json.Marshal(item)
+		},
+	)
+	return out, err
+}
+
+func (s Service) ArchiveFlow(
+	ctx context.Context,
+	idempotencyKey string,
+	actorID string,
+	flowID string,
+) (ports.FlowDefinition, error) {
+	var out ports.FlowDefinition
+	flowID = strings.TrimSpace(flowID)
+	if strings.TrimSpace(actorID) == "" || flowID == "" {
+		return out, domainerrors.ErrInvalidRequest
+	}
+	if err := s.requireIdempotency(idempotencyKey); err != nil {
+		return out, err
+	}
+	requestHash := hashStrings("m22_archive_flow", actorID, flowID)
+	err := s.runIdempotent(
+		ctx,
+		strings.TrimSpace(idempotencyKey),
+		requestHash,
+		func(raw []byte) error { return json.Unmarshal(raw, &out) },
+		func() ([]byte, error) {
+			item, err := s.Repo.ArchiveFlowDefinition(ctx, actorID, flowID, s.now())
+			if err != nil {
+				return nil, err
+			}
+			s.logFlowAuthoring("onboarding_flow_archived", actorID, flowID, item.PublishedVersion)
+			return json.Marshal(item)
+		},
+	)
+	return out, err
+}
+
+// ApplyActivityEvent satisfies event-driven step completion criteria. Events
+// for users without onboarding progress are accepted and ignored.
+func (s Service) ApplyActivityEvent(ctx context.Context, event ports.ActivityEvent) (ports.ActivityResult, error) {
+	event.EventID = strings.TrimSpace(event.EventID)
+	event.UserID = strings.TrimSpace(event.UserID)
+	event.EventType = strings.ToLower(strings.TrimSpace(event.EventType))
+	if event.EventID == "" || event.UserID == "" || event.EventType == "" {
+		return ports.ActivityResult{}, domainerrors.ErrSchemaInvalid
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = s.now()
+	}
+	result, err := s.Repo.ApplyActivityEvent(ctx, event, s.now())
+	if errors.Is(err, domainerrors.ErrProgressNotFound) {
+		return ports.ActivityResult{UserID: event.UserID, CompletedStepKeys: []string{}}, nil
+	}
+	if err != nil {
+		return ports.ActivityResult{}, err
+	}
+	if len(result.CompletedStepKeys) > 0 {
+		ResolveLogger(s.Logger).Info("onboarding steps completed by activity event",
+			"event", "onboarding_steps_completed_by_event",
+			"module", "identity-access/onboarding-service",
+			"layer", "application",
+			"user_id", event.UserID,
+			"event_type", event.EventType,
+			"step_keys", strings.Join(result.CompletedStepKeys, ","),
+		)
+	}
+	return result, nil
+}
+
+func (s Service) ListExperiments(ctx context.Context) ([]ports.Experiment, error) {
+	return s.Repo.ListExperiments(ctx)
+}
+
+func (s Service) CreateExperiment(
+	ctx context.Context,
+	idempotencyKey string,
+	actorID string,
+	input ports.CreateExperimentInput,
+) (ports.Experiment, error) {
+	var out ports.Experiment
+	input, err := normalizeExperiment(input)
+	if err != nil {
+		return out, err
+	}
+	if strings.TrimSpace(actorID) == "" {
+		return out, domainerrors.ErrInvalidRequest
+	}
+	if err := s.requireIdempotency(idempotencyKey); err != nil {
+		return out, err
+	}
+	parts := make([]string, 0, len(input.Variants))
+	for _, variant := range input.Variants {
+		parts = append(parts, variant.VariantKey+":"+strconv.Itoa(variant.Weight)+":"+variant.FlowID)
+	}
+	requestHash := hashStrings("m22_create_experiment", actorID, input.ExperimentKey, input.Role, strings.Join(parts, ";"))
+	err = s.runIdempotent(
+		ctx,
+		strings.TrimSpace(idempotencyKey),
+		requestHash,
+		func(raw []byte) error { return json.Unmarshal(raw, &out) },
+		func() ([]byte, error) {
+			item, err := s.Repo.CreateExperiment(ctx, actorID, input, s.now())
+			if err != nil {
+				return nil, err
+			}
+			ResolveLogger(s.Logger).Info("onboarding experiment started",
+				"event", "onboarding_experiment_started",
+				"module", "identity-access/onboarding-service",
+				"layer", "application",
+				"actor_id", actorID,
+				"experiment_key", item.ExperimentKey,
+				"role", item.Role,
+			)
+			return json.Marshal(item)
+		},
+	)
+	return out, err
+}
+
+func (s Service) StopExperiment(
+	ctx context.Context,
+	idempotencyKey string,
+	actorID string,
+	experimentKey string,
+) (ports.Experiment, error) {
+	var out ports.Experiment
+	experimentKey = strings.TrimSpace(experimentKey)
+	if strings.TrimSpace(actorID) == "" || experimentKey == "" {
+		return out, domainerrors.ErrInvalidRequest
+	}
+	if err := s.requireIdempotency(idempotencyKey); err != nil {
+		return out, err
+	}
+	requestHash := hashStrings("m22_stop_experiment", actorID, experimentKey)
+	err := s.runIdempotent(
+		ctx,
+		strings.TrimSpace(idempotencyKey),
+		requestHash,
+		func(raw []byte) error { return json.Unmarshal(raw, &out) },
+		func() ([]byte, error) {
+			item, err := s.Repo.StopExperiment(ctx, actorID, experimentKey, s.now())
+			if err != nil {
+				return nil, err
+			}
+			ResolveLogger(s.Logger).Info("onboarding experiment stopped",
+				"event", "onboarding_experiment_stopped",
+				"module", "identity-access/onboarding-service",
+				"layer", "application",
+				"actor_id", actorID,
+				"experiment_key", item.ExperimentKey,
+			)
+			return json.Marshal(item)
+		},
+	)
+	return out, err
+}
+
+// GetExperimentReport reports completion rate and time-to-complete for each
+// variant of an experiment.
+func (s Service) GetExperimentReport(ctx context.Context, experimentKey string) (ports.ExperimentReport, error) {
+	experimentKey = strings.TrimSpace(experimentKey)
+	if experimentKey == "" {
+		return ports.ExperimentReport{}, domainerrors.ErrInvalidRequest
+	}
+	experiment, err := s.Repo.GetExperiment(ctx, experimentKey)
+	if err != nil {
+		return ports.ExperimentReport{}, err
+	}
+	outcomes, err := s.Repo.ListExperimentOutcomes(ctx, experimentKey)
+	if err != nil {
+		return ports.ExperimentReport{}, err
+	}
+
+	keys := make([]string, 0, len(experiment.Variants))
+	for _, variant := range experiment.Variants {
+		keys = append(keys, variant.VariantKey)
+	}
+	samples := make([]services.VariantOutcome, 0, len(outcomes))
+	for _, outcome := range outcomes {
+		samples = append(samples, services.VariantOutcome{
+			VariantKey:  outcome.VariantKey,
+			AssignedAt:  outcome.AssignedAt,
+			ExposedAt:   outcome.ExposedAt,
+			CompletedAt: outcome.CompletedAt,
+		})
+	}
+	summaries := services.SummarizeVariants(keys, samples)
+
+	report := ports.ExperimentReport{
+		Experiment: experiment,
+		Variants:   make([]ports.VariantMetrics, 0, len(summaries)),
+	}
+	for i, summary := range summaries {
+		report.Variants = append(report.Variants, ports.VariantMetrics{
+			VariantKey:                  summary.VariantKey,
+			Weight:                      experiment.Variants[i].Weight,
+			Assigned:                    summary.Assigned,
+			Exposed:                     summary.Exposed,
+			Completed:                   summary.Completed,
+			CompletionRate:              summary.CompletionRate,
+			AvgTimeToCompleteSeconds:    int64(summary.AvgTimeToComplete / time.Second),
+			MedianTimeToCompleteSeconds: int64(summary.MedianTimeToComplete / time.Second),
+		})
+	}
+	return report, nil
+}
+
+func (s Service) assignVariant(ctx context.Context, userID string, role string) (ports.VariantAssignment, error) {
+	experiment, err := s.Repo.GetRunningExperiment(ctx, role)
+	if errors.Is(err, domainerrors.ErrExperimentNotFound) {
+		return ports.VariantAssignment{}, nil
+	}
+	if err != nil {
+		return ports.VariantAssignment{}, err
+	}
+	weighted := make([]services.WeightedVariant, 0, len(experiment.Variants))
+	for _, variant := range experiment.Variants {
+		weighted = append(weighted, services.WeightedVariant{Key: variant.VariantKey, Weight: variant.Weight})
+	}
+	variantKey := services.AssignVariant(experiment.ExperimentKey, userID, weighted)
+	for _, variant := range experiment.Variants {
+		if variant.VariantKey == variantKey {
+			return ports.VariantAssignment{
+				UserID:        userID,
+				ExperimentKey: experiment.ExperimentKey,
+				VariantKey:    variant.VariantKey,
+				FlowID:        variant.FlowID,
+			}, nil
+		}
+	}
+	return ports.VariantAssignment{}, nil
+}
+
+// recordExposure marks the first exposure and emits the exposure event.
+// Failures are logged rather than returned so reading a flow never breaks
+// on experiment bookkeeping.
+func (s Service) recordExposure(ctx context.Context, state ports.FlowState) {
+	logger := ResolveLogger(s.Logger)
+	assignment, first, err := s.Repo.MarkVariantExposed(ctx, state.UserID, s.now())
+	if err != nil || !first {
+		if err != nil {
+			logger.Warn("onboarding variant exposure not recorded",
+				"event", "onboarding_variant_exposure_failed",
+				"module", "identity-access/onboarding-service",
+				"layer", "application",
+				"user_id", state.UserID,
+				"error", err.Error(),
+			)
+		}
+		return
+	}
+	if s.Outbox == nil {
+		return
+	}
+	payload, err := json.Marshal(map[string]any{
+		"user_id":        assignment.UserID,
+		"role":           state.Role,
+		"experiment_key": assignment.ExperimentKey,
+		"variant_key":    assignment.VariantKey,
+		"flow_id":        state.FlowID,
+		"flow_version":   state.FlowVersion,
+		"exposed_at":     assignment.ExposedAt.UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return
+	}
+	eventID := "evt_onb_exposure_" + hashStrings(assignment.ExperimentKey, assignment.UserID)[:24]
+	if err := s.Outbox.AppendOutbox(ctx, ports.EventEnvelope{
+		EventID:          eventID,
+		EventType:        "onboarding.variant_exposed",
+		OccurredAt:       assignment.ExposedAt.UTC(),
+		SourceService:    "onboarding-service",
+		TraceID:          eventID,
+		SchemaVersion:    1,
+		PartitionKeyPath: "user_id",
+		PartitionKey:     assignment.UserID,
+		Data:             payload,
+	}); err != nil {
+		logger.Warn("onboarding exposure event append failed",
+			"event", "onboarding_exposure_outbox_append_failed",
+			"module", "identity-access/onboarding-service",
+			"layer", "application",
+			"user_id", assignment.UserID,
+			"experiment_key", assignment.ExperimentKey,
+			"error", err.Error(),
+		)
+	}
+}
+
 func (s Service) now() time.Time {
 	if s.Clock == nil {
 		return time.Now().UTC()
@@ -195,7 +616,7 @@ func (s Service) runIdempotent(
 		return err
 	}
 
-	resolveLogger(s.Logger).Debug("onboarding idempotent operation committed",
+	ResolveLogger(s.Logger).Debug("onboarding idempotent operation committed",
 		"event", "onboarding_idempotent_operation_committed",
 		"module", "identity-access/onboarding-service",
 		"layer", "application",
@@ -204,6 +625,96 @@ func (s Service) runIdempotent(
 	return decode(payload)
 }
 
+func (s Service) logFlowAuthoring(eventName string, actorID string, flowID string, version int) {
+	ResolveLogger(s.Logger).Info("onboarding flow definition changed",
+		"event", eventName,
+		"module", "identity-access/onboarding-service",
+		"layer", "application",
+		"actor_id", actorID,
+		"flow_id", flowID,
+		"version", version,
+	)
+}
+
+// normalizeSteps validates an authored step list. Step order is the list
+// order, so reordering is a matter of saving a new draft.
+func normalizeSteps(steps []ports.FlowStep) ([]ports.FlowStep, error) {
+	if len(steps) == 0 {
+		return nil, domainerrors.ErrInvalidRequest
+	}
+	seen := make(map[string]struct{}, len(steps))
+	out := make([]ports.FlowStep, 0, len(steps))
+	for _, step := range steps {
+		item := ports.FlowStep{
+			StepKey:         strings.ToLower(strings.TrimSpace(step.StepKey)),
+			Title:           strings.TrimSpace(step.Title),
+			CompletionEvent: strings.ToLower(strings.TrimSpace(step.CompletionEvent)),
+		}
+		if item.StepKey == "" || item.Title == "" || strings.ContainsAny(item.StepKey, " /|") {
+			return nil, domainerrors.ErrInvalidRequest
+		}
+		if item.CompletionEvent != "" && !strings.Contains(item.CompletionEvent, ".") {
+			return nil, domainerrors.ErrInvalidRequest
+		}
+		if _, exists := seen[item.StepKey]; exists {
+			return nil, domainerrors.ErrInvalidRequest
+		}
+		seen[item.StepKey] = struct{}{}
+		out = append(out, item)
+	}
+	return out, nil
+}
+
+func normalizeExperiment(input ports.CreateExperimentInput) (ports.CreateExperimentInput, error) {
+	input.ExperimentKey = strings.ToLower(strings.TrimSpace(input.ExperimentKey))
+	input.Role = strings.ToLower(strings.TrimSpace(input.Role))
+	if !isSlug(input.ExperimentKey) {
+		return input, domainerrors.ErrInvalidRequest
+	}
+	if !ports.IsValidRole(input.Role) {
+		return input, domainerrors.ErrUnknownRole
+	}
+	if len(input.Variants) < 2 || len(input.Variants) > 10 {
+		return input, domainerrors.ErrInvalidRequest
+	}
+	seen := make(map[string]struct{}, len(input.Variants))
+	variants := make([]ports.ExperimentVariant, 0, len(input.Variants))
+	for _, variant := range input.Variants {
+		variant.VariantKey = strings.ToLower(strings.TrimSpace(variant.VariantKey))
+		variant.FlowID = strings.TrimSpace(variant.FlowID)
+		if !isSlug(variant.VariantKey) || variant.Weight <= 0 || variant.Weight > 10000 {
+			return input, domainerrors.ErrInvalidRequest
+		}
+		if _, exists := seen[variant.VariantKey]; exists {
+			return input, domainerrors.ErrInvalidRequest
+		}
+		seen[variant.VariantKey] = struct{}{}
+		variants = append(variants, variant)
+	}
+	input.Variants = variants
+	return input, nil
+}
+
+func isSlug(value string) bool {
+	if value == "" || len(value) > 64 {
+		return false
+	}
+	for _, r := range value {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '_' && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+func stepsFingerprint(steps []ports.FlowStep) string {
+	parts := make([]string, 0, len(steps))
+	for _, step := range steps {
+		parts = append(parts, step.StepKey+":"+step.Title+":"+step.CompletionEvent)
+	}
+	return strings.Join(parts, ";")
+}
+
 func hashStrings(values ...string) string {
 	sum := sha256.Sum256([]byte(strings.Join(values, "|")))
 	return hex.EncodeToString(sum[:])
@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"strconv"
 	"testing"
 	"time"
 
@@ -95,3 +96,144 @@ func TestCompleteStepIdempotencyConflict(t *testing.T) {
 		t.Fatalf("expected idempotency conflict, got %v", err)
 	}
 }
+
+func TestActivityEventCompletesMatchingStep(t *testing.T) {
+	store := memory.NewStore()
+	service := Service{
+		Repo:           store,
+		Idempotency:    store,
+		Clock:          store,
+		IdempotencyTTL: 7 * 24 * time.Hour,
+	}
+	if _, err := service.ConsumeUserRegisteredEvent(context.Background(), ports.UserRegisteredEvent{
+		EventID: "evt_onb_act_1",
+		UserID:  "user_act_1",
+		Role:    "editor",
+	}); err != nil {
+		t.Fatalf("consume event failed: %v", err)
+	}
+
+	event := ports.ActivityEvent{EventID: "evt_sub_1", UserID: "user_act_1", EventType: "submission.created"}
+	result, err := service.ApplyActivityEvent(context.Background(), event)
+	if err != nil {
+		t.Fatalf("apply activity failed: %v", err)
+	}
+	if len(result.CompletedStepKeys) != 1 || result.CompletedStepKeys[0] != "submit_first_clip" {
+		t.Fatalf("expected submit_first_clip completed, got %+v", result)
+	}
+	replay, err := service.ApplyActivityEvent(context.Background(), event)
+	if err != nil {
+		t.Fatalf("replay activity failed: %v", err)
+	}
+	if len(replay.CompletedStepKeys) != 0 || replay.CompletedSteps != 1 {
+		t.Fatalf("expected replay to be a no-op, got %+v", replay)
+	}
+
+	unknown, err := service.ApplyActivityEvent(context.Background(), ports.ActivityEvent{
+		EventID:   "evt_sub_2",
+		UserID:    "user_without_progress",
+		EventType: "submission.created",
+	})
+	if err != nil || len(unknown.CompletedStepKeys) != 0 {
+		t.Fatalf("expected events without progress to be ignored, got %+v err=%v", unknown, err)
+	}
+}
+
+func TestCreateFlowRejectsDuplicateStepKeys(t *testing.T) {
+	store := memory.NewStore()
+	service := Service{Repo: store, Idempotency: store, Clock: store}
+
+	_, err := service.CreateFlow(context.Background(), "idem-flow-1", "admin_1", ports.CreateFlowInput{
+		Role: "brand",
+		Name: "Brand fast track",
+		Steps: []ports.FlowStep{
+			{StepKey: "welcome", Title: "Welcome"},
+			{StepKey: "welcome", Title: "Welcome again"},
+		},
+	})
+	if err != domainerrors.ErrInvalidRequest {
+		t.Fatalf("expected invalid request, got %v", err)
+	}
+}
+
+func TestExperimentAssignmentExposureAndReport(t *testing.T) {
+	store := memory.NewStore()
+	service := Service{Repo: store, Idempotency: store, Outbox: store, Clock: store}
+
+	experiment, err := service.CreateExperiment(context.Background(), "idem-exp-1", "admin_1", ports.CreateExperimentInput{
+		ExperimentKey: "brand_welcome_copy",
+		Role:          "brand",
+		Variants: []ports.ExperimentVariant{
+			{VariantKey: "control", Weight: 80},
+			{VariantKey: "short_copy", Weight: 20},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create experiment failed: %v", err)
+	}
+
+	registeredAt := time.Date(2026, time.March, 1, 9, 0, 0, 0, time.UTC)
+	counts := map[string]int{}
+	for i := 0; i < 200; i++ {
+		userID := "user_exp_" + strconv.Itoa(i)
+		state, err := service.ConsumeUserRegisteredEvent(context.Background(), ports.UserRegisteredEvent{
+			EventID:    "evt_exp_" + strconv.Itoa(i),
+			UserID:     userID,
+			Role:       "brand",
+			OccurredAt: registeredAt,
+		})
+		if err != nil {
+			t.Fatalf("consume event failed: %v", err)
+		}
+		if state.ExperimentKey != experiment.ExperimentKey {
+			t.Fatalf("expected experiment key on flow state, got %q", state.ExperimentKey)
+		}
+		again, err := service.ConsumeUserRegisteredEvent(context.Background(), ports.UserRegisteredEvent{
+			EventID:    "evt_exp_" + strconv.Itoa(i),
+			UserID:     userID,
+			Role:       "brand",
+			OccurredAt: registeredAt,
+		})
+		if err != nil || again.VariantKey != state.VariantKey {
+			t.Fatalf("expected stable assignment, got %q then %q err=%v", state.VariantKey, again.VariantKey, err)
+		}
+		counts[state.VariantKey]++
+	}
+	if counts["control"] < 130 || counts["short_copy"] < 20 {
+		t.Fatalf("expected roughly 80/20 split, got %+v", counts)
+	}
+
+	if _, err := service.GetFlow(context.Background(), "user_exp_0"); err != nil {
+		t.Fatalf("get flow failed: %v", err)
+	}
+	if _, err := service.GetFlow(context.Background(), "user_exp_0"); err != nil {
+		t.Fatalf("second get flow failed: %v", err)
+	}
+	exposures := store.Outbox()
+	if len(exposures) != 1 || exposures[0].EventType != "onboarding.variant_exposed" {
+		t.Fatalf("expected one exposure event, got %+v", exposures)
+	}
+
+	for _, step := range []string{"welcome", "connect_storefront", "create_first_product"} {
+		if _, err := service.CompleteStep(context.Background(), "idem-exp-step-"+step, "user_exp_0", step, nil); err != nil {
+			t.Fatalf("complete step %s failed: %v", step, err)
+		}
+	}
+	report, err := service.GetExperimentReport(context.Background(), experiment.ExperimentKey)
+	if err != nil {
+		t.Fatalf("report failed: %v", err)
+	}
+	if len(report.Variants) != 2 {
+		t.Fatalf("expected two variants in report, got %+v", report.Variants)
+	}
+	completed := 0
+	for _, variant := range report.Variants {
+		completed += variant.Completed
+		if variant.Assigned != counts[variant.VariantKey] {
+			t.Fatalf("expected %d assigned for %s, got %d", counts[variant.VariantKey], variant.VariantKey, variant.Assigned)
+		}
+	}
+	if completed != 1 {
+		t.Fatalf("expected one completion across variants, got %d", completed)
+	}
+}
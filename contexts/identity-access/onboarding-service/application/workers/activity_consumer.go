@@ -0,0 +1,125 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"solomon/contexts/identity-access/onboarding-service/application"
+	domainerrors "solomon/contexts/identity-access/onboarding-service/domain/errors"
+	"solomon/contexts/identity-access/onboarding-service/ports"
+)
+
+const defaultActivityConsumerGroup = "onboarding-service-activity-cg"
+
+// ActivityTopics are the submission-service and campaign-service events that
+// can complete onboarding steps. The event type is the completion event a
+// flow step names.
+var ActivityTopics = []string{
+	"submission.created",
+	"campaign.created",
+	"campaign.launched",
+}
+
+type activityPayload struct {
+	UserID    string `json:"user_id"`
+	CreatorID string `json:"creator_id"`
+	BrandID   string `json:"brand_id"`
+}
+
+// userID is the user the event is about: the creator of a submission or the
+// brand owning a campaign.
+func (p activityPayload) userID() string {
+	for _, candidate := range []string{p.UserID, p.CreatorID, p.BrandID} {
+		if value := strings.TrimSpace(candidate); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// ActivityConsumer completes event-driven onboarding steps from the outbox
+// events other modules publish. Activity events are deduplicated by event
+// ID, so redelivered events are acknowledged without effect.
+type ActivityConsumer struct {
+	Subscriber    ports.EventSubscriber
+	Service       application.Service
+	ConsumerGroup string
+	Logger        *slog.Logger
+}
+
+func (c ActivityConsumer) Start(ctx context.Context) error {
+	logger := application.ResolveLogger(c.Logger)
+	group := c.ConsumerGroup
+	if group == "" {
+		group = defaultActivityConsumerGroup
+	}
+	for _, topic := range ActivityTopics {
+		if err := c.Subscriber.Subscribe(ctx, topic, group, c.Handle); err != nil {
+			logger.Error("onboarding activity consumer subscribe failed",
+				"event", "onboarding_activity_consumer_subscribe_failed",
+				"module", "identity-access/onboarding-service",
+				"layer", "worker",
+				"topic", topic,
+				"consumer_group", group,
+				"error", err.Error(),
+			)
+			return err
+		}
+	}
+	logger.Info("onboarding activity consumer subscribed",
+		"event", "onboarding_activity_consumer_subscribed",
+		"module", "identity-access/onboarding-service",
+		"layer", "worker",
+		"consumer_group", group,
+	)
+	return nil
+}
+
+// Handle applies one activity envelope to the user's onboarding progress.
+func (c ActivityConsumer) Handle(ctx context.Context, event ports.EventEnvelope) error {
+	logger := application.ResolveLogger(c.Logger)
+	var payload activityPayload
+	if err := json.Unmarshal(event.Data, &payload); err != nil {
+		logger.Error("onboarding activity event decode failed",
+			"event", "onboarding_activity_event_decode_failed",
+			"module", "identity-access/onboarding-service",
+			"layer", "worker",
+			"event_id", event.EventID,
+			"error", err.Error(),
+		)
+		return err
+	}
+
+	_, err := c.Service.ApplyActivityEvent(ctx, ports.ActivityEvent{
+		EventID:    event.EventID,
+		UserID:     payload.userID(),
+		EventType:  event.EventType,
+		OccurredAt: event.OccurredAt,
+	})
+	if errors.Is(err, domainerrors.ErrSchemaInvalid) {
+		// Malformed events cannot succeed on redelivery; log and drop.
+		logger.Warn("onboarding activity event rejected",
+			"event", "onboarding_activity_event_rejected",
+			"module", "identity-access/onboarding-service",
+			"layer", "worker",
+			"event_id", event.EventID,
+			"event_type", event.EventType,
+		)
+		return nil
+	}
+	if err != nil {
+		logger.Error("onboarding activity event failed",
+			"event", "onboarding_activity_event_failed",
+			"module", "identity-access/onboarding-service",
+			"layer", "worker",
+			"event_id", event.EventID,
+			"event_type", event.EventType,
+			"error", err.Error(),
+		)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+
+	"solomon/contexts/identity-access/onboarding-service/application"
+)
+
+// ReminderScheduler sends due onboarding reminders for M22.
+type ReminderScheduler struct {
+	Service   application.Service
+	BatchSize int
+	Disabled  bool
+	Logger    *slog.Logger
+}
+
+func (j ReminderScheduler) RunOnce(ctx context.Context) error {
+	if j.Disabled {
+		return nil
+	}
+	logger := application.ResolveLogger(j.Logger)
+	limit := j.BatchSize
+	if limit <= 0 {
+		limit = 100
+	}
+	result, err := j.Service.ProcessDueReminders(ctx, limit)
+	if err != nil {
+		logger.Error("onboarding reminder cycle failed",
+			"event", "onboarding_reminder_cycle_failed",
+			"module", "identity-access/onboarding-service",
+			"layer", "worker",
+			"error", err.Error(),
+		)
+		return err
+	}
+	logger.Debug("onboarding reminder cycle succeeded",
+		"event", "onboarding_reminder_cycle_succeeded",
+		"module", "identity-access/onboarding-service",
+		"layer", "worker",
+		"due", result.Due,
+		"sent", result.Sent,
+		"deferred", result.Deferred,
+		"suppressed", result.Suppressed,
+		"failed", result.Failed,
+	)
+	return nil
+}
@@ -6,6 +6,11 @@ var (
 	ErrInvalidRequest         = errors.New("invalid request")
 	ErrNotFound               = errors.New("resource not found")
 	ErrFlowNotFound           = errors.New("flow not found")
+	ErrFlowVersionNotFound    = errors.New("flow version not found")
+	ErrFlowArchived           = errors.New("flow archived")
+	ErrFlowActive             = errors.New("active flow cannot be archived")
+	ErrExperimentNotFound     = errors.New("experiment not found")
+	ErrExperimentRunning      = errors.New("experiment already running for role")
 	ErrProgressNotFound       = errors.New("progress not found")
 	ErrStepNotFound           = errors.New("step not found")
 	ErrStepAlreadyCompleted   = errors.New("step already completed")
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var errInvalidClock = errors.New("invalid clock time")
+
+// ParseClock parses an "HH:MM" wall-clock time into minutes after midnight.
+func ParseClock(value string) (int, error) {
+	parts := strings.Split(strings.TrimSpace(value), ":")
+	if len(parts) != 2 || len(parts[0]) != 2 || len(parts[1]) != 2 {
+		return 0, errInvalidClock
+	}
+	hours, err := strconv.Atoi(parts[0])
+	if err != nil || hours < 0 || hours > 23 {
+		return 0, errInvalidClock
+	}
+	minutes, err := strconv.Atoi(parts[1])
+	if err != nil || minutes < 0 || minutes > 59 {
+		return 0, errInvalidClock
+	}
+	return hours*60 + minutes, nil
+}
+
+// InQuietHours reports whether local falls within [start, end). Windows whose
+// end is earlier than their start wrap past midnight; equal bounds are empty.
+func InQuietHours(local time.Time, startMinute int, endMinute int) bool {
+	if startMinute == endMinute {
+		return false
+	}
+	minute := local.Hour()*60 + local.Minute()
+	if startMinute < endMinute {
+		return minute >= startMinute && minute < endMinute
+	}
+	return minute >= startMinute || minute < endMinute
+}
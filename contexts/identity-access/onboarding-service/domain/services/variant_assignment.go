@@ -0,0 +1,115 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"sort"
+	"time"
+)
+
+// WeightedVariant is one arm of an experiment. Weights are relative, so
+// {50, 50} and {1, 1} split traffic the same way.
+type WeightedVariant struct {
+	Key    string
+	Weight int
+}
+
+// AssignVariant buckets a user into a variant by hashing the experiment key
+// with the user ID. The result depends only on its inputs, so repeated calls
+// and other instances agree without coordination. It returns "" when no
+// variant carries weight.
+func AssignVariant(experimentKey string, userID string, variants []WeightedVariant) string {
+	total := 0
+	for _, variant := range variants {
+		if variant.Weight > 0 {
+			total += variant.Weight
+		}
+	}
+	if total == 0 {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(experimentKey + ":" + userID))
+	bucket := int(binary.BigEndian.Uint64(sum[:8]) % uint64(total))
+	for _, variant := range variants {
+		if variant.Weight <= 0 {
+			continue
+		}
+		if bucket < variant.Weight {
+			return variant.Key
+		}
+		bucket -= variant.Weight
+	}
+	return ""
+}
+
+// VariantOutcome is one assigned user's onboarding result.
+type VariantOutcome struct {
+	VariantKey  string
+	AssignedAt  time.Time
+	ExposedAt   *time.Time
+	CompletedAt *time.Time
+}
+
+// VariantSummary aggregates outcomes for a single variant. Durations are
+// measured from assignment to flow completion.
+type VariantSummary struct {
+	VariantKey           string
+	Assigned             int
+	Exposed              int
+	Completed            int
+	CompletionRate       float64
+	AvgTimeToComplete    time.Duration
+	MedianTimeToComplete time.Duration
+}
+
+// SummarizeVariants reports per-variant completion, in the order of keys.
+// Variants without outcomes are still reported with zero counts.
+func SummarizeVariants(keys []string, outcomes []VariantOutcome) []VariantSummary {
+	durations := make(map[string][]time.Duration, len(keys))
+	byKey := make(map[string]*VariantSummary, len(keys))
+	out := make([]VariantSummary, len(keys))
+	for i, key := range keys {
+		out[i].VariantKey = key
+		byKey[key] = &out[i]
+	}
+	for _, outcome := range outcomes {
+		summary, ok := byKey[outcome.VariantKey]
+		if !ok {
+			continue
+		}
+		summary.Assigned++
+		if outcome.ExposedAt != nil {
+			summary.Exposed++
+		}
+		if outcome.CompletedAt != nil {
+			summary.Completed++
+			elapsed := outcome.CompletedAt.Sub(outcome.AssignedAt)
+			if elapsed < 0 {
+				elapsed = 0
+			}
+			durations[outcome.VariantKey] = append(durations[outcome.VariantKey], elapsed)
+		}
+	}
+	for i := range out {
+		if out[i].Assigned > 0 {
+			out[i].CompletionRate = float64(out[i].Completed) / float64(out[i].Assigned)
+		}
+		samples := durations[out[i].VariantKey]
+		if len(samples) == 0 {
+			continue
+		}
+		var total time.Duration
+		for _, sample := range samples {
+			total += sample
+		}
+		out[i].AvgTimeToComplete = total / time.Duration(len(samples))
+		sort.Slice(samples, func(a int, b int) bool { return samples[a] < samples[b] })
+		mid := len(samples) / 2
+		if len(samples)%2 == 1 {
+			out[i].MedianTimeToComplete = samples[mid]
+		} else {
+			out[i].MedianTimeToComplete = (samples[mid-1] + samples[mid]) / 2
+		}
+	}
+	return out
+}
@@ -1,54 +1,110 @@
 package onboardingservice
 
 import (
+	"context"
 	"log/slog"
 	"time"
 
 	httpadapter "solomon/contexts/identity-access/onboarding-service/adapters/http"
 	"solomon/contexts/identity-access/onboarding-service/adapters/memory"
+	"solomon/contexts/identity-access/onboarding-service/adapters/notification"
 	"solomon/contexts/identity-access/onboarding-service/application"
+	"solomon/contexts/identity-access/onboarding-service/application/workers"
 	"solomon/contexts/identity-access/onboarding-service/ports"
 )
 
 type Module struct {
-	Handler httpadapter.Handler
-	Store   *memory.Store
+	Handler       httpadapter.Handler
+	Reminders     workers.ReminderScheduler
+	Activity      *workers.ActivityConsumer
+	Store         *memory.Store
+	Notifications *notification.Recorder
 }
 
 type Dependencies struct {
-	Repository     ports.Repository
-	Idempotency    ports.IdempotencyStore
-	Clock          ports.Clock
-	IDGenerator    ports.IDGenerator
-	IdempotencyTTL time.Duration
-	Logger         *slog.Logger
+	Repository  ports.Repository
+	Idempotency ports.IdempotencyStore
+	Outbox      ports.OutboxWriter
+	Notifier    ports.Notifier
+	// EventSubscriber feeds submission and campaign events into
+	// event-driven step completion.
+	EventSubscriber ports.EventSubscriber
+	Clock           ports.Clock
+	IDGenerator     ports.IDGenerator
+	IdempotencyTTL  time.Duration
+	ReminderPolicy  application.ReminderPolicy
+	Logger          *slog.Logger
 }
 
 func NewModule(deps Dependencies) Module {
 	service := application.Service{
 		Repo:           deps.Repository,
 		Idempotency:    deps.Idempotency,
+		Outbox:         deps.Outbox,
+		Notifier:       deps.Notifier,
 		Clock:          deps.Clock,
 		Logger:         deps.Logger,
 		IdempotencyTTL: deps.IdempotencyTTL,
+		Reminders:      deps.ReminderPolicy,
 	}
-	return Module{
+	module := Module{
 		Handler: httpadapter.Handler{
 			Service: service,
 			Logger:  deps.Logger,
 		},
+		Reminders: workers.ReminderScheduler{
+			Service:   service,
+			BatchSize: 100,
+			Disabled:  deps.Notifier == nil,
+			Logger:    deps.Logger,
+		},
+	}
+	if deps.EventSubscriber != nil {
+		module.Activity = &workers.ActivityConsumer{
+			Subscriber: deps.EventSubscriber,
+			Service:    service,
+			Logger:     deps.Logger,
+		}
+	}
+	return module
+}
+
+// StartConsumers subscribes to the activity events that complete onboarding
+// steps until ctx ends.
+func (m Module) StartConsumers(ctx context.Context) error {
+	if m.Activity == nil {
+		return nil
 	}
+	return m.Activity.Start(ctx)
 }
 
 func NewInMemoryModule(logger *slog.Logger) Module {
+	recorder := notification.NewRecorder()
+	module := NewInMemoryModuleWithNotifier(logger, recorder)
+	module.Notifications = recorder
+	return module
+}
+
+// NewInMemoryModuleWithNotifier keeps onboarding state in memory but delivers
+// reminders through the given notifier, such as an SMTPNotifier.
+func NewInMemoryModuleWithNotifier(logger *slog.Logger, notifier ports.Notifier) Module {
+	return NewInMemoryModuleWithEvents(logger, notifier, nil)
+}
+
+// NewInMemoryModuleWithEvents also completes steps from the submission and
+// campaign events delivered by subscriber.
+func NewInMemoryModuleWithEvents(logger *slog.Logger, notifier ports.Notifier, subscriber ports.EventSubscriber) Module {
 	store := memory.NewStore()
 	module := NewModule(Dependencies{
-		Repository:     store,
-		Idempotency:    store,
-		Clock:          store,
-		IDGenerator:    store,
-		IdempotencyTTL: 7 * 24 * time.Hour,
-		Logger:         logger,
+		Repository:      store,
+		Idempotency:     store,
+		Outbox:          store,
+		Notifier:        notifier,
+		EventSubscriber: subscriber,
+		Clock:           store,
+		IDGenerator:     store,
+		IdempotencyTTL:  7 * 24 * time.Hour,
+		Logger:          logger,
 	})
 	module.Store = store
 	return module
@@ -3,6 +3,8 @@ package ports
 import (
 	"context"
 	"time"
+
+	contractsv1 "solomon/contracts/gen/events/v1"
 )
 
 const (
@@ -44,19 +46,33 @@ type UserRegisteredEvent struct {
 	EventID    string
 	UserID     string
 	Role       string
+	Email      string
 	OccurredAt time.Time
 }
 
+const (
+	FlowVersionDraft      = "draft"
+	FlowVersionPublished  = "published"
+	FlowVersionSuperseded = "superseded"
+)
+
+// FlowStep is a step as authored in a flow version and as reported in a
+// user's flow state. CompletionEvent, when set, names the activity event
+// type (for example "submission.created") that completes the step without
+// an explicit call.
 type FlowStep struct {
-	StepKey string
-	Title   string
-	Status  string
+	StepKey         string
+	Title           string
+	Status          string
+	CompletionEvent string
 }
 
 type FlowState struct {
 	UserID         string
 	Role           string
 	FlowID         string
+	FlowVersion    int
+	ExperimentKey  string
 	VariantKey     string
 	Status         string
 	CompletedSteps int
@@ -82,17 +98,252 @@ type ResumeResult struct {
 }
 
 type AdminFlow struct {
+	FlowID           string
+	Role             string
+	Name             string
+	IsActive         bool
+	StepsCount       int
+	PublishedVersion int
+	LatestVersion    int
+}
+
+type FlowVersion struct {
+	FlowID      string
+	Version     int
+	Status      string
+	Steps       []FlowStep
+	CreatedBy   string
+	CreatedAt   time.Time
+	PublishedBy string
+	PublishedAt *time.Time
+}
+
+// FlowDefinition is the authored flow for a role. At most one flow per role
+// is active; new users start on its published version and keep that version
+// until they finish, even when a newer version is published.
+type FlowDefinition struct {
+	FlowID           string
+	Role             string
+	Name             string
+	IsActive         bool
+	Archived         bool
+	PublishedVersion int
+	Versions         []FlowVersion
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+type CreateFlowInput struct {
+	Role  string
+	Name  string
+	Steps []FlowStep
+}
+
+type ActivityEvent struct {
+	EventID    string
+	UserID     string
+	EventType  string
+	OccurredAt time.Time
+}
+
+type ActivityResult struct {
+	UserID            string
+	Status            string
+	CompletedStepKeys []string
+	CompletedSteps    int
+	TotalSteps        int
+}
+
+const (
+	ExperimentRunning = "running"
+	ExperimentStopped = "stopped"
+
+	// DefaultVariantKey is reported for users enrolled while no experiment
+	// was running for their role.
+	DefaultVariantKey = "control"
+)
+
+// ExperimentVariant is a weighted arm of an onboarding experiment. FlowID
+// optionally routes the variant to a different published flow of the same
+// role; when empty the role's active flow is used.
+type ExperimentVariant struct {
+	VariantKey string
+	Weight     int
 	FlowID     string
-	Role       string
-	IsActive   bool
-	StepsCount int
+}
+
+type Experiment struct {
+	ExperimentKey string
+	Role          string
+	Status        string
+	Variants      []ExperimentVariant
+	CreatedBy     string
+	CreatedAt     time.Time
+	StoppedAt     *time.Time
+}
+
+type CreateExperimentInput struct {
+	ExperimentKey string
+	Role          string
+	Variants      []ExperimentVariant
+}
+
+// VariantAssignment is the persisted variant a user was bucketed into. A zero
+// value means the user was enrolled outside any experiment.
+type VariantAssignment struct {
+	UserID        string
+	ExperimentKey string
+	VariantKey    string
+	FlowID        string
+	AssignedAt    time.Time
+	ExposedAt     *time.Time
+}
+
+type ExperimentOutcome struct {
+	UserID      string
+	VariantKey  string
+	AssignedAt  time.Time
+	ExposedAt   *time.Time
+	CompletedAt *time.Time
+}
+
+type VariantMetrics struct {
+	VariantKey                  string
+	Weight                      int
+	Assigned                    int
+	Exposed                     int
+	Completed                   int
+	CompletionRate              float64
+	AvgTimeToCompleteSeconds    int64
+	MedianTimeToCompleteSeconds int64
+}
+
+type ExperimentReport struct {
+	Experiment Experiment
+	Variants   []VariantMetrics
+}
+
+const (
+	ChannelEmail = "email"
+	ChannelInApp = "in_app"
+
+	ReminderReasonSkipped = "skipped"
+	ReminderReasonStalled = "stalled"
+)
+
+// Notification is a rendered message for a single channel. Recipient is the
+// email address for the email channel and is unused for in-app delivery.
+type Notification struct {
+	NotificationID string
+	UserID         string
+	Channel        string
+	Recipient      string
+	TemplateKey    string
+	Subject        string
+	Body           string
+	CreatedAt      time.Time
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification) error
+}
+
+// ReminderPreferences controls onboarding nudges for a user. Quiet hours are
+// "HH:MM" wall-clock times in Timezone; an end before the start wraps past
+// midnight. Empty quiet hours disable the window.
+type ReminderPreferences struct {
+	UserID          string
+	EmailEnabled    bool
+	InAppEnabled    bool
+	Unsubscribed    bool
+	QuietHoursStart string
+	QuietHoursEnd   string
+	Timezone        string
+	UpdatedAt       time.Time
+}
+
+// ReminderCandidate is a flow that is due a nudge. DueAt identifies the
+// schedule slot so a claim fails if the flow changed after it was listed.
+type ReminderCandidate struct {
+	UserID         string
+	Email          string
+	Role           string
+	FlowID         string
+	Status         string
+	Reason         string
+	NextStep       string
+	NextStepTitle  string
+	CompletedSteps int
+	TotalSteps     int
+	RemindersSent  int
+	DueAt          time.Time
+}
+
+// ReminderRunResult summarises one reminder scheduler pass.
+type ReminderRunResult struct {
+	Due        int
+	Sent       int
+	Deferred   int
+	Suppressed int
+	Failed     int
+}
+
+type EventEnvelope = contractsv1.Envelope
+
+type OutboxWriter interface {
+	AppendOutbox(ctx context.Context, envelope EventEnvelope) error
+}
+
+// EventSubscriber delivers other modules' outbox events, such as
+// submission.created, that complete onboarding steps.
+type EventSubscriber interface {
+	Subscribe(
+		ctx context.Context,
+		topic string,
+		consumerGroup string,
+		handler func(context.Context, EventEnvelope) error,
+	) error
 }
 
 type Repository interface {
-	ConsumeUserRegisteredEvent(ctx context.Context, event UserRegisteredEvent, now time.Time) (FlowState, error)
+	// ConsumeUserRegisteredEvent enrolls the user and persists the variant
+	// assignment alongside the new progress record.
+	ConsumeUserRegisteredEvent(ctx context.Context, event UserRegisteredEvent, assignment VariantAssignment, now time.Time) (FlowState, error)
 	GetFlow(ctx context.Context, userID string) (FlowState, error)
 	CompleteStep(ctx context.Context, userID string, stepKey string, metadata map[string]any, now time.Time) (StepCompletion, error)
 	SkipFlow(ctx context.Context, userID string, reason string, now time.Time) (SkipResult, error)
 	ResumeFlow(ctx context.Context, userID string, now time.Time) (ResumeResult, error)
 	ListAdminFlows(ctx context.Context) ([]AdminFlow, error)
+
+	CreateFlowDefinition(ctx context.Context, actorID string, input CreateFlowInput, now time.Time) (FlowDefinition, error)
+	GetFlowDefinition(ctx context.Context, flowID string) (FlowDefinition, error)
+	// SaveFlowDraft replaces the steps of the flow's draft version, opening a
+	// new draft after the latest published version when none exists.
+	SaveFlowDraft(ctx context.Context, actorID string, flowID string, steps []FlowStep, now time.Time) (FlowVersion, error)
+	PublishFlowVersion(ctx context.Context, actorID string, flowID string, version int, now time.Time) (FlowDefinition, error)
+	ArchiveFlowDefinition(ctx context.Context, actorID string, flowID string, now time.Time) (FlowDefinition, error)
+	// ApplyActivityEvent completes pending steps whose completion event
+	// matches, against the user's pinned flow version. Replays are no-ops.
+	ApplyActivityEvent(ctx context.Context, event ActivityEvent, now time.Time) (ActivityResult, error)
+
+	CreateExperiment(ctx context.Context, actorID string, input CreateExperimentInput, now time.Time) (Experiment, error)
+	ListExperiments(ctx context.Context) ([]Experiment, error)
+	GetExperiment(ctx context.Context, experimentKey string) (Experiment, error)
+	GetRunningExperiment(ctx context.Context, role string) (Experiment, error)
+	StopExperiment(ctx context.Context, actorID string, experimentKey string, now time.Time) (Experiment, error)
+	// MarkVariantExposed records the first time a user saw their variant and
+	// reports whether this call was that first time.
+	MarkVariantExposed(ctx context.Context, userID string, now time.Time) (VariantAssignment, bool, error)
+	ListExperimentOutcomes(ctx context.Context, experimentKey string) ([]ExperimentOutcome, error)
+
+	// ListDueReminders returns skipped flows whose reminder time has passed
+	// and in-progress flows idle since stalledBefore, oldest first.
+	ListDueReminders(ctx context.Context, now time.Time, stalledBefore time.Time, limit int) ([]ReminderCandidate, error)
+	// ClaimReminder records a reminder for the candidate slot. Skipped flows
+	// are reminded again at nextAt; stalled flows after another idle period.
+	// A nil nextAt stops reminders until the user resumes. It fails with
+	// ErrConflict when the slot is no longer due.
+	ClaimReminder(ctx context.Context, candidate ReminderCandidate, now time.Time, nextAt *time.Time) error
+	GetReminderPreferences(ctx context.Context, userID string) (ReminderPreferences, error)
+	SaveReminderPreferences(ctx context.Context, preferences ReminderPreferences) (ReminderPreferences, error)
 }
@@ -11,14 +11,17 @@ type GetFlowResponse struct {
 		UserID         string `json:"user_id"`
 		Role           string `json:"role"`
 		FlowID         string `json:"flow_id"`
+		FlowVersion    int    `json:"flow_version"`
+		ExperimentKey  string `json:"experiment_key,omitempty"`
 		VariantKey     string `json:"variant_key"`
 		Status         string `json:"status"`
 		CompletedSteps int    `json:"completed_steps"`
 		TotalSteps     int    `json:"total_steps"`
 		Steps          []struct {
-			StepKey string `json:"step_key"`
-			Title   string `json:"title"`
-			Status  string `json:"status"`
+			StepKey         string `json:"step_key"`
+			Title           string `json:"title"`
+			Status          string `json:"status"`
+			CompletionEvent string `json:"completion_event,omitempty"`
 		} `json:"steps"`
 	} `json:"data"`
 }
@@ -61,18 +64,91 @@ type AdminFlowsResponse struct {
 	Status string `json:"status"`
 	Data   struct {
 		Flows []struct {
-			FlowID     string `json:"flow_id"`
-			Role       string `json:"role"`
-			IsActive   bool   `json:"is_active"`
-			StepsCount int    `json:"steps_count"`
+			FlowID           string `json:"flow_id"`
+			Role             string `json:"role"`
+			Name             string `json:"name"`
+			IsActive         bool   `json:"is_active"`
+			StepsCount       int    `json:"steps_count"`
+			PublishedVersion int    `json:"published_version"`
+			LatestVersion    int    `json:"latest_version"`
 		} `json:"flows"`
 	} `json:"data"`
 }
 
+type FlowStepDTO struct {
+	StepKey         string `json:"step_key"`
+	Title           string `json:"title"`
+	CompletionEvent string `json:"completion_event,omitempty"`
+}
+
+type FlowVersionDTO struct {
+	Version     int           `json:"version"`
+	Status      string        `json:"status"`
+	Steps       []FlowStepDTO `json:"steps"`
+	CreatedBy   string        `json:"created_by"`
+	CreatedAt   string        `json:"created_at"`
+	PublishedBy string        `json:"published_by,omitempty"`
+	PublishedAt string        `json:"published_at,omitempty"`
+}
+
+type FlowDefinitionDTO struct {
+	FlowID           string           `json:"flow_id"`
+	Role             string           `json:"role"`
+	Name             string           `json:"name"`
+	IsActive         bool             `json:"is_active"`
+	Archived         bool             `json:"archived"`
+	PublishedVersion int              `json:"published_version"`
+	Versions         []FlowVersionDTO `json:"versions"`
+	CreatedAt        string           `json:"created_at"`
+	UpdatedAt        string           `json:"updated_at"`
+}
+
+type CreateFlowRequest struct {
+	Role  string        `json:"role"`
+	Name  string        `json:"name"`
+	Steps []FlowStepDTO `json:"steps"`
+}
+
+type SaveFlowDraftRequest struct {
+	Steps []FlowStepDTO `json:"steps"`
+}
+
+type FlowDefinitionResponse struct {
+	Status string            `json:"status"`
+	Data   FlowDefinitionDTO `json:"data"`
+}
+
+type FlowVersionResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		FlowID string `json:"flow_id"`
+		FlowVersionDTO
+	} `json:"data"`
+}
+
+type ActivityEventRequest struct {
+	EventID    string `json:"event_id"`
+	UserID     string `json:"user_id"`
+	EventType  string `json:"event_type"`
+	OccurredAt string `json:"occurred_at,omitempty"`
+}
+
+type ActivityEventResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		UserID            string   `json:"user_id"`
+		Status            string   `json:"status,omitempty"`
+		CompletedStepKeys []string `json:"completed_step_keys"`
+		CompletedSteps    int      `json:"completed_steps"`
+		TotalSteps        int      `json:"total_steps"`
+	} `json:"data"`
+}
+
 type UserRegisteredEventRequest struct {
 	EventID    string `json:"event_id"`
 	UserID     string `json:"user_id"`
 	Role       string `json:"role"`
+	Email      string `json:"email,omitempty"`
 	OccurredAt string `json:"occurred_at,omitempty"`
 }
 
@@ -85,3 +161,77 @@ type UserRegisteredEventResponse struct {
 		Status string `json:"status"`
 	} `json:"data"`
 }
+
+type ExperimentVariantDTO struct {
+	VariantKey string `json:"variant_key"`
+	Weight     int    `json:"weight"`
+	FlowID     string `json:"flow_id,omitempty"`
+}
+
+type ExperimentDTO struct {
+	ExperimentKey string                 `json:"experiment_key"`
+	Role          string                 `json:"role"`
+	Status        string                 `json:"status"`
+	Variants      []ExperimentVariantDTO `json:"variants"`
+	CreatedBy     string                 `json:"created_by"`
+	CreatedAt     string                 `json:"created_at"`
+	StoppedAt     string                 `json:"stopped_at,omitempty"`
+}
+
+type CreateExperimentRequest struct {
+	ExperimentKey string                 `json:"experiment_key"`
+	Role          string                 `json:"role"`
+	Variants      []ExperimentVariantDTO `json:"variants"`
+}
+
+type ExperimentResponse struct {
+	Status string        `json:"status"`
+	Data   ExperimentDTO `json:"data"`
+}
+
+type ExperimentsResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		Experiments []ExperimentDTO `json:"experiments"`
+	} `json:"data"`
+}
+
+type ExperimentResultsResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		Experiment ExperimentDTO `json:"experiment"`
+		Variants   []struct {
+			VariantKey                  string  `json:"variant_key"`
+			Weight                      int     `json:"weight"`
+			Assigned                    int     `json:"assigned"`
+			Exposed                     int     `json:"exposed"`
+			Completed                   int     `json:"completed"`
+			CompletionRate              float64 `json:"completion_rate"`
+			AvgTimeToCompleteSeconds    int64   `json:"avg_time_to_complete_seconds"`
+			MedianTimeToCompleteSeconds int64   `json:"median_time_to_complete_seconds"`
+		} `json:"variants"`
+	} `json:"data"`
+}
+
+type ReminderPreferencesRequest struct {
+	EmailEnabled    bool   `json:"email_enabled"`
+	InAppEnabled    bool   `json:"in_app_enabled"`
+	Unsubscribed    bool   `json:"unsubscribed"`
+	QuietHoursStart string `json:"quiet_hours_start,omitempty"`
+	QuietHoursEnd   string `json:"quiet_hours_end,omitempty"`
+	Timezone        string `json:"timezone,omitempty"`
+}
+
+type ReminderPreferencesResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		UserID          string `json:"user_id"`
+		EmailEnabled    bool   `json:"email_enabled"`
+		InAppEnabled    bool   `json:"in_app_enabled"`
+		Unsubscribed    bool   `json:"unsubscribed"`
+		QuietHoursStart string `json:"quiet_hours_start,omitempty"`
+		QuietHoursEnd   string `json:"quiet_hours_end,omitempty"`
+		Timezone        string `json:"timezone"`
+		UpdatedAt       string `json:"updated_at,omitempty"`
+	} `json:"data"`
+}
@@ -33,9 +33,34 @@ func (h Handler) RecordAdminActionHandler(
 	return httptransport.RecordAdminActionResponse{
 		AuditID:    row.AuditID,
 		OccurredAt: row.OccurredAt.UTC().Format(time.RFC3339),
+		Sequence:   row.Sequence,
+		EntryHash:  row.EntryHash,
 	}, nil
 }
 
+func (h Handler) VerifyAuditChainHandler(ctx context.Context) (httptransport.AuditChainVerificationResponse, error) {
+	report, err := h.Service.VerifyAuditChain(ctx)
+	if err != nil {
+		return httptransport.AuditChainVerificationResponse{}, err
+	}
+	resp := httptransport.AuditChainVerificationResponse{
+		Valid:          report.Valid,
+		EntriesChecked: report.EntriesChecked,
+		AnchorsChecked: report.AnchorsChecked,
+		HeadSequence:   report.HeadSequence,
+		HeadHash:       report.HeadHash,
+	}
+	if report.FirstBreak != nil {
+		resp.FirstBreak = &httptransport.AuditChainBreakDTO{
+			Sequence: report.FirstBreak.Sequence,
+			AuditID:  report.FirstBreak.AuditID,
+			AnchorID: report.FirstBreak.AnchorID,
+			Reason:   report.FirstBreak.Reason,
+		}
+	}
+	return resp, nil
+}
+
 func (h Handler) GrantIdentityRoleHandler(
 	ctx context.Context,
 	adminID string,
@@ -16,6 +16,7 @@ import (
 type Store struct {
 	mu                sync.Mutex
 	logs              []ports.AuditLog
+	anchors           []ports.AuditAnchor
 	idempotency       map[string]ports.IdempotencyRecord
 	consents          map[string]ports.ConsentRecordResult
 	portability       map[string]ports.PortabilityRequestResult
@@ -50,10 +51,60 @@ func NewStore() *Store {
 func (s *Store) AppendAuditLog(_ context.Context, row ports.AuditLog) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	if row.Sequence != int64(len(s.logs))+1 {
+		return domainerrors.ErrAuditChainConflict
+	}
 	s.logs = append(s.logs, row)
 	return nil
 }
 
+func (s *Store) GetAuditChainHead(_ context.Context) (ports.AuditLog, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.logs) == 0 {
+		return ports.AuditLog{}, false, nil
+	}
+	return s.logs[len(s.logs)-1], true, nil
+}
+
+func (s *Store) ListAuditChain(_ context.Context, afterSequence int64, limit int) ([]ports.AuditLog, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]ports.AuditLog, 0, limit)
+	for _, row := range s.logs {
+		if row.Sequence <= afterSequence {
+			continue
+		}
+		out = append(out, row)
+		if len(out) >= limit {
+			break
+		}
+	}
+	return out, nil
+}
+
+func (s *Store) AppendAuditAnchor(_ context.Context, anchor ports.AuditAnchor) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.anchors = append(s.anchors, anchor)
+	return nil
+}
+
+func (s *Store) GetLatestAuditAnchor(_ context.Context) (ports.AuditAnchor, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.anchors) == 0 {
+		return ports.AuditAnchor{}, false, nil
+	}
+	return s.anchors[len(s.anchors)-1], true, nil
+}
+
+func (s *Store) ListAuditAnchors(_ context.Context) ([]ports.AuditAnchor, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.anchors), nil
+}
+
 func (s *Store) ListRecentAuditLogs(_ context.Context, limit int) ([]ports.AuditLog, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
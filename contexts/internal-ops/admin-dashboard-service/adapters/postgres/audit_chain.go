@@ -0,0 +1,199 @@
+package postgresadapter
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	domainerrors "solomon/contexts/internal-ops/admin-dashboard-service/domain/errors"
+	"solomon/contexts/internal-ops/admin-dashboard-service/ports"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// AuditChainRepository persists the hash-chained control-plane audit log
+// and its head anchors. The sequence primary key serialises concurrent
+// appends; both tables are append-only in the database.
+type AuditChainRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditChainRepository builds the GORM-backed audit chain adapter.
+func NewAuditChainRepository(db *gorm.DB) *AuditChainRepository {
+	return &AuditChainRepository{db: db}
+}
+
+func (r *AuditChainRepository) AppendAuditLog(ctx context.Context, row ports.AuditLog) error {
+	if err := r.db.WithContext(ctx).Create(auditLogFromPort(row)).Error; err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domainerrors.ErrAuditChainConflict
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *AuditChainRepository) ListRecentAuditLogs(ctx context.Context, limit int) ([]ports.AuditLog, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	var rows []auditLogModel
+	if err := r.db.WithContext(ctx).
+		Order("sequence DESC").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return auditLogsToPorts(rows), nil
+}
+
+func (r *AuditChainRepository) GetAuditChainHead(ctx context.Context) (ports.AuditLog, bool, error) {
+	var rows []auditLogModel
+	if err := r.db.WithContext(ctx).
+		Order("sequence DESC").
+		Limit(1).
+		Find(&rows).Error; err != nil {
+		return ports.AuditLog{}, false, err
+	}
+	if len(rows) == 0 {
+		return ports.AuditLog{}, false, nil
+	}
+	return rows[0].toPort(), true, nil
+}
+
+func (r *AuditChainRepository) ListAuditChain(ctx context.Context, afterSequence int64, limit int) ([]ports.AuditLog, error) {
+	var rows []auditLogModel
+	if err := r.db.WithContext(ctx).
+		Where("sequence > ?", afterSequence).
+		Order("sequence ASC").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return auditLogsToPorts(rows), nil
+}
+
+func (r *AuditChainRepository) AppendAuditAnchor(ctx context.Context, anchor ports.AuditAnchor) error {
+	return r.db.WithContext(ctx).Create(&auditAnchorModel{
+		AnchorID:   anchor.AnchorID,
+		Sequence:   anchor.Sequence,
+		EntryHash:  anchor.EntryHash,
+		Signature:  anchor.Signature,
+		AnchoredAt: anchor.AnchoredAt.UTC(),
+	}).Error
+}
+
+func (r *AuditChainRepository) GetLatestAuditAnchor(ctx context.Context) (ports.AuditAnchor, bool, error) {
+	var rows []auditAnchorModel
+	if err := r.db.WithContext(ctx).
+		Order("sequence DESC, anchored_at DESC").
+		Limit(1).
+		Find(&rows).Error; err != nil {
+		return ports.AuditAnchor{}, false, err
+	}
+	if len(rows) == 0 {
+		return ports.AuditAnchor{}, false, nil
+	}
+	return rows[0].toPort(), true, nil
+}
+
+func (r *AuditChainRepository) ListAuditAnchors(ctx context.Context) ([]ports.AuditAnchor, error) {
+	var rows []auditAnchorModel
+	if err := r.db.WithContext(ctx).
+		Order("sequence ASC, anchored_at ASC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	items := make([]ports.AuditAnchor, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, row.toPort())
+	}
+	return items, nil
+}
+
+type auditLogModel struct {
+	Sequence      int64     `gorm:"column:sequence;primaryKey;autoIncrement:false"`
+	AuditID       string    `gorm:"column:audit_id"`
+	ActorID       string    `gorm:"column:actor_id"`
+	Action        string    `gorm:"column:action"`
+	TargetID      string    `gorm:"column:target_id"`
+	Justification string    `gorm:"column:justification"`
+	OccurredAt    time.Time `gorm:"column:occurred_at"`
+	SourceIP      string    `gorm:"column:source_ip"`
+	CorrelationID string    `gorm:"column:correlation_id"`
+	PrevHash      string    `gorm:"column:prev_hash"`
+	EntryHash     string    `gorm:"column:entry_hash"`
+	Signature     string    `gorm:"column:signature"`
+}
+
+func (auditLogModel) TableName() string {
+	return "admin_audit_logs"
+}
+
+func auditLogFromPort(row ports.AuditLog) *auditLogModel {
+	return &auditLogModel{
+		Sequence:      row.Sequence,
+		AuditID:       row.AuditID,
+		ActorID:       row.ActorID,
+		Action:        row.Action,
+		TargetID:      row.TargetID,
+		Justification: row.Justification,
+		OccurredAt:    row.OccurredAt.UTC(),
+		SourceIP:      row.SourceIP,
+		CorrelationID: row.CorrelationID,
+		PrevHash:      row.PrevHash,
+		EntryHash:     row.EntryHash,
+		Signature:     row.Signature,
+	}
+}
+
+func (m auditLogModel) toPort() ports.AuditLog {
+	return ports.AuditLog{
+		AuditID:       m.AuditID,
+		ActorID:       m.ActorID,
+		Action:        m.Action,
+		TargetID:      m.TargetID,
+		Justification: m.Justification,
+		OccurredAt:    m.OccurredAt.UTC(),
+		SourceIP:      m.SourceIP,
+		CorrelationID: m.CorrelationID,
+		Sequence:      m.Sequence,
+		PrevHash:      m.PrevHash,
+		EntryHash:     m.EntryHash,
+		Signature:     m.Signature,
+	}
+}
+
+func auditLogsToPorts(rows []auditLogModel) []ports.AuditLog {
+	items := make([]ports.AuditLog, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, row.toPort())
+	}
+	return items
+}
+
+type auditAnchorModel struct {
+	AnchorID   string    `gorm:"column:anchor_id;primaryKey"`
+	Sequence   int64     `gorm:"column:sequence"`
+	EntryHash  string    `gorm:"column:entry_hash"`
+	Signature  string    `gorm:"column:signature"`
+	AnchoredAt time.Time `gorm:"column:anchored_at"`
+}
+
+func (auditAnchorModel) TableName() string {
+	return "admin_audit_anchors"
+}
+
+func (m auditAnchorModel) toPort() ports.AuditAnchor {
+	return ports.AuditAnchor{
+		AnchorID:   m.AnchorID,
+		Sequence:   m.Sequence,
+		EntryHash:  m.EntryHash,
+		Signature:  m.Signature,
+		AnchoredAt: m.AnchoredAt.UTC(),
+	}
+}
+
+var _ ports.Repository = (*AuditChainRepository)(nil)
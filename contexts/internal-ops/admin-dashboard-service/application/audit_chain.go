@@ -0,0 +1,134 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	domainerrors "solomon/contexts/internal-ops/admin-dashboard-service/domain/errors"
+	"solomon/contexts/internal-ops/admin-dashboard-service/domain/services"
+	"solomon/contexts/internal-ops/admin-dashboard-service/ports"
+	"solomon/internal/platform/auditchain"
+)
+
+// appendAuditLog links row to the chain head, signs it and stores it,
+// retrying when a concurrent append took the next sequence first.
+func (s Service) appendAuditLog(ctx context.Context, row ports.AuditLog) (ports.AuditLog, error) {
+	chain := s.auditChain()
+	row.OccurredAt = auditchain.CanonicalTime(row.OccurredAt)
+	err := auditchain.Append(ctx,
+		func(ctx context.Context) (int64, string, bool, error) {
+			head, found, err := s.Repo.GetAuditChainHead(ctx)
+			return head.Sequence, head.EntryHash, found, err
+		},
+		func(ctx context.Context, sequence int64, prevHash string) error {
+			row.Sequence = sequence
+			row.PrevHash = prevHash
+			content, err := services.CanonicalAuditContent(toAuditEntry(row))
+			if err != nil {
+				return err
+			}
+			row.EntryHash, row.Signature, err = chain.Seal(content)
+			if err != nil {
+				return err
+			}
+			return s.Repo.AppendAuditLog(ctx, row)
+		},
+		domainerrors.ErrAuditChainConflict,
+	)
+	if err != nil {
+		return ports.AuditLog{}, err
+	}
+	return row, nil
+}
+
+// VerifyAuditChain walks the control-plane audit chain from genesis and
+// then checks each anchor against the entry it recorded.
+func (s Service) VerifyAuditChain(ctx context.Context) (auditchain.Report, error) {
+	if s.AuditSigner == nil {
+		return auditchain.Report{}, domainerrors.ErrDependencyUnavailable
+	}
+	return s.auditChain().Verify(ctx, auditChainSource{repo: s.Repo})
+}
+
+// AnchorAuditChain records the current chain head unless it is already
+// anchored.
+func (s Service) AnchorAuditChain(ctx context.Context) (ports.AuditAnchor, bool, error) {
+	if s.AuditSigner == nil {
+		return ports.AuditAnchor{}, false, domainerrors.ErrDependencyUnavailable
+	}
+	head, found, err := s.Repo.GetAuditChainHead(ctx)
+	if err != nil || !found {
+		return ports.AuditAnchor{}, false, err
+	}
+	latest, anchoredBefore, err := s.Repo.GetLatestAuditAnchor(ctx)
+	if err != nil {
+		return ports.AuditAnchor{}, false, err
+	}
+	if anchoredBefore && latest.Sequence >= head.Sequence {
+		return latest, false, nil
+	}
+	now := auditchain.CanonicalTime(s.now())
+	anchor := ports.AuditAnchor(s.auditChain().SignAnchor(fmt.Sprintf("anchor_%d", now.UnixNano()), head.Sequence, head.EntryHash, now))
+	if err := s.Repo.AppendAuditAnchor(ctx, anchor); err != nil {
+		return ports.AuditAnchor{}, false, err
+	}
+	return anchor, true, nil
+}
+
+func (s Service) auditChain() auditchain.Chain {
+	return auditchain.Chain{Signer: s.AuditSigner, AnchorDomain: services.AuditAnchorDomain}
+}
+
+// auditChainSource reads the stored chain for verification.
+type auditChainSource struct {
+	repo ports.Repository
+}
+
+func (a auditChainSource) ListEntries(ctx context.Context, afterSequence int64, limit int) ([]auditchain.Entry, error) {
+	rows, err := a.repo.ListAuditChain(ctx, afterSequence, limit)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]auditchain.Entry, 0, len(rows))
+	for _, row := range rows {
+		// An entry that cannot be encoded keeps nil content and is
+		// reported as a hash mismatch.
+		content, _ := services.CanonicalAuditContent(toAuditEntry(row))
+		entries = append(entries, auditchain.Entry{
+			Sequence:  row.Sequence,
+			AuditID:   row.AuditID,
+			PrevHash:  row.PrevHash,
+			Content:   content,
+			EntryHash: row.EntryHash,
+			Signature: row.Signature,
+		})
+	}
+	return entries, nil
+}
+
+func (a auditChainSource) ListAnchors(ctx context.Context) ([]auditchain.Anchor, error) {
+	anchors, err := a.repo.ListAuditAnchors(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]auditchain.Anchor, 0, len(anchors))
+	for _, anchor := range anchors {
+		out = append(out, auditchain.Anchor(anchor))
+	}
+	return out, nil
+}
+
+func toAuditEntry(row ports.AuditLog) services.AuditEntry {
+	return services.AuditEntry{
+		Sequence:      row.Sequence,
+		AuditID:       row.AuditID,
+		ActorID:       row.ActorID,
+		Action:        row.Action,
+		TargetID:      row.TargetID,
+		Justification: row.Justification,
+		OccurredAt:    row.OccurredAt,
+		SourceIP:      row.SourceIP,
+		CorrelationID: row.CorrelationID,
+		PrevHash:      row.PrevHash,
+	}
+}
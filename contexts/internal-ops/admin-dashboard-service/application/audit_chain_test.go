@@ -0,0 +1,85 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"solomon/contexts/internal-ops/admin-dashboard-service/adapters/memory"
+	"solomon/contexts/internal-ops/admin-dashboard-service/ports"
+	"solomon/internal/platform/auditchain"
+)
+
+// tamperedChainStore rewrites one entry as it is read back.
+type tamperedChainStore struct {
+	*memory.Store
+	sequence int64
+}
+
+func (s tamperedChainStore) ListAuditChain(ctx context.Context, afterSequence int64, limit int) ([]ports.AuditLog, error) {
+	rows, err := s.Store.ListAuditChain(ctx, afterSequence, limit)
+	for i := range rows {
+		if rows[i].Sequence == s.sequence {
+			rows[i].Justification = "rewritten"
+		}
+	}
+	return rows, err
+}
+
+func recordTestActions(t *testing.T, svc Service, count int) {
+	t.Helper()
+	for i := 1; i <= count; i++ {
+		_, err := svc.RecordAdminAction(context.Background(), fmt.Sprintf("idem-chain-%d", i), RecordActionInput{
+			ActorID:       "admin_1",
+			Action:        "admin.test.action",
+			TargetID:      fmt.Sprintf("target_%d", i),
+			Justification: "chain test",
+		})
+		if err != nil {
+			t.Fatalf("record action %d: %v", i, err)
+		}
+	}
+}
+
+func TestAuditChainLinksAndVerifies(t *testing.T) {
+	store := memory.NewStore()
+	svc := Service{Repo: store, Idempotency: store, Clock: store, AuditSigner: testAuditSigner}
+	recordTestActions(t, svc, 3)
+
+	rows, err := store.ListAuditChain(context.Background(), 0, 10)
+	if err != nil {
+		t.Fatalf("list chain: %v", err)
+	}
+	if len(rows) != 3 || rows[0].PrevHash != auditchain.GenesisHash || rows[2].PrevHash != rows[1].EntryHash {
+		t.Fatalf("unexpected chain links: %+v", rows)
+	}
+	if _, created, err := svc.AnchorAuditChain(context.Background()); err != nil || !created {
+		t.Fatalf("anchor chain: created=%v err=%v", created, err)
+	}
+
+	report, err := svc.VerifyAuditChain(context.Background())
+	if err != nil {
+		t.Fatalf("verify chain: %v", err)
+	}
+	if !report.Valid || report.EntriesChecked != 3 || report.AnchorsChecked != 1 {
+		t.Fatalf("expected valid anchored chain, got %+v", report)
+	}
+}
+
+func TestAuditChainReportsTamperedEntry(t *testing.T) {
+	store := memory.NewStore()
+	svc := Service{Repo: store, Idempotency: store, Clock: store, AuditSigner: testAuditSigner}
+	recordTestActions(t, svc, 3)
+
+	svc.Repo = tamperedChainStore{Store: store, sequence: 2}
+	report, err := svc.VerifyAuditChain(context.Background())
+	if err != nil {
+		t.Fatalf("verify chain: %v", err)
+	}
+	if report.Valid || report.FirstBreak == nil {
+		t.Fatalf("expected broken chain, got %+v", report)
+	}
+	if report.FirstBreak.Sequence != 2 || report.FirstBreak.Reason != auditchain.BreakEntryHash {
+		t.Fatalf("expected entry hash break at 2, got %+v", report.FirstBreak)
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	domainerrors "solomon/contexts/internal-ops/admin-dashboard-service/domain/errors"
 	"solomon/contexts/internal-ops/admin-dashboard-service/ports"
+	"solomon/internal/platform/auditchain"
 )
 
 type Service struct {
@@ -37,6 +38,7 @@ type Service struct {
 	IntegrationHubClient   ports.IntegrationHubClient
 	WebhookManagerClient   ports.WebhookManagerClient
 	DataMigrationClient    ports.DataMigrationClient
+	AuditSigner            auditchain.Signer
 	Clock                  ports.Clock
 	IdempotencyTTL         time.Duration
 }
@@ -756,10 +758,11 @@ func (s Service) RecordAdminAction(ctx context.Context, idempotencyKey string, i
 				SourceIP:      strings.TrimSpace(input.SourceIP),
 				CorrelationID: strings.TrimSpace(input.CorrelationID),
 			}
-			if err := s.Repo.AppendAuditLog(ctx, logRow); err != nil {
+			sealed, err := s.appendAuditLog(ctx, logRow)
+			if err != nil {
 				return nil, err
 			}
-			return json.Marshal(logRow)
+			return json.Marshal(sealed)
 		},
 	); err != nil {
 		return ports.AuditLog{}, err
@@ -819,7 +822,7 @@ func (s Service) GrantIdentityRole(
 				SourceIP:      strings.TrimSpace(input.SourceIP),
 				CorrelationID: strings.TrimSpace(input.CorrelationID),
 			}
-			if err := s.Repo.AppendAuditLog(ctx, auditRow); err != nil {
+			if _, err := s.appendAuditLog(ctx, auditRow); err != nil {
 				return nil, err
 			}
 			return json.Marshal(GrantIdentityRoleResult{
@@ -916,7 +919,7 @@ func (s Service) ModerateSubmission(
 				SourceIP:      strings.TrimSpace(input.SourceIP),
 				CorrelationID: strings.TrimSpace(input.CorrelationID),
 			}
-			if err := s.Repo.AppendAuditLog(ctx, auditRow); err != nil {
+			if _, err := s.appendAuditLog(ctx, auditRow); err != nil {
 				return nil, err
 			}
 			return json.Marshal(ModerateSubmissionResult{
@@ -991,7 +994,7 @@ func (s Service) ReleaseAbuseLockout(
 				SourceIP:      input.SourceIP,
 				CorrelationID: input.CorrelationID,
 			}
-			if err := s.Repo.AppendAuditLog(ctx, auditRow); err != nil {
+			if _, err := s.appendAuditLog(ctx, auditRow); err != nil {
 				return nil, err
 			}
 			return json.Marshal(ReleaseAbuseLockoutResult{
@@ -1065,7 +1068,7 @@ func (s Service) CreateFinanceRefund(
 				SourceIP:      input.SourceIP,
 				CorrelationID: input.CorrelationID,
 			}
-			if err := s.Repo.AppendAuditLog(ctx, auditRow); err != nil {
+			if _, err := s.appendAuditLog(ctx, auditRow); err != nil {
 				return nil, err
 			}
 			return json.Marshal(CreateFinanceRefundResult{
@@ -1140,7 +1143,7 @@ func (s Service) CreateBillingRefund(
 				SourceIP:      input.SourceIP,
 				CorrelationID: input.CorrelationID,
 			}
-			if err := s.Repo.AppendAuditLog(ctx, auditRow); err != nil {
+			if _, err := s.appendAuditLog(ctx, auditRow); err != nil {
 				return nil, err
 			}
 			return json.Marshal(CreateBillingRefundResult{
@@ -1220,7 +1223,7 @@ func (s Service) RecalculateReward(
 				SourceIP:      input.SourceIP,
 				CorrelationID: input.CorrelationID,
 			}
-			if err := s.Repo.AppendAuditLog(ctx, auditRow); err != nil {
+			if _, err := s.appendAuditLog(ctx, auditRow); err != nil {
 				return nil, err
 			}
 			return json.Marshal(RecalculateRewardResult{
@@ -1294,7 +1297,7 @@ func (s Service) SuspendAffiliate(
 				SourceIP:      input.SourceIP,
 				CorrelationID: input.CorrelationID,
 			}
-			if err := s.Repo.AppendAuditLog(ctx, auditRow); err != nil {
+			if _, err := s.appendAuditLog(ctx, auditRow); err != nil {
 				return nil, err
 			}
 			return json.Marshal(SuspendAffiliateResult{
@@ -1375,7 +1378,7 @@ func (s Service) CreateAffiliateAttribution(
 				SourceIP:      input.SourceIP,
 				CorrelationID: input.CorrelationID,
 			}
-			if err := s.Repo.AppendAuditLog(ctx, auditRow); err != nil {
+			if _, err := s.appendAuditLog(ctx, auditRow); err != nil {
 				return nil, err
 			}
 			return json.Marshal(CreateAffiliateAttributionResult{
@@ -1446,7 +1449,7 @@ func (s Service) RetryPayout(
 				SourceIP:      input.SourceIP,
 				CorrelationID: input.CorrelationID,
 			}
-			if err := s.Repo.AppendAuditLog(ctx, auditRow); err != nil {
+			if _, err := s.appendAuditLog(ctx, auditRow); err != nil {
 				return nil, err
 			}
 			return json.Marshal(RetryPayoutResult{
@@ -1532,7 +1535,7 @@ func (s Service) ResolveDispute(
 				SourceIP:      input.SourceIP,
 				CorrelationID: input.CorrelationID,
 			}
-			if err := s.Repo.AppendAuditLog(ctx, auditRow); err != nil {
+			if _, err := s.appendAuditLog(ctx, auditRow); err != nil {
 				return nil, err
 			}
 			return json.Marshal(ResolveDisputeResult{
@@ -1630,7 +1633,7 @@ func (s Service) UpdateConsent(
 				SourceIP:      input.SourceIP,
 				CorrelationID: input.CorrelationID,
 			}
-			if err := s.Repo.AppendAuditLog(ctx, auditRow); err != nil {
+			if _, err := s.appendAuditLog(ctx, auditRow); err != nil {
 				return nil, err
 			}
 			return json.Marshal(UpdateConsentResult{
@@ -1701,7 +1704,7 @@ func (s Service) WithdrawConsent(
 				SourceIP:      input.SourceIP,
 				CorrelationID: input.CorrelationID,
 			}
-			if err := s.Repo.AppendAuditLog(ctx, auditRow); err != nil {
+			if _, err := s.appendAuditLog(ctx, auditRow); err != nil {
 				return nil, err
 			}
 			return json.Marshal(WithdrawConsentResult{
@@ -1775,7 +1778,7 @@ func (s Service) StartDataExport(
 				SourceIP:      input.SourceIP,
 				CorrelationID: input.CorrelationID,
 			}
-			if err := s.Repo.AppendAuditLog(ctx, auditRow); err != nil {
+			if _, err := s.appendAuditLog(ctx, auditRow); err != nil {
 				return nil, err
 			}
 			return json.Marshal(StartDataExportResult{
@@ -1879,7 +1882,7 @@ func (s Service) RequestDeletion(
 				SourceIP:      input.SourceIP,
 				CorrelationID: input.CorrelationID,
 			}
-			if err := s.Repo.AppendAuditLog(ctx, auditRow); err != nil {
+			if _, err := s.appendAuditLog(ctx, auditRow); err != nil {
 				return nil, err
 			}
 			return json.Marshal(RequestDeletionResult{
@@ -1954,7 +1957,7 @@ func (s Service) CreateRetentionLegalHold(
 				SourceIP:      input.SourceIP,
 				CorrelationID: input.CorrelationID,
 			}
-			if err := s.Repo.AppendAuditLog(ctx, auditRow); err != nil {
+			if _, err := s.appendAuditLog(ctx, auditRow); err != nil {
 				return nil, err
 			}
 			return json.Marshal(CreateRetentionLegalHoldResult{
@@ -2005,7 +2008,7 @@ func (s Service) CheckLegalHold(ctx context.Context, input CheckLegalHoldInput)
 		SourceIP:      input.SourceIP,
 		CorrelationID: input.CorrelationID,
 	}
-	if err := s.Repo.AppendAuditLog(ctx, auditRow); err != nil {
+	if _, err := s.appendAuditLog(ctx, auditRow); err != nil {
 		return CheckLegalHoldResult{}, err
 	}
 	return CheckLegalHoldResult{
@@ -2070,7 +2073,7 @@ func (s Service) ReleaseLegalHold(
 				SourceIP:      input.SourceIP,
 				CorrelationID: input.CorrelationID,
 			}
-			if err := s.Repo.AppendAuditLog(ctx, auditRow); err != nil {
+			if _, err := s.appendAuditLog(ctx, auditRow); err != nil {
 				return nil, err
 			}
 			return json.Marshal(ReleaseLegalHoldResult{
@@ -2141,7 +2144,7 @@ func (s Service) RunComplianceScan(
 				SourceIP:      input.SourceIP,
 				CorrelationID: input.CorrelationID,
 			}
-			if err := s.Repo.AppendAuditLog(ctx, auditRow); err != nil {
+			if _, err := s.appendAuditLog(ctx, auditRow); err != nil {
 				return nil, err
 			}
 			return json.Marshal(RunComplianceScanResult{
@@ -2271,7 +2274,7 @@ func (s Service) AssignSupportTicket(
 				SourceIP:      input.SourceIP,
 				CorrelationID: input.CorrelationID,
 			}
-			if err := s.Repo.AppendAuditLog(ctx, auditRow); err != nil {
+			if _, err := s.appendAuditLog(ctx, auditRow); err != nil {
 				return nil, err
 			}
 			return json.Marshal(AssignSupportTicketResult{
@@ -2351,7 +2354,7 @@ func (s Service) UpdateSupportTicket(
 				SourceIP:      input.SourceIP,
 				CorrelationID: input.CorrelationID,
 			}
-			if err := s.Repo.AppendAuditLog(ctx, auditRow); err != nil {
+			if _, err := s.appendAuditLog(ctx, auditRow); err != nil {
 				return nil, err
 			}
 			return json.Marshal(UpdateSupportTicketResult{
@@ -2419,7 +2422,7 @@ func (s Service) SaveEditorCampaign(
 				SourceIP:      input.SourceIP,
 				CorrelationID: input.CorrelationID,
 			}
-			if err := s.Repo.AppendAuditLog(ctx, auditRow); err != nil {
+			if _, err := s.appendAuditLog(ctx, auditRow); err != nil {
 				return nil, err
 			}
 			return json.Marshal(SaveEditorCampaignResult{
@@ -2498,7 +2501,7 @@ func (s Service) RequestClippingExport(
 				SourceIP:      input.SourceIP,
 				CorrelationID: input.CorrelationID,
 			}
-			if err := s.Repo.AppendAuditLog(ctx, auditRow); err != nil {
+			if _, err := s.appendAuditLog(ctx, auditRow); err != nil {
 				return nil, err
 			}
 			return json.Marshal(RequestClippingExportResult{
@@ -2584,7 +2587,7 @@ func (s Service) DeployAutoClippingModel(
 				SourceIP:      input.SourceIP,
 				CorrelationID: input.CorrelationID,
 			}
-			if err := s.Repo.AppendAuditLog(ctx, auditRow); err != nil {
+			if _, err := s.appendAuditLog(ctx, auditRow); err != nil {
 				return nil, err
 			}
 			return json.Marshal(DeployAutoClippingModelResult{
@@ -2653,7 +2656,7 @@ func (s Service) RotateIntegrationKey(
 				SourceIP:      input.SourceIP,
 				CorrelationID: input.CorrelationID,
 			}
-			if err := s.Repo.AppendAuditLog(ctx, auditRow); err != nil {
+			if _, err := s.appendAuditLog(ctx, auditRow); err != nil {
 				return nil, err
 			}
 			return json.Marshal(RotateIntegrationKeyResult{
@@ -2723,7 +2726,7 @@ func (s Service) TestIntegrationWorkflow(
 				SourceIP:      input.SourceIP,
 				CorrelationID: input.CorrelationID,
 			}
-			if err := s.Repo.AppendAuditLog(ctx, auditRow); err != nil {
+			if _, err := s.appendAuditLog(ctx, auditRow); err != nil {
 				return nil, err
 			}
 			return json.Marshal(TestIntegrationWorkflowResult{
@@ -2793,7 +2796,7 @@ func (s Service) ReplayWebhook(
 				SourceIP:      input.SourceIP,
 				CorrelationID: input.CorrelationID,
 			}
-			if err := s.Repo.AppendAuditLog(ctx, auditRow); err != nil {
+			if _, err := s.appendAuditLog(ctx, auditRow); err != nil {
 				return nil, err
 			}
 			return json.Marshal(ReplayWebhookResult{
@@ -2864,7 +2867,7 @@ func (s Service) DisableWebhook(
 				SourceIP:      input.SourceIP,
 				CorrelationID: input.CorrelationID,
 			}
-			if err := s.Repo.AppendAuditLog(ctx, auditRow); err != nil {
+			if _, err := s.appendAuditLog(ctx, auditRow); err != nil {
 				return nil, err
 			}
 			return json.Marshal(DisableWebhookResult{
@@ -3019,7 +3022,7 @@ func (s Service) CreateMigrationPlan(
 				SourceIP:      input.SourceIP,
 				CorrelationID: input.CorrelationID,
 			}
-			if err := s.Repo.AppendAuditLog(ctx, auditRow); err != nil {
+			if _, err := s.appendAuditLog(ctx, auditRow); err != nil {
 				return nil, err
 			}
 			return json.Marshal(CreateMigrationPlanResult{
@@ -3134,7 +3137,7 @@ func (s Service) StartMigrationRun(
 				SourceIP:      input.SourceIP,
 				CorrelationID: input.CorrelationID,
 			}
-			if err := s.Repo.AppendAuditLog(ctx, auditRow); err != nil {
+			if _, err := s.appendAuditLog(ctx, auditRow); err != nil {
 				return nil, err
 			}
 			return json.Marshal(StartMigrationRunResult{
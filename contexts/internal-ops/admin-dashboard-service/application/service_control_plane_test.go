@@ -7,6 +7,7 @@ import (
 	"solomon/contexts/internal-ops/admin-dashboard-service/adapters/memory"
 	domainerrors "solomon/contexts/internal-ops/admin-dashboard-service/domain/errors"
 	"solomon/contexts/internal-ops/admin-dashboard-service/ports"
+	"solomon/internal/platform/auditchain"
 )
 
 type fakeAuthorizationClient struct {
@@ -366,6 +367,7 @@ func TestGrantIdentityRoleIdempotentReplay(t *testing.T) {
 		Idempotency:         store,
 		AuthorizationClient: authz,
 		Clock:               store,
+		AuditSigner:         testAuditSigner,
 	}
 
 	input := GrantIdentityRoleInput{
@@ -425,6 +427,7 @@ func TestGrantIdentityRoleIdempotencyConflict(t *testing.T) {
 		Idempotency:         store,
 		AuthorizationClient: authz,
 		Clock:               store,
+		AuditSigner:         testAuditSigner,
 	}
 
 	_, err := svc.GrantIdentityRole(context.Background(), "idem-grant-cf", GrantIdentityRoleInput{
@@ -457,6 +460,7 @@ func TestModerateSubmissionRejectRequiresReason(t *testing.T) {
 		Idempotency:      store,
 		ModerationClient: &fakeModerationClient{},
 		Clock:            store,
+		AuditSigner:      testAuditSigner,
 	}
 
 	_, err := svc.ModerateSubmission(context.Background(), "idem-mod-1", ModerateSubmissionInput{
@@ -488,6 +492,7 @@ func TestModerateSubmissionApproveIdempotentReplay(t *testing.T) {
 		Idempotency:      store,
 		ModerationClient: mod,
 		Clock:            store,
+		AuditSigner:      testAuditSigner,
 	}
 
 	input := ModerateSubmissionInput{
@@ -545,6 +550,7 @@ func TestModerateSubmissionIdempotencyConflict(t *testing.T) {
 		Idempotency:      store,
 		ModerationClient: mod,
 		Clock:            store,
+		AuditSigner:      testAuditSigner,
 	}
 
 	_, err := svc.ModerateSubmission(context.Background(), "idem-mod-cf", ModerateSubmissionInput{
@@ -587,6 +593,7 @@ func TestReleaseAbuseLockoutIdempotentReplay(t *testing.T) {
 		Idempotency:           store,
 		AbusePreventionClient: abuse,
 		Clock:                 store,
+		AuditSigner:           testAuditSigner,
 	}
 
 	input := ReleaseAbuseLockoutInput{
@@ -642,6 +649,7 @@ func TestReleaseAbuseLockoutIdempotencyConflict(t *testing.T) {
 		Idempotency:           store,
 		AbusePreventionClient: abuse,
 		Clock:                 store,
+		AuditSigner:           testAuditSigner,
 	}
 
 	_, err := svc.ReleaseAbuseLockout(context.Background(), "idem-abuse-cp-cf", ReleaseAbuseLockoutInput{
@@ -682,6 +690,7 @@ func TestCreateFinanceRefundIdempotentReplay(t *testing.T) {
 		Idempotency:   store,
 		FinanceClient: finance,
 		Clock:         store,
+		AuditSigner:   testAuditSigner,
 	}
 
 	input := CreateFinanceRefundInput{
@@ -730,6 +739,7 @@ func TestRetryPayoutIdempotencyConflict(t *testing.T) {
 		Idempotency:  store,
 		PayoutClient: payout,
 		Clock:        store,
+		AuditSigner:  testAuditSigner,
 	}
 
 	_, err := svc.RetryPayout(context.Background(), "idem-pay-1", RetryPayoutInput{
@@ -764,6 +774,7 @@ func TestResolveDisputeReopenEmitsStatusAudit(t *testing.T) {
 		Idempotency:      store,
 		ResolutionClient: resolution,
 		Clock:            store,
+		AuditSigner:      testAuditSigner,
 	}
 
 	_, err := svc.ResolveDispute(context.Background(), "idem-dsp-1", ResolveDisputeInput{
@@ -798,6 +809,7 @@ func TestSaveEditorCampaignIdempotentReplay(t *testing.T) {
 		Idempotency:          store,
 		EditorWorkflowClient: editor,
 		Clock:                store,
+		AuditSigner:          testAuditSigner,
 	}
 
 	input := SaveEditorCampaignInput{
@@ -845,6 +857,7 @@ func TestRequestClippingExportIdempotentReplay(t *testing.T) {
 		Idempotency:            store,
 		ClippingWorkflowClient: clipping,
 		Clock:                  store,
+		AuditSigner:            testAuditSigner,
 	}
 
 	input := RequestClippingExportInput{
@@ -895,6 +908,7 @@ func TestDeployAutoClippingModelIdempotentReplay(t *testing.T) {
 		Idempotency:        store,
 		AutoClippingClient: auto,
 		Clock:              store,
+		AuditSigner:        testAuditSigner,
 	}
 
 	input := DeployAutoClippingModelInput{
@@ -948,6 +962,7 @@ func TestRotateIntegrationKeyIdempotentReplay(t *testing.T) {
 		Idempotency:           store,
 		DeveloperPortalClient: developerPortal,
 		Clock:                 store,
+		AuditSigner:           testAuditSigner,
 	}
 
 	input := RotateIntegrationKeyInput{
@@ -995,6 +1010,7 @@ func TestReplayWebhookIdempotentReplay(t *testing.T) {
 		Idempotency:          store,
 		WebhookManagerClient: webhooks,
 		Clock:                store,
+		AuditSigner:          testAuditSigner,
 	}
 
 	input := ReplayWebhookInput{
@@ -1059,6 +1075,7 @@ func TestCreateMigrationPlanAndRunAudits(t *testing.T) {
 		Idempotency:         store,
 		DataMigrationClient: migrations,
 		Clock:               store,
+		AuditSigner:         testAuditSigner,
 	}
 
 	_, err := svc.CreateMigrationPlan(context.Background(), "idem-mig-plan-1", CreateMigrationPlanInput{
@@ -1096,3 +1113,5 @@ func TestCreateMigrationPlanAndRunAudits(t *testing.T) {
 		t.Fatalf("unexpected backfill audit actions: first=%q second=%q", logs[0].Action, logs[1].Action)
 	}
 }
+
+var testAuditSigner = auditchain.HMACSigner{Key: []byte("control-plane-test-audit-key")}
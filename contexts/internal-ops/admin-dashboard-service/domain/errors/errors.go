@@ -11,4 +11,5 @@ var (
 	ErrIdempotencyConflict   = errors.New("idempotency key reused with different payload")
 	ErrDependencyUnavailable = errors.New("dependency unavailable")
 	ErrUnsupportedAction     = errors.New("unsupported action")
+	ErrAuditChainConflict    = errors.New("audit chain head moved")
 )
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"encoding/json"
+	"time"
+
+	"solomon/internal/platform/auditchain"
+)
+
+// AuditAnchorDomain prefixes the signed content of control-plane anchors.
+const AuditAnchorDomain = "control-plane-audit-anchor-v1"
+
+// AuditEntry is the signed content of one control-plane audit entry.
+type AuditEntry struct {
+	Sequence      int64
+	AuditID       string
+	ActorID       string
+	Action        string
+	TargetID      string
+	Justification string
+	OccurredAt    time.Time
+	SourceIP      string
+	CorrelationID string
+	PrevHash      string
+}
+
+// CanonicalAuditContent encodes an entry deterministically, with UTC
+// microsecond timestamps so it survives a database round trip.
+func CanonicalAuditContent(entry AuditEntry) ([]byte, error) {
+	return json.Marshal(struct {
+		Version       int    `json:"v"`
+		Sequence      int64  `json:"sequence"`
+		AuditID       string `json:"audit_id"`
+		ActorID       string `json:"actor_id"`
+		Action        string `json:"action"`
+		TargetID      string `json:"target_id"`
+		Justification string `json:"justification"`
+		OccurredAt    string `json:"occurred_at"`
+		SourceIP      string `json:"source_ip"`
+		CorrelationID string `json:"correlation_id"`
+		PrevHash      string `json:"prev_hash"`
+	}{
+		Version:       1,
+		Sequence:      entry.Sequence,
+		AuditID:       entry.AuditID,
+		ActorID:       entry.ActorID,
+		Action:        entry.Action,
+		TargetID:      entry.TargetID,
+		Justification: entry.Justification,
+		OccurredAt:    auditchain.CanonicalTime(entry.OccurredAt).Format(time.RFC3339Nano),
+		SourceIP:      entry.SourceIP,
+		CorrelationID: entry.CorrelationID,
+		PrevHash:      entry.PrevHash,
+	})
+}
@@ -1,12 +1,15 @@
 package admindashboardservice
 
 import (
+	"context"
+	"crypto/rand"
 	"time"
 
 	httpadapter "solomon/contexts/internal-ops/admin-dashboard-service/adapters/http"
 	"solomon/contexts/internal-ops/admin-dashboard-service/adapters/memory"
 	"solomon/contexts/internal-ops/admin-dashboard-service/application"
 	"solomon/contexts/internal-ops/admin-dashboard-service/ports"
+	"solomon/internal/platform/auditchain"
 )
 
 type Module struct {
@@ -38,6 +41,7 @@ type Dependencies struct {
 	IntegrationHubClient   ports.IntegrationHubClient
 	WebhookManagerClient   ports.WebhookManagerClient
 	DataMigrationClient    ports.DataMigrationClient
+	AuditSigner            auditchain.Signer
 	Clock                  ports.Clock
 	IdempotencyTTL         time.Duration
 }
@@ -69,6 +73,7 @@ func NewModule(deps Dependencies) Module {
 				IntegrationHubClient:   deps.IntegrationHubClient,
 				WebhookManagerClient:   deps.WebhookManagerClient,
 				DataMigrationClient:    deps.DataMigrationClient,
+				AuditSigner:            deps.AuditSigner,
 				Clock:                  deps.Clock,
 				IdempotencyTTL:         deps.IdempotencyTTL,
 			},
@@ -76,8 +81,17 @@ func NewModule(deps Dependencies) Module {
 	}
 }
 
+// AnchorAuditChain records the control-plane audit chain head.
+func (m Module) AnchorAuditChain(ctx context.Context) error {
+	_, _, err := m.Handler.Service.AnchorAuditChain(ctx)
+	return err
+}
+
+// NewInMemoryModule signs the audit chain with a random per-process key.
 func NewInMemoryModule() Module {
 	store := memory.NewStore()
+	key := make([]byte, 32)
+	_, _ = rand.Read(key)
 	module := NewModule(Dependencies{
 		Repository:             store,
 		Idempotency:            store,
@@ -102,6 +116,7 @@ func NewInMemoryModule() Module {
 		IntegrationHubClient:   store,
 		WebhookManagerClient:   store,
 		DataMigrationClient:    store,
+		AuditSigner:            auditchain.HMACSigner{Key: key},
 		Clock:                  store,
 		IdempotencyTTL:         7 * 24 * time.Hour,
 	})
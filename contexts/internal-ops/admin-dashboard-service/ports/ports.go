@@ -14,6 +14,22 @@ type AuditLog struct {
 	OccurredAt    time.Time
 	SourceIP      string
 	CorrelationID string
+
+	// Sequence orders the hash chain; PrevHash links to the entry before
+	// and Signature covers the entry's canonical content.
+	Sequence  int64
+	PrevHash  string
+	EntryHash string
+	Signature string
+}
+
+// AuditAnchor records the audit chain head at a point in time.
+type AuditAnchor struct {
+	AnchorID   string
+	Sequence   int64
+	EntryHash  string
+	Signature  string
+	AnchoredAt time.Time
 }
 
 type RoleGrantResult struct {
@@ -312,9 +328,18 @@ type SupportTicketSearchFilter struct {
 	Limit      int
 }
 
+// Repository stores the hash-chained control-plane audit log. AppendAuditLog
+// rejects an entry that does not directly follow the head with
+// ErrAuditChainConflict.
 type Repository interface {
 	AppendAuditLog(ctx context.Context, row AuditLog) error
 	ListRecentAuditLogs(ctx context.Context, limit int) ([]AuditLog, error)
+	GetAuditChainHead(ctx context.Context) (AuditLog, bool, error)
+	ListAuditChain(ctx context.Context, afterSequence int64, limit int) ([]AuditLog, error)
+
+	AppendAuditAnchor(ctx context.Context, anchor AuditAnchor) error
+	GetLatestAuditAnchor(ctx context.Context) (AuditAnchor, bool, error)
+	ListAuditAnchors(ctx context.Context) ([]AuditAnchor, error)
 }
 
 type IdempotencyRecord struct {
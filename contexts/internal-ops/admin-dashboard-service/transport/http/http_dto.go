@@ -11,6 +11,24 @@ type RecordAdminActionRequest struct {
 type RecordAdminActionResponse struct {
 	AuditID    string `json:"audit_id"`
 	OccurredAt string `json:"occurred_at"`
+	Sequence   int64  `json:"sequence"`
+	EntryHash  string `json:"entry_hash"`
+}
+
+type AuditChainBreakDTO struct {
+	Sequence int64  `json:"sequence"`
+	AuditID  string `json:"audit_id,omitempty"`
+	AnchorID string `json:"anchor_id,omitempty"`
+	Reason   string `json:"reason"`
+}
+
+type AuditChainVerificationResponse struct {
+	Valid          bool                `json:"valid"`
+	EntriesChecked int64               `json:"entries_checked"`
+	AnchorsChecked int                 `json:"anchors_checked"`
+	HeadSequence   int64               `json:"head_sequence"`
+	HeadHash       string              `json:"head_hash,omitempty"`
+	FirstBreak     *AuditChainBreakDTO `json:"first_break,omitempty"`
 }
 
 type GrantIdentityRoleRequest struct {
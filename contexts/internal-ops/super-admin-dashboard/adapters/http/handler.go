@@ -20,7 +20,15 @@ func (h Handler) StartImpersonationHandler(
 	idempotencyKey string,
 	req httptransport.StartImpersonationRequest,
 ) (httptransport.StartImpersonationResponse, error) {
-	result, err := h.Service.StartImpersonation(ctx, adminID, idempotencyKey, req.ImpersonatedUserID, req.Reason)
+	result, err := h.Service.StartImpersonation(
+		ctx,
+		adminID,
+		idempotencyKey,
+		req.ImpersonatedUserID,
+		req.Reason,
+		req.Scopes,
+		time.Duration(req.DurationMinutes)*time.Minute,
+	)
 	if err != nil {
 		return httptransport.StartImpersonationResponse{}, err
 	}
@@ -29,6 +37,7 @@ func (h Handler) StartImpersonationHandler(
 		UserID:          result.UserID,
 		AccessToken:     result.AccessToken,
 		TokenExpiresAt:  result.TokenExpiresAt.UTC().Format(time.RFC3339),
+		Scopes:          result.Scopes,
 		StartedAt:       result.StartedAt.UTC().Format(time.RFC3339),
 		Status:          result.Status,
 	}, nil
@@ -44,6 +53,7 @@ func (h Handler) EndImpersonationHandler(
 		return httptransport.EndImpersonationResponse{}, err
 	}
 	resp := httptransport.EndImpersonationResponse{Status: result.Status}
+	resp.ActivitySummary.ActionsLogged = result.ActionsLogged
 	if result.EndedAt != nil {
 		resp.EndedAt = result.EndedAt.UTC().Format(time.RFC3339)
 		resp.ActivitySummary.DurationMinutes = int(result.EndedAt.Sub(result.StartedAt).Minutes())
@@ -363,6 +373,9 @@ func (h Handler) AuditLogsHandler(
 			IPAddress:          item.IPAddress,
 			SignatureHash:      item.SignatureHash,
 			IsVerified:         item.IsVerified,
+			Sequence:           item.Sequence,
+			PrevHash:           item.PrevHash,
+			EntryHash:          item.EntryHash,
 		})
 	}
 	resp.Pagination.Cursor = next
@@ -370,22 +383,25 @@ func (h Handler) AuditLogsHandler(
 	return resp, nil
 }
 
-func (h Handler) ExportAuditLogsHandler(
-	ctx context.Context,
-	format string,
-	start time.Time,
-	end time.Time,
-	includeSignatures bool,
-) (httptransport.AuditLogExportResponse, error) {
-	result, err := h.Service.ExportAuditLogs(ctx, format, start, end, includeSignatures)
+func (h Handler) VerifyAuditChainHandler(ctx context.Context) (httptransport.AuditChainVerificationResponse, error) {
+	report, err := h.Service.VerifyAuditChain(ctx)
 	if err != nil {
-		return httptransport.AuditLogExportResponse{}, err
+		return httptransport.AuditChainVerificationResponse{}, err
 	}
-	return httptransport.AuditLogExportResponse{
-		ExportJobID:         result.ExportJobID,
-		Status:              result.Status,
-		FileURL:             result.FileURL,
-		CreatedAt:           result.CreatedAt.UTC().Format(time.RFC3339),
-		EstimatedCompletion: result.EstimatedCompletion.UTC().Format(time.RFC3339),
-	}, nil
-}
\ No newline at end of file
+	resp := httptransport.AuditChainVerificationResponse{
+		Valid:          report.Valid,
+		EntriesChecked: report.EntriesChecked,
+		AnchorsChecked: report.AnchorsChecked,
+		HeadSequence:   report.HeadSequence,
+		HeadHash:       report.HeadHash,
+	}
+	if report.FirstBreak != nil {
+		resp.FirstBreak = &httptransport.AuditChainBreakDTO{
+			Sequence: report.FirstBreak.Sequence,
+			AuditID:  report.FirstBreak.AuditID,
+			AnchorID: report.FirstBreak.AnchorID,
+			Reason:   report.FirstBreak.Reason,
+		}
+	}
+	return resp, nil
+}
@@ -2,7 +2,6 @@ package memory
 
 import (
 	"context"
-	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -15,6 +14,8 @@ import (
 
 	domainerrors "solomon/contexts/internal-ops/super-admin-dashboard/domain/errors"
 	"solomon/contexts/internal-ops/super-admin-dashboard/ports"
+
+	"github.com/google/uuid"
 )
 
 type Store struct {
@@ -28,6 +29,7 @@ type Store struct {
 	campaigns     map[string]campaignState
 	submissions   map[string]string
 	audits        []ports.AuditLog
+	anchors       []ports.AuditAnchor
 	idempotency   map[string]ports.IdempotencyRecord
 	sequence      uint64
 }
@@ -86,11 +88,12 @@ func NewStore() *Store {
 			"submission-1": "flagged",
 		},
 		audits:      make([]ports.AuditLog, 0),
+		anchors:     make([]ports.AuditAnchor, 0),
 		idempotency: make(map[string]ports.IdempotencyRecord),
 	}
 }
 
-func (s *Store) StartImpersonation(ctx context.Context, adminID string, userID string, reason string) (ports.ImpersonationSession, error) {
+func (s *Store) StartImpersonation(ctx context.Context, adminID string, userID string, reason string, scopes []string, ttl time.Duration) (ports.ImpersonationSession, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -100,25 +103,24 @@ func (s *Store) StartImpersonation(ctx context.Context, adminID string, userID s
 	if ban, ok := s.bans[userID]; ok && ban.Status == "active" {
 		return ports.ImpersonationSession{}, domainerrors.ErrForbidden
 	}
+	now := s.Now()
 	for _, item := range s.impersonation {
-		if item.AdminID == adminID && item.Status == "active" {
+		if item.AdminID == adminID && item.Status == "active" && now.Before(item.TokenExpiresAt) {
 			return ports.ImpersonationSession{}, domainerrors.ErrImpersonationAlreadyActive
 		}
 	}
-	now := s.Now()
 	impersonationID := s.nextID("imp")
 	session := ports.ImpersonationSession{
 		ImpersonationID: impersonationID,
 		UserID:          userID,
-		AccessToken:     "imp-token-" + impersonationID,
-		TokenExpiresAt:  now.Add(2 * time.Hour),
+		TokenExpiresAt:  now.Add(ttl),
 		StartedAt:       now,
 		Status:          "active",
 		Reason:          reason,
 		AdminID:         adminID,
+		Scopes:          append([]string(nil), scopes...),
 	}
 	s.impersonation[impersonationID] = session
-	s.appendAudit(adminID, "impersonation.start", "user", userID, nil, map[string]any{"impersonation_id": impersonationID}, reason, "")
 	return session, nil
 }
 
@@ -137,10 +139,45 @@ func (s *Store) EndImpersonation(ctx context.Context, impersonationID string) (p
 	session.Status = "ended"
 	session.EndedAt = &now
 	s.impersonation[impersonationID] = session
-	s.appendAudit(session.AdminID, "impersonation.end", "user", session.UserID, nil, map[string]any{"impersonation_id": impersonationID}, "impersonation ended", "")
 	return session, nil
 }
 
+func (s *Store) GetImpersonation(ctx context.Context, impersonationID string) (ports.ImpersonationSession, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.impersonation[impersonationID]
+	if !ok {
+		return ports.ImpersonationSession{}, domainerrors.ErrImpersonationNotFound
+	}
+	session.Scopes = append([]string(nil), session.Scopes...)
+	return session, nil
+}
+
+func (s *Store) RecordImpersonationAction(ctx context.Context, impersonationID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.impersonation[impersonationID]
+	if !ok {
+		return domainerrors.ErrImpersonationNotFound
+	}
+	session.ActionsLogged++
+	s.impersonation[impersonationID] = session
+	return nil
+}
+
+func (s *Store) GetUser(ctx context.Context, userID string) (ports.AdminUser, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	user, ok := s.users[userID]
+	if !ok {
+		return ports.AdminUser{}, domainerrors.ErrUserNotFound
+	}
+	return user, nil
+}
+
 func (s *Store) AdjustWallet(ctx context.Context, adminID string, userID string, amount float64, adjustmentType string, reason string) (ports.WalletAdjustment, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -161,23 +198,34 @@ func (s *Store) AdjustWallet(ctx context.Context, adminID string, userID string,
 	default:
 		return ports.WalletAdjustment{}, domainerrors.ErrInvalidRequest
 	}
-	now := s.Now()
-	adjustment := ports.WalletAdjustment{
-		AdjustmentID:  s.nextID("adj"),
+	s.balances[userID] = after
+	return s.appendWalletAdjustment(ports.WalletAdjustment{
 		UserID:        userID,
 		Amount:        amount,
 		Type:          strings.ToLower(adjustmentType),
 		Reason:        reason,
 		BalanceBefore: before,
 		BalanceAfter:  after,
-		AdjustedAt:    now,
-		AuditLogID:    s.nextID("audit"),
 		AdminID:       adminID,
+	}), nil
+}
+
+func (s *Store) RecordWalletAdjustment(ctx context.Context, adjustment ports.WalletAdjustment) (ports.WalletAdjustment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[adjustment.UserID]; !ok {
+		return ports.WalletAdjustment{}, domainerrors.ErrUserNotFound
 	}
-	s.balances[userID] = after
+	return s.appendWalletAdjustment(adjustment), nil
+}
+
+func (s *Store) appendWalletAdjustment(adjustment ports.WalletAdjustment) ports.WalletAdjustment {
+	adjustment.AdjustmentID = s.nextID("adj")
+	adjustment.AdjustedAt = s.Now()
+	adjustment.AuditLogID = newAuditID()
 	s.wallet = append([]ports.WalletAdjustment{adjustment}, s.wallet...)
-	s.appendAudit(adminID, "wallet.adjust", "user", userID, map[string]any{"balance": before}, map[string]any{"balance": after, "amount": amount}, reason, adjustment.AuditLogID)
-	return adjustment, nil
+	return adjustment
 }
 
 func (s *Store) ListWalletHistory(ctx context.Context, userID string, cursor string, limit int) ([]ports.WalletAdjustment, string, error) {
@@ -225,7 +273,7 @@ func (s *Store) BanUser(ctx context.Context, adminID string, userID string, banT
 		BanType:            strings.ToLower(banType),
 		BannedAt:           now,
 		AllSessionsRevoked: true,
-		AuditLogID:         s.nextID("audit"),
+		AuditLogID:         newAuditID(),
 		Status:             "active",
 		Reason:             reason,
 	}
@@ -240,7 +288,6 @@ func (s *Store) BanUser(ctx context.Context, adminID string, userID string, banT
 	user := s.users[userID]
 	user.Status = "banned"
 	s.users[userID] = user
-	s.appendAudit(adminID, "user.ban", "user", userID, map[string]any{"status": "active"}, map[string]any{"status": "banned", "ban_type": ban.BanType}, reason, ban.AuditLogID)
 	return ban, nil
 }
 
@@ -261,7 +308,6 @@ func (s *Store) UnbanUser(ctx context.Context, adminID string, userID string, re
 	user := s.users[userID]
 	user.Status = "active"
 	s.users[userID] = user
-	s.appendAudit(adminID, "user.unban", "user", userID, map[string]any{"status": "banned"}, map[string]any{"status": "active"}, reason, s.nextID("audit"))
 	return ban, nil
 }
 
@@ -318,7 +364,6 @@ func (s *Store) CreateBulkActionJob(ctx context.Context, adminID string, userIDs
 		CreatedAt:               now,
 		EstimatedCompletionTime: now.Add(2 * time.Minute),
 	}
-	s.appendAudit(adminID, "users.bulk_action", "user", "*", nil, map[string]any{"job_id": job.JobID, "action": action, "user_count": len(userIDs)}, "bulk action queued", s.nextID("audit"))
 	return job, nil
 }
 
@@ -336,8 +381,7 @@ func (s *Store) PauseCampaign(ctx context.Context, adminID string, campaignID st
 	now := s.Now()
 	state.Status = "paused"
 	s.campaigns[campaignID] = state
-	result := ports.CampaignPauseResult{CampaignID: campaignID, Status: state.Status, PausedAt: now, AuditLogID: s.nextID("audit")}
-	s.appendAudit(adminID, "campaign.pause", "campaign", campaignID, map[string]any{"status": "active"}, map[string]any{"status": "paused"}, reason, result.AuditLogID)
+	result := ports.CampaignPauseResult{CampaignID: campaignID, Status: state.Status, PausedAt: now, AuditLogID: newAuditID()}
 	return result, nil
 }
 
@@ -360,12 +404,11 @@ func (s *Store) AdjustCampaign(ctx context.Context, adminID string, campaignID s
 		OldRatePer1kViews: state.RatePer1kView,
 		NewRatePer1kViews: newRate,
 		AdjustedAt:        now,
-		AuditLogID:        s.nextID("audit"),
+		AuditLogID:        newAuditID(),
 	}
 	state.Budget = newBudget
 	state.RatePer1kView = newRate
 	s.campaigns[campaignID] = state
-	s.appendAudit(adminID, "campaign.adjust", "campaign", campaignID, map[string]any{"budget": result.OldBudget, "rate": result.OldRatePer1kViews}, map[string]any{"budget": newBudget, "rate": newRate}, reason, result.AuditLogID)
 	return result, nil
 }
 
@@ -384,9 +427,8 @@ func (s *Store) OverrideSubmission(ctx context.Context, adminID string, submissi
 		OldStatus:    oldStatus,
 		NewStatus:    newStatus,
 		OverriddenAt: now,
-		AuditLogID:   s.nextID("audit"),
+		AuditLogID:   newAuditID(),
 	}
-	s.appendAudit(adminID, "submission.override", "submission", submissionID, map[string]any{"status": oldStatus}, map[string]any{"status": newStatus}, reason, result.AuditLogID)
 	return result, nil
 }
 
@@ -416,7 +458,6 @@ func (s *Store) ToggleFeatureFlag(ctx context.Context, adminID string, flagKey s
 	flag.UpdatedBy = adminID
 	flag.UpdatedAt = s.Now()
 	s.flags[flagKey] = flag
-	s.appendAudit(adminID, "feature_flag.toggle", "feature_flag", flagKey, map[string]any{"enabled": oldEnabled}, map[string]any{"enabled": enabled}, reason, s.nextID("audit"))
 	return flag, oldEnabled, nil
 }
 
@@ -440,6 +481,27 @@ func (s *Store) GetAnalyticsDashboard(ctx context.Context, start time.Time, end
 	}, nil
 }
 
+func (s *Store) GetAuditChainHead(ctx context.Context) (ports.AuditLog, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.audits) == 0 {
+		return ports.AuditLog{}, false, nil
+	}
+	return cloneAudit(s.audits[len(s.audits)-1]), true, nil
+}
+
+func (s *Store) AppendAuditEntry(ctx context.Context, entry ports.AuditLog) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry.Sequence != int64(len(s.audits))+1 {
+		return domainerrors.ErrAuditChainConflict
+	}
+	s.audits = append(s.audits, cloneAudit(entry))
+	return nil
+}
+
 func (s *Store) ListAuditLogs(ctx context.Context, adminID string, actionType string, cursor string, pageSize int) ([]ports.AuditLog, string, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -447,14 +509,15 @@ func (s *Store) ListAuditLogs(ctx context.Context, adminID string, actionType st
 	adminID = strings.TrimSpace(adminID)
 	actionType = strings.TrimSpace(actionType)
 	items := make([]ports.AuditLog, 0, len(s.audits))
-	for _, item := range s.audits {
+	for i := len(s.audits) - 1; i >= 0; i-- {
+		item := s.audits[i]
 		if adminID != "" && item.AdminID != adminID {
 			continue
 		}
 		if actionType != "" && item.ActionType != actionType {
 			continue
 		}
-		items = append(items, item)
+		items = append(items, cloneAudit(item))
 	}
 	start := decodeCursor(cursor)
 	if start < 0 || start > len(items) {
@@ -468,18 +531,49 @@ func (s *Store) ListAuditLogs(ctx context.Context, adminID string, actionType st
 	if end < len(items) {
 		next = encodeCursor(end)
 	}
-	return append([]ports.AuditLog(nil), items[start:end]...), next, nil
+	return items[start:end], next, nil
 }
 
-func (s *Store) CreateAuditExport(ctx context.Context, format string, start time.Time, end time.Time, includeSignatures bool) (ports.AuditExport, error) {
-	now := s.Now()
-	return ports.AuditExport{
-		ExportJobID:         s.nextID("export"),
-		Status:              "queued",
-		FileURL:             "https://exports.local/audit-logs.csv",
-		CreatedAt:           now,
-		EstimatedCompletion: now.Add(1 * time.Minute),
-	}, nil
+func (s *Store) ListAuditChain(ctx context.Context, afterSequence int64, limit int) ([]ports.AuditLog, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	items := make([]ports.AuditLog, 0, limit)
+	for _, item := range s.audits {
+		if item.Sequence <= afterSequence {
+			continue
+		}
+		items = append(items, cloneAudit(item))
+		if len(items) >= limit {
+			break
+		}
+	}
+	return items, nil
+}
+
+func (s *Store) AppendAuditAnchor(ctx context.Context, anchor ports.AuditAnchor) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.anchors = append(s.anchors, anchor)
+	return nil
+}
+
+func (s *Store) GetLatestAuditAnchor(ctx context.Context) (ports.AuditAnchor, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.anchors) == 0 {
+		return ports.AuditAnchor{}, false, nil
+	}
+	return s.anchors[len(s.anchors)-1], true, nil
+}
+
+func (s *Store) ListAuditAnchors(ctx context.Context) ([]ports.AuditAnchor, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]ports.AuditAnchor(nil), s.anchors...), nil
 }
 
 func (s *Store) Get(ctx context.Context, key string, now time.Time) (ports.IdempotencyRecord, bool, error) {
@@ -511,62 +605,29 @@ func (s *Store) Put(ctx context.Context, record ports.IdempotencyRecord) error {
 	return nil
 }
 
+// NewID returns globally unique IDs: audit entries and anchors may outlive
+// this store in a persistent audit chain.
 func (s *Store) NewID(ctx context.Context) (string, error) {
-	return s.nextID("m20"), nil
+	return "m20-" + uuid.NewString(), nil
 }
 
 func (s *Store) Now() time.Time {
 	return time.Now().UTC()
 }
 
+func newAuditID() string {
+	return "audit-" + uuid.NewString()
+}
+
 func (s *Store) nextID(prefix string) string {
 	id := atomic.AddUint64(&s.sequence, 1)
 	return fmt.Sprintf("%s-%d", prefix, id)
 }
 
-func (s *Store) appendAudit(
-	adminID string,
-	actionType string,
-	targetResourceType string,
-	targetResourceID string,
-	oldValue map[string]any,
-	newValue map[string]any,
-	reason string,
-	auditID string,
-) {
-	if strings.TrimSpace(auditID) == "" {
-		auditID = s.nextID("audit")
-	}
-	now := s.Now()
-	entry := ports.AuditLog{
-		AuditID:            auditID,
-		AdminID:            adminID,
-		ActionType:         actionType,
-		TargetResourceID:   targetResourceID,
-		TargetResourceType: targetResourceType,
-		OldValue:           cloneMap(oldValue),
-		NewValue:           cloneMap(newValue),
-		Reason:             reason,
-		PerformedAt:        now,
-		IPAddress:          "127.0.0.1",
-		SignatureHash:      signatureHash(entrySeed(actionType, targetResourceType, targetResourceID, now)),
-		IsVerified:         true,
-	}
-	s.audits = append([]ports.AuditLog{entry}, s.audits...)
-}
-
-func entrySeed(actionType string, targetType string, targetID string, now time.Time) string {
-	return actionType + "|" + targetType + "|" + targetID + "|" + now.UTC().Format(time.RFC3339)
-}
-
-func signatureHash(seed string) string {
-	sum := sha256String(seed)
-	return "sig_" + sum[:16]
-}
-
-func sha256String(v string) string {
-	sum := sha256.Sum256([]byte(v))
-	return fmt.Sprintf("%x", sum)
+func cloneAudit(entry ports.AuditLog) ports.AuditLog {
+	entry.OldValue = cloneMap(entry.OldValue)
+	entry.NewValue = cloneMap(entry.NewValue)
+	return entry
 }
 
 func cloneMap(input map[string]any) map[string]any {
@@ -607,3 +668,4 @@ var _ ports.Repository = (*Store)(nil)
 var _ ports.IdempotencyStore = (*Store)(nil)
 var _ ports.IDGenerator = (*Store)(nil)
 var _ ports.Clock = (*Store)(nil)
+var _ ports.AuditChainStore = (*Store)(nil)
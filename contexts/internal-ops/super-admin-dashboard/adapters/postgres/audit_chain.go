@@ -0,0 +1,264 @@
+package postgresadapter
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	domainerrors "solomon/contexts/internal-ops/super-admin-dashboard/domain/errors"
+	"solomon/contexts/internal-ops/super-admin-dashboard/ports"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// AuditChainRepository persists the hash-chained admin audit log and its
+// head anchors. The sequence primary key serialises concurrent appends.
+type AuditChainRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditChainRepository builds the GORM-backed audit chain adapter.
+func NewAuditChainRepository(db *gorm.DB) *AuditChainRepository {
+	return &AuditChainRepository{db: db}
+}
+
+func (r *AuditChainRepository) GetAuditChainHead(ctx context.Context) (ports.AuditLog, bool, error) {
+	var rows []auditLogModel
+	if err := r.db.WithContext(ctx).
+		Order("sequence DESC").
+		Limit(1).
+		Find(&rows).Error; err != nil {
+		return ports.AuditLog{}, false, err
+	}
+	if len(rows) == 0 {
+		return ports.AuditLog{}, false, nil
+	}
+	entry, err := rows[0].toPort()
+	return entry, err == nil, err
+}
+
+func (r *AuditChainRepository) AppendAuditEntry(ctx context.Context, entry ports.AuditLog) error {
+	row, err := auditLogFromPort(entry)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Create(&row).Error; err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domainerrors.ErrAuditChainConflict
+		}
+		return err
+	}
+	return nil
+}
+
+// ListAuditLogs pages newest first. The cursor is the last sequence served.
+func (r *AuditChainRepository) ListAuditLogs(ctx context.Context, adminID string, actionType string, cursor string, pageSize int) ([]ports.AuditLog, string, error) {
+	query := r.db.WithContext(ctx).Model(&auditLogModel{})
+	if adminID = strings.TrimSpace(adminID); adminID != "" {
+		query = query.Where("admin_id = ?", adminID)
+	}
+	if actionType = strings.TrimSpace(actionType); actionType != "" {
+		query = query.Where("action_type = ?", actionType)
+	}
+	if before, ok := decodeSequenceCursor(cursor); ok {
+		query = query.Where("sequence < ?", before)
+	}
+	var rows []auditLogModel
+	if err := query.Order("sequence DESC").Limit(pageSize + 1).Find(&rows).Error; err != nil {
+		return nil, "", err
+	}
+	next := ""
+	if len(rows) > pageSize {
+		rows = rows[:pageSize]
+		next = encodeSequenceCursor(rows[len(rows)-1].Sequence)
+	}
+	items, err := auditLogsToPorts(rows)
+	return items, next, err
+}
+
+func (r *AuditChainRepository) ListAuditChain(ctx context.Context, afterSequence int64, limit int) ([]ports.AuditLog, error) {
+	var rows []auditLogModel
+	if err := r.db.WithContext(ctx).
+		Where("sequence > ?", afterSequence).
+		Order("sequence ASC").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return auditLogsToPorts(rows)
+}
+
+func (r *AuditChainRepository) AppendAuditAnchor(ctx context.Context, anchor ports.AuditAnchor) error {
+	return r.db.WithContext(ctx).Create(&auditAnchorModel{
+		AnchorID:   anchor.AnchorID,
+		Sequence:   anchor.Sequence,
+		EntryHash:  anchor.EntryHash,
+		Signature:  anchor.Signature,
+		AnchoredAt: anchor.AnchoredAt.UTC(),
+	}).Error
+}
+
+func (r *AuditChainRepository) GetLatestAuditAnchor(ctx context.Context) (ports.AuditAnchor, bool, error) {
+	var rows []auditAnchorModel
+	if err := r.db.WithContext(ctx).
+		Order("sequence DESC, anchored_at DESC").
+		Limit(1).
+		Find(&rows).Error; err != nil {
+		return ports.AuditAnchor{}, false, err
+	}
+	if len(rows) == 0 {
+		return ports.AuditAnchor{}, false, nil
+	}
+	return rows[0].toPort(), true, nil
+}
+
+func (r *AuditChainRepository) ListAuditAnchors(ctx context.Context) ([]ports.AuditAnchor, error) {
+	var rows []auditAnchorModel
+	if err := r.db.WithContext(ctx).
+		Order("sequence ASC, anchored_at ASC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	items := make([]ports.AuditAnchor, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, row.toPort())
+	}
+	return items, nil
+}
+
+type auditLogModel struct {
+	Sequence           int64     `gorm:"column:sequence;primaryKey;autoIncrement:false"`
+	AuditID            string    `gorm:"column:audit_id"`
+	AdminID            string    `gorm:"column:admin_id"`
+	ActionType         string    `gorm:"column:action_type"`
+	TargetResourceType string    `gorm:"column:target_resource_type"`
+	TargetResourceID   string    `gorm:"column:target_resource_id"`
+	OldValue           []byte    `gorm:"column:old_value"`
+	NewValue           []byte    `gorm:"column:new_value"`
+	Reason             string    `gorm:"column:reason"`
+	PerformedAt        time.Time `gorm:"column:performed_at"`
+	IPAddress          string    `gorm:"column:ip_address"`
+	PrevHash           string    `gorm:"column:prev_hash"`
+	EntryHash          string    `gorm:"column:entry_hash"`
+	Signature          string    `gorm:"column:signature"`
+}
+
+func (auditLogModel) TableName() string {
+	return "super_admin_audit_log"
+}
+
+func auditLogFromPort(entry ports.AuditLog) (auditLogModel, error) {
+	oldValue, err := json.Marshal(nonNilMap(entry.OldValue))
+	if err != nil {
+		return auditLogModel{}, err
+	}
+	newValue, err := json.Marshal(nonNilMap(entry.NewValue))
+	if err != nil {
+		return auditLogModel{}, err
+	}
+	return auditLogModel{
+		Sequence:           entry.Sequence,
+		AuditID:            entry.AuditID,
+		AdminID:            entry.AdminID,
+		ActionType:         entry.ActionType,
+		TargetResourceType: entry.TargetResourceType,
+		TargetResourceID:   entry.TargetResourceID,
+		OldValue:           oldValue,
+		NewValue:           newValue,
+		Reason:             entry.Reason,
+		PerformedAt:        entry.PerformedAt.UTC(),
+		IPAddress:          entry.IPAddress,
+		PrevHash:           entry.PrevHash,
+		EntryHash:          entry.EntryHash,
+		Signature:          entry.SignatureHash,
+	}, nil
+}
+
+func (m auditLogModel) toPort() (ports.AuditLog, error) {
+	entry := ports.AuditLog{
+		Sequence:           m.Sequence,
+		AuditID:            m.AuditID,
+		AdminID:            m.AdminID,
+		ActionType:         m.ActionType,
+		TargetResourceType: m.TargetResourceType,
+		TargetResourceID:   m.TargetResourceID,
+		Reason:             m.Reason,
+		PerformedAt:        m.PerformedAt.UTC(),
+		IPAddress:          m.IPAddress,
+		PrevHash:           m.PrevHash,
+		EntryHash:          m.EntryHash,
+		SignatureHash:      m.Signature,
+	}
+	if err := json.Unmarshal(m.OldValue, &entry.OldValue); err != nil {
+		return ports.AuditLog{}, err
+	}
+	if err := json.Unmarshal(m.NewValue, &entry.NewValue); err != nil {
+		return ports.AuditLog{}, err
+	}
+	return entry, nil
+}
+
+func auditLogsToPorts(rows []auditLogModel) ([]ports.AuditLog, error) {
+	items := make([]ports.AuditLog, 0, len(rows))
+	for _, row := range rows {
+		entry, err := row.toPort()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, entry)
+	}
+	return items, nil
+}
+
+type auditAnchorModel struct {
+	AnchorID   string    `gorm:"column:anchor_id;primaryKey"`
+	Sequence   int64     `gorm:"column:sequence"`
+	EntryHash  string    `gorm:"column:entry_hash"`
+	Signature  string    `gorm:"column:signature"`
+	AnchoredAt time.Time `gorm:"column:anchored_at"`
+}
+
+func (auditAnchorModel) TableName() string {
+	return "super_admin_audit_anchors"
+}
+
+func (m auditAnchorModel) toPort() ports.AuditAnchor {
+	return ports.AuditAnchor{
+		AnchorID:   m.AnchorID,
+		Sequence:   m.Sequence,
+		EntryHash:  m.EntryHash,
+		Signature:  m.Signature,
+		AnchoredAt: m.AnchoredAt.UTC(),
+	}
+}
+
+func nonNilMap(value map[string]any) map[string]any {
+	if value == nil {
+		return map[string]any{}
+	}
+	return value
+}
+
+func encodeSequenceCursor(sequence int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(sequence, 10)))
+}
+
+func decodeSequenceCursor(cursor string) (int64, bool) {
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimSpace(cursor))
+	if err != nil || len(raw) == 0 {
+		return 0, false
+	}
+	sequence, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil || sequence <= 0 {
+		return 0, false
+	}
+	return sequence, true
+}
+
+var _ ports.AuditChainStore = (*AuditChainRepository)(nil)
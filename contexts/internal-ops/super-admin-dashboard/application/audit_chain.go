@@ -0,0 +1,186 @@
+package application
+
+import (
+	"context"
+
+	domainerrors "solomon/contexts/internal-ops/super-admin-dashboard/domain/errors"
+	"solomon/contexts/internal-ops/super-admin-dashboard/domain/services"
+	"solomon/contexts/internal-ops/super-admin-dashboard/ports"
+	"solomon/internal/platform/auditchain"
+)
+
+// recordAudit seals entry onto the head of the chain. Concurrent appends
+// race on the next sequence; the loser re-reads the head and tries again.
+func (s Service) recordAudit(ctx context.Context, entry ports.AuditLog) (ports.AuditLog, error) {
+	if entry.AuditID == "" {
+		auditID, err := s.IDGenerator.NewID(ctx)
+		if err != nil {
+			return ports.AuditLog{}, err
+		}
+		entry.AuditID = auditID
+	}
+	entry.PerformedAt = auditchain.CanonicalTime(s.now())
+	entry.OldValue = cloneAuditValue(entry.OldValue)
+	entry.NewValue = cloneAuditValue(entry.NewValue)
+
+	chain := s.auditChain()
+	err := auditchain.Append(ctx,
+		func(ctx context.Context) (int64, string, bool, error) {
+			head, found, err := s.AuditChain.GetAuditChainHead(ctx)
+			return head.Sequence, head.EntryHash, found, err
+		},
+		func(ctx context.Context, sequence int64, prevHash string) error {
+			entry.Sequence = sequence
+			entry.PrevHash = prevHash
+			content, err := services.CanonicalAuditContent(toAuditEntry(entry))
+			if err != nil {
+				return err
+			}
+			entry.EntryHash, entry.SignatureHash, err = chain.Seal(content)
+			if err != nil {
+				return err
+			}
+			return s.AuditChain.AppendAuditEntry(ctx, entry)
+		},
+		domainerrors.ErrAuditChainConflict,
+	)
+	if err != nil {
+		return ports.AuditLog{}, err
+	}
+	entry.IsVerified = true
+	return entry, nil
+}
+
+// audited appends the audit entry for a mutation that has already been
+// applied. A failed append is logged loudly; the mutation is not undone.
+func (s Service) audited(ctx context.Context, entry ports.AuditLog) error {
+	if _, err := s.recordAudit(ctx, entry); err != nil {
+		ResolveLogger(s.Logger).Error("super admin audit append failed",
+			"event", "super_admin_audit_append_failed",
+			"module", "internal-ops/super-admin-dashboard",
+			"layer", "application",
+			"action_type", entry.ActionType,
+			"target_resource_id", entry.TargetResourceID,
+			"error", err.Error(),
+		)
+		return err
+	}
+	return nil
+}
+
+func (s Service) ListAuditLogs(ctx context.Context, adminID string, actionType string, cursor string, pageSize int) ([]ports.AuditLog, string, error) {
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+	items, next, err := s.AuditChain.ListAuditLogs(ctx, adminID, actionType, cursor, pageSize)
+	if err != nil {
+		return nil, "", err
+	}
+	chain := s.auditChain()
+	for i := range items {
+		content, _ := services.CanonicalAuditContent(toAuditEntry(items[i]))
+		items[i].IsVerified = chain.CheckEntry(content, items[i].EntryHash, items[i].SignatureHash) == ""
+	}
+	return items, next, nil
+}
+
+// VerifyAuditChain walks the chain from genesis and checks every anchor
+// against the entry it recorded.
+func (s Service) VerifyAuditChain(ctx context.Context) (auditchain.Report, error) {
+	return s.auditChain().Verify(ctx, auditChainSource{store: s.AuditChain})
+}
+
+// AnchorAuditChain records the current chain head in the anchor table. It
+// is a no-op when the chain is empty or the head is already anchored.
+func (s Service) AnchorAuditChain(ctx context.Context) (ports.AuditAnchor, bool, error) {
+	head, found, err := s.AuditChain.GetAuditChainHead(ctx)
+	if err != nil || !found {
+		return ports.AuditAnchor{}, false, err
+	}
+	latest, anchoredBefore, err := s.AuditChain.GetLatestAuditAnchor(ctx)
+	if err != nil {
+		return ports.AuditAnchor{}, false, err
+	}
+	if anchoredBefore && latest.Sequence >= head.Sequence {
+		return latest, false, nil
+	}
+	anchorID, err := s.IDGenerator.NewID(ctx)
+	if err != nil {
+		return ports.AuditAnchor{}, false, err
+	}
+	anchor := ports.AuditAnchor(s.auditChain().SignAnchor(anchorID, head.Sequence, head.EntryHash, s.now()))
+	if err := s.AuditChain.AppendAuditAnchor(ctx, anchor); err != nil {
+		return ports.AuditAnchor{}, false, err
+	}
+	ResolveLogger(s.Logger).Info("super admin audit chain anchored",
+		"event", "super_admin_audit_chain_anchored",
+		"module", "internal-ops/super-admin-dashboard",
+		"layer", "application",
+		"sequence", anchor.Sequence,
+		"entry_hash", anchor.EntryHash,
+	)
+	return anchor, true, nil
+}
+
+func (s Service) auditChain() auditchain.Chain {
+	return auditchain.Chain{Signer: s.AuditSigner, AnchorDomain: services.AuditAnchorDomain}
+}
+
+// auditChainSource reads the stored chain for verification.
+type auditChainSource struct {
+	store ports.AuditChainStore
+}
+
+func (a auditChainSource) ListEntries(ctx context.Context, afterSequence int64, limit int) ([]auditchain.Entry, error) {
+	rows, err := a.store.ListAuditChain(ctx, afterSequence, limit)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]auditchain.Entry, 0, len(rows))
+	for _, row := range rows {
+		// An entry that cannot be encoded keeps nil content and is
+		// reported as a hash mismatch.
+		content, _ := services.CanonicalAuditContent(toAuditEntry(row))
+		entries = append(entries, auditchain.Entry{
+			Sequence:  row.Sequence,
+			AuditID:   row.AuditID,
+			PrevHash:  row.PrevHash,
+			Content:   content,
+			EntryHash: row.EntryHash,
+			Signature: row.SignatureHash,
+		})
+	}
+	return entries, nil
+}
+
+func (a auditChainSource) ListAnchors(ctx context.Context) ([]auditchain.Anchor, error) {
+	anchors, err := a.store.ListAuditAnchors(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]auditchain.Anchor, 0, len(anchors))
+	for _, anchor := range anchors {
+		out = append(out, auditchain.Anchor(anchor))
+	}
+	return out, nil
+}
+
+func toAuditEntry(row ports.AuditLog) services.AuditEntry {
+	return services.AuditEntry{
+		Sequence:           row.Sequence,
+		AuditID:            row.AuditID,
+		AdminID:            row.AdminID,
+		ActionType:         row.ActionType,
+		TargetResourceType: row.TargetResourceType,
+		TargetResourceID:   row.TargetResourceID,
+		OldValue:           row.OldValue,
+		NewValue:           row.NewValue,
+		Reason:             row.Reason,
+		PerformedAt:        row.PerformedAt,
+		IPAddress:          row.IPAddress,
+		PrevHash:           row.PrevHash,
+	}
+}
@@ -0,0 +1,109 @@
+package application
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	domainerrors "solomon/contexts/internal-ops/super-admin-dashboard/domain/errors"
+	"solomon/contexts/internal-ops/super-admin-dashboard/domain/services"
+	"solomon/contexts/internal-ops/super-admin-dashboard/ports"
+)
+
+// AuditExportColumns are the columns of an audit log export, in the order
+// AuditExportRow returns them. The chain columns at the end are only
+// exported when signatures are requested.
+var AuditExportColumns = []string{
+	"sequence",
+	"audit_id",
+	"admin_id",
+	"action_type",
+	"target_resource_type",
+	"target_resource_id",
+	"reason",
+	"performed_at",
+	"ip_address",
+	"is_verified",
+	"prev_hash",
+	"entry_hash",
+	"signature_hash",
+}
+
+// AuditExportSignatureColumns is the number of trailing chain columns in
+// AuditExportColumns.
+const AuditExportSignatureColumns = 3
+
+const auditExportBatch = 500
+
+// AuthorizeAuditExport validates the performed_at window of an export. A
+// zero start or end leaves that side open.
+func (s Service) AuthorizeAuditExport(_ context.Context, start time.Time, end time.Time) error {
+	if !start.IsZero() && !end.IsZero() && end.Before(start) {
+		return domainerrors.ErrUnprocessable
+	}
+	return nil
+}
+
+// StreamAuditExport walks the chain in sequence order and calls fn with up
+// to limit entries performed inside the window, each checked against its
+// own hash and signature.
+func (s Service) StreamAuditExport(
+	ctx context.Context,
+	start time.Time,
+	end time.Time,
+	limit int,
+	fn func(ports.AuditLog) error,
+) error {
+	if limit <= 0 {
+		return domainerrors.ErrInvalidRequest
+	}
+	if err := s.AuthorizeAuditExport(ctx, start, end); err != nil {
+		return err
+	}
+	chain := s.auditChain()
+	emitted := 0
+	var after int64
+	for {
+		batch, err := s.AuditChain.ListAuditChain(ctx, after, auditExportBatch)
+		if err != nil {
+			return err
+		}
+		for _, item := range batch {
+			if (!start.IsZero() && item.PerformedAt.Before(start)) || (!end.IsZero() && item.PerformedAt.After(end)) {
+				continue
+			}
+			content, _ := services.CanonicalAuditContent(toAuditEntry(item))
+			item.IsVerified = chain.CheckEntry(content, item.EntryHash, item.SignatureHash) == ""
+			if err := fn(item); err != nil {
+				return err
+			}
+			emitted++
+			if emitted >= limit {
+				return nil
+			}
+		}
+		if len(batch) < auditExportBatch {
+			return nil
+		}
+		after = batch[len(batch)-1].Sequence
+	}
+}
+
+// AuditExportRow renders an entry as a row aligned with AuditExportColumns.
+func AuditExportRow(item ports.AuditLog) []string {
+	return []string{
+		strconv.FormatInt(item.Sequence, 10),
+		item.AuditID,
+		item.AdminID,
+		item.ActionType,
+		item.TargetResourceType,
+		item.TargetResourceID,
+		item.Reason,
+		item.PerformedAt.UTC().Format(time.RFC3339Nano),
+		item.IPAddress,
+		strconv.FormatBool(item.IsVerified),
+		item.PrevHash,
+		item.EntryHash,
+		item.SignatureHash,
+	}
+}
@@ -0,0 +1,71 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	domainerrors "solomon/contexts/internal-ops/super-admin-dashboard/domain/errors"
+	"solomon/contexts/internal-ops/super-admin-dashboard/domain/services"
+	"solomon/contexts/internal-ops/super-admin-dashboard/ports"
+)
+
+// ImpersonatedRequest describes one API request made with an impersonation
+// token, for the audit log.
+type ImpersonatedRequest struct {
+	Method    string
+	Route     string
+	Path      string
+	Status    int
+	Allowed   bool
+	IPAddress string
+}
+
+// AuthenticateImpersonation verifies an impersonation token and checks that
+// its session is still active, so ending a session revokes its token.
+func (s Service) AuthenticateImpersonation(ctx context.Context, token string) (services.ImpersonationClaims, error) {
+	claims, err := s.ImpersonationTokens.Parse(token, s.now())
+	if err != nil {
+		return services.ImpersonationClaims{}, err
+	}
+	session, err := s.Repo.GetImpersonation(ctx, claims.ImpersonationID)
+	if errors.Is(err, domainerrors.ErrImpersonationNotFound) {
+		return services.ImpersonationClaims{}, domainerrors.ErrImpersonationTokenInvalid
+	}
+	if err != nil {
+		return services.ImpersonationClaims{}, err
+	}
+	if session.AdminID != claims.AdminID || session.UserID != claims.UserID {
+		return services.ImpersonationClaims{}, domainerrors.ErrImpersonationTokenInvalid
+	}
+	if session.Status != "active" {
+		return services.ImpersonationClaims{}, domainerrors.ErrImpersonationRevoked
+	}
+	return claims, nil
+}
+
+// RecordImpersonatedRequest appends an audit entry for a request made under
+// claims, whether it was served or blocked by scope.
+func (s Service) RecordImpersonatedRequest(ctx context.Context, claims services.ImpersonationClaims, req ImpersonatedRequest) error {
+	actionType := "impersonation.request_denied"
+	if req.Allowed {
+		actionType = "impersonation.request"
+		if err := s.Repo.RecordImpersonationAction(ctx, claims.ImpersonationID); err != nil {
+			return err
+		}
+	}
+	return s.audited(ctx, ports.AuditLog{
+		AdminID:            claims.AdminID,
+		ActionType:         actionType,
+		TargetResourceType: "user",
+		TargetResourceID:   claims.UserID,
+		NewValue: map[string]any{
+			"impersonation_id": claims.ImpersonationID,
+			"method":           req.Method,
+			"route":            req.Route,
+			"path":             req.Path,
+			"status":           req.Status,
+		},
+		Reason:    "impersonated request",
+		IPAddress: req.IPAddress,
+	})
+}
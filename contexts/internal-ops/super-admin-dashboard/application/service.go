@@ -11,15 +11,27 @@ import (
 	"time"
 
 	domainerrors "solomon/contexts/internal-ops/super-admin-dashboard/domain/errors"
+	"solomon/contexts/internal-ops/super-admin-dashboard/domain/services"
 	"solomon/contexts/internal-ops/super-admin-dashboard/ports"
+	"solomon/internal/platform/auditchain"
+)
+
+const (
+	defaultImpersonationTTL = 30 * time.Minute
+	maxImpersonationTTL     = 2 * time.Hour
 )
 
 type Service struct {
-	Repo          ports.Repository
-	Idempotency   ports.IdempotencyStore
-	Clock         ports.Clock
-	Logger        *slog.Logger
-	IdempotencyTTL time.Duration
+	Repo                ports.Repository
+	Idempotency         ports.IdempotencyStore
+	AuditChain          ports.AuditChainStore
+	AuditSigner         auditchain.Signer
+	ImpersonationTokens services.ImpersonationTokenCodec
+	WalletLedger        ports.WalletLedger
+	IDGenerator         ports.IDGenerator
+	Clock               ports.Clock
+	Logger              *slog.Logger
+	IdempotencyTTL      time.Duration
 }
 
 func (s Service) StartImpersonation(
@@ -28,25 +40,62 @@ func (s Service) StartImpersonation(
 	idempotencyKey string,
 	userID string,
 	reason string,
+	scopes []string,
+	ttl time.Duration,
 ) (ports.ImpersonationSession, error) {
 	var out ports.ImpersonationSession
 	if strings.TrimSpace(userID) == "" || strings.TrimSpace(reason) == "" {
 		return out, domainerrors.ErrInvalidRequest
 	}
+	scopes, ok := services.NormalizeImpersonationScopes(scopes)
+	if !ok {
+		return out, domainerrors.ErrInvalidRequest
+	}
+	if ttl == 0 {
+		ttl = defaultImpersonationTTL
+	}
+	if ttl < 0 || ttl > maxImpersonationTTL {
+		return out, domainerrors.ErrInvalidRequest
+	}
 	if err := s.requireIdempotency(idempotencyKey); err != nil {
 		return out, err
 	}
-	requestHash := hashStrings("start_impersonation", adminID, userID, reason)
+	requestHash := hashStrings("start_impersonation", adminID, userID, reason, strings.Join(scopes, ","), ttl.String())
 	err := s.runIdempotent(
 		ctx,
 		strings.TrimSpace(idempotencyKey),
 		requestHash,
 		func(payload []byte) error { return json.Unmarshal(payload, &out) },
 		func() ([]byte, error) {
-			result, err := s.Repo.StartImpersonation(ctx, adminID, userID, reason)
+			result, err := s.Repo.StartImpersonation(ctx, adminID, userID, reason, scopes, ttl)
 			if err != nil {
 				return nil, err
 			}
+			result.AccessToken, err = s.ImpersonationTokens.Issue(services.ImpersonationClaims{
+				ImpersonationID: result.ImpersonationID,
+				AdminID:         result.AdminID,
+				UserID:          result.UserID,
+				Scopes:          result.Scopes,
+				IssuedAt:        result.StartedAt,
+				ExpiresAt:       result.TokenExpiresAt,
+			})
+			if err != nil {
+				return nil, err
+			}
+			if err := s.audited(ctx, ports.AuditLog{
+				AdminID:            adminID,
+				ActionType:         "impersonation.start",
+				TargetResourceType: "user",
+				TargetResourceID:   userID,
+				NewValue: map[string]any{
+					"impersonation_id": result.ImpersonationID,
+					"scopes":           result.Scopes,
+					"expires_at":       result.TokenExpiresAt.UTC().Format(time.RFC3339),
+				},
+				Reason: reason,
+			}); err != nil {
+				return nil, err
+			}
 			return json.Marshal(result)
 		},
 	)
@@ -76,6 +125,19 @@ func (s Service) EndImpersonation(
 			if err != nil {
 				return nil, err
 			}
+			if err := s.audited(ctx, ports.AuditLog{
+				AdminID:            result.AdminID,
+				ActionType:         "impersonation.end",
+				TargetResourceType: "user",
+				TargetResourceID:   result.UserID,
+				NewValue: map[string]any{
+					"impersonation_id": result.ImpersonationID,
+					"actions_logged":   result.ActionsLogged,
+				},
+				Reason: "impersonation ended",
+			}); err != nil {
+				return nil, err
+			}
 			return json.Marshal(result)
 		},
 	)
@@ -108,16 +170,72 @@ func (s Service) AdjustWallet(
 		requestHash,
 		func(payload []byte) error { return json.Unmarshal(payload, &out) },
 		func() ([]byte, error) {
-			result, err := s.Repo.AdjustWallet(ctx, adminID, userID, amount, adjustmentType, reason)
+			result, err := s.adjustWallet(ctx, adminID, strings.TrimSpace(idempotencyKey), userID, amount, adjustmentType, reason)
 			if err != nil {
 				return nil, err
 			}
+			if err := s.audited(ctx, ports.AuditLog{
+				AuditID:            result.AuditLogID,
+				AdminID:            adminID,
+				ActionType:         "wallet.adjust",
+				TargetResourceType: "user",
+				TargetResourceID:   userID,
+				OldValue:           map[string]any{"balance": result.BalanceBefore},
+				NewValue:           map[string]any{"balance": result.BalanceAfter, "amount": amount},
+				Reason:             reason,
+			}); err != nil {
+				return nil, err
+			}
 			return json.Marshal(result)
 		},
 	)
 	return out, err
 }
 
+// adjustWallet posts the adjustment to the wallet ledger when one is
+// configured, so the balances come from its journal; otherwise the
+// repository applies it to its own balances.
+func (s Service) adjustWallet(
+	ctx context.Context,
+	adminID string,
+	postingKey string,
+	userID string,
+	amount float64,
+	adjustmentType string,
+	reason string,
+) (ports.WalletAdjustment, error) {
+	if s.WalletLedger == nil {
+		return s.Repo.AdjustWallet(ctx, adminID, userID, amount, adjustmentType, reason)
+	}
+	adjustmentType = strings.ToLower(strings.TrimSpace(adjustmentType))
+	if adjustmentType != "credit" && adjustmentType != "debit" {
+		return ports.WalletAdjustment{}, domainerrors.ErrInvalidRequest
+	}
+	if _, err := s.Repo.GetUser(ctx, userID); err != nil {
+		return ports.WalletAdjustment{}, err
+	}
+	change, err := s.WalletLedger.PostWalletAdjustment(ctx, ports.WalletPosting{
+		PostingKey: postingKey,
+		AdminID:    adminID,
+		UserID:     userID,
+		Amount:     amount,
+		Type:       adjustmentType,
+		Reason:     reason,
+	})
+	if err != nil {
+		return ports.WalletAdjustment{}, err
+	}
+	return s.Repo.RecordWalletAdjustment(ctx, ports.WalletAdjustment{
+		UserID:        userID,
+		Amount:        amount,
+		Type:          adjustmentType,
+		Reason:        reason,
+		BalanceBefore: change.BalanceBefore,
+		BalanceAfter:  change.BalanceAfter,
+		AdminID:       adminID,
+	})
+}
+
 func (s Service) ListWalletHistory(ctx context.Context, userID string, cursor string, limit int) ([]ports.WalletAdjustment, string, error) {
 	if strings.TrimSpace(userID) == "" {
 		return nil, "", domainerrors.ErrInvalidRequest
@@ -158,6 +276,18 @@ func (s Service) BanUser(
 			if err != nil {
 				return nil, err
 			}
+			if err := s.audited(ctx, ports.AuditLog{
+				AuditID:            result.AuditLogID,
+				AdminID:            adminID,
+				ActionType:         "user.ban",
+				TargetResourceType: "user",
+				TargetResourceID:   userID,
+				OldValue:           map[string]any{"status": "active"},
+				NewValue:           map[string]any{"status": "banned", "ban_type": result.BanType},
+				Reason:             reason,
+			}); err != nil {
+				return nil, err
+			}
 			return json.Marshal(result)
 		},
 	)
@@ -189,6 +319,17 @@ func (s Service) UnbanUser(
 			if err != nil {
 				return nil, err
 			}
+			if err := s.audited(ctx, ports.AuditLog{
+				AdminID:            adminID,
+				ActionType:         "user.unban",
+				TargetResourceType: "user",
+				TargetResourceID:   userID,
+				OldValue:           map[string]any{"status": "banned"},
+				NewValue:           map[string]any{"status": "active"},
+				Reason:             reason,
+			}); err != nil {
+				return nil, err
+			}
 			return json.Marshal(result)
 		},
 	)
@@ -231,6 +372,16 @@ func (s Service) BulkAction(
 			if err != nil {
 				return nil, err
 			}
+			if err := s.audited(ctx, ports.AuditLog{
+				AdminID:            adminID,
+				ActionType:         "users.bulk_action",
+				TargetResourceType: "user",
+				TargetResourceID:   "*",
+				NewValue:           map[string]any{"job_id": result.JobID, "action": action, "user_count": result.UserCount},
+				Reason:             "bulk action queued",
+			}); err != nil {
+				return nil, err
+			}
 			return json.Marshal(result)
 		},
 	)
@@ -262,6 +413,18 @@ func (s Service) PauseCampaign(
 			if err != nil {
 				return nil, err
 			}
+			if err := s.audited(ctx, ports.AuditLog{
+				AuditID:            result.AuditLogID,
+				AdminID:            adminID,
+				ActionType:         "campaign.pause",
+				TargetResourceType: "campaign",
+				TargetResourceID:   campaignID,
+				OldValue:           map[string]any{"status": "active"},
+				NewValue:           map[string]any{"status": result.Status},
+				Reason:             reason,
+			}); err != nil {
+				return nil, err
+			}
 			return json.Marshal(result)
 		},
 	)
@@ -305,6 +468,18 @@ func (s Service) AdjustCampaign(
 			if err != nil {
 				return nil, err
 			}
+			if err := s.audited(ctx, ports.AuditLog{
+				AuditID:            result.AuditLogID,
+				AdminID:            adminID,
+				ActionType:         "campaign.adjust",
+				TargetResourceType: "campaign",
+				TargetResourceID:   campaignID,
+				OldValue:           map[string]any{"budget": result.OldBudget, "rate": result.OldRatePer1kViews},
+				NewValue:           map[string]any{"budget": result.NewBudget, "rate": result.NewRatePer1kViews},
+				Reason:             reason,
+			}); err != nil {
+				return nil, err
+			}
 			return json.Marshal(result)
 		},
 	)
@@ -337,6 +512,18 @@ func (s Service) OverrideSubmission(
 			if err != nil {
 				return nil, err
 			}
+			if err := s.audited(ctx, ports.AuditLog{
+				AuditID:            result.AuditLogID,
+				AdminID:            adminID,
+				ActionType:         "submission.override",
+				TargetResourceType: "submission",
+				TargetResourceID:   submissionID,
+				OldValue:           map[string]any{"status": result.OldStatus},
+				NewValue:           map[string]any{"status": result.NewStatus},
+				Reason:             reason,
+			}); err != nil {
+				return nil, err
+			}
 			return json.Marshal(result)
 		},
 	)
@@ -387,6 +574,17 @@ func (s Service) ToggleFeatureFlag(
 			if err != nil {
 				return nil, err
 			}
+			if err := s.audited(ctx, ports.AuditLog{
+				AdminID:            adminID,
+				ActionType:         "feature_flag.toggle",
+				TargetResourceType: "feature_flag",
+				TargetResourceID:   flagKey,
+				OldValue:           map[string]any{"enabled": old},
+				NewValue:           map[string]any{"enabled": flag.Enabled},
+				Reason:             reason,
+			}); err != nil {
+				return nil, err
+			}
 			return json.Marshal(struct {
 				Flag       ports.FeatureFlag `json:"flag"`
 				OldEnabled bool              `json:"old_enabled"`
@@ -403,26 +601,6 @@ func (s Service) GetAnalyticsDashboard(ctx context.Context, start time.Time, end
 	return s.Repo.GetAnalyticsDashboard(ctx, start, end)
 }
 
-func (s Service) ListAuditLogs(ctx context.Context, adminID string, actionType string, cursor string, pageSize int) ([]ports.AuditLog, string, error) {
-	if pageSize <= 0 {
-		pageSize = 20
-	}
-	if pageSize > 100 {
-		pageSize = 100
-	}
-	return s.Repo.ListAuditLogs(ctx, adminID, actionType, cursor, pageSize)
-}
-
-func (s Service) ExportAuditLogs(ctx context.Context, format string, start time.Time, end time.Time, includeSignatures bool) (ports.AuditExport, error) {
-	if strings.TrimSpace(format) == "" {
-		return ports.AuditExport{}, domainerrors.ErrInvalidRequest
-	}
-	if !start.IsZero() && !end.IsZero() && end.Before(start) {
-		return ports.AuditExport{}, domainerrors.ErrUnprocessable
-	}
-	return s.Repo.CreateAuditExport(ctx, format, start, end, includeSignatures)
-}
-
 func (s Service) now() time.Time {
 	if s.Clock == nil {
 		return time.Now().UTC()
@@ -489,4 +667,21 @@ func (s Service) runIdempotent(
 func hashStrings(values ...string) string {
 	sum := sha256.Sum256([]byte(strings.Join(values, "|")))
 	return hex.EncodeToString(sum[:])
-}
\ No newline at end of file
+}
+
+// cloneAuditValue normalises values through JSON so the sealed entry holds
+// exactly what a store will hand back when the chain is re-read.
+func cloneAuditValue(input map[string]any) map[string]any {
+	if input == nil {
+		return map[string]any{}
+	}
+	payload, err := json.Marshal(input)
+	if err != nil {
+		return map[string]any{}
+	}
+	var out map[string]any
+	if err := json.Unmarshal(payload, &out); err != nil {
+		return map[string]any{}
+	}
+	return out
+}
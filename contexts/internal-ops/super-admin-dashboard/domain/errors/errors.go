@@ -21,4 +21,9 @@ var (
 	ErrAlreadyBanned              = errors.New("user already banned")
 	ErrNotBanned                  = errors.New("user is not currently banned")
 	ErrBulkActionConflict         = errors.New("bulk action conflict")
+	ErrAuditChainConflict         = errors.New("audit chain head moved")
+	ErrImpersonationTokenInvalid  = errors.New("impersonation token invalid")
+	ErrImpersonationTokenExpired  = errors.New("impersonation token expired")
+	ErrImpersonationRevoked       = errors.New("impersonation session ended")
+	ErrImpersonationScopeDenied   = errors.New("impersonation scope does not allow this request")
 )
\ No newline at end of file
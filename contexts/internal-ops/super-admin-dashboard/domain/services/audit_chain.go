@@ -0,0 +1,70 @@
+package services
+
+import (
+	"encoding/json"
+	"time"
+
+	"solomon/internal/platform/auditchain"
+)
+
+// AuditAnchorDomain prefixes the signed content of super-admin anchors.
+const AuditAnchorDomain = "audit-anchor-v1"
+
+// AuditEntry is the signed content of one audit log entry. Every field is
+// covered by the entry hash, including the previous entry's hash.
+type AuditEntry struct {
+	Sequence           int64
+	AuditID            string
+	AdminID            string
+	ActionType         string
+	TargetResourceType string
+	TargetResourceID   string
+	OldValue           map[string]any
+	NewValue           map[string]any
+	Reason             string
+	PerformedAt        time.Time
+	IPAddress          string
+	PrevHash           string
+}
+
+// CanonicalAuditContent encodes an entry deterministically. Timestamps are
+// UTC with microsecond precision so the content survives a Postgres round
+// trip, and nil maps encode like empty ones.
+func CanonicalAuditContent(entry AuditEntry) ([]byte, error) {
+	return json.Marshal(struct {
+		Version            int            `json:"v"`
+		Sequence           int64          `json:"sequence"`
+		AuditID            string         `json:"audit_id"`
+		AdminID            string         `json:"admin_id"`
+		ActionType         string         `json:"action_type"`
+		TargetResourceType string         `json:"target_resource_type"`
+		TargetResourceID   string         `json:"target_resource_id"`
+		OldValue           map[string]any `json:"old_value"`
+		NewValue           map[string]any `json:"new_value"`
+		Reason             string         `json:"reason"`
+		PerformedAt        string         `json:"performed_at"`
+		IPAddress          string         `json:"ip_address"`
+		PrevHash           string         `json:"prev_hash"`
+	}{
+		Version:            1,
+		Sequence:           entry.Sequence,
+		AuditID:            entry.AuditID,
+		AdminID:            entry.AdminID,
+		ActionType:         entry.ActionType,
+		TargetResourceType: entry.TargetResourceType,
+		TargetResourceID:   entry.TargetResourceID,
+		OldValue:           nonNilMap(entry.OldValue),
+		NewValue:           nonNilMap(entry.NewValue),
+		Reason:             entry.Reason,
+		PerformedAt:        auditchain.CanonicalTime(entry.PerformedAt).Format(time.RFC3339Nano),
+		IPAddress:          entry.IPAddress,
+		PrevHash:           entry.PrevHash,
+	})
+}
+
+func nonNilMap(value map[string]any) map[string]any {
+	if value == nil {
+		return map[string]any{}
+	}
+	return value
+}
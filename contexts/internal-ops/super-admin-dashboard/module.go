@@ -1,13 +1,17 @@
 package superadmindashboard
 
 import (
+	"context"
+	"crypto/rand"
 	"log/slog"
 	"time"
 
 	httpadapter "solomon/contexts/internal-ops/super-admin-dashboard/adapters/http"
 	"solomon/contexts/internal-ops/super-admin-dashboard/adapters/memory"
 	"solomon/contexts/internal-ops/super-admin-dashboard/application"
+	"solomon/contexts/internal-ops/super-admin-dashboard/domain/services"
 	"solomon/contexts/internal-ops/super-admin-dashboard/ports"
+	"solomon/internal/platform/auditchain"
 )
 
 // Module is the M20 composition surface exposed to Solomon runtime wiring.
@@ -18,22 +22,31 @@ type Module struct {
 
 // Dependencies captures runtime ports/config required by NewModule.
 type Dependencies struct {
-	Repository     ports.Repository
-	Idempotency    ports.IdempotencyStore
-	Clock          ports.Clock
-	IDGenerator    ports.IDGenerator
-	IdempotencyTTL time.Duration
-	Logger         *slog.Logger
+	Repository       ports.Repository
+	Idempotency      ports.IdempotencyStore
+	AuditChain       ports.AuditChainStore
+	AuditSigner      auditchain.Signer
+	ImpersonationKey []byte
+	WalletLedger     ports.WalletLedger
+	Clock            ports.Clock
+	IDGenerator      ports.IDGenerator
+	IdempotencyTTL   time.Duration
+	Logger           *slog.Logger
 }
 
 // NewModule wires M20 use cases with explicit ports.
 func NewModule(deps Dependencies) Module {
 	service := application.Service{
-		Repo:           deps.Repository,
-		Idempotency:    deps.Idempotency,
-		Clock:          deps.Clock,
-		Logger:         deps.Logger,
-		IdempotencyTTL: deps.IdempotencyTTL,
+		Repo:                deps.Repository,
+		Idempotency:         deps.Idempotency,
+		AuditChain:          deps.AuditChain,
+		AuditSigner:         deps.AuditSigner,
+		ImpersonationTokens: services.ImpersonationTokenCodec{Key: deps.ImpersonationKey},
+		WalletLedger:        deps.WalletLedger,
+		IDGenerator:         deps.IDGenerator,
+		Clock:               deps.Clock,
+		Logger:              deps.Logger,
+		IdempotencyTTL:      deps.IdempotencyTTL,
 	}
 	return Module{
 		Handler: httpadapter.Handler{
@@ -43,17 +56,68 @@ func NewModule(deps Dependencies) Module {
 	}
 }
 
+// AnchorAuditChain records the audit chain head in the anchor table.
+func (m Module) AnchorAuditChain(ctx context.Context) error {
+	_, _, err := m.Handler.Service.AnchorAuditChain(ctx)
+	return err
+}
+
+// AuthenticateImpersonation verifies an impersonation bearer token.
+func (m Module) AuthenticateImpersonation(ctx context.Context, token string) (services.ImpersonationClaims, error) {
+	return m.Handler.Service.AuthenticateImpersonation(ctx, token)
+}
+
+// RecordImpersonatedRequest audits one request made with an impersonation token.
+func (m Module) RecordImpersonatedRequest(ctx context.Context, claims services.ImpersonationClaims, req application.ImpersonatedRequest) error {
+	return m.Handler.Service.RecordImpersonatedRequest(ctx, claims, req)
+}
+
 // NewInMemoryModule wires M20 against in-memory adapters for foundation/runtime bootstrap.
+// Audit entries and impersonation tokens are signed with random per-process keys.
 func NewInMemoryModule(logger *slog.Logger) Module {
+	return NewInMemoryModuleWithAuditChain(logger, nil, nil, nil, nil)
+}
+
+// NewInMemoryModuleWithAuditChain keeps M20 state in memory but appends the
+// admin audit log to chain, signed by signer, signs impersonation tokens with
+// impersonationKey and posts wallet adjustments to walletLedger. A nil chain
+// keeps the log in memory; a nil signer or empty key uses a random
+// per-process key; a nil ledger keeps wallet balances in memory.
+func NewInMemoryModuleWithAuditChain(
+	logger *slog.Logger,
+	chain ports.AuditChainStore,
+	signer auditchain.Signer,
+	impersonationKey []byte,
+	walletLedger ports.WalletLedger,
+) Module {
 	store := memory.NewStore()
+	if chain == nil {
+		chain = store
+	}
+	if signer == nil {
+		signer = auditchain.HMACSigner{Key: randomKey()}
+	}
+	if len(impersonationKey) == 0 {
+		impersonationKey = randomKey()
+	}
 	module := NewModule(Dependencies{
-		Repository:     store,
-		Idempotency:    store,
-		Clock:          store,
-		IDGenerator:    store,
-		IdempotencyTTL: 7 * 24 * time.Hour,
-		Logger:         logger,
+		Repository:       store,
+		Idempotency:      store,
+		AuditChain:       chain,
+		AuditSigner:      signer,
+		ImpersonationKey: impersonationKey,
+		WalletLedger:     walletLedger,
+		Clock:            store,
+		IDGenerator:      store,
+		IdempotencyTTL:   7 * 24 * time.Hour,
+		Logger:           logger,
 	})
 	module.Store = store
 	return module
 }
+
+func randomKey() []byte {
+	key := make([]byte, 32)
+	_, _ = rand.Read(key)
+	return key
+}
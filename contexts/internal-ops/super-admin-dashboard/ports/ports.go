@@ -39,6 +39,8 @@ type ImpersonationSession struct {
 	Status          string
 	Reason          string
 	AdminID         string
+	Scopes          []string
+	ActionsLogged   int
 }
 
 type WalletAdjustment struct {
@@ -142,21 +144,36 @@ type AuditLog struct {
 	IPAddress           string
 	SignatureHash       string
 	IsVerified          bool
+
+	// Sequence orders the hash chain. PrevHash is the EntryHash of the
+	// entry before it; SignatureHash signs the entry's canonical content.
+	Sequence  int64
+	PrevHash  string
+	EntryHash string
 }
 
-type AuditExport struct {
-	ExportJobID          string
-	Status               string
-	FileURL              string
-	CreatedAt            time.Time
-	EstimatedCompletion  time.Time
+// AuditAnchor records the chain head at a point in time. Anchors live apart
+// from the log so truncating or rewriting the tail of the chain is caught.
+type AuditAnchor struct {
+	AnchorID   string
+	Sequence   int64
+	EntryHash  string
+	Signature  string
+	AnchoredAt time.Time
 }
 
 type Repository interface {
-	StartImpersonation(ctx context.Context, adminID string, userID string, reason string) (ImpersonationSession, error)
+	StartImpersonation(ctx context.Context, adminID string, userID string, reason string, scopes []string, ttl time.Duration) (ImpersonationSession, error)
 	EndImpersonation(ctx context.Context, impersonationID string) (ImpersonationSession, error)
+	GetImpersonation(ctx context.Context, impersonationID string) (ImpersonationSession, error)
+	RecordImpersonationAction(ctx context.Context, impersonationID string) error
+
+	GetUser(ctx context.Context, userID string) (AdminUser, error)
 
 	AdjustWallet(ctx context.Context, adminID string, userID string, amount float64, adjustmentType string, reason string) (WalletAdjustment, error)
+	// RecordWalletAdjustment appends an adjustment whose balances were set by
+	// the wallet ledger to the history, leaving stored balances untouched.
+	RecordWalletAdjustment(ctx context.Context, adjustment WalletAdjustment) (WalletAdjustment, error)
 	ListWalletHistory(ctx context.Context, userID string, cursor string, limit int) ([]WalletAdjustment, string, error)
 
 	BanUser(ctx context.Context, adminID string, userID string, banType string, durationDays int, reason string) (UserBan, error)
@@ -174,7 +191,40 @@ type Repository interface {
 	ToggleFeatureFlag(ctx context.Context, adminID string, flagKey string, enabled bool, reason string, config map[string]any) (FeatureFlag, bool, error)
 
 	GetAnalyticsDashboard(ctx context.Context, start time.Time, end time.Time) (AnalyticsDashboard, error)
+}
+
+// WalletLedger posts wallet adjustments to the double-entry ledger. When it
+// is configured the ledger owns wallet balances and the repository only
+// keeps the adjustment history.
+type WalletLedger interface {
+	PostWalletAdjustment(ctx context.Context, posting WalletPosting) (WalletBalanceChange, error)
+}
+
+// WalletPosting is keyed by PostingKey, so posting it again is safe.
+type WalletPosting struct {
+	PostingKey string
+	AdminID    string
+	UserID     string
+	Amount     float64
+	Type       string
+	Reason     string
+}
 
+type WalletBalanceChange struct {
+	BalanceBefore float64
+	BalanceAfter  float64
+}
+
+// AuditChainStore persists the hash-chained admin audit log. AppendAuditEntry
+// must reject an entry whose sequence is taken or does not follow the head
+// with ErrAuditChainConflict; the caller re-reads the head and retries.
+type AuditChainStore interface {
+	GetAuditChainHead(ctx context.Context) (AuditLog, bool, error)
+	AppendAuditEntry(ctx context.Context, entry AuditLog) error
 	ListAuditLogs(ctx context.Context, adminID string, actionType string, cursor string, pageSize int) ([]AuditLog, string, error)
-	CreateAuditExport(ctx context.Context, format string, start time.Time, end time.Time, includeSignatures bool) (AuditExport, error)
-}
\ No newline at end of file
+	ListAuditChain(ctx context.Context, afterSequence int64, limit int) ([]AuditLog, error)
+
+	AppendAuditAnchor(ctx context.Context, anchor AuditAnchor) error
+	GetLatestAuditAnchor(ctx context.Context) (AuditAnchor, bool, error)
+	ListAuditAnchors(ctx context.Context) ([]AuditAnchor, error)
+}
@@ -6,19 +6,22 @@ type ErrorResponse struct {
 }
 
 type StartImpersonationRequest struct {
-	ImpersonatedUserID string `json:"impersonated_user_id"`
-	Reason             string `json:"reason"`
-	Notes              string `json:"notes,omitempty"`
+	ImpersonatedUserID string   `json:"impersonated_user_id"`
+	Reason             string   `json:"reason"`
+	Notes              string   `json:"notes,omitempty"`
+	Scopes             []string `json:"scopes,omitempty"`
+	DurationMinutes    int      `json:"duration_minutes,omitempty"`
 }
 
 type StartImpersonationResponse struct {
-	ImpersonationID string `json:"impersonation_id"`
-	UserID          string `json:"user_id"`
-	AccessToken     string `json:"access_token"`
-	TokenExpiresAt  string `json:"token_expires_at"`
-	StartedAt       string `json:"started_at"`
-	Status          string `json:"status"`
-	Replayed        bool   `json:"replayed,omitempty"`
+	ImpersonationID string   `json:"impersonation_id"`
+	UserID          string   `json:"user_id"`
+	AccessToken     string   `json:"access_token"`
+	TokenExpiresAt  string   `json:"token_expires_at"`
+	Scopes          []string `json:"scopes"`
+	StartedAt       string   `json:"started_at"`
+	Status          string   `json:"status"`
+	Replayed        bool     `json:"replayed,omitempty"`
 }
 
 type EndImpersonationRequest struct {
@@ -229,6 +232,9 @@ type AuditLogDTO struct {
 	IPAddress          string         `json:"ip_address"`
 	SignatureHash      string         `json:"signature_hash"`
 	IsVerified         bool           `json:"is_verified"`
+	Sequence           int64          `json:"sequence"`
+	PrevHash           string         `json:"prev_hash"`
+	EntryHash          string         `json:"entry_hash"`
 }
 
 type AuditLogsResponse struct {
@@ -239,10 +245,33 @@ type AuditLogsResponse struct {
 	} `json:"pagination"`
 }
 
+type AuditChainBreakDTO struct {
+	Sequence int64  `json:"sequence"`
+	AuditID  string `json:"audit_id,omitempty"`
+	AnchorID string `json:"anchor_id,omitempty"`
+	Reason   string `json:"reason"`
+}
+
+type AuditChainVerificationResponse struct {
+	Valid          bool                `json:"valid"`
+	EntriesChecked int64               `json:"entries_checked"`
+	AnchorsChecked int                 `json:"anchors_checked"`
+	HeadSequence   int64               `json:"head_sequence"`
+	HeadHash       string              `json:"head_hash,omitempty"`
+	FirstBreak     *AuditChainBreakDTO `json:"first_break,omitempty"`
+}
+
 type AuditLogExportResponse struct {
-	ExportJobID          string `json:"export_job_id"`
-	Status               string `json:"status"`
-	FileURL              string `json:"file_url"`
-	CreatedAt            string `json:"created_at"`
-	EstimatedCompletion  string `json:"estimated_completion"`
+	ExportJobID string   `json:"export_job_id"`
+	Status      string   `json:"status"`
+	Format      string   `json:"format"`
+	Columns     []string `json:"columns"`
+	RowLimit    int      `json:"row_limit"`
+	RowCount    int      `json:"row_count"`
+	Truncated   bool     `json:"truncated"`
+	Error       string   `json:"error,omitempty"`
+	CreatedAt   string   `json:"created_at"`
+	CompletedAt string   `json:"completed_at,omitempty"`
+	StatusURL   string   `json:"status_url"`
+	DownloadURL string   `json:"download_url,omitempty"`
 }
\ No newline at end of file
@@ -0,0 +1,41 @@
+package httpadapter
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	domainerrors "solomon/contexts/internal-ops/team-management-service/domain/errors"
+	"solomon/contexts/internal-ops/team-management-service/ports"
+	httptransport "solomon/contexts/internal-ops/team-management-service/transport/http"
+)
+
+func (h Handler) SyncDirectoryUserHandler(
+	ctx context.Context,
+	userID string,
+	req httptransport.DirectoryUserRequest,
+) (httptransport.DirectoryUserResponse, error) {
+	user := ports.DirectoryUser{
+		UserID:     userID,
+		Email:      req.Email,
+		MFAEnabled: req.MFAEnabled,
+	}
+	if raw := strings.TrimSpace(req.UpdatedAt); raw != "" {
+		updatedAt, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return httptransport.DirectoryUserResponse{}, domainerrors.ErrInvalidRequest
+		}
+		user.UpdatedAt = updatedAt
+	}
+	stored, err := h.Service.SyncDirectoryUser(ctx, user)
+	if err != nil {
+		return httptransport.DirectoryUserResponse{}, err
+	}
+
+	resp := httptransport.DirectoryUserResponse{Status: "success"}
+	resp.Data.UserID = stored.UserID
+	resp.Data.Email = stored.Email
+	resp.Data.MFAEnabled = stored.MFAEnabled
+	resp.Data.UpdatedAt = stored.UpdatedAt.UTC().Format(time.RFC3339)
+	return resp, nil
+}
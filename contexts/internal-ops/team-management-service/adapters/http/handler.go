@@ -55,11 +55,56 @@ func (h Handler) CreateInviteHandler(
 	}
 
 	resp := httptransport.CreateInviteResponse{Status: "success"}
+	resp.Data.InviteID = item.Invite.InviteID
+	resp.Data.Email = item.Invite.Email
+	resp.Data.Role = item.Invite.Role
+	resp.Data.Status = item.Invite.Status
+	resp.Data.ExpiresAt = item.Invite.ExpiresAt.UTC().Format(time.RFC3339)
+	resp.Data.EmailStatus = item.EmailStatus
+	return resp, nil
+}
+
+func (h Handler) ResendInviteHandler(
+	ctx context.Context,
+	idempotencyKey string,
+	actorUserID string,
+	teamID string,
+	inviteID string,
+) (httptransport.ResendInviteResponse, error) {
+	item, err := h.Service.ResendInvite(ctx, idempotencyKey, actorUserID, strings.TrimSpace(teamID), strings.TrimSpace(inviteID))
+	if err != nil {
+		return httptransport.ResendInviteResponse{}, err
+	}
+
+	resp := httptransport.ResendInviteResponse{Status: "success"}
+	resp.Data.InviteID = item.Invite.InviteID
+	resp.Data.Email = item.Invite.Email
+	resp.Data.Status = item.Invite.Status
+	resp.Data.ExpiresAt = item.Invite.ExpiresAt.UTC().Format(time.RFC3339)
+	resp.Data.SentCount = item.Invite.SentCount
+	resp.Data.EmailStatus = item.EmailStatus
+	return resp, nil
+}
+
+func (h Handler) RevokeInviteHandler(
+	ctx context.Context,
+	idempotencyKey string,
+	actorUserID string,
+	teamID string,
+	inviteID string,
+) (httptransport.RevokeInviteResponse, error) {
+	item, err := h.Service.RevokeInvite(ctx, idempotencyKey, actorUserID, strings.TrimSpace(teamID), strings.TrimSpace(inviteID))
+	if err != nil {
+		return httptransport.RevokeInviteResponse{}, err
+	}
+
+	resp := httptransport.RevokeInviteResponse{Status: "success"}
 	resp.Data.InviteID = item.InviteID
-	resp.Data.Email = item.Email
-	resp.Data.Role = item.Role
+	resp.Data.TeamID = item.TeamID
 	resp.Data.Status = item.Status
-	resp.Data.ExpiresAt = item.ExpiresAt.UTC().Format(time.RFC3339)
+	if item.RevokedAt != nil {
+		resp.Data.RevokedAt = item.RevokedAt.UTC().Format(time.RFC3339)
+	}
 	return resp, nil
 }
 
@@ -249,21 +294,3 @@ func (h Handler) ListAuditLogsHandler(
 	}
 	return resp, nil
 }
-
-func (h Handler) ExportMembersHandler(
-	ctx context.Context,
-	actorUserID string,
-	teamID string,
-) (httptransport.ExportMembersResponse, error) {
-	item, err := h.Service.CreateMembersExport(ctx, actorUserID, strings.TrimSpace(teamID))
-	if err != nil {
-		return httptransport.ExportMembersResponse{}, err
-	}
-	resp := httptransport.ExportMembersResponse{Status: "success"}
-	resp.Data.ExportJobID = item.ExportJobID
-	resp.Data.TeamID = item.TeamID
-	resp.Data.Status = item.Status
-	resp.Data.CreatedAt = item.CreatedAt.UTC().Format(time.RFC3339)
-	resp.Data.EstimatedCompletionAt = item.EstimatedCompletionAt.UTC().Format(time.RFC3339)
-	return resp, nil
-}
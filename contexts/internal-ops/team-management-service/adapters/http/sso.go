@@ -0,0 +1,411 @@
+package httpadapter
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"solomon/contexts/internal-ops/team-management-service/application"
+	domainerrors "solomon/contexts/internal-ops/team-management-service/domain/errors"
+	"solomon/contexts/internal-ops/team-management-service/ports"
+	httptransport "solomon/contexts/internal-ops/team-management-service/transport/http"
+)
+
+// scimFilterPattern accepts the equality filters IdPs send to look a user
+// up before creating it.
+var scimFilterPattern = regexp.MustCompile(`(?i)^\s*(userName|externalId|emails|emails\.value)\s+eq\s+("(?:[^"\\]|\\.)*")\s*$`)
+
+func (h Handler) CreateSSOConnectionHandler(
+	ctx context.Context,
+	actorUserID string,
+	teamID string,
+	req httptransport.SSOConnectionRequest,
+) (httptransport.SSOConnectionResponse, error) {
+	item, err := h.Service.CreateSSOConnection(ctx, actorUserID, strings.TrimSpace(teamID), toSSOConnectionInput(req))
+	if err != nil {
+		return httptransport.SSOConnectionResponse{}, err
+	}
+	return httptransport.SSOConnectionResponse{Status: "success", Data: toSSOConnectionDTO(item)}, nil
+}
+
+func (h Handler) UpdateSSOConnectionHandler(
+	ctx context.Context,
+	actorUserID string,
+	teamID string,
+	connectionID string,
+	req httptransport.SSOConnectionRequest,
+) (httptransport.SSOConnectionResponse, error) {
+	item, err := h.Service.UpdateSSOConnection(
+		ctx,
+		actorUserID,
+		strings.TrimSpace(teamID),
+		strings.TrimSpace(connectionID),
+		toSSOConnectionInput(req),
+	)
+	if err != nil {
+		return httptransport.SSOConnectionResponse{}, err
+	}
+	return httptransport.SSOConnectionResponse{Status: "success", Data: toSSOConnectionDTO(item)}, nil
+}
+
+func (h Handler) RotateSCIMTokenHandler(
+	ctx context.Context,
+	actorUserID string,
+	teamID string,
+	connectionID string,
+) (httptransport.SSOConnectionResponse, error) {
+	item, err := h.Service.RotateSCIMToken(ctx, actorUserID, strings.TrimSpace(teamID), strings.TrimSpace(connectionID))
+	if err != nil {
+		return httptransport.SSOConnectionResponse{}, err
+	}
+	return httptransport.SSOConnectionResponse{Status: "success", Data: toSSOConnectionDTO(item)}, nil
+}
+
+func (h Handler) ListSSOConnectionsHandler(
+	ctx context.Context,
+	actorUserID string,
+	teamID string,
+) (httptransport.SSOConnectionsResponse, error) {
+	items, err := h.Service.ListSSOConnections(ctx, actorUserID, strings.TrimSpace(teamID))
+	if err != nil {
+		return httptransport.SSOConnectionsResponse{}, err
+	}
+	resp := httptransport.SSOConnectionsResponse{Status: "success"}
+	resp.Data.Items = make([]httptransport.SSOConnection, 0, len(items))
+	for _, item := range items {
+		resp.Data.Items = append(resp.Data.Items, toSSOConnectionDTO(item))
+	}
+	return resp, nil
+}
+
+func (h Handler) BeginSSOLoginHandler(ctx context.Context, connectionID string) (application.SSOLoginStart, error) {
+	return h.Service.BeginSSOLogin(ctx, strings.TrimSpace(connectionID))
+}
+
+func (h Handler) CompleteSSOLoginHandler(
+	ctx context.Context,
+	connectionID string,
+	state string,
+	callback ports.SSOCallback,
+) (httptransport.SSOLoginResponse, error) {
+	item, err := h.Service.CompleteSSOLogin(ctx, strings.TrimSpace(connectionID), strings.TrimSpace(state), callback)
+	if err != nil {
+		return httptransport.SSOLoginResponse{}, err
+	}
+	resp := httptransport.SSOLoginResponse{Status: "success"}
+	resp.Data.TeamID = item.Member.TeamID
+	resp.Data.UserID = item.Member.UserID
+	resp.Data.MemberID = item.Member.MemberID
+	resp.Data.Role = item.Member.Role
+	resp.Data.Action = item.Action
+	return resp, nil
+}
+
+func (h Handler) SAMLMetadataHandler(ctx context.Context, connectionID string) ([]byte, error) {
+	return h.Service.SAMLMetadata(ctx, strings.TrimSpace(connectionID))
+}
+
+func (h Handler) AuthenticateSCIMHandler(ctx context.Context, connectionID string, token string) (ports.SSOConnection, error) {
+	return h.Service.AuthenticateSCIM(ctx, strings.TrimSpace(connectionID), strings.TrimSpace(token))
+}
+
+func (h Handler) SCIMServiceProviderConfigHandler() httptransport.SCIMServiceProviderConfig {
+	resp := httptransport.SCIMServiceProviderConfig{
+		Schemas: []string{httptransport.SCIMSchemaServiceProviderConfig},
+		Patch:   httptransport.SCIMSupported{Supported: true},
+		Filter:  httptransport.SCIMFilterSupport{Supported: true, MaxResults: 100},
+	}
+	resp.AuthenticationSchemes = append(resp.AuthenticationSchemes, struct {
+		Type        string `json:"type"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	}{
+		Type:        "oauthbearertoken",
+		Name:        "Bearer token",
+		Description: "The connection's SCIM token, issued when the connection is created or the token rotated.",
+	})
+	return resp
+}
+
+// ListSCIMUsersHandler pages with the 1-based startIndex and count of
+// RFC 7644 section 3.4.2.4.
+func (h Handler) ListSCIMUsersHandler(
+	ctx context.Context,
+	conn ports.SSOConnection,
+	filter string,
+	startIndex string,
+	count string,
+) (httptransport.SCIMListResponse, error) {
+	query, err := parseSCIMFilter(filter)
+	if err != nil {
+		return httptransport.SCIMListResponse{}, err
+	}
+	start := 1
+	if parsed, err := strconv.Atoi(strings.TrimSpace(startIndex)); err == nil && parsed > 1 {
+		start = parsed
+	}
+	query.Offset = start - 1
+	totalOnly := false
+	if parsed, err := strconv.Atoi(strings.TrimSpace(count)); err == nil {
+		query.Limit = parsed
+		totalOnly = parsed == 0
+	}
+	items, total, err := h.Service.ListSCIMUsers(ctx, conn, query)
+	if err != nil {
+		return httptransport.SCIMListResponse{}, err
+	}
+	if totalOnly {
+		items = nil
+	}
+	resp := httptransport.SCIMListResponse{
+		Schemas:      []string{httptransport.SCIMSchemaListResponse},
+		TotalResults: total,
+		StartIndex:   start,
+		ItemsPerPage: len(items),
+		Resources:    make([]httptransport.SCIMUser, 0, len(items)),
+	}
+	for _, item := range items {
+		resp.Resources = append(resp.Resources, h.toSCIMUser(conn, item))
+	}
+	return resp, nil
+}
+
+func (h Handler) GetSCIMUserHandler(ctx context.Context, conn ports.SSOConnection, identityID string) (httptransport.SCIMUser, error) {
+	item, err := h.Service.GetSCIMUser(ctx, conn, identityID)
+	if err != nil {
+		return httptransport.SCIMUser{}, err
+	}
+	return h.toSCIMUser(conn, item), nil
+}
+
+func (h Handler) CreateSCIMUserHandler(ctx context.Context, conn ports.SSOConnection, req httptransport.SCIMUser) (httptransport.SCIMUser, error) {
+	email := req.UserName
+	for _, candidate := range req.Emails {
+		if candidate.Primary {
+			email = candidate.Value
+		}
+	}
+	active := req.Active == nil || *req.Active
+	item, err := h.Service.CreateSCIMUser(ctx, conn, email, req.ExternalID, active)
+	if err != nil {
+		return httptransport.SCIMUser{}, err
+	}
+	return h.toSCIMUser(conn, item), nil
+}
+
+// ReplaceSCIMUserHandler applies a PUT. userName and externalId cannot
+// change, so only active is taken from the replacement; a missing active
+// means true.
+func (h Handler) ReplaceSCIMUserHandler(
+	ctx context.Context,
+	conn ports.SSOConnection,
+	identityID string,
+	req httptransport.SCIMUser,
+) (httptransport.SCIMUser, error) {
+	active := req.Active == nil || *req.Active
+	item, err := h.Service.SetSCIMUserActive(ctx, conn, identityID, active)
+	if err != nil {
+		return httptransport.SCIMUser{}, err
+	}
+	return h.toSCIMUser(conn, item), nil
+}
+
+// PatchSCIMUserHandler applies the active attribute of a PatchOp. Other
+// attributes are not stored and their operations are ignored.
+func (h Handler) PatchSCIMUserHandler(
+	ctx context.Context,
+	conn ports.SSOConnection,
+	identityID string,
+	req httptransport.SCIMPatchRequest,
+) (httptransport.SCIMUser, error) {
+	var active *bool
+	for _, op := range req.Operations {
+		switch strings.ToLower(strings.TrimSpace(op.Op)) {
+		case "add", "replace":
+		case "remove":
+			if strings.EqualFold(strings.TrimSpace(op.Path), "active") {
+				return httptransport.SCIMUser{}, domainerrors.ErrInvalidRequest
+			}
+			continue
+		default:
+			return httptransport.SCIMUser{}, domainerrors.ErrInvalidRequest
+		}
+		value, ok, err := patchActiveValue(op)
+		if err != nil {
+			return httptransport.SCIMUser{}, err
+		}
+		if ok {
+			active = &value
+		}
+	}
+	if active == nil {
+		return h.GetSCIMUserHandler(ctx, conn, identityID)
+	}
+	item, err := h.Service.SetSCIMUserActive(ctx, conn, identityID, *active)
+	if err != nil {
+		return httptransport.SCIMUser{}, err
+	}
+	return h.toSCIMUser(conn, item), nil
+}
+
+func (h Handler) DeleteSCIMUserHandler(ctx context.Context, conn ports.SSOConnection, identityID string) error {
+	return h.Service.DeleteSCIMUser(ctx, conn, identityID)
+}
+
+func (h Handler) toSCIMUser(conn ports.SSOConnection, item ports.SSOIdentity) httptransport.SCIMUser {
+	active := item.Active
+	return httptransport.SCIMUser{
+		Schemas:    []string{httptransport.SCIMSchemaUser},
+		ID:         item.IdentityID,
+		ExternalID: item.ExternalID,
+		UserName:   item.Email,
+		Active:     &active,
+		Emails:     []httptransport.SCIMEmail{{Value: item.Email, Type: "work", Primary: true}},
+		Meta: &httptransport.SCIMMeta{
+			ResourceType: "User",
+			Created:      item.CreatedAt.UTC().Format(time.RFC3339),
+			LastModified: item.UpdatedAt.UTC().Format(time.RFC3339),
+			Location:     h.Service.SSOServiceProvider(conn.ConnectionID).SCIMBaseURL + "/Users/" + item.IdentityID,
+		},
+	}
+}
+
+func parseSCIMFilter(filter string) (ports.SSOIdentityQuery, error) {
+	if strings.TrimSpace(filter) == "" {
+		return ports.SSOIdentityQuery{}, nil
+	}
+	match := scimFilterPattern.FindStringSubmatch(filter)
+	if match == nil {
+		return ports.SSOIdentityQuery{}, domainerrors.ErrInvalidRequest
+	}
+	value, err := strconv.Unquote(match[2])
+	if err != nil {
+		return ports.SSOIdentityQuery{}, domainerrors.ErrInvalidRequest
+	}
+	if strings.EqualFold(match[1], "externalId") {
+		return ports.SSOIdentityQuery{ExternalID: value}, nil
+	}
+	return ports.SSOIdentityQuery{Email: value}, nil
+}
+
+// patchActiveValue reads active from a path "active" operation or from a
+// path-less one whose value object carries it. Some IdPs send the boolean
+// as the string "True" or "False".
+func patchActiveValue(op httptransport.SCIMPatchOperation) (bool, bool, error) {
+	raw := op.Value
+	switch path := strings.TrimSpace(op.Path); {
+	case strings.EqualFold(path, "active"):
+	case path == "":
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(op.Value, &object); err != nil {
+			return false, false, nil
+		}
+		found := false
+		for key, value := range object {
+			if strings.EqualFold(key, "active") {
+				raw, found = value, true
+			}
+		}
+		if !found {
+			return false, false, nil
+		}
+	default:
+		return false, false, nil
+	}
+	var flag bool
+	if err := json.Unmarshal(raw, &flag); err == nil {
+		return flag, true, nil
+	}
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		if parsed, err := strconv.ParseBool(strings.ToLower(text)); err == nil {
+			return parsed, true, nil
+		}
+	}
+	return false, false, domainerrors.ErrInvalidRequest
+}
+
+func toSSOConnectionInput(req httptransport.SSOConnectionRequest) application.SSOConnectionInput {
+	input := application.SSOConnectionInput{
+		Protocol:       req.Protocol,
+		Status:         req.Status,
+		AllowedDomains: req.AllowedDomains,
+		DefaultRole:    req.DefaultRole,
+	}
+	for _, mapping := range req.RoleMappings {
+		input.RoleMappings = append(input.RoleMappings, ports.SSORoleMapping{Group: mapping.Group, Role: mapping.Role})
+	}
+	if req.OIDC != nil {
+		input.OIDC = ports.OIDCSettings{
+			Issuer:           req.OIDC.Issuer,
+			ClientID:         req.OIDC.ClientID,
+			ClientSecret:     req.OIDC.ClientSecret,
+			AuthorizationURL: req.OIDC.AuthorizationURL,
+			TokenURL:         req.OIDC.TokenURL,
+			JWKSURL:          req.OIDC.JWKSURL,
+			Scopes:           req.OIDC.Scopes,
+			GroupsClaim:      req.OIDC.GroupsClaim,
+		}
+	}
+	if req.SAML != nil {
+		input.SAML = ports.SAMLSettings{
+			IdPEntityID:     req.SAML.IdPEntityID,
+			SSOURL:          req.SAML.SSOURL,
+			Certificate:     req.SAML.Certificate,
+			EmailAttribute:  req.SAML.EmailAttribute,
+			GroupsAttribute: req.SAML.GroupsAttribute,
+		}
+	}
+	return input
+}
+
+func toSSOConnectionDTO(item application.SSOConnectionResult) httptransport.SSOConnection {
+	conn := item.Connection
+	out := httptransport.SSOConnection{
+		ConnectionID:   conn.ConnectionID,
+		TeamID:         conn.TeamID,
+		Protocol:       conn.Protocol,
+		Status:         conn.Status,
+		AllowedDomains: append([]string{}, conn.AllowedDomains...),
+		RoleMappings:   make([]httptransport.SSORoleMapping, 0, len(conn.RoleMappings)),
+		DefaultRole:    conn.DefaultRole,
+		ServiceProvider: httptransport.SSOServiceProvider{
+			LoginURL:    item.ServiceProvider.LoginURL,
+			EntityID:    item.ServiceProvider.EntityID,
+			MetadataURL: item.ServiceProvider.EntityID,
+			ACSURL:      item.ServiceProvider.ACSURL,
+			RedirectURL: item.ServiceProvider.RedirectURL,
+			SCIMBaseURL: item.ServiceProvider.SCIMBaseURL,
+		},
+		SCIMToken: item.SCIMToken,
+		CreatedAt: conn.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt: conn.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+	for _, mapping := range conn.RoleMappings {
+		out.RoleMappings = append(out.RoleMappings, httptransport.SSORoleMapping{Group: mapping.Group, Role: mapping.Role})
+	}
+	switch conn.Protocol {
+	case ports.SSOProtocolOIDC:
+		out.OIDC = &httptransport.OIDCSettings{
+			Issuer:           conn.OIDC.Issuer,
+			ClientID:         conn.OIDC.ClientID,
+			AuthorizationURL: conn.OIDC.AuthorizationURL,
+			TokenURL:         conn.OIDC.TokenURL,
+			JWKSURL:          conn.OIDC.JWKSURL,
+			Scopes:           conn.OIDC.Scopes,
+			GroupsClaim:      conn.OIDC.GroupsClaim,
+		}
+	case ports.SSOProtocolSAML:
+		out.SAML = &httptransport.SAMLSettings{
+			IdPEntityID:     conn.SAML.IdPEntityID,
+			SSOURL:          conn.SAML.SSOURL,
+			Certificate:     conn.SAML.Certificate,
+			EmailAttribute:  conn.SAML.EmailAttribute,
+			GroupsAttribute: conn.SAML.GroupsAttribute,
+		}
+	}
+	return out
+}
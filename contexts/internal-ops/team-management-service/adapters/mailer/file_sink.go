@@ -0,0 +1,64 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"solomon/contexts/internal-ops/team-management-service/ports"
+)
+
+// FileSink writes each invite email as an .eml file under Dir instead of
+// sending it. It is meant for tests and local development; the files hold
+// live invite tokens, so they are created owner-readable only.
+type FileSink struct {
+	Dir       string
+	From      string
+	AcceptURL string
+}
+
+func (f FileSink) SendInvite(ctx context.Context, email ports.InviteEmail) error {
+	now := time.Now()
+	message, err := composeInviteMessage(f.From, f.AcceptURL, email, now)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(f.Dir, 0o700); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%d_%s.eml", now.UnixNano(), email.InviteID)
+	return os.WriteFile(filepath.Join(f.Dir, name), message, 0o600)
+}
+
+// Messages returns the written messages, oldest first.
+func (f FileSink) Messages() ([]string, error) {
+	entries, err := os.ReadDir(f.Dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".eml") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	out := make([]string, 0, len(names))
+	for _, name := range names {
+		raw, err := os.ReadFile(filepath.Join(f.Dir, name))
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, string(raw))
+	}
+	return out, nil
+}
+
+var _ ports.InviteMailer = FileSink{}
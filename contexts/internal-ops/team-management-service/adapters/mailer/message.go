@@ -0,0 +1,46 @@
+package mailer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	domainerrors "solomon/contexts/internal-ops/team-management-service/domain/errors"
+	"solomon/contexts/internal-ops/team-management-service/ports"
+)
+
+// composeInviteMessage renders an invite as a plain-text RFC 5322 message.
+// acceptURL may contain a {token} placeholder; without one, the message
+// carries only the invite code.
+func composeInviteMessage(from string, acceptURL string, email ports.InviteEmail, date time.Time) ([]byte, error) {
+	recipient := strings.TrimSpace(email.Email)
+	if recipient == "" || strings.TrimSpace(email.Token) == "" ||
+		strings.ContainsAny(recipient+email.TeamName+from, "\r\n") {
+		return nil, domainerrors.ErrInvalidRequest
+	}
+	teamName := strings.TrimSpace(email.TeamName)
+	if teamName == "" {
+		teamName = email.TeamID
+	}
+	subject := fmt.Sprintf("You're invited to join %s", teamName)
+	if email.Resend {
+		subject = fmt.Sprintf("Reminder: you're invited to join %s", teamName)
+	}
+
+	var message strings.Builder
+	fmt.Fprintf(&message, "From: %s\r\n", from)
+	fmt.Fprintf(&message, "To: %s\r\n", recipient)
+	fmt.Fprintf(&message, "Subject: %s\r\n", subject)
+	fmt.Fprintf(&message, "Date: %s\r\n", date.UTC().Format(time.RFC1123Z))
+	fmt.Fprintf(&message, "Message-ID: <%s.%d@teams.solomon>\r\n", email.InviteID, date.UnixNano())
+	message.WriteString("MIME-Version: 1.0\r\n")
+	message.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	message.WriteString("\r\n")
+	fmt.Fprintf(&message, "You have been invited to join %s as %s.\r\n\r\n", teamName, email.Role)
+	if strings.TrimSpace(acceptURL) != "" {
+		fmt.Fprintf(&message, "Accept the invite: %s\r\n", strings.ReplaceAll(acceptURL, "{token}", email.Token))
+	}
+	fmt.Fprintf(&message, "Invite code: %s\r\n\r\n", email.Token)
+	fmt.Fprintf(&message, "This invite expires at %s.\r\n", email.ExpiresAt.UTC().Format(time.RFC1123))
+	return []byte(message.String()), nil
+}
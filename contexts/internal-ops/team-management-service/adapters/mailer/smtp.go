@@ -0,0 +1,36 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"strings"
+	"time"
+
+	domainerrors "solomon/contexts/internal-ops/team-management-service/domain/errors"
+	"solomon/contexts/internal-ops/team-management-service/ports"
+)
+
+// SMTPMailer sends invite emails through an SMTP relay.
+type SMTPMailer struct {
+	Addr      string
+	From      string
+	Auth      smtp.Auth
+	AcceptURL string
+}
+
+func (m SMTPMailer) SendInvite(ctx context.Context, email ports.InviteEmail) error {
+	if strings.TrimSpace(m.Addr) == "" {
+		return domainerrors.ErrDependencyUnavailable
+	}
+	message, err := composeInviteMessage(m.From, m.AcceptURL, email, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := smtp.SendMail(m.Addr, m.Auth, m.From, []string{strings.TrimSpace(email.Email)}, message); err != nil {
+		return fmt.Errorf("%w: %v", domainerrors.ErrDependencyUnavailable, err)
+	}
+	return nil
+}
+
+var _ ports.InviteMailer = SMTPMailer{}
@@ -0,0 +1,440 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	domainerrors "solomon/contexts/internal-ops/team-management-service/domain/errors"
+	"solomon/contexts/internal-ops/team-management-service/ports"
+)
+
+func (s *Store) CreateSSOConnection(ctx context.Context, actorUserID string, conn ports.SSOConnection, now time.Time) (ports.SSOConnection, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	team, err := s.ownedTeamLocked(actorUserID, conn.TeamID)
+	if err != nil {
+		return ports.SSOConnection{}, err
+	}
+	now = now.UTC()
+	conn.ConnectionID = "ssoconn_" + s.nextID("m87")
+	conn.TeamID = team.TeamID
+	conn.CreatedBy = actorUserID
+	conn.CreatedAt = now
+	conn.UpdatedAt = now
+	s.ssoConnectionsByID[conn.ConnectionID] = cloneSSOConnection(conn)
+	s.addAuditLocked(team.TeamID, actorUserID, "team.sso.connection_created", "sso_connection", conn.ConnectionID, now, map[string]string{
+		"protocol": conn.Protocol,
+	})
+	return cloneSSOConnection(conn), nil
+}
+
+func (s *Store) UpdateSSOConnection(ctx context.Context, actorUserID string, conn ports.SSOConnection, now time.Time) (ports.SSOConnection, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, err := s.ownedSSOConnectionLocked(actorUserID, conn.TeamID, conn.ConnectionID)
+	if err != nil {
+		return ports.SSOConnection{}, err
+	}
+	now = now.UTC()
+	conn.TeamID = existing.TeamID
+	conn.Protocol = existing.Protocol
+	conn.SCIMTokenHash = existing.SCIMTokenHash
+	conn.CreatedBy = existing.CreatedBy
+	conn.CreatedAt = existing.CreatedAt
+	conn.UpdatedAt = now
+	s.ssoConnectionsByID[conn.ConnectionID] = cloneSSOConnection(conn)
+	s.addAuditLocked(conn.TeamID, actorUserID, "team.sso.connection_updated", "sso_connection", conn.ConnectionID, now, map[string]string{
+		"status": conn.Status,
+	})
+	return cloneSSOConnection(conn), nil
+}
+
+func (s *Store) RotateSCIMToken(ctx context.Context, actorUserID string, teamID string, connectionID string, tokenHash string, now time.Time) (ports.SSOConnection, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	conn, err := s.ownedSSOConnectionLocked(actorUserID, teamID, connectionID)
+	if err != nil {
+		return ports.SSOConnection{}, err
+	}
+	if strings.TrimSpace(tokenHash) == "" {
+		return ports.SSOConnection{}, domainerrors.ErrInvalidRequest
+	}
+	now = now.UTC()
+	conn.SCIMTokenHash = tokenHash
+	conn.UpdatedAt = now
+	s.ssoConnectionsByID[conn.ConnectionID] = conn
+	s.addAuditLocked(conn.TeamID, actorUserID, "team.sso.scim_token_rotated", "sso_connection", conn.ConnectionID, now, nil)
+	return cloneSSOConnection(conn), nil
+}
+
+func (s *Store) ListSSOConnections(ctx context.Context, actorUserID string, teamID string) ([]ports.SSOConnection, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	team, err := s.ownedTeamLocked(actorUserID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]ports.SSOConnection, 0)
+	for _, conn := range s.ssoConnectionsByID {
+		if conn.TeamID == team.TeamID {
+			items = append(items, cloneSSOConnection(conn))
+		}
+	}
+	sort.Slice(items, func(i int, j int) bool {
+		return items[i].CreatedAt.Before(items[j].CreatedAt)
+	})
+	return items, nil
+}
+
+func (s *Store) GetSSOConnection(ctx context.Context, connectionID string) (ports.SSOConnection, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	conn, ok := s.ssoConnectionsByID[strings.TrimSpace(connectionID)]
+	if !ok {
+		return ports.SSOConnection{}, domainerrors.ErrNotFound
+	}
+	return cloneSSOConnection(conn), nil
+}
+
+// CreateSSOLoginState also drops states whose login window has closed.
+func (s *Store) CreateSSOLoginState(ctx context.Context, login ports.SSOLoginState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if strings.TrimSpace(login.StateHash) == "" {
+		return domainerrors.ErrInvalidRequest
+	}
+	if _, exists := s.ssoLoginStates[login.StateHash]; exists {
+		return domainerrors.ErrConflict
+	}
+	for hash, item := range s.ssoLoginStates {
+		if !login.CreatedAt.Before(item.ExpiresAt) {
+			delete(s.ssoLoginStates, hash)
+		}
+	}
+	s.ssoLoginStates[login.StateHash] = login
+	return nil
+}
+
+// ConsumeSSOLoginState removes the state whether or not it is still
+// valid, so each IdP answer is accepted at most once.
+func (s *Store) ConsumeSSOLoginState(ctx context.Context, stateHash string, now time.Time) (ports.SSOLoginState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	login, ok := s.ssoLoginStates[strings.TrimSpace(stateHash)]
+	if !ok {
+		return ports.SSOLoginState{}, domainerrors.ErrSSOLoginExpired
+	}
+	delete(s.ssoLoginStates, login.StateHash)
+	if !now.UTC().Before(login.ExpiresAt) {
+		return ports.SSOLoginState{}, domainerrors.ErrSSOLoginExpired
+	}
+	return login, nil
+}
+
+func (s *Store) ProvisionSSOMember(ctx context.Context, conn ports.SSOConnection, assertion ports.SSOAssertion, role string, now time.Time) (ports.SSOProvisionResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	team, ok := s.teamsByID[conn.TeamID]
+	if !ok {
+		return ports.SSOProvisionResult{}, domainerrors.ErrTeamNotFound
+	}
+	now = now.UTC()
+	identity, found, err := s.loginIdentityLocked(conn, assertion)
+	if err != nil {
+		return ports.SSOProvisionResult{}, err
+	}
+	if !found {
+		if role == "" {
+			return ports.SSOProvisionResult{Action: ports.SSOProvisionDenied}, nil
+		}
+		userID, ok := s.userIDByEmail[assertion.Email]
+		if !ok {
+			return ports.SSOProvisionResult{}, domainerrors.ErrDependencyUnavailable
+		}
+		identity = ports.SSOIdentity{
+			IdentityID:   "ssoid_" + s.nextID("m87"),
+			ConnectionID: conn.ConnectionID,
+			TeamID:       team.TeamID,
+			UserID:       userID,
+			Active:       true,
+			CreatedAt:    now,
+		}
+	}
+	if !identity.Active {
+		return ports.SSOProvisionResult{Identity: identity, Action: ports.SSOProvisionDenied}, nil
+	}
+	identity.Subject = assertion.Subject
+	identity.Email = assertion.Email
+	identity.UpdatedAt = now
+	s.ssoIdentitiesByID[identity.IdentityID] = identity
+
+	actor := "sso:" + conn.ConnectionID
+	member, active := s.activeMemberLocked(team.TeamID, identity.UserID)
+	result := ports.SSOProvisionResult{Identity: identity, Member: cloneMember(member)}
+	switch {
+	case role == "":
+		if !active || member.Role == ports.RoleOwner {
+			result.Action = ports.SSOProvisionDenied
+			return result, nil
+		}
+		result.Member = cloneMember(s.deprovisionMemberLocked(member, actor, now))
+		result.Action = ports.SSOProvisionDeprovisioned
+	case !active:
+		result.Member = cloneMember(s.provisionMemberLocked(team.TeamID, identity.UserID, role, actor, now))
+		result.Action = ports.SSOProvisionCreated
+	case member.Role == ports.RoleOwner || member.Role == role:
+		result.Action = ports.SSOProvisionUnchanged
+	default:
+		member.Role = role
+		s.membersByID[member.MemberID] = member
+		s.addAuditLocked(team.TeamID, actor, "team.role.changed", "member", member.MemberID, now, map[string]string{
+			"new_role": role,
+			"source":   "sso",
+		})
+		result.Member = cloneMember(member)
+		result.Action = ports.SSOProvisionRoleUpdated
+	}
+	return result, nil
+}
+
+func (s *Store) ListSSOIdentities(ctx context.Context, connectionID string, query ports.SSOIdentityQuery) ([]ports.SSOIdentity, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	matches := make([]ports.SSOIdentity, 0)
+	for _, identity := range s.ssoIdentitiesByID {
+		if identity.ConnectionID != connectionID ||
+			(query.Email != "" && identity.Email != query.Email) ||
+			(query.ExternalID != "" && identity.ExternalID != query.ExternalID) {
+			continue
+		}
+		matches = append(matches, identity)
+	}
+	sort.Slice(matches, func(i int, j int) bool {
+		if matches[i].CreatedAt.Equal(matches[j].CreatedAt) {
+			return matches[i].IdentityID < matches[j].IdentityID
+		}
+		return matches[i].CreatedAt.Before(matches[j].CreatedAt)
+	})
+	total := len(matches)
+	if query.Offset >= total {
+		return []ports.SSOIdentity{}, total, nil
+	}
+	matches = matches[query.Offset:]
+	if query.Limit > 0 && len(matches) > query.Limit {
+		matches = matches[:query.Limit]
+	}
+	return matches, total, nil
+}
+
+func (s *Store) GetSSOIdentity(ctx context.Context, connectionID string, identityID string) (ports.SSOIdentity, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	identity, ok := s.ssoIdentitiesByID[identityID]
+	if !ok || identity.ConnectionID != connectionID {
+		return ports.SSOIdentity{}, domainerrors.ErrNotFound
+	}
+	return identity, nil
+}
+
+func (s *Store) CreateSSOIdentity(ctx context.Context, conn ports.SSOConnection, identity ports.SSOIdentity, role string, now time.Time) (ports.SSOIdentity, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	team, ok := s.teamsByID[conn.TeamID]
+	if !ok {
+		return ports.SSOIdentity{}, domainerrors.ErrTeamNotFound
+	}
+	userID, ok := s.userIDByEmail[identity.Email]
+	if !ok {
+		return ports.SSOIdentity{}, domainerrors.ErrDependencyUnavailable
+	}
+	for _, existing := range s.ssoIdentitiesByID {
+		if existing.ConnectionID == conn.ConnectionID &&
+			(existing.UserID == userID || (identity.ExternalID != "" && existing.ExternalID == identity.ExternalID)) {
+			return ports.SSOIdentity{}, domainerrors.ErrConflict
+		}
+	}
+	now = now.UTC()
+	identity.IdentityID = "ssoid_" + s.nextID("m87")
+	identity.ConnectionID = conn.ConnectionID
+	identity.TeamID = team.TeamID
+	identity.UserID = userID
+	identity.CreatedAt = now
+	identity.UpdatedAt = now
+	s.ssoIdentitiesByID[identity.IdentityID] = identity
+
+	actor := "scim:" + conn.ConnectionID
+	s.addAuditLocked(team.TeamID, actor, "team.sso.identity_provisioned", "sso_identity", identity.IdentityID, now, map[string]string{
+		"user_id": userID,
+	})
+	if identity.Active {
+		if _, active := s.activeMemberLocked(team.TeamID, userID); !active {
+			s.provisionMemberLocked(team.TeamID, userID, role, actor, now)
+		}
+	}
+	return identity, nil
+}
+
+func (s *Store) SetSSOIdentityActive(ctx context.Context, conn ports.SSOConnection, identityID string, active bool, role string, now time.Time) (ports.SSOIdentity, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	identity, ok := s.ssoIdentitiesByID[identityID]
+	if !ok || identity.ConnectionID != conn.ConnectionID {
+		return ports.SSOIdentity{}, domainerrors.ErrNotFound
+	}
+	now = now.UTC()
+	actor := "scim:" + conn.ConnectionID
+	member, isMember := s.activeMemberLocked(identity.TeamID, identity.UserID)
+	switch {
+	case active && !isMember:
+		s.provisionMemberLocked(identity.TeamID, identity.UserID, role, actor, now)
+	case !active && isMember:
+		if member.Role == ports.RoleOwner {
+			return ports.SSOIdentity{}, domainerrors.ErrOwnerTransferRequired
+		}
+		s.deprovisionMemberLocked(member, actor, now)
+	}
+	identity.Active = active
+	identity.UpdatedAt = now
+	s.ssoIdentitiesByID[identity.IdentityID] = identity
+	return identity, nil
+}
+
+func (s *Store) DeleteSSOIdentity(ctx context.Context, conn ports.SSOConnection, identityID string, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	identity, ok := s.ssoIdentitiesByID[identityID]
+	if !ok || identity.ConnectionID != conn.ConnectionID {
+		return domainerrors.ErrNotFound
+	}
+	now = now.UTC()
+	actor := "scim:" + conn.ConnectionID
+	if member, isMember := s.activeMemberLocked(identity.TeamID, identity.UserID); isMember {
+		if member.Role == ports.RoleOwner {
+			return domainerrors.ErrOwnerTransferRequired
+		}
+		s.deprovisionMemberLocked(member, actor, now)
+	}
+	delete(s.ssoIdentitiesByID, identity.IdentityID)
+	s.addAuditLocked(identity.TeamID, actor, "team.sso.identity_deleted", "sso_identity", identity.IdentityID, now, map[string]string{
+		"user_id": identity.UserID,
+	})
+	return nil
+}
+
+// loginIdentityLocked finds the identity a login belongs to: by IdP
+// subject, else the not yet logged-in identity of the M01 user with the
+// asserted email.
+func (s *Store) loginIdentityLocked(conn ports.SSOConnection, assertion ports.SSOAssertion) (ports.SSOIdentity, bool, error) {
+	for _, identity := range s.ssoIdentitiesByID {
+		if identity.ConnectionID == conn.ConnectionID && identity.Subject == assertion.Subject {
+			return identity, true, nil
+		}
+	}
+	userID, ok := s.userIDByEmail[assertion.Email]
+	if !ok {
+		return ports.SSOIdentity{}, false, nil
+	}
+	for _, identity := range s.ssoIdentitiesByID {
+		if identity.ConnectionID == conn.ConnectionID && identity.UserID == userID {
+			// The account is already linked to a different IdP user.
+			if identity.Subject != "" {
+				return ports.SSOIdentity{}, false, domainerrors.ErrForbidden
+			}
+			return identity, true, nil
+		}
+	}
+	return ports.SSOIdentity{}, false, nil
+}
+
+func (s *Store) activeMemberLocked(teamID string, userID string) (ports.TeamMember, bool) {
+	memberID, ok := s.memberIDByTeamUser[teamUserKey(teamID, userID)]
+	if !ok {
+		return ports.TeamMember{}, false
+	}
+	member, ok := s.membersByID[memberID]
+	if !ok || member.Status != "active" {
+		return ports.TeamMember{}, false
+	}
+	return member, true
+}
+
+func (s *Store) provisionMemberLocked(teamID string, userID string, role string, actor string, now time.Time) ports.TeamMember {
+	member := ports.TeamMember{
+		MemberID: "member_" + s.nextID("m87"),
+		TeamID:   teamID,
+		UserID:   userID,
+		Role:     role,
+		Status:   "active",
+		JoinedAt: now,
+	}
+	s.membersByID[member.MemberID] = member
+	s.memberIDByTeamUser[teamUserKey(teamID, userID)] = member.MemberID
+	s.addAuditLocked(teamID, actor, "team.member.provisioned", "member", member.MemberID, now, map[string]string{
+		"user_id": userID,
+		"role":    role,
+	})
+	return member
+}
+
+func (s *Store) deprovisionMemberLocked(member ports.TeamMember, actor string, now time.Time) ports.TeamMember {
+	member.Status = "removed"
+	member.RemovedAt = &now
+	s.membersByID[member.MemberID] = member
+	delete(s.memberIDByTeamUser, teamUserKey(member.TeamID, member.UserID))
+	s.addAuditLocked(member.TeamID, actor, "team.member.deprovisioned", "member", member.MemberID, now, map[string]string{
+		"user_id": member.UserID,
+	})
+	return member
+}
+
+func (s *Store) ownedTeamLocked(actorUserID string, teamID string) (ports.Team, error) {
+	if err := s.ensureUserExistsLocked(actorUserID); err != nil {
+		return ports.Team{}, err
+	}
+	team, ok := s.teamsByID[strings.TrimSpace(teamID)]
+	if !ok {
+		return ports.Team{}, domainerrors.ErrTeamNotFound
+	}
+	member, active := s.activeMemberLocked(team.TeamID, actorUserID)
+	if !active || member.Role != ports.RoleOwner {
+		return ports.Team{}, domainerrors.ErrForbidden
+	}
+	return team, nil
+}
+
+func (s *Store) ownedSSOConnectionLocked(actorUserID string, teamID string, connectionID string) (ports.SSOConnection, error) {
+	team, err := s.ownedTeamLocked(actorUserID, teamID)
+	if err != nil {
+		return ports.SSOConnection{}, err
+	}
+	conn, ok := s.ssoConnectionsByID[strings.TrimSpace(connectionID)]
+	if !ok || conn.TeamID != team.TeamID {
+		return ports.SSOConnection{}, domainerrors.ErrNotFound
+	}
+	return conn, nil
+}
+
+func cloneSSOConnection(in ports.SSOConnection) ports.SSOConnection {
+	out := in
+	out.AllowedDomains = append([]string(nil), in.AllowedDomains...)
+	out.RoleMappings = append([]ports.SSORoleMapping(nil), in.RoleMappings...)
+	out.OIDC.Scopes = append([]string(nil), in.OIDC.Scopes...)
+	return out
+}
+
+var _ ports.SSORepository = (*Store)(nil)
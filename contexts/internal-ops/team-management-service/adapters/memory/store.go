@@ -3,8 +3,6 @@ package memory
 import (
 	"context"
 	"fmt"
-	"net/mail"
-	"regexp"
 	"sort"
 	"strings"
 	"sync"
@@ -12,27 +10,34 @@ import (
 	"time"
 
 	domainerrors "solomon/contexts/internal-ops/team-management-service/domain/errors"
+	domainservices "solomon/contexts/internal-ops/team-management-service/domain/services"
 	"solomon/contexts/internal-ops/team-management-service/ports"
 )
 
-var teamNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9 -]{1,118}[A-Za-z0-9]$`)
-
 type userProjection struct {
 	Email      string
 	MFAEnabled bool
+	UpdatedAt  time.Time
 }
 
 type Store struct {
 	mu sync.RWMutex
 
-	teamsByID                     map[string]ports.Team
-	membersByID                   map[string]ports.TeamMember
-	memberIDByTeamUser            map[string]string
-	invitesByToken                map[string]ports.TeamInvite
-	inviteTokenByTeamEmailPending map[string]string
-	auditLogsByTeamID             map[string][]ports.TeamAuditLog
-
-	// DBR:M01-Authentication-Service owner_api read-only projection.
+	teamsByID          map[string]ports.Team
+	membersByID        map[string]ports.TeamMember
+	memberIDByTeamUser map[string]string
+	invitesByID        map[string]ports.TeamInvite
+	// Token hashes stay indexed after an invite closes so accepting it can
+	// report why; only a resend drops the previous hash.
+	inviteIDByTokenHash        map[string]string
+	inviteIDByTeamEmailPending map[string]string
+	auditLogsByTeamID          map[string][]ports.TeamAuditLog
+
+	ssoConnectionsByID map[string]ports.SSOConnection
+	ssoLoginStates     map[string]ports.SSOLoginState
+	ssoIdentitiesByID  map[string]ports.SSOIdentity
+
+	// DBR:M01-Authentication-Service projection, written by directory syncs.
 	usersByID     map[string]userProjection
 	userIDByEmail map[string]string
 
@@ -51,16 +56,20 @@ func NewStore() *Store {
 	}
 
 	store := &Store{
-		teamsByID:                     make(map[string]ports.Team),
-		membersByID:                   make(map[string]ports.TeamMember),
-		memberIDByTeamUser:            make(map[string]string),
-		invitesByToken:                make(map[string]ports.TeamInvite),
-		inviteTokenByTeamEmailPending: make(map[string]string),
-		auditLogsByTeamID:             make(map[string][]ports.TeamAuditLog),
-		usersByID:                     make(map[string]userProjection, len(users)),
-		userIDByEmail:                 make(map[string]string, len(users)),
-		idempotency:                   make(map[string]ports.IdempotencyRecord),
-		sequence:                      1,
+		teamsByID:                  make(map[string]ports.Team),
+		membersByID:                make(map[string]ports.TeamMember),
+		memberIDByTeamUser:         make(map[string]string),
+		invitesByID:                make(map[string]ports.TeamInvite),
+		inviteIDByTokenHash:        make(map[string]string),
+		inviteIDByTeamEmailPending: make(map[string]string),
+		auditLogsByTeamID:          make(map[string][]ports.TeamAuditLog),
+		ssoConnectionsByID:         make(map[string]ports.SSOConnection),
+		ssoLoginStates:             make(map[string]ports.SSOLoginState),
+		ssoIdentitiesByID:          make(map[string]ports.SSOIdentity),
+		usersByID:                  make(map[string]userProjection, len(users)),
+		userIDByEmail:              make(map[string]string, len(users)),
+		idempotency:                make(map[string]ports.IdempotencyRecord),
+		sequence:                   1,
 	}
 
 	for id, user := range users {
@@ -114,7 +123,7 @@ func (s *Store) CreateTeam(ctx context.Context, actorUserID string, input ports.
 	if err := s.ensureUserExistsLocked(actorUserID); err != nil {
 		return ports.Team{}, err
 	}
-	if !isValidTeamName(input.Name) || strings.TrimSpace(input.OrgID) == "" {
+	if !domainservices.IsValidTeamName(input.Name) || strings.TrimSpace(input.OrgID) == "" {
 		return ports.Team{}, domainerrors.ErrInvalidRequest
 	}
 
@@ -156,6 +165,8 @@ func (s *Store) CreateInvite(
 	teamID string,
 	email string,
 	role string,
+	tokenHash string,
+	expiresAt time.Time,
 	now time.Time,
 ) (ports.TeamInvite, error) {
 	s.mu.Lock()
@@ -171,69 +182,76 @@ func (s *Store) CreateInvite(
 	if _, err := s.requireManagerOrOwnerLocked(team.TeamID, actorUserID); err != nil {
 		return ports.TeamInvite{}, err
 	}
-	if !ports.IsValidRole(role) || role == ports.RoleOwner {
+	if !ports.IsValidRole(role) || role == ports.RoleOwner || strings.TrimSpace(tokenHash) == "" {
 		return ports.TeamInvite{}, domainerrors.ErrInvalidRequest
 	}
 
-	normalizedEmail, err := normalizeEmail(email)
+	normalizedEmail, err := domainservices.NormalizeEmail(email)
 	if err != nil {
 		return ports.TeamInvite{}, err
 	}
 	pendingKey := teamEmailKey(team.TeamID, normalizedEmail)
-	if _, exists := s.inviteTokenByTeamEmailPending[pendingKey]; exists {
+	if _, exists := s.inviteIDByTeamEmailPending[pendingKey]; exists {
 		return ports.TeamInvite{}, domainerrors.ErrConflict
 	}
-	if invitedUserID, exists := s.userIDByEmail[normalizedEmail]; exists {
-		if _, memberExists := s.memberIDByTeamUser[teamUserKey(team.TeamID, invitedUserID)]; memberExists {
-			return ports.TeamInvite{}, domainerrors.ErrConflict
-		}
+	if err := s.ensureNotMemberLocked(team.TeamID, normalizedEmail); err != nil {
+		return ports.TeamInvite{}, err
+	}
+	if _, exists := s.inviteIDByTokenHash[tokenHash]; exists {
+		return ports.TeamInvite{}, domainerrors.ErrConflict
 	}
 
 	now = now.UTC()
 	inviteID := "invite_" + s.nextID("m87")
-	token := "token_" + s.nextID("m87")
 	item := ports.TeamInvite{
 		InviteID:  inviteID,
 		TeamID:    team.TeamID,
+		TeamName:  team.Name,
 		Email:     normalizedEmail,
 		Role:      role,
-		Token:     token,
-		Status:    "pending",
-		ExpiresAt: now.Add(7 * 24 * time.Hour),
+		TokenHash: tokenHash,
+		Status:    ports.InviteStatusPending,
+		ExpiresAt: expiresAt.UTC(),
 		CreatedBy: actorUserID,
 		CreatedAt: now,
 		UpdatedAt: now,
+		SentCount: 1,
 	}
-	s.invitesByToken[token] = item
-	s.inviteTokenByTeamEmailPending[pendingKey] = token
+	s.invitesByID[inviteID] = item
+	s.inviteIDByTokenHash[tokenHash] = inviteID
+	s.inviteIDByTeamEmailPending[pendingKey] = inviteID
 	s.addAuditLocked(team.TeamID, actorUserID, "team.invite.sent", "invite", inviteID, now, map[string]string{
 		"email": normalizedEmail,
 		"role":  role,
 	})
-	return item, nil
+	return cloneInvite(item), nil
 }
 
-func (s *Store) AcceptInvite(ctx context.Context, actorUserID string, token string, now time.Time) (ports.Membership, error) {
+func (s *Store) AcceptInvite(ctx context.Context, actorUserID string, tokenHash string, now time.Time) (ports.Membership, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	if err := s.ensureUserExistsLocked(actorUserID); err != nil {
 		return ports.Membership{}, err
 	}
-	invite, ok := s.invitesByToken[strings.TrimSpace(token)]
+	inviteID, ok := s.inviteIDByTokenHash[strings.TrimSpace(tokenHash)]
 	if !ok {
 		return ports.Membership{}, domainerrors.ErrInviteNotFound
 	}
-	if invite.Status != "pending" {
+	invite := s.invitesByID[inviteID]
+	switch invite.Status {
+	case ports.InviteStatusPending:
+	case ports.InviteStatusExpired:
+		return ports.Membership{}, domainerrors.ErrInviteExpired
+	case ports.InviteStatusRevoked:
+		return ports.Membership{}, domainerrors.ErrInviteRevoked
+	default:
 		return ports.Membership{}, domainerrors.ErrInvalidRequest
 	}
 
 	now = now.UTC()
-	if now.After(invite.ExpiresAt.UTC()) {
-		invite.Status = "expired"
-		invite.UpdatedAt = now
-		s.invitesByToken[invite.Token] = invite
-		delete(s.inviteTokenByTeamEmailPending, teamEmailKey(invite.TeamID, invite.Email))
+	if !now.Before(invite.ExpiresAt.UTC()) {
+		s.expireInviteLocked(invite, now)
 		return ports.Membership{}, domainerrors.ErrInviteExpired
 	}
 
@@ -258,12 +276,12 @@ func (s *Store) AcceptInvite(ctx context.Context, actorUserID string, token stri
 	s.membersByID[memberID] = member
 	s.memberIDByTeamUser[teamUserKey(invite.TeamID, actorUserID)] = memberID
 
-	invite.Status = "accepted"
+	invite.Status = ports.InviteStatusAccepted
 	invite.AcceptedBy = actorUserID
 	invite.AcceptedAt = &now
 	invite.UpdatedAt = now
-	s.invitesByToken[invite.Token] = invite
-	delete(s.inviteTokenByTeamEmailPending, teamEmailKey(invite.TeamID, invite.Email))
+	s.invitesByID[invite.InviteID] = invite
+	delete(s.inviteIDByTeamEmailPending, teamEmailKey(invite.TeamID, invite.Email))
 
 	s.addAuditLocked(invite.TeamID, actorUserID, "team.invite.accepted", "invite", invite.InviteID, now, map[string]string{
 		"member_id": memberID,
@@ -280,6 +298,112 @@ func (s *Store) AcceptInvite(ctx context.Context, actorUserID string, token stri
 	}, nil
 }
 
+// ResendInvite rotates the token of a pending or expired invite and pushes
+// its expiry out. The previous token stops working immediately.
+func (s *Store) ResendInvite(
+	ctx context.Context,
+	actorUserID string,
+	teamID string,
+	inviteID string,
+	tokenHash string,
+	expiresAt time.Time,
+	now time.Time,
+) (ports.TeamInvite, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	invite, err := s.manageableInviteLocked(actorUserID, teamID, inviteID)
+	if err != nil {
+		return ports.TeamInvite{}, err
+	}
+	if strings.TrimSpace(tokenHash) == "" {
+		return ports.TeamInvite{}, domainerrors.ErrInvalidRequest
+	}
+	if _, exists := s.inviteIDByTokenHash[tokenHash]; exists {
+		return ports.TeamInvite{}, domainerrors.ErrConflict
+	}
+	pendingKey := teamEmailKey(invite.TeamID, invite.Email)
+	switch invite.Status {
+	case ports.InviteStatusPending:
+	case ports.InviteStatusExpired:
+		if _, exists := s.inviteIDByTeamEmailPending[pendingKey]; exists {
+			return ports.TeamInvite{}, domainerrors.ErrConflict
+		}
+		if err := s.ensureNotMemberLocked(invite.TeamID, invite.Email); err != nil {
+			return ports.TeamInvite{}, err
+		}
+	default:
+		return ports.TeamInvite{}, domainerrors.ErrConflict
+	}
+
+	now = now.UTC()
+	delete(s.inviteIDByTokenHash, invite.TokenHash)
+	invite.TokenHash = tokenHash
+	invite.Status = ports.InviteStatusPending
+	invite.ExpiresAt = expiresAt.UTC()
+	invite.UpdatedAt = now
+	invite.SentCount++
+	s.invitesByID[invite.InviteID] = invite
+	s.inviteIDByTokenHash[tokenHash] = invite.InviteID
+	s.inviteIDByTeamEmailPending[pendingKey] = invite.InviteID
+	s.addAuditLocked(invite.TeamID, actorUserID, "team.invite.resent", "invite", invite.InviteID, now, map[string]string{
+		"email":      invite.Email,
+		"expires_at": invite.ExpiresAt.Format(time.RFC3339),
+	})
+	return cloneInvite(invite), nil
+}
+
+func (s *Store) RevokeInvite(ctx context.Context, actorUserID string, teamID string, inviteID string, now time.Time) (ports.TeamInvite, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	invite, err := s.manageableInviteLocked(actorUserID, teamID, inviteID)
+	if err != nil {
+		return ports.TeamInvite{}, err
+	}
+	if invite.Status != ports.InviteStatusPending {
+		return ports.TeamInvite{}, domainerrors.ErrConflict
+	}
+
+	now = now.UTC()
+	invite.Status = ports.InviteStatusRevoked
+	invite.RevokedBy = actorUserID
+	invite.RevokedAt = &now
+	invite.UpdatedAt = now
+	s.invitesByID[invite.InviteID] = invite
+	delete(s.inviteIDByTeamEmailPending, teamEmailKey(invite.TeamID, invite.Email))
+	s.addAuditLocked(invite.TeamID, actorUserID, "team.invite.revoked", "invite", invite.InviteID, now, map[string]string{
+		"email": invite.Email,
+	})
+	return cloneInvite(invite), nil
+}
+
+// ExpireInvites marks pending invites whose expiry has passed as expired,
+// oldest expiry first.
+func (s *Store) ExpireInvites(ctx context.Context, now time.Time, limit int) ([]ports.TeamInvite, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now = now.UTC()
+	stale := make([]ports.TeamInvite, 0)
+	for _, invite := range s.invitesByID {
+		if invite.Status == ports.InviteStatusPending && !now.Before(invite.ExpiresAt.UTC()) {
+			stale = append(stale, invite)
+		}
+	}
+	sort.Slice(stale, func(i int, j int) bool {
+		return stale[i].ExpiresAt.Before(stale[j].ExpiresAt)
+	})
+	if limit > 0 && len(stale) > limit {
+		stale = stale[:limit]
+	}
+	out := make([]ports.TeamInvite, 0, len(stale))
+	for _, invite := range stale {
+		out = append(out, cloneInvite(s.expireInviteLocked(invite, now)))
+	}
+	return out, nil
+}
+
 func (s *Store) UpdateMemberRole(
 	ctx context.Context,
 	actorUserID string,
@@ -318,7 +442,9 @@ func (s *Store) UpdateMemberRole(
 	if member.Status != "active" {
 		return ports.TeamMember{}, domainerrors.ErrConflict
 	}
-	if member.Role == ports.RoleOwner && newRole != ports.RoleOwner {
+	// A team has exactly one owner: the owner cannot be demoted and nobody
+	// else can be promoted here.
+	if (member.Role == ports.RoleOwner) != (newRole == ports.RoleOwner) {
 		return ports.TeamMember{}, domainerrors.ErrOwnerTransferRequired
 	}
 	if actorMember.Role != ports.RoleOwner && member.Role == ports.RoleOwner {
@@ -405,8 +531,8 @@ func (s *Store) GetTeamDashboard(ctx context.Context, actorUserID string, teamID
 	})
 
 	invites := make([]ports.TeamInvite, 0)
-	for _, invite := range s.invitesByToken {
-		if invite.TeamID == team.TeamID && invite.Status == "pending" {
+	for _, invite := range s.invitesByID {
+		if invite.TeamID == team.TeamID && invite.Status == ports.InviteStatusPending {
 			invites = append(invites, cloneInvite(invite))
 		}
 	}
@@ -474,26 +600,77 @@ func (s *Store) ListAuditLogs(ctx context.Context, actorUserID string, teamID st
 	return out, nil
 }
 
-func (s *Store) CreateMembersExport(ctx context.Context, actorUserID string, teamID string, now time.Time) (ports.MemberExportJob, error) {
+func (s *Store) AuthorizeMembersExport(ctx context.Context, actorUserID string, teamID string, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	team, ok := s.teamsByID[strings.TrimSpace(teamID)]
+	if !ok {
+		return domainerrors.ErrTeamNotFound
+	}
+	if _, err := s.requireManagerOrOwnerLocked(team.TeamID, actorUserID); err != nil {
+		return err
+	}
+	s.addAuditLocked(team.TeamID, actorUserID, "team.members.export_requested", "team", team.TeamID, now.UTC(), nil)
+	return nil
+}
+
+func (s *Store) StreamMembers(ctx context.Context, actorUserID string, teamID string, limit int, fn func(ports.MemberExportRow) error) error {
+	rows, err := s.memberExportRows(actorUserID, teamID, limit)
+	if err != nil {
+		return err
+	}
+	// fn runs without the lock so slow export writers never block the
+	// store.
+	for _, row := range rows {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Store) memberExportRows(actorUserID string, teamID string, limit int) ([]ports.MemberExportRow, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	team, ok := s.teamsByID[strings.TrimSpace(teamID)]
 	if !ok {
-		return ports.MemberExportJob{}, domainerrors.ErrTeamNotFound
+		return nil, domainerrors.ErrTeamNotFound
 	}
 	if _, err := s.requireManagerOrOwnerLocked(team.TeamID, actorUserID); err != nil {
-		return ports.MemberExportJob{}, err
+		return nil, err
 	}
-
-	now = now.UTC()
-	return ports.MemberExportJob{
-		ExportJobID:           "export_" + s.peekID("m87"),
-		TeamID:                team.TeamID,
-		Status:                "queued",
-		CreatedAt:             now,
-		EstimatedCompletionAt: now.Add(30 * time.Second),
-	}, nil
+	members := make([]ports.TeamMember, 0)
+	for _, item := range s.membersByID {
+		if item.TeamID == team.TeamID && item.Status == "active" {
+			members = append(members, item)
+		}
+	}
+	sort.Slice(members, func(i int, j int) bool {
+		if members[i].JoinedAt.Equal(members[j].JoinedAt) {
+			return members[i].MemberID < members[j].MemberID
+		}
+		return members[i].JoinedAt.Before(members[j].JoinedAt)
+	})
+	if limit > 0 && len(members) > limit {
+		members = members[:limit]
+	}
+	rows := make([]ports.MemberExportRow, 0, len(members))
+	for _, member := range members {
+		rows = append(rows, ports.MemberExportRow{
+			MemberID:     member.MemberID,
+			UserID:       member.UserID,
+			Email:        s.usersByID[member.UserID].Email,
+			Role:         member.Role,
+			JoinedAt:     member.JoinedAt,
+			LastActiveAt: cloneMember(member).LastActiveAt,
+		})
+	}
+	return rows, nil
 }
 
 func (s *Store) Get(ctx context.Context, key string, now time.Time) (ports.IdempotencyRecord, bool, error) {
@@ -538,11 +715,6 @@ func (s *Store) nextID(prefix string) string {
 	return fmt.Sprintf("%s_%d", prefix, n)
 }
 
-func (s *Store) peekID(prefix string) string {
-	n := atomic.LoadUint64(&s.sequence) + 1
-	return fmt.Sprintf("%s_%d", prefix, n)
-}
-
 func (s *Store) addAuditLocked(teamID string, actorUserID string, action string, targetType string, targetID string, now time.Time, metadata map[string]string) {
 	record := ports.TeamAuditLog{
 		AuditID:     "audit_" + s.nextID("m87"),
@@ -557,6 +729,45 @@ func (s *Store) addAuditLocked(teamID string, actorUserID string, action string,
 	s.auditLogsByTeamID[teamID] = append(s.auditLogsByTeamID[teamID], record)
 }
 
+func (s *Store) expireInviteLocked(invite ports.TeamInvite, now time.Time) ports.TeamInvite {
+	invite.Status = ports.InviteStatusExpired
+	invite.UpdatedAt = now
+	s.invitesByID[invite.InviteID] = invite
+	delete(s.inviteIDByTeamEmailPending, teamEmailKey(invite.TeamID, invite.Email))
+	s.addAuditLocked(invite.TeamID, "system", "team.invite.expired", "invite", invite.InviteID, now, map[string]string{
+		"email":      invite.Email,
+		"expires_at": invite.ExpiresAt.UTC().Format(time.RFC3339),
+	})
+	return invite
+}
+
+func (s *Store) manageableInviteLocked(actorUserID string, teamID string, inviteID string) (ports.TeamInvite, error) {
+	if err := s.ensureUserExistsLocked(actorUserID); err != nil {
+		return ports.TeamInvite{}, err
+	}
+	team, ok := s.teamsByID[strings.TrimSpace(teamID)]
+	if !ok {
+		return ports.TeamInvite{}, domainerrors.ErrTeamNotFound
+	}
+	if _, err := s.requireManagerOrOwnerLocked(team.TeamID, actorUserID); err != nil {
+		return ports.TeamInvite{}, err
+	}
+	invite, ok := s.invitesByID[strings.TrimSpace(inviteID)]
+	if !ok || invite.TeamID != team.TeamID {
+		return ports.TeamInvite{}, domainerrors.ErrInviteNotFound
+	}
+	return invite, nil
+}
+
+func (s *Store) ensureNotMemberLocked(teamID string, email string) error {
+	if invitedUserID, exists := s.userIDByEmail[email]; exists {
+		if _, memberExists := s.memberIDByTeamUser[teamUserKey(teamID, invitedUserID)]; memberExists {
+			return domainerrors.ErrConflict
+		}
+	}
+	return nil
+}
+
 func (s *Store) requireManagerOrOwnerLocked(teamID string, actorUserID string) (ports.TeamMember, error) {
 	memberID, ok := s.memberIDByTeamUser[teamUserKey(teamID, actorUserID)]
 	if !ok {
@@ -572,6 +783,35 @@ func (s *Store) requireManagerOrOwnerLocked(teamID string, actorUserID string) (
 	return member, nil
 }
 
+// UpsertDirectoryUser applies an M01 account sync unless the stored row is
+// newer. An email still held by another account is a conflict until that
+// account's own change arrives.
+func (s *Store) UpsertDirectoryUser(_ context.Context, user ports.DirectoryUser) (ports.DirectoryUser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, exists := s.usersByID[user.UserID]
+	if exists && current.UpdatedAt.After(user.UpdatedAt) {
+		return ports.DirectoryUser{
+			UserID:     user.UserID,
+			Email:      current.Email,
+			MFAEnabled: current.MFAEnabled,
+			UpdatedAt:  current.UpdatedAt,
+		}, nil
+	}
+	email := strings.ToLower(strings.TrimSpace(user.Email))
+	if holder, taken := s.userIDByEmail[email]; taken && holder != user.UserID {
+		return ports.DirectoryUser{}, domainerrors.ErrConflict
+	}
+	if exists {
+		delete(s.userIDByEmail, strings.ToLower(strings.TrimSpace(current.Email)))
+	}
+	s.usersByID[user.UserID] = userProjection{Email: email, MFAEnabled: user.MFAEnabled, UpdatedAt: user.UpdatedAt}
+	s.userIDByEmail[email] = user.UserID
+	user.Email = email
+	return user, nil
+}
+
 func (s *Store) ensureUserExistsLocked(userID string) error {
 	if _, ok := s.usersByID[strings.TrimSpace(userID)]; !ok {
 		return domainerrors.ErrDependencyUnavailable
@@ -579,26 +819,6 @@ func (s *Store) ensureUserExistsLocked(userID string) error {
 	return nil
 }
 
-func normalizeEmail(email string) (string, error) {
-	addr, err := mail.ParseAddress(strings.TrimSpace(email))
-	if err != nil {
-		return "", domainerrors.ErrInvalidRequest
-	}
-	normalized := strings.ToLower(strings.TrimSpace(addr.Address))
-	if normalized == "" {
-		return "", domainerrors.ErrInvalidRequest
-	}
-	return normalized, nil
-}
-
-func isValidTeamName(name string) bool {
-	trimmed := strings.TrimSpace(name)
-	if len(trimmed) < 3 || len(trimmed) > 120 {
-		return false
-	}
-	return teamNamePattern.MatchString(trimmed)
-}
-
 func teamUserKey(teamID string, userID string) string {
 	return strings.TrimSpace(teamID) + "|" + strings.TrimSpace(userID)
 }
@@ -641,6 +861,10 @@ func cloneInvite(in ports.TeamInvite) ports.TeamInvite {
 		t := in.AcceptedAt.UTC()
 		out.AcceptedAt = &t
 	}
+	if in.RevokedAt != nil {
+		t := in.RevokedAt.UTC()
+		out.RevokedAt = &t
+	}
 	return out
 }
 
@@ -654,3 +878,4 @@ var _ ports.Repository = (*Store)(nil)
 var _ ports.IdempotencyStore = (*Store)(nil)
 var _ ports.Clock = (*Store)(nil)
 var _ ports.IDGenerator = (*Store)(nil)
+var _ ports.UserDirectory = (*Store)(nil)
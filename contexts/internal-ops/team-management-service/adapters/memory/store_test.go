@@ -33,11 +33,11 @@ func TestAcceptInviteRequiresMatchingM01Email(t *testing.T) {
 	store := NewStore()
 	now := time.Now().UTC()
 
-	invite, err := store.CreateInvite(context.Background(), "user_owner_1", "team_seed_1", "editor@example.com", ports.RoleEditor, now)
+	invite, err := store.CreateInvite(context.Background(), "user_owner_1", "team_seed_1", "editor@example.com", ports.RoleEditor, "hash_1", now.Add(24*time.Hour), now)
 	if err != nil {
 		t.Fatalf("create invite failed: %v", err)
 	}
-	_, err = store.AcceptInvite(context.Background(), "user_viewer_1", invite.Token, now.Add(time.Hour))
+	_, err = store.AcceptInvite(context.Background(), "user_viewer_1", invite.TokenHash, now.Add(time.Hour))
 	if err == nil {
 		t.Fatal("expected forbidden for mismatched email")
 	}
@@ -66,3 +66,78 @@ func TestOwnerSensitiveActionsRequireMFA(t *testing.T) {
 		t.Fatalf("expected mfa required, got %v", err)
 	}
 }
+
+func TestExpireInvitesMarksStalePendingInvitesAndAudits(t *testing.T) {
+	store := NewStore()
+	now := time.Now().UTC()
+
+	stale, err := store.CreateInvite(context.Background(), "user_owner_1", "team_seed_1", "editor@example.com", ports.RoleEditor, "hash_stale", now.Add(time.Hour), now)
+	if err != nil {
+		t.Fatalf("create stale invite failed: %v", err)
+	}
+	if _, err := store.CreateInvite(context.Background(), "user_owner_1", "team_seed_1", "viewer@example.com", ports.RoleViewer, "hash_fresh", now.Add(48*time.Hour), now); err != nil {
+		t.Fatalf("create fresh invite failed: %v", err)
+	}
+
+	expired, err := store.ExpireInvites(context.Background(), now.Add(2*time.Hour), 10)
+	if err != nil {
+		t.Fatalf("expire invites failed: %v", err)
+	}
+	if len(expired) != 1 || expired[0].InviteID != stale.InviteID || expired[0].Status != ports.InviteStatusExpired {
+		t.Fatalf("expected only the stale invite expired, got %+v", expired)
+	}
+	if _, err := store.AcceptInvite(context.Background(), "user_editor_1", "hash_stale", now.Add(2*time.Hour)); err != domainerrors.ErrInviteExpired {
+		t.Fatalf("expected expired token to report expiry, got %v", err)
+	}
+	logs, err := store.ListAuditLogs(context.Background(), "user_owner_1", "team_seed_1", 10)
+	if err != nil {
+		t.Fatalf("list audit logs failed: %v", err)
+	}
+	if logs[0].Action != "team.invite.expired" || logs[0].ActorUserID != "system" || logs[0].TargetID != stale.InviteID {
+		t.Fatalf("expected expiry audit entry first, got %+v", logs[0])
+	}
+}
+
+func TestPromotingSecondOwnerRequiresTransfer(t *testing.T) {
+	store := NewStore()
+	_, err := store.UpdateMemberRole(
+		context.Background(),
+		"user_owner_1",
+		"team_seed_1",
+		"member_seed_manager",
+		ports.RoleOwner,
+		"123456",
+		time.Now().UTC(),
+	)
+	if err != domainerrors.ErrOwnerTransferRequired {
+		t.Fatalf("expected owner transfer required, got %v", err)
+	}
+}
+
+func TestStreamMembersRequiresManagerAndKeepsJoinOrder(t *testing.T) {
+	store := NewStore()
+	now := time.Now().UTC()
+
+	if err := store.AuthorizeMembersExport(context.Background(), "user_editor_1", "team_seed_1", now); err != domainerrors.ErrForbidden {
+		t.Fatalf("expected non-member to be forbidden, got %v", err)
+	}
+	if err := store.AuthorizeMembersExport(context.Background(), "user_manager_1", "team_seed_1", now); err != nil {
+		t.Fatalf("authorize export failed: %v", err)
+	}
+	logs, _ := store.ListAuditLogs(context.Background(), "user_owner_1", "team_seed_1", 1)
+	if len(logs) != 1 || logs[0].Action != "team.members.export_requested" {
+		t.Fatalf("expected export request to be audited, got %+v", logs)
+	}
+
+	var emails []string
+	err := store.StreamMembers(context.Background(), "user_owner_1", "team_seed_1", 1, func(row ports.MemberExportRow) error {
+		emails = append(emails, row.Email)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("stream members failed: %v", err)
+	}
+	if len(emails) != 1 || emails[0] != "owner@example.com" {
+		t.Fatalf("expected the earliest member only, got %v", emails)
+	}
+}
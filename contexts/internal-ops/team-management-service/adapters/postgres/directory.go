@@ -0,0 +1,45 @@
+package postgresadapter
+
+import (
+	"context"
+
+	"solomon/contexts/internal-ops/team-management-service/ports"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UpsertDirectoryUser writes an M01 account sync into team_user_directory
+// unless the stored row is newer. The lower(email) unique index turns an
+// email still held by another account into a conflict.
+func (r *Repository) UpsertDirectoryUser(ctx context.Context, user ports.DirectoryUser) (ports.DirectoryUser, error) {
+	var stored userDirectoryModel
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"email", "mfa_enabled", "updated_at"}),
+			Where: clause.Where{Exprs: []clause.Expression{
+				clause.Expr{SQL: "team_user_directory.updated_at <= EXCLUDED.updated_at"},
+			}},
+		}).Create(&userDirectoryModel{
+			UserID:     user.UserID,
+			Email:      user.Email,
+			MFAEnabled: user.MFAEnabled,
+			UpdatedAt:  user.UpdatedAt.UTC(),
+		}).Error; err != nil {
+			return mapWriteError(err)
+		}
+		return tx.Where("user_id = ?", user.UserID).First(&stored).Error
+	})
+	if err != nil {
+		return ports.DirectoryUser{}, err
+	}
+	return ports.DirectoryUser{
+		UserID:     stored.UserID,
+		Email:      stored.Email,
+		MFAEnabled: stored.MFAEnabled,
+		UpdatedAt:  stored.UpdatedAt.UTC(),
+	}, nil
+}
+
+var _ ports.UserDirectory = (*Repository)(nil)
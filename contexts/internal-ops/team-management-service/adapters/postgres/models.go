@@ -0,0 +1,369 @@
+package postgresadapter
+
+import (
+	"encoding/json"
+	"time"
+
+	"solomon/contexts/internal-ops/team-management-service/ports"
+)
+
+type userDirectoryModel struct {
+	UserID     string    `gorm:"column:user_id;primaryKey"`
+	Email      string    `gorm:"column:email"`
+	MFAEnabled bool      `gorm:"column:mfa_enabled"`
+	UpdatedAt  time.Time `gorm:"column:updated_at"`
+}
+
+func (userDirectoryModel) TableName() string {
+	return "team_user_directory"
+}
+
+type teamModel struct {
+	TeamID       string    `gorm:"column:team_id;primaryKey"`
+	Name         string    `gorm:"column:name"`
+	OrgID        string    `gorm:"column:org_id"`
+	StorefrontID string    `gorm:"column:storefront_id"`
+	OwnerUserID  string    `gorm:"column:owner_user_id"`
+	Status       string    `gorm:"column:status"`
+	CreatedAt    time.Time `gorm:"column:created_at"`
+	UpdatedAt    time.Time `gorm:"column:updated_at"`
+}
+
+func (teamModel) TableName() string {
+	return "teams"
+}
+
+func (m teamModel) toPort() ports.Team {
+	return ports.Team{
+		TeamID:       m.TeamID,
+		Name:         m.Name,
+		OrgID:        m.OrgID,
+		StorefrontID: m.StorefrontID,
+		OwnerUserID:  m.OwnerUserID,
+		Status:       m.Status,
+		CreatedAt:    m.CreatedAt.UTC(),
+		UpdatedAt:    m.UpdatedAt.UTC(),
+	}
+}
+
+type memberModel struct {
+	MemberID     string     `gorm:"column:member_id;primaryKey"`
+	TeamID       string     `gorm:"column:team_id"`
+	UserID       string     `gorm:"column:user_id"`
+	Role         string     `gorm:"column:role"`
+	Status       string     `gorm:"column:status"`
+	LastActiveAt *time.Time `gorm:"column:last_active_at"`
+	JoinedAt     time.Time  `gorm:"column:joined_at"`
+	RemovedAt    *time.Time `gorm:"column:removed_at"`
+}
+
+func (memberModel) TableName() string {
+	return "team_members"
+}
+
+func (m memberModel) toPort() ports.TeamMember {
+	return ports.TeamMember{
+		MemberID:     m.MemberID,
+		TeamID:       m.TeamID,
+		UserID:       m.UserID,
+		Role:         m.Role,
+		Status:       m.Status,
+		LastActiveAt: utcPtr(m.LastActiveAt),
+		JoinedAt:     m.JoinedAt.UTC(),
+		RemovedAt:    utcPtr(m.RemovedAt),
+	}
+}
+
+type inviteModel struct {
+	InviteID   string     `gorm:"column:invite_id;primaryKey"`
+	TeamID     string     `gorm:"column:team_id"`
+	Email      string     `gorm:"column:email"`
+	Role       string     `gorm:"column:role"`
+	TokenHash  string     `gorm:"column:token_hash"`
+	Status     string     `gorm:"column:status"`
+	ExpiresAt  time.Time  `gorm:"column:expires_at"`
+	SentCount  int        `gorm:"column:sent_count"`
+	CreatedBy  string     `gorm:"column:created_by"`
+	CreatedAt  time.Time  `gorm:"column:created_at"`
+	UpdatedAt  time.Time  `gorm:"column:updated_at"`
+	AcceptedBy string     `gorm:"column:accepted_by"`
+	AcceptedAt *time.Time `gorm:"column:accepted_at"`
+	RevokedBy  string     `gorm:"column:revoked_by"`
+	RevokedAt  *time.Time `gorm:"column:revoked_at"`
+}
+
+func (inviteModel) TableName() string {
+	return "team_invites"
+}
+
+func (m inviteModel) toPort(teamName string) ports.TeamInvite {
+	return ports.TeamInvite{
+		InviteID:   m.InviteID,
+		TeamID:     m.TeamID,
+		TeamName:   teamName,
+		Email:      m.Email,
+		Role:       m.Role,
+		TokenHash:  m.TokenHash,
+		Status:     m.Status,
+		ExpiresAt:  m.ExpiresAt.UTC(),
+		CreatedBy:  m.CreatedBy,
+		CreatedAt:  m.CreatedAt.UTC(),
+		UpdatedAt:  m.UpdatedAt.UTC(),
+		AcceptedBy: m.AcceptedBy,
+		AcceptedAt: utcPtr(m.AcceptedAt),
+		RevokedBy:  m.RevokedBy,
+		RevokedAt:  utcPtr(m.RevokedAt),
+		SentCount:  m.SentCount,
+	}
+}
+
+type auditLogModel struct {
+	AuditID     string    `gorm:"column:audit_id;primaryKey"`
+	TeamID      string    `gorm:"column:team_id"`
+	ActorUserID string    `gorm:"column:actor_user_id"`
+	Action      string    `gorm:"column:action"`
+	TargetType  string    `gorm:"column:target_type"`
+	TargetID    string    `gorm:"column:target_id"`
+	Metadata    []byte    `gorm:"column:metadata;type:jsonb"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+}
+
+func (auditLogModel) TableName() string {
+	return "team_audit_logs"
+}
+
+func (m auditLogModel) toPort() ports.TeamAuditLog {
+	metadata := map[string]string{}
+	if len(m.Metadata) > 0 {
+		_ = json.Unmarshal(m.Metadata, &metadata)
+	}
+	return ports.TeamAuditLog{
+		AuditID:     m.AuditID,
+		TeamID:      m.TeamID,
+		ActorUserID: m.ActorUserID,
+		Action:      m.Action,
+		TargetType:  m.TargetType,
+		TargetID:    m.TargetID,
+		Metadata:    metadata,
+		CreatedAt:   m.CreatedAt.UTC(),
+	}
+}
+
+type memberExportRowModel struct {
+	MemberID     string     `gorm:"column:member_id"`
+	UserID       string     `gorm:"column:user_id"`
+	Email        string     `gorm:"column:email"`
+	Role         string     `gorm:"column:role"`
+	JoinedAt     time.Time  `gorm:"column:joined_at"`
+	LastActiveAt *time.Time `gorm:"column:last_active_at"`
+}
+
+func (m memberExportRowModel) toPort() ports.MemberExportRow {
+	return ports.MemberExportRow{
+		MemberID:     m.MemberID,
+		UserID:       m.UserID,
+		Email:        m.Email,
+		Role:         m.Role,
+		JoinedAt:     m.JoinedAt.UTC(),
+		LastActiveAt: utcPtr(m.LastActiveAt),
+	}
+}
+
+type idempotencyModel struct {
+	Key         string    `gorm:"column:key;primaryKey"`
+	RequestHash string    `gorm:"column:request_hash"`
+	Payload     []byte    `gorm:"column:payload"`
+	ExpiresAt   time.Time `gorm:"column:expires_at"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+}
+
+func (idempotencyModel) TableName() string {
+	return "team_idempotency"
+}
+
+func utcPtr(value *time.Time) *time.Time {
+	if value == nil {
+		return nil
+	}
+	utc := value.UTC()
+	return &utc
+}
+
+type ssoConnectionModel struct {
+	ConnectionID         string    `gorm:"column:connection_id;primaryKey"`
+	TeamID               string    `gorm:"column:team_id"`
+	Protocol             string    `gorm:"column:protocol"`
+	Status               string    `gorm:"column:status"`
+	AllowedDomains       []byte    `gorm:"column:allowed_domains;type:jsonb"`
+	RoleMappings         []byte    `gorm:"column:role_mappings;type:jsonb"`
+	DefaultRole          string    `gorm:"column:default_role"`
+	OIDCIssuer           string    `gorm:"column:oidc_issuer"`
+	OIDCClientID         string    `gorm:"column:oidc_client_id"`
+	OIDCClientSecret     string    `gorm:"column:oidc_client_secret"`
+	OIDCAuthorizationURL string    `gorm:"column:oidc_authorization_url"`
+	OIDCTokenURL         string    `gorm:"column:oidc_token_url"`
+	OIDCJWKSURL          string    `gorm:"column:oidc_jwks_url"`
+	OIDCScopes           []byte    `gorm:"column:oidc_scopes;type:jsonb"`
+	OIDCGroupsClaim      string    `gorm:"column:oidc_groups_claim"`
+	SAMLIdPEntityID      string    `gorm:"column:saml_idp_entity_id"`
+	SAMLSSOURL           string    `gorm:"column:saml_sso_url"`
+	SAMLCertificate      string    `gorm:"column:saml_certificate"`
+	SAMLEmailAttribute   string    `gorm:"column:saml_email_attribute"`
+	SAMLGroupsAttribute  string    `gorm:"column:saml_groups_attribute"`
+	SCIMTokenHash        string    `gorm:"column:scim_token_hash"`
+	CreatedBy            string    `gorm:"column:created_by"`
+	CreatedAt            time.Time `gorm:"column:created_at"`
+	UpdatedAt            time.Time `gorm:"column:updated_at"`
+}
+
+func (ssoConnectionModel) TableName() string {
+	return "team_sso_connections"
+}
+
+func newSSOConnectionModel(conn ports.SSOConnection) (ssoConnectionModel, error) {
+	domains, err := json.Marshal(nonNilStrings(conn.AllowedDomains))
+	if err != nil {
+		return ssoConnectionModel{}, err
+	}
+	mappings := conn.RoleMappings
+	if mappings == nil {
+		mappings = []ports.SSORoleMapping{}
+	}
+	rawMappings, err := json.Marshal(mappings)
+	if err != nil {
+		return ssoConnectionModel{}, err
+	}
+	scopes, err := json.Marshal(nonNilStrings(conn.OIDC.Scopes))
+	if err != nil {
+		return ssoConnectionModel{}, err
+	}
+	return ssoConnectionModel{
+		ConnectionID:         conn.ConnectionID,
+		TeamID:               conn.TeamID,
+		Protocol:             conn.Protocol,
+		Status:               conn.Status,
+		AllowedDomains:       domains,
+		RoleMappings:         rawMappings,
+		DefaultRole:          conn.DefaultRole,
+		OIDCIssuer:           conn.OIDC.Issuer,
+		OIDCClientID:         conn.OIDC.ClientID,
+		OIDCClientSecret:     conn.OIDC.ClientSecret,
+		OIDCAuthorizationURL: conn.OIDC.AuthorizationURL,
+		OIDCTokenURL:         conn.OIDC.TokenURL,
+		OIDCJWKSURL:          conn.OIDC.JWKSURL,
+		OIDCScopes:           scopes,
+		OIDCGroupsClaim:      conn.OIDC.GroupsClaim,
+		SAMLIdPEntityID:      conn.SAML.IdPEntityID,
+		SAMLSSOURL:           conn.SAML.SSOURL,
+		SAMLCertificate:      conn.SAML.Certificate,
+		SAMLEmailAttribute:   conn.SAML.EmailAttribute,
+		SAMLGroupsAttribute:  conn.SAML.GroupsAttribute,
+		SCIMTokenHash:        conn.SCIMTokenHash,
+		CreatedBy:            conn.CreatedBy,
+		CreatedAt:            conn.CreatedAt.UTC(),
+		UpdatedAt:            conn.UpdatedAt.UTC(),
+	}, nil
+}
+
+func (m ssoConnectionModel) toPort() ports.SSOConnection {
+	var domains, scopes []string
+	var mappings []ports.SSORoleMapping
+	_ = json.Unmarshal(m.AllowedDomains, &domains)
+	_ = json.Unmarshal(m.RoleMappings, &mappings)
+	_ = json.Unmarshal(m.OIDCScopes, &scopes)
+	return ports.SSOConnection{
+		ConnectionID:   m.ConnectionID,
+		TeamID:         m.TeamID,
+		Protocol:       m.Protocol,
+		Status:         m.Status,
+		AllowedDomains: domains,
+		RoleMappings:   mappings,
+		DefaultRole:    m.DefaultRole,
+		OIDC: ports.OIDCSettings{
+			Issuer:           m.OIDCIssuer,
+			ClientID:         m.OIDCClientID,
+			ClientSecret:     m.OIDCClientSecret,
+			AuthorizationURL: m.OIDCAuthorizationURL,
+			TokenURL:         m.OIDCTokenURL,
+			JWKSURL:          m.OIDCJWKSURL,
+			Scopes:           scopes,
+			GroupsClaim:      m.OIDCGroupsClaim,
+		},
+		SAML: ports.SAMLSettings{
+			IdPEntityID:     m.SAMLIdPEntityID,
+			SSOURL:          m.SAMLSSOURL,
+			Certificate:     m.SAMLCertificate,
+			EmailAttribute:  m.SAMLEmailAttribute,
+			GroupsAttribute: m.SAMLGroupsAttribute,
+		},
+		SCIMTokenHash: m.SCIMTokenHash,
+		CreatedBy:     m.CreatedBy,
+		CreatedAt:     m.CreatedAt.UTC(),
+		UpdatedAt:     m.UpdatedAt.UTC(),
+	}
+}
+
+type ssoLoginStateModel struct {
+	StateHash    string    `gorm:"column:state_hash;primaryKey"`
+	ConnectionID string    `gorm:"column:connection_id"`
+	Nonce        string    `gorm:"column:nonce"`
+	CodeVerifier string    `gorm:"column:code_verifier"`
+	RequestID    string    `gorm:"column:request_id"`
+	CreatedAt    time.Time `gorm:"column:created_at"`
+	ExpiresAt    time.Time `gorm:"column:expires_at"`
+}
+
+func (ssoLoginStateModel) TableName() string {
+	return "team_sso_login_states"
+}
+
+func (m ssoLoginStateModel) toPort() ports.SSOLoginState {
+	return ports.SSOLoginState{
+		StateHash:    m.StateHash,
+		ConnectionID: m.ConnectionID,
+		Nonce:        m.Nonce,
+		CodeVerifier: m.CodeVerifier,
+		RequestID:    m.RequestID,
+		CreatedAt:    m.CreatedAt.UTC(),
+		ExpiresAt:    m.ExpiresAt.UTC(),
+	}
+}
+
+type ssoIdentityModel struct {
+	IdentityID   string    `gorm:"column:identity_id;primaryKey"`
+	ConnectionID string    `gorm:"column:connection_id"`
+	TeamID       string    `gorm:"column:team_id"`
+	UserID       string    `gorm:"column:user_id"`
+	Email        string    `gorm:"column:email"`
+	Subject      string    `gorm:"column:subject"`
+	ExternalID   string    `gorm:"column:external_id"`
+	Active       bool      `gorm:"column:active"`
+	CreatedAt    time.Time `gorm:"column:created_at"`
+	UpdatedAt    time.Time `gorm:"column:updated_at"`
+}
+
+func (ssoIdentityModel) TableName() string {
+	return "team_sso_identities"
+}
+
+func (m ssoIdentityModel) toPort() ports.SSOIdentity {
+	return ports.SSOIdentity{
+		IdentityID:   m.IdentityID,
+		ConnectionID: m.ConnectionID,
+		TeamID:       m.TeamID,
+		UserID:       m.UserID,
+		Email:        m.Email,
+		Subject:      m.Subject,
+		ExternalID:   m.ExternalID,
+		Active:       m.Active,
+		CreatedAt:    m.CreatedAt.UTC(),
+		UpdatedAt:    m.UpdatedAt.UTC(),
+	}
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,757 @@
+package postgresadapter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	domainerrors "solomon/contexts/internal-ops/team-management-service/domain/errors"
+	domainservices "solomon/contexts/internal-ops/team-management-service/domain/services"
+	"solomon/contexts/internal-ops/team-management-service/ports"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const systemActor = "system"
+
+// Repository persists teams, memberships, invites and the team audit log.
+// Each mutation and its audit entries commit in one transaction; the
+// partial unique indexes on team_members and team_invites settle races
+// between concurrent writers.
+type Repository struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+// NewRepository builds the GORM-backed team management adapter.
+func NewRepository(db *gorm.DB, logger *slog.Logger) *Repository {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Repository{db: db, logger: logger}
+}
+
+func (r *Repository) CreateTeam(ctx context.Context, actorUserID string, input ports.CreateTeamInput, now time.Time) (ports.Team, error) {
+	if !domainservices.IsValidTeamName(input.Name) || strings.TrimSpace(input.OrgID) == "" {
+		return ports.Team{}, domainerrors.ErrInvalidRequest
+	}
+	now = now.UTC()
+	team := teamModel{
+		TeamID:       "team_" + uuid.NewString(),
+		Name:         strings.TrimSpace(input.Name),
+		OrgID:        strings.TrimSpace(input.OrgID),
+		StorefrontID: strings.TrimSpace(input.StorefrontID),
+		OwnerUserID:  actorUserID,
+		Status:       "active",
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := lookupUser(tx, actorUserID); err != nil {
+			return err
+		}
+		if err := tx.Create(&team).Error; err != nil {
+			return err
+		}
+		owner := memberModel{
+			MemberID: "member_" + uuid.NewString(),
+			TeamID:   team.TeamID,
+			UserID:   actorUserID,
+			Role:     ports.RoleOwner,
+			Status:   "active",
+			JoinedAt: now,
+		}
+		if err := tx.Create(&owner).Error; err != nil {
+			return mapWriteError(err)
+		}
+		return addAudit(tx, team.TeamID, actorUserID, "team.created", "team", team.TeamID, now, map[string]string{
+			"name": team.Name,
+		})
+	})
+	if err != nil {
+		return ports.Team{}, err
+	}
+	return team.toPort(), nil
+}
+
+func (r *Repository) CreateInvite(
+	ctx context.Context,
+	actorUserID string,
+	teamID string,
+	email string,
+	role string,
+	tokenHash string,
+	expiresAt time.Time,
+	now time.Time,
+) (ports.TeamInvite, error) {
+	if !ports.IsValidRole(role) || role == ports.RoleOwner || strings.TrimSpace(tokenHash) == "" {
+		return ports.TeamInvite{}, domainerrors.ErrInvalidRequest
+	}
+	normalizedEmail, err := domainservices.NormalizeEmail(email)
+	if err != nil {
+		return ports.TeamInvite{}, err
+	}
+	now = now.UTC()
+	var out ports.TeamInvite
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		team, err := r.authorizeManager(tx, actorUserID, teamID)
+		if err != nil {
+			return err
+		}
+		if err := ensureNotMember(tx, team.TeamID, normalizedEmail); err != nil {
+			return err
+		}
+		row := inviteModel{
+			InviteID:  "invite_" + uuid.NewString(),
+			TeamID:    team.TeamID,
+			Email:     normalizedEmail,
+			Role:      role,
+			TokenHash: tokenHash,
+			Status:    ports.InviteStatusPending,
+			ExpiresAt: expiresAt.UTC(),
+			SentCount: 1,
+			CreatedBy: actorUserID,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if err := tx.Create(&row).Error; err != nil {
+			return mapWriteError(err)
+		}
+		out = row.toPort(team.Name)
+		return addAudit(tx, team.TeamID, actorUserID, "team.invite.sent", "invite", row.InviteID, now, map[string]string{
+			"email": normalizedEmail,
+			"role":  role,
+		})
+	})
+	if err != nil {
+		return ports.TeamInvite{}, err
+	}
+	return out, nil
+}
+
+// AcceptInvite joins the invited user to the team. An invite found past its
+// expiry is marked expired and that change is committed before
+// ErrInviteExpired is returned.
+func (r *Repository) AcceptInvite(ctx context.Context, actorUserID string, tokenHash string, now time.Time) (ports.Membership, error) {
+	now = now.UTC()
+	var out ports.Membership
+	expired := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		user, err := lookupUser(tx, actorUserID)
+		if err != nil {
+			return err
+		}
+		var invite inviteModel
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("token_hash = ?", strings.TrimSpace(tokenHash)).
+			First(&invite).Error; err != nil {
+			return mapNotFound(err, domainerrors.ErrInviteNotFound)
+		}
+		switch invite.Status {
+		case ports.InviteStatusPending:
+		case ports.InviteStatusExpired:
+			return domainerrors.ErrInviteExpired
+		case ports.InviteStatusRevoked:
+			return domainerrors.ErrInviteRevoked
+		default:
+			return domainerrors.ErrInvalidRequest
+		}
+		if !now.Before(invite.ExpiresAt.UTC()) {
+			expired = true
+			return expireInvite(tx, invite, now)
+		}
+		if !strings.EqualFold(strings.TrimSpace(user.Email), invite.Email) {
+			return domainerrors.ErrForbidden
+		}
+
+		member := memberModel{
+			MemberID: "member_" + uuid.NewString(),
+			TeamID:   invite.TeamID,
+			UserID:   actorUserID,
+			Role:     invite.Role,
+			Status:   "active",
+			JoinedAt: now,
+		}
+		if err := tx.Create(&member).Error; err != nil {
+			return mapWriteError(err)
+		}
+		if err := tx.Model(&inviteModel{}).
+			Where("invite_id = ?", invite.InviteID).
+			Updates(map[string]any{
+				"status":      ports.InviteStatusAccepted,
+				"accepted_by": actorUserID,
+				"accepted_at": now,
+				"updated_at":  now,
+			}).Error; err != nil {
+			return err
+		}
+		if err := addAudit(tx, invite.TeamID, actorUserID, "team.invite.accepted", "invite", invite.InviteID, now, map[string]string{
+			"member_id": member.MemberID,
+		}); err != nil {
+			return err
+		}
+		if err := addAudit(tx, invite.TeamID, actorUserID, "team.member.added", "member", member.MemberID, now, map[string]string{
+			"role": member.Role,
+		}); err != nil {
+			return err
+		}
+		out = ports.Membership{
+			TeamID:      invite.TeamID,
+			UserID:      actorUserID,
+			Role:        member.Role,
+			Permissions: ports.PermissionsForRole(member.Role),
+		}
+		return nil
+	})
+	if err != nil {
+		return ports.Membership{}, err
+	}
+	if expired {
+		return ports.Membership{}, domainerrors.ErrInviteExpired
+	}
+	return out, nil
+}
+
+func (r *Repository) ResendInvite(
+	ctx context.Context,
+	actorUserID string,
+	teamID string,
+	inviteID string,
+	tokenHash string,
+	expiresAt time.Time,
+	now time.Time,
+) (ports.TeamInvite, error) {
+	if strings.TrimSpace(tokenHash) == "" {
+		return ports.TeamInvite{}, domainerrors.ErrInvalidRequest
+	}
+	now = now.UTC()
+	var out ports.TeamInvite
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		team, invite, err := r.lockManagedInvite(tx, actorUserID, teamID, inviteID)
+		if err != nil {
+			return err
+		}
+		switch invite.Status {
+		case ports.InviteStatusPending:
+		case ports.InviteStatusExpired:
+			if err := ensureNotMember(tx, team.TeamID, invite.Email); err != nil {
+				return err
+			}
+		default:
+			return domainerrors.ErrConflict
+		}
+		invite.TokenHash = tokenHash
+		invite.Status = ports.InviteStatusPending
+		invite.ExpiresAt = expiresAt.UTC()
+		invite.UpdatedAt = now
+		invite.SentCount++
+		// Reopening an expired invite collides with any newer pending invite
+		// for the same email on the one-pending-invite index.
+		if err := tx.Model(&inviteModel{}).
+			Where("invite_id = ?", invite.InviteID).
+			Updates(map[string]any{
+				"token_hash": invite.TokenHash,
+				"status":     invite.Status,
+				"expires_at": invite.ExpiresAt,
+				"updated_at": invite.UpdatedAt,
+				"sent_count": invite.SentCount,
+			}).Error; err != nil {
+			return mapWriteError(err)
+		}
+		out = invite.toPort(team.Name)
+		return addAudit(tx, team.TeamID, actorUserID, "team.invite.resent", "invite", invite.InviteID, now, map[string]string{
+			"email":      invite.Email,
+			"expires_at": invite.ExpiresAt.Format(time.RFC3339),
+		})
+	})
+	if err != nil {
+		return ports.TeamInvite{}, err
+	}
+	return out, nil
+}
+
+func (r *Repository) RevokeInvite(ctx context.Context, actorUserID string, teamID string, inviteID string, now time.Time) (ports.TeamInvite, error) {
+	now = now.UTC()
+	var out ports.TeamInvite
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		team, invite, err := r.lockManagedInvite(tx, actorUserID, teamID, inviteID)
+		if err != nil {
+			return err
+		}
+		if invite.Status != ports.InviteStatusPending {
+			return domainerrors.ErrConflict
+		}
+		invite.Status = ports.InviteStatusRevoked
+		invite.RevokedBy = actorUserID
+		invite.RevokedAt = &now
+		invite.UpdatedAt = now
+		if err := tx.Model(&inviteModel{}).
+			Where("invite_id = ?", invite.InviteID).
+			Updates(map[string]any{
+				"status":     invite.Status,
+				"revoked_by": invite.RevokedBy,
+				"revoked_at": now,
+				"updated_at": now,
+			}).Error; err != nil {
+			return err
+		}
+		out = invite.toPort(team.Name)
+		return addAudit(tx, team.TeamID, actorUserID, "team.invite.revoked", "invite", invite.InviteID, now, map[string]string{
+			"email": invite.Email,
+		})
+	})
+	if err != nil {
+		return ports.TeamInvite{}, err
+	}
+	return out, nil
+}
+
+// ExpireInvites expires overdue pending invites. Rows locked by another
+// sweeper are skipped, so concurrent API instances split the work.
+func (r *Repository) ExpireInvites(ctx context.Context, now time.Time, limit int) ([]ports.TeamInvite, error) {
+	now = now.UTC()
+	var out []ports.TeamInvite
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var rows []inviteModel
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND expires_at <= ?", ports.InviteStatusPending, now).
+			Order("expires_at ASC").
+			Limit(limit).
+			Find(&rows).Error; err != nil {
+			return err
+		}
+		out = make([]ports.TeamInvite, 0, len(rows))
+		for _, row := range rows {
+			if err := expireInvite(tx, row, now); err != nil {
+				return err
+			}
+			row.Status = ports.InviteStatusExpired
+			row.UpdatedAt = now
+			out = append(out, row.toPort(""))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *Repository) UpdateMemberRole(
+	ctx context.Context,
+	actorUserID string,
+	teamID string,
+	memberID string,
+	newRole string,
+	mfaCode string,
+	now time.Time,
+) (ports.TeamMember, error) {
+	if !ports.IsValidRole(newRole) {
+		return ports.TeamMember{}, domainerrors.ErrInvalidRequest
+	}
+	now = now.UTC()
+	var out ports.TeamMember
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		team, actor, err := r.authorizeManagerMember(tx, actorUserID, teamID)
+		if err != nil {
+			return err
+		}
+		if actor.Role == ports.RoleOwner && strings.TrimSpace(mfaCode) == "" {
+			return domainerrors.ErrMFARequired
+		}
+		member, err := lockMember(tx, team.TeamID, memberID)
+		if err != nil {
+			return err
+		}
+		if member.Status != "active" {
+			return domainerrors.ErrConflict
+		}
+		// A team has exactly one owner: the owner cannot be demoted and
+		// nobody else can be promoted here.
+		if (member.Role == ports.RoleOwner) != (newRole == ports.RoleOwner) {
+			return domainerrors.ErrOwnerTransferRequired
+		}
+		if err := tx.Model(&memberModel{}).
+			Where("member_id = ?", member.MemberID).
+			Update("role", newRole).Error; err != nil {
+			return mapWriteError(err)
+		}
+		member.Role = newRole
+		out = member.toPort()
+		return addAudit(tx, team.TeamID, actorUserID, "team.role.changed", "member", member.MemberID, now, map[string]string{
+			"new_role": newRole,
+		})
+	})
+	if err != nil {
+		return ports.TeamMember{}, err
+	}
+	return out, nil
+}
+
+func (r *Repository) RemoveMember(
+	ctx context.Context,
+	actorUserID string,
+	teamID string,
+	memberID string,
+	mfaCode string,
+	now time.Time,
+) (ports.TeamMember, error) {
+	now = now.UTC()
+	var out ports.TeamMember
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		team, actor, err := r.authorizeManagerMember(tx, actorUserID, teamID)
+		if err != nil {
+			return err
+		}
+		if actor.Role == ports.RoleOwner && strings.TrimSpace(mfaCode) == "" {
+			return domainerrors.ErrMFARequired
+		}
+		member, err := lockMember(tx, team.TeamID, memberID)
+		if err != nil {
+			return err
+		}
+		if member.Role == ports.RoleOwner {
+			return domainerrors.ErrOwnerTransferRequired
+		}
+		if member.Status != "active" {
+			return domainerrors.ErrConflict
+		}
+		if err := tx.Model(&memberModel{}).
+			Where("member_id = ?", member.MemberID).
+			Updates(map[string]any{"status": "removed", "removed_at": now}).Error; err != nil {
+			return err
+		}
+		member.Status = "removed"
+		member.RemovedAt = &now
+		out = member.toPort()
+		return addAudit(tx, team.TeamID, actorUserID, "team.member.removed", "member", member.MemberID, now, map[string]string{
+			"user_id": member.UserID,
+		})
+	})
+	if err != nil {
+		return ports.TeamMember{}, err
+	}
+	return out, nil
+}
+
+func (r *Repository) GetTeamDashboard(ctx context.Context, actorUserID string, teamID string) (ports.TeamDashboard, error) {
+	db := r.db.WithContext(ctx)
+	team, err := r.authorizeManager(db, actorUserID, teamID)
+	if err != nil {
+		return ports.TeamDashboard{}, err
+	}
+
+	var memberRows []memberModel
+	if err := db.Where("team_id = ?", team.TeamID).
+		Order("joined_at ASC").
+		Find(&memberRows).Error; err != nil {
+		return ports.TeamDashboard{}, err
+	}
+	members := make([]ports.TeamMember, 0, len(memberRows))
+	for _, row := range memberRows {
+		members = append(members, row.toPort())
+	}
+
+	var inviteRows []inviteModel
+	if err := db.Where("team_id = ? AND status = ?", team.TeamID, ports.InviteStatusPending).
+		Order("created_at ASC").
+		Find(&inviteRows).Error; err != nil {
+		return ports.TeamDashboard{}, err
+	}
+	invites := make([]ports.TeamInvite, 0, len(inviteRows))
+	for _, row := range inviteRows {
+		invites = append(invites, row.toPort(team.Name))
+	}
+
+	return ports.TeamDashboard{
+		Team:           team.toPort(),
+		Members:        members,
+		PendingInvites: invites,
+	}, nil
+}
+
+// CheckMembership answers in one round trip: the team row left-joined to
+// the caller's active membership, which the partial unique index on
+// team_members serves without touching the table.
+func (r *Repository) CheckMembership(ctx context.Context, teamID string, userID string) (ports.Membership, error) {
+	var row struct {
+		TeamID string  `gorm:"column:team_id"`
+		Role   *string `gorm:"column:role"`
+	}
+	result := r.db.WithContext(ctx).
+		Table("teams AS t").
+		Select("t.team_id, m.role").
+		Joins("LEFT JOIN team_members AS m ON m.team_id = t.team_id AND m.user_id = ? AND m.status = 'active'", strings.TrimSpace(userID)).
+		Where("t.team_id = ?", strings.TrimSpace(teamID)).
+		Limit(1).
+		Scan(&row)
+	if result.Error != nil {
+		return ports.Membership{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ports.Membership{}, domainerrors.ErrTeamNotFound
+	}
+	if row.Role == nil {
+		return ports.Membership{}, domainerrors.ErrNotFound
+	}
+	return ports.Membership{
+		TeamID:      row.TeamID,
+		UserID:      strings.TrimSpace(userID),
+		Role:        *row.Role,
+		Permissions: ports.PermissionsForRole(*row.Role),
+	}, nil
+}
+
+func (r *Repository) ListAuditLogs(ctx context.Context, actorUserID string, teamID string, limit int) ([]ports.TeamAuditLog, error) {
+	db := r.db.WithContext(ctx)
+	team, err := r.authorizeManager(db, actorUserID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	var rows []auditLogModel
+	if err := db.Where("team_id = ?", team.TeamID).
+		Order("created_at DESC, audit_id DESC").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	items := make([]ports.TeamAuditLog, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, row.toPort())
+	}
+	return items, nil
+}
+
+func (r *Repository) AuthorizeMembersExport(ctx context.Context, actorUserID string, teamID string, now time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		team, err := r.authorizeManager(tx, actorUserID, teamID)
+		if err != nil {
+			return err
+		}
+		return addAudit(tx, team.TeamID, actorUserID, "team.members.export_requested", "team", team.TeamID, now, nil)
+	})
+}
+
+// StreamMembers reads active members through a cursor so large teams are
+// never loaded into memory at once.
+func (r *Repository) StreamMembers(ctx context.Context, actorUserID string, teamID string, limit int, fn func(ports.MemberExportRow) error) error {
+	db := r.db.WithContext(ctx)
+	team, err := r.authorizeManager(db, actorUserID, teamID)
+	if err != nil {
+		return err
+	}
+	rows, err := db.Table("team_members AS m").
+		Select("m.member_id, m.user_id, COALESCE(u.email, '') AS email, m.role, m.joined_at, m.last_active_at").
+		Joins("LEFT JOIN team_user_directory AS u ON u.user_id = m.user_id").
+		Where("m.team_id = ? AND m.status = 'active'", team.TeamID).
+		Order("m.joined_at ASC, m.member_id ASC").
+		Limit(limit).
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var row memberExportRowModel
+		if err := db.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		if err := fn(row.toPort()); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (r *Repository) Get(ctx context.Context, key string, now time.Time) (ports.IdempotencyRecord, bool, error) {
+	var row idempotencyModel
+	err := r.db.WithContext(ctx).Where("key = ?", key).First(&row).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ports.IdempotencyRecord{}, false, nil
+		}
+		return ports.IdempotencyRecord{}, false, err
+	}
+	if !row.ExpiresAt.After(now.UTC()) {
+		if err := r.db.WithContext(ctx).Where("key = ?", key).Delete(&idempotencyModel{}).Error; err != nil {
+			return ports.IdempotencyRecord{}, false, err
+		}
+		return ports.IdempotencyRecord{}, false, nil
+	}
+	return ports.IdempotencyRecord{
+		Key:         row.Key,
+		RequestHash: row.RequestHash,
+		Payload:     append([]byte(nil), row.Payload...),
+		ExpiresAt:   row.ExpiresAt.UTC(),
+	}, true, nil
+}
+
+// Put inserts a new idempotency record and checks request-hash collisions.
+func (r *Repository) Put(ctx context.Context, record ports.IdempotencyRecord) error {
+	row := idempotencyModel{
+		Key:         record.Key,
+		RequestHash: record.RequestHash,
+		Payload:     append([]byte(nil), record.Payload...),
+		ExpiresAt:   record.ExpiresAt.UTC(),
+		CreatedAt:   time.Now().UTC(),
+	}
+	createResult := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "key"}},
+			DoNothing: true,
+		}).
+		Create(&row)
+	if createResult.Error != nil {
+		return createResult.Error
+	}
+	if createResult.RowsAffected > 0 {
+		return nil
+	}
+	var existing idempotencyModel
+	if err := r.db.WithContext(ctx).Where("key = ?", row.Key).First(&existing).Error; err != nil {
+		return err
+	}
+	if existing.RequestHash != row.RequestHash {
+		return domainerrors.ErrIdempotencyConflict
+	}
+	return nil
+}
+
+// authorizeManager loads the team and requires the actor to be one of its
+// active owners or managers.
+func (r *Repository) authorizeManager(db *gorm.DB, actorUserID string, teamID string) (teamModel, error) {
+	team, _, err := r.authorizeManagerMember(db, actorUserID, teamID)
+	return team, err
+}
+
+func (r *Repository) authorizeManagerMember(db *gorm.DB, actorUserID string, teamID string) (teamModel, memberModel, error) {
+	if _, err := lookupUser(db, actorUserID); err != nil {
+		return teamModel{}, memberModel{}, err
+	}
+	var team teamModel
+	if err := db.Where("team_id = ?", strings.TrimSpace(teamID)).First(&team).Error; err != nil {
+		return teamModel{}, memberModel{}, mapNotFound(err, domainerrors.ErrTeamNotFound)
+	}
+	var actor memberModel
+	if err := db.Where("team_id = ? AND user_id = ? AND status = 'active'", team.TeamID, strings.TrimSpace(actorUserID)).
+		First(&actor).Error; err != nil {
+		return teamModel{}, memberModel{}, mapNotFound(err, domainerrors.ErrForbidden)
+	}
+	if actor.Role != ports.RoleOwner && actor.Role != ports.RoleManager {
+		return teamModel{}, memberModel{}, domainerrors.ErrForbidden
+	}
+	return team, actor, nil
+}
+
+func (r *Repository) lockManagedInvite(tx *gorm.DB, actorUserID string, teamID string, inviteID string) (teamModel, inviteModel, error) {
+	team, err := r.authorizeManager(tx, actorUserID, teamID)
+	if err != nil {
+		return teamModel{}, inviteModel{}, err
+	}
+	var invite inviteModel
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("invite_id = ? AND team_id = ?", strings.TrimSpace(inviteID), team.TeamID).
+		First(&invite).Error; err != nil {
+		return teamModel{}, inviteModel{}, mapNotFound(err, domainerrors.ErrInviteNotFound)
+	}
+	return team, invite, nil
+}
+
+// lookupUser reads the M01 directory projection. A user missing from it is
+// a dependency problem, not a client error.
+func lookupUser(db *gorm.DB, userID string) (userDirectoryModel, error) {
+	var user userDirectoryModel
+	if err := db.Where("user_id = ?", strings.TrimSpace(userID)).First(&user).Error; err != nil {
+		return userDirectoryModel{}, mapNotFound(err, domainerrors.ErrDependencyUnavailable)
+	}
+	return user, nil
+}
+
+func ensureNotMember(db *gorm.DB, teamID string, email string) error {
+	var count int64
+	if err := db.Table("team_members AS m").
+		Joins("JOIN team_user_directory AS u ON u.user_id = m.user_id").
+		Where("m.team_id = ? AND m.status = 'active' AND lower(u.email) = ?", teamID, strings.ToLower(email)).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return domainerrors.ErrConflict
+	}
+	return nil
+}
+
+func lockMember(tx *gorm.DB, teamID string, memberID string) (memberModel, error) {
+	var member memberModel
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("member_id = ? AND team_id = ?", strings.TrimSpace(memberID), teamID).
+		First(&member).Error; err != nil {
+		return memberModel{}, mapNotFound(err, domainerrors.ErrMemberNotFound)
+	}
+	return member, nil
+}
+
+func expireInvite(tx *gorm.DB, invite inviteModel, now time.Time) error {
+	if err := tx.Model(&inviteModel{}).
+		Where("invite_id = ?", invite.InviteID).
+		Updates(map[string]any{"status": ports.InviteStatusExpired, "updated_at": now}).Error; err != nil {
+		return err
+	}
+	return addAudit(tx, invite.TeamID, systemActor, "team.invite.expired", "invite", invite.InviteID, now, map[string]string{
+		"email":      invite.Email,
+		"expires_at": invite.ExpiresAt.UTC().Format(time.RFC3339),
+	})
+}
+
+func addAudit(tx *gorm.DB, teamID string, actorUserID string, action string, targetType string, targetID string, now time.Time, metadata map[string]string) error {
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	raw, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+	return tx.Create(&auditLogModel{
+		AuditID:     "audit_" + uuid.NewString(),
+		TeamID:      teamID,
+		ActorUserID: actorUserID,
+		Action:      action,
+		TargetType:  targetType,
+		TargetID:    targetID,
+		Metadata:    raw,
+		CreatedAt:   now.UTC(),
+	}).Error
+}
+
+func mapNotFound(err error, notFound error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return notFound
+	}
+	return err
+}
+
+// mapWriteError turns unique index violations into domain errors: a second
+// owner, a second active membership or a second pending invite for an
+// email.
+func mapWriteError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		if pgErr.ConstraintName == "idx_team_members_one_owner" {
+			return domainerrors.ErrOwnerTransferRequired
+		}
+		return domainerrors.ErrConflict
+	}
+	return err
+}
+
+var _ ports.Repository = (*Repository)(nil)
+var _ ports.IdempotencyStore = (*Repository)(nil)
@@ -0,0 +1,542 @@
+package postgresadapter
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	domainerrors "solomon/contexts/internal-ops/team-management-service/domain/errors"
+	"solomon/contexts/internal-ops/team-management-service/ports"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *Repository) CreateSSOConnection(ctx context.Context, actorUserID string, conn ports.SSOConnection, now time.Time) (ports.SSOConnection, error) {
+	now = now.UTC()
+	var out ports.SSOConnection
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		team, err := r.authorizeOwner(tx, actorUserID, conn.TeamID)
+		if err != nil {
+			return err
+		}
+		conn.ConnectionID = "ssoconn_" + uuid.NewString()
+		conn.TeamID = team.TeamID
+		conn.CreatedBy = actorUserID
+		conn.CreatedAt = now
+		conn.UpdatedAt = now
+		model, err := newSSOConnectionModel(conn)
+		if err != nil {
+			return err
+		}
+		if err := tx.Create(&model).Error; err != nil {
+			return mapWriteError(err)
+		}
+		out = model.toPort()
+		return addAudit(tx, team.TeamID, actorUserID, "team.sso.connection_created", "sso_connection", conn.ConnectionID, now, map[string]string{
+			"protocol": conn.Protocol,
+		})
+	})
+	if err != nil {
+		return ports.SSOConnection{}, err
+	}
+	return out, nil
+}
+
+func (r *Repository) UpdateSSOConnection(ctx context.Context, actorUserID string, conn ports.SSOConnection, now time.Time) (ports.SSOConnection, error) {
+	now = now.UTC()
+	var out ports.SSOConnection
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		existing, err := r.lockOwnedSSOConnection(tx, actorUserID, conn.TeamID, conn.ConnectionID)
+		if err != nil {
+			return err
+		}
+		conn.TeamID = existing.TeamID
+		conn.Protocol = existing.Protocol
+		conn.SCIMTokenHash = existing.SCIMTokenHash
+		conn.CreatedBy = existing.CreatedBy
+		conn.CreatedAt = existing.CreatedAt
+		conn.UpdatedAt = now
+		model, err := newSSOConnectionModel(conn)
+		if err != nil {
+			return err
+		}
+		if err := tx.Save(&model).Error; err != nil {
+			return mapWriteError(err)
+		}
+		out = model.toPort()
+		return addAudit(tx, conn.TeamID, actorUserID, "team.sso.connection_updated", "sso_connection", conn.ConnectionID, now, map[string]string{
+			"status": conn.Status,
+		})
+	})
+	if err != nil {
+		return ports.SSOConnection{}, err
+	}
+	return out, nil
+}
+
+func (r *Repository) RotateSCIMToken(ctx context.Context, actorUserID string, teamID string, connectionID string, tokenHash string, now time.Time) (ports.SSOConnection, error) {
+	if strings.TrimSpace(tokenHash) == "" {
+		return ports.SSOConnection{}, domainerrors.ErrInvalidRequest
+	}
+	now = now.UTC()
+	var out ports.SSOConnection
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		conn, err := r.lockOwnedSSOConnection(tx, actorUserID, teamID, connectionID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&ssoConnectionModel{}).
+			Where("connection_id = ?", conn.ConnectionID).
+			Updates(map[string]any{"scim_token_hash": tokenHash, "updated_at": now}).Error; err != nil {
+			return err
+		}
+		conn.SCIMTokenHash = tokenHash
+		conn.UpdatedAt = now
+		out = conn
+		return addAudit(tx, conn.TeamID, actorUserID, "team.sso.scim_token_rotated", "sso_connection", conn.ConnectionID, now, nil)
+	})
+	if err != nil {
+		return ports.SSOConnection{}, err
+	}
+	return out, nil
+}
+
+func (r *Repository) ListSSOConnections(ctx context.Context, actorUserID string, teamID string) ([]ports.SSOConnection, error) {
+	db := r.db.WithContext(ctx)
+	team, err := r.authorizeOwner(db, actorUserID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	var rows []ssoConnectionModel
+	if err := db.Where("team_id = ?", team.TeamID).Order("created_at ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]ports.SSOConnection, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, row.toPort())
+	}
+	return out, nil
+}
+
+func (r *Repository) GetSSOConnection(ctx context.Context, connectionID string) (ports.SSOConnection, error) {
+	var row ssoConnectionModel
+	if err := r.db.WithContext(ctx).
+		Where("connection_id = ?", strings.TrimSpace(connectionID)).
+		First(&row).Error; err != nil {
+		return ports.SSOConnection{}, mapNotFound(err, domainerrors.ErrNotFound)
+	}
+	return row.toPort(), nil
+}
+
+// CreateSSOLoginState also deletes states whose login window has closed.
+func (r *Repository) CreateSSOLoginState(ctx context.Context, login ports.SSOLoginState) error {
+	if strings.TrimSpace(login.StateHash) == "" {
+		return domainerrors.ErrInvalidRequest
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("expires_at <= ?", login.CreatedAt.UTC()).Delete(&ssoLoginStateModel{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&ssoLoginStateModel{
+			StateHash:    login.StateHash,
+			ConnectionID: login.ConnectionID,
+			Nonce:        login.Nonce,
+			CodeVerifier: login.CodeVerifier,
+			RequestID:    login.RequestID,
+			CreatedAt:    login.CreatedAt.UTC(),
+			ExpiresAt:    login.ExpiresAt.UTC(),
+		}).Error; err != nil {
+			return mapWriteError(err)
+		}
+		return nil
+	})
+}
+
+// ConsumeSSOLoginState deletes the state whether or not it is still valid,
+// so each IdP answer is accepted at most once even under concurrent posts.
+func (r *Repository) ConsumeSSOLoginState(ctx context.Context, stateHash string, now time.Time) (ports.SSOLoginState, error) {
+	var rows []ssoLoginStateModel
+	result := r.db.WithContext(ctx).
+		Clauses(clause.Returning{}).
+		Where("state_hash = ?", strings.TrimSpace(stateHash)).
+		Delete(&rows)
+	if result.Error != nil {
+		return ports.SSOLoginState{}, result.Error
+	}
+	if len(rows) != 1 || !now.UTC().Before(rows[0].ExpiresAt) {
+		return ports.SSOLoginState{}, domainerrors.ErrSSOLoginExpired
+	}
+	return rows[0].toPort(), nil
+}
+
+func (r *Repository) ProvisionSSOMember(ctx context.Context, conn ports.SSOConnection, assertion ports.SSOAssertion, role string, now time.Time) (ports.SSOProvisionResult, error) {
+	now = now.UTC()
+	var out ports.SSOProvisionResult
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var team teamModel
+		if err := tx.Where("team_id = ?", conn.TeamID).First(&team).Error; err != nil {
+			return mapNotFound(err, domainerrors.ErrTeamNotFound)
+		}
+		identity, found, err := lockLoginIdentity(tx, conn, assertion)
+		if err != nil {
+			return err
+		}
+		if !found {
+			if role == "" {
+				out = ports.SSOProvisionResult{Action: ports.SSOProvisionDenied}
+				return nil
+			}
+			user, err := lookupUserByEmail(tx, assertion.Email)
+			if err != nil {
+				return err
+			}
+			identity = ssoIdentityModel{
+				IdentityID:   "ssoid_" + uuid.NewString(),
+				ConnectionID: conn.ConnectionID,
+				TeamID:       team.TeamID,
+				UserID:       user.UserID,
+				Active:       true,
+				CreatedAt:    now,
+			}
+		}
+		if !identity.Active {
+			out = ports.SSOProvisionResult{Identity: identity.toPort(), Action: ports.SSOProvisionDenied}
+			return nil
+		}
+		identity.Subject = assertion.Subject
+		identity.Email = assertion.Email
+		identity.UpdatedAt = now
+		if err := tx.Save(&identity).Error; err != nil {
+			return mapWriteError(err)
+		}
+
+		actor := "sso:" + conn.ConnectionID
+		member, active, err := lockActiveMember(tx, team.TeamID, identity.UserID)
+		if err != nil {
+			return err
+		}
+		out = ports.SSOProvisionResult{Identity: identity.toPort(), Member: member.toPort()}
+		switch {
+		case role == "":
+			if !active || member.Role == ports.RoleOwner {
+				out.Action = ports.SSOProvisionDenied
+				return nil
+			}
+			member, err = deprovisionMember(tx, member, actor, now)
+			out.Action = ports.SSOProvisionDeprovisioned
+		case !active:
+			member, err = provisionMember(tx, team.TeamID, identity.UserID, role, actor, now)
+			out.Action = ports.SSOProvisionCreated
+		case member.Role == ports.RoleOwner || member.Role == role:
+			out.Action = ports.SSOProvisionUnchanged
+		default:
+			if err = tx.Model(&memberModel{}).
+				Where("member_id = ?", member.MemberID).
+				Update("role", role).Error; err != nil {
+				return mapWriteError(err)
+			}
+			member.Role = role
+			err = addAudit(tx, team.TeamID, actor, "team.role.changed", "member", member.MemberID, now, map[string]string{
+				"new_role": role,
+				"source":   "sso",
+			})
+			out.Action = ports.SSOProvisionRoleUpdated
+		}
+		if err != nil {
+			return err
+		}
+		out.Member = member.toPort()
+		return nil
+	})
+	if err != nil {
+		return ports.SSOProvisionResult{}, err
+	}
+	return out, nil
+}
+
+func (r *Repository) ListSSOIdentities(ctx context.Context, connectionID string, query ports.SSOIdentityQuery) ([]ports.SSOIdentity, int, error) {
+	db := r.db.WithContext(ctx).Model(&ssoIdentityModel{}).Where("connection_id = ?", connectionID)
+	if query.Email != "" {
+		db = db.Where("lower(email) = ?", strings.ToLower(query.Email))
+	}
+	if query.ExternalID != "" {
+		db = db.Where("external_id = ?", query.ExternalID)
+	}
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	db = db.Order("created_at ASC, identity_id ASC").Offset(query.Offset)
+	if query.Limit > 0 {
+		db = db.Limit(query.Limit)
+	}
+	var rows []ssoIdentityModel
+	if err := db.Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	out := make([]ports.SSOIdentity, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, row.toPort())
+	}
+	return out, int(total), nil
+}
+
+func (r *Repository) GetSSOIdentity(ctx context.Context, connectionID string, identityID string) (ports.SSOIdentity, error) {
+	var row ssoIdentityModel
+	if err := r.db.WithContext(ctx).
+		Where("identity_id = ? AND connection_id = ?", identityID, connectionID).
+		First(&row).Error; err != nil {
+		return ports.SSOIdentity{}, mapNotFound(err, domainerrors.ErrNotFound)
+	}
+	return row.toPort(), nil
+}
+
+func (r *Repository) CreateSSOIdentity(ctx context.Context, conn ports.SSOConnection, identity ports.SSOIdentity, role string, now time.Time) (ports.SSOIdentity, error) {
+	now = now.UTC()
+	var out ports.SSOIdentity
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var team teamModel
+		if err := tx.Where("team_id = ?", conn.TeamID).First(&team).Error; err != nil {
+			return mapNotFound(err, domainerrors.ErrTeamNotFound)
+		}
+		user, err := lookupUserByEmail(tx, identity.Email)
+		if err != nil {
+			return err
+		}
+		row := ssoIdentityModel{
+			IdentityID:   "ssoid_" + uuid.NewString(),
+			ConnectionID: conn.ConnectionID,
+			TeamID:       team.TeamID,
+			UserID:       user.UserID,
+			Email:        identity.Email,
+			ExternalID:   identity.ExternalID,
+			Active:       identity.Active,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}
+		if err := tx.Create(&row).Error; err != nil {
+			return mapWriteError(err)
+		}
+		out = row.toPort()
+		actor := "scim:" + conn.ConnectionID
+		if err := addAudit(tx, team.TeamID, actor, "team.sso.identity_provisioned", "sso_identity", row.IdentityID, now, map[string]string{
+			"user_id": user.UserID,
+		}); err != nil {
+			return err
+		}
+		if !row.Active {
+			return nil
+		}
+		if _, active, err := lockActiveMember(tx, team.TeamID, user.UserID); err != nil || active {
+			return err
+		}
+		_, err = provisionMember(tx, team.TeamID, user.UserID, role, actor, now)
+		return err
+	})
+	if err != nil {
+		return ports.SSOIdentity{}, err
+	}
+	return out, nil
+}
+
+func (r *Repository) SetSSOIdentityActive(ctx context.Context, conn ports.SSOConnection, identityID string, active bool, role string, now time.Time) (ports.SSOIdentity, error) {
+	now = now.UTC()
+	var out ports.SSOIdentity
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		identity, err := lockSSOIdentity(tx, conn.ConnectionID, identityID)
+		if err != nil {
+			return err
+		}
+		actor := "scim:" + conn.ConnectionID
+		member, isMember, err := lockActiveMember(tx, identity.TeamID, identity.UserID)
+		if err != nil {
+			return err
+		}
+		switch {
+		case active && !isMember:
+			_, err = provisionMember(tx, identity.TeamID, identity.UserID, role, actor, now)
+		case !active && isMember:
+			if member.Role == ports.RoleOwner {
+				return domainerrors.ErrOwnerTransferRequired
+			}
+			_, err = deprovisionMember(tx, member, actor, now)
+		}
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&ssoIdentityModel{}).
+			Where("identity_id = ?", identity.IdentityID).
+			Updates(map[string]any{"active": active, "updated_at": now}).Error; err != nil {
+			return err
+		}
+		identity.Active = active
+		identity.UpdatedAt = now
+		out = identity.toPort()
+		return nil
+	})
+	if err != nil {
+		return ports.SSOIdentity{}, err
+	}
+	return out, nil
+}
+
+func (r *Repository) DeleteSSOIdentity(ctx context.Context, conn ports.SSOConnection, identityID string, now time.Time) error {
+	now = now.UTC()
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		identity, err := lockSSOIdentity(tx, conn.ConnectionID, identityID)
+		if err != nil {
+			return err
+		}
+		actor := "scim:" + conn.ConnectionID
+		member, isMember, err := lockActiveMember(tx, identity.TeamID, identity.UserID)
+		if err != nil {
+			return err
+		}
+		if isMember {
+			if member.Role == ports.RoleOwner {
+				return domainerrors.ErrOwnerTransferRequired
+			}
+			if _, err := deprovisionMember(tx, member, actor, now); err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("identity_id = ?", identity.IdentityID).Delete(&ssoIdentityModel{}).Error; err != nil {
+			return err
+		}
+		return addAudit(tx, identity.TeamID, actor, "team.sso.identity_deleted", "sso_identity", identity.IdentityID, now, map[string]string{
+			"user_id": identity.UserID,
+		})
+	})
+}
+
+// authorizeOwner requires the actor to be the team's active owner; SSO
+// settings decide who can join, so managers cannot change them.
+func (r *Repository) authorizeOwner(db *gorm.DB, actorUserID string, teamID string) (teamModel, error) {
+	team, actor, err := r.authorizeManagerMember(db, actorUserID, teamID)
+	if err != nil {
+		return teamModel{}, err
+	}
+	if actor.Role != ports.RoleOwner {
+		return teamModel{}, domainerrors.ErrForbidden
+	}
+	return team, nil
+}
+
+func (r *Repository) lockOwnedSSOConnection(tx *gorm.DB, actorUserID string, teamID string, connectionID string) (ports.SSOConnection, error) {
+	team, err := r.authorizeOwner(tx, actorUserID, teamID)
+	if err != nil {
+		return ports.SSOConnection{}, err
+	}
+	var row ssoConnectionModel
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("connection_id = ? AND team_id = ?", strings.TrimSpace(connectionID), team.TeamID).
+		First(&row).Error; err != nil {
+		return ports.SSOConnection{}, mapNotFound(err, domainerrors.ErrNotFound)
+	}
+	return row.toPort(), nil
+}
+
+// lockLoginIdentity finds the identity a login belongs to: by IdP subject,
+// else the not yet logged-in identity of the M01 user with the asserted
+// email.
+func lockLoginIdentity(tx *gorm.DB, conn ports.SSOConnection, assertion ports.SSOAssertion) (ssoIdentityModel, bool, error) {
+	var identity ssoIdentityModel
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("connection_id = ? AND subject = ?", conn.ConnectionID, assertion.Subject).
+		First(&identity).Error
+	if err == nil {
+		return identity, true, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return ssoIdentityModel{}, false, err
+	}
+	user, err := lookupUserByEmail(tx, assertion.Email)
+	if errors.Is(err, domainerrors.ErrDependencyUnavailable) {
+		return ssoIdentityModel{}, false, nil
+	}
+	if err != nil {
+		return ssoIdentityModel{}, false, err
+	}
+	err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("connection_id = ? AND user_id = ?", conn.ConnectionID, user.UserID).
+		First(&identity).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ssoIdentityModel{}, false, nil
+	}
+	if err != nil {
+		return ssoIdentityModel{}, false, err
+	}
+	// The account is already linked to a different IdP user.
+	if identity.Subject != "" {
+		return ssoIdentityModel{}, false, domainerrors.ErrForbidden
+	}
+	return identity, true, nil
+}
+
+func lockSSOIdentity(tx *gorm.DB, connectionID string, identityID string) (ssoIdentityModel, error) {
+	var identity ssoIdentityModel
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("identity_id = ? AND connection_id = ?", identityID, connectionID).
+		First(&identity).Error; err != nil {
+		return ssoIdentityModel{}, mapNotFound(err, domainerrors.ErrNotFound)
+	}
+	return identity, nil
+}
+
+func lookupUserByEmail(db *gorm.DB, email string) (userDirectoryModel, error) {
+	var user userDirectoryModel
+	if err := db.Where("lower(email) = ?", strings.ToLower(strings.TrimSpace(email))).First(&user).Error; err != nil {
+		return userDirectoryModel{}, mapNotFound(err, domainerrors.ErrDependencyUnavailable)
+	}
+	return user, nil
+}
+
+func lockActiveMember(tx *gorm.DB, teamID string, userID string) (memberModel, bool, error) {
+	var member memberModel
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("team_id = ? AND user_id = ? AND status = 'active'", teamID, userID).
+		First(&member).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return memberModel{}, false, nil
+	}
+	if err != nil {
+		return memberModel{}, false, err
+	}
+	return member, true, nil
+}
+
+func provisionMember(tx *gorm.DB, teamID string, userID string, role string, actor string, now time.Time) (memberModel, error) {
+	member := memberModel{
+		MemberID: "member_" + uuid.NewString(),
+		TeamID:   teamID,
+		UserID:   userID,
+		Role:     role,
+		Status:   "active",
+		JoinedAt: now,
+	}
+	if err := tx.Create(&member).Error; err != nil {
+		return memberModel{}, mapWriteError(err)
+	}
+	return member, addAudit(tx, teamID, actor, "team.member.provisioned", "member", member.MemberID, now, map[string]string{
+		"user_id": userID,
+		"role":    role,
+	})
+}
+
+func deprovisionMember(tx *gorm.DB, member memberModel, actor string, now time.Time) (memberModel, error) {
+	if err := tx.Model(&memberModel{}).
+		Where("member_id = ?", member.MemberID).
+		Updates(map[string]any{"status": "removed", "removed_at": now}).Error; err != nil {
+		return memberModel{}, err
+	}
+	member.Status = "removed"
+	member.RemovedAt = &now
+	return member, addAudit(tx, member.TeamID, actor, "team.member.deprovisioned", "member", member.MemberID, now, map[string]string{
+		"user_id": member.UserID,
+	})
+}
+
+var _ ports.SSORepository = (*Repository)(nil)
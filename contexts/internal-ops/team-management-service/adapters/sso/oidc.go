@@ -0,0 +1,361 @@
+package sso
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	domainerrors "solomon/contexts/internal-ops/team-management-service/domain/errors"
+	"solomon/contexts/internal-ops/team-management-service/ports"
+)
+
+const (
+	jwksCacheTTL     = 10 * time.Minute
+	maxOIDCBodyBytes = 1 << 20
+	defaultGroups    = "groups"
+)
+
+var defaultOIDCScopes = []string{"openid", "email", "profile"}
+
+// OIDCProvider runs the OpenID Connect authorization code flow with PKCE
+// against a team's IdP and verifies the RS256 ID token it returns. Signing
+// keys are cached per JWKS URL and refetched once when a token names an
+// unknown key id, so IdP key rotation needs no restart.
+type OIDCProvider struct {
+	client *http.Client
+
+	mu   sync.Mutex
+	keys map[string]jwksEntry
+}
+
+type jwksEntry struct {
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+}
+
+func NewOIDCProvider(client *http.Client) *OIDCProvider {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &OIDCProvider{client: client, keys: map[string]jwksEntry{}}
+}
+
+func (p *OIDCProvider) AuthorizationURL(conn ports.SSOConnection, sp ports.SSOServiceProvider, login ports.SSOLoginState, state string) (string, error) {
+	target, err := url.Parse(conn.OIDC.AuthorizationURL)
+	if err != nil {
+		return "", err
+	}
+	scopes := conn.OIDC.Scopes
+	if len(scopes) == 0 {
+		scopes = defaultOIDCScopes
+	}
+	challenge := sha256.Sum256([]byte(login.CodeVerifier))
+	query := target.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", conn.OIDC.ClientID)
+	query.Set("redirect_uri", sp.RedirectURL)
+	query.Set("scope", strings.Join(scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", login.Nonce)
+	query.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	query.Set("code_challenge_method", "S256")
+	target.RawQuery = query.Encode()
+	return target.String(), nil
+}
+
+func (p *OIDCProvider) Verify(
+	ctx context.Context,
+	conn ports.SSOConnection,
+	sp ports.SSOServiceProvider,
+	login ports.SSOLoginState,
+	callback ports.SSOCallback,
+	now time.Time,
+) (ports.SSOAssertion, error) {
+	if strings.TrimSpace(callback.Code) == "" {
+		return ports.SSOAssertion{}, invalidAssertion("missing authorization code")
+	}
+	idToken, err := p.exchangeCode(ctx, conn, sp, login, callback.Code)
+	if err != nil {
+		return ports.SSOAssertion{}, err
+	}
+	claims, err := p.verifyIDToken(ctx, conn, idToken)
+	if err != nil {
+		return ports.SSOAssertion{}, err
+	}
+	return checkIDTokenClaims(conn, login, claims, now)
+}
+
+func (p *OIDCProvider) exchangeCode(
+	ctx context.Context,
+	conn ports.SSOConnection,
+	sp ports.SSOServiceProvider,
+	login ports.SSOLoginState,
+	code string,
+) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", sp.RedirectURL)
+	form.Set("code_verifier", login.CodeVerifier)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, conn.OIDC.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(conn.OIDC.ClientID), url.QueryEscape(conn.OIDC.ClientSecret))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: oidc token endpoint: %v", domainerrors.ErrDependencyUnavailable, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxOIDCBodyBytes))
+	if err != nil {
+		return "", fmt.Errorf("%w: oidc token endpoint: %v", domainerrors.ErrDependencyUnavailable, err)
+	}
+	if resp.StatusCode >= 500 {
+		return "", fmt.Errorf("%w: oidc token endpoint returned %d", domainerrors.ErrDependencyUnavailable, resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", invalidAssertion(fmt.Sprintf("token endpoint returned %d", resp.StatusCode))
+	}
+	var token struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := json.Unmarshal(body, &token); err != nil || token.IDToken == "" {
+		return "", invalidAssertion("token response has no id_token")
+	}
+	return token.IDToken, nil
+}
+
+func (p *OIDCProvider) verifyIDToken(ctx context.Context, conn ports.SSOConnection, idToken string) (map[string]json.RawMessage, error) {
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return nil, invalidAssertion("id_token is not a JWS")
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeJWTSegment(parts[0], &header); err != nil {
+		return nil, invalidAssertion("id_token header is malformed")
+	}
+	if header.Alg != "RS256" {
+		return nil, invalidAssertion("id_token must be signed with RS256")
+	}
+	key, err := p.signingKey(ctx, conn.OIDC.JWKSURL, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, invalidAssertion("id_token signature is malformed")
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, invalidAssertion("id_token signature does not verify")
+	}
+	var claims map[string]json.RawMessage
+	if err := decodeJWTSegment(parts[1], &claims); err != nil {
+		return nil, invalidAssertion("id_token claims are malformed")
+	}
+	return claims, nil
+}
+
+func checkIDTokenClaims(conn ports.SSOConnection, login ports.SSOLoginState, claims map[string]json.RawMessage, now time.Time) (ports.SSOAssertion, error) {
+	var issuer, subject, nonce, email, azp string
+	decodeClaim(claims, "iss", &issuer)
+	decodeClaim(claims, "sub", &subject)
+	decodeClaim(claims, "nonce", &nonce)
+	decodeClaim(claims, "email", &email)
+	decodeClaim(claims, "azp", &azp)
+	if issuer != conn.OIDC.Issuer {
+		return ports.SSOAssertion{}, invalidAssertion("id_token issuer mismatch")
+	}
+	audiences := stringsClaim(claims["aud"])
+	if !containsString(audiences, conn.OIDC.ClientID) {
+		return ports.SSOAssertion{}, invalidAssertion("id_token audience mismatch")
+	}
+	if (len(audiences) > 1 || azp != "") && azp != conn.OIDC.ClientID {
+		return ports.SSOAssertion{}, invalidAssertion("id_token authorized party mismatch")
+	}
+	var expiresAt, notBefore, issuedAt float64
+	if !decodeClaim(claims, "exp", &expiresAt) || !now.Before(time.Unix(int64(expiresAt), 0).Add(clockSkew)) {
+		return ports.SSOAssertion{}, invalidAssertion("id_token has expired")
+	}
+	if decodeClaim(claims, "nbf", &notBefore) && now.Add(clockSkew).Before(time.Unix(int64(notBefore), 0)) {
+		return ports.SSOAssertion{}, invalidAssertion("id_token is not valid yet")
+	}
+	if decodeClaim(claims, "iat", &issuedAt) && now.Add(clockSkew).Before(time.Unix(int64(issuedAt), 0)) {
+		return ports.SSOAssertion{}, invalidAssertion("id_token was issued in the future")
+	}
+	if subtle.ConstantTimeCompare([]byte(nonce), []byte(login.Nonce)) != 1 {
+		return ports.SSOAssertion{}, invalidAssertion("id_token nonce mismatch")
+	}
+	if subject == "" || email == "" {
+		return ports.SSOAssertion{}, invalidAssertion("id_token has no subject or email")
+	}
+	if !emailVerified(claims["email_verified"]) {
+		return ports.SSOAssertion{}, invalidAssertion("IdP has not verified the email")
+	}
+	groupsClaim := conn.OIDC.GroupsClaim
+	if groupsClaim == "" {
+		groupsClaim = defaultGroups
+	}
+	return ports.SSOAssertion{
+		Subject: subject,
+		Email:   email,
+		Groups:  stringsClaim(claims[groupsClaim]),
+	}, nil
+}
+
+// signingKey returns the JWKS key for kid, refetching the set at most once
+// per call when the cache is stale or does not know kid.
+func (p *OIDCProvider) signingKey(ctx context.Context, jwksURL string, kid string) (*rsa.PublicKey, error) {
+	p.mu.Lock()
+	entry, ok := p.keys[jwksURL]
+	p.mu.Unlock()
+	if ok && time.Since(entry.fetchedAt) < jwksCacheTTL {
+		if key := pickKey(entry.keys, kid); key != nil {
+			return key, nil
+		}
+	}
+	keys, err := p.fetchJWKS(ctx, jwksURL)
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	p.keys[jwksURL] = jwksEntry{keys: keys, fetchedAt: time.Now()}
+	p.mu.Unlock()
+	if key := pickKey(keys, kid); key != nil {
+		return key, nil
+	}
+	return nil, invalidAssertion("id_token is signed with an unknown key")
+}
+
+func (p *OIDCProvider) fetchJWKS(ctx context.Context, jwksURL string) (map[string]*rsa.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, jwksURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: oidc jwks: %v", domainerrors.ErrDependencyUnavailable, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: oidc jwks returned %d", domainerrors.ErrDependencyUnavailable, resp.StatusCode)
+	}
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxOIDCBodyBytes)).Decode(&set); err != nil {
+		return nil, fmt.Errorf("%w: oidc jwks is malformed", domainerrors.ErrDependencyUnavailable)
+	}
+	keys := map[string]*rsa.PublicKey{}
+	for _, key := range set.Keys {
+		if key.Kty != "RSA" || (key.Use != "" && key.Use != "sig") {
+			continue
+		}
+		n, errN := base64.RawURLEncoding.DecodeString(key.N)
+		e, errE := base64.RawURLEncoding.DecodeString(key.E)
+		if errN != nil || errE != nil || len(e) == 0 || len(e) > 4 {
+			continue
+		}
+		exponent := new(big.Int).SetBytes(e)
+		keys[key.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}
+	}
+	return keys, nil
+}
+
+// pickKey finds kid, or the only key when the token names none.
+func pickKey(keys map[string]*rsa.PublicKey, kid string) *rsa.PublicKey {
+	if kid != "" {
+		return keys[kid]
+	}
+	if len(keys) == 1 {
+		for _, key := range keys {
+			return key
+		}
+	}
+	return nil
+}
+
+func decodeJWTSegment(segment string, out any) error {
+	raw, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, out)
+}
+
+func decodeClaim(claims map[string]json.RawMessage, name string, out any) bool {
+	raw, ok := claims[name]
+	if !ok {
+		return false
+	}
+	return json.Unmarshal(raw, out) == nil
+}
+
+// stringsClaim reads a claim that IdPs send either as a string or as an
+// array of strings.
+func stringsClaim(raw json.RawMessage) []string {
+	if len(raw) == 0 {
+		return nil
+	}
+	var single string
+	if err := json.Unmarshal(raw, &single); err == nil {
+		if single == "" {
+			return nil
+		}
+		return []string{single}
+	}
+	var many []string
+	if err := json.Unmarshal(raw, &many); err == nil {
+		return many
+	}
+	return nil
+}
+
+func emailVerified(raw json.RawMessage) bool {
+	var flag bool
+	if err := json.Unmarshal(raw, &flag); err == nil {
+		return flag
+	}
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return strings.EqualFold(text, "true")
+	}
+	return false
+}
+
+func containsString(values []string, want string) bool {
+	for _, value := range values {
+		if value == want {
+			return true
+		}
+	}
+	return false
+}
+
+var _ ports.SSOProtocol = (*OIDCProvider)(nil)
@@ -0,0 +1,305 @@
+package sso
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	domainerrors "solomon/contexts/internal-ops/team-management-service/domain/errors"
+	domainservices "solomon/contexts/internal-ops/team-management-service/domain/services"
+	"solomon/contexts/internal-ops/team-management-service/ports"
+)
+
+const (
+	nsSAMLProtocol  = "urn:oasis:names:tc:SAML:2.0:protocol"
+	nsSAMLAssertion = "urn:oasis:names:tc:SAML:2.0:assertion"
+	nsSAMLMetadata  = "urn:oasis:names:tc:SAML:2.0:metadata"
+
+	samlStatusSuccess   = "urn:oasis:names:tc:SAML:2.0:status:Success"
+	samlBearer          = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+	samlBindingPOST     = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+	samlNameIDEmail     = "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"
+	samlNameIDAnyFormat = "urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified"
+
+	// clockSkew tolerates small clock differences with the IdP.
+	clockSkew = 2 * time.Minute
+)
+
+var (
+	defaultSAMLEmailAttributes = []string{
+		"email",
+		"mail",
+		"emailaddress",
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress",
+		"urn:oid:0.9.2342.19200300.100.1.3",
+	}
+	defaultSAMLGroupsAttributes = []string{
+		"groups",
+		"memberOf",
+		"http://schemas.microsoft.com/ws/2008/06/identity/claims/groups",
+	}
+)
+
+// SAMLProvider is the service-provider side of SAML 2.0 browser SSO. Logins
+// are SP-initiated only: an unsigned AuthnRequest goes out over the
+// HTTP-Redirect binding and the signed Response comes back over HTTP-POST,
+// answering that request. Encrypted assertions are not supported.
+type SAMLProvider struct{}
+
+func (SAMLProvider) AuthorizationURL(conn ports.SSOConnection, sp ports.SSOServiceProvider, login ports.SSOLoginState, state string) (string, error) {
+	target, err := url.Parse(conn.SAML.SSOURL)
+	if err != nil {
+		return "", err
+	}
+	request := `<samlp:AuthnRequest xmlns:samlp="` + nsSAMLProtocol + `" xmlns:saml="` + nsSAMLAssertion + `"` +
+		` ID="` + xmlEscape(login.RequestID) + `" Version="2.0"` +
+		` IssueInstant="` + login.CreatedAt.UTC().Format(time.RFC3339) + `"` +
+		` Destination="` + xmlEscape(conn.SAML.SSOURL) + `"` +
+		` AssertionConsumerServiceURL="` + xmlEscape(sp.ACSURL) + `"` +
+		` ProtocolBinding="` + samlBindingPOST + `">` +
+		`<saml:Issuer>` + xmlEscape(sp.EntityID) + `</saml:Issuer>` +
+		`<samlp:NameIDPolicy Format="` + samlNameIDAnyFormat + `" AllowCreate="true"/>` +
+		`</samlp:AuthnRequest>`
+
+	var deflated bytes.Buffer
+	writer, err := flate.NewWriter(&deflated, flate.BestCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err := writer.Write([]byte(request)); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+	query := target.Query()
+	query.Set("SAMLRequest", base64.StdEncoding.EncodeToString(deflated.Bytes()))
+	query.Set("RelayState", state)
+	target.RawQuery = query.Encode()
+	return target.String(), nil
+}
+
+func (SAMLProvider) Verify(
+	ctx context.Context,
+	conn ports.SSOConnection,
+	sp ports.SSOServiceProvider,
+	login ports.SSOLoginState,
+	callback ports.SSOCallback,
+	now time.Time,
+) (ports.SSOAssertion, error) {
+	raw, err := decodeBase64(callback.SAMLResponse)
+	if err != nil || len(raw) == 0 {
+		return ports.SSOAssertion{}, invalidAssertion("SAMLResponse is not base64")
+	}
+	root, err := parseXML(raw)
+	if err != nil {
+		return ports.SSOAssertion{}, invalidAssertion("SAMLResponse is not well-formed: " + err.Error())
+	}
+	if !root.is(nsSAMLProtocol, "Response") {
+		return ports.SSOAssertion{}, invalidAssertion("not a SAML Response")
+	}
+	if destination, ok := root.attr("Destination"); ok && destination != sp.ACSURL {
+		return ports.SSOAssertion{}, invalidAssertion("response destination mismatch")
+	}
+	if root.attrValue("InResponseTo") != login.RequestID {
+		return ports.SSOAssertion{}, invalidAssertion("response does not answer this login")
+	}
+	status := root.child(nsSAMLProtocol, "Status")
+	if status == nil {
+		return ports.SSOAssertion{}, invalidAssertion("response has no status")
+	}
+	if code := status.child(nsSAMLProtocol, "StatusCode"); code == nil || code.attrValue("Value") != samlStatusSuccess {
+		return ports.SSOAssertion{}, invalidAssertion("IdP reported a failed login")
+	}
+	if len(root.childElements(nsSAMLAssertion, "EncryptedAssertion")) > 0 {
+		return ports.SSOAssertion{}, invalidAssertion("encrypted assertions are not supported")
+	}
+	assertion := root.child(nsSAMLAssertion, "Assertion")
+	if assertion == nil {
+		return ports.SSOAssertion{}, invalidAssertion("response must carry exactly one assertion")
+	}
+
+	cert, err := domainservices.ParseIdPCertificate(conn.SAML.Certificate)
+	if err != nil {
+		return ports.SSOAssertion{}, err
+	}
+	signed := false
+	for _, el := range []*xmlNode{root, assertion} {
+		switch len(el.childElements(nsXMLDSig, "Signature")) {
+		case 0:
+			continue
+		case 1:
+		default:
+			return ports.SSOAssertion{}, invalidAssertion("element has more than one signature")
+		}
+		if err := verifyEnvelopedSignature(root, el, cert); err != nil {
+			return ports.SSOAssertion{}, invalidAssertion(err.Error())
+		}
+		signed = true
+	}
+	if !signed {
+		return ports.SSOAssertion{}, invalidAssertion("neither the response nor the assertion is signed")
+	}
+
+	if issuer := assertion.child(nsSAMLAssertion, "Issuer"); issuer == nil || issuer.textContent() != conn.SAML.IdPEntityID {
+		return ports.SSOAssertion{}, invalidAssertion("assertion issuer mismatch")
+	}
+	subject := assertion.child(nsSAMLAssertion, "Subject")
+	if subject == nil {
+		return ports.SSOAssertion{}, invalidAssertion("assertion has no subject")
+	}
+	nameID := subject.child(nsSAMLAssertion, "NameID")
+	if nameID == nil || nameID.textContent() == "" {
+		return ports.SSOAssertion{}, invalidAssertion("assertion has no NameID")
+	}
+	if err := checkBearerConfirmation(subject, sp, login, now); err != nil {
+		return ports.SSOAssertion{}, err
+	}
+	if err := checkConditions(assertion, sp, now); err != nil {
+		return ports.SSOAssertion{}, err
+	}
+
+	attributes := samlAttributes(assertion)
+	emailNames := defaultSAMLEmailAttributes
+	if conn.SAML.EmailAttribute != "" {
+		emailNames = []string{conn.SAML.EmailAttribute}
+	}
+	groupNames := defaultSAMLGroupsAttributes
+	if conn.SAML.GroupsAttribute != "" {
+		groupNames = []string{conn.SAML.GroupsAttribute}
+	}
+	out := ports.SSOAssertion{Subject: nameID.textContent()}
+	for _, name := range emailNames {
+		if values := attributes[name]; len(values) > 0 {
+			out.Email = values[0]
+			break
+		}
+	}
+	if out.Email == "" && nameID.attrValue("Format") == samlNameIDEmail {
+		out.Email = nameID.textContent()
+	}
+	for _, name := range groupNames {
+		out.Groups = append(out.Groups, attributes[name]...)
+	}
+	return out, nil
+}
+
+// Metadata describes this SP for one connection, for IdP admins to import.
+func (SAMLProvider) Metadata(sp ports.SSOServiceProvider) ([]byte, error) {
+	doc := xml.Header +
+		`<md:EntityDescriptor xmlns:md="` + nsSAMLMetadata + `" entityID="` + xmlEscape(sp.EntityID) + `">` +
+		`<md:SPSSODescriptor AuthnRequestsSigned="false" WantAssertionsSigned="true" protocolSupportEnumeration="` + nsSAMLProtocol + `">` +
+		`<md:NameIDFormat>` + samlNameIDEmail + `</md:NameIDFormat>` +
+		`<md:AssertionConsumerService Binding="` + samlBindingPOST + `" Location="` + xmlEscape(sp.ACSURL) + `" index="0" isDefault="true"/>` +
+		`</md:SPSSODescriptor>` +
+		`</md:EntityDescriptor>`
+	return []byte(doc), nil
+}
+
+// checkBearerConfirmation requires a bearer confirmation issued for this
+// login, addressed to our ACS and not yet expired.
+func checkBearerConfirmation(subject *xmlNode, sp ports.SSOServiceProvider, login ports.SSOLoginState, now time.Time) error {
+	for _, confirmation := range subject.childElements(nsSAMLAssertion, "SubjectConfirmation") {
+		if confirmation.attrValue("Method") != samlBearer {
+			continue
+		}
+		data := confirmation.child(nsSAMLAssertion, "SubjectConfirmationData")
+		if data == nil ||
+			data.attrValue("Recipient") != sp.ACSURL ||
+			data.attrValue("InResponseTo") != login.RequestID {
+			continue
+		}
+		notOnOrAfter, err := parseSAMLTime(data.attrValue("NotOnOrAfter"))
+		if err != nil || !now.Before(notOnOrAfter.Add(clockSkew)) {
+			continue
+		}
+		if raw, ok := data.attr("NotBefore"); ok {
+			notBefore, err := parseSAMLTime(raw)
+			if err != nil || now.Add(clockSkew).Before(notBefore) {
+				continue
+			}
+		}
+		return nil
+	}
+	return invalidAssertion("no valid bearer subject confirmation")
+}
+
+// checkConditions enforces the assertion's validity window and requires
+// every audience restriction to name this SP.
+func checkConditions(assertion *xmlNode, sp ports.SSOServiceProvider, now time.Time) error {
+	conditions := assertion.child(nsSAMLAssertion, "Conditions")
+	if conditions == nil {
+		return invalidAssertion("assertion has no conditions")
+	}
+	if raw, ok := conditions.attr("NotBefore"); ok {
+		notBefore, err := parseSAMLTime(raw)
+		if err != nil || now.Add(clockSkew).Before(notBefore) {
+			return invalidAssertion("assertion is not valid yet")
+		}
+	}
+	if raw, ok := conditions.attr("NotOnOrAfter"); ok {
+		notOnOrAfter, err := parseSAMLTime(raw)
+		if err != nil || !now.Before(notOnOrAfter.Add(clockSkew)) {
+			return invalidAssertion("assertion has expired")
+		}
+	}
+	restrictions := conditions.childElements(nsSAMLAssertion, "AudienceRestriction")
+	if len(restrictions) == 0 {
+		return invalidAssertion("assertion has no audience restriction")
+	}
+	for _, restriction := range restrictions {
+		matched := false
+		for _, audience := range restriction.childElements(nsSAMLAssertion, "Audience") {
+			if audience.textContent() == sp.EntityID {
+				matched = true
+			}
+		}
+		if !matched {
+			return invalidAssertion("assertion is for another audience")
+		}
+	}
+	return nil
+}
+
+// samlAttributes indexes attribute values by Name and by FriendlyName.
+func samlAttributes(assertion *xmlNode) map[string][]string {
+	out := map[string][]string{}
+	for _, statement := range assertion.childElements(nsSAMLAssertion, "AttributeStatement") {
+		for _, attribute := range statement.childElements(nsSAMLAssertion, "Attribute") {
+			var values []string
+			for _, value := range attribute.childElements(nsSAMLAssertion, "AttributeValue") {
+				if text := value.textContent(); text != "" {
+					values = append(values, text)
+				}
+			}
+			for _, key := range []string{attribute.attrValue("Name"), attribute.attrValue("FriendlyName")} {
+				if key != "" {
+					out[key] = append(out[key], values...)
+				}
+			}
+		}
+	}
+	return out
+}
+
+func parseSAMLTime(raw string) (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, strings.TrimSpace(raw))
+}
+
+func xmlEscape(value string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(value))
+	return buf.String()
+}
+
+func invalidAssertion(reason string) error {
+	return fmt.Errorf("%w: %s", domainerrors.ErrSSOAssertionInvalid, reason)
+}
+
+var _ ports.SAMLProtocol = SAMLProvider{}
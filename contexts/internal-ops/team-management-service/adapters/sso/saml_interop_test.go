@@ -0,0 +1,205 @@
+package sso
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	domainerrors "solomon/contexts/internal-ops/team-management-service/domain/errors"
+	"solomon/contexts/internal-ops/team-management-service/ports"
+)
+
+// The responses under testdata/saml were signed by libxmlsec1 rather than
+// this package, in the layouts Okta, AD FS, Entra ID and Shibboleth emit.
+// testdata/saml/README.md describes each file.
+var fixtureNow = time.Date(2026, time.March, 2, 9, 1, 0, 0, time.UTC)
+
+type samlFixture struct {
+	file   string
+	issuer string
+	want   ports.SSOAssertion
+}
+
+var samlFixtures = []samlFixture{
+	{
+		file:   "okta.xml",
+		issuer: "http://www.okta.com/exk1fixture",
+		want:   ports.SSOAssertion{Subject: "ana@acme.test", Email: "ana@acme.test", Groups: []string{"Everyone", "brand-admins"}},
+	},
+	{
+		file:   "okta-attacker.xml",
+		issuer: "http://www.okta.com/exk1fixture",
+		want:   ports.SSOAssertion{Subject: "ana@acme.test.evil.example", Email: "ana@acme.test.evil.example", Groups: []string{"Everyone", "brand-admins"}},
+	},
+	{
+		file:   "adfs.xml",
+		issuer: "http://adfs.fixture.example/adfs/services/trust",
+		want:   ports.SSOAssertion{Subject: `FIXTURE\bo.jensen`, Email: "bo.jensen@acme.test", Groups: []string{"Domain Users", "Brand & Design"}},
+	},
+	{
+		file:   "entra.xml",
+		issuer: "https://sts.windows.net/00000000-fixture-tenant/",
+		want:   ports.SSOAssertion{Subject: "cy@acme.test", Email: "cy@acme.test", Groups: []string{"6f1c2d3e-4a5b-4c6d-8e7f-90a1b2c3d4e5"}},
+	},
+	{
+		file:   "shibboleth.xml",
+		issuer: "https://idp.fixture.example/idp/shibboleth",
+		want:   ports.SSOAssertion{Subject: "AAdzZWNyZXQxfixture==", Email: "dee@acme.test", Groups: []string{"cn=brand <editors>,ou=groups"}},
+	},
+}
+
+func TestSAMLVerifiesIdPFixtures(t *testing.T) {
+	for _, fixture := range samlFixtures {
+		got, err := verifyFixture(t, fixture.issuer, readFixture(t, fixture.file))
+		if err != nil {
+			t.Fatalf("%s: verify: %v", fixture.file, err)
+		}
+		if !sameAssertion(got, fixture.want) {
+			t.Fatalf("%s: expected %+v, got %+v", fixture.file, fixture.want, got)
+		}
+	}
+}
+
+func TestSAMLCommentInjectionDoesNotTruncateIdentity(t *testing.T) {
+	// The attacker's own signed login with "<!---->" spliced into the
+	// address: the signature still holds, so the identity must be the
+	// attacker's full address, never the victim's prefix.
+	got, err := verifyFixture(t, "http://www.okta.com/exk1fixture", readFixture(t, "attacks/comment-injection.xml"))
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if got.Email != "ana@acme.test.evil.example" || got.Subject != "ana@acme.test.evil.example" {
+		t.Fatalf("comment truncated the identity: %+v", got)
+	}
+}
+
+func TestSAMLRejectsSignatureWrappingFixtures(t *testing.T) {
+	cases := []struct {
+		file   string
+		issuer string
+	}{
+		{file: "wrap-assertion-extensions.xml", issuer: "http://adfs.fixture.example/adfs/services/trust"},
+		{file: "wrap-assertion-same-id.xml", issuer: "http://adfs.fixture.example/adfs/services/trust"},
+		{file: "wrap-signature-copied.xml", issuer: "http://adfs.fixture.example/adfs/services/trust"},
+		{file: "wrap-nested-in-advice.xml", issuer: "http://adfs.fixture.example/adfs/services/trust"},
+		{file: "wrap-second-assertion.xml", issuer: "http://adfs.fixture.example/adfs/services/trust"},
+		{file: "wrap-response.xml", issuer: "http://www.okta.com/exk1fixture"},
+		{file: "attacker-signed.xml", issuer: "http://adfs.fixture.example/adfs/services/trust"},
+	}
+	for _, tc := range cases {
+		got, err := verifyFixture(t, tc.issuer, readFixture(t, filepath.Join("attacks", tc.file)))
+		if !errors.Is(err, domainerrors.ErrSSOAssertionInvalid) {
+			t.Fatalf("%s: expected rejection, got %+v err=%v", tc.file, got, err)
+		}
+	}
+}
+
+func TestParseXMLRejectsDuplicateAttributes(t *testing.T) {
+	for _, doc := range []string{
+		`<a ID="x" ID="y"/>`,
+		`<a xmlns:p="urn:p" xmlns:q="urn:p" p:ID="x" q:ID="y"/>`,
+		`<a xmlns:p="urn:p" xmlns:p="urn:q"/>`,
+	} {
+		if _, err := parseXML([]byte(doc)); err == nil {
+			t.Fatalf("expected %s to be rejected", doc)
+		}
+	}
+}
+
+// FuzzSAMLVerify feeds arbitrary responses to the verifier under every
+// fixture connection. Only the fixtures carry a valid signature, so any
+// accepted response must yield exactly the identity a fixture signed.
+func FuzzSAMLVerify(f *testing.F) {
+	for _, dir := range []string{"testdata/saml", "testdata/saml/attacks"} {
+		files, err := filepath.Glob(filepath.Join(dir, "*.xml"))
+		if err != nil {
+			f.Fatal(err)
+		}
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				f.Fatal(err)
+			}
+			f.Add(data)
+		}
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for _, fixture := range samlFixtures {
+			got, err := verifyFixture(t, fixture.issuer, data)
+			if err != nil {
+				continue
+			}
+			if !signedByFixture(fixture.issuer, got) {
+				t.Fatalf("accepted an identity no fixture signed: %+v", got)
+			}
+		}
+	})
+}
+
+// FuzzCanonicalize checks that canonical output parses back and is a
+// fixed point, so the digest of a document does not depend on how many
+// times it went through the canonicalizer.
+func FuzzCanonicalize(f *testing.F) {
+	for _, fixture := range samlFixtures {
+		data, err := os.ReadFile(filepath.Join("testdata/saml", fixture.file))
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(data)
+	}
+	f.Add([]byte(`<a xmlns="urn:a"><b xmlns=""><!-- c --><![CDATA[<&>]]></b><p:c xmlns:p="urn:p" p:x="&#x9;&#xD;"/></a>`))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		root, err := parseXML(data)
+		if err != nil {
+			return
+		}
+		first := canonicalize(root, nil, nil)
+		again, err := parseXML(first)
+		if err != nil {
+			t.Fatalf("canonical form does not parse: %v\n%s", err, first)
+		}
+		if second := canonicalize(again, nil, nil); !bytes.Equal(first, second) {
+			t.Fatalf("canonical form is not stable:\n%s\n%s", first, second)
+		}
+	})
+}
+
+func verifyFixture(t testing.TB, issuer string, data []byte) (ports.SSOAssertion, error) {
+	t.Helper()
+	conn := ports.SSOConnection{
+		ConnectionID: "conn_1",
+		Protocol:     ports.SSOProtocolSAML,
+		SAML:         ports.SAMLSettings{IdPEntityID: issuer, Certificate: string(readFixture(t, "idp-cert.pem"))},
+	}
+	login := ports.SSOLoginState{ConnectionID: "conn_1", RequestID: "_request_1", CreatedAt: fixtureNow}
+	callback := ports.SSOCallback{SAMLResponse: base64.StdEncoding.EncodeToString(data)}
+	return SAMLProvider{}.Verify(context.Background(), conn, testSP, login, callback, fixtureNow)
+}
+
+func readFixture(t testing.TB, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata/saml", name))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	return data
+}
+
+func signedByFixture(issuer string, got ports.SSOAssertion) bool {
+	for _, fixture := range samlFixtures {
+		if fixture.issuer == issuer && sameAssertion(got, fixture.want) {
+			return true
+		}
+	}
+	return false
+}
+
+func sameAssertion(a ports.SSOAssertion, b ports.SSOAssertion) bool {
+	return a.Subject == b.Subject && a.Email == b.Email && slices.Equal(a.Groups, b.Groups)
+}
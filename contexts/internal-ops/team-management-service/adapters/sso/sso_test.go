@@ -0,0 +1,475 @@
+package sso
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"encoding/xml"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	domainerrors "solomon/contexts/internal-ops/team-management-service/domain/errors"
+	"solomon/contexts/internal-ops/team-management-service/ports"
+)
+
+var testSP = ports.SSOServiceProvider{
+	EntityID:    "https://solomon.test/teams/sso/conn_1/saml/metadata",
+	ACSURL:      "https://solomon.test/teams/sso/conn_1/saml/acs",
+	RedirectURL: "https://solomon.test/teams/sso/conn_1/oidc/callback",
+}
+
+func TestOIDCLoginVerifiesIDToken(t *testing.T) {
+	idp := newFakeIdP(t)
+	provider := NewOIDCProvider(idp.server.Client())
+	conn := ports.SSOConnection{ConnectionID: "conn_1", Protocol: ports.SSOProtocolOIDC, OIDC: idp.oidcSettings()}
+	login := testLogin()
+
+	authURL, err := provider.AuthorizationURL(conn, testSP, login, "state-1")
+	if err != nil {
+		t.Fatalf("authorization url: %v", err)
+	}
+	code, state := idp.authorize(t, authURL, fakeUser{Subject: "sub-1", Email: "ana@acme.test", Groups: []string{"editors"}})
+	if state != "state-1" {
+		t.Fatalf("expected state to round-trip, got %q", state)
+	}
+	assertion, err := provider.Verify(context.Background(), conn, testSP, login, ports.SSOCallback{Code: code}, time.Now())
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if assertion.Subject != "sub-1" || assertion.Email != "ana@acme.test" || len(assertion.Groups) != 1 || assertion.Groups[0] != "editors" {
+		t.Fatalf("unexpected assertion: %+v", assertion)
+	}
+
+	if _, err := provider.Verify(context.Background(), conn, testSP, login, ports.SSOCallback{Code: code}, time.Now()); !errors.Is(err, domainerrors.ErrSSOAssertionInvalid) {
+		t.Fatalf("expected a replayed code to be rejected, got %v", err)
+	}
+}
+
+func TestOIDCRejectsNonceMismatchAndUnverifiedEmail(t *testing.T) {
+	idp := newFakeIdP(t)
+	provider := NewOIDCProvider(idp.server.Client())
+	conn := ports.SSOConnection{ConnectionID: "conn_1", Protocol: ports.SSOProtocolOIDC, OIDC: idp.oidcSettings()}
+
+	login := testLogin()
+	authURL, _ := provider.AuthorizationURL(conn, testSP, login, "state-1")
+	code, _ := idp.authorize(t, authURL, fakeUser{Subject: "sub-1", Email: "ana@acme.test"})
+	other := login
+	other.Nonce = "another-nonce"
+	if _, err := provider.Verify(context.Background(), conn, testSP, other, ports.SSOCallback{Code: code}, time.Now()); !errors.Is(err, domainerrors.ErrSSOAssertionInvalid) {
+		t.Fatalf("expected nonce mismatch to be rejected, got %v", err)
+	}
+
+	authURL, _ = provider.AuthorizationURL(conn, testSP, login, "state-2")
+	code, _ = idp.authorize(t, authURL, fakeUser{Subject: "sub-1", Email: "ana@acme.test", Unverified: true})
+	if _, err := provider.Verify(context.Background(), conn, testSP, login, ports.SSOCallback{Code: code}, time.Now()); !errors.Is(err, domainerrors.ErrSSOAssertionInvalid) {
+		t.Fatalf("expected unverified email to be rejected, got %v", err)
+	}
+}
+
+func TestSAMLLoginVerifiesSignedAssertion(t *testing.T) {
+	idp := newFakeIdP(t)
+	conn := ports.SSOConnection{ConnectionID: "conn_1", Protocol: ports.SSOProtocolSAML, SAML: idp.samlSettings()}
+	login := testLogin()
+	provider := SAMLProvider{}
+
+	authURL, err := provider.AuthorizationURL(conn, testSP, login, "state-1")
+	if err != nil {
+		t.Fatalf("authorization url: %v", err)
+	}
+	form := idp.samlPost(t, authURL, fakeUser{Subject: "ana", Email: "ana@acme.test", Groups: []string{"admins", "editors"}})
+	if form.Get("RelayState") != "state-1" {
+		t.Fatalf("expected relay state to round-trip, got %q", form.Get("RelayState"))
+	}
+	assertion, err := provider.Verify(context.Background(), conn, testSP, login, ports.SSOCallback{SAMLResponse: form.Get("SAMLResponse")}, time.Now())
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if assertion.Subject != "ana" || assertion.Email != "ana@acme.test" || len(assertion.Groups) != 2 {
+		t.Fatalf("unexpected assertion: %+v", assertion)
+	}
+
+	metadata, err := provider.Metadata(testSP)
+	if err != nil || !bytes.Contains(metadata, []byte(testSP.ACSURL)) {
+		t.Fatalf("expected metadata to advertise the ACS url: %v", err)
+	}
+}
+
+func TestSAMLRejectsTamperedForeignAndStaleResponses(t *testing.T) {
+	idp := newFakeIdP(t)
+	conn := ports.SSOConnection{ConnectionID: "conn_1", Protocol: ports.SSOProtocolSAML, SAML: idp.samlSettings()}
+	login := testLogin()
+	provider := SAMLProvider{}
+	authURL, _ := provider.AuthorizationURL(conn, testSP, login, "state-1")
+	response := idp.samlPost(t, authURL, fakeUser{Subject: "ana", Email: "ana@acme.test"}).Get("SAMLResponse")
+
+	raw, _ := base64.StdEncoding.DecodeString(response)
+	tampered := base64.StdEncoding.EncodeToString(bytes.Replace(raw, []byte("ana@acme.test"), []byte("eve@acme.test"), 1))
+	foreign := conn
+	foreign.SAML.Certificate = newFakeIdP(t).samlSettings().Certificate
+	otherLogin := login
+	otherLogin.RequestID = "_another_request"
+
+	cases := []struct {
+		name     string
+		conn     ports.SSOConnection
+		login    ports.SSOLoginState
+		response string
+		now      time.Time
+	}{
+		{name: "tampered", conn: conn, login: login, response: tampered, now: time.Now()},
+		{name: "wrong certificate", conn: foreign, login: login, response: response, now: time.Now()},
+		{name: "expired", conn: conn, login: login, response: response, now: time.Now().Add(time.Hour)},
+		{name: "other request", conn: conn, login: otherLogin, response: response, now: time.Now()},
+	}
+	for _, tc := range cases {
+		_, err := provider.Verify(context.Background(), tc.conn, testSP, tc.login, ports.SSOCallback{SAMLResponse: tc.response}, tc.now)
+		if !errors.Is(err, domainerrors.ErrSSOAssertionInvalid) {
+			t.Fatalf("%s: expected assertion to be rejected, got %v", tc.name, err)
+		}
+	}
+}
+
+func testLogin() ports.SSOLoginState {
+	return ports.SSOLoginState{
+		ConnectionID: "conn_1",
+		Nonce:        "nonce-1",
+		CodeVerifier: "verifier-verifier-verifier-verifier-verifier",
+		RequestID:    "_request_1",
+		CreatedAt:    time.Now().UTC(),
+	}
+}
+
+type fakeUser struct {
+	Subject    string
+	Email      string
+	Groups     []string
+	Unverified bool
+}
+
+// fakeIdP is an OIDC provider and SAML IdP in one TLS test server. It
+// signs with a throwaway key whose self-signed certificate stands in for
+// the one an admin would paste into the connection.
+type fakeIdP struct {
+	server  *httptest.Server
+	key     *rsa.PrivateKey
+	certPEM string
+
+	mu    sync.Mutex
+	codes map[string]fakeCode
+}
+
+type fakeCode struct {
+	user      fakeUser
+	nonce     string
+	challenge string
+	redirect  string
+}
+
+func newFakeIdP(t *testing.T) *fakeIdP {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "fake-idp"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	idp := &fakeIdP{
+		key:     key,
+		certPEM: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		codes:   map[string]fakeCode{},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", idp.handleToken)
+	mux.HandleFunc("/jwks", idp.handleJWKS)
+	idp.server = httptest.NewTLSServer(mux)
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+func (idp *fakeIdP) oidcSettings() ports.OIDCSettings {
+	return ports.OIDCSettings{
+		Issuer:           idp.server.URL,
+		ClientID:         "solomon client",
+		ClientSecret:     "s3cret:with/chars",
+		AuthorizationURL: idp.server.URL + "/authorize",
+		TokenURL:         idp.server.URL + "/token",
+		JWKSURL:          idp.server.URL + "/jwks",
+	}
+}
+
+func (idp *fakeIdP) samlSettings() ports.SAMLSettings {
+	return ports.SAMLSettings{
+		IdPEntityID: idp.server.URL + "/saml",
+		SSOURL:      idp.server.URL + "/saml/sso",
+		Certificate: idp.certPEM,
+	}
+}
+
+// authorize plays the user signing in at the IdP and returns the code and
+// state the IdP would redirect back with.
+func (idp *fakeIdP) authorize(t *testing.T, authURL string, user fakeUser) (string, string) {
+	t.Helper()
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("parse authorization url: %v", err)
+	}
+	query := parsed.Query()
+	if query.Get("code_challenge_method") != "S256" || query.Get("response_type") != "code" {
+		t.Fatalf("unexpected authorization request: %s", authURL)
+	}
+	code := randomText(t)
+	idp.mu.Lock()
+	idp.codes[code] = fakeCode{
+		user:      user,
+		nonce:     query.Get("nonce"),
+		challenge: query.Get("code_challenge"),
+		redirect:  query.Get("redirect_uri"),
+	}
+	idp.mu.Unlock()
+	return code, query.Get("state")
+}
+
+func (idp *fakeIdP) handleToken(w http.ResponseWriter, r *http.Request) {
+	settings := idp.oidcSettings()
+	clientID, secret, _ := r.BasicAuth()
+	if id, _ := url.QueryUnescape(clientID); id != settings.ClientID {
+		http.Error(w, "invalid_client", http.StatusUnauthorized)
+		return
+	}
+	if value, _ := url.QueryUnescape(secret); value != settings.ClientSecret {
+		http.Error(w, "invalid_client", http.StatusUnauthorized)
+		return
+	}
+	_ = r.ParseForm()
+	idp.mu.Lock()
+	grant, ok := idp.codes[r.PostForm.Get("code")]
+	delete(idp.codes, r.PostForm.Get("code"))
+	idp.mu.Unlock()
+	challenge := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok ||
+		grant.redirect != r.PostForm.Get("redirect_uri") ||
+		grant.challenge != base64.RawURLEncoding.EncodeToString(challenge[:]) {
+		http.Error(w, "invalid_grant", http.StatusBadRequest)
+		return
+	}
+	now := time.Now()
+	claims := map[string]any{
+		"iss":            settings.Issuer,
+		"aud":            []string{settings.ClientID},
+		"azp":            settings.ClientID,
+		"sub":            grant.user.Subject,
+		"email":          grant.user.Email,
+		"email_verified": !grant.user.Unverified,
+		"groups":         grant.user.Groups,
+		"nonce":          grant.nonce,
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+	}
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": "key-1", "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	signature := idp.sign([]byte(signingInput))
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"id_token":   signingInput + "." + base64.RawURLEncoding.EncodeToString(signature),
+		"token_type": "Bearer",
+	})
+}
+
+func (idp *fakeIdP) handleJWKS(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "key-1",
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(idp.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(idp.key.E)).Bytes()),
+		}},
+	})
+}
+
+// samlPost reads the AuthnRequest out of authURL and returns the form the
+// IdP would auto-post to the ACS: a Response carrying a signed assertion.
+func (idp *fakeIdP) samlPost(t *testing.T, authURL string, user fakeUser) url.Values {
+	t.Helper()
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("parse authorization url: %v", err)
+	}
+	deflated, err := base64.StdEncoding.DecodeString(parsed.Query().Get("SAMLRequest"))
+	if err != nil {
+		t.Fatalf("decode SAMLRequest: %v", err)
+	}
+	inflated, err := io.ReadAll(flate.NewReader(bytes.NewReader(deflated)))
+	if err != nil {
+		t.Fatalf("inflate SAMLRequest: %v", err)
+	}
+	var request struct {
+		ID     string `xml:"ID,attr"`
+		ACS    string `xml:"AssertionConsumerServiceURL,attr"`
+		Issuer string `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+	}
+	if err := xml.Unmarshal(inflated, &request); err != nil {
+		t.Fatalf("parse AuthnRequest: %v", err)
+	}
+
+	now := time.Now().UTC()
+	instant := now.Format(time.RFC3339)
+	expires := now.Add(5 * time.Minute).Format(time.RFC3339)
+	assertionID := "_a" + randomText(t)
+	issuer := fakeElement{name: "saml:Issuer", text: idp.samlSettings().IdPEntityID}
+	body := []fakeElement{
+		{name: "saml:Subject", children: []fakeElement{
+			{name: "saml:NameID", attrs: [][2]string{{"Format", samlNameIDAnyFormat}}, text: user.Subject},
+			{name: "saml:SubjectConfirmation", attrs: [][2]string{{"Method", samlBearer}}, children: []fakeElement{
+				{name: "saml:SubjectConfirmationData", attrs: [][2]string{
+					{"InResponseTo", request.ID}, {"NotOnOrAfter", expires}, {"Recipient", request.ACS},
+				}},
+			}},
+		}},
+		{name: "saml:Conditions", attrs: [][2]string{{"NotBefore", instant}, {"NotOnOrAfter", expires}}, children: []fakeElement{
+			{name: "saml:AudienceRestriction", children: []fakeElement{{name: "saml:Audience", text: request.Issuer}}},
+		}},
+		{name: "saml:AttributeStatement", children: []fakeElement{
+			fakeAttribute("email", user.Email),
+			fakeAttribute("groups", user.Groups...),
+		}},
+	}
+	assertion := fakeElement{
+		name:     "saml:Assertion",
+		attrs:    [][2]string{{"xmlns:saml", nsSAMLAssertion}, {"ID", assertionID}, {"IssueInstant", instant}, {"Version", "2.0"}},
+		children: append([]fakeElement{issuer}, body...),
+	}
+	digest := sha256.Sum256([]byte(assertion.render(true)))
+
+	algorithm := func(name string, value string) fakeElement {
+		return fakeElement{name: name, attrs: [][2]string{{"Algorithm", value}}}
+	}
+	signedInfo := fakeElement{
+		name:  "ds:SignedInfo",
+		attrs: [][2]string{{"xmlns:ds", nsXMLDSig}},
+		children: []fakeElement{
+			algorithm("ds:CanonicalizationMethod", algExcC14N),
+			algorithm("ds:SignatureMethod", algRSASHA256),
+			{name: "ds:Reference", attrs: [][2]string{{"URI", "#" + assertionID}}, children: []fakeElement{
+				{name: "ds:Transforms", children: []fakeElement{
+					algorithm("ds:Transform", algEnveloped),
+					algorithm("ds:Transform", algExcC14N),
+				}},
+				algorithm("ds:DigestMethod", algSHA256),
+				{name: "ds:DigestValue", text: base64.StdEncoding.EncodeToString(digest[:])},
+			}},
+		},
+	}
+	signature := idp.sign([]byte(signedInfo.render(true)))
+
+	// The document form differs from the signed canonical form the way real
+	// IdP output does: namespaces inherited from the Response, reordered
+	// attributes and self-closed empty elements.
+	signedInfo.attrs = nil
+	assertion.attrs = assertion.attrs[1:]
+	assertion.children = append([]fakeElement{issuer, {
+		name:  "ds:Signature",
+		attrs: [][2]string{{"xmlns:ds", nsXMLDSig}},
+		children: []fakeElement{
+			signedInfo,
+			{name: "ds:SignatureValue", text: base64.StdEncoding.EncodeToString(signature)},
+		},
+	}}, body...)
+	response := `<?xml version="1.0" encoding="UTF-8"?>` +
+		`<samlp:Response xmlns:samlp="` + nsSAMLProtocol + `" xmlns:saml="` + nsSAMLAssertion + `"` +
+		` ID="_r` + randomText(t) + `" Version="2.0" IssueInstant="` + instant + `"` +
+		` Destination="` + request.ACS + `" InResponseTo="` + request.ID + `">` +
+		`<saml:Issuer>` + idp.samlSettings().IdPEntityID + `</saml:Issuer>` +
+		`<samlp:Status><samlp:StatusCode Value="` + samlStatusSuccess + `"/></samlp:Status>` +
+		assertion.render(false) +
+		`</samlp:Response>`
+
+	return url.Values{
+		"SAMLResponse": {base64.StdEncoding.EncodeToString([]byte(response))},
+		"RelayState":   {parsed.Query().Get("RelayState")},
+	}
+}
+
+func (idp *fakeIdP) sign(data []byte) []byte {
+	digest := sha256.Sum256(data)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, idp.key, crypto.SHA256, digest[:])
+	if err != nil {
+		panic(err)
+	}
+	return signature
+}
+
+// fakeElement renders either in exclusive canonical form, with attributes
+// given in canonical order, or as an IdP might write it.
+type fakeElement struct {
+	name     string
+	attrs    [][2]string
+	text     string
+	children []fakeElement
+}
+
+func fakeAttribute(name string, values ...string) fakeElement {
+	attribute := fakeElement{name: "saml:Attribute", attrs: [][2]string{{"Name", name}}}
+	for _, value := range values {
+		attribute.children = append(attribute.children, fakeElement{name: "saml:AttributeValue", text: value})
+	}
+	return attribute
+}
+
+func (e fakeElement) render(canonical bool) string {
+	var b strings.Builder
+	b.WriteString("<" + e.name)
+	for i := range e.attrs {
+		attr := e.attrs[i]
+		if !canonical {
+			attr = e.attrs[len(e.attrs)-1-i]
+		}
+		b.WriteString(" " + attr[0] + `="` + escapeCanonicalAttr(attr[1]) + `"`)
+	}
+	if !canonical && e.text == "" && len(e.children) == 0 {
+		b.WriteString("/>")
+		return b.String()
+	}
+	b.WriteString(">" + escapeCanonicalText(e.text))
+	for _, child := range e.children {
+		b.WriteString(child.render(canonical))
+	}
+	b.WriteString("</" + e.name + ">")
+	return b.String()
+}
+
+func randomText(t *testing.T) string {
+	buf := make([]byte, 12)
+	if _, err := rand.Read(buf); err != nil {
+		t.Fatalf("random: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
@@ -0,0 +1,469 @@
+package sso
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// The XML signature profile SAML IdPs use in practice: an enveloped
+// RSA-SHA256 signature over exclusive canonical XML with a SHA-256 digest.
+// Anything else is rejected rather than half supported.
+const (
+	nsXMLDSig       = "http://www.w3.org/2000/09/xmldsig#"
+	nsXML           = "http://www.w3.org/XML/1998/namespace"
+	algExcC14N      = "http://www.w3.org/2001/10/xml-exc-c14n#"
+	algEnveloped    = "http://www.w3.org/2000/09/xmldsig#enveloped-signature"
+	algRSASHA256    = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"
+	algSHA256       = "http://www.w3.org/2001/04/xmlenc#sha256"
+	maxXMLDocument  = 1 << 20
+	maxXMLNestDepth = 64
+)
+
+// xmlNode is an element or a text node. Elements keep their prefixes and
+// namespace declarations as written, which canonicalization needs and
+// encoding/xml's resolved names lose.
+type xmlNode struct {
+	parent   *xmlNode
+	children []*xmlNode
+
+	prefix string
+	local  string
+	space  string
+	attrs  []xmlAttr
+	decls  map[string]string
+
+	isText bool
+	text   string
+}
+
+type xmlAttr struct {
+	prefix string
+	local  string
+	space  string
+	value  string
+}
+
+// parseXML builds a tree from a SAML message. DTDs are refused, so no
+// entity expansion or external references are possible; comments and
+// processing instructions are dropped, as exclusive canonicalization
+// without comments does.
+func parseXML(data []byte) (*xmlNode, error) {
+	if len(data) > maxXMLDocument {
+		return nil, errors.New("xml document too large")
+	}
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = true
+
+	var root *xmlNode
+	var current *xmlNode
+	depth := 0
+	// Text is buffered until the next tag, so text split by comments or
+	// CDATA sections becomes one node without quadratic concatenation.
+	var text bytes.Buffer
+	flushText := func() {
+		if text.Len() > 0 {
+			current.children = append(current.children, &xmlNode{parent: current, isText: true, text: text.String()})
+			text.Reset()
+		}
+	}
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch tok := token.(type) {
+		case xml.StartElement:
+			if root != nil && current == nil {
+				return nil, errors.New("xml document has more than one root element")
+			}
+			if current != nil {
+				flushText()
+			}
+			depth++
+			if depth > maxXMLNestDepth {
+				return nil, errors.New("xml document nested too deeply")
+			}
+			node := &xmlNode{parent: current, prefix: tok.Name.Space, local: tok.Name.Local, decls: map[string]string{}}
+			for _, attr := range tok.Attr {
+				switch {
+				case attr.Name.Space == "" && attr.Name.Local == "xmlns":
+					if _, ok := node.decls[""]; ok {
+						return nil, errors.New("duplicate xml namespace declaration")
+					}
+					node.decls[""] = attr.Value
+				case attr.Name.Space == "xmlns":
+					if _, ok := node.decls[attr.Name.Local]; ok {
+						return nil, errors.New("duplicate xml namespace declaration")
+					}
+					node.decls[attr.Name.Local] = attr.Value
+				default:
+					node.attrs = append(node.attrs, xmlAttr{prefix: attr.Name.Space, local: attr.Name.Local, value: attr.Value})
+				}
+			}
+			space, ok := node.lookup(node.prefix)
+			if !ok {
+				return nil, fmt.Errorf("unbound namespace prefix %q", node.prefix)
+			}
+			node.space = space
+			// encoding/xml accepts repeated attributes; refusing them keeps
+			// attr from reading a different value than the signer saw.
+			names := make(map[[2]string]bool, len(node.attrs))
+			for i, attr := range node.attrs {
+				if attr.prefix != "" {
+					space, ok := node.lookup(attr.prefix)
+					if !ok {
+						return nil, fmt.Errorf("unbound namespace prefix %q", attr.prefix)
+					}
+					node.attrs[i].space = space
+				}
+				name := [2]string{node.attrs[i].space, attr.local}
+				if names[name] {
+					return nil, errors.New("duplicate xml attribute")
+				}
+				names[name] = true
+			}
+			if current == nil {
+				root = node
+			} else {
+				current.children = append(current.children, node)
+			}
+			current = node
+		case xml.EndElement:
+			// RawToken leaves matching start and end tags to the caller.
+			if current == nil || tok.Name.Space != current.prefix || tok.Name.Local != current.local {
+				return nil, errors.New("unbalanced xml end element")
+			}
+			flushText()
+			depth--
+			current = current.parent
+		case xml.CharData:
+			if current == nil {
+				if len(bytes.TrimSpace(tok)) > 0 {
+					return nil, errors.New("text outside the xml root element")
+				}
+				continue
+			}
+			text.Write(tok)
+		case xml.Directive:
+			return nil, errors.New("xml directives are not allowed")
+		}
+	}
+	if root == nil || current != nil {
+		return nil, errors.New("incomplete xml document")
+	}
+	return root, nil
+}
+
+// lookup resolves a namespace prefix in scope at n. The empty prefix with
+// no default namespace resolves to "".
+func (n *xmlNode) lookup(prefix string) (string, bool) {
+	if prefix == "xml" {
+		return nsXML, true
+	}
+	for node := n; node != nil; node = node.parent {
+		if space, ok := node.decls[prefix]; ok {
+			return space, true
+		}
+	}
+	return "", prefix == ""
+}
+
+func (n *xmlNode) is(space string, local string) bool {
+	return !n.isText && n.space == space && n.local == local
+}
+
+func (n *xmlNode) attr(local string) (string, bool) {
+	for _, attr := range n.attrs {
+		if attr.prefix == "" && attr.local == local {
+			return attr.value, true
+		}
+	}
+	return "", false
+}
+
+func (n *xmlNode) attrValue(local string) string {
+	value, _ := n.attr(local)
+	return value
+}
+
+func (n *xmlNode) childElements(space string, local string) []*xmlNode {
+	var out []*xmlNode
+	for _, child := range n.children {
+		if child.is(space, local) {
+			out = append(out, child)
+		}
+	}
+	return out
+}
+
+// child returns the only child element with the given name, or nil when
+// there is none or more than one.
+func (n *xmlNode) child(space string, local string) *xmlNode {
+	children := n.childElements(space, local)
+	if len(children) != 1 {
+		return nil
+	}
+	return children[0]
+}
+
+func (n *xmlNode) textContent() string {
+	var b strings.Builder
+	for _, child := range n.children {
+		if child.isText {
+			b.WriteString(child.text)
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+func (n *xmlNode) walk(fn func(*xmlNode)) {
+	if n.isText {
+		return
+	}
+	fn(n)
+	for _, child := range n.children {
+		child.walk(fn)
+	}
+}
+
+// canonicalize writes n in Exclusive XML Canonicalization 1.0 form,
+// omitting the subtree exclude. A namespace is declared on the first
+// output element that visibly uses it, plus, as in inclusive
+// canonicalization, any prefix listed in inclusive that is in scope.
+func canonicalize(n *xmlNode, exclude *xmlNode, inclusive []string) []byte {
+	var buf bytes.Buffer
+	writeCanonical(&buf, n, exclude, inclusive, map[string]string{})
+	return buf.Bytes()
+}
+
+func writeCanonical(buf *bytes.Buffer, n *xmlNode, exclude *xmlNode, inclusive []string, rendered map[string]string) {
+	if n == exclude {
+		return
+	}
+	if n.isText {
+		buf.WriteString(escapeCanonicalText(n.text))
+		return
+	}
+
+	used := []string{n.prefix}
+	for _, attr := range n.attrs {
+		if attr.prefix != "" && attr.prefix != "xml" {
+			used = append(used, attr.prefix)
+		}
+	}
+	for _, prefix := range inclusive {
+		if _, ok := n.lookup(prefix); ok {
+			used = append(used, prefix)
+		}
+	}
+
+	type decl struct{ prefix, space string }
+	var decls []decl
+	scope := make(map[string]string, len(rendered)+len(used))
+	for prefix, space := range rendered {
+		scope[prefix] = space
+	}
+	seen := map[string]bool{}
+	for _, prefix := range used {
+		if seen[prefix] {
+			continue
+		}
+		seen[prefix] = true
+		space, _ := n.lookup(prefix)
+		previous, wasRendered := rendered[prefix]
+		if prefix == "" && space == "" && previous == "" {
+			continue
+		}
+		if wasRendered && previous == space {
+			continue
+		}
+		decls = append(decls, decl{prefix: prefix, space: space})
+		scope[prefix] = space
+	}
+	sort.Slice(decls, func(i int, j int) bool { return decls[i].prefix < decls[j].prefix })
+
+	attrs := append([]xmlAttr(nil), n.attrs...)
+	sort.Slice(attrs, func(i int, j int) bool {
+		if attrs[i].space != attrs[j].space {
+			return attrs[i].space < attrs[j].space
+		}
+		return attrs[i].local < attrs[j].local
+	})
+
+	name := qualifiedName(n.prefix, n.local)
+	buf.WriteByte('<')
+	buf.WriteString(name)
+	for _, d := range decls {
+		if d.prefix == "" {
+			buf.WriteString(` xmlns="`)
+		} else {
+			buf.WriteString(` xmlns:` + d.prefix + `="`)
+		}
+		buf.WriteString(escapeCanonicalAttr(d.space))
+		buf.WriteByte('"')
+	}
+	for _, attr := range attrs {
+		buf.WriteByte(' ')
+		buf.WriteString(qualifiedName(attr.prefix, attr.local))
+		buf.WriteString(`="`)
+		buf.WriteString(escapeCanonicalAttr(attr.value))
+		buf.WriteByte('"')
+	}
+	buf.WriteByte('>')
+	for _, child := range n.children {
+		writeCanonical(buf, child, exclude, inclusive, scope)
+	}
+	buf.WriteString("</" + name + ">")
+}
+
+func qualifiedName(prefix string, local string) string {
+	if prefix == "" {
+		return local
+	}
+	return prefix + ":" + local
+}
+
+var (
+	canonicalTextEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\r", "&#xD;")
+	canonicalAttrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", `"`, "&quot;", "\t", "&#x9;", "\n", "&#xA;", "\r", "&#xD;")
+)
+
+func escapeCanonicalText(s string) string {
+	return canonicalTextEscaper.Replace(s)
+}
+
+func escapeCanonicalAttr(s string) string {
+	return canonicalAttrEscaper.Replace(s)
+}
+
+// verifyEnvelopedSignature checks the ds:Signature child of el: it must
+// reference el by its ID, the digest must match el's canonical form
+// without the signature, and cert must have signed the SignedInfo. IDs
+// must be unique in the document so the reference cannot be pointed at a
+// copy of el planted elsewhere.
+func verifyEnvelopedSignature(root *xmlNode, el *xmlNode, cert *x509.Certificate) error {
+	id, ok := el.attr("ID")
+	if !ok || id == "" {
+		return errors.New("signed element has no ID")
+	}
+	count := 0
+	root.walk(func(node *xmlNode) {
+		if value, ok := node.attr("ID"); ok && value == id {
+			count++
+		}
+	})
+	if count != 1 {
+		return errors.New("signed element ID is not unique")
+	}
+
+	signature := el.child(nsXMLDSig, "Signature")
+	if signature == nil {
+		return errors.New("element is not signed")
+	}
+	signedInfo := signature.child(nsXMLDSig, "SignedInfo")
+	if signedInfo == nil {
+		return errors.New("signature has no SignedInfo")
+	}
+	c14nMethod := signedInfo.child(nsXMLDSig, "CanonicalizationMethod")
+	if c14nMethod == nil || c14nMethod.attrValue("Algorithm") != algExcC14N {
+		return errors.New("unsupported canonicalization method")
+	}
+	method := signedInfo.child(nsXMLDSig, "SignatureMethod")
+	if method == nil || method.attrValue("Algorithm") != algRSASHA256 {
+		return errors.New("unsupported signature method")
+	}
+	reference := signedInfo.child(nsXMLDSig, "Reference")
+	if reference == nil || reference.attrValue("URI") != "#"+id {
+		return errors.New("signature does not reference the signed element")
+	}
+
+	var referenceInclusive []string
+	enveloped, exclusive := false, false
+	if transforms := reference.child(nsXMLDSig, "Transforms"); transforms != nil {
+		for _, transform := range transforms.childElements(nsXMLDSig, "Transform") {
+			switch transform.attrValue("Algorithm") {
+			case algEnveloped:
+				enveloped = true
+			case algExcC14N:
+				exclusive = true
+				referenceInclusive = inclusivePrefixes(transform)
+			default:
+				return errors.New("unsupported signature transform")
+			}
+		}
+	}
+	if !enveloped || !exclusive {
+		return errors.New("signature must use the enveloped and exclusive c14n transforms")
+	}
+	digestMethod := reference.child(nsXMLDSig, "DigestMethod")
+	if digestMethod == nil || digestMethod.attrValue("Algorithm") != algSHA256 {
+		return errors.New("unsupported digest method")
+	}
+	digestValue := reference.child(nsXMLDSig, "DigestValue")
+	if digestValue == nil {
+		return errors.New("signature has no digest")
+	}
+	wantDigest, err := decodeBase64(digestValue.textContent())
+	if err != nil {
+		return errors.New("signature digest is not base64")
+	}
+	gotDigest := sha256.Sum256(canonicalize(el, signature, referenceInclusive))
+	if subtle.ConstantTimeCompare(gotDigest[:], wantDigest) != 1 {
+		return errors.New("signed element digest mismatch")
+	}
+
+	signatureValue := signature.child(nsXMLDSig, "SignatureValue")
+	if signatureValue == nil {
+		return errors.New("signature has no value")
+	}
+	rawSignature, err := decodeBase64(signatureValue.textContent())
+	if err != nil {
+		return errors.New("signature value is not base64")
+	}
+	publicKey, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return errors.New("certificate key is not RSA")
+	}
+	signedDigest := sha256.Sum256(canonicalize(signedInfo, nil, inclusivePrefixes(c14nMethod)))
+	if err := rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, signedDigest[:], rawSignature); err != nil {
+		return errors.New("signature does not verify")
+	}
+	return nil
+}
+
+// inclusivePrefixes reads the optional ec:InclusiveNamespaces PrefixList
+// of an exclusive c14n algorithm element; "#default" is the default
+// namespace.
+func inclusivePrefixes(method *xmlNode) []string {
+	var out []string
+	for _, child := range method.children {
+		if child.isText || child.local != "InclusiveNamespaces" || child.space != algExcC14N {
+			continue
+		}
+		for _, prefix := range strings.Fields(child.attrValue("PrefixList")) {
+			if prefix == "#default" {
+				prefix = ""
+			}
+			out = append(out, prefix)
+		}
+	}
+	return out
+}
+
+func decodeBase64(value string) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(strings.Join(strings.Fields(value), ""))
+}
@@ -0,0 +1,33 @@
+package application
+
+import (
+	"context"
+	"strings"
+
+	domainerrors "solomon/contexts/internal-ops/team-management-service/domain/errors"
+	domainservices "solomon/contexts/internal-ops/team-management-service/domain/services"
+	"solomon/contexts/internal-ops/team-management-service/ports"
+)
+
+// SyncDirectoryUser applies an M01 account change to the user directory
+// that team creation, invite acceptance and SSO provisioning read. It
+// returns the stored row, which is newer than user when the sync was stale.
+func (s Service) SyncDirectoryUser(ctx context.Context, user ports.DirectoryUser) (ports.DirectoryUser, error) {
+	if s.Directory == nil {
+		return ports.DirectoryUser{}, domainerrors.ErrDependencyUnavailable
+	}
+	user.UserID = strings.TrimSpace(user.UserID)
+	if user.UserID == "" {
+		return ports.DirectoryUser{}, domainerrors.ErrInvalidRequest
+	}
+	email, err := domainservices.NormalizeEmail(user.Email)
+	if err != nil {
+		return ports.DirectoryUser{}, err
+	}
+	user.Email = email
+	if user.UpdatedAt.IsZero() {
+		user.UpdatedAt = s.now()
+	}
+	user.UpdatedAt = user.UpdatedAt.UTC()
+	return s.Directory.UpsertDirectoryUser(ctx, user)
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	domainerrors "solomon/contexts/internal-ops/team-management-service/domain/errors"
+	domainservices "solomon/contexts/internal-ops/team-management-service/domain/services"
 	"solomon/contexts/internal-ops/team-management-service/ports"
 )
 
@@ -17,8 +18,21 @@ type Service struct {
 	Repo           ports.Repository
 	Idempotency    ports.IdempotencyStore
 	Clock          ports.Clock
+	Mailer         ports.InviteMailer
 	Logger         *slog.Logger
 	IdempotencyTTL time.Duration
+	InviteTTL      time.Duration
+
+	// Directory receives M01 account syncs; Repo and SSO read the rows.
+	Directory ports.UserDirectory
+
+	// SSO stores IdP connections and identities; OIDC and SAML verify
+	// logins. SSOBaseURL is the public origin IdPs redirect back to.
+	SSO         ports.SSORepository
+	OIDC        ports.SSOProtocol
+	SAML        ports.SAMLProtocol
+	SSOBaseURL  string
+	SSOLoginTTL time.Duration
 }
 
 type CreateInviteInput struct {
@@ -26,6 +40,20 @@ type CreateInviteInput struct {
 	Role  string
 }
 
+const (
+	InviteEmailSent     = "sent"
+	InviteEmailFailed   = "failed"
+	InviteEmailDisabled = "disabled"
+)
+
+// InviteResult is an issued invite and the outcome of emailing its token.
+// A failed email leaves the invite pending; resending it issues a new
+// token.
+type InviteResult struct {
+	Invite      ports.TeamInvite
+	EmailStatus string
+}
+
 func (s Service) CreateTeam(
 	ctx context.Context,
 	idempotencyKey string,
@@ -65,8 +93,8 @@ func (s Service) CreateInvite(
 	actorUserID string,
 	teamID string,
 	input CreateInviteInput,
-) (ports.TeamInvite, error) {
-	var out ports.TeamInvite
+) (InviteResult, error) {
+	var out InviteResult
 	if strings.TrimSpace(actorUserID) == "" ||
 		strings.TrimSpace(teamID) == "" ||
 		strings.TrimSpace(input.Email) == "" ||
@@ -84,11 +112,25 @@ func (s Service) CreateInvite(
 		requestHash,
 		func(raw []byte) error { return json.Unmarshal(raw, &out) },
 		func() ([]byte, error) {
-			item, err := s.Repo.CreateInvite(ctx, actorUserID, teamID, input.Email, input.Role, s.now())
+			token, err := domainservices.NewInviteToken()
 			if err != nil {
 				return nil, err
 			}
-			return json.Marshal(item)
+			now := s.now()
+			item, err := s.Repo.CreateInvite(
+				ctx,
+				actorUserID,
+				teamID,
+				input.Email,
+				input.Role,
+				domainservices.HashInviteToken(token),
+				now.Add(s.inviteTTL()),
+				now,
+			)
+			if err != nil {
+				return nil, err
+			}
+			return json.Marshal(InviteResult{Invite: item, EmailStatus: s.sendInvite(ctx, item, token, false)})
 		},
 	)
 	return out, err
@@ -114,7 +156,7 @@ func (s Service) AcceptInvite(
 		requestHash,
 		func(raw []byte) error { return json.Unmarshal(raw, &out) },
 		func() ([]byte, error) {
-			item, err := s.Repo.AcceptInvite(ctx, actorUserID, token, s.now())
+			item, err := s.Repo.AcceptInvite(ctx, actorUserID, domainservices.HashInviteToken(token), s.now())
 			if err != nil {
 				return nil, err
 			}
@@ -124,6 +166,96 @@ func (s Service) AcceptInvite(
 	return out, err
 }
 
+// ResendInvite issues a fresh token for a pending or expired invite, resets
+// its expiry and emails it again. Earlier tokens stop working.
+func (s Service) ResendInvite(
+	ctx context.Context,
+	idempotencyKey string,
+	actorUserID string,
+	teamID string,
+	inviteID string,
+) (InviteResult, error) {
+	var out InviteResult
+	if strings.TrimSpace(actorUserID) == "" ||
+		strings.TrimSpace(teamID) == "" ||
+		strings.TrimSpace(inviteID) == "" {
+		return out, domainerrors.ErrInvalidRequest
+	}
+	if err := s.requireIdempotency(idempotencyKey); err != nil {
+		return out, err
+	}
+	requestHash := hashStrings("m87_resend_invite", actorUserID, teamID, inviteID)
+	err := s.runIdempotent(
+		ctx,
+		strings.TrimSpace(idempotencyKey),
+		requestHash,
+		func(raw []byte) error { return json.Unmarshal(raw, &out) },
+		func() ([]byte, error) {
+			token, err := domainservices.NewInviteToken()
+			if err != nil {
+				return nil, err
+			}
+			now := s.now()
+			item, err := s.Repo.ResendInvite(
+				ctx,
+				actorUserID,
+				teamID,
+				inviteID,
+				domainservices.HashInviteToken(token),
+				now.Add(s.inviteTTL()),
+				now,
+			)
+			if err != nil {
+				return nil, err
+			}
+			return json.Marshal(InviteResult{Invite: item, EmailStatus: s.sendInvite(ctx, item, token, true)})
+		},
+	)
+	return out, err
+}
+
+func (s Service) RevokeInvite(
+	ctx context.Context,
+	idempotencyKey string,
+	actorUserID string,
+	teamID string,
+	inviteID string,
+) (ports.TeamInvite, error) {
+	var out ports.TeamInvite
+	if strings.TrimSpace(actorUserID) == "" ||
+		strings.TrimSpace(teamID) == "" ||
+		strings.TrimSpace(inviteID) == "" {
+		return out, domainerrors.ErrInvalidRequest
+	}
+	if err := s.requireIdempotency(idempotencyKey); err != nil {
+		return out, err
+	}
+	requestHash := hashStrings("m87_revoke_invite", actorUserID, teamID, inviteID)
+	err := s.runIdempotent(
+		ctx,
+		strings.TrimSpace(idempotencyKey),
+		requestHash,
+		func(raw []byte) error { return json.Unmarshal(raw, &out) },
+		func() ([]byte, error) {
+			item, err := s.Repo.RevokeInvite(ctx, actorUserID, teamID, inviteID, s.now())
+			if err != nil {
+				return nil, err
+			}
+			return json.Marshal(item)
+		},
+	)
+	return out, err
+}
+
+// ExpireStaleInvites marks up to limit overdue pending invites as expired.
+// The repository audits each one.
+func (s Service) ExpireStaleInvites(ctx context.Context, limit int) ([]ports.TeamInvite, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	return s.Repo.ExpireInvites(ctx, s.now(), limit)
+}
+
 func (s Service) UpdateMemberRole(
 	ctx context.Context,
 	idempotencyKey string,
@@ -215,11 +347,24 @@ func (s Service) ListAuditLogs(ctx context.Context, actorUserID string, teamID s
 	return s.Repo.ListAuditLogs(ctx, actorUserID, teamID, limit)
 }
 
-func (s Service) CreateMembersExport(ctx context.Context, actorUserID string, teamID string) (ports.MemberExportJob, error) {
+// AuthorizeMembersExport checks that the actor may export the member list
+// and records the request in the team audit log. The export itself runs
+// later through StreamMembersExport.
+func (s Service) AuthorizeMembersExport(ctx context.Context, actorUserID string, teamID string) error {
 	if strings.TrimSpace(actorUserID) == "" || strings.TrimSpace(teamID) == "" {
-		return ports.MemberExportJob{}, domainerrors.ErrInvalidRequest
+		return domainerrors.ErrInvalidRequest
+	}
+	return s.Repo.AuthorizeMembersExport(ctx, actorUserID, strings.TrimSpace(teamID), s.now())
+}
+
+// StreamMembersExport re-checks the actor's role, since it may have changed
+// since the export was queued, then calls fn for up to limit active members
+// in join order.
+func (s Service) StreamMembersExport(ctx context.Context, actorUserID string, teamID string, limit int, fn func(ports.MemberExportRow) error) error {
+	if strings.TrimSpace(actorUserID) == "" || strings.TrimSpace(teamID) == "" || limit <= 0 {
+		return domainerrors.ErrInvalidRequest
 	}
-	return s.Repo.CreateMembersExport(ctx, actorUserID, teamID, s.now())
+	return s.Repo.StreamMembers(ctx, actorUserID, strings.TrimSpace(teamID), limit, fn)
 }
 
 func (s Service) now() time.Time {
@@ -229,6 +374,50 @@ func (s Service) now() time.Time {
 	return s.Clock.Now().UTC()
 }
 
+func (s Service) inviteTTL() time.Duration {
+	if s.InviteTTL <= 0 {
+		return 7 * 24 * time.Hour
+	}
+	return s.InviteTTL
+}
+
+// sendInvite emails the plaintext token. Delivery problems are logged and
+// reported in the result rather than failing the request, because the
+// invite has already been stored.
+func (s Service) sendInvite(ctx context.Context, invite ports.TeamInvite, token string, resend bool) string {
+	logger := resolveLogger(s.Logger)
+	if s.Mailer == nil {
+		logger.Warn("team invite email skipped: no mailer configured",
+			"event", "team_mgmt_invite_email_disabled",
+			"module", "internal-ops/team-management-service",
+			"layer", "application",
+			"invite_id", invite.InviteID,
+		)
+		return InviteEmailDisabled
+	}
+	err := s.Mailer.SendInvite(ctx, ports.InviteEmail{
+		InviteID:  invite.InviteID,
+		TeamID:    invite.TeamID,
+		TeamName:  invite.TeamName,
+		Email:     invite.Email,
+		Role:      invite.Role,
+		Token:     token,
+		ExpiresAt: invite.ExpiresAt,
+		Resend:    resend,
+	})
+	if err != nil {
+		logger.Error("team invite email failed",
+			"event", "team_mgmt_invite_email_failed",
+			"module", "internal-ops/team-management-service",
+			"layer", "application",
+			"invite_id", invite.InviteID,
+			"error", err.Error(),
+		)
+		return InviteEmailFailed
+	}
+	return InviteEmailSent
+}
+
 func (s Service) idempotencyTTL() time.Duration {
 	if s.IdempotencyTTL <= 0 {
 		return 7 * 24 * time.Hour
@@ -2,11 +2,14 @@ package application
 
 import (
 	"context"
+	"regexp"
 	"testing"
 	"time"
 
+	"solomon/contexts/internal-ops/team-management-service/adapters/mailer"
 	"solomon/contexts/internal-ops/team-management-service/adapters/memory"
 	domainerrors "solomon/contexts/internal-ops/team-management-service/domain/errors"
+	domainservices "solomon/contexts/internal-ops/team-management-service/domain/services"
 	"solomon/contexts/internal-ops/team-management-service/ports"
 )
 
@@ -64,3 +67,187 @@ func TestCreateTeamIdempotencyConflict(t *testing.T) {
 		t.Fatalf("expected idempotency conflict, got %v", err)
 	}
 }
+
+func TestInviteTokensAreRandomHashedAndMailed(t *testing.T) {
+	store := memory.NewStore()
+	sink := mailer.FileSink{Dir: t.TempDir(), From: "teams@example.com"}
+	clock := &fixedClock{now: time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)}
+	service := Service{
+		Repo:        store,
+		Idempotency: store,
+		Mailer:      sink,
+		Clock:       clock,
+		InviteTTL:   48 * time.Hour,
+	}
+	ctx := context.Background()
+
+	result, err := service.CreateInvite(ctx, "idem-invite-1", "user_owner_1", "team_seed_1", CreateInviteInput{
+		Email: "editor@example.com",
+		Role:  ports.RoleEditor,
+	})
+	if err != nil {
+		t.Fatalf("create invite failed: %v", err)
+	}
+	if result.EmailStatus != InviteEmailSent {
+		t.Fatalf("expected invite email sent, got %q", result.EmailStatus)
+	}
+	if want := clock.now.Add(48 * time.Hour); !result.Invite.ExpiresAt.Equal(want) {
+		t.Fatalf("expected expiry %s, got %s", want, result.Invite.ExpiresAt)
+	}
+	token := lastInviteToken(t, sink)
+	if len(token) < 43 || result.Invite.TokenHash == token || result.Invite.TokenHash != domainservices.HashInviteToken(token) {
+		t.Fatalf("expected a random token stored only as its hash, token=%q hash=%q", token, result.Invite.TokenHash)
+	}
+
+	membership, err := service.AcceptInvite(ctx, "idem-accept-1", "user_editor_1", token)
+	if err != nil {
+		t.Fatalf("accept invite failed: %v", err)
+	}
+	if membership.Role != ports.RoleEditor {
+		t.Fatalf("expected editor membership, got %+v", membership)
+	}
+}
+
+func TestResendRotatesTokenAndRevokeClosesInvite(t *testing.T) {
+	store := memory.NewStore()
+	sink := mailer.FileSink{Dir: t.TempDir(), From: "teams@example.com"}
+	clock := &fixedClock{now: time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)}
+	service := Service{Repo: store, Idempotency: store, Mailer: sink, Clock: clock}
+	ctx := context.Background()
+
+	created, err := service.CreateInvite(ctx, "idem-invite-2", "user_owner_1", "team_seed_1", CreateInviteInput{
+		Email: "editor@example.com",
+		Role:  ports.RoleEditor,
+	})
+	if err != nil {
+		t.Fatalf("create invite failed: %v", err)
+	}
+	firstToken := lastInviteToken(t, sink)
+
+	clock.now = clock.now.Add(24 * time.Hour)
+	resent, err := service.ResendInvite(ctx, "idem-resend-2", "user_manager_1", "team_seed_1", created.Invite.InviteID)
+	if err != nil {
+		t.Fatalf("resend invite failed: %v", err)
+	}
+	secondToken := lastInviteToken(t, sink)
+	if secondToken == firstToken || resent.Invite.SentCount != 2 {
+		t.Fatalf("expected rotated token and second send, got sent_count=%d", resent.Invite.SentCount)
+	}
+	if want := clock.now.Add(7 * 24 * time.Hour); !resent.Invite.ExpiresAt.Equal(want) {
+		t.Fatalf("expected resend to extend expiry to %s, got %s", want, resent.Invite.ExpiresAt)
+	}
+	if _, err := service.AcceptInvite(ctx, "idem-accept-old", "user_editor_1", firstToken); err != domainerrors.ErrInviteNotFound {
+		t.Fatalf("expected superseded token rejected, got %v", err)
+	}
+
+	revoked, err := service.RevokeInvite(ctx, "idem-revoke-2", "user_owner_1", "team_seed_1", created.Invite.InviteID)
+	if err != nil {
+		t.Fatalf("revoke invite failed: %v", err)
+	}
+	if revoked.Status != ports.InviteStatusRevoked || revoked.RevokedBy != "user_owner_1" {
+		t.Fatalf("unexpected revoked invite %+v", revoked)
+	}
+	if _, err := service.AcceptInvite(ctx, "idem-accept-new", "user_editor_1", secondToken); err != domainerrors.ErrInviteRevoked {
+		t.Fatalf("expected revoked invite rejected, got %v", err)
+	}
+	if _, err := service.ResendInvite(ctx, "idem-resend-3", "user_owner_1", "team_seed_1", created.Invite.InviteID); err != domainerrors.ErrConflict {
+		t.Fatalf("expected revoked invite not resendable, got %v", err)
+	}
+}
+
+func TestExpireStaleInvitesAllowsResendOfExpiredInvite(t *testing.T) {
+	store := memory.NewStore()
+	clock := &fixedClock{now: time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)}
+	service := Service{Repo: store, Idempotency: store, Clock: clock, InviteTTL: time.Hour}
+	ctx := context.Background()
+
+	created, err := service.CreateInvite(ctx, "idem-invite-3", "user_owner_1", "team_seed_1", CreateInviteInput{
+		Email: "viewer@example.com",
+		Role:  ports.RoleViewer,
+	})
+	if err != nil {
+		t.Fatalf("create invite failed: %v", err)
+	}
+	if created.EmailStatus != InviteEmailDisabled {
+		t.Fatalf("expected email disabled without a mailer, got %q", created.EmailStatus)
+	}
+
+	clock.now = clock.now.Add(2 * time.Hour)
+	expired, err := service.ExpireStaleInvites(ctx, 10)
+	if err != nil || len(expired) != 1 {
+		t.Fatalf("expected one expired invite, got %d err=%v", len(expired), err)
+	}
+	dashboard, err := service.GetTeamDashboard(ctx, "user_owner_1", "team_seed_1")
+	if err != nil || len(dashboard.PendingInvites) != 0 {
+		t.Fatalf("expected no pending invites after sweep, got %+v err=%v", dashboard.PendingInvites, err)
+	}
+
+	resent, err := service.ResendInvite(ctx, "idem-resend-4", "user_owner_1", "team_seed_1", created.Invite.InviteID)
+	if err != nil {
+		t.Fatalf("resend expired invite failed: %v", err)
+	}
+	if resent.Invite.Status != ports.InviteStatusPending {
+		t.Fatalf("expected resent invite pending again, got %s", resent.Invite.Status)
+	}
+}
+
+type fixedClock struct {
+	now time.Time
+}
+
+func (c *fixedClock) Now() time.Time {
+	return c.now
+}
+
+var inviteCodePattern = regexp.MustCompile(`Invite code: (\S+)`)
+
+func lastInviteToken(t *testing.T, sink mailer.FileSink) string {
+	t.Helper()
+	messages, err := sink.Messages()
+	if err != nil || len(messages) == 0 {
+		t.Fatalf("expected an invite email, got %d err=%v", len(messages), err)
+	}
+	match := inviteCodePattern.FindStringSubmatch(messages[len(messages)-1])
+	if match == nil {
+		t.Fatalf("invite email has no code: %s", messages[len(messages)-1])
+	}
+	return match[1]
+}
+
+func TestSyncDirectoryUserLetsNewAccountsCreateTeams(t *testing.T) {
+	store := memory.NewStore()
+	service := Service{
+		Repo:           store,
+		Idempotency:    store,
+		Clock:          store,
+		Directory:      store,
+		IdempotencyTTL: 7 * 24 * time.Hour,
+	}
+	input := ports.CreateTeamInput{Name: "New Ops", OrgID: "org_1"}
+	if _, err := service.CreateTeam(context.Background(), "idem-dir-1", "user_new_1", input); err != domainerrors.ErrDependencyUnavailable {
+		t.Fatalf("expected unsynced user to be unavailable, got %v", err)
+	}
+
+	changedAt := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	synced, err := service.SyncDirectoryUser(context.Background(), ports.DirectoryUser{
+		UserID: "user_new_1", Email: "New@Example.com", UpdatedAt: changedAt,
+	})
+	if err != nil || synced.Email != "new@example.com" {
+		t.Fatalf("sync failed: %+v err=%v", synced, err)
+	}
+	if _, err := service.CreateTeam(context.Background(), "idem-dir-2", "user_new_1", input); err != nil {
+		t.Fatalf("expected synced user to create a team, got %v", err)
+	}
+
+	stale, err := service.SyncDirectoryUser(context.Background(), ports.DirectoryUser{
+		UserID: "user_new_1", Email: "old@example.com", UpdatedAt: changedAt.Add(-time.Hour),
+	})
+	if err != nil || stale.Email != "new@example.com" {
+		t.Fatalf("expected stale sync to be ignored, got %+v err=%v", stale, err)
+	}
+	if _, err := service.SyncDirectoryUser(context.Background(), ports.DirectoryUser{
+		UserID: "user_new_2", Email: "new@example.com", UpdatedAt: changedAt,
+	}); err != domainerrors.ErrConflict {
+		t.Fatalf("expected an email held by another account to conflict, got %v", err)
+	}
+}
@@ -0,0 +1,578 @@
+package application
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"strings"
+	"time"
+
+	domainerrors "solomon/contexts/internal-ops/team-management-service/domain/errors"
+	domainservices "solomon/contexts/internal-ops/team-management-service/domain/services"
+	"solomon/contexts/internal-ops/team-management-service/ports"
+)
+
+const (
+	defaultSSOLoginTTL = 10 * time.Minute
+	maxSCIMPageSize    = 100
+)
+
+// SSOConnectionInput configures a connection. On update an empty OIDC
+// client secret keeps the stored one, and the protocol cannot change.
+type SSOConnectionInput struct {
+	Protocol       string
+	Status         string
+	AllowedDomains []string
+	RoleMappings   []ports.SSORoleMapping
+	DefaultRole    string
+	OIDC           ports.OIDCSettings
+	SAML           ports.SAMLSettings
+}
+
+// SSOConnectionResult is a connection with its secrets removed, the
+// endpoints to register with the IdP and, right after it was issued, the
+// plaintext SCIM token. The token is not stored and cannot be shown again.
+type SSOConnectionResult struct {
+	Connection      ports.SSOConnection
+	ServiceProvider ports.SSOServiceProvider
+	SCIMToken       string
+}
+
+// SSOLoginStart sends the browser to the IdP. State must also be bound to
+// the browser, e.g. in a cookie, and checked when the IdP answers.
+type SSOLoginStart struct {
+	RedirectURL string
+	State       string
+	ExpiresAt   time.Time
+}
+
+func (s Service) CreateSSOConnection(
+	ctx context.Context,
+	actorUserID string,
+	teamID string,
+	input SSOConnectionInput,
+) (SSOConnectionResult, error) {
+	if strings.TrimSpace(actorUserID) == "" || strings.TrimSpace(teamID) == "" {
+		return SSOConnectionResult{}, domainerrors.ErrInvalidRequest
+	}
+	if s.SSO == nil {
+		return SSOConnectionResult{}, domainerrors.ErrDependencyUnavailable
+	}
+	conn := buildSSOConnection(input)
+	conn.TeamID = strings.TrimSpace(teamID)
+	if conn.Status == "" {
+		conn.Status = ports.SSOConnectionActive
+	}
+	if err := validateSSOConnection(conn); err != nil {
+		return SSOConnectionResult{}, err
+	}
+	token, err := domainservices.NewSSOSecret()
+	if err != nil {
+		return SSOConnectionResult{}, err
+	}
+	conn.SCIMTokenHash = domainservices.HashSSOSecret(token)
+	conn.CreatedBy = actorUserID
+	stored, err := s.SSO.CreateSSOConnection(ctx, actorUserID, conn, s.now())
+	if err != nil {
+		return SSOConnectionResult{}, err
+	}
+	return SSOConnectionResult{
+		Connection:      redactSSOConnection(stored),
+		ServiceProvider: s.SSOServiceProvider(stored.ConnectionID),
+		SCIMToken:       token,
+	}, nil
+}
+
+func (s Service) UpdateSSOConnection(
+	ctx context.Context,
+	actorUserID string,
+	teamID string,
+	connectionID string,
+	input SSOConnectionInput,
+) (SSOConnectionResult, error) {
+	existing, err := s.ownedSSOConnection(ctx, actorUserID, teamID, connectionID)
+	if err != nil {
+		return SSOConnectionResult{}, err
+	}
+	if input.Protocol != "" && strings.ToLower(strings.TrimSpace(input.Protocol)) != existing.Protocol {
+		return SSOConnectionResult{}, domainerrors.ErrInvalidRequest
+	}
+	conn := buildSSOConnection(input)
+	conn.ConnectionID = existing.ConnectionID
+	conn.TeamID = existing.TeamID
+	conn.Protocol = existing.Protocol
+	conn.SCIMTokenHash = existing.SCIMTokenHash
+	conn.CreatedBy = existing.CreatedBy
+	conn.CreatedAt = existing.CreatedAt
+	if conn.Status == "" {
+		conn.Status = existing.Status
+	}
+	if conn.OIDC.ClientSecret == "" {
+		conn.OIDC.ClientSecret = existing.OIDC.ClientSecret
+	}
+	if err := validateSSOConnection(conn); err != nil {
+		return SSOConnectionResult{}, err
+	}
+	stored, err := s.SSO.UpdateSSOConnection(ctx, actorUserID, conn, s.now())
+	if err != nil {
+		return SSOConnectionResult{}, err
+	}
+	return SSOConnectionResult{
+		Connection:      redactSSOConnection(stored),
+		ServiceProvider: s.SSOServiceProvider(stored.ConnectionID),
+	}, nil
+}
+
+// RotateSCIMToken issues a new SCIM bearer token; the previous one stops
+// working immediately.
+func (s Service) RotateSCIMToken(ctx context.Context, actorUserID string, teamID string, connectionID string) (SSOConnectionResult, error) {
+	if strings.TrimSpace(actorUserID) == "" || strings.TrimSpace(teamID) == "" || strings.TrimSpace(connectionID) == "" {
+		return SSOConnectionResult{}, domainerrors.ErrInvalidRequest
+	}
+	if s.SSO == nil {
+		return SSOConnectionResult{}, domainerrors.ErrDependencyUnavailable
+	}
+	token, err := domainservices.NewSSOSecret()
+	if err != nil {
+		return SSOConnectionResult{}, err
+	}
+	stored, err := s.SSO.RotateSCIMToken(
+		ctx,
+		actorUserID,
+		strings.TrimSpace(teamID),
+		strings.TrimSpace(connectionID),
+		domainservices.HashSSOSecret(token),
+		s.now(),
+	)
+	if err != nil {
+		return SSOConnectionResult{}, err
+	}
+	return SSOConnectionResult{
+		Connection:      redactSSOConnection(stored),
+		ServiceProvider: s.SSOServiceProvider(stored.ConnectionID),
+		SCIMToken:       token,
+	}, nil
+}
+
+func (s Service) ListSSOConnections(ctx context.Context, actorUserID string, teamID string) ([]SSOConnectionResult, error) {
+	if strings.TrimSpace(actorUserID) == "" || strings.TrimSpace(teamID) == "" {
+		return nil, domainerrors.ErrInvalidRequest
+	}
+	if s.SSO == nil {
+		return nil, domainerrors.ErrDependencyUnavailable
+	}
+	items, err := s.SSO.ListSSOConnections(ctx, actorUserID, strings.TrimSpace(teamID))
+	if err != nil {
+		return nil, err
+	}
+	out := make([]SSOConnectionResult, 0, len(items))
+	for _, item := range items {
+		out = append(out, SSOConnectionResult{
+			Connection:      redactSSOConnection(item),
+			ServiceProvider: s.SSOServiceProvider(item.ConnectionID),
+		})
+	}
+	return out, nil
+}
+
+// SSOServiceProvider returns the endpoints an IdP admin registers for a
+// connection.
+func (s Service) SSOServiceProvider(connectionID string) ports.SSOServiceProvider {
+	base := strings.TrimRight(strings.TrimSpace(s.SSOBaseURL), "/")
+	prefix := base + "/teams/sso/" + connectionID
+	return ports.SSOServiceProvider{
+		LoginURL:    prefix + "/login",
+		EntityID:    prefix + "/saml/metadata",
+		ACSURL:      prefix + "/saml/acs",
+		RedirectURL: prefix + "/oidc/callback",
+		SCIMBaseURL: base + "/scim/v2/teams/sso/" + connectionID,
+	}
+}
+
+// SAMLMetadata is the SP metadata document for a SAML connection.
+func (s Service) SAMLMetadata(ctx context.Context, connectionID string) ([]byte, error) {
+	conn, err := s.activeSSOConnection(ctx, connectionID)
+	if err != nil {
+		return nil, err
+	}
+	if conn.Protocol != ports.SSOProtocolSAML {
+		return nil, domainerrors.ErrNotFound
+	}
+	if s.SAML == nil {
+		return nil, domainerrors.ErrDependencyUnavailable
+	}
+	return s.SAML.Metadata(s.SSOServiceProvider(conn.ConnectionID))
+}
+
+// BeginSSOLogin records a single-use login state and returns the IdP URL
+// to send the browser to.
+func (s Service) BeginSSOLogin(ctx context.Context, connectionID string) (SSOLoginStart, error) {
+	conn, err := s.activeSSOConnection(ctx, connectionID)
+	if err != nil {
+		return SSOLoginStart{}, err
+	}
+	protocol, err := s.ssoProtocol(conn.Protocol)
+	if err != nil {
+		return SSOLoginStart{}, err
+	}
+	secrets := make([]string, 4)
+	for i := range secrets {
+		if secrets[i], err = domainservices.NewSSOSecret(); err != nil {
+			return SSOLoginStart{}, err
+		}
+	}
+	now := s.now()
+	state := secrets[0]
+	login := ports.SSOLoginState{
+		StateHash:    domainservices.HashSSOSecret(state),
+		ConnectionID: conn.ConnectionID,
+		Nonce:        secrets[1],
+		CodeVerifier: secrets[2],
+		// SAML IDs must be XML names, which cannot start with a digit or
+		// hyphen.
+		RequestID: "_" + secrets[3],
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.ssoLoginTTL()),
+	}
+	redirect, err := protocol.AuthorizationURL(conn, s.SSOServiceProvider(conn.ConnectionID), login, state)
+	if err != nil {
+		return SSOLoginStart{}, err
+	}
+	if err := s.SSO.CreateSSOLoginState(ctx, login); err != nil {
+		return SSOLoginStart{}, err
+	}
+	return SSOLoginStart{RedirectURL: redirect, State: state, ExpiresAt: login.ExpiresAt}, nil
+}
+
+// CompleteSSOLogin verifies the IdP's answer to a login started by
+// BeginSSOLogin and provisions the user's membership just in time: a new
+// member is added with the role mapped from their IdP groups, an existing
+// one has that role applied, and a user the IdP no longer grants access
+// is removed from the team. Owners are never changed.
+func (s Service) CompleteSSOLogin(
+	ctx context.Context,
+	connectionID string,
+	state string,
+	callback ports.SSOCallback,
+) (ports.SSOProvisionResult, error) {
+	if strings.TrimSpace(connectionID) == "" || strings.TrimSpace(state) == "" {
+		return ports.SSOProvisionResult{}, domainerrors.ErrInvalidRequest
+	}
+	if s.SSO == nil {
+		return ports.SSOProvisionResult{}, domainerrors.ErrDependencyUnavailable
+	}
+	now := s.now()
+	login, err := s.SSO.ConsumeSSOLoginState(ctx, domainservices.HashSSOSecret(state), now)
+	if err != nil {
+		return ports.SSOProvisionResult{}, err
+	}
+	if login.ConnectionID != strings.TrimSpace(connectionID) {
+		return ports.SSOProvisionResult{}, domainerrors.ErrForbidden
+	}
+	conn, err := s.activeSSOConnection(ctx, login.ConnectionID)
+	if err != nil {
+		return ports.SSOProvisionResult{}, err
+	}
+	protocol, err := s.ssoProtocol(conn.Protocol)
+	if err != nil {
+		return ports.SSOProvisionResult{}, err
+	}
+
+	logger := resolveLogger(s.Logger)
+	assertion, err := protocol.Verify(ctx, conn, s.SSOServiceProvider(conn.ConnectionID), login, callback, now)
+	if err != nil {
+		logger.Warn("team sso login rejected",
+			"event", "team_mgmt_sso_login_rejected",
+			"module", "internal-ops/team-management-service",
+			"layer", "application",
+			"connection_id", conn.ConnectionID,
+			"error", err.Error(),
+		)
+		return ports.SSOProvisionResult{}, err
+	}
+	email, err := domainservices.NormalizeEmail(assertion.Email)
+	if err != nil || strings.TrimSpace(assertion.Subject) == "" {
+		return ports.SSOProvisionResult{}, domainerrors.ErrSSOAssertionInvalid
+	}
+	assertion.Email = email
+	assertion.Subject = strings.TrimSpace(assertion.Subject)
+	if !domainservices.EmailDomainAllowed(email, conn.AllowedDomains) {
+		logger.Warn("team sso login from a domain outside the connection",
+			"event", "team_mgmt_sso_domain_rejected",
+			"module", "internal-ops/team-management-service",
+			"layer", "application",
+			"connection_id", conn.ConnectionID,
+		)
+		return ports.SSOProvisionResult{}, domainerrors.ErrForbidden
+	}
+
+	role := resolveSSORole(conn.RoleMappings, conn.DefaultRole, assertion.Groups)
+	result, err := s.SSO.ProvisionSSOMember(ctx, conn, assertion, role, now)
+	if err != nil {
+		return ports.SSOProvisionResult{}, err
+	}
+	logger.Info("team sso login",
+		"event", "team_mgmt_sso_login",
+		"module", "internal-ops/team-management-service",
+		"layer", "application",
+		"connection_id", conn.ConnectionID,
+		"team_id", conn.TeamID,
+		"user_id", result.Identity.UserID,
+		"action", result.Action,
+	)
+	if result.Action == ports.SSOProvisionDenied || result.Action == ports.SSOProvisionDeprovisioned {
+		return ports.SSOProvisionResult{}, domainerrors.ErrForbidden
+	}
+	return result, nil
+}
+
+// AuthenticateSCIM resolves the connection a SCIM client speaks for. Any
+// failure reads as forbidden so connection IDs cannot be probed.
+func (s Service) AuthenticateSCIM(ctx context.Context, connectionID string, token string) (ports.SSOConnection, error) {
+	if s.SSO == nil {
+		return ports.SSOConnection{}, domainerrors.ErrDependencyUnavailable
+	}
+	if strings.TrimSpace(connectionID) == "" || strings.TrimSpace(token) == "" {
+		return ports.SSOConnection{}, domainerrors.ErrForbidden
+	}
+	conn, err := s.SSO.GetSSOConnection(ctx, strings.TrimSpace(connectionID))
+	if errors.Is(err, domainerrors.ErrNotFound) {
+		return ports.SSOConnection{}, domainerrors.ErrForbidden
+	}
+	if err != nil {
+		return ports.SSOConnection{}, err
+	}
+	presented := domainservices.HashSSOSecret(token)
+	if conn.SCIMTokenHash == "" ||
+		subtle.ConstantTimeCompare([]byte(presented), []byte(conn.SCIMTokenHash)) != 1 ||
+		conn.Status != ports.SSOConnectionActive {
+		return ports.SSOConnection{}, domainerrors.ErrForbidden
+	}
+	return conn, nil
+}
+
+func (s Service) ListSCIMUsers(ctx context.Context, conn ports.SSOConnection, query ports.SSOIdentityQuery) ([]ports.SSOIdentity, int, error) {
+	if query.Offset < 0 {
+		query.Offset = 0
+	}
+	if query.Limit <= 0 || query.Limit > maxSCIMPageSize {
+		query.Limit = maxSCIMPageSize
+	}
+	if query.Email != "" {
+		email, err := domainservices.NormalizeEmail(query.Email)
+		if err != nil {
+			return []ports.SSOIdentity{}, 0, nil
+		}
+		query.Email = email
+	}
+	return s.SSO.ListSSOIdentities(ctx, conn.ConnectionID, query)
+}
+
+func (s Service) GetSCIMUser(ctx context.Context, conn ports.SSOConnection, identityID string) (ports.SSOIdentity, error) {
+	return s.SSO.GetSSOIdentity(ctx, conn.ConnectionID, strings.TrimSpace(identityID))
+}
+
+// CreateSCIMUser links an M01 user to the connection ahead of their first
+// login. An active user joins the team with the connection's default
+// role, or Viewer, until a login applies their group mapping.
+func (s Service) CreateSCIMUser(ctx context.Context, conn ports.SSOConnection, email string, externalID string, active bool) (ports.SSOIdentity, error) {
+	normalized, err := domainservices.NormalizeEmail(email)
+	if err != nil {
+		return ports.SSOIdentity{}, err
+	}
+	if !domainservices.EmailDomainAllowed(normalized, conn.AllowedDomains) {
+		return ports.SSOIdentity{}, domainerrors.ErrForbidden
+	}
+	return s.SSO.CreateSSOIdentity(ctx, conn, ports.SSOIdentity{
+		Email:      normalized,
+		ExternalID: strings.TrimSpace(externalID),
+		Active:     active,
+	}, scimRole(conn), s.now())
+}
+
+// SetSCIMUserActive deprovisions (active false) or reinstates a user.
+// Deprovisioning removes the team membership; the owner must transfer
+// ownership first.
+func (s Service) SetSCIMUserActive(ctx context.Context, conn ports.SSOConnection, identityID string, active bool) (ports.SSOIdentity, error) {
+	return s.SSO.SetSSOIdentityActive(ctx, conn, strings.TrimSpace(identityID), active, scimRole(conn), s.now())
+}
+
+// DeleteSCIMUser deprovisions the user and forgets the identity link.
+func (s Service) DeleteSCIMUser(ctx context.Context, conn ports.SSOConnection, identityID string) error {
+	return s.SSO.DeleteSSOIdentity(ctx, conn, strings.TrimSpace(identityID), s.now())
+}
+
+func (s Service) ownedSSOConnection(ctx context.Context, actorUserID string, teamID string, connectionID string) (ports.SSOConnection, error) {
+	if strings.TrimSpace(actorUserID) == "" || strings.TrimSpace(teamID) == "" || strings.TrimSpace(connectionID) == "" {
+		return ports.SSOConnection{}, domainerrors.ErrInvalidRequest
+	}
+	if s.SSO == nil {
+		return ports.SSOConnection{}, domainerrors.ErrDependencyUnavailable
+	}
+	items, err := s.SSO.ListSSOConnections(ctx, actorUserID, strings.TrimSpace(teamID))
+	if err != nil {
+		return ports.SSOConnection{}, err
+	}
+	for _, item := range items {
+		if item.ConnectionID == strings.TrimSpace(connectionID) {
+			return item, nil
+		}
+	}
+	return ports.SSOConnection{}, domainerrors.ErrNotFound
+}
+
+func (s Service) activeSSOConnection(ctx context.Context, connectionID string) (ports.SSOConnection, error) {
+	if strings.TrimSpace(connectionID) == "" {
+		return ports.SSOConnection{}, domainerrors.ErrInvalidRequest
+	}
+	if s.SSO == nil {
+		return ports.SSOConnection{}, domainerrors.ErrDependencyUnavailable
+	}
+	conn, err := s.SSO.GetSSOConnection(ctx, strings.TrimSpace(connectionID))
+	if err != nil {
+		return ports.SSOConnection{}, err
+	}
+	if conn.Status != ports.SSOConnectionActive {
+		return ports.SSOConnection{}, domainerrors.ErrForbidden
+	}
+	return conn, nil
+}
+
+func (s Service) ssoProtocol(protocol string) (ports.SSOProtocol, error) {
+	switch {
+	case protocol == ports.SSOProtocolOIDC && s.OIDC != nil:
+		return s.OIDC, nil
+	case protocol == ports.SSOProtocolSAML && s.SAML != nil:
+		return s.SAML, nil
+	default:
+		return nil, domainerrors.ErrDependencyUnavailable
+	}
+}
+
+func (s Service) ssoLoginTTL() time.Duration {
+	if s.SSOLoginTTL <= 0 {
+		return defaultSSOLoginTTL
+	}
+	return s.SSOLoginTTL
+}
+
+func buildSSOConnection(input SSOConnectionInput) ports.SSOConnection {
+	mappings := make([]ports.SSORoleMapping, 0, len(input.RoleMappings))
+	for _, mapping := range input.RoleMappings {
+		mappings = append(mappings, ports.SSORoleMapping{
+			Group: strings.TrimSpace(mapping.Group),
+			Role:  strings.TrimSpace(mapping.Role),
+		})
+	}
+	scopes := make([]string, 0, len(input.OIDC.Scopes))
+	for _, scope := range input.OIDC.Scopes {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return ports.SSOConnection{
+		Protocol:       strings.ToLower(strings.TrimSpace(input.Protocol)),
+		Status:         strings.ToLower(strings.TrimSpace(input.Status)),
+		AllowedDomains: domainservices.NormalizeDomains(input.AllowedDomains),
+		RoleMappings:   mappings,
+		DefaultRole:    strings.TrimSpace(input.DefaultRole),
+		OIDC: ports.OIDCSettings{
+			Issuer:           strings.TrimSpace(input.OIDC.Issuer),
+			ClientID:         strings.TrimSpace(input.OIDC.ClientID),
+			ClientSecret:     strings.TrimSpace(input.OIDC.ClientSecret),
+			AuthorizationURL: strings.TrimSpace(input.OIDC.AuthorizationURL),
+			TokenURL:         strings.TrimSpace(input.OIDC.TokenURL),
+			JWKSURL:          strings.TrimSpace(input.OIDC.JWKSURL),
+			Scopes:           scopes,
+			GroupsClaim:      strings.TrimSpace(input.OIDC.GroupsClaim),
+		},
+		SAML: ports.SAMLSettings{
+			IdPEntityID:     strings.TrimSpace(input.SAML.IdPEntityID),
+			SSOURL:          strings.TrimSpace(input.SAML.SSOURL),
+			Certificate:     strings.TrimSpace(input.SAML.Certificate),
+			EmailAttribute:  strings.TrimSpace(input.SAML.EmailAttribute),
+			GroupsAttribute: strings.TrimSpace(input.SAML.GroupsAttribute),
+		},
+	}
+}
+
+// validateSSOConnection checks a connection before it is stored. Logins
+// are matched to M01 accounts by email, so every connection must name the
+// email domains its IdP may vouch for; and no IdP group may grant Owner.
+func validateSSOConnection(conn ports.SSOConnection) error {
+	if conn.Status != ports.SSOConnectionActive && conn.Status != ports.SSOConnectionDisabled {
+		return domainerrors.ErrInvalidRequest
+	}
+	if len(conn.AllowedDomains) == 0 {
+		return domainerrors.ErrInvalidRequest
+	}
+	for _, domain := range conn.AllowedDomains {
+		if !domainservices.IsValidEmailDomain(domain) {
+			return domainerrors.ErrInvalidRequest
+		}
+	}
+	for _, mapping := range conn.RoleMappings {
+		if mapping.Group == "" || !isSSOGrantableRole(mapping.Role) {
+			return domainerrors.ErrInvalidRequest
+		}
+	}
+	if conn.DefaultRole != "" && !isSSOGrantableRole(conn.DefaultRole) {
+		return domainerrors.ErrInvalidRequest
+	}
+
+	switch conn.Protocol {
+	case ports.SSOProtocolOIDC:
+		settings := conn.OIDC
+		if settings.ClientID == "" || settings.ClientSecret == "" {
+			return domainerrors.ErrInvalidRequest
+		}
+		for _, raw := range []string{settings.Issuer, settings.AuthorizationURL, settings.TokenURL, settings.JWKSURL} {
+			if !domainservices.IsHTTPSURL(raw) {
+				return domainerrors.ErrInvalidRequest
+			}
+		}
+	case ports.SSOProtocolSAML:
+		if conn.SAML.IdPEntityID == "" || !domainservices.IsHTTPSURL(conn.SAML.SSOURL) {
+			return domainerrors.ErrInvalidRequest
+		}
+		if _, err := domainservices.ParseIdPCertificate(conn.SAML.Certificate); err != nil {
+			return err
+		}
+	default:
+		return domainerrors.ErrInvalidRequest
+	}
+	return nil
+}
+
+// resolveSSORole maps IdP groups to a team role: the first mapping whose
+// group the user is in, else defaultRole. An empty result means the IdP
+// grants no access to the team.
+func resolveSSORole(mappings []ports.SSORoleMapping, defaultRole string, groups []string) string {
+	member := make(map[string]bool, len(groups))
+	for _, group := range groups {
+		member[strings.TrimSpace(group)] = true
+	}
+	for _, mapping := range mappings {
+		if member[mapping.Group] {
+			return mapping.Role
+		}
+	}
+	return defaultRole
+}
+
+func scimRole(conn ports.SSOConnection) string {
+	if conn.DefaultRole != "" {
+		return conn.DefaultRole
+	}
+	return ports.RoleViewer
+}
+
+func isSSOGrantableRole(role string) bool {
+	return ports.IsValidRole(role) && role != ports.RoleOwner
+}
+
+func redactSSOConnection(conn ports.SSOConnection) ports.SSOConnection {
+	conn.OIDC.ClientSecret = ""
+	conn.SCIMTokenHash = ""
+	return conn
+}
@@ -0,0 +1,179 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"solomon/contexts/internal-ops/team-management-service/adapters/memory"
+	domainerrors "solomon/contexts/internal-ops/team-management-service/domain/errors"
+	"solomon/contexts/internal-ops/team-management-service/ports"
+)
+
+func TestSSOLoginProvisionsMappedRoleAndRemovesUnmappedUser(t *testing.T) {
+	ctx := context.Background()
+	service, idp := newSSOTestService()
+	conn := createTestSSOConnection(t, service)
+
+	idp.assertion = ports.SSOAssertion{Subject: "idp-editor", Email: "Editor@Example.com", Groups: []string{"creative"}}
+	result, err := service.CompleteSSOLogin(ctx, conn.Connection.ConnectionID, beginTestSSOLogin(t, service, conn.Connection.ConnectionID), ports.SSOCallback{Code: "code"})
+	if err != nil {
+		t.Fatalf("first login: %v", err)
+	}
+	if result.Action != ports.SSOProvisionCreated || result.Member.Role != ports.RoleEditor || result.Member.UserID != "user_editor_1" {
+		t.Fatalf("expected editor to be provisioned, got %+v", result)
+	}
+
+	state := beginTestSSOLogin(t, service, conn.Connection.ConnectionID)
+	if _, err := service.CompleteSSOLogin(ctx, conn.Connection.ConnectionID, state, ports.SSOCallback{Code: "code"}); err != nil {
+		t.Fatalf("second login: %v", err)
+	}
+	if _, err := service.CompleteSSOLogin(ctx, conn.Connection.ConnectionID, state, ports.SSOCallback{Code: "code"}); !errors.Is(err, domainerrors.ErrSSOLoginExpired) {
+		t.Fatalf("expected a replayed state to be rejected, got %v", err)
+	}
+
+	idp.assertion.Groups = nil
+	_, err = service.CompleteSSOLogin(ctx, conn.Connection.ConnectionID, beginTestSSOLogin(t, service, conn.Connection.ConnectionID), ports.SSOCallback{Code: "code"})
+	if !errors.Is(err, domainerrors.ErrForbidden) {
+		t.Fatalf("expected login without a mapped group to be refused, got %v", err)
+	}
+	if _, err := service.CheckMembership(ctx, "team_seed_1", "user_editor_1"); err == nil {
+		t.Fatalf("expected membership to be removed once the IdP stopped granting a role")
+	}
+
+	idp.assertion = ports.SSOAssertion{Subject: "idp-outsider", Email: "viewer@other.example", Groups: []string{"creative"}}
+	_, err = service.CompleteSSOLogin(ctx, conn.Connection.ConnectionID, beginTestSSOLogin(t, service, conn.Connection.ConnectionID), ports.SSOCallback{Code: "code"})
+	if !errors.Is(err, domainerrors.ErrForbidden) {
+		t.Fatalf("expected a login outside the allowed domains to be refused, got %v", err)
+	}
+}
+
+func TestSCIMDeprovisionRemovesMembershipAndProtectsOwner(t *testing.T) {
+	ctx := context.Background()
+	service, _ := newSSOTestService()
+	created := createTestSSOConnection(t, service)
+
+	conn, err := service.AuthenticateSCIM(ctx, created.Connection.ConnectionID, created.SCIMToken)
+	if err != nil {
+		t.Fatalf("authenticate scim: %v", err)
+	}
+	if _, err := service.AuthenticateSCIM(ctx, created.Connection.ConnectionID, "wrong-token"); !errors.Is(err, domainerrors.ErrForbidden) {
+		t.Fatalf("expected a wrong token to be refused, got %v", err)
+	}
+
+	identity, err := service.CreateSCIMUser(ctx, conn, "viewer@example.com", "ext-1", true)
+	if err != nil {
+		t.Fatalf("create scim user: %v", err)
+	}
+	membership, err := service.CheckMembership(ctx, "team_seed_1", "user_viewer_1")
+	if err != nil || membership.Role != ports.RoleViewer {
+		t.Fatalf("expected provisioned viewer membership, got %+v err=%v", membership, err)
+	}
+	items, total, err := service.ListSCIMUsers(ctx, conn, ports.SSOIdentityQuery{ExternalID: "ext-1"})
+	if err != nil || total != 1 || items[0].IdentityID != identity.IdentityID {
+		t.Fatalf("expected to find the user by externalId, got %d items err=%v", total, err)
+	}
+
+	if _, err := service.SetSCIMUserActive(ctx, conn, identity.IdentityID, false); err != nil {
+		t.Fatalf("deactivate scim user: %v", err)
+	}
+	if _, err := service.CheckMembership(ctx, "team_seed_1", "user_viewer_1"); err == nil {
+		t.Fatalf("expected membership to be removed on deprovisioning")
+	}
+
+	owner, err := service.CreateSCIMUser(ctx, conn, "owner@example.com", "ext-owner", true)
+	if err != nil {
+		t.Fatalf("create owner identity: %v", err)
+	}
+	if err := service.DeleteSCIMUser(ctx, conn, owner.IdentityID); !errors.Is(err, domainerrors.ErrOwnerTransferRequired) {
+		t.Fatalf("expected owner deprovisioning to require a transfer, got %v", err)
+	}
+}
+
+func TestSSOConnectionsAreOwnerOnlyAndHideSecrets(t *testing.T) {
+	service, _ := newSSOTestService()
+	created := createTestSSOConnection(t, service)
+	if created.Connection.OIDC.ClientSecret != "" || created.Connection.SCIMTokenHash != "" {
+		t.Fatalf("expected secrets to be redacted, got %+v", created.Connection)
+	}
+
+	if _, err := service.ListSSOConnections(context.Background(), "user_manager_1", "team_seed_1"); !errors.Is(err, domainerrors.ErrForbidden) {
+		t.Fatalf("expected managers to be refused, got %v", err)
+	}
+	_, err := service.CreateSSOConnection(context.Background(), "user_owner_1", "team_seed_1", SSOConnectionInput{
+		Protocol:       ports.SSOProtocolOIDC,
+		AllowedDomains: []string{"example.com"},
+		RoleMappings:   []ports.SSORoleMapping{{Group: "admins", Role: ports.RoleOwner}},
+		OIDC:           testOIDCSettings(),
+	})
+	if !errors.Is(err, domainerrors.ErrInvalidRequest) {
+		t.Fatalf("expected Owner mappings to be rejected, got %v", err)
+	}
+}
+
+// stubSSOProtocol vouches for whatever assertion the test sets; the real
+// protocol checks are covered in adapters/sso.
+type stubSSOProtocol struct {
+	assertion ports.SSOAssertion
+}
+
+func (p *stubSSOProtocol) AuthorizationURL(_ ports.SSOConnection, _ ports.SSOServiceProvider, _ ports.SSOLoginState, state string) (string, error) {
+	return "https://idp.example.com/authorize?state=" + url.QueryEscape(state), nil
+}
+
+func (p *stubSSOProtocol) Verify(context.Context, ports.SSOConnection, ports.SSOServiceProvider, ports.SSOLoginState, ports.SSOCallback, time.Time) (ports.SSOAssertion, error) {
+	return p.assertion, nil
+}
+
+func newSSOTestService() (Service, *stubSSOProtocol) {
+	store := memory.NewStore()
+	idp := &stubSSOProtocol{}
+	return Service{
+		Repo:           store,
+		Idempotency:    store,
+		Clock:          store,
+		IdempotencyTTL: 7 * 24 * time.Hour,
+		SSO:            store,
+		OIDC:           idp,
+		SSOBaseURL:     "https://solomon.example.com",
+	}, idp
+}
+
+func createTestSSOConnection(t *testing.T, service Service) SSOConnectionResult {
+	t.Helper()
+	created, err := service.CreateSSOConnection(context.Background(), "user_owner_1", "team_seed_1", SSOConnectionInput{
+		Protocol:       ports.SSOProtocolOIDC,
+		AllowedDomains: []string{"Example.com"},
+		RoleMappings:   []ports.SSORoleMapping{{Group: "creative", Role: ports.RoleEditor}},
+		OIDC:           testOIDCSettings(),
+	})
+	if err != nil {
+		t.Fatalf("create sso connection: %v", err)
+	}
+	if created.SCIMToken == "" {
+		t.Fatalf("expected a scim token on create")
+	}
+	return created
+}
+
+func beginTestSSOLogin(t *testing.T, service Service, connectionID string) string {
+	t.Helper()
+	start, err := service.BeginSSOLogin(context.Background(), connectionID)
+	if err != nil {
+		t.Fatalf("begin sso login: %v", err)
+	}
+	return start.State
+}
+
+func testOIDCSettings() ports.OIDCSettings {
+	return ports.OIDCSettings{
+		Issuer:           "https://idp.example.com",
+		ClientID:         "solomon",
+		ClientSecret:     "secret",
+		AuthorizationURL: "https://idp.example.com/authorize",
+		TokenURL:         "https://idp.example.com/token",
+		JWKSURL:          "https://idp.example.com/jwks",
+	}
+}
@@ -0,0 +1,42 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+
+	"solomon/contexts/internal-ops/team-management-service/application"
+)
+
+// InviteExpirySweeper marks pending invites past their expiry as expired so
+// dashboards stop listing them and the team audit log records it.
+type InviteExpirySweeper struct {
+	Service   application.Service
+	BatchSize int
+	Logger    *slog.Logger
+}
+
+func (j InviteExpirySweeper) RunOnce(ctx context.Context) error {
+	logger := j.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	expired, err := j.Service.ExpireStaleInvites(ctx, j.BatchSize)
+	if err != nil {
+		logger.Error("team invite expiry sweep failed",
+			"event", "team_mgmt_invite_expiry_failed",
+			"module", "internal-ops/team-management-service",
+			"layer", "worker",
+			"error", err.Error(),
+		)
+		return err
+	}
+	if len(expired) > 0 {
+		logger.Info("team invites expired",
+			"event", "team_mgmt_invites_expired",
+			"module", "internal-ops/team-management-service",
+			"layer", "worker",
+			"count", len(expired),
+		)
+	}
+	return nil
+}
@@ -11,9 +11,12 @@ var (
 	ErrForbidden              = errors.New("forbidden")
 	ErrConflict               = errors.New("conflict")
 	ErrInviteExpired          = errors.New("invite expired")
+	ErrInviteRevoked          = errors.New("invite revoked")
 	ErrOwnerTransferRequired  = errors.New("owner transfer required")
 	ErrMFARequired            = errors.New("mfa required")
 	ErrIdempotencyKeyRequired = errors.New("idempotency key required")
 	ErrIdempotencyConflict    = errors.New("idempotency key conflict")
 	ErrDependencyUnavailable  = errors.New("dependency unavailable")
+	ErrSSOAssertionInvalid    = errors.New("sso assertion invalid")
+	ErrSSOLoginExpired        = errors.New("sso login expired")
 )
@@ -0,0 +1,29 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+// inviteTokenBytes is the entropy behind an invite token: 256 bits, so
+// tokens cannot be guessed or enumerated.
+const inviteTokenBytes = 32
+
+// NewInviteToken returns a random, URL-safe invite token. Only its hash is
+// stored; the token itself exists in the invite email.
+func NewInviteToken() (string, error) {
+	buf := make([]byte, inviteTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// HashInviteToken is the at-rest form of an invite token.
+func HashInviteToken(token string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(token)))
+	return hex.EncodeToString(sum[:])
+}
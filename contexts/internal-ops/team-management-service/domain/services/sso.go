@@ -0,0 +1,86 @@
+package services
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/url"
+	"strings"
+
+	domainerrors "solomon/contexts/internal-ops/team-management-service/domain/errors"
+)
+
+// NewSSOSecret returns a random value for SSO state, nonces, PKCE
+// verifiers and SCIM tokens. It is as strong as an invite token.
+func NewSSOSecret() (string, error) {
+	return NewInviteToken()
+}
+
+// HashSSOSecret is the at-rest form of SSO state values and SCIM tokens.
+func HashSSOSecret(secret string) string {
+	return HashInviteToken(secret)
+}
+
+// NormalizeDomains lower-cases and de-duplicates allowed email domains.
+func NormalizeDomains(domains []string) []string {
+	out := make([]string, 0, len(domains))
+	seen := make(map[string]bool, len(domains))
+	for _, domain := range domains {
+		domain = strings.ToLower(strings.TrimSpace(domain))
+		if domain == "" || seen[domain] {
+			continue
+		}
+		seen[domain] = true
+		out = append(out, domain)
+	}
+	return out
+}
+
+// IsValidEmailDomain accepts bare host names such as example.com.
+func IsValidEmailDomain(domain string) bool {
+	if domain == "" || len(domain) > 253 || strings.ContainsAny(domain, "@/: ") {
+		return false
+	}
+	return strings.Contains(domain, ".") && !strings.HasPrefix(domain, ".") && !strings.HasSuffix(domain, ".")
+}
+
+// EmailDomainAllowed reports whether email belongs to one of domains.
+// Subdomains are not included implicitly.
+func EmailDomainAllowed(email string, domains []string) bool {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domain := strings.ToLower(email[at+1:])
+	for _, allowed := range domains {
+		if domain == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// IsHTTPSURL reports whether raw is an absolute https URL. IdP endpoints
+// must be https so codes, tokens and keys cannot be read or swapped in
+// transit.
+func IsHTTPSURL(raw string) bool {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	return err == nil && parsed.Scheme == "https" && parsed.Host != ""
+}
+
+// ParseIdPCertificate reads the PEM certificate of a SAML IdP. Only RSA
+// keys are accepted because only RSA-SHA256 signatures are verified.
+func ParseIdPCertificate(raw string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(strings.TrimSpace(raw)))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, domainerrors.ErrInvalidRequest
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, domainerrors.ErrInvalidRequest
+	}
+	if _, ok := cert.PublicKey.(*rsa.PublicKey); !ok {
+		return nil, domainerrors.ErrInvalidRequest
+	}
+	return cert, nil
+}
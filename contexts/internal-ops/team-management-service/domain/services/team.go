@@ -0,0 +1,35 @@
+package services
+
+import (
+	"net/mail"
+	"regexp"
+	"strings"
+
+	domainerrors "solomon/contexts/internal-ops/team-management-service/domain/errors"
+)
+
+var teamNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9 -]{1,118}[A-Za-z0-9]$`)
+
+// IsValidTeamName accepts 3-120 letters, digits, spaces and hyphens that
+// start and end with a letter or digit.
+func IsValidTeamName(name string) bool {
+	trimmed := strings.TrimSpace(name)
+	if len(trimmed) < 3 || len(trimmed) > 120 {
+		return false
+	}
+	return teamNamePattern.MatchString(trimmed)
+}
+
+// NormalizeEmail returns the lower-cased address part of email, which is
+// how invites and the user directory are matched.
+func NormalizeEmail(email string) (string, error) {
+	addr, err := mail.ParseAddress(strings.TrimSpace(email))
+	if err != nil {
+		return "", domainerrors.ErrInvalidRequest
+	}
+	normalized := strings.ToLower(strings.TrimSpace(addr.Address))
+	if normalized == "" {
+		return "", domainerrors.ErrInvalidRequest
+	}
+	return normalized, nil
+}
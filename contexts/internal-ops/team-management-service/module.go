@@ -2,53 +2,101 @@ package teammanagementservice
 
 import (
 	"log/slog"
+	"net/http"
 	"time"
 
 	httpadapter "solomon/contexts/internal-ops/team-management-service/adapters/http"
 	"solomon/contexts/internal-ops/team-management-service/adapters/memory"
+	"solomon/contexts/internal-ops/team-management-service/adapters/sso"
 	"solomon/contexts/internal-ops/team-management-service/application"
+	"solomon/contexts/internal-ops/team-management-service/application/workers"
 	"solomon/contexts/internal-ops/team-management-service/ports"
 )
 
 type Module struct {
-	Handler httpadapter.Handler
-	Store   *memory.Store
+	Handler      httpadapter.Handler
+	InviteExpiry workers.InviteExpirySweeper
+	Store        *memory.Store
 }
 
 type Dependencies struct {
 	Repository     ports.Repository
 	Idempotency    ports.IdempotencyStore
+	Mailer         ports.InviteMailer
 	Clock          ports.Clock
 	IDGenerator    ports.IDGenerator
 	IdempotencyTTL time.Duration
+	InviteTTL      time.Duration
 	Logger         *slog.Logger
+
+	// Directory takes the M01 account syncs that keep the user directory
+	// current; without it the sync endpoint reports the dependency as
+	// unavailable.
+	Directory ports.UserDirectory
+
+	// SSO is optional; without it the SSO and SCIM endpoints report the
+	// dependency as unavailable. SSOBaseURL is the public origin IdPs call
+	// back to; a zero SSOLoginTTL means the 10-minute default.
+	SSO         ports.SSORepository
+	OIDC        ports.SSOProtocol
+	SAML        ports.SAMLProtocol
+	SSOBaseURL  string
+	SSOLoginTTL time.Duration
 }
 
 func NewModule(deps Dependencies) Module {
 	service := application.Service{
 		Repo:           deps.Repository,
 		Idempotency:    deps.Idempotency,
+		Mailer:         deps.Mailer,
 		Clock:          deps.Clock,
 		Logger:         deps.Logger,
 		IdempotencyTTL: deps.IdempotencyTTL,
+		InviteTTL:      deps.InviteTTL,
+		Directory:      deps.Directory,
+		SSO:            deps.SSO,
+		OIDC:           deps.OIDC,
+		SAML:           deps.SAML,
+		SSOBaseURL:     deps.SSOBaseURL,
+		SSOLoginTTL:    deps.SSOLoginTTL,
 	}
 	return Module{
 		Handler: httpadapter.Handler{
 			Service: service,
 			Logger:  deps.Logger,
 		},
+		InviteExpiry: workers.InviteExpirySweeper{
+			Service:   service,
+			BatchSize: 100,
+			Logger:    deps.Logger,
+		},
 	}
 }
 
+// NewInMemoryModule keeps team state in memory and sends no invite emails.
 func NewInMemoryModule(logger *slog.Logger) Module {
+	return NewInMemoryModuleWithMailer(logger, nil, 0)
+}
+
+// NewInMemoryModuleWithMailer keeps team state in memory but delivers
+// invites through mailer, such as an SMTPMailer or FileSink. A zero
+// inviteTTL means the 7-day default.
+func NewInMemoryModuleWithMailer(logger *slog.Logger, mailer ports.InviteMailer, inviteTTL time.Duration) Module {
 	store := memory.NewStore()
 	module := NewModule(Dependencies{
 		Repository:     store,
 		Idempotency:    store,
+		Mailer:         mailer,
 		Clock:          store,
 		IDGenerator:    store,
 		IdempotencyTTL: 7 * 24 * time.Hour,
+		InviteTTL:      inviteTTL,
 		Logger:         logger,
+		Directory:      store,
+		SSO:            store,
+		OIDC:           sso.NewOIDCProvider(&http.Client{Timeout: 10 * time.Second}),
+		SAML:           sso.SAMLProvider{},
+		SSOBaseURL:     "http://localhost:8080",
 	})
 	module.Store = store
 	return module
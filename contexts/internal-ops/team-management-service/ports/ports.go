@@ -5,6 +5,13 @@ import (
 	"time"
 )
 
+const (
+	InviteStatusPending  = "pending"
+	InviteStatusAccepted = "accepted"
+	InviteStatusExpired  = "expired"
+	InviteStatusRevoked  = "revoked"
+)
+
 const (
 	RoleOwner   = "Owner"
 	RoleManager = "Manager"
@@ -111,12 +118,15 @@ type TeamMember struct {
 	RemovedAt    *time.Time
 }
 
+// TeamInvite never carries the invite token itself, only its SHA-256 hash;
+// the token is handed to the InviteMailer and then forgotten.
 type TeamInvite struct {
 	InviteID   string
 	TeamID     string
+	TeamName   string
 	Email      string
 	Role       string
-	Token      string
+	TokenHash  string
 	Status     string
 	ExpiresAt  time.Time
 	CreatedBy  string
@@ -124,6 +134,26 @@ type TeamInvite struct {
 	UpdatedAt  time.Time
 	AcceptedBy string
 	AcceptedAt *time.Time
+	RevokedBy  string
+	RevokedAt  *time.Time
+	SentCount  int
+}
+
+// InviteEmail is one invite delivery. Resend is set when the token was
+// rotated for an existing invite.
+type InviteEmail struct {
+	InviteID  string
+	TeamID    string
+	TeamName  string
+	Email     string
+	Role      string
+	Token     string
+	ExpiresAt time.Time
+	Resend    bool
+}
+
+type InviteMailer interface {
+	SendInvite(ctx context.Context, email InviteEmail) error
 }
 
 type TeamAuditLog struct {
@@ -150,22 +180,45 @@ type TeamDashboard struct {
 	PendingInvites []TeamInvite
 }
 
-type MemberExportJob struct {
-	ExportJobID           string
-	TeamID                string
-	Status                string
-	CreatedAt             time.Time
-	EstimatedCompletionAt time.Time
+// MemberExportRow is one active member in a members export, joined with
+// the member's directory email.
+type MemberExportRow struct {
+	MemberID     string
+	UserID       string
+	Email        string
+	Role         string
+	JoinedAt     time.Time
+	LastActiveAt *time.Time
+}
+
+// DirectoryUser is this service's projection of one M01 account: the
+// email invites and SSO logins are matched on, and the MFA flag owner
+// actions check. UpdatedAt is M01's change time and orders the syncs.
+type DirectoryUser struct {
+	UserID     string
+	Email      string
+	MFAEnabled bool
+	UpdatedAt  time.Time
+}
+
+// UserDirectory keeps the M01 projection current. A sync older than the
+// stored row is ignored, so replays and reordering are harmless.
+type UserDirectory interface {
+	UpsertDirectoryUser(ctx context.Context, user DirectoryUser) (DirectoryUser, error)
 }
 
 type Repository interface {
 	CreateTeam(ctx context.Context, actorUserID string, input CreateTeamInput, now time.Time) (Team, error)
-	CreateInvite(ctx context.Context, actorUserID string, teamID string, email string, role string, now time.Time) (TeamInvite, error)
-	AcceptInvite(ctx context.Context, actorUserID string, token string, now time.Time) (Membership, error)
+	CreateInvite(ctx context.Context, actorUserID string, teamID string, email string, role string, tokenHash string, expiresAt time.Time, now time.Time) (TeamInvite, error)
+	AcceptInvite(ctx context.Context, actorUserID string, tokenHash string, now time.Time) (Membership, error)
+	ResendInvite(ctx context.Context, actorUserID string, teamID string, inviteID string, tokenHash string, expiresAt time.Time, now time.Time) (TeamInvite, error)
+	RevokeInvite(ctx context.Context, actorUserID string, teamID string, inviteID string, now time.Time) (TeamInvite, error)
+	ExpireInvites(ctx context.Context, now time.Time, limit int) ([]TeamInvite, error)
 	UpdateMemberRole(ctx context.Context, actorUserID string, teamID string, memberID string, newRole string, mfaCode string, now time.Time) (TeamMember, error)
 	RemoveMember(ctx context.Context, actorUserID string, teamID string, memberID string, mfaCode string, now time.Time) (TeamMember, error)
 	GetTeamDashboard(ctx context.Context, actorUserID string, teamID string) (TeamDashboard, error)
 	CheckMembership(ctx context.Context, teamID string, userID string) (Membership, error)
 	ListAuditLogs(ctx context.Context, actorUserID string, teamID string, limit int) ([]TeamAuditLog, error)
-	CreateMembersExport(ctx context.Context, actorUserID string, teamID string, now time.Time) (MemberExportJob, error)
+	AuthorizeMembersExport(ctx context.Context, actorUserID string, teamID string, now time.Time) error
+	StreamMembers(ctx context.Context, actorUserID string, teamID string, limit int, fn func(MemberExportRow) error) error
 }
@@ -0,0 +1,174 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	SSOProtocolOIDC = "oidc"
+	SSOProtocolSAML = "saml"
+)
+
+const (
+	SSOConnectionActive   = "active"
+	SSOConnectionDisabled = "disabled"
+)
+
+// Outcomes of provisioning a member from a verified SSO login.
+const (
+	SSOProvisionCreated       = "created"
+	SSOProvisionRoleUpdated   = "role_updated"
+	SSOProvisionUnchanged     = "unchanged"
+	SSOProvisionDeprovisioned = "deprovisioned"
+	SSOProvisionDenied        = "denied"
+)
+
+// SSORoleMapping grants Role to users whose IdP groups include Group.
+// Mappings are tried in order and the first match wins.
+type SSORoleMapping struct {
+	Group string
+	Role  string
+}
+
+type OIDCSettings struct {
+	Issuer           string
+	ClientID         string
+	ClientSecret     string
+	AuthorizationURL string
+	TokenURL         string
+	JWKSURL          string
+	Scopes           []string
+	GroupsClaim      string
+}
+
+// SAMLSettings describe the IdP side of a SAML 2.0 connection. Certificate
+// is the PEM X.509 certificate the IdP signs responses or assertions with.
+type SAMLSettings struct {
+	IdPEntityID     string
+	SSOURL          string
+	Certificate     string
+	EmailAttribute  string
+	GroupsAttribute string
+}
+
+// SSOConnection is one team's link to its corporate IdP. The OIDC client
+// secret and the SCIM token hash never leave the service.
+type SSOConnection struct {
+	ConnectionID   string
+	TeamID         string
+	Protocol       string
+	Status         string
+	AllowedDomains []string
+	RoleMappings   []SSORoleMapping
+	DefaultRole    string
+	OIDC           OIDCSettings
+	SAML           SAMLSettings
+	SCIMTokenHash  string
+	CreatedBy      string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// SSOServiceProvider holds this service's endpoints for one connection, as
+// registered with the IdP.
+type SSOServiceProvider struct {
+	LoginURL    string
+	EntityID    string
+	ACSURL      string
+	RedirectURL string
+	SCIMBaseURL string
+}
+
+// SSOLoginState is an SP-initiated login waiting for the IdP to answer.
+// Only the hash of the state value is stored; the value itself travels in
+// the redirect and the browser cookie. States are single use.
+type SSOLoginState struct {
+	StateHash    string
+	ConnectionID string
+	Nonce        string
+	CodeVerifier string
+	RequestID    string
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+}
+
+// SSOCallback is what the IdP sent back: an authorization code for OIDC or
+// a base64 SAMLResponse for SAML.
+type SSOCallback struct {
+	Code         string
+	SAMLResponse string
+}
+
+// SSOAssertion is what a verified IdP response says about the user.
+type SSOAssertion struct {
+	Subject string
+	Email   string
+	Groups  []string
+}
+
+// SSOIdentity links an IdP user to an M01 user for one connection. SCIM
+// clients see identities as their User resources; Active false means the
+// IdP deprovisioned the user.
+type SSOIdentity struct {
+	IdentityID   string
+	ConnectionID string
+	TeamID       string
+	UserID       string
+	Email        string
+	Subject      string
+	ExternalID   string
+	Active       bool
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type SSOIdentityQuery struct {
+	Email      string
+	ExternalID string
+	Offset     int
+	Limit      int
+}
+
+type SSOProvisionResult struct {
+	Member   TeamMember
+	Identity SSOIdentity
+	Action   string
+}
+
+// SSOProtocol speaks one federation protocol to IdPs.
+type SSOProtocol interface {
+	// AuthorizationURL is where the browser is sent to sign in.
+	AuthorizationURL(conn SSOConnection, sp SSOServiceProvider, login SSOLoginState, state string) (string, error)
+	// Verify checks the IdP's answer for login and returns the user it
+	// vouches for. Rejected answers wrap ErrSSOAssertionInvalid.
+	Verify(ctx context.Context, conn SSOConnection, sp SSOServiceProvider, login SSOLoginState, callback SSOCallback, now time.Time) (SSOAssertion, error)
+}
+
+// SAMLProtocol also publishes the SP metadata IdP admins import.
+type SAMLProtocol interface {
+	SSOProtocol
+	Metadata(sp SSOServiceProvider) ([]byte, error)
+}
+
+// SSORepository stores SSO connections, logins in flight and IdP
+// identities. Connection management is limited to the team owner; the
+// login and SCIM methods are called after the caller has been
+// authenticated by the IdP or the connection's SCIM token instead.
+type SSORepository interface {
+	CreateSSOConnection(ctx context.Context, actorUserID string, conn SSOConnection, now time.Time) (SSOConnection, error)
+	UpdateSSOConnection(ctx context.Context, actorUserID string, conn SSOConnection, now time.Time) (SSOConnection, error)
+	RotateSCIMToken(ctx context.Context, actorUserID string, teamID string, connectionID string, tokenHash string, now time.Time) (SSOConnection, error)
+	ListSSOConnections(ctx context.Context, actorUserID string, teamID string) ([]SSOConnection, error)
+	GetSSOConnection(ctx context.Context, connectionID string) (SSOConnection, error)
+
+	CreateSSOLoginState(ctx context.Context, login SSOLoginState) error
+	ConsumeSSOLoginState(ctx context.Context, stateHash string, now time.Time) (SSOLoginState, error)
+	ProvisionSSOMember(ctx context.Context, conn SSOConnection, assertion SSOAssertion, role string, now time.Time) (SSOProvisionResult, error)
+
+	ListSSOIdentities(ctx context.Context, connectionID string, query SSOIdentityQuery) ([]SSOIdentity, int, error)
+	GetSSOIdentity(ctx context.Context, connectionID string, identityID string) (SSOIdentity, error)
+	CreateSSOIdentity(ctx context.Context, conn SSOConnection, identity SSOIdentity, role string, now time.Time) (SSOIdentity, error)
+	SetSSOIdentityActive(ctx context.Context, conn SSOConnection, identityID string, active bool, role string, now time.Time) (SSOIdentity, error)
+	DeleteSSOIdentity(ctx context.Context, conn SSOConnection, identityID string, now time.Time) error
+}
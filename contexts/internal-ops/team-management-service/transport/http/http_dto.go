@@ -1,5 +1,7 @@
 package http
 
+import "encoding/json"
+
 type ErrorResponse struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
@@ -20,19 +22,60 @@ type CreateTeamResponse struct {
 	} `json:"data"`
 }
 
+// DirectoryUserRequest is an M01 account sync. UpdatedAt is M01's change
+// time in RFC 3339; syncs older than the stored row are ignored.
+type DirectoryUserRequest struct {
+	Email      string `json:"email"`
+	MFAEnabled bool   `json:"mfa_enabled"`
+	UpdatedAt  string `json:"updated_at,omitempty"`
+}
+
+type DirectoryUserResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		UserID     string `json:"user_id"`
+		Email      string `json:"email"`
+		MFAEnabled bool   `json:"mfa_enabled"`
+		UpdatedAt  string `json:"updated_at"`
+	} `json:"data"`
+}
+
 type CreateInviteRequest struct {
 	Email string `json:"email"`
 	Role  string `json:"role"`
 }
 
 type CreateInviteResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		InviteID    string `json:"invite_id"`
+		Email       string `json:"email"`
+		Role        string `json:"role"`
+		Status      string `json:"status"`
+		ExpiresAt   string `json:"expires_at"`
+		EmailStatus string `json:"email_status"`
+	} `json:"data"`
+}
+
+type ResendInviteResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		InviteID    string `json:"invite_id"`
+		Email       string `json:"email"`
+		Status      string `json:"status"`
+		ExpiresAt   string `json:"expires_at"`
+		SentCount   int    `json:"sent_count"`
+		EmailStatus string `json:"email_status"`
+	} `json:"data"`
+}
+
+type RevokeInviteResponse struct {
 	Status string `json:"status"`
 	Data   struct {
 		InviteID  string `json:"invite_id"`
-		Email     string `json:"email"`
-		Role      string `json:"role"`
+		TeamID    string `json:"team_id"`
 		Status    string `json:"status"`
-		ExpiresAt string `json:"expires_at"`
+		RevokedAt string `json:"revoked_at,omitempty"`
 	} `json:"data"`
 }
 
@@ -125,10 +168,182 @@ type AuditLogsResponse struct {
 type ExportMembersResponse struct {
 	Status string `json:"status"`
 	Data   struct {
-		ExportJobID           string `json:"export_job_id"`
-		TeamID                string `json:"team_id"`
-		Status                string `json:"status"`
-		CreatedAt             string `json:"created_at"`
-		EstimatedCompletionAt string `json:"estimated_completion_at"`
+		ExportJobID string   `json:"export_job_id"`
+		TeamID      string   `json:"team_id"`
+		Status      string   `json:"status"`
+		Format      string   `json:"format"`
+		Columns     []string `json:"columns"`
+		RowLimit    int      `json:"row_limit"`
+		CreatedAt   string   `json:"created_at"`
+		StatusURL   string   `json:"status_url"`
+	} `json:"data"`
+}
+
+type SSORoleMapping struct {
+	Group string `json:"group"`
+	Role  string `json:"role"`
+}
+
+// OIDCSettings is also returned in responses, where ClientSecret is always
+// empty: the secret is write-only.
+type OIDCSettings struct {
+	Issuer           string   `json:"issuer"`
+	ClientID         string   `json:"client_id"`
+	ClientSecret     string   `json:"client_secret,omitempty"`
+	AuthorizationURL string   `json:"authorization_url"`
+	TokenURL         string   `json:"token_url"`
+	JWKSURL          string   `json:"jwks_url"`
+	Scopes           []string `json:"scopes,omitempty"`
+	GroupsClaim      string   `json:"groups_claim,omitempty"`
+}
+
+type SAMLSettings struct {
+	IdPEntityID     string `json:"idp_entity_id"`
+	SSOURL          string `json:"sso_url"`
+	Certificate     string `json:"certificate"`
+	EmailAttribute  string `json:"email_attribute,omitempty"`
+	GroupsAttribute string `json:"groups_attribute,omitempty"`
+}
+
+type SSOConnectionRequest struct {
+	Protocol       string           `json:"protocol"`
+	Status         string           `json:"status,omitempty"`
+	AllowedDomains []string         `json:"allowed_domains"`
+	RoleMappings   []SSORoleMapping `json:"role_mappings"`
+	DefaultRole    string           `json:"default_role"`
+	OIDC           *OIDCSettings    `json:"oidc,omitempty"`
+	SAML           *SAMLSettings    `json:"saml,omitempty"`
+}
+
+type SSOServiceProvider struct {
+	LoginURL    string `json:"login_url"`
+	EntityID    string `json:"entity_id"`
+	MetadataURL string `json:"metadata_url"`
+	ACSURL      string `json:"acs_url"`
+	RedirectURL string `json:"redirect_url"`
+	SCIMBaseURL string `json:"scim_base_url"`
+}
+
+type SSOConnection struct {
+	ConnectionID    string             `json:"connection_id"`
+	TeamID          string             `json:"team_id"`
+	Protocol        string             `json:"protocol"`
+	Status          string             `json:"status"`
+	AllowedDomains  []string           `json:"allowed_domains"`
+	RoleMappings    []SSORoleMapping   `json:"role_mappings"`
+	DefaultRole     string             `json:"default_role"`
+	OIDC            *OIDCSettings      `json:"oidc,omitempty"`
+	SAML            *SAMLSettings      `json:"saml,omitempty"`
+	ServiceProvider SSOServiceProvider `json:"service_provider"`
+	SCIMToken       string             `json:"scim_token,omitempty"`
+	CreatedAt       string             `json:"created_at"`
+	UpdatedAt       string             `json:"updated_at"`
+}
+
+type SSOConnectionResponse struct {
+	Status string        `json:"status"`
+	Data   SSOConnection `json:"data"`
+}
+
+type SSOConnectionsResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		Items []SSOConnection `json:"items"`
+	} `json:"data"`
+}
+
+type SSOLoginResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		TeamID   string `json:"team_id"`
+		UserID   string `json:"user_id"`
+		MemberID string `json:"member_id"`
+		Role     string `json:"role"`
+		Action   string `json:"action"`
 	} `json:"data"`
 }
+
+// SCIM 2.0 (RFC 7643/7644) wire types. Only the User resource is
+// supported, and only its userName, externalId and active attributes are
+// stored.
+const (
+	SCIMContentType                 = "application/scim+json"
+	SCIMSchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SCIMSchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SCIMSchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SCIMSchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+	SCIMSchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+)
+
+type SCIMEmail struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+type SCIMMeta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Location     string `json:"location,omitempty"`
+}
+
+type SCIMUser struct {
+	Schemas    []string    `json:"schemas"`
+	ID         string      `json:"id,omitempty"`
+	ExternalID string      `json:"externalId,omitempty"`
+	UserName   string      `json:"userName"`
+	Active     *bool       `json:"active,omitempty"`
+	Emails     []SCIMEmail `json:"emails,omitempty"`
+	Meta       *SCIMMeta   `json:"meta,omitempty"`
+}
+
+type SCIMListResponse struct {
+	Schemas      []string   `json:"schemas"`
+	TotalResults int        `json:"totalResults"`
+	StartIndex   int        `json:"startIndex"`
+	ItemsPerPage int        `json:"itemsPerPage"`
+	Resources    []SCIMUser `json:"Resources"`
+}
+
+type SCIMPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+type SCIMPatchRequest struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []SCIMPatchOperation `json:"Operations"`
+}
+
+type SCIMError struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	SCIMType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail"`
+}
+
+type SCIMSupported struct {
+	Supported bool `json:"supported"`
+}
+
+type SCIMFilterSupport struct {
+	Supported  bool `json:"supported"`
+	MaxResults int  `json:"maxResults"`
+}
+
+type SCIMServiceProviderConfig struct {
+	Schemas               []string          `json:"schemas"`
+	Patch                 SCIMSupported     `json:"patch"`
+	Bulk                  SCIMSupported     `json:"bulk"`
+	Filter                SCIMFilterSupport `json:"filter"`
+	ChangePassword        SCIMSupported     `json:"changePassword"`
+	Sort                  SCIMSupported     `json:"sort"`
+	ETag                  SCIMSupported     `json:"etag"`
+	AuthenticationSchemes []struct {
+		Type        string `json:"type"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	} `json:"authenticationSchemes"`
+}
@@ -37,3 +37,74 @@ func (h Handler) ReleaseLockoutHandler(
 		OwnerAuditLogID: result.OwnerAuditLogID,
 	}, nil
 }
+
+func (h Handler) LoginHandler(
+	ctx context.Context,
+	req httptransport.LoginRequest,
+) (httptransport.LoginDecisionDTO, error) {
+	result, err := h.Service.EvaluateLogin(ctx, application.LoginInput{
+		UserID:            req.UserID,
+		IPAddress:         req.IPAddress,
+		DeviceFingerprint: req.DeviceFingerprint,
+		CredentialsValid:  req.CredentialsValid,
+		ChallengeID:       req.ChallengeID,
+	})
+	if err != nil {
+		return httptransport.LoginDecisionDTO{}, err
+	}
+	resp := httptransport.LoginDecisionDTO{
+		AttemptID: result.AttemptID,
+		RiskScore: result.RiskScore,
+		RiskTier:  result.RiskTier,
+		Decision:  result.Decision,
+		Reasons:   result.Reasons,
+	}
+	if result.Challenge != nil {
+		resp.ChallengeID = result.Challenge.ChallengeID
+		resp.Challenge = &httptransport.LoginChallengeDTO{
+			ChallengeID:    result.Challenge.ChallengeID,
+			Nonce:          result.Challenge.Nonce,
+			DifficultyBits: result.Challenge.DifficultyBits,
+			ExpiresAt:      result.Challenge.ExpiresAt.UTC().Format(time.RFC3339),
+		}
+	}
+	if result.LockedUntil != nil {
+		resp.LockedUntil = result.LockedUntil.UTC().Format(time.RFC3339)
+	}
+	return resp, nil
+}
+
+func (h Handler) ChallengeHandler(
+	ctx context.Context,
+	idempotencyKey string,
+	clientIP string,
+	challengeID string,
+	req httptransport.ChallengeRequest,
+) (httptransport.ChallengeResultDTO, error) {
+	result, err := h.Service.SubmitChallenge(ctx, idempotencyKey, application.ChallengeInput{
+		ChallengeID:       challengeID,
+		Solution:          req.Solution,
+		IPAddress:         clientIP,
+		DeviceFingerprint: req.DeviceFingerprint,
+	})
+	if err != nil {
+		return httptransport.ChallengeResultDTO{}, err
+	}
+	return httptransport.ChallengeResultDTO{
+		ChallengeID:       result.ChallengeID,
+		Result:            result.Result,
+		AttemptsRemaining: result.AttemptsRemaining,
+	}, nil
+}
+
+func (h Handler) ThreatSummaryHandler(ctx context.Context, adminID string) (httptransport.ThreatSummaryDTO, error) {
+	summary, err := h.Service.GetThreatSummary(ctx, adminID)
+	if err != nil {
+		return httptransport.ThreatSummaryDTO{}, err
+	}
+	return httptransport.ThreatSummaryDTO{
+		ActiveSuspiciousAccounts: summary.ActiveSuspiciousAccounts,
+		FailedLoginsLast24h:      summary.FailedLoginsLast24h,
+		ActiveLockouts:           summary.ActiveLockouts,
+	}, nil
+}
@@ -15,6 +15,9 @@ type lockoutRecord struct {
 	ThreatID   string
 	UserID     string
 	Active     bool
+	Reason     string
+	LockedAt   time.Time
+	ExpiresAt  *time.Time
 	ReleasedAt *time.Time
 }
 
@@ -23,6 +26,9 @@ type Store struct {
 	lockouts    map[string]lockoutRecord
 	idempotency map[string]ports.IdempotencyRecord
 	auditLogs   []ports.AuditLog
+	attempts    []ports.LoginAttempt
+	devices     map[string]ports.KnownDevice
+	challenges  map[string]ports.LoginChallenge
 	sequence    int64
 }
 
@@ -42,6 +48,8 @@ func NewStore() *Store {
 		},
 		idempotency: map[string]ports.IdempotencyRecord{},
 		auditLogs:   make([]ports.AuditLog, 0, 64),
+		devices:     map[string]ports.KnownDevice{},
+		challenges:  map[string]ports.LoginChallenge{},
 	}
 }
 
@@ -50,7 +58,7 @@ func (s *Store) ReleaseLockout(_ context.Context, userID string, releasedAt time
 	defer s.mu.Unlock()
 
 	row, ok := s.lockouts[userID]
-	if !ok || !row.Active {
+	if !ok || !row.Active || lockoutExpired(row, releasedAt) {
 		return ports.LockoutRelease{}, domainerrors.ErrThreatNotFound
 	}
 	releasedAt = releasedAt.UTC()
@@ -92,6 +100,178 @@ func (s *Store) ListRecentAuditLogs(_ context.Context, limit int) ([]ports.Audit
 	return out, nil
 }
 
+func (s *Store) RecordLoginAttempt(_ context.Context, attempt ports.LoginAttempt) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attempts = append(s.attempts, attempt)
+	return nil
+}
+
+func (s *Store) CountLoginFailures(
+	_ context.Context,
+	userID string,
+	ipAddress string,
+	deviceFingerprint string,
+	since time.Time,
+) (ports.LoginWindowCounts, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counts := ports.LoginWindowCounts{}
+	ipUsers := map[string]struct{}{}
+	for _, attempt := range s.attempts {
+		if attempt.OccurredAt.Before(since) {
+			continue
+		}
+		if attempt.IPAddress == ipAddress {
+			ipUsers[attempt.UserID] = struct{}{}
+		}
+		if attempt.Succeeded {
+			continue
+		}
+		if attempt.UserID == userID {
+			counts.UserFailures++
+		}
+		if attempt.IPAddress == ipAddress {
+			counts.IPFailures++
+		}
+		if deviceFingerprint != "" && attempt.DeviceFingerprint == deviceFingerprint {
+			counts.DeviceFailures++
+		}
+	}
+	counts.IPDistinctUsers = len(ipUsers)
+	return counts, nil
+}
+
+func (s *Store) IsKnownDevice(_ context.Context, userID string, deviceFingerprint string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.devices[userID+"|"+deviceFingerprint]
+	return ok, nil
+}
+
+func (s *Store) RememberDevice(_ context.Context, device ports.KnownDevice) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if device.DeviceFingerprint == "" {
+		return nil
+	}
+	key := device.UserID + "|" + device.DeviceFingerprint
+	if existing, ok := s.devices[key]; ok {
+		device.FirstSeenAt = existing.FirstSeenAt
+	}
+	s.devices[key] = device
+	return nil
+}
+
+func (s *Store) CreateChallenge(_ context.Context, challenge ports.LoginChallenge) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.challenges[challenge.ChallengeID] = challenge
+	return nil
+}
+
+func (s *Store) GetChallenge(_ context.Context, challengeID string) (ports.LoginChallenge, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	row, ok := s.challenges[challengeID]
+	if !ok {
+		return ports.LoginChallenge{}, domainerrors.ErrChallengeNotFound
+	}
+	return row, nil
+}
+
+func (s *Store) UpdateChallenge(_ context.Context, challenge ports.LoginChallenge, previousAttempts int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	row, ok := s.challenges[challenge.ChallengeID]
+	if !ok {
+		return domainerrors.ErrChallengeNotFound
+	}
+	if row.Status != "pending" || row.Attempts != previousAttempts {
+		return domainerrors.ErrChallengeClosed
+	}
+	s.challenges[challenge.ChallengeID] = challenge
+	return nil
+}
+
+func (s *Store) RedeemChallenge(_ context.Context, challengeID string, redeemedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	row, ok := s.challenges[challengeID]
+	if !ok {
+		return domainerrors.ErrChallengeNotFound
+	}
+	if row.Status != "passed" {
+		return domainerrors.ErrChallengeClosed
+	}
+	redeemedAt = redeemedAt.UTC()
+	row.Status = "redeemed"
+	row.ResolvedAt = &redeemedAt
+	s.challenges[challengeID] = row
+	return nil
+}
+
+func (s *Store) GetActiveLockout(_ context.Context, userID string, now time.Time) (ports.Lockout, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	row, ok := s.lockouts[userID]
+	if !ok || !row.Active || lockoutExpired(row, now) {
+		return ports.Lockout{}, false, nil
+	}
+	return ports.Lockout{
+		ThreatID:  row.ThreatID,
+		UserID:    row.UserID,
+		Reason:    row.Reason,
+		LockedAt:  row.LockedAt,
+		ExpiresAt: row.ExpiresAt,
+	}, true, nil
+}
+
+func (s *Store) CreateLockout(_ context.Context, lockout ports.Lockout) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lockouts[lockout.UserID] = lockoutRecord{
+		ThreatID:  lockout.ThreatID,
+		UserID:    lockout.UserID,
+		Active:    true,
+		Reason:    lockout.Reason,
+		LockedAt:  lockout.LockedAt,
+		ExpiresAt: lockout.ExpiresAt,
+	}
+	return nil
+}
+
+func (s *Store) GetThreatSummary(_ context.Context, since time.Time, now time.Time) (ports.ThreatSummary, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	summary := ports.ThreatSummary{}
+	suspicious := map[string]struct{}{}
+	for _, attempt := range s.attempts {
+		if attempt.OccurredAt.Before(since) {
+			continue
+		}
+		if !attempt.Succeeded {
+			summary.FailedLoginsLast24h++
+		}
+		if attempt.RiskTier == "high" || attempt.RiskTier == "critical" {
+			suspicious[attempt.UserID] = struct{}{}
+		}
+	}
+	summary.ActiveSuspiciousAccounts = len(suspicious)
+	for _, row := range s.lockouts {
+		if row.Active && !lockoutExpired(row, now) {
+			summary.ActiveLockouts++
+		}
+	}
+	return summary, nil
+}
+
+func lockoutExpired(row lockoutRecord, now time.Time) bool {
+	return row.ExpiresAt != nil && !now.Before(*row.ExpiresAt)
+}
+
 func (s *Store) Get(_ context.Context, key string, now time.Time) (*ports.IdempotencyRecord, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -39,6 +39,7 @@ func (r *Repository) ReleaseLockout(
 	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
 			Where("user_id = ? AND status = ?", userID, lockoutStatusActive).
+			Where("expires_at IS NULL OR expires_at > ?", releasedAt).
 			Order("locked_at DESC").
 			First(&row).Error; err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -120,6 +121,235 @@ func (r *Repository) ListRecentAuditLogs(ctx context.Context, limit int) ([]port
 	return out, nil
 }
 
+func (r *Repository) RecordLoginAttempt(ctx context.Context, attempt ports.LoginAttempt) error {
+	return r.db.WithContext(ctx).Create(&loginAttemptModel{
+		AttemptID:         strings.TrimSpace(attempt.AttemptID),
+		UserID:            strings.TrimSpace(attempt.UserID),
+		IPAddress:         strings.TrimSpace(attempt.IPAddress),
+		DeviceFingerprint: strings.TrimSpace(attempt.DeviceFingerprint),
+		Succeeded:         attempt.Succeeded,
+		RiskScore:         attempt.RiskScore,
+		RiskTier:          attempt.RiskTier,
+		Decision:          attempt.Decision,
+		OccurredAt:        attempt.OccurredAt.UTC(),
+	}).Error
+}
+
+func (r *Repository) CountLoginFailures(
+	ctx context.Context,
+	userID string,
+	ipAddress string,
+	deviceFingerprint string,
+	since time.Time,
+) (ports.LoginWindowCounts, error) {
+	var row struct {
+		UserFailures    int
+		IPFailures      int
+		DeviceFailures  int
+		IPDistinctUsers int
+	}
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT
+			COUNT(*) FILTER (WHERE user_id = ? AND NOT succeeded) AS user_failures,
+			COUNT(*) FILTER (WHERE ip_address = ? AND NOT succeeded) AS ip_failures,
+			COUNT(*) FILTER (WHERE ? <> '' AND device_fingerprint = ? AND NOT succeeded) AS device_failures,
+			COUNT(DISTINCT user_id) FILTER (WHERE ip_address = ?) AS ip_distinct_users
+		FROM abuse_login_attempts
+		WHERE occurred_at >= ?
+		  AND (user_id = ? OR ip_address = ? OR (? <> '' AND device_fingerprint = ?))`,
+		userID,
+		ipAddress,
+		deviceFingerprint, deviceFingerprint,
+		ipAddress,
+		since.UTC(),
+		userID, ipAddress, deviceFingerprint, deviceFingerprint,
+	).Scan(&row).Error
+	if err != nil {
+		return ports.LoginWindowCounts{}, err
+	}
+	return ports.LoginWindowCounts{
+		UserFailures:    row.UserFailures,
+		IPFailures:      row.IPFailures,
+		DeviceFailures:  row.DeviceFailures,
+		IPDistinctUsers: row.IPDistinctUsers,
+	}, nil
+}
+
+func (r *Repository) IsKnownDevice(ctx context.Context, userID string, deviceFingerprint string) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&knownDeviceModel{}).
+		Where("user_id = ? AND device_fingerprint = ?", strings.TrimSpace(userID), strings.TrimSpace(deviceFingerprint)).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *Repository) RememberDevice(ctx context.Context, device ports.KnownDevice) error {
+	if strings.TrimSpace(device.DeviceFingerprint) == "" {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "device_fingerprint"}},
+			DoUpdates: clause.AssignmentColumns([]string{"last_seen_at"}),
+		}).
+		Create(&knownDeviceModel{
+			UserID:            strings.TrimSpace(device.UserID),
+			DeviceFingerprint: strings.TrimSpace(device.DeviceFingerprint),
+			FirstSeenAt:       device.FirstSeenAt.UTC(),
+			LastSeenAt:        device.LastSeenAt.UTC(),
+		}).Error
+}
+
+func (r *Repository) CreateChallenge(ctx context.Context, challenge ports.LoginChallenge) error {
+	model := loginChallengeModelFromPort(challenge)
+	return r.db.WithContext(ctx).Create(&model).Error
+}
+
+func (r *Repository) GetChallenge(ctx context.Context, challengeID string) (ports.LoginChallenge, error) {
+	var row loginChallengeModel
+	if err := r.db.WithContext(ctx).
+		Where("challenge_id = ?", strings.TrimSpace(challengeID)).
+		First(&row).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ports.LoginChallenge{}, domainerrors.ErrChallengeNotFound
+		}
+		return ports.LoginChallenge{}, err
+	}
+	return row.toPort(), nil
+}
+
+func (r *Repository) UpdateChallenge(ctx context.Context, challenge ports.LoginChallenge, previousAttempts int) error {
+	result := r.db.WithContext(ctx).Model(&loginChallengeModel{}).
+		Where("challenge_id = ? AND status = ? AND attempts = ?", challenge.ChallengeID, "pending", previousAttempts).
+		Updates(map[string]any{
+			"status":      challenge.Status,
+			"attempts":    challenge.Attempts,
+			"resolved_at": challenge.ResolvedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+	if _, err := r.GetChallenge(ctx, challenge.ChallengeID); err != nil {
+		return err
+	}
+	return domainerrors.ErrChallengeClosed
+}
+
+func (r *Repository) RedeemChallenge(ctx context.Context, challengeID string, redeemedAt time.Time) error {
+	redeemedAt = redeemedAt.UTC()
+	result := r.db.WithContext(ctx).Model(&loginChallengeModel{}).
+		Where("challenge_id = ? AND status = ?", strings.TrimSpace(challengeID), "passed").
+		Updates(map[string]any{
+			"status":      "redeemed",
+			"resolved_at": &redeemedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+	if _, err := r.GetChallenge(ctx, challengeID); err != nil {
+		return err
+	}
+	return domainerrors.ErrChallengeClosed
+}
+
+func (r *Repository) GetActiveLockout(ctx context.Context, userID string, now time.Time) (ports.Lockout, bool, error) {
+	var row lockoutHistoryModel
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND status = ?", strings.TrimSpace(userID), lockoutStatusActive).
+		Where("expires_at IS NULL OR expires_at > ?", now.UTC()).
+		Order("locked_at DESC").
+		First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ports.Lockout{}, false, nil
+	}
+	if err != nil {
+		return ports.Lockout{}, false, err
+	}
+	return ports.Lockout{
+		LockoutID: row.LockoutID,
+		ThreatID:  row.ThreatID,
+		UserID:    row.UserID,
+		Reason:    row.Reason,
+		LockedAt:  row.LockedAt.UTC(),
+		ExpiresAt: row.ExpiresAt,
+	}, true, nil
+}
+
+// CreateLockout closes any expired lockout still marked active for the user
+// before inserting, since a user has at most one active lockout row. A user
+// who is already locked keeps the existing lockout.
+func (r *Repository) CreateLockout(ctx context.Context, lockout ports.Lockout) error {
+	lockedAt := lockout.LockedAt.UTC()
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing []lockoutHistoryModel
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ? AND status = ?", lockout.UserID, lockoutStatusActive).
+			Find(&existing).Error; err != nil {
+			return err
+		}
+		for _, row := range existing {
+			if row.ExpiresAt == nil || row.ExpiresAt.After(lockedAt) {
+				return nil
+			}
+			if err := tx.Model(&lockoutHistoryModel{}).
+				Where("lockout_id = ?", row.LockoutID).
+				Updates(map[string]any{
+					"status":      lockoutStatusReleased,
+					"released_at": *row.ExpiresAt,
+					"updated_at":  lockedAt,
+				}).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Create(&lockoutHistoryModel{
+			LockoutID: lockout.LockoutID,
+			ThreatID:  lockout.ThreatID,
+			UserID:    lockout.UserID,
+			Status:    lockoutStatusActive,
+			Reason:    lockout.Reason,
+			LockedAt:  lockedAt,
+			ExpiresAt: lockout.ExpiresAt,
+			UpdatedAt: lockedAt,
+		}).Error
+	})
+}
+
+func (r *Repository) GetThreatSummary(ctx context.Context, since time.Time, now time.Time) (ports.ThreatSummary, error) {
+	var attempts struct {
+		FailedLogins       int
+		SuspiciousAccounts int
+	}
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT
+			COUNT(*) FILTER (WHERE NOT succeeded) AS failed_logins,
+			COUNT(DISTINCT user_id) FILTER (WHERE risk_tier IN ('high', 'critical')) AS suspicious_accounts
+		FROM abuse_login_attempts
+		WHERE occurred_at >= ?`, since.UTC()).
+		Scan(&attempts).Error; err != nil {
+		return ports.ThreatSummary{}, err
+	}
+	var lockouts int64
+	if err := r.db.WithContext(ctx).Model(&lockoutHistoryModel{}).
+		Where("status = ?", lockoutStatusActive).
+		Where("expires_at IS NULL OR expires_at > ?", now.UTC()).
+		Count(&lockouts).Error; err != nil {
+		return ports.ThreatSummary{}, err
+	}
+	return ports.ThreatSummary{
+		ActiveSuspiciousAccounts: attempts.SuspiciousAccounts,
+		FailedLoginsLast24h:      attempts.FailedLogins,
+		ActiveLockouts:           int(lockouts),
+	}, nil
+}
+
 func (r *Repository) Get(ctx context.Context, key string, now time.Time) (*ports.IdempotencyRecord, error) {
 	key = strings.TrimSpace(key)
 	if key == "" {
@@ -226,6 +456,7 @@ type lockoutHistoryModel struct {
 	Status     string     `gorm:"column:status"`
 	Reason     string     `gorm:"column:reason"`
 	LockedAt   time.Time  `gorm:"column:locked_at"`
+	ExpiresAt  *time.Time `gorm:"column:expires_at"`
 	ReleasedAt *time.Time `gorm:"column:released_at"`
 	UpdatedAt  time.Time  `gorm:"column:updated_at"`
 }
@@ -249,6 +480,86 @@ func (auditLogModel) TableName() string {
 	return "abuse_audit_log"
 }
 
+type loginAttemptModel struct {
+	AttemptID         string    `gorm:"column:attempt_id;primaryKey"`
+	UserID            string    `gorm:"column:user_id"`
+	IPAddress         string    `gorm:"column:ip_address"`
+	DeviceFingerprint string    `gorm:"column:device_fingerprint"`
+	Succeeded         bool      `gorm:"column:succeeded"`
+	RiskScore         float64   `gorm:"column:risk_score"`
+	RiskTier          string    `gorm:"column:risk_tier"`
+	Decision          string    `gorm:"column:decision"`
+	OccurredAt        time.Time `gorm:"column:occurred_at"`
+}
+
+func (loginAttemptModel) TableName() string {
+	return "abuse_login_attempts"
+}
+
+type knownDeviceModel struct {
+	UserID            string    `gorm:"column:user_id;primaryKey"`
+	DeviceFingerprint string    `gorm:"column:device_fingerprint;primaryKey"`
+	FirstSeenAt       time.Time `gorm:"column:first_seen_at"`
+	LastSeenAt        time.Time `gorm:"column:last_seen_at"`
+}
+
+func (knownDeviceModel) TableName() string {
+	return "abuse_known_devices"
+}
+
+type loginChallengeModel struct {
+	ChallengeID       string     `gorm:"column:challenge_id;primaryKey"`
+	UserID            string     `gorm:"column:user_id"`
+	DeviceFingerprint string     `gorm:"column:device_fingerprint"`
+	IPAddress         string     `gorm:"column:ip_address"`
+	Nonce             string     `gorm:"column:nonce"`
+	DifficultyBits    int        `gorm:"column:difficulty_bits"`
+	Status            string     `gorm:"column:status"`
+	Attempts          int        `gorm:"column:attempts"`
+	MaxAttempts       int        `gorm:"column:max_attempts"`
+	ExpiresAt         time.Time  `gorm:"column:expires_at"`
+	CreatedAt         time.Time  `gorm:"column:created_at"`
+	ResolvedAt        *time.Time `gorm:"column:resolved_at"`
+}
+
+func (loginChallengeModel) TableName() string {
+	return "abuse_login_challenges"
+}
+
+func loginChallengeModelFromPort(challenge ports.LoginChallenge) loginChallengeModel {
+	return loginChallengeModel{
+		ChallengeID:       strings.TrimSpace(challenge.ChallengeID),
+		UserID:            strings.TrimSpace(challenge.UserID),
+		DeviceFingerprint: strings.TrimSpace(challenge.DeviceFingerprint),
+		IPAddress:         strings.TrimSpace(challenge.IPAddress),
+		Nonce:             challenge.Nonce,
+		DifficultyBits:    challenge.DifficultyBits,
+		Status:            challenge.Status,
+		Attempts:          challenge.Attempts,
+		MaxAttempts:       challenge.MaxAttempts,
+		ExpiresAt:         challenge.ExpiresAt.UTC(),
+		CreatedAt:         challenge.CreatedAt.UTC(),
+		ResolvedAt:        challenge.ResolvedAt,
+	}
+}
+
+func (m loginChallengeModel) toPort() ports.LoginChallenge {
+	return ports.LoginChallenge{
+		ChallengeID:       m.ChallengeID,
+		UserID:            m.UserID,
+		DeviceFingerprint: m.DeviceFingerprint,
+		IPAddress:         m.IPAddress,
+		Nonce:             m.Nonce,
+		DifficultyBits:    m.DifficultyBits,
+		Status:            m.Status,
+		Attempts:          m.Attempts,
+		MaxAttempts:       m.MaxAttempts,
+		ExpiresAt:         m.ExpiresAt.UTC(),
+		CreatedAt:         m.CreatedAt.UTC(),
+		ResolvedAt:        m.ResolvedAt,
+	}
+}
+
 type idempotencyModel struct {
 	Key          string    `gorm:"column:key;primaryKey"`
 	RequestHash  string    `gorm:"column:request_hash"`
@@ -0,0 +1,202 @@
+package application
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"math/bits"
+	"strings"
+	"time"
+
+	domainerrors "solomon/contexts/moderation-safety/abuse-prevention-service/domain/errors"
+	"solomon/contexts/moderation-safety/abuse-prevention-service/ports"
+)
+
+// ChallengeInput is a solution submitted by the end user. IPAddress is the
+// resolved client address and DeviceFingerprint the submitting device; both
+// must match the login the challenge was issued for.
+type ChallengeInput struct {
+	ChallengeID       string
+	Solution          string
+	IPAddress         string
+	DeviceFingerprint string
+}
+
+type ChallengeResult struct {
+	ChallengeID       string
+	Result            string
+	AttemptsRemaining int
+}
+
+// issueChallenge creates a proof-of-work challenge: the client must find a
+// solution whose SHA-256 over "nonce:solution" starts with DifficultyBits
+// zero bits. Higher risk asks for more work.
+func (s Service) issueChallenge(ctx context.Context, input LoginInput, tier string, now time.Time) (LoginChallengeResult, error) {
+	challengeID, err := newRandomID("challenge_")
+	if err != nil {
+		return LoginChallengeResult{}, err
+	}
+	nonce, err := newRandomID("")
+	if err != nil {
+		return LoginChallengeResult{}, err
+	}
+	difficulty := mediumRiskDifficultyBits
+	if tier == "high" {
+		difficulty = highRiskDifficultyBits
+	}
+	challenge := ports.LoginChallenge{
+		ChallengeID:       challengeID,
+		UserID:            input.UserID,
+		DeviceFingerprint: input.DeviceFingerprint,
+		IPAddress:         input.IPAddress,
+		Nonce:             nonce,
+		DifficultyBits:    difficulty,
+		Status:            ChallengeStatusPending,
+		MaxAttempts:       defaultChallengeMaxAttempts,
+		ExpiresAt:         now.Add(s.challengeTTL()),
+		CreatedAt:         now,
+	}
+	if err := s.Repo.CreateChallenge(ctx, challenge); err != nil {
+		return LoginChallengeResult{}, err
+	}
+	return LoginChallengeResult{
+		ChallengeID:    challenge.ChallengeID,
+		Nonce:          challenge.Nonce,
+		DifficultyBits: challenge.DifficultyBits,
+		ExpiresAt:      challenge.ExpiresAt,
+	}, nil
+}
+
+// SubmitChallenge checks a solution for a pending challenge. Only the
+// address and device the challenge was issued to may answer it. A challenge
+// accepts a limited number of attempts and passes at most once; passing it
+// vouches for nothing until the auth service reports the login again with
+// the challenge ID.
+func (s Service) SubmitChallenge(ctx context.Context, idempotencyKey string, input ChallengeInput) (ChallengeResult, error) {
+	input.ChallengeID = strings.TrimSpace(input.ChallengeID)
+	input.Solution = strings.TrimSpace(input.Solution)
+	input.IPAddress = strings.TrimSpace(input.IPAddress)
+	input.DeviceFingerprint = strings.TrimSpace(input.DeviceFingerprint)
+	if input.ChallengeID == "" || input.Solution == "" || input.IPAddress == "" {
+		return ChallengeResult{}, domainerrors.ErrInvalidRequest
+	}
+	if strings.TrimSpace(idempotencyKey) == "" {
+		return ChallengeResult{}, domainerrors.ErrIdempotencyKeyRequired
+	}
+
+	var output ChallengeResult
+	if err := s.runIdempotent(
+		ctx,
+		"challenge:"+idempotencyKey,
+		hashPayload(input),
+		func(payload []byte) error { return json.Unmarshal(payload, &output) },
+		func() ([]byte, error) {
+			result, err := s.resolveChallenge(ctx, input)
+			if err != nil {
+				return nil, err
+			}
+			return json.Marshal(result)
+		},
+	); err != nil {
+		return ChallengeResult{}, err
+	}
+	return output, nil
+}
+
+func (s Service) resolveChallenge(ctx context.Context, input ChallengeInput) (ChallengeResult, error) {
+	now := s.now()
+	challenge, err := s.Repo.GetChallenge(ctx, input.ChallengeID)
+	if err != nil {
+		return ChallengeResult{}, err
+	}
+	if challenge.IPAddress != input.IPAddress || challenge.DeviceFingerprint != input.DeviceFingerprint {
+		return ChallengeResult{}, domainerrors.ErrForbidden
+	}
+	if challenge.Status != ChallengeStatusPending {
+		return ChallengeResult{}, domainerrors.ErrChallengeClosed
+	}
+	previousAttempts := challenge.Attempts
+	if !now.Before(challenge.ExpiresAt) {
+		challenge.Status = ChallengeStatusExpired
+		challenge.ResolvedAt = &now
+		if err := s.Repo.UpdateChallenge(ctx, challenge, previousAttempts); err != nil {
+			return ChallengeResult{}, err
+		}
+		return ChallengeResult{}, domainerrors.ErrChallengeExpired
+	}
+
+	challenge.Attempts++
+	passed := verifyChallengeSolution(challenge.Nonce, input.Solution, challenge.DifficultyBits)
+	switch {
+	case passed:
+		challenge.Status = ChallengeStatusPassed
+		challenge.ResolvedAt = &now
+	case challenge.Attempts >= challenge.MaxAttempts:
+		challenge.Status = ChallengeStatusFailed
+		challenge.ResolvedAt = &now
+	}
+	if err := s.Repo.UpdateChallenge(ctx, challenge, previousAttempts); err != nil {
+		return ChallengeResult{}, err
+	}
+
+	result := ChallengeResult{
+		ChallengeID:       challenge.ChallengeID,
+		Result:            "failed",
+		AttemptsRemaining: challenge.MaxAttempts - challenge.Attempts,
+	}
+	if passed {
+		result.Result = "passed"
+	}
+	if result.AttemptsRemaining < 0 || challenge.Status != ChallengeStatusPending {
+		result.AttemptsRemaining = 0
+	}
+	return result, nil
+}
+
+// redeemChallenge reports whether the login carries a passed, unexpired
+// challenge issued to the same user, device and IP, and uses it up so it
+// lets only one login through. Any other challenge is ignored and the login
+// is scored as usual.
+func (s Service) redeemChallenge(ctx context.Context, input LoginInput, now time.Time) (bool, error) {
+	challenge, err := s.Repo.GetChallenge(ctx, input.ChallengeID)
+	if errors.Is(err, domainerrors.ErrChallengeNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if challenge.Status != ChallengeStatusPassed ||
+		challenge.UserID != input.UserID ||
+		challenge.DeviceFingerprint != input.DeviceFingerprint ||
+		challenge.IPAddress != input.IPAddress ||
+		!now.Before(challenge.ExpiresAt) {
+		return false, nil
+	}
+	err = s.Repo.RedeemChallenge(ctx, challenge.ChallengeID, now)
+	if errors.Is(err, domainerrors.ErrChallengeClosed) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func verifyChallengeSolution(nonce string, solution string, difficultyBits int) bool {
+	sum := sha256.Sum256([]byte(nonce + ":" + solution))
+	zeros := 0
+	for _, b := range sum {
+		if b == 0 {
+			zeros += 8
+			continue
+		}
+		zeros += bits.LeadingZeros8(b)
+		break
+	}
+	return zeros >= difficultyBits
+}
+
+func (s Service) challengeTTL() time.Duration {
+	if s.ChallengeTTL <= 0 {
+		return defaultChallengeTTL
+	}
+	return s.ChallengeTTL
+}
@@ -0,0 +1,310 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"math"
+	"strings"
+	"time"
+
+	domainerrors "solomon/contexts/moderation-safety/abuse-prevention-service/domain/errors"
+	"solomon/contexts/moderation-safety/abuse-prevention-service/ports"
+)
+
+const (
+	DecisionAllow     = "allow"
+	DecisionChallenge = "challenge"
+	DecisionDeny      = "deny"
+	DecisionBlock     = "block"
+
+	ChallengeStatusPending = "pending"
+	ChallengeStatusPassed  = "passed"
+	ChallengeStatusFailed  = "failed"
+	ChallengeStatusExpired = "expired"
+	// ChallengeStatusRedeemed challenges were passed and then used by a
+	// successful login, so they cannot vouch for another one.
+	ChallengeStatusRedeemed = "redeemed"
+
+	defaultLoginWindow          = 15 * time.Minute
+	defaultLockoutThreshold     = 10
+	defaultLockoutDuration      = 30 * time.Minute
+	defaultChallengeTTL         = 5 * time.Minute
+	defaultChallengeMaxAttempts = 3
+
+	mediumRiskDifficultyBits = 16
+	highRiskDifficultyBits   = 20
+)
+
+type LoginInput struct {
+	UserID            string
+	IPAddress         string
+	DeviceFingerprint string
+	// CredentialsValid is the outcome of the auth service's own credential
+	// check, reported over the authenticated internal hook; clients never
+	// supply it. Every other risk signal comes from server-side records.
+	CredentialsValid bool
+	// ChallengeID is the challenge the user passed before the auth service
+	// retried this login. A passed challenge bound to the same user, device
+	// and IP lets valid credentials through once.
+	ChallengeID string
+}
+
+type LoginChallengeResult struct {
+	ChallengeID    string
+	Nonce          string
+	DifficultyBits int
+	ExpiresAt      time.Time
+}
+
+type LoginResult struct {
+	AttemptID   string
+	RiskScore   float64
+	RiskTier    string
+	Decision    string
+	Reasons     []string
+	Challenge   *LoginChallengeResult
+	LockedUntil *time.Time
+}
+
+// EvaluateLogin scores a login attempt from the sliding-window failure
+// counters of its user, IP and device and the user's known-device registry,
+// records the attempt and decides how to proceed. Valid credentials from a
+// risky context are challenged, and let through once the challenge is
+// passed; too many failures for a user lock the account until the lockout
+// expires or an admin releases it. A device becomes known only when a login
+// is allowed.
+func (s Service) EvaluateLogin(ctx context.Context, input LoginInput) (LoginResult, error) {
+	input.UserID = strings.TrimSpace(input.UserID)
+	input.IPAddress = strings.TrimSpace(input.IPAddress)
+	input.DeviceFingerprint = strings.TrimSpace(input.DeviceFingerprint)
+	input.ChallengeID = strings.TrimSpace(input.ChallengeID)
+	if input.UserID == "" || input.IPAddress == "" {
+		return LoginResult{}, domainerrors.ErrInvalidRequest
+	}
+
+	now := s.now()
+	result, counts, locked, err := s.scoreLoginContext(ctx, input.UserID, input.IPAddress, input.DeviceFingerprint, now)
+	if err != nil {
+		return LoginResult{}, err
+	}
+	result.AttemptID, err = newRandomID("login_attempt_")
+	if err != nil {
+		return LoginResult{}, err
+	}
+	challengePassed := false
+	if input.CredentialsValid && input.ChallengeID != "" && !locked && result.RiskTier != "critical" {
+		challengePassed, err = s.redeemChallenge(ctx, input, now)
+		if err != nil {
+			return LoginResult{}, err
+		}
+	}
+
+	switch {
+	case locked:
+		// Blocked by scoreLoginContext.
+	case !input.CredentialsValid:
+		result.Decision = DecisionDeny
+		if counts.UserFailures+1 >= s.lockoutThreshold() {
+			until, err := s.lockUser(ctx, input.UserID, now)
+			if err != nil {
+				return LoginResult{}, err
+			}
+			result.Decision = DecisionBlock
+			result.Reasons = append(result.Reasons, "account_locked")
+			result.LockedUntil = &until
+		}
+	case result.RiskTier == "critical":
+		result.Decision = DecisionBlock
+	case (result.RiskTier == "high" || result.RiskTier == "medium") && !challengePassed:
+		challenge, err := s.issueChallenge(ctx, input, result.RiskTier, now)
+		if err != nil {
+			return LoginResult{}, err
+		}
+		result.Decision = DecisionChallenge
+		result.Challenge = &challenge
+	default:
+		result.Decision = DecisionAllow
+		if challengePassed {
+			result.Reasons = append(result.Reasons, "challenge_passed")
+		}
+		if err := s.Repo.RememberDevice(ctx, ports.KnownDevice{
+			UserID:            input.UserID,
+			DeviceFingerprint: input.DeviceFingerprint,
+			FirstSeenAt:       now,
+			LastSeenAt:        now,
+		}); err != nil {
+			return LoginResult{}, err
+		}
+	}
+
+	if err := s.Repo.RecordLoginAttempt(ctx, ports.LoginAttempt{
+		AttemptID:         result.AttemptID,
+		UserID:            input.UserID,
+		IPAddress:         input.IPAddress,
+		DeviceFingerprint: input.DeviceFingerprint,
+		Succeeded:         input.CredentialsValid,
+		RiskScore:         result.RiskScore,
+		RiskTier:          result.RiskTier,
+		Decision:          result.Decision,
+		OccurredAt:        now,
+	}); err != nil {
+		return LoginResult{}, err
+	}
+	return result, nil
+}
+
+// scoreLoginContext loads the lockout, failure counters and device
+// knowledge behind a login and scores them. A locked user comes back with
+// the block decision already set.
+func (s Service) scoreLoginContext(
+	ctx context.Context,
+	userID string,
+	ipAddress string,
+	deviceFingerprint string,
+	now time.Time,
+) (LoginResult, ports.LoginWindowCounts, bool, error) {
+	lockout, locked, err := s.Repo.GetActiveLockout(ctx, userID, now)
+	if err != nil {
+		return LoginResult{}, ports.LoginWindowCounts{}, false, err
+	}
+	counts, err := s.Repo.CountLoginFailures(ctx, userID, ipAddress, deviceFingerprint, now.Add(-s.loginWindow()))
+	if err != nil {
+		return LoginResult{}, ports.LoginWindowCounts{}, false, err
+	}
+	knownDevice := false
+	if deviceFingerprint != "" {
+		knownDevice, err = s.Repo.IsKnownDevice(ctx, userID, deviceFingerprint)
+		if err != nil {
+			return LoginResult{}, ports.LoginWindowCounts{}, false, err
+		}
+	}
+
+	score, reasons := scoreLogin(counts, knownDevice, deviceFingerprint != "")
+	result := LoginResult{
+		RiskScore: score,
+		RiskTier:  riskTier(score),
+		Reasons:   reasons,
+	}
+	if locked {
+		result.Decision = DecisionBlock
+		result.Reasons = append(result.Reasons, "account_locked")
+		result.LockedUntil = lockout.ExpiresAt
+	}
+	return result, counts, locked, nil
+}
+
+func (s Service) GetThreatSummary(ctx context.Context, adminID string) (ports.ThreatSummary, error) {
+	if strings.TrimSpace(adminID) == "" {
+		return ports.ThreatSummary{}, domainerrors.ErrUnauthorized
+	}
+	now := s.now()
+	return s.Repo.GetThreatSummary(ctx, now.Add(-24*time.Hour), now)
+}
+
+func (s Service) lockUser(ctx context.Context, userID string, now time.Time) (time.Time, error) {
+	lockoutID, err := newRandomID("lockout_")
+	if err != nil {
+		return time.Time{}, err
+	}
+	until := now.Add(s.lockoutDuration())
+	if err := s.Repo.CreateLockout(ctx, ports.Lockout{
+		LockoutID: lockoutID,
+		ThreatID:  "threat_" + strings.TrimPrefix(lockoutID, "lockout_"),
+		UserID:    userID,
+		Reason:    "too_many_failed_logins",
+		LockedAt:  now,
+		ExpiresAt: &until,
+	}); err != nil {
+		return time.Time{}, err
+	}
+	if err := s.Repo.AppendAuditLog(ctx, ports.AuditLog{
+		AuditID:       "abuse_audit_" + strings.TrimPrefix(lockoutID, "lockout_"),
+		ActorID:       "system",
+		Action:        "abuse.lockout.created",
+		TargetID:      userID,
+		Justification: "too_many_failed_logins",
+		OccurredAt:    now,
+	}); err != nil {
+		return time.Time{}, err
+	}
+	return until, nil
+}
+
+// scoreLogin turns the server-side signals into a risk score in [0, 1] and
+// the reasons that raised it.
+func scoreLogin(counts ports.LoginWindowCounts, knownDevice bool, hasFingerprint bool) (float64, []string) {
+	score := 0.1
+	reasons := []string{}
+	switch {
+	case counts.UserFailures >= 6:
+		score += 0.4
+		reasons = append(reasons, "repeated_failed_logins")
+	case counts.UserFailures >= 3:
+		score += 0.3
+		reasons = append(reasons, "recent_failed_logins")
+	}
+	if counts.IPFailures >= 10 {
+		score += 0.3
+		reasons = append(reasons, "ip_failure_velocity")
+	}
+	if counts.IPDistinctUsers >= 5 {
+		score += 0.3
+		reasons = append(reasons, "ip_many_accounts")
+	}
+	if counts.DeviceFailures >= 5 {
+		score += 0.2
+		reasons = append(reasons, "device_failure_velocity")
+	}
+	switch {
+	case !hasFingerprint:
+		score += 0.2
+		reasons = append(reasons, "missing_device_fingerprint")
+	case !knownDevice:
+		score += 0.2
+		reasons = append(reasons, "unknown_device")
+	}
+	return math.Min(1, math.Round(score*100)/100), reasons
+}
+
+func riskTier(score float64) string {
+	switch {
+	case score >= 0.9:
+		return "critical"
+	case score >= 0.7:
+		return "high"
+	case score >= 0.3:
+		return "medium"
+	default:
+		return "low"
+	}
+}
+
+func (s Service) loginWindow() time.Duration {
+	if s.LoginWindow <= 0 {
+		return defaultLoginWindow
+	}
+	return s.LoginWindow
+}
+
+func (s Service) lockoutThreshold() int {
+	if s.LockoutThreshold <= 0 {
+		return defaultLockoutThreshold
+	}
+	return s.LockoutThreshold
+}
+
+func (s Service) lockoutDuration() time.Duration {
+	if s.LockoutDuration <= 0 {
+		return defaultLockoutDuration
+	}
+	return s.LockoutDuration
+}
+
+func newRandomID(prefix string) (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return prefix + hex.EncodeToString(buf), nil
+}
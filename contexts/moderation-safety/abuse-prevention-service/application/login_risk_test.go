@@ -0,0 +1,197 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"solomon/contexts/moderation-safety/abuse-prevention-service/adapters/memory"
+	domainerrors "solomon/contexts/moderation-safety/abuse-prevention-service/domain/errors"
+)
+
+type fixedClock struct {
+	now time.Time
+}
+
+func (c *fixedClock) Now() time.Time {
+	return c.now
+}
+
+func solveChallenge(t *testing.T, nonce string, difficultyBits int) string {
+	t.Helper()
+	for i := 0; i < 1<<26; i++ {
+		candidate := strconv.Itoa(i)
+		if verifyChallengeSolution(nonce, candidate, difficultyBits) {
+			return candidate
+		}
+	}
+	t.Fatalf("no solution found for difficulty %d", difficultyBits)
+	return ""
+}
+
+func TestPassedChallengeRegistersDeviceOnlyAfterReportedLogin(t *testing.T) {
+	store := memory.NewStore()
+	svc := Service{Repo: store, Idempotency: store, Clock: store}
+	ctx := context.Background()
+	input := LoginInput{
+		UserID:            "user-risk-1",
+		IPAddress:         "203.0.113.10",
+		DeviceFingerprint: "fp-laptop",
+		CredentialsValid:  true,
+	}
+
+	first, err := svc.EvaluateLogin(ctx, input)
+	if err != nil {
+		t.Fatalf("evaluate login failed: %v", err)
+	}
+	if first.Decision != DecisionChallenge || first.Challenge == nil {
+		t.Fatalf("expected unknown device to be challenged, got %+v", first)
+	}
+	submit := ChallengeInput{
+		ChallengeID:       first.Challenge.ChallengeID,
+		IPAddress:         input.IPAddress,
+		DeviceFingerprint: input.DeviceFingerprint,
+	}
+
+	stranger := submit
+	stranger.IPAddress = "198.51.100.99"
+	stranger.Solution = "1"
+	if _, err := svc.SubmitChallenge(ctx, "idem-challenge-stranger", stranger); !errors.Is(err, domainerrors.ErrForbidden) {
+		t.Fatalf("expected a solution from another address to be refused, got %v", err)
+	}
+
+	wrong := submit
+	wrong.Solution = "not-a-solution-" + first.Challenge.Nonce
+	wrongResult, err := svc.SubmitChallenge(ctx, "idem-challenge-wrong", wrong)
+	if err != nil {
+		t.Fatalf("submit wrong solution failed: %v", err)
+	}
+	if wrongResult.Result != "failed" || wrongResult.AttemptsRemaining != defaultChallengeMaxAttempts-1 {
+		t.Fatalf("unexpected wrong-solution result %+v", wrongResult)
+	}
+
+	submit.Solution = solveChallenge(t, first.Challenge.Nonce, first.Challenge.DifficultyBits)
+	passed, err := svc.SubmitChallenge(ctx, "idem-challenge-pass", submit)
+	if err != nil {
+		t.Fatalf("submit solution failed: %v", err)
+	}
+	if passed.Result != "passed" {
+		t.Fatalf("expected challenge to pass, got %+v", passed)
+	}
+	if _, err := svc.SubmitChallenge(ctx, "idem-challenge-reuse", submit); !errors.Is(err, domainerrors.ErrChallengeClosed) {
+		t.Fatalf("expected reused challenge to be closed, got %v", err)
+	}
+	if known, _ := store.IsKnownDevice(ctx, input.UserID, input.DeviceFingerprint); known {
+		t.Fatalf("expected a passed challenge alone not to register the device")
+	}
+
+	// Another user's login cannot borrow the passed challenge.
+	other := input
+	other.UserID = "user-risk-other"
+	other.ChallengeID = first.Challenge.ChallengeID
+	borrowed, err := svc.EvaluateLogin(ctx, other)
+	if err != nil {
+		t.Fatalf("borrowed challenge login failed: %v", err)
+	}
+	if borrowed.Decision != DecisionChallenge {
+		t.Fatalf("expected another user's login to be challenged, got %+v", borrowed)
+	}
+
+	input.ChallengeID = first.Challenge.ChallengeID
+	second, err := svc.EvaluateLogin(ctx, input)
+	if err != nil {
+		t.Fatalf("login with passed challenge failed: %v", err)
+	}
+	if second.Decision != DecisionAllow {
+		t.Fatalf("expected the reported login with a passed challenge to be allowed, got %+v", second)
+	}
+	if challenge, _ := store.GetChallenge(ctx, first.Challenge.ChallengeID); challenge.Status != ChallengeStatusRedeemed {
+		t.Fatalf("expected the challenge redeemed, got %q", challenge.Status)
+	}
+
+	input.ChallengeID = ""
+	third, err := svc.EvaluateLogin(ctx, input)
+	if err != nil {
+		t.Fatalf("third login failed: %v", err)
+	}
+	if third.Decision != DecisionAllow {
+		t.Fatalf("expected known device to be allowed, got %+v", third)
+	}
+}
+
+func TestLoginChallengeExpires(t *testing.T) {
+	store := memory.NewStore()
+	clock := &fixedClock{now: time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)}
+	svc := Service{Repo: store, Idempotency: store, Clock: clock}
+	ctx := context.Background()
+
+	result, err := svc.EvaluateLogin(ctx, LoginInput{
+		UserID:           "user-risk-2",
+		IPAddress:        "203.0.113.20",
+		CredentialsValid: true,
+	})
+	if err != nil {
+		t.Fatalf("evaluate login failed: %v", err)
+	}
+	if result.Challenge == nil {
+		t.Fatalf("expected login without fingerprint to be challenged, got %+v", result)
+	}
+	clock.now = clock.now.Add(defaultChallengeTTL)
+	_, err = svc.SubmitChallenge(ctx, "idem-challenge-expired", ChallengeInput{
+		ChallengeID: result.Challenge.ChallengeID,
+		Solution:    "1",
+		IPAddress:   "203.0.113.20",
+	})
+	if !errors.Is(err, domainerrors.ErrChallengeExpired) {
+		t.Fatalf("expected expired challenge, got %v", err)
+	}
+}
+
+func TestLoginFailuresLockAccount(t *testing.T) {
+	store := memory.NewStore()
+	clock := &fixedClock{now: time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)}
+	svc := Service{Repo: store, Idempotency: store, Clock: clock, LockoutThreshold: 4}
+	ctx := context.Background()
+	input := LoginInput{
+		UserID:            "user-risk-3",
+		IPAddress:         "198.51.100.7",
+		DeviceFingerprint: "fp-attacker",
+	}
+
+	var last LoginResult
+	for i := 0; i < 4; i++ {
+		var err error
+		last, err = svc.EvaluateLogin(ctx, input)
+		if err != nil {
+			t.Fatalf("login %d failed: %v", i, err)
+		}
+		clock.now = clock.now.Add(time.Minute)
+	}
+	if last.Decision != DecisionBlock || last.LockedUntil == nil {
+		t.Fatalf("expected account to be locked after repeated failures, got %+v", last)
+	}
+
+	input.CredentialsValid = true
+	locked, err := svc.EvaluateLogin(ctx, input)
+	if err != nil {
+		t.Fatalf("login while locked failed: %v", err)
+	}
+	if locked.Decision != DecisionBlock {
+		t.Fatalf("expected valid credentials to stay blocked while locked, got %+v", locked)
+	}
+
+	summary, err := svc.GetThreatSummary(ctx, "admin-1")
+	if err != nil {
+		t.Fatalf("threat summary failed: %v", err)
+	}
+	if summary.FailedLoginsLast24h != 4 || summary.ActiveLockouts != 3 {
+		t.Fatalf("unexpected threat summary %+v", summary)
+	}
+
+	clock.now = *last.LockedUntil
+	if _, locked, err := store.GetActiveLockout(ctx, input.UserID, clock.now); err != nil || locked {
+		t.Fatalf("expected lockout to expire, locked=%v err=%v", locked, err)
+	}
+}
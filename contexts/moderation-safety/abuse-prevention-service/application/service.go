@@ -18,6 +18,12 @@ type Service struct {
 	Idempotency    ports.IdempotencyStore
 	Clock          ports.Clock
 	IdempotencyTTL time.Duration
+
+	// Login risk tuning; zero values use the defaults in login_risk.go.
+	LoginWindow      time.Duration
+	LockoutThreshold int
+	LockoutDuration  time.Duration
+	ChallengeTTL     time.Duration
 }
 
 type ReleaseLockoutInput struct {
@@ -9,4 +9,7 @@ var (
 	ErrIdempotencyKeyRequired = errors.New("idempotency key required")
 	ErrIdempotencyConflict    = errors.New("idempotency key reused with different payload")
 	ErrThreatNotFound         = errors.New("abuse threat not found")
+	ErrChallengeNotFound      = errors.New("login challenge not found")
+	ErrChallengeExpired       = errors.New("login challenge expired")
+	ErrChallengeClosed        = errors.New("login challenge already resolved")
 )
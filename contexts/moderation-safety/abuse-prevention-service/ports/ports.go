@@ -36,10 +36,90 @@ type AuditLog struct {
 	CorrelationID string
 }
 
+// LoginAttempt is one server-observed login. IPAddress comes from the
+// connection, never from the request body.
+type LoginAttempt struct {
+	AttemptID         string
+	UserID            string
+	IPAddress         string
+	DeviceFingerprint string
+	Succeeded         bool
+	RiskScore         float64
+	RiskTier          string
+	Decision          string
+	OccurredAt        time.Time
+}
+
+// LoginWindowCounts are sliding-window failure counters for the user, IP and
+// device of a login attempt. IPDistinctUsers counts the accounts tried from
+// the IP, which is how credential stuffing shows up.
+type LoginWindowCounts struct {
+	UserFailures    int
+	IPFailures      int
+	DeviceFailures  int
+	IPDistinctUsers int
+}
+
+type KnownDevice struct {
+	UserID            string
+	DeviceFingerprint string
+	FirstSeenAt       time.Time
+	LastSeenAt        time.Time
+}
+
+// LoginChallenge is a single-use proof-of-work challenge bound to the user,
+// device and IP that triggered it.
+type LoginChallenge struct {
+	ChallengeID       string
+	UserID            string
+	DeviceFingerprint string
+	IPAddress         string
+	Nonce             string
+	DifficultyBits    int
+	Status            string
+	Attempts          int
+	MaxAttempts       int
+	ExpiresAt         time.Time
+	CreatedAt         time.Time
+	ResolvedAt        *time.Time
+}
+
+type Lockout struct {
+	LockoutID string
+	ThreatID  string
+	UserID    string
+	Reason    string
+	LockedAt  time.Time
+	ExpiresAt *time.Time
+}
+
+type ThreatSummary struct {
+	ActiveSuspiciousAccounts int
+	FailedLoginsLast24h      int
+	ActiveLockouts           int
+}
+
 type Repository interface {
 	ReleaseLockout(ctx context.Context, userID string, releasedAt time.Time) (LockoutRelease, error)
 	AppendAuditLog(ctx context.Context, row AuditLog) error
 	ListRecentAuditLogs(ctx context.Context, limit int) ([]AuditLog, error)
+
+	RecordLoginAttempt(ctx context.Context, attempt LoginAttempt) error
+	CountLoginFailures(ctx context.Context, userID string, ipAddress string, deviceFingerprint string, since time.Time) (LoginWindowCounts, error)
+	IsKnownDevice(ctx context.Context, userID string, deviceFingerprint string) (bool, error)
+	RememberDevice(ctx context.Context, device KnownDevice) error
+	CreateChallenge(ctx context.Context, challenge LoginChallenge) error
+	GetChallenge(ctx context.Context, challengeID string) (LoginChallenge, error)
+	// UpdateChallenge stores a challenge only if it is still pending with
+	// previousAttempts attempts, so each solution is checked exactly once.
+	UpdateChallenge(ctx context.Context, challenge LoginChallenge, previousAttempts int) error
+	// RedeemChallenge moves a passed challenge to redeemed, failing with
+	// ErrChallengeClosed unless it is still passed, so it is redeemed once.
+	RedeemChallenge(ctx context.Context, challengeID string, redeemedAt time.Time) error
+	// GetActiveLockout returns the user's unexpired active lockout, if any.
+	GetActiveLockout(ctx context.Context, userID string, now time.Time) (Lockout, bool, error)
+	CreateLockout(ctx context.Context, lockout Lockout) error
+	GetThreatSummary(ctx context.Context, since time.Time, now time.Time) (ThreatSummary, error)
 }
 
 type Clock interface {
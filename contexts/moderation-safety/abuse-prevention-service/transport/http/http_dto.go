@@ -13,3 +13,48 @@ type ReleaseLockoutResponse struct {
 	ReleasedAt      string `json:"released_at"`
 	OwnerAuditLogID string `json:"owner_audit_log_id"`
 }
+
+// LoginRequest is reported by the auth service after it has checked the
+// credentials; IPAddress is the end user's address, not the caller's.
+type LoginRequest struct {
+	UserID            string `json:"user_id"`
+	IPAddress         string `json:"ip_address"`
+	DeviceFingerprint string `json:"device_fingerprint,omitempty"`
+	CredentialsValid  bool   `json:"credentials_valid"`
+	ChallengeID       string `json:"challenge_id,omitempty"`
+}
+
+type LoginChallengeDTO struct {
+	ChallengeID    string `json:"challenge_id"`
+	Nonce          string `json:"nonce"`
+	DifficultyBits int    `json:"difficulty_bits"`
+	ExpiresAt      string `json:"expires_at"`
+}
+
+type LoginDecisionDTO struct {
+	AttemptID   string             `json:"attempt_id"`
+	RiskScore   float64            `json:"risk_score"`
+	RiskTier    string             `json:"risk_tier"`
+	Decision    string             `json:"decision"`
+	Reasons     []string           `json:"reasons"`
+	ChallengeID string             `json:"challenge_id,omitempty"`
+	Challenge   *LoginChallengeDTO `json:"challenge,omitempty"`
+	LockedUntil string             `json:"locked_until,omitempty"`
+}
+
+type ChallengeRequest struct {
+	Solution          string `json:"solution"`
+	DeviceFingerprint string `json:"device_fingerprint,omitempty"`
+}
+
+type ChallengeResultDTO struct {
+	ChallengeID       string `json:"challenge_id"`
+	Result            string `json:"result"`
+	AttemptsRemaining int    `json:"attempts_remaining"`
+}
+
+type ThreatSummaryDTO struct {
+	ActiveSuspiciousAccounts int `json:"active_suspicious_accounts"`
+	FailedLoginsLast24h      int `json:"failed_logins_last_24h"`
+	ActiveLockouts           int `json:"active_lockouts"`
+}
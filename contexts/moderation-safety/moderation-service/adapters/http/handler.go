@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"solomon/contexts/moderation-safety/moderation-service/application"
+	domainerrors "solomon/contexts/moderation-safety/moderation-service/domain/errors"
 	"solomon/contexts/moderation-safety/moderation-service/ports"
 	httptransport "solomon/contexts/moderation-safety/moderation-service/transport/http"
 )
@@ -17,8 +18,23 @@ type Handler struct {
 	Logger  *slog.Logger
 }
 
-func (h Handler) ListQueueHandler(ctx context.Context, statusRaw string, limitRaw string, offsetRaw string) (httptransport.QueueResponse, error) {
-	filter := ports.QueueFilter{Status: strings.TrimSpace(statusRaw)}
+func (h Handler) ListQueueHandler(
+	ctx context.Context,
+	statusRaw string,
+	assignedRaw string,
+	severityRaw string,
+	overdueRaw string,
+	limitRaw string,
+	offsetRaw string,
+) (httptransport.QueueResponse, error) {
+	filter := ports.QueueFilter{
+		Status:              strings.TrimSpace(statusRaw),
+		AssignedModeratorID: strings.TrimSpace(assignedRaw),
+		Severity:            strings.TrimSpace(severityRaw),
+	}
+	if parsed, err := strconv.ParseBool(strings.TrimSpace(overdueRaw)); err == nil {
+		filter.Overdue = parsed
+	}
 	if parsed, err := strconv.Atoi(strings.TrimSpace(limitRaw)); err == nil {
 		filter.Limit = parsed
 	}
@@ -30,40 +46,89 @@ func (h Handler) ListQueueHandler(ctx context.Context, statusRaw string, limitRa
 		return httptransport.QueueResponse{}, err
 	}
 	resp := httptransport.QueueResponse{Status: "success", Timestamp: time.Now().UTC().Format(time.RFC3339)}
-	resp.Data.Items = make([]struct {
-		SubmissionID        string  `json:"submission_id"`
-		CampaignID          string  `json:"campaign_id"`
-		CreatorID           string  `json:"creator_id"`
-		Status              string  `json:"status"`
-		RiskScore           float64 `json:"risk_score"`
-		ReportCount         int     `json:"report_count"`
-		QueuedAt            string  `json:"queued_at"`
-		AssignedModeratorID string  `json:"assigned_moderator_id,omitempty"`
-	}, 0, len(items))
+	resp.Data.Items = make([]httptransport.QueueItem, 0, len(items))
 	for _, item := range items {
-		resp.Data.Items = append(resp.Data.Items, struct {
-			SubmissionID        string  `json:"submission_id"`
-			CampaignID          string  `json:"campaign_id"`
-			CreatorID           string  `json:"creator_id"`
-			Status              string  `json:"status"`
-			RiskScore           float64 `json:"risk_score"`
-			ReportCount         int     `json:"report_count"`
-			QueuedAt            string  `json:"queued_at"`
-			AssignedModeratorID string  `json:"assigned_moderator_id,omitempty"`
-		}{
-			SubmissionID:        item.SubmissionID,
-			CampaignID:          item.CampaignID,
-			CreatorID:           item.CreatorID,
-			Status:              item.Status,
-			RiskScore:           item.RiskScore,
-			ReportCount:         item.ReportCount,
-			QueuedAt:            item.QueuedAt.UTC().Format(time.RFC3339),
-			AssignedModeratorID: item.AssignedModeratorID,
-		})
+		resp.Data.Items = append(resp.Data.Items, mapQueueItem(item))
 	}
 	return resp, nil
 }
 
+func (h Handler) ClaimHandler(ctx context.Context, moderatorID string, submissionID string) (httptransport.QueueItemResponse, error) {
+	item, err := h.Service.ClaimQueueItem(ctx, moderatorID, submissionID)
+	if err != nil {
+		return httptransport.QueueItemResponse{}, err
+	}
+	return mapQueueItemResponse(item), nil
+}
+
+func (h Handler) ClaimNextHandler(ctx context.Context, moderatorID string) (httptransport.QueueItemResponse, error) {
+	item, err := h.Service.ClaimNextQueueItem(ctx, moderatorID)
+	if err != nil {
+		return httptransport.QueueItemResponse{}, err
+	}
+	return mapQueueItemResponse(item), nil
+}
+
+func (h Handler) ReleaseHandler(ctx context.Context, moderatorID string, submissionID string) (httptransport.QueueItemResponse, error) {
+	item, err := h.Service.ReleaseQueueItem(ctx, moderatorID, submissionID)
+	if err != nil {
+		return httptransport.QueueItemResponse{}, err
+	}
+	return mapQueueItemResponse(item), nil
+}
+
+func (h Handler) UpsertModeratorHandler(ctx context.Context, requesterID string, moderatorID string, req httptransport.UpsertModeratorRequest) (httptransport.ModeratorResponse, error) {
+	moderator, err := h.Service.UpsertModerator(ctx, requesterID, ports.Moderator{
+		ModeratorID:    strings.TrimSpace(moderatorID),
+		Skills:         req.Skills,
+		Senior:         req.Senior,
+		Active:         req.Active,
+		MaxActiveItems: req.MaxActiveItems,
+	})
+	if err != nil {
+		return httptransport.ModeratorResponse{}, err
+	}
+	resp := httptransport.ModeratorResponse{Status: "success", Timestamp: time.Now().UTC().Format(time.RFC3339)}
+	resp.Data.ModeratorID = moderator.ModeratorID
+	resp.Data.Skills = append([]string{}, moderator.Skills...)
+	resp.Data.Senior = moderator.Senior
+	resp.Data.Active = moderator.Active
+	resp.Data.MaxActiveItems = moderator.MaxActiveItems
+	resp.Data.ActiveItems = moderator.ActiveItems
+	resp.Data.LastAssignedAt = formatOptionalTime(moderator.LastAssignedAt)
+	resp.Data.UpdatedAt = moderator.UpdatedAt.UTC().Format(time.RFC3339)
+	return resp, nil
+}
+
+func (h Handler) ModeratorMetricsHandler(ctx context.Context, requesterID string, moderatorID string, windowHoursRaw string) (httptransport.ModeratorMetricsResponse, error) {
+	var window time.Duration
+	if raw := strings.TrimSpace(windowHoursRaw); raw != "" {
+		hours, err := strconv.Atoi(raw)
+		if err != nil || hours <= 0 {
+			return httptransport.ModeratorMetricsResponse{}, domainerrors.ErrInvalidRequest
+		}
+		window = time.Duration(hours) * time.Hour
+	}
+	metrics, err := h.Service.ModeratorMetrics(ctx, requesterID, moderatorID, window)
+	if err != nil {
+		return httptransport.ModeratorMetricsResponse{}, err
+	}
+	resp := httptransport.ModeratorMetricsResponse{Status: "success", Timestamp: time.Now().UTC().Format(time.RFC3339)}
+	resp.Data.ModeratorID = metrics.ModeratorID
+	resp.Data.WindowStart = metrics.WindowStart.UTC().Format(time.RFC3339)
+	resp.Data.WindowEnd = metrics.WindowEnd.UTC().Format(time.RFC3339)
+	resp.Data.Decisions = metrics.Decisions
+	resp.Data.Approved = metrics.Approved
+	resp.Data.Rejected = metrics.Rejected
+	resp.Data.Flagged = metrics.Flagged
+	resp.Data.SLABreaches = metrics.SLABreaches
+	resp.Data.AverageHandleSeconds = metrics.AverageHandleSeconds
+	resp.Data.Reviewed = metrics.Reviewed
+	resp.Data.Overturned = metrics.Overturned
+	resp.Data.Accuracy = metrics.Accuracy
+	return resp, nil
+}
+
 func (h Handler) ApproveHandler(ctx context.Context, idempotencyKey string, moderatorID string, req httptransport.ApproveRequest) (httptransport.DecisionResponse, error) {
 	record, err := h.Service.Approve(ctx, idempotencyKey, moderatorID, ports.ModerationActionInput{
 		SubmissionID: strings.TrimSpace(req.SubmissionID),
@@ -104,6 +169,136 @@ func (h Handler) FlagHandler(ctx context.Context, idempotencyKey string, moderat
 	return mapDecisionResponse(record), nil
 }
 
+func (h Handler) FileAppealHandler(ctx context.Context, idempotencyKey string, creatorID string, decisionID string, req httptransport.FileAppealRequest) (httptransport.AppealResponse, error) {
+	appeal, err := h.Service.FileAppeal(ctx, idempotencyKey, creatorID, decisionID, req.Reason)
+	if err != nil {
+		return httptransport.AppealResponse{}, err
+	}
+	return mapAppealResponse(appeal), nil
+}
+
+func (h Handler) ResolveAppealHandler(ctx context.Context, idempotencyKey string, moderatorID string, appealID string, req httptransport.ResolveAppealRequest) (httptransport.AppealResponse, error) {
+	appeal, err := h.Service.ResolveAppeal(ctx, idempotencyKey, moderatorID, appealID, req.Outcome, req.Notes)
+	if err != nil {
+		return httptransport.AppealResponse{}, err
+	}
+	return mapAppealResponse(appeal), nil
+}
+
+func (h Handler) GetAppealHandler(ctx context.Context, requesterID string, appealID string) (httptransport.AppealResponse, error) {
+	appeal, err := h.Service.GetAppeal(ctx, requesterID, appealID)
+	if err != nil {
+		return httptransport.AppealResponse{}, err
+	}
+	return mapAppealResponse(appeal), nil
+}
+
+func (h Handler) ListAppealsHandler(
+	ctx context.Context,
+	requesterID string,
+	statusRaw string,
+	assignedRaw string,
+	limitRaw string,
+	offsetRaw string,
+) (httptransport.AppealListResponse, error) {
+	filter := ports.AppealFilter{
+		Status:              strings.TrimSpace(statusRaw),
+		AssignedModeratorID: strings.TrimSpace(assignedRaw),
+	}
+	if parsed, err := strconv.Atoi(strings.TrimSpace(limitRaw)); err == nil {
+		filter.Limit = parsed
+	}
+	if parsed, err := strconv.Atoi(strings.TrimSpace(offsetRaw)); err == nil {
+		filter.Offset = parsed
+	}
+	appeals, err := h.Service.ListAppeals(ctx, requesterID, filter)
+	if err != nil {
+		return httptransport.AppealListResponse{}, err
+	}
+	resp := httptransport.AppealListResponse{Status: "success", Timestamp: time.Now().UTC().Format(time.RFC3339)}
+	resp.Data.Items = make([]httptransport.Appeal, 0, len(appeals))
+	for _, appeal := range appeals {
+		resp.Data.Items = append(resp.Data.Items, mapAppeal(appeal))
+	}
+	return resp, nil
+}
+
+func mapAppealResponse(appeal ports.Appeal) httptransport.AppealResponse {
+	return httptransport.AppealResponse{
+		Status:    "success",
+		Data:      mapAppeal(appeal),
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	}
+}
+
+func mapAppeal(appeal ports.Appeal) httptransport.Appeal {
+	out := httptransport.Appeal{
+		AppealID:             appeal.AppealID,
+		DecisionID:           appeal.DecisionID,
+		SubmissionID:         appeal.SubmissionID,
+		CampaignID:           appeal.CampaignID,
+		CreatorID:            appeal.CreatorID,
+		OriginalModeratorID:  appeal.OriginalModeratorID,
+		OriginalAction:       appeal.OriginalAction,
+		OriginalReason:       appeal.OriginalReason,
+		Reason:               appeal.Reason,
+		Status:               appeal.Status,
+		AssignedModeratorID:  appeal.AssignedModeratorID,
+		ResolvedByID:         appeal.ResolvedByID,
+		ResolutionNotes:      appeal.ResolutionNotes,
+		ResolutionDecisionID: appeal.ResolutionDecisionID,
+		CreatedAt:            appeal.CreatedAt.UTC().Format(time.RFC3339),
+		ResolvedAt:           formatOptionalTime(appeal.ResolvedAt),
+	}
+	for _, entry := range appeal.Audit {
+		out.Audit = append(out.Audit, httptransport.AppealAuditEntry{
+			Action:     entry.Action,
+			ActorID:    entry.ActorID,
+			FromStatus: entry.FromStatus,
+			ToStatus:   entry.ToStatus,
+			Notes:      entry.Notes,
+			CreatedAt:  entry.CreatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	return out
+}
+
+func mapQueueItemResponse(item ports.QueueItem) httptransport.QueueItemResponse {
+	return httptransport.QueueItemResponse{
+		Status:    "success",
+		Data:      mapQueueItem(item),
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	}
+}
+
+func mapQueueItem(item ports.QueueItem) httptransport.QueueItem {
+	return httptransport.QueueItem{
+		SubmissionID:        item.SubmissionID,
+		CampaignID:          item.CampaignID,
+		CreatorID:           item.CreatorID,
+		Status:              item.Status,
+		RiskScore:           item.RiskScore,
+		ReportCount:         item.ReportCount,
+		QueuedAt:            item.QueuedAt.UTC().Format(time.RFC3339),
+		AssignedModeratorID: item.AssignedModeratorID,
+		Category:            item.Category,
+		Severity:            item.Severity,
+		SLADueAt:            item.SLADueAt.UTC().Format(time.RFC3339),
+		ClaimedAt:           formatOptionalTime(item.ClaimedAt),
+		LeaseExpiresAt:      formatOptionalTime(item.LeaseExpiresAt),
+		EscalationLevel:     item.EscalationLevel,
+		EscalatedAt:         formatOptionalTime(item.EscalatedAt),
+		SeniorRequired:      item.SeniorRequired,
+	}
+}
+
+func formatOptionalTime(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+	return value.UTC().Format(time.RFC3339)
+}
+
 func mapDecisionResponse(record ports.DecisionRecord) httptransport.DecisionResponse {
 	resp := httptransport.DecisionResponse{Status: "success", Timestamp: time.Now().UTC().Format(time.RFC3339)}
 	resp.Data.DecisionID = record.DecisionID
@@ -115,6 +310,7 @@ func mapDecisionResponse(record ports.DecisionRecord) httptransport.DecisionResp
 	resp.Data.Notes = record.Notes
 	resp.Data.Severity = record.Severity
 	resp.Data.QueueStatus = record.QueueStatus
+	resp.Data.SLABreached = record.SLABreached
 	resp.Data.CreatedAt = record.CreatedAt.UTC().Format(time.RFC3339)
 	return resp
 }
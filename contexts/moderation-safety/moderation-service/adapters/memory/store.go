@@ -3,12 +3,14 @@ package memory
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	domainerrors "solomon/contexts/moderation-safety/moderation-service/domain/errors"
+	"solomon/contexts/moderation-safety/moderation-service/domain/services"
 	"solomon/contexts/moderation-safety/moderation-service/ports"
 )
 
@@ -17,37 +19,80 @@ type Store struct {
 
 	queue       map[string]ports.QueueItem
 	decisions   map[string]ports.DecisionRecord
+	moderators  map[string]ports.Moderator
+	appeals     map[string]ports.Appeal
 	idempotency map[string]ports.IdempotencyRecord
 	sequence    uint64
 }
 
 func NewStore() *Store {
 	now := time.Now().UTC()
-	return &Store{
-		queue: map[string]ports.QueueItem{
-			"sub-1": {
-				SubmissionID: "sub-1",
-				CampaignID:   "camp-1",
-				CreatorID:    "creator-1",
-				Status:       "pending",
-				RiskScore:    0.78,
-				ReportCount:  2,
-				QueuedAt:     now.Add(-2 * time.Hour),
-			},
-			"sub-2": {
-				SubmissionID: "sub-2",
-				CampaignID:   "camp-2",
-				CreatorID:    "creator-2",
-				Status:       "pending",
-				RiskScore:    0.55,
-				ReportCount:  0,
-				QueuedAt:     now.Add(-4 * time.Hour),
-			},
-		},
+	store := &Store{
+		queue:       map[string]ports.QueueItem{},
 		decisions:   map[string]ports.DecisionRecord{},
+		moderators:  map[string]ports.Moderator{},
+		appeals:     map[string]ports.Appeal{},
 		idempotency: map[string]ports.IdempotencyRecord{},
 		sequence:    1,
 	}
+	store.seedQueueItem(ports.QueueItem{
+		SubmissionID: "sub-1",
+		CampaignID:   "camp-1",
+		CreatorID:    "creator-1",
+		Status:       "pending",
+		RiskScore:    0.78,
+		ReportCount:  2,
+		QueuedAt:     now.Add(-2 * time.Hour),
+	})
+	store.seedQueueItem(ports.QueueItem{
+		SubmissionID: "sub-2",
+		CampaignID:   "camp-2",
+		CreatorID:    "creator-2",
+		Status:       "pending",
+		RiskScore:    0.55,
+		ReportCount:  0,
+		QueuedAt:     now.Add(-4 * time.Hour),
+		Category:     "copyright",
+	})
+	store.moderators["mod-1"] = ports.Moderator{
+		ModeratorID:    "mod-1",
+		Skills:         []string{"copyright"},
+		Active:         true,
+		MaxActiveItems: 5,
+		UpdatedAt:      now,
+	}
+	store.moderators["mod-senior-1"] = ports.Moderator{
+		ModeratorID:    "mod-senior-1",
+		Skills:         []string{"copyright"},
+		Senior:         true,
+		Active:         true,
+		MaxActiveItems: 5,
+		UpdatedAt:      now,
+	}
+	return store
+}
+
+// SeedQueueItem adds an item to the review queue, deriving severity and SLA
+// deadline from the risk score when they are not set.
+func (s *Store) SeedQueueItem(item ports.QueueItem) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seedQueueItem(item)
+}
+
+func (s *Store) seedQueueItem(item ports.QueueItem) {
+	if item.QueuedAt.IsZero() {
+		item.QueuedAt = time.Now().UTC()
+	}
+	if severity, ok := services.NormalizeSeverity(item.Severity); ok {
+		item.Severity = severity
+	} else {
+		item.Severity = services.SeverityForRisk(item.RiskScore)
+	}
+	if item.SLADueAt.IsZero() {
+		item.SLADueAt = services.SLADeadline(item.Severity, item.QueuedAt)
+	}
+	s.queue[item.SubmissionID] = item
 }
 
 func (s *Store) ListQueue(ctx context.Context, filter ports.QueueFilter) ([]ports.QueueItem, error) {
@@ -58,8 +103,18 @@ func (s *Store) ListQueue(ctx context.Context, filter ports.QueueFilter) ([]port
 		if filter.Status != "" && !strings.EqualFold(item.Status, filter.Status) {
 			continue
 		}
+		if filter.AssignedModeratorID != "" && item.AssignedModeratorID != filter.AssignedModeratorID {
+			continue
+		}
+		if filter.Severity != "" && !strings.EqualFold(item.Severity, filter.Severity) {
+			continue
+		}
+		if !filter.OverdueAt.IsZero() && (!services.IsOpenStatus(item.Status) || !item.SLADueAt.Before(filter.OverdueAt)) {
+			continue
+		}
 		items = append(items, item)
 	}
+	sortBySLA(items)
 	if filter.Offset >= len(items) {
 		return []ports.QueueItem{}, nil
 	}
@@ -70,15 +125,29 @@ func (s *Store) ListQueue(ctx context.Context, filter ports.QueueFilter) ([]port
 	return append([]ports.QueueItem(nil), items[filter.Offset:end]...), nil
 }
 
+func (s *Store) GetQueueItem(ctx context.Context, submissionID string) (ports.QueueItem, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	item, ok := s.queue[submissionID]
+	if !ok {
+		return ports.QueueItem{}, domainerrors.ErrNotFound
+	}
+	return item, nil
+}
+
 func (s *Store) RecordDecision(ctx context.Context, record ports.DecisionRecord, now time.Time) (ports.DecisionRecord, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	now = now.UTC()
 	item, ok := s.queue[record.SubmissionID]
 	if !ok {
 		return ports.DecisionRecord{}, domainerrors.ErrNotFound
 	}
+	if leaseLive(item, now) && item.AssignedModeratorID != record.ModeratorID {
+		return ports.DecisionRecord{}, domainerrors.ErrQueueItemClaimed
+	}
 	record.DecisionID = s.nextID("decision")
-	record.CreatedAt = now.UTC()
+	record.CreatedAt = now
 	switch record.Action {
 	case "approved":
 		item.Status = "approved"
@@ -86,16 +155,240 @@ func (s *Store) RecordDecision(ctx context.Context, record ports.DecisionRecord,
 		item.Status = "rejected"
 	case "flagged":
 		item.Status = "flagged"
+		// A flag restarts the clock at the flag's severity so the second
+		// look is held to its own deadline.
+		if severity, ok := services.NormalizeSeverity(record.Severity); ok {
+			item.Severity = severity
+		}
+		item.SLADueAt = services.SLADeadline(item.Severity, now)
 	default:
 		return ports.DecisionRecord{}, domainerrors.ErrInvalidRequest
 	}
 	item.AssignedModeratorID = record.ModeratorID
+	item.ClaimedAt = nil
+	item.LeaseExpiresAt = nil
 	s.queue[record.SubmissionID] = item
 	record.QueueStatus = item.Status
 	s.decisions[record.DecisionID] = record
 	return record, nil
 }
 
+func (s *Store) GetDecision(ctx context.Context, decisionID string) (ports.DecisionRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	record, ok := s.decisions[decisionID]
+	if !ok {
+		return ports.DecisionRecord{}, domainerrors.ErrNotFound
+	}
+	return record, nil
+}
+
+func (s *Store) ListDecisions(ctx context.Context, filter ports.DecisionFilter) ([]ports.DecisionRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	records := make([]ports.DecisionRecord, 0, len(s.decisions))
+	for _, record := range s.decisions {
+		if filter.ModeratorID != "" && record.ModeratorID != filter.ModeratorID {
+			continue
+		}
+		if !filter.Since.IsZero() && record.CreatedAt.Before(filter.Since) {
+			continue
+		}
+		records = append(records, record)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if !records[i].CreatedAt.Equal(records[j].CreatedAt) {
+			return records[i].CreatedAt.Before(records[j].CreatedAt)
+		}
+		return records[i].DecisionID < records[j].DecisionID
+	})
+	return records, nil
+}
+
+func (s *Store) ClaimQueueItem(ctx context.Context, claim ports.QueueClaim, now time.Time) (ports.QueueItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now = now.UTC()
+	item, ok := s.queue[claim.SubmissionID]
+	if !ok {
+		return ports.QueueItem{}, domainerrors.ErrNotFound
+	}
+	if !services.IsOpenStatus(item.Status) {
+		return ports.QueueItem{}, domainerrors.ErrQueueItemClosed
+	}
+	renewing := leaseLive(item, now) && item.AssignedModeratorID == claim.ModeratorID
+	if leaseLive(item, now) && !renewing {
+		return ports.QueueItem{}, domainerrors.ErrQueueItemClaimed
+	}
+	if !renewing {
+		claimedAt := now
+		item.ClaimedAt = &claimedAt
+		if moderator, ok := s.moderators[claim.ModeratorID]; ok {
+			assignedAt := now
+			moderator.LastAssignedAt = &assignedAt
+			s.moderators[claim.ModeratorID] = moderator
+		}
+	}
+	leaseExpiresAt := claim.LeaseExpiresAt.UTC()
+	item.AssignedModeratorID = claim.ModeratorID
+	item.LeaseExpiresAt = &leaseExpiresAt
+	s.queue[claim.SubmissionID] = item
+	return item, nil
+}
+
+func (s *Store) ReleaseQueueItem(ctx context.Context, submissionID string, moderatorID string, now time.Time) (ports.QueueItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item, ok := s.queue[submissionID]
+	if !ok {
+		return ports.QueueItem{}, domainerrors.ErrNotFound
+	}
+	if item.LeaseExpiresAt == nil || item.AssignedModeratorID != moderatorID {
+		return ports.QueueItem{}, domainerrors.ErrLeaseNotHeld
+	}
+	item.AssignedModeratorID = ""
+	item.ClaimedAt = nil
+	item.LeaseExpiresAt = nil
+	s.queue[submissionID] = item
+	return item, nil
+}
+
+func (s *Store) ListUnassignedQueue(ctx context.Context, now time.Time, limit int) ([]ports.QueueItem, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	items := make([]ports.QueueItem, 0)
+	for _, item := range s.queue {
+		if !services.IsOpenStatus(item.Status) || leaseLive(item, now) {
+			continue
+		}
+		items = append(items, item)
+	}
+	sortBySLA(items)
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+func (s *Store) ListEscalationCandidates(ctx context.Context, now time.Time, limit int) ([]ports.QueueItem, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	items := make([]ports.QueueItem, 0)
+	for _, item := range s.queue {
+		if !services.IsOpenStatus(item.Status) || !item.SLADueAt.Before(now) {
+			continue
+		}
+		if item.EscalationLevel >= services.MaxEscalationLevel {
+			continue
+		}
+		items = append(items, item)
+	}
+	sortBySLA(items)
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+func (s *Store) EscalateQueueItem(ctx context.Context, escalation ports.QueueEscalation, now time.Time) (ports.QueueItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now = now.UTC()
+	item, ok := s.queue[escalation.SubmissionID]
+	if !ok {
+		return ports.QueueItem{}, domainerrors.ErrNotFound
+	}
+	if !services.IsOpenStatus(item.Status) {
+		return ports.QueueItem{}, domainerrors.ErrQueueItemClosed
+	}
+	if item.EscalationLevel != escalation.Level-1 {
+		return ports.QueueItem{}, domainerrors.ErrQueueItemChanged
+	}
+	escalatedAt := now
+	item.Status = "escalated"
+	item.EscalationLevel = escalation.Level
+	item.EscalatedAt = &escalatedAt
+	item.SeniorRequired = true
+	item.Severity = escalation.Severity
+	item.SLADueAt = escalation.SLADueAt.UTC()
+	item.AssignedModeratorID = escalation.ModeratorID
+	item.ClaimedAt = nil
+	item.LeaseExpiresAt = nil
+	if escalation.ModeratorID != "" && escalation.LeaseExpiresAt != nil {
+		claimedAt := now
+		leaseExpiresAt := escalation.LeaseExpiresAt.UTC()
+		item.ClaimedAt = &claimedAt
+		item.LeaseExpiresAt = &leaseExpiresAt
+		if moderator, ok := s.moderators[escalation.ModeratorID]; ok {
+			assignedAt := now
+			moderator.LastAssignedAt = &assignedAt
+			s.moderators[escalation.ModeratorID] = moderator
+		}
+	}
+	s.queue[escalation.SubmissionID] = item
+	return item, nil
+}
+
+func (s *Store) ListModerators(ctx context.Context, now time.Time) ([]ports.Moderator, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	moderators := make([]ports.Moderator, 0, len(s.moderators))
+	for _, moderator := range s.moderators {
+		moderators = append(moderators, s.withLoad(moderator, now))
+	}
+	sort.Slice(moderators, func(i, j int) bool {
+		return moderators[i].ModeratorID < moderators[j].ModeratorID
+	})
+	return moderators, nil
+}
+
+func (s *Store) GetModerator(ctx context.Context, moderatorID string, now time.Time) (ports.Moderator, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	moderator, ok := s.moderators[moderatorID]
+	if !ok {
+		return ports.Moderator{}, domainerrors.ErrNotFound
+	}
+	return s.withLoad(moderator, now), nil
+}
+
+func (s *Store) UpsertModerator(ctx context.Context, moderator ports.Moderator, now time.Time) (ports.Moderator, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.moderators[moderator.ModeratorID]; ok {
+		moderator.LastAssignedAt = existing.LastAssignedAt
+	}
+	moderator.Skills = append([]string(nil), moderator.Skills...)
+	moderator.UpdatedAt = now.UTC()
+	s.moderators[moderator.ModeratorID] = moderator
+	return s.withLoad(moderator, now), nil
+}
+
+// withLoad fills ActiveItems from live leases; callers hold s.mu.
+func (s *Store) withLoad(moderator ports.Moderator, now time.Time) ports.Moderator {
+	moderator.ActiveItems = 0
+	for _, item := range s.queue {
+		if item.AssignedModeratorID == moderator.ModeratorID && services.IsOpenStatus(item.Status) && leaseLive(item, now) {
+			moderator.ActiveItems++
+		}
+	}
+	moderator.Skills = append([]string(nil), moderator.Skills...)
+	return moderator
+}
+
+func leaseLive(item ports.QueueItem, now time.Time) bool {
+	return item.LeaseExpiresAt != nil && now.Before(*item.LeaseExpiresAt)
+}
+
+func sortBySLA(items []ports.QueueItem) {
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].SLADueAt.Equal(items[j].SLADueAt) {
+			return items[i].SLADueAt.Before(items[j].SLADueAt)
+		}
+		return items[i].SubmissionID < items[j].SubmissionID
+	})
+}
+
 func (s *Store) ApproveSubmission(ctx context.Context, submissionID string, moderatorID string, reason string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -122,6 +415,172 @@ func (s *Store) RejectSubmission(ctx context.Context, submissionID string, moder
 	return nil
 }
 
+func (s *Store) DisputeSubmission(ctx context.Context, submissionID string, creatorID string, appealID string, reason string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item, ok := s.queue[submissionID]
+	if !ok {
+		return domainerrors.ErrNotFound
+	}
+	if item.Status != "rejected" {
+		return domainerrors.ErrAppealNotAllowed
+	}
+	item.Status = "disputed"
+	s.queue[submissionID] = item
+	return nil
+}
+
+func (s *Store) ResolveDispute(ctx context.Context, submissionID string, moderatorID string, appealID string, reversed bool, notes string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item, ok := s.queue[submissionID]
+	if !ok {
+		return domainerrors.ErrNotFound
+	}
+	if item.Status != "disputed" {
+		return domainerrors.ErrAppealClosed
+	}
+	item.Status = "rejected"
+	if reversed {
+		item.Status = "approved"
+	}
+	item.AssignedModeratorID = moderatorID
+	s.queue[submissionID] = item
+	return nil
+}
+
+func (s *Store) CreateAppeal(ctx context.Context, appeal ports.Appeal, now time.Time) (ports.Appeal, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now = now.UTC()
+	for _, existing := range s.appeals {
+		if existing.DecisionID == appeal.DecisionID {
+			return ports.Appeal{}, domainerrors.ErrAppealExists
+		}
+	}
+	item, ok := s.queue[appeal.SubmissionID]
+	if !ok {
+		return ports.Appeal{}, domainerrors.ErrNotFound
+	}
+	item.Status = "disputed"
+	s.queue[appeal.SubmissionID] = item
+
+	appeal.CreatedAt = now
+	appeal.Audit = []ports.AppealAuditEntry{{
+		EntryID:   s.nextID("appeal-audit"),
+		AppealID:  appeal.AppealID,
+		Action:    "filed",
+		ActorID:   appeal.CreatorID,
+		ToStatus:  appeal.Status,
+		Notes:     appeal.Reason,
+		CreatedAt: now,
+	}}
+	if appeal.AssignedModeratorID != "" {
+		appeal.Audit = append(appeal.Audit, ports.AppealAuditEntry{
+			EntryID:    s.nextID("appeal-audit"),
+			AppealID:   appeal.AppealID,
+			Action:     "assigned",
+			ActorID:    appeal.AssignedModeratorID,
+			FromStatus: appeal.Status,
+			ToStatus:   appeal.Status,
+			CreatedAt:  now,
+		})
+	}
+	s.appeals[appeal.AppealID] = appeal
+	return copyAppeal(appeal), nil
+}
+
+func (s *Store) GetAppeal(ctx context.Context, appealID string) (ports.Appeal, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	appeal, ok := s.appeals[appealID]
+	if !ok {
+		return ports.Appeal{}, domainerrors.ErrNotFound
+	}
+	return copyAppeal(appeal), nil
+}
+
+func (s *Store) ListAppeals(ctx context.Context, filter ports.AppealFilter) ([]ports.Appeal, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	appeals := make([]ports.Appeal, 0, len(s.appeals))
+	for _, appeal := range s.appeals {
+		if filter.Status != "" && appeal.Status != filter.Status {
+			continue
+		}
+		if filter.AssignedModeratorID != "" && appeal.AssignedModeratorID != filter.AssignedModeratorID {
+			continue
+		}
+		appeals = append(appeals, copyAppeal(appeal))
+	}
+	sort.Slice(appeals, func(i, j int) bool {
+		if !appeals[i].CreatedAt.Equal(appeals[j].CreatedAt) {
+			return appeals[i].CreatedAt.Before(appeals[j].CreatedAt)
+		}
+		return appeals[i].AppealID < appeals[j].AppealID
+	})
+	if filter.Offset >= len(appeals) {
+		return []ports.Appeal{}, nil
+	}
+	end := filter.Offset + filter.Limit
+	if end > len(appeals) {
+		end = len(appeals)
+	}
+	return appeals[filter.Offset:end], nil
+}
+
+func (s *Store) ResolveAppeal(ctx context.Context, resolution ports.AppealResolution, now time.Time) (ports.Appeal, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now = now.UTC()
+	appeal, ok := s.appeals[resolution.AppealID]
+	if !ok {
+		return ports.Appeal{}, domainerrors.ErrNotFound
+	}
+	if appeal.Status != ports.AppealStatusOpen {
+		return ports.Appeal{}, domainerrors.ErrAppealClosed
+	}
+	item, ok := s.queue[appeal.SubmissionID]
+	if !ok {
+		return ports.Appeal{}, domainerrors.ErrNotFound
+	}
+
+	decision := resolution.Decision
+	decision.DecisionID = s.nextID("decision")
+	decision.CreatedAt = now
+	item.Status = decision.Action
+	item.AssignedModeratorID = decision.ModeratorID
+	item.ClaimedAt = nil
+	item.LeaseExpiresAt = nil
+	decision.QueueStatus = item.Status
+	s.queue[appeal.SubmissionID] = item
+	s.decisions[decision.DecisionID] = decision
+
+	resolvedAt := now
+	appeal.Audit = append(appeal.Audit, ports.AppealAuditEntry{
+		EntryID:    s.nextID("appeal-audit"),
+		AppealID:   appeal.AppealID,
+		Action:     "resolved",
+		ActorID:    resolution.ModeratorID,
+		FromStatus: appeal.Status,
+		ToStatus:   resolution.Status,
+		Notes:      resolution.Notes,
+		CreatedAt:  now,
+	})
+	appeal.Status = resolution.Status
+	appeal.ResolvedByID = resolution.ModeratorID
+	appeal.ResolutionNotes = resolution.Notes
+	appeal.ResolutionDecisionID = decision.DecisionID
+	appeal.ResolvedAt = &resolvedAt
+	s.appeals[appeal.AppealID] = appeal
+	return copyAppeal(appeal), nil
+}
+
+func copyAppeal(appeal ports.Appeal) ports.Appeal {
+	appeal.Audit = append([]ports.AppealAuditEntry(nil), appeal.Audit...)
+	return appeal
+}
+
 func (s *Store) Get(ctx context.Context, key string, now time.Time) (ports.IdempotencyRecord, bool, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
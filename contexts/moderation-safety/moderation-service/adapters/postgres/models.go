@@ -0,0 +1,282 @@
+package postgresadapter
+
+import (
+	"time"
+
+	"solomon/contexts/moderation-safety/moderation-service/ports"
+)
+
+type queueItemModel struct {
+	SubmissionID        string     `gorm:"column:submission_id;primaryKey"`
+	CampaignID          string     `gorm:"column:campaign_id"`
+	CreatorID           string     `gorm:"column:creator_id"`
+	Status              string     `gorm:"column:status"`
+	RiskScore           float64    `gorm:"column:risk_score"`
+	ReportCount         int        `gorm:"column:report_count"`
+	QueuedAt            time.Time  `gorm:"column:queued_at"`
+	AssignedModeratorID string     `gorm:"column:assigned_moderator_id"`
+	Category            string     `gorm:"column:category"`
+	Severity            string     `gorm:"column:severity"`
+	SLADueAt            time.Time  `gorm:"column:sla_due_at"`
+	ClaimedAt           *time.Time `gorm:"column:claimed_at"`
+	LeaseExpiresAt      *time.Time `gorm:"column:lease_expires_at"`
+	EscalationLevel     int        `gorm:"column:escalation_level"`
+	EscalatedAt         *time.Time `gorm:"column:escalated_at"`
+	SeniorRequired      bool       `gorm:"column:senior_required"`
+	UpdatedAt           time.Time  `gorm:"column:updated_at"`
+}
+
+func (queueItemModel) TableName() string {
+	return "moderation_queue"
+}
+
+func (m queueItemModel) toPort() ports.QueueItem {
+	return ports.QueueItem{
+		SubmissionID:        m.SubmissionID,
+		CampaignID:          m.CampaignID,
+		CreatorID:           m.CreatorID,
+		Status:              m.Status,
+		RiskScore:           m.RiskScore,
+		ReportCount:         m.ReportCount,
+		QueuedAt:            m.QueuedAt.UTC(),
+		AssignedModeratorID: m.AssignedModeratorID,
+		Category:            m.Category,
+		Severity:            m.Severity,
+		SLADueAt:            m.SLADueAt.UTC(),
+		ClaimedAt:           utcPtr(m.ClaimedAt),
+		LeaseExpiresAt:      utcPtr(m.LeaseExpiresAt),
+		EscalationLevel:     m.EscalationLevel,
+		EscalatedAt:         utcPtr(m.EscalatedAt),
+		SeniorRequired:      m.SeniorRequired,
+	}
+}
+
+func queueItemsToPorts(rows []queueItemModel) []ports.QueueItem {
+	out := make([]ports.QueueItem, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, row.toPort())
+	}
+	return out
+}
+
+type decisionModel struct {
+	DecisionID   string     `gorm:"column:decision_id;primaryKey"`
+	SubmissionID string     `gorm:"column:submission_id"`
+	CampaignID   string     `gorm:"column:campaign_id"`
+	ModeratorID  string     `gorm:"column:moderator_id"`
+	Action       string     `gorm:"column:action"`
+	Reason       string     `gorm:"column:reason"`
+	Notes        string     `gorm:"column:notes"`
+	Severity     string     `gorm:"column:severity"`
+	QueueStatus  string     `gorm:"column:queue_status"`
+	ClaimedAt    *time.Time `gorm:"column:claimed_at"`
+	SLADueAt     *time.Time `gorm:"column:sla_due_at"`
+	SLABreached  bool       `gorm:"column:sla_breached"`
+	CreatedAt    time.Time  `gorm:"column:created_at"`
+}
+
+func (decisionModel) TableName() string {
+	return "moderation_decisions"
+}
+
+func decisionModelFromPort(record ports.DecisionRecord) decisionModel {
+	row := decisionModel{
+		DecisionID:   record.DecisionID,
+		SubmissionID: record.SubmissionID,
+		CampaignID:   record.CampaignID,
+		ModeratorID:  record.ModeratorID,
+		Action:       record.Action,
+		Reason:       record.Reason,
+		Notes:        record.Notes,
+		Severity:     record.Severity,
+		QueueStatus:  record.QueueStatus,
+		ClaimedAt:    utcPtr(record.ClaimedAt),
+		SLABreached:  record.SLABreached,
+		CreatedAt:    record.CreatedAt.UTC(),
+	}
+	if !record.SLADueAt.IsZero() {
+		slaDueAt := record.SLADueAt.UTC()
+		row.SLADueAt = &slaDueAt
+	}
+	return row
+}
+
+func (m decisionModel) toPort() ports.DecisionRecord {
+	record := ports.DecisionRecord{
+		DecisionID:   m.DecisionID,
+		SubmissionID: m.SubmissionID,
+		CampaignID:   m.CampaignID,
+		ModeratorID:  m.ModeratorID,
+		Action:       m.Action,
+		Reason:       m.Reason,
+		Notes:        m.Notes,
+		Severity:     m.Severity,
+		CreatedAt:    m.CreatedAt.UTC(),
+		QueueStatus:  m.QueueStatus,
+		ClaimedAt:    utcPtr(m.ClaimedAt),
+		SLABreached:  m.SLABreached,
+	}
+	if m.SLADueAt != nil {
+		record.SLADueAt = m.SLADueAt.UTC()
+	}
+	return record
+}
+
+type moderatorModel struct {
+	ModeratorID    string     `gorm:"column:moderator_id;primaryKey"`
+	Skills         []string   `gorm:"column:skills;type:text[]"`
+	Senior         bool       `gorm:"column:senior"`
+	Active         bool       `gorm:"column:active"`
+	MaxActiveItems int        `gorm:"column:max_active_items"`
+	LastAssignedAt *time.Time `gorm:"column:last_assigned_at"`
+	UpdatedAt      time.Time  `gorm:"column:updated_at"`
+}
+
+func (moderatorModel) TableName() string {
+	return "moderation_moderators"
+}
+
+func (m moderatorModel) toPort() ports.Moderator {
+	return ports.Moderator{
+		ModeratorID:    m.ModeratorID,
+		Skills:         append([]string(nil), m.Skills...),
+		Senior:         m.Senior,
+		Active:         m.Active,
+		MaxActiveItems: m.MaxActiveItems,
+		LastAssignedAt: utcPtr(m.LastAssignedAt),
+		UpdatedAt:      m.UpdatedAt.UTC(),
+	}
+}
+
+type appealModel struct {
+	AppealID             string     `gorm:"column:appeal_id;primaryKey"`
+	DecisionID           string     `gorm:"column:decision_id"`
+	SubmissionID         string     `gorm:"column:submission_id"`
+	CampaignID           string     `gorm:"column:campaign_id"`
+	CreatorID            string     `gorm:"column:creator_id"`
+	OriginalModeratorID  string     `gorm:"column:original_moderator_id"`
+	OriginalAction       string     `gorm:"column:original_action"`
+	OriginalReason       string     `gorm:"column:original_reason"`
+	Reason               string     `gorm:"column:reason"`
+	Status               string     `gorm:"column:status"`
+	AssignedModeratorID  string     `gorm:"column:assigned_moderator_id"`
+	ResolvedByID         string     `gorm:"column:resolved_by_id"`
+	ResolutionNotes      string     `gorm:"column:resolution_notes"`
+	ResolutionDecisionID *string    `gorm:"column:resolution_decision_id"`
+	CreatedAt            time.Time  `gorm:"column:created_at"`
+	ResolvedAt           *time.Time `gorm:"column:resolved_at"`
+}
+
+func (appealModel) TableName() string {
+	return "moderation_appeals"
+}
+
+func appealModelFromPort(appeal ports.Appeal) appealModel {
+	row := appealModel{
+		AppealID:            appeal.AppealID,
+		DecisionID:          appeal.DecisionID,
+		SubmissionID:        appeal.SubmissionID,
+		CampaignID:          appeal.CampaignID,
+		CreatorID:           appeal.CreatorID,
+		OriginalModeratorID: appeal.OriginalModeratorID,
+		OriginalAction:      appeal.OriginalAction,
+		OriginalReason:      appeal.OriginalReason,
+		Reason:              appeal.Reason,
+		Status:              appeal.Status,
+		AssignedModeratorID: appeal.AssignedModeratorID,
+		ResolvedByID:        appeal.ResolvedByID,
+		ResolutionNotes:     appeal.ResolutionNotes,
+		CreatedAt:           appeal.CreatedAt.UTC(),
+		ResolvedAt:          utcPtr(appeal.ResolvedAt),
+	}
+	if appeal.ResolutionDecisionID != "" {
+		decisionID := appeal.ResolutionDecisionID
+		row.ResolutionDecisionID = &decisionID
+	}
+	return row
+}
+
+func (m appealModel) toPort() ports.Appeal {
+	appeal := ports.Appeal{
+		AppealID:            m.AppealID,
+		DecisionID:          m.DecisionID,
+		SubmissionID:        m.SubmissionID,
+		CampaignID:          m.CampaignID,
+		CreatorID:           m.CreatorID,
+		OriginalModeratorID: m.OriginalModeratorID,
+		OriginalAction:      m.OriginalAction,
+		OriginalReason:      m.OriginalReason,
+		Reason:              m.Reason,
+		Status:              m.Status,
+		AssignedModeratorID: m.AssignedModeratorID,
+		ResolvedByID:        m.ResolvedByID,
+		ResolutionNotes:     m.ResolutionNotes,
+		CreatedAt:           m.CreatedAt.UTC(),
+		ResolvedAt:          utcPtr(m.ResolvedAt),
+	}
+	if m.ResolutionDecisionID != nil {
+		appeal.ResolutionDecisionID = *m.ResolutionDecisionID
+	}
+	return appeal
+}
+
+type auditModel struct {
+	EntryID    string    `gorm:"column:entry_id;primaryKey"`
+	AppealID   string    `gorm:"column:appeal_id"`
+	Action     string    `gorm:"column:action"`
+	ActorID    string    `gorm:"column:actor_id"`
+	FromStatus string    `gorm:"column:from_status"`
+	ToStatus   string    `gorm:"column:to_status"`
+	Notes      string    `gorm:"column:notes"`
+	CreatedAt  time.Time `gorm:"column:created_at"`
+}
+
+func (auditModel) TableName() string {
+	return "moderation_audit_log"
+}
+
+func auditModelFromPort(entry ports.AppealAuditEntry) auditModel {
+	return auditModel{
+		EntryID:    entry.EntryID,
+		AppealID:   entry.AppealID,
+		Action:     entry.Action,
+		ActorID:    entry.ActorID,
+		FromStatus: entry.FromStatus,
+		ToStatus:   entry.ToStatus,
+		Notes:      entry.Notes,
+		CreatedAt:  entry.CreatedAt.UTC(),
+	}
+}
+
+func (m auditModel) toPort() ports.AppealAuditEntry {
+	return ports.AppealAuditEntry{
+		EntryID:    m.EntryID,
+		AppealID:   m.AppealID,
+		Action:     m.Action,
+		ActorID:    m.ActorID,
+		FromStatus: m.FromStatus,
+		ToStatus:   m.ToStatus,
+		Notes:      m.Notes,
+		CreatedAt:  m.CreatedAt.UTC(),
+	}
+}
+
+type idempotencyModel struct {
+	Key         string    `gorm:"column:key;primaryKey"`
+	RequestHash string    `gorm:"column:request_hash"`
+	Payload     []byte    `gorm:"column:payload"`
+	ExpiresAt   time.Time `gorm:"column:expires_at"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+}
+
+func (idempotencyModel) TableName() string {
+	return "moderation_idempotency"
+}
+
+func utcPtr(value *time.Time) *time.Time {
+	if value == nil {
+		return nil
+	}
+	utc := value.UTC()
+	return &utc
+}
@@ -0,0 +1,629 @@
+package postgresadapter
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	domainerrors "solomon/contexts/moderation-safety/moderation-service/domain/errors"
+	"solomon/contexts/moderation-safety/moderation-service/domain/services"
+	"solomon/contexts/moderation-safety/moderation-service/ports"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var openStatuses = []string{"pending", "flagged", "escalated"}
+
+type Repository struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+// NewRepository builds the GORM-backed moderation repository adapter.
+func NewRepository(db *gorm.DB, logger *slog.Logger) *Repository {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Repository{db: db, logger: logger}
+}
+
+func (r *Repository) ListQueue(ctx context.Context, filter ports.QueueFilter) ([]ports.QueueItem, error) {
+	query := r.db.WithContext(ctx).Model(&queueItemModel{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.AssignedModeratorID != "" {
+		query = query.Where("assigned_moderator_id = ?", filter.AssignedModeratorID)
+	}
+	if filter.Severity != "" {
+		query = query.Where("severity = ?", filter.Severity)
+	}
+	if !filter.OverdueAt.IsZero() {
+		query = query.Where("status IN ? AND sla_due_at < ?", openStatuses, filter.OverdueAt.UTC())
+	}
+	var rows []queueItemModel
+	if err := query.
+		Order("sla_due_at ASC, submission_id ASC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return queueItemsToPorts(rows), nil
+}
+
+func (r *Repository) GetQueueItem(ctx context.Context, submissionID string) (ports.QueueItem, error) {
+	var row queueItemModel
+	if err := r.db.WithContext(ctx).Where("submission_id = ?", submissionID).First(&row).Error; err != nil {
+		return ports.QueueItem{}, mapNotFound(err)
+	}
+	return row.toPort(), nil
+}
+
+// RecordDecision appends the decision and moves the queue item in one
+// transaction, re-checking the lease under a row lock.
+func (r *Repository) RecordDecision(ctx context.Context, record ports.DecisionRecord, now time.Time) (ports.DecisionRecord, error) {
+	now = now.UTC()
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		item, err := lockQueueItem(tx, record.SubmissionID)
+		if err != nil {
+			return err
+		}
+		if leaseLive(item, now) && item.AssignedModeratorID != record.ModeratorID {
+			return domainerrors.ErrQueueItemClaimed
+		}
+		updates := map[string]any{
+			"assigned_moderator_id": record.ModeratorID,
+			"claimed_at":            nil,
+			"lease_expires_at":      nil,
+			"updated_at":            now,
+		}
+		switch record.Action {
+		case "approved", "rejected":
+			updates["status"] = record.Action
+		case "flagged":
+			updates["status"] = "flagged"
+			severity := item.Severity
+			if normalized, ok := services.NormalizeSeverity(record.Severity); ok {
+				severity = normalized
+			}
+			updates["severity"] = severity
+			updates["sla_due_at"] = services.SLADeadline(severity, now)
+		default:
+			return domainerrors.ErrInvalidRequest
+		}
+		if err := tx.Model(&queueItemModel{}).
+			Where("submission_id = ?", item.SubmissionID).
+			Updates(updates).Error; err != nil {
+			return err
+		}
+		record.DecisionID = uuid.NewString()
+		record.CreatedAt = now
+		record.QueueStatus = updates["status"].(string)
+		row := decisionModelFromPort(record)
+		return tx.Create(&row).Error
+	})
+	if err != nil {
+		return ports.DecisionRecord{}, err
+	}
+	return record, nil
+}
+
+func (r *Repository) GetDecision(ctx context.Context, decisionID string) (ports.DecisionRecord, error) {
+	var row decisionModel
+	if err := r.db.WithContext(ctx).Where("decision_id = ?", decisionID).First(&row).Error; err != nil {
+		return ports.DecisionRecord{}, mapNotFound(err)
+	}
+	return row.toPort(), nil
+}
+
+func (r *Repository) ListDecisions(ctx context.Context, filter ports.DecisionFilter) ([]ports.DecisionRecord, error) {
+	query := r.db.WithContext(ctx).Model(&decisionModel{})
+	if filter.ModeratorID != "" {
+		query = query.Where("moderator_id = ?", filter.ModeratorID)
+	}
+	if !filter.Since.IsZero() {
+		query = query.Where("created_at >= ?", filter.Since.UTC())
+	}
+	var rows []decisionModel
+	if err := query.Order("created_at ASC, decision_id ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]ports.DecisionRecord, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, row.toPort())
+	}
+	return out, nil
+}
+
+func (r *Repository) ClaimQueueItem(ctx context.Context, claim ports.QueueClaim, now time.Time) (ports.QueueItem, error) {
+	now = now.UTC()
+	var out ports.QueueItem
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		item, err := lockQueueItem(tx, claim.SubmissionID)
+		if err != nil {
+			return err
+		}
+		if !services.IsOpenStatus(item.Status) {
+			return domainerrors.ErrQueueItemClosed
+		}
+		renewing := leaseLive(item, now) && item.AssignedModeratorID == claim.ModeratorID
+		if leaseLive(item, now) && !renewing {
+			return domainerrors.ErrQueueItemClaimed
+		}
+		leaseExpiresAt := claim.LeaseExpiresAt.UTC()
+		updates := map[string]any{
+			"assigned_moderator_id": claim.ModeratorID,
+			"lease_expires_at":      leaseExpiresAt,
+			"updated_at":            now,
+		}
+		item.AssignedModeratorID = claim.ModeratorID
+		item.LeaseExpiresAt = &leaseExpiresAt
+		if !renewing {
+			claimedAt := now
+			updates["claimed_at"] = claimedAt
+			item.ClaimedAt = &claimedAt
+			if err := tx.Model(&moderatorModel{}).
+				Where("moderator_id = ?", claim.ModeratorID).
+				Update("last_assigned_at", now).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Model(&queueItemModel{}).
+			Where("submission_id = ?", item.SubmissionID).
+			Updates(updates).Error; err != nil {
+			return err
+		}
+		out = item.toPort()
+		return nil
+	})
+	return out, err
+}
+
+func (r *Repository) ReleaseQueueItem(ctx context.Context, submissionID string, moderatorID string, now time.Time) (ports.QueueItem, error) {
+	var out ports.QueueItem
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		item, err := lockQueueItem(tx, submissionID)
+		if err != nil {
+			return err
+		}
+		if item.LeaseExpiresAt == nil || item.AssignedModeratorID != moderatorID {
+			return domainerrors.ErrLeaseNotHeld
+		}
+		if err := tx.Model(&queueItemModel{}).
+			Where("submission_id = ?", item.SubmissionID).
+			Updates(map[string]any{
+				"assigned_moderator_id": "",
+				"claimed_at":            nil,
+				"lease_expires_at":      nil,
+				"updated_at":            now.UTC(),
+			}).Error; err != nil {
+			return err
+		}
+		item.AssignedModeratorID = ""
+		item.ClaimedAt = nil
+		item.LeaseExpiresAt = nil
+		out = item.toPort()
+		return nil
+	})
+	return out, err
+}
+
+func (r *Repository) ListUnassignedQueue(ctx context.Context, now time.Time, limit int) ([]ports.QueueItem, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	var rows []queueItemModel
+	if err := r.db.WithContext(ctx).
+		Where("status IN ? AND (lease_expires_at IS NULL OR lease_expires_at <= ?)", openStatuses, now.UTC()).
+		Order("sla_due_at ASC, submission_id ASC").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return queueItemsToPorts(rows), nil
+}
+
+func (r *Repository) ListEscalationCandidates(ctx context.Context, now time.Time, limit int) ([]ports.QueueItem, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	var rows []queueItemModel
+	if err := r.db.WithContext(ctx).
+		Where("status IN ? AND sla_due_at < ? AND escalation_level < ?", openStatuses, now.UTC(), services.MaxEscalationLevel).
+		Order("sla_due_at ASC, submission_id ASC").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return queueItemsToPorts(rows), nil
+}
+
+func (r *Repository) EscalateQueueItem(ctx context.Context, escalation ports.QueueEscalation, now time.Time) (ports.QueueItem, error) {
+	now = now.UTC()
+	var out ports.QueueItem
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		item, err := lockQueueItem(tx, escalation.SubmissionID)
+		if err != nil {
+			return err
+		}
+		if !services.IsOpenStatus(item.Status) {
+			return domainerrors.ErrQueueItemClosed
+		}
+		if item.EscalationLevel != escalation.Level-1 {
+			return domainerrors.ErrQueueItemChanged
+		}
+		escalatedAt := now
+		item.Status = "escalated"
+		item.EscalationLevel = escalation.Level
+		item.EscalatedAt = &escalatedAt
+		item.SeniorRequired = true
+		item.Severity = escalation.Severity
+		item.SLADueAt = escalation.SLADueAt.UTC()
+		item.AssignedModeratorID = escalation.ModeratorID
+		item.ClaimedAt = nil
+		item.LeaseExpiresAt = nil
+		if escalation.ModeratorID != "" && escalation.LeaseExpiresAt != nil {
+			claimedAt := now
+			leaseExpiresAt := escalation.LeaseExpiresAt.UTC()
+			item.ClaimedAt = &claimedAt
+			item.LeaseExpiresAt = &leaseExpiresAt
+			if err := tx.Model(&moderatorModel{}).
+				Where("moderator_id = ?", escalation.ModeratorID).
+				Update("last_assigned_at", now).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Model(&queueItemModel{}).
+			Where("submission_id = ?", item.SubmissionID).
+			Updates(map[string]any{
+				"status":                item.Status,
+				"escalation_level":      item.EscalationLevel,
+				"escalated_at":          item.EscalatedAt,
+				"senior_required":       true,
+				"severity":              item.Severity,
+				"sla_due_at":            item.SLADueAt,
+				"assigned_moderator_id": item.AssignedModeratorID,
+				"claimed_at":            item.ClaimedAt,
+				"lease_expires_at":      item.LeaseExpiresAt,
+				"updated_at":            now,
+			}).Error; err != nil {
+			return err
+		}
+		out = item.toPort()
+		return nil
+	})
+	return out, err
+}
+
+func (r *Repository) ListModerators(ctx context.Context, now time.Time) ([]ports.Moderator, error) {
+	var rows []moderatorModel
+	if err := r.db.WithContext(ctx).Order("moderator_id ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	load, err := r.activeLoad(ctx, now, "")
+	if err != nil {
+		return nil, err
+	}
+	out := make([]ports.Moderator, 0, len(rows))
+	for _, row := range rows {
+		moderator := row.toPort()
+		moderator.ActiveItems = load[row.ModeratorID]
+		out = append(out, moderator)
+	}
+	return out, nil
+}
+
+func (r *Repository) GetModerator(ctx context.Context, moderatorID string, now time.Time) (ports.Moderator, error) {
+	var row moderatorModel
+	if err := r.db.WithContext(ctx).Where("moderator_id = ?", moderatorID).First(&row).Error; err != nil {
+		return ports.Moderator{}, mapNotFound(err)
+	}
+	load, err := r.activeLoad(ctx, now, moderatorID)
+	if err != nil {
+		return ports.Moderator{}, err
+	}
+	moderator := row.toPort()
+	moderator.ActiveItems = load[moderatorID]
+	return moderator, nil
+}
+
+func (r *Repository) UpsertModerator(ctx context.Context, moderator ports.Moderator, now time.Time) (ports.Moderator, error) {
+	row := moderatorModel{
+		ModeratorID:    moderator.ModeratorID,
+		Skills:         append([]string{}, moderator.Skills...),
+		Senior:         moderator.Senior,
+		Active:         moderator.Active,
+		MaxActiveItems: moderator.MaxActiveItems,
+		UpdatedAt:      now.UTC(),
+	}
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "moderator_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"skills", "senior", "active", "max_active_items", "updated_at"}),
+		}).
+		Create(&row).Error; err != nil {
+		return ports.Moderator{}, err
+	}
+	return r.GetModerator(ctx, moderator.ModeratorID, now)
+}
+
+// CreateAppeal stores the appeal, its opening audit entries and the
+// disputed queue status together.
+func (r *Repository) CreateAppeal(ctx context.Context, appeal ports.Appeal, now time.Time) (ports.Appeal, error) {
+	now = now.UTC()
+	appeal.CreatedAt = now
+	appeal.Audit = []ports.AppealAuditEntry{{
+		EntryID:   uuid.NewString(),
+		AppealID:  appeal.AppealID,
+		Action:    "filed",
+		ActorID:   appeal.CreatorID,
+		ToStatus:  appeal.Status,
+		Notes:     appeal.Reason,
+		CreatedAt: now,
+	}}
+	if appeal.AssignedModeratorID != "" {
+		appeal.Audit = append(appeal.Audit, ports.AppealAuditEntry{
+			EntryID:    uuid.NewString(),
+			AppealID:   appeal.AppealID,
+			Action:     "assigned",
+			ActorID:    appeal.AssignedModeratorID,
+			FromStatus: appeal.Status,
+			ToStatus:   appeal.Status,
+			CreatedAt:  now,
+		})
+	}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		row := appealModelFromPort(appeal)
+		if err := tx.Create(&row).Error; err != nil {
+			return mapAppealWriteError(err)
+		}
+		for _, entry := range appeal.Audit {
+			auditRow := auditModelFromPort(entry)
+			if err := tx.Create(&auditRow).Error; err != nil {
+				return err
+			}
+		}
+		result := tx.Model(&queueItemModel{}).
+			Where("submission_id = ?", appeal.SubmissionID).
+			Updates(map[string]any{"status": "disputed", "updated_at": now})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domainerrors.ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return ports.Appeal{}, err
+	}
+	return appeal, nil
+}
+
+func (r *Repository) GetAppeal(ctx context.Context, appealID string) (ports.Appeal, error) {
+	var row appealModel
+	if err := r.db.WithContext(ctx).Where("appeal_id = ?", appealID).First(&row).Error; err != nil {
+		return ports.Appeal{}, mapNotFound(err)
+	}
+	appeal := row.toPort()
+	var auditRows []auditModel
+	if err := r.db.WithContext(ctx).
+		Where("appeal_id = ?", appealID).
+		Order("created_at ASC, entry_id ASC").
+		Find(&auditRows).Error; err != nil {
+		return ports.Appeal{}, err
+	}
+	for _, auditRow := range auditRows {
+		appeal.Audit = append(appeal.Audit, auditRow.toPort())
+	}
+	return appeal, nil
+}
+
+// ListAppeals returns appeals without their audit trail; callers fetch a
+// single appeal for the full history.
+func (r *Repository) ListAppeals(ctx context.Context, filter ports.AppealFilter) ([]ports.Appeal, error) {
+	query := r.db.WithContext(ctx).Model(&appealModel{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.AssignedModeratorID != "" {
+		query = query.Where("assigned_moderator_id = ?", filter.AssignedModeratorID)
+	}
+	var rows []appealModel
+	if err := query.
+		Order("created_at ASC, appeal_id ASC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]ports.Appeal, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, row.toPort())
+	}
+	return out, nil
+}
+
+// ResolveAppeal closes the appeal, appends the resolving decision, moves the
+// queue item and writes the audit entry in one transaction.
+func (r *Repository) ResolveAppeal(ctx context.Context, resolution ports.AppealResolution, now time.Time) (ports.Appeal, error) {
+	now = now.UTC()
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var row appealModel
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("appeal_id = ?", resolution.AppealID).
+			First(&row).Error; err != nil {
+			return mapNotFound(err)
+		}
+		if row.Status != ports.AppealStatusOpen {
+			return domainerrors.ErrAppealClosed
+		}
+
+		decision := resolution.Decision
+		decision.DecisionID = uuid.NewString()
+		decision.CreatedAt = now
+		decision.QueueStatus = decision.Action
+		decisionRow := decisionModelFromPort(decision)
+		if err := tx.Create(&decisionRow).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&queueItemModel{}).
+			Where("submission_id = ?", row.SubmissionID).
+			Updates(map[string]any{
+				"status":                decision.Action,
+				"assigned_moderator_id": decision.ModeratorID,
+				"claimed_at":            nil,
+				"lease_expires_at":      nil,
+				"updated_at":            now,
+			}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&appealModel{}).
+			Where("appeal_id = ?", row.AppealID).
+			Updates(map[string]any{
+				"status":                 resolution.Status,
+				"resolved_by_id":         resolution.ModeratorID,
+				"resolution_notes":       resolution.Notes,
+				"resolution_decision_id": decision.DecisionID,
+				"resolved_at":            now,
+			}).Error; err != nil {
+			return err
+		}
+		auditRow := auditModelFromPort(ports.AppealAuditEntry{
+			EntryID:    uuid.NewString(),
+			AppealID:   row.AppealID,
+			Action:     "resolved",
+			ActorID:    resolution.ModeratorID,
+			FromStatus: row.Status,
+			ToStatus:   resolution.Status,
+			Notes:      resolution.Notes,
+			CreatedAt:  now,
+		})
+		return tx.Create(&auditRow).Error
+	})
+	if err != nil {
+		return ports.Appeal{}, err
+	}
+	return r.GetAppeal(ctx, resolution.AppealID)
+}
+
+func (r *Repository) Get(ctx context.Context, key string, now time.Time) (ports.IdempotencyRecord, bool, error) {
+	var row idempotencyModel
+	err := r.db.WithContext(ctx).Where("key = ?", key).First(&row).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ports.IdempotencyRecord{}, false, nil
+		}
+		return ports.IdempotencyRecord{}, false, err
+	}
+	if !row.ExpiresAt.After(now.UTC()) {
+		if err := r.db.WithContext(ctx).Where("key = ?", key).Delete(&idempotencyModel{}).Error; err != nil {
+			return ports.IdempotencyRecord{}, false, err
+		}
+		return ports.IdempotencyRecord{}, false, nil
+	}
+	return ports.IdempotencyRecord{
+		Key:         row.Key,
+		RequestHash: row.RequestHash,
+		Payload:     append([]byte(nil), row.Payload...),
+		ExpiresAt:   row.ExpiresAt.UTC(),
+	}, true, nil
+}
+
+// Put inserts a new idempotency record and checks request-hash collisions.
+func (r *Repository) Put(ctx context.Context, record ports.IdempotencyRecord) error {
+	row := idempotencyModel{
+		Key:         record.Key,
+		RequestHash: record.RequestHash,
+		Payload:     append([]byte(nil), record.Payload...),
+		ExpiresAt:   record.ExpiresAt.UTC(),
+		CreatedAt:   time.Now().UTC(),
+	}
+	createResult := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "key"}},
+			DoNothing: true,
+		}).
+		Create(&row)
+	if createResult.Error != nil {
+		return createResult.Error
+	}
+	if createResult.RowsAffected > 0 {
+		return nil
+	}
+	var existing idempotencyModel
+	if err := r.db.WithContext(ctx).Where("key = ?", row.Key).First(&existing).Error; err != nil {
+		return err
+	}
+	if existing.RequestHash != row.RequestHash {
+		return domainerrors.ErrIdempotencyConflict
+	}
+	return nil
+}
+
+// activeLoad counts live leases per moderator, optionally for one moderator.
+func (r *Repository) activeLoad(ctx context.Context, now time.Time, moderatorID string) (map[string]int, error) {
+	type loadRow struct {
+		ModeratorID string `gorm:"column:assigned_moderator_id"`
+		Items       int    `gorm:"column:items"`
+	}
+	query := r.db.WithContext(ctx).
+		Model(&queueItemModel{}).
+		Select("assigned_moderator_id, COUNT(*) AS items").
+		Where("status IN ? AND lease_expires_at > ? AND assigned_moderator_id <> ''", openStatuses, now.UTC())
+	if moderatorID != "" {
+		query = query.Where("assigned_moderator_id = ?", moderatorID)
+	}
+	var rows []loadRow
+	if err := query.Group("assigned_moderator_id").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	load := make(map[string]int, len(rows))
+	for _, row := range rows {
+		load[row.ModeratorID] = row.Items
+	}
+	return load, nil
+}
+
+func lockQueueItem(tx *gorm.DB, submissionID string) (queueItemModel, error) {
+	var row queueItemModel
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("submission_id = ?", strings.TrimSpace(submissionID)).
+		First(&row).Error; err != nil {
+		return queueItemModel{}, mapNotFound(err)
+	}
+	return row, nil
+}
+
+func leaseLive(item queueItemModel, now time.Time) bool {
+	return item.LeaseExpiresAt != nil && now.Before(*item.LeaseExpiresAt)
+}
+
+func mapNotFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domainerrors.ErrNotFound
+	}
+	return err
+}
+
+func mapAppealWriteError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23503":
+			return domainerrors.ErrNotFound
+		case "23505":
+			return domainerrors.ErrAppealExists
+		}
+	}
+	return err
+}
+
+var _ ports.Repository = (*Repository)(nil)
+var _ ports.IdempotencyStore = (*Repository)(nil)
@@ -0,0 +1,267 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	domainerrors "solomon/contexts/moderation-safety/moderation-service/domain/errors"
+	"solomon/contexts/moderation-safety/moderation-service/ports"
+)
+
+// appealWindow is how long after a rejection its creator may appeal.
+const appealWindow = 30 * 24 * time.Hour
+
+// FileAppeal lets a creator contest a rejection. The appeal is routed to a
+// qualified moderator other than the one who rejected, and the submission is
+// marked disputed until the appeal is resolved.
+func (s Service) FileAppeal(ctx context.Context, idempotencyKey string, creatorID string, decisionID string, reason string) (ports.Appeal, error) {
+	idempotencyKey = strings.TrimSpace(idempotencyKey)
+	creatorID = strings.TrimSpace(creatorID)
+	decisionID = strings.TrimSpace(decisionID)
+	reason = strings.TrimSpace(reason)
+	if idempotencyKey == "" {
+		return ports.Appeal{}, domainerrors.ErrIdempotencyKeyRequired
+	}
+	if creatorID == "" || decisionID == "" || reason == "" {
+		return ports.Appeal{}, domainerrors.ErrInvalidRequest
+	}
+
+	requestHash := hashStrings("appeal", creatorID, decisionID, reason)
+	var output ports.Appeal
+	err := s.runIdempotent(
+		ctx,
+		idempotencyKey,
+		requestHash,
+		func(raw []byte) error { return json.Unmarshal(raw, &output) },
+		func() ([]byte, error) {
+			now := s.now()
+			decision, err := s.Repo.GetDecision(ctx, decisionID)
+			if err != nil {
+				return nil, err
+			}
+			item, err := s.Repo.GetQueueItem(ctx, decision.SubmissionID)
+			if err != nil {
+				return nil, err
+			}
+			if item.CreatorID != creatorID {
+				return nil, domainerrors.ErrForbidden
+			}
+			if item.Status == "disputed" {
+				return nil, domainerrors.ErrAppealExists
+			}
+			// Only the rejection currently in force can be appealed: a later
+			// decision on the same submission supersedes it.
+			if decision.Action != "rejected" || item.Status != "rejected" || now.Sub(decision.CreatedAt) > appealWindow {
+				return nil, domainerrors.ErrAppealNotAllowed
+			}
+			latest, err := s.latestDecision(ctx, decision)
+			if err != nil {
+				return nil, err
+			}
+			if latest.DecisionID != decision.DecisionID {
+				return nil, domainerrors.ErrAppealNotAllowed
+			}
+
+			appeal := ports.Appeal{
+				AppealID:            "appeal_" + hashStrings(decisionID, idempotencyKey)[:24],
+				DecisionID:          decision.DecisionID,
+				SubmissionID:        decision.SubmissionID,
+				CampaignID:          decision.CampaignID,
+				CreatorID:           creatorID,
+				OriginalModeratorID: decision.ModeratorID,
+				OriginalAction:      decision.Action,
+				OriginalReason:      decision.Reason,
+				Reason:              reason,
+				Status:              ports.AppealStatusOpen,
+			}
+			moderators, err := s.Repo.ListModerators(ctx, now)
+			if err != nil {
+				return nil, err
+			}
+			if index, ok := pickModerator(moderators, ports.QueueItem{Category: item.Category}, decision.ModeratorID); ok {
+				appeal.AssignedModeratorID = moderators[index].ModeratorID
+			}
+
+			if s.SubmissionClient == nil {
+				return nil, domainerrors.ErrDependencyUnavailable
+			}
+			if err := s.SubmissionClient.DisputeSubmission(ctx, appeal.SubmissionID, creatorID, appeal.AppealID, reason); err != nil {
+				return nil, err
+			}
+			created, err := s.Repo.CreateAppeal(ctx, appeal, now)
+			if err != nil {
+				return nil, err
+			}
+			ResolveLogger(s.Logger).Info("moderation appeal filed",
+				"event", "moderation_appeal_filed",
+				"module", "moderation-safety/moderation-service",
+				"layer", "application",
+				"appeal_id", created.AppealID,
+				"decision_id", created.DecisionID,
+				"assigned_moderator_id", created.AssignedModeratorID,
+			)
+			return json.Marshal(created)
+		},
+	)
+	return output, err
+}
+
+// ResolveAppeal upholds or reverses the appealed decision. The resolver must
+// be on the roster and must not be the moderator who made the original
+// decision; when the appeal was routed to someone, only they or a senior
+// moderator may resolve it.
+func (s Service) ResolveAppeal(ctx context.Context, idempotencyKey string, moderatorID string, appealID string, outcome string, notes string) (ports.Appeal, error) {
+	idempotencyKey = strings.TrimSpace(idempotencyKey)
+	moderatorID = strings.TrimSpace(moderatorID)
+	appealID = strings.TrimSpace(appealID)
+	outcome = strings.TrimSpace(strings.ToLower(outcome))
+	notes = strings.TrimSpace(notes)
+	if idempotencyKey == "" {
+		return ports.Appeal{}, domainerrors.ErrIdempotencyKeyRequired
+	}
+	var status string
+	switch outcome {
+	case "uphold":
+		status = ports.AppealStatusUpheld
+	case "reverse":
+		status = ports.AppealStatusReversed
+	default:
+		return ports.Appeal{}, domainerrors.ErrInvalidRequest
+	}
+	if moderatorID == "" || appealID == "" || notes == "" {
+		return ports.Appeal{}, domainerrors.ErrInvalidRequest
+	}
+
+	requestHash := hashStrings("appeal_resolution", moderatorID, appealID, status, notes)
+	var output ports.Appeal
+	err := s.runIdempotent(
+		ctx,
+		idempotencyKey,
+		requestHash,
+		func(raw []byte) error { return json.Unmarshal(raw, &output) },
+		func() ([]byte, error) {
+			now := s.now()
+			moderator, err := s.rosterModerator(ctx, moderatorID, now)
+			if err != nil {
+				return nil, err
+			}
+			appeal, err := s.Repo.GetAppeal(ctx, appealID)
+			if err != nil {
+				return nil, err
+			}
+			if appeal.Status != ports.AppealStatusOpen {
+				return nil, domainerrors.ErrAppealClosed
+			}
+			if appeal.OriginalModeratorID == moderatorID {
+				return nil, domainerrors.ErrForbidden
+			}
+			if appeal.AssignedModeratorID != "" && appeal.AssignedModeratorID != moderatorID && !moderator.Senior {
+				return nil, domainerrors.ErrForbidden
+			}
+
+			if s.SubmissionClient == nil {
+				return nil, domainerrors.ErrDependencyUnavailable
+			}
+			reversed := status == ports.AppealStatusReversed
+			if err := s.SubmissionClient.ResolveDispute(ctx, appeal.SubmissionID, moderatorID, appeal.AppealID, reversed, notes); err != nil {
+				return nil, err
+			}
+			decision := ports.DecisionRecord{
+				SubmissionID: appeal.SubmissionID,
+				CampaignID:   appeal.CampaignID,
+				ModeratorID:  moderatorID,
+				Action:       "rejected",
+				Reason:       appeal.OriginalReason,
+				Notes:        notes,
+			}
+			if reversed {
+				decision.Action = "approved"
+				decision.Reason = "appeal_reversed"
+			}
+			resolved, err := s.Repo.ResolveAppeal(ctx, ports.AppealResolution{
+				AppealID:    appeal.AppealID,
+				ModeratorID: moderatorID,
+				Status:      status,
+				Notes:       notes,
+				Decision:    decision,
+			}, now)
+			if err != nil {
+				return nil, err
+			}
+			ResolveLogger(s.Logger).Info("moderation appeal resolved",
+				"event", "moderation_appeal_resolved",
+				"module", "moderation-safety/moderation-service",
+				"layer", "application",
+				"appeal_id", resolved.AppealID,
+				"status", resolved.Status,
+				"moderator_id", moderatorID,
+			)
+			return json.Marshal(resolved)
+		},
+	)
+	return output, err
+}
+
+// GetAppeal returns an appeal with its audit trail to the creator who filed
+// it or to any moderator on the roster.
+func (s Service) GetAppeal(ctx context.Context, requesterID string, appealID string) (ports.Appeal, error) {
+	requesterID = strings.TrimSpace(requesterID)
+	appealID = strings.TrimSpace(appealID)
+	if requesterID == "" || appealID == "" {
+		return ports.Appeal{}, domainerrors.ErrInvalidRequest
+	}
+	appeal, err := s.Repo.GetAppeal(ctx, appealID)
+	if err != nil {
+		return ports.Appeal{}, err
+	}
+	if appeal.CreatorID == requesterID {
+		return appeal, nil
+	}
+	if _, err := s.rosterModerator(ctx, requesterID, s.now()); err != nil {
+		return ports.Appeal{}, err
+	}
+	return appeal, nil
+}
+
+func (s Service) ListAppeals(ctx context.Context, requesterID string, filter ports.AppealFilter) ([]ports.Appeal, error) {
+	requesterID = strings.TrimSpace(requesterID)
+	if requesterID == "" {
+		return nil, domainerrors.ErrInvalidRequest
+	}
+	if _, err := s.rosterModerator(ctx, requesterID, s.now()); err != nil {
+		return nil, err
+	}
+	filter.Status = strings.TrimSpace(strings.ToLower(filter.Status))
+	switch filter.Status {
+	case "", ports.AppealStatusOpen, ports.AppealStatusUpheld, ports.AppealStatusReversed:
+	default:
+		return nil, domainerrors.ErrInvalidRequest
+	}
+	filter.AssignedModeratorID = strings.TrimSpace(filter.AssignedModeratorID)
+	if filter.Limit <= 0 {
+		filter.Limit = 20
+	}
+	if filter.Limit > 100 {
+		filter.Limit = 100
+	}
+	if filter.Offset < 0 {
+		return nil, domainerrors.ErrInvalidRequest
+	}
+	return s.Repo.ListAppeals(ctx, filter)
+}
+
+func (s Service) latestDecision(ctx context.Context, decision ports.DecisionRecord) (ports.DecisionRecord, error) {
+	decisions, err := s.Repo.ListDecisions(ctx, ports.DecisionFilter{Since: decision.CreatedAt})
+	if err != nil {
+		return ports.DecisionRecord{}, err
+	}
+	latest := decision
+	for _, candidate := range decisions {
+		if candidate.SubmissionID == decision.SubmissionID && candidate.CreatedAt.After(latest.CreatedAt) {
+			latest = candidate
+		}
+	}
+	return latest, nil
+}
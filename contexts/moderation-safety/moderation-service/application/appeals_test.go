@@ -0,0 +1,120 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"solomon/contexts/moderation-safety/moderation-service/adapters/memory"
+	domainerrors "solomon/contexts/moderation-safety/moderation-service/domain/errors"
+	"solomon/contexts/moderation-safety/moderation-service/ports"
+)
+
+func rejectForAppeal(t *testing.T, svc Service, ctx context.Context) ports.DecisionRecord {
+	t.Helper()
+	decision, err := svc.Reject(ctx, "mod-key-appeal-reject", "mod-1", ports.ModerationActionInput{
+		SubmissionID: "sub-1",
+		CampaignID:   "camp-1",
+		Reason:       "duplicate_content",
+	})
+	if err != nil {
+		t.Fatalf("reject failed: %v", err)
+	}
+	return decision
+}
+
+func TestAppealReversalRoutesToAnotherModeratorAndOverturnsDecision(t *testing.T) {
+	store := memory.NewStore()
+	clock := &fixedClock{now: time.Now().UTC()}
+	svc := newAssignmentService(store, clock)
+	ctx := context.Background()
+	decision := rejectForAppeal(t, svc, ctx)
+
+	clock.now = clock.now.Add(time.Hour)
+	appeal, err := svc.FileAppeal(ctx, "appeal-key-1", "creator-1", decision.DecisionID, "the clip is original footage")
+	if err != nil {
+		t.Fatalf("file appeal failed: %v", err)
+	}
+	if appeal.Status != ports.AppealStatusOpen || appeal.AssignedModeratorID != "mod-senior-1" {
+		t.Fatalf("expected open appeal routed away from mod-1, got %+v", appeal)
+	}
+	item, err := store.GetQueueItem(ctx, "sub-1")
+	if err != nil {
+		t.Fatalf("get item failed: %v", err)
+	}
+	if item.Status != "disputed" {
+		t.Fatalf("expected disputed submission, got %s", item.Status)
+	}
+
+	if _, err := svc.ResolveAppeal(ctx, "resolve-key-1", "mod-1", appeal.AppealID, "uphold", "still a duplicate"); !errors.Is(err, domainerrors.ErrForbidden) {
+		t.Fatalf("expected original moderator blocked, got %v", err)
+	}
+	resolved, err := svc.ResolveAppeal(ctx, "resolve-key-2", "mod-senior-1", appeal.AppealID, "reverse", "source files confirm originality")
+	if err != nil {
+		t.Fatalf("resolve appeal failed: %v", err)
+	}
+	if resolved.Status != ports.AppealStatusReversed || resolved.ResolutionDecisionID == "" {
+		t.Fatalf("unexpected resolution %+v", resolved)
+	}
+	if len(resolved.Audit) != 3 {
+		t.Fatalf("expected filed, assigned and resolved audit entries, got %+v", resolved.Audit)
+	}
+	item, err = store.GetQueueItem(ctx, "sub-1")
+	if err != nil {
+		t.Fatalf("get item failed: %v", err)
+	}
+	if item.Status != "approved" {
+		t.Fatalf("expected approved submission after reversal, got %s", item.Status)
+	}
+
+	metrics, err := svc.ModeratorMetrics(ctx, "mod-1", "mod-1", 0)
+	if err != nil {
+		t.Fatalf("metrics failed: %v", err)
+	}
+	if metrics.Overturned != 1 {
+		t.Fatalf("expected reversal counted as overturn, got %+v", metrics)
+	}
+	if _, err := svc.ResolveAppeal(ctx, "resolve-key-3", "mod-senior-1", appeal.AppealID, "uphold", "second look"); !errors.Is(err, domainerrors.ErrAppealClosed) {
+		t.Fatalf("expected closed appeal, got %v", err)
+	}
+}
+
+func TestAppealRequiresOwningCreatorAndSingleOpenAppeal(t *testing.T) {
+	store := memory.NewStore()
+	clock := &fixedClock{now: time.Now().UTC()}
+	svc := newAssignmentService(store, clock)
+	ctx := context.Background()
+	decision := rejectForAppeal(t, svc, ctx)
+
+	if _, err := svc.FileAppeal(ctx, "appeal-key-1", "creator-2", decision.DecisionID, "not mine"); !errors.Is(err, domainerrors.ErrForbidden) {
+		t.Fatalf("expected forbidden for another creator, got %v", err)
+	}
+	appeal, err := svc.FileAppeal(ctx, "appeal-key-2", "creator-1", decision.DecisionID, "please review again")
+	if err != nil {
+		t.Fatalf("file appeal failed: %v", err)
+	}
+	replayed, err := svc.FileAppeal(ctx, "appeal-key-2", "creator-1", decision.DecisionID, "please review again")
+	if err != nil || replayed.AppealID != appeal.AppealID {
+		t.Fatalf("expected idempotent replay, got %+v err=%v", replayed, err)
+	}
+	if _, err := svc.FileAppeal(ctx, "appeal-key-3", "creator-1", decision.DecisionID, "again"); !errors.Is(err, domainerrors.ErrAppealExists) {
+		t.Fatalf("expected appeal exists, got %v", err)
+	}
+	if _, err := svc.GetAppeal(ctx, "creator-2", appeal.AppealID); !errors.Is(err, domainerrors.ErrForbidden) {
+		t.Fatalf("expected forbidden read for another creator, got %v", err)
+	}
+}
+
+func TestAppealWindowExpires(t *testing.T) {
+	store := memory.NewStore()
+	clock := &fixedClock{now: time.Now().UTC()}
+	svc := newAssignmentService(store, clock)
+	ctx := context.Background()
+	decision := rejectForAppeal(t, svc, ctx)
+
+	clock.now = clock.now.Add(31 * 24 * time.Hour)
+	if _, err := svc.FileAppeal(ctx, "appeal-key-1", "creator-1", decision.DecisionID, "late"); !errors.Is(err, domainerrors.ErrAppealNotAllowed) {
+		t.Fatalf("expected appeal window closed, got %v", err)
+	}
+}
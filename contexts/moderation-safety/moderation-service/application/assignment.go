@@ -0,0 +1,324 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	domainerrors "solomon/contexts/moderation-safety/moderation-service/domain/errors"
+	"solomon/contexts/moderation-safety/moderation-service/domain/services"
+	"solomon/contexts/moderation-safety/moderation-service/ports"
+)
+
+// AssignmentRun summarises one automatic assignment cycle.
+type AssignmentRun struct {
+	Candidates int
+	Assigned   int
+	Unmatched  int
+}
+
+// EscalationRun summarises one SLA escalation cycle.
+type EscalationRun struct {
+	Overdue    int
+	Escalated  int
+	Unassigned int
+}
+
+// ClaimQueueItem reserves an open item for the moderator until the lease
+// expires. Claiming an item the moderator already holds renews the lease, so
+// the call is safe to repeat and does not take an idempotency key.
+func (s Service) ClaimQueueItem(ctx context.Context, moderatorID string, submissionID string) (ports.QueueItem, error) {
+	moderatorID = strings.TrimSpace(moderatorID)
+	submissionID = strings.TrimSpace(submissionID)
+	if moderatorID == "" || submissionID == "" {
+		return ports.QueueItem{}, domainerrors.ErrInvalidRequest
+	}
+	now := s.now()
+	moderator, err := s.rosterModerator(ctx, moderatorID, now)
+	if err != nil {
+		return ports.QueueItem{}, err
+	}
+	item, err := s.Repo.GetQueueItem(ctx, submissionID)
+	if err != nil {
+		return ports.QueueItem{}, err
+	}
+	if !canReview(moderator, item) {
+		return ports.QueueItem{}, domainerrors.ErrForbidden
+	}
+	return s.claim(ctx, item.SubmissionID, moderatorID, now)
+}
+
+// ClaimNextQueueItem claims the most urgent unleased item the moderator is
+// qualified for.
+func (s Service) ClaimNextQueueItem(ctx context.Context, moderatorID string) (ports.QueueItem, error) {
+	moderatorID = strings.TrimSpace(moderatorID)
+	if moderatorID == "" {
+		return ports.QueueItem{}, domainerrors.ErrInvalidRequest
+	}
+	now := s.now()
+	moderator, err := s.rosterModerator(ctx, moderatorID, now)
+	if err != nil {
+		return ports.QueueItem{}, err
+	}
+	items, err := s.Repo.ListUnassignedQueue(ctx, now, 100)
+	if err != nil {
+		return ports.QueueItem{}, err
+	}
+	for _, item := range items {
+		if !canReview(moderator, item) {
+			continue
+		}
+		claimed, err := s.claim(ctx, item.SubmissionID, moderatorID, now)
+		if errors.Is(err, domainerrors.ErrQueueItemClaimed) || errors.Is(err, domainerrors.ErrQueueItemClosed) {
+			continue
+		}
+		return claimed, err
+	}
+	return ports.QueueItem{}, domainerrors.ErrNotFound
+}
+
+// ReleaseQueueItem hands a claimed item back to the pool before its lease
+// runs out.
+func (s Service) ReleaseQueueItem(ctx context.Context, moderatorID string, submissionID string) (ports.QueueItem, error) {
+	moderatorID = strings.TrimSpace(moderatorID)
+	submissionID = strings.TrimSpace(submissionID)
+	if moderatorID == "" || submissionID == "" {
+		return ports.QueueItem{}, domainerrors.ErrInvalidRequest
+	}
+	item, err := s.Repo.ReleaseQueueItem(ctx, submissionID, moderatorID, s.now())
+	if err != nil {
+		return ports.QueueItem{}, err
+	}
+	ResolveLogger(s.Logger).Info("moderation queue item released",
+		"event", "moderation_queue_item_released",
+		"module", "moderation-safety/moderation-service",
+		"layer", "application",
+		"submission_id", submissionID,
+		"moderator_id", moderatorID,
+	)
+	return item, nil
+}
+
+// AutoAssign leases unassigned items to active moderators. Each item goes to
+// the qualified moderator with the fewest live leases, ties broken by who was
+// assigned least recently, which gives round-robin within a skill pool.
+func (s Service) AutoAssign(ctx context.Context, limit int) (AssignmentRun, error) {
+	var run AssignmentRun
+	if limit <= 0 {
+		limit = 100
+	}
+	now := s.now()
+	items, err := s.Repo.ListUnassignedQueue(ctx, now, limit)
+	if err != nil {
+		return run, err
+	}
+	run.Candidates = len(items)
+	if len(items) == 0 {
+		return run, nil
+	}
+	moderators, err := s.Repo.ListModerators(ctx, now)
+	if err != nil {
+		return run, err
+	}
+	for _, item := range items {
+		index, ok := pickModerator(moderators, item, "")
+		if !ok {
+			run.Unmatched++
+			continue
+		}
+		if _, err := s.claim(ctx, item.SubmissionID, moderators[index].ModeratorID, now); err != nil {
+			if errors.Is(err, domainerrors.ErrQueueItemClaimed) || errors.Is(err, domainerrors.ErrQueueItemClosed) {
+				continue
+			}
+			return run, err
+		}
+		assignedAt := now
+		moderators[index].ActiveItems++
+		moderators[index].LastAssignedAt = &assignedAt
+		run.Assigned++
+	}
+	return run, nil
+}
+
+// EscalateOverdueItems bumps items past their SLA deadline one severity step
+// and reassigns them to a senior moderator other than the current holder.
+// When no senior has capacity the item stays in the pool flagged as senior
+// only, so the next assignment cycle routes it as soon as one frees up.
+func (s Service) EscalateOverdueItems(ctx context.Context, limit int) (EscalationRun, error) {
+	var run EscalationRun
+	if limit <= 0 {
+		limit = 100
+	}
+	now := s.now()
+	items, err := s.Repo.ListEscalationCandidates(ctx, now, limit)
+	if err != nil {
+		return run, err
+	}
+	run.Overdue = len(items)
+	if len(items) == 0 {
+		return run, nil
+	}
+	moderators, err := s.Repo.ListModerators(ctx, now)
+	if err != nil {
+		return run, err
+	}
+	logger := ResolveLogger(s.Logger)
+	for _, item := range items {
+		severity := services.EscalatedSeverity(item.Severity)
+		escalation := ports.QueueEscalation{
+			SubmissionID:    item.SubmissionID,
+			FromModeratorID: item.AssignedModeratorID,
+			Severity:        severity,
+			Level:           item.EscalationLevel + 1,
+			SLADueAt:        services.SLADeadline(severity, now),
+		}
+		target := item
+		target.SeniorRequired = true
+		index, ok := pickModerator(moderators, target, item.AssignedModeratorID)
+		if ok {
+			leaseExpiresAt := now.Add(s.leaseTTL())
+			escalation.ModeratorID = moderators[index].ModeratorID
+			escalation.LeaseExpiresAt = &leaseExpiresAt
+		}
+		if _, err := s.Repo.EscalateQueueItem(ctx, escalation, now); err != nil {
+			if errors.Is(err, domainerrors.ErrQueueItemChanged) || errors.Is(err, domainerrors.ErrQueueItemClosed) {
+				continue
+			}
+			return run, err
+		}
+		run.Escalated++
+		if ok {
+			assignedAt := now
+			moderators[index].ActiveItems++
+			moderators[index].LastAssignedAt = &assignedAt
+		} else {
+			run.Unassigned++
+		}
+		logger.Warn("moderation queue item escalated",
+			"event", "moderation_queue_item_escalated",
+			"module", "moderation-safety/moderation-service",
+			"layer", "application",
+			"submission_id", item.SubmissionID,
+			"escalation_level", escalation.Level,
+			"severity", severity,
+			"from_moderator_id", escalation.FromModeratorID,
+			"to_moderator_id", escalation.ModeratorID,
+		)
+	}
+	return run, nil
+}
+
+// UpsertModerator adds or updates a roster entry. Only senior moderators may
+// change the roster.
+func (s Service) UpsertModerator(ctx context.Context, requesterID string, moderator ports.Moderator) (ports.Moderator, error) {
+	requesterID = strings.TrimSpace(requesterID)
+	moderator.ModeratorID = strings.TrimSpace(moderator.ModeratorID)
+	if requesterID == "" || moderator.ModeratorID == "" || moderator.MaxActiveItems < 0 {
+		return ports.Moderator{}, domainerrors.ErrInvalidRequest
+	}
+	now := s.now()
+	requester, err := s.rosterModerator(ctx, requesterID, now)
+	if err != nil {
+		return ports.Moderator{}, err
+	}
+	if !requester.Senior {
+		return ports.Moderator{}, domainerrors.ErrForbidden
+	}
+	skills := make([]string, 0, len(moderator.Skills))
+	seen := map[string]struct{}{}
+	for _, skill := range moderator.Skills {
+		skill = strings.TrimSpace(strings.ToLower(skill))
+		if skill == "" {
+			continue
+		}
+		if _, ok := seen[skill]; ok {
+			continue
+		}
+		seen[skill] = struct{}{}
+		skills = append(skills, skill)
+	}
+	moderator.Skills = skills
+	return s.Repo.UpsertModerator(ctx, moderator, now)
+}
+
+func (s Service) claim(ctx context.Context, submissionID string, moderatorID string, now time.Time) (ports.QueueItem, error) {
+	item, err := s.Repo.ClaimQueueItem(ctx, ports.QueueClaim{
+		SubmissionID:   submissionID,
+		ModeratorID:    moderatorID,
+		LeaseExpiresAt: now.Add(s.leaseTTL()),
+	}, now)
+	if err != nil {
+		return ports.QueueItem{}, err
+	}
+	ResolveLogger(s.Logger).Info("moderation queue item claimed",
+		"event", "moderation_queue_item_claimed",
+		"module", "moderation-safety/moderation-service",
+		"layer", "application",
+		"submission_id", submissionID,
+		"moderator_id", moderatorID,
+	)
+	return item, nil
+}
+
+// rosterModerator loads the acting moderator; callers outside the roster are
+// not allowed to work the queue.
+func (s Service) rosterModerator(ctx context.Context, moderatorID string, now time.Time) (ports.Moderator, error) {
+	moderator, err := s.Repo.GetModerator(ctx, moderatorID, now)
+	if errors.Is(err, domainerrors.ErrNotFound) {
+		return ports.Moderator{}, domainerrors.ErrForbidden
+	}
+	return moderator, err
+}
+
+// canReview reports whether the moderator is qualified for the item. Senior
+// moderators act as the catch-all pool and may take any category.
+func canReview(moderator ports.Moderator, item ports.QueueItem) bool {
+	if item.SeniorRequired && !moderator.Senior {
+		return false
+	}
+	if item.Category == "" || moderator.Senior {
+		return true
+	}
+	for _, skill := range moderator.Skills {
+		if strings.EqualFold(skill, item.Category) {
+			return true
+		}
+	}
+	return false
+}
+
+func pickModerator(moderators []ports.Moderator, item ports.QueueItem, excludeID string) (int, bool) {
+	best := -1
+	for i, moderator := range moderators {
+		if !moderator.Active || moderator.ModeratorID == excludeID || !canReview(moderator, item) {
+			continue
+		}
+		if moderator.MaxActiveItems > 0 && moderator.ActiveItems >= moderator.MaxActiveItems {
+			continue
+		}
+		if best < 0 || lessLoaded(moderator, moderators[best]) {
+			best = i
+		}
+	}
+	return best, best >= 0
+}
+
+func lessLoaded(a ports.Moderator, b ports.Moderator) bool {
+	if a.ActiveItems != b.ActiveItems {
+		return a.ActiveItems < b.ActiveItems
+	}
+	switch {
+	case a.LastAssignedAt == nil && b.LastAssignedAt != nil:
+		return true
+	case a.LastAssignedAt != nil && b.LastAssignedAt == nil:
+		return false
+	case a.LastAssignedAt != nil && !a.LastAssignedAt.Equal(*b.LastAssignedAt):
+		return a.LastAssignedAt.Before(*b.LastAssignedAt)
+	}
+	return a.ModeratorID < b.ModeratorID
+}
+
+func leaseLive(item ports.QueueItem, now time.Time) bool {
+	return item.LeaseExpiresAt != nil && now.Before(*item.LeaseExpiresAt)
+}
@@ -0,0 +1,202 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"solomon/contexts/moderation-safety/moderation-service/adapters/memory"
+	domainerrors "solomon/contexts/moderation-safety/moderation-service/domain/errors"
+	"solomon/contexts/moderation-safety/moderation-service/ports"
+)
+
+type fixedClock struct {
+	now time.Time
+}
+
+func (c *fixedClock) Now() time.Time {
+	return c.now
+}
+
+func newAssignmentService(store *memory.Store, clock *fixedClock) Service {
+	return Service{
+		Repo:             store,
+		Idempotency:      store,
+		SubmissionClient: store,
+		Clock:            clock,
+		LeaseTTL:         10 * time.Minute,
+	}
+}
+
+func TestClaimBlocksOtherModeratorsUntilLeaseExpires(t *testing.T) {
+	store := memory.NewStore()
+	clock := &fixedClock{now: time.Now().UTC()}
+	svc := newAssignmentService(store, clock)
+	ctx := context.Background()
+
+	item, err := svc.ClaimQueueItem(ctx, "mod-1", "sub-1")
+	if err != nil {
+		t.Fatalf("claim failed: %v", err)
+	}
+	if item.AssignedModeratorID != "mod-1" || item.LeaseExpiresAt == nil {
+		t.Fatalf("expected lease held by mod-1, got %+v", item)
+	}
+	if _, err := svc.ClaimQueueItem(ctx, "mod-senior-1", "sub-1"); !errors.Is(err, domainerrors.ErrQueueItemClaimed) {
+		t.Fatalf("expected claimed conflict, got %v", err)
+	}
+	_, err = svc.Approve(ctx, "mod-key-claim-1", "mod-senior-1", ports.ModerationActionInput{
+		SubmissionID: "sub-1",
+		CampaignID:   "camp-1",
+		Reason:       "manual_review_pass",
+	})
+	if !errors.Is(err, domainerrors.ErrQueueItemClaimed) {
+		t.Fatalf("expected decision blocked by lease, got %v", err)
+	}
+	if _, err := svc.ReleaseQueueItem(ctx, "mod-senior-1", "sub-1"); !errors.Is(err, domainerrors.ErrLeaseNotHeld) {
+		t.Fatalf("expected lease not held, got %v", err)
+	}
+
+	clock.now = clock.now.Add(11 * time.Minute)
+	item, err = svc.ClaimQueueItem(ctx, "mod-senior-1", "sub-1")
+	if err != nil {
+		t.Fatalf("claim after lease expiry failed: %v", err)
+	}
+	if item.AssignedModeratorID != "mod-senior-1" {
+		t.Fatalf("expected senior to hold the lease, got %q", item.AssignedModeratorID)
+	}
+}
+
+func TestClaimRequiresMatchingSkill(t *testing.T) {
+	store := memory.NewStore()
+	clock := &fixedClock{now: time.Now().UTC()}
+	svc := newAssignmentService(store, clock)
+	ctx := context.Background()
+	if _, err := store.UpsertModerator(ctx, ports.Moderator{ModeratorID: "mod-2", Active: true}, clock.now); err != nil {
+		t.Fatalf("seed moderator failed: %v", err)
+	}
+
+	if _, err := svc.ClaimQueueItem(ctx, "mod-2", "sub-2"); !errors.Is(err, domainerrors.ErrForbidden) {
+		t.Fatalf("expected forbidden for missing skill, got %v", err)
+	}
+	if _, err := svc.ClaimQueueItem(ctx, "mod-unknown", "sub-1"); !errors.Is(err, domainerrors.ErrForbidden) {
+		t.Fatalf("expected forbidden outside the roster, got %v", err)
+	}
+	item, err := svc.ClaimNextQueueItem(ctx, "mod-2")
+	if err != nil {
+		t.Fatalf("claim next failed: %v", err)
+	}
+	if item.SubmissionID != "sub-1" {
+		t.Fatalf("expected the uncategorised item, got %s", item.SubmissionID)
+	}
+}
+
+func TestAutoAssignSpreadsItemsAcrossQualifiedModerators(t *testing.T) {
+	store := memory.NewStore()
+	clock := &fixedClock{now: time.Now().UTC()}
+	svc := newAssignmentService(store, clock)
+	ctx := context.Background()
+	for _, id := range []string{"sub-3", "sub-4"} {
+		store.SeedQueueItem(ports.QueueItem{
+			SubmissionID: id,
+			CampaignID:   "camp-3",
+			CreatorID:    "creator-3",
+			Status:       "pending",
+			RiskScore:    0.2,
+			QueuedAt:     clock.now,
+		})
+	}
+
+	run, err := svc.AutoAssign(ctx, 10)
+	if err != nil {
+		t.Fatalf("auto assign failed: %v", err)
+	}
+	if run.Candidates != 4 || run.Assigned != 4 {
+		t.Fatalf("expected all four items assigned, got %+v", run)
+	}
+	moderators, err := store.ListModerators(ctx, clock.now)
+	if err != nil {
+		t.Fatalf("list moderators failed: %v", err)
+	}
+	for _, moderator := range moderators {
+		if moderator.ActiveItems != 2 {
+			t.Fatalf("expected even split, %s has %d items", moderator.ModeratorID, moderator.ActiveItems)
+		}
+	}
+}
+
+func TestEscalationMovesOverdueItemToSenior(t *testing.T) {
+	store := memory.NewStore()
+	clock := &fixedClock{now: time.Now().UTC()}
+	svc := newAssignmentService(store, clock)
+	ctx := context.Background()
+	if _, err := svc.ClaimQueueItem(ctx, "mod-1", "sub-1"); err != nil {
+		t.Fatalf("claim failed: %v", err)
+	}
+
+	// sub-1 is high severity (4h SLA) and was queued two hours ago.
+	clock.now = clock.now.Add(3 * time.Hour)
+	run, err := svc.EscalateOverdueItems(ctx, 10)
+	if err != nil {
+		t.Fatalf("escalation failed: %v", err)
+	}
+	if run.Escalated != 1 {
+		t.Fatalf("expected one escalation, got %+v", run)
+	}
+	item, err := store.GetQueueItem(ctx, "sub-1")
+	if err != nil {
+		t.Fatalf("get item failed: %v", err)
+	}
+	if item.Status != "escalated" || item.EscalationLevel != 1 || !item.SeniorRequired {
+		t.Fatalf("unexpected escalated item %+v", item)
+	}
+	if item.AssignedModeratorID != "mod-senior-1" || item.Severity != "critical" {
+		t.Fatalf("expected critical item with senior, got %+v", item)
+	}
+	if _, err := svc.ClaimQueueItem(ctx, "mod-1", "sub-1"); !errors.Is(err, domainerrors.ErrForbidden) {
+		t.Fatalf("expected regular moderator blocked from senior item, got %v", err)
+	}
+}
+
+func TestModeratorMetricsCountsOverturnedDecisions(t *testing.T) {
+	store := memory.NewStore()
+	clock := &fixedClock{now: time.Now().UTC()}
+	svc := newAssignmentService(store, clock)
+	ctx := context.Background()
+	if _, err := svc.ClaimQueueItem(ctx, "mod-1", "sub-1"); err != nil {
+		t.Fatalf("claim failed: %v", err)
+	}
+	clock.now = clock.now.Add(2 * time.Minute)
+	if _, err := svc.Approve(ctx, "mod-key-metrics-1", "mod-1", ports.ModerationActionInput{
+		SubmissionID: "sub-1",
+		CampaignID:   "camp-1",
+		Reason:       "manual_review_pass",
+	}); err != nil {
+		t.Fatalf("approve failed: %v", err)
+	}
+	clock.now = clock.now.Add(time.Minute)
+	if _, err := svc.Reject(ctx, "mod-key-metrics-2", "mod-senior-1", ports.ModerationActionInput{
+		SubmissionID: "sub-1",
+		CampaignID:   "camp-1",
+		Reason:       "duplicate_content",
+	}); err != nil {
+		t.Fatalf("reject failed: %v", err)
+	}
+
+	metrics, err := svc.ModeratorMetrics(ctx, "mod-1", "mod-1", 0)
+	if err != nil {
+		t.Fatalf("metrics failed: %v", err)
+	}
+	if metrics.Decisions != 1 || metrics.Approved != 1 || metrics.Reviewed != 1 || metrics.Overturned != 1 {
+		t.Fatalf("unexpected metrics %+v", metrics)
+	}
+	if metrics.Accuracy == nil || *metrics.Accuracy != 0 {
+		t.Fatalf("expected zero accuracy, got %v", metrics.Accuracy)
+	}
+	if metrics.AverageHandleSeconds != 120 {
+		t.Fatalf("expected 120s handle time, got %v", metrics.AverageHandleSeconds)
+	}
+	if _, err := svc.ModeratorMetrics(ctx, "mod-1", "mod-senior-1", 0); !errors.Is(err, domainerrors.ErrForbidden) {
+		t.Fatalf("expected forbidden for peer metrics, got %v", err)
+	}
+}
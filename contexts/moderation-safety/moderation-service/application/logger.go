@@ -2,7 +2,8 @@ package application
 
 import "log/slog"
 
-func resolveLogger(logger *slog.Logger) *slog.Logger {
+// ResolveLogger falls back to the default logger when none is configured.
+func ResolveLogger(logger *slog.Logger) *slog.Logger {
 	if logger != nil {
 		return logger
 	}
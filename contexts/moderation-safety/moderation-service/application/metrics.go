@@ -0,0 +1,118 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	domainerrors "solomon/contexts/moderation-safety/moderation-service/domain/errors"
+	"solomon/contexts/moderation-safety/moderation-service/ports"
+)
+
+const (
+	defaultMetricsWindow = 7 * 24 * time.Hour
+	maxMetricsWindow     = 90 * 24 * time.Hour
+)
+
+// ModeratorMetrics reports throughput and accuracy for one moderator over
+// the trailing window. Moderators may read their own numbers; seniors may
+// read anyone's.
+//
+// Accuracy only counts final decisions (approve/reject) that another
+// moderator later decided again: a matching outcome confirms the first
+// decision, a different one overturns it. Flags are a request for a second
+// look, not a verdict, so they are neither scored nor treated as reviews.
+func (s Service) ModeratorMetrics(ctx context.Context, requesterID string, moderatorID string, window time.Duration) (ports.ModeratorMetrics, error) {
+	requesterID = strings.TrimSpace(requesterID)
+	moderatorID = strings.TrimSpace(moderatorID)
+	if requesterID == "" || moderatorID == "" || window < 0 || window > maxMetricsWindow {
+		return ports.ModeratorMetrics{}, domainerrors.ErrInvalidRequest
+	}
+	if window == 0 {
+		window = defaultMetricsWindow
+	}
+	now := s.now()
+	if requesterID != moderatorID {
+		requester, err := s.rosterModerator(ctx, requesterID, now)
+		if err != nil {
+			return ports.ModeratorMetrics{}, err
+		}
+		if !requester.Senior {
+			return ports.ModeratorMetrics{}, domainerrors.ErrForbidden
+		}
+	}
+
+	metrics := ports.ModeratorMetrics{
+		ModeratorID: moderatorID,
+		WindowStart: now.Add(-window),
+		WindowEnd:   now,
+	}
+	// Later decisions by other moderators are needed to score this
+	// moderator's work, so load the whole window rather than only theirs.
+	decisions, err := s.Repo.ListDecisions(ctx, ports.DecisionFilter{Since: metrics.WindowStart})
+	if err != nil {
+		return ports.ModeratorMetrics{}, err
+	}
+
+	var handleSeconds float64
+	var handled int
+	for i, decision := range decisions {
+		if decision.ModeratorID != moderatorID {
+			continue
+		}
+		metrics.Decisions++
+		switch decision.Action {
+		case "approved":
+			metrics.Approved++
+		case "rejected":
+			metrics.Rejected++
+		case "flagged":
+			metrics.Flagged++
+		}
+		if decision.SLABreached {
+			metrics.SLABreaches++
+		}
+		if decision.ClaimedAt != nil && !decision.CreatedAt.Before(*decision.ClaimedAt) {
+			handleSeconds += decision.CreatedAt.Sub(*decision.ClaimedAt).Seconds()
+			handled++
+		}
+		if !isFinalAction(decision.Action) {
+			continue
+		}
+		if review, ok := nextFinalReview(decisions[i+1:], decision); ok {
+			metrics.Reviewed++
+			if review.Action != decision.Action {
+				metrics.Overturned++
+			}
+		}
+	}
+	if handled > 0 {
+		metrics.AverageHandleSeconds = handleSeconds / float64(handled)
+	}
+	if metrics.Reviewed > 0 {
+		accuracy := float64(metrics.Reviewed-metrics.Overturned) / float64(metrics.Reviewed)
+		metrics.Accuracy = &accuracy
+	}
+	return metrics, nil
+}
+
+// nextFinalReview finds the first later final decision on the same
+// submission by a different moderator. later must be in creation order.
+func nextFinalReview(later []ports.DecisionRecord, decision ports.DecisionRecord) (ports.DecisionRecord, bool) {
+	for _, candidate := range later {
+		if candidate.SubmissionID != decision.SubmissionID || !isFinalAction(candidate.Action) {
+			continue
+		}
+		if candidate.ModeratorID == decision.ModeratorID {
+			// A moderator changing their own mind supersedes the earlier
+			// decision; only the latest one is up for review.
+			return ports.DecisionRecord{}, false
+		}
+		return candidate, true
+	}
+	return ports.DecisionRecord{}, false
+}
+
+func isFinalAction(action string) bool {
+	return action == "approved" || action == "rejected"
+}
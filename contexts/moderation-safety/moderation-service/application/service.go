@@ -10,6 +10,7 @@ import (
 	"time"
 
 	domainerrors "solomon/contexts/moderation-safety/moderation-service/domain/errors"
+	"solomon/contexts/moderation-safety/moderation-service/domain/services"
 	"solomon/contexts/moderation-safety/moderation-service/ports"
 )
 
@@ -19,18 +20,33 @@ type Service struct {
 	SubmissionClient ports.SubmissionDecisionClient
 	Clock            ports.Clock
 	IdempotencyTTL   time.Duration
-	Logger           *slog.Logger
+	// LeaseTTL is how long a claim or assignment reserves an item for one
+	// moderator before it returns to the pool.
+	LeaseTTL time.Duration
+	Logger   *slog.Logger
 }
 
 func (s Service) ListQueue(ctx context.Context, filter ports.QueueFilter) ([]ports.QueueItem, error) {
 	filter.Status = strings.TrimSpace(strings.ToLower(filter.Status))
 	if filter.Status != "" {
 		switch filter.Status {
-		case "pending", "approved", "rejected", "flagged", "escalated":
+		case "pending", "approved", "rejected", "flagged", "escalated", "disputed":
 		default:
 			return nil, domainerrors.ErrInvalidRequest
 		}
 	}
+	filter.AssignedModeratorID = strings.TrimSpace(filter.AssignedModeratorID)
+	if filter.Severity != "" {
+		severity, ok := services.NormalizeSeverity(filter.Severity)
+		if !ok {
+			return nil, domainerrors.ErrInvalidRequest
+		}
+		filter.Severity = severity
+	}
+	filter.OverdueAt = time.Time{}
+	if filter.Overdue {
+		filter.OverdueAt = s.now()
+	}
 	if filter.Limit <= 0 {
 		filter.Limit = 20
 	}
@@ -109,12 +125,22 @@ func (s Service) runDecision(
 		requestHash,
 		func(raw []byte) error { return json.Unmarshal(raw, &output) },
 		func() ([]byte, error) {
+			now := s.now()
+			item, err := s.Repo.GetQueueItem(ctx, input.SubmissionID)
+			if err != nil {
+				return nil, err
+			}
+			// Check the lease before touching the submission so a moderator
+			// cannot decide an item someone else is reviewing.
+			if leaseLive(item, now) && item.AssignedModeratorID != moderatorID {
+				return nil, domainerrors.ErrQueueItemClaimed
+			}
 			if beforePersist != nil {
 				if err := beforePersist(); err != nil {
 					return nil, err
 				}
 			}
-			record, err := s.Repo.RecordDecision(ctx, ports.DecisionRecord{
+			record := ports.DecisionRecord{
 				SubmissionID: input.SubmissionID,
 				CampaignID:   input.CampaignID,
 				ModeratorID:  moderatorID,
@@ -122,7 +148,14 @@ func (s Service) runDecision(
 				Reason:       input.Reason,
 				Notes:        input.Notes,
 				Severity:     input.Severity,
-			}, s.now())
+				SLADueAt:     item.SLADueAt,
+				SLABreached:  services.IsOpenStatus(item.Status) && now.After(item.SLADueAt),
+			}
+			if item.ClaimedAt != nil && item.AssignedModeratorID == moderatorID {
+				claimedAt := *item.ClaimedAt
+				record.ClaimedAt = &claimedAt
+			}
+			record, err = s.Repo.RecordDecision(ctx, record, now)
 			if err != nil {
 				return nil, err
 			}
@@ -146,6 +179,13 @@ func (s Service) idempotencyTTL() time.Duration {
 	return s.IdempotencyTTL
 }
 
+func (s Service) leaseTTL() time.Duration {
+	if s.LeaseTTL <= 0 {
+		return 15 * time.Minute
+	}
+	return s.LeaseTTL
+}
+
 func (s Service) runIdempotent(
 	ctx context.Context,
 	key string,
@@ -176,7 +216,7 @@ func (s Service) runIdempotent(
 	}); err != nil {
 		return err
 	}
-	resolveLogger(s.Logger).Debug("moderation idempotent mutation committed",
+	ResolveLogger(s.Logger).Debug("moderation idempotent mutation committed",
 		"event", "moderation_idempotent_mutation_committed",
 		"module", "moderation-safety/moderation-service",
 		"layer", "application",
@@ -0,0 +1,42 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+
+	application "solomon/contexts/moderation-safety/moderation-service/application"
+)
+
+// AssignmentJob leases unassigned queue items to qualified moderators.
+type AssignmentJob struct {
+	Service   application.Service
+	BatchSize int
+	Logger    *slog.Logger
+}
+
+func (j AssignmentJob) RunOnce(ctx context.Context) error {
+	logger := application.ResolveLogger(j.Logger)
+	run, err := j.Service.AutoAssign(ctx, j.BatchSize)
+	if err != nil {
+		logger.Error("moderation queue assignment cycle failed",
+			"event", "moderation_queue_assignment_cycle_failed",
+			"module", "moderation-safety/moderation-service",
+			"layer", "worker",
+			"assigned", run.Assigned,
+			"error", err.Error(),
+		)
+		return err
+	}
+	if run.Candidates == 0 {
+		return nil
+	}
+	logger.Debug("moderation queue assignment cycle succeeded",
+		"event", "moderation_queue_assignment_cycle_succeeded",
+		"module", "moderation-safety/moderation-service",
+		"layer", "worker",
+		"candidates", run.Candidates,
+		"assigned", run.Assigned,
+		"unmatched", run.Unmatched,
+	)
+	return nil
+}
@@ -0,0 +1,43 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+
+	application "solomon/contexts/moderation-safety/moderation-service/application"
+)
+
+// EscalationJob bumps queue items that missed their SLA deadline to senior
+// moderators.
+type EscalationJob struct {
+	Service   application.Service
+	BatchSize int
+	Logger    *slog.Logger
+}
+
+func (j EscalationJob) RunOnce(ctx context.Context) error {
+	logger := application.ResolveLogger(j.Logger)
+	run, err := j.Service.EscalateOverdueItems(ctx, j.BatchSize)
+	if err != nil {
+		logger.Error("moderation sla escalation cycle failed",
+			"event", "moderation_sla_escalation_cycle_failed",
+			"module", "moderation-safety/moderation-service",
+			"layer", "worker",
+			"escalated", run.Escalated,
+			"error", err.Error(),
+		)
+		return err
+	}
+	if run.Overdue == 0 {
+		return nil
+	}
+	logger.Info("moderation sla escalation cycle succeeded",
+		"event", "moderation_sla_escalation_cycle_succeeded",
+		"module", "moderation-safety/moderation-service",
+		"layer", "worker",
+		"overdue", run.Overdue,
+		"escalated", run.Escalated,
+		"unassigned", run.Unassigned,
+	)
+	return nil
+}
@@ -9,4 +9,11 @@ var (
 	ErrDependencyUnavailable  = errors.New("dependency unavailable")
 	ErrIdempotencyKeyRequired = errors.New("idempotency key is required")
 	ErrIdempotencyConflict    = errors.New("idempotency key reused with different request")
+	ErrQueueItemClaimed       = errors.New("queue item is claimed by another moderator")
+	ErrLeaseNotHeld           = errors.New("moderator does not hold the review lease")
+	ErrQueueItemClosed        = errors.New("queue item is no longer open for review")
+	ErrQueueItemChanged       = errors.New("queue item changed concurrently")
+	ErrAppealExists           = errors.New("decision has already been appealed")
+	ErrAppealNotAllowed       = errors.New("decision cannot be appealed")
+	ErrAppealClosed           = errors.New("appeal is already resolved")
 )
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	SeverityLow      = "low"
+	SeverityMedium   = "medium"
+	SeverityHigh     = "high"
+	SeverityCritical = "critical"
+)
+
+// MaxEscalationLevel bounds how many times an overdue item is bumped. Past
+// it the item stays with the senior pool rather than cycling forever.
+const MaxEscalationLevel = 2
+
+var slaBySeverity = map[string]time.Duration{
+	SeverityCritical: time.Hour,
+	SeverityHigh:     4 * time.Hour,
+	SeverityMedium:   24 * time.Hour,
+	SeverityLow:      72 * time.Hour,
+}
+
+// NormalizeSeverity lower-cases a severity and reports whether it is known.
+func NormalizeSeverity(raw string) (string, bool) {
+	severity := strings.TrimSpace(strings.ToLower(raw))
+	_, ok := slaBySeverity[severity]
+	return severity, ok
+}
+
+// SeverityForRisk maps a queue risk score onto a review severity.
+func SeverityForRisk(riskScore float64) string {
+	switch {
+	case riskScore >= 0.9:
+		return SeverityCritical
+	case riskScore >= 0.7:
+		return SeverityHigh
+	case riskScore >= 0.4:
+		return SeverityMedium
+	default:
+		return SeverityLow
+	}
+}
+
+// SLADeadline is the time by which an item of the given severity must be
+// decided when its clock starts at from. Unknown severities get the medium
+// window.
+func SLADeadline(severity string, from time.Time) time.Time {
+	window, ok := slaBySeverity[severity]
+	if !ok {
+		window = slaBySeverity[SeverityMedium]
+	}
+	return from.UTC().Add(window)
+}
+
+// EscalatedSeverity is the severity an overdue item moves to when it is
+// escalated: one step up, capped at critical.
+func EscalatedSeverity(severity string) string {
+	switch severity {
+	case SeverityLow:
+		return SeverityMedium
+	case SeverityMedium:
+		return SeverityHigh
+	default:
+		return SeverityCritical
+	}
+}
+
+// IsOpenStatus reports whether a queue item still awaits a final decision.
+func IsOpenStatus(status string) bool {
+	switch status {
+	case "pending", "flagged", "escalated":
+		return true
+	default:
+		return false
+	}
+}
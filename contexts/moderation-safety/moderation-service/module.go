@@ -1,18 +1,22 @@
 package moderationservice
 
 import (
+	"context"
 	"log/slog"
 	"time"
 
 	httpadapter "solomon/contexts/moderation-safety/moderation-service/adapters/http"
 	"solomon/contexts/moderation-safety/moderation-service/adapters/memory"
 	"solomon/contexts/moderation-safety/moderation-service/application"
+	"solomon/contexts/moderation-safety/moderation-service/application/workers"
 	"solomon/contexts/moderation-safety/moderation-service/ports"
 )
 
 type Module struct {
-	Handler httpadapter.Handler
-	Store   *memory.Store
+	Handler     httpadapter.Handler
+	Assignments workers.AssignmentJob
+	Escalations workers.EscalationJob
+	Store       *memory.Store
 }
 
 type Dependencies struct {
@@ -21,6 +25,7 @@ type Dependencies struct {
 	SubmissionClient ports.SubmissionDecisionClient
 	Clock            ports.Clock
 	IdempotencyTTL   time.Duration
+	LeaseTTL         time.Duration
 	Logger           *slog.Logger
 }
 
@@ -31,6 +36,7 @@ func NewModule(deps Dependencies) Module {
 		SubmissionClient: deps.SubmissionClient,
 		Clock:            deps.Clock,
 		IdempotencyTTL:   deps.IdempotencyTTL,
+		LeaseTTL:         deps.LeaseTTL,
 		Logger:           deps.Logger,
 	}
 	return Module{
@@ -38,9 +44,27 @@ func NewModule(deps Dependencies) Module {
 			Service: service,
 			Logger:  deps.Logger,
 		},
+		Assignments: workers.AssignmentJob{
+			Service: service,
+			Logger:  deps.Logger,
+		},
+		Escalations: workers.EscalationJob{
+			Service: service,
+			Logger:  deps.Logger,
+		},
 	}
 }
 
+// AssignQueue runs one automatic assignment cycle.
+func (m Module) AssignQueue(ctx context.Context) error {
+	return m.Assignments.RunOnce(ctx)
+}
+
+// EscalateQueue runs one SLA escalation cycle.
+func (m Module) EscalateQueue(ctx context.Context) error {
+	return m.Escalations.RunOnce(ctx)
+}
+
 func NewInMemoryModule(logger *slog.Logger) Module {
 	store := memory.NewStore()
 	module := NewModule(Dependencies{
@@ -49,6 +73,7 @@ func NewInMemoryModule(logger *slog.Logger) Module {
 		SubmissionClient: store,
 		Clock:            store,
 		IdempotencyTTL:   7 * 24 * time.Hour,
+		LeaseTTL:         15 * time.Minute,
 		Logger:           logger,
 	})
 	module.Store = store
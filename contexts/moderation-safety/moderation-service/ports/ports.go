@@ -22,9 +22,15 @@ type IdempotencyStore interface {
 }
 
 type QueueFilter struct {
-	Status string
-	Limit  int
-	Offset int
+	Status              string
+	AssignedModeratorID string
+	Severity            string
+	// Overdue asks for open items past their SLA deadline; the service
+	// resolves it to OverdueAt so the repository filters on a fixed instant.
+	Overdue   bool
+	OverdueAt time.Time
+	Limit     int
+	Offset    int
 }
 
 type QueueItem struct {
@@ -36,6 +42,73 @@ type QueueItem struct {
 	ReportCount         int
 	QueuedAt            time.Time
 	AssignedModeratorID string
+	// Category is the skill a moderator needs to review the item; empty
+	// means any moderator may take it.
+	Category        string
+	Severity        string
+	SLADueAt        time.Time
+	ClaimedAt       *time.Time
+	LeaseExpiresAt  *time.Time
+	EscalationLevel int
+	EscalatedAt     *time.Time
+	SeniorRequired  bool
+}
+
+// QueueClaim takes or renews the review lease on an open queue item. The
+// repository rejects it when another moderator holds an unexpired lease.
+type QueueClaim struct {
+	SubmissionID   string
+	ModeratorID    string
+	LeaseExpiresAt time.Time
+}
+
+// QueueEscalation moves an overdue item up one level and hands it to a
+// senior moderator. ModeratorID is empty when no senior was available.
+type QueueEscalation struct {
+	SubmissionID    string
+	FromModeratorID string
+	ModeratorID     string
+	Severity        string
+	Level           int
+	SLADueAt        time.Time
+	LeaseExpiresAt  *time.Time
+}
+
+type Moderator struct {
+	ModeratorID string
+	Skills      []string
+	Senior      bool
+	Active      bool
+	// MaxActiveItems caps concurrent leases; zero means no cap.
+	MaxActiveItems int
+	// ActiveItems and LastAssignedAt are maintained by the repository and
+	// drive least-loaded round-robin assignment.
+	ActiveItems    int
+	LastAssignedAt *time.Time
+	UpdatedAt      time.Time
+}
+
+type DecisionFilter struct {
+	ModeratorID string
+	Since       time.Time
+}
+
+type ModeratorMetrics struct {
+	ModeratorID          string
+	WindowStart          time.Time
+	WindowEnd            time.Time
+	Decisions            int
+	Approved             int
+	Rejected             int
+	Flagged              int
+	SLABreaches          int
+	AverageHandleSeconds float64
+	// Reviewed counts decisions another moderator later re-decided;
+	// Overturned is the subset where they reached a different outcome.
+	Reviewed   int
+	Overturned int
+	// Accuracy is nil until at least one decision has been reviewed.
+	Accuracy *float64
 }
 
 type ModerationActionInput struct {
@@ -57,14 +130,107 @@ type DecisionRecord struct {
 	Severity     string
 	CreatedAt    time.Time
 	QueueStatus  string
+	ClaimedAt    *time.Time
+	SLADueAt     time.Time
+	SLABreached  bool
 }
 
 type SubmissionDecisionClient interface {
 	ApproveSubmission(ctx context.Context, submissionID string, moderatorID string, reason string) error
 	RejectSubmission(ctx context.Context, submissionID string, moderatorID string, reason string, notes string) error
+	// DisputeSubmission marks a rejected submission as disputed while an
+	// appeal filed by its creator is open.
+	DisputeSubmission(ctx context.Context, submissionID string, creatorID string, appealID string, reason string) error
+	// ResolveDispute closes the dispute: reversed approves the submission,
+	// otherwise the rejection is restored.
+	ResolveDispute(ctx context.Context, submissionID string, moderatorID string, appealID string, reversed bool, notes string) error
+}
+
+const (
+	AppealStatusOpen     = "open"
+	AppealStatusUpheld   = "upheld"
+	AppealStatusReversed = "reversed"
+)
+
+type Appeal struct {
+	AppealID             string
+	DecisionID           string
+	SubmissionID         string
+	CampaignID           string
+	CreatorID            string
+	OriginalModeratorID  string
+	OriginalAction       string
+	OriginalReason       string
+	Reason               string
+	Status               string
+	AssignedModeratorID  string
+	ResolvedByID         string
+	ResolutionNotes      string
+	ResolutionDecisionID string
+	CreatedAt            time.Time
+	ResolvedAt           *time.Time
+	Audit                []AppealAuditEntry
+}
+
+// AppealAuditEntry is one step in an appeal's history. Entries are append
+// only and written in the same transaction as the change they describe.
+type AppealAuditEntry struct {
+	EntryID    string
+	AppealID   string
+	Action     string
+	ActorID    string
+	FromStatus string
+	ToStatus   string
+	Notes      string
+	CreatedAt  time.Time
+}
+
+type AppealFilter struct {
+	Status              string
+	AssignedModeratorID string
+	Limit               int
+	Offset              int
+}
+
+// AppealResolution closes an open appeal and records the resolving
+// moderator's decision on the submission alongside it.
+type AppealResolution struct {
+	AppealID    string
+	ModeratorID string
+	Status      string
+	Notes       string
+	Decision    DecisionRecord
 }
 
 type Repository interface {
 	ListQueue(ctx context.Context, filter QueueFilter) ([]QueueItem, error)
+	GetQueueItem(ctx context.Context, submissionID string) (QueueItem, error)
+	// RecordDecision fails with ErrQueueItemClaimed when another moderator
+	// holds an unexpired lease on the item.
 	RecordDecision(ctx context.Context, record DecisionRecord, now time.Time) (DecisionRecord, error)
+	GetDecision(ctx context.Context, decisionID string) (DecisionRecord, error)
+	ListDecisions(ctx context.Context, filter DecisionFilter) ([]DecisionRecord, error)
+
+	ClaimQueueItem(ctx context.Context, claim QueueClaim, now time.Time) (QueueItem, error)
+	ReleaseQueueItem(ctx context.Context, submissionID string, moderatorID string, now time.Time) (QueueItem, error)
+	// ListUnassignedQueue returns open items without a live lease, most
+	// urgent SLA first.
+	ListUnassignedQueue(ctx context.Context, now time.Time, limit int) ([]QueueItem, error)
+	// ListEscalationCandidates returns open items past their SLA deadline
+	// that have not reached the maximum escalation level.
+	ListEscalationCandidates(ctx context.Context, now time.Time, limit int) ([]QueueItem, error)
+	EscalateQueueItem(ctx context.Context, escalation QueueEscalation, now time.Time) (QueueItem, error)
+
+	ListModerators(ctx context.Context, now time.Time) ([]Moderator, error)
+	GetModerator(ctx context.Context, moderatorID string, now time.Time) (Moderator, error)
+	UpsertModerator(ctx context.Context, moderator Moderator, now time.Time) (Moderator, error)
+
+	// CreateAppeal stores a new open appeal and moves the queue item to
+	// disputed. It fails with ErrAppealExists when the decision was already
+	// appealed.
+	CreateAppeal(ctx context.Context, appeal Appeal, now time.Time) (Appeal, error)
+	GetAppeal(ctx context.Context, appealID string) (Appeal, error)
+	ListAppeals(ctx context.Context, filter AppealFilter) ([]Appeal, error)
+	// ResolveAppeal fails with ErrAppealClosed unless the appeal is open.
+	ResolveAppeal(ctx context.Context, resolution AppealResolution, now time.Time) (Appeal, error)
 }
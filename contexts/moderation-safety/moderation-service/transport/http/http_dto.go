@@ -46,24 +46,134 @@ type DecisionResponse struct {
 		Notes        string `json:"notes,omitempty"`
 		Severity     string `json:"severity,omitempty"`
 		QueueStatus  string `json:"queue_status"`
+		SLABreached  bool   `json:"sla_breached"`
 		CreatedAt    string `json:"created_at"`
 	} `json:"data"`
 	Timestamp string `json:"timestamp"`
 }
 
+type QueueItem struct {
+	SubmissionID        string  `json:"submission_id"`
+	CampaignID          string  `json:"campaign_id"`
+	CreatorID           string  `json:"creator_id"`
+	Status              string  `json:"status"`
+	RiskScore           float64 `json:"risk_score"`
+	ReportCount         int     `json:"report_count"`
+	QueuedAt            string  `json:"queued_at"`
+	AssignedModeratorID string  `json:"assigned_moderator_id,omitempty"`
+	Category            string  `json:"category,omitempty"`
+	Severity            string  `json:"severity"`
+	SLADueAt            string  `json:"sla_due_at"`
+	ClaimedAt           string  `json:"claimed_at,omitempty"`
+	LeaseExpiresAt      string  `json:"lease_expires_at,omitempty"`
+	EscalationLevel     int     `json:"escalation_level"`
+	EscalatedAt         string  `json:"escalated_at,omitempty"`
+	SeniorRequired      bool    `json:"senior_required"`
+}
+
 type QueueResponse struct {
 	Status string `json:"status"`
 	Data   struct {
-		Items []struct {
-			SubmissionID        string  `json:"submission_id"`
-			CampaignID          string  `json:"campaign_id"`
-			CreatorID           string  `json:"creator_id"`
-			Status              string  `json:"status"`
-			RiskScore           float64 `json:"risk_score"`
-			ReportCount         int     `json:"report_count"`
-			QueuedAt            string  `json:"queued_at"`
-			AssignedModeratorID string  `json:"assigned_moderator_id,omitempty"`
-		} `json:"items"`
+		Items []QueueItem `json:"items"`
+	} `json:"data"`
+	Timestamp string `json:"timestamp"`
+}
+
+type QueueItemResponse struct {
+	Status    string    `json:"status"`
+	Data      QueueItem `json:"data"`
+	Timestamp string    `json:"timestamp"`
+}
+
+type UpsertModeratorRequest struct {
+	Skills         []string `json:"skills"`
+	Senior         bool     `json:"senior"`
+	Active         bool     `json:"active"`
+	MaxActiveItems int      `json:"max_active_items"`
+}
+
+type ModeratorResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		ModeratorID    string   `json:"moderator_id"`
+		Skills         []string `json:"skills"`
+		Senior         bool     `json:"senior"`
+		Active         bool     `json:"active"`
+		MaxActiveItems int      `json:"max_active_items"`
+		ActiveItems    int      `json:"active_items"`
+		LastAssignedAt string   `json:"last_assigned_at,omitempty"`
+		UpdatedAt      string   `json:"updated_at"`
+	} `json:"data"`
+	Timestamp string `json:"timestamp"`
+}
+
+type ModeratorMetricsResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		ModeratorID          string   `json:"moderator_id"`
+		WindowStart          string   `json:"window_start"`
+		WindowEnd            string   `json:"window_end"`
+		Decisions            int      `json:"decisions"`
+		Approved             int      `json:"approved"`
+		Rejected             int      `json:"rejected"`
+		Flagged              int      `json:"flagged"`
+		SLABreaches          int      `json:"sla_breaches"`
+		AverageHandleSeconds float64  `json:"average_handle_seconds"`
+		Reviewed             int      `json:"reviewed"`
+		Overturned           int      `json:"overturned"`
+		Accuracy             *float64 `json:"accuracy"`
+	} `json:"data"`
+	Timestamp string `json:"timestamp"`
+}
+
+type FileAppealRequest struct {
+	Reason string `json:"reason"`
+}
+
+type ResolveAppealRequest struct {
+	Outcome string `json:"outcome"`
+	Notes   string `json:"notes"`
+}
+
+type AppealAuditEntry struct {
+	Action     string `json:"action"`
+	ActorID    string `json:"actor_id"`
+	FromStatus string `json:"from_status,omitempty"`
+	ToStatus   string `json:"to_status,omitempty"`
+	Notes      string `json:"notes,omitempty"`
+	CreatedAt  string `json:"created_at"`
+}
+
+type Appeal struct {
+	AppealID             string             `json:"appeal_id"`
+	DecisionID           string             `json:"decision_id"`
+	SubmissionID         string             `json:"submission_id"`
+	CampaignID           string             `json:"campaign_id"`
+	CreatorID            string             `json:"creator_id"`
+	OriginalModeratorID  string             `json:"original_moderator_id"`
+	OriginalAction       string             `json:"original_action"`
+	OriginalReason       string             `json:"original_reason,omitempty"`
+	Reason               string             `json:"reason"`
+	Status               string             `json:"status"`
+	AssignedModeratorID  string             `json:"assigned_moderator_id,omitempty"`
+	ResolvedByID         string             `json:"resolved_by_id,omitempty"`
+	ResolutionNotes      string             `json:"resolution_notes,omitempty"`
+	ResolutionDecisionID string             `json:"resolution_decision_id,omitempty"`
+	CreatedAt            string             `json:"created_at"`
+	ResolvedAt           string             `json:"resolved_at,omitempty"`
+	Audit                []AppealAuditEntry `json:"audit,omitempty"`
+}
+
+type AppealResponse struct {
+	Status    string `json:"status"`
+	Data      Appeal `json:"data"`
+	Timestamp string `json:"timestamp"`
+}
+
+type AppealListResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		Items []Appeal `json:"items"`
 	} `json:"data"`
 	Timestamp string `json:"timestamp"`
 }
@@ -0,0 +1,27 @@
+package bootstrap
+
+import (
+	"log/slog"
+	"time"
+
+	billingservice "solomon/contexts/finance-core/billing-service"
+	pdfadapter "solomon/contexts/finance-core/billing-service/adapters/pdf"
+	billingpostgres "solomon/contexts/finance-core/billing-service/adapters/postgres"
+	"solomon/internal/platform/db"
+)
+
+// newBillingModule builds the Postgres-backed billing module; tax rates are
+// read from billing_tax_rates.
+func newBillingModule(pg *db.Postgres, logger *slog.Logger) billingservice.Module {
+	repo := billingpostgres.NewRepository(pg.DB, logger)
+	return billingservice.NewModule(billingservice.Dependencies{
+		Repository:     repo,
+		Idempotency:    repo,
+		TaxRates:       repo,
+		Renderer:       pdfadapter.Renderer{},
+		Clock:          billingpostgres.SystemClock{},
+		IDGenerator:    billingpostgres.UUIDGenerator{},
+		IdempotencyTTL: 7 * 24 * time.Hour,
+		Logger:         logger,
+	})
+}
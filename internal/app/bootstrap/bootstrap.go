@@ -20,21 +20,35 @@ import (
 	distributionworkers "solomon/contexts/campaign-editorial/distribution-service/application/workers"
 	submissionservice "solomon/contexts/campaign-editorial/submission-service"
 	submissionpostgres "solomon/contexts/campaign-editorial/submission-service/adapters/postgres"
+	submissioncommands "solomon/contexts/campaign-editorial/submission-service/application/commands"
 	submissionworkers "solomon/contexts/campaign-editorial/submission-service/application/workers"
 	votingengine "solomon/contexts/campaign-editorial/voting-engine"
 	votingpostgres "solomon/contexts/campaign-editorial/voting-engine/adapters/postgres"
 	votingworkers "solomon/contexts/campaign-editorial/voting-engine/application/workers"
+	chatservice "solomon/contexts/community-experience/chat-service"
+	chatpostgres "solomon/contexts/community-experience/chat-service/adapters/postgres"
+	chatscanner "solomon/contexts/community-experience/chat-service/adapters/scanner"
+	chatunfurl "solomon/contexts/community-experience/chat-service/adapters/unfurl"
+	chatports "solomon/contexts/community-experience/chat-service/ports"
+	communityhealthservice "solomon/contexts/community-experience/community-health-service"
+	payoutworkers "solomon/contexts/finance-core/payout-service/application/workers"
 	authorization "solomon/contexts/identity-access/authorization-service"
 	authevents "solomon/contexts/identity-access/authorization-service/adapters/events"
 	authmemory "solomon/contexts/identity-access/authorization-service/adapters/memory"
 	authpostgres "solomon/contexts/identity-access/authorization-service/adapters/postgres"
 	authworkers "solomon/contexts/identity-access/authorization-service/application/workers"
+	admindashboardservice "solomon/contexts/internal-ops/admin-dashboard-service"
+	admindashboardpostgres "solomon/contexts/internal-ops/admin-dashboard-service/adapters/postgres"
+	superadmindashboard "solomon/contexts/internal-ops/super-admin-dashboard"
+	superadminpostgres "solomon/contexts/internal-ops/super-admin-dashboard/adapters/postgres"
 	abusepreventionservice "solomon/contexts/moderation-safety/abuse-prevention-service"
 	abusepostgres "solomon/contexts/moderation-safety/abuse-prevention-service/adapters/postgres"
+	"solomon/internal/platform/auditchain"
 	"solomon/internal/platform/config"
 	"solomon/internal/platform/db"
 	"solomon/internal/platform/httpserver"
 	"solomon/internal/platform/messaging"
+	"solomon/internal/platform/ratelimit"
 )
 
 // Package bootstrap is the composition root.
@@ -61,10 +75,13 @@ type WorkerApp struct {
 	submissionLaunch     submissionworkers.CampaignLaunchedConsumer
 	submissionAuto       submissionworkers.AutoApproveJob
 	submissionViewLock   submissionworkers.ViewLockJob
+	payoutSettlement     *payoutworkers.SettlementJob
 	authzOutbox          authworkers.OutboxRelay
 	votingOutbox         votingworkers.OutboxRelay
 	votingSubmission     votingworkers.SubmissionLifecycleConsumer
 	votingCampaign       votingworkers.CampaignStateConsumer
+	communityHealth      communityhealthservice.Module
+	periodicJobs         []periodicJob
 	pollInterval         time.Duration
 	logger               *slog.Logger
 }
@@ -79,6 +96,24 @@ func BuildAPI() (*APIApp, error) {
 	if strings.TrimSpace(cfg.PostgresDSN) == "" {
 		return nil, errors.New("POSTGRES_DSN is required")
 	}
+	if len(cfg.AdminAuditSigningKey) < 32 {
+		return nil, errors.New("ADMIN_AUDIT_SIGNING_KEY must be at least 32 bytes")
+	}
+	if len(cfg.AdminImpersonationSigningKey) < 32 {
+		return nil, errors.New("ADMIN_IMPERSONATION_SIGNING_KEY must be at least 32 bytes")
+	}
+	if len(cfg.ExportSigningKey) < 32 {
+		return nil, errors.New("EXPORT_SIGNING_KEY must be at least 32 bytes")
+	}
+	if len(cfg.AbuseLoginHookToken) < 32 {
+		return nil, errors.New("ABUSE_LOGIN_HOOK_TOKEN must be at least 32 bytes")
+	}
+	if len(cfg.TeamDirectorySyncToken) < 32 {
+		return nil, errors.New("TEAM_DIRECTORY_SYNC_TOKEN must be at least 32 bytes")
+	}
+	if len(cfg.OnboardingActivityToken) < 32 {
+		return nil, errors.New("ONBOARDING_ACTIVITY_HOOK_TOKEN must be at least 32 bytes")
+	}
 
 	pg, err := db.Connect(cfg.PostgresDSN)
 	if err != nil {
@@ -137,6 +172,8 @@ func BuildAPI() (*APIApp, error) {
 	submissionModule := submissionservice.NewModule(submissionservice.Dependencies{
 		Repository:     submissionRepo,
 		Campaigns:      submissionRepo,
+		Policies:       submissionRepo,
+		Creators:       submissionRepo,
 		Idempotency:    submissionRepo,
 		Outbox:         submissionRepo,
 		Clock:          submissionpostgres.SystemClock{},
@@ -144,6 +181,7 @@ func BuildAPI() (*APIApp, error) {
 		IdempotencyTTL: 7 * 24 * time.Hour,
 		Logger:         logger,
 	})
+	moderationModule := newModerationModule(pg, submissionModule.Handler.ReviewSubmission, logger)
 	distributionRepo := distributionpostgres.NewRepository(pg.DB, logger)
 	distributionModule := distributionservice.NewModule(distributionservice.Dependencies{
 		Repository: distributionRepo,
@@ -163,6 +201,87 @@ func BuildAPI() (*APIApp, error) {
 		Logger:         logger,
 	})
 
+	bus, err := messaging.NewKafka(cfg.KafkaBrokers, logger)
+	if err != nil {
+		_ = pg.Close()
+		return nil, fmt.Errorf("init messaging adapter: %w", err)
+	}
+	attachmentScanner, err := chatscanner.New(chatscanner.Options{
+		Kind:    cfg.ChatAttachmentScanner,
+		Network: cfg.ChatClamdNetwork,
+		Address: cfg.ChatClamdAddr,
+	})
+	if err != nil {
+		_ = pg.Close()
+		return nil, fmt.Errorf("init chat attachment scanner: %w", err)
+	}
+	var linkUnfurler chatports.LinkUnfurler
+	if cfg.ChatLinkUnfurl {
+		linkUnfurler = chatunfurl.HTTPUnfurler{}
+	}
+	chatRepo := chatpostgres.NewRepository(pg.DB, logger)
+	chatModule := chatservice.NewModule(chatservice.Dependencies{
+		Repository:      chatRepo,
+		Idempotency:     chatRepo,
+		Clock:           chatpostgres.SystemClock{},
+		IDGenerator:     chatpostgres.UUIDGenerator{},
+		IdempotencyTTL:  7 * 24 * time.Hour,
+		Logger:          logger,
+		EventPublisher:  bus,
+		EventSubscriber: bus,
+		Outbox:          chatRepo,
+		Scanner:         attachmentScanner,
+		Unfurler:        linkUnfurler,
+		LinkPreviews:    chatRepo,
+	})
+	communityHealthModule, err := newCommunityHealthModule(pg, cfg, bus, logger)
+	if err != nil {
+		_ = pg.Close()
+		return nil, err
+	}
+
+	walletLedgerModule := newWalletLedgerModule(pg, logger)
+	payoutModule := newPayoutModule(pg, cfg, nil, nil, logger)
+	auditSigningKey := []byte(cfg.AdminAuditSigningKey)
+	superAdminModule := superadmindashboard.NewInMemoryModuleWithAuditChain(
+		logger,
+		superadminpostgres.NewAuditChainRepository(pg.DB),
+		auditchain.HMACSigner{Key: auditSigningKey},
+		[]byte(cfg.AdminImpersonationSigningKey),
+		httpserver.SuperAdminWalletLedger(walletLedgerModule),
+	)
+
+	teamManagementModule := newTeamManagementModule(pg, cfg, logger)
+	billingModule := newBillingModule(pg, logger)
+	overrides := httpserver.ModuleOverrides{
+		AbusePrevention:         &abuseModule,
+		Billing:                 &billingModule,
+		Chat:                    &chatModule,
+		CommunityHealth:         &communityHealthModule,
+		Moderation:              &moderationModule,
+		SuperAdmin:              &superAdminModule,
+		WalletLedger:            &walletLedgerModule,
+		Payout:                  &payoutModule,
+		TeamManagement:          &teamManagementModule,
+		TrustedProxies:          cfg.TrustedProxies,
+		AdminAuditSigningKey:    auditSigningKey,
+		AdminAuditChain:         admindashboardpostgres.NewAuditChainRepository(pg.DB),
+		AbuseLoginHookToken:     []byte(cfg.AbuseLoginHookToken),
+		TeamDirectorySyncToken:  []byte(cfg.TeamDirectorySyncToken),
+		OnboardingActivityToken: []byte(cfg.OnboardingActivityToken),
+		Exports:                 newExportService(pg, cfg, logger),
+	}
+	switch cfg.RateLimitBackend {
+	case "memory":
+	case "postgres":
+		overrides.RateLimitStore = ratelimit.NewPostgresStore(pg.DB)
+	default:
+		_ = pg.Close()
+		return nil, fmt.Errorf("unknown rate limit backend %q", cfg.RateLimitBackend)
+	}
+	onboardingModule := newOnboardingModule(pg, cfg, bus, logger)
+	overrides.Onboarding = &onboardingModule
+
 	server, err := httpserver.NewWithOverrides(
 		module,
 		authModule,
@@ -172,9 +291,7 @@ func BuildAPI() (*APIApp, error) {
 		votingModule,
 		logger,
 		normalizeAddr(cfg.HTTPPort),
-		httpserver.ModuleOverrides{
-			AbusePrevention: &abuseModule,
-		},
+		overrides,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("build http server: %w", err)
@@ -196,6 +313,12 @@ func BuildWorker() (*WorkerApp, error) {
 	if strings.TrimSpace(cfg.PostgresDSN) == "" {
 		return nil, errors.New("POSTGRES_DSN is required")
 	}
+	if len(cfg.AdminAuditSigningKey) < 32 {
+		return nil, errors.New("ADMIN_AUDIT_SIGNING_KEY must be at least 32 bytes")
+	}
+	if err := validatePayoutSettlement(cfg); err != nil {
+		return nil, err
+	}
 
 	pg, err := db.Connect(cfg.PostgresDSN)
 	if err != nil {
@@ -222,6 +345,47 @@ func BuildWorker() (*WorkerApp, error) {
 		Logger:     logger,
 	}
 	authPublisher := authevents.NewKafkaPublisher(kafka, logger, "authz.policy_changed")
+	walletLedgerModule := newWalletLedgerModule(pg, logger)
+	payoutModule := newPayoutModule(pg, cfg, payoutSubmissionEarnings{
+		settlement: submissioncommands.PayoutSettlementUseCase{
+			Repository: submissionRepo,
+			Payouts:    submissionRepo,
+			Clock:      submissionpostgres.SystemClock{},
+			IDGen:      submissionpostgres.UUIDGenerator{},
+			Logger:     logger,
+		},
+	}, payoutSettlementLedger{module: walletLedgerModule}, logger)
+	moderationModule := newModerationModule(pg, submissioncommands.ReviewSubmissionUseCase{
+		Repository:     submissionRepo,
+		Clock:          submissionpostgres.SystemClock{},
+		IDGen:          submissionpostgres.UUIDGenerator{},
+		Outbox:         submissionRepo,
+		Idempotency:    submissionRepo,
+		IdempotencyTTL: 7 * 24 * time.Hour,
+		Logger:         logger,
+	}, logger)
+	communityHealthModule, err := newCommunityHealthModule(pg, cfg, kafka, logger)
+	if err != nil {
+		_ = pg.Close()
+		return nil, err
+	}
+	if communityHealthModule.ChatConsumer != nil {
+		communityHealthModule.ChatConsumer.ConsumerGroup = communityHealthReportsConsumerGroup
+	}
+	auditSigner := auditchain.HMACSigner{Key: []byte(cfg.AdminAuditSigningKey)}
+	// Only the anchor job runs here, so the impersonation key and wallet
+	// ledger are left unset.
+	superAdminModule := superadmindashboard.NewInMemoryModuleWithAuditChain(
+		logger,
+		superadminpostgres.NewAuditChainRepository(pg.DB),
+		auditSigner,
+		nil,
+		nil,
+	)
+	adminDashboardModule := admindashboardservice.NewModule(admindashboardservice.Dependencies{
+		Repository:  admindashboardpostgres.NewAuditChainRepository(pg.DB),
+		AuditSigner: auditSigner,
+	})
 	return &WorkerApp{
 		postgres: pg,
 		outboxRelay: workerapp.OutboxRelay{
@@ -314,11 +478,13 @@ func BuildWorker() (*WorkerApp, error) {
 			Clock:           submissionpostgres.SystemClock{},
 			IDGen:           submissionpostgres.UUIDGenerator{},
 			Outbox:          submissionRepo,
+			Ledger:          submissionEarningsLedger{module: walletLedgerModule},
 			BatchSize:       100,
 			PlatformFeeRate: 0.15,
 			Disabled:        !cfg.EnableM26ViewLock,
 			Logger:          logger,
 		},
+		payoutSettlement: payoutModule.Settlement,
 		campaignDeadlineJob: campaignworkers.DeadlineCompleter{
 			Campaigns: campaignRepo,
 			Clock:     campaignpostgres.SystemClock{},
@@ -364,6 +530,17 @@ func BuildWorker() (*WorkerApp, error) {
 			Disabled:      !cfg.EnableM08CampaignConsumer,
 			Logger:        logger,
 		},
+		communityHealth: communityHealthModule,
+		// These jobs must run once per deployment, so they live in the
+		// worker rather than on every API replica.
+		periodicJobs: []periodicJob{
+			{name: "community_health_weekly_reports", interval: time.Hour, run: communityHealthModule.GenerateWeeklyReports},
+			{name: "moderation_queue_assignment", interval: 30 * time.Second, run: moderationModule.AssignQueue},
+			{name: "moderation_sla_escalation", interval: time.Minute, run: moderationModule.EscalateQueue},
+			{name: "super_admin_audit_anchor", interval: time.Hour, run: superAdminModule.AnchorAuditChain},
+			{name: "admin_control_plane_audit_anchor", interval: time.Hour, run: adminDashboardModule.AnchorAuditChain},
+			{name: "wallet_ledger_reconciliation", interval: 15 * time.Minute, run: walletLedgerModule.Reconcile},
+		},
 		pollInterval: 500 * time.Millisecond,
 		logger:       logger,
 	}, nil
@@ -420,6 +597,12 @@ func (w *WorkerApp) Run(ctx context.Context) error {
 	if err := w.votingCampaign.Start(ctx); err != nil {
 		return fmt.Errorf("start voting campaign state consumer: %w", err)
 	}
+	if err := w.communityHealth.StartConsumers(ctx); err != nil {
+		return fmt.Errorf("start community health chat consumer: %w", err)
+	}
+	for _, job := range w.periodicJobs {
+		go job.runEvery(ctx, logger)
+	}
 
 	ticker := time.NewTicker(w.pollInterval)
 	defer ticker.Stop()
@@ -456,6 +639,9 @@ func (w *WorkerApp) Run(ctx context.Context) error {
 		if err := w.submissionViewLock.RunOnce(ctx); err != nil {
 			return fmt.Errorf("run submission view-lock job: %w", err)
 		}
+		if err := w.payoutSettlement.RunOnce(ctx); err != nil {
+			return fmt.Errorf("run payout settlement job: %w", err)
+		}
 		if err := w.submissionOutbox.RunOnce(ctx); err != nil {
 			return fmt.Errorf("run submission outbox relay: %w", err)
 		}
@@ -473,6 +659,35 @@ func (w *WorkerApp) Run(ctx context.Context) error {
 	}
 }
 
+// periodicJob runs on its own interval beside the poll loop. A failed run is
+// logged and retried on the next tick instead of stopping the worker.
+type periodicJob struct {
+	name     string
+	interval time.Duration
+	run      func(context.Context) error
+}
+
+func (j periodicJob) runEvery(ctx context.Context, logger *slog.Logger) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := j.run(ctx); err != nil {
+			logger.Warn("worker periodic job failed",
+				"event", "bootstrap_worker_periodic_job_failed",
+				"module", "internal/app/bootstrap",
+				"layer", "platform",
+				"job", j.name,
+				"error", err.Error(),
+			)
+		}
+	}
+}
+
 func (w *WorkerApp) Close() error {
 	if w.postgres != nil {
 		return w.postgres.Close()
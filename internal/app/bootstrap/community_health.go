@@ -0,0 +1,46 @@
+package bootstrap
+
+import (
+	"fmt"
+	"log/slog"
+
+	communityhealthservice "solomon/contexts/community-experience/community-health-service"
+	communityhealthclassifier "solomon/contexts/community-experience/community-health-service/adapters/classifier"
+	communityhealthpostgres "solomon/contexts/community-experience/community-health-service/adapters/postgres"
+	communityhealthports "solomon/contexts/community-experience/community-health-service/ports"
+	"solomon/internal/platform/config"
+	"solomon/internal/platform/db"
+)
+
+// communityHealthReportsConsumerGroup is the worker's own consumer group, so
+// the weekly report job scores every chat message rather than the share of
+// partitions one API replica is assigned.
+const communityHealthReportsConsumerGroup = "community-health-service-weekly-reports-cg"
+
+// newCommunityHealthModule builds the community health module: scores are
+// kept in memory from the chat.message.* events on subscriber and weekly
+// reports are stored in Postgres.
+func newCommunityHealthModule(
+	pg *db.Postgres,
+	cfg config.Config,
+	subscriber communityhealthports.EventSubscriber,
+	logger *slog.Logger,
+) (communityhealthservice.Module, error) {
+	messageClassifier, err := communityhealthclassifier.New(communityhealthclassifier.Options{
+		Kind:              cfg.CommunityHealthClassifier,
+		ConfigPath:        cfg.CommunityHealthClassifierConfig,
+		Endpoint:          cfg.CommunityHealthClassifierURL,
+		APIKey:            cfg.CommunityHealthClassifierAPIKey,
+		FallbackToLexicon: true,
+		Logger:            logger,
+	})
+	if err != nil {
+		return communityhealthservice.Module{}, fmt.Errorf("init community health classifier: %w", err)
+	}
+	return communityhealthservice.NewInMemoryModuleWithReports(
+		logger,
+		subscriber,
+		messageClassifier,
+		communityhealthpostgres.NewReportRepository(pg.DB, logger),
+	), nil
+}
@@ -0,0 +1,24 @@
+package bootstrap
+
+import (
+	"log/slog"
+
+	"solomon/internal/platform/config"
+	"solomon/internal/platform/db"
+	"solomon/internal/platform/export"
+)
+
+// newExportService keeps export jobs in Postgres so any instance's worker
+// can run them, and files in the shared export directory.
+func newExportService(pg *db.Postgres, cfg config.Config, logger *slog.Logger) *export.Service {
+	return &export.Service{
+		Jobs:        export.NewPostgresJobStore(pg.DB),
+		Storage:     export.DirStorage{Root: cfg.ExportStorageDir},
+		SigningKey:  []byte(cfg.ExportSigningKey),
+		BaseURL:     cfg.ExportBaseURL,
+		MaxRowLimit: cfg.ExportMaxRows,
+		FileTTL:     cfg.ExportFileTTL,
+		LinkTTL:     cfg.ExportLinkTTL,
+		Logger:      logger,
+	}
+}
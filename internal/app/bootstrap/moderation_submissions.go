@@ -0,0 +1,92 @@
+package bootstrap
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"strings"
+	"time"
+
+	submissioncommands "solomon/contexts/campaign-editorial/submission-service/application/commands"
+	moderationservice "solomon/contexts/moderation-safety/moderation-service"
+	moderationpostgres "solomon/contexts/moderation-safety/moderation-service/adapters/postgres"
+	moderationports "solomon/contexts/moderation-safety/moderation-service/ports"
+	"solomon/internal/platform/db"
+)
+
+// newModerationModule builds the Postgres-backed moderation module whose
+// decisions go through review. The API serves its handlers and the worker
+// runs its queue assignment and escalation jobs.
+func newModerationModule(
+	pg *db.Postgres,
+	review submissioncommands.ReviewSubmissionUseCase,
+	logger *slog.Logger,
+) moderationservice.Module {
+	repo := moderationpostgres.NewRepository(pg.DB, logger)
+	return moderationservice.NewModule(moderationservice.Dependencies{
+		Repository:       repo,
+		Idempotency:      repo,
+		SubmissionClient: moderationSubmissionClient{review: review},
+		Clock:            moderationpostgres.SystemClock{},
+		IdempotencyTTL:   7 * 24 * time.Hour,
+		LeaseTTL:         15 * time.Minute,
+		Logger:           logger,
+	})
+}
+
+// moderationSubmissionClient routes moderation outcomes through the
+// submission service, which owns the submissions table, its audit trail and
+// the submission.* events. Idempotency keys are derived from the moderation
+// action so a retried moderation request replays instead of failing on the
+// submission's status transition.
+type moderationSubmissionClient struct {
+	review submissioncommands.ReviewSubmissionUseCase
+}
+
+func (c moderationSubmissionClient) ApproveSubmission(ctx context.Context, submissionID string, moderatorID string, reason string) error {
+	return c.review.Approve(ctx, submissioncommands.ApproveSubmissionCommand{
+		IdempotencyKey: moderationSubmissionKey("approve", submissionID, moderatorID, reason),
+		SubmissionID:   submissionID,
+		ActorID:        moderatorID,
+		Reason:         reason,
+	})
+}
+
+func (c moderationSubmissionClient) RejectSubmission(ctx context.Context, submissionID string, moderatorID string, reason string, notes string) error {
+	return c.review.Reject(ctx, submissioncommands.RejectSubmissionCommand{
+		IdempotencyKey: moderationSubmissionKey("reject", submissionID, moderatorID, reason, notes),
+		SubmissionID:   submissionID,
+		ActorID:        moderatorID,
+		Reason:         reason,
+		Notes:          notes,
+	})
+}
+
+func (c moderationSubmissionClient) DisputeSubmission(ctx context.Context, submissionID string, creatorID string, appealID string, reason string) error {
+	return c.review.Dispute(ctx, submissioncommands.DisputeSubmissionCommand{
+		IdempotencyKey: moderationSubmissionKey("dispute", appealID),
+		SubmissionID:   submissionID,
+		ActorID:        creatorID,
+		AppealID:       appealID,
+		Reason:         reason,
+	})
+}
+
+func (c moderationSubmissionClient) ResolveDispute(ctx context.Context, submissionID string, moderatorID string, appealID string, reversed bool, notes string) error {
+	return c.review.ResolveDispute(ctx, submissioncommands.ResolveDisputeCommand{
+		IdempotencyKey: moderationSubmissionKey("resolve_dispute", appealID),
+		SubmissionID:   submissionID,
+		ActorID:        moderatorID,
+		AppealID:       appealID,
+		Reversed:       reversed,
+		Notes:          notes,
+	})
+}
+
+func moderationSubmissionKey(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "|")))
+	return "moderation_" + hex.EncodeToString(sum[:16])
+}
+
+var _ moderationports.SubmissionDecisionClient = moderationSubmissionClient{}
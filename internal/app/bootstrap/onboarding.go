@@ -0,0 +1,44 @@
+package bootstrap
+
+import (
+	"log/slog"
+	"time"
+
+	onboardingservice "solomon/contexts/identity-access/onboarding-service"
+	onboardingnotification "solomon/contexts/identity-access/onboarding-service/adapters/notification"
+	onboardingpostgres "solomon/contexts/identity-access/onboarding-service/adapters/postgres"
+	onboardingports "solomon/contexts/identity-access/onboarding-service/ports"
+	"solomon/internal/platform/config"
+	"solomon/internal/platform/db"
+)
+
+// newOnboardingModule builds the Postgres-backed onboarding module. Reminders
+// go out over SMTP when it is configured and are recorded in process
+// otherwise; subscriber feeds the activity events that complete steps.
+func newOnboardingModule(
+	pg *db.Postgres,
+	cfg config.Config,
+	subscriber onboardingports.EventSubscriber,
+	logger *slog.Logger,
+) onboardingservice.Module {
+	var notifier onboardingports.Notifier = onboardingnotification.NewRecorder()
+	if cfg.OnboardingSMTPAddr != "" {
+		notifier = onboardingnotification.SMTPNotifier{
+			Addr:  cfg.OnboardingSMTPAddr,
+			From:  cfg.OnboardingSMTPFrom,
+			InApp: onboardingnotification.NewRecorder(),
+		}
+	}
+	repo := onboardingpostgres.NewRepository(pg.DB, logger)
+	return onboardingservice.NewModule(onboardingservice.Dependencies{
+		Repository:      repo,
+		Idempotency:     repo,
+		Outbox:          repo,
+		Notifier:        notifier,
+		EventSubscriber: subscriber,
+		Clock:           onboardingpostgres.SystemClock{},
+		IDGenerator:     onboardingpostgres.UUIDGenerator{},
+		IdempotencyTTL:  7 * 24 * time.Hour,
+		Logger:          logger,
+	})
+}
@@ -0,0 +1,136 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"time"
+
+	submissioncommands "solomon/contexts/campaign-editorial/submission-service/application/commands"
+	payoutservice "solomon/contexts/finance-core/payout-service"
+	payoutpostgres "solomon/contexts/finance-core/payout-service/adapters/postgres"
+	payoutprovider "solomon/contexts/finance-core/payout-service/adapters/provider"
+	payoutports "solomon/contexts/finance-core/payout-service/ports"
+	walletledgerservice "solomon/contexts/finance-core/wallet-ledger-service"
+	walletledgerhttp "solomon/contexts/finance-core/wallet-ledger-service/transport/http"
+	"solomon/internal/platform/config"
+	"solomon/internal/platform/db"
+	"solomon/internal/platform/httpserver"
+)
+
+// validatePayoutSettlement refuses to run settlement without a legal hold
+// checker, which would pay held creators, or with the fake provider, the
+// only one so far, unless PAYOUT_FAKE_PROVIDER enables it for development.
+func validatePayoutSettlement(cfg config.Config) error {
+	if !cfg.EnableM14PayoutSettlement {
+		return nil
+	}
+	if cfg.PayoutLegalHoldBaseURL == "" {
+		return errors.New("PAYOUT_LEGAL_HOLD_BASE_URL is required when ENABLE_M14_PAYOUT_SETTLEMENT is on")
+	}
+	if !cfg.PayoutFakeProvider {
+		return errors.New("no payout provider is configured; set PAYOUT_FAKE_PROVIDER=true in development or turn off ENABLE_M14_PAYOUT_SETTLEMENT")
+	}
+	return nil
+}
+
+// newPayoutModule builds the Postgres-backed payout module. Without an
+// earnings source (the API process) it only serves lookups and admin
+// retries; the worker passes the submission service and the wallet ledger
+// and, once validatePayoutSettlement passed, runs settlement.
+func newPayoutModule(
+	pg *db.Postgres,
+	cfg config.Config,
+	earnings payoutports.EarningsSource,
+	ledger payoutports.SettlementLedger,
+	logger *slog.Logger,
+) payoutservice.Module {
+	settle := earnings != nil && cfg.EnableM14PayoutSettlement
+	deps := payoutservice.Dependencies{
+		Repository:         payoutpostgres.NewRepository(pg.DB, logger),
+		Earnings:           earnings,
+		Ledger:             ledger,
+		Clock:              payoutpostgres.SystemClock{},
+		IDGenerator:        payoutpostgres.UUIDGenerator{},
+		SettlementInterval: time.Minute,
+		DisableSettlement:  !settle,
+		Logger:             logger,
+	}
+	if settle {
+		deps.LegalHolds = httpserver.PayoutLegalHolds(cfg.PayoutLegalHoldBaseURL)
+		deps.Provider = payoutprovider.NewFake()
+		logger.Warn("payout settlement uses the fake provider; no money leaves the platform",
+			"event", "payout_fake_provider_enabled",
+			"module", "internal/app/bootstrap",
+			"layer", "bootstrap",
+		)
+	}
+	return payoutservice.NewModule(deps)
+}
+
+// payoutSettlementLedger reserves payouts out of creators' wallets in the
+// wallet ledger before they are transferred, and reverses reservations whose
+// transfer failed for good. Entries are keyed by payout and reservation, so
+// a retried settlement posts once.
+type payoutSettlementLedger struct {
+	module walletledgerservice.Module
+}
+
+func (l payoutSettlementLedger) PostPayoutSettlement(ctx context.Context, payout payoutports.Payout) error {
+	_, err := l.module.Handler.PostPayoutSettlementHandler(ctx, payoutSettlementRequest(payout))
+	return err
+}
+
+func (l payoutSettlementLedger) ReversePayoutSettlement(ctx context.Context, payout payoutports.Payout) error {
+	_, err := l.module.Handler.ReversePayoutSettlementHandler(ctx, payoutSettlementRequest(payout))
+	return err
+}
+
+func payoutSettlementRequest(payout payoutports.Payout) walletledgerhttp.PayoutSettlementRequest {
+	return walletledgerhttp.PayoutSettlementRequest{
+		PayoutID:    payout.PayoutID,
+		CreatorID:   payout.CreatorID,
+		AmountCents: payout.AmountCents,
+		Reservation: payout.LedgerReleases + 1,
+	}
+}
+
+// payoutSubmissionEarnings exposes view-locked submissions to the payout
+// service and records their reward_eligible and paid transitions.
+type payoutSubmissionEarnings struct {
+	settlement submissioncommands.PayoutSettlementUseCase
+}
+
+func (e payoutSubmissionEarnings) ListMaturedEarnings(
+	ctx context.Context,
+	lockedBefore time.Time,
+	limit int,
+) ([]payoutports.Earning, error) {
+	submissions, err := e.settlement.ListMaturedEarnings(ctx, lockedBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]payoutports.Earning, 0, len(submissions))
+	for _, submission := range submissions {
+		earning := payoutports.Earning{
+			SubmissionID: submission.SubmissionID,
+			CreatorID:    submission.CreatorID,
+			CampaignID:   submission.CampaignID,
+			AmountCents:  int64(math.Round(submission.NetAmount * 100)),
+		}
+		if submission.LockedAt != nil {
+			earning.LockedAt = submission.LockedAt.UTC()
+		}
+		items = append(items, earning)
+	}
+	return items, nil
+}
+
+func (e payoutSubmissionEarnings) MarkRewardEligible(ctx context.Context, submissionID string) error {
+	return e.settlement.MarkRewardEligible(ctx, submissionID)
+}
+
+func (e payoutSubmissionEarnings) MarkPaid(ctx context.Context, submissionID string, payoutID string) error {
+	return e.settlement.MarkPaid(ctx, submissionID, payoutID)
+}
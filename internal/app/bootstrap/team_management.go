@@ -0,0 +1,54 @@
+package bootstrap
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	teammanagementservice "solomon/contexts/internal-ops/team-management-service"
+	teammailer "solomon/contexts/internal-ops/team-management-service/adapters/mailer"
+	teampostgres "solomon/contexts/internal-ops/team-management-service/adapters/postgres"
+	teamsso "solomon/contexts/internal-ops/team-management-service/adapters/sso"
+	teamports "solomon/contexts/internal-ops/team-management-service/ports"
+	"solomon/internal/platform/config"
+	"solomon/internal/platform/db"
+)
+
+// newTeamManagementModule builds the Postgres-backed team module and wires
+// invite delivery and expiry from config. SMTP wins over the file sink; with
+// neither, invites are not emailed. The user directory and SSO connections
+// live in the same repository.
+func newTeamManagementModule(pg *db.Postgres, cfg config.Config, logger *slog.Logger) teammanagementservice.Module {
+	var mailer teamports.InviteMailer
+	switch {
+	case cfg.TeamInviteSMTPAddr != "":
+		mailer = teammailer.SMTPMailer{
+			Addr:      cfg.TeamInviteSMTPAddr,
+			From:      cfg.TeamInviteSMTPFrom,
+			AcceptURL: cfg.TeamInviteAcceptURL,
+		}
+	case cfg.TeamInviteMailDir != "":
+		mailer = teammailer.FileSink{
+			Dir:       cfg.TeamInviteMailDir,
+			From:      cfg.TeamInviteSMTPFrom,
+			AcceptURL: cfg.TeamInviteAcceptURL,
+		}
+	}
+	repo := teampostgres.NewRepository(pg.DB, logger)
+	return teammanagementservice.NewModule(teammanagementservice.Dependencies{
+		Repository:     repo,
+		Idempotency:    repo,
+		Mailer:         mailer,
+		Clock:          teampostgres.SystemClock{},
+		IDGenerator:    teampostgres.UUIDGenerator{},
+		IdempotencyTTL: 7 * 24 * time.Hour,
+		InviteTTL:      cfg.TeamInviteTTL,
+		Logger:         logger,
+		Directory:      repo,
+		SSO:            repo,
+		OIDC:           teamsso.NewOIDCProvider(&http.Client{Timeout: 10 * time.Second}),
+		SAML:           teamsso.SAMLProvider{},
+		SSOBaseURL:     cfg.TeamSSOBaseURL,
+		SSOLoginTTL:    cfg.TeamSSOLoginTTL,
+	})
+}
@@ -0,0 +1,51 @@
+package bootstrap
+
+import (
+	"context"
+	"log/slog"
+	"math"
+
+	submissionentities "solomon/contexts/campaign-editorial/submission-service/domain/entities"
+	walletledgerservice "solomon/contexts/finance-core/wallet-ledger-service"
+	walletledgerpostgres "solomon/contexts/finance-core/wallet-ledger-service/adapters/postgres"
+	walletledgerhttp "solomon/contexts/finance-core/wallet-ledger-service/transport/http"
+	"solomon/internal/platform/db"
+)
+
+func newWalletLedgerModule(pg *db.Postgres, logger *slog.Logger) walletledgerservice.Module {
+	return walletledgerservice.NewModule(walletledgerservice.Dependencies{
+		Repository:  walletledgerpostgres.NewRepository(pg.DB, logger),
+		Clock:       walletledgerpostgres.SystemClock{},
+		IDGenerator: walletledgerpostgres.UUIDGenerator{},
+		Logger:      logger,
+	})
+}
+
+// submissionEarningsLedger posts view-locked earnings through the wallet
+// ledger: the campaign's account pays the gross, the creator's wallet gets
+// the net and the platform fees account gets the fee the view-lock job
+// computed.
+type submissionEarningsLedger struct {
+	module walletledgerservice.Module
+}
+
+func (l submissionEarningsLedger) PostViewLockEarning(ctx context.Context, submission submissionentities.Submission) error {
+	grossCents := int64(math.Round(submission.GrossAmount * 100))
+	if grossCents <= 0 {
+		return nil
+	}
+	// The fee is derived from the rounded gross and net so the entry
+	// balances to the cent.
+	feeCents := grossCents - int64(math.Round(submission.NetAmount*100))
+	if feeCents < 0 {
+		feeCents = 0
+	}
+	_, err := l.module.Handler.PostViewLockEarningHandler(ctx, walletledgerhttp.ViewLockEarningRequest{
+		SubmissionID: submission.SubmissionID,
+		CreatorID:    submission.CreatorID,
+		CampaignID:   submission.CampaignID,
+		GrossCents:   grossCents,
+		FeeCents:     feeCents,
+	})
+	return err
+}
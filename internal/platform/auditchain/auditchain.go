@@ -0,0 +1,286 @@
+// Package auditchain links audit entries into a signed hash chain. Each
+// module keeps its own canonical entry encoding; this package hashes and
+// signs that content, links every entry to the previous entry's hash,
+// retries appends that lose the race for the next sequence, and walks a
+// stored chain and its anchors to find the first break.
+package auditchain
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GenesisHash is the previous hash of the first entry in a chain.
+var GenesisHash = strings.Repeat("0", 64)
+
+// Reasons a chain walk stops at an entry or anchor.
+const (
+	BreakSequenceGap      = "sequence_gap"
+	BreakPrevHashMismatch = "prev_hash_mismatch"
+	BreakEntryHash        = "entry_hash_mismatch"
+	BreakSignature        = "signature_invalid"
+	BreakAnchorMismatch   = "anchor_mismatch"
+	BreakAnchorMissing    = "anchor_beyond_head"
+	BreakAnchorSignature  = "anchor_signature_invalid"
+)
+
+const (
+	appendAttempts = 5
+	verifyBatch    = 500
+)
+
+// Signer signs canonical audit content. Signatures carry an algorithm
+// prefix so a key or algorithm rotation can be told apart from tampering.
+type Signer interface {
+	Sign(content []byte) string
+	Verify(content []byte, signature string) bool
+}
+
+// HMACSigner signs with HMAC-SHA256 over a shared secret.
+type HMACSigner struct {
+	Key []byte
+}
+
+const hmacSignaturePrefix = "hmac-sha256:"
+
+func (s HMACSigner) Sign(content []byte) string {
+	mac := hmac.New(sha256.New, s.Key)
+	mac.Write(content)
+	return hmacSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s HMACSigner) Verify(content []byte, signature string) bool {
+	if len(s.Key) == 0 || !strings.HasPrefix(signature, hmacSignaturePrefix) {
+		return false
+	}
+	return hmac.Equal([]byte(s.Sign(content)), []byte(signature))
+}
+
+// CanonicalTime truncates to the precision stored by Postgres, so signed
+// timestamps survive a database round trip.
+func CanonicalTime(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Microsecond)
+}
+
+// Chain seals and verifies the entries of one audit chain.
+type Chain struct {
+	Signer Signer
+	// AnchorDomain prefixes signed anchor content, so an anchor signed for
+	// one chain never verifies against another chain sharing the key.
+	AnchorDomain string
+}
+
+// Seal returns the entry hash and signature of canonical entry content
+// whose sequence and previous hash are already set.
+func (c Chain) Seal(content []byte) (string, string, error) {
+	if c.Signer == nil {
+		return "", "", errors.New("audit signer is not configured")
+	}
+	return hashContent(content), c.Signer.Sign(content), nil
+}
+
+// CheckEntry recomputes one entry's hash and signature in isolation. It
+// returns "" when both match, otherwise the break reason.
+func (c Chain) CheckEntry(content []byte, entryHash string, signature string) string {
+	if content == nil || hashContent(content) != entryHash {
+		return BreakEntryHash
+	}
+	if c.Signer == nil || !c.Signer.Verify(content, signature) {
+		return BreakSignature
+	}
+	return ""
+}
+
+// AnchorContent is the signed content of a chain head anchor.
+func (c Chain) AnchorContent(sequence int64, entryHash string, anchoredAt time.Time) []byte {
+	return []byte(strings.Join([]string{
+		c.AnchorDomain,
+		strconv.FormatInt(sequence, 10),
+		entryHash,
+		CanonicalTime(anchoredAt).Format(time.RFC3339Nano),
+	}, "|"))
+}
+
+// Anchor records a chain head at a point in time.
+type Anchor struct {
+	AnchorID   string
+	Sequence   int64
+	EntryHash  string
+	Signature  string
+	AnchoredAt time.Time
+}
+
+// SignAnchor returns the anchor for a chain head with its signature set.
+func (c Chain) SignAnchor(anchorID string, sequence int64, entryHash string, anchoredAt time.Time) Anchor {
+	anchoredAt = CanonicalTime(anchoredAt)
+	return Anchor{
+		AnchorID:   anchorID,
+		Sequence:   sequence,
+		EntryHash:  entryHash,
+		Signature:  c.Signer.Sign(c.AnchorContent(sequence, entryHash, anchoredAt)),
+		AnchoredAt: anchoredAt,
+	}
+}
+
+// Append links a new entry to the chain head and stores it. write seals
+// and stores the entry with the given sequence and previous hash; when it
+// returns conflict, a concurrent append took the sequence first and the
+// head is read again. Append returns conflict once its attempts run out.
+func Append(
+	ctx context.Context,
+	head func(context.Context) (sequence int64, entryHash string, found bool, err error),
+	write func(ctx context.Context, sequence int64, prevHash string) error,
+	conflict error,
+) error {
+	for attempt := 0; attempt < appendAttempts; attempt++ {
+		headSequence, headHash, found, err := head(ctx)
+		if err != nil {
+			return err
+		}
+		sequence, prevHash := int64(1), GenesisHash
+		if found {
+			sequence, prevHash = headSequence+1, headHash
+		}
+		err = write(ctx, sequence, prevHash)
+		if errors.Is(err, conflict) {
+			continue
+		}
+		return err
+	}
+	return conflict
+}
+
+// Verifier walks entries in sequence order and reports the first link that
+// does not follow from the one before it.
+type Verifier struct {
+	chain    Chain
+	next     int64
+	prevHash string
+}
+
+func (c Chain) NewVerifier() *Verifier {
+	return &Verifier{chain: c, next: 1, prevHash: GenesisHash}
+}
+
+// Check verifies the next entry and returns "" when it extends the chain.
+func (v *Verifier) Check(entry Entry) string {
+	if entry.Sequence != v.next {
+		return BreakSequenceGap
+	}
+	if entry.PrevHash != v.prevHash {
+		return BreakPrevHashMismatch
+	}
+	if reason := v.chain.CheckEntry(entry.Content, entry.EntryHash, entry.Signature); reason != "" {
+		return reason
+	}
+	v.next++
+	v.prevHash = entry.EntryHash
+	return ""
+}
+
+// Entry is a stored chain entry with its canonical content. A nil Content
+// means the entry could not be encoded and is reported as a hash mismatch.
+type Entry struct {
+	Sequence  int64
+	AuditID   string
+	PrevHash  string
+	Content   []byte
+	EntryHash string
+	Signature string
+}
+
+// Source reads a stored chain in sequence order, and its anchors.
+type Source interface {
+	ListEntries(ctx context.Context, afterSequence int64, limit int) ([]Entry, error)
+	ListAnchors(ctx context.Context) ([]Anchor, error)
+}
+
+// Break is the first entry or anchor that failed verification.
+type Break struct {
+	Sequence int64
+	AuditID  string
+	AnchorID string
+	Reason   string
+}
+
+// Report is the result of walking a whole chain.
+type Report struct {
+	Valid          bool
+	EntriesChecked int64
+	AnchorsChecked int
+	HeadSequence   int64
+	HeadHash       string
+	FirstBreak     *Break
+}
+
+// Verify walks the chain from genesis, checking sequence continuity, hash
+// links and signatures, then checks every anchor against the entry it
+// recorded. Deleting the tail of the chain is only visible once an anchor
+// covers it.
+func (c Chain) Verify(ctx context.Context, source Source) (Report, error) {
+	anchors, err := source.ListAnchors(ctx)
+	if err != nil {
+		return Report{}, err
+	}
+	anchored := make(map[int64]string, len(anchors))
+	for _, anchor := range anchors {
+		anchored[anchor.Sequence] = ""
+	}
+
+	report := Report{Valid: true}
+	verifier := c.NewVerifier()
+	var after int64
+	for {
+		batch, err := source.ListEntries(ctx, after, verifyBatch)
+		if err != nil {
+			return Report{}, err
+		}
+		for _, entry := range batch {
+			if reason := verifier.Check(entry); reason != "" {
+				report.Valid = false
+				report.FirstBreak = &Break{Sequence: entry.Sequence, AuditID: entry.AuditID, Reason: reason}
+				return report, nil
+			}
+			report.EntriesChecked++
+			report.HeadSequence = entry.Sequence
+			report.HeadHash = entry.EntryHash
+			if _, ok := anchored[entry.Sequence]; ok {
+				anchored[entry.Sequence] = entry.EntryHash
+			}
+		}
+		if len(batch) < verifyBatch {
+			break
+		}
+		after = batch[len(batch)-1].Sequence
+	}
+
+	for _, anchor := range anchors {
+		reason := ""
+		switch {
+		case c.Signer == nil || !c.Signer.Verify(c.AnchorContent(anchor.Sequence, anchor.EntryHash, anchor.AnchoredAt), anchor.Signature):
+			reason = BreakAnchorSignature
+		case anchor.Sequence > report.HeadSequence:
+			reason = BreakAnchorMissing
+		case anchored[anchor.Sequence] != anchor.EntryHash:
+			reason = BreakAnchorMismatch
+		}
+		if reason != "" {
+			report.Valid = false
+			report.FirstBreak = &Break{Sequence: anchor.Sequence, AnchorID: anchor.AnchorID, Reason: reason}
+			return report, nil
+		}
+		report.AnchorsChecked++
+	}
+	return report, nil
+}
+
+func hashContent(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,137 @@
+package auditchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type sliceSource struct {
+	entries []Entry
+	anchors []Anchor
+}
+
+func (s *sliceSource) ListEntries(_ context.Context, afterSequence int64, limit int) ([]Entry, error) {
+	out := make([]Entry, 0, limit)
+	for _, entry := range s.entries {
+		if entry.Sequence > afterSequence && len(out) < limit {
+			out = append(out, entry)
+		}
+	}
+	return out, nil
+}
+
+func (s *sliceSource) ListAnchors(context.Context) ([]Anchor, error) {
+	return s.anchors, nil
+}
+
+func (s *sliceSource) head(context.Context) (int64, string, bool, error) {
+	if len(s.entries) == 0 {
+		return 0, "", false, nil
+	}
+	last := s.entries[len(s.entries)-1]
+	return last.Sequence, last.EntryHash, true, nil
+}
+
+var errConflict = errors.New("conflict")
+
+func appendEntries(t *testing.T, chain Chain, source *sliceSource, count int) {
+	t.Helper()
+	for i := 0; i < count; i++ {
+		err := Append(context.Background(), source.head, func(_ context.Context, sequence int64, prevHash string) error {
+			content := []byte(fmt.Sprintf(`{"sequence":%d,"prev_hash":%q}`, sequence, prevHash))
+			entryHash, signature, err := chain.Seal(content)
+			if err != nil {
+				return err
+			}
+			source.entries = append(source.entries, Entry{
+				Sequence:  sequence,
+				AuditID:   fmt.Sprintf("audit_%d", sequence),
+				PrevHash:  prevHash,
+				Content:   content,
+				EntryHash: entryHash,
+				Signature: signature,
+			})
+			return nil
+		}, errConflict)
+		if err != nil {
+			t.Fatalf("append: %v", err)
+		}
+	}
+}
+
+func TestVerifyAcceptsAppendedChainAndAnchor(t *testing.T) {
+	chain := Chain{Signer: HMACSigner{Key: []byte("test-key")}, AnchorDomain: "test-anchor-v1"}
+	source := &sliceSource{}
+	appendEntries(t, chain, source, 3)
+	if source.entries[0].PrevHash != GenesisHash || source.entries[2].PrevHash != source.entries[1].EntryHash {
+		t.Fatalf("entries are not linked: %+v", source.entries)
+	}
+	source.anchors = append(source.anchors, chain.SignAnchor("anchor_1", 3, source.entries[2].EntryHash, time.Now()))
+
+	report, err := chain.Verify(context.Background(), source)
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if !report.Valid || report.EntriesChecked != 3 || report.AnchorsChecked != 1 || report.HeadSequence != 3 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+}
+
+func TestVerifyReportsFirstTamperedEntry(t *testing.T) {
+	chain := Chain{Signer: HMACSigner{Key: []byte("test-key")}, AnchorDomain: "test-anchor-v1"}
+	source := &sliceSource{}
+	appendEntries(t, chain, source, 3)
+	source.entries[1].Content = []byte(`{"tampered":true}`)
+
+	report, err := chain.Verify(context.Background(), source)
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if report.Valid || report.FirstBreak == nil || report.FirstBreak.Sequence != 2 || report.FirstBreak.Reason != BreakEntryHash {
+		t.Fatalf("expected entry hash break at sequence 2, got %+v", report)
+	}
+}
+
+func TestVerifyRejectsAnchorFromAnotherDomain(t *testing.T) {
+	signer := HMACSigner{Key: []byte("shared-key")}
+	chain := Chain{Signer: signer, AnchorDomain: "test-anchor-v1"}
+	other := Chain{Signer: signer, AnchorDomain: "other-anchor-v1"}
+	source := &sliceSource{}
+	appendEntries(t, chain, source, 1)
+	source.anchors = append(source.anchors, other.SignAnchor("anchor_1", 1, source.entries[0].EntryHash, time.Now()))
+
+	report, err := chain.Verify(context.Background(), source)
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if report.Valid || report.FirstBreak == nil || report.FirstBreak.Reason != BreakAnchorSignature {
+		t.Fatalf("expected anchor signature break, got %+v", report)
+	}
+}
+
+func TestAppendRetriesConflictsThenGivesUp(t *testing.T) {
+	source := &sliceSource{}
+	calls := 0
+	err := Append(context.Background(), source.head, func(context.Context, int64, string) error {
+		calls++
+		if calls < 3 {
+			return errConflict
+		}
+		return nil
+	}, errConflict)
+	if err != nil || calls != 3 {
+		t.Fatalf("expected success on third attempt, got err=%v calls=%d", err, calls)
+	}
+
+	calls = 0
+	err = Append(context.Background(), source.head, func(context.Context, int64, string) error {
+		calls++
+		return errConflict
+	}, errConflict)
+	if !errors.Is(err, errConflict) || calls != appendAttempts {
+		t.Fatalf("expected conflict after %d attempts, got err=%v calls=%d", appendAttempts, err, calls)
+	}
+}
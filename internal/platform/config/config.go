@@ -2,7 +2,9 @@ package config
 
 import (
 	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // Config is centralized process configuration.
@@ -19,6 +21,90 @@ type Config struct {
 	EnableM26ViewLock             bool
 	EnableM08SubmissionConsumer   bool
 	EnableM08CampaignConsumer     bool
+	EnableM14PayoutSettlement     bool
+
+	// PayoutLegalHoldBaseURL is the legal service (M69) the payout worker
+	// checks creators' legal holds against; it defaults to
+	// ADMIN_M69_BASE_URL and is required to run settlement.
+	// PayoutFakeProvider settles payouts through the in-process fake
+	// provider (development only); without it settlement cannot start.
+	PayoutLegalHoldBaseURL string
+	PayoutFakeProvider     bool
+
+	// OnboardingSMTPAddr routes onboarding reminder emails to an SMTP server
+	// (a local sink in development). Empty keeps reminders in process.
+	OnboardingSMTPAddr string
+	OnboardingSMTPFrom string
+
+	// OnboardingActivityToken authenticates platform services on the
+	// onboarding activity hook. Required by the API process; at least 32
+	// bytes.
+	OnboardingActivityToken string
+
+	// TeamInviteSMTPAddr sends team invite emails over SMTP; otherwise
+	// TeamInviteMailDir, when set, writes them as .eml files (development
+	// and tests). With neither, invites are created but not emailed.
+	// TeamInviteAcceptURL is the accept link, with a {token} placeholder.
+	TeamInviteTTL       time.Duration
+	TeamInviteSMTPAddr  string
+	TeamInviteSMTPFrom  string
+	TeamInviteMailDir   string
+	TeamInviteAcceptURL string
+
+	// TeamSSOBaseURL is the public origin IdPs redirect and post back to;
+	// SSO callback, ACS, metadata and SCIM URLs are built from it.
+	// TeamSSOLoginTTL bounds how long a user may take at the IdP.
+	TeamSSOBaseURL  string
+	TeamSSOLoginTTL time.Duration
+
+	// ExportStorageDir holds asynchronous export files; every API instance
+	// must mount the same directory. ExportSigningKey signs download links
+	// and is required by the API process; at least 32 bytes. ExportBaseURL
+	// prefixes download links (empty gives relative links).
+	ExportStorageDir string
+	ExportSigningKey string
+	ExportBaseURL    string
+	ExportFileTTL    time.Duration
+	ExportLinkTTL    time.Duration
+	ExportMaxRows    int
+
+	// CommunityHealthClassifier selects the message classifier: lexicon
+	// (default), weighted (rules file) or http (external model server).
+	CommunityHealthClassifier       string
+	CommunityHealthClassifierConfig string
+	CommunityHealthClassifierURL    string
+	CommunityHealthClassifierAPIKey string
+
+	// ChatAttachmentScanner selects the chat attachment malware scanner: clamd
+	// (default, which needs ChatClamdAddr) or noop, which passes attachments
+	// as unscanned and is for development only. ChatClamdNetwork is tcp or
+	// unix.
+	ChatAttachmentScanner string
+	ChatClamdNetwork      string
+	ChatClamdAddr         string
+
+	// ChatLinkUnfurl enables server-side link previews for chat messages.
+	ChatLinkUnfurl bool
+
+	// TrustedProxies are the CIDRs allowed to set X-Forwarded-For.
+	// RateLimitBackend is memory (default, per instance) or postgres.
+	TrustedProxies   []string
+	RateLimitBackend string
+
+	// AdminAuditSigningKey signs the super-admin and control-plane audit
+	// chains. AdminImpersonationSigningKey signs support impersonation
+	// tokens. Both are required by the API process; at least 32 bytes.
+	AdminAuditSigningKey         string
+	AdminImpersonationSigningKey string
+
+	// AbuseLoginHookToken authenticates the auth service on the internal
+	// login-attempt hook. Required by the API process; at least 32 bytes.
+	AbuseLoginHookToken string
+
+	// TeamDirectorySyncToken authenticates M01 when it syncs accounts into
+	// the team user directory. Required by the API process; at least 32
+	// bytes.
+	TeamDirectorySyncToken string
 }
 
 func Load() (Config, error) {
@@ -32,6 +118,14 @@ func Load() (Config, error) {
 		port = "8080"
 	}
 
+	var trustedProxies []string
+	for _, value := range strings.Split(os.Getenv("TRUSTED_PROXY_CIDRS"), ",") {
+		value = strings.TrimSpace(value)
+		if value != "" {
+			trustedProxies = append(trustedProxies, value)
+		}
+	}
+
 	var brokers []string
 	for _, value := range strings.Split(os.Getenv("KAFKA_BROKERS"), ",") {
 		value = strings.TrimSpace(value)
@@ -55,9 +149,78 @@ func Load() (Config, error) {
 		EnableM26ViewLock:             envBool("ENABLE_M26_VIEW_LOCK", true),
 		EnableM08SubmissionConsumer:   envBool("ENABLE_M08_SUBMISSION_CONSUMER", true),
 		EnableM08CampaignConsumer:     envBool("ENABLE_M08_CAMPAIGN_CONSUMER", true),
+		EnableM14PayoutSettlement:     envBool("ENABLE_M14_PAYOUT_SETTLEMENT", false),
+
+		PayoutLegalHoldBaseURL: envString("PAYOUT_LEGAL_HOLD_BASE_URL", strings.TrimSpace(os.Getenv("ADMIN_M69_BASE_URL"))),
+		PayoutFakeProvider:     envBool("PAYOUT_FAKE_PROVIDER", false),
+
+		OnboardingSMTPAddr: strings.TrimSpace(os.Getenv("ONBOARDING_SMTP_ADDR")),
+		OnboardingSMTPFrom: envString("ONBOARDING_SMTP_FROM", "onboarding@viralforge.local"),
+
+		OnboardingActivityToken: strings.TrimSpace(os.Getenv("ONBOARDING_ACTIVITY_HOOK_TOKEN")),
+
+		TeamInviteTTL:       envDuration("TEAM_INVITE_TTL", 7*24*time.Hour),
+		TeamInviteSMTPAddr:  strings.TrimSpace(os.Getenv("TEAM_INVITE_SMTP_ADDR")),
+		TeamInviteSMTPFrom:  envString("TEAM_INVITE_SMTP_FROM", "teams@viralforge.local"),
+		TeamInviteMailDir:   strings.TrimSpace(os.Getenv("TEAM_INVITE_MAIL_DIR")),
+		TeamInviteAcceptURL: strings.TrimSpace(os.Getenv("TEAM_INVITE_ACCEPT_URL")),
+
+		TeamSSOBaseURL:  envString("TEAM_SSO_BASE_URL", "http://localhost:8080"),
+		TeamSSOLoginTTL: envDuration("TEAM_SSO_LOGIN_TTL", 10*time.Minute),
+
+		ExportStorageDir: envString("EXPORT_STORAGE_DIR", "var/exports"),
+		ExportSigningKey: strings.TrimSpace(os.Getenv("EXPORT_SIGNING_KEY")),
+		ExportBaseURL:    strings.TrimSpace(os.Getenv("EXPORT_BASE_URL")),
+		ExportFileTTL:    envDuration("EXPORT_FILE_TTL", 24*time.Hour),
+		ExportLinkTTL:    envDuration("EXPORT_LINK_TTL", 15*time.Minute),
+		ExportMaxRows:    envInt("EXPORT_MAX_ROWS", 100000),
+
+		CommunityHealthClassifier:       envString("COMMUNITY_HEALTH_CLASSIFIER", "lexicon"),
+		CommunityHealthClassifierConfig: strings.TrimSpace(os.Getenv("COMMUNITY_HEALTH_CLASSIFIER_CONFIG")),
+		CommunityHealthClassifierURL:    strings.TrimSpace(os.Getenv("COMMUNITY_HEALTH_CLASSIFIER_URL")),
+		CommunityHealthClassifierAPIKey: strings.TrimSpace(os.Getenv("COMMUNITY_HEALTH_CLASSIFIER_API_KEY")),
+
+		ChatAttachmentScanner: envString("CHAT_ATTACHMENT_SCANNER", "clamd"),
+		ChatClamdNetwork:      envString("CHAT_CLAMD_NETWORK", "tcp"),
+		ChatClamdAddr:         strings.TrimSpace(os.Getenv("CHAT_CLAMD_ADDR")),
+
+		ChatLinkUnfurl: envBool("CHAT_LINK_UNFURL", true),
+
+		TrustedProxies:   trustedProxies,
+		RateLimitBackend: envString("RATE_LIMIT_BACKEND", "memory"),
+
+		AdminAuditSigningKey:         strings.TrimSpace(os.Getenv("ADMIN_AUDIT_SIGNING_KEY")),
+		AdminImpersonationSigningKey: strings.TrimSpace(os.Getenv("ADMIN_IMPERSONATION_SIGNING_KEY")),
+
+		AbuseLoginHookToken:    strings.TrimSpace(os.Getenv("ABUSE_LOGIN_HOOK_TOKEN")),
+		TeamDirectorySyncToken: strings.TrimSpace(os.Getenv("TEAM_DIRECTORY_SYNC_TOKEN")),
 	}, nil
 }
 
+func envString(name string, fallback string) string {
+	value := strings.TrimSpace(os.Getenv(name))
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+func envDuration(name string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(strings.TrimSpace(os.Getenv(name)))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+func envInt(name string, fallback int) int {
+	value, err := strconv.Atoi(strings.TrimSpace(os.Getenv(name)))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
 func envBool(name string, fallback bool) bool {
 	raw := strings.TrimSpace(strings.ToLower(os.Getenv(name)))
 	if raw == "" {
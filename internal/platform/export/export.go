@@ -0,0 +1,109 @@
+// Package export runs asynchronous file exports for any module. A module
+// contributes a Source that authorises requests and streams rows from its
+// own repository; the Service queues jobs, writes CSV or XLSX files into a
+// Storage, hands out signed download links and deletes files once they
+// expire.
+package export
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+const (
+	StatusQueued    = "queued"
+	StatusRunning   = "running"
+	StatusCompleted = "completed"
+	StatusFailed    = "failed"
+	StatusExpired   = "expired"
+)
+
+var (
+	ErrInvalidRequest = errors.New("invalid export request")
+	ErrUnknownKind    = errors.New("unknown export kind")
+	ErrNotFound       = errors.New("export not found")
+	ErrForbidden      = errors.New("forbidden")
+	ErrNotReady       = errors.New("export not ready")
+	ErrExpired        = errors.New("export expired")
+	ErrLinkInvalid    = errors.New("download link invalid or expired")
+)
+
+// Column is one exportable field. Key is what clients select; Header is
+// the first-row label in the file.
+type Column struct {
+	Key    string
+	Header string
+}
+
+// Source is one kind of export owned by a module.
+type Source interface {
+	// Columns lists every column the source emits, in row order.
+	Columns() []Column
+	// Authorize checks, when the job is requested, that requestedBy may
+	// export with params. It returns ErrForbidden, ErrNotFound or
+	// ErrInvalidRequest for client errors.
+	Authorize(ctx context.Context, requestedBy string, params map[string]string) error
+	// Stream calls emit once per row, each aligned with Columns, and stops
+	// when emit returns an error. It never needs to emit more than limit
+	// rows.
+	Stream(ctx context.Context, requestedBy string, params map[string]string, limit int, emit func(row []string) error) error
+}
+
+type Job struct {
+	ExportID    string
+	Kind        string
+	RequestedBy string
+	Params      map[string]string
+	Format      string
+	Columns     []string
+	RowLimit    int
+	Status      string
+	Attempts    int
+	RowCount    int
+	Truncated   bool
+	FileKey     string
+	ContentType string
+	SizeBytes   int64
+	Error       string
+	LeaseUntil  *time.Time
+	CreatedAt   time.Time
+	StartedAt   *time.Time
+	CompletedAt *time.Time
+	// ExpiresAt is when a completed job's file is deleted.
+	ExpiresAt *time.Time
+}
+
+// Filename is the download name offered to clients.
+func (j Job) Filename() string {
+	return j.Kind + "-" + j.ExportID + "." + j.Format
+}
+
+func cloneJob(in Job) Job {
+	out := in
+	out.Columns = append([]string(nil), in.Columns...)
+	if in.Params != nil {
+		out.Params = make(map[string]string, len(in.Params))
+		for k, v := range in.Params {
+			out.Params[k] = v
+		}
+	}
+	out.LeaseUntil = timePtr(in.LeaseUntil)
+	out.StartedAt = timePtr(in.StartedAt)
+	out.CompletedAt = timePtr(in.CompletedAt)
+	out.ExpiresAt = timePtr(in.ExpiresAt)
+	return out
+}
+
+func timePtr(value *time.Time) *time.Time {
+	if value == nil {
+		return nil
+	}
+	utc := value.UTC()
+	return &utc
+}
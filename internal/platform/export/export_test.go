@@ -0,0 +1,239 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeSource struct {
+	rows   [][]string
+	denied bool
+}
+
+func (fakeSource) Columns() []Column {
+	return []Column{{Key: "id", Header: "ID"}, {Key: "name", Header: "Name"}, {Key: "note", Header: "Note"}}
+}
+
+func (s fakeSource) Authorize(context.Context, string, map[string]string) error {
+	if s.denied {
+		return ErrForbidden
+	}
+	return nil
+}
+
+func (s fakeSource) Stream(ctx context.Context, _ string, _ map[string]string, limit int, emit func([]string) error) error {
+	for i, row := range s.rows {
+		if i == limit {
+			return nil
+		}
+		if err := emit(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newTestService(now *time.Time, source Source) *Service {
+	service := &Service{
+		Jobs:       NewMemoryJobStore(),
+		Storage:    NewMemoryStorage(),
+		SigningKey: []byte("0123456789abcdef0123456789abcdef"),
+		Now:        func() time.Time { return *now },
+		Logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+	service.Register("things", source)
+	return service
+}
+
+func runAndDownload(t *testing.T, service *Service, job Job) (Job, []byte) {
+	t.Helper()
+	if err := service.RunOnce(context.Background(), 5); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	done, err := service.Get(context.Background(), job.ExportID, job.RequestedBy)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	link, _, err := service.DownloadURL(done)
+	if err != nil {
+		t.Fatalf("download url for %+v: %v", done, err)
+	}
+	parsed, _ := url.Parse(link)
+	_, body, err := service.Open(context.Background(), done.ExportID, parsed.Query().Get("expires"), parsed.Query().Get("signature"))
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer body.Close()
+	raw, _ := io.ReadAll(body)
+	return done, raw
+}
+
+func TestExportWritesSelectedColumnsAndTruncatesAtRowLimit(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	service := newTestService(&now, fakeSource{rows: [][]string{
+		{"1", "alpha", "=SUM(A1)"},
+		{"2", "beta", "ok"},
+		{"3", "gamma", "ok"},
+	}})
+
+	job, err := service.Request(context.Background(), Request{
+		Kind:        "things",
+		RequestedBy: "user_1",
+		Columns:     []string{"note", "id"},
+		RowLimit:    2,
+	})
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	if job.Status != StatusQueued || job.Format != FormatCSV {
+		t.Fatalf("expected queued csv job, got %+v", job)
+	}
+
+	done, raw := runAndDownload(t, service, job)
+	if done.Status != StatusCompleted || done.RowCount != 2 || !done.Truncated {
+		t.Fatalf("expected 2 truncated rows, got %+v", done)
+	}
+	want := "Note,ID\n'=SUM(A1),1\nok,2\n"
+	if string(raw) != want {
+		t.Fatalf("unexpected csv:\n%s", raw)
+	}
+}
+
+func TestExportWritesReadableXLSX(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	service := newTestService(&now, fakeSource{rows: [][]string{{"1", "a & <b>", ""}}})
+	job, err := service.Request(context.Background(), Request{Kind: "things", RequestedBy: "user_1", Format: "xlsx"})
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+
+	done, raw := runAndDownload(t, service, job)
+	if done.RowCount != 1 || done.Truncated || !strings.Contains(done.ContentType, "spreadsheetml") {
+		t.Fatalf("unexpected job %+v", done)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(raw), int64(len(raw)))
+	if err != nil {
+		t.Fatalf("xlsx is not a zip: %v", err)
+	}
+	var sheet string
+	for _, f := range archive.File {
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			rc, _ := f.Open()
+			body, _ := io.ReadAll(rc)
+			_ = rc.Close()
+			sheet = string(body)
+		}
+	}
+	if !strings.Contains(sheet, "a &amp; &lt;b&gt;") || strings.Count(sheet, "<row>") != 2 {
+		t.Fatalf("unexpected sheet: %s", sheet)
+	}
+}
+
+func TestExportRejectsBadRequests(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	service := newTestService(&now, fakeSource{})
+	service.Register("denied", fakeSource{denied: true})
+
+	cases := []struct {
+		name string
+		req  Request
+		want error
+	}{
+		{"unknown kind", Request{Kind: "nope", RequestedBy: "u"}, ErrUnknownKind},
+		{"unknown column", Request{Kind: "things", RequestedBy: "u", Columns: []string{"secret"}}, ErrInvalidRequest},
+		{"bad format", Request{Kind: "things", RequestedBy: "u", Format: "pdf"}, ErrInvalidRequest},
+		{"limit too high", Request{Kind: "things", RequestedBy: "u", RowLimit: DefaultMaxRowLimit + 1}, ErrInvalidRequest},
+		{"no requester", Request{Kind: "things"}, ErrForbidden},
+		{"source denies", Request{Kind: "denied", RequestedBy: "u"}, ErrForbidden},
+	}
+	for _, tc := range cases {
+		if _, err := service.Request(context.Background(), tc.req); !errors.Is(err, tc.want) {
+			t.Fatalf("%s: expected %v, got %v", tc.name, tc.want, err)
+		}
+	}
+}
+
+func TestExportStatusAndLinksAreScoped(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	service := newTestService(&now, fakeSource{rows: [][]string{{"1", "a", "b"}}})
+	job, _ := service.Request(context.Background(), Request{Kind: "things", RequestedBy: "user_1"})
+
+	if _, err := service.Get(context.Background(), job.ExportID, "user_2"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected other users to get not found, got %v", err)
+	}
+	if _, _, err := service.DownloadURL(job); !errors.Is(err, ErrNotReady) {
+		t.Fatalf("expected no link before completion, got %v", err)
+	}
+	if err := service.RunOnce(context.Background(), 1); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	done, _ := service.Get(context.Background(), job.ExportID, "user_1")
+	link, expiresAt, _ := service.DownloadURL(done)
+	query, _ := url.Parse(link)
+	expires := query.Query().Get("expires")
+	signature := query.Query().Get("signature")
+
+	if _, _, err := service.Open(context.Background(), job.ExportID, expires, signature[:len(signature)-1]+"x"); !errors.Is(err, ErrLinkInvalid) {
+		t.Fatalf("expected tampered signature to fail, got %v", err)
+	}
+	later := strconv.FormatInt(expiresAt.Add(time.Hour).Unix(), 10)
+	if _, _, err := service.Open(context.Background(), job.ExportID, later, signature); !errors.Is(err, ErrLinkInvalid) {
+		t.Fatalf("expected extended expiry to fail, got %v", err)
+	}
+	now = expiresAt
+	if _, _, err := service.Open(context.Background(), job.ExportID, expires, signature); !errors.Is(err, ErrLinkInvalid) {
+		t.Fatalf("expected expired link to fail, got %v", err)
+	}
+}
+
+func TestExportSweepDeletesExpiredFiles(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	service := newTestService(&now, fakeSource{rows: [][]string{{"1", "a", "b"}}})
+	service.FileTTL = time.Hour
+	job, _ := service.Request(context.Background(), Request{Kind: "things", RequestedBy: "user_1"})
+	if err := service.RunOnce(context.Background(), 1); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	done, _ := service.Get(context.Background(), job.ExportID, "user_1")
+
+	if swept, _ := service.Sweep(context.Background(), 10); swept != 0 {
+		t.Fatalf("expected nothing to sweep yet, got %d", swept)
+	}
+	now = now.Add(time.Hour)
+	if swept, err := service.Sweep(context.Background(), 10); err != nil || swept != 1 {
+		t.Fatalf("expected one expired file, got %d err=%v", swept, err)
+	}
+	expired, _ := service.Get(context.Background(), job.ExportID, "user_1")
+	if expired.Status != StatusExpired {
+		t.Fatalf("expected expired job, got %+v", expired)
+	}
+	if _, err := service.Storage.Open(context.Background(), done.FileKey); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected file deleted, got %v", err)
+	}
+}
+
+func TestExportReclaimsJobsWhoseLeaseRanOut(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryJobStore()
+	_ = store.Create(context.Background(), Job{ExportID: "e1", Status: StatusQueued, CreatedAt: now})
+
+	if _, ok, _ := store.ClaimNext(context.Background(), now, now.Add(time.Minute)); !ok {
+		t.Fatalf("expected queued job to be claimed")
+	}
+	if _, ok, _ := store.ClaimNext(context.Background(), now.Add(30*time.Second), now.Add(2*time.Minute)); ok {
+		t.Fatalf("expected leased job to stay with its worker")
+	}
+	job, ok, _ := store.ClaimNext(context.Background(), now.Add(time.Minute), now.Add(2*time.Minute))
+	if !ok || job.Attempts != 2 {
+		t.Fatalf("expected lease expiry to allow a second attempt, got %+v ok=%v", job, ok)
+	}
+}
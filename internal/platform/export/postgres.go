@@ -0,0 +1,223 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PostgresJobStore shares export jobs between instances. ClaimNext locks
+// one claimable row with SKIP LOCKED so concurrent workers never run the
+// same job.
+type PostgresJobStore struct {
+	db *gorm.DB
+}
+
+func NewPostgresJobStore(db *gorm.DB) *PostgresJobStore {
+	return &PostgresJobStore{db: db}
+}
+
+func (s *PostgresJobStore) Create(ctx context.Context, job Job) error {
+	row, err := toJobModel(job)
+	if err != nil {
+		return err
+	}
+	return s.db.WithContext(ctx).Create(&row).Error
+}
+
+func (s *PostgresJobStore) Get(ctx context.Context, exportID string) (Job, error) {
+	var row jobModel
+	err := s.db.WithContext(ctx).Where("export_id = ?", exportID).First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Job{}, ErrNotFound
+	}
+	if err != nil {
+		return Job{}, err
+	}
+	return row.toJob()
+}
+
+func (s *PostgresJobStore) ClaimNext(ctx context.Context, now time.Time, leaseUntil time.Time) (Job, bool, error) {
+	now = now.UTC()
+	var claimed Job
+	found := false
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var row jobModel
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? OR (status = ? AND lease_until <= ?)", StatusQueued, StatusRunning, now).
+			Order("created_at ASC").
+			Limit(1).
+			Find(&row).Error
+		if err != nil || row.ExportID == "" {
+			return err
+		}
+		row.Status = StatusRunning
+		row.Attempts++
+		lease := leaseUntil.UTC()
+		row.LeaseUntil = &lease
+		if row.StartedAt == nil {
+			row.StartedAt = &now
+		}
+		if err := tx.Model(&jobModel{}).
+			Where("export_id = ?", row.ExportID).
+			Updates(map[string]any{
+				"status":      row.Status,
+				"attempts":    row.Attempts,
+				"lease_until": row.LeaseUntil,
+				"started_at":  row.StartedAt,
+			}).Error; err != nil {
+			return err
+		}
+		job, err := row.toJob()
+		if err != nil {
+			return err
+		}
+		claimed = job
+		found = true
+		return nil
+	})
+	return claimed, found, err
+}
+
+func (s *PostgresJobStore) Update(ctx context.Context, job Job) error {
+	row, err := toJobModel(job)
+	if err != nil {
+		return err
+	}
+	result := s.db.WithContext(ctx).Model(&jobModel{}).
+		Where("export_id = ?", job.ExportID).
+		Select("*").
+		Omit("export_id", "created_at").
+		Updates(&row)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *PostgresJobStore) ListExpired(ctx context.Context, now time.Time, limit int) ([]Job, error) {
+	query := s.db.WithContext(ctx).
+		Where("status = ? AND expires_at <= ?", StatusCompleted, now.UTC()).
+		Order("expires_at ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	var rows []jobModel
+	if err := query.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	items := make([]Job, 0, len(rows))
+	for _, row := range rows {
+		job, err := row.toJob()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, job)
+	}
+	return items, nil
+}
+
+type jobModel struct {
+	ExportID    string     `gorm:"column:export_id;primaryKey"`
+	Kind        string     `gorm:"column:kind"`
+	RequestedBy string     `gorm:"column:requested_by"`
+	Params      []byte     `gorm:"column:params;type:jsonb"`
+	Format      string     `gorm:"column:format"`
+	Columns     []byte     `gorm:"column:columns;type:jsonb"`
+	RowLimit    int        `gorm:"column:row_limit"`
+	Status      string     `gorm:"column:status"`
+	Attempts    int        `gorm:"column:attempts"`
+	RowCount    int        `gorm:"column:row_count"`
+	Truncated   bool       `gorm:"column:truncated"`
+	FileKey     string     `gorm:"column:file_key"`
+	ContentType string     `gorm:"column:content_type"`
+	SizeBytes   int64      `gorm:"column:size_bytes"`
+	Error       string     `gorm:"column:error"`
+	LeaseUntil  *time.Time `gorm:"column:lease_until"`
+	CreatedAt   time.Time  `gorm:"column:created_at"`
+	StartedAt   *time.Time `gorm:"column:started_at"`
+	CompletedAt *time.Time `gorm:"column:completed_at"`
+	ExpiresAt   *time.Time `gorm:"column:expires_at"`
+}
+
+func (jobModel) TableName() string {
+	return "data_export_jobs"
+}
+
+func toJobModel(job Job) (jobModel, error) {
+	params := job.Params
+	if params == nil {
+		params = map[string]string{}
+	}
+	rawParams, err := json.Marshal(params)
+	if err != nil {
+		return jobModel{}, err
+	}
+	rawColumns, err := json.Marshal(job.Columns)
+	if err != nil {
+		return jobModel{}, err
+	}
+	return jobModel{
+		ExportID:    job.ExportID,
+		Kind:        job.Kind,
+		RequestedBy: job.RequestedBy,
+		Params:      rawParams,
+		Format:      job.Format,
+		Columns:     rawColumns,
+		RowLimit:    job.RowLimit,
+		Status:      job.Status,
+		Attempts:    job.Attempts,
+		RowCount:    job.RowCount,
+		Truncated:   job.Truncated,
+		FileKey:     job.FileKey,
+		ContentType: job.ContentType,
+		SizeBytes:   job.SizeBytes,
+		Error:       job.Error,
+		LeaseUntil:  timePtr(job.LeaseUntil),
+		CreatedAt:   job.CreatedAt.UTC(),
+		StartedAt:   timePtr(job.StartedAt),
+		CompletedAt: timePtr(job.CompletedAt),
+		ExpiresAt:   timePtr(job.ExpiresAt),
+	}, nil
+}
+
+func (m jobModel) toJob() (Job, error) {
+	job := Job{
+		ExportID:    m.ExportID,
+		Kind:        m.Kind,
+		RequestedBy: m.RequestedBy,
+		Format:      m.Format,
+		RowLimit:    m.RowLimit,
+		Status:      m.Status,
+		Attempts:    m.Attempts,
+		RowCount:    m.RowCount,
+		Truncated:   m.Truncated,
+		FileKey:     m.FileKey,
+		ContentType: m.ContentType,
+		SizeBytes:   m.SizeBytes,
+		Error:       m.Error,
+		LeaseUntil:  timePtr(m.LeaseUntil),
+		CreatedAt:   m.CreatedAt.UTC(),
+		StartedAt:   timePtr(m.StartedAt),
+		CompletedAt: timePtr(m.CompletedAt),
+		ExpiresAt:   timePtr(m.ExpiresAt),
+	}
+	if len(m.Params) > 0 {
+		if err := json.Unmarshal(m.Params, &job.Params); err != nil {
+			return Job{}, err
+		}
+	}
+	if len(m.Columns) > 0 {
+		if err := json.Unmarshal(m.Columns, &job.Columns); err != nil {
+			return Job{}, err
+		}
+	}
+	return job, nil
+}
@@ -0,0 +1,500 @@
+package export
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultRowLimit    = 10000
+	DefaultMaxRowLimit = 100000
+	DefaultFileTTL     = 24 * time.Hour
+	DefaultLinkTTL     = 15 * time.Minute
+	DefaultLease       = 10 * time.Minute
+	DefaultMaxAttempts = 3
+)
+
+type Request struct {
+	Kind        string
+	RequestedBy string
+	Params      map[string]string
+	Format      string
+	Columns     []string
+	RowLimit    int
+}
+
+// Service queues, runs and serves exports. Register every Source before
+// the worker starts.
+type Service struct {
+	Jobs    JobStore
+	Storage Storage
+	// SigningKey signs download links; every instance must share it.
+	SigningKey []byte
+	// BaseURL prefixes download links, e.g. "https://api.example.com".
+	BaseURL     string
+	MaxRowLimit int
+	FileTTL     time.Duration
+	LinkTTL     time.Duration
+	Lease       time.Duration
+	MaxAttempts int
+	Now         func() time.Time
+	Logger      *slog.Logger
+
+	mu      sync.RWMutex
+	sources map[string]Source
+}
+
+func (s *Service) Register(kind string, source Source) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sources == nil {
+		s.sources = make(map[string]Source)
+	}
+	s.sources[kind] = source
+}
+
+func (s *Service) source(kind string) (Source, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	source, ok := s.sources[kind]
+	return source, ok
+}
+
+// Request validates and queues an export. The source authorises the
+// requester now so that clients see permission errors synchronously.
+func (s *Service) Request(ctx context.Context, req Request) (Job, error) {
+	req.Kind = strings.TrimSpace(req.Kind)
+	req.RequestedBy = strings.TrimSpace(req.RequestedBy)
+	if req.RequestedBy == "" {
+		return Job{}, ErrForbidden
+	}
+	source, ok := s.source(req.Kind)
+	if !ok {
+		return Job{}, ErrUnknownKind
+	}
+	format := strings.ToLower(strings.TrimSpace(req.Format))
+	if format == "" {
+		format = FormatCSV
+	}
+	if format != FormatCSV && format != FormatXLSX {
+		return Job{}, ErrInvalidRequest
+	}
+	columns, err := selectColumns(source.Columns(), req.Columns)
+	if err != nil {
+		return Job{}, err
+	}
+	limit := req.RowLimit
+	if limit == 0 {
+		limit = DefaultRowLimit
+	}
+	if limit < 0 || limit > s.maxRowLimit() {
+		return Job{}, ErrInvalidRequest
+	}
+	if err := source.Authorize(ctx, req.RequestedBy, req.Params); err != nil {
+		return Job{}, err
+	}
+
+	job := Job{
+		ExportID:    uuid.NewString(),
+		Kind:        req.Kind,
+		RequestedBy: req.RequestedBy,
+		Params:      req.Params,
+		Format:      format,
+		Columns:     columns,
+		RowLimit:    limit,
+		Status:      StatusQueued,
+		CreatedAt:   s.now(),
+	}
+	if err := s.Jobs.Create(ctx, job); err != nil {
+		return Job{}, err
+	}
+	s.logger().Info("export queued",
+		"event", "export_queued",
+		"module", "platform/export",
+		"layer", "platform",
+		"export_id", job.ExportID,
+		"kind", job.Kind,
+		"format", job.Format,
+		"row_limit", job.RowLimit,
+	)
+	return job, nil
+}
+
+// Get returns a job to the user who requested it. Other users see
+// ErrNotFound so export IDs cannot be probed.
+func (s *Service) Get(ctx context.Context, exportID string, requestedBy string) (Job, error) {
+	job, err := s.Jobs.Get(ctx, strings.TrimSpace(exportID))
+	if err != nil {
+		return Job{}, err
+	}
+	if job.RequestedBy != strings.TrimSpace(requestedBy) {
+		return Job{}, ErrNotFound
+	}
+	return job, nil
+}
+
+// DownloadURL signs a short-lived link for a completed job. The link never
+// outlives the file.
+func (s *Service) DownloadURL(job Job) (string, time.Time, error) {
+	if job.Status != StatusCompleted {
+		return "", time.Time{}, ErrNotReady
+	}
+	expiresAt := s.now().Add(s.linkTTL())
+	if job.ExpiresAt != nil && job.ExpiresAt.Before(expiresAt) {
+		expiresAt = *job.ExpiresAt
+	}
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", s.sign(job.ExportID, expires))
+	link := strings.TrimRight(s.BaseURL, "/") + "/v1/exports/" + url.PathEscape(job.ExportID) + "/download?" + query.Encode()
+	return link, expiresAt.UTC(), nil
+}
+
+// Open checks a download link and returns the file. The caller closes the
+// reader.
+func (s *Service) Open(ctx context.Context, exportID string, expires string, signature string) (Job, io.ReadCloser, error) {
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || signature == "" {
+		return Job{}, nil, ErrLinkInvalid
+	}
+	if !hmac.Equal([]byte(s.sign(exportID, expires)), []byte(signature)) {
+		return Job{}, nil, ErrLinkInvalid
+	}
+	if !s.now().Before(time.Unix(unix, 0)) {
+		return Job{}, nil, ErrLinkInvalid
+	}
+	job, err := s.Jobs.Get(ctx, exportID)
+	if err != nil {
+		return Job{}, nil, err
+	}
+	switch job.Status {
+	case StatusCompleted:
+	case StatusExpired:
+		return Job{}, nil, ErrExpired
+	default:
+		return Job{}, nil, ErrNotReady
+	}
+	if job.ExpiresAt != nil && !s.now().Before(*job.ExpiresAt) {
+		return Job{}, nil, ErrExpired
+	}
+	body, err := s.Storage.Open(ctx, job.FileKey)
+	if errors.Is(err, ErrNotFound) {
+		return Job{}, nil, ErrExpired
+	}
+	if err != nil {
+		return Job{}, nil, err
+	}
+	return job, body, nil
+}
+
+// RunOnce claims and runs queued jobs until none are left or maxJobs have
+// run.
+func (s *Service) RunOnce(ctx context.Context, maxJobs int) error {
+	if maxJobs <= 0 {
+		maxJobs = 1
+	}
+	for i := 0; i < maxJobs; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		now := s.now()
+		job, ok, err := s.Jobs.ClaimNext(ctx, now, now.Add(s.lease()))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+		if err := s.run(ctx, job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Service) run(ctx context.Context, job Job) error {
+	logger := s.logger().With(
+		"module", "platform/export",
+		"layer", "platform",
+		"export_id", job.ExportID,
+		"kind", job.Kind,
+	)
+	if job.Attempts > s.maxAttempts() {
+		return s.fail(ctx, job, "export abandoned after repeated worker crashes", logger)
+	}
+	source, ok := s.source(job.Kind)
+	if !ok {
+		return s.fail(ctx, job, ErrUnknownKind.Error(), logger)
+	}
+
+	result, err := s.write(ctx, source, job)
+	if err != nil {
+		if ctx.Err() != nil {
+			// Shutting down: leave the lease to run out so another worker
+			// retries the job.
+			return ctx.Err()
+		}
+		// Source errors can carry storage details, so only the log keeps
+		// them; the job shows a generic reason.
+		logger.Error("export stream failed", "event", "export_stream_failed", "error", err)
+		return s.fail(ctx, job, "export failed", logger)
+	}
+
+	now := s.now()
+	expiresAt := now.Add(s.fileTTL())
+	job.Status = StatusCompleted
+	job.RowCount = result.rows
+	job.Truncated = result.truncated
+	job.FileKey = result.key
+	job.ContentType = result.contentType
+	job.SizeBytes = result.size
+	job.Error = ""
+	job.LeaseUntil = nil
+	job.CompletedAt = &now
+	job.ExpiresAt = &expiresAt
+	if err := s.Jobs.Update(ctx, job); err != nil {
+		_ = s.Storage.Delete(ctx, result.key)
+		return err
+	}
+	logger.Info("export completed",
+		"event", "export_completed",
+		"rows", result.rows,
+		"truncated", result.truncated,
+		"size_bytes", result.size,
+	)
+	return nil
+}
+
+type writeResult struct {
+	key         string
+	contentType string
+	rows        int
+	truncated   bool
+	size        int64
+}
+
+var errRowLimitReached = errors.New("row limit reached")
+
+// write streams rows from the source through the format writer into
+// storage without holding the whole file in memory.
+func (s *Service) write(ctx context.Context, source Source, job Job) (writeResult, error) {
+	all := source.Columns()
+	index := make(map[string]int, len(all))
+	for i, column := range all {
+		index[column.Key] = i
+	}
+	headers := make([]string, len(job.Columns))
+	positions := make([]int, len(job.Columns))
+	for i, key := range job.Columns {
+		pos, ok := index[key]
+		if !ok {
+			return writeResult{}, fmt.Errorf("column %q is no longer exported", key)
+		}
+		positions[i] = pos
+		headers[i] = all[pos].Header
+	}
+
+	result := writeResult{key: "exports/" + job.ExportID + "." + job.Format}
+	reader, pipe := io.Pipe()
+	writer, contentType, err := newRowWriter(job.Format, pipe)
+	if err != nil {
+		return writeResult{}, err
+	}
+	result.contentType = contentType
+
+	done := make(chan error, 1)
+	go func() {
+		err := writer.Write(headers)
+		if err == nil {
+			// Ask for one extra row so a full result can be told apart from
+			// a truncated one.
+			err = source.Stream(ctx, job.RequestedBy, job.Params, job.RowLimit+1, func(row []string) error {
+				if result.rows >= job.RowLimit {
+					result.truncated = true
+					return errRowLimitReached
+				}
+				if len(row) != len(all) {
+					return fmt.Errorf("source emitted %d values for %d columns", len(row), len(all))
+				}
+				selected := make([]string, len(positions))
+				for i, pos := range positions {
+					selected[i] = row[pos]
+				}
+				if err := writer.Write(selected); err != nil {
+					return err
+				}
+				result.rows++
+				return nil
+			})
+			if errors.Is(err, errRowLimitReached) {
+				err = nil
+			}
+		}
+		if err == nil {
+			err = writer.Close()
+		}
+		_ = pipe.CloseWithError(err)
+		done <- err
+	}()
+
+	size, putErr := s.Storage.Put(ctx, result.key, contentType, reader)
+	// Unblock the producer if storage stopped reading early.
+	_ = reader.CloseWithError(io.ErrClosedPipe)
+	streamErr := <-done
+	if streamErr != nil || putErr != nil {
+		_ = s.Storage.Delete(ctx, result.key)
+		if putErr != nil {
+			return writeResult{}, putErr
+		}
+		return writeResult{}, streamErr
+	}
+	result.size = size
+	return result, nil
+}
+
+func (s *Service) fail(ctx context.Context, job Job, reason string, logger *slog.Logger) error {
+	now := s.now()
+	job.Status = StatusFailed
+	job.Error = reason
+	job.LeaseUntil = nil
+	job.CompletedAt = &now
+	logger.Error("export failed",
+		"event", "export_failed",
+		"attempts", job.Attempts,
+		"error", reason,
+	)
+	return s.Jobs.Update(ctx, job)
+}
+
+// Sweep deletes files whose jobs have expired and marks the jobs expired.
+func (s *Service) Sweep(ctx context.Context, limit int) (int, error) {
+	jobs, err := s.Jobs.ListExpired(ctx, s.now(), limit)
+	if err != nil {
+		return 0, err
+	}
+	expired := 0
+	for _, job := range jobs {
+		if err := s.Storage.Delete(ctx, job.FileKey); err != nil {
+			return expired, err
+		}
+		job.Status = StatusExpired
+		if err := s.Jobs.Update(ctx, job); err != nil {
+			return expired, err
+		}
+		expired++
+	}
+	if expired > 0 {
+		s.logger().Info("export files expired",
+			"event", "export_files_expired",
+			"module", "platform/export",
+			"layer", "platform",
+			"count", expired,
+		)
+	}
+	return expired, nil
+}
+
+func (s *Service) sign(exportID string, expires string) string {
+	mac := hmac.New(sha256.New, s.SigningKey)
+	_, _ = mac.Write([]byte(exportID + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// selectColumns returns the requested keys in request order, or every
+// column when none are requested.
+func selectColumns(all []Column, requested []string) ([]string, error) {
+	known := make(map[string]struct{}, len(all))
+	for _, column := range all {
+		known[column.Key] = struct{}{}
+	}
+	if len(requested) == 0 {
+		keys := make([]string, 0, len(all))
+		for _, column := range all {
+			keys = append(keys, column.Key)
+		}
+		return keys, nil
+	}
+	seen := make(map[string]struct{}, len(requested))
+	keys := make([]string, 0, len(requested))
+	for _, raw := range requested {
+		key := strings.TrimSpace(raw)
+		if key == "" {
+			continue
+		}
+		if _, ok := known[key]; !ok {
+			return nil, ErrInvalidRequest
+		}
+		if _, dup := seen[key]; dup {
+			continue
+		}
+		seen[key] = struct{}{}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, ErrInvalidRequest
+	}
+	return keys, nil
+}
+
+func (s *Service) now() time.Time {
+	if s.Now != nil {
+		return s.Now().UTC()
+	}
+	return time.Now().UTC()
+}
+
+func (s *Service) logger() *slog.Logger {
+	if s.Logger != nil {
+		return s.Logger
+	}
+	return slog.Default()
+}
+
+func (s *Service) maxRowLimit() int {
+	if s.MaxRowLimit > 0 {
+		return s.MaxRowLimit
+	}
+	return DefaultMaxRowLimit
+}
+
+func (s *Service) fileTTL() time.Duration {
+	if s.FileTTL > 0 {
+		return s.FileTTL
+	}
+	return DefaultFileTTL
+}
+
+func (s *Service) linkTTL() time.Duration {
+	if s.LinkTTL > 0 {
+		return s.LinkTTL
+	}
+	return DefaultLinkTTL
+}
+
+func (s *Service) lease() time.Duration {
+	if s.Lease > 0 {
+		return s.Lease
+	}
+	return DefaultLease
+}
+
+func (s *Service) maxAttempts() int {
+	if s.MaxAttempts > 0 {
+		return s.MaxAttempts
+	}
+	return DefaultMaxAttempts
+}
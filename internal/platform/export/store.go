@@ -0,0 +1,114 @@
+package export
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// JobStore persists export jobs. ClaimNext hands a queued job, or a running
+// job whose lease ran out, to exactly one worker.
+type JobStore interface {
+	Create(ctx context.Context, job Job) error
+	Get(ctx context.Context, exportID string) (Job, error)
+	ClaimNext(ctx context.Context, now time.Time, leaseUntil time.Time) (Job, bool, error)
+	Update(ctx context.Context, job Job) error
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]Job, error)
+}
+
+// MemoryJobStore is a single-process JobStore.
+type MemoryJobStore struct {
+	mu   sync.Mutex
+	jobs map[string]Job
+}
+
+func NewMemoryJobStore() *MemoryJobStore {
+	return &MemoryJobStore{jobs: make(map[string]Job)}
+}
+
+func (s *MemoryJobStore) Create(_ context.Context, job Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.jobs[job.ExportID]; exists {
+		return ErrInvalidRequest
+	}
+	s.jobs[job.ExportID] = cloneJob(job)
+	return nil
+}
+
+func (s *MemoryJobStore) Get(_ context.Context, exportID string) (Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[exportID]
+	if !ok {
+		return Job{}, ErrNotFound
+	}
+	return cloneJob(job), nil
+}
+
+func (s *MemoryJobStore) ClaimNext(_ context.Context, now time.Time, leaseUntil time.Time) (Job, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var next *Job
+	for id := range s.jobs {
+		job := s.jobs[id]
+		if !claimable(job, now) {
+			continue
+		}
+		if next == nil || job.CreatedAt.Before(next.CreatedAt) {
+			candidate := job
+			next = &candidate
+		}
+	}
+	if next == nil {
+		return Job{}, false, nil
+	}
+	next.Status = StatusRunning
+	next.Attempts++
+	next.LeaseUntil = timePtr(&leaseUntil)
+	if next.StartedAt == nil {
+		next.StartedAt = timePtr(&now)
+	}
+	s.jobs[next.ExportID] = *next
+	return cloneJob(*next), true, nil
+}
+
+func (s *MemoryJobStore) Update(_ context.Context, job Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.jobs[job.ExportID]; !ok {
+		return ErrNotFound
+	}
+	s.jobs[job.ExportID] = cloneJob(job)
+	return nil
+}
+
+func (s *MemoryJobStore) ListExpired(_ context.Context, now time.Time, limit int) ([]Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	items := make([]Job, 0)
+	for _, job := range s.jobs {
+		if job.Status == StatusCompleted && job.ExpiresAt != nil && !job.ExpiresAt.After(now) {
+			items = append(items, cloneJob(job))
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].ExpiresAt.Before(*items[j].ExpiresAt)
+	})
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+func claimable(job Job, now time.Time) bool {
+	switch job.Status {
+	case StatusQueued:
+		return true
+	case StatusRunning:
+		return job.LeaseUntil != nil && !job.LeaseUntil.After(now)
+	default:
+		return false
+	}
+}
@@ -30,7 +30,12 @@ var adminRouteOwnership = map[string]adminRouteOwner{
 	"GET /api/admin/v1/analytics/dashboard":                                     adminRouteOwnerM20,
 	"GET /api/admin/v1/audit-logs":                                              adminRouteOwnerM20,
 	"GET /api/admin/v1/audit-logs/export":                                       adminRouteOwnerM20,
+	"GET /api/admin/v1/audit-logs/export/{export_id}":                           adminRouteOwnerM20,
+	"GET /api/admin/v1/audit-logs/verify":                                       adminRouteOwnerM20,
+	"GET /api/admin/v1/ledger/accounts/{account_id}":                            adminRouteOwnerM20,
+	"GET /api/admin/v1/ledger/drifts":                                           adminRouteOwnerM20,
 	"POST /api/admin/v1/actions/log":                                            adminRouteOwnerM86,
+	"GET /api/admin/v1/actions/log/verify":                                      adminRouteOwnerM86,
 	"POST /api/admin/v1/identity/roles/grant":                                   adminRouteOwnerM86,
 	"POST /api/admin/v1/moderation/decisions":                                   adminRouteOwnerM86,
 	"POST /api/admin/v1/abuse-prevention/lockouts/{user_id}/release":            adminRouteOwnerM86,
@@ -0,0 +1,163 @@
+package httpserver
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+
+	superadminapplication "solomon/contexts/internal-ops/super-admin-dashboard/application"
+	superadmindomainerrors "solomon/contexts/internal-ops/super-admin-dashboard/domain/errors"
+	superadminservices "solomon/contexts/internal-ops/super-admin-dashboard/domain/services"
+	"solomon/internal/platform/ratelimit"
+)
+
+const (
+	impersonatorIDHeader   = "X-Impersonator-Id"
+	impersonationIDHeader  = "X-Impersonation-Id"
+	impersonatedUserHeader = "X-User-Id"
+)
+
+type impersonationContextKey struct{}
+
+// ImpersonationFromContext returns the verified impersonation claims of the
+// request, if it was made with an impersonation token.
+func ImpersonationFromContext(ctx context.Context) (superadminservices.ImpersonationClaims, bool) {
+	claims, ok := ctx.Value(impersonationContextKey{}).(superadminservices.ImpersonationClaims)
+	return claims, ok
+}
+
+// impersonation verifies impersonation bearer tokens. A verified request is
+// rewritten to act as the impersonated user, tagged with the admin behind it,
+// checked against the token scopes and audited once served. Other requests
+// pass through with any client-supplied impersonation headers removed.
+func (s *Server) impersonation(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Header.Del(impersonatorIDHeader)
+		r.Header.Del(impersonationIDHeader)
+		token := bearerToken(r)
+		if !strings.HasPrefix(token, superadminservices.ImpersonationTokenPrefix) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		claims, err := s.superAdmin.AuthenticateImpersonation(r.Context(), token)
+		if err != nil {
+			writeImpersonationError(w, err)
+			return
+		}
+		route := s.routePattern(r)
+		access, listed := impersonationRoutes[route]
+		required := superadminservices.RequiredImpersonationScope(r.Method, access.movesMoney)
+		record := superadminapplication.ImpersonatedRequest{
+			Method:    r.Method,
+			Route:     route,
+			Path:      r.URL.Path,
+			Allowed:   listed && claims.Allows(required),
+			IPAddress: ratelimit.ClientIPFromContext(r.Context()),
+		}
+		if !record.Allowed {
+			record.Status = http.StatusForbidden
+			s.recordImpersonatedRequest(r.Context(), claims, record)
+			writeImpersonationError(w, superadmindomainerrors.ErrImpersonationScopeDenied)
+			return
+		}
+
+		r.Header.Del("X-Admin-Id")
+		r.Header.Set(impersonatedUserHeader, claims.UserID)
+		r.Header.Set(impersonatorIDHeader, claims.AdminID)
+		r.Header.Set(impersonationIDHeader, claims.ImpersonationID)
+		ctx := ratelimit.WithPrincipal(r.Context(), claims.UserID)
+		ctx = context.WithValue(ctx, impersonationContextKey{}, claims)
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r.WithContext(ctx))
+		record.Status = recorder.status
+		s.recordImpersonatedRequest(context.WithoutCancel(ctx), claims, record)
+	})
+}
+
+func (s *Server) recordImpersonatedRequest(
+	ctx context.Context,
+	claims superadminservices.ImpersonationClaims,
+	record superadminapplication.ImpersonatedRequest,
+) {
+	if err := s.superAdmin.RecordImpersonatedRequest(ctx, claims, record); err != nil {
+		s.logger.Error("impersonated request audit failed",
+			"event", "http_server_impersonation_audit_failed",
+			"module", "internal/platform/httpserver",
+			"layer", "platform",
+			"impersonation_id", claims.ImpersonationID,
+			"route", record.Route,
+			"error", err.Error(),
+		)
+	}
+}
+
+func writeImpersonationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, superadmindomainerrors.ErrImpersonationTokenInvalid):
+		writeSuperAdminError(w, http.StatusUnauthorized, "impersonation_token_invalid", err.Error())
+	case errors.Is(err, superadmindomainerrors.ErrImpersonationTokenExpired):
+		writeSuperAdminError(w, http.StatusUnauthorized, "impersonation_token_expired", err.Error())
+	case errors.Is(err, superadmindomainerrors.ErrImpersonationRevoked):
+		writeSuperAdminError(w, http.StatusUnauthorized, "impersonation_revoked", err.Error())
+	case errors.Is(err, superadmindomainerrors.ErrImpersonationScopeDenied):
+		writeSuperAdminError(w, http.StatusForbidden, "impersonation_scope_denied", err.Error())
+	default:
+		writeSuperAdminError(w, http.StatusInternalServerError, "internal_error", "impersonation check failed")
+	}
+}
+
+func bearerToken(r *http.Request) string {
+	parts := strings.SplitN(strings.TrimSpace(r.Header.Get("Authorization")), " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(parts[1])
+}
+
+// statusRecorder captures the response status while keeping streaming
+// responses flushable and WebSocket upgrades hijackable.
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (w *statusRecorder) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusRecorder) Write(body []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(body)
+}
+
+func (w *statusRecorder) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *statusRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err == nil && !w.wroteHeader {
+		w.status = http.StatusSwitchingProtocols
+		w.wroteHeader = true
+	}
+	return conn, rw, err
+}
@@ -0,0 +1,216 @@
+package httpserver
+
+// impersonationAccess is what an impersonation token needs to call a route
+// beyond the read or write scope implied by its method.
+type impersonationAccess struct {
+	// movesMoney routes spend or refund on the user's behalf and need the
+	// payouts scope on top of write.
+	movesMoney bool
+}
+
+// impersonationRoutes is the deny-by-default allowlist of routes an
+// impersonation token may call. Routes are the patterns passed to s.mux in
+// registerRoutes. Impersonation acts as an end user, so admin and moderator
+// surfaces, role grants, service-to-service hooks, IdP-facing SSO and SCIM
+// endpoints and account deletion are deliberately absent.
+var impersonationRoutes = map[string]impersonationAccess{
+	// Content library marketplace.
+	"GET /library/clips":                            {},
+	"GET /library/clips/{clip_id}":                  {},
+	"GET /library/clips/{clip_id}/preview":          {},
+	"POST /library/clips/{clip_id}/claim":           {},
+	"POST /library/clips/{clip_id}/download":        {},
+	"GET /library/claims":                           {},
+	"GET /v1/marketplace/clips":                     {},
+	"GET /v1/marketplace/clips/{clip_id}":           {},
+	"GET /v1/marketplace/clips/{clip_id}/preview":   {},
+	"POST /v1/marketplace/clips/{clip_id}/claim":    {},
+	"POST /v1/marketplace/clips/{clip_id}/download": {},
+	"GET /v1/marketplace/claims":                    {},
+
+	// Products, discovery and the user's own data.
+	"GET /api/v1/products":                                   {},
+	"POST /api/v1/products":                                  {},
+	"GET /api/v1/products/{product_id}/access":               {},
+	"POST /api/v1/products/{id}/purchase":                    {movesMoney: true},
+	"POST /api/v1/products/{id}/fulfill":                     {},
+	"PUT /api/v1/products/{product_id}/media/reorder":        {},
+	"GET /api/v1/discover":                                   {},
+	"GET /api/v1/discover/feed":                              {},
+	"GET /api/v1/search":                                     {},
+	"GET /api/v1/users/{user_id}/data-export":                {},
+	"GET /api/discover/v1/campaigns/browse":                  {},
+	"GET /api/discover/v1/campaigns/search":                  {},
+	"GET /api/discover/v1/campaigns/{campaign_id}":           {},
+	"POST /api/discover/v1/campaigns/{campaign_id}/bookmark": {},
+	"GET /api/v1/reputation/user/{user_id}":                  {},
+	"GET /api/v1/reputation/leaderboard":                     {},
+
+	// Storefronts.
+	"POST /storefronts":                        {},
+	"PATCH /storefronts/{storefrontId}":        {},
+	"GET /storefronts/{identifier}":            {},
+	"POST /storefronts/{storefrontId}/publish": {},
+	"POST /storefronts/{storefrontId}/reports": {},
+
+	// Chat, without the moderator actions.
+	"POST /api/v1/chat/messages":                                         {},
+	"PUT /api/v1/chat/messages/{message_id}":                             {},
+	"DELETE /api/v1/chat/messages/{message_id}":                          {},
+	"GET /api/v1/chat/channels/{channel_id}/messages":                    {},
+	"GET /api/v1/chat/channels/{channel_id}/unread-count":                {},
+	"GET /api/v1/chat/search":                                            {},
+	"GET /api/v1/chat/messages":                                          {},
+	"GET /api/v1/chat/poll":                                              {},
+	"GET /api/v1/chat/messages/subscribe":                                {},
+	"GET /api/v1/chat/channels/{channel_id}/ws":                          {},
+	"POST /api/v1/chat/channels/{channel_id}/typing":                     {},
+	"POST /api/v1/chat/messages/{message_id}/reactions":                  {},
+	"DELETE /api/v1/chat/messages/{message_id}/reactions/{emoji}":        {},
+	"POST /api/v1/chat/messages/{message_id}/pin":                        {},
+	"POST /api/v1/chat/messages/{message_id}/report":                     {},
+	"POST /api/v1/chat/messages/{message_id}/attachments":                {},
+	"GET /api/v1/chat/messages/{message_id}/attachments/{attachment_id}": {},
+	"POST /api/v1/chat/export":                                           {},
+
+	// Community health reads.
+	"GET /api/v1/community-health/{server_id}/health-score":        {},
+	"GET /api/v1/community-health/{server_id}/user-risk/{user_id}": {},
+	"GET /api/v1/community-health/{server_id}/alerts":              {},
+	"GET /api/v1/community-health/{server_id}/moderation-tuning":   {},
+	"GET /api/v1/community-health/{server_id}/reports":             {},
+	"GET /api/v1/community-health/{server_id}/reports/{report_id}": {},
+
+	// Clipping tool.
+	"POST /api/clipping/v1/projects":                                       {},
+	"GET /api/clipping/v1/projects/{project_id}":                           {},
+	"PATCH /api/clipping/v1/projects/{project_id}/timeline":                {},
+	"POST /api/clipping/v1/projects/{project_id}/timeline/insert":          {},
+	"GET /api/clipping/v1/projects/{project_id}/suggestions":               {},
+	"POST /api/clipping/v1/projects/{project_id}/export":                   {},
+	"GET /api/clipping/v1/projects/{project_id}/export/{export_id}/status": {},
+	"POST /api/clipping/v1/projects/{project_id}/submit":                   {},
+
+	// Editor and influencer dashboards.
+	"GET /v1/editor/feed":                   {},
+	"GET /v1/editor/campaigns/feed":         {},
+	"GET /v1/editor/dashboard/summary":      {},
+	"GET /v1/editor/submissions":            {},
+	"GET /v1/editor/earnings":               {},
+	"GET /v1/editor/performance":            {},
+	"GET /v1/editor/submissions/export":     {},
+	"POST /v1/editor/campaigns/{id}/save":   {},
+	"DELETE /v1/editor/campaigns/{id}/save": {},
+	"GET /api/v1/dashboard/summary":         {},
+	"GET /api/v1/dashboard/content":         {},
+	"POST /api/v1/goals":                    {},
+
+	// Login challenges the user was issued.
+	"POST /api/v1/auth/challenge/{id}": {},
+
+	// Subscriptions and invoices.
+	"POST /api/v1/subscriptions":                               {movesMoney: true},
+	"POST /api/v1/subscriptions/{subscription_id}/change-plan": {movesMoney: true},
+	"POST /api/v1/subscriptions/{subscription_id}/cancel":      {movesMoney: true},
+	"GET /api/v1/billing/invoices":                             {},
+	"GET /api/v1/billing/invoices/{invoice_id}":                {},
+	"GET /api/v1/billing/invoices/{invoice_id}/pdf":            {},
+
+	// Onboarding, without flow authoring.
+	"GET /api/onboarding/v1/flow":                       {},
+	"POST /api/onboarding/v1/steps/{step_key}/complete": {},
+	"POST /api/onboarding/v1/skip":                      {},
+	"POST /api/onboarding/v1/resume":                    {},
+	"GET /api/onboarding/v1/reminders/preferences":      {},
+	"PUT /api/onboarding/v1/reminders/preferences":      {},
+	"POST /api/onboarding/v1/reminders/unsubscribe":     {},
+
+	// Teams, without SSO configuration.
+	"POST /teams":                                        {},
+	"POST /teams/{teamId}/invites":                       {},
+	"POST /teams/invites/{token}/accept":                 {},
+	"POST /teams/{teamId}/invites/{inviteId}/resend":     {},
+	"DELETE /teams/{teamId}/invites/{inviteId}":          {},
+	"POST /teams/{teamId}/members/{memberId}/role":       {},
+	"DELETE /teams/{teamId}/members/{memberId}":          {},
+	"GET /teams/{teamId}":                                {},
+	"GET /teams/{teamId}/membership":                     {},
+	"GET /teams/{teamId}/audit-logs":                     {},
+	"GET /teams/{teamId}/exports/members":                {},
+	"POST /v1/team":                                      {},
+	"POST /v1/team/{team_id}/invites":                    {},
+	"POST /v1/team/invites/{invite_id}/accept":           {},
+	"POST /v1/team/{team_id}/invites/{invite_id}/resend": {},
+	"DELETE /v1/team/{team_id}/invites/{invite_id}":      {},
+	"PUT /v1/team/{team_id}/members/{member_id}/role":    {},
+	"DELETE /v1/team/{team_id}/members/{member_id}":      {},
+
+	// Exports.
+	"POST /v1/exports":                     {},
+	"GET /v1/exports/{export_id}":          {},
+	"GET /v1/exports/{export_id}/download": {},
+
+	// Campaigns.
+	"POST /v1/campaigns":                                        {},
+	"GET /v1/campaigns":                                         {},
+	"GET /v1/campaigns/{campaign_id}":                           {},
+	"PUT /v1/campaigns/{campaign_id}":                           {},
+	"POST /v1/campaigns/{campaign_id}/launch":                   {},
+	"POST /v1/campaigns/{campaign_id}/pause":                    {},
+	"POST /v1/campaigns/{campaign_id}/resume":                   {},
+	"POST /v1/campaigns/{campaign_id}/complete":                 {},
+	"POST /v1/campaigns/{campaign_id}/media/upload-url":         {},
+	"POST /v1/campaigns/{campaign_id}/media/{media_id}/confirm": {},
+	"GET /v1/campaigns/{campaign_id}/media":                     {},
+	"GET /v1/campaigns/{campaign_id}/analytics":                 {},
+	"GET /v1/campaigns/{campaign_id}/analytics/export":          {},
+	"POST /v1/campaigns/{campaign_id}/budget/increase":          {movesMoney: true},
+
+	// Submissions.
+	"POST /submissions":                                            {},
+	"GET /submissions/{submission_id}":                             {},
+	"GET /submissions":                                             {},
+	"POST /submissions/{submission_id}/approve":                    {},
+	"POST /submissions/{submission_id}/reject":                     {},
+	"POST /submissions/{submission_id}/report":                     {},
+	"POST /submissions/bulk-operations":                            {},
+	"GET /submissions/{submission_id}/analytics":                   {},
+	"PUT /submissions/campaigns/{campaign_id}/screening-policy":    {},
+	"GET /submissions/campaigns/{campaign_id}/screening-policy":    {},
+	"GET /dashboard/creator":                                       {},
+	"GET /dashboard/brand":                                         {},
+	"POST /v1/submissions":                                         {},
+	"GET /v1/submissions/{submission_id}":                          {},
+	"GET /v1/submissions":                                          {},
+	"POST /v1/submissions/{submission_id}/approve":                 {},
+	"POST /v1/submissions/{submission_id}/reject":                  {},
+	"POST /v1/submissions/{submission_id}/report":                  {},
+	"POST /v1/submissions/bulk-operations":                         {},
+	"GET /v1/submissions/{submission_id}/analytics":                {},
+	"PUT /v1/submissions/campaigns/{campaign_id}/screening-policy": {},
+	"GET /v1/submissions/campaigns/{campaign_id}/screening-policy": {},
+	"GET /v1/dashboard/creator":                                    {},
+	"GET /v1/dashboard/brand":                                      {},
+
+	// Distribution.
+	"POST /api/v1/distribution/items/{id}/overlays":      {},
+	"GET /api/v1/distribution/items/{id}/preview":        {},
+	"POST /api/v1/distribution/items/{id}/schedule":      {},
+	"POST /api/v1/distribution/items/{id}/reschedule":    {},
+	"POST /api/v1/distribution/items/{id}/publish":       {},
+	"POST /api/v1/distribution/items/{id}/download":      {},
+	"POST /api/v1/distribution/items/{id}/publish-multi": {},
+	"POST /api/v1/distribution/items/{id}/retry":         {},
+
+	// Voting, without quarantine review.
+	"POST /v1/votes":                              {},
+	"DELETE /v1/votes/{vote_id}":                  {},
+	"GET /v1/votes/submissions/{submission_id}":   {},
+	"GET /v1/votes/leaderboard":                   {},
+	"GET /v1/leaderboards/campaign/{campaign_id}": {},
+	"GET /v1/leaderboards/round/{round_id}":       {},
+	"GET /v1/leaderboards/trending":               {},
+	"GET /v1/leaderboards/creator/{user_id}":      {},
+	"GET /v1/rounds/{round_id}/results":           {},
+	"GET /v1/analytics/votes":                     {},
+}
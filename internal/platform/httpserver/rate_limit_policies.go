@@ -0,0 +1,113 @@
+package httpserver
+
+import (
+	"net/http"
+	"time"
+
+	"solomon/internal/platform/ratelimit"
+)
+
+// defaultRateLimit applies to every route outside the groups below.
+var defaultRateLimit = ratelimit.Policy{
+	Name:   "default",
+	Limit:  600,
+	Period: time.Minute,
+	Burst:  120,
+	Key:    ratelimit.KeyIP,
+}
+
+// rateLimitPolicies declares the route groups with tighter limits. Routes
+// are the patterns passed to s.mux in registerRoutes.
+func rateLimitPolicies() []ratelimit.Policy {
+	return []ratelimit.Policy{
+		{
+			// Login attempts arrive through the auth service's internal hook
+			// and are scored there; this caps how fast one address can grind
+			// through proof-of-work challenges.
+			Name:   "auth",
+			Limit:  10,
+			Period: time.Minute,
+			Burst:  10,
+			Key:    ratelimit.KeyIP,
+			Routes: []string{
+				"POST /api/v1/auth/challenge/{id}",
+			},
+		},
+		{
+			Name:   "votes",
+			Limit:  60,
+			Period: time.Minute,
+			Burst:  20,
+			Key:    ratelimit.KeyPrincipal,
+			Routes: []string{
+				"POST /v1/votes",
+				"DELETE /v1/votes/{vote_id}",
+			},
+		},
+		{
+			Name:   "chat_write",
+			Limit:  30,
+			Period: time.Minute,
+			Burst:  15,
+			Key:    ratelimit.KeyPrincipal,
+			Routes: []string{
+				"POST /api/v1/chat/messages",
+				"PUT /api/v1/chat/messages/{message_id}",
+				"DELETE /api/v1/chat/messages/{message_id}",
+				"POST /api/v1/chat/channels/{channel_id}/typing",
+				"POST /api/v1/chat/messages/{message_id}/reactions",
+				"DELETE /api/v1/chat/messages/{message_id}/reactions/{emoji}",
+				"POST /api/v1/chat/messages/{message_id}/report",
+				"POST /api/v1/chat/messages/{message_id}/attachments",
+			},
+		},
+		{
+			Name:   "marketplace_claims",
+			Limit:  20,
+			Period: time.Minute,
+			Burst:  5,
+			Key:    ratelimit.KeyPrincipal,
+			Routes: []string{
+				"POST /library/clips/{clip_id}/claim",
+				"POST /library/clips/{clip_id}/download",
+				"POST /v1/marketplace/clips/{clip_id}/claim",
+				"POST /v1/marketplace/clips/{clip_id}/download",
+			},
+		},
+		{
+			// Each export holds a worker for up to the row limit; queueing
+			// them in bursts only delays everyone else's.
+			Name:   "exports",
+			Limit:  30,
+			Period: time.Hour,
+			Burst:  5,
+			Key:    ratelimit.KeyPrincipal,
+			Routes: []string{
+				"POST /v1/exports",
+				"GET /teams/{teamId}/exports/members",
+			},
+		},
+		{
+			// SSO logins are unauthenticated until the IdP answers; each
+			// start writes a login state and each answer costs a signature
+			// check and often an IdP round trip.
+			Name:   "team_sso",
+			Limit:  30,
+			Period: time.Minute,
+			Burst:  10,
+			Key:    ratelimit.KeyIP,
+			Routes: []string{
+				"GET /teams/sso/{connectionId}/login",
+				"GET /teams/sso/{connectionId}/oidc/callback",
+				"POST /teams/sso/{connectionId}/saml/acs",
+			},
+		},
+	}
+}
+
+// routePattern returns the mux pattern that will serve r, or "" when no
+// route matches.
+func (s *Server) routePattern(r *http.Request) string {
+	_, pattern := s.mux.Handler(r)
+	return pattern
+}
@@ -2,6 +2,7 @@ package httpserver
 
 import (
 	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"errors"
 	"io"
@@ -9,6 +10,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	campaigndiscoveryservice "solomon/contexts/campaign-editorial/campaign-discovery-service"
@@ -40,11 +42,15 @@ import (
 	reputationservice "solomon/contexts/community-experience/reputation-service"
 	storefrontservice "solomon/contexts/community-experience/storefront-service"
 	subscriptionservice "solomon/contexts/community-experience/subscription-service"
+	billingservice "solomon/contexts/finance-core/billing-service"
+	payoutservice "solomon/contexts/finance-core/payout-service"
+	walletledgerservice "solomon/contexts/finance-core/wallet-ledger-service"
 	authorization "solomon/contexts/identity-access/authorization-service"
 	authzerrors "solomon/contexts/identity-access/authorization-service/domain/errors"
 	authzhttp "solomon/contexts/identity-access/authorization-service/transport/http"
 	onboardingservice "solomon/contexts/identity-access/onboarding-service"
 	admindashboardservice "solomon/contexts/internal-ops/admin-dashboard-service"
+	admindashboardports "solomon/contexts/internal-ops/admin-dashboard-service/ports"
 	superadmindashboard "solomon/contexts/internal-ops/super-admin-dashboard"
 	superadmindomainerrors "solomon/contexts/internal-ops/super-admin-dashboard/domain/errors"
 	superadminhttp "solomon/contexts/internal-ops/super-admin-dashboard/transport/http"
@@ -53,42 +59,89 @@ import (
 	moderationservice "solomon/contexts/moderation-safety/moderation-service"
 
 	httpSwagger "github.com/swaggo/http-swagger"
+	"solomon/internal/platform/export"
 	_ "solomon/internal/platform/httpserver/docs"
+	"solomon/internal/platform/ratelimit"
 )
 
 type Server struct {
-	mux                 *http.ServeMux
-	logger              *slog.Logger
-	addr                string
-	httpServer          *http.Server
-	marketplace         contentlibrarymarketplace.Module
-	authorization       authorization.Module
-	campaign            campaignservice.Module
-	campaignDiscovery   campaigndiscoveryservice.Module
-	clippingTool        clippingtoolservice.Module
-	editorDashboard     editordashboardservice.Module
-	influencerDashboard influencerdashboardservice.Module
-	submission          submissionservice.Module
-	distribution        distributionservice.Module
-	voting              votingengine.Module
-	moderation          moderationservice.Module
-	abusePrevention     abusepreventionservice.Module
-	chat                chatservice.Module
-	reputation          reputationservice.Module
-	communityHealth     communityhealthservice.Module
-	product             productservice.Module
-	storefront          storefrontservice.Module
-	subscription        subscriptionservice.Module
-	onboarding          onboardingservice.Module
-	adminDashboard      admindashboardservice.Module
-	superAdmin          superadmindashboard.Module
-	teamManagement      teammanagementservice.Module
+	mux                     *http.ServeMux
+	logger                  *slog.Logger
+	addr                    string
+	httpServer              *http.Server
+	stopBackground          context.CancelFunc
+	closing                 chan struct{}
+	closeOnce               sync.Once
+	chatHeartbeat           time.Duration
+	clientIPs               ratelimit.ClientIPResolver
+	rateLimiter             *ratelimit.Limiter
+	marketplace             contentlibrarymarketplace.Module
+	authorization           authorization.Module
+	campaign                campaignservice.Module
+	campaignDiscovery       campaigndiscoveryservice.Module
+	clippingTool            clippingtoolservice.Module
+	editorDashboard         editordashboardservice.Module
+	influencerDashboard     influencerdashboardservice.Module
+	submission              submissionservice.Module
+	distribution            distributionservice.Module
+	voting                  votingengine.Module
+	moderation              moderationservice.Module
+	abusePrevention         abusepreventionservice.Module
+	abuseLoginHookToken     []byte
+	onboardingActivityToken []byte
+	chat                    chatservice.Module
+	reputation              reputationservice.Module
+	communityHealth         communityhealthservice.Module
+	product                 productservice.Module
+	storefront              storefrontservice.Module
+	subscription            subscriptionservice.Module
+	billing                 billingservice.Module
+	onboarding              onboardingservice.Module
+	adminDashboard          admindashboardservice.Module
+	superAdmin              superadmindashboard.Module
+	teamManagement          teammanagementservice.Module
+	teamDirectoryToken      []byte
+	walletLedger            walletledgerservice.Module
+	exports                 *export.Service
 }
 
 type ModuleOverrides struct {
 	Moderation      *moderationservice.Module
 	AbusePrevention *abusepreventionservice.Module
 	AdminDashboard  *admindashboardservice.Module
+	SuperAdmin      *superadmindashboard.Module
+	WalletLedger    *walletledgerservice.Module
+	Payout          *payoutservice.Module
+	Onboarding      *onboardingservice.Module
+	Chat            *chatservice.Module
+	CommunityHealth *communityhealthservice.Module
+	TeamManagement  *teammanagementservice.Module
+	Billing         *billingservice.Module
+
+	// RateLimitStore replaces the in-memory rate limit state, e.g. with a
+	// Postgres store shared by every instance.
+	RateLimitStore ratelimit.Store
+	// TrustedProxies lists the proxy CIDRs whose X-Forwarded-For is used to
+	// derive the client IP. Empty means the connection address is used.
+	TrustedProxies []string
+	// AdminAuditSigningKey signs the control-plane audit chain. Empty means
+	// a random per-process key, which only suits development.
+	AdminAuditSigningKey []byte
+	// AdminAuditChain stores the control-plane audit chain and its anchors,
+	// e.g. in Postgres. Nil keeps them in process.
+	AdminAuditChain admindashboardports.Repository
+	// AbuseLoginHookToken is the bearer token the auth service presents
+	// when it reports a login attempt. Empty keeps the hook closed.
+	AbuseLoginHookToken []byte
+	// OnboardingActivityToken is the bearer token platform services present
+	// on the onboarding activity hook. Empty keeps the hook closed.
+	OnboardingActivityToken []byte
+	// TeamDirectorySyncToken is the bearer token M01 presents when it
+	// syncs accounts into the team user directory. Empty keeps it closed.
+	TeamDirectorySyncToken []byte
+	// Exports replaces the in-memory export service, e.g. with Postgres
+	// jobs and shared file storage. Export sources are registered on it.
+	Exports *export.Service
 }
 
 func New(
@@ -144,6 +197,32 @@ func NewWithOverrides(
 
 	clippingToolModule := clippingtoolservice.NewInMemoryModule(logger)
 	editorDashboardModule := editordashboardservice.NewInMemoryModule(logger)
+	billingModule := billingservice.NewInMemoryModule(logger)
+	if overrides.Billing != nil {
+		billingModule = *overrides.Billing
+	}
+	onboardingModule := onboardingservice.NewInMemoryModule(logger)
+	if overrides.Onboarding != nil {
+		onboardingModule = *overrides.Onboarding
+	}
+	chatModule := chatservice.NewInMemoryModule(logger)
+	if overrides.Chat != nil {
+		chatModule = *overrides.Chat
+	}
+	communityHealthModule := communityhealthservice.NewInMemoryModule(logger)
+	if overrides.CommunityHealth != nil {
+		communityHealthModule = *overrides.CommunityHealth
+	}
+
+	teamManagementModule := teammanagementservice.NewInMemoryModule(logger)
+	if overrides.TeamManagement != nil {
+		teamManagementModule = *overrides.TeamManagement
+	}
+
+	payoutModule := payoutservice.NewInMemoryModule(logger)
+	if overrides.Payout != nil {
+		payoutModule = *overrides.Payout
+	}
 
 	adminDashboardModule, err := newAdminDashboardModule(
 		authorizationModule,
@@ -151,6 +230,10 @@ func NewWithOverrides(
 		abusePreventionModule,
 		editorDashboardModule,
 		clippingToolModule,
+		billingModule,
+		payoutModule,
+		overrides.AdminAuditSigningKey,
+		overrides.AdminAuditChain,
 	)
 	if err != nil {
 		return nil, err
@@ -158,42 +241,102 @@ func NewWithOverrides(
 	if overrides.AdminDashboard != nil {
 		adminDashboardModule = *overrides.AdminDashboard
 	}
+	walletLedgerModule := walletledgerservice.NewInMemoryModule(logger)
+	if overrides.WalletLedger != nil {
+		walletLedgerModule = *overrides.WalletLedger
+	}
+	// An overriding super-admin module brings its own wallet ledger wiring.
+	superAdminModule := superadmindashboard.NewInMemoryModuleWithAuditChain(
+		logger,
+		nil,
+		nil,
+		nil,
+		SuperAdminWalletLedger(walletLedgerModule),
+	)
+	if overrides.SuperAdmin != nil {
+		superAdminModule = *overrides.SuperAdmin
+	}
+
+	clientIPs, err := ratelimit.NewClientIPResolver(overrides.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+	rateLimiter, err := ratelimit.NewLimiter(ratelimit.Config{
+		Store:    overrides.RateLimitStore,
+		Policies: rateLimitPolicies(),
+		Default:  defaultRateLimit,
+		Logger:   logger,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	exportService := overrides.Exports
+	if exportService == nil {
+		exportService = newDefaultExportService(logger)
+	}
+	registerExportSources(exportService, teamManagementModule, editorDashboardModule, campaignModule, superAdminModule)
 
 	s := &Server{
-		mux:                 http.NewServeMux(),
-		logger:              logger,
-		addr:                addr,
-		marketplace:         marketplace,
-		authorization:       authorizationModule,
-		campaign:            campaignModule,
-		campaignDiscovery:   campaigndiscoveryservice.NewInMemoryModule(logger),
-		clippingTool:        clippingToolModule,
-		editorDashboard:     editorDashboardModule,
-		influencerDashboard: influencerdashboardservice.NewInMemoryModule(logger),
-		submission:          submissionModule,
-		distribution:        distributionModule,
-		voting:              votingModule,
-		moderation:          moderationModule,
-		abusePrevention:     abusePreventionModule,
-		chat:                chatservice.NewInMemoryModule(logger),
-		reputation:          reputationservice.NewInMemoryModule(logger),
-		communityHealth:     communityhealthservice.NewInMemoryModule(logger),
-		product:             productservice.NewInMemoryModule(logger),
-		storefront:          storefrontservice.NewInMemoryModule(logger),
-		subscription:        subscriptionservice.NewInMemoryModule(logger),
-		onboarding:          onboardingservice.NewInMemoryModule(logger),
-		adminDashboard:      adminDashboardModule,
-		superAdmin:          superadmindashboard.NewInMemoryModule(logger),
-		teamManagement:      teammanagementservice.NewInMemoryModule(logger),
+		mux:                     http.NewServeMux(),
+		closing:                 make(chan struct{}),
+		logger:                  logger,
+		addr:                    addr,
+		clientIPs:               clientIPs,
+		rateLimiter:             rateLimiter,
+		marketplace:             marketplace,
+		authorization:           authorizationModule,
+		campaign:                campaignModule,
+		campaignDiscovery:       campaigndiscoveryservice.NewInMemoryModule(logger),
+		clippingTool:            clippingToolModule,
+		editorDashboard:         editorDashboardModule,
+		influencerDashboard:     influencerdashboardservice.NewInMemoryModule(logger),
+		submission:              submissionModule,
+		distribution:            distributionModule,
+		voting:                  votingModule,
+		moderation:              moderationModule,
+		abusePrevention:         abusePreventionModule,
+		abuseLoginHookToken:     overrides.AbuseLoginHookToken,
+		onboardingActivityToken: overrides.OnboardingActivityToken,
+		chat:                    chatModule,
+		reputation:              reputationservice.NewInMemoryModule(logger),
+		communityHealth:         communityHealthModule,
+		product: productservice.NewInMemoryModuleWithInvoices(
+			logger,
+			productInvoiceIssuer{billingInvoiceIssuer{module: billingModule}},
+		),
+		storefront: storefrontservice.NewInMemoryModule(logger),
+		subscription: subscriptionservice.NewInMemoryModuleWithInvoices(
+			logger,
+			subscriptionInvoiceIssuer{billingInvoiceIssuer{module: billingModule}},
+		),
+		billing:            billingModule,
+		onboarding:         onboardingModule,
+		adminDashboard:     adminDashboardModule,
+		superAdmin:         superAdminModule,
+		teamManagement:     teamManagementModule,
+		teamDirectoryToken: overrides.TeamDirectorySyncToken,
+		walletLedger:       walletLedgerModule,
+		exports:            exportService,
 	}
 	s.registerRoutes()
 	s.httpServer = &http.Server{
 		Addr:    s.addr,
-		Handler: s.mux,
+		Handler: s.Handler(),
 	}
 	return s, nil
 }
 
+// Handler is the full request pipeline: client IP resolution, impersonation
+// token verification, rate limiting and then the routes.
+func (s *Server) Handler() http.Handler {
+	limited := s.impersonation(s.rateLimiter.Wrap(s.mux, s.routePattern))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := ratelimit.WithClientIP(r.Context(), s.clientIPs.Resolve(r))
+		limited.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
 func (s *Server) Start() error {
 	s.logger.Info("http server starting",
 		"event", "http_server_starting",
@@ -204,9 +347,10 @@ func (s *Server) Start() error {
 	if s.httpServer == nil {
 		s.httpServer = &http.Server{
 			Addr:    s.addr,
-			Handler: s.mux,
+			Handler: s.Handler(),
 		}
 	}
+	s.startBackgroundJobs()
 	if err := s.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		return err
 	}
@@ -214,12 +358,88 @@ func (s *Server) Start() error {
 }
 
 func (s *Server) Shutdown(ctx context.Context) error {
+	if s.stopBackground != nil {
+		s.stopBackground()
+	}
+	// Streaming responses never go idle, so end them before draining.
+	s.closeOnce.Do(func() { close(s.closing) })
 	if s.httpServer == nil {
 		return nil
 	}
 	return s.httpServer.Shutdown(ctx)
 }
 
+// startBackgroundJobs runs periodic jobs owned by in-process modules for as
+// long as the server is up.
+func (s *Server) startBackgroundJobs() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stopBackground = cancel
+	go s.runPeriodic(ctx, "onboarding_reminders", time.Minute, s.onboarding.Reminders.RunOnce)
+	go s.runPeriodic(ctx, "subscription_invoice_retry", time.Minute, s.subscription.InvoiceRetry.RunOnce)
+	go s.runPeriodic(ctx, "product_invoice_retry", time.Minute, s.product.InvoiceRetry.RunOnce)
+	if err := s.onboarding.StartConsumers(ctx); err != nil {
+		s.logger.Warn("onboarding activity consumer failed to start",
+			"event", "http_server_onboarding_consumer_start_failed",
+			"module", "internal/platform/httpserver",
+			"layer", "platform",
+			"error", err.Error(),
+		)
+	}
+	if err := s.chat.StartRealtime(ctx); err != nil {
+		s.logger.Warn("chat realtime fan-out failed to start",
+			"event", "http_server_chat_realtime_start_failed",
+			"module", "internal/platform/httpserver",
+			"layer", "platform",
+			"error", err.Error(),
+		)
+	}
+	// Consumers subscribe before the chat outbox drains so the backlog written
+	// while the process was down reaches community-health.
+	if err := s.communityHealth.StartConsumers(ctx); err != nil {
+		s.logger.Warn("community health consumers failed to start",
+			"event", "http_server_community_health_consumers_start_failed",
+			"module", "internal/platform/httpserver",
+			"layer", "platform",
+			"error", err.Error(),
+		)
+	}
+	go s.runPeriodic(ctx, "chat_attachment_scan", 2*time.Second, s.chat.ScanAttachments)
+	go s.runPeriodic(ctx, "chat_link_unfurl", 2*time.Second, s.chat.UnfurlLinks)
+	go s.runPeriodic(ctx, "rate_limit_sweep", time.Minute, s.rateLimiter.Sweep)
+	go s.runPeriodic(ctx, "team_invite_expiry", time.Minute, s.teamManagement.InviteExpiry.RunOnce)
+	go s.runPeriodic(ctx, "export_jobs", 2*time.Second, s.runExportJobs)
+	go s.runPeriodic(ctx, "export_file_expiry", time.Minute, s.sweepExportFiles)
+	go func() {
+		s.runJob(ctx, "chat_outbox_drain", s.chat.DrainOutbox)
+		s.runPeriodic(ctx, "chat_outbox_relay", 2*time.Second, s.chat.RelayOutbox)
+	}()
+}
+
+func (s *Server) runPeriodic(ctx context.Context, job string, interval time.Duration, run func(context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		s.runJob(ctx, job, run)
+	}
+}
+
+func (s *Server) runJob(ctx context.Context, job string, run func(context.Context) error) {
+	if err := run(ctx); err != nil {
+		s.logger.Warn("background job failed",
+			"event", "http_server_background_job_failed",
+			"module", "internal/platform/httpserver",
+			"layer", "platform",
+			"job", job,
+			"error", err.Error(),
+		)
+	}
+}
+
 func (s *Server) registerRoutes() {
 	s.mux.Handle("/swagger/", httpSwagger.Handler(httpSwagger.URL("/swagger/doc.json")))
 
@@ -262,9 +482,14 @@ func (s *Server) registerRoutes() {
 	s.registerAdminOwnedRoute(adminRouteOwnerM20, "GET /api/admin/v1/analytics/dashboard", s.handleAdminAnalyticsDashboard)
 	s.registerAdminOwnedRoute(adminRouteOwnerM20, "GET /api/admin/v1/audit-logs", s.handleAdminAuditLogs)
 	s.registerAdminOwnedRoute(adminRouteOwnerM20, "GET /api/admin/v1/audit-logs/export", s.handleAdminAuditLogsExport)
+	s.registerAdminOwnedRoute(adminRouteOwnerM20, "GET /api/admin/v1/audit-logs/export/{export_id}", s.handleAdminAuditLogsExportGet)
+	s.registerAdminOwnedRoute(adminRouteOwnerM20, "GET /api/admin/v1/audit-logs/verify", s.handleAdminAuditLogsVerify)
+	s.registerAdminOwnedRoute(adminRouteOwnerM20, "GET /api/admin/v1/ledger/accounts/{account_id}", s.handleAdminLedgerAccount)
+	s.registerAdminOwnedRoute(adminRouteOwnerM20, "GET /api/admin/v1/ledger/drifts", s.handleAdminLedgerDrifts)
 
 	// M86
 	s.registerAdminOwnedRoute(adminRouteOwnerM86, "POST /api/admin/v1/actions/log", s.handleAdminRecordAction)
+	s.registerAdminOwnedRoute(adminRouteOwnerM86, "GET /api/admin/v1/actions/log/verify", s.handleAdminVerifyActionLog)
 	s.registerAdminOwnedRoute(adminRouteOwnerM86, "POST /api/admin/v1/identity/roles/grant", s.handleAdminIdentityGrantRole)
 	s.registerAdminOwnedRoute(adminRouteOwnerM86, "POST /api/admin/v1/moderation/decisions", s.handleAdminModerationDecision)
 	s.registerAdminOwnedRoute(adminRouteOwnerM86, "POST /api/admin/v1/abuse-prevention/lockouts/{user_id}/release", s.handleAdminReleaseAbuseLockout)
@@ -335,7 +560,9 @@ func (s *Server) registerRoutes() {
 	s.mux.HandleFunc("GET /api/v1/chat/search", s.handleChatSearch)
 	s.mux.HandleFunc("GET /api/v1/chat/messages", s.handleChatBackfill)
 	s.mux.HandleFunc("GET /api/v1/chat/poll", s.handleChatPoll)
-	s.mux.HandleFunc("GET /api/v1/chat/messages/subscribe", s.handleChatPoll)
+	s.mux.HandleFunc("GET /api/v1/chat/messages/subscribe", s.handleChatSubscribe)
+	s.mux.HandleFunc("GET /api/v1/chat/channels/{channel_id}/ws", s.handleChatWebSocket)
+	s.mux.HandleFunc("POST /api/v1/chat/channels/{channel_id}/typing", s.handleChatTyping)
 	s.mux.HandleFunc("POST /api/v1/chat/messages/{message_id}/reactions", s.handleChatAddReaction)
 	s.mux.HandleFunc("DELETE /api/v1/chat/messages/{message_id}/reactions/{emoji}", s.handleChatRemoveReaction)
 	s.mux.HandleFunc("POST /api/v1/chat/messages/{message_id}/pin", s.handleChatPinMessage)
@@ -351,6 +578,14 @@ func (s *Server) registerRoutes() {
 	s.mux.HandleFunc("POST /webhooks/chat/message", s.handleCommunityHealthWebhook)
 	s.mux.HandleFunc("GET /api/v1/community-health/{server_id}/health-score", s.handleCommunityHealthGetScore)
 	s.mux.HandleFunc("GET /api/v1/community-health/{server_id}/user-risk/{user_id}", s.handleCommunityHealthGetUserRisk)
+	s.mux.HandleFunc("GET /api/v1/community-health/{server_id}/alerts", s.handleCommunityHealthListAlerts)
+	s.mux.HandleFunc("POST /api/v1/community-health/{server_id}/alerts/{alert_id}/acknowledge", s.handleCommunityHealthAlertTransition("acknowledged"))
+	s.mux.HandleFunc("POST /api/v1/community-health/{server_id}/alerts/{alert_id}/resolve", s.handleCommunityHealthAlertTransition("resolved"))
+	s.mux.HandleFunc("POST /api/v1/community-health/{server_id}/alerts/{alert_id}/dismiss", s.handleCommunityHealthAlertTransition("dismissed"))
+	s.mux.HandleFunc("POST /api/v1/community-health/{server_id}/messages/{message_id}/feedback", s.handleCommunityHealthFeedback)
+	s.mux.HandleFunc("GET /api/v1/community-health/{server_id}/moderation-tuning", s.handleCommunityHealthGetTuning)
+	s.mux.HandleFunc("GET /api/v1/community-health/{server_id}/reports", s.handleCommunityHealthListReports)
+	s.mux.HandleFunc("GET /api/v1/community-health/{server_id}/reports/{report_id}", s.handleCommunityHealthGetReport)
 
 	// M48
 	s.mux.HandleFunc("GET /api/v1/reputation/user/{user_id}", s.handleReputationGetUser)
@@ -393,9 +628,18 @@ func (s *Server) registerRoutes() {
 	s.mux.HandleFunc("POST /api/moderation/approve", s.handleModerationApprove)
 	s.mux.HandleFunc("POST /api/moderation/reject", s.handleModerationReject)
 	s.mux.HandleFunc("POST /api/moderation/flag", s.handleModerationFlag)
+	s.mux.HandleFunc("POST /api/moderation/queue/next/claim", s.handleModerationClaimNext)
+	s.mux.HandleFunc("POST /api/moderation/queue/{submission_id}/claim", s.handleModerationClaim)
+	s.mux.HandleFunc("POST /api/moderation/queue/{submission_id}/release", s.handleModerationRelease)
+	s.mux.HandleFunc("PUT /api/moderation/moderators/{moderator_id}", s.handleModerationUpsertModerator)
+	s.mux.HandleFunc("GET /api/moderation/moderators/{moderator_id}/metrics", s.handleModerationModeratorMetrics)
+	s.mux.HandleFunc("POST /api/moderation/decisions/{decision_id}/appeals", s.handleModerationFileAppeal)
+	s.mux.HandleFunc("GET /api/moderation/appeals", s.handleModerationListAppeals)
+	s.mux.HandleFunc("GET /api/moderation/appeals/{appeal_id}", s.handleModerationGetAppeal)
+	s.mux.HandleFunc("POST /api/moderation/appeals/{appeal_id}/resolve", s.handleModerationResolveAppeal)
 
 	// M37
-	s.mux.HandleFunc("POST /api/v1/auth/login", s.handleAbuseLogin)
+	s.mux.HandleFunc("POST /api/v1/internal/auth/login-attempts", s.handleAbuseLoginAttempt)
 	s.mux.HandleFunc("POST /api/v1/auth/challenge/{id}", s.handleAbuseChallenge)
 	s.mux.HandleFunc("GET /api/v1/admin/abuse-threats", s.handleAbuseAdminThreats)
 	s.mux.HandleFunc("POST /api/v1/admin/abuse-threats/{user_id}/lockout/release", s.handleAbuseAdminReleaseLockout)
@@ -405,29 +649,72 @@ func (s *Server) registerRoutes() {
 	s.mux.HandleFunc("POST /api/v1/subscriptions/{subscription_id}/change-plan", s.handleSubscriptionChangePlan)
 	s.mux.HandleFunc("POST /api/v1/subscriptions/{subscription_id}/cancel", s.handleSubscriptionCancel)
 
+	// M05
+	s.mux.HandleFunc("GET /api/v1/billing/invoices", s.handleBillingListInvoices)
+	s.mux.HandleFunc("GET /api/v1/billing/invoices/{invoice_id}", s.handleBillingGetInvoice)
+	s.mux.HandleFunc("GET /api/v1/billing/invoices/{invoice_id}/pdf", s.handleBillingGetInvoicePDF)
+
 	// M22
 	s.mux.HandleFunc("GET /api/onboarding/v1/flow", s.handleOnboardingGetFlow)
 	s.mux.HandleFunc("POST /api/onboarding/v1/steps/{step_key}/complete", s.handleOnboardingCompleteStep)
 	s.mux.HandleFunc("POST /api/onboarding/v1/skip", s.handleOnboardingSkip)
 	s.mux.HandleFunc("POST /api/onboarding/v1/resume", s.handleOnboardingResume)
+	s.mux.HandleFunc("GET /api/onboarding/v1/reminders/preferences", s.handleOnboardingGetReminderPreferences)
+	s.mux.HandleFunc("PUT /api/onboarding/v1/reminders/preferences", s.handleOnboardingUpdateReminderPreferences)
+	s.mux.HandleFunc("POST /api/onboarding/v1/reminders/unsubscribe", s.handleOnboardingUnsubscribeReminders)
 	s.mux.HandleFunc("GET /api/onboarding/v1/admin/flows", s.handleOnboardingAdminFlows)
+	s.mux.HandleFunc("POST /api/onboarding/v1/admin/flows", s.handleOnboardingAdminCreateFlow)
+	s.mux.HandleFunc("GET /api/onboarding/v1/admin/flows/{flow_id}", s.handleOnboardingAdminGetFlow)
+	s.mux.HandleFunc("PUT /api/onboarding/v1/admin/flows/{flow_id}/draft", s.handleOnboardingAdminSaveDraft)
+	s.mux.HandleFunc("POST /api/onboarding/v1/admin/flows/{flow_id}/versions/{version}/publish", s.handleOnboardingAdminPublishVersion)
+	s.mux.HandleFunc("DELETE /api/onboarding/v1/admin/flows/{flow_id}", s.handleOnboardingAdminArchiveFlow)
+	s.mux.HandleFunc("GET /api/onboarding/v1/admin/experiments", s.handleOnboardingAdminListExperiments)
+	s.mux.HandleFunc("POST /api/onboarding/v1/admin/experiments", s.handleOnboardingAdminCreateExperiment)
+	s.mux.HandleFunc("POST /api/onboarding/v1/admin/experiments/{experiment_key}/stop", s.handleOnboardingAdminStopExperiment)
+	s.mux.HandleFunc("GET /api/onboarding/v1/admin/experiments/{experiment_key}/results", s.handleOnboardingAdminExperimentResults)
 	s.mux.HandleFunc("POST /api/onboarding/v1/internal/events/user-registered", s.handleOnboardingUserRegisteredEvent)
+	s.mux.HandleFunc("POST /api/onboarding/v1/internal/events/activity", s.handleOnboardingActivityEvent)
 
 	// M87
 	s.mux.HandleFunc("POST /teams", s.handleTeamCreate)
 	s.mux.HandleFunc("POST /teams/{teamId}/invites", s.handleTeamCreateInvite)
 	s.mux.HandleFunc("POST /teams/invites/{token}/accept", s.handleTeamAcceptInvite)
+	s.mux.HandleFunc("POST /teams/{teamId}/invites/{inviteId}/resend", s.handleTeamResendInvite)
+	s.mux.HandleFunc("DELETE /teams/{teamId}/invites/{inviteId}", s.handleTeamRevokeInvite)
 	s.mux.HandleFunc("POST /teams/{teamId}/members/{memberId}/role", s.handleTeamUpdateMemberRole)
 	s.mux.HandleFunc("DELETE /teams/{teamId}/members/{memberId}", s.handleTeamRemoveMember)
 	s.mux.HandleFunc("GET /teams/{teamId}", s.handleTeamGet)
 	s.mux.HandleFunc("GET /teams/{teamId}/membership", s.handleTeamMembership)
 	s.mux.HandleFunc("GET /teams/{teamId}/audit-logs", s.handleTeamAuditLogs)
 	s.mux.HandleFunc("GET /teams/{teamId}/exports/members", s.handleTeamExportMembers)
+	s.mux.HandleFunc("PUT /teams/internal/directory/users/{userId}", s.handleTeamDirectorySyncUser)
+	s.mux.HandleFunc("POST /teams/{teamId}/sso/connections", s.handleTeamSSOCreateConnection)
+	s.mux.HandleFunc("GET /teams/{teamId}/sso/connections", s.handleTeamSSOListConnections)
+	s.mux.HandleFunc("PUT /teams/{teamId}/sso/connections/{connectionId}", s.handleTeamSSOUpdateConnection)
+	s.mux.HandleFunc("POST /teams/{teamId}/sso/connections/{connectionId}/scim-token", s.handleTeamSSORotateSCIMToken)
+	s.mux.HandleFunc("GET /teams/sso/{connectionId}/login", s.handleTeamSSOLogin)
+	s.mux.HandleFunc("GET /teams/sso/{connectionId}/oidc/callback", s.handleTeamSSOOIDCCallback)
+	s.mux.HandleFunc("POST /teams/sso/{connectionId}/saml/acs", s.handleTeamSSOSAMLACS)
+	s.mux.HandleFunc("GET /teams/sso/{connectionId}/saml/metadata", s.handleTeamSSOSAMLMetadata)
+	s.mux.HandleFunc("GET /scim/v2/teams/sso/{connectionId}/ServiceProviderConfig", s.handleTeamSCIMServiceProviderConfig)
+	s.mux.HandleFunc("GET /scim/v2/teams/sso/{connectionId}/Users", s.handleTeamSCIMListUsers)
+	s.mux.HandleFunc("POST /scim/v2/teams/sso/{connectionId}/Users", s.handleTeamSCIMCreateUser)
+	s.mux.HandleFunc("GET /scim/v2/teams/sso/{connectionId}/Users/{userId}", s.handleTeamSCIMGetUser)
+	s.mux.HandleFunc("PUT /scim/v2/teams/sso/{connectionId}/Users/{userId}", s.handleTeamSCIMReplaceUser)
+	s.mux.HandleFunc("PATCH /scim/v2/teams/sso/{connectionId}/Users/{userId}", s.handleTeamSCIMPatchUser)
+	s.mux.HandleFunc("DELETE /scim/v2/teams/sso/{connectionId}/Users/{userId}", s.handleTeamSCIMDeleteUser)
+
+	// Shared asynchronous exports
+	s.mux.HandleFunc("POST /v1/exports", s.handleExportCreate)
+	s.mux.HandleFunc("GET /v1/exports/{export_id}", s.handleExportGet)
+	s.mux.HandleFunc("GET /v1/exports/{export_id}/download", s.handleExportDownload)
 
 	// M87 delegated path compatibility
 	s.mux.HandleFunc("POST /v1/team", s.handleTeamCreate)
 	s.mux.HandleFunc("POST /v1/team/{team_id}/invites", s.handleTeamCreateInviteV1)
 	s.mux.HandleFunc("POST /v1/team/invites/{invite_id}/accept", s.handleTeamAcceptInviteV1)
+	s.mux.HandleFunc("POST /v1/team/{team_id}/invites/{invite_id}/resend", s.handleTeamResendInviteV1)
+	s.mux.HandleFunc("DELETE /v1/team/{team_id}/invites/{invite_id}", s.handleTeamRevokeInviteV1)
 	s.mux.HandleFunc("PUT /v1/team/{team_id}/members/{member_id}/role", s.handleTeamUpdateMemberRoleV1)
 	s.mux.HandleFunc("DELETE /v1/team/{team_id}/members/{member_id}", s.handleTeamRemoveMemberV1)
 
@@ -456,6 +743,8 @@ func (s *Server) registerRoutes() {
 	s.mux.HandleFunc("POST /submissions/{submission_id}/report", s.handleSubmissionReport)
 	s.mux.HandleFunc("POST /submissions/bulk-operations", s.handleSubmissionBulkOperation)
 	s.mux.HandleFunc("GET /submissions/{submission_id}/analytics", s.handleSubmissionAnalytics)
+	s.mux.HandleFunc("PUT /submissions/campaigns/{campaign_id}/screening-policy", s.handleSubmissionPutScreeningPolicy)
+	s.mux.HandleFunc("GET /submissions/campaigns/{campaign_id}/screening-policy", s.handleSubmissionGetScreeningPolicy)
 	s.mux.HandleFunc("GET /dashboard/creator", s.handleSubmissionCreatorDashboard)
 	s.mux.HandleFunc("GET /dashboard/brand", s.handleSubmissionBrandDashboard)
 	s.mux.HandleFunc("POST /v1/submissions", s.handleSubmissionCreate)
@@ -466,6 +755,8 @@ func (s *Server) registerRoutes() {
 	s.mux.HandleFunc("POST /v1/submissions/{submission_id}/report", s.handleSubmissionReport)
 	s.mux.HandleFunc("POST /v1/submissions/bulk-operations", s.handleSubmissionBulkOperation)
 	s.mux.HandleFunc("GET /v1/submissions/{submission_id}/analytics", s.handleSubmissionAnalytics)
+	s.mux.HandleFunc("PUT /v1/submissions/campaigns/{campaign_id}/screening-policy", s.handleSubmissionPutScreeningPolicy)
+	s.mux.HandleFunc("GET /v1/submissions/campaigns/{campaign_id}/screening-policy", s.handleSubmissionGetScreeningPolicy)
 	s.mux.HandleFunc("GET /v1/dashboard/creator", s.handleSubmissionCreatorDashboard)
 	s.mux.HandleFunc("GET /v1/dashboard/brand", s.handleSubmissionBrandDashboard)
 
@@ -512,11 +803,23 @@ func getRequestID(r *http.Request) string {
 	return strings.TrimSpace(r.Header.Get("Idempotency-Key"))
 }
 
+// resolveClientIP returns the client IP derived by Handler, which only
+// trusts X-Forwarded-For from configured proxies.
 func resolveClientIP(r *http.Request) string {
-	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
-		return forwarded
+	if ip := ratelimit.ClientIPFromContext(r.Context()); ip != "" {
+		return ip
 	}
-	return r.RemoteAddr
+	return ratelimit.ClientIPResolver{}.Resolve(r)
+}
+
+// hasServiceToken reports whether r carries token as its bearer token. An
+// empty token matches nothing, so an unconfigured internal hook is closed.
+func hasServiceToken(r *http.Request, token []byte) bool {
+	parts := strings.SplitN(strings.TrimSpace(r.Header.Get("Authorization")), " ", 2)
+	return len(token) > 0 &&
+		len(parts) == 2 &&
+		strings.EqualFold(parts[0], "Bearer") &&
+		subtle.ConstantTimeCompare([]byte(strings.TrimSpace(parts[1])), token) == 1
 }
 
 func resolveAuthzUserID(bodyUserID string, r *http.Request) string {
@@ -941,6 +1244,10 @@ func writeChatDomainError(w http.ResponseWriter, err error) {
 	case errors.Is(err, chatdomainerrors.ErrIdempotencyConflict),
 		errors.Is(err, chatdomainerrors.ErrConflict):
 		writeChatError(w, http.StatusConflict, "conflict", err.Error())
+	case errors.Is(err, chatdomainerrors.ErrAttachmentNotReady):
+		writeChatError(w, http.StatusConflict, "attachment_not_ready", err.Error())
+	case errors.Is(err, chatdomainerrors.ErrAttachmentBlocked):
+		writeChatError(w, http.StatusForbidden, "attachment_quarantined", err.Error())
 	case errors.Is(err, chatdomainerrors.ErrRateLimited):
 		writeChatError(w, http.StatusTooManyRequests, "rate_limit_exceeded", err.Error())
 	case errors.Is(err, chatdomainerrors.ErrForbidden):
@@ -954,6 +1261,8 @@ func writeCampaignDomainError(w http.ResponseWriter, err error) {
 	switch {
 	case errors.Is(err, campaignerrors.ErrCampaignNotFound):
 		writeCampaignError(w, http.StatusNotFound, "campaign_not_found", err.Error())
+	case errors.Is(err, campaignerrors.ErrCampaignAccessDenied):
+		writeCampaignError(w, http.StatusForbidden, "forbidden", err.Error())
 	case errors.Is(err, campaignerrors.ErrMediaFileTooLarge):
 		writeCampaignError(w, http.StatusRequestEntityTooLarge, "file_too_large", err.Error())
 	case errors.Is(err, campaignerrors.ErrInvalidCampaignInput),
@@ -1007,6 +1316,10 @@ func writeSubmissionDomainError(w http.ResponseWriter, err error) {
 		writeSubmissionError(w, http.StatusConflict, "invalid_status_transition", err.Error())
 	case errors.Is(err, submissionerrors.ErrUnauthorizedActor):
 		writeSubmissionError(w, http.StatusForbidden, "forbidden", err.Error())
+	case errors.Is(err, submissionerrors.ErrInvalidScreeningPolicy):
+		writeSubmissionError(w, http.StatusBadRequest, "invalid_screening_policy", err.Error())
+	case errors.Is(err, submissionerrors.ErrScreeningPolicyNotFound):
+		writeSubmissionError(w, http.StatusNotFound, "screening_policy_not_found", err.Error())
 	default:
 		writeSubmissionError(w, http.StatusInternalServerError, "internal_error", "internal server error")
 	}
@@ -1696,13 +2009,29 @@ func (s *Server) handleAdminAuditLogs(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, resp)
 }
 
-func (s *Server) handleAdminAuditLogsExport(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleAdminAuditLogsVerify(w http.ResponseWriter, r *http.Request) {
 	if !requireAdminHeaders(w, r) {
 		return
 	}
 	if _, ok := requireAdminID(w, r); !ok {
 		return
 	}
+	resp, err := s.superAdmin.Handler.VerifyAuditChainHandler(r.Context())
+	if err != nil {
+		writeSuperAdminDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleAdminAuditLogsExport(w http.ResponseWriter, r *http.Request) {
+	if !requireAdminHeaders(w, r) {
+		return
+	}
+	adminID, ok := requireAdminID(w, r)
+	if !ok {
+		return
+	}
 	query := r.URL.Query()
 	format := strings.TrimSpace(query.Get("format"))
 	startRaw := strings.TrimSpace(query.Get("start"))
@@ -1723,6 +2052,10 @@ func (s *Server) handleAdminAuditLogsExport(w http.ResponseWriter, r *http.Reque
 		writeSuperAdminError(w, http.StatusUnprocessableEntity, "invalid_date_range", "end/date_to must be RFC3339 or YYYY-MM-DD")
 		return
 	}
+	if !start.IsZero() && !end.IsZero() && end.Before(start) {
+		writeSuperAdminError(w, http.StatusUnprocessableEntity, "invalid_date_range", "end/date_to must not be before start/date_from")
+		return
+	}
 	includeSignatures := false
 	if raw := strings.TrimSpace(query.Get("include_signatures")); raw != "" {
 		parsed, err := strconv.ParseBool(raw)
@@ -1732,12 +2065,56 @@ func (s *Server) handleAdminAuditLogsExport(w http.ResponseWriter, r *http.Reque
 		}
 		includeSignatures = parsed
 	}
-	resp, err := s.superAdmin.Handler.ExportAuditLogsHandler(r.Context(), format, start, end, includeSignatures)
+	rowLimit := 0
+	if raw := strings.TrimSpace(query.Get("limit")); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			writeSuperAdminError(w, http.StatusBadRequest, "invalid_request", "limit must be an integer")
+			return
+		}
+		rowLimit = parsed
+	}
+	params := map[string]string{}
+	if !start.IsZero() {
+		params["start"] = start.UTC().Format(time.RFC3339Nano)
+	}
+	if !end.IsZero() {
+		params["end"] = end.UTC().Format(time.RFC3339Nano)
+	}
+	job, err := s.exports.Request(r.Context(), export.Request{
+		Kind:        exportKindAdminAuditLogs,
+		RequestedBy: adminID,
+		Params:      params,
+		Format:      format,
+		Columns:     adminAuditExportColumns(includeSignatures),
+		RowLimit:    rowLimit,
+	})
+	if err != nil {
+		writeAdminExportError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, s.adminAuditLogExportResponse(job))
+}
+
+// handleAdminAuditLogsExportGet shows the requesting admin the status of an
+// audit log export and, once completed, its signed download link.
+func (s *Server) handleAdminAuditLogsExportGet(w http.ResponseWriter, r *http.Request) {
+	if !requireAdminHeaders(w, r) {
+		return
+	}
+	adminID, ok := requireAdminID(w, r)
+	if !ok {
+		return
+	}
+	job, err := s.exports.Get(r.Context(), r.PathValue("export_id"), adminID)
+	if err == nil && job.Kind != exportKindAdminAuditLogs {
+		err = export.ErrNotFound
+	}
 	if err != nil {
-		writeSuperAdminDomainError(w, err)
+		writeAdminExportError(w, err)
 		return
 	}
-	writeJSON(w, http.StatusOK, resp)
+	writeJSON(w, http.StatusOK, s.adminAuditLogExportResponse(job))
 }
 
 func (s *Server) handleProductList(w http.ResponseWriter, r *http.Request) {
@@ -2076,6 +2453,7 @@ func (s *Server) handleChatListMessages(w http.ResponseWriter, r *http.Request)
 		r.Context(),
 		r.PathValue("channel_id"),
 		strings.TrimSpace(r.URL.Query().Get("before")),
+		strings.TrimSpace(r.URL.Query().Get("after")),
 		afterSeq,
 		limit,
 	)
@@ -2156,7 +2534,14 @@ func (s *Server) handleChatBackfill(w http.ResponseWriter, r *http.Request) {
 		}
 		limit = parsed
 	}
-	resp, err := s.chat.Handler.ListMessagesHandler(r.Context(), channelID, "", afterSeq, limit)
+	resp, err := s.chat.Handler.ListMessagesHandler(
+		r.Context(),
+		channelID,
+		"",
+		strings.TrimSpace(r.URL.Query().Get("after")),
+		afterSeq,
+		limit,
+	)
 	if err != nil {
 		writeChatDomainError(w, err)
 		return
@@ -2182,7 +2567,14 @@ func (s *Server) handleChatPoll(w http.ResponseWriter, r *http.Request) {
 		}
 		afterSeq = parsed
 	}
-	resp, err := s.chat.Handler.ListMessagesHandler(r.Context(), channelID, "", afterSeq, 50)
+	resp, err := s.chat.Handler.ListMessagesHandler(
+		r.Context(),
+		channelID,
+		"",
+		strings.TrimSpace(r.URL.Query().Get("after")),
+		afterSeq,
+		50,
+	)
 	if err != nil {
 		writeChatDomainError(w, err)
 		return
@@ -2412,6 +2804,7 @@ func (s *Server) handleChatExport(w http.ResponseWriter, r *http.Request) {
 		r.Context(),
 		strings.TrimSpace(r.URL.Query().Get("server_id")),
 		strings.TrimSpace(r.URL.Query().Get("channel_id")),
+		strings.TrimSpace(r.URL.Query().Get("cursor")),
 		limit,
 	)
 	if err != nil {
@@ -2611,12 +3004,52 @@ func (s *Server) handleCampaignAnalytics(w http.ResponseWriter, r *http.Request)
 }
 
 func (s *Server) handleCampaignAnalyticsExport(w http.ResponseWriter, r *http.Request) {
-	resp, err := s.campaign.Handler.ExportAnalyticsHandler(r.Context(), r.PathValue("campaign_id"))
+	userID := getUserID(r)
+	if userID == "" {
+		writeCampaignError(w, http.StatusUnauthorized, "missing_user", "X-User-Id header is required")
+		return
+	}
+	if getRequestID(r) == "" {
+		writeCampaignError(w, http.StatusBadRequest, "missing_request_id", "X-Request-Id header is required")
+		return
+	}
+	query := r.URL.Query()
+	rowLimit := 0
+	if raw := strings.TrimSpace(query.Get("limit")); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			writeCampaignError(w, http.StatusBadRequest, "invalid_request", "limit must be an integer")
+			return
+		}
+		rowLimit = parsed
+	}
+	var columns []string
+	if raw := strings.TrimSpace(query.Get("columns")); raw != "" {
+		columns = strings.Split(raw, ",")
+	}
+	campaignID := strings.TrimSpace(r.PathValue("campaign_id"))
+	job, err := s.exports.Request(r.Context(), export.Request{
+		Kind:        exportKindCampaignAnalytics,
+		RequestedBy: userID,
+		Params:      map[string]string{"campaign_id": campaignID},
+		Format:      query.Get("format"),
+		Columns:     columns,
+		RowLimit:    rowLimit,
+	})
 	if err != nil {
-		writeCampaignDomainError(w, err)
+		writeExportDomainError(w, err)
 		return
 	}
-	writeJSON(w, http.StatusOK, resp)
+	writeJSON(w, http.StatusAccepted, campaignhttp.ExportAnalyticsResponse{
+		ExportJobID: job.ExportID,
+		CampaignID:  campaignID,
+		Status:      job.Status,
+		Format:      job.Format,
+		Columns:     job.Columns,
+		RowLimit:    job.RowLimit,
+		CreatedAt:   job.CreatedAt.UTC().Format(time.RFC3339),
+		StatusURL:   exportStatusURLBase + job.ExportID,
+	})
 }
 
 func (s *Server) handleCampaignIncreaseBudget(w http.ResponseWriter, r *http.Request) {
@@ -2778,6 +3211,42 @@ func (s *Server) handleSubmissionReject(w http.ResponseWriter, r *http.Request)
 	})
 }
 
+func (s *Server) handleSubmissionPutScreeningPolicy(w http.ResponseWriter, r *http.Request) {
+	if !requireSubmissionAuthorization(w, r) || !requireSubmissionRequestID(w, r) {
+		return
+	}
+	userID, ok := requireSubmissionUser(w, r)
+	if !ok {
+		return
+	}
+	var req submissionhttp.PutScreeningPolicyRequest
+	if !s.decodeJSON(w, r, &req, writeSubmissionError) {
+		return
+	}
+	resp, err := s.submission.Handler.PutScreeningPolicyHandler(r.Context(), userID, r.PathValue("campaign_id"), req)
+	if err != nil {
+		writeSubmissionDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleSubmissionGetScreeningPolicy(w http.ResponseWriter, r *http.Request) {
+	if !requireSubmissionAuthorization(w, r) || !requireSubmissionRequestID(w, r) {
+		return
+	}
+	userID, ok := requireSubmissionUser(w, r)
+	if !ok {
+		return
+	}
+	resp, err := s.submission.Handler.GetScreeningPolicyHandler(r.Context(), userID, r.PathValue("campaign_id"))
+	if err != nil {
+		writeSubmissionDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
 func (s *Server) handleSubmissionReport(w http.ResponseWriter, r *http.Request) {
 	if !requireSubmissionAuthorization(w, r) || !requireSubmissionRequestID(w, r) {
 		return
@@ -22,50 +22,22 @@ type abuseErrorBody struct {
 	Details map[string]any `json:"details,omitempty"`
 }
 
-type abuseLoginRequest struct {
-	FailedAttempts int  `json:"failed_attempts"`
-	KnownDevice    bool `json:"known_device"`
-	VPNDetected    bool `json:"vpn_detected"`
-}
-
-type abuseChallengeRequest struct {
-	Passed bool `json:"passed"`
-}
-
 type abuseLoginResponse struct {
-	Status    string        `json:"status"`
-	Data      abuseDecision `json:"data"`
-	Timestamp string        `json:"timestamp"`
+	Status    string                     `json:"status"`
+	Data      abusehttp.LoginDecisionDTO `json:"data"`
+	Timestamp string                     `json:"timestamp"`
 }
 
 type abuseChallengeResponse struct {
-	Status    string            `json:"status"`
-	Data      abuseChallengeDTO `json:"data"`
-	Timestamp string            `json:"timestamp"`
+	Status    string                       `json:"status"`
+	Data      abusehttp.ChallengeResultDTO `json:"data"`
+	Timestamp string                       `json:"timestamp"`
 }
 
 type abuseThreatsResponse struct {
-	Status    string          `json:"status"`
-	Data      abuseThreatsDTO `json:"data"`
-	Timestamp string          `json:"timestamp"`
-}
-
-type abuseDecision struct {
-	RiskScore   float64 `json:"risk_score"`
-	RiskTier    string  `json:"risk_tier"`
-	Decision    string  `json:"decision"`
-	ChallengeID string  `json:"challenge_id,omitempty"`
-}
-
-type abuseChallengeDTO struct {
-	ChallengeID string `json:"challenge_id"`
-	Result      string `json:"result"`
-}
-
-type abuseThreatsDTO struct {
-	ActiveSuspiciousAccounts int `json:"active_suspicious_accounts"`
-	FailedLoginsLast24h      int `json:"failed_logins_last_24h"`
-	ActiveLockouts           int `json:"active_lockouts"`
+	Status    string                     `json:"status"`
+	Data      abusehttp.ThreatSummaryDTO `json:"data"`
+	Timestamp string                     `json:"timestamp"`
 }
 
 func writeAbuseError(w http.ResponseWriter, status int, code string, message string, details map[string]any) {
@@ -114,17 +86,31 @@ func requireAbuseAdminID(w http.ResponseWriter, r *http.Request) bool {
 	return true
 }
 
-func (s *Server) handleAbuseLogin(w http.ResponseWriter, r *http.Request) {
-	if !requireAbuseRequestID(w, r) {
+// requireAbuseLoginHookToken admits only the auth service, which alone
+// knows whether the credentials were valid.
+func (s *Server) requireAbuseLoginHookToken(w http.ResponseWriter, r *http.Request) bool {
+	if !hasServiceToken(r, s.abuseLoginHookToken) {
+		writeAbuseError(w, http.StatusUnauthorized, "UNAUTHORIZED", "login attempts are reported by the auth service only", nil)
+		return false
+	}
+	return true
+}
+
+func (s *Server) handleAbuseLoginAttempt(w http.ResponseWriter, r *http.Request) {
+	if !s.requireAbuseLoginHookToken(w, r) || !requireAbuseRequestID(w, r) {
 		return
 	}
-	var req abuseLoginRequest
+	var req abusehttp.LoginRequest
 	if !s.decodeJSON(w, r, &req, func(w http.ResponseWriter, status int, code string, message string) {
 		writeAbuseError(w, status, strings.ToUpper(code), message, nil)
 	}) {
 		return
 	}
-	decision := evaluateAbuseRisk(req)
+	decision, err := s.abusePrevention.Handler.LoginHandler(r.Context(), req)
+	if err != nil {
+		writeAbuseDomainError(w, err)
+		return
+	}
 	writeJSON(w, http.StatusOK, abuseLoginResponse{
 		Status:    "success",
 		Data:      decision,
@@ -132,26 +118,35 @@ func (s *Server) handleAbuseLogin(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// handleAbuseChallenge takes a proof-of-work solution from the end user
+// mid-login, before they hold any credential. The challenge is bound to the
+// client address and device it was issued to, so only that caller can
+// answer it; passing it only matters once the auth service reports the login
+// again.
 func (s *Server) handleAbuseChallenge(w http.ResponseWriter, r *http.Request) {
-	if !requireAbuseAuthorization(w, r) || !requireAbuseRequestID(w, r) || !requireAbuseIdempotencyKey(w, r) {
+	if !requireAbuseRequestID(w, r) || !requireAbuseIdempotencyKey(w, r) {
 		return
 	}
-	var req abuseChallengeRequest
+	var req abusehttp.ChallengeRequest
 	if !s.decodeJSON(w, r, &req, func(w http.ResponseWriter, status int, code string, message string) {
 		writeAbuseError(w, status, strings.ToUpper(code), message, nil)
 	}) {
 		return
 	}
-	result := "failed"
-	if req.Passed {
-		result = "passed"
+	result, err := s.abusePrevention.Handler.ChallengeHandler(
+		r.Context(),
+		strings.TrimSpace(r.Header.Get("Idempotency-Key")),
+		resolveClientIP(r),
+		r.PathValue("id"),
+		req,
+	)
+	if err != nil {
+		writeAbuseDomainError(w, err)
+		return
 	}
 	writeJSON(w, http.StatusOK, abuseChallengeResponse{
-		Status: "success",
-		Data: abuseChallengeDTO{
-			ChallengeID: r.PathValue("id"),
-			Result:      result,
-		},
+		Status:    "success",
+		Data:      result,
 		Timestamp: time.Now().UTC().Format(time.RFC3339),
 	})
 }
@@ -160,13 +155,14 @@ func (s *Server) handleAbuseAdminThreats(w http.ResponseWriter, r *http.Request)
 	if !requireAbuseAuthorization(w, r) || !requireAbuseRequestID(w, r) || !requireAbuseAdminID(w, r) {
 		return
 	}
+	summary, err := s.abusePrevention.Handler.ThreatSummaryHandler(r.Context(), strings.TrimSpace(r.Header.Get("X-Admin-Id")))
+	if err != nil {
+		writeAbuseDomainError(w, err)
+		return
+	}
 	writeJSON(w, http.StatusOK, abuseThreatsResponse{
-		Status: "success",
-		Data: abuseThreatsDTO{
-			ActiveSuspiciousAccounts: 12,
-			FailedLoginsLast24h:      97,
-			ActiveLockouts:           4,
-		},
+		Status:    "success",
+		Data:      summary,
 		Timestamp: time.Now().UTC().Format(time.RFC3339),
 	})
 }
@@ -181,6 +177,12 @@ func writeAbuseDomainError(w http.ResponseWriter, err error) {
 		writeAbuseError(w, http.StatusForbidden, "FORBIDDEN", err.Error(), nil)
 	case errors.Is(err, abuseerrors.ErrThreatNotFound):
 		writeAbuseError(w, http.StatusNotFound, "THREAT_NOT_FOUND", err.Error(), nil)
+	case errors.Is(err, abuseerrors.ErrChallengeNotFound):
+		writeAbuseError(w, http.StatusNotFound, "CHALLENGE_NOT_FOUND", err.Error(), nil)
+	case errors.Is(err, abuseerrors.ErrChallengeExpired):
+		writeAbuseError(w, http.StatusGone, "CHALLENGE_EXPIRED", err.Error(), nil)
+	case errors.Is(err, abuseerrors.ErrChallengeClosed):
+		writeAbuseError(w, http.StatusConflict, "CHALLENGE_CLOSED", err.Error(), nil)
 	case errors.Is(err, abuseerrors.ErrIdempotencyKeyRequired):
 		writeAbuseError(w, http.StatusBadRequest, "IDEMPOTENCY_KEY_REQUIRED", err.Error(), nil)
 	case errors.Is(err, abuseerrors.ErrIdempotencyConflict):
@@ -225,26 +227,3 @@ func (s *Server) handleAbuseAdminReleaseLockout(w http.ResponseWriter, r *http.R
 	}
 	writeJSON(w, http.StatusOK, resp)
 }
-
-func evaluateAbuseRisk(req abuseLoginRequest) abuseDecision {
-	score := 0.1
-	if req.FailedAttempts >= 3 {
-		score += 0.4
-	}
-	if !req.KnownDevice {
-		score += 0.2
-	}
-	if req.VPNDetected {
-		score += 0.3
-	}
-	switch {
-	case score >= 0.9:
-		return abuseDecision{RiskScore: score, RiskTier: "critical", Decision: "block", ChallengeID: "challenge-critical"}
-	case score >= 0.7:
-		return abuseDecision{RiskScore: score, RiskTier: "high", Decision: "challenge", ChallengeID: "challenge-high"}
-	case score >= 0.3:
-		return abuseDecision{RiskScore: score, RiskTier: "medium", Decision: "challenge", ChallengeID: "challenge-medium"}
-	default:
-		return abuseDecision{RiskScore: score, RiskTier: "low", Decision: "allow"}
-	}
-}
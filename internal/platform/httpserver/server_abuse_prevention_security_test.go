@@ -2,15 +2,72 @@ package httpserver
 
 import (
 	"bytes"
+	"encoding/json"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"testing"
+
+	campaignservice "solomon/contexts/campaign-editorial/campaign-service"
+	contentlibrarymarketplace "solomon/contexts/campaign-editorial/content-library-marketplace"
+	distributionservice "solomon/contexts/campaign-editorial/distribution-service"
+	submissionservice "solomon/contexts/campaign-editorial/submission-service"
+	votingengine "solomon/contexts/campaign-editorial/voting-engine"
+	authorization "solomon/contexts/identity-access/authorization-service"
 )
 
+const testAbuseLoginHookToken = "test-login-hook-token-0123456789abcdef"
+
+func newAbuseHookTestServer(t *testing.T) *Server {
+	t.Helper()
+	_ = os.Setenv(adminRuntimeModeEnv, "test")
+	server, err := NewWithOverrides(
+		contentlibrarymarketplace.NewInMemoryModule(nil, slog.Default()),
+		authorization.NewInMemoryModule(slog.Default()),
+		campaignservice.NewInMemoryModule(nil, slog.Default()),
+		submissionservice.NewInMemoryModule(nil, slog.Default()),
+		distributionservice.NewInMemoryModule(nil, slog.Default()),
+		votingengine.NewInMemoryModule(nil, slog.Default()),
+		slog.Default(),
+		":0",
+		ModuleOverrides{AbuseLoginHookToken: []byte(testAbuseLoginHookToken)},
+	)
+	if err != nil {
+		t.Fatalf("build server: %v", err)
+	}
+	return server
+}
+
+func TestAbuseLoginAttemptRequiresHookToken(t *testing.T) {
+	for name, server := range map[string]*Server{
+		"hook closed": newTestServer(),
+		"hook open":   newAbuseHookTestServer(t),
+	} {
+		for _, auth := range []string{"", "Bearer token", "Bearer " + testAbuseLoginHookToken + "x"} {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/internal/auth/login-attempts", bytes.NewReader([]byte(
+				`{"user_id":"user-1","ip_address":"203.0.113.9","credentials_valid":false}`,
+			)))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Request-Id", "req-m37-hook")
+			if auth != "" {
+				req.Header.Set("Authorization", auth)
+			}
+
+			rr := httptest.NewRecorder()
+			server.mux.ServeHTTP(rr, req)
+			if rr.Code != http.StatusUnauthorized {
+				t.Fatalf("%s with %q: expected 401, got %d body=%s", name, auth, rr.Code, rr.Body.String())
+			}
+		}
+	}
+}
+
 func TestAbuseLoginRequiresRequestID(t *testing.T) {
-	server := newTestServer()
-	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", bytes.NewReader([]byte(`{"failed_attempts":1}`)))
+	server := newAbuseHookTestServer(t)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/internal/auth/login-attempts", bytes.NewReader([]byte(`{"user_id":"user-1","ip_address":"203.0.113.9","credentials_valid":true}`)))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+testAbuseLoginHookToken)
 
 	rr := httptest.NewRecorder()
 	server.mux.ServeHTTP(rr, req)
@@ -19,11 +76,100 @@ func TestAbuseLoginRequiresRequestID(t *testing.T) {
 	}
 }
 
+func TestAbuseLoginIgnoresClientReportedCounters(t *testing.T) {
+	server := newAbuseHookTestServer(t)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/internal/auth/login-attempts", bytes.NewReader([]byte(
+		`{"user_id":"user-m37-login","ip_address":"203.0.113.9","device_fingerprint":"fp-new","credentials_valid":true,"failed_attempts":0,"known_device":true}`,
+	)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+testAbuseLoginHookToken)
+	req.Header.Set("X-Request-Id", "req-m37-login")
+
+	rr := httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rr.Code, rr.Body.String())
+	}
+	var resp struct {
+		Data struct {
+			Decision  string `json:"decision"`
+			Challenge *struct {
+				ChallengeID string `json:"challenge_id"`
+				Nonce       string `json:"nonce"`
+			} `json:"challenge"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Data.Decision != "challenge" || resp.Data.Challenge == nil || resp.Data.Challenge.Nonce == "" {
+		t.Fatalf("expected unknown device to be challenged, got %s", rr.Body.String())
+	}
+}
+
+func TestAbusePublicLoginRouteIsRemoved(t *testing.T) {
+	server := newTestServer()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", bytes.NewReader([]byte(
+		`{"user_id":"user-m37-public","device_fingerprint":"fp-new"}`,
+	)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-Id", "req-m37-public")
+
+	rr := httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, req)
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d body=%s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestAbuseChallengeIsBoundToIssuedClient(t *testing.T) {
+	server := newAbuseHookTestServer(t)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/internal/auth/login-attempts", bytes.NewReader([]byte(
+		`{"user_id":"user-m37-bound","ip_address":"203.0.113.9","device_fingerprint":"fp-new","credentials_valid":true}`,
+	)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+testAbuseLoginHookToken)
+	req.Header.Set("X-Request-Id", "req-m37-bound")
+	rr := httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, req)
+	var resp struct {
+		Data struct {
+			ChallengeID string `json:"challenge_id"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil || resp.Data.ChallengeID == "" {
+		t.Fatalf("expected a challenge, got %d body=%s", rr.Code, rr.Body.String())
+	}
+
+	for _, tc := range []struct {
+		name        string
+		remoteAddr  string
+		fingerprint string
+		want        int
+	}{
+		{"other address", "198.51.100.20:4000", "fp-new", http.StatusForbidden},
+		{"other device", "203.0.113.9:4000", "fp-other", http.StatusForbidden},
+		{"issued client", "203.0.113.9:4000", "fp-new", http.StatusOK},
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/challenge/"+resp.Data.ChallengeID, bytes.NewReader([]byte(
+			`{"solution":"42","device_fingerprint":"`+tc.fingerprint+`"}`,
+		)))
+		req.RemoteAddr = tc.remoteAddr
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Request-Id", "req-m37-bound-submit")
+		req.Header.Set("Idempotency-Key", "idem-m37-bound-"+tc.name)
+		rr := httptest.NewRecorder()
+		server.mux.ServeHTTP(rr, req)
+		if rr.Code != tc.want {
+			t.Fatalf("%s: expected %d, got %d body=%s", tc.name, tc.want, rr.Code, rr.Body.String())
+		}
+	}
+}
+
 func TestAbuseChallengeRequiresIdempotencyKey(t *testing.T) {
 	server := newTestServer()
-	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/challenge/ch-1", bytes.NewReader([]byte(`{"passed":true}`)))
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/challenge/ch-1", bytes.NewReader([]byte(`{"solution":"42"}`)))
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer token")
 	req.Header.Set("X-Request-Id", "req-m37-2")
 
 	rr := httptest.NewRecorder()
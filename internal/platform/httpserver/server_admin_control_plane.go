@@ -3,6 +3,7 @@ package httpserver
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -20,6 +21,9 @@ import (
 	clippinghttp "solomon/contexts/campaign-editorial/clipping-tool-service/transport/http"
 	editordashboardservice "solomon/contexts/campaign-editorial/editor-dashboard-service"
 	editordashboarderrors "solomon/contexts/campaign-editorial/editor-dashboard-service/domain/errors"
+	billingservice "solomon/contexts/finance-core/billing-service"
+	billingerrors "solomon/contexts/finance-core/billing-service/domain/errors"
+	payoutservice "solomon/contexts/finance-core/payout-service"
 	authorization "solomon/contexts/identity-access/authorization-service"
 	authzerrors "solomon/contexts/identity-access/authorization-service/domain/errors"
 	authzhttp "solomon/contexts/identity-access/authorization-service/transport/http"
@@ -34,6 +38,7 @@ import (
 	moderationservice "solomon/contexts/moderation-safety/moderation-service"
 	moderationerrors "solomon/contexts/moderation-safety/moderation-service/domain/errors"
 	moderationhttp "solomon/contexts/moderation-safety/moderation-service/transport/http"
+	"solomon/internal/platform/auditchain"
 )
 
 type meshSuccessEnvelope struct {
@@ -1946,13 +1951,26 @@ func newAdminDashboardModule(
 	abuseModule abusepreventionservice.Module,
 	editorModule editordashboardservice.Module,
 	clippingModule clippingtoolservice.Module,
+	billingModule billingservice.Module,
+	payoutModule payoutservice.Module,
+	auditSigningKey []byte,
+	auditChain admindashboardports.Repository,
 ) (admindashboardservice.Module, error) {
 	cfg, err := loadAdminOwnerClientConfigFromEnv()
 	if err != nil {
 		return admindashboardservice.Module{}, err
 	}
+	if len(auditSigningKey) == 0 {
+		auditSigningKey = make([]byte, 32)
+		if _, err := rand.Read(auditSigningKey); err != nil {
+			return admindashboardservice.Module{}, err
+		}
+	}
 
 	store := admindashboardmemory.NewStore()
+	if auditChain == nil {
+		auditChain = store
+	}
 	httpClient := &http.Client{Timeout: ownerRequestTimeout}
 
 	var financeFallback admindashboardports.FinanceClient
@@ -1973,10 +1991,10 @@ func newAdminDashboardModule(
 	var dataMigrationFallback admindashboardports.DataMigrationClient
 	if cfg.runtime.allowFallback {
 		financeFallback = store
-		billingFallback = store
+		billingFallback = controlPlaneLocalBillingClient{module: billingModule}
 		rewardFallback = store
 		affiliateFallback = store
-		payoutFallback = store
+		payoutFallback = controlPlaneLocalPayoutClient{module: payoutModule}
 		resolutionFallback = store
 		consentFallback = store
 		portabilityFallback = store
@@ -1991,7 +2009,7 @@ func newAdminDashboardModule(
 	}
 
 	module := admindashboardservice.NewModule(admindashboardservice.Dependencies{
-		Repository:            store,
+		Repository:            auditChain,
 		Idempotency:           store,
 		AuthorizationClient:   controlPlaneAuthorizationClient{module: authorizationModule},
 		ModerationClient:      controlPlaneModerationClient{module: moderationModule},
@@ -2082,6 +2100,7 @@ func newAdminDashboardModule(
 			client:   httpClient,
 			fallback: dataMigrationFallback,
 		},
+		AuditSigner:    auditchain.HMACSigner{Key: auditSigningKey},
 		Clock:          store,
 		IdempotencyTTL: 7 * 24 * time.Hour,
 	})
@@ -2834,6 +2853,17 @@ func writeAdminDashboardDomainError(w http.ResponseWriter, err error) {
 		writeSuperAdminError(w, http.StatusBadRequest, "idempotency_key_required", err.Error())
 	case errors.Is(err, moderationerrors.ErrIdempotencyConflict):
 		writeSuperAdminError(w, http.StatusConflict, "idempotency_conflict", err.Error())
+	case errors.Is(err, billingerrors.ErrInvalidRequest):
+		writeSuperAdminError(w, http.StatusBadRequest, "invalid_request", err.Error())
+	case errors.Is(err, billingerrors.ErrInvoiceNotFound),
+		errors.Is(err, billingerrors.ErrLineItemNotFound):
+		writeSuperAdminError(w, http.StatusNotFound, "not_found", err.Error())
+	case errors.Is(err, billingerrors.ErrCreditExceedsRefundable):
+		writeSuperAdminError(w, http.StatusConflict, "conflict", err.Error())
+	case errors.Is(err, billingerrors.ErrIdempotencyKeyRequired):
+		writeSuperAdminError(w, http.StatusBadRequest, "idempotency_key_required", err.Error())
+	case errors.Is(err, billingerrors.ErrIdempotencyConflict):
+		writeSuperAdminError(w, http.StatusConflict, "idempotency_conflict", err.Error())
 	case errors.Is(err, abuseerrors.ErrInvalidRequest):
 		writeSuperAdminError(w, http.StatusBadRequest, "invalid_request", err.Error())
 	case errors.Is(err, abuseerrors.ErrUnauthorized):
@@ -2905,6 +2935,21 @@ func (s *Server) handleAdminRecordAction(w http.ResponseWriter, r *http.Request)
 	writeJSON(w, http.StatusOK, resp)
 }
 
+func (s *Server) handleAdminVerifyActionLog(w http.ResponseWriter, r *http.Request) {
+	if !requireAdminHeaders(w, r) {
+		return
+	}
+	if _, ok := requireAdminID(w, r); !ok {
+		return
+	}
+	resp, err := s.adminDashboard.Handler.VerifyAuditChainHandler(r.Context())
+	if err != nil {
+		writeAdminDashboardDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
 func (s *Server) handleAdminIdentityGrantRole(w http.ResponseWriter, r *http.Request) {
 	if !requireAdminHeaders(w, r) {
 		return
@@ -4,9 +4,19 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"testing"
+
+	campaignservice "solomon/contexts/campaign-editorial/campaign-service"
+	contentlibrarymarketplace "solomon/contexts/campaign-editorial/content-library-marketplace"
+	distributionservice "solomon/contexts/campaign-editorial/distribution-service"
+	submissionservice "solomon/contexts/campaign-editorial/submission-service"
+	votingengine "solomon/contexts/campaign-editorial/voting-engine"
+	authorization "solomon/contexts/identity-access/authorization-service"
+	admindashboardmemory "solomon/contexts/internal-ops/admin-dashboard-service/adapters/memory"
 )
 
 type adminControlPlaneGrantRoleResponse struct {
@@ -373,6 +383,49 @@ func TestAdminControlPlaneRecordActionRouteAvailable(t *testing.T) {
 	}
 }
 
+func TestAdminControlPlaneAuditChainUsesConfiguredStore(t *testing.T) {
+	_ = os.Setenv(adminRuntimeModeEnv, "test")
+	chain := admindashboardmemory.NewStore()
+	server, err := NewWithOverrides(
+		contentlibrarymarketplace.NewInMemoryModule(nil, slog.Default()),
+		authorization.NewInMemoryModule(slog.Default()),
+		campaignservice.NewInMemoryModule(nil, slog.Default()),
+		submissionservice.NewInMemoryModule(nil, slog.Default()),
+		distributionservice.NewInMemoryModule(nil, slog.Default()),
+		votingengine.NewInMemoryModule(nil, slog.Default()),
+		slog.Default(),
+		":0",
+		ModuleOverrides{AdminAuditChain: chain},
+	)
+	if err != nil {
+		t.Fatalf("build server: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/v1/actions/log", bytes.NewReader([]byte(`{
+		"action":"admin.manual.review",
+		"target_id":"sub-1",
+		"justification":"manual intervention"
+	}`)))
+	adminHeaders(req, "admin-1", "idem-admin-cp-chain-store")
+	rr := httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rr.Code, rr.Body.String())
+	}
+
+	if err := server.adminDashboard.AnchorAuditChain(context.Background()); err != nil {
+		t.Fatalf("anchor: %v", err)
+	}
+	logs, _ := chain.ListRecentAuditLogs(context.Background(), 10)
+	anchors, _ := chain.ListAuditAnchors(context.Background())
+	if len(logs) != 1 || logs[0].Action != "admin.manual.review" || len(anchors) != 1 {
+		t.Fatalf("expected the entry and anchor in the configured store, got logs=%+v anchors=%+v", logs, anchors)
+	}
+	local, _ := server.adminDashboard.Store.ListRecentAuditLogs(context.Background(), 10)
+	if len(local) != 0 {
+		t.Fatalf("expected nothing in the in-process store, got %+v", local)
+	}
+}
+
 func TestAdminControlPlaneAbuseLockoutReleaseForbiddenByScope(t *testing.T) {
 	server := newTestServer()
 	req := httptest.NewRequest(http.MethodPost, "/api/admin/v1/abuse-prevention/lockouts/locked-user-1/release", bytes.NewReader([]byte(`{
@@ -0,0 +1,225 @@
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	productports "solomon/contexts/community-experience/product-service/ports"
+	subscriptionports "solomon/contexts/community-experience/subscription-service/ports"
+	billingservice "solomon/contexts/finance-core/billing-service"
+	billingerrors "solomon/contexts/finance-core/billing-service/domain/errors"
+	billinghttp "solomon/contexts/finance-core/billing-service/transport/http"
+	admindashboardports "solomon/contexts/internal-ops/admin-dashboard-service/ports"
+)
+
+func writeBillingError(w http.ResponseWriter, status int, code string, message string) {
+	writeJSON(w, status, billinghttp.ErrorResponse{Code: code, Message: message})
+}
+
+func writeBillingDomainError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, billingerrors.ErrInvoiceNotFound),
+		errors.Is(err, billingerrors.ErrLineItemNotFound),
+		errors.Is(err, billingerrors.ErrNotFound):
+		writeBillingError(w, http.StatusNotFound, "not_found", err.Error())
+	case errors.Is(err, billingerrors.ErrInvalidRequest),
+		errors.Is(err, billingerrors.ErrIdempotencyKeyRequired):
+		writeBillingError(w, http.StatusBadRequest, "invalid_request", err.Error())
+	case errors.Is(err, billingerrors.ErrUnsupportedFormat):
+		writeBillingError(w, http.StatusNotAcceptable, "unsupported_format", err.Error())
+	case errors.Is(err, billingerrors.ErrIdempotencyConflict),
+		errors.Is(err, billingerrors.ErrConflict),
+		errors.Is(err, billingerrors.ErrCreditExceedsRefundable):
+		writeBillingError(w, http.StatusConflict, "conflict", err.Error())
+	case errors.Is(err, billingerrors.ErrForbidden):
+		writeBillingError(w, http.StatusForbidden, "forbidden", err.Error())
+	default:
+		writeBillingError(w, http.StatusInternalServerError, "internal_error", "internal server error")
+	}
+}
+
+func requireBillingAuthorization(w http.ResponseWriter, r *http.Request) bool {
+	authHeader := strings.TrimSpace(r.Header.Get("Authorization"))
+	parts := strings.SplitN(authHeader, " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") || strings.TrimSpace(parts[1]) == "" {
+		writeBillingError(w, http.StatusUnauthorized, "unauthorized", "Authorization bearer token is required")
+		return false
+	}
+	return true
+}
+
+func requireBillingRequestID(w http.ResponseWriter, r *http.Request) bool {
+	if strings.TrimSpace(r.Header.Get("X-Request-Id")) == "" {
+		writeBillingError(w, http.StatusBadRequest, "missing_request_id", "X-Request-Id header is required")
+		return false
+	}
+	return true
+}
+
+func requireBillingUser(w http.ResponseWriter, r *http.Request) (string, bool) {
+	userID := strings.TrimSpace(r.Header.Get("X-User-Id"))
+	if userID == "" {
+		writeBillingError(w, http.StatusUnauthorized, "missing_user", "X-User-Id header is required")
+		return "", false
+	}
+	return userID, true
+}
+
+func (s *Server) handleBillingListInvoices(w http.ResponseWriter, r *http.Request) {
+	if !requireBillingAuthorization(w, r) || !requireBillingRequestID(w, r) {
+		return
+	}
+	userID, ok := requireBillingUser(w, r)
+	if !ok {
+		return
+	}
+	limit, _ := strconv.Atoi(strings.TrimSpace(r.URL.Query().Get("limit")))
+	offset, _ := strconv.Atoi(strings.TrimSpace(r.URL.Query().Get("offset")))
+	resp, err := s.billing.Handler.ListInvoicesHandler(r.Context(), userID, billinghttp.ListInvoicesRequest{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		writeBillingDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleBillingGetInvoice(w http.ResponseWriter, r *http.Request) {
+	if !requireBillingAuthorization(w, r) || !requireBillingRequestID(w, r) {
+		return
+	}
+	userID, ok := requireBillingUser(w, r)
+	if !ok {
+		return
+	}
+	resp, err := s.billing.Handler.GetInvoiceHandler(r.Context(), userID, r.PathValue("invoice_id"))
+	if err != nil {
+		writeBillingDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleBillingGetInvoicePDF(w http.ResponseWriter, r *http.Request) {
+	if !requireBillingAuthorization(w, r) || !requireBillingRequestID(w, r) {
+		return
+	}
+	userID, ok := requireBillingUser(w, r)
+	if !ok {
+		return
+	}
+	document, err := s.billing.Handler.GetInvoicePDFHandler(r.Context(), userID, r.PathValue("invoice_id"))
+	if err != nil {
+		writeBillingDomainError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+document.FileName+`"`)
+	w.Header().Set("Content-Length", strconv.Itoa(len(document.Body)))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(document.Body)
+}
+
+// billingInvoiceIssuer lets product and subscription charges issue invoices
+// through the in-process billing module.
+type billingInvoiceIssuer struct {
+	module billingservice.Module
+}
+
+func (i billingInvoiceIssuer) issue(ctx context.Context, req billinghttp.IssueInvoiceRequest) (string, error) {
+	resp, err := i.module.Handler.IssueInvoiceHandler(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	return resp.Data.InvoiceID, nil
+}
+
+type productInvoiceIssuer struct {
+	billingInvoiceIssuer
+}
+
+func (i productInvoiceIssuer) IssueInvoice(ctx context.Context, req productports.InvoiceRequest) (string, error) {
+	lines := make([]billinghttp.IssueInvoiceLineRequest, 0, len(req.Lines))
+	for _, line := range req.Lines {
+		lines = append(lines, billinghttp.IssueInvoiceLineRequest{
+			Description:     line.Description,
+			Quantity:        line.Quantity,
+			UnitAmountCents: line.UnitAmountCents,
+			ReferenceType:   line.ReferenceType,
+			ReferenceID:     line.ReferenceID,
+		})
+	}
+	return i.issue(ctx, billinghttp.IssueInvoiceRequest{
+		UserID:     req.UserID,
+		SourceType: req.SourceType,
+		SourceID:   req.SourceID,
+		Currency:   req.Currency,
+		Lines:      lines,
+	})
+}
+
+type subscriptionInvoiceIssuer struct {
+	billingInvoiceIssuer
+}
+
+func (i subscriptionInvoiceIssuer) IssueInvoice(ctx context.Context, req subscriptionports.InvoiceRequest) (string, error) {
+	lines := make([]billinghttp.IssueInvoiceLineRequest, 0, len(req.Lines))
+	for _, line := range req.Lines {
+		lines = append(lines, billinghttp.IssueInvoiceLineRequest{
+			Description:     line.Description,
+			Quantity:        line.Quantity,
+			UnitAmountCents: line.UnitAmountCents,
+			ReferenceType:   line.ReferenceType,
+			ReferenceID:     line.ReferenceID,
+		})
+	}
+	return i.issue(ctx, billinghttp.IssueInvoiceRequest{
+		UserID:     req.UserID,
+		SourceType: req.SourceType,
+		SourceID:   req.SourceID,
+		Currency:   req.Currency,
+		Lines:      lines,
+	})
+}
+
+// controlPlaneLocalBillingClient serves admin invoice refunds from the
+// in-process billing module by issuing credit notes. It replaces the
+// admin-dashboard stub when no remote M05 owner is configured.
+type controlPlaneLocalBillingClient struct {
+	module billingservice.Module
+}
+
+func (c controlPlaneLocalBillingClient) CreateInvoiceRefund(
+	ctx context.Context,
+	adminID string,
+	invoiceID string,
+	lineItemID string,
+	amount float64,
+	reason string,
+	idempotencyKey string,
+) (admindashboardports.BillingRefundResult, error) {
+	resp, err := c.module.Handler.CreateCreditNoteHandler(ctx, adminID, idempotencyKey, invoiceID, billinghttp.CreateCreditNoteRequest{
+		LineItemID:  lineItemID,
+		AmountCents: int64(math.Round(amount * 100)),
+		Reason:      reason,
+	})
+	if err != nil {
+		return admindashboardports.BillingRefundResult{}, err
+	}
+	processedAt, _ := time.Parse(time.RFC3339, resp.Data.IssuedAt)
+	return admindashboardports.BillingRefundResult{
+		RefundID:    resp.Data.CreditNoteID,
+		InvoiceID:   resp.Data.InvoiceID,
+		LineItemID:  resp.Data.LineItemID,
+		Amount:      float64(resp.Data.AmountCents) / 100,
+		Reason:      resp.Data.Reason,
+		ProcessedAt: processedAt,
+	}, nil
+}
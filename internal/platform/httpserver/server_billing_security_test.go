@@ -0,0 +1,83 @@
+package httpserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestBillingInvoicesRequireAuthorization(t *testing.T) {
+	server := newTestServer()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/billing/invoices", nil)
+	req.Header.Set("X-Request-Id", "req-billing-1")
+	req.Header.Set("X-User-Id", "user-1")
+
+	rr := httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, req)
+	if rr.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d body=%s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestBillingSubscriptionChargeIssuesOwnerScopedInvoice(t *testing.T) {
+	server := newTestServer()
+
+	body := []byte(`{"plan_id":"plan_pro_monthly","trial":false}`)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/subscriptions", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set("X-Request-Id", "req-billing-sub-1")
+	req.Header.Set("X-User-Id", "user-1")
+	req.Header.Set("Idempotency-Key", "idem-billing-sub-1")
+	rr := httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, req)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d body=%s", rr.Code, rr.Body.String())
+	}
+	var created struct {
+		Data struct {
+			InvoiceID string `json:"invoice_id"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &created); err != nil {
+		t.Fatalf("decode subscription response: %v", err)
+	}
+	if created.Data.InvoiceID == "" {
+		t.Fatalf("expected invoice id on paid subscription, body=%s", rr.Body.String())
+	}
+
+	listReq := billingRequest(http.MethodGet, "/api/v1/billing/invoices", "user-1")
+	listRR := httptest.NewRecorder()
+	server.mux.ServeHTTP(listRR, listReq)
+	if listRR.Code != http.StatusOK || !strings.Contains(listRR.Body.String(), created.Data.InvoiceID) {
+		t.Fatalf("expected invoice in list, got %d body=%s", listRR.Code, listRR.Body.String())
+	}
+
+	otherReq := billingRequest(http.MethodGet, "/api/v1/billing/invoices/"+created.Data.InvoiceID, "user-2")
+	otherRR := httptest.NewRecorder()
+	server.mux.ServeHTTP(otherRR, otherReq)
+	if otherRR.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for other user, got %d body=%s", otherRR.Code, otherRR.Body.String())
+	}
+
+	pdfReq := billingRequest(http.MethodGet, "/api/v1/billing/invoices/"+created.Data.InvoiceID+"/pdf", "user-1")
+	pdfRR := httptest.NewRecorder()
+	server.mux.ServeHTTP(pdfRR, pdfReq)
+	if pdfRR.Code != http.StatusOK {
+		t.Fatalf("expected 200 for pdf, got %d body=%s", pdfRR.Code, pdfRR.Body.String())
+	}
+	if pdfRR.Header().Get("Content-Type") != "application/pdf" || !bytes.HasPrefix(pdfRR.Body.Bytes(), []byte("%PDF-")) {
+		t.Fatalf("expected pdf response, got content-type=%s", pdfRR.Header().Get("Content-Type"))
+	}
+}
+
+func billingRequest(method string, path string, userID string) *http.Request {
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set("X-Request-Id", "req-billing-"+userID)
+	req.Header.Set("X-User-Id", userID)
+	return req
+}
@@ -0,0 +1,235 @@
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	chatports "solomon/contexts/community-experience/chat-service/ports"
+	chathttp "solomon/contexts/community-experience/chat-service/transport/http"
+
+	"golang.org/x/net/websocket"
+)
+
+const (
+	defaultChatStreamHeartbeat = 15 * time.Second
+	chatStreamHeartbeatType    = "heartbeat"
+)
+
+// handleChatSubscribe streams a channel over Server-Sent Events. Clients
+// resume with after_seq or the Last-Event-ID header, which carries the
+// sequence number of the last message.created frame they received.
+func (s *Server) handleChatSubscribe(w http.ResponseWriter, r *http.Request) {
+	if !requireChatAuthorization(w, r) || !requireChatRequestID(w, r) {
+		return
+	}
+	if _, ok := requireChatUser(w, r); !ok {
+		return
+	}
+	channelID := strings.TrimSpace(r.URL.Query().Get("channel_id"))
+	if channelID == "" {
+		writeChatError(w, http.StatusBadRequest, "invalid_request", "channel_id query parameter is required")
+		return
+	}
+	afterSeq, ok := chatStreamResumePoint(w, r)
+	if !ok {
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeChatError(w, http.StatusInternalServerError, "internal_error", "streaming is not supported")
+		return
+	}
+
+	events, cancel, err := s.chat.Handler.OpenChannelStream(r.Context(), channelID, afterSeq)
+	if err != nil {
+		writeChatDomainError(w, err)
+		return
+	}
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(s.chatStreamHeartbeat())
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-s.closing:
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case frame, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := writeChatSSEFrame(w, frame); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// handleChatWebSocket streams a channel over WebSocket. Besides receiving
+// server frames, clients may send {"type":"typing"} frames.
+func (s *Server) handleChatWebSocket(w http.ResponseWriter, r *http.Request) {
+	if !requireChatAuthorization(w, r) || !requireChatRequestID(w, r) {
+		return
+	}
+	userID, ok := requireChatUser(w, r)
+	if !ok {
+		return
+	}
+	channelID := strings.TrimSpace(r.PathValue("channel_id"))
+	afterSeq, ok := chatStreamResumePoint(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, stop := context.WithCancel(r.Context())
+	defer stop()
+	events, cancel, err := s.chat.Handler.OpenChannelStream(ctx, channelID, afterSeq)
+	if err != nil {
+		writeChatDomainError(w, err)
+		return
+	}
+	defer cancel()
+
+	server := websocket.Server{
+		// Callers authenticate with headers above; browsers on any origin may connect.
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(conn *websocket.Conn) {
+			defer conn.Close()
+			go s.readChatWebSocket(ctx, stop, conn, userID, channelID)
+
+			heartbeat := time.NewTicker(s.chatStreamHeartbeat())
+			defer heartbeat.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-s.closing:
+					return
+				case <-heartbeat.C:
+					if err := websocket.JSON.Send(conn, chathttp.ChatStreamEvent{
+						Type:      chatStreamHeartbeatType,
+						ChannelID: channelID,
+					}); err != nil {
+						return
+					}
+				case frame, ok := <-events:
+					if !ok {
+						return
+					}
+					if err := websocket.JSON.Send(conn, frame); err != nil {
+						return
+					}
+				}
+			}
+		},
+	}
+	server.ServeHTTP(w, r)
+}
+
+func (s *Server) readChatWebSocket(
+	ctx context.Context,
+	stop context.CancelFunc,
+	conn *websocket.Conn,
+	userID string,
+	channelID string,
+) {
+	defer stop()
+	for {
+		var frame chathttp.ChatStreamClientFrame
+		if err := websocket.JSON.Receive(conn, &frame); err != nil {
+			return
+		}
+		if frame.Type != chatports.RealtimeTyping {
+			continue
+		}
+		if _, err := s.chat.Handler.TypingHandler(ctx, userID, channelID, chathttp.TypingRequest{
+			ServerID: frame.ServerID,
+		}); err != nil {
+			s.logger.Warn("chat websocket typing frame rejected",
+				"event", "chat_websocket_typing_rejected",
+				"module", "internal/platform/httpserver",
+				"layer", "platform",
+				"channel_id", channelID,
+				"error", err.Error(),
+			)
+		}
+	}
+}
+
+func (s *Server) handleChatTyping(w http.ResponseWriter, r *http.Request) {
+	if !requireChatAuthorization(w, r) || !requireChatRequestID(w, r) {
+		return
+	}
+	userID, ok := requireChatUser(w, r)
+	if !ok {
+		return
+	}
+	var req chathttp.TypingRequest
+	if !s.decodeJSON(w, r, &req, writeChatError) {
+		return
+	}
+	resp, err := s.chat.Handler.TypingHandler(r.Context(), userID, r.PathValue("channel_id"), req)
+	if err != nil {
+		writeChatDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, resp)
+}
+
+func chatStreamResumePoint(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	raw := strings.TrimSpace(r.URL.Query().Get("after_seq"))
+	if raw == "" {
+		raw = strings.TrimSpace(r.Header.Get("Last-Event-ID"))
+	}
+	if raw == "" {
+		return 0, true
+	}
+	parsed, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || parsed < 0 {
+		writeChatError(w, http.StatusBadRequest, "invalid_request", "after_seq must be a non-negative integer")
+		return 0, false
+	}
+	return parsed, true
+}
+
+func writeChatSSEFrame(w http.ResponseWriter, frame chathttp.ChatStreamEvent) error {
+	data, err := json.Marshal(frame)
+	if err != nil {
+		return err
+	}
+	// Only new messages advance the resume point; edits and deletes of older
+	// messages must not move Last-Event-ID backwards.
+	if frame.Type == chatports.RealtimeMessageCreated && frame.SequenceNumber > 0 {
+		if _, err := fmt.Fprintf(w, "id: %d\n", frame.SequenceNumber); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", frame.Type, data)
+	return err
+}
+
+func (s *Server) chatStreamHeartbeat() time.Duration {
+	if s.chatHeartbeat <= 0 {
+		return defaultChatStreamHeartbeat
+	}
+	return s.chatHeartbeat
+}
@@ -0,0 +1,120 @@
+package httpserver
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChatSubscribeRequiresAuthorization(t *testing.T) {
+	server := newTestServer()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/chat/messages/subscribe?channel_id=ch_001", nil)
+	req.Header.Set("X-Request-Id", "req-chat-stream-1")
+	req.Header.Set("X-User-Id", "user-1")
+
+	rr := httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, req)
+	if rr.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d body=%s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestChatSubscribeRejectsInvalidResumePoint(t *testing.T) {
+	server := newTestServer()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/chat/messages/subscribe?channel_id=ch_001", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set("X-Request-Id", "req-chat-stream-2")
+	req.Header.Set("X-User-Id", "user-1")
+	req.Header.Set("Last-Event-ID", "not-a-number")
+
+	rr := httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d body=%s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestChatTypingAccepted(t *testing.T) {
+	server := newTestServer()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/chat/channels/ch_001/typing", bytes.NewReader([]byte(`{"server_id":"srv_001"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set("X-Request-Id", "req-chat-stream-3")
+	req.Header.Set("X-User-Id", "user-1")
+
+	rr := httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, req)
+	if rr.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d body=%s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestChatSubscribeStreamsServerSentEvents(t *testing.T) {
+	server := newTestServer()
+	ts := httptest.NewServer(server.mux)
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/api/v1/chat/messages/subscribe?channel_id=ch_sse", nil)
+	if err != nil {
+		t.Fatalf("build request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set("X-Request-Id", "req-chat-stream-4")
+	req.Header.Set("X-User-Id", "user-1")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("subscribe failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	if got := resp.Header.Get("Content-Type"); got != "text/event-stream" {
+		t.Fatalf("expected text/event-stream, got %q", got)
+	}
+
+	reader := bufio.NewReader(resp.Body)
+	readEvent := func() string {
+		t.Helper()
+		var lines []string
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				t.Fatalf("read stream: %v", err)
+			}
+			line = strings.TrimRight(line, "\n")
+			if line == "" {
+				return strings.Join(lines, "\n")
+			}
+			lines = append(lines, line)
+		}
+	}
+	if ready := readEvent(); !strings.Contains(ready, "event: ready") {
+		t.Fatalf("expected ready frame, got %q", ready)
+	}
+
+	post := httptest.NewRequest(http.MethodPost, "/api/v1/chat/messages", bytes.NewReader([]byte(`{"server_id":"srv_001","channel_id":"ch_sse","content":"hello stream"}`)))
+	post.Header.Set("Content-Type", "application/json")
+	post.Header.Set("Authorization", "Bearer token")
+	post.Header.Set("X-Request-Id", "req-chat-stream-5")
+	post.Header.Set("X-User-Id", "user-1")
+	post.Header.Set("Idempotency-Key", "idem-chat-stream-5")
+	rr := httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, post)
+	if rr.Code != http.StatusCreated && rr.Code != http.StatusOK {
+		t.Fatalf("post message failed: %d body=%s", rr.Code, rr.Body.String())
+	}
+
+	created := readEvent()
+	if !strings.Contains(created, "id: 1") || !strings.Contains(created, "event: message.created") ||
+		!strings.Contains(created, "hello stream") {
+		t.Fatalf("unexpected message frame %q", created)
+	}
+}
@@ -1,14 +1,17 @@
 package httpserver
 
 import (
+	"bytes"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 
 	communityhealthdomainerrors "solomon/contexts/community-experience/community-health-service/domain/errors"
@@ -166,3 +169,167 @@ func (s *Server) handleCommunityHealthGetUserRisk(w http.ResponseWriter, r *http
 	}
 	writeJSON(w, http.StatusOK, resp)
 }
+
+func requireCommunityHealthModerator(w http.ResponseWriter, r *http.Request) (string, bool) {
+	moderatorID := getUserID(r)
+	if moderatorID == "" {
+		writeCommunityHealthError(w, http.StatusUnauthorized, "missing_user", "X-User-Id header is required")
+		return "", false
+	}
+	return moderatorID, true
+}
+
+func (s *Server) handleCommunityHealthListAlerts(w http.ResponseWriter, r *http.Request) {
+	if !requireCommunityHealthAuthorization(w, r) || !requireCommunityHealthRequestID(w, r) {
+		return
+	}
+	resp, err := s.communityHealth.Handler.ListAlertsHandler(
+		r.Context(),
+		r.PathValue("server_id"),
+		r.URL.Query().Get("status"),
+	)
+	if err != nil {
+		writeCommunityHealthDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handleCommunityHealthAlertTransition serves the acknowledge, resolve and
+// dismiss endpoints, each moving the alert to status.
+func (s *Server) handleCommunityHealthAlertTransition(status string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !requireCommunityHealthAuthorization(w, r) || !requireCommunityHealthRequestID(w, r) {
+			return
+		}
+		moderatorID, ok := requireCommunityHealthModerator(w, r)
+		if !ok {
+			return
+		}
+		idempotencyKey, ok := requireCommunityHealthIdempotencyKey(w, r)
+		if !ok {
+			return
+		}
+		var req communityhealthhttp.AlertTransitionRequest
+		if !s.decodeJSON(w, r, &req, writeCommunityHealthError) {
+			return
+		}
+		resp, err := s.communityHealth.Handler.TransitionAlertHandler(
+			r.Context(),
+			idempotencyKey,
+			r.PathValue("server_id"),
+			r.PathValue("alert_id"),
+			moderatorID,
+			status,
+			req,
+		)
+		if err != nil {
+			writeCommunityHealthDomainError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
+
+func (s *Server) handleCommunityHealthFeedback(w http.ResponseWriter, r *http.Request) {
+	if !requireCommunityHealthAuthorization(w, r) || !requireCommunityHealthRequestID(w, r) {
+		return
+	}
+	moderatorID, ok := requireCommunityHealthModerator(w, r)
+	if !ok {
+		return
+	}
+	idempotencyKey, ok := requireCommunityHealthIdempotencyKey(w, r)
+	if !ok {
+		return
+	}
+	var req communityhealthhttp.ModerationFeedbackRequest
+	if !s.decodeJSON(w, r, &req, writeCommunityHealthError) {
+		return
+	}
+	resp, err := s.communityHealth.Handler.RecordModerationFeedbackHandler(
+		r.Context(),
+		idempotencyKey,
+		r.PathValue("server_id"),
+		r.PathValue("message_id"),
+		moderatorID,
+		req,
+	)
+	if err != nil {
+		writeCommunityHealthDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+func (s *Server) handleCommunityHealthGetTuning(w http.ResponseWriter, r *http.Request) {
+	if !requireCommunityHealthAuthorization(w, r) || !requireCommunityHealthRequestID(w, r) {
+		return
+	}
+	resp, err := s.communityHealth.Handler.GetModerationTuningHandler(r.Context(), r.PathValue("server_id"))
+	if err != nil {
+		writeCommunityHealthDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handleCommunityHealthListReports lists weekly reports newest first. Pass
+// format=csv (or Accept: text/csv) for a CSV export of the same page.
+func (s *Server) handleCommunityHealthListReports(w http.ResponseWriter, r *http.Request) {
+	if !requireCommunityHealthAuthorization(w, r) || !requireCommunityHealthRequestID(w, r) {
+		return
+	}
+	query := r.URL.Query()
+	limit := 0
+	if raw := strings.TrimSpace(query.Get("limit")); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			writeCommunityHealthError(w, http.StatusBadRequest, "invalid_request", "limit must be an integer")
+			return
+		}
+		limit = parsed
+	}
+	serverID := r.PathValue("server_id")
+	cursor := query.Get("cursor")
+
+	if strings.EqualFold(query.Get("format"), "csv") || strings.Contains(r.Header.Get("Accept"), "text/csv") {
+		var body bytes.Buffer
+		next, err := s.communityHealth.Handler.ExportWeeklyReportsCSV(r.Context(), &body, serverID, cursor, limit)
+		if err != nil {
+			writeCommunityHealthDomainError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "community-health-"+serverID+"-reports.csv"))
+		if next != "" {
+			w.Header().Set("X-Next-Cursor", next)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(body.Bytes())
+		return
+	}
+
+	resp, err := s.communityHealth.Handler.ListWeeklyReportsHandler(r.Context(), serverID, cursor, limit)
+	if err != nil {
+		writeCommunityHealthDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleCommunityHealthGetReport(w http.ResponseWriter, r *http.Request) {
+	if !requireCommunityHealthAuthorization(w, r) || !requireCommunityHealthRequestID(w, r) {
+		return
+	}
+	resp, err := s.communityHealth.Handler.GetWeeklyReportHandler(
+		r.Context(),
+		r.PathValue("server_id"),
+		r.PathValue("report_id"),
+	)
+	if err != nil {
+		writeCommunityHealthDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
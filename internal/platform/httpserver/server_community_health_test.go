@@ -107,3 +107,79 @@ func TestCommunityHealthWebhookRejectsInvalidTimestamp(t *testing.T) {
 		t.Fatalf("expected 400, got %d body=%s", rr.Code, rr.Body.String())
 	}
 }
+
+func TestCommunityHealthAlertTransitionRequiresModerator(t *testing.T) {
+	server := newTestServer()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/community-health/server_123/alerts/alert-1/acknowledge", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set("X-Request-Id", "req-chs-3")
+	req.Header.Set("Idempotency-Key", "idem-ack-1")
+
+	rr := httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, req)
+	if rr.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d body=%s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestCommunityHealthAlertTransitionUnknownAlert(t *testing.T) {
+	server := newTestServer()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/community-health/server_123/alerts/alert-missing/resolve", bytes.NewReader([]byte(`{"note":"handled"}`)))
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set("X-Request-Id", "req-chs-4")
+	req.Header.Set("X-User-Id", "mod-1")
+	req.Header.Set("Idempotency-Key", "idem-resolve-1")
+
+	rr := httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, req)
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d body=%s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestCommunityHealthFeedbackRejectsUnknownType(t *testing.T) {
+	server := newTestServer()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/community-health/server_123/messages/msg-1/feedback", bytes.NewReader([]byte(`{"feedback_type":"maybe"}`)))
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set("X-Request-Id", "req-chs-5")
+	req.Header.Set("X-User-Id", "mod-1")
+	req.Header.Set("Idempotency-Key", "idem-feedback-1")
+
+	rr := httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d body=%s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestCommunityHealthReportsCSVExport(t *testing.T) {
+	server := newTestServer()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/community-health/server_123/reports?format=csv", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set("X-Request-Id", "req-chs-6")
+
+	rr := httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rr.Code, rr.Body.String())
+	}
+	if got := rr.Header().Get("Content-Type"); got != "text/csv; charset=utf-8" {
+		t.Fatalf("expected csv content type, got %q", got)
+	}
+	if !bytes.HasPrefix(rr.Body.Bytes(), []byte("report_id,week_start_date,")) {
+		t.Fatalf("expected csv header, got %q", rr.Body.String())
+	}
+}
+
+func TestCommunityHealthGetReportNotFound(t *testing.T) {
+	server := newTestServer()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/community-health/server_123/reports/report-missing", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set("X-Request-Id", "req-chs-7")
+
+	rr := httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, req)
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d body=%s", rr.Code, rr.Body.String())
+	}
+}
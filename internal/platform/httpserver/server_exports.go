@@ -0,0 +1,530 @@
+package httpserver
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	campaignservice "solomon/contexts/campaign-editorial/campaign-service"
+	campaignqueries "solomon/contexts/campaign-editorial/campaign-service/application/queries"
+	campaignerrors "solomon/contexts/campaign-editorial/campaign-service/domain/errors"
+	editordashboardservice "solomon/contexts/campaign-editorial/editor-dashboard-service"
+	editorapplication "solomon/contexts/campaign-editorial/editor-dashboard-service/application"
+	editorerrors "solomon/contexts/campaign-editorial/editor-dashboard-service/domain/errors"
+	editorports "solomon/contexts/campaign-editorial/editor-dashboard-service/ports"
+	superadmindashboard "solomon/contexts/internal-ops/super-admin-dashboard"
+	superadminapplication "solomon/contexts/internal-ops/super-admin-dashboard/application"
+	superadmindomainerrors "solomon/contexts/internal-ops/super-admin-dashboard/domain/errors"
+	superadminports "solomon/contexts/internal-ops/super-admin-dashboard/ports"
+	superadminhttp "solomon/contexts/internal-ops/super-admin-dashboard/transport/http"
+	teammanagementservice "solomon/contexts/internal-ops/team-management-service"
+	teamerrors "solomon/contexts/internal-ops/team-management-service/domain/errors"
+	teamports "solomon/contexts/internal-ops/team-management-service/ports"
+	"solomon/internal/platform/export"
+)
+
+const (
+	exportKindTeamMembers       = "team_members"
+	exportKindEditorSubmissions = "editor_submissions"
+	exportKindCampaignAnalytics = "campaign_analytics"
+	// exportKindAdminAuditLogs is only requested and read through the admin
+	// API; the user-facing export endpoints treat it as unknown.
+	exportKindAdminAuditLogs = "admin_audit_logs"
+
+	// exportJobsPerTick bounds how long one worker tick holds the claim
+	// loop; the next tick picks up the rest.
+	exportJobsPerTick   = 5
+	exportSweepPerTick  = 100
+	exportStatusURLBase = "/v1/exports/"
+
+	adminAuditExportStatusURLBase = "/api/admin/v1/audit-logs/export/"
+)
+
+type exportErrorResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type createExportRequest struct {
+	Kind     string            `json:"kind"`
+	Format   string            `json:"format"`
+	Columns  []string          `json:"columns"`
+	RowLimit int               `json:"row_limit"`
+	Params   map[string]string `json:"params"`
+}
+
+type exportJobResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		ExportID          string   `json:"export_id"`
+		Kind              string   `json:"kind"`
+		Format            string   `json:"format"`
+		Columns           []string `json:"columns"`
+		RowLimit          int      `json:"row_limit"`
+		Status            string   `json:"status"`
+		RowCount          int      `json:"row_count"`
+		Truncated         bool     `json:"truncated"`
+		SizeBytes         int64    `json:"size_bytes"`
+		Error             string   `json:"error,omitempty"`
+		CreatedAt         string   `json:"created_at"`
+		CompletedAt       string   `json:"completed_at,omitempty"`
+		ExpiresAt         string   `json:"expires_at,omitempty"`
+		DownloadURL       string   `json:"download_url,omitempty"`
+		DownloadExpiresAt string   `json:"download_expires_at,omitempty"`
+	} `json:"data"`
+}
+
+// newDefaultExportService keeps jobs and files in process, with a random
+// link signing key; it only suits development and tests.
+func newDefaultExportService(logger *slog.Logger) *export.Service {
+	key := make([]byte, 32)
+	_, _ = rand.Read(key)
+	return &export.Service{
+		Jobs:       export.NewMemoryJobStore(),
+		Storage:    export.NewMemoryStorage(),
+		SigningKey: key,
+		Logger:     logger,
+	}
+}
+
+func registerExportSources(
+	service *export.Service,
+	teamManagement teammanagementservice.Module,
+	editorDashboard editordashboardservice.Module,
+	campaign campaignservice.Module,
+	superAdmin superadmindashboard.Module,
+) {
+	service.Register(exportKindTeamMembers, teamMembersExportSource{module: teamManagement})
+	service.Register(exportKindEditorSubmissions, editorSubmissionsExportSource{module: editorDashboard})
+	service.Register(exportKindCampaignAnalytics, campaignAnalyticsExportSource{module: campaign})
+	service.Register(exportKindAdminAuditLogs, adminAuditLogsExportSource{module: superAdmin})
+}
+
+func (s *Server) runExportJobs(ctx context.Context) error {
+	return s.exports.RunOnce(ctx, exportJobsPerTick)
+}
+
+func (s *Server) sweepExportFiles(ctx context.Context) error {
+	_, err := s.exports.Sweep(ctx, exportSweepPerTick)
+	return err
+}
+
+func (s *Server) handleExportCreate(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireExportCaller(w, r)
+	if !ok {
+		return
+	}
+	var req createExportRequest
+	if !s.decodeJSON(w, r, &req, writeExportError) {
+		return
+	}
+	if strings.TrimSpace(req.Kind) == exportKindAdminAuditLogs {
+		writeExportDomainError(w, export.ErrUnknownKind)
+		return
+	}
+	job, err := s.exports.Request(r.Context(), export.Request{
+		Kind:        req.Kind,
+		RequestedBy: userID,
+		Params:      req.Params,
+		Format:      req.Format,
+		Columns:     req.Columns,
+		RowLimit:    req.RowLimit,
+	})
+	if err != nil {
+		writeExportDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, s.exportJobResponse(job))
+}
+
+func (s *Server) handleExportGet(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireExportCaller(w, r)
+	if !ok {
+		return
+	}
+	job, err := s.exports.Get(r.Context(), r.PathValue("export_id"), userID)
+	if err == nil && job.Kind == exportKindAdminAuditLogs {
+		err = export.ErrNotFound
+	}
+	if err != nil {
+		writeExportDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.exportJobResponse(job))
+}
+
+// handleExportDownload serves a file to whoever holds a valid signed link,
+// so it takes no caller headers.
+func (s *Server) handleExportDownload(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	job, body, err := s.exports.Open(r.Context(), r.PathValue("export_id"), query.Get("expires"), query.Get("signature"))
+	if err != nil {
+		writeExportDomainError(w, err)
+		return
+	}
+	defer body.Close()
+	w.Header().Set("Content-Type", job.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": job.Filename()}))
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if job.SizeBytes > 0 {
+		w.Header().Set("Content-Length", strconv.FormatInt(job.SizeBytes, 10))
+	}
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, body); err != nil {
+		s.logger.Warn("export download interrupted",
+			"event", "export_download_interrupted",
+			"module", "platform/export",
+			"layer", "httpserver",
+			"export_id", job.ExportID,
+			"error", err,
+		)
+	}
+}
+
+func (s *Server) exportJobResponse(job export.Job) exportJobResponse {
+	resp := exportJobResponse{Status: "success"}
+	resp.Data.ExportID = job.ExportID
+	resp.Data.Kind = job.Kind
+	resp.Data.Format = job.Format
+	resp.Data.Columns = job.Columns
+	resp.Data.RowLimit = job.RowLimit
+	resp.Data.Status = job.Status
+	resp.Data.RowCount = job.RowCount
+	resp.Data.Truncated = job.Truncated
+	resp.Data.SizeBytes = job.SizeBytes
+	resp.Data.Error = job.Error
+	resp.Data.CreatedAt = job.CreatedAt.UTC().Format(time.RFC3339)
+	if job.CompletedAt != nil {
+		resp.Data.CompletedAt = job.CompletedAt.UTC().Format(time.RFC3339)
+	}
+	if job.ExpiresAt != nil {
+		resp.Data.ExpiresAt = job.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	if link, expiresAt, err := s.exports.DownloadURL(job); err == nil {
+		resp.Data.DownloadURL = link
+		resp.Data.DownloadExpiresAt = expiresAt.Format(time.RFC3339)
+	}
+	return resp
+}
+
+func (s *Server) adminAuditLogExportResponse(job export.Job) superadminhttp.AuditLogExportResponse {
+	resp := superadminhttp.AuditLogExportResponse{
+		ExportJobID: job.ExportID,
+		Status:      job.Status,
+		Format:      job.Format,
+		Columns:     job.Columns,
+		RowLimit:    job.RowLimit,
+		RowCount:    job.RowCount,
+		Truncated:   job.Truncated,
+		Error:       job.Error,
+		CreatedAt:   job.CreatedAt.UTC().Format(time.RFC3339),
+		StatusURL:   adminAuditExportStatusURLBase + job.ExportID,
+	}
+	if job.CompletedAt != nil {
+		resp.CompletedAt = job.CompletedAt.UTC().Format(time.RFC3339)
+	}
+	if link, _, err := s.exports.DownloadURL(job); err == nil {
+		resp.DownloadURL = link
+	}
+	return resp
+}
+
+func requireExportCaller(w http.ResponseWriter, r *http.Request) (string, bool) {
+	if bearerToken(r) == "" {
+		writeExportError(w, http.StatusUnauthorized, "unauthorized", "Authorization bearer token is required")
+		return "", false
+	}
+	if strings.TrimSpace(r.Header.Get("X-Request-Id")) == "" {
+		writeExportError(w, http.StatusBadRequest, "missing_request_id", "X-Request-Id header is required")
+		return "", false
+	}
+	userID := getUserID(r)
+	if userID == "" {
+		writeExportError(w, http.StatusUnauthorized, "missing_user", "X-User-Id header is required")
+		return "", false
+	}
+	return userID, true
+}
+
+func writeExportError(w http.ResponseWriter, status int, code string, message string) {
+	writeJSON(w, status, exportErrorResponse{Code: code, Message: message})
+}
+
+func writeExportDomainError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, export.ErrNotFound):
+		writeExportError(w, http.StatusNotFound, "not_found", err.Error())
+	case errors.Is(err, export.ErrInvalidRequest),
+		errors.Is(err, export.ErrUnknownKind):
+		writeExportError(w, http.StatusBadRequest, "invalid_request", err.Error())
+	case errors.Is(err, export.ErrForbidden):
+		writeExportError(w, http.StatusForbidden, "forbidden", err.Error())
+	case errors.Is(err, export.ErrLinkInvalid):
+		writeExportError(w, http.StatusForbidden, "invalid_download_link", err.Error())
+	case errors.Is(err, export.ErrNotReady):
+		writeExportError(w, http.StatusConflict, "export_not_ready", err.Error())
+	case errors.Is(err, export.ErrExpired):
+		writeExportError(w, http.StatusGone, "export_expired", err.Error())
+	default:
+		writeExportError(w, http.StatusInternalServerError, "internal_error", "internal server error")
+	}
+}
+
+// writeAdminExportError reports export failures in the super admin error
+// shape.
+func writeAdminExportError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, export.ErrNotFound):
+		writeSuperAdminError(w, http.StatusNotFound, "not_found", err.Error())
+	case errors.Is(err, export.ErrInvalidRequest):
+		writeSuperAdminError(w, http.StatusBadRequest, "invalid_request", err.Error())
+	case errors.Is(err, export.ErrForbidden):
+		writeSuperAdminError(w, http.StatusForbidden, "forbidden", err.Error())
+	default:
+		writeSuperAdminError(w, http.StatusInternalServerError, "internal_error", "internal server error")
+	}
+}
+
+// teamMembersExportSource exports a team's active members for its owners
+// and managers. Params: team_id.
+type teamMembersExportSource struct {
+	module teammanagementservice.Module
+}
+
+func (teamMembersExportSource) Columns() []export.Column {
+	return []export.Column{
+		{Key: "member_id", Header: "member_id"},
+		{Key: "user_id", Header: "user_id"},
+		{Key: "email", Header: "email"},
+		{Key: "role", Header: "role"},
+		{Key: "joined_at", Header: "joined_at"},
+		{Key: "last_active_at", Header: "last_active_at"},
+	}
+}
+
+func (s teamMembersExportSource) Authorize(ctx context.Context, requestedBy string, params map[string]string) error {
+	err := s.module.Handler.Service.AuthorizeMembersExport(ctx, requestedBy, params["team_id"])
+	return teamExportError(err)
+}
+
+func (s teamMembersExportSource) Stream(
+	ctx context.Context,
+	requestedBy string,
+	params map[string]string,
+	limit int,
+	emit func(row []string) error,
+) error {
+	err := s.module.Handler.Service.StreamMembersExport(ctx, requestedBy, params["team_id"], limit, func(row teamports.MemberExportRow) error {
+		lastActive := ""
+		if row.LastActiveAt != nil {
+			lastActive = row.LastActiveAt.UTC().Format(time.RFC3339)
+		}
+		return emit([]string{
+			row.MemberID,
+			row.UserID,
+			row.Email,
+			row.Role,
+			row.JoinedAt.UTC().Format(time.RFC3339),
+			lastActive,
+		})
+	})
+	return teamExportError(err)
+}
+
+func teamExportError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, teamerrors.ErrTeamNotFound),
+		errors.Is(err, teamerrors.ErrNotFound):
+		return export.ErrNotFound
+	case errors.Is(err, teamerrors.ErrForbidden):
+		return export.ErrForbidden
+	case errors.Is(err, teamerrors.ErrInvalidRequest):
+		return export.ErrInvalidRequest
+	default:
+		return err
+	}
+}
+
+// editorSubmissionsExportSource exports the requester's own submissions.
+// Params: status (optional).
+type editorSubmissionsExportSource struct {
+	module editordashboardservice.Module
+}
+
+func (editorSubmissionsExportSource) Columns() []export.Column {
+	columns := make([]export.Column, 0, len(editorapplication.SubmissionExportColumns))
+	for _, key := range editorapplication.SubmissionExportColumns {
+		columns = append(columns, export.Column{Key: key, Header: key})
+	}
+	return columns
+}
+
+func (s editorSubmissionsExportSource) Authorize(ctx context.Context, requestedBy string, params map[string]string) error {
+	// Listing one row validates the status filter up front.
+	_, err := s.module.Handler.Service.ListSubmissions(ctx, requestedBy, editorports.SubmissionQuery{
+		Status: params["status"],
+		Limit:  1,
+	})
+	return editorExportError(err)
+}
+
+func (s editorSubmissionsExportSource) Stream(
+	ctx context.Context,
+	requestedBy string,
+	params map[string]string,
+	limit int,
+	emit func(row []string) error,
+) error {
+	err := s.module.Handler.Service.StreamSubmissions(ctx, requestedBy, params["status"], limit, func(item editorports.SubmissionRecord) error {
+		return emit(editorapplication.ExportSubmissionRow(item))
+	})
+	return editorExportError(err)
+}
+
+func editorExportError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, editorerrors.ErrNotFound):
+		return export.ErrNotFound
+	case errors.Is(err, editorerrors.ErrForbidden):
+		return export.ErrForbidden
+	case errors.Is(err, editorerrors.ErrInvalidRequest):
+		return export.ErrInvalidRequest
+	default:
+		return err
+	}
+}
+
+// campaignAnalyticsExportSource exports a campaign's analytics for the
+// brand that owns it. Params: campaign_id.
+type campaignAnalyticsExportSource struct {
+	module campaignservice.Module
+}
+
+func (campaignAnalyticsExportSource) Columns() []export.Column {
+	columns := make([]export.Column, 0, len(campaignqueries.AnalyticsExportColumns))
+	for _, key := range campaignqueries.AnalyticsExportColumns {
+		columns = append(columns, export.Column{Key: key, Header: key})
+	}
+	return columns
+}
+
+func (s campaignAnalyticsExportSource) Authorize(ctx context.Context, requestedBy string, params map[string]string) error {
+	err := s.module.Handler.ExportAnalytics.Authorize(ctx, requestedBy, params["campaign_id"])
+	return campaignExportError(err)
+}
+
+func (s campaignAnalyticsExportSource) Stream(
+	ctx context.Context,
+	requestedBy string,
+	params map[string]string,
+	_ int,
+	emit func(row []string) error,
+) error {
+	err := s.module.Handler.ExportAnalytics.Stream(ctx, requestedBy, params["campaign_id"], emit)
+	return campaignExportError(err)
+}
+
+func campaignExportError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, campaignerrors.ErrCampaignNotFound):
+		return export.ErrNotFound
+	case errors.Is(err, campaignerrors.ErrCampaignAccessDenied):
+		return export.ErrForbidden
+	case errors.Is(err, campaignerrors.ErrInvalidCampaignInput):
+		return export.ErrInvalidRequest
+	default:
+		return err
+	}
+}
+
+// adminAuditLogsExportSource exports the super admin audit chain. The admin
+// API checks the caller before requesting a job. Params: start and end
+// (RFC3339, optional).
+type adminAuditLogsExportSource struct {
+	module superadmindashboard.Module
+}
+
+func (adminAuditLogsExportSource) Columns() []export.Column {
+	columns := make([]export.Column, 0, len(superadminapplication.AuditExportColumns))
+	for _, key := range superadminapplication.AuditExportColumns {
+		columns = append(columns, export.Column{Key: key, Header: key})
+	}
+	return columns
+}
+
+func (s adminAuditLogsExportSource) Authorize(ctx context.Context, _ string, params map[string]string) error {
+	start, end, err := adminAuditExportWindow(params)
+	if err != nil {
+		return err
+	}
+	return adminAuditExportError(s.module.Handler.Service.AuthorizeAuditExport(ctx, start, end))
+}
+
+func (s adminAuditLogsExportSource) Stream(
+	ctx context.Context,
+	_ string,
+	params map[string]string,
+	limit int,
+	emit func(row []string) error,
+) error {
+	start, end, err := adminAuditExportWindow(params)
+	if err != nil {
+		return err
+	}
+	err = s.module.Handler.Service.StreamAuditExport(ctx, start, end, limit, func(item superadminports.AuditLog) error {
+		return emit(superadminapplication.AuditExportRow(item))
+	})
+	return adminAuditExportError(err)
+}
+
+// adminAuditExportColumns leaves out the chain hashes and signatures unless
+// they are requested.
+func adminAuditExportColumns(includeSignatures bool) []string {
+	columns := superadminapplication.AuditExportColumns
+	if includeSignatures {
+		return columns
+	}
+	return columns[:len(columns)-superadminapplication.AuditExportSignatureColumns]
+}
+
+func adminAuditExportWindow(params map[string]string) (time.Time, time.Time, error) {
+	var window [2]time.Time
+	for i, key := range []string{"start", "end"} {
+		raw := strings.TrimSpace(params[key])
+		if raw == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339Nano, raw)
+		if err != nil {
+			return time.Time{}, time.Time{}, export.ErrInvalidRequest
+		}
+		window[i] = parsed
+	}
+	return window[0], window[1], nil
+}
+
+func adminAuditExportError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, superadmindomainerrors.ErrInvalidRequest),
+		errors.Is(err, superadmindomainerrors.ErrUnprocessable):
+		return export.ErrInvalidRequest
+	default:
+		return err
+	}
+}
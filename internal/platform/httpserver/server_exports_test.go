@@ -0,0 +1,239 @@
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	campaignservice "solomon/contexts/campaign-editorial/campaign-service"
+	campaignentities "solomon/contexts/campaign-editorial/campaign-service/domain/entities"
+	contentlibrarymarketplace "solomon/contexts/campaign-editorial/content-library-marketplace"
+	distributionservice "solomon/contexts/campaign-editorial/distribution-service"
+	submissionservice "solomon/contexts/campaign-editorial/submission-service"
+	votingengine "solomon/contexts/campaign-editorial/voting-engine"
+	authorization "solomon/contexts/identity-access/authorization-service"
+	superadminhttp "solomon/contexts/internal-ops/super-admin-dashboard/transport/http"
+)
+
+func exportTestRequest(method string, target string, userID string, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set("X-Request-Id", "req-export")
+	req.Header.Set("X-User-Id", userID)
+	return req
+}
+
+func TestTeamMembersExportProducesSignedDownload(t *testing.T) {
+	server := newTestServer()
+
+	rr := httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, exportTestRequest(http.MethodGet, "/teams/team_seed_1/exports/members?columns=email,role", "user_owner_1", ""))
+	if rr.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d body=%s", rr.Code, rr.Body.String())
+	}
+	var queued struct {
+		Data struct {
+			ExportJobID string `json:"export_job_id"`
+			StatusURL   string `json:"status_url"`
+		} `json:"data"`
+	}
+	_ = json.Unmarshal(rr.Body.Bytes(), &queued)
+
+	if err := server.exports.RunOnce(context.Background(), 1); err != nil {
+		t.Fatalf("run exports: %v", err)
+	}
+
+	rr = httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, exportTestRequest(http.MethodGet, queued.Data.StatusURL, "user_manager_1", ""))
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected another user to get 404, got %d body=%s", rr.Code, rr.Body.String())
+	}
+
+	rr = httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, exportTestRequest(http.MethodGet, queued.Data.StatusURL, "user_owner_1", ""))
+	var status exportJobResponse
+	_ = json.Unmarshal(rr.Body.Bytes(), &status)
+	if status.Data.Status != "completed" || status.Data.RowCount != 2 || status.Data.DownloadURL == "" {
+		t.Fatalf("expected completed export with link, got %s", rr.Body.String())
+	}
+
+	rr = httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, status.Data.DownloadURL, nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected download, got %d body=%s", rr.Code, rr.Body.String())
+	}
+	want := "email,role\nowner@example.com,Owner\nmanager@example.com,Manager\n"
+	if rr.Body.String() != want {
+		t.Fatalf("unexpected file:\n%s", rr.Body.String())
+	}
+	if !strings.Contains(rr.Header().Get("Content-Disposition"), "attachment") {
+		t.Fatalf("expected attachment disposition, got %q", rr.Header().Get("Content-Disposition"))
+	}
+
+	rr = httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, status.Data.DownloadURL+"0", nil))
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected altered link to be refused, got %d", rr.Code)
+	}
+}
+
+func TestTeamMembersExportRequiresManager(t *testing.T) {
+	server := newTestServer()
+	rr := httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, exportTestRequest(http.MethodGet, "/teams/team_seed_1/exports/members", "user_editor_1", ""))
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d body=%s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestExportCreateRejectsUnknownColumns(t *testing.T) {
+	server := newTestServer()
+	body := `{"kind":"team_members","columns":["password"],"params":{"team_id":"team_seed_1"}}`
+	req := exportTestRequest(http.MethodPost, "/v1/exports", "user_owner_1", body)
+	rr := httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d body=%s", rr.Code, rr.Body.String())
+	}
+}
+
+func newCampaignExportTestServer(t *testing.T) *Server {
+	t.Helper()
+	_ = os.Setenv(adminRuntimeModeEnv, "test")
+	server, err := New(
+		contentlibrarymarketplace.NewInMemoryModule(nil, slog.Default()),
+		authorization.NewInMemoryModule(slog.Default()),
+		campaignservice.NewInMemoryModule([]campaignentities.Campaign{{
+			CampaignID:      "campaign_export_1",
+			BrandID:         "brand_1",
+			Title:           "Spring launch",
+			Status:          campaignentities.CampaignStatusActive,
+			BudgetTotal:     500,
+			BudgetSpent:     120,
+			BudgetRemaining: 380,
+			RatePer1KViews:  1.5,
+		}}, slog.Default()),
+		submissionservice.NewInMemoryModule(nil, slog.Default()),
+		distributionservice.NewInMemoryModule(nil, slog.Default()),
+		votingengine.NewInMemoryModule(nil, slog.Default()),
+		slog.Default(),
+		":0",
+	)
+	if err != nil {
+		t.Fatalf("new server: %v", err)
+	}
+	return server
+}
+
+func TestCampaignAnalyticsExportQueuesJobForOwningBrand(t *testing.T) {
+	server := newCampaignExportTestServer(t)
+
+	rr := httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, exportTestRequest(http.MethodGet, "/v1/campaigns/campaign_export_1/analytics/export", "brand_2", ""))
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected another brand to get 403, got %d body=%s", rr.Code, rr.Body.String())
+	}
+
+	rr = httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, exportTestRequest(http.MethodGet, "/v1/campaigns/campaign_export_1/analytics/export?columns=campaign_id,total_views,budget_spent", "brand_1", ""))
+	if rr.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d body=%s", rr.Code, rr.Body.String())
+	}
+	var queued struct {
+		ExportJobID string `json:"export_job_id"`
+		StatusURL   string `json:"status_url"`
+		DownloadURL string `json:"download_url"`
+	}
+	_ = json.Unmarshal(rr.Body.Bytes(), &queued)
+	if queued.ExportJobID == "" || queued.DownloadURL != "" {
+		t.Fatalf("expected a queued job without a link, got %s", rr.Body.String())
+	}
+
+	if err := server.exports.RunOnce(context.Background(), 1); err != nil {
+		t.Fatalf("run exports: %v", err)
+	}
+	rr = httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, exportTestRequest(http.MethodGet, queued.StatusURL, "brand_1", ""))
+	var status exportJobResponse
+	_ = json.Unmarshal(rr.Body.Bytes(), &status)
+	if status.Data.Status != "completed" || status.Data.DownloadURL == "" {
+		t.Fatalf("expected completed export with link, got %s", rr.Body.String())
+	}
+
+	rr = httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, status.Data.DownloadURL, nil))
+	want := "campaign_id,total_views,budget_spent\ncampaign_export_1,80000,120.00\n"
+	if rr.Code != http.StatusOK || rr.Body.String() != want {
+		t.Fatalf("unexpected download %d:\n%s", rr.Code, rr.Body.String())
+	}
+}
+
+func adminExportTestRequest(target string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.Header.Set("Authorization", "Bearer admin-token")
+	req.Header.Set("X-Request-Id", "req-admin-export")
+	req.Header.Set("X-MFA-Code", "123456")
+	req.Header.Set("X-Admin-Id", "admin-1")
+	return req
+}
+
+func TestAdminAuditLogExportRunsThroughExportService(t *testing.T) {
+	server := newTestServer()
+	startTestImpersonation(t, server.mux, "idem-audit-export", `{"impersonated_user_id":"user-1","reason":"ticket 7"}`)
+
+	rr := httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, adminExportTestRequest("/api/admin/v1/audit-logs/export?format=csv"))
+	if rr.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d body=%s", rr.Code, rr.Body.String())
+	}
+	var queued superadminhttp.AuditLogExportResponse
+	_ = json.Unmarshal(rr.Body.Bytes(), &queued)
+	if queued.Status != "queued" || !strings.HasPrefix(queued.StatusURL, adminAuditExportStatusURLBase) {
+		t.Fatalf("expected a queued admin export, got %s", rr.Body.String())
+	}
+	for _, column := range queued.Columns {
+		if column == "signature_hash" {
+			t.Fatalf("expected signatures to be left out by default, got %v", queued.Columns)
+		}
+	}
+
+	// The job is invisible to the user-facing export endpoints.
+	rr = httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, exportTestRequest(http.MethodGet, exportStatusURLBase+queued.ExportJobID, "admin-1", ""))
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 from the user status route, got %d body=%s", rr.Code, rr.Body.String())
+	}
+
+	if err := server.exports.RunOnce(context.Background(), 1); err != nil {
+		t.Fatalf("run exports: %v", err)
+	}
+	rr = httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, adminExportTestRequest(queued.StatusURL))
+	var status superadminhttp.AuditLogExportResponse
+	_ = json.Unmarshal(rr.Body.Bytes(), &status)
+	if rr.Code != http.StatusOK || status.Status != "completed" || status.RowCount != 1 || status.DownloadURL == "" {
+		t.Fatalf("expected completed export with one row, got %d body=%s", rr.Code, rr.Body.String())
+	}
+
+	rr = httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, status.DownloadURL, nil))
+	lines := strings.Split(strings.TrimSpace(rr.Body.String()), "\n")
+	if rr.Code != http.StatusOK || len(lines) != 2 || !strings.HasPrefix(lines[0], "sequence,audit_id,admin_id") || !strings.HasSuffix(lines[1], ",true") {
+		t.Fatalf("unexpected download %d:\n%s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestExportCreateRejectsAdminKinds(t *testing.T) {
+	server := newTestServer()
+	req := exportTestRequest(http.MethodPost, "/v1/exports", "user-1", `{"kind":"admin_audit_logs"}`)
+	rr := httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d body=%s", rr.Code, rr.Body.String())
+	}
+}
@@ -0,0 +1,198 @@
+package httpserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	chathttp "solomon/contexts/community-experience/chat-service/transport/http"
+	superadminhttp "solomon/contexts/internal-ops/super-admin-dashboard/transport/http"
+
+	"golang.org/x/net/websocket"
+)
+
+func startTestImpersonation(t *testing.T, handler http.Handler, idempotencyKey string, body string) superadminhttp.StartImpersonationResponse {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/v1/impersonation/start", bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer admin-token")
+	req.Header.Set("X-Request-Id", "req-imp-start")
+	req.Header.Set("X-MFA-Code", "123456")
+	req.Header.Set("X-Admin-Id", "admin-1")
+	req.Header.Set("Idempotency-Key", idempotencyKey)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("start impersonation: expected 200, got %d body=%s", rr.Code, rr.Body.String())
+	}
+	var resp superadminhttp.StartImpersonationResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode start response: %v", err)
+	}
+	return resp
+}
+
+func sendImpersonated(handler http.Handler, method string, path string, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, bytes.NewReader([]byte(`{}`)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("X-Request-Id", "req-imp")
+	req.Header.Set("X-MFA-Code", "123456")
+	req.Header.Set("X-Admin-Id", "admin-1")
+	req.Header.Set("X-User-Id", "someone-else")
+	req.Header.Set("Idempotency-Key", "idem-imp-"+method)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestImpersonationTokenActsAsUserWithinScope(t *testing.T) {
+	server := newTestServer()
+	handler := server.Handler()
+	session := startTestImpersonation(t, handler, "idem-imp-read", `{"impersonated_user_id":"user-1","reason":"ticket 42"}`)
+	if !strings.HasPrefix(session.AccessToken, "imp1.") || len(session.Scopes) != 1 || session.Scopes[0] != "read" {
+		t.Fatalf("expected a read-only signed token, got %+v", session)
+	}
+
+	rr := sendImpersonated(handler, http.MethodGet, "/api/v1/billing/invoices", session.AccessToken)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected impersonated read to succeed, got %d body=%s", rr.Code, rr.Body.String())
+	}
+
+	rr = sendImpersonated(handler, http.MethodPost, "/api/v1/subscriptions", session.AccessToken)
+	if rr.Code != http.StatusForbidden || !strings.Contains(rr.Body.String(), "impersonation_scope_denied") {
+		t.Fatalf("expected write outside scope to be denied, got %d body=%s", rr.Code, rr.Body.String())
+	}
+
+	rr = sendImpersonated(handler, http.MethodGet, "/api/admin/v1/audit-logs", session.AccessToken)
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected admin route to be denied, got %d body=%s", rr.Code, rr.Body.String())
+	}
+
+	logs, err := server.superAdmin.Handler.AuditLogsHandler(context.Background(), "admin-1", "", "", 10)
+	if err != nil {
+		t.Fatalf("list audit logs: %v", err)
+	}
+	counts := map[string]int{}
+	for _, entry := range logs.AuditLogs {
+		counts[entry.ActionType]++
+		if entry.ActionType == "impersonation.request" && entry.TargetResourceID != "user-1" {
+			t.Fatalf("expected impersonated request audited against user-1, got %+v", entry)
+		}
+	}
+	if counts["impersonation.request"] != 1 || counts["impersonation.request_denied"] != 2 {
+		t.Fatalf("expected one served and two denied requests audited, got %v", counts)
+	}
+}
+
+func TestImpersonationNoPayoutsScopeBlocksMoneyRoutes(t *testing.T) {
+	server := newTestServer()
+	handler := server.Handler()
+	session := startTestImpersonation(t, handler, "idem-imp-write", `{"impersonated_user_id":"user-1","reason":"ticket 43","scopes":["write"],"duration_minutes":15}`)
+
+	rr := sendImpersonated(handler, http.MethodPost, "/api/v1/products/prod-1/purchase", session.AccessToken)
+	if rr.Code != http.StatusForbidden || !strings.Contains(rr.Body.String(), "impersonation_scope_denied") {
+		t.Fatalf("expected purchase without payouts scope to be denied, got %d body=%s", rr.Code, rr.Body.String())
+	}
+	rr = sendImpersonated(handler, http.MethodPost, "/api/v1/chat/messages", session.AccessToken)
+	if rr.Code == http.StatusForbidden || rr.Code == http.StatusUnauthorized {
+		t.Fatalf("expected in-scope write to reach the route, got %d body=%s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestImpersonationTokenRejectedWhenTamperedOrEnded(t *testing.T) {
+	server := newTestServer()
+	handler := server.Handler()
+	session := startTestImpersonation(t, handler, "idem-imp-end", `{"impersonated_user_id":"user-1","reason":"ticket 44"}`)
+
+	tampered := session.AccessToken[:len(session.AccessToken)-2] + "xx"
+	rr := sendImpersonated(handler, http.MethodGet, "/api/v1/billing/invoices", tampered)
+	if rr.Code != http.StatusUnauthorized || !strings.Contains(rr.Body.String(), "impersonation_token_invalid") {
+		t.Fatalf("expected tampered token to be rejected, got %d body=%s", rr.Code, rr.Body.String())
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/v1/impersonation/end", bytes.NewReader([]byte(`{"impersonation_id":"`+session.ImpersonationID+`"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer admin-token")
+	req.Header.Set("X-Request-Id", "req-imp-end")
+	req.Header.Set("X-MFA-Code", "123456")
+	req.Header.Set("X-Admin-Id", "admin-1")
+	req.Header.Set("Idempotency-Key", "idem-imp-end-call")
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("end impersonation: expected 200, got %d body=%s", rr.Code, rr.Body.String())
+	}
+
+	rr = sendImpersonated(handler, http.MethodGet, "/api/v1/billing/invoices", session.AccessToken)
+	if rr.Code != http.StatusUnauthorized || !strings.Contains(rr.Body.String(), "impersonation_revoked") {
+		t.Fatalf("expected ended session token to be revoked, got %d body=%s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestImpersonationDeniesRoutesOutsideAllowlist(t *testing.T) {
+	server := newTestServer()
+	handler := server.Handler()
+	session := startTestImpersonation(t, handler, "idem-imp-admin", `{"impersonated_user_id":"user-1","reason":"ticket 45","scopes":["write"],"duration_minutes":15}`)
+
+	for _, path := range []string{
+		"/api/onboarding/v1/admin/flows",
+		"/api/authz/v1/users/user-2/roles/grant",
+		"/api/authz/v1/users/user-2/roles/revoke",
+		"/api/moderation/approve",
+		"/api/v1/admin/abuse-threats/user-2/lockout/release",
+		"/api/onboarding/v1/internal/events/activity",
+	} {
+		rr := sendImpersonated(handler, http.MethodPost, path, session.AccessToken)
+		if rr.Code != http.StatusForbidden || !strings.Contains(rr.Body.String(), "impersonation_scope_denied") {
+			t.Fatalf("expected %s to be denied to impersonation, got %d body=%s", path, rr.Code, rr.Body.String())
+		}
+	}
+}
+
+func TestImpersonationRoutesAreRegistered(t *testing.T) {
+	server := newTestServer()
+	wildcard := regexp.MustCompile(`\{[^}]+\}`)
+	for route := range impersonationRoutes {
+		method, path, _ := strings.Cut(route, " ")
+		req := httptest.NewRequest(method, wildcard.ReplaceAllString(path, "x"), nil)
+		if got := server.routePattern(req); got != route {
+			t.Fatalf("impersonation route %q is served by %q", route, got)
+		}
+	}
+}
+
+func TestImpersonationTokenUpgradesChatWebSocket(t *testing.T) {
+	server := newTestServer()
+	handler := server.Handler()
+	session := startTestImpersonation(t, handler, "idem-imp-ws", `{"impersonated_user_id":"user-1","reason":"ticket 46"}`)
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	config, err := websocket.NewConfig("ws"+strings.TrimPrefix(ts.URL, "http")+"/api/v1/chat/channels/ch_imp/ws", ts.URL)
+	if err != nil {
+		t.Fatalf("build websocket config: %v", err)
+	}
+	config.Header.Set("Authorization", "Bearer "+session.AccessToken)
+	config.Header.Set("X-Request-Id", "req-imp-ws")
+	conn, err := websocket.DialConfig(config)
+	if err != nil {
+		t.Fatalf("expected impersonated websocket upgrade to succeed: %v", err)
+	}
+	defer conn.Close()
+
+	var frame chathttp.ChatStreamEvent
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if err := websocket.JSON.Receive(conn, &frame); err != nil {
+		t.Fatalf("read first frame: %v", err)
+	}
+	if frame.ChannelID != "ch_imp" {
+		t.Fatalf("expected a frame for ch_imp, got %+v", frame)
+	}
+}
@@ -34,6 +34,20 @@ func writeModerationDomainError(w http.ResponseWriter, err error) {
 		writeModerationError(w, http.StatusBadRequest, "IDEMPOTENCY_KEY_REQUIRED", err.Error(), nil)
 	case errors.Is(err, moderationerrors.ErrIdempotencyConflict):
 		writeModerationError(w, http.StatusConflict, "IDEMPOTENCY_CONFLICT", err.Error(), nil)
+	case errors.Is(err, moderationerrors.ErrQueueItemClaimed):
+		writeModerationError(w, http.StatusConflict, "QUEUE_ITEM_CLAIMED", err.Error(), nil)
+	case errors.Is(err, moderationerrors.ErrLeaseNotHeld):
+		writeModerationError(w, http.StatusConflict, "LEASE_NOT_HELD", err.Error(), nil)
+	case errors.Is(err, moderationerrors.ErrQueueItemClosed):
+		writeModerationError(w, http.StatusConflict, "QUEUE_ITEM_CLOSED", err.Error(), nil)
+	case errors.Is(err, moderationerrors.ErrQueueItemChanged):
+		writeModerationError(w, http.StatusConflict, "QUEUE_ITEM_CHANGED", err.Error(), nil)
+	case errors.Is(err, moderationerrors.ErrAppealExists):
+		writeModerationError(w, http.StatusConflict, "APPEAL_EXISTS", err.Error(), nil)
+	case errors.Is(err, moderationerrors.ErrAppealNotAllowed):
+		writeModerationError(w, http.StatusConflict, "APPEAL_NOT_ALLOWED", err.Error(), nil)
+	case errors.Is(err, moderationerrors.ErrAppealClosed):
+		writeModerationError(w, http.StatusConflict, "APPEAL_CLOSED", err.Error(), nil)
 	case errors.Is(err, moderationerrors.ErrDependencyUnavailable):
 		writeModerationError(w, http.StatusServiceUnavailable, "DEPENDENCY_UNAVAILABLE", err.Error(), nil)
 	default:
@@ -87,6 +101,9 @@ func (s *Server) handleModerationQueue(w http.ResponseWriter, r *http.Request) {
 	resp, err := s.moderation.Handler.ListQueueHandler(
 		r.Context(),
 		r.URL.Query().Get("status"),
+		r.URL.Query().Get("assigned_moderator_id"),
+		r.URL.Query().Get("severity"),
+		r.URL.Query().Get("overdue"),
 		r.URL.Query().Get("limit"),
 		r.URL.Query().Get("offset"),
 	)
@@ -174,3 +191,185 @@ func (s *Server) handleModerationFlag(w http.ResponseWriter, r *http.Request) {
 	}
 	writeJSON(w, http.StatusOK, resp)
 }
+
+func (s *Server) handleModerationClaim(w http.ResponseWriter, r *http.Request) {
+	if !requireModerationAuthorization(w, r) || !requireModerationRequestID(w, r) {
+		return
+	}
+	moderatorID, ok := requireModerationUser(w, r)
+	if !ok {
+		return
+	}
+	resp, err := s.moderation.Handler.ClaimHandler(r.Context(), moderatorID, r.PathValue("submission_id"))
+	if err != nil {
+		writeModerationDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleModerationClaimNext(w http.ResponseWriter, r *http.Request) {
+	if !requireModerationAuthorization(w, r) || !requireModerationRequestID(w, r) {
+		return
+	}
+	moderatorID, ok := requireModerationUser(w, r)
+	if !ok {
+		return
+	}
+	resp, err := s.moderation.Handler.ClaimNextHandler(r.Context(), moderatorID)
+	if err != nil {
+		writeModerationDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleModerationRelease(w http.ResponseWriter, r *http.Request) {
+	if !requireModerationAuthorization(w, r) || !requireModerationRequestID(w, r) {
+		return
+	}
+	moderatorID, ok := requireModerationUser(w, r)
+	if !ok {
+		return
+	}
+	resp, err := s.moderation.Handler.ReleaseHandler(r.Context(), moderatorID, r.PathValue("submission_id"))
+	if err != nil {
+		writeModerationDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleModerationUpsertModerator(w http.ResponseWriter, r *http.Request) {
+	if !requireModerationAuthorization(w, r) || !requireModerationRequestID(w, r) {
+		return
+	}
+	requesterID, ok := requireModerationUser(w, r)
+	if !ok {
+		return
+	}
+	var req moderationhttp.UpsertModeratorRequest
+	if !s.decodeJSON(w, r, &req, func(w http.ResponseWriter, status int, code string, message string) {
+		writeModerationError(w, status, strings.ToUpper(code), message, nil)
+	}) {
+		return
+	}
+	resp, err := s.moderation.Handler.UpsertModeratorHandler(r.Context(), requesterID, r.PathValue("moderator_id"), req)
+	if err != nil {
+		writeModerationDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleModerationModeratorMetrics(w http.ResponseWriter, r *http.Request) {
+	if !requireModerationAuthorization(w, r) || !requireModerationRequestID(w, r) {
+		return
+	}
+	requesterID, ok := requireModerationUser(w, r)
+	if !ok {
+		return
+	}
+	resp, err := s.moderation.Handler.ModeratorMetricsHandler(
+		r.Context(),
+		requesterID,
+		r.PathValue("moderator_id"),
+		r.URL.Query().Get("window_hours"),
+	)
+	if err != nil {
+		writeModerationDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleModerationFileAppeal(w http.ResponseWriter, r *http.Request) {
+	if !requireModerationAuthorization(w, r) || !requireModerationRequestID(w, r) {
+		return
+	}
+	creatorID, ok := requireModerationUser(w, r)
+	if !ok {
+		return
+	}
+	idempotencyKey, ok := requireModerationIdempotency(w, r)
+	if !ok {
+		return
+	}
+	var req moderationhttp.FileAppealRequest
+	if !s.decodeJSON(w, r, &req, func(w http.ResponseWriter, status int, code string, message string) {
+		writeModerationError(w, status, strings.ToUpper(code), message, nil)
+	}) {
+		return
+	}
+	resp, err := s.moderation.Handler.FileAppealHandler(r.Context(), idempotencyKey, creatorID, r.PathValue("decision_id"), req)
+	if err != nil {
+		writeModerationDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+func (s *Server) handleModerationListAppeals(w http.ResponseWriter, r *http.Request) {
+	if !requireModerationAuthorization(w, r) || !requireModerationRequestID(w, r) {
+		return
+	}
+	requesterID, ok := requireModerationUser(w, r)
+	if !ok {
+		return
+	}
+	resp, err := s.moderation.Handler.ListAppealsHandler(
+		r.Context(),
+		requesterID,
+		r.URL.Query().Get("status"),
+		r.URL.Query().Get("assigned_moderator_id"),
+		r.URL.Query().Get("limit"),
+		r.URL.Query().Get("offset"),
+	)
+	if err != nil {
+		writeModerationDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleModerationGetAppeal(w http.ResponseWriter, r *http.Request) {
+	if !requireModerationAuthorization(w, r) || !requireModerationRequestID(w, r) {
+		return
+	}
+	requesterID, ok := requireModerationUser(w, r)
+	if !ok {
+		return
+	}
+	resp, err := s.moderation.Handler.GetAppealHandler(r.Context(), requesterID, r.PathValue("appeal_id"))
+	if err != nil {
+		writeModerationDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleModerationResolveAppeal(w http.ResponseWriter, r *http.Request) {
+	if !requireModerationAuthorization(w, r) || !requireModerationRequestID(w, r) {
+		return
+	}
+	moderatorID, ok := requireModerationUser(w, r)
+	if !ok {
+		return
+	}
+	idempotencyKey, ok := requireModerationIdempotency(w, r)
+	if !ok {
+		return
+	}
+	var req moderationhttp.ResolveAppealRequest
+	if !s.decodeJSON(w, r, &req, func(w http.ResponseWriter, status int, code string, message string) {
+		writeModerationError(w, status, strings.ToUpper(code), message, nil)
+	}) {
+		return
+	}
+	resp, err := s.moderation.Handler.ResolveAppealHandler(r.Context(), idempotencyKey, moderatorID, r.PathValue("appeal_id"), req)
+	if err != nil {
+		writeModerationDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -44,3 +44,24 @@ func TestModerationRejectRequiresIdempotency(t *testing.T) {
 		t.Fatalf("expected 400, got %d body=%s", rr.Code, rr.Body.String())
 	}
 }
+
+func TestModerationClaimConflictsWithAnotherModerator(t *testing.T) {
+	server := newTestServer()
+	claim := func(moderatorID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/moderation/queue/sub-1/claim", nil)
+		req.Header.Set("Authorization", "Bearer token")
+		req.Header.Set("X-Request-Id", "req-mod-claim-"+moderatorID)
+		req.Header.Set("X-User-Id", moderatorID)
+		rr := httptest.NewRecorder()
+		server.mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	if rr := claim("mod-1"); rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rr.Code, rr.Body.String())
+	}
+	rr := claim("mod-senior-1")
+	if rr.Code != http.StatusConflict || !bytes.Contains(rr.Body.Bytes(), []byte("QUEUE_ITEM_CLAIMED")) {
+		t.Fatalf("expected 409 QUEUE_ITEM_CLAIMED, got %d body=%s", rr.Code, rr.Body.String())
+	}
+}
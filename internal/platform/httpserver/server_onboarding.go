@@ -5,10 +5,13 @@ import (
 	"net/http"
 	"strings"
 
+	authzhttp "solomon/contexts/identity-access/authorization-service/transport/http"
 	onboardingerrors "solomon/contexts/identity-access/onboarding-service/domain/errors"
 	onboardinghttp "solomon/contexts/identity-access/onboarding-service/transport/http"
 )
 
+const onboardingAdminPermission = "policy.manage"
+
 func writeOnboardingError(w http.ResponseWriter, status int, code string, message string) {
 	writeJSON(w, status, onboardinghttp.ErrorResponse{Code: code, Message: message})
 }
@@ -16,6 +19,8 @@ func writeOnboardingError(w http.ResponseWriter, status int, code string, messag
 func writeOnboardingDomainError(w http.ResponseWriter, err error) {
 	switch {
 	case errors.Is(err, onboardingerrors.ErrFlowNotFound),
+		errors.Is(err, onboardingerrors.ErrFlowVersionNotFound),
+		errors.Is(err, onboardingerrors.ErrExperimentNotFound),
 		errors.Is(err, onboardingerrors.ErrProgressNotFound),
 		errors.Is(err, onboardingerrors.ErrStepNotFound),
 		errors.Is(err, onboardingerrors.ErrNotFound):
@@ -29,6 +34,9 @@ func writeOnboardingDomainError(w http.ResponseWriter, err error) {
 	case errors.Is(err, onboardingerrors.ErrStepAlreadyCompleted),
 		errors.Is(err, onboardingerrors.ErrFlowAlreadyCompleted),
 		errors.Is(err, onboardingerrors.ErrResumeNotAllowed),
+		errors.Is(err, onboardingerrors.ErrFlowArchived),
+		errors.Is(err, onboardingerrors.ErrFlowActive),
+		errors.Is(err, onboardingerrors.ErrExperimentRunning),
 		errors.Is(err, onboardingerrors.ErrIdempotencyConflict),
 		errors.Is(err, onboardingerrors.ErrConflict):
 		writeOnboardingError(w, http.StatusConflict, "conflict", err.Error())
@@ -66,6 +74,27 @@ func requireOnboardingUser(w http.ResponseWriter, r *http.Request) (string, bool
 	return userID, true
 }
 
+// requireOnboardingAdmin admits flow and experiment authors: the acting
+// user must hold policy.manage, which the admin roles grant.
+func (s *Server) requireOnboardingAdmin(w http.ResponseWriter, r *http.Request) (string, bool) {
+	userID, ok := requireOnboardingUser(w, r)
+	if !ok {
+		return "", false
+	}
+	decision, err := s.authorization.Handler.CheckPermissionHandler(r.Context(), userID, authzhttp.CheckPermissionRequest{
+		Permission: onboardingAdminPermission,
+	})
+	if err != nil {
+		writeOnboardingError(w, http.StatusServiceUnavailable, "dependency_unavailable", "authorization check failed")
+		return "", false
+	}
+	if !decision.Allowed {
+		writeOnboardingError(w, http.StatusForbidden, "forbidden", "onboarding administration requires an admin role")
+		return "", false
+	}
+	return userID, true
+}
+
 func requireOnboardingIdempotencyKey(w http.ResponseWriter, r *http.Request) (string, bool) {
 	key := strings.TrimSpace(r.Header.Get("Idempotency-Key"))
 	if key == "" {
@@ -168,7 +197,7 @@ func (s *Server) handleOnboardingAdminFlows(w http.ResponseWriter, r *http.Reque
 	if !requireOnboardingAuthorization(w, r) || !requireOnboardingRequestID(w, r) {
 		return
 	}
-	if _, ok := requireOnboardingUser(w, r); !ok {
+	if _, ok := s.requireOnboardingAdmin(w, r); !ok {
 		return
 	}
 	resp, err := s.onboarding.Handler.ListAdminFlowsHandler(r.Context())
@@ -194,3 +223,261 @@ func (s *Server) handleOnboardingUserRegisteredEvent(w http.ResponseWriter, r *h
 	}
 	writeJSON(w, http.StatusAccepted, resp)
 }
+
+func (s *Server) handleOnboardingAdminCreateFlow(w http.ResponseWriter, r *http.Request) {
+	if !requireOnboardingAuthorization(w, r) || !requireOnboardingRequestID(w, r) {
+		return
+	}
+	actorID, ok := s.requireOnboardingAdmin(w, r)
+	if !ok {
+		return
+	}
+	idempotencyKey, ok := requireOnboardingIdempotencyKey(w, r)
+	if !ok {
+		return
+	}
+	var req onboardinghttp.CreateFlowRequest
+	if !s.decodeJSON(w, r, &req, writeOnboardingError) {
+		return
+	}
+	resp, err := s.onboarding.Handler.CreateFlowHandler(r.Context(), idempotencyKey, actorID, req)
+	if err != nil {
+		writeOnboardingDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+func (s *Server) handleOnboardingAdminGetFlow(w http.ResponseWriter, r *http.Request) {
+	if !requireOnboardingAuthorization(w, r) || !requireOnboardingRequestID(w, r) {
+		return
+	}
+	if _, ok := s.requireOnboardingAdmin(w, r); !ok {
+		return
+	}
+	resp, err := s.onboarding.Handler.GetFlowDefinitionHandler(r.Context(), r.PathValue("flow_id"))
+	if err != nil {
+		writeOnboardingDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleOnboardingAdminSaveDraft(w http.ResponseWriter, r *http.Request) {
+	if !requireOnboardingAuthorization(w, r) || !requireOnboardingRequestID(w, r) {
+		return
+	}
+	actorID, ok := s.requireOnboardingAdmin(w, r)
+	if !ok {
+		return
+	}
+	idempotencyKey, ok := requireOnboardingIdempotencyKey(w, r)
+	if !ok {
+		return
+	}
+	var req onboardinghttp.SaveFlowDraftRequest
+	if !s.decodeJSON(w, r, &req, writeOnboardingError) {
+		return
+	}
+	resp, err := s.onboarding.Handler.SaveFlowDraftHandler(r.Context(), idempotencyKey, actorID, r.PathValue("flow_id"), req)
+	if err != nil {
+		writeOnboardingDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleOnboardingAdminPublishVersion(w http.ResponseWriter, r *http.Request) {
+	if !requireOnboardingAuthorization(w, r) || !requireOnboardingRequestID(w, r) {
+		return
+	}
+	actorID, ok := s.requireOnboardingAdmin(w, r)
+	if !ok {
+		return
+	}
+	idempotencyKey, ok := requireOnboardingIdempotencyKey(w, r)
+	if !ok {
+		return
+	}
+	resp, err := s.onboarding.Handler.PublishFlowVersionHandler(
+		r.Context(),
+		idempotencyKey,
+		actorID,
+		r.PathValue("flow_id"),
+		r.PathValue("version"),
+	)
+	if err != nil {
+		writeOnboardingDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleOnboardingAdminArchiveFlow(w http.ResponseWriter, r *http.Request) {
+	if !requireOnboardingAuthorization(w, r) || !requireOnboardingRequestID(w, r) {
+		return
+	}
+	actorID, ok := s.requireOnboardingAdmin(w, r)
+	if !ok {
+		return
+	}
+	idempotencyKey, ok := requireOnboardingIdempotencyKey(w, r)
+	if !ok {
+		return
+	}
+	resp, err := s.onboarding.Handler.ArchiveFlowHandler(r.Context(), idempotencyKey, actorID, r.PathValue("flow_id"))
+	if err != nil {
+		writeOnboardingDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handleOnboardingActivityEvent lets other services report activity the
+// event consumer does not see. Submission and campaign events arrive
+// through the outbox consumer instead.
+func (s *Server) handleOnboardingActivityEvent(w http.ResponseWriter, r *http.Request) {
+	if !hasServiceToken(r, s.onboardingActivityToken) {
+		writeOnboardingError(w, http.StatusUnauthorized, "unauthorized", "activity events are accepted from platform services only")
+		return
+	}
+	if !requireOnboardingRequestID(w, r) {
+		return
+	}
+	var req onboardinghttp.ActivityEventRequest
+	if !s.decodeJSON(w, r, &req, writeOnboardingError) {
+		return
+	}
+	resp, err := s.onboarding.Handler.ConsumeActivityEventHandler(r.Context(), req)
+	if err != nil {
+		writeOnboardingDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, resp)
+}
+
+func (s *Server) handleOnboardingAdminListExperiments(w http.ResponseWriter, r *http.Request) {
+	if !requireOnboardingAuthorization(w, r) || !requireOnboardingRequestID(w, r) {
+		return
+	}
+	if _, ok := s.requireOnboardingAdmin(w, r); !ok {
+		return
+	}
+	resp, err := s.onboarding.Handler.ListExperimentsHandler(r.Context())
+	if err != nil {
+		writeOnboardingDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleOnboardingAdminCreateExperiment(w http.ResponseWriter, r *http.Request) {
+	if !requireOnboardingAuthorization(w, r) || !requireOnboardingRequestID(w, r) {
+		return
+	}
+	actorID, ok := s.requireOnboardingAdmin(w, r)
+	if !ok {
+		return
+	}
+	idempotencyKey, ok := requireOnboardingIdempotencyKey(w, r)
+	if !ok {
+		return
+	}
+	var req onboardinghttp.CreateExperimentRequest
+	if !s.decodeJSON(w, r, &req, writeOnboardingError) {
+		return
+	}
+	resp, err := s.onboarding.Handler.CreateExperimentHandler(r.Context(), idempotencyKey, actorID, req)
+	if err != nil {
+		writeOnboardingDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+func (s *Server) handleOnboardingAdminStopExperiment(w http.ResponseWriter, r *http.Request) {
+	if !requireOnboardingAuthorization(w, r) || !requireOnboardingRequestID(w, r) {
+		return
+	}
+	actorID, ok := s.requireOnboardingAdmin(w, r)
+	if !ok {
+		return
+	}
+	idempotencyKey, ok := requireOnboardingIdempotencyKey(w, r)
+	if !ok {
+		return
+	}
+	resp, err := s.onboarding.Handler.StopExperimentHandler(r.Context(), idempotencyKey, actorID, r.PathValue("experiment_key"))
+	if err != nil {
+		writeOnboardingDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleOnboardingAdminExperimentResults(w http.ResponseWriter, r *http.Request) {
+	if !requireOnboardingAuthorization(w, r) || !requireOnboardingRequestID(w, r) {
+		return
+	}
+	if _, ok := s.requireOnboardingAdmin(w, r); !ok {
+		return
+	}
+	resp, err := s.onboarding.Handler.ExperimentResultsHandler(r.Context(), r.PathValue("experiment_key"))
+	if err != nil {
+		writeOnboardingDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleOnboardingGetReminderPreferences(w http.ResponseWriter, r *http.Request) {
+	if !requireOnboardingAuthorization(w, r) || !requireOnboardingRequestID(w, r) {
+		return
+	}
+	userID, ok := requireOnboardingUser(w, r)
+	if !ok {
+		return
+	}
+	resp, err := s.onboarding.Handler.GetReminderPreferencesHandler(r.Context(), userID)
+	if err != nil {
+		writeOnboardingDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleOnboardingUpdateReminderPreferences(w http.ResponseWriter, r *http.Request) {
+	if !requireOnboardingAuthorization(w, r) || !requireOnboardingRequestID(w, r) {
+		return
+	}
+	userID, ok := requireOnboardingUser(w, r)
+	if !ok {
+		return
+	}
+	var req onboardinghttp.ReminderPreferencesRequest
+	if !s.decodeJSON(w, r, &req, writeOnboardingError) {
+		return
+	}
+	resp, err := s.onboarding.Handler.UpdateReminderPreferencesHandler(r.Context(), userID, req)
+	if err != nil {
+		writeOnboardingDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleOnboardingUnsubscribeReminders(w http.ResponseWriter, r *http.Request) {
+	if !requireOnboardingAuthorization(w, r) || !requireOnboardingRequestID(w, r) {
+		return
+	}
+	userID, ok := requireOnboardingUser(w, r)
+	if !ok {
+		return
+	}
+	resp, err := s.onboarding.Handler.UnsubscribeRemindersHandler(r.Context(), userID)
+	if err != nil {
+		writeOnboardingDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -2,8 +2,10 @@ package httpserver
 
 import (
 	"bytes"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -81,3 +83,132 @@ func TestOnboardingRequiresUserRegisteredEventFirst(t *testing.T) {
 		t.Fatalf("expected 200 after event ingest, got %d body=%s", rr2.Code, rr2.Body.String())
 	}
 }
+
+func TestOnboardingAdminAuthorsAndPublishesFlow(t *testing.T) {
+	server := newTestServer()
+
+	body := []byte(`{"role":"influencer","name":"Influencer fast track","steps":[{"step_key":"connect_social","title":"Connect social account","completion_event":"social_account.connected"}]}`)
+	createReq := onboardingAdminRequest(http.MethodPost, "/api/onboarding/v1/admin/flows", body, "idem-onb-flow-1")
+	createRR := httptest.NewRecorder()
+	server.mux.ServeHTTP(createRR, createReq)
+	if createRR.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d body=%s", createRR.Code, createRR.Body.String())
+	}
+	var created struct {
+		Data struct {
+			FlowID   string `json:"flow_id"`
+			IsActive bool   `json:"is_active"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(createRR.Body.Bytes(), &created); err != nil {
+		t.Fatalf("decode create response: %v", err)
+	}
+	if created.Data.FlowID == "" || created.Data.IsActive {
+		t.Fatalf("expected inactive draft flow, body=%s", createRR.Body.String())
+	}
+
+	publishReq := onboardingAdminRequest(http.MethodPost, "/api/onboarding/v1/admin/flows/"+created.Data.FlowID+"/versions/1/publish", nil, "idem-onb-flow-2")
+	publishRR := httptest.NewRecorder()
+	server.mux.ServeHTTP(publishRR, publishReq)
+	if publishRR.Code != http.StatusOK || !strings.Contains(publishRR.Body.String(), `"is_active":true`) {
+		t.Fatalf("expected active published flow, got %d body=%s", publishRR.Code, publishRR.Body.String())
+	}
+
+	archiveReq := onboardingAdminRequest(http.MethodDelete, "/api/onboarding/v1/admin/flows/"+created.Data.FlowID, nil, "idem-onb-flow-3")
+	archiveRR := httptest.NewRecorder()
+	server.mux.ServeHTTP(archiveRR, archiveReq)
+	if archiveRR.Code != http.StatusConflict {
+		t.Fatalf("expected 409 archiving active flow, got %d body=%s", archiveRR.Code, archiveRR.Body.String())
+	}
+}
+
+func onboardingAdminRequest(method string, path string, body []byte, idempotencyKey string) *http.Request {
+	req := httptest.NewRequest(method, path, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set("X-Request-Id", "req-"+idempotencyKey)
+	req.Header.Set("X-User-Id", "admin-1")
+	req.Header.Set("Idempotency-Key", idempotencyKey)
+	return req
+}
+
+func TestOnboardingAdminExperimentResultsPerVariant(t *testing.T) {
+	server := newTestServer()
+
+	body := []byte(`{"experiment_key":"editor_profile_first","role":"editor","variants":[{"variant_key":"control","weight":50},{"variant_key":"profile_first","weight":50}]}`)
+	createRR := httptest.NewRecorder()
+	server.mux.ServeHTTP(createRR, onboardingAdminRequest(http.MethodPost, "/api/onboarding/v1/admin/experiments", body, "idem-onb-exp-1"))
+	if createRR.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d body=%s", createRR.Code, createRR.Body.String())
+	}
+
+	duplicateRR := httptest.NewRecorder()
+	server.mux.ServeHTTP(duplicateRR, onboardingAdminRequest(http.MethodPost, "/api/onboarding/v1/admin/experiments",
+		[]byte(`{"experiment_key":"editor_other","role":"editor","variants":[{"variant_key":"a","weight":1},{"variant_key":"b","weight":1}]}`), "idem-onb-exp-2"))
+	if duplicateRR.Code != http.StatusConflict {
+		t.Fatalf("expected 409 for second running experiment, got %d body=%s", duplicateRR.Code, duplicateRR.Body.String())
+	}
+
+	resultsRR := httptest.NewRecorder()
+	server.mux.ServeHTTP(resultsRR, onboardingAdminRequest(http.MethodGet, "/api/onboarding/v1/admin/experiments/editor_profile_first/results", nil, "idem-onb-exp-3"))
+	if resultsRR.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", resultsRR.Code, resultsRR.Body.String())
+	}
+	if !strings.Contains(resultsRR.Body.String(), `"variant_key":"profile_first"`) ||
+		!strings.Contains(resultsRR.Body.String(), `"completion_rate"`) {
+		t.Fatalf("expected per-variant metrics, body=%s", resultsRR.Body.String())
+	}
+}
+
+func TestOnboardingReminderPreferencesValidateAndUnsubscribe(t *testing.T) {
+	server := newTestServer()
+
+	invalid := []byte(`{"email_enabled":true,"in_app_enabled":true,"quiet_hours_start":"22:00"}`)
+	invalidRR := httptest.NewRecorder()
+	server.mux.ServeHTTP(invalidRR, onboardingAdminRequest(http.MethodPut, "/api/onboarding/v1/reminders/preferences", invalid, "idem-onb-pref-1"))
+	if invalidRR.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for half-open quiet hours, got %d body=%s", invalidRR.Code, invalidRR.Body.String())
+	}
+
+	unsubscribeRR := httptest.NewRecorder()
+	server.mux.ServeHTTP(unsubscribeRR, onboardingAdminRequest(http.MethodPost, "/api/onboarding/v1/reminders/unsubscribe", nil, "idem-onb-pref-2"))
+	if unsubscribeRR.Code != http.StatusOK || !strings.Contains(unsubscribeRR.Body.String(), `"unsubscribed":true`) {
+		t.Fatalf("expected unsubscribed preferences, got %d body=%s", unsubscribeRR.Code, unsubscribeRR.Body.String())
+	}
+}
+
+func TestOnboardingAdminRoutesRequireAdminRole(t *testing.T) {
+	server := newTestServer()
+	req := onboardingAdminRequest(http.MethodGet, "/api/onboarding/v1/admin/flows", nil, "idem-onb-role-1")
+	req.Header.Set("X-User-Id", "user_onb_not_admin")
+
+	rr := httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, req)
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for a user without an admin role, got %d body=%s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestOnboardingActivityHookRequiresServiceToken(t *testing.T) {
+	const token = "test-onboarding-activity-token-0123456789"
+	server := newTestServer()
+	server.onboardingActivityToken = []byte(token)
+
+	send := func(auth string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/onboarding/v1/internal/events/activity", bytes.NewReader([]byte(
+			`{"event_id":"evt-onb-act-1","user_id":"user_onb_act","event_type":"submission.created"}`,
+		)))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", auth)
+		req.Header.Set("X-Request-Id", "req-onb-act-1")
+		rr := httptest.NewRecorder()
+		server.mux.ServeHTTP(rr, req)
+		return rr
+	}
+	if rr := send("Bearer token"); rr.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for a user bearer token, got %d body=%s", rr.Code, rr.Body.String())
+	}
+	if rr := send("Bearer " + token); rr.Code != http.StatusAccepted {
+		t.Fatalf("expected 202 with the service token, got %d body=%s", rr.Code, rr.Body.String())
+	}
+}
@@ -0,0 +1,76 @@
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	payoutservice "solomon/contexts/finance-core/payout-service"
+	payouterrors "solomon/contexts/finance-core/payout-service/domain/errors"
+	payoutports "solomon/contexts/finance-core/payout-service/ports"
+	payouthttp "solomon/contexts/finance-core/payout-service/transport/http"
+	admindashboarderrors "solomon/contexts/internal-ops/admin-dashboard-service/domain/errors"
+	admindashboardports "solomon/contexts/internal-ops/admin-dashboard-service/ports"
+)
+
+// payoutLegalHoldActor identifies the payout worker to the legal service
+// (M69) when it checks holds.
+const payoutLegalHoldActor = "payout-service"
+
+// PayoutLegalHolds checks creators against legal holds held by the legal
+// service (M69) at baseURL, the same owner the admin control plane uses.
+func PayoutLegalHolds(baseURL string) payoutports.LegalHoldChecker {
+	return payoutLegalHoldChecker{legal: controlPlaneLegalClient{
+		baseURL: strings.TrimSpace(baseURL),
+		client:  &http.Client{Timeout: ownerRequestTimeout},
+	}}
+}
+
+type payoutLegalHoldChecker struct {
+	legal controlPlaneLegalClient
+}
+
+func (c payoutLegalHoldChecker) IsHeld(ctx context.Context, creatorID string) (bool, error) {
+	result, err := c.legal.CheckHold(ctx, payoutLegalHoldActor, "user", creatorID)
+	if err != nil {
+		return false, err
+	}
+	return result.Held, nil
+}
+
+// controlPlaneLocalPayoutClient serves admin payout retries from the
+// in-process payout module when no remote payout owner is configured. A
+// retry only reschedules the payout; the worker settles it.
+type controlPlaneLocalPayoutClient struct {
+	module payoutservice.Module
+}
+
+func (c controlPlaneLocalPayoutClient) RetryFailedPayout(
+	ctx context.Context,
+	adminID string,
+	payoutID string,
+	reason string,
+	_ string,
+) (admindashboardports.PayoutRetryResult, error) {
+	resp, err := c.module.Handler.RetryPayoutHandler(ctx, adminID, payoutID, payouthttp.RetryPayoutRequest{Reason: reason})
+	switch {
+	case errors.Is(err, payouterrors.ErrPayoutNotFound):
+		return admindashboardports.PayoutRetryResult{}, admindashboarderrors.ErrNotFound
+	case errors.Is(err, payouterrors.ErrPayoutNotRetryable):
+		return admindashboardports.PayoutRetryResult{}, admindashboarderrors.ErrConflict
+	case errors.Is(err, payouterrors.ErrInvalidRequest):
+		return admindashboardports.PayoutRetryResult{}, admindashboarderrors.ErrInvalidInput
+	case err != nil:
+		return admindashboardports.PayoutRetryResult{}, err
+	}
+	processedAt, _ := time.Parse(time.RFC3339, resp.UpdatedAt)
+	return admindashboardports.PayoutRetryResult{
+		PayoutID:      resp.PayoutID,
+		UserID:        resp.CreatorID,
+		Status:        resp.Status,
+		FailureReason: resp.LastError,
+		ProcessedAt:   processedAt,
+	}, nil
+}
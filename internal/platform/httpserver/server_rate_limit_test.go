@@ -0,0 +1,82 @@
+package httpserver
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestChallengeRouteIsRateLimitedPerClientIP(t *testing.T) {
+	server := newTestServer()
+	handler := server.Handler()
+
+	send := func(remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/challenge/ch-rl", bytes.NewReader([]byte(`{"solution":"1"}`)))
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Request-Id", "req-rate-limit")
+		req.Header.Set("X-Forwarded-For", "198.51.100.77")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	for i := 0; i < 10; i++ {
+		rr := send("203.0.113.40:1234")
+		if rr.Code == http.StatusTooManyRequests {
+			t.Fatalf("request %d limited too early", i)
+		}
+		if rr.Header().Get("RateLimit-Limit") != "10" {
+			t.Fatalf("expected RateLimit-Limit 10, got %q", rr.Header().Get("RateLimit-Limit"))
+		}
+	}
+	rr := send("203.0.113.40:1234")
+	if rr.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %d body=%s", rr.Code, rr.Body.String())
+	}
+	if rr.Header().Get("Retry-After") == "" || rr.Header().Get("RateLimit-Remaining") != "0" {
+		t.Fatalf("expected Retry-After and zero remaining, got %v", rr.Header())
+	}
+
+	// X-Forwarded-For from an untrusted peer is ignored, so the limit follows
+	// the connection address rather than the spoofable header.
+	if rr := send("203.0.113.41:1234"); rr.Code == http.StatusTooManyRequests {
+		t.Fatalf("expected a different client IP to have its own bucket")
+	}
+}
+
+func TestClaimRouteIsRateLimitedPerImpersonatedUser(t *testing.T) {
+	server := newTestServer()
+	handler := server.Handler()
+	session := startTestImpersonation(t, handler, "idem-imp-rate-limit", `{"impersonated_user_id":"user-1","reason":"ticket 77","scopes":["write"],"duration_minutes":15}`)
+
+	send := func(remoteAddr string, bearer string, userID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1/marketplace/clips/clip-rl/claim", bytes.NewReader([]byte(`{}`)))
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+bearer)
+		req.Header.Set("X-User-Id", userID)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// The claims bucket follows the verified impersonated user, so changing
+	// address does not buy more requests.
+	for i := 0; i < 5; i++ {
+		if rr := send(fmt.Sprintf("203.0.113.%d:1234", 50+i), session.AccessToken, "user-1"); rr.Code == http.StatusTooManyRequests {
+			t.Fatalf("request %d limited too early", i)
+		}
+	}
+	if rr := send("203.0.113.90:1234", session.AccessToken, "user-1"); rr.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 for the same user from a new address, got %d", rr.Code)
+	}
+
+	// An unverified X-User-Id is not a key: the request gets its address's
+	// bucket instead of the exhausted user's.
+	if rr := send("203.0.113.91:1234", "user-token", "user-1"); rr.Code == http.StatusTooManyRequests {
+		t.Fatalf("expected a client-supplied user header to be keyed by IP, got %d", rr.Code)
+	}
+}
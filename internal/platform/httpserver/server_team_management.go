@@ -3,10 +3,13 @@ package httpserver
 import (
 	"errors"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	teamerrors "solomon/contexts/internal-ops/team-management-service/domain/errors"
 	teamhttp "solomon/contexts/internal-ops/team-management-service/transport/http"
+	"solomon/internal/platform/export"
 )
 
 func writeTeamError(w http.ResponseWriter, status int, code string, message string) {
@@ -33,6 +36,14 @@ func writeTeamDomainError(w http.ResponseWriter, err error) {
 		writeTeamError(w, http.StatusConflict, "conflict", err.Error())
 	case errors.Is(err, teamerrors.ErrInviteExpired):
 		writeTeamError(w, http.StatusGone, "invite_expired", err.Error())
+	case errors.Is(err, teamerrors.ErrInviteRevoked):
+		writeTeamError(w, http.StatusGone, "invite_revoked", err.Error())
+	case errors.Is(err, teamerrors.ErrSSOAssertionInvalid):
+		// The reason stays in the logs; it would help an attacker tune a
+		// forged response.
+		writeTeamError(w, http.StatusUnauthorized, "sso_assertion_invalid", "identity provider response could not be verified")
+	case errors.Is(err, teamerrors.ErrSSOLoginExpired):
+		writeTeamError(w, http.StatusGone, "sso_login_expired", err.Error())
 	case errors.Is(err, teamerrors.ErrDependencyUnavailable):
 		writeTeamError(w, http.StatusFailedDependency, "dependency_unavailable", err.Error())
 	default:
@@ -40,6 +51,28 @@ func writeTeamDomainError(w http.ResponseWriter, err error) {
 	}
 }
 
+// handleTeamDirectorySyncUser is M01's hook for keeping team_user_directory
+// current; team creation, invite acceptance and SSO provisioning read it.
+func (s *Server) handleTeamDirectorySyncUser(w http.ResponseWriter, r *http.Request) {
+	if !hasServiceToken(r, s.teamDirectoryToken) {
+		writeTeamError(w, http.StatusUnauthorized, "unauthorized", "directory syncs are accepted from M01 only")
+		return
+	}
+	if !requireTeamRequestID(w, r) {
+		return
+	}
+	var req teamhttp.DirectoryUserRequest
+	if !s.decodeJSON(w, r, &req, writeTeamError) {
+		return
+	}
+	resp, err := s.teamManagement.Handler.SyncDirectoryUserHandler(r.Context(), r.PathValue("userId"), req)
+	if err != nil {
+		writeTeamDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
 func requireTeamAuthorization(w http.ResponseWriter, r *http.Request) bool {
 	authHeader := strings.TrimSpace(r.Header.Get("Authorization"))
 	parts := strings.SplitN(authHeader, " ", 2)
@@ -167,6 +200,70 @@ func (s *Server) handleTeamAcceptInviteV1(w http.ResponseWriter, r *http.Request
 	s.handleTeamAcceptInvite(w, r)
 }
 
+func (s *Server) handleTeamResendInvite(w http.ResponseWriter, r *http.Request) {
+	if !requireTeamAuthorization(w, r) || !requireTeamRequestID(w, r) {
+		return
+	}
+	actorUserID, ok := requireTeamUser(w, r)
+	if !ok {
+		return
+	}
+	idempotencyKey, ok := requireTeamIdempotencyKey(w, r)
+	if !ok {
+		return
+	}
+	resp, err := s.teamManagement.Handler.ResendInviteHandler(
+		r.Context(),
+		idempotencyKey,
+		actorUserID,
+		r.PathValue("teamId"),
+		r.PathValue("inviteId"),
+	)
+	if err != nil {
+		writeTeamDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, resp)
+}
+
+func (s *Server) handleTeamResendInviteV1(w http.ResponseWriter, r *http.Request) {
+	r.SetPathValue("teamId", r.PathValue("team_id"))
+	r.SetPathValue("inviteId", r.PathValue("invite_id"))
+	s.handleTeamResendInvite(w, r)
+}
+
+func (s *Server) handleTeamRevokeInvite(w http.ResponseWriter, r *http.Request) {
+	if !requireTeamAuthorization(w, r) || !requireTeamRequestID(w, r) {
+		return
+	}
+	actorUserID, ok := requireTeamUser(w, r)
+	if !ok {
+		return
+	}
+	idempotencyKey, ok := requireTeamIdempotencyKey(w, r)
+	if !ok {
+		return
+	}
+	resp, err := s.teamManagement.Handler.RevokeInviteHandler(
+		r.Context(),
+		idempotencyKey,
+		actorUserID,
+		r.PathValue("teamId"),
+		r.PathValue("inviteId"),
+	)
+	if err != nil {
+		writeTeamDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleTeamRevokeInviteV1(w http.ResponseWriter, r *http.Request) {
+	r.SetPathValue("teamId", r.PathValue("team_id"))
+	r.SetPathValue("inviteId", r.PathValue("invite_id"))
+	s.handleTeamRevokeInvite(w, r)
+}
+
 func (s *Server) handleTeamUpdateMemberRole(w http.ResponseWriter, r *http.Request) {
 	if !requireTeamAuthorization(w, r) || !requireTeamRequestID(w, r) {
 		return
@@ -297,6 +394,8 @@ func (s *Server) handleTeamAuditLogs(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, resp)
 }
 
+// handleTeamExportMembers queues a team_members export. Query: format
+// (csv or xlsx), columns (comma separated) and limit.
 func (s *Server) handleTeamExportMembers(w http.ResponseWriter, r *http.Request) {
 	if !requireTeamAuthorization(w, r) || !requireTeamRequestID(w, r) {
 		return
@@ -305,10 +404,41 @@ func (s *Server) handleTeamExportMembers(w http.ResponseWriter, r *http.Request)
 	if !ok {
 		return
 	}
-	resp, err := s.teamManagement.Handler.ExportMembersHandler(r.Context(), actorUserID, r.PathValue("teamId"))
+	query := r.URL.Query()
+	rowLimit := 0
+	if raw := strings.TrimSpace(query.Get("limit")); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			writeTeamError(w, http.StatusBadRequest, "invalid_request", "limit must be an integer")
+			return
+		}
+		rowLimit = parsed
+	}
+	var columns []string
+	if raw := strings.TrimSpace(query.Get("columns")); raw != "" {
+		columns = strings.Split(raw, ",")
+	}
+	teamID := strings.TrimSpace(r.PathValue("teamId"))
+	job, err := s.exports.Request(r.Context(), export.Request{
+		Kind:        exportKindTeamMembers,
+		RequestedBy: actorUserID,
+		Params:      map[string]string{"team_id": teamID},
+		Format:      query.Get("format"),
+		Columns:     columns,
+		RowLimit:    rowLimit,
+	})
 	if err != nil {
-		writeTeamDomainError(w, err)
-		return
-	}
+		writeExportDomainError(w, err)
+		return
+	}
+	resp := teamhttp.ExportMembersResponse{Status: "success"}
+	resp.Data.ExportJobID = job.ExportID
+	resp.Data.TeamID = teamID
+	resp.Data.Status = job.Status
+	resp.Data.Format = job.Format
+	resp.Data.Columns = job.Columns
+	resp.Data.RowLimit = job.RowLimit
+	resp.Data.CreatedAt = job.CreatedAt.UTC().Format(time.RFC3339)
+	resp.Data.StatusURL = exportStatusURLBase + job.ExportID
 	writeJSON(w, http.StatusAccepted, resp)
 }
@@ -55,3 +55,18 @@ func TestTeamCreateEnforcesM01Projection(t *testing.T) {
 		t.Fatalf("expected 424, got %d body=%s", rr.Code, rr.Body.String())
 	}
 }
+
+func TestTeamDirectorySyncRequiresServiceToken(t *testing.T) {
+	server := newTestServer()
+	body := []byte(`{"email":"attacker@example.com","mfa_enabled":true}`)
+	req := httptest.NewRequest(http.MethodPut, "/teams/internal/directory/users/user_owner_1", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set("X-Request-Id", "req-team-dir-1")
+
+	rr := httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, req)
+	if rr.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d body=%s", rr.Code, rr.Body.String())
+	}
+}
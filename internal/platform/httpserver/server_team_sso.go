@@ -0,0 +1,365 @@
+package httpserver
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	teamerrors "solomon/contexts/internal-ops/team-management-service/domain/errors"
+	teamports "solomon/contexts/internal-ops/team-management-service/ports"
+	teamhttp "solomon/contexts/internal-ops/team-management-service/transport/http"
+)
+
+const (
+	// teamSSOStateCookie binds a login to the browser that started it, so a
+	// state value leaked from the IdP redirect cannot be replayed
+	// elsewhere. SameSite=None lets it ride on the IdP's cross-site POST to
+	// the SAML ACS.
+	teamSSOStateCookie = "team_sso_state"
+	maxSAMLPostBytes   = 2 << 20
+)
+
+// SSO administration. These endpoints skip Idempotency-Key handling on
+// purpose: replayed responses would be persisted with the plaintext SCIM
+// token in them.
+
+func (s *Server) handleTeamSSOCreateConnection(w http.ResponseWriter, r *http.Request) {
+	actorUserID, ok := requireTeamSSOAdmin(w, r)
+	if !ok {
+		return
+	}
+	var req teamhttp.SSOConnectionRequest
+	if !s.decodeJSON(w, r, &req, writeTeamError) {
+		return
+	}
+	resp, err := s.teamManagement.Handler.CreateSSOConnectionHandler(r.Context(), actorUserID, r.PathValue("teamId"), req)
+	if err != nil {
+		writeTeamDomainError(w, err)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+func (s *Server) handleTeamSSOListConnections(w http.ResponseWriter, r *http.Request) {
+	actorUserID, ok := requireTeamSSOAdmin(w, r)
+	if !ok {
+		return
+	}
+	resp, err := s.teamManagement.Handler.ListSSOConnectionsHandler(r.Context(), actorUserID, r.PathValue("teamId"))
+	if err != nil {
+		writeTeamDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleTeamSSOUpdateConnection(w http.ResponseWriter, r *http.Request) {
+	actorUserID, ok := requireTeamSSOAdmin(w, r)
+	if !ok {
+		return
+	}
+	var req teamhttp.SSOConnectionRequest
+	if !s.decodeJSON(w, r, &req, writeTeamError) {
+		return
+	}
+	resp, err := s.teamManagement.Handler.UpdateSSOConnectionHandler(
+		r.Context(),
+		actorUserID,
+		r.PathValue("teamId"),
+		r.PathValue("connectionId"),
+		req,
+	)
+	if err != nil {
+		writeTeamDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleTeamSSORotateSCIMToken(w http.ResponseWriter, r *http.Request) {
+	actorUserID, ok := requireTeamSSOAdmin(w, r)
+	if !ok {
+		return
+	}
+	resp, err := s.teamManagement.Handler.RotateSCIMTokenHandler(
+		r.Context(),
+		actorUserID,
+		r.PathValue("teamId"),
+		r.PathValue("connectionId"),
+	)
+	if err != nil {
+		writeTeamDomainError(w, err)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Browser login. The IdP is the authenticator here, so these endpoints
+// take no Authorization header.
+
+func (s *Server) handleTeamSSOLogin(w http.ResponseWriter, r *http.Request) {
+	connectionID := r.PathValue("connectionId")
+	start, err := s.teamManagement.Handler.BeginSSOLoginHandler(r.Context(), connectionID)
+	if err != nil {
+		writeTeamDomainError(w, err)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     teamSSOStateCookie,
+		Value:    start.State,
+		Path:     "/teams/sso/" + connectionID + "/",
+		Expires:  start.ExpiresAt,
+		MaxAge:   int(time.Until(start.ExpiresAt).Seconds()),
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteNoneMode,
+	})
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, start.RedirectURL, http.StatusFound)
+}
+
+func (s *Server) handleTeamSSOOIDCCallback(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	state, ok := requireTeamSSOState(w, r, query.Get("state"))
+	if !ok {
+		return
+	}
+	if query.Get("error") != "" {
+		writeTeamError(w, http.StatusUnauthorized, "sso_assertion_invalid", "identity provider did not complete the login")
+		return
+	}
+	s.completeTeamSSOLogin(w, r, state, teamports.SSOCallback{Code: query.Get("code")})
+}
+
+func (s *Server) handleTeamSSOSAMLACS(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxSAMLPostBytes)
+	if err := r.ParseForm(); err != nil {
+		writeTeamError(w, http.StatusBadRequest, "invalid_request", "SAMLResponse form is invalid")
+		return
+	}
+	state, ok := requireTeamSSOState(w, r, r.PostForm.Get("RelayState"))
+	if !ok {
+		return
+	}
+	s.completeTeamSSOLogin(w, r, state, teamports.SSOCallback{SAMLResponse: r.PostForm.Get("SAMLResponse")})
+}
+
+func (s *Server) completeTeamSSOLogin(w http.ResponseWriter, r *http.Request, state string, callback teamports.SSOCallback) {
+	resp, err := s.teamManagement.Handler.CompleteSSOLoginHandler(r.Context(), r.PathValue("connectionId"), state, callback)
+	if err != nil {
+		writeTeamDomainError(w, err)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleTeamSSOSAMLMetadata(w http.ResponseWriter, r *http.Request) {
+	metadata, err := s.teamManagement.Handler.SAMLMetadataHandler(r.Context(), r.PathValue("connectionId"))
+	if err != nil {
+		writeTeamDomainError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/samlmetadata+xml")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(metadata)
+}
+
+// SCIM 2.0 provisioning, authenticated with the connection's SCIM token.
+
+func (s *Server) handleTeamSCIMServiceProviderConfig(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.requireTeamSCIMConnection(w, r); !ok {
+		return
+	}
+	writeSCIM(w, http.StatusOK, s.teamManagement.Handler.SCIMServiceProviderConfigHandler())
+}
+
+func (s *Server) handleTeamSCIMListUsers(w http.ResponseWriter, r *http.Request) {
+	conn, ok := s.requireTeamSCIMConnection(w, r)
+	if !ok {
+		return
+	}
+	query := r.URL.Query()
+	resp, err := s.teamManagement.Handler.ListSCIMUsersHandler(
+		r.Context(),
+		conn,
+		query.Get("filter"),
+		query.Get("startIndex"),
+		query.Get("count"),
+	)
+	if err != nil {
+		if errors.Is(err, teamerrors.ErrInvalidRequest) {
+			writeSCIMError(w, http.StatusBadRequest, "invalidFilter", `only "userName eq" and "externalId eq" filters are supported`)
+			return
+		}
+		writeSCIMDomainError(w, err)
+		return
+	}
+	writeSCIM(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleTeamSCIMGetUser(w http.ResponseWriter, r *http.Request) {
+	conn, ok := s.requireTeamSCIMConnection(w, r)
+	if !ok {
+		return
+	}
+	resp, err := s.teamManagement.Handler.GetSCIMUserHandler(r.Context(), conn, r.PathValue("userId"))
+	if err != nil {
+		writeSCIMDomainError(w, err)
+		return
+	}
+	writeSCIM(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleTeamSCIMCreateUser(w http.ResponseWriter, r *http.Request) {
+	conn, ok := s.requireTeamSCIMConnection(w, r)
+	if !ok {
+		return
+	}
+	var req teamhttp.SCIMUser
+	if !s.decodeJSON(w, r, &req, writeSCIMInvalidJSON) {
+		return
+	}
+	resp, err := s.teamManagement.Handler.CreateSCIMUserHandler(r.Context(), conn, req)
+	if err != nil {
+		writeSCIMDomainError(w, err)
+		return
+	}
+	w.Header().Set("Location", resp.Meta.Location)
+	writeSCIM(w, http.StatusCreated, resp)
+}
+
+func (s *Server) handleTeamSCIMReplaceUser(w http.ResponseWriter, r *http.Request) {
+	conn, ok := s.requireTeamSCIMConnection(w, r)
+	if !ok {
+		return
+	}
+	var req teamhttp.SCIMUser
+	if !s.decodeJSON(w, r, &req, writeSCIMInvalidJSON) {
+		return
+	}
+	resp, err := s.teamManagement.Handler.ReplaceSCIMUserHandler(r.Context(), conn, r.PathValue("userId"), req)
+	if err != nil {
+		writeSCIMDomainError(w, err)
+		return
+	}
+	writeSCIM(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleTeamSCIMPatchUser(w http.ResponseWriter, r *http.Request) {
+	conn, ok := s.requireTeamSCIMConnection(w, r)
+	if !ok {
+		return
+	}
+	var req teamhttp.SCIMPatchRequest
+	if !s.decodeJSON(w, r, &req, writeSCIMInvalidJSON) {
+		return
+	}
+	resp, err := s.teamManagement.Handler.PatchSCIMUserHandler(r.Context(), conn, r.PathValue("userId"), req)
+	if err != nil {
+		writeSCIMDomainError(w, err)
+		return
+	}
+	writeSCIM(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleTeamSCIMDeleteUser(w http.ResponseWriter, r *http.Request) {
+	conn, ok := s.requireTeamSCIMConnection(w, r)
+	if !ok {
+		return
+	}
+	if err := s.teamManagement.Handler.DeleteSCIMUserHandler(r.Context(), conn, r.PathValue("userId")); err != nil {
+		writeSCIMDomainError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func requireTeamSSOAdmin(w http.ResponseWriter, r *http.Request) (string, bool) {
+	if !requireTeamAuthorization(w, r) || !requireTeamRequestID(w, r) {
+		return "", false
+	}
+	return requireTeamUser(w, r)
+}
+
+// requireTeamSSOState checks the state the IdP echoed against the cookie
+// set when the login started, then clears the cookie.
+func requireTeamSSOState(w http.ResponseWriter, r *http.Request, state string) (string, bool) {
+	state = strings.TrimSpace(state)
+	cookie, err := r.Cookie(teamSSOStateCookie)
+	if state == "" || err != nil || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(state)) != 1 {
+		writeTeamError(w, http.StatusForbidden, "sso_state_mismatch", "login was not started from this browser")
+		return "", false
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     teamSSOStateCookie,
+		Value:    "",
+		Path:     "/teams/sso/" + r.PathValue("connectionId") + "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteNoneMode,
+	})
+	return state, true
+}
+
+func (s *Server) requireTeamSCIMConnection(w http.ResponseWriter, r *http.Request) (teamports.SSOConnection, bool) {
+	parts := strings.SplitN(strings.TrimSpace(r.Header.Get("Authorization")), " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") || strings.TrimSpace(parts[1]) == "" {
+		writeSCIMError(w, http.StatusUnauthorized, "", "SCIM bearer token is required")
+		return teamports.SSOConnection{}, false
+	}
+	conn, err := s.teamManagement.Handler.AuthenticateSCIMHandler(r.Context(), r.PathValue("connectionId"), parts[1])
+	if errors.Is(err, teamerrors.ErrForbidden) {
+		writeSCIMError(w, http.StatusUnauthorized, "", "SCIM bearer token is not valid for this connection")
+		return teamports.SSOConnection{}, false
+	}
+	if err != nil {
+		writeSCIMDomainError(w, err)
+		return teamports.SSOConnection{}, false
+	}
+	return conn, true
+}
+
+func writeSCIM(w http.ResponseWriter, status int, payload any) {
+	w.Header().Set("Content-Type", teamhttp.SCIMContentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
+
+func writeSCIMError(w http.ResponseWriter, status int, scimType string, detail string) {
+	writeSCIM(w, status, teamhttp.SCIMError{
+		Schemas:  []string{teamhttp.SCIMSchemaError},
+		Status:   http.StatusText(status),
+		SCIMType: scimType,
+		Detail:   detail,
+	})
+}
+
+func writeSCIMInvalidJSON(w http.ResponseWriter, status int, _ string, message string) {
+	writeSCIMError(w, status, "invalidSyntax", message)
+}
+
+func writeSCIMDomainError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, teamerrors.ErrNotFound):
+		writeSCIMError(w, http.StatusNotFound, "", "user not found")
+	case errors.Is(err, teamerrors.ErrInvalidRequest):
+		writeSCIMError(w, http.StatusBadRequest, "invalidValue", err.Error())
+	case errors.Is(err, teamerrors.ErrForbidden):
+		writeSCIMError(w, http.StatusForbidden, "", "email domain is not allowed for this connection")
+	case errors.Is(err, teamerrors.ErrConflict):
+		writeSCIMError(w, http.StatusConflict, "uniqueness", "user is already provisioned")
+	case errors.Is(err, teamerrors.ErrOwnerTransferRequired):
+		writeSCIMError(w, http.StatusConflict, "mutability", "the team owner cannot be deprovisioned; transfer ownership first")
+	case errors.Is(err, teamerrors.ErrDependencyUnavailable):
+		writeSCIMError(w, http.StatusFailedDependency, "", "the user account is not available")
+	default:
+		writeSCIMError(w, http.StatusInternalServerError, "", "internal server error")
+	}
+}
@@ -0,0 +1,60 @@
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTeamSSOConnectionsRequireAuthorization(t *testing.T) {
+	server := newTestServer()
+	req := httptest.NewRequest(http.MethodGet, "/teams/team_seed_1/sso/connections", nil)
+	req.Header.Set("X-Request-Id", "req-team-sso-1")
+	req.Header.Set("X-User-Id", "user_owner_1")
+
+	rr := httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, req)
+	if rr.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d body=%s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestTeamSSOLoginUnknownConnection(t *testing.T) {
+	server := newTestServer()
+	req := httptest.NewRequest(http.MethodGet, "/teams/sso/sso_missing/login", nil)
+	req.Header.Set("X-Request-Id", "req-team-sso-2")
+
+	rr := httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, req)
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d body=%s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestTeamSSOCallbackRequiresStateCookie(t *testing.T) {
+	server := newTestServer()
+	req := httptest.NewRequest(http.MethodGet, "/teams/sso/sso_missing/oidc/callback?state=abc&code=xyz", nil)
+	req.Header.Set("X-Request-Id", "req-team-sso-3")
+
+	rr := httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, req)
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d body=%s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestTeamSCIMRequiresBearerToken(t *testing.T) {
+	server := newTestServer()
+	req := httptest.NewRequest(http.MethodGet, "/scim/v2/teams/sso/sso_missing/Users", nil)
+	req.Header.Set("X-Request-Id", "req-team-scim-1")
+
+	rr := httptest.NewRecorder()
+	server.mux.ServeHTTP(rr, req)
+	if rr.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d body=%s", rr.Code, rr.Body.String())
+	}
+	if contentType := rr.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "application/scim+json") {
+		t.Fatalf("expected a SCIM error body, got content type %q", contentType)
+	}
+}
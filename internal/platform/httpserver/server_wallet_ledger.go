@@ -0,0 +1,123 @@
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	walletledgerservice "solomon/contexts/finance-core/wallet-ledger-service"
+	ledgerdomainerrors "solomon/contexts/finance-core/wallet-ledger-service/domain/errors"
+	ledgerhttp "solomon/contexts/finance-core/wallet-ledger-service/transport/http"
+	superadmindomainerrors "solomon/contexts/internal-ops/super-admin-dashboard/domain/errors"
+	superadminports "solomon/contexts/internal-ops/super-admin-dashboard/ports"
+)
+
+// SuperAdminWalletLedger posts super-admin wallet adjustments as journal
+// entries in the wallet ledger, which then owns wallet balances.
+func SuperAdminWalletLedger(ledger walletledgerservice.Module) superadminports.WalletLedger {
+	return superAdminWalletLedger{module: ledger}
+}
+
+type superAdminWalletLedger struct {
+	module walletledgerservice.Module
+}
+
+func (l superAdminWalletLedger) PostWalletAdjustment(
+	ctx context.Context,
+	posting superadminports.WalletPosting,
+) (superadminports.WalletBalanceChange, error) {
+	resp, err := l.module.Handler.PostAdminAdjustmentHandler(ctx, ledgerhttp.AdminAdjustmentRequest{
+		IdempotencyKey: posting.PostingKey,
+		AdminID:        posting.AdminID,
+		UserID:         posting.UserID,
+		AmountCents:    int64(math.Round(posting.Amount * 100)),
+		Direction:      posting.Type,
+		Reason:         posting.Reason,
+	})
+	switch {
+	case errors.Is(err, ledgerdomainerrors.ErrInsufficientFunds):
+		return superadminports.WalletBalanceChange{}, superadmindomainerrors.ErrConflict
+	case errors.Is(err, ledgerdomainerrors.ErrIdempotencyConflict):
+		return superadminports.WalletBalanceChange{}, superadmindomainerrors.ErrIdempotencyConflict
+	case errors.Is(err, ledgerdomainerrors.ErrInvalidRequest),
+		errors.Is(err, ledgerdomainerrors.ErrInvalidAccount),
+		errors.Is(err, ledgerdomainerrors.ErrUnbalancedEntry):
+		return superadminports.WalletBalanceChange{}, superadmindomainerrors.ErrInvalidRequest
+	case err != nil:
+		return superadminports.WalletBalanceChange{}, err
+	}
+	return superadminports.WalletBalanceChange{
+		BalanceBefore: float64(resp.BalanceBeforeCents) / 100,
+		BalanceAfter:  float64(resp.BalanceAfterCents) / 100,
+	}, nil
+}
+
+func (s *Server) handleAdminLedgerAccount(w http.ResponseWriter, r *http.Request) {
+	if !requireAdminHeaders(w, r) {
+		return
+	}
+	if _, ok := requireAdminID(w, r); !ok {
+		return
+	}
+	limit, ok := ledgerQueryInt(w, r, "page_size", 20)
+	if !ok {
+		return
+	}
+	offset, ok := ledgerQueryInt(w, r, "offset", 0)
+	if !ok {
+		return
+	}
+	resp, err := s.walletLedger.Handler.GetAccountStatementHandler(r.Context(), r.PathValue("account_id"), limit, offset)
+	if err != nil {
+		writeLedgerDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleAdminLedgerDrifts(w http.ResponseWriter, r *http.Request) {
+	if !requireAdminHeaders(w, r) {
+		return
+	}
+	if _, ok := requireAdminID(w, r); !ok {
+		return
+	}
+	limit, ok := ledgerQueryInt(w, r, "page_size", 50)
+	if !ok {
+		return
+	}
+	resp, err := s.walletLedger.Handler.ListDriftsHandler(r.Context(), limit)
+	if err != nil {
+		writeLedgerDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func ledgerQueryInt(w http.ResponseWriter, r *http.Request, name string, fallback int) (int, bool) {
+	raw := strings.TrimSpace(r.URL.Query().Get(name))
+	if raw == "" {
+		return fallback, true
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, ledgerhttp.ErrorResponse{Code: "invalid_request", Message: name + " must be an integer"})
+		return 0, false
+	}
+	return value, true
+}
+
+func writeLedgerDomainError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ledgerdomainerrors.ErrAccountNotFound):
+		writeJSON(w, http.StatusNotFound, ledgerhttp.ErrorResponse{Code: "account_not_found", Message: err.Error()})
+	case errors.Is(err, ledgerdomainerrors.ErrInvalidAccount),
+		errors.Is(err, ledgerdomainerrors.ErrInvalidRequest):
+		writeJSON(w, http.StatusBadRequest, ledgerhttp.ErrorResponse{Code: "invalid_request", Message: err.Error()})
+	default:
+		writeJSON(w, http.StatusInternalServerError, ledgerhttp.ErrorResponse{Code: "internal_error", Message: "internal server error"})
+	}
+}
@@ -0,0 +1,89 @@
+package ratelimit
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// ClientIPResolver derives the client IP of a request. X-Forwarded-For is
+// only honoured when the connection comes from a trusted proxy, and then it
+// is read right to left, skipping further trusted hops, so a client cannot
+// choose its own address by prepending entries.
+type ClientIPResolver struct {
+	trusted []netip.Prefix
+}
+
+// NewClientIPResolver parses trusted proxy CIDRs or bare addresses.
+func NewClientIPResolver(trustedProxies []string) (ClientIPResolver, error) {
+	resolver := ClientIPResolver{}
+	for _, raw := range trustedProxies {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		if !strings.Contains(raw, "/") {
+			addr, err := netip.ParseAddr(raw)
+			if err != nil {
+				return ClientIPResolver{}, fmt.Errorf("parse trusted proxy %q: %w", raw, err)
+			}
+			resolver.trusted = append(resolver.trusted, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(raw)
+		if err != nil {
+			return ClientIPResolver{}, fmt.Errorf("parse trusted proxy %q: %w", raw, err)
+		}
+		resolver.trusted = append(resolver.trusted, prefix.Masked())
+	}
+	return resolver, nil
+}
+
+func (c ClientIPResolver) Resolve(r *http.Request) string {
+	remote, ok := parseIP(r.RemoteAddr)
+	if !ok {
+		return strings.TrimSpace(r.RemoteAddr)
+	}
+	if !c.isTrusted(remote) {
+		return remote.String()
+	}
+	hops := []string{}
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(header, ",")...)
+	}
+	client := remote
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, ok := parseIP(hops[i])
+		if !ok {
+			break
+		}
+		client = hop
+		if !c.isTrusted(hop) {
+			break
+		}
+	}
+	return client.String()
+}
+
+func (c ClientIPResolver) isTrusted(addr netip.Addr) bool {
+	for _, prefix := range c.trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseIP(raw string) (netip.Addr, bool) {
+	raw = strings.TrimSpace(raw)
+	if host, _, err := net.SplitHostPort(raw); err == nil {
+		raw = host
+	}
+	addr, err := netip.ParseAddr(strings.Trim(raw, "[]"))
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr.Unmap(), true
+}
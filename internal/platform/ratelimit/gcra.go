@@ -0,0 +1,91 @@
+package ratelimit
+
+import (
+	"fmt"
+	"time"
+)
+
+// KeyKind selects what a policy counts requests against.
+type KeyKind string
+
+const (
+	// KeyIP limits by client IP.
+	KeyIP KeyKind = "ip"
+	// KeyPrincipal limits by verified principal and falls back to the client
+	// IP for requests that carry none.
+	KeyPrincipal KeyKind = "principal"
+)
+
+// Policy is the limit for one route group: Limit requests per Period on
+// average, with up to Burst requests allowed back to back.
+type Policy struct {
+	Name   string
+	Routes []string
+	Limit  int
+	Period time.Duration
+	Burst  int
+	Key    KeyKind
+}
+
+func (p Policy) validate() error {
+	if p.Name == "" || p.Limit <= 0 || p.Period <= 0 {
+		return fmt.Errorf("rate limit policy %q needs a name, limit and period", p.Name)
+	}
+	if p.Key != KeyIP && p.Key != KeyPrincipal {
+		return fmt.Errorf("rate limit policy %q has unknown key %q", p.Name, p.Key)
+	}
+	return nil
+}
+
+func (p Policy) burst() int {
+	if p.Burst <= 0 {
+		return p.Limit
+	}
+	return p.Burst
+}
+
+// emissionInterval is the time one request "costs" under the policy.
+func (p Policy) emissionInterval() time.Duration {
+	return p.Period / time.Duration(p.Limit)
+}
+
+// Decision is the outcome of one GCRA check.
+type Decision struct {
+	Allowed bool
+	// TAT is the theoretical arrival time to store for the key. It only
+	// moves forward when the request is allowed.
+	TAT        time.Time
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration
+	// ResetAfter is how long until the key is back to a full burst.
+	ResetAfter time.Duration
+}
+
+// Decide applies the generic cell rate algorithm to a stored theoretical
+// arrival time. A zero tat means the key has no history.
+func Decide(tat time.Time, now time.Time, policy Policy) Decision {
+	interval := policy.emissionInterval()
+	burst := policy.burst()
+	tolerance := interval * time.Duration(burst)
+	if tat.Before(now) {
+		tat = now
+	}
+	next := tat.Add(interval)
+	allowAt := next.Add(-tolerance)
+
+	decision := Decision{Limit: burst, TAT: tat}
+	if now.Before(allowAt) {
+		decision.RetryAfter = allowAt.Sub(now)
+		decision.ResetAfter = tat.Sub(now)
+		return decision
+	}
+	decision.Allowed = true
+	decision.TAT = next
+	decision.ResetAfter = next.Sub(now)
+	decision.Remaining = int((tolerance - decision.ResetAfter) / interval)
+	if decision.Remaining < 0 {
+		decision.Remaining = 0
+	}
+	return decision
+}
@@ -0,0 +1,171 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type contextKey int
+
+const (
+	clientIPKey contextKey = iota
+	principalKey
+)
+
+// WithClientIP stores the resolved client IP on the request context.
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey, ip)
+}
+
+func ClientIPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey).(string)
+	return ip
+}
+
+// WithPrincipal marks the request as made by an authenticated principal.
+// Only middleware that has verified a credential should call it; identity
+// headers supplied by the client are never used as rate limit keys.
+func WithPrincipal(ctx context.Context, principalID string) context.Context {
+	return context.WithValue(ctx, principalKey, principalID)
+}
+
+func PrincipalFromContext(ctx context.Context) string {
+	principalID, _ := ctx.Value(principalKey).(string)
+	return principalID
+}
+
+// Config declares the route-group policies. Routes are matched against the
+// ServeMux pattern a request resolves to, e.g. "POST /v1/votes". Requests
+// to routes outside every group use Default.
+type Config struct {
+	Store    Store
+	Policies []Policy
+	Default  Policy
+	Logger   *slog.Logger
+	Clock    func() time.Time
+}
+
+type Limiter struct {
+	store    Store
+	byRoute  map[string]Policy
+	fallback Policy
+	logger   *slog.Logger
+	clock    func() time.Time
+}
+
+func NewLimiter(cfg Config) (*Limiter, error) {
+	if cfg.Store == nil {
+		cfg.Store = NewMemoryStore()
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default()
+	}
+	if cfg.Clock == nil {
+		cfg.Clock = time.Now
+	}
+	if err := cfg.Default.validate(); err != nil {
+		return nil, err
+	}
+	limiter := &Limiter{
+		store:    cfg.Store,
+		byRoute:  make(map[string]Policy),
+		fallback: cfg.Default,
+		logger:   cfg.Logger,
+		clock:    cfg.Clock,
+	}
+	for _, policy := range cfg.Policies {
+		if err := policy.validate(); err != nil {
+			return nil, err
+		}
+		for _, route := range policy.Routes {
+			if existing, ok := limiter.byRoute[route]; ok {
+				return nil, fmt.Errorf("route %q is in rate limit policies %q and %q", route, existing.Name, policy.Name)
+			}
+			limiter.byRoute[route] = policy
+		}
+	}
+	return limiter, nil
+}
+
+// Wrap limits requests before they reach next. route returns the pattern
+// the request will be served by. The limiter fails open: a store error is
+// logged and the request goes through.
+func (l *Limiter) Wrap(next http.Handler, route func(*http.Request) string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		policy, ok := l.byRoute[route(r)]
+		if !ok {
+			policy = l.fallback
+		}
+		key := l.key(r, policy)
+		decision, err := l.store.Take(r.Context(), key, l.clock().UTC(), policy)
+		if err != nil {
+			l.logger.Warn("rate limit check failed",
+				"event", "rate_limit_check_failed",
+				"module", "internal/platform/ratelimit",
+				"layer", "platform",
+				"policy", policy.Name,
+				"error", err.Error(),
+			)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		header := w.Header()
+		header.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d;burst=%d", policy.Limit, int(policy.Period.Seconds()), policy.burst()))
+		header.Set("RateLimit-Limit", strconv.Itoa(decision.Limit))
+		header.Set("RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+		header.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(decision.ResetAfter)))
+		if decision.Allowed {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		retryAfter := ceilSeconds(decision.RetryAfter)
+		header.Set("Retry-After", strconv.Itoa(retryAfter))
+		header.Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"code":                "rate_limited",
+			"message":             "too many requests",
+			"policy":              policy.Name,
+			"retry_after_seconds": retryAfter,
+		})
+	})
+}
+
+// Sweep drops expired state when the store supports it.
+func (l *Limiter) Sweep(ctx context.Context) error {
+	sweeper, ok := l.store.(interface {
+		Sweep(ctx context.Context, now time.Time) error
+	})
+	if !ok {
+		return nil
+	}
+	return sweeper.Sweep(ctx, l.clock())
+}
+
+func (l *Limiter) key(r *http.Request, policy Policy) string {
+	if policy.Key == KeyPrincipal {
+		if principalID := PrincipalFromContext(r.Context()); principalID != "" {
+			return policy.Name + "|principal:" + principalID
+		}
+	}
+	ip := ClientIPFromContext(r.Context())
+	if ip == "" {
+		ip = r.RemoteAddr
+	}
+	return policy.Name + "|ip:" + ip
+}
+
+func ceilSeconds(d time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+	return int(math.Ceil(d.Seconds()))
+}
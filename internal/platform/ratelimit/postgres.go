@@ -0,0 +1,64 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PostgresStore shares rate limit state between instances. Each Take locks
+// the key's row for the duration of one short transaction.
+type PostgresStore struct {
+	db *gorm.DB
+}
+
+func NewPostgresStore(db *gorm.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+func (s *PostgresStore) Take(ctx context.Context, key string, now time.Time, policy Policy) (Decision, error) {
+	now = now.UTC()
+	var decision Decision
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&bucketModel{BucketKey: key, TAT: now, ExpiresAt: now}).Error; err != nil {
+			return err
+		}
+		var row bucketModel
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("bucket_key = ?", key).
+			First(&row).Error; err != nil {
+			return err
+		}
+		decision = Decide(row.TAT.UTC(), now, policy)
+		if !decision.Allowed {
+			return nil
+		}
+		return tx.Model(&bucketModel{}).
+			Where("bucket_key = ?", key).
+			Updates(map[string]any{
+				"tat":        decision.TAT,
+				"expires_at": decision.TAT,
+			}).Error
+	})
+	return decision, err
+}
+
+// Sweep deletes buckets whose arrival time has passed; they hold no state.
+func (s *PostgresStore) Sweep(ctx context.Context, now time.Time) error {
+	return s.db.WithContext(ctx).
+		Where("expires_at < ?", now.UTC()).
+		Delete(&bucketModel{}).Error
+}
+
+type bucketModel struct {
+	BucketKey string    `gorm:"column:bucket_key;primaryKey"`
+	TAT       time.Time `gorm:"column:tat"`
+	ExpiresAt time.Time `gorm:"column:expires_at"`
+}
+
+func (bucketModel) TableName() string {
+	return "rate_limit_buckets"
+}
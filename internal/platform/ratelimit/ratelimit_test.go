@@ -0,0 +1,102 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDecideAllowsBurstThenSpacesRequests(t *testing.T) {
+	policy := Policy{Name: "test", Limit: 6, Period: time.Minute, Burst: 3, Key: KeyIP}
+	store := NewMemoryStore()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 3; i++ {
+		decision, err := store.Take(context.Background(), "k", now, policy)
+		if err != nil || !decision.Allowed {
+			t.Fatalf("request %d: expected allowed, got %+v err=%v", i, decision, err)
+		}
+		if decision.Remaining != 2-i {
+			t.Fatalf("request %d: expected %d remaining, got %d", i, 2-i, decision.Remaining)
+		}
+	}
+	denied, _ := store.Take(context.Background(), "k", now, policy)
+	if denied.Allowed || denied.RetryAfter != 10*time.Second {
+		t.Fatalf("expected denial with 10s retry, got %+v", denied)
+	}
+
+	later, _ := store.Take(context.Background(), "k", now.Add(10*time.Second), policy)
+	if !later.Allowed || later.Remaining != 0 {
+		t.Fatalf("expected one slot after one emission interval, got %+v", later)
+	}
+}
+
+func TestClientIPResolverOnlyTrustsConfiguredProxies(t *testing.T) {
+	resolver, err := NewClientIPResolver([]string{"10.0.0.0/8"})
+	if err != nil {
+		t.Fatalf("new resolver: %v", err)
+	}
+	cases := []struct {
+		name      string
+		remote    string
+		forwarded string
+		want      string
+	}{
+		{name: "direct client ignores header", remote: "203.0.113.9:5000", forwarded: "198.51.100.1", want: "203.0.113.9"},
+		{name: "trusted proxy", remote: "10.0.0.2:443", forwarded: "198.51.100.1", want: "198.51.100.1"},
+		{name: "spoofed prefix is skipped", remote: "10.0.0.2:443", forwarded: "1.1.1.1, 198.51.100.1, 10.0.0.3", want: "198.51.100.1"},
+		{name: "no header", remote: "10.0.0.2:443", want: "10.0.0.2"},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = tc.remote
+		if tc.forwarded != "" {
+			req.Header.Set("X-Forwarded-For", tc.forwarded)
+		}
+		if got := resolver.Resolve(req); got != tc.want {
+			t.Fatalf("%s: expected %s, got %s", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestLimiterKeysPrincipalRoutesByVerifiedPrincipal(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	limiter, err := NewLimiter(Config{
+		Policies: []Policy{{Name: "votes", Routes: []string{"POST /v1/votes"}, Limit: 1, Period: time.Minute, Key: KeyPrincipal}},
+		Default:  Policy{Name: "default", Limit: 100, Period: time.Minute, Key: KeyIP},
+		Clock:    func() time.Time { return now },
+	})
+	if err != nil {
+		t.Fatalf("new limiter: %v", err)
+	}
+	handler := limiter.Wrap(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}), func(*http.Request) string { return "POST /v1/votes" })
+
+	send := func(principalID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1/votes", nil)
+		req = req.WithContext(WithClientIP(req.Context(), "203.0.113.5"))
+		if principalID != "" {
+			req = req.WithContext(WithPrincipal(req.Context(), principalID))
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	if rr := send("user-a"); rr.Code != http.StatusNoContent {
+		t.Fatalf("expected first vote allowed, got %d", rr.Code)
+	}
+	if rr := send("user-b"); rr.Code != http.StatusNoContent {
+		t.Fatalf("expected another principal on the same IP to be allowed, got %d", rr.Code)
+	}
+	rr := send("user-a")
+	if rr.Code != http.StatusTooManyRequests || rr.Header().Get("Retry-After") != "60" {
+		t.Fatalf("expected 429 with Retry-After 60, got %d %q", rr.Code, rr.Header().Get("Retry-After"))
+	}
+	if rr := send(""); rr.Code != http.StatusNoContent {
+		t.Fatalf("expected anonymous request to use the IP bucket, got %d", rr.Code)
+	}
+}
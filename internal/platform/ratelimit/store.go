@@ -0,0 +1,49 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Store keeps one theoretical arrival time per key and applies Decide
+// atomically, so concurrent requests for the same key cannot both take the
+// last slot.
+type Store interface {
+	Take(ctx context.Context, key string, now time.Time, policy Policy) (Decision, error)
+}
+
+// MemoryStore is a single-process Store. Keys whose arrival time has passed
+// carry no state and are swept periodically.
+type MemoryStore struct {
+	mu        sync.Mutex
+	tats      map[string]time.Time
+	takes     int
+	sweepEach int
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		tats:      make(map[string]time.Time),
+		sweepEach: 1024,
+	}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, now time.Time, policy Policy) (Decision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.takes++
+	if s.takes%s.sweepEach == 0 {
+		for k, tat := range s.tats {
+			if !tat.After(now) {
+				delete(s.tats, k)
+			}
+		}
+	}
+	decision := Decide(s.tats[key], now, policy)
+	if decision.Allowed {
+		s.tats[key] = decision.TAT
+	}
+	return decision, nil
+}
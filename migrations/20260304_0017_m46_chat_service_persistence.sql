@@ -0,0 +1,132 @@
+-- M46-Chat-Service production persistence.
+-- Channel history is paged by (channel_id, sequence_number) keyset and exports
+-- by (created_at, message_id), so neither path needs OFFSET scans.
+
+CREATE TABLE IF NOT EXISTS chat_channel_sequences (
+    channel_id VARCHAR(64) PRIMARY KEY,
+    last_sequence BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS chat_messages (
+    message_id VARCHAR(64) PRIMARY KEY,
+    server_id VARCHAR(64) NOT NULL,
+    channel_id VARCHAR(64) NOT NULL,
+    thread_id VARCHAR(64) NOT NULL DEFAULT '',
+    user_id VARCHAR(64) NOT NULL,
+    username TEXT NOT NULL DEFAULT '',
+    content TEXT NOT NULL,
+    sequence_number BIGINT NOT NULL,
+    mentions JSONB NOT NULL DEFAULT '[]'::jsonb,
+    embeds JSONB NOT NULL DEFAULT '[]'::jsonb,
+    edited BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ NULL,
+    deleted_by_user_id VARCHAR(64) NOT NULL DEFAULT '',
+    deletion_reason TEXT NOT NULL DEFAULT '',
+    CONSTRAINT chat_messages_sequence_positive CHECK (sequence_number > 0)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS ux_chat_messages_channel_sequence
+    ON chat_messages (channel_id, sequence_number);
+CREATE INDEX IF NOT EXISTS idx_chat_messages_server_export
+    ON chat_messages (server_id, created_at, message_id);
+CREATE INDEX IF NOT EXISTS idx_chat_messages_channel_export
+    ON chat_messages (channel_id, created_at, message_id);
+
+CREATE TABLE IF NOT EXISTS chat_message_edits (
+    edit_id VARCHAR(64) PRIMARY KEY,
+    message_id VARCHAR(64) NOT NULL REFERENCES chat_messages (message_id),
+    editor_id VARCHAR(64) NOT NULL,
+    previous_content TEXT NOT NULL,
+    edited_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_chat_message_edits_message_edited_at
+    ON chat_message_edits (message_id, edited_at);
+
+CREATE TABLE IF NOT EXISTS chat_reactions (
+    reaction_id VARCHAR(64) PRIMARY KEY,
+    message_id VARCHAR(64) NOT NULL REFERENCES chat_messages (message_id),
+    user_id VARCHAR(64) NOT NULL,
+    emoji TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS ux_chat_reactions_message_user_emoji
+    ON chat_reactions (message_id, user_id, emoji);
+
+CREATE TABLE IF NOT EXISTS chat_read_states (
+    read_state_id VARCHAR(64) PRIMARY KEY,
+    user_id VARCHAR(64) NOT NULL,
+    channel_id VARCHAR(64) NOT NULL,
+    last_read_message_id VARCHAR(64) NOT NULL DEFAULT '',
+    last_read_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS ux_chat_read_states_user_channel
+    ON chat_read_states (user_id, channel_id);
+
+CREATE TABLE IF NOT EXISTS chat_mutes (
+    mute_id VARCHAR(64) PRIMARY KEY,
+    user_id VARCHAR(64) NOT NULL,
+    server_id VARCHAR(64) NOT NULL,
+    muted_by_user_id VARCHAR(64) NOT NULL,
+    muted_until TIMESTAMPTZ NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS ux_chat_mutes_user_server
+    ON chat_mutes (user_id, server_id);
+
+CREATE TABLE IF NOT EXISTS chat_server_moderators (
+    server_id VARCHAR(64) NOT NULL,
+    user_id VARCHAR(64) NOT NULL,
+    updated_by VARCHAR(64) NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (server_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS chat_pins (
+    message_id VARCHAR(64) PRIMARY KEY REFERENCES chat_messages (message_id),
+    pinned_by VARCHAR(64) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    pinned_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS chat_reports (
+    report_id VARCHAR(64) PRIMARY KEY,
+    message_id VARCHAR(64) NOT NULL REFERENCES chat_messages (message_id),
+    reporter_id VARCHAR(64) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    reported_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_chat_reports_message_id
+    ON chat_reports (message_id, reported_at DESC);
+
+CREATE TABLE IF NOT EXISTS chat_thread_locks (
+    thread_id VARCHAR(64) PRIMARY KEY,
+    locked_by VARCHAR(64) NOT NULL,
+    locked_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS chat_attachments (
+    attachment_id VARCHAR(64) PRIMARY KEY,
+    message_id VARCHAR(64) NOT NULL REFERENCES chat_messages (message_id),
+    user_id VARCHAR(64) NOT NULL,
+    filename TEXT NOT NULL,
+    file_size BIGINT NOT NULL,
+    mime_type TEXT NOT NULL DEFAULT '',
+    url TEXT NOT NULL,
+    scan_result TEXT NOT NULL DEFAULT 'CLEAN',
+    scanned_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_chat_attachments_message_id
+    ON chat_attachments (message_id);
+
+CREATE TABLE IF NOT EXISTS chat_idempotency (
+    key TEXT PRIMARY KEY,
+    request_hash TEXT NOT NULL,
+    payload BYTEA NOT NULL DEFAULT ''::bytea,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_chat_idempotency_expires_at
+    ON chat_idempotency (expires_at);
@@ -0,0 +1,19 @@
+-- M46-Chat-Service outbox for chat.message.* events.
+-- Rows are written alongside message writes and relayed to the event bus, so
+-- consumers such as community-health catch up on any backlog at startup.
+-- seq breaks created_at ties so relay order matches append order.
+
+CREATE TABLE IF NOT EXISTS chat_outbox (
+    outbox_id VARCHAR(64) PRIMARY KEY,
+    seq BIGSERIAL NOT NULL,
+    event_type VARCHAR(120) NOT NULL,
+    partition_key VARCHAR(120) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    published_at TIMESTAMPTZ NULL,
+    CONSTRAINT chat_outbox_status_check CHECK (status IN ('pending', 'published'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_chat_outbox_status_created
+    ON chat_outbox (status, created_at ASC, seq ASC);
@@ -0,0 +1,13 @@
+-- M49-Community-Health weekly reports.
+-- One report per server and week; week_start_date is the Monday (UTC) as
+-- YYYY-MM-DD so it sorts lexically for history paging.
+
+CREATE TABLE IF NOT EXISTS community_health_weekly_reports (
+    report_id VARCHAR(64) PRIMARY KEY,
+    server_id VARCHAR(64) NOT NULL,
+    week_start_date VARCHAR(10) NOT NULL,
+    report JSONB NOT NULL,
+    generated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS ux_community_health_weekly_reports_server_week
+    ON community_health_weekly_reports (server_id, week_start_date);
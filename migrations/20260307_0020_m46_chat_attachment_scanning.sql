@@ -0,0 +1,30 @@
+-- M46-Chat-Service attachment malware scanning.
+-- Attachments now start pending_scan and are served only once clean. Content
+-- is kept in chat_attachment_contents so the scan worker can stream it to the
+-- scanner; infected attachments keep their content but are quarantined.
+
+ALTER TABLE chat_attachments
+    ALTER COLUMN scan_result SET DEFAULT 'pending_scan',
+    ALTER COLUMN scanned_at DROP NOT NULL,
+    ALTER COLUMN scanned_at DROP DEFAULT,
+    ADD COLUMN IF NOT EXISTS scan_signature TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS scan_attempts INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS quarantined_at TIMESTAMPTZ NULL,
+    ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+-- Rows written before scanning existed were stamped CLEAN without a scan.
+UPDATE chat_attachments SET scan_result = 'clean' WHERE scan_result = 'CLEAN';
+
+ALTER TABLE chat_attachments
+    DROP CONSTRAINT IF EXISTS chat_attachments_scan_result_check,
+    ADD CONSTRAINT chat_attachments_scan_result_check
+        CHECK (scan_result IN ('pending_scan', 'clean', 'infected', 'scan_error'));
+
+CREATE INDEX IF NOT EXISTS idx_chat_attachments_pending_scan
+    ON chat_attachments (created_at ASC, attachment_id ASC)
+    WHERE scan_result = 'pending_scan';
+
+CREATE TABLE IF NOT EXISTS chat_attachment_contents (
+    attachment_id VARCHAR(64) PRIMARY KEY REFERENCES chat_attachments (attachment_id) ON DELETE CASCADE,
+    content BYTEA NOT NULL
+);
@@ -0,0 +1,21 @@
+-- M46-Chat-Service server-side link unfurling.
+-- Messages with links are flagged embeds_pending until the unfurl worker has
+-- replaced their embeds with fetched previews; previews are cached per URL,
+-- including failures, until expires_at.
+
+ALTER TABLE chat_messages
+    ADD COLUMN IF NOT EXISTS embeds_pending BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_chat_messages_embeds_pending
+    ON chat_messages (created_at ASC, message_id ASC)
+    WHERE embeds_pending AND deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS chat_link_previews (
+    url TEXT PRIMARY KEY,
+    title TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    image_url TEXT NOT NULL DEFAULT '',
+    failed BOOLEAN NOT NULL DEFAULT FALSE,
+    fetched_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,114 @@
+-- M35-Moderation-Service production persistence.
+-- Queue items carry the review lease and SLA state used for assignment and
+-- escalation; decisions are append only. Appeals reference the decision they
+-- contest, one appeal per decision, and every appeal transition is written to
+-- moderation_audit_log in the same transaction.
+
+CREATE TABLE IF NOT EXISTS moderation_queue (
+    submission_id VARCHAR(64) PRIMARY KEY,
+    campaign_id VARCHAR(64) NOT NULL,
+    creator_id VARCHAR(64) NOT NULL,
+    status VARCHAR(24) NOT NULL DEFAULT 'pending',
+    risk_score DOUBLE PRECISION NOT NULL DEFAULT 0,
+    report_count INTEGER NOT NULL DEFAULT 0,
+    queued_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    assigned_moderator_id VARCHAR(64) NOT NULL DEFAULT '',
+    category VARCHAR(64) NOT NULL DEFAULT '',
+    severity VARCHAR(16) NOT NULL DEFAULT 'medium',
+    sla_due_at TIMESTAMPTZ NOT NULL,
+    claimed_at TIMESTAMPTZ NULL,
+    lease_expires_at TIMESTAMPTZ NULL,
+    escalation_level INTEGER NOT NULL DEFAULT 0,
+    escalated_at TIMESTAMPTZ NULL,
+    senior_required BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT moderation_queue_status_check
+        CHECK (status IN ('pending', 'approved', 'rejected', 'flagged', 'escalated', 'disputed')),
+    CONSTRAINT moderation_queue_severity_check
+        CHECK (severity IN ('low', 'medium', 'high', 'critical'))
+);
+CREATE INDEX IF NOT EXISTS idx_moderation_queue_open_sla
+    ON moderation_queue (sla_due_at ASC, submission_id ASC)
+    WHERE status IN ('pending', 'flagged', 'escalated');
+CREATE INDEX IF NOT EXISTS idx_moderation_queue_assigned
+    ON moderation_queue (assigned_moderator_id, status);
+
+CREATE TABLE IF NOT EXISTS moderation_decisions (
+    decision_id VARCHAR(64) PRIMARY KEY,
+    submission_id VARCHAR(64) NOT NULL REFERENCES moderation_queue (submission_id),
+    campaign_id VARCHAR(64) NOT NULL,
+    moderator_id VARCHAR(64) NOT NULL,
+    action VARCHAR(16) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    notes TEXT NOT NULL DEFAULT '',
+    severity VARCHAR(16) NOT NULL DEFAULT '',
+    queue_status VARCHAR(24) NOT NULL,
+    claimed_at TIMESTAMPTZ NULL,
+    sla_due_at TIMESTAMPTZ NULL,
+    sla_breached BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT moderation_decisions_action_check CHECK (action IN ('approved', 'rejected', 'flagged'))
+);
+CREATE INDEX IF NOT EXISTS idx_moderation_decisions_created_at
+    ON moderation_decisions (created_at ASC, decision_id ASC);
+CREATE INDEX IF NOT EXISTS idx_moderation_decisions_submission
+    ON moderation_decisions (submission_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_moderation_decisions_moderator
+    ON moderation_decisions (moderator_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS moderation_moderators (
+    moderator_id VARCHAR(64) PRIMARY KEY,
+    skills TEXT[] NOT NULL DEFAULT '{}',
+    senior BOOLEAN NOT NULL DEFAULT FALSE,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    max_active_items INTEGER NOT NULL DEFAULT 0,
+    last_assigned_at TIMESTAMPTZ NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS moderation_appeals (
+    appeal_id VARCHAR(64) PRIMARY KEY,
+    decision_id VARCHAR(64) NOT NULL REFERENCES moderation_decisions (decision_id),
+    submission_id VARCHAR(64) NOT NULL,
+    campaign_id VARCHAR(64) NOT NULL,
+    creator_id VARCHAR(64) NOT NULL,
+    original_moderator_id VARCHAR(64) NOT NULL,
+    original_action VARCHAR(16) NOT NULL,
+    original_reason TEXT NOT NULL DEFAULT '',
+    reason TEXT NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'open',
+    assigned_moderator_id VARCHAR(64) NOT NULL DEFAULT '',
+    resolved_by_id VARCHAR(64) NOT NULL DEFAULT '',
+    resolution_notes TEXT NOT NULL DEFAULT '',
+    resolution_decision_id VARCHAR(64) NULL REFERENCES moderation_decisions (decision_id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMPTZ NULL,
+    CONSTRAINT moderation_appeals_status_check CHECK (status IN ('open', 'upheld', 'reversed'))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS ux_moderation_appeals_decision
+    ON moderation_appeals (decision_id);
+CREATE INDEX IF NOT EXISTS idx_moderation_appeals_status
+    ON moderation_appeals (status, created_at ASC);
+
+CREATE TABLE IF NOT EXISTS moderation_audit_log (
+    entry_id VARCHAR(64) PRIMARY KEY,
+    appeal_id VARCHAR(64) NOT NULL REFERENCES moderation_appeals (appeal_id),
+    action VARCHAR(32) NOT NULL,
+    actor_id VARCHAR(64) NOT NULL,
+    from_status VARCHAR(16) NOT NULL DEFAULT '',
+    to_status VARCHAR(16) NOT NULL DEFAULT '',
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_moderation_audit_log_appeal
+    ON moderation_audit_log (appeal_id, created_at ASC);
+
+CREATE TABLE IF NOT EXISTS moderation_idempotency (
+    key TEXT PRIMARY KEY,
+    request_hash TEXT NOT NULL,
+    payload BYTEA NOT NULL DEFAULT ''::bytea,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_moderation_idempotency_expires_at
+    ON moderation_idempotency (expires_at);
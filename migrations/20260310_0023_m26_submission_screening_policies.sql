@@ -0,0 +1,12 @@
+-- M26-Submission-Service pre-moderation rules.
+-- One rule set per campaign, evaluated when a submission is created. Every
+-- evaluated rule is recorded in submission_flags with a pre_moderation_*
+-- flag type; reputation tiers are read from the M48 projection.
+
+CREATE TABLE IF NOT EXISTS submission_screening_policies (
+    campaign_id UUID PRIMARY KEY,
+    rules JSONB NOT NULL DEFAULT '[]'::jsonb,
+    auto_approve BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_by_user_id VARCHAR(64) NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,55 @@
+-- M37-Abuse-Prevention-Service server-side login risk engine.
+-- Login attempts feed sliding-window failure counters per user, IP and device;
+-- known devices and proof-of-work challenges are owned here, and automatic
+-- lockouts reuse abuse_lockout_history with an expiry.
+
+ALTER TABLE abuse_lockout_history
+    ADD COLUMN IF NOT EXISTS expires_at TIMESTAMPTZ NULL;
+
+CREATE TABLE IF NOT EXISTS abuse_login_attempts (
+    attempt_id VARCHAR(64) PRIMARY KEY,
+    user_id VARCHAR(64) NOT NULL,
+    ip_address TEXT NOT NULL,
+    device_fingerprint TEXT NOT NULL DEFAULT '',
+    succeeded BOOLEAN NOT NULL,
+    risk_score NUMERIC(4, 2) NOT NULL,
+    risk_tier TEXT NOT NULL,
+    decision TEXT NOT NULL,
+    occurred_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT abuse_login_attempts_decision_check CHECK (decision IN ('allow', 'challenge', 'deny', 'block'))
+);
+CREATE INDEX IF NOT EXISTS idx_abuse_login_attempts_user_occurred_at
+    ON abuse_login_attempts (user_id, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_abuse_login_attempts_ip_occurred_at
+    ON abuse_login_attempts (ip_address, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_abuse_login_attempts_device_occurred_at
+    ON abuse_login_attempts (device_fingerprint, occurred_at DESC)
+    WHERE device_fingerprint <> '';
+CREATE INDEX IF NOT EXISTS idx_abuse_login_attempts_occurred_at
+    ON abuse_login_attempts (occurred_at DESC);
+
+CREATE TABLE IF NOT EXISTS abuse_known_devices (
+    user_id VARCHAR(64) NOT NULL,
+    device_fingerprint TEXT NOT NULL,
+    first_seen_at TIMESTAMPTZ NOT NULL,
+    last_seen_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, device_fingerprint)
+);
+
+CREATE TABLE IF NOT EXISTS abuse_login_challenges (
+    challenge_id VARCHAR(64) PRIMARY KEY,
+    user_id VARCHAR(64) NOT NULL,
+    device_fingerprint TEXT NOT NULL DEFAULT '',
+    ip_address TEXT NOT NULL,
+    nonce TEXT NOT NULL,
+    difficulty_bits INTEGER NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMPTZ NULL,
+    CONSTRAINT abuse_login_challenges_status_check CHECK (status IN ('pending', 'passed', 'failed', 'expired'))
+);
+CREATE INDEX IF NOT EXISTS idx_abuse_login_challenges_user_created_at
+    ON abuse_login_challenges (user_id, created_at DESC);
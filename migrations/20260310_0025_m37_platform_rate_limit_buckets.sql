@@ -0,0 +1,11 @@
+-- Platform HTTP rate limiting shared across API instances.
+-- One GCRA theoretical arrival time per policy/key bucket. Rows past
+-- expires_at are back to a full burst and are swept by the API process.
+
+CREATE TABLE IF NOT EXISTS rate_limit_buckets (
+    bucket_key TEXT PRIMARY KEY,
+    tat TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_rate_limit_buckets_expires_at
+    ON rate_limit_buckets (expires_at);
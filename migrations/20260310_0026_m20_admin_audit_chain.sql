@@ -0,0 +1,52 @@
+-- M20-Super-Admin-Dashboard tamper-evident admin audit log.
+-- Each entry carries the previous entry's hash and an HMAC over its canonical
+-- content; anchors periodically record the chain head in a separate table so
+-- a rewritten or truncated tail is detected. Both tables are append-only.
+
+CREATE TABLE IF NOT EXISTS super_admin_audit_log (
+    sequence BIGINT PRIMARY KEY,
+    audit_id VARCHAR(64) NOT NULL UNIQUE,
+    admin_id VARCHAR(64) NOT NULL,
+    action_type TEXT NOT NULL,
+    target_resource_type TEXT NOT NULL,
+    target_resource_id TEXT NOT NULL,
+    old_value JSONB NOT NULL DEFAULT '{}'::jsonb,
+    new_value JSONB NOT NULL DEFAULT '{}'::jsonb,
+    reason TEXT NOT NULL,
+    performed_at TIMESTAMPTZ NOT NULL,
+    ip_address TEXT NOT NULL DEFAULT '',
+    prev_hash CHAR(64) NOT NULL,
+    entry_hash CHAR(64) NOT NULL UNIQUE,
+    signature TEXT NOT NULL,
+    CONSTRAINT super_admin_audit_log_sequence_check CHECK (sequence > 0)
+);
+CREATE INDEX IF NOT EXISTS idx_super_admin_audit_log_admin
+    ON super_admin_audit_log (admin_id, sequence DESC);
+CREATE INDEX IF NOT EXISTS idx_super_admin_audit_log_action
+    ON super_admin_audit_log (action_type, sequence DESC);
+
+CREATE TABLE IF NOT EXISTS super_admin_audit_anchors (
+    anchor_id VARCHAR(64) PRIMARY KEY,
+    sequence BIGINT NOT NULL,
+    entry_hash CHAR(64) NOT NULL,
+    signature TEXT NOT NULL,
+    anchored_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_super_admin_audit_anchors_sequence
+    ON super_admin_audit_anchors (sequence DESC, anchored_at DESC);
+
+CREATE OR REPLACE FUNCTION super_admin_audit_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION '% is append-only', TG_TABLE_NAME;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS super_admin_audit_log_append_only ON super_admin_audit_log;
+CREATE TRIGGER super_admin_audit_log_append_only
+    BEFORE UPDATE OR DELETE ON super_admin_audit_log
+    FOR EACH ROW EXECUTE FUNCTION super_admin_audit_append_only();
+
+DROP TRIGGER IF EXISTS super_admin_audit_anchors_append_only ON super_admin_audit_anchors;
+CREATE TRIGGER super_admin_audit_anchors_append_only
+    BEFORE UPDATE OR DELETE ON super_admin_audit_anchors
+    FOR EACH ROW EXECUTE FUNCTION super_admin_audit_append_only();
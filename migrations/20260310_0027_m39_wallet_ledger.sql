@@ -0,0 +1,99 @@
+-- Double-entry wallet ledger.
+-- Every journal entry carries at least two postings whose debits equal their
+-- credits; a deferred constraint trigger rejects any transaction that leaves
+-- an entry unbalanced. Entries and postings are append-only. Account rows
+-- cache the running balance; reconciliation compares it with the postings and
+-- records drifts in ledger_balance_drifts.
+
+CREATE TABLE IF NOT EXISTS ledger_accounts (
+    account_id VARCHAR(128) PRIMARY KEY,
+    account_type VARCHAR(32) NOT NULL,
+    owner_id VARCHAR(64) NOT NULL DEFAULT '',
+    cached_balance_cents BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT ledger_accounts_type_check CHECK (
+        account_type IN ('user_wallet', 'campaign_payouts', 'platform_fees', 'admin_adjustments', 'opening_balances')
+    )
+);
+
+CREATE TABLE IF NOT EXISTS ledger_journal_entries (
+    entry_id VARCHAR(64) PRIMARY KEY,
+    idempotency_key VARCHAR(255) NOT NULL UNIQUE,
+    request_hash CHAR(64) NOT NULL,
+    entry_type VARCHAR(32) NOT NULL,
+    reference_type VARCHAR(32) NOT NULL DEFAULT '',
+    reference_id VARCHAR(128) NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    posted_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_ledger_journal_entries_reference
+    ON ledger_journal_entries (reference_type, reference_id);
+
+CREATE TABLE IF NOT EXISTS ledger_postings (
+    entry_id VARCHAR(64) NOT NULL REFERENCES ledger_journal_entries (entry_id),
+    line_no INT NOT NULL,
+    account_id VARCHAR(128) NOT NULL REFERENCES ledger_accounts (account_id),
+    direction VARCHAR(8) NOT NULL,
+    amount_cents BIGINT NOT NULL,
+    balance_after_cents BIGINT NOT NULL,
+    posted_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (entry_id, line_no),
+    CONSTRAINT ledger_postings_direction_check CHECK (direction IN ('debit', 'credit')),
+    CONSTRAINT ledger_postings_amount_check CHECK (amount_cents > 0)
+);
+CREATE INDEX IF NOT EXISTS idx_ledger_postings_account
+    ON ledger_postings (account_id, posted_at DESC);
+
+CREATE TABLE IF NOT EXISTS ledger_balance_drifts (
+    drift_id VARCHAR(64) PRIMARY KEY,
+    account_id VARCHAR(128) NOT NULL REFERENCES ledger_accounts (account_id),
+    cached_balance_cents BIGINT NOT NULL,
+    journal_balance_cents BIGINT NOT NULL,
+    drift_cents BIGINT NOT NULL,
+    detected_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_ledger_balance_drifts_detected
+    ON ledger_balance_drifts (detected_at DESC);
+
+CREATE OR REPLACE FUNCTION ledger_assert_entry_balanced() RETURNS trigger AS $$
+DECLARE
+    debits BIGINT;
+    credits BIGINT;
+    lines INT;
+BEGIN
+    SELECT
+        COALESCE(SUM(amount_cents) FILTER (WHERE direction = 'debit'), 0),
+        COALESCE(SUM(amount_cents) FILTER (WHERE direction = 'credit'), 0),
+        COUNT(*)
+    INTO debits, credits, lines
+    FROM ledger_postings
+    WHERE entry_id = NEW.entry_id;
+    IF lines < 2 OR debits <> credits THEN
+        RAISE EXCEPTION 'journal entry % is unbalanced: debits % credits %', NEW.entry_id, debits, credits;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS ledger_postings_balanced ON ledger_postings;
+CREATE CONSTRAINT TRIGGER ledger_postings_balanced
+    AFTER INSERT ON ledger_postings
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION ledger_assert_entry_balanced();
+
+CREATE OR REPLACE FUNCTION ledger_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION '% is append-only', TG_TABLE_NAME;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS ledger_journal_entries_append_only ON ledger_journal_entries;
+CREATE TRIGGER ledger_journal_entries_append_only
+    BEFORE UPDATE OR DELETE ON ledger_journal_entries
+    FOR EACH ROW EXECUTE FUNCTION ledger_append_only();
+
+DROP TRIGGER IF EXISTS ledger_postings_append_only ON ledger_postings;
+CREATE TRIGGER ledger_postings_append_only
+    BEFORE UPDATE OR DELETE ON ledger_postings
+    FOR EACH ROW EXECUTE FUNCTION ledger_append_only();
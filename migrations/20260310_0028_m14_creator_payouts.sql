@@ -0,0 +1,43 @@
+-- Creator payout batching and settlement.
+-- payout_earnings holds each view-locked submission's net earnings once its
+-- hold period has passed; payout_id stays NULL until the earning is batched
+-- into a creator payout. payouts carry their settlement state and retry
+-- schedule; the worker settles rows whose next_attempt_at has passed.
+
+CREATE TABLE IF NOT EXISTS payouts (
+    payout_id VARCHAR(64) PRIMARY KEY,
+    creator_id VARCHAR(64) NOT NULL,
+    amount_cents BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ,
+    last_error TEXT NOT NULL DEFAULT '',
+    provider_reference VARCHAR(128) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    paid_at TIMESTAMPTZ,
+    CONSTRAINT payouts_status_check CHECK (status IN ('pending', 'held', 'failed', 'paid')),
+    CONSTRAINT payouts_amount_check CHECK (amount_cents > 0)
+);
+CREATE INDEX IF NOT EXISTS idx_payouts_due
+    ON payouts (next_attempt_at)
+    WHERE status <> 'paid' AND next_attempt_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_payouts_creator
+    ON payouts (creator_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS payout_earnings (
+    submission_id VARCHAR(64) PRIMARY KEY,
+    creator_id VARCHAR(64) NOT NULL,
+    campaign_id VARCHAR(64) NOT NULL,
+    amount_cents BIGINT NOT NULL,
+    locked_at TIMESTAMPTZ NOT NULL,
+    payout_id VARCHAR(64) REFERENCES payouts (payout_id),
+    recorded_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT payout_earnings_amount_check CHECK (amount_cents >= 0)
+);
+CREATE INDEX IF NOT EXISTS idx_payout_earnings_unbatched
+    ON payout_earnings (creator_id, locked_at)
+    WHERE payout_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_payout_earnings_payout
+    ON payout_earnings (payout_id);
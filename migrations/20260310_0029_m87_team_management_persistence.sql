@@ -0,0 +1,112 @@
+-- M87-Team-Management-Service production persistence.
+-- A team has one active owner and a user holds at most one active
+-- membership per team; both are enforced with partial unique indexes, the
+-- membership one covering role so authorisation checks are index-only.
+-- Invites store only the SHA-256 hash of their token, and a team has at most
+-- one pending invite per email. team_user_directory is the projection of
+-- M01 accounts (email, MFA), written only by M01's directory sync hook.
+
+CREATE TABLE IF NOT EXISTS team_user_directory (
+    user_id VARCHAR(64) PRIMARY KEY,
+    email TEXT NOT NULL,
+    mfa_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_team_user_directory_email
+    ON team_user_directory (lower(email));
+
+CREATE TABLE IF NOT EXISTS teams (
+    team_id VARCHAR(64) PRIMARY KEY,
+    name VARCHAR(120) NOT NULL,
+    org_id VARCHAR(64) NOT NULL,
+    storefront_id VARCHAR(64) NOT NULL DEFAULT '',
+    owner_user_id VARCHAR(64) NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'active',
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_teams_org_id ON teams (org_id);
+
+CREATE TABLE IF NOT EXISTS team_members (
+    member_id VARCHAR(64) PRIMARY KEY,
+    team_id VARCHAR(64) NOT NULL REFERENCES teams (team_id),
+    user_id VARCHAR(64) NOT NULL,
+    role VARCHAR(16) NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'active',
+    last_active_at TIMESTAMPTZ,
+    joined_at TIMESTAMPTZ NOT NULL,
+    removed_at TIMESTAMPTZ,
+    CONSTRAINT team_members_role_check
+        CHECK (role IN ('Owner', 'Manager', 'Editor', 'Support', 'Viewer')),
+    CONSTRAINT team_members_status_check CHECK (status IN ('active', 'removed'))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_team_members_active_user
+    ON team_members (team_id, user_id) INCLUDE (role, member_id)
+    WHERE status = 'active';
+CREATE UNIQUE INDEX IF NOT EXISTS idx_team_members_one_owner
+    ON team_members (team_id)
+    WHERE role = 'Owner' AND status = 'active';
+CREATE INDEX IF NOT EXISTS idx_team_members_team_joined
+    ON team_members (team_id, joined_at ASC);
+
+CREATE TABLE IF NOT EXISTS team_invites (
+    invite_id VARCHAR(64) PRIMARY KEY,
+    team_id VARCHAR(64) NOT NULL REFERENCES teams (team_id),
+    email TEXT NOT NULL,
+    role VARCHAR(16) NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    expires_at TIMESTAMPTZ NOT NULL,
+    sent_count INTEGER NOT NULL DEFAULT 1,
+    created_by VARCHAR(64) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    accepted_by VARCHAR(64) NOT NULL DEFAULT '',
+    accepted_at TIMESTAMPTZ,
+    revoked_by VARCHAR(64) NOT NULL DEFAULT '',
+    revoked_at TIMESTAMPTZ,
+    CONSTRAINT team_invites_role_check
+        CHECK (role IN ('Manager', 'Editor', 'Support', 'Viewer')),
+    CONSTRAINT team_invites_status_check
+        CHECK (status IN ('pending', 'accepted', 'expired', 'revoked'))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_team_invites_one_pending_email
+    ON team_invites (team_id, email)
+    WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_team_invites_pending_expiry
+    ON team_invites (expires_at ASC)
+    WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS team_audit_logs (
+    audit_id VARCHAR(64) PRIMARY KEY,
+    team_id VARCHAR(64) NOT NULL REFERENCES teams (team_id),
+    actor_user_id VARCHAR(64) NOT NULL,
+    action VARCHAR(64) NOT NULL,
+    target_type VARCHAR(32) NOT NULL,
+    target_id VARCHAR(64) NOT NULL DEFAULT '',
+    metadata JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_team_audit_logs_team_created
+    ON team_audit_logs (team_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS team_member_exports (
+    export_job_id VARCHAR(64) PRIMARY KEY,
+    team_id VARCHAR(64) NOT NULL REFERENCES teams (team_id),
+    requested_by VARCHAR(64) NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'queued',
+    created_at TIMESTAMPTZ NOT NULL,
+    estimated_completion_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_team_member_exports_team
+    ON team_member_exports (team_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS team_idempotency (
+    key TEXT PRIMARY KEY,
+    request_hash TEXT NOT NULL,
+    payload BYTEA NOT NULL DEFAULT ''::bytea,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_team_idempotency_expires_at
+    ON team_idempotency (expires_at);
@@ -0,0 +1,43 @@
+-- Shared asynchronous export jobs (internal/platform/export).
+-- Workers claim queued rows, or running rows whose lease ran out, with
+-- FOR UPDATE SKIP LOCKED. Completed rows keep the storage key of their file
+-- until expires_at, when the sweeper deletes the file and marks the row
+-- expired. team_member_exports is superseded by data_export_jobs rows of
+-- kind team_members. The name avoids the M24 clipping export_jobs table.
+
+CREATE TABLE IF NOT EXISTS data_export_jobs (
+    export_id VARCHAR(64) PRIMARY KEY,
+    kind VARCHAR(64) NOT NULL,
+    requested_by VARCHAR(64) NOT NULL,
+    params JSONB NOT NULL DEFAULT '{}'::jsonb,
+    format VARCHAR(8) NOT NULL,
+    columns JSONB NOT NULL DEFAULT '[]'::jsonb,
+    row_limit INTEGER NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'queued',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    row_count INTEGER NOT NULL DEFAULT 0,
+    truncated BOOLEAN NOT NULL DEFAULT FALSE,
+    file_key TEXT NOT NULL DEFAULT '',
+    content_type TEXT NOT NULL DEFAULT '',
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    lease_until TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL,
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ,
+    CONSTRAINT data_export_jobs_format_check CHECK (format IN ('csv', 'xlsx')),
+    CONSTRAINT data_export_jobs_status_check
+        CHECK (status IN ('queued', 'running', 'completed', 'failed', 'expired')),
+    CONSTRAINT data_export_jobs_row_limit_check CHECK (row_limit > 0)
+);
+CREATE INDEX IF NOT EXISTS idx_data_export_jobs_claimable
+    ON data_export_jobs (created_at ASC)
+    WHERE status IN ('queued', 'running');
+CREATE INDEX IF NOT EXISTS idx_data_export_jobs_file_expiry
+    ON data_export_jobs (expires_at ASC)
+    WHERE status = 'completed';
+CREATE INDEX IF NOT EXISTS idx_data_export_jobs_requested_by
+    ON data_export_jobs (requested_by, created_at DESC);
+
+DROP TABLE IF EXISTS team_member_exports;
@@ -0,0 +1,75 @@
+-- M87-Team-Management-Service per-team SSO and SCIM.
+-- A connection links one team to its IdP over OIDC or SAML. Only the
+-- SHA-256 hash of the SCIM bearer token is stored. Login states are the
+-- hashed, single-use state values of SP-initiated logins still waiting for
+-- the IdP; expired rows are deleted as new logins start. An identity links
+-- an IdP user (subject from logins, externalId from SCIM) to an M01 user,
+-- at most once per connection.
+
+CREATE TABLE IF NOT EXISTS team_sso_connections (
+    connection_id VARCHAR(64) PRIMARY KEY,
+    team_id VARCHAR(64) NOT NULL REFERENCES teams (team_id),
+    protocol VARCHAR(8) NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'active',
+    allowed_domains JSONB NOT NULL DEFAULT '[]'::jsonb,
+    role_mappings JSONB NOT NULL DEFAULT '[]'::jsonb,
+    default_role VARCHAR(16) NOT NULL DEFAULT '',
+    oidc_issuer TEXT NOT NULL DEFAULT '',
+    oidc_client_id TEXT NOT NULL DEFAULT '',
+    oidc_client_secret TEXT NOT NULL DEFAULT '',
+    oidc_authorization_url TEXT NOT NULL DEFAULT '',
+    oidc_token_url TEXT NOT NULL DEFAULT '',
+    oidc_jwks_url TEXT NOT NULL DEFAULT '',
+    oidc_scopes JSONB NOT NULL DEFAULT '[]'::jsonb,
+    oidc_groups_claim TEXT NOT NULL DEFAULT '',
+    saml_idp_entity_id TEXT NOT NULL DEFAULT '',
+    saml_sso_url TEXT NOT NULL DEFAULT '',
+    saml_certificate TEXT NOT NULL DEFAULT '',
+    saml_email_attribute TEXT NOT NULL DEFAULT '',
+    saml_groups_attribute TEXT NOT NULL DEFAULT '',
+    scim_token_hash CHAR(64) NOT NULL,
+    created_by VARCHAR(64) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT team_sso_connections_protocol_check CHECK (protocol IN ('oidc', 'saml')),
+    CONSTRAINT team_sso_connections_status_check CHECK (status IN ('active', 'disabled')),
+    CONSTRAINT team_sso_connections_default_role_check
+        CHECK (default_role IN ('', 'Manager', 'Editor', 'Support', 'Viewer'))
+);
+CREATE INDEX IF NOT EXISTS idx_team_sso_connections_team
+    ON team_sso_connections (team_id, created_at ASC);
+
+CREATE TABLE IF NOT EXISTS team_sso_login_states (
+    state_hash CHAR(64) PRIMARY KEY,
+    connection_id VARCHAR(64) NOT NULL REFERENCES team_sso_connections (connection_id),
+    nonce TEXT NOT NULL,
+    code_verifier TEXT NOT NULL,
+    request_id TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_team_sso_login_states_expiry
+    ON team_sso_login_states (expires_at ASC);
+
+CREATE TABLE IF NOT EXISTS team_sso_identities (
+    identity_id VARCHAR(64) PRIMARY KEY,
+    connection_id VARCHAR(64) NOT NULL REFERENCES team_sso_connections (connection_id),
+    team_id VARCHAR(64) NOT NULL REFERENCES teams (team_id),
+    user_id VARCHAR(64) NOT NULL,
+    email TEXT NOT NULL,
+    subject TEXT NOT NULL DEFAULT '',
+    external_id TEXT NOT NULL DEFAULT '',
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_team_sso_identities_user
+    ON team_sso_identities (connection_id, user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_team_sso_identities_subject
+    ON team_sso_identities (connection_id, subject)
+    WHERE subject <> '';
+CREATE UNIQUE INDEX IF NOT EXISTS idx_team_sso_identities_external_id
+    ON team_sso_identities (connection_id, external_id)
+    WHERE external_id <> '';
+CREATE INDEX IF NOT EXISTS idx_team_sso_identities_connection_created
+    ON team_sso_identities (connection_id, created_at ASC, identity_id ASC);
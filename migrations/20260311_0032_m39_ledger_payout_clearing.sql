@@ -0,0 +1,8 @@
+-- Payout clearing account.
+-- Settled creator payouts move from the creator's wallet to
+-- platform:payout_clearing, so wallet balances track money still owed.
+
+ALTER TABLE ledger_accounts DROP CONSTRAINT IF EXISTS ledger_accounts_type_check;
+ALTER TABLE ledger_accounts ADD CONSTRAINT ledger_accounts_type_check CHECK (
+    account_type IN ('user_wallet', 'campaign_payouts', 'platform_fees', 'admin_adjustments', 'opening_balances', 'payout_clearing')
+);
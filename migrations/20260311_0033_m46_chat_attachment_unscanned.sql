@@ -0,0 +1,8 @@
+-- M46-Chat-Service unscanned attachments.
+-- The development no-op scanner now records attachments as unscanned rather
+-- than clean, so a clean result always means a real scanner inspected them.
+
+ALTER TABLE chat_attachments
+    DROP CONSTRAINT IF EXISTS chat_attachments_scan_result_check,
+    ADD CONSTRAINT chat_attachments_scan_result_check
+        CHECK (scan_result IN ('pending_scan', 'clean', 'unscanned', 'infected', 'scan_error'));
@@ -0,0 +1,170 @@
+-- M22-Onboarding-Service production persistence.
+-- Flows are versioned; a flow has at most one published version and a role
+-- at most one active flow. Progress pins the user to the flow version they
+-- started on and keeps per-step status as JSONB. Registration and activity
+-- events are deduplicated by event ID, and a role runs at most one
+-- experiment at a time. The default flows match the in-memory seed.
+
+CREATE TABLE IF NOT EXISTS onboarding_flows (
+    flow_id VARCHAR(64) PRIMARY KEY,
+    role VARCHAR(16) NOT NULL,
+    name VARCHAR(120) NOT NULL,
+    archived BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT onboarding_flows_role_check CHECK (role IN ('brand', 'editor', 'influencer'))
+);
+
+CREATE TABLE IF NOT EXISTS onboarding_flow_versions (
+    flow_id VARCHAR(64) NOT NULL REFERENCES onboarding_flows (flow_id),
+    version INTEGER NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    steps JSONB NOT NULL DEFAULT '[]'::jsonb,
+    created_by VARCHAR(64) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    published_by VARCHAR(64) NOT NULL DEFAULT '',
+    published_at TIMESTAMPTZ,
+    PRIMARY KEY (flow_id, version),
+    CONSTRAINT onboarding_flow_versions_status_check
+        CHECK (status IN ('draft', 'published', 'superseded'))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_onboarding_flow_versions_one_published
+    ON onboarding_flow_versions (flow_id)
+    WHERE status = 'published';
+
+CREATE TABLE IF NOT EXISTS onboarding_active_flows (
+    role VARCHAR(16) PRIMARY KEY,
+    flow_id VARCHAR(64) NOT NULL REFERENCES onboarding_flows (flow_id),
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS onboarding_progress (
+    user_id VARCHAR(64) PRIMARY KEY,
+    email TEXT NOT NULL DEFAULT '',
+    role VARCHAR(16) NOT NULL,
+    flow_id VARCHAR(64) NOT NULL,
+    flow_version INTEGER NOT NULL,
+    experiment_key VARCHAR(64) NOT NULL DEFAULT '',
+    variant_key VARCHAR(64) NOT NULL DEFAULT '',
+    status VARCHAR(16) NOT NULL,
+    step_status JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    completed_at TIMESTAMPTZ,
+    reminder_scheduled_at TIMESTAMPTZ,
+    reminders_sent INTEGER NOT NULL DEFAULT 0,
+    last_reminder_at TIMESTAMPTZ,
+    reminders_stopped BOOLEAN NOT NULL DEFAULT FALSE,
+    FOREIGN KEY (flow_id, flow_version) REFERENCES onboarding_flow_versions (flow_id, version),
+    CONSTRAINT onboarding_progress_status_check
+        CHECK (status IN ('in_progress', 'skipped', 'completed'))
+);
+CREATE INDEX IF NOT EXISTS idx_onboarding_progress_skipped_reminders
+    ON onboarding_progress (reminder_scheduled_at ASC)
+    WHERE status = 'skipped' AND NOT reminders_stopped;
+CREATE INDEX IF NOT EXISTS idx_onboarding_progress_stalled_reminders
+    ON onboarding_progress (updated_at ASC)
+    WHERE status = 'in_progress' AND NOT reminders_stopped;
+
+CREATE TABLE IF NOT EXISTS onboarding_registration_dedup (
+    event_id VARCHAR(64) PRIMARY KEY,
+    request_hash CHAR(64) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS onboarding_activity_dedup (
+    event_id VARCHAR(64) PRIMARY KEY,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_onboarding_activity_dedup_expires_at
+    ON onboarding_activity_dedup (expires_at);
+
+CREATE TABLE IF NOT EXISTS onboarding_experiments (
+    experiment_key VARCHAR(64) PRIMARY KEY,
+    role VARCHAR(16) NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    variants JSONB NOT NULL DEFAULT '[]'::jsonb,
+    created_by VARCHAR(64) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    stopped_at TIMESTAMPTZ,
+    CONSTRAINT onboarding_experiments_status_check CHECK (status IN ('running', 'stopped'))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_onboarding_experiments_one_running
+    ON onboarding_experiments (role)
+    WHERE status = 'running';
+
+CREATE TABLE IF NOT EXISTS onboarding_variant_assignments (
+    user_id VARCHAR(64) PRIMARY KEY,
+    experiment_key VARCHAR(64) NOT NULL REFERENCES onboarding_experiments (experiment_key),
+    variant_key VARCHAR(64) NOT NULL,
+    flow_id VARCHAR(64) NOT NULL DEFAULT '',
+    assigned_at TIMESTAMPTZ NOT NULL,
+    exposed_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_onboarding_variant_assignments_experiment
+    ON onboarding_variant_assignments (experiment_key, user_id);
+
+CREATE TABLE IF NOT EXISTS onboarding_reminder_preferences (
+    user_id VARCHAR(64) PRIMARY KEY,
+    email_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    in_app_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    unsubscribed BOOLEAN NOT NULL DEFAULT FALSE,
+    quiet_hours_start VARCHAR(5) NOT NULL DEFAULT '',
+    quiet_hours_end VARCHAR(5) NOT NULL DEFAULT '',
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS onboarding_outbox (
+    outbox_id VARCHAR(64) PRIMARY KEY,
+    seq BIGSERIAL NOT NULL,
+    event_type VARCHAR(120) NOT NULL,
+    partition_key VARCHAR(120) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    published_at TIMESTAMPTZ NULL,
+    CONSTRAINT onboarding_outbox_status_check CHECK (status IN ('pending', 'published'))
+);
+CREATE INDEX IF NOT EXISTS idx_onboarding_outbox_status_created
+    ON onboarding_outbox (status, created_at ASC, seq ASC);
+
+CREATE TABLE IF NOT EXISTS onboarding_idempotency (
+    key TEXT PRIMARY KEY,
+    request_hash TEXT NOT NULL,
+    payload BYTEA NOT NULL DEFAULT ''::bytea,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_onboarding_idempotency_expires_at
+    ON onboarding_idempotency (expires_at);
+
+INSERT INTO onboarding_flows (flow_id, role, name, created_at, updated_at) VALUES
+    ('flow_brand_default', 'brand', 'Brand onboarding', '2026-01-01T00:00:00Z', '2026-01-01T00:00:00Z'),
+    ('flow_editor_default', 'editor', 'Editor onboarding', '2026-01-01T00:00:00Z', '2026-01-01T00:00:00Z'),
+    ('flow_influencer_default', 'influencer', 'Influencer onboarding', '2026-01-01T00:00:00Z', '2026-01-01T00:00:00Z')
+ON CONFLICT (flow_id) DO NOTHING;
+
+INSERT INTO onboarding_flow_versions (flow_id, version, status, steps, created_by, created_at, published_by, published_at) VALUES
+    ('flow_brand_default', 1, 'published', '[
+        {"step_key": "welcome", "title": "Welcome to ViralForge"},
+        {"step_key": "connect_storefront", "title": "Connect your storefront", "completion_event": "storefront.connected"},
+        {"step_key": "create_first_product", "title": "Create your first product", "completion_event": "product.created"}
+    ]'::jsonb, 'system', '2026-01-01T00:00:00Z', 'system', '2026-01-01T00:00:00Z'),
+    ('flow_editor_default', 1, 'published', '[
+        {"step_key": "welcome", "title": "Welcome to ViralForge"},
+        {"step_key": "complete_profile", "title": "Complete your profile", "completion_event": "profile.completed"},
+        {"step_key": "submit_first_clip", "title": "Submit your first clip", "completion_event": "submission.created"}
+    ]'::jsonb, 'system', '2026-01-01T00:00:00Z', 'system', '2026-01-01T00:00:00Z'),
+    ('flow_influencer_default', 1, 'published', '[
+        {"step_key": "welcome", "title": "Welcome to ViralForge"},
+        {"step_key": "connect_social", "title": "Connect social account", "completion_event": "social_account.connected"},
+        {"step_key": "join_first_campaign", "title": "Join your first campaign", "completion_event": "campaign.joined"}
+    ]'::jsonb, 'system', '2026-01-01T00:00:00Z', 'system', '2026-01-01T00:00:00Z')
+ON CONFLICT (flow_id, version) DO NOTHING;
+
+INSERT INTO onboarding_active_flows (role, flow_id, updated_at) VALUES
+    ('brand', 'flow_brand_default', '2026-01-01T00:00:00Z'),
+    ('editor', 'flow_editor_default', '2026-01-01T00:00:00Z'),
+    ('influencer', 'flow_influencer_default', '2026-01-01T00:00:00Z')
+ON CONFLICT (role) DO NOTHING;
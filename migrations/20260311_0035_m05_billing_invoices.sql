@@ -0,0 +1,98 @@
+-- M05-Billing-Service invoice persistence.
+-- Invoices and credit notes are immutable once written: refunds add credit
+-- notes and never touch the invoice. A charge source is invoiced at most
+-- once, and document numbers are reserved per kind and year from
+-- billing_document_sequences. Credit notes for an invoice are serialised by
+-- locking the invoice row, so a line can never be credited past its amount.
+
+CREATE TABLE IF NOT EXISTS billing_document_sequences (
+    kind VARCHAR(32) NOT NULL,
+    year INTEGER NOT NULL,
+    last_value BIGINT NOT NULL,
+    PRIMARY KEY (kind, year)
+);
+
+CREATE TABLE IF NOT EXISTS billing_invoices (
+    invoice_id VARCHAR(64) PRIMARY KEY,
+    invoice_number VARCHAR(32) NOT NULL UNIQUE,
+    user_id VARCHAR(64) NOT NULL,
+    source_type VARCHAR(64) NOT NULL,
+    source_id VARCHAR(128) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    subtotal_cents BIGINT NOT NULL,
+    tax_cents BIGINT NOT NULL,
+    total_cents BIGINT NOT NULL,
+    issued_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT billing_invoices_source_unique UNIQUE (source_type, source_id)
+);
+CREATE INDEX IF NOT EXISTS idx_billing_invoices_user_issued
+    ON billing_invoices (user_id, issued_at DESC, invoice_number DESC);
+
+CREATE TABLE IF NOT EXISTS billing_invoice_line_items (
+    line_item_id VARCHAR(96) PRIMARY KEY,
+    invoice_id VARCHAR(64) NOT NULL REFERENCES billing_invoices (invoice_id),
+    position INTEGER NOT NULL,
+    description TEXT NOT NULL,
+    quantity INTEGER NOT NULL,
+    unit_amount_cents BIGINT NOT NULL,
+    amount_cents BIGINT NOT NULL,
+    tax_cents BIGINT NOT NULL DEFAULT 0,
+    reference_type VARCHAR(64) NOT NULL DEFAULT '',
+    reference_id VARCHAR(128) NOT NULL DEFAULT '',
+    CONSTRAINT billing_invoice_line_items_position_unique UNIQUE (invoice_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS billing_invoice_tax_lines (
+    invoice_id VARCHAR(64) NOT NULL REFERENCES billing_invoices (invoice_id),
+    position INTEGER NOT NULL,
+    tax_code VARCHAR(64) NOT NULL,
+    name VARCHAR(120) NOT NULL,
+    rate_basis_points INTEGER NOT NULL,
+    inclusive BOOLEAN NOT NULL DEFAULT TRUE,
+    taxable_cents BIGINT NOT NULL,
+    tax_cents BIGINT NOT NULL,
+    PRIMARY KEY (invoice_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS billing_credit_notes (
+    credit_note_id VARCHAR(64) PRIMARY KEY,
+    credit_note_number VARCHAR(32) NOT NULL UNIQUE,
+    invoice_id VARCHAR(64) NOT NULL REFERENCES billing_invoices (invoice_id),
+    invoice_number VARCHAR(32) NOT NULL,
+    user_id VARCHAR(64) NOT NULL,
+    line_item_id VARCHAR(96) NOT NULL REFERENCES billing_invoice_line_items (line_item_id),
+    currency CHAR(3) NOT NULL,
+    amount_cents BIGINT NOT NULL,
+    tax_cents BIGINT NOT NULL,
+    reason TEXT NOT NULL,
+    issued_by VARCHAR(64) NOT NULL,
+    issued_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT billing_credit_notes_amount_check CHECK (amount_cents > 0)
+);
+CREATE INDEX IF NOT EXISTS idx_billing_credit_notes_invoice
+    ON billing_credit_notes (invoice_id, issued_at ASC);
+
+CREATE TABLE IF NOT EXISTS billing_tax_rates (
+    currency CHAR(3) NOT NULL,
+    tax_code VARCHAR(64) NOT NULL,
+    name VARCHAR(120) NOT NULL,
+    rate_basis_points INTEGER NOT NULL,
+    PRIMARY KEY (currency, tax_code),
+    CONSTRAINT billing_tax_rates_rate_check CHECK (rate_basis_points >= 0)
+);
+
+CREATE TABLE IF NOT EXISTS billing_idempotency (
+    key TEXT PRIMARY KEY,
+    request_hash TEXT NOT NULL,
+    payload BYTEA NOT NULL DEFAULT ''::bytea,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_billing_idempotency_expires_at
+    ON billing_idempotency (expires_at);
+
+INSERT INTO billing_tax_rates (currency, tax_code, name, rate_basis_points) VALUES
+    ('USD', 'us_sales_tax', 'Sales tax', 0),
+    ('EUR', 'eu_vat_standard', 'VAT', 2000),
+    ('GBP', 'uk_vat_standard', 'VAT', 2000)
+ON CONFLICT (currency, tax_code) DO NOTHING;
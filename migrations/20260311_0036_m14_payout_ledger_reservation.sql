@@ -0,0 +1,8 @@
+-- Payouts are reserved out of the creator's wallet before their transfer is
+-- sent, and the reservation is reversed when the transfer fails for good.
+-- ledger_debited marks a payout whose current reservation is posted;
+-- ledger_releases counts reversed reservations and keys the next one.
+
+ALTER TABLE payouts
+    ADD COLUMN IF NOT EXISTS ledger_debited BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS ledger_releases INT NOT NULL DEFAULT 0;
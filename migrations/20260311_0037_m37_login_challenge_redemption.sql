@@ -0,0 +1,10 @@
+-- A passed login challenge no longer registers the device by itself. The
+-- auth service reports the login again with the challenge ID, and only that
+-- allowed login remembers the device; the challenge is then redeemed so it
+-- lets one login through.
+
+ALTER TABLE abuse_login_challenges
+    DROP CONSTRAINT IF EXISTS abuse_login_challenges_status_check;
+ALTER TABLE abuse_login_challenges
+    ADD CONSTRAINT abuse_login_challenges_status_check
+    CHECK (status IN ('pending', 'passed', 'failed', 'expired', 'redeemed'));
@@ -0,0 +1,48 @@
+-- M86-Admin-Dashboard-Service tamper-evident control-plane audit log.
+-- Entries are hash-chained and HMAC-signed like the M20 chain in
+-- 20260310_0026_m20_admin_audit_chain.sql; anchors record the chain head in
+-- a separate table. Both tables are append-only.
+
+CREATE TABLE IF NOT EXISTS admin_audit_logs (
+    sequence BIGINT PRIMARY KEY,
+    audit_id VARCHAR(64) NOT NULL UNIQUE,
+    actor_id VARCHAR(64) NOT NULL,
+    action TEXT NOT NULL,
+    target_id TEXT NOT NULL,
+    justification TEXT NOT NULL,
+    occurred_at TIMESTAMPTZ NOT NULL,
+    source_ip TEXT NOT NULL DEFAULT '',
+    correlation_id TEXT NOT NULL DEFAULT '',
+    prev_hash CHAR(64) NOT NULL,
+    entry_hash CHAR(64) NOT NULL UNIQUE,
+    signature TEXT NOT NULL,
+    CONSTRAINT admin_audit_logs_sequence_check CHECK (sequence > 0)
+);
+CREATE INDEX IF NOT EXISTS idx_admin_audit_logs_actor
+    ON admin_audit_logs (actor_id, sequence DESC);
+
+CREATE TABLE IF NOT EXISTS admin_audit_anchors (
+    anchor_id VARCHAR(64) PRIMARY KEY,
+    sequence BIGINT NOT NULL,
+    entry_hash CHAR(64) NOT NULL,
+    signature TEXT NOT NULL,
+    anchored_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_admin_audit_anchors_sequence
+    ON admin_audit_anchors (sequence DESC, anchored_at DESC);
+
+CREATE OR REPLACE FUNCTION admin_audit_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION '% is append-only', TG_TABLE_NAME;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS admin_audit_logs_append_only ON admin_audit_logs;
+CREATE TRIGGER admin_audit_logs_append_only
+    BEFORE UPDATE OR DELETE ON admin_audit_logs
+    FOR EACH ROW EXECUTE FUNCTION admin_audit_append_only();
+
+DROP TRIGGER IF EXISTS admin_audit_anchors_append_only ON admin_audit_anchors;
+CREATE TRIGGER admin_audit_anchors_append_only
+    BEFORE UPDATE OR DELETE ON admin_audit_anchors
+    FOR EACH ROW EXECUTE FUNCTION admin_audit_append_only();
@@ -27,6 +27,7 @@ func TestAdminDashboardOpenAPIContractIncludesImplementedRoutes(t *testing.T) {
 
 	expected := map[string][]string{
 		"/api/admin/v1/actions/log":                                            {"post"},
+		"/api/admin/v1/actions/log/verify":                                     {"get"},
 		"/api/admin/v1/identity/roles/grant":                                   {"post"},
 		"/api/admin/v1/moderation/decisions":                                   {"post"},
 		"/api/admin/v1/abuse-prevention/lockouts/{user_id}/release":            {"post"},
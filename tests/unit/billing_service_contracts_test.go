@@ -0,0 +1,45 @@
+package unit
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBillingServiceOpenAPIContractIncludesImplementedRoutes(t *testing.T) {
+	root, err := findRepoRoot()
+	if err != nil {
+		t.Fatalf("resolve repo root: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(root, "contracts", "api", "v1", "billing-service.openapi.json"))
+	if err != nil {
+		t.Fatalf("read billing-service openapi: %v", err)
+	}
+
+	var doc struct {
+		Paths map[string]map[string]any `json:"paths"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("decode billing-service openapi: %v", err)
+	}
+
+	expected := map[string][]string{
+		"/api/v1/billing/invoices":                  {"get"},
+		"/api/v1/billing/invoices/{invoice_id}":     {"get"},
+		"/api/v1/billing/invoices/{invoice_id}/pdf": {"get"},
+	}
+
+	for path, methods := range expected {
+		ops, ok := doc.Paths[path]
+		if !ok {
+			t.Fatalf("missing path in openapi contract: %s", path)
+		}
+		for _, method := range methods {
+			if _, ok := ops[method]; !ok {
+				t.Fatalf("missing method %s for path %s in openapi contract", method, path)
+			}
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package unit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	chatservice "solomon/contexts/community-experience/chat-service"
+	"solomon/contexts/community-experience/chat-service/adapters/memory"
+	chatscanner "solomon/contexts/community-experience/chat-service/adapters/scanner"
+	"solomon/contexts/community-experience/chat-service/application"
+	domainerrors "solomon/contexts/community-experience/chat-service/domain/errors"
+	chatports "solomon/contexts/community-experience/chat-service/ports"
+	httptransport "solomon/contexts/community-experience/chat-service/transport/http"
+)
+
+// signatureScanner flags content containing "EICAR" and fails while down is set.
+type signatureScanner struct {
+	down bool
+}
+
+func (s *signatureScanner) ScanAttachment(
+	ctx context.Context,
+	attachment chatports.Attachment,
+	content io.Reader,
+) (chatports.ScanVerdict, error) {
+	if s.down {
+		return chatports.ScanVerdict{}, errors.New("scanner unavailable")
+	}
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return chatports.ScanVerdict{}, err
+	}
+	if bytes.Contains(data, []byte("EICAR")) {
+		return chatports.ScanVerdict{Infected: true, Signature: "Eicar-Test-Signature"}, nil
+	}
+	return chatports.ScanVerdict{}, nil
+}
+
+func newScannedChatModule(scanner chatports.AttachmentScanner) (chatservice.Module, *memory.Store) {
+	store := memory.NewStore()
+	module := chatservice.NewModule(chatservice.Dependencies{
+		Repository:     store,
+		Idempotency:    store,
+		Clock:          store,
+		IDGenerator:    store,
+		IdempotencyTTL: time.Hour,
+		Outbox:         store,
+		Scanner:        scanner,
+	})
+	module.Store = store
+	return module, store
+}
+
+func TestChatAttachmentScanQuarantinesInfectedFilesAndNotifiesAuthor(t *testing.T) {
+	module, store := newScannedChatModule(&signatureScanner{})
+	ctx := context.Background()
+
+	clean, err := module.Handler.AddAttachmentHandler(ctx, "user_900", "msg_001", "idem-att-clean", httptransport.AddAttachmentRequest{
+		Filename: "notes.txt",
+		MimeType: "text/plain",
+		Content:  []byte("meeting notes"),
+	})
+	if err != nil {
+		t.Fatalf("add clean attachment: %v", err)
+	}
+	infected, err := module.Handler.AddAttachmentHandler(ctx, "user_900", "msg_001", "idem-att-infected", httptransport.AddAttachmentRequest{
+		Filename: "invoice.exe",
+		MimeType: "application/octet-stream",
+		Content:  []byte("X5O!P%@AP EICAR payload"),
+	})
+	if err != nil {
+		t.Fatalf("add infected attachment: %v", err)
+	}
+	if clean.Data.ScanResult != chatports.ScanResultPending || clean.Data.FileSize != int64(len("meeting notes")) {
+		t.Fatalf("expected pending attachment sized from content, got %+v", clean.Data)
+	}
+	if _, err := module.Handler.GetAttachmentHandler(ctx, "msg_001", clean.Data.AttachmentID); !errors.Is(err, domainerrors.ErrAttachmentNotReady) {
+		t.Fatalf("expected unscanned attachment to be refused, got %v", err)
+	}
+
+	if err := module.ScanAttachments(ctx); err != nil {
+		t.Fatalf("scan attachments: %v", err)
+	}
+	served, err := module.Handler.GetAttachmentHandler(ctx, "msg_001", clean.Data.AttachmentID)
+	if err != nil || served.Data.ScanResult != chatports.ScanResultClean {
+		t.Fatalf("expected clean attachment to be served, got %+v err=%v", served.Data, err)
+	}
+	if _, err := module.Handler.GetAttachmentHandler(ctx, "msg_001", infected.Data.AttachmentID); !errors.Is(err, domainerrors.ErrAttachmentBlocked) {
+		t.Fatalf("expected infected attachment to be quarantined, got %v", err)
+	}
+	quarantined, err := store.GetAttachment(ctx, "msg_001", infected.Data.AttachmentID)
+	if err != nil || quarantined.QuarantinedAt == nil || quarantined.ScanSignature != "Eicar-Test-Signature" {
+		t.Fatalf("expected quarantine to be recorded, got %+v err=%v", quarantined, err)
+	}
+
+	pending, err := store.ListPendingOutbox(ctx, 10)
+	if err != nil {
+		t.Fatalf("list outbox: %v", err)
+	}
+	notices := 0
+	for _, row := range pending {
+		var event chatports.EventEnvelope
+		if err := json.Unmarshal(row.Payload, &event); err != nil {
+			t.Fatalf("decode outbox row: %v", err)
+		}
+		if event.EventType != application.AttachmentQuarantinedTopic {
+			continue
+		}
+		notices++
+		var data map[string]string
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			t.Fatalf("decode quarantine payload: %v", err)
+		}
+		if data["attachment_id"] != infected.Data.AttachmentID || data["recipient_user_id"] != "creator_001" || data["uploader_user_id"] != "user_900" {
+			t.Fatalf("unexpected quarantine notice: %v", data)
+		}
+	}
+	if notices != 1 {
+		t.Fatalf("expected one quarantine notice, got %d", notices)
+	}
+
+	// A second pass finds nothing left to scan.
+	if err := module.ScanAttachments(ctx); err != nil {
+		t.Fatalf("rescan attachments: %v", err)
+	}
+	if after, _ := store.ListPendingOutbox(ctx, 10); len(after) != len(pending) {
+		t.Fatalf("expected no further outbox events, got %d then %d", len(pending), len(after))
+	}
+}
+
+func TestChatAttachmentScanGivesUpAfterRepeatedFailures(t *testing.T) {
+	scanner := &signatureScanner{down: true}
+	module, store := newScannedChatModule(scanner)
+	ctx := context.Background()
+
+	added, err := module.Handler.AddAttachmentHandler(ctx, "user_900", "msg_001", "idem-att-retry", httptransport.AddAttachmentRequest{
+		Filename: "photo.png",
+		MimeType: "image/png",
+		Content:  []byte("png bytes"),
+	})
+	if err != nil {
+		t.Fatalf("add attachment: %v", err)
+	}
+	for i := 0; i < 4; i++ {
+		if err := module.ScanAttachments(ctx); err != nil {
+			t.Fatalf("scan pass %d: %v", i, err)
+		}
+	}
+	item, _ := store.GetAttachment(ctx, "msg_001", added.Data.AttachmentID)
+	if item.ScanResult != chatports.ScanResultPending || item.ScanAttempts != 4 {
+		t.Fatalf("expected attachment to stay pending after 4 failures, got %+v", item)
+	}
+	if err := module.ScanAttachments(ctx); err != nil {
+		t.Fatalf("final scan pass: %v", err)
+	}
+	item, _ = store.GetAttachment(ctx, "msg_001", added.Data.AttachmentID)
+	if item.ScanResult != chatports.ScanResultScanError {
+		t.Fatalf("expected scan_error after 5 failures, got %+v", item)
+	}
+	if _, err := module.Handler.GetAttachmentHandler(ctx, "msg_001", added.Data.AttachmentID); !errors.Is(err, domainerrors.ErrAttachmentNotReady) {
+		t.Fatalf("expected scan_error attachment to be refused, got %v", err)
+	}
+}
+
+func TestChatAttachmentNoopScanRecordsUnscanned(t *testing.T) {
+	module, store := newScannedChatModule(chatscanner.NoopScanner{})
+	ctx := context.Background()
+
+	added, err := module.Handler.AddAttachmentHandler(ctx, "user_900", "msg_001", "idem-att-noop", httptransport.AddAttachmentRequest{
+		Filename: "notes.txt",
+		MimeType: "text/plain",
+		Content:  []byte("X5O!P%@AP EICAR payload"),
+	})
+	if err != nil {
+		t.Fatalf("add attachment: %v", err)
+	}
+	if err := module.ScanAttachments(ctx); err != nil {
+		t.Fatalf("scan attachments: %v", err)
+	}
+	item, _ := store.GetAttachment(ctx, "msg_001", added.Data.AttachmentID)
+	if item.ScanResult != chatports.ScanResultUnscanned || item.QuarantinedAt != nil {
+		t.Fatalf("expected noop scan to record unscanned, got %+v", item)
+	}
+}
+
+func TestChatAttachmentScannerDefaultsToClamd(t *testing.T) {
+	if _, err := chatscanner.New(chatscanner.Options{}); err == nil {
+		t.Fatalf("expected the default scanner to require a clamd address")
+	}
+	if _, err := chatscanner.New(chatscanner.Options{Kind: chatscanner.KindNoop}); err != nil {
+		t.Fatalf("expected explicit noop scanner, got %v", err)
+	}
+}
+
+func TestChatAttachmentRejectsMismatchedDeclaredSize(t *testing.T) {
+	module, _ := newScannedChatModule(&signatureScanner{})
+	_, err := module.Handler.AddAttachmentHandler(context.Background(), "user_900", "msg_001", "idem-att-size", httptransport.AddAttachmentRequest{
+		Filename: "notes.txt",
+		FileSize: 99,
+		Content:  []byte("short"),
+	})
+	if !errors.Is(err, domainerrors.ErrInvalidRequest) {
+		t.Fatalf("expected invalid request, got %v", err)
+	}
+}
@@ -33,6 +33,8 @@ func TestChatServiceOpenAPIContractIncludesImplementedRoutes(t *testing.T) {
 		"/api/v1/chat/search":                                            {"get"},
 		"/api/v1/chat/poll":                                              {"get"},
 		"/api/v1/chat/messages/subscribe":                                {"get"},
+		"/api/v1/chat/channels/{channel_id}/ws":                          {"get"},
+		"/api/v1/chat/channels/{channel_id}/typing":                      {"post"},
 		"/api/v1/chat/messages/{message_id}/reactions":                   {"post"},
 		"/api/v1/chat/messages/{message_id}/reactions/{emoji}":           {"delete"},
 		"/api/v1/chat/messages/{message_id}/pin":                         {"post"},
@@ -0,0 +1,112 @@
+package unit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	chatservice "solomon/contexts/community-experience/chat-service"
+	chatports "solomon/contexts/community-experience/chat-service/ports"
+	chathttp "solomon/contexts/community-experience/chat-service/transport/http"
+	communityhealthservice "solomon/contexts/community-experience/community-health-service"
+	healthports "solomon/contexts/community-experience/community-health-service/ports"
+)
+
+// syncBus delivers each event to every handler of its topic before Publish
+// returns, and keeps a copy so tests can redeliver.
+type syncBus struct {
+	mu        sync.Mutex
+	handlers  map[string][]func(context.Context, chatports.EventEnvelope) error
+	published []chatports.EventEnvelope
+}
+
+func newSyncBus() *syncBus {
+	return &syncBus{handlers: make(map[string][]func(context.Context, chatports.EventEnvelope) error)}
+}
+
+func (b *syncBus) Publish(ctx context.Context, topic string, event chatports.EventEnvelope) error {
+	b.mu.Lock()
+	handlers := append([]func(context.Context, chatports.EventEnvelope) error(nil), b.handlers[topic]...)
+	b.published = append(b.published, event)
+	b.mu.Unlock()
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *syncBus) Subscribe(
+	_ context.Context,
+	topic string,
+	_ string,
+	handler func(context.Context, chatports.EventEnvelope) error,
+) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[topic] = append(b.handlers[topic], handler)
+	return nil
+}
+
+func TestChatMessageEventsFeedCommunityHealthBacklog(t *testing.T) {
+	ctx := context.Background()
+	bus := newSyncBus()
+	chat := chatservice.NewInMemoryModuleWithEvents(nil, bus, bus)
+	health := communityhealthservice.NewInMemoryModuleWithEvents(nil, bus, nil)
+
+	// Written before community-health subscribes: the outbox keeps them.
+	var messageIDs []string
+	for i, content := range []string{"thanks, this is great", "awesome helpful stream"} {
+		resp, err := chat.Handler.PostMessageHandler(ctx, "user_a", "user_a", fmt.Sprintf("idem-chat-health-%d", i), chathttp.PostMessageRequest{
+			ServerID:  "srv_health",
+			ChannelID: "ch_health",
+			Content:   content,
+		})
+		if err != nil {
+			t.Fatalf("post message %d failed: %v", i, err)
+		}
+		messageIDs = append(messageIDs, resp.Data.Message.MessageID)
+	}
+	if _, err := chat.Handler.DeleteMessageHandler(ctx, "user_a", messageIDs[1], "idem-chat-health-delete", chathttp.DeleteMessageRequest{}); err != nil {
+		t.Fatalf("delete message failed: %v", err)
+	}
+
+	if err := health.StartConsumers(ctx); err != nil {
+		t.Fatalf("start consumers failed: %v", err)
+	}
+	if err := chat.DrainOutbox(ctx); err != nil {
+		t.Fatalf("drain outbox failed: %v", err)
+	}
+
+	score, err := health.Handler.Service.GetCommunityHealthScore(ctx, "srv_health")
+	if err != nil {
+		t.Fatalf("health score lookup failed: %v", err)
+	}
+	if score.TotalMessages != 1 {
+		t.Fatalf("expected one live message after backlog replay, got %d", score.TotalMessages)
+	}
+
+	// Redelivery is acknowledged without double counting.
+	for _, event := range bus.published {
+		if err := health.ChatConsumer.Handle(ctx, healthports.EventEnvelope(event)); err != nil {
+			t.Fatalf("redelivery of %s failed: %v", event.EventType, err)
+		}
+	}
+	score, err = health.Handler.Service.GetCommunityHealthScore(ctx, "srv_health")
+	if err != nil {
+		t.Fatalf("health score lookup after redelivery failed: %v", err)
+	}
+	if score.TotalMessages != 1 {
+		t.Fatalf("expected redelivery to be idempotent, got %d messages", score.TotalMessages)
+	}
+
+	pending, err := chat.Store.ListPendingOutbox(ctx, 10)
+	if err != nil {
+		t.Fatalf("list pending outbox failed: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Fatalf("expected drained outbox, got %d pending rows", len(pending))
+	}
+}
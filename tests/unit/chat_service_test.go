@@ -3,7 +3,9 @@ package unit
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	chatservice "solomon/contexts/community-experience/chat-service"
 	domainerrors "solomon/contexts/community-experience/chat-service/domain/errors"
@@ -89,3 +91,164 @@ func TestChatServiceMessageSearchAndUnreadCount(t *testing.T) {
 		t.Fatalf("expected unread count >=1, got %d", unread.Data.UnreadCount)
 	}
 }
+
+func TestChatServiceListMessagesKeysetCursors(t *testing.T) {
+	module := chatservice.NewInMemoryModule(nil)
+	ctx := context.Background()
+
+	// ch_001 is seeded with msg_001; add five more so the channel holds six.
+	for i := 0; i < 5; i++ {
+		if _, err := module.Handler.PostMessageHandler(ctx, "user_700", "user_700", fmt.Sprintf("idem-chat-page-%d", i), httptransport.PostMessageRequest{
+			ServerID:  "srv_001",
+			ChannelID: "ch_001",
+			Content:   fmt.Sprintf("page message %d", i),
+		}); err != nil {
+			t.Fatalf("post message %d failed: %v", i, err)
+		}
+	}
+
+	latest, err := module.Handler.ListMessagesHandler(ctx, "ch_001", "", "", 0, 4)
+	if err != nil {
+		t.Fatalf("list latest failed: %v", err)
+	}
+	if len(latest.Data.Messages) != 4 || !latest.Data.HasMore {
+		t.Fatalf("expected 4 newest messages with more, got %d has_more=%v", len(latest.Data.Messages), latest.Data.HasMore)
+	}
+	if latest.Data.Messages[0].SequenceNumber != 6 || latest.Data.Messages[3].SequenceNumber != 3 {
+		t.Fatalf("expected sequences 6..3 newest first, got %d..%d",
+			latest.Data.Messages[0].SequenceNumber, latest.Data.Messages[3].SequenceNumber)
+	}
+
+	older, err := module.Handler.ListMessagesHandler(ctx, "ch_001", latest.Data.NextBefore, "", 0, 4)
+	if err != nil {
+		t.Fatalf("list older failed: %v", err)
+	}
+	if len(older.Data.Messages) != 2 || older.Data.HasMore {
+		t.Fatalf("expected final 2 older messages, got %d has_more=%v", len(older.Data.Messages), older.Data.HasMore)
+	}
+	if older.Data.Messages[0].SequenceNumber != 2 || older.Data.Messages[1].MessageID != "msg_001" {
+		t.Fatalf("unexpected older page: %+v", older.Data.Messages)
+	}
+
+	newer, err := module.Handler.ListMessagesHandler(ctx, "ch_001", "", older.Data.NextAfter, 0, 2)
+	if err != nil {
+		t.Fatalf("list newer failed: %v", err)
+	}
+	if len(newer.Data.Messages) != 2 || !newer.Data.HasMore ||
+		newer.Data.Messages[0].SequenceNumber != 4 || newer.Data.Messages[1].SequenceNumber != 3 {
+		t.Fatalf("expected sequences 4,3 after cursor, got %+v has_more=%v", newer.Data.Messages, newer.Data.HasMore)
+	}
+
+	if _, err := module.Handler.ListMessagesHandler(ctx, "ch_002", latest.Data.NextBefore, "", 0, 4); !errors.Is(err, domainerrors.ErrInvalidRequest) {
+		t.Fatalf("expected cursor from another channel to be rejected, got %v", err)
+	}
+	if _, err := module.Handler.ListMessagesHandler(ctx, "ch_001", latest.Data.NextBefore, older.Data.NextAfter, 0, 4); !errors.Is(err, domainerrors.ErrInvalidRequest) {
+		t.Fatalf("expected before and after together to be rejected, got %v", err)
+	}
+}
+
+func TestChatServiceExportMessagesResumesFromCursor(t *testing.T) {
+	module := chatservice.NewInMemoryModule(nil)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if _, err := module.Handler.PostMessageHandler(ctx, "user_701", "user_701", fmt.Sprintf("idem-chat-export-%d", i), httptransport.PostMessageRequest{
+			ServerID:  "srv_001",
+			ChannelID: "ch_001",
+			Content:   fmt.Sprintf("export message %d", i),
+		}); err != nil {
+			t.Fatalf("post message %d failed: %v", i, err)
+		}
+	}
+
+	seen := make(map[string]struct{})
+	cursor := ""
+	for pages := 0; pages < 10; pages++ {
+		page, err := module.Handler.ExportMessagesHandler(ctx, "srv_001", "", cursor, 3)
+		if err != nil {
+			t.Fatalf("export page failed: %v", err)
+		}
+		for _, item := range page.Data.Messages {
+			if _, dup := seen[item.MessageID]; dup {
+				t.Fatalf("message %s exported twice", item.MessageID)
+			}
+			seen[item.MessageID] = struct{}{}
+		}
+		if page.Data.NextCursor == "" {
+			break
+		}
+		cursor = page.Data.NextCursor
+	}
+	if len(seen) != 4 {
+		t.Fatalf("expected 4 exported messages, got %d", len(seen))
+	}
+}
+
+func TestChatServiceChannelStreamReplaysThenForwardsLiveEvents(t *testing.T) {
+	module := chatservice.NewInMemoryModule(nil)
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		resp, err := module.Handler.PostMessageHandler(ctx, "user_1", "user_1", fmt.Sprintf("idem-chat-stream-%d", i), httptransport.PostMessageRequest{
+			ServerID:  "srv_001",
+			ChannelID: "ch_stream",
+			Content:   fmt.Sprintf("message %d", i),
+		})
+		if err != nil {
+			t.Fatalf("post message %d failed: %v", i, err)
+		}
+		ids = append(ids, resp.Data.Message.MessageID)
+	}
+
+	events, cancel, err := module.Handler.OpenChannelStream(ctx, "ch_stream", 1)
+	if err != nil {
+		t.Fatalf("open channel stream failed: %v", err)
+	}
+	defer cancel()
+
+	next := func() httptransport.ChatStreamEvent {
+		t.Helper()
+		select {
+		case frame, ok := <-events:
+			if !ok {
+				t.Fatalf("stream closed unexpectedly")
+			}
+			return frame
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for stream frame")
+		}
+		return httptransport.ChatStreamEvent{}
+	}
+
+	for i, want := range ids[1:] {
+		frame := next()
+		if frame.Type != "message.created" || frame.MessageID != want || frame.SequenceNumber != int64(i+2) {
+			t.Fatalf("unexpected replay frame %d: %+v", i, frame)
+		}
+	}
+	if ready := next(); ready.Type != "ready" || ready.SequenceNumber != 3 {
+		t.Fatalf("expected ready frame at sequence 3, got %+v", ready)
+	}
+
+	if _, err := module.Handler.AddReactionHandler(ctx, "user_2", ids[0], "idem-chat-stream-react", httptransport.ReactionRequest{Emoji: "👍"}); err != nil {
+		t.Fatalf("add reaction failed: %v", err)
+	}
+	frame := next()
+	if frame.Type != "reaction.added" || frame.MessageID != ids[0] || frame.Reactions["👍"] != 1 {
+		t.Fatalf("unexpected live reaction frame: %+v", frame)
+	}
+
+	if _, err := module.Handler.PostMessageHandler(ctx, "user_1", "user_1", "idem-chat-stream-live", httptransport.PostMessageRequest{
+		ServerID:  "srv_001",
+		ChannelID: "ch_stream",
+		Content:   "live message",
+	}); err != nil {
+		t.Fatalf("post live message failed: %v", err)
+	}
+	frame = next()
+	if frame.Type != "message.created" || frame.SequenceNumber != 4 || frame.Message == nil || frame.Message.Content != "live message" {
+		t.Fatalf("unexpected live message frame: %+v", frame)
+	}
+}
@@ -0,0 +1,127 @@
+package unit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	chatservice "solomon/contexts/community-experience/chat-service"
+	"solomon/contexts/community-experience/chat-service/adapters/memory"
+	"solomon/contexts/community-experience/chat-service/adapters/unfurl"
+	chatports "solomon/contexts/community-experience/chat-service/ports"
+	httptransport "solomon/contexts/community-experience/chat-service/transport/http"
+)
+
+func TestChatLinkUnfurlFillsEmbedsOnceAndCachesPerURL(t *testing.T) {
+	var hits atomic.Int32
+	fixture := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><head><meta property="og:title" content="Season recap"><meta property="og:description" content="Highlights"></head></html>`)
+	}))
+	defer fixture.Close()
+
+	store := memory.NewStore()
+	module := chatservice.NewModule(chatservice.Dependencies{
+		Repository:     store,
+		Idempotency:    store,
+		Clock:          store,
+		IDGenerator:    store,
+		IdempotencyTTL: time.Hour,
+		Unfurler:       unfurl.HTTPUnfurler{Timeout: time.Second, AllowPrivateNetworks: true},
+		LinkPreviews:   store,
+	})
+	ctx := context.Background()
+	events, cancel := module.Hub.SubscribeChannel("ch_001")
+	defer cancel()
+
+	link := fixture.URL + "/recap"
+	first, err := module.Handler.PostMessageHandler(ctx, "user_321", "user_321", "idem-unfurl-1", httptransport.PostMessageRequest{
+		ServerID:  "srv_001",
+		ChannelID: "ch_001",
+		Content:   "watch this " + link,
+	})
+	if err != nil {
+		t.Fatalf("post message: %v", err)
+	}
+	if !first.Data.Message.EmbedsPending || len(first.Data.Message.Embeds) != 0 {
+		t.Fatalf("expected links to await unfurling, got %+v", first.Data.Message)
+	}
+	if _, err := module.Handler.PostMessageHandler(ctx, "user_322", "user_322", "idem-unfurl-2", httptransport.PostMessageRequest{
+		ServerID:  "srv_001",
+		ChannelID: "ch_001",
+		Content:   "same link (" + link + ")",
+	}); err != nil {
+		t.Fatalf("post message: %v", err)
+	}
+	<-events
+	<-events
+
+	if err := module.UnfurlLinks(ctx); err != nil {
+		t.Fatalf("unfurl links: %v", err)
+	}
+	if got := hits.Load(); got != 1 {
+		t.Fatalf("expected one fetch for a repeated link, got %d", got)
+	}
+	message, err := store.GetMessage(ctx, first.Data.Message.MessageID)
+	if err != nil {
+		t.Fatalf("get message: %v", err)
+	}
+	if message.EmbedsPending || len(message.Embeds) != 1 || message.Embeds[0].Title != "Season recap" || message.Embeds[0].URL != link {
+		t.Fatalf("expected verified embed, got %+v", message)
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case event := <-events:
+			if event.Type != chatports.RealtimeMessageEdited || event.Message == nil || len(event.Message.Embeds) != 1 {
+				t.Fatalf("expected message.edited with embeds, got %+v", event)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected an edit event per unfurled message")
+		}
+	}
+
+	pending, _ := store.ListPendingUnfurls(ctx, 10)
+	if len(pending) != 0 {
+		t.Fatalf("expected no pending unfurls, got %d", len(pending))
+	}
+}
+
+func TestChatLinkUnfurlSkipsMessagesEditedMeanwhile(t *testing.T) {
+	store := memory.NewStore()
+	ctx := context.Background()
+	created, err := store.CreateMessage(ctx, chatports.CreateMessageInput{
+		ServerID:  "srv_001",
+		ChannelID: "ch_001",
+		UserID:    "user_323",
+		Content:   "see https://example.com/a",
+	}, time.Now().UTC())
+	if err != nil {
+		t.Fatalf("create message: %v", err)
+	}
+	edited, err := store.UpdateMessage(ctx, chatports.UpdateMessageInput{
+		MessageID: created.MessageID,
+		UserID:    "user_323",
+		Content:   "see https://example.com/b",
+	}, created.UpdatedAt.Add(time.Second))
+	if err != nil {
+		t.Fatalf("edit message: %v", err)
+	}
+
+	_, err = store.SetMessageEmbeds(ctx, chatports.MessageEmbedsUpdate{
+		MessageID:      created.MessageID,
+		ContentVersion: created.UpdatedAt,
+		Embeds:         []chatports.Embed{{URL: "https://example.com/a", Title: "stale"}},
+	}, time.Now().UTC())
+	if err == nil {
+		t.Fatal("expected stale embeds to be rejected")
+	}
+	current, _ := store.GetMessage(ctx, created.MessageID)
+	if !current.EmbedsPending || len(current.Embeds) != 0 || !current.UpdatedAt.Equal(edited.UpdatedAt) {
+		t.Fatalf("expected edited message to stay pending, got %+v", current)
+	}
+}
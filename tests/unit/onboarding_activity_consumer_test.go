@@ -0,0 +1,80 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	onboardingservice "solomon/contexts/identity-access/onboarding-service"
+	onboardingports "solomon/contexts/identity-access/onboarding-service/ports"
+	onboardinghttp "solomon/contexts/identity-access/onboarding-service/transport/http"
+)
+
+type onboardingStubSubscriber struct {
+	handlers map[string]func(context.Context, onboardingports.EventEnvelope) error
+}
+
+func (s *onboardingStubSubscriber) Subscribe(
+	_ context.Context,
+	topic string,
+	_ string,
+	handler func(context.Context, onboardingports.EventEnvelope) error,
+) error {
+	s.handlers[topic] = handler
+	return nil
+}
+
+func TestOnboardingConsumesSubmissionCreatedEvents(t *testing.T) {
+	subscriber := &onboardingStubSubscriber{handlers: map[string]func(context.Context, onboardingports.EventEnvelope) error{}}
+	module := onboardingservice.NewInMemoryModuleWithEvents(slog.Default(), nil, subscriber)
+	ctx := context.Background()
+	if err := module.StartConsumers(ctx); err != nil {
+		t.Fatalf("start consumers: %v", err)
+	}
+	for _, topic := range []string{"submission.created", "campaign.created", "campaign.launched"} {
+		if subscriber.handlers[topic] == nil {
+			t.Fatalf("expected a subscription to %s", topic)
+		}
+	}
+
+	if _, err := module.Handler.ConsumeUserRegisteredEventHandler(ctx, onboardinghttp.UserRegisteredEventRequest{
+		EventID: "evt-onb-consumer-1",
+		UserID:  "user_onb_consumer",
+		Role:    "editor",
+	}); err != nil {
+		t.Fatalf("register user: %v", err)
+	}
+
+	data, _ := json.Marshal(map[string]string{
+		"submission_id": "sub-onb-1",
+		"creator_id":    "user_onb_consumer",
+		"campaign_id":   "campaign-1",
+	})
+	event := onboardingports.EventEnvelope{
+		EventID:    "evt-sub-created-1",
+		EventType:  "submission.created",
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	}
+	for i := 0; i < 2; i++ {
+		if err := subscriber.handlers["submission.created"](ctx, event); err != nil {
+			t.Fatalf("handle delivery %d: %v", i, err)
+		}
+	}
+
+	flow, err := module.Handler.GetFlowHandler(ctx, "user_onb_consumer")
+	if err != nil {
+		t.Fatalf("get flow: %v", err)
+	}
+	status := ""
+	for _, step := range flow.Data.Steps {
+		if step.StepKey == "submit_first_clip" {
+			status = step.Status
+		}
+	}
+	if status != "completed" || flow.Data.CompletedSteps != 1 {
+		t.Fatalf("expected submission.created to complete submit_first_clip, got %+v", flow.Data.Steps)
+	}
+}
@@ -0,0 +1,60 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"solomon/contexts/campaign-editorial/submission-service/adapters/memory"
+	"solomon/contexts/campaign-editorial/submission-service/application/commands"
+	"solomon/contexts/campaign-editorial/submission-service/domain/entities"
+	domainerrors "solomon/contexts/campaign-editorial/submission-service/domain/errors"
+)
+
+func TestSubmissionPayoutSettlementTransitions(t *testing.T) {
+	now := time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)
+	lockedLongAgo := now.Add(-8 * 24 * time.Hour)
+	lockedRecently := now.Add(-time.Hour)
+	store := memory.NewStore([]entities.Submission{
+		{SubmissionID: "sub-old", CampaignID: "campaign-1", CreatorID: "creator-1", Platform: "tiktok", PostURL: "https://tiktok.com/@c/video/1", Status: entities.SubmissionStatusViewLocked, LockedAt: &lockedLongAgo, NetAmount: 12.5},
+		{SubmissionID: "sub-new", CampaignID: "campaign-1", CreatorID: "creator-1", Platform: "tiktok", PostURL: "https://tiktok.com/@c/video/2", Status: entities.SubmissionStatusViewLocked, LockedAt: &lockedRecently, NetAmount: 3},
+	})
+	settlement := commands.PayoutSettlementUseCase{
+		Repository: store,
+		Payouts:    store,
+		Clock:      fixedClock{now: now},
+		IDGen:      store,
+	}
+	ctx := context.Background()
+
+	matured, err := settlement.ListMaturedEarnings(ctx, now.Add(-7*24*time.Hour), 10)
+	if err != nil || len(matured) != 1 || matured[0].SubmissionID != "sub-old" {
+		t.Fatalf("expected only sub-old matured, got %d err=%v", len(matured), err)
+	}
+
+	if err := settlement.MarkPaid(ctx, "sub-old", "payout-1"); !errors.Is(err, domainerrors.ErrInvalidStatusTransition) {
+		t.Fatalf("expected view_locked -> paid to be rejected, got %v", err)
+	}
+	if err := settlement.MarkRewardEligible(ctx, "sub-old"); err != nil {
+		t.Fatalf("mark reward eligible failed: %v", err)
+	}
+	if err := settlement.MarkRewardEligible(ctx, "sub-old"); err != nil {
+		t.Fatalf("repeated mark reward eligible should be a no-op, got %v", err)
+	}
+	if err := settlement.MarkPaid(ctx, "sub-old", "payout-1"); err != nil {
+		t.Fatalf("mark paid failed: %v", err)
+	}
+	if err := settlement.MarkPaid(ctx, "sub-old", "payout-1"); err != nil {
+		t.Fatalf("repeated mark paid should be a no-op, got %v", err)
+	}
+
+	submission, err := store.GetSubmission(ctx, "sub-old")
+	if err != nil || submission.Status != entities.SubmissionStatusPaid {
+		t.Fatalf("expected sub-old paid, got %q err=%v", submission.Status, err)
+	}
+	matured, _ = settlement.ListMaturedEarnings(ctx, now, 10)
+	if len(matured) != 1 || matured[0].SubmissionID != "sub-new" {
+		t.Fatalf("expected only sub-new still view_locked, got %d", len(matured))
+	}
+}
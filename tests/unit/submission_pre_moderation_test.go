@@ -0,0 +1,174 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	submissionservice "solomon/contexts/campaign-editorial/submission-service"
+	"solomon/contexts/campaign-editorial/submission-service/adapters/memory"
+	"solomon/contexts/campaign-editorial/submission-service/domain/entities"
+	"solomon/contexts/campaign-editorial/submission-service/domain/services"
+	"solomon/contexts/campaign-editorial/submission-service/ports"
+	httptransport "solomon/contexts/campaign-editorial/submission-service/transport/http"
+)
+
+func newPreModerationModule(t *testing.T) (submissionservice.Module, *memory.Store) {
+	t.Helper()
+	store := memory.NewStore(nil)
+	store.SetCampaign("campaign-screened", "active", []string{"tiktok"}, 1.5)
+	store.SetCampaignRequirements("campaign-screened", "brand-screened", []string{"#Launch", "ad"})
+	store.SetCampaign("campaign-other", "active", []string{"tiktok"}, 1.5)
+	module := submissionservice.NewModule(submissionservice.Dependencies{
+		Repository:     store,
+		Campaigns:      store,
+		Policies:       store,
+		Creators:       store,
+		Idempotency:    store,
+		Outbox:         store,
+		Clock:          store,
+		IDGen:          store,
+		IdempotencyTTL: time.Hour,
+	})
+	return module, store
+}
+
+func TestSubmissionPreModerationRoutes(t *testing.T) {
+	module, store := newPreModerationModule(t)
+	ctx := context.Background()
+	store.SetCreatorProfile(ports.CreatorProfile{CreatorID: "creator-gold", ReputationTier: "gold"})
+	store.SetCreatorProfile(ports.CreatorProfile{CreatorID: "creator-bronze", ReputationTier: "bronze"})
+
+	if _, err := module.Handler.PutScreeningPolicyHandler(ctx, "brand-other", "campaign-screened", httptransport.PutScreeningPolicyRequest{}); err == nil {
+		t.Fatalf("expected policy update by another brand to fail")
+	}
+	_, err := module.Handler.PutScreeningPolicyHandler(ctx, "brand-screened", "campaign-screened", httptransport.PutScreeningPolicyRequest{
+		AutoApprove: true,
+		Rules: []httptransport.ScreeningRuleDTO{
+			{RuleType: "required_hashtags", OnFail: "reject"},
+			{RuleType: "min_reputation_tier", OnFail: "review", MinTier: "silver"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("put screening policy failed: %v", err)
+	}
+
+	create := func(creatorID string, key string, url string, caption string) httptransport.SubmissionDTO {
+		t.Helper()
+		resp, err := module.Handler.CreateSubmissionHandler(ctx, creatorID, httptransport.CreateSubmissionRequest{
+			IdempotencyKey: key,
+			CampaignID:     "campaign-screened",
+			Platform:       "tiktok",
+			PostURL:        url,
+			Caption:        caption,
+		})
+		if err != nil {
+			t.Fatalf("create submission %s failed: %v", key, err)
+		}
+		return resp.Submission
+	}
+
+	approved := create("creator-gold", "idem-screen-approve", "https://tiktok.com/@gold/video/101", "new drop #launch #AD")
+	if approved.Status != "approved" || approved.PreModeration == nil || approved.PreModeration.Outcome != "auto_approve" {
+		t.Fatalf("expected auto-approved submission, got %+v", approved)
+	}
+
+	flagged := create("creator-bronze", "idem-screen-review", "https://tiktok.com/@bronze/video/102", "#launch #ad")
+	if flagged.Status != "flagged" || flagged.PreModeration == nil {
+		t.Fatalf("expected flagged submission, got %+v", flagged)
+	}
+	if flagged.PreModeration.ReasonCode != "reputation_tier_below_minimum" || flagged.PreModeration.RiskScore != 0.4 {
+		t.Fatalf("unexpected pre-moderation result: %+v", flagged.PreModeration)
+	}
+
+	rejected := create("creator-gold", "idem-screen-reject", "https://tiktok.com/@gold/video/103", "#launch only")
+	if rejected.Status != "rejected" || rejected.RejectionReason != "missing_required_hashtags" {
+		t.Fatalf("expected auto-rejected submission, got %+v", rejected)
+	}
+
+	flags := store.Flags(rejected.SubmissionID)
+	if len(flags) != 2 {
+		t.Fatalf("expected one flag per rule, got %d", len(flags))
+	}
+	for _, flag := range flags {
+		switch flag.FlagType {
+		case "pre_moderation_required_hashtags":
+			if flag.IsResolved || flag.Severity != "high" {
+				t.Fatalf("expected open high severity hashtag flag, got %+v", flag)
+			}
+		case "pre_moderation_min_reputation_tier":
+			if !flag.IsResolved {
+				t.Fatalf("expected passed tier rule to be resolved, got %+v", flag)
+			}
+		default:
+			t.Fatalf("unexpected flag type %s", flag.FlagType)
+		}
+	}
+}
+
+func TestSubmissionPreModerationDuplicatePostAcrossCampaigns(t *testing.T) {
+	module, _ := newPreModerationModule(t)
+	ctx := context.Background()
+
+	if _, err := module.Handler.PutScreeningPolicyHandler(ctx, "brand-screened", "campaign-screened", httptransport.PutScreeningPolicyRequest{
+		Rules: []httptransport.ScreeningRuleDTO{{RuleType: "unique_post_id", OnFail: "review"}},
+	}); err != nil {
+		t.Fatalf("put screening policy failed: %v", err)
+	}
+	if _, err := module.Handler.CreateSubmissionHandler(ctx, "creator-dup-post", httptransport.CreateSubmissionRequest{
+		IdempotencyKey: "idem-dup-post-first",
+		CampaignID:     "campaign-other",
+		Platform:       "tiktok",
+		PostURL:        "https://tiktok.com/@dup/video/555",
+	}); err != nil {
+		t.Fatalf("create first submission failed: %v", err)
+	}
+	resp, err := module.Handler.CreateSubmissionHandler(ctx, "creator-dup-post", httptransport.CreateSubmissionRequest{
+		IdempotencyKey: "idem-dup-post-second",
+		CampaignID:     "campaign-screened",
+		Platform:       "tiktok",
+		PostURL:        "https://tiktok.com/@dup/video/555",
+	})
+	if err != nil {
+		t.Fatalf("create second submission failed: %v", err)
+	}
+	if resp.Submission.Status != "flagged" || resp.Submission.PreModeration.ReasonCode != "duplicate_post_id" {
+		t.Fatalf("expected duplicate post to be flagged, got %+v", resp.Submission)
+	}
+}
+
+func TestScreeningPolicyEvaluation(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	policy := entities.ScreeningPolicy{
+		CampaignID: "campaign-eval",
+		Rules: []entities.ScreeningRule{
+			{RuleType: entities.ScreeningRuleMinAccountAge, OnFail: entities.ScreeningActionReject, MinAccountAgeDays: 30},
+			{RuleType: entities.ScreeningRuleUniquePostID, OnFail: entities.ScreeningActionReview},
+		},
+	}
+	if !policy.Validate() {
+		t.Fatalf("expected policy to validate")
+	}
+
+	decision := services.EvaluateScreening(policy, services.ScreeningInput{Now: now, DuplicatePostID: true})
+	if decision.Outcome != entities.ScreeningOutcomeReview || decision.ReasonCode != "duplicate_post_id" {
+		t.Fatalf("expected duplicate post review with unknown account age, got %+v", decision)
+	}
+	if decision.RiskScore != 1 {
+		t.Fatalf("expected risk score capped at 1, got %v", decision.RiskScore)
+	}
+
+	decision = services.EvaluateScreening(policy, services.ScreeningInput{Now: now, AccountCreatedAt: now.AddDate(0, 0, -3)})
+	if decision.Outcome != entities.ScreeningOutcomeAutoReject || decision.ReasonCode != "account_too_new" {
+		t.Fatalf("expected young account to be rejected, got %+v", decision)
+	}
+
+	decision = services.EvaluateScreening(policy, services.ScreeningInput{Now: now, AccountCreatedAt: now.AddDate(-1, 0, 0)})
+	if decision.Outcome != entities.ScreeningOutcomeNone || decision.RiskScore != 0 {
+		t.Fatalf("expected passing submission to stay pending, got %+v", decision)
+	}
+
+	if got := services.ExtractHashtags("Go #Launch, #launch and #ad_2026!"); len(got) != 2 || got[0] != "launch" || got[1] != "ad_2026" {
+		t.Fatalf("unexpected hashtags %v", got)
+	}
+}
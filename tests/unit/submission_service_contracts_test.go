@@ -35,24 +35,26 @@ func TestSubmissionServiceOpenAPIContractIncludesImplementedRoutes(t *testing.T)
 	}
 
 	expected := map[string][]string{
-		"/submissions":                              {"post", "get"},
-		"/submissions/{submission_id}":              {"get"},
-		"/submissions/{submission_id}/approve":      {"post"},
-		"/submissions/{submission_id}/reject":       {"post"},
-		"/submissions/{submission_id}/report":       {"post"},
-		"/submissions/bulk-operations":              {"post"},
-		"/submissions/{submission_id}/analytics":    {"get"},
-		"/dashboard/creator":                        {"get"},
-		"/dashboard/brand":                          {"get"},
-		"/v1/submissions":                           {"post", "get"},
-		"/v1/submissions/{submission_id}":           {"get"},
-		"/v1/submissions/{submission_id}/approve":   {"post"},
-		"/v1/submissions/{submission_id}/reject":    {"post"},
-		"/v1/submissions/{submission_id}/report":    {"post"},
-		"/v1/submissions/bulk-operations":           {"post"},
-		"/v1/submissions/{submission_id}/analytics": {"get"},
-		"/v1/dashboard/creator":                     {"get"},
-		"/v1/dashboard/brand":                       {"get"},
+		"/submissions":                                             {"post", "get"},
+		"/submissions/{submission_id}":                             {"get"},
+		"/submissions/{submission_id}/approve":                     {"post"},
+		"/submissions/{submission_id}/reject":                      {"post"},
+		"/submissions/{submission_id}/report":                      {"post"},
+		"/submissions/bulk-operations":                             {"post"},
+		"/submissions/{submission_id}/analytics":                   {"get"},
+		"/dashboard/creator":                                       {"get"},
+		"/dashboard/brand":                                         {"get"},
+		"/v1/submissions":                                          {"post", "get"},
+		"/v1/submissions/{submission_id}":                          {"get"},
+		"/v1/submissions/{submission_id}/approve":                  {"post"},
+		"/v1/submissions/{submission_id}/reject":                   {"post"},
+		"/v1/submissions/{submission_id}/report":                   {"post"},
+		"/v1/submissions/bulk-operations":                          {"post"},
+		"/v1/submissions/{submission_id}/analytics":                {"get"},
+		"/v1/dashboard/creator":                                    {"get"},
+		"/v1/dashboard/brand":                                      {"get"},
+		"/submissions/campaigns/{campaign_id}/screening-policy":    {"get", "put"},
+		"/v1/submissions/campaigns/{campaign_id}/screening-policy": {"get", "put"},
 	}
 
 	for path, methods := range expected {
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	submissionservice "solomon/contexts/campaign-editorial/submission-service"
+	"solomon/contexts/campaign-editorial/submission-service/application/commands"
 	domainerrors "solomon/contexts/campaign-editorial/submission-service/domain/errors"
 	httptransport "solomon/contexts/campaign-editorial/submission-service/transport/http"
 )
@@ -59,3 +60,63 @@ func TestSubmissionDuplicateBlocked(t *testing.T) {
 		t.Fatalf("expected duplicate submission error, got %v", err)
 	}
 }
+
+func TestSubmissionDisputeReversalApproves(t *testing.T) {
+	module := submissionservice.NewInMemoryModule(nil, nil)
+	ctx := context.Background()
+
+	created, err := module.Handler.CreateSubmissionHandler(ctx, "creator-dispute", httptransport.CreateSubmissionRequest{
+		IdempotencyKey: "idem-create-dispute",
+		CampaignID:     "campaign-1",
+		Platform:       "tiktok",
+		PostURL:        "https://tiktok.com/@creator/video/dispute",
+	})
+	if err != nil {
+		t.Fatalf("create submission failed: %v", err)
+	}
+	submissionID := created.Submission.SubmissionID
+	if err := module.Handler.RejectSubmissionHandler(ctx, "brand-1", submissionID, httptransport.RejectSubmissionRequest{
+		IdempotencyKey: "idem-reject-dispute",
+		Reason:         "duplicate_content",
+	}); err != nil {
+		t.Fatalf("reject submission failed: %v", err)
+	}
+
+	err = module.Handler.ReviewSubmission.Dispute(ctx, commands.DisputeSubmissionCommand{
+		IdempotencyKey: "idem-dispute-other",
+		SubmissionID:   submissionID,
+		ActorID:        "creator-other",
+		AppealID:       "appeal-1",
+		Reason:         "original footage",
+	})
+	if !errors.Is(err, domainerrors.ErrUnauthorizedActor) {
+		t.Fatalf("expected unauthorized actor, got %v", err)
+	}
+	if err := module.Handler.ReviewSubmission.Dispute(ctx, commands.DisputeSubmissionCommand{
+		IdempotencyKey: "idem-dispute",
+		SubmissionID:   submissionID,
+		ActorID:        "creator-dispute",
+		AppealID:       "appeal-1",
+		Reason:         "original footage",
+	}); err != nil {
+		t.Fatalf("dispute failed: %v", err)
+	}
+	if err := module.Handler.ReviewSubmission.ResolveDispute(ctx, commands.ResolveDisputeCommand{
+		IdempotencyKey: "idem-resolve-dispute",
+		SubmissionID:   submissionID,
+		ActorID:        "mod-2",
+		AppealID:       "appeal-1",
+		Reversed:       true,
+		Notes:          "source files confirm originality",
+	}); err != nil {
+		t.Fatalf("resolve dispute failed: %v", err)
+	}
+
+	fetched, err := module.Handler.GetSubmissionHandler(ctx, "creator-dispute", submissionID)
+	if err != nil {
+		t.Fatalf("get submission failed: %v", err)
+	}
+	if fetched.Submission.Status != "approved" {
+		t.Fatalf("expected approved status after reversal, got %s", fetched.Submission.Status)
+	}
+}
@@ -0,0 +1,242 @@
+package unit
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	superadmindashboard "solomon/contexts/internal-ops/super-admin-dashboard"
+	domainerrors "solomon/contexts/internal-ops/super-admin-dashboard/domain/errors"
+	"solomon/contexts/internal-ops/super-admin-dashboard/ports"
+	httptransport "solomon/contexts/internal-ops/super-admin-dashboard/transport/http"
+	"solomon/internal/platform/auditchain"
+)
+
+func TestSuperAdminAuditChainVerifiesAfterActions(t *testing.T) {
+	module := superadmindashboard.NewInMemoryModule(nil)
+	ctx := context.Background()
+	recordSuperAdminActions(t, module)
+
+	report, err := module.Handler.VerifyAuditChainHandler(ctx)
+	if err != nil {
+		t.Fatalf("verify chain: %v", err)
+	}
+	if !report.Valid || report.EntriesChecked != 3 || report.HeadSequence != 3 {
+		t.Fatalf("expected valid chain of 3 entries, got %+v", report)
+	}
+
+	logs, err := module.Handler.AuditLogsHandler(ctx, "", "", "", 10)
+	if err != nil {
+		t.Fatalf("list audit logs: %v", err)
+	}
+	if len(logs.AuditLogs) != 3 {
+		t.Fatalf("expected 3 audit logs, got %d", len(logs.AuditLogs))
+	}
+	newest, oldest := logs.AuditLogs[0], logs.AuditLogs[2]
+	if newest.Sequence != 3 || oldest.Sequence != 1 || oldest.PrevHash != auditchain.GenesisHash {
+		t.Fatalf("unexpected chain order: newest=%d oldest=%d prev=%s", newest.Sequence, oldest.Sequence, oldest.PrevHash)
+	}
+	if logs.AuditLogs[1].PrevHash != oldest.EntryHash || newest.PrevHash != logs.AuditLogs[1].EntryHash {
+		t.Fatal("expected each entry to link to the previous entry hash")
+	}
+	for _, item := range logs.AuditLogs {
+		if !item.IsVerified {
+			t.Fatalf("expected audit %s to verify", item.AuditID)
+		}
+	}
+}
+
+func TestSuperAdminAuditChainReportsFirstTamperedEntry(t *testing.T) {
+	chain := &sliceAuditChain{}
+	module := superadmindashboard.NewInMemoryModuleWithAuditChain(nil, chain, auditchain.HMACSigner{Key: []byte("unit-test-audit-key")}, nil, nil)
+	ctx := context.Background()
+	recordSuperAdminActions(t, module)
+
+	chain.mu.Lock()
+	chain.entries[1].Reason = "rewritten reason"
+	chain.mu.Unlock()
+
+	report, err := module.Handler.VerifyAuditChainHandler(ctx)
+	if err != nil {
+		t.Fatalf("verify chain: %v", err)
+	}
+	if report.Valid || report.FirstBreak == nil {
+		t.Fatalf("expected broken chain, got %+v", report)
+	}
+	if report.FirstBreak.Sequence != 2 || report.FirstBreak.Reason != auditchain.BreakEntryHash {
+		t.Fatalf("expected entry hash break at sequence 2, got %+v", report.FirstBreak)
+	}
+
+	logs, err := module.Handler.AuditLogsHandler(ctx, "", "", "", 10)
+	if err != nil {
+		t.Fatalf("list audit logs: %v", err)
+	}
+	for _, item := range logs.AuditLogs {
+		if item.IsVerified == (item.Sequence == 2) {
+			t.Fatalf("unexpected is_verified=%v for sequence %d", item.IsVerified, item.Sequence)
+		}
+	}
+}
+
+func TestSuperAdminAuditChainDetectsDeletedEntry(t *testing.T) {
+	chain := &sliceAuditChain{}
+	module := superadmindashboard.NewInMemoryModuleWithAuditChain(nil, chain, auditchain.HMACSigner{Key: []byte("unit-test-audit-key")}, nil, nil)
+	recordSuperAdminActions(t, module)
+
+	chain.mu.Lock()
+	chain.entries = append(chain.entries[:1], chain.entries[2:]...)
+	chain.mu.Unlock()
+
+	report, err := module.Handler.VerifyAuditChainHandler(context.Background())
+	if err != nil {
+		t.Fatalf("verify chain: %v", err)
+	}
+	if report.Valid || report.FirstBreak.Sequence != 3 || report.FirstBreak.Reason != auditchain.BreakSequenceGap {
+		t.Fatalf("expected sequence gap at 3, got %+v", report.FirstBreak)
+	}
+}
+
+func TestSuperAdminAuditChainAnchorDetectsTruncation(t *testing.T) {
+	chain := &sliceAuditChain{}
+	module := superadmindashboard.NewInMemoryModuleWithAuditChain(nil, chain, auditchain.HMACSigner{Key: []byte("unit-test-audit-key")}, nil, nil)
+	ctx := context.Background()
+	recordSuperAdminActions(t, module)
+
+	if err := module.AnchorAuditChain(ctx); err != nil {
+		t.Fatalf("anchor chain: %v", err)
+	}
+	if err := module.AnchorAuditChain(ctx); err != nil {
+		t.Fatalf("re-anchor chain: %v", err)
+	}
+	if len(chain.anchors) != 1 {
+		t.Fatalf("expected an unchanged head to be anchored once, got %d anchors", len(chain.anchors))
+	}
+	report, err := module.Handler.VerifyAuditChainHandler(ctx)
+	if err != nil {
+		t.Fatalf("verify chain: %v", err)
+	}
+	if !report.Valid || report.AnchorsChecked != 1 {
+		t.Fatalf("expected valid anchored chain, got %+v", report)
+	}
+
+	chain.mu.Lock()
+	chain.entries = chain.entries[:2]
+	chain.mu.Unlock()
+
+	report, err = module.Handler.VerifyAuditChainHandler(ctx)
+	if err != nil {
+		t.Fatalf("verify chain: %v", err)
+	}
+	if report.Valid || report.FirstBreak.Reason != auditchain.BreakAnchorMissing || report.FirstBreak.Sequence != 3 {
+		t.Fatalf("expected truncation to be reported against the anchor, got %+v", report.FirstBreak)
+	}
+}
+
+func TestSuperAdminAuditChainRejectsForeignKey(t *testing.T) {
+	chain := &sliceAuditChain{}
+	writer := superadmindashboard.NewInMemoryModuleWithAuditChain(nil, chain, auditchain.HMACSigner{Key: []byte("unit-test-audit-key")}, nil, nil)
+	recordSuperAdminActions(t, writer)
+
+	reader := superadmindashboard.NewInMemoryModuleWithAuditChain(nil, chain, auditchain.HMACSigner{Key: []byte("another-audit-key")}, nil, nil)
+	report, err := reader.Handler.VerifyAuditChainHandler(context.Background())
+	if err != nil {
+		t.Fatalf("verify chain: %v", err)
+	}
+	if report.Valid || report.FirstBreak.Sequence != 1 || report.FirstBreak.Reason != auditchain.BreakSignature {
+		t.Fatalf("expected signature break at sequence 1, got %+v", report.FirstBreak)
+	}
+}
+
+func recordSuperAdminActions(t *testing.T, module superadmindashboard.Module) {
+	t.Helper()
+	ctx := context.Background()
+	if _, err := module.Handler.AdjustWalletHandler(ctx, "admin-1", "idem-chain-wallet", "user-1", httptransport.WalletAdjustRequest{
+		Amount:         10,
+		AdjustmentType: "credit",
+		Reason:         "goodwill",
+	}); err != nil {
+		t.Fatalf("adjust wallet: %v", err)
+	}
+	if _, err := module.Handler.BanUserHandler(ctx, "admin-1", "idem-chain-ban", "user-2", httptransport.BanUserRequest{
+		BanType:      "temporary",
+		DurationDays: 3,
+		Reason:       "spam",
+	}); err != nil {
+		t.Fatalf("ban user: %v", err)
+	}
+	if _, err := module.Handler.UnbanUserHandler(ctx, "admin-1", "idem-chain-unban", "user-2", httptransport.UnbanUserRequest{
+		Reason: "appeal accepted",
+	}); err != nil {
+		t.Fatalf("unban user: %v", err)
+	}
+}
+
+// sliceAuditChain is an audit chain store whose rows a test can rewrite.
+type sliceAuditChain struct {
+	mu      sync.Mutex
+	entries []ports.AuditLog
+	anchors []ports.AuditAnchor
+}
+
+func (c *sliceAuditChain) GetAuditChainHead(context.Context) (ports.AuditLog, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) == 0 {
+		return ports.AuditLog{}, false, nil
+	}
+	return c.entries[len(c.entries)-1], true, nil
+}
+
+func (c *sliceAuditChain) AppendAuditEntry(_ context.Context, entry ports.AuditLog) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if int64(len(c.entries))+1 != entry.Sequence {
+		return domainerrors.ErrAuditChainConflict
+	}
+	c.entries = append(c.entries, entry)
+	return nil
+}
+
+func (c *sliceAuditChain) ListAuditLogs(_ context.Context, _ string, _ string, _ string, pageSize int) ([]ports.AuditLog, string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	items := make([]ports.AuditLog, 0, len(c.entries))
+	for i := len(c.entries) - 1; i >= 0 && len(items) < pageSize; i-- {
+		items = append(items, c.entries[i])
+	}
+	return items, "", nil
+}
+
+func (c *sliceAuditChain) ListAuditChain(_ context.Context, afterSequence int64, limit int) ([]ports.AuditLog, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	items := make([]ports.AuditLog, 0, limit)
+	for _, entry := range c.entries {
+		if entry.Sequence > afterSequence && len(items) < limit {
+			items = append(items, entry)
+		}
+	}
+	return items, nil
+}
+
+func (c *sliceAuditChain) AppendAuditAnchor(_ context.Context, anchor ports.AuditAnchor) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.anchors = append(c.anchors, anchor)
+	return nil
+}
+
+func (c *sliceAuditChain) GetLatestAuditAnchor(context.Context) (ports.AuditAnchor, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.anchors) == 0 {
+		return ports.AuditAnchor{}, false, nil
+	}
+	return c.anchors[len(c.anchors)-1], true, nil
+}
+
+func (c *sliceAuditChain) ListAuditAnchors(context.Context) ([]ports.AuditAnchor, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]ports.AuditAnchor(nil), c.anchors...), nil
+}
@@ -42,6 +42,8 @@ func TestSuperAdminDashboardOpenAPIContractIncludesImplementedRoutes(t *testing.
 		"/api/admin/v1/analytics/dashboard":                  {"get"},
 		"/api/admin/v1/audit-logs":                           {"get"},
 		"/api/admin/v1/audit-logs/export":                    {"get"},
+		"/api/admin/v1/audit-logs/export/{export_id}":        {"get"},
+		"/api/admin/v1/audit-logs/verify":                    {"get"},
 	}
 
 	for path, methods := range expected {
@@ -3,11 +3,13 @@ package unit
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	superadmindashboard "solomon/contexts/internal-ops/super-admin-dashboard"
 	domainerrors "solomon/contexts/internal-ops/super-admin-dashboard/domain/errors"
+	"solomon/contexts/internal-ops/super-admin-dashboard/domain/services"
 	httptransport "solomon/contexts/internal-ops/super-admin-dashboard/transport/http"
 )
 
@@ -108,3 +110,69 @@ func TestSuperAdminDashboardAnalyticsDateValidation(t *testing.T) {
 		t.Fatalf("expected unprocessable date range, got %v", err)
 	}
 }
+
+func TestSuperAdminImpersonationTokenVerification(t *testing.T) {
+	codec := services.ImpersonationTokenCodec{Key: []byte("unit-test-impersonation-key")}
+	issuedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	token, err := codec.Issue(services.ImpersonationClaims{
+		ImpersonationID: "imp-1",
+		AdminID:         "admin-1",
+		UserID:          "user-1",
+		Scopes:          []string{services.ScopeRead},
+		IssuedAt:        issuedAt,
+		ExpiresAt:       issuedAt.Add(30 * time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("issue token: %v", err)
+	}
+
+	claims, err := codec.Parse(token, issuedAt.Add(time.Minute))
+	if err != nil || claims.UserID != "user-1" || claims.Allows(services.ScopeWrite) {
+		t.Fatalf("expected read-only claims for user-1, got %+v err=%v", claims, err)
+	}
+	if _, err := codec.Parse(token, issuedAt.Add(30*time.Minute)); !errors.Is(err, domainerrors.ErrImpersonationTokenExpired) {
+		t.Fatalf("expected expired token, got %v", err)
+	}
+	other := services.ImpersonationTokenCodec{Key: []byte("another-impersonation-key")}
+	if _, err := other.Parse(token, issuedAt); !errors.Is(err, domainerrors.ErrImpersonationTokenInvalid) {
+		t.Fatalf("expected token signed with another key to be invalid, got %v", err)
+	}
+}
+
+func TestSuperAdminStartImpersonationValidatesScopesAndDuration(t *testing.T) {
+	module := superadmindashboard.NewInMemoryModule(nil)
+	ctx := context.Background()
+
+	_, err := module.Handler.StartImpersonationHandler(ctx, "admin-1", "idem-imp-scope", httptransport.StartImpersonationRequest{
+		ImpersonatedUserID: "user-1",
+		Reason:             "support ticket",
+		Scopes:             []string{"everything"},
+	})
+	if !errors.Is(err, domainerrors.ErrInvalidRequest) {
+		t.Fatalf("expected unknown scope to be rejected, got %v", err)
+	}
+	_, err = module.Handler.StartImpersonationHandler(ctx, "admin-1", "idem-imp-ttl", httptransport.StartImpersonationRequest{
+		ImpersonatedUserID: "user-1",
+		Reason:             "support ticket",
+		DurationMinutes:    240,
+	})
+	if !errors.Is(err, domainerrors.ErrInvalidRequest) {
+		t.Fatalf("expected over-long session to be rejected, got %v", err)
+	}
+
+	session, err := module.Handler.StartImpersonationHandler(ctx, "admin-1", "idem-imp-ok", httptransport.StartImpersonationRequest{
+		ImpersonatedUserID: "user-1",
+		Reason:             "support ticket",
+		Scopes:             []string{"write", "payouts"},
+	})
+	if err != nil {
+		t.Fatalf("start impersonation: %v", err)
+	}
+	if strings.Join(session.Scopes, ",") != "read,write,payouts" {
+		t.Fatalf("expected normalised scopes, got %v", session.Scopes)
+	}
+	claims, err := module.AuthenticateImpersonation(ctx, session.AccessToken)
+	if err != nil || claims.AdminID != "admin-1" || claims.UserID != "user-1" {
+		t.Fatalf("expected token to authenticate admin-1 as user-1, got %+v err=%v", claims, err)
+	}
+}
@@ -0,0 +1,53 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	walletledgerservice "solomon/contexts/finance-core/wallet-ledger-service"
+	superadmindashboard "solomon/contexts/internal-ops/super-admin-dashboard"
+	domainerrors "solomon/contexts/internal-ops/super-admin-dashboard/domain/errors"
+	httptransport "solomon/contexts/internal-ops/super-admin-dashboard/transport/http"
+	"solomon/internal/platform/httpserver"
+)
+
+func TestSuperAdminWalletAdjustmentPostsToLedger(t *testing.T) {
+	ledger := walletledgerservice.NewInMemoryModule(nil)
+	module := superadmindashboard.NewInMemoryModuleWithAuditChain(nil, nil, nil, nil, httpserver.SuperAdminWalletLedger(ledger))
+	ctx := context.Background()
+
+	resp, err := module.Handler.AdjustWalletHandler(ctx, "admin-1", "idem-ledger-1", "user-1", httptransport.WalletAdjustRequest{
+		Amount:         12.5,
+		AdjustmentType: "credit",
+		Reason:         "support credit",
+	})
+	if err != nil {
+		t.Fatalf("wallet adjust failed: %v", err)
+	}
+	if resp.BalanceBefore != 500 || resp.BalanceAfter != 512.5 {
+		t.Fatalf("expected ledger balances 500 -> 512.5, got %v -> %v", resp.BalanceBefore, resp.BalanceAfter)
+	}
+
+	statement, err := ledger.Handler.GetAccountStatementHandler(ctx, "user:user-1", 10, 0)
+	if err != nil {
+		t.Fatalf("ledger statement failed: %v", err)
+	}
+	if statement.BalanceCents != 51250 || len(statement.Entries) != 2 {
+		t.Fatalf("expected opening balance plus adjustment, got balance=%d entries=%d", statement.BalanceCents, len(statement.Entries))
+	}
+}
+
+func TestSuperAdminWalletDebitCannotOverdrawLedger(t *testing.T) {
+	ledger := walletledgerservice.NewInMemoryModule(nil)
+	module := superadmindashboard.NewInMemoryModuleWithAuditChain(nil, nil, nil, nil, httpserver.SuperAdminWalletLedger(ledger))
+
+	_, err := module.Handler.AdjustWalletHandler(context.Background(), "admin-1", "idem-ledger-2", "user-2", httptransport.WalletAdjustRequest{
+		Amount:         150.01,
+		AdjustmentType: "debit",
+		Reason:         "chargeback",
+	})
+	if !errors.Is(err, domainerrors.ErrConflict) {
+		t.Fatalf("expected conflict for overdraft, got %v", err)
+	}
+}